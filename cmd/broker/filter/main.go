@@ -24,6 +24,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered"
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -41,14 +43,20 @@ import (
 	"knative.dev/eventing/cmd/broker"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/auth"
+	pkgbroker "knative.dev/eventing/pkg/broker"
 	"knative.dev/eventing/pkg/broker/filter"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
 	triggerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
+	eventpolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/eventpolicy"
+	redactionpolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/redactionpolicy"
 	eventtypeinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/eventtype"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/reconciler/names"
+	"knative.dev/eventing/pkg/signing"
 )
 
 const (
@@ -63,6 +71,49 @@ type envConfig struct {
 	ContainerName string `envconfig:"CONTAINER_NAME" required:"true"`
 	HTTPPort      int    `envconfig:"FILTER_PORT" default:"8080"`
 	HTTPSPort     int    `envconfig:"FILTER_PORT_HTTPS" default:"8443"`
+	// ProbePort is an optional port dedicated to health checks, 0 disables it.
+	ProbePort int `envconfig:"FILTER_PROBE_PORT" default:"0"`
+	// UnauthenticatedPaths is a comma separated list of paths served without
+	// authentication, e.g. for kubelet probes and mesh health checks.
+	UnauthenticatedPaths string `envconfig:"UNAUTHENTICATED_PATHS" default:"/healthz,/readyz"`
+	// BindAddress is the address the servers listen on, all the addresses of
+	// BindAddressFamily when empty.
+	BindAddress string `envconfig:"BIND_ADDRESS"`
+	// BindAddressFamily is one of dual, ipv4 or ipv6.
+	BindAddressFamily string `envconfig:"BIND_ADDRESS_FAMILY" default:"dual"`
+	// ResponseHeadersAsExtensions is a comma separated list of subscriber
+	// response headers propagated onto the reply event as extensions.
+	ResponseHeadersAsExtensions string `envconfig:"RESPONSE_HEADERS_AS_EXTENSIONS"`
+	// ResponseHeadersPreserved is a comma separated list of subscriber
+	// response headers preserved on the HTTP response.
+	ResponseHeadersPreserved string `envconfig:"RESPONSE_HEADERS_PRESERVED"`
+	// MaxConcurrentDispatchesPerTrigger bounds the number of events
+	// dispatched concurrently for each Trigger, 0 doesn't bound them.
+	MaxConcurrentDispatchesPerTrigger int `envconfig:"MAX_CONCURRENT_DISPATCHES_PER_TRIGGER" default:"0"`
+	// EventIndexSize is the number of recent event outcomes kept in the
+	// event index, which is disabled when 0.
+	EventIndexSize int `envconfig:"EVENT_INDEX_SIZE" default:"0"`
+	// EventIndexPort is the port of the debug endpoint querying the event
+	// index, which isn't served when 0.
+	EventIndexPort int `envconfig:"EVENT_INDEX_PORT" default:"0"`
+	// DeliveryFailedSink is the URL of the sink receiving the
+	// dev.knative.delivery.failed events when the
+	// broker-delivery-failed-events feature is enabled.
+	DeliveryFailedSink string `envconfig:"DELIVERY_FAILED_SINK"`
+	// PartitionOrdering dispatches the events of a Trigger with the same
+	// partition key in order, they are dispatched like the other events when
+	// false.
+	PartitionOrdering bool `envconfig:"PARTITION_ORDERING" default:"false"`
+	// AuthSubjectVerificationKeyFiles lists the files holding the keys the
+	// authsubject extension of the events can be signed with, each one
+	// identified by the base name of its file. The signature isn't verified
+	// when empty.
+	AuthSubjectVerificationKeyFiles []string `envconfig:"AUTH_SUBJECT_VERIFICATION_KEY_FILES"`
+	// ReplayProtectionKeyFiles lists the files holding the keys the
+	// replay protection headers of the authenticated requests are signed
+	// with, the first one, and verified with, each one identified by the base
+	// name of its file. They are required by the replay-protection feature.
+	ReplayProtectionKeyFiles []string `envconfig:"REPLAY_PROTECTION_KEY_FILES"`
 }
 
 func main() {
@@ -135,6 +186,8 @@ func main() {
 	})
 	featureStore.WatchConfigs(configMapWatcher)
 
+	kncloudevents.WatchProxyConfig(sl, configMapWatcher)
+
 	// Decorate contexts with the current state of the feature config.
 	ctxFunc := func(ctx context.Context) context.Context {
 		return featureStore.ToContext(ctx)
@@ -157,7 +210,48 @@ func main() {
 	if err != nil {
 		logger.Fatal("Error creating Handler", zap.Error(err))
 	}
-	serverManager, err := filter.NewServerManager(ctx, logger, configMapWatcher, env.HTTPPort, env.HTTPSPort, handler)
+	handler.RedactionPolicyLister = redactionpolicyinformer.Get(ctx).Lister()
+	handler.EventPolicyLister = eventpolicyinformer.Get(ctx).Lister()
+	if len(env.AuthSubjectVerificationKeyFiles) > 0 {
+		keys := make([]signing.Key, 0, len(env.AuthSubjectVerificationKeyFiles))
+		for _, file := range env.AuthSubjectVerificationKeyFiles {
+			key, err := signing.KeyFromFile(file, "")
+			if err != nil {
+				logger.Fatal("Invalid auth subject verification key", zap.Error(err))
+			}
+			keys = append(keys, key)
+		}
+		handler.AuthSubjectKeys = signing.NewKeyring(keys...)
+	}
+	if err := kncloudevents.LoadReplayProtection(env.ReplayProtectionKeyFiles); err != nil {
+		logger.Fatal("Invalid replay protection configuration", zap.Error(err))
+	}
+	kncloudevents.WatchReplayProtection(sl, configMapWatcher)
+	handler.ResponseHeaderPolicy, err = filter.ParseResponseHeaderPolicy(env.ResponseHeadersAsExtensions, env.ResponseHeadersPreserved)
+	if err != nil {
+		logger.Fatal("Invalid response header policy", zap.Error(err))
+	}
+	handler.TriggerPools = filter.NewTriggerPools(env.MaxConcurrentDispatchesPerTrigger)
+	if env.PartitionOrdering {
+		handler.Partitions = filter.NewPartitions(ctx)
+	}
+	handler.EventIndex = eventindex.New(names.BrokerFilterName, env.EventIndexSize)
+	handler.DeadLetterStats = filter.NewDeadLetterStats(eventingclient.Get(ctx).EventingV1(), logger)
+	if env.DeliveryFailedSink != "" {
+		sinkURL, err := apis.ParseURL(env.DeliveryFailedSink)
+		if err != nil || !sinkURL.URL().IsAbs() {
+			logger.Fatal("Invalid delivery failed sink", zap.String("sink", env.DeliveryFailedSink), zap.Error(err))
+		}
+		handler.DeliveryFailedSink = &duckv1.Addressable{URL: sinkURL}
+	}
+	addressFamily, err := kncloudevents.ParseAddressFamily(env.BindAddressFamily)
+	if err != nil {
+		logger.Fatal("Invalid bind address family", zap.Error(err))
+	}
+	bindAddress := kncloudevents.WithBindAddress(env.BindAddress, addressFamily)
+
+	unauthenticatedPaths := pkgbroker.ParseUnauthenticatedPaths(env.UnauthenticatedPaths)
+	serverManager, err := filter.NewServerManager(ctx, logger, configMapWatcher, env.HTTPPort, env.HTTPSPort, unauthenticatedPaths.Handler(handler), bindAddress)
 	if err != nil {
 		logger.Fatal("Error creating server manager", zap.Error(err))
 	}
@@ -174,6 +268,9 @@ func main() {
 	}
 
 	// Start the servers
+	broker.StartProbeServer(ctx, logger, env.ProbePort, broker.LocalAddress(env.BindAddress, addressFamily, env.HTTPPort), unauthenticatedPaths, bindAddress)
+	handler.EventIndex.StartServer(ctx, logger, env.EventIndexPort, bindAddress)
+	handler.DeadLetterStats.Start(ctx)
 	logger.Info("Filter starting...")
 	err = serverManager.StartServers(ctx)
 	if err != nil {
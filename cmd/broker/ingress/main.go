@@ -20,6 +20,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
+	"strconv"
+	"time"
 
 	// Uncomment the following line to load the gcp plugin (only required to authenticate against GKE clusters).
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -43,6 +46,7 @@ import (
 	tracingconfig "knative.dev/pkg/tracing/config"
 
 	cmdbroker "knative.dev/eventing/cmd/broker"
+	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/broker"
@@ -50,9 +54,12 @@ import (
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
 	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
 	eventtypeinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/eventtype"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/reconciler/names"
+	"knative.dev/eventing/pkg/signing"
 )
 
 // TODO make these constants configurable (either as env variables, config map, or part of broker spec).
@@ -67,6 +74,10 @@ const (
 	defaultMaxIdleConnectionsPerHost = 1000
 	defaultMetricsPort               = 9092
 	component                        = "mt_broker_ingress"
+
+	// maintenanceDrainInterval is how often the events buffered for the
+	// Brokers whose maintenance window is over are forwarded.
+	maintenanceDrainInterval = time.Second
 )
 
 type envConfig struct {
@@ -77,6 +88,66 @@ type envConfig struct {
 	MaxTTL        int    `envconfig:"MAX_TTL" default:"255"`
 	HTTPPort      int    `envconfig:"INGRESS_PORT" default:"8080"`
 	HTTPSPort     int    `envconfig:"INGRESS_PORT_HTTPS" default:"8443"`
+	// GRPCPort is the port of the gRPC endpoint receiving the events when
+	// the broker-ingress-grpc feature is enabled, 0 disables it.
+	GRPCPort int `envconfig:"INGRESS_PORT_GRPC" default:"0"`
+	// ProbePort is an optional port dedicated to health checks, 0 disables it.
+	ProbePort int `envconfig:"INGRESS_PROBE_PORT" default:"0"`
+	// UnauthenticatedPaths is a comma separated list of paths served without
+	// authentication, e.g. for kubelet probes and mesh health checks.
+	UnauthenticatedPaths string `envconfig:"UNAUTHENTICATED_PATHS" default:"/healthz,/readyz"`
+	// BindAddress is the address the servers listen on, all the addresses of
+	// BindAddressFamily when empty.
+	BindAddress string `envconfig:"BIND_ADDRESS"`
+	// BindAddressFamily is one of dual, ipv4 or ipv6.
+	BindAddressFamily string `envconfig:"BIND_ADDRESS_FAMILY" default:"dual"`
+	// EventValidation is the event validation level of the Brokers without
+	// the eventing.knative.dev/event-validation annotation, one of minimal,
+	// spec-strict or registry-enforced.
+	EventValidation string `envconfig:"EVENT_VALIDATION" default:"minimal"`
+	// EventIndexSize is the number of recent event outcomes kept in the
+	// event index, which is disabled when 0.
+	EventIndexSize int `envconfig:"EVENT_INDEX_SIZE" default:"0"`
+	// EventIndexPort is the port of the debug endpoint querying the event
+	// index, which isn't served when 0.
+	EventIndexPort int `envconfig:"EVENT_INDEX_PORT" default:"0"`
+	// MaintenanceBufferSize is the number of events buffered per Broker in
+	// its maintenance window, beyond which the events are refused. The
+	// maintenance windows are ignored when 0.
+	MaintenanceBufferSize int `envconfig:"MAINTENANCE_BUFFER_SIZE" default:"0"`
+	// MaintenanceBufferMaxEvents is the number of events buffered for all the
+	// Brokers, beyond which the events are refused. It defaults to
+	// MaintenanceBufferSize when 0.
+	MaintenanceBufferMaxEvents int `envconfig:"MAINTENANCE_BUFFER_MAX_EVENTS" default:"10000"`
+	// RateLimiterSize is the number of sources whose rate limit bucket is
+	// kept, beyond which the buckets of the least recently seen sources are
+	// removed.
+	RateLimiterSize int `envconfig:"RATE_LIMITER_SIZE" default:"10000"`
+	// MaintenanceFlushTimeout bounds the time the buffered events are
+	// forwarded for on shutdown, after which they are lost.
+	MaintenanceFlushTimeout time.Duration `envconfig:"MAINTENANCE_FLUSH_TIMEOUT" default:"10s"`
+	// PodIP is the IP the Broker filters post the results of the deliveries
+	// of the events sent in synchronous delivery mode to.
+	PodIP string `envconfig:"POD_IP"`
+	// SyncDeliveryPort is the port receiving the results of the deliveries
+	// of the events sent in synchronous delivery mode, which is disabled
+	// when 0.
+	SyncDeliveryPort int `envconfig:"SYNC_DELIVERY_PORT" default:"0"`
+	// SyncDeliveryTimeout bounds the time a producer waits for the result
+	// of the delivery of an event sent in synchronous delivery mode.
+	SyncDeliveryTimeout time.Duration `envconfig:"SYNC_DELIVERY_TIMEOUT" default:"10s"`
+	// AuthSubjectSigningKeyFile is the file holding the key signing the
+	// authsubject extension of the events, whose id is the base name of the
+	// file. The extension isn't signed when empty.
+	AuthSubjectSigningKeyFile string `envconfig:"AUTH_SUBJECT_SIGNING_KEY_FILE"`
+	// MaxDecompressedBodySize is the maximum size in bytes of the decompressed
+	// body of the compressed requests, which isn't bounded when 0.
+	MaxDecompressedBodySize int64 `envconfig:"MAX_DECOMPRESSED_BODY_SIZE" default:"10485760"`
+	// ReplayProtectionKeyFiles lists the files holding the keys the
+	// replay protection headers of the authenticated requests are signed
+	// with, the first one, and verified with, each one identified by the base
+	// name of its file. They are required by the replay-protection feature.
+	ReplayProtectionKeyFiles []string `envconfig:"REPLAY_PROTECTION_KEY_FILES"`
 }
 
 func main() {
@@ -97,6 +168,13 @@ func main() {
 		log.Fatalf("Invalid MaxTTL value, must be >=0, was: %d", env.MaxTTL)
 	}
 
+	switch env.EventValidation {
+	case eventing.EventValidationMinimal, eventing.EventValidationSpecStrict, eventing.EventValidationRegistryEnforced:
+	default:
+		log.Fatalf("Invalid EVENT_VALIDATION value, must be one of %s, %s or %s, was: %q",
+			eventing.EventValidationMinimal, eventing.EventValidationSpecStrict, eventing.EventValidationRegistryEnforced, env.EventValidation)
+	}
+
 	log.Printf("Using TTL of %d", env.MaxTTL)
 	log.Printf("Registering %d clients", len(injection.Default.GetClients()))
 	log.Printf("Registering %d informer factories", len(injection.Default.GetInformerFactories()))
@@ -159,6 +237,8 @@ func main() {
 	})
 	featureStore.WatchConfigs(configMapWatcher)
 
+	kncloudevents.WatchProxyConfig(sl, configMapWatcher)
+
 	// Decorate contexts with the current state of the feature config.
 	ctxFunc := func(ctx context.Context) context.Context {
 		return featureStore.ToContext(ctx)
@@ -173,8 +253,39 @@ func main() {
 	if err != nil {
 		logger.Fatal("Error creating Handler", zap.Error(err))
 	}
+	handler.EventValidation = env.EventValidation
+	handler.EventTypeLister = eventtypeinformer.Get(ctx).Lister()
+	handler.EventIndex = eventindex.New(names.BrokerIngressName, env.EventIndexSize)
+	handler.MaintenanceBuffer = ingress.NewMaintenanceBuffer(env.MaintenanceBufferSize, env.MaintenanceBufferMaxEvents)
+	handler.RateLimiter = ingress.NewRateLimiter(env.RateLimiterSize)
+	handler.MaxDecompressedSize = env.MaxDecompressedBodySize
+	if env.AuthSubjectSigningKeyFile != "" {
+		key, err := signing.KeyFromFile(env.AuthSubjectSigningKeyFile, "")
+		if err != nil {
+			logger.Fatal("Invalid auth subject signing key", zap.Error(err))
+		}
+		handler.AuthSubjectKey = &key
+	}
+	if err := kncloudevents.LoadReplayProtection(env.ReplayProtectionKeyFiles); err != nil {
+		logger.Fatal("Invalid replay protection configuration", zap.Error(err))
+	}
+	kncloudevents.WatchReplayProtection(sl, configMapWatcher)
+	if env.SyncDeliveryPort > 0 {
+		if env.PodIP == "" {
+			logger.Fatal("POD_IP is required when SYNC_DELIVERY_PORT is set")
+		}
+		replyURL := "http://" + net.JoinHostPort(env.PodIP, strconv.Itoa(env.SyncDeliveryPort))
+		handler.SyncDelivery = ingress.NewSyncDelivery(replyURL, env.SyncDeliveryTimeout)
+	}
 
-	serverManager, err := ingress.NewServerManager(ctx, logger, configMapWatcher, env.HTTPPort, env.HTTPSPort, handler)
+	addressFamily, err := kncloudevents.ParseAddressFamily(env.BindAddressFamily)
+	if err != nil {
+		logger.Fatal("Invalid bind address family", zap.Error(err))
+	}
+	bindAddress := kncloudevents.WithBindAddress(env.BindAddress, addressFamily)
+
+	unauthenticatedPaths := broker.ParseUnauthenticatedPaths(env.UnauthenticatedPaths)
+	serverManager, err := ingress.NewServerManager(ctx, logger, configMapWatcher, env.HTTPPort, env.HTTPSPort, unauthenticatedPaths.Handler(handler), bindAddress)
 	if err != nil {
 		logger.Fatal("Error creating server manager", zap.Error(err))
 	}
@@ -202,11 +313,31 @@ func main() {
 	}
 
 	// Start the servers
+	cmdbroker.StartProbeServer(ctx, logger, env.ProbePort, cmdbroker.LocalAddress(env.BindAddress, addressFamily, env.HTTPPort), unauthenticatedPaths, bindAddress)
+	handler.EventIndex.StartServer(ctx, logger, env.EventIndexPort, bindAddress)
+	handler.SyncDelivery.StartServer(ctx, logger, env.SyncDeliveryPort, bindAddress)
+	handler.StartGRPCServer(ctx, env.GRPCPort, bindAddress)
+	drainCtx, stopDrain := context.WithCancel(ctx)
+	drainDone := make(chan struct{})
+	go func() {
+		defer close(drainDone)
+		handler.DrainMaintenanceBuffer(drainCtx, maintenanceDrainInterval)
+	}()
 	logger.Info("Ingress starting...")
 	err = serverManager.StartServers(ctx)
 	if err != nil {
 		logger.Fatal("serverManager.StartServers() returned an error", zap.Error(err))
 	}
+
+	// The servers are drained, forward the events still buffered in the
+	// maintenance windows rather than losing them, once the periodic drain
+	// stopped.
+	stopDrain()
+	<-drainDone
+	flushCtx, cancel := context.WithTimeout(context.Background(), env.MaintenanceFlushTimeout)
+	handler.FlushMaintenanceBuffer(flushCtx)
+	cancel()
+
 	tracer.Shutdown(context.Background())
 	logger.Info("Exiting...")
 }
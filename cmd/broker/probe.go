@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const probeTimeout = time.Second
+
+// StartProbeServer serves the unauthenticated paths on a dedicated port, so
+// that mesh health checks don't share the port used for event traffic. The
+// probes are answered with the readiness of the event server listening at
+// eventAddress, including its draining state. It is a no-op when port is not
+// positive.
+func StartProbeServer(ctx context.Context, logger *zap.Logger, port int, eventAddress string, paths broker.UnauthenticatedPaths, opts ...kncloudevents.HTTPEventReceiverOption) {
+	if port <= 0 {
+		return
+	}
+
+	handler := paths.ProbeHandler(eventAddress, &http.Client{Timeout: probeTimeout})
+	opts = append(opts, kncloudevents.WithChecker(handler.ServeHTTP))
+	receiver := kncloudevents.NewHTTPEventReceiver(port, opts...)
+	go func() {
+		logger.Info("Starting probe server", zap.Int("port", port), zap.String("eventAddress", eventAddress))
+		if err := receiver.StartListen(ctx, handler); err != nil {
+			logger.Error("Probe server returned an error", zap.Error(err))
+		}
+	}()
+}
+
+// LocalAddress returns the address at which the event server listening on
+// port is reachable from within the pod.
+func LocalAddress(bindAddress string, family kncloudevents.AddressFamily, port int) string {
+	host := bindAddress
+	if host == "" {
+		host = "127.0.0.1"
+		if family == kncloudevents.AddressFamilyIPv6 {
+			host = "::1"
+		}
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
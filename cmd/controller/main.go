@@ -31,19 +31,40 @@ import (
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/signals"
 
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	eventingv1beta2 "knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/apis/sinks"
+	sinksv1alpha1 "knative.dev/eventing/pkg/apis/sinks/v1alpha1"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/leaderelection"
+	metricscontroller "knative.dev/eventing/pkg/metrics/controller"
 	"knative.dev/eventing/pkg/reconciler/jobsink"
 
 	"knative.dev/eventing/pkg/reconciler/apiserversource"
+	"knative.dev/eventing/pkg/reconciler/brokerdefaultspolicy"
+	"knative.dev/eventing/pkg/reconciler/carotation"
 	"knative.dev/eventing/pkg/reconciler/channel"
 	"knative.dev/eventing/pkg/reconciler/containersource"
+	"knative.dev/eventing/pkg/reconciler/deletionprotection"
+	"knative.dev/eventing/pkg/reconciler/eventemission"
+	eventemissionresources "knative.dev/eventing/pkg/reconciler/eventemission/resources"
+	"knative.dev/eventing/pkg/reconciler/eventpolicy"
 	"knative.dev/eventing/pkg/reconciler/eventtype"
+	"knative.dev/eventing/pkg/reconciler/kreferencemapping"
 	"knative.dev/eventing/pkg/reconciler/parallel"
 	"knative.dev/eventing/pkg/reconciler/pingsource"
+	"knative.dev/eventing/pkg/reconciler/redactionpolicy"
 	"knative.dev/eventing/pkg/reconciler/sequence"
 	sourcecrd "knative.dev/eventing/pkg/reconciler/source/crd"
+	"knative.dev/eventing/pkg/reconciler/storageversion"
 	"knative.dev/eventing/pkg/reconciler/subscription"
 	sugarnamespace "knative.dev/eventing/pkg/reconciler/sugar/namespace"
 	sugartrigger "knative.dev/eventing/pkg/reconciler/sugar/trigger"
@@ -84,33 +105,53 @@ func main() {
 		auth.OIDCLabelSelector,
 		eventingtls.TrustBundleLabelSelector,
 		sinks.JobSinkJobsLabelSelector,
+		eventemissionresources.LabelSelector,
 	)
 
+	ctx, err := leaderelection.WithComponentConfig(ctx, "controller")
+	if err != nil {
+		log.Fatal("Error loading leader election configuration: ", err)
+	}
+
 	sharedmain.MainWithContext(ctx, "controller",
 		// Messaging
-		channel.NewController,
-		subscription.NewController,
+		metricscontroller.WithKindMetrics(messagingv1.SchemeGroupVersion.WithKind("Channel"), channel.NewController),
+		metricscontroller.WithKindMetrics(messagingv1.SchemeGroupVersion.WithKind("Subscription"), subscription.NewController),
 
 		// Eventing
-		eventtype.NewController,
+		metricscontroller.WithKindMetrics(eventingv1beta2.SchemeGroupVersion.WithKind("EventType"), eventtype.NewController),
+		metricscontroller.WithKindMetrics(eventingv1alpha1.SchemeGroupVersion.WithKind("EventEmission"), eventemission.NewController),
+		metricscontroller.WithKindMetrics(eventingv1alpha1.SchemeGroupVersion.WithKind("EventPolicy"), eventpolicy.NewController),
+		metricscontroller.WithKindMetrics(eventingv1alpha1.SchemeGroupVersion.WithKind("RedactionPolicy"), redactionpolicy.NewController),
+		metricscontroller.WithKindMetrics(eventingv1alpha1.SchemeGroupVersion.WithKind("KReferenceMapping"), kreferencemapping.NewController),
+		metricscontroller.WithKindMetrics(eventingv1alpha1.SchemeGroupVersion.WithKind("BrokerDefaultsPolicy"), brokerdefaultspolicy.NewController),
+
+		// Deletion protection
+		metricscontroller.WithKindMetrics(eventingv1.SchemeGroupVersion.WithKind("Broker"), deletionprotection.NewBrokerController),
+		metricscontroller.WithKindMetrics(messagingv1.SchemeGroupVersion.WithKind("Channel"), deletionprotection.NewChannelController),
 
 		// Flows
-		parallel.NewController,
-		sequence.NewController,
+		metricscontroller.WithKindMetrics(flowsv1.SchemeGroupVersion.WithKind("Parallel"), parallel.NewController),
+		metricscontroller.WithKindMetrics(flowsv1.SchemeGroupVersion.WithKind("Sequence"), sequence.NewController),
 
 		// Sources
-		apiserversource.NewController,
-		pingsource.NewController,
-		containersource.NewController,
+		metricscontroller.WithKindMetrics(sourcesv1.SchemeGroupVersion.WithKind("ApiServerSource"), apiserversource.NewController),
+		metricscontroller.WithKindMetrics(sourcesv1.SchemeGroupVersion.WithKind("PingSource"), pingsource.NewController),
+		metricscontroller.WithKindMetrics(sourcesv1.SchemeGroupVersion.WithKind("ContainerSource"), containersource.NewController),
 		// Sources CRD
-		sourcecrd.NewController,
+		metricscontroller.WithKindMetrics(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), sourcecrd.NewController),
+		// Storage version migration of the eventing CRDs
+		metricscontroller.WithKindMetrics(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"), storageversion.NewController),
 
 		// Sinks
-		jobsink.NewController,
+		metricscontroller.WithKindMetrics(sinksv1alpha1.SchemeGroupVersion.WithKind("JobSink"), jobsink.NewController),
 
 		// Sugar
-		sugarnamespace.NewController,
-		sugartrigger.NewController,
+		metricscontroller.WithKindMetrics(corev1.SchemeGroupVersion.WithKind("Namespace"), sugarnamespace.NewController),
+		metricscontroller.WithKindMetrics(eventingv1.SchemeGroupVersion.WithKind("Trigger"), sugartrigger.NewController),
+
+		// TLS
+		metricscontroller.WithKindMetrics(carotation.CertificateGVK, carotation.NewController),
 	)
 }
 
@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/uuid"
+	"github.com/kelseyhightower/envconfig"
+	"knative.dev/pkg/signals"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/reconciler/eventemission/resources"
+)
+
+const (
+	terminationMessagePath = "/dev/termination-log"
+
+	sendTimeout = 30 * time.Second
+)
+
+type envConfig struct {
+	// Sink URL where to send the event.
+	Sink string `envconfig:"K_SINK" required:"true"`
+
+	// CACerts are the certificates for enabling HTTPS in Sink URL.
+	CACerts string `envconfig:"K_CA_CERTS"`
+
+	// Event is the JSON encoded EventEmissionEvent to send.
+	Event string `envconfig:"K_EVENT" required:"true"`
+}
+
+// The event_emitter sends a single CloudEvent on behalf of an EventEmission
+// and reports the outcome through its termination message, which the
+// EventEmission reconciler propagates to the status.
+func main() {
+	ctx := signals.NewContext()
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatalf("Failed to process env var: %v", err)
+	}
+
+	result := emit(ctx, env)
+	if err := writeResult(result); err != nil {
+		log.Printf("Failed to write termination message: %v", err)
+	}
+
+	if result.StatusCode < http.StatusOK || result.StatusCode >= http.StatusMultipleChoices {
+		log.Printf("Failed to send event %q: %d %s", result.EventID, result.StatusCode, result.Message)
+		os.Exit(1)
+	}
+	log.Printf("Sent event %q: %d", result.EventID, result.StatusCode)
+}
+
+func emit(ctx context.Context, env envConfig) *v1alpha1.EventEmissionResult {
+	spec := v1alpha1.EventEmissionEvent{}
+	if err := json.Unmarshal([]byte(env.Event), &spec); err != nil {
+		return &v1alpha1.EventEmissionResult{Message: fmt.Sprintf("invalid event: %v", err)}
+	}
+
+	event, err := newEvent(spec)
+	if err != nil {
+		return &v1alpha1.EventEmissionResult{EventID: spec.ID, Message: err.Error()}
+	}
+	result := &v1alpha1.EventEmissionResult{EventID: event.ID()}
+
+	c, err := newClient(env)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	token, err := os.ReadFile(filepath.Join(resources.OIDCTokenMountPath, resources.OIDCTokenFileName))
+	if err == nil {
+		headers := cehttp.HeaderFrom(ctx)
+		headers.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		ctx = cehttp.WithCustomHeader(ctx, headers)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to read OIDC token, client will not send Authorization header: %v", err)
+	}
+
+	res := c.Send(ctx, event)
+	var httpResult *cehttp.Result
+	if cloudevents.ResultAs(res, &httpResult) {
+		result.StatusCode = httpResult.StatusCode
+		if !cloudevents.IsACK(res) {
+			result.Message = httpResult.Error()
+		}
+	} else if res != nil && !cloudevents.IsACK(res) {
+		result.Message = res.Error()
+	}
+	return result
+}
+
+func newEvent(spec v1alpha1.EventEmissionEvent) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent()
+	if spec.ID != "" {
+		event.SetID(spec.ID)
+	} else {
+		event.SetID(uuid.New().String())
+	}
+	event.SetType(spec.Type)
+	event.SetSource(spec.Source)
+	event.SetTime(time.Now())
+	if spec.Subject != "" {
+		event.SetSubject(spec.Subject)
+	}
+	for name, value := range spec.Extensions {
+		event.SetExtension(name, value)
+	}
+	if spec.Data != "" {
+		if err := event.SetData(spec.DataContentType, []byte(spec.Data)); err != nil {
+			return event, fmt.Errorf("failed to set data: %w", err)
+		}
+	}
+	return event, event.Validate()
+}
+
+func newClient(env envConfig) (cloudevents.Client, error) {
+	opts := []cehttp.Option{cloudevents.WithTarget(env.Sink)}
+
+	if eventingtls.IsHttpsSink(env.Sink) {
+		clientConfig := eventingtls.NewDefaultClientConfig()
+		clientConfig.CACerts = &env.CACerts
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		tlsConfig, err := eventingtls.GetTLSClientConfig(clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS client config: %w", err)
+		}
+		transport.TLSClientConfig = tlsConfig
+		opts = append(opts, cehttp.WithRoundTripper(transport))
+	}
+
+	return cloudevents.NewClientHTTP(opts...)
+}
+
+func writeResult(result *v1alpha1.EventEmissionResult) error {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(terminationMessagePath, b, 0644)
+}
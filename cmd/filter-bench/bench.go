@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"knative.dev/pkg/logging"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/broker/filter"
+	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventfilter/attributes"
+	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+)
+
+// result is the outcome of the benchmark of the filter of a Trigger.
+type result struct {
+	trigger string
+	// kind is the kind of filter of the Trigger: filters, attributes or none.
+	kind string
+	// passed is the ratio of the events passing the filter.
+	passed float64
+	// perEvent is the average time spent evaluating the filter on an event.
+	perEvent time.Duration
+}
+
+// loadTriggersFile reads the Triggers of a file of YAML or JSON documents,
+// which can also be lists of Triggers. The other resources are ignored.
+func loadTriggersFile(path string) ([]*eventingv1.Trigger, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return loadTriggers(f)
+}
+
+func loadTriggers(r io.Reader) ([]*eventingv1.Trigger, error) {
+	var triggers []*eventingv1.Trigger
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var doc struct {
+			Kind  string            `json:"kind"`
+			Items []json.RawMessage `json:"items"`
+		}
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return triggers, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return nil, err
+		}
+
+		items := []json.RawMessage{raw}
+		if doc.Kind == "List" || doc.Kind == "TriggerList" {
+			items = doc.Items
+		}
+		for _, item := range items {
+			t := &eventingv1.Trigger{}
+			if err := json.Unmarshal(item, t); err != nil {
+				return nil, err
+			}
+			if t.Kind == "Trigger" {
+				triggers = append(triggers, t)
+			}
+		}
+	}
+}
+
+// loadEventsFile reads a file of JSON encoded CloudEvents, one after the
+// other or in arrays.
+func loadEventsFile(path string) ([]cloudevents.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return loadEvents(f)
+}
+
+func loadEvents(r io.Reader) ([]cloudevents.Event, error) {
+	var events []cloudevents.Event
+	decoder := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 && raw[0] == '[' {
+			var batch []cloudevents.Event
+			if err := json.Unmarshal(raw, &batch); err != nil {
+				return nil, err
+			}
+			events = append(events, batch...)
+			continue
+		}
+		var e cloudevents.Event
+		if err := json.Unmarshal(raw, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	if len(events) == 0 {
+		return nil, errors.New("no event")
+	}
+	for i := range events {
+		if err := events[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+	}
+	return events, nil
+}
+
+// triggersOfBroker returns the Triggers of the named Broker, or all of them
+// when the name is empty.
+func triggersOfBroker(triggers []*eventingv1.Trigger, broker string) []*eventingv1.Trigger {
+	if broker == "" {
+		return triggers
+	}
+	var res []*eventingv1.Trigger
+	for _, t := range triggers {
+		if t.Spec.Broker == broker {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// triggerFilter materializes the filter of the Trigger like the Broker filter,
+// with the new-trigger-filters feature enabled.
+func triggerFilter(logger *zap.Logger, t *eventingv1.Trigger) (eventfilter.Filter, string) {
+	switch {
+	case len(t.Spec.Filters) > 0:
+		return subscriptionsapi.NewAllFilter(filter.MaterializeFiltersList(logger, t.Spec.Filters)...), "filters"
+	case t.Spec.Filter != nil:
+		return attributes.NewAttributesFilter(t.Spec.Filter.Attributes), "attributes"
+	default:
+		return subscriptionsapi.NewNoFilter(), "none"
+	}
+}
+
+// benchmark evaluates the filter of each Trigger against the events, over
+// and over for the given duration.
+func benchmark(triggers []*eventingv1.Trigger, events []cloudevents.Event, duration time.Duration) []result {
+	logger := zap.NewNop()
+	ctx := logging.WithLogger(context.Background(), logger.Sugar())
+
+	results := make([]result, 0, len(triggers))
+	for _, t := range triggers {
+		f, kind := triggerFilter(logger, t)
+
+		passed := 0
+		for _, e := range events {
+			if f.Filter(ctx, e) != eventfilter.FailFilter {
+				passed++
+			}
+		}
+
+		evaluated := 0
+		start := time.Now()
+		for time.Since(start) < duration {
+			for _, e := range events {
+				f.Filter(ctx, e)
+			}
+			evaluated += len(events)
+		}
+		elapsed := time.Since(start)
+		f.Cleanup()
+
+		results = append(results, result{
+			trigger:  t.Namespace + "/" + t.Name,
+			kind:     kind,
+			passed:   float64(passed) / float64(len(events)),
+			perEvent: elapsed / time.Duration(evaluated),
+		})
+	}
+	return results
+}
+
+// report writes the results, followed by the throughput of the Broker filter
+// evaluating the filters of all the Triggers for each event.
+func report(w io.Writer, results []result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "TRIGGER\tFILTER\tPASSED\tNS/EVENT\tEVENTS/S")
+
+	var total time.Duration
+	for _, r := range results {
+		total += r.perEvent
+		fmt.Fprintf(tw, "%s\t%s\t%.1f%%\t%d\t%d\n", r.trigger, r.kind, r.passed*100, r.perEvent.Nanoseconds(), throughput(r.perEvent))
+	}
+	fmt.Fprintf(tw, "total\t\t\t%d\t%d\n", total.Nanoseconds(), throughput(total))
+	return tw.Flush()
+}
+
+// throughput returns the number of events per second processed in the given
+// time each.
+func throughput(perEvent time.Duration) int64 {
+	if perEvent <= 0 {
+		return 0
+	}
+	return int64(time.Second / perEvent)
+}
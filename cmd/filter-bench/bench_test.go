@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+const triggersYAML = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: eventing.knative.dev/v1
+  kind: Trigger
+  metadata:
+    name: orders
+    namespace: default
+  spec:
+    broker: default
+    filters:
+    - cesql: "type = 'order.created'"
+---
+apiVersion: eventing.knative.dev/v1
+kind: Trigger
+metadata:
+  name: payments
+  namespace: default
+spec:
+  broker: other
+  filter:
+    attributes:
+      type: payment.done
+---
+apiVersion: eventing.knative.dev/v1
+kind: Broker
+metadata:
+  name: default
+  namespace: default
+`
+
+const eventsJSON = `
+{"specversion":"1.0","id":"1","source":"/shop","type":"order.created"}
+[{"specversion":"1.0","id":"2","source":"/bank","type":"payment.done"},
+ {"specversion":"1.0","id":"3","source":"/shop","type":"order.shipped"},
+ {"specversion":"1.0","id":"4","source":"/shop","type":"order.created"}]
+`
+
+func TestLoadTriggers(t *testing.T) {
+	triggers, err := loadTriggers(strings.NewReader(triggersYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triggers) != 2 {
+		t.Fatalf("got %d triggers, want 2", len(triggers))
+	}
+	if got := triggersOfBroker(triggers, "default"); len(got) != 1 || got[0].Name != "orders" {
+		t.Errorf("triggersOfBroker() = %v, want the orders trigger", got)
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	events, err := loadEvents(strings.NewReader(eventsJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	if _, err := loadEvents(strings.NewReader("")); err == nil {
+		t.Error("expected an error loading no event")
+	}
+	if _, err := loadEvents(strings.NewReader(`{"specversion":"1.0","id":"1"}`)); err == nil {
+		t.Error("expected an error loading an invalid event")
+	}
+}
+
+func TestBenchmark(t *testing.T) {
+	triggers, err := loadTriggers(strings.NewReader(triggersYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := loadEvents(strings.NewReader(eventsJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := benchmark(triggers, events, time.Millisecond)
+	want := []result{
+		{trigger: "default/orders", kind: "filters", passed: 0.5},
+		{trigger: "default/payments", kind: "attributes", passed: 0.25},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, r := range results {
+		if r.trigger != want[i].trigger || r.kind != want[i].kind || r.passed != want[i].passed {
+			t.Errorf("result %d = %+v, want %+v", i, r, want[i])
+		}
+		if r.perEvent <= 0 {
+			t.Errorf("result %d has no evaluation time", i)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := report(&out, results); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 4 {
+		t.Errorf("got %d report lines, want 4:\n%s", lines, out.String())
+	}
+}
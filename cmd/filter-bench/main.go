@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/client/clientset/versioned"
+)
+
+/*
+filter-bench measures how many events per second the filters of Triggers
+evaluate, the way the Broker filter does, to predict its capacity before
+rolling out new Triggers. The Triggers are read from YAML or JSON files, as
+output by `kubectl get triggers -o yaml`, or from a cluster:
+
+	filter-bench -triggers triggers.yaml -events events.json
+	filter-bench -kubeconfig ~/.kube/config -namespace default -broker default -events events.json
+
+The events are read from a file of JSON encoded CloudEvents, one after the
+other or in arrays. Example Output:
+
+	TRIGGER            FILTER      PASSED   NS/EVENT   EVENTS/S
+	default/orders     filters     50.0%    412        2427184
+	default/payments   attributes  25.0%    98         10204081
+	total                                   510        1960784
+*/
+
+func main() {
+	var (
+		triggerFiles stringsFlag
+		kubeconfig   = flag.String("kubeconfig", "", "Path to the kubeconfig of the cluster to read the Triggers from, when no -triggers file is given.")
+		namespace    = flag.String("namespace", "", "Namespace of the Triggers read from the cluster, all namespaces when empty.")
+		brokerName   = flag.String("broker", "", "Only benchmark the Triggers of the Broker with this name.")
+		eventsFile   = flag.String("events", "", "Path to the file of JSON encoded CloudEvents the filters are evaluated against.")
+		duration     = flag.Duration("duration", time.Second, "Time spent benchmarking the filter of each Trigger.")
+	)
+	flag.Var(&triggerFiles, "triggers", "Path to a YAML or JSON file of Triggers, can be repeated.")
+	flag.Parse()
+
+	if *eventsFile == "" {
+		log.Fatal("-events is required")
+	}
+	if *duration <= 0 {
+		log.Fatal("-duration must be positive")
+	}
+
+	events, err := loadEventsFile(*eventsFile)
+	if err != nil {
+		log.Fatal("Failed to load the events: ", err)
+	}
+
+	var triggers []*eventingv1.Trigger
+	if len(triggerFiles) > 0 {
+		for _, path := range triggerFiles {
+			ts, err := loadTriggersFile(path)
+			if err != nil {
+				log.Fatal("Failed to load the Triggers: ", err)
+			}
+			triggers = append(triggers, ts...)
+		}
+	} else {
+		triggers, err = listTriggers(context.Background(), *kubeconfig, *namespace)
+		if err != nil {
+			log.Fatal("Failed to list the Triggers: ", err)
+		}
+	}
+	triggers = triggersOfBroker(triggers, *brokerName)
+	if len(triggers) == 0 {
+		log.Fatal("No Trigger to benchmark")
+	}
+
+	results := benchmark(triggers, events, *duration)
+	if err := report(os.Stdout, results); err != nil {
+		log.Fatal("Failed to write the report: ", err)
+	}
+}
+
+// listTriggers lists the Triggers of the namespace, or of all the namespaces
+// when empty, from the cluster of the kubeconfig, which defaults to the
+// in-cluster configuration.
+func listTriggers(ctx context.Context, kubeconfig, namespace string) ([]*eventingv1.Trigger, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the client configuration: %w", err)
+	}
+	client, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client: %w", err)
+	}
+	list, err := client.EventingV1().Triggers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	triggers := make([]*eventingv1.Trigger, 0, len(list.Items))
+	for i := range list.Items {
+		triggers = append(triggers, &list.Items[i])
+	}
+	return triggers, nil
+}
+
+// stringsFlag is a flag which can be repeated.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return fmt.Sprint([]string(*f))
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
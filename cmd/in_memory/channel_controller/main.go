@@ -21,6 +21,7 @@ import (
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
 	"context"
+	"log"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
@@ -34,6 +35,8 @@ import (
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	"knative.dev/eventing/pkg/leaderelection"
+	metricscontroller "knative.dev/eventing/pkg/metrics/controller"
 	inmemorychannel "knative.dev/eventing/pkg/reconciler/inmemorychannel/controller"
 )
 
@@ -97,11 +100,16 @@ func main() {
 		SecretName: "inmemorychannel-webhook-certs",
 	})
 
+	ctx, err := leaderelection.WithComponentConfig(ctx, webhook.NameFromEnv())
+	if err != nil {
+		log.Fatal("Error loading leader election configuration: ", err)
+	}
+
 	sharedmain.MainWithContext(ctx, webhook.NameFromEnv(),
 		certificates.NewController,
 		NewValidationAdmissionController,
 		NewDefaultingAdmissionController,
 
-		inmemorychannel.NewController,
+		metricscontroller.WithKindMetrics(messagingv1.SchemeGroupVersion.WithKind("InMemoryChannel"), inmemorychannel.NewController),
 	)
 }
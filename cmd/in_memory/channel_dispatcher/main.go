@@ -20,6 +20,7 @@ import (
 	// Uncomment the following line to load the gcp plugin (only required to authenticate against GKE clusters).
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
+	"log"
 	"os"
 
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -27,8 +28,11 @@ import (
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/signals"
 
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/leaderelection"
+	metricscontroller "knative.dev/eventing/pkg/metrics/controller"
 	inmemorychannel "knative.dev/eventing/pkg/reconciler/inmemorychannel/dispatcher"
 )
 
@@ -44,7 +48,12 @@ func main() {
 		eventingtls.TrustBundleLabelSelector,
 	)
 
+	ctx, err := leaderelection.WithComponentConfig(ctx, "inmemorychannel-dispatcher")
+	if err != nil {
+		log.Fatal("Error loading leader election configuration: ", err)
+	}
+
 	sharedmain.MainWithContext(ctx, "inmemorychannel-dispatcher",
-		inmemorychannel.NewController,
+		metricscontroller.WithKindMetrics(messagingv1.SchemeGroupVersion.WithKind("InMemoryChannel"), inmemorychannel.NewController),
 	)
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/ptr"
+
+	sinksv "knative.dev/eventing/pkg/apis/sinks/v1alpha1"
+	"knative.dev/eventing/pkg/eventfilter/attributes"
+	"knative.dev/eventing/pkg/redaction"
+)
+
+// eventEnvValues returns the values of the env vars of a JobSink for the
+// event, keyed by name. The env vars whose value is missing from the event
+// are left out.
+func eventEnvValues(event cloudevents.Event, env []sinksv.JobSinkEnvVar) map[string][]byte {
+	values := make(map[string][]byte, len(env))
+
+	var data interface{}
+	dataDecoded := false
+
+	for _, e := range env {
+		if e.Attribute != "" {
+			v, ok := attributes.LookupAttribute(event, e.Attribute)
+			if !ok || v == nil {
+				continue
+			}
+			s, err := cetypes.Format(v)
+			if err != nil {
+				s = fmt.Sprint(v)
+			}
+			if s != "" {
+				values[e.Name] = []byte(s)
+			}
+			continue
+		}
+
+		// The validation of the JobSink rejects invalid paths.
+		p, err := redaction.ParsePath(e.DataPath)
+		if err != nil {
+			continue
+		}
+		if !dataDecoded {
+			dataDecoded = true
+			if err := json.Unmarshal(event.Data(), &data); err != nil {
+				data = nil
+			}
+		}
+		selected := p.Select(data)
+		switch len(selected) {
+		case 0:
+			continue
+		case 1:
+			values[e.Name] = encodeEnvValue(selected[0])
+		default:
+			values[e.Name] = encodeEnvValue(selected)
+		}
+	}
+
+	return values
+}
+
+func encodeEnvValue(v interface{}) []byte {
+	if s, ok := v.(string); ok {
+		return []byte(s)
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+// withEventEnv sets the env vars of the JobSink in the containers of the
+// job, from the keys of the secret holding the event.
+func withEventEnv(job *batchv1.Job, secretName string, env []sinksv.JobSinkEnvVar) {
+	for i := range job.Spec.Template.Spec.Containers {
+		for _, e := range env {
+			job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, corev1.EnvVar{
+				Name: e.Name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+						Key:                  e.Name,
+						// The value is missing from the secret when the
+						// event doesn't have it.
+						Optional: ptr.Bool(true),
+					},
+				},
+			})
+		}
+	}
+}
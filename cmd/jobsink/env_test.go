@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/ptr"
+
+	sinksv "knative.dev/eventing/pkg/apis/sinks/v1alpha1"
+)
+
+func TestEventEnvValues(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	event.SetType("dev.knative.example")
+	event.SetSource("/example")
+	event.SetExtension("priority", 3)
+	if err := event.SetData(cloudevents.ApplicationJSON, map[string]interface{}{
+		"user":  map[string]interface{}{"name": "jane", "age": 42},
+		"items": []string{"a", "b"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	env := []sinksv.JobSinkEnvVar{
+		{Name: "ID", Attribute: "id"},
+		{Name: "TYPE", Attribute: "type"},
+		{Name: "PRIORITY", Attribute: "priority"},
+		{Name: "SUBJECT", Attribute: "subject"},
+		{Name: "MISSING", Attribute: "missing"},
+		{Name: "USER", DataPath: "$.user.name"},
+		{Name: "AGE", DataPath: "$.user.age"},
+		{Name: "ITEMS", DataPath: "$.items[*]"},
+		{Name: "EMAIL", DataPath: "$.user.email"},
+	}
+
+	want := map[string][]byte{
+		"ID":       []byte("1234"),
+		"TYPE":     []byte("dev.knative.example"),
+		"PRIORITY": []byte("3"),
+		"USER":     []byte("jane"),
+		"AGE":      []byte("42"),
+		"ITEMS":    []byte(`["a","b"]`),
+	}
+	if diff := cmp.Diff(want, eventEnvValues(event, env)); diff != "" {
+		t.Error("Unexpected values (-want, +got):", diff)
+	}
+}
+
+func TestEventEnvValuesNonJSONData(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("1234")
+	if err := event.SetData(cloudevents.TextPlain, "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	got := eventEnvValues(event, []sinksv.JobSinkEnvVar{{Name: "USER", DataPath: "$.user"}})
+	if len(got) != 0 {
+		t.Errorf("Unexpected values %v", got)
+	}
+}
+
+func TestWithEventEnv(t *testing.T) {
+	job := &batchv1.Job{}
+	job.Spec.Template.Spec.Containers = []corev1.Container{{Name: "main"}}
+
+	withEventEnv(job, "secret", []sinksv.JobSinkEnvVar{{Name: "ID", Attribute: "id"}})
+
+	want := []corev1.EnvVar{{
+		Name: "ID",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "secret"},
+				Key:                  "ID",
+				Optional:             ptr.Bool(true),
+			},
+		},
+	}}
+	if diff := cmp.Diff(want, job.Spec.Template.Spec.Containers[0].Env); diff != "" {
+		t.Error("Unexpected env (-want, +got):", diff)
+	}
+}
@@ -272,6 +272,9 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		BlockOwnerDeletion: ptr.Bool(false),
 	}
 
+	data := eventEnvValues(*event, js.Spec.Env)
+	data["event"] = eventBytes
+
 	secret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{},
 		ObjectMeta: metav1.ObjectMeta{
@@ -284,7 +287,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			OwnerReferences: []metav1.OwnerReference{or},
 		},
 		Immutable: ptr.Bool(true),
-		Data:      map[string][]byte{"event": eventBytes},
+		Data:      data,
 		Type:      corev1.SecretTypeOpaque,
 	}
 
@@ -331,6 +334,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	withEventEnv(job, jobName, js.Spec.Env)
+
 	found := false
 	for i := range job.Spec.Template.Spec.Volumes {
 		if job.Spec.Template.Spec.Volumes[i].Name == "jobsink-event" {
@@ -434,9 +439,7 @@ func getServerTLSConfig(ctx context.Context) (*tls.Config, error) {
 		Name:      eventingtls.JobSinkDispatcherServerTLSSecretName,
 	}
 
-	serverTLSConfig := eventingtls.NewDefaultServerConfig()
-	serverTLSConfig.GetCertificate = eventingtls.GetCertificateFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
-	return eventingtls.GetTLSServerConfig(serverTLSConfig)
+	return eventingtls.GetTLSServerConfigFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
 }
 
 func locationHeader(ref types.NamespacedName, source, id string) string {
@@ -21,14 +21,19 @@ import (
 	// _ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
 	"context"
+	"log"
 
 	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/leaderelection"
 	"knative.dev/pkg/injection/sharedmain"
 
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/signals"
 
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	metricscontroller "knative.dev/eventing/pkg/metrics/controller"
 	"knative.dev/eventing/pkg/reconciler/broker"
+	"knative.dev/eventing/pkg/reconciler/broker/ingressexposure"
 	mttrigger "knative.dev/eventing/pkg/reconciler/broker/trigger"
 )
 
@@ -42,12 +47,20 @@ func main() {
 	ctx = filteredFactory.WithSelectors(ctx,
 		auth.OIDCLabelSelector)
 
+	ctx, err := leaderelection.WithComponentConfig(ctx, component)
+	if err != nil {
+		log.Fatal("Error loading leader election configuration: ", err)
+	}
+
 	sharedmain.MainWithContext(ctx,
 		component,
 
-		broker.NewController,
+		metricscontroller.WithKindMetrics(eventingv1.SchemeGroupVersion.WithKind("Broker"), broker.NewController),
+
+		metricscontroller.WithKindMetrics(eventingv1.SchemeGroupVersion.WithKind("Trigger"), mttrigger.NewController),
 
-		mttrigger.NewController,
+		// Exposure of the ingress with the Gateway API
+		metricscontroller.WithKindMetrics(eventingv1.SchemeGroupVersion.WithKind("Broker"), ingressexposure.NewController),
 	)
 	broker.Tracer.Shutdown(context.Background())
 }
@@ -20,14 +20,19 @@ import (
 	"context"
 	"os"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	eventingv1beta3 "knative.dev/eventing/pkg/apis/eventing/v1beta3"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/quota"
 	"knative.dev/eventing/pkg/apis/sinks"
 	sinksv1alpha1 "knative.dev/eventing/pkg/apis/sinks/v1alpha1"
 	"knative.dev/eventing/pkg/auth"
@@ -64,7 +69,13 @@ import (
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	sourcesv1beta2 "knative.dev/eventing/pkg/apis/sources/v1beta2"
 	"knative.dev/eventing/pkg/apis/sugar"
+	brokerdefaultspolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/brokerdefaultspolicy"
+	pingsourceinformer "knative.dev/eventing/pkg/client/injection/informers/sources/v1/pingsource"
+	"knative.dev/eventing/pkg/kreferencemapping"
+	eventingleaderelection "knative.dev/eventing/pkg/leaderelection"
+	"knative.dev/eventing/pkg/reconciler/brokerdefaultspolicy"
 	"knative.dev/eventing/pkg/reconciler/sinkbinding"
+	"knative.dev/eventing/pkg/resolver"
 
 	versionedscheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
 )
@@ -75,6 +86,11 @@ func init() {
 
 var ourTypes = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// For group eventing.knative.dev.
+	// v1alpha1
+	eventingv1alpha1.SchemeGroupVersion.WithKind("EventEmission"):        &eventingv1alpha1.EventEmission{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("RedactionPolicy"):      &eventingv1alpha1.RedactionPolicy{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("KReferenceMapping"):    &eventingv1alpha1.KReferenceMapping{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("BrokerDefaultsPolicy"): &eventingv1alpha1.BrokerDefaultsPolicy{},
 	// v1beta1
 	eventingv1beta1.SchemeGroupVersion.WithKind("EventType"): &eventingv1beta1.EventType{},
 	// v1beta2
@@ -120,9 +136,15 @@ func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher
 	featureStore := feature.NewStore(logging.FromContext(ctx).Named("feature-config-store"))
 	featureStore.WatchConfigs(cmw)
 
+	// Look up the BrokerDefaultsPolicies selecting the namespace of the
+	// Brokers and Triggers.
+	namespaceDefaultsLookup := brokerdefaultspolicy.NewNamespaceDefaultsLookup(
+		namespaceinformer.Get(ctx).Lister(), brokerdefaultspolicyinformer.Get(ctx).Lister())
+
 	// Decorate contexts with the current state of the config.
 	ctxFunc := func(ctx context.Context) context.Context {
-		return featureStore.ToContext(channelStore.ToContext(store.ToContext(ctx)))
+		return defaultconfig.WithNamespaceDefaultsLookup(
+			featureStore.ToContext(channelStore.ToContext(store.ToContext(ctx))), namespaceDefaultsLookup)
 	}
 
 	return defaulting.NewAdmissionController(ctx,
@@ -160,12 +182,42 @@ func NewValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 	k8s := kubeclient.Get(ctx)
 
+	// Count the PingSources of a namespace to enforce the max-per-namespace
+	// quota of config-ping-defaults.
+	pingSourceLister := pingsourceinformer.Get(ctx).Lister()
+	pingSourceCounter := func(_ context.Context, namespace string) (int, error) {
+		pingSources, err := pingSourceLister.PingSources(namespace).List(labels.Everything())
+		return len(pingSources), err
+	}
+
+	// Count the Brokers, Triggers, Channels and Subscriptions of a namespace
+	// to enforce the quotas of config-namespace-quotas, and report their
+	// usage.
+	informers := quotaInformers(ctx)
+	indexers := make(map[quota.Resource]cache.Indexer, len(informers))
+	for res, informer := range informers {
+		indexers[res] = informer.GetIndexer()
+	}
+	quotaReporter := newQuotaReporter(indexers)
+	for res, informer := range informers {
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    quotaReporter.reportObject(res),
+			DeleteFunc: quotaReporter.reportObject(res),
+		})
+	}
+	quotaStore := quota.NewStore(logging.FromContext(ctx).Named("quota-config-store"), quotaReporter.onConfigChanged)
+	quotaStore.WatchConfigs(cmw)
+
 	// Decorate contexts with the current state of the config.
 	ctxFunc := func(ctx context.Context) context.Context {
 		return sinks.WithConfig(
 			featureStore.ToContext(
 				channelStore.ToContext(
-					pingstore.ToContext(store.ToContext(ctx)))),
+					pingdefaultconfig.WithPingSourceCounter(
+						pingstore.ToContext(
+							quota.WithCounter(
+								quotaStore.ToContext(store.ToContext(ctx)), quotaReporter.count)),
+						pingSourceCounter))),
 			&sinks.Config{
 				KubeClient: k8s,
 			})
@@ -207,8 +259,10 @@ func NewConfigValidationController(ctx context.Context, _ configmap.Watcher) *co
 			tracingconfig.ConfigName: tracingconfig.NewTracingConfigFromConfigMap,
 			// metrics.ConfigMapName():   metricsconfig.NewObservabilityConfigFromConfigMap,
 			logging.ConfigMapName():        logging.NewConfigFromConfigMap,
-			leaderelection.ConfigMapName(): leaderelection.NewConfigFromConfigMap,
+			leaderelection.ConfigMapName(): eventingleaderelection.NewConfigFromConfigMap,
 			sugar.ConfigName:               sugar.NewConfigFromConfigMap,
+			resolver.ConfigMapName:         kreferencemapping.NewFromConfigMap,
+			quota.ConfigName:               quota.NewConfigFromConfigMap,
 		},
 	)
 }
@@ -252,6 +306,21 @@ func NewConversionController(ctx context.Context, cmw configmap.Watcher) *contro
 		return featureStore.ToContext(channelStore.ToContext(store.ToContext(ctx)))
 	}
 
+	return conversion.NewConversionController(ctx,
+		// The path on which to serve the webhook
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted
+		conversions(),
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		ctxFunc,
+	)
+}
+
+// conversions returns the conversions of the custom resource definitions
+// with several versions.
+func conversions() map[schema.GroupKind]conversion.GroupKindConversion {
 	var (
 		sourcesv1beta2_  = sourcesv1beta2.SchemeGroupVersion.Version
 		sourcesv1_       = sourcesv1.SchemeGroupVersion.Version
@@ -260,36 +329,27 @@ func NewConversionController(ctx context.Context, cmw configmap.Watcher) *contro
 		eventingv1beta3_ = eventingv1beta3.SchemeGroupVersion.Version
 	)
 
-	return conversion.NewConversionController(ctx,
-		// The path on which to serve the webhook
-		"/resource-conversion",
-
-		// Specify the types of custom resource definitions that should be converted
-		map[schema.GroupKind]conversion.GroupKindConversion{
-			// Sources
-			sourcesv1.Kind("PingSource"): {
-				DefinitionName: sources.PingSourceResource.String(),
-				HubVersion:     sourcesv1beta2_,
-				Zygotes: map[string]conversion.ConvertibleObject{
-					sourcesv1beta2_: &sourcesv1beta2.PingSource{},
-					sourcesv1_:      &sourcesv1.PingSource{},
-				},
+	return map[schema.GroupKind]conversion.GroupKindConversion{
+		// Sources
+		sourcesv1.Kind("PingSource"): {
+			DefinitionName: sources.PingSourceResource.String(),
+			HubVersion:     sourcesv1beta2_,
+			Zygotes: map[string]conversion.ConvertibleObject{
+				sourcesv1beta2_: &sourcesv1beta2.PingSource{},
+				sourcesv1_:      &sourcesv1.PingSource{},
 			},
-			// Eventing
-			eventingv1beta2.Kind("EventType"): {
-				DefinitionName: eventing.EventTypesResource.String(),
-				HubVersion:     eventingv1beta1_,
-				Zygotes: map[string]conversion.ConvertibleObject{
-					eventingv1beta1_: &eventingv1beta1.EventType{},
-					eventingv1beta2_: &eventingv1beta2.EventType{},
-					eventingv1beta3_: &eventingv1beta3.EventType{},
-				},
+		},
+		// Eventing
+		eventingv1beta2.Kind("EventType"): {
+			DefinitionName: eventing.EventTypesResource.String(),
+			HubVersion:     eventingv1beta1_,
+			Zygotes: map[string]conversion.ConvertibleObject{
+				eventingv1beta1_: &eventingv1beta1.EventType{},
+				eventingv1beta2_: &eventingv1beta2.EventType{},
+				eventingv1beta3_: &eventingv1beta3.EventType{},
 			},
 		},
-
-		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
-		ctxFunc,
-	)
+	}
 }
 
 func main() {
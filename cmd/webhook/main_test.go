@@ -17,9 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 )
 
@@ -30,3 +35,62 @@ func TestSchemaRegistrations(t *testing.T) {
 		t.Errorf("Schemas should have been registered: %v", err)
 	}
 }
+
+// TestConversions checks that the custom resource definitions with several
+// versions are converted between all of them.
+func TestConversions(t *testing.T) {
+	files, err := filepath.Glob("../../config/core/resources/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no custom resource definition found")
+	}
+
+	convs := conversions()
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		crd := struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				Group string `json:"group"`
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+				Versions []struct {
+					Name string `json:"name"`
+				} `json:"versions"`
+			} `json:"spec"`
+		}{}
+		if err := yaml.Unmarshal(b, &crd); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if crd.Kind != "CustomResourceDefinition" || len(crd.Spec.Versions) < 2 {
+			continue
+		}
+
+		gk := schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}
+		conv, ok := convs[gk]
+		if !ok {
+			t.Errorf("%s has several versions but no conversion", gk)
+			continue
+		}
+		if conv.DefinitionName != crd.Metadata.Name {
+			t.Errorf("%s conversion has the definition name %q, want %q", gk, conv.DefinitionName, crd.Metadata.Name)
+		}
+		if _, ok := conv.Zygotes[conv.HubVersion]; !ok {
+			t.Errorf("%s conversion has no zygote for its hub version %s", gk, conv.HubVersion)
+		}
+		for _, v := range crd.Spec.Versions {
+			if _, ok := conv.Zygotes[v.Name]; !ok {
+				t.Errorf("%s conversion has no zygote for the version %s", gk, v.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/messaging"
+	"knative.dev/eventing/pkg/apis/quota"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	triggerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
+	channelinformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/channel"
+	subscriptioninformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+var (
+	// namespaceCountM records the number of objects of a resource in a
+	// namespace.
+	namespaceCountM = stats.Int64(
+		"namespace_resource_count",
+		"Number of objects of the resource in the namespace",
+		stats.UnitDimensionless,
+	)
+
+	// namespaceQuotaM records the quota of a resource in a namespace, -1 when
+	// there is no limit.
+	namespaceQuotaM = stats.Int64(
+		"namespace_resource_quota",
+		"Maximum number of objects of the resource allowed in the namespace",
+		stats.UnitDimensionless,
+	)
+
+	namespaceKey = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+	resourceKey  = tag.MustNewKey(eventingmetrics.LabelResourceGroup)
+
+	// quotaResourceGroups are the resource_group tags of the resources
+	// having a quota.
+	quotaResourceGroups = map[quota.Resource]string{
+		quota.Brokers:       eventing.BrokersResource.String(),
+		quota.Triggers:      eventing.TriggersResource.String(),
+		quota.Channels:      messaging.ChannelsResource.String(),
+		quota.Subscriptions: messaging.SubscriptionsResource.String(),
+	}
+)
+
+func init() {
+	registerQuotaViews()
+}
+
+func registerQuotaViews() {
+	if err := view.Register(
+		&view.View{
+			Description: namespaceCountM.Description(),
+			Measure:     namespaceCountM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey, resourceKey},
+		},
+		&view.View{
+			Description: namespaceQuotaM.Description(),
+			Measure:     namespaceQuotaM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey, resourceKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// quotaInformers returns the informers of the resources having a quota.
+func quotaInformers(ctx context.Context) map[quota.Resource]cache.SharedIndexInformer {
+	return map[quota.Resource]cache.SharedIndexInformer{
+		quota.Brokers:       brokerinformer.Get(ctx).Informer(),
+		quota.Triggers:      triggerinformer.Get(ctx).Informer(),
+		quota.Channels:      channelinformer.Get(ctx).Informer(),
+		quota.Subscriptions: subscriptioninformer.Get(ctx).Informer(),
+	}
+}
+
+// quotaReporter counts the objects of the resources having a quota, and
+// reports the usage of the quotas enforced by the webhook.
+type quotaReporter struct {
+	indexers map[quota.Resource]cache.Indexer
+	config   atomic.Pointer[quota.Config]
+}
+
+func newQuotaReporter(indexers map[quota.Resource]cache.Indexer) *quotaReporter {
+	return &quotaReporter{indexers: indexers}
+}
+
+// count is the quota.Counter of the webhook.
+func (r *quotaReporter) count(_ context.Context, res quota.Resource, namespace string) (int, error) {
+	indexer, ok := r.indexers[res]
+	if !ok {
+		return 0, fmt.Errorf("unknown resource %q", res)
+	}
+	objs, err := indexer.ByIndex(cache.NamespaceIndex, namespace)
+	return len(objs), err
+}
+
+// onConfigChanged is called by the quota store, it records the new quotas
+// for every namespace.
+func (r *quotaReporter) onConfigChanged(_ string, value interface{}) {
+	cfg, ok := value.(*quota.Config)
+	if !ok {
+		return
+	}
+	r.config.Store(cfg.DeepCopy())
+	r.reportAll()
+}
+
+// reportObject returns an informer event handler reporting the quota usage
+// of the namespace of the given object of the resource.
+func (r *quotaReporter) reportObject(res quota.Resource) func(obj interface{}) {
+	return func(obj interface{}) {
+		accessor, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		r.report(res, accessor.GetNamespace())
+	}
+}
+
+// reportAll reports the quota usage of every namespace holding objects of
+// the resources having a quota.
+func (r *quotaReporter) reportAll() {
+	for res, indexer := range r.indexers {
+		for _, ns := range indexer.ListIndexFuncValues(cache.NamespaceIndex) {
+			r.report(res, ns)
+		}
+	}
+}
+
+func (r *quotaReporter) report(res quota.Resource, namespace string) {
+	count, err := r.count(context.Background(), res, namespace)
+	if err != nil {
+		return
+	}
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(namespaceKey, namespace),
+		tag.Insert(resourceKey, quotaResourceGroups[res]))
+	if err != nil {
+		return
+	}
+	metrics.RecordBatch(ctx, namespaceCountM.M(int64(count)), namespaceQuotaM.M(r.config.Load().Limit(namespace, res)))
+}
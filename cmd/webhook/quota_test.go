@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/quota"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+func TestQuotaReporter(t *testing.T) {
+	resetQuotaMetrics()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	b1 := newQuotaBroker("ns1", "b1")
+	for _, b := range []*eventingv1.Broker{b1, newQuotaBroker("ns1", "b2"), newQuotaBroker("ns2", "b3")} {
+		if err := indexer.Add(b); err != nil {
+			t.Fatal("indexer.Add() =", err)
+		}
+	}
+	r := newQuotaReporter(map[quota.Resource]cache.Indexer{quota.Brokers: indexer})
+
+	if count, err := r.count(context.Background(), quota.Brokers, "ns1"); err != nil || count != 2 {
+		t.Errorf("count() = %d, %v, want 2", count, err)
+	}
+	if _, err := r.count(context.Background(), quota.Channels, "ns1"); err == nil {
+		t.Error("count() of a resource without indexer succeeded")
+	}
+
+	ns1 := map[string]string{eventingmetrics.LabelNamespaceName: "ns1", eventingmetrics.LabelResourceGroup: "brokers.eventing.knative.dev"}
+	ns2 := map[string]string{eventingmetrics.LabelNamespaceName: "ns2", eventingmetrics.LabelResourceGroup: "brokers.eventing.knative.dev"}
+
+	r.reportObject(quota.Brokers)(b1)
+	metricstest.CheckLastValueData(t, "namespace_resource_count", ns1, 2)
+	metricstest.CheckLastValueData(t, "namespace_resource_quota", ns1, quota.Unlimited)
+
+	// A config change reports the new quotas for every namespace.
+	resetQuotaMetrics()
+	r.onConfigChanged(quota.ConfigName, &quota.Config{
+		Default:    quota.Limits{quota.Brokers: 5},
+		Namespaces: map[string]quota.Limits{"ns2": {quota.Brokers: 1}},
+	})
+	checkNamespaceValue(t, "namespace_resource_quota", ns1, 5)
+	checkNamespaceValue(t, "namespace_resource_quota", ns2, 1)
+	checkNamespaceValue(t, "namespace_resource_count", ns2, 1)
+
+	// Deleted Brokers may be delivered as tombstones.
+	resetQuotaMetrics()
+	if err := indexer.Delete(b1); err != nil {
+		t.Fatal("indexer.Delete() =", err)
+	}
+	r.reportObject(quota.Brokers)(cache.DeletedFinalStateUnknown{Key: "ns1/b1", Obj: b1})
+	metricstest.CheckLastValueData(t, "namespace_resource_count", ns1, 1)
+}
+
+// checkNamespaceValue checks the last value reported with the given tags, as
+// metricstest.CheckLastValueData only looks at one of the rows of the views
+// reporting several namespaces.
+func checkNamespaceValue(t *testing.T, name string, tags map[string]string, want float64) {
+	t.Helper()
+	rows, err := view.RetrieveData(name)
+	if err != nil {
+		t.Fatalf("RetrieveData(%s) = %v", name, err)
+	}
+	for _, row := range rows {
+		if !rowHasTags(row, tags) {
+			continue
+		}
+		if got := row.Data.(*view.LastValueData).Value; got != want {
+			t.Errorf("%s%v = %v, want %v", name, tags, got, want)
+		}
+		return
+	}
+	t.Errorf("%s%v not reported", name, tags)
+}
+
+func rowHasTags(row *view.Row, tags map[string]string) bool {
+	if len(row.Tags) != len(tags) {
+		return false
+	}
+	for _, tag := range row.Tags {
+		if tags[tag.Key.Name()] != tag.Value {
+			return false
+		}
+	}
+	return true
+}
+
+func newQuotaBroker(namespace, name string) *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func resetQuotaMetrics() {
+	// OpenCensus metrics carry global state that need to be reset between unit tests.
+	metricstest.Unregister("namespace_resource_count", "namespace_resource_quota")
+	registerQuotaViews()
+}
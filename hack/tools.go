@@ -36,4 +36,7 @@ import (
 	// API reference docs generation.
 	_ "github.com/ahmetb/gen-crd-api-reference-docs"
 	_ "github.com/ahmetb/gen-crd-api-reference-docs/template"
+
+	// Protobuf messages generation.
+	_ "google.golang.org/protobuf/cmd/protoc-gen-go"
 )
@@ -30,6 +30,10 @@ group "Generating checksums for configmap _example keys"
 
 ${REPO_ROOT_DIR}/hack/update-checksums.sh
 
+group "Protobuf Codegen"
+
+${REPO_ROOT_DIR}/hack/update-protos.sh
+
 group "Kubernetes Codegen"
 
 # generate the code with:
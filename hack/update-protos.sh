@@ -0,0 +1,43 @@
+#!/usr/bin/env bash
+
+# Copyright 2024 The Knative Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+export GO111MODULE=on
+
+if [ -z "${GOPATH:-}" ]; then
+  export GOPATH=$(go env GOPATH)
+fi
+
+source $(dirname $0)/../vendor/knative.dev/hack/library.sh
+
+# The CloudEvent messages are the ones of the CloudEvents SDK.
+CLOUDEVENTS_PROTO_DIR="${REPO_ROOT_DIR}/vendor/github.com/cloudevents/sdk-go/binding/format/protobuf/v2/pb"
+
+TMP_DIR="$(mktemp -d)"
+trap 'rm -rf "${TMP_DIR}"' EXIT
+
+go build -o "${TMP_DIR}/protoc-gen-go" "${REPO_ROOT_DIR}/vendor/google.golang.org/protobuf/cmd/protoc-gen-go"
+
+protoc \
+  --plugin=protoc-gen-go="${TMP_DIR}/protoc-gen-go" \
+  -I "${REPO_ROOT_DIR}/pkg" \
+  -I "${CLOUDEVENTS_PROTO_DIR}" \
+  --go_out="${REPO_ROOT_DIR}/pkg" \
+  --go_opt=paths=source_relative \
+  "${REPO_ROOT_DIR}/pkg/broker/ingress/pb/ingress.proto"
@@ -30,12 +30,17 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	kle "knative.dev/pkg/leaderelection"
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 	brokerfilter "knative.dev/eventing/pkg/broker/filter"
 	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+	"knative.dev/eventing/pkg/objectfilter"
 )
 
 type envConfig struct {
@@ -53,8 +58,28 @@ type apiServerAdapter struct {
 
 	discover discovery.DiscoveryInterface
 	k8s      dynamic.Interface
+	// metadata watches the resources metadata-only once the memory
+	// watermark is exceeded.
+	metadata metadata.Interface
 	source   string // TODO: who dis?
 	name     string // TODO: who dis?
+	// namespace is the namespace of the ApiServerSource.
+	namespace string
+
+	// leases and leaderElection are used to elect the replica sending the
+	// events when config.LeaseName is set.
+	leases         coordinationv1client.LeasesGetter
+	leaderElection *kle.ComponentConfig
+
+	// backoff, deadLetterSink and sink implement config.Delivery.
+	backoff        *backoff
+	deadLetterSink cloudevents.Client
+	sink           string
+
+	// monitoring sends the events about the source when config.Monitoring is
+	// set, monitor sends them while watching, nil otherwise.
+	monitoring cloudevents.Client
+	monitor    *monitor
 }
 
 func (a *apiServerAdapter) Start(ctx context.Context) error {
@@ -62,59 +87,130 @@ func (a *apiServerAdapter) Start(ctx context.Context) error {
 }
 
 func (a *apiServerAdapter) start(ctx context.Context, stopCh <-chan struct{}) error {
-	// Local stop channel.
-	stop := make(chan struct{})
+	srv := &http.Server{
+		Addr: ":8080",
+		// Configure read header timeout to overcome potential Slowloris Attack because ReadHeaderTimeout is not
+		// configured in the http.Server.
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	}
+	go srv.ListenAndServe()
+	defer srv.Shutdown(ctx)
+
+	if a.config.LeaseName == "" {
+		return a.watch(ctx, stopCh)
+	}
+	// The replicas standing by are ready, so that they take over as soon as
+	// they are elected.
+	return a.watchWhileLeading(ctx, stopCh)
+}
+
+// watch watches the resources and sends the events until stopCh is closed.
+func (a *apiServerAdapter) watch(ctx context.Context, stopCh <-chan struct{}) error {
+	// The reflectors are stopped along with the adapter.
+	watchCtx, stopWatches := context.WithCancel(ctx)
 
 	resyncPeriod := 10 * time.Hour
 
-	var delegate cache.Store = &resourceDelegate{
-		ce:                  a.ce,
-		source:              a.source,
-		logger:              a.logger,
-		ref:                 a.config.EventMode == v1.ReferenceMode,
-		apiServerSourceName: a.name,
-		filter:              subscriptionsapi.NewAllFilter(brokerfilter.MaterializeFiltersList(a.logger.Desugar(), a.config.Filters)...),
+	// The events identify the ApiServerSource, so that the EventTypes
+	// auto-created for them reference it.
+	sourceRef := &duckv1.KReference{
+		APIVersion: v1.SchemeGroupVersion.String(),
+		Kind:       "ApiServerSource",
+		Namespace:  a.namespace,
+		Name:       a.name,
+	}
+
+	if a.monitoring != nil {
+		a.monitor = newMonitor(a.monitoring, a.source, sourceRef, a.logger)
 	}
+
 	if a.config.ResourceOwner != nil {
 		a.logger.Infow("will be filtered",
 			zap.String("APIVersion", a.config.ResourceOwner.APIVersion),
 			zap.String("Kind", a.config.ResourceOwner.Kind))
-		delegate = &controllerFilter{
+	}
+
+	// The watches queue the events, so that they are sent by priority and
+	// the watches are held back while the sink is slow.
+	queue := newSendQueue(a.ce, a.config.QueuePriorities, a.logger)
+	queue.backoff = a.backoff
+	queue.deadLetterSink = a.deadLetterSink
+	queue.sink = a.sink
+	go queue.run(watchCtx)
+
+	// Each watch has its own delegate, so that in the ResourceDiff mode the
+	// resources it last saw are replaced along with its list.
+	newDelegate := func() cache.Store {
+		d := &resourceDelegate{
+			ce:                  queue,
+			source:              a.source,
+			logger:              a.logger,
+			ref:                 a.config.EventMode == v1.ReferenceMode,
+			protobuf:            a.config.EventMode == v1.ResourceMode && a.config.DataEncoding == v1.ProtobufDataEncoding,
+			apiServerSourceName: a.name,
+			sourceRef:           sourceRef,
+			filter:              subscriptionsapi.NewAllFilter(brokerfilter.MaterializeFiltersList(a.logger.Desugar(), a.config.Filters)...),
+		}
+		if a.config.EventMode == v1.ResourceDiffMode {
+			d.objects = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		}
+		if a.config.ResourceOwner == nil {
+			return d
+		}
+		return &controllerFilter{
 			apiVersion: a.config.ResourceOwner.APIVersion,
 			kind:       a.config.ResourceOwner.Kind,
-			delegate:   delegate,
+			delegate:   d,
 		}
 	}
 
 	a.logger.Infof("STARTING -- %#v", a.config)
+	a.monitor.started(watchCtx)
 
+	var watches []*resourceWatch
 	for _, configRes := range a.config.Resources {
 
 		resources, err := a.discover.ServerResourcesForGroupVersion(configRes.GVR.GroupVersion().String())
 		if err != nil {
+			stopWatches()
 			return fmt.Errorf("failed to retrieve information about resource %s: %v", configRes.GVR.String(), err)
 		}
 
+		var filter *objectfilter.Filter
+		if configRes.CELFilter != "" {
+			filter, err = objectfilter.Compile(configRes.CELFilter)
+			if err != nil {
+				stopWatches()
+				return fmt.Errorf("failed to compile the CEL filter of resource %s: %w", configRes.GVR.String(), err)
+			}
+		}
+
 		exists := false
 		for _, apires := range resources.APIResources {
 			if apires.Name == configRes.GVR.Resource {
-				var resources []dynamic.ResourceInterface
+				namespaces := []string{metav1.NamespaceAll}
 				if apires.Namespaced && !a.config.AllNamespaces {
-					for _, ns := range a.config.Namespaces {
-						resources = append(resources, a.k8s.Resource(configRes.GVR).Namespace(ns))
-					}
-				} else {
-					resources = append(resources, a.k8s.Resource(configRes.GVR))
+					namespaces = a.config.Namespaces
 				}
 
-				for _, res := range resources {
-					lw := &cache.ListWatch{
-						ListFunc:  asUnstructuredLister(ctx, res.List, configRes.LabelSelector),
-						WatchFunc: asUnstructuredWatcher(ctx, res.Watch, configRes.LabelSelector),
+				for _, ns := range namespaces {
+					delegate := newDelegate()
+					w := &resourceWatch{
+						gvr:           configRes.GVR,
+						kind:          apires.Kind,
+						namespace:     ns,
+						labelSelector: configRes.LabelSelector,
+						fieldSelector: configRes.FieldSelector,
+						delegate:      delegate,
 					}
-
-					reflector := cache.NewReflector(lw, &unstructured.Unstructured{}, delegate, resyncPeriod)
-					go reflector.Run(stop)
+					if filter != nil {
+						w.delegate = newCELFilter(filter, delegate, a.logger)
+					}
+					a.runWatch(watchCtx, w, resyncPeriod)
+					watches = append(watches, w)
 				}
 
 				exists = true
@@ -127,45 +223,57 @@ func (a *apiServerAdapter) start(ctx context.Context, stopCh <-chan struct{}) er
 		}
 	}
 
-	srv := &http.Server{
-		Addr: ":8080",
-		// Configure read header timeout to overcome potential Slowloris Attack because ReadHeaderTimeout is not
-		// configured in the http.Server.
-		ReadHeaderTimeout: 10 * time.Second,
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-		}),
+	if a.config.MemoryWatermark > 0 {
+		go a.watchMemory(watchCtx, watches, resyncPeriod)
 	}
-	go srv.ListenAndServe()
 
 	<-stopCh
-	stop <- struct{}{}
-	srv.Shutdown(ctx)
+	stopWatches()
 	return nil
 }
 
 type unstructuredLister func(context.Context, metav1.ListOptions) (*unstructured.UnstructuredList, error)
 
-func asUnstructuredLister(ctx context.Context, ulist unstructuredLister, selector string) cache.ListFunc {
+func asUnstructuredLister(ctx context.Context, ulist unstructuredLister, labelSelector, fieldSelector string, transform objectTransform) cache.ListFunc {
 	return func(opts metav1.ListOptions) (runtime.Object, error) {
-		if selector != "" && opts.LabelSelector == "" {
-			opts.LabelSelector = selector
+		if labelSelector != "" && opts.LabelSelector == "" {
+			opts.LabelSelector = labelSelector
+		}
+		if fieldSelector != "" && opts.FieldSelector == "" {
+			opts.FieldSelector = fieldSelector
 		}
 		ul, err := ulist(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
+		if transform != nil {
+			for i := range ul.Items {
+				transform(&ul.Items[i])
+			}
+		}
 		return ul, nil
 	}
 }
 
 type structuredWatcher func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 
-func asUnstructuredWatcher(ctx context.Context, wf structuredWatcher, selector string) cache.WatchFunc {
+func asUnstructuredWatcher(ctx context.Context, wf structuredWatcher, labelSelector, fieldSelector string, transform objectTransform) cache.WatchFunc {
 	return func(lo metav1.ListOptions) (watch.Interface, error) {
-		if selector != "" && lo.LabelSelector == "" {
-			lo.LabelSelector = selector
+		if labelSelector != "" && lo.LabelSelector == "" {
+			lo.LabelSelector = labelSelector
+		}
+		if fieldSelector != "" && lo.FieldSelector == "" {
+			lo.FieldSelector = fieldSelector
 		}
-		return wf(ctx, lo)
+		w, err := wf(ctx, lo)
+		if err != nil || transform == nil {
+			return w, err
+		}
+		return watch.Filter(w, func(e watch.Event) (watch.Event, bool) {
+			if u, ok := e.Object.(*unstructured.Unstructured); ok {
+				transform(u)
+			}
+			return e, true
+		}), nil
 	}
 }
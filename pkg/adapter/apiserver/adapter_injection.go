@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"knative.dev/eventing/pkg/adapter/v2"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -66,13 +68,55 @@ func NewAdapter(ctx context.Context, processed adapter.EnvConfigAccessor, ceClie
 		panic("failed to create config from json")
 	}
 
+	var metadataClient metadata.Interface
+	if config.MemoryWatermark > 0 {
+		metadataClient = metadata.NewForConfigOrDie(injection.GetConfig(ctx))
+	}
+
+	leaderElection, err := env.GetLeaderElectionConfig()
+	if err != nil {
+		logger.Warnw("Failed to load the leader election configuration, using the default one", zap.Error(err))
+	}
+
+	backoff, err := newBackoff(config.Delivery)
+	if err != nil {
+		logger.Warnw("Failed to configure the retries, the events won't be retried", zap.Error(err))
+	}
+
+	var deadLetterSink cloudevents.Client
+	if config.Delivery != nil && config.Delivery.DeadLetterSink != nil {
+		deadLetterSink, err = newAddressableClient(ctx, env, config.Delivery.DeadLetterSink)
+		if err != nil {
+			logger.Fatalw("Failed to create the dead letter sink client", zap.Error(err))
+		}
+	}
+
+	var monitoring cloudevents.Client
+	if config.Monitoring != nil && config.Monitoring.Sink != nil {
+		monitoring, err = newAddressableClient(ctx, env, config.Monitoring.Sink)
+		if err != nil {
+			logger.Fatalw("Failed to create the monitoring sink client", zap.Error(err))
+		}
+	}
+
 	return &apiServerAdapter{
-		discover: kubeclient.Get(ctx).Discovery(),
-		k8s:      dynamicclient.Get(ctx),
-		ce:       ceClient,
-		source:   Get(ctx),
-		name:     env.Name,
-		config:   config,
+		discover:  kubeclient.Get(ctx).Discovery(),
+		metadata:  metadataClient,
+		k8s:       dynamicclient.Get(ctx),
+		ce:        ceClient,
+		source:    Get(ctx),
+		name:      env.Name,
+		namespace: env.Namespace,
+		config:    config,
+
+		leases:         kubeclient.Get(ctx).CoordinationV1(),
+		leaderElection: leaderElection,
+
+		backoff:        backoff,
+		deadLetterSink: deadLetterSink,
+		sink:           env.GetSink(),
+
+		monitoring: monitoring,
 
 		logger: logger,
 	}
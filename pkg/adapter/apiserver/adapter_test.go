@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
 	discoveryfake "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/dynamic"
@@ -189,6 +190,37 @@ func TestAdapter_StartNonNamespacedResource(t *testing.T) {
 // Common methods:
 
 // GetDynamicClient returns the mockDynamicClient to use for this test case.
+func TestAsUnstructuredListWatch_Selectors(t *testing.T) {
+	var listed, watched metav1.ListOptions
+	listFunc := asUnstructuredLister(context.Background(), func(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+		listed = opts
+		return &unstructured.UnstructuredList{}, nil
+	}, "app=test", "status.phase=Running", nil)
+	watchFunc := asUnstructuredWatcher(context.Background(), func(_ context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		watched = opts
+		return watch.NewEmptyWatch(), nil
+	}, "app=test", "status.phase=Running", nil)
+
+	if _, err := listFunc(metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := watchFunc(metav1.ListOptions{ResourceVersion: "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []metav1.ListOptions{listed, watched} {
+		if opts.LabelSelector != "app=test" {
+			t.Errorf("Expected the label selector %q, got %q", "app=test", opts.LabelSelector)
+		}
+		if opts.FieldSelector != "status.phase=Running" {
+			t.Errorf("Expected the field selector %q, got %q", "status.phase=Running", opts.FieldSelector)
+		}
+	}
+	if watched.ResourceVersion != "1" {
+		t.Errorf("Expected the watch options to be kept, got %+v", watched)
+	}
+}
+
 func makeDynamicClient(objects ...runtime.Object) dynamic.Interface {
 	sc := runtime.NewScheme()
 	_ = corev1.AddToScheme(sc)
@@ -17,7 +17,9 @@ package apiserver
 
 import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 )
@@ -30,6 +32,17 @@ type ResourceWatch struct {
 	// label selector.
 	// +optional
 	LabelSelector string `json:"selector,omitempty"`
+
+	// FieldSelector filters this source to the objects matching the field
+	// selector.
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// CELFilter is a CEL expression evaluated against the previous and the
+	// current state of the objects, filtering out the events for which it
+	// evaluates to false.
+	// +optional
+	CELFilter string `json:"celFilter,omitempty"`
 }
 
 type Config struct {
@@ -59,6 +72,12 @@ type Config struct {
 	// +optional
 	EventMode string `json:"mode,omitempty"`
 
+	// DataEncoding controls the encoding of the resources sent by the
+	// `Resource` mode. `json` (default) or `protobuf`, in which case the
+	// resources having no protobuf encoding are still sent as JSON.
+	// +optional
+	DataEncoding string `json:"dataEncoding,omitempty"`
+
 	// Filters is an experimental field that conforms to the CNCF CloudEvents Subscriptions
 	// API. It's an array of filter expressions that evaluate to true or false.
 	// If any filter expression in the array evaluates to false, the event MUST
@@ -68,4 +87,77 @@ type Config struct {
 	//
 	// +optional
 	Filters []eventingv1.SubscriptionsAPIFilter `json:"filters,omitempty"`
+
+	// StripManagedFields removes the managed fields of the resources as soon
+	// as they are received, so that they aren't held in memory nor sent.
+	// +optional
+	StripManagedFields bool `json:"stripManagedFields,omitempty"`
+
+	// StripLastAppliedConfiguration removes the
+	// kubectl.kubernetes.io/last-applied-configuration annotation of the
+	// resources as soon as they are received, so that it isn't held in
+	// memory nor sent.
+	// +optional
+	StripLastAppliedConfiguration bool `json:"stripLastAppliedConfiguration,omitempty"`
+
+	// MemoryWatermark is the size of the heap, in bytes, beyond which the
+	// resources with the most objects are watched metadata-only, one at a
+	// time, until the heap shrinks below it. Disabled when 0.
+	// +optional
+	MemoryWatermark int64 `json:"memoryWatermark,omitempty"`
+
+	// LeaseName is the name of the Lease, in the namespace of the adapter,
+	// used to elect the replica watching the resources and sending events.
+	// The other replicas stand by to take over when the leader goes away.
+	// Leader election is disabled when empty.
+	// +optional
+	LeaseName string `json:"leaseName,omitempty"`
+
+	// QueuePriorities are the operations, Add, Update and Delete, by
+	// decreasing priority of their events in the queue of the events sent to
+	// the sink. The operations left out come last, in the default Delete,
+	// Add, Update order.
+	// +optional
+	QueuePriorities []string `json:"queuePriorities,omitempty"`
+
+	// Delivery configures the retries of the events sent to the sink and the
+	// dead letter sink receiving the events that still can't be sent.
+	// +optional
+	Delivery *DeliveryConfig `json:"delivery,omitempty"`
+
+	// Monitoring enables the events about the source itself, the adapter
+	// starting and the watches being established, dropped or denied by RBAC.
+	// +optional
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+}
+
+// DeliveryConfig is the delivery of an ApiServerSource, with its dead letter
+// sink resolved.
+type DeliveryConfig struct {
+	// Retry is the number of retries of an event before it is sent to the
+	// dead letter sink.
+	// +optional
+	Retry int32 `json:"retry,omitempty"`
+
+	// BackoffPolicy is the retry backoff policy (linear, exponential), the
+	// retries are sent every BackoffDelay when not set.
+	// +optional
+	BackoffPolicy eventingduckv1.BackoffPolicyType `json:"backoffPolicy,omitempty"`
+
+	// BackoffDelay is the ISO 8601 duration used to compute the delay
+	// before a retry.
+	// +optional
+	BackoffDelay string `json:"backoffDelay,omitempty"`
+
+	// DeadLetterSink is the resolved address of the dead letter sink.
+	// +optional
+	DeadLetterSink *duckv1.Addressable `json:"deadLetterSink,omitempty"`
+}
+
+// MonitoringConfig is the monitoring of an ApiServerSource, with its sink
+// resolved.
+type MonitoringConfig struct {
+	// Sink is the resolved address receiving the events about the source.
+	// +required
+	Sink *duckv1.Addressable `json:"sink"`
 }
@@ -18,21 +18,34 @@ package apiserver
 
 import (
 	"context"
+	"errors"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
 	"knative.dev/eventing/pkg/adapter/apiserver/events"
 	"knative.dev/eventing/pkg/eventfilter"
+	"knative.dev/eventing/pkg/eventtype"
 )
 
 type resourceDelegate struct {
-	ce                  cloudevents.Client
-	source              string
-	ref                 bool
+	// ce sends the events, the adapter's send queue.
+	ce     eventSender
+	source string
+	ref    bool
+	// protobuf sends the resources in their protobuf encoding.
+	protobuf bool
+	// objects holds the last seen resources in the ResourceDiff mode, so that
+	// the updates carry a JSON patch from them, nil in the other modes.
+	objects             cache.Store
 	apiServerSourceName string
-	filter              eventfilter.Filter
+	// sourceRef is the ApiServerSource set as the source extensions of the
+	// events, if any.
+	sourceRef *duckv1.KReference
+	filter    eventfilter.Filter
 
 	logger *zap.SugaredLogger
 }
@@ -40,14 +53,41 @@ type resourceDelegate struct {
 var _ cache.Store = (*resourceDelegate)(nil)
 
 func (a *resourceDelegate) Add(obj interface{}) error {
+	if a.objects != nil {
+		if err := a.objects.Add(obj); err != nil {
+			return err
+		}
+	}
 	return a.handleKubernetesObject(events.MakeAddEvent, obj)
 }
 
 func (a *resourceDelegate) Update(obj interface{}) error {
-	return a.handleKubernetesObject(events.MakeUpdateEvent, obj)
+	if a.objects == nil {
+		return a.handleKubernetesObject(events.MakeUpdateEvent, obj)
+	}
+
+	oldObj, exists, err := a.objects.Get(obj)
+	if err != nil {
+		return err
+	}
+	if err := a.objects.Update(obj); err != nil {
+		return err
+	}
+	// The full resource is sent when the previous one wasn't seen.
+	if !exists {
+		return a.handleKubernetesObject(events.MakeUpdateEvent, obj)
+	}
+	return a.handleKubernetesObject(func(source, apiServerSourceName string, obj interface{}, _ bool) (context.Context, cloudevents.Event, error) {
+		return events.MakePatchEvent(source, apiServerSourceName, oldObj, obj)
+	}, obj)
 }
 
 func (a *resourceDelegate) Delete(obj interface{}) error {
+	if a.objects != nil {
+		if err := a.objects.Delete(obj); err != nil {
+			return err
+		}
+	}
 	return a.handleKubernetesObject(events.MakeDeleteEvent, obj)
 
 }
@@ -64,17 +104,30 @@ func (a *resourceDelegate) handleKubernetesObject(makeEvent makeEventFunc, obj i
 		return err
 	}
 
+	if a.sourceRef != nil {
+		eventtype.SetSourceExtensions(&event, a.sourceRef)
+	}
+
 	filterResult := a.filter.Filter(ctx, event)
 	if filterResult == eventfilter.FailFilter {
 		a.logger.Debugf("event type %s filtered out", event.Type())
 		return nil
 	}
 
+	// The resource is only re-encoded once the event passed the filters, so
+	// that the filtered out events aren't encoded twice.
+	if a.protobuf {
+		if err := events.SetProtobufData(&event, obj); err != nil && !errors.Is(err, events.ErrNoProtobufEncoding) {
+			a.logger.Infow("protobuf encoding failed", zap.Error(err))
+			return err
+		}
+	}
+
 	a.sendCloudEvent(ctx, event)
 	return nil
 }
 
-// sendCloudEvent sends a cloudevent everytime k8s api event is created, updated or deleted.
+// sendCloudEvent queues a cloudevent everytime k8s api event is created, updated or deleted.
 func (a *resourceDelegate) sendCloudEvent(ctx context.Context, event cloudevents.Event) {
 	event.SetID(uuid.New().String()) // provide an ID here so we can track it with logging
 	defer a.logger.Debug("Finished sending cloudevent id: ", event.ID())
@@ -82,12 +135,13 @@ func (a *resourceDelegate) sendCloudEvent(ctx context.Context, event cloudevents
 	subject := event.Context.GetSubject()
 	a.logger.Debugf("sending cloudevent id: %s, source: %s, subject: %s", event.ID(), source, subject)
 
-	if result := a.ce.Send(ctx, event); !cloudevents.IsACK(result) {
-		a.logger.Errorw("failed to send cloudevent", zap.Error(result), zap.String("source", source),
-			zap.String("subject", subject), zap.String("id", event.ID()))
-	} else {
-		a.logger.Debugf("cloudevent sent id: %s, source: %s, subject: %s", event.ID(), source, subject)
+	result := a.ce.Send(ctx, event)
+	if cloudevents.IsACK(result) {
+		a.logger.Debugf("cloudevent queued id: %s, source: %s, subject: %s", event.ID(), source, subject)
+		return
 	}
+	a.logger.Errorw("failed to queue cloudevent", zap.Error(result), zap.String("source", source),
+		zap.String("subject", subject), zap.String("id", event.ID()))
 }
 
 // Stub cache.Store impl
@@ -113,7 +167,10 @@ func (a *resourceDelegate) GetByKey(key string) (item interface{}, exists bool,
 }
 
 // Implements cache.Store
-func (a *resourceDelegate) Replace([]interface{}, string) error {
+func (a *resourceDelegate) Replace(list []interface{}, resourceVersion string) error {
+	if a.objects != nil {
+		return a.objects.Replace(list, resourceVersion)
+	}
 	return nil
 }
 
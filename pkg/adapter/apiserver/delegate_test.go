@@ -18,12 +18,20 @@ package apiserver
 import (
 	"testing"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/adapter/apiserver/events"
 	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/sources"
 	brokerfilter "knative.dev/eventing/pkg/broker/filter"
 	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+	"knative.dev/eventing/pkg/eventtype"
 )
 
 func TestResourceAddEvent(t *testing.T) {
@@ -44,6 +52,38 @@ func TestResourceDeleteEvent(t *testing.T) {
 	validateSent(t, ce, sources.ApiServerSourceDeleteEventType)
 }
 
+func TestResourceDiffUpdateEvent(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	d.objects = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+	// The full resource is sent when the previous one wasn't seen.
+	d.Update(simplePod("unit", "test"))
+	validateSent(t, ce, sources.ApiServerSourceUpdateEventType)
+
+	ce.Reset()
+	pod := simplePod("unit", "test")
+	pod.SetLabels(map[string]string{"app": "unit"})
+	d.Update(pod)
+	validateSent(t, ce, sources.ApiServerSourcePatchEventType)
+	want := `[{"op":"add","path":"/metadata/labels","value":{"app":"unit"}}]`
+	if got := string(ce.Sent()[0].Data()); got != want {
+		t.Errorf("Expected the patch %s, got %s", want, got)
+	}
+
+	// The relists replace the resources last seen.
+	ce.Reset()
+	d.Replace([]interface{}{simplePod("unit", "test")}, "")
+	d.Update(pod)
+	validateSent(t, ce, sources.ApiServerSourcePatchEventType)
+
+	ce.Reset()
+	d.Delete(pod)
+	validateSent(t, ce, sources.ApiServerSourceDeleteEventType)
+	if keys := d.objects.ListKeys(); len(keys) != 0 {
+		t.Error("Expected the deleted resource to be forgotten, got:", keys)
+	}
+}
+
 func TestResourceAddEventNil(t *testing.T) {
 	d, ce := makeResourceAndTestingClient()
 	d.Add(nil)
@@ -110,3 +150,51 @@ func TestEmptyFiltersList(t *testing.T) {
 	delegate.Update(simplePod("unit", "test"))
 	validateSent(t, ce, sources.ApiServerSourceUpdateEventType)
 }
+
+func TestResourceAddEventProtobuf(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	d.protobuf = true
+	d.Add(simplePod("unit", "test"))
+	validateSent(t, ce, sources.ApiServerSourceAddEventType)
+
+	if got := ce.Sent()[0].DataContentType(); got != events.ProtobufContentType {
+		t.Errorf("Expected %q data content type, got %q", events.ProtobufContentType, got)
+	}
+}
+
+func TestResourceAddEventProtobufCustomResource(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	d.protobuf = true
+	d.Add(&unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "sources.knative.dev/v1",
+			"kind":       "PingSource",
+			"metadata": map[string]interface{}{
+				"namespace": "test",
+				"name":      "unit",
+			},
+		},
+	})
+	validateSent(t, ce, sources.ApiServerSourceAddEventType)
+
+	if got := ce.Sent()[0].DataContentType(); got != cloudevents.ApplicationJSON {
+		t.Errorf("Expected %q data content type, got %q", cloudevents.ApplicationJSON, got)
+	}
+}
+
+func TestResourceAddEventSourceExtensions(t *testing.T) {
+	d, ce := makeResourceAndTestingClient()
+	d.sourceRef = &duckv1.KReference{
+		APIVersion: "sources.knative.dev/v1",
+		Kind:       "ApiServerSource",
+		Namespace:  "test",
+		Name:       apiServerSourceNameTest,
+	}
+	d.Add(simplePod("unit", "test"))
+	validateSent(t, ce, sources.ApiServerSourceAddEventType)
+
+	got := ce.Sent()[0]
+	if diff := cmp.Diff(d.sourceRef, eventtype.SourceReference(&got)); diff != "" {
+		t.Error("Unexpected source reference (-want, +got):", diff)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/rickb777/date/period"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/adapter/v2"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents/attributes"
+)
+
+// backoff is the retry policy of the delivery, the send queue requeues the
+// events that couldn't be sent after the delay of their retry.
+type backoff struct {
+	retries int
+	policy  eventingduckv1.BackoffPolicyType
+	delay   time.Duration
+}
+
+// newBackoff returns the backoff of the delivery, nil when the events aren't
+// retried.
+func newBackoff(delivery *DeliveryConfig) (*backoff, error) {
+	if delivery == nil || delivery.Retry <= 0 {
+		return nil, nil
+	}
+
+	b := &backoff{
+		retries: int(delivery.Retry),
+		policy:  delivery.BackoffPolicy,
+	}
+	if delivery.BackoffDelay != "" {
+		p, err := period.Parse(delivery.BackoffDelay)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the backoff delay %q: %w", delivery.BackoffDelay, err)
+		}
+		b.delay, _ = p.Duration()
+	}
+	return b, nil
+}
+
+// duration returns the delay before the retry, starting at 1.
+func (b *backoff) duration(retry int) time.Duration {
+	switch b.policy {
+	case eventingduckv1.BackoffPolicyExponential:
+		return b.delay * time.Duration(math.Exp2(float64(retry)))
+	case eventingduckv1.BackoffPolicyLinear:
+		return b.delay * time.Duration(retry)
+	default:
+		return b.delay
+	}
+}
+
+// addressableEnv overrides the sink of the adapter configuration with
+// another addressable, such as the dead letter sink, so that a client sending
+// events to it can be built with the same configuration.
+type addressableEnv struct {
+	adapter.EnvConfigAccessor
+	addr *duckv1.Addressable
+}
+
+func (e *addressableEnv) GetSink() string {
+	return e.addr.URL.String()
+}
+
+func (e *addressableEnv) GetCACerts() *string {
+	return e.addr.CACerts
+}
+
+func (e *addressableEnv) GetAudience() *string {
+	return e.addr.Audience
+}
+
+// newAddressableClient returns a client sending events to the addressable,
+// configured like the client sending events to the sink.
+func newAddressableClient(ctx context.Context, env adapter.EnvConfigAccessor, addr *duckv1.Addressable) (cloudevents.Client, error) {
+	cfg := adapter.GetClientConfig(ctx)
+	if cfg.Env != nil {
+		env = cfg.Env
+	}
+	cfg.Env = &addressableEnv{EnvConfigAccessor: env, addr: addr}
+	return adapter.NewClient(cfg)
+}
+
+// setKnativeErrorExtensions sets the Knative error extensions of an event
+// that couldn't be sent to the sink before it's sent to the dead letter sink.
+func setKnativeErrorExtensions(event *cloudevents.Event, sink string, result error) {
+	if sink != "" {
+		event.SetExtension(attributes.KnativeErrorDestExtensionKey, sink)
+	}
+	var rres *cehttp.RetriesResult
+	if cloudevents.ResultAs(result, &rres) {
+		result = rres.Result
+	}
+	var res *cehttp.Result
+	if cloudevents.ResultAs(result, &res) {
+		event.SetExtension(attributes.KnativeErrorCodeExtensionKey, res.StatusCode)
+	}
+	if result != nil {
+		data := result.Error()
+		if len(data) > attributes.KnativeErrorDataExtensionMaxLength {
+			data = data[:attributes.KnativeErrorDataExtensionMaxLength]
+		}
+		event.SetExtension(attributes.KnativeErrorDataExtensionKey, data)
+	}
+}
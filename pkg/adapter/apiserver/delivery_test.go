@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/kncloudevents/attributes"
+)
+
+// failingClient rejects every event.
+type failingClient struct {
+	*adaptertest.TestCloudEventsClient
+}
+
+func (c *failingClient) Send(ctx context.Context, out cloudevents.Event) protocol.Result {
+	c.TestCloudEventsClient.Send(ctx, out)
+	return cehttp.NewResult(503, "%w", protocol.ResultNACK)
+}
+
+func TestNewBackoff(t *testing.T) {
+	exponential := eventingduckv1.BackoffPolicyExponential
+	linear := eventingduckv1.BackoffPolicyLinear
+
+	tests := map[string]struct {
+		delivery *DeliveryConfig
+		want     *backoff
+		// wantDelays are the delays of the first retries.
+		wantDelays []time.Duration
+		wantErr    bool
+	}{
+		"no delivery": {},
+		"no retry": {
+			delivery: &DeliveryConfig{BackoffPolicy: exponential, BackoffDelay: "PT1S"},
+		},
+		"exponential": {
+			delivery:   &DeliveryConfig{Retry: 3, BackoffPolicy: exponential, BackoffDelay: "PT1S"},
+			want:       &backoff{retries: 3, policy: exponential, delay: time.Second},
+			wantDelays: []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		"linear": {
+			delivery:   &DeliveryConfig{Retry: 2, BackoffPolicy: linear, BackoffDelay: "PT0.5S"},
+			want:       &backoff{retries: 2, policy: linear, delay: 500 * time.Millisecond},
+			wantDelays: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		"no policy": {
+			delivery:   &DeliveryConfig{Retry: 1, BackoffDelay: "PT1S"},
+			want:       &backoff{retries: 1, delay: time.Second},
+			wantDelays: []time.Duration{time.Second, time.Second},
+		},
+		"invalid delay": {
+			delivery: &DeliveryConfig{Retry: 1, BackoffDelay: "1s"},
+			wantErr:  true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := newBackoff(tc.delivery)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("newBackoff() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(backoff{})); diff != "" {
+				t.Error("Unexpected backoff (-want, +got) =", diff)
+			}
+			for i, want := range tc.wantDelays {
+				if got := got.duration(i + 1); got != want {
+					t.Errorf("duration(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceEventToDeadLetterSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := &failingClient{TestCloudEventsClient: adaptertest.NewTestClient()}
+	dls := adaptertest.NewTestClient()
+	q := newSendQueue(sink, nil, zap.NewExample().Sugar())
+	q.deadLetterSink = dls
+	q.sink = "http://sink.example.com"
+	q.backoff, _ = newBackoff(&DeliveryConfig{Retry: 2, BackoffPolicy: eventingduckv1.BackoffPolicyExponential, BackoffDelay: "PT0.01S"})
+	go q.run(ctx)
+
+	d, _ := makeResourceAndTestingClient()
+	d.ce = q
+	d.Add(simplePod("unit", "test"))
+
+	waitForSent(t, dls, 1)
+	if got := len(sink.Sent()); got != 3 {
+		t.Errorf("Expected the event to be sent 3 times to the sink, got %d", got)
+	}
+	ext := dls.Sent()[0].Extensions()
+	if ext[attributes.KnativeErrorDestExtensionKey] != "http://sink.example.com" {
+		t.Errorf("Unexpected %s extension %v", attributes.KnativeErrorDestExtensionKey, ext[attributes.KnativeErrorDestExtensionKey])
+	}
+	if ext[attributes.KnativeErrorCodeExtensionKey] != int32(503) {
+		t.Errorf("Unexpected %s extension %v", attributes.KnativeErrorCodeExtensionKey, ext[attributes.KnativeErrorCodeExtensionKey])
+	}
+}
+
+func TestResourceEventWithoutDeadLetterSink(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sink := &failingClient{TestCloudEventsClient: adaptertest.NewTestClient()}
+	q := newSendQueue(sink, nil, zap.NewExample().Sugar())
+	go q.run(ctx)
+
+	d, _ := makeResourceAndTestingClient()
+	d.ce = q
+	d.Add(simplePod("unit", "test"))
+
+	waitForSent(t, sink.TestCloudEventsClient, 1)
+}
@@ -135,6 +135,81 @@ func TestMakeUpdateEvent(t *testing.T) {
 	}
 }
 
+func TestMakePatchEvent(t *testing.T) {
+	patchContentType := "application/json-patch+json"
+	labeledPod := simplePod("unit", "test")
+	labeledPod.SetLabels(map[string]string{"app": "unit"})
+
+	testCases := map[string]struct {
+		oldObj interface{}
+		obj    interface{}
+		source string
+
+		want     *cloudevents.Event
+		wantData string
+		wantErr  string
+	}{
+		"nil object": {
+			source:  "unit-test",
+			oldObj:  simplePod("unit", "test"),
+			want:    nil,
+			wantErr: "resource can not be nil",
+		},
+		"nil old object": {
+			source:  "unit-test",
+			obj:     simplePod("unit", "test"),
+			want:    nil,
+			wantErr: "resource can not be nil",
+		},
+		"labeled pod": {
+			source: "unit-test",
+			oldObj: simplePod("unit", "test"),
+			obj:    labeledPod,
+			want: &cloudevents.Event{
+				Context: cloudevents.EventContextV1{
+					Type:            "dev.knative.apiserver.resource.patch",
+					Source:          *cloudevents.ParseURIRef("unit-test"),
+					Subject:         simpleSubject("unit", "test"),
+					DataContentType: &patchContentType,
+					Extensions: map[string]interface{}{
+						"apiversion": "v1",
+						"kind":       "Pod",
+						"name":       "unit",
+						"namespace":  "test",
+					},
+				}.AsV1(),
+			},
+			wantData: `[{"op":"add","path":"/metadata/labels","value":{"app":"unit"}}]`,
+		},
+		"unchanged pod": {
+			source: "unit-test",
+			oldObj: simplePod("unit", "test"),
+			obj:    simplePod("unit", "test"),
+			want: &cloudevents.Event{
+				Context: cloudevents.EventContextV1{
+					Type:            "dev.knative.apiserver.resource.patch",
+					Source:          *cloudevents.ParseURIRef("unit-test"),
+					Subject:         simpleSubject("unit", "test"),
+					DataContentType: &patchContentType,
+					Extensions: map[string]interface{}{
+						"apiversion": "v1",
+						"kind":       "Pod",
+						"name":       "unit",
+						"namespace":  "test",
+					},
+				}.AsV1(),
+			},
+			wantData: `[]`,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			_, got, err := events.MakePatchEvent(tc.source, apiServerSourceNameTest, tc.oldObj, tc.obj)
+			validate(t, got, err, tc.want, tc.wantData, tc.wantErr)
+		})
+	}
+}
+
 func TestMakeDeleteEvent(t *testing.T) {
 	testCases := map[string]struct {
 		obj    interface{}
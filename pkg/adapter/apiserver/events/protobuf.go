@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// ProtobufContentType is the data content type of the events carrying the
+// protobuf encoding of a Kubernetes object.
+const ProtobufContentType = runtime.ContentTypeProtobuf
+
+// ErrNoProtobufEncoding is returned for the objects having no protobuf
+// encoding, such as custom resources, which are sent as JSON instead.
+var ErrNoProtobufEncoding = errors.New("object has no protobuf encoding")
+
+var protobufSerializer = protobuf.NewSerializer(scheme.Scheme, scheme.Scheme)
+
+// SetProtobufData replaces the data of the event with the protobuf encoding
+// of obj, as served by the Kubernetes API server for the
+// application/vnd.kubernetes.protobuf media type. It returns
+// ErrNoProtobufEncoding, leaving the event untouched, when the type of obj
+// has no protobuf encoding.
+func SetProtobufData(event *cloudevents.Event, obj interface{}) error {
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	typed, err := scheme.Scheme.New(object.GroupVersionKind())
+	if runtime.IsNotRegisteredError(err) {
+		return ErrNoProtobufEncoding
+	} else if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, typed); err != nil {
+		return fmt.Errorf("failed to convert %s: %w", object.GroupVersionKind(), err)
+	}
+
+	var buf bytes.Buffer
+	if err := protobufSerializer.Encode(typed, &buf); err != nil {
+		if protobuf.IsNotMarshalable(err) {
+			return ErrNoProtobufEncoding
+		}
+		return err
+	}
+	return event.SetData(ProtobufContentType, buf.Bytes())
+}
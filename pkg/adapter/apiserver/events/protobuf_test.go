@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events_test
+
+import (
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"knative.dev/eventing/pkg/adapter/apiserver/events"
+)
+
+func TestSetProtobufData(t *testing.T) {
+	t.Run("built-in type", func(t *testing.T) {
+		_, event, err := events.MakeAddEvent("unit-test", apiServerSourceNameTest, simplePod("unit", "test"), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := events.SetProtobufData(&event, simplePod("unit", "test")); err != nil {
+			t.Fatal("SetProtobufData() =", err)
+		}
+		if got := event.DataContentType(); got != events.ProtobufContentType {
+			t.Errorf("DataContentType() = %q, want %q", got, events.ProtobufContentType)
+		}
+
+		obj, _, err := protobuf.NewSerializer(scheme.Scheme, scheme.Scheme).Decode(event.Data(), nil, nil)
+		if err != nil {
+			t.Fatal("failed to decode the data:", err)
+		}
+		pod, ok := obj.(*corev1.Pod)
+		if !ok {
+			t.Fatalf("decoded %T, want *corev1.Pod", obj)
+		}
+		if pod.Namespace != "test" || pod.Name != "unit" {
+			t.Errorf("decoded pod %s/%s, want test/unit", pod.Namespace, pod.Name)
+		}
+	})
+
+	t.Run("custom resource", func(t *testing.T) {
+		obj := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "sources.knative.dev/v1",
+				"kind":       "PingSource",
+				"metadata": map[string]interface{}{
+					"namespace": "test",
+					"name":      "unit",
+				},
+			},
+		}
+		_, event, err := events.MakeAddEvent("unit-test", apiServerSourceNameTest, obj, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := events.SetProtobufData(&event, obj); !errors.Is(err, events.ErrNoProtobufEncoding) {
+			t.Fatalf("SetProtobufData() = %v, want %v", err, events.ErrNoProtobufEncoding)
+		}
+		if got := event.DataContentType(); got != cloudevents.ApplicationJSON {
+			t.Errorf("DataContentType() = %q, want %q", got, cloudevents.ApplicationJSON)
+		}
+	})
+
+	t.Run("unexpected type", func(t *testing.T) {
+		event := cloudevents.NewEvent()
+		if err := events.SetProtobufData(&event, "not an object"); err == nil {
+			t.Error("SetProtobufData() = nil, want an error")
+		}
+	})
+}
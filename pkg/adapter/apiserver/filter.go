@@ -17,9 +17,14 @@ limitations under the License.
 package apiserver
 
 import (
+	"sync"
+
+	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/eventing/pkg/objectfilter"
 )
 
 // controllerFilter by apiVersion and/or kind
@@ -95,3 +100,122 @@ func (c *controllerFilter) Replace([]interface{}, string) error {
 func (c *controllerFilter) Resync() error {
 	return nil
 }
+
+// celFilter filters the changes of the objects with a CEL expression
+// evaluated against their previous and current state. The last state of
+// each object is kept, so that it can be compared to the next one.
+type celFilter struct {
+	filter   *objectfilter.Filter
+	delegate cache.Store
+	logger   *zap.SugaredLogger
+
+	mu      sync.Mutex
+	objects map[string]*unstructured.Unstructured
+}
+
+var _ cache.Store = (*celFilter)(nil)
+
+func newCELFilter(filter *objectfilter.Filter, delegate cache.Store, logger *zap.SugaredLogger) *celFilter {
+	return &celFilter{
+		filter:   filter,
+		delegate: delegate,
+		logger:   logger,
+		objects:  make(map[string]*unstructured.Unstructured),
+	}
+}
+
+// Implements Store
+
+func (c *celFilter) Add(obj interface{}) error {
+	if !c.matches(obj, false) {
+		return nil
+	}
+	return c.delegate.Add(obj)
+}
+
+func (c *celFilter) Update(obj interface{}) error {
+	if !c.matches(obj, false) {
+		return nil
+	}
+	return c.delegate.Update(obj)
+}
+
+func (c *celFilter) Delete(obj interface{}) error {
+	if !c.matches(obj, true) {
+		return nil
+	}
+	return c.delegate.Delete(obj)
+}
+
+// Implements cache.Store
+func (c *celFilter) Replace(list []interface{}, resourceVersion string) error {
+	c.mu.Lock()
+	c.objects = make(map[string]*unstructured.Unstructured, len(list))
+	for _, obj := range list {
+		if key, err := cache.MetaNamespaceKeyFunc(obj); err == nil {
+			c.objects[key] = obj.(*unstructured.Unstructured)
+		}
+	}
+	c.mu.Unlock()
+	return c.delegate.Replace(list, resourceVersion)
+}
+
+// matches records the new state of the object and evaluates the expression
+// against its previous one. The changes for which the expression fails to
+// evaluate are filtered out.
+func (c *celFilter) matches(obj interface{}, deleted bool) bool {
+	u := obj.(*unstructured.Unstructured)
+	key, err := cache.MetaNamespaceKeyFunc(u)
+	if err != nil {
+		return false
+	}
+
+	var oldObj, newObj *unstructured.Unstructured
+	c.mu.Lock()
+	oldObj = c.objects[key]
+	if deleted {
+		delete(c.objects, key)
+		if oldObj == nil {
+			oldObj = u
+		}
+	} else {
+		c.objects[key] = u
+		newObj = u
+	}
+	c.mu.Unlock()
+
+	matches, err := c.filter.Matches(oldObj, newObj)
+	if err != nil {
+		c.logger.Debugw("CEL filter failed to evaluate", zap.String("object", key), zap.Stringer("filter", c.filter), zap.Error(err))
+		return false
+	}
+	return matches
+}
+
+// List, ListKeys, Get, GetByKey and Resync are delegated, the objects kept
+// by the filter only being used to evaluate the expression.
+
+// Implements cache.Store
+func (c *celFilter) List() []interface{} {
+	return c.delegate.List()
+}
+
+// Implements cache.Store
+func (c *celFilter) ListKeys() []string {
+	return c.delegate.ListKeys()
+}
+
+// Implements cache.Store
+func (c *celFilter) Get(obj interface{}) (item interface{}, exists bool, err error) {
+	return c.delegate.Get(obj)
+}
+
+// Implements cache.Store
+func (c *celFilter) GetByKey(key string) (item interface{}, exists bool, err error) {
+	return c.delegate.GetByKey(key)
+}
+
+// Implements cache.Store
+func (c *celFilter) Resync() error {
+	return c.delegate.Resync()
+}
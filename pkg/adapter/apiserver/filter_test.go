@@ -19,8 +19,12 @@ package apiserver
 import (
 	"testing"
 
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
 	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
 	sources "knative.dev/eventing/pkg/apis/sources"
+	"knative.dev/eventing/pkg/objectfilter"
 )
 
 func TestControllerAddEventWithNoController(t *testing.T) {
@@ -103,3 +107,58 @@ func makeController(apiVersion, kind string) (*controllerFilter, *adaptertest.Te
 		delegate:   delegate,
 	}, tc
 }
+
+func TestCELFilterPhaseChanged(t *testing.T) {
+	c, tc := makeCELFilter(t, "old != null && new != null && old.status.phase != new.status.phase")
+
+	c.Add(podInPhase("Pending"))
+	validateNotSent(t, tc, sources.ApiServerSourceAddRefEventType)
+
+	c.Update(podInPhase("Pending"))
+	validateNotSent(t, tc, sources.ApiServerSourceUpdateRefEventType)
+
+	c.Update(podInPhase("Running"))
+	validateSent(t, tc, sources.ApiServerSourceUpdateRefEventType)
+}
+
+func TestCELFilterReplace(t *testing.T) {
+	c, tc := makeCELFilter(t, "old != null && new != null && old.status.phase != new.status.phase")
+
+	c.Replace([]interface{}{podInPhase("Pending")}, "1")
+	c.Update(podInPhase("Running"))
+	validateSent(t, tc, sources.ApiServerSourceUpdateRefEventType)
+}
+
+func TestCELFilterDelete(t *testing.T) {
+	c, tc := makeCELFilter(t, "new == null && old.status.phase == 'Running'")
+
+	c.Add(podInPhase("Pending"))
+	c.Update(podInPhase("Running"))
+	validateNotSent(t, tc, sources.ApiServerSourceUpdateRefEventType)
+
+	c.Delete(podInPhase("Succeeded"))
+	validateSent(t, tc, sources.ApiServerSourceDeleteRefEventType)
+}
+
+func TestCELFilterEvaluationError(t *testing.T) {
+	c, tc := makeCELFilter(t, "old.status.phase != new.status.phase")
+
+	c.Add(podInPhase("Pending"))
+	validateNotSent(t, tc, sources.ApiServerSourceAddRefEventType)
+}
+
+func makeCELFilter(t *testing.T, expression string) (*celFilter, *adaptertest.TestCloudEventsClient) {
+	t.Helper()
+	filter, err := objectfilter.Compile(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegate, tc := makeRefAndTestingClient()
+	return newCELFilter(filter, delegate, zap.NewExample().Sugar()), tc
+}
+
+func podInPhase(phase string) *unstructured.Unstructured {
+	pod := simplePod("unit", "test")
+	pod.Object["status"] = map[string]interface{}{"phase": phase}
+	return pod
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	kle "knative.dev/pkg/leaderelection"
+)
+
+// watchWhileLeading campaigns for the Lease config.LeaseName until stopCh is
+// closed, and watches the resources while this replica is the leader. When
+// the leadership is lost, the watches are stopped and the replica stands by
+// until it is elected again. The Lease is released on shutdown, so that a
+// replica standing by takes over without waiting for it to expire.
+func (a *apiServerAdapter) watchWhileLeading(ctx context.Context, stopCh <-chan struct{}) error {
+	id, err := kle.UniqueID()
+	if err != nil {
+		return fmt.Errorf("failed to build the leader election identity: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// errCh holds the error that made the leader give up.
+	errCh := make(chan error, 1)
+
+	le, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Namespace: a.namespace,
+				Name:      a.config.LeaseName,
+			},
+			Client:     a.leases,
+			LockConfig: resourcelock.ResourceLockConfig{Identity: id},
+		},
+		LeaseDuration:   a.leaderElection.LeaseDuration,
+		RenewDeadline:   a.leaderElection.RenewDeadline,
+		RetryPeriod:     a.leaderElection.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            a.config.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				a.logger.Infow("Started leading", "lease", a.config.LeaseName, "identity", id)
+				if err := a.watch(leaderCtx, leaderCtx.Done()); err != nil {
+					select {
+					case errCh <- err:
+					default:
+					}
+					cancel()
+				}
+			},
+			OnStoppedLeading: func() {
+				a.logger.Infow("Stopped leading", "lease", a.config.LeaseName, "identity", id)
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create the leader elector: %w", err)
+	}
+
+	// Run returns when the leadership is lost, campaign again until the
+	// adapter is stopped.
+	for ctx.Err() == nil {
+		le.Run(ctx)
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return nil
+	}
+}
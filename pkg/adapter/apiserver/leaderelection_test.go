@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	kle "knative.dev/pkg/leaderelection"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+	pkgtesting "knative.dev/pkg/reconciler/testing"
+
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+)
+
+const testLeaseName = "apiserversource-unittest"
+
+// makeLeaderElectionAdapter returns an adapter electing its leader with the
+// Lease testLeaseName, and the discovery client recording the resources it
+// looks up before watching them.
+func makeLeaderElectionAdapter(t *testing.T, kc *fake.Clientset) (*apiServerAdapter, *discoveryfake.FakeDiscovery) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	discover := makeDiscoveryClient().(*discoveryfake.FakeDiscovery)
+	return &apiServerAdapter{
+		ce:     adaptertest.NewTestClient(),
+		logger: logging.FromContext(ctx),
+		config: Config{
+			Namespaces: []string{"default"},
+			Resources: []ResourceWatch{{
+				GVR: schema.GroupVersionResource{
+					Version:  "v1",
+					Resource: "pods",
+				},
+			}},
+			EventMode: "Resource",
+			LeaseName: testLeaseName,
+		},
+
+		discover:  discover,
+		k8s:       makeDynamicClient(simplePod("foo", "default")),
+		source:    "unit-test",
+		name:      "unittest",
+		namespace: "default",
+
+		leases: kc.CoordinationV1(),
+		leaderElection: &kle.ComponentConfig{
+			LeaseDuration: 2 * time.Second,
+			RenewDeadline: time.Second,
+			RetryPeriod:   100 * time.Millisecond,
+		},
+	}, discover
+}
+
+func TestAdapter_StartLeading(t *testing.T) {
+	kc := fake.NewSimpleClientset()
+	a, discover := makeLeaderElectionAdapter(t, kc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- a.Start(ctx)
+	}()
+
+	// Wait for the lease to be acquired and the reflector to be initialized.
+	time.Sleep(1 * time.Second)
+
+	lease, err := kc.CoordinationV1().Leases("default").Get(ctx, testLeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Expected the lease to be created, got:", err)
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		t.Error("Expected the lease to be held")
+	}
+	if len(discover.Actions()) == 0 {
+		t.Error("Expected the leader to watch the resources")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Error("Did not expect an error, but got:", err)
+	}
+
+	lease, err = kc.CoordinationV1().Leases("default").Get(context.Background(), testLeaseName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity != "" {
+		t.Errorf("Expected the lease to be released on shutdown, held by %q", *lease.Spec.HolderIdentity)
+	}
+}
+
+func TestAdapter_StandBy(t *testing.T) {
+	now := metav1.NewMicroTime(time.Now())
+	kc := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      testLeaseName,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.String("other-replica"),
+			LeaseDurationSeconds: ptr.Int32(3600),
+			AcquireTime:          &now,
+			RenewTime:            &now,
+		},
+	})
+	a, discover := makeLeaderElectionAdapter(t, kc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- a.Start(ctx)
+	}()
+
+	time.Sleep(1 * time.Second)
+
+	if actions := discover.Actions(); len(actions) != 0 {
+		t.Errorf("Expected the replica standing by not to watch the resources, got %v", actions)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Error("Did not expect an error, but got:", err)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	goruntime "runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/tools/cache"
+)
+
+// memoryCheckInterval is how often the heap is compared to the memory
+// watermark.
+const memoryCheckInterval = 10 * time.Second
+
+// objectTransform trims an object as soon as it is received.
+type objectTransform func(*unstructured.Unstructured)
+
+// resourceWatch is a resource watched by a reflector, in a namespace or in
+// all namespaces.
+type resourceWatch struct {
+	gvr           schema.GroupVersionResource
+	kind          string
+	namespace     string
+	labelSelector string
+	fieldSelector string
+	delegate      cache.Store
+
+	// metadataOnly is set once the resource is watched metadata-only.
+	metadataOnly bool
+	// objects is the number of objects of the resource last seen by the
+	// reflector.
+	objects atomic.Int64
+	// stop stops the reflector.
+	stop context.CancelFunc
+}
+
+// objectCounter counts the objects passed to its Store.
+type objectCounter struct {
+	cache.Store
+	objects *atomic.Int64
+}
+
+func (c *objectCounter) Add(obj interface{}) error {
+	c.objects.Add(1)
+	return c.Store.Add(obj)
+}
+
+func (c *objectCounter) Delete(obj interface{}) error {
+	c.objects.Add(-1)
+	return c.Store.Delete(obj)
+}
+
+func (c *objectCounter) Replace(list []interface{}, resourceVersion string) error {
+	c.objects.Store(int64(len(list)))
+	return c.Store.Replace(list, resourceVersion)
+}
+
+// transform returns the transform removing the fields the adapter is
+// configured to strip from the resources, or nil if there is none.
+func (a *apiServerAdapter) transform() objectTransform {
+	if !a.config.StripManagedFields && !a.config.StripLastAppliedConfiguration {
+		return nil
+	}
+	return func(u *unstructured.Unstructured) {
+		if a.config.StripManagedFields {
+			u.SetManagedFields(nil)
+		}
+		if a.config.StripLastAppliedConfiguration {
+			if annotations := u.GetAnnotations(); annotations != nil {
+				if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+					delete(annotations, corev1.LastAppliedConfigAnnotation)
+					u.SetAnnotations(annotations)
+				}
+			}
+		}
+	}
+}
+
+// runWatch starts a reflector watching the resource until ctx is done or the
+// watch is stopped.
+func (a *apiServerAdapter) runWatch(ctx context.Context, w *resourceWatch, resyncPeriod time.Duration) {
+	ctx, w.stop = context.WithCancel(ctx)
+	store := &objectCounter{Store: w.delegate, objects: &w.objects}
+	reflector := cache.NewReflector(a.listWatch(ctx, w), &unstructured.Unstructured{}, store, resyncPeriod)
+	go reflector.Run(ctx.Done())
+}
+
+func (a *apiServerAdapter) listWatch(ctx context.Context, w *resourceWatch) cache.ListerWatcher {
+	lw := a.resourceListWatch(ctx, w)
+	if a.monitor == nil {
+		return lw
+	}
+	// The reflector watches the resource once it is listed, and lists it
+	// again when the watch fails.
+	list, watchFunc := lw.ListFunc, lw.WatchFunc
+	lw.ListFunc = func(opts metav1.ListOptions) (runtime.Object, error) {
+		obj, err := list(opts)
+		if err != nil {
+			a.monitor.watchFailed(ctx, w, err)
+		} else {
+			a.monitor.watchEstablished(ctx, w)
+		}
+		return obj, err
+	}
+	lw.WatchFunc = func(opts metav1.ListOptions) (watch.Interface, error) {
+		wi, err := watchFunc(opts)
+		if err != nil {
+			a.monitor.watchFailed(ctx, w, err)
+			return wi, err
+		}
+		return watch.Filter(wi, func(e watch.Event) (watch.Event, bool) {
+			if e.Type == watch.Error {
+				a.monitor.watchFailed(ctx, w, apierrors.FromObject(e.Object))
+			}
+			return e, true
+		}), nil
+	}
+	return lw
+}
+
+func (a *apiServerAdapter) resourceListWatch(ctx context.Context, w *resourceWatch) *cache.ListWatch {
+	transform := a.transform()
+
+	if w.metadataOnly {
+		var res metadata.ResourceInterface = a.metadata.Resource(w.gvr)
+		if w.namespace != metav1.NamespaceAll {
+			res = a.metadata.Resource(w.gvr).Namespace(w.namespace)
+		}
+		gvk := w.gvr.GroupVersion().WithKind(w.kind)
+		return &cache.ListWatch{
+			ListFunc:  asUnstructuredLister(ctx, asMetadataLister(res.List, gvk), w.labelSelector, w.fieldSelector, transform),
+			WatchFunc: asUnstructuredWatcher(ctx, asMetadataWatcher(res.Watch, gvk), w.labelSelector, w.fieldSelector, transform),
+		}
+	}
+
+	var res dynamic.ResourceInterface = a.k8s.Resource(w.gvr)
+	if w.namespace != metav1.NamespaceAll {
+		res = a.k8s.Resource(w.gvr).Namespace(w.namespace)
+	}
+	return &cache.ListWatch{
+		ListFunc:  asUnstructuredLister(ctx, res.List, w.labelSelector, w.fieldSelector, transform),
+		WatchFunc: asUnstructuredWatcher(ctx, res.Watch, w.labelSelector, w.fieldSelector, transform),
+	}
+}
+
+type metadataLister func(context.Context, metav1.ListOptions) (*metav1.PartialObjectMetadataList, error)
+
+// asMetadataLister lists the metadata of the resources as unstructured
+// objects of the given kind, so that they are handled like the full ones.
+func asMetadataLister(list metadataLister, gvk schema.GroupVersionKind) unstructuredLister {
+	return func(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+		ml, err := list(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		ul := &unstructured.UnstructuredList{
+			Items: make([]unstructured.Unstructured, 0, len(ml.Items)),
+		}
+		ul.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		ul.SetResourceVersion(ml.ResourceVersion)
+		ul.SetContinue(ml.Continue)
+		for i := range ml.Items {
+			u, err := metadataToUnstructured(&ml.Items[i], gvk)
+			if err != nil {
+				return nil, err
+			}
+			ul.Items = append(ul.Items, *u)
+		}
+		return ul, nil
+	}
+}
+
+// asMetadataWatcher watches the metadata of the resources as unstructured
+// objects of the given kind.
+func asMetadataWatcher(wf structuredWatcher, gvk schema.GroupVersionKind) structuredWatcher {
+	return func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+		w, err := wf(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		return watch.Filter(w, func(e watch.Event) (watch.Event, bool) {
+			if m, ok := e.Object.(*metav1.PartialObjectMetadata); ok {
+				u, err := metadataToUnstructured(m, gvk)
+				if err != nil {
+					return e, false
+				}
+				e.Object = u
+			}
+			return e, true
+		}), nil
+	}
+}
+
+func metadataToUnstructured(m *metav1.PartialObjectMetadata, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	meta, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&m.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"metadata": meta}}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// watchMemory compares the heap to the memory watermark until ctx is done.
+func (a *apiServerAdapter) watchMemory(ctx context.Context, watches []*resourceWatch, resyncPeriod time.Duration) {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			var stats goruntime.MemStats
+			goruntime.ReadMemStats(&stats)
+			if a.checkMemory(ctx, watches, resyncPeriod, stats.HeapAlloc) != nil {
+				// Release the memory of the full resources right away, so
+				// that the next check sees the effect of the switch.
+				goruntime.GC()
+			}
+		}
+	}
+}
+
+// checkMemory switches the resource with the most objects still watched in
+// full to a metadata-only watch if heap exceeds the memory watermark, and
+// returns it. The changes made to the resource while its reflector restarts
+// aren't sent.
+func (a *apiServerAdapter) checkMemory(ctx context.Context, watches []*resourceWatch, resyncPeriod time.Duration, heap uint64) *resourceWatch {
+	if a.metadata == nil || heap <= uint64(a.config.MemoryWatermark) {
+		return nil
+	}
+
+	var largest *resourceWatch
+	for _, w := range watches {
+		if w.metadataOnly {
+			continue
+		}
+		if largest == nil || w.objects.Load() > largest.objects.Load() {
+			largest = w
+		}
+	}
+	if largest == nil {
+		return nil
+	}
+
+	a.logger.Warnw("Memory watermark exceeded, watching the resource metadata-only",
+		zap.Uint64("heap", heap),
+		zap.Int64("watermark", a.config.MemoryWatermark),
+		zap.String("resource", largest.gvr.String()),
+		zap.String("namespace", largest.namespace),
+		zap.Int64("objects", largest.objects.Load()))
+	largest.stop()
+	largest.metadataOnly = true
+	a.runWatch(ctx, largest, resyncPeriod)
+	return largest
+}
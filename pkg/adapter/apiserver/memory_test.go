@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+	pkgtesting "knative.dev/pkg/reconciler/testing"
+)
+
+var podsGVR = schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+
+func TestAdapter_Transform(t *testing.T) {
+	pod := func() *unstructured.Unstructured {
+		u := simplePod("foo", "default")
+		u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+		u.SetAnnotations(map[string]string{
+			corev1.LastAppliedConfigAnnotation: "{}",
+			"foo":                              "bar",
+		})
+		return u
+	}
+
+	tests := []struct {
+		name            string
+		config          Config
+		wantNil         bool
+		wantManaged     bool
+		wantAnnotations map[string]string
+	}{{
+		name:    "no transform",
+		wantNil: true,
+	}, {
+		name:   "strip managed fields",
+		config: Config{StripManagedFields: true},
+		wantAnnotations: map[string]string{
+			corev1.LastAppliedConfigAnnotation: "{}",
+			"foo":                              "bar",
+		},
+	}, {
+		name:            "strip last applied configuration",
+		config:          Config{StripLastAppliedConfiguration: true},
+		wantManaged:     true,
+		wantAnnotations: map[string]string{"foo": "bar"},
+	}, {
+		name:            "strip both",
+		config:          Config{StripManagedFields: true, StripLastAppliedConfiguration: true},
+		wantAnnotations: map[string]string{"foo": "bar"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &apiServerAdapter{config: tc.config}
+			transform := a.transform()
+			if tc.wantNil {
+				if transform != nil {
+					t.Error("Expected no transform")
+				}
+				return
+			}
+
+			u := pod()
+			transform(u)
+			if got := len(u.GetManagedFields()) > 0; got != tc.wantManaged {
+				t.Errorf("Expected managed fields kept to be %v, got %v", tc.wantManaged, u.GetManagedFields())
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, u.GetAnnotations()); diff != "" {
+				t.Error("Unexpected annotations (-want +got)", diff)
+			}
+		})
+	}
+}
+
+func TestAdapter_CheckMemory(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pod := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "foo",
+		},
+	}
+	scheme := metadatafake.NewTestScheme()
+	metav1.AddMetaToScheme(scheme)
+	a := &apiServerAdapter{
+		logger:   logging.FromContext(ctx),
+		config:   Config{MemoryWatermark: 100},
+		k8s:      makeDynamicClient(simplePod("foo", "default")),
+		metadata: metadatafake.NewSimpleMetadataClient(scheme, pod),
+	}
+
+	small := &resourceWatch{gvr: podsGVR, kind: "Pod", namespace: "small", delegate: &resourceDelegate{}}
+	small.objects.Store(1)
+	small.stop = func() {}
+	store := &replacedStore{replaced: make(chan []interface{}, 1)}
+	large := &resourceWatch{gvr: podsGVR, kind: "Pod", namespace: "default", delegate: store}
+	large.objects.Store(10)
+	large.stop = func() {}
+	watches := []*resourceWatch{small, large}
+
+	if got := a.checkMemory(ctx, watches, time.Hour, 100); got != nil {
+		t.Errorf("Expected no switch below the watermark, got %v", got.namespace)
+	}
+
+	if got := a.checkMemory(ctx, watches, time.Hour, 101); got != large {
+		t.Fatal("Expected the resource with the most objects to be switched")
+	}
+	if !large.metadataOnly || small.metadataOnly {
+		t.Errorf("Expected only the large resource to be metadata-only, got %v and %v", large.metadataOnly, small.metadataOnly)
+	}
+
+	select {
+	case items := <-store.replaced:
+		if len(items) != 1 {
+			t.Fatalf("Expected 1 listed object, got %d", len(items))
+		}
+		u, ok := items[0].(*unstructured.Unstructured)
+		if !ok {
+			t.Fatalf("Expected an unstructured object, got %T", items[0])
+		}
+		if u.GetKind() != "Pod" || u.GetAPIVersion() != "v1" || u.GetName() != "foo" {
+			t.Errorf("Unexpected metadata-only object %v", u.Object)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the metadata-only list")
+	}
+}
+
+// replacedStore records the objects the reflector lists.
+type replacedStore struct {
+	cache.Store
+	replaced chan []interface{}
+}
+
+func (s *replacedStore) Replace(items []interface{}, _ string) error {
+	s.replaced <- items
+	return nil
+}
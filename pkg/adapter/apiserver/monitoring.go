@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/apis/sources"
+	"knative.dev/eventing/pkg/eventtype"
+)
+
+// watchEventData is the data of the events about a watch of the source.
+type watchEventData struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// monitor sends the events about the source itself: the adapter starting and
+// the watches being established, dropped or denied by RBAC. Only the changes
+// of the state of a watch are sent, not every retry of a failing watch. A nil
+// monitor sends nothing.
+type monitor struct {
+	ce        cloudevents.Client
+	source    string
+	subject   string
+	sourceRef *duckv1.KReference
+	logger    *zap.SugaredLogger
+
+	mu sync.Mutex
+	// states is the type of the last event sent about each watch.
+	states map[*resourceWatch]string
+}
+
+// newMonitor returns a monitor sending the events about the given
+// ApiServerSource with ce.
+func newMonitor(ce cloudevents.Client, source string, sourceRef *duckv1.KReference, logger *zap.SugaredLogger) *monitor {
+	return &monitor{
+		ce:        ce,
+		source:    source,
+		subject:   fmt.Sprintf("/apis/%s/namespaces/%s/apiserversources/%s", sourceRef.APIVersion, sourceRef.Namespace, sourceRef.Name),
+		sourceRef: sourceRef,
+		logger:    logger,
+		states:    make(map[*resourceWatch]string),
+	}
+}
+
+// started reports that the adapter started watching the resources.
+func (m *monitor) started(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.send(ctx, sources.ApiServerSourceStartedEventType, nil)
+}
+
+// watchEstablished reports that the resource of the watch was listed, the
+// reflector then watches it.
+func (m *monitor) watchEstablished(ctx context.Context, w *resourceWatch) {
+	if m == nil || !m.transition(w, sources.ApiServerSourceWatchEstablishedEventType) {
+		return
+	}
+	m.send(ctx, sources.ApiServerSourceWatchEstablishedEventType, newWatchEventData(w, nil))
+}
+
+// watchFailed reports that the watch was dropped, or denied by RBAC. The
+// watches closed normally or expired are restarted by the reflector right
+// away and aren't reported.
+func (m *monitor) watchFailed(ctx context.Context, w *resourceWatch, err error) {
+	if m == nil || errors.Is(err, io.EOF) || apierrors.IsResourceExpired(err) || apierrors.IsGone(err) {
+		return
+	}
+	eventType := sources.ApiServerSourceWatchDroppedEventType
+	if apierrors.IsForbidden(err) {
+		eventType = sources.ApiServerSourceRBACDeniedEventType
+	}
+	if !m.transition(w, eventType) {
+		return
+	}
+	m.send(ctx, eventType, newWatchEventData(w, err))
+}
+
+// transition records the type of the event about the watch, returning
+// whether it changed.
+func (m *monitor) transition(w *resourceWatch, eventType string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.states[w] == eventType {
+		return false
+	}
+	m.states[w] = eventType
+	return true
+}
+
+func (m *monitor) send(ctx context.Context, eventType string, data *watchEventData) {
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(uuid.New().String())
+	event.SetType(eventType)
+	event.SetSource(m.source)
+	event.SetSubject(m.subject)
+	eventtype.SetSourceExtensions(&event, m.sourceRef)
+	if data != nil {
+		if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+			m.logger.Errorw("Failed to set the data of the monitoring event", zap.String("type", eventType), zap.Error(err))
+			return
+		}
+	}
+
+	if result := m.ce.Send(ctx, event); !cloudevents.IsACK(result) {
+		m.logger.Errorw("Failed to send the monitoring event", zap.String("type", eventType), zap.Error(result))
+	}
+}
+
+func newWatchEventData(w *resourceWatch, err error) *watchEventData {
+	data := &watchEventData{
+		Group:     w.gvr.Group,
+		Version:   w.gvr.Version,
+		Resource:  w.gvr.Resource,
+		Namespace: w.namespace,
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	return data
+}
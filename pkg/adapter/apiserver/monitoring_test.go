@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+	pkgtesting "knative.dev/pkg/reconciler/testing"
+
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+	"knative.dev/eventing/pkg/apis/sources"
+	"knative.dev/eventing/pkg/eventtype"
+)
+
+func TestMonitor_Transitions(t *testing.T) {
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+	ce := adaptertest.NewTestClient()
+	m := newMonitor(ce, "unit-test", &duckv1.KReference{
+		APIVersion: "sources.knative.dev/v1",
+		Kind:       "ApiServerSource",
+		Namespace:  "default",
+		Name:       apiServerSourceNameTest,
+	}, logging.FromContext(ctx))
+
+	w := &resourceWatch{gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}, namespace: "default"}
+	gr := schema.GroupResource{Resource: "pods"}
+
+	m.started(ctx)
+	m.watchEstablished(ctx, w)
+	m.watchEstablished(ctx, w)
+	// The watches closed normally or expired aren't reported.
+	m.watchFailed(ctx, w, io.EOF)
+	m.watchFailed(ctx, w, apierrors.NewResourceExpired("too old"))
+	m.watchFailed(ctx, w, apierrors.NewForbidden(gr, "", errors.New("denied")))
+	m.watchFailed(ctx, w, apierrors.NewForbidden(gr, "", errors.New("denied")))
+	m.watchFailed(ctx, w, errors.New("connection refused"))
+	m.watchEstablished(ctx, w)
+
+	want := []string{
+		sources.ApiServerSourceStartedEventType,
+		sources.ApiServerSourceWatchEstablishedEventType,
+		sources.ApiServerSourceRBACDeniedEventType,
+		sources.ApiServerSourceWatchDroppedEventType,
+		sources.ApiServerSourceWatchEstablishedEventType,
+	}
+	var got []string
+	for _, e := range ce.Sent() {
+		got = append(got, e.Type())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal("Unexpected events (-want, +got):", diff)
+	}
+
+	denied := ce.Sent()[2]
+	if want := "/apis/sources.knative.dev/v1/namespaces/default/apiserversources/" + apiServerSourceNameTest; denied.Subject() != want {
+		t.Errorf("Expected the subject %q, got %q", want, denied.Subject())
+	}
+	if ref := eventtype.SourceReference(&denied); ref == nil || ref.Name != apiServerSourceNameTest {
+		t.Errorf("Expected the event to reference the source, got %v", ref)
+	}
+	var data watchEventData
+	if err := denied.DataAs(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.Resource != "pods" || data.Namespace != "default" || data.Error == "" {
+		t.Errorf("Unexpected data %+v", data)
+	}
+}
+
+func TestMonitor_Nil(t *testing.T) {
+	var m *monitor
+	w := &resourceWatch{gvr: schema.GroupVersionResource{Version: "v1", Resource: "pods"}}
+	m.started(context.Background())
+	m.watchEstablished(context.Background(), w)
+	m.watchFailed(context.Background(), w, errors.New("connection refused"))
+}
+
+func TestAdapter_StartMonitoring(t *testing.T) {
+	ce := adaptertest.NewTestClient()
+	monitoring := adaptertest.NewTestClient()
+
+	config := Config{
+		Namespaces: []string{"default"},
+		Resources: []ResourceWatch{{
+			GVR: schema.GroupVersionResource{
+				Version:  "v1",
+				Resource: "pods",
+			},
+		}},
+		EventMode: "Resource",
+	}
+	ctx, _ := pkgtesting.SetupFakeContext(t)
+
+	a := &apiServerAdapter{
+		ce:     ce,
+		logger: logging.FromContext(ctx),
+		config: config,
+
+		discover:   makeDiscoveryClient(),
+		k8s:        makeDynamicClient(simplePod("foo", "default")),
+		source:     "unit-test",
+		name:       apiServerSourceNameTest,
+		namespace:  "default",
+		monitoring: monitoring,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		a.Start(ctx)
+		close(done)
+	}()
+
+	// Wait for the reflector to list the pods.
+	time.Sleep(1 * time.Second)
+
+	cancel()
+	<-done
+
+	want := []string{
+		sources.ApiServerSourceStartedEventType,
+		sources.ApiServerSourceWatchEstablishedEventType,
+	}
+	var got []string
+	for _, e := range monitoring.Sent() {
+		got = append(got, e.Type())
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Unexpected monitoring events (-want, +got):", diff)
+	}
+	for _, e := range ce.Sent() {
+		if e.Type() == sources.ApiServerSourceStartedEventType {
+			t.Error("Expected the monitoring events to be sent to the monitoring sink only")
+		}
+	}
+}
@@ -0,0 +1,335 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing/pkg/apis/sources"
+	v1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+// queueSize is the number of events the send queue holds, the watches are
+// held back while it is full.
+const queueSize = 1000
+
+var (
+	// defaultQueuePriorities are the operations by decreasing priority of
+	// their events, so that the consumers learn first about the resources
+	// going away.
+	defaultQueuePriorities = []string{v1.DeleteOperation, v1.AddOperation, v1.UpdateOperation}
+
+	// eventTypeOperations are the operations of the event types.
+	eventTypeOperations = map[string]string{
+		sources.ApiServerSourceAddEventType:       v1.AddOperation,
+		sources.ApiServerSourceAddRefEventType:    v1.AddOperation,
+		sources.ApiServerSourceUpdateEventType:    v1.UpdateOperation,
+		sources.ApiServerSourceUpdateRefEventType: v1.UpdateOperation,
+		sources.ApiServerSourcePatchEventType:     v1.UpdateOperation,
+		sources.ApiServerSourceDeleteEventType:    v1.DeleteOperation,
+		sources.ApiServerSourceDeleteRefEventType: v1.DeleteOperation,
+	}
+
+	errQueueClosed = errors.New("the send queue is closed")
+)
+
+var (
+	// queueDepthM records the number of events waiting to be sent.
+	queueDepthM = stats.Int64(
+		"apiserversource_queue_depth",
+		"Number of events waiting in the send queue",
+		stats.UnitDimensionless,
+	)
+
+	// queueLatencyM records the time the events waited before their first
+	// sending attempt.
+	queueLatencyM = stats.Float64(
+		"apiserversource_queue_latency",
+		"Time the events waited in the send queue before being sent",
+		stats.UnitMilliseconds,
+	)
+
+	// queueRetriesM records the events requeued after failing to be sent.
+	queueRetriesM = stats.Int64(
+		"apiserversource_queue_retries",
+		"Number of events requeued after failing to be sent",
+		stats.UnitDimensionless,
+	)
+
+	operationKey = tag.MustNewKey("operation")
+)
+
+func init() {
+	registerQueueViews()
+}
+
+func registerQueueViews() {
+	if err := view.Register(
+		&view.View{
+			Description: queueDepthM.Description(),
+			Measure:     queueDepthM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{operationKey},
+		},
+		&view.View{
+			Description: queueLatencyM.Description(),
+			Measure:     queueLatencyM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 100000)...),
+			TagKeys:     []tag.Key{operationKey},
+		},
+		&view.View{
+			Description: queueRetriesM.Description(),
+			Measure:     queueRetriesM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{operationKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// eventSender sends the events of the resources, it is implemented by the
+// CloudEvents clients and by the send queue.
+type eventSender interface {
+	Send(ctx context.Context, event cloudevents.Event) protocol.Result
+}
+
+// queuedEvent is an event waiting to be sent.
+type queuedEvent struct {
+	ctx      context.Context
+	event    cloudevents.Event
+	priority int
+	queued   time.Time
+	// attempts is the number of times the event failed to be sent.
+	attempts int
+}
+
+// sendQueue is a bounded queue of the events sent to the sink, which sends
+// the events of the highest priority first, and the events of a priority in
+// order. The events failing to be sent are requeued after the delay of their
+// retry, so that they don't hold back the other events meanwhile, and are
+// sent to the dead letter sink once their retries are exhausted.
+type sendQueue struct {
+	ce      cloudevents.Client
+	backoff *backoff
+	// deadLetterSink receives the events that couldn't be sent to the sink, if
+	// any.
+	deadLetterSink cloudevents.Client
+	sink           string
+	logger         *zap.SugaredLogger
+
+	size int
+	// operations are the operations by decreasing priority, the priority of
+	// an event being the index of the operation of its type.
+	operations []string
+	// tags are the metrics tags of the priorities.
+	tags []context.Context
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	// events are the queued events by priority.
+	events [][]*queuedEvent
+	// len is the number of queued events, except the retried ones.
+	len    int
+	closed bool
+}
+
+var _ eventSender = (*sendQueue)(nil)
+
+// newSendQueue creates a queue ordering the events by the given operations,
+// the operations left out come last in their default order.
+func newSendQueue(ce cloudevents.Client, priorities []string, logger *zap.SugaredLogger) *sendQueue {
+	q := &sendQueue{
+		ce:     ce,
+		logger: logger,
+		size:   queueSize,
+	}
+	seen := make(map[string]bool, len(defaultQueuePriorities))
+	operations := append(append([]string{}, priorities...), defaultQueuePriorities...)
+	for _, op := range operations {
+		if seen[op] {
+			continue
+		}
+		seen[op] = true
+		q.operations = append(q.operations, op)
+		ctx, _ := tag.New(context.Background(), tag.Insert(operationKey, op))
+		q.tags = append(q.tags, ctx)
+	}
+	q.events = make([][]*queuedEvent, len(q.operations))
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// Send queues the event, blocking while the queue is full. The event is
+// acknowledged once queued, the failures to send it are only logged.
+func (q *sendQueue) Send(ctx context.Context, event cloudevents.Event) protocol.Result {
+	e := &queuedEvent{
+		ctx:      ctx,
+		event:    event,
+		priority: q.priority(event.Type()),
+		queued:   time.Now(),
+	}
+
+	q.mu.Lock()
+	for q.len >= q.size && !q.closed {
+		q.notFull.Wait()
+	}
+	if q.closed {
+		q.mu.Unlock()
+		return errQueueClosed
+	}
+	q.events[e.priority] = append(q.events[e.priority], e)
+	q.len++
+	depth := len(q.events[e.priority])
+	q.notEmpty.Signal()
+	q.mu.Unlock()
+
+	metrics.Record(q.tags[e.priority], queueDepthM.M(int64(depth)))
+	return nil
+}
+
+// priority returns the priority of the events of the type, the events of an
+// unknown type have the lowest priority.
+func (q *sendQueue) priority(eventType string) int {
+	op := eventTypeOperations[eventType]
+	for i := range q.operations {
+		if q.operations[i] == op {
+			return i
+		}
+	}
+	return len(q.operations) - 1
+}
+
+// run sends the queued events until ctx is done, the events still queued are
+// then dropped.
+func (q *sendQueue) run(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		q.close()
+	}()
+
+	for {
+		e, ok := q.next()
+		if !ok {
+			return
+		}
+		q.send(e)
+	}
+}
+
+// next waits for the queued event of the highest priority, it returns false
+// once the queue is closed.
+func (q *sendQueue) next() (*queuedEvent, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.closed {
+			return nil, false
+		}
+		for priority, events := range q.events {
+			if len(events) == 0 {
+				continue
+			}
+			e := events[0]
+			events[0] = nil
+			q.events[priority] = events[1:]
+			if e.attempts == 0 {
+				q.len--
+				q.notFull.Signal()
+			}
+			metrics.Record(q.tags[priority], queueDepthM.M(int64(len(q.events[priority]))))
+			return e, true
+		}
+		q.notEmpty.Wait()
+	}
+}
+
+// close releases the watches waiting for room in the queue and stops run.
+func (q *sendQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	if q.len > 0 {
+		q.logger.Infow("Dropping the queued events", zap.Int("events", q.len))
+	}
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+// requeue queues a retried event, even if the queue is full, so that the
+// retries don't wait for the watches.
+func (q *sendQueue) requeue(e *queuedEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.events[e.priority] = append(q.events[e.priority], e)
+	q.notEmpty.Signal()
+}
+
+func (q *sendQueue) send(e *queuedEvent) {
+	if e.attempts == 0 {
+		metrics.Record(q.tags[e.priority], queueLatencyM.M(float64(time.Since(e.queued).Milliseconds())))
+	}
+
+	source := e.event.Context.GetSource()
+	subject := e.event.Context.GetSubject()
+	result := q.ce.Send(e.ctx, e.event)
+	if cloudevents.IsACK(result) {
+		q.logger.Debugf("cloudevent sent id: %s, source: %s, subject: %s", e.event.ID(), source, subject)
+		return
+	}
+	e.attempts++
+	q.logger.Errorw("failed to send cloudevent", zap.Error(result), zap.String("source", source),
+		zap.String("subject", subject), zap.String("id", e.event.ID()), zap.Int("attempts", e.attempts))
+
+	if q.backoff != nil && e.attempts <= q.backoff.retries {
+		metrics.Record(q.tags[e.priority], queueRetriesM.M(1))
+		time.AfterFunc(q.backoff.duration(e.attempts), func() {
+			q.requeue(e)
+		})
+		return
+	}
+
+	if q.deadLetterSink == nil {
+		return
+	}
+	setKnativeErrorExtensions(&e.event, q.sink, result)
+	if result := q.deadLetterSink.Send(e.ctx, e.event); !cloudevents.IsACK(result) {
+		q.logger.Errorw("failed to send cloudevent to the dead letter sink", zap.Error(result), zap.String("source", source),
+			zap.String("subject", subject), zap.String("id", e.event.ID()))
+	} else {
+		q.logger.Debugf("cloudevent sent to the dead letter sink id: %s, source: %s, subject: %s", e.event.ID(), source, subject)
+	}
+}
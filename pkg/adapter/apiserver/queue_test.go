@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	adaptertest "knative.dev/eventing/pkg/adapter/v2/test"
+	"knative.dev/eventing/pkg/apis/sources"
+	v1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+func TestSendQueuePriorities(t *testing.T) {
+	tests := map[string]struct {
+		priorities []string
+		want       []string
+	}{
+		"default": {
+			want: []string{"delete-1", "delete-2", "add-1", "add-2", "update-1", "patch-1", "update-2"},
+		},
+		"updates first": {
+			priorities: []string{v1.UpdateOperation},
+			want:       []string{"update-1", "patch-1", "update-2", "delete-1", "delete-2", "add-1", "add-2"},
+		},
+		"all": {
+			priorities: []string{v1.AddOperation, v1.UpdateOperation, v1.DeleteOperation},
+			want:       []string{"add-1", "add-2", "update-1", "patch-1", "update-2", "delete-1", "delete-2"},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			ce := adaptertest.NewTestClient()
+			q := newSendQueue(ce, tc.priorities, zap.NewExample().Sugar())
+
+			for _, e := range []struct {
+				id        string
+				eventType string
+			}{
+				{"update-1", sources.ApiServerSourceUpdateEventType},
+				{"add-1", sources.ApiServerSourceAddEventType},
+				{"delete-1", sources.ApiServerSourceDeleteRefEventType},
+				{"patch-1", sources.ApiServerSourcePatchEventType},
+				{"add-2", sources.ApiServerSourceAddRefEventType},
+				{"update-2", sources.ApiServerSourceUpdateRefEventType},
+				{"delete-2", sources.ApiServerSourceDeleteEventType},
+			} {
+				if result := q.Send(context.Background(), makeQueueTestEvent(e.id, e.eventType)); !cloudevents.IsACK(result) {
+					t.Fatal("Send() =", result)
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go q.run(ctx)
+
+			waitForSent(t, ce, len(tc.want))
+			var got []string
+			for _, e := range ce.Sent() {
+				got = append(got, e.ID())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected order of the events (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestSendQueueFull(t *testing.T) {
+	ce := adaptertest.NewTestClient()
+	q := newSendQueue(ce, nil, zap.NewExample().Sugar())
+	q.size = 1
+
+	q.Send(context.Background(), makeQueueTestEvent("1", sources.ApiServerSourceAddEventType))
+
+	sent := make(chan error)
+	go func() {
+		sent <- q.Send(context.Background(), makeQueueTestEvent("2", sources.ApiServerSourceAddEventType))
+	}()
+	select {
+	case err := <-sent:
+		t.Fatal("Expected Send() to block while the queue is full, got", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.run(ctx)
+
+	if err := <-sent; err != nil {
+		t.Error("Send() =", err)
+	}
+	waitForSent(t, ce, 2)
+}
+
+func TestSendQueueClosed(t *testing.T) {
+	q := newSendQueue(adaptertest.NewTestClient(), nil, zap.NewExample().Sugar())
+	q.size = 1
+	q.Send(context.Background(), makeQueueTestEvent("1", sources.ApiServerSourceAddEventType))
+
+	sent := make(chan error)
+	go func() {
+		sent <- q.Send(context.Background(), makeQueueTestEvent("2", sources.ApiServerSourceAddEventType))
+	}()
+
+	q.close()
+
+	if err := <-sent; !errors.Is(err, errQueueClosed) {
+		t.Errorf("Send() = %v, want %v", err, errQueueClosed)
+	}
+}
+
+func makeQueueTestEvent(id, eventType string) cloudevents.Event {
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	e.SetType(eventType)
+	e.SetSource("unit-test")
+	return e
+}
+
+// waitForSent waits for the client to have sent n events.
+func waitForSent(t *testing.T, ce *adaptertest.TestCloudEventsClient, n int) {
+	t.Helper()
+	err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return len(ce.Sent()) >= n, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected %d events to be sent, got %d", n, len(ce.Sent()))
+	}
+}
@@ -21,10 +21,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/uuid"
+	"github.com/rickb777/date/period"
 	"github.com/robfig/cron/v3"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -35,6 +38,7 @@ import (
 	"knative.dev/eventing/pkg/adapter/v2"
 	kncloudevents "knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
+	"knative.dev/eventing/pkg/apis/sources"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/observability"
 )
@@ -61,8 +65,45 @@ type cronJobsRunner struct {
 
 const (
 	resourceGroup = "pingsources.sources.knative.dev"
+
+	// defaultJitter is the maximum random delay of the ticks of the
+	// PingSources without a jitter.
+	defaultJitter = 500 * time.Millisecond
+)
+
+// scheduleParser parses the schedules of the PingSources, as validated by the
+// webhook.
+var scheduleParser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
 )
 
+// tickSchedule records the activation times of a schedule, so that its job
+// knows the time its tick was scheduled at. The cron runner computes the next
+// activation time of an entry when it runs its job.
+type tickSchedule struct {
+	cron.Schedule
+
+	mu        sync.Mutex
+	next      time.Time
+	scheduled time.Time
+}
+
+func (s *tickSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scheduled = s.next
+	s.next = next
+	return next
+}
+
+// scheduledTime returns the time the running tick was scheduled at.
+func (s *tickSchedule) scheduledTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.scheduled
+}
+
 func NewCronJobsRunner(cfg adapter.ClientConfig, kubeClient kubernetes.Interface, logger *zap.SugaredLogger, opts ...cron.Option) *cronJobsRunner {
 	return &cronJobsRunner{
 		cron:         *cron.New(opts...),
@@ -107,6 +148,16 @@ func (a *cronJobsRunner) AddSchedule(source *sourcesv1.PingSource) cron.EntryID
 
 	ctx = kncloudevents.ContextWithMetricTag(ctx, metricTag)
 
+	parsed, err := scheduleParser.Parse(schedule)
+	if err != nil {
+		a.Logger.Desugar().Error("Failed to parse the schedule",
+			zap.String("name", source.GetName()),
+			zap.String("namespace", source.GetNamespace()),
+			zap.Error(err),
+		)
+		return -1
+	}
+
 	client, err := a.newPingSourceClient(source)
 	if err != nil {
 		a.Logger.Desugar().Error("Failed to create client",
@@ -117,8 +168,8 @@ func (a *cronJobsRunner) AddSchedule(source *sourcesv1.PingSource) cron.EntryID
 		return -1
 	}
 
-	id, _ := a.cron.AddFunc(schedule, a.cronTick(ctx, client, source, event))
-	return id
+	tick := &tickSchedule{Schedule: parsed}
+	return a.cron.Schedule(tick, cron.FuncJob(a.cronTick(ctx, client, source, event, tick)))
 }
 
 func (a *cronJobsRunner) RemoveSchedule(id cron.EntryID) {
@@ -138,17 +189,33 @@ func (a *cronJobsRunner) Stop() {
 	}
 }
 
-func (a *cronJobsRunner) cronTick(ctx context.Context, client kncloudevents.Client, src *sourcesv1.PingSource, event cloudevents.Event) func() {
+func (a *cronJobsRunner) cronTick(ctx context.Context, client kncloudevents.Client, src *sourcesv1.PingSource, event cloudevents.Event, tick *tickSchedule) func() {
 	target := src.Status.SinkURI.String()
+	jitter := jitterOf(src)
+	location := time.Local
+	if spec, ok := tick.Schedule.(*cron.SpecSchedule); ok {
+		location = spec.Location
+	}
 
 	return func() {
+		scheduled := tick.scheduledTime()
 		event := event.Clone()
 		event.SetID(uuid.New().String()) // provide an ID here so we can track it with logging
 		defer a.Logger.Debug("Finished sending cloudevent id: ", event.ID())
 		source := event.Context.GetSource()
 
 		// Provide a delay so not all ping fired instantaneously distribute load on resources.
-		time.Sleep(time.Duration(rand.Intn(500)) * time.Millisecond) //nolint:gosec // Cryptographic randomness not necessary here.
+		if jitter > 0 {
+			time.Sleep(time.Duration(rand.Int63n(int64(jitter)))) //nolint:gosec // Cryptographic randomness not necessary here.
+		}
+
+		// Let the consumers detect the delayed ticks.
+		fired := time.Now()
+		if !scheduled.IsZero() {
+			event.SetExtension(sourcesv1.PingSourceScheduledTimeExtension, scheduled.In(location).Format(time.RFC3339Nano))
+			event.SetExtension(sourcesv1.PingSourceDriftExtension, int32(fired.Sub(scheduled).Milliseconds()))
+		}
+		event.SetExtension(sourcesv1.PingSourceFireTimeExtension, fired.In(location).Format(time.RFC3339Nano))
 
 		a.Logger.Debugf("sending cloudevent id: %s, source: %s, target: %s", event.ID(), source, target)
 
@@ -162,6 +229,19 @@ func (a *cronJobsRunner) cronTick(ctx context.Context, client kncloudevents.Clie
 	}
 }
 
+// jitterOf returns the maximum random delay of the ticks of the PingSource.
+func jitterOf(source *sourcesv1.PingSource) time.Duration {
+	if source.Spec.Jitter == nil {
+		return defaultJitter
+	}
+	p, err := period.Parse(*source.Spec.Jitter)
+	if err != nil || p.IsNegative() {
+		return defaultJitter
+	}
+	jitter, _ := p.Duration()
+	return jitter
+}
+
 func makeEvent(source *sourcesv1.PingSource) (cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 	event.SetType(sourcesv1.PingSourceEventType)
@@ -192,19 +272,26 @@ func (a *cronJobsRunner) newPingSourceClient(source *sourcesv1.PingSource) (adap
 	var env adapter.EnvConfig
 	if a.clientConfig.Env != nil {
 		env = adapter.EnvConfig{
-			Namespace:      source.GetNamespace(),
-			Name:           a.clientConfig.Env.GetName(),
-			EnvSinkTimeout: fmt.Sprintf("%d", a.clientConfig.Env.GetSinktimeout()),
-			Audience:       source.Status.SinkAudience,
+			Namespace:            source.GetNamespace(),
+			Name:                 a.clientConfig.Env.GetName(),
+			EnvSinkTimeout:       fmt.Sprintf("%d", a.clientConfig.Env.GetSinktimeout()),
+			EventLogSamplingRate: strconv.FormatFloat(a.clientConfig.Env.GetEventLogSamplingRate(), 'f', -1, 64),
 		}
 
 		if source.Status.Auth != nil {
 			env.OIDCServiceAccountName = source.Status.Auth.ServiceAccountName
 		}
+
+		if retryAfterMax := a.clientConfig.Env.GetRetryAfterMax(); retryAfterMax != nil {
+			p, _ := period.NewOf(*retryAfterMax)
+			env.RetryAfterMax = p.String()
+		}
 	}
 
+	env.TraceContextInjection = source.Annotations[sources.TraceContextInjectionAnnotationKey]
 	env.Sink = source.Status.SinkURI.String()
 	env.CACerts = source.Status.SinkCACerts
+	env.Audience = source.Status.SinkAudience
 
 	a.Logger.Debugw("Creating client",
 		"namespace", source.Namespace,
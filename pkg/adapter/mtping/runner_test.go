@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -30,6 +31,7 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	bindingshttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -43,6 +45,7 @@ import (
 	rectesting "knative.dev/pkg/reconciler/testing"
 
 	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/apis/sources"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/eventingtls/eventingtlstesting"
 )
@@ -308,6 +311,104 @@ func TestSendEventsTLS(t *testing.T) {
 	require.Equal(t, sourcesv1.PingSourceSource("test-ns", "test-name1"), event.Source())
 }
 
+func TestSendEventsRetryAfter(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+
+	var requests []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				CloudEventOverrides: &duckv1.CloudEventOverrides{},
+			},
+			Schedule:    "* * * * *",
+			ContentType: cloudevents.TextPlain,
+			Data:        sampleData,
+		},
+		Status: sourcesv1.PingSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: apis.HTTP(server.Listener.Addr().String()),
+			},
+		},
+	}
+
+	cc := adapter.ClientConfig{
+		Env: &adapter.EnvConfig{
+			EnvSinkTimeout: "-1",
+			RetryAfterMax:  "PT5S",
+		},
+	}
+	runner := NewCronJobsRunner(cc, kubeclient.Get(ctx), logging.FromContext(ctx))
+	entryId := runner.AddSchedule(src)
+	runner.cron.Entry(entryId).Job.Run()
+
+	require.Len(t, requests, 2)
+	if wait := requests[1].Sub(requests[0]); wait < time.Second {
+		t.Errorf("Expected the retry to wait for the Retry-After duration, waited %v", wait)
+	}
+}
+
+func TestSendEventsTraceContextInjection(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get("Ce-Traceparent")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+			Annotations: map[string]string{
+				sources.TraceContextInjectionAnnotationKey: sources.TraceContextInjectionEnabled,
+			},
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			SourceSpec: duckv1.SourceSpec{
+				CloudEventOverrides: &duckv1.CloudEventOverrides{},
+			},
+			Schedule:    "* * * * *",
+			ContentType: cloudevents.TextPlain,
+			Data:        sampleData,
+		},
+		Status: sourcesv1.PingSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: apis.HTTP(server.Listener.Addr().String()),
+			},
+		},
+	}
+
+	cc := adapter.ClientConfig{
+		Env: &adapter.EnvConfig{
+			EnvSinkTimeout: "-1",
+		},
+	}
+	runner := NewCronJobsRunner(cc, kubeclient.Get(ctx), logging.FromContext(ctx))
+	entryId := runner.AddSchedule(src)
+	runner.cron.Entry(entryId).Job.Run()
+
+	if !strings.HasPrefix(traceParent, "00-") {
+		t.Errorf("Expected the event to carry a traceparent extension, got %q", traceParent)
+	}
+}
+
 func TestStartStopCron(t *testing.T) {
 	ctx, _ := rectesting.SetupFakeContext(t)
 	logger := logging.FromContext(ctx)
@@ -407,6 +508,10 @@ func validateSent(t *testing.T, events []cloudevents.Event, wantData []byte, wan
 	}
 
 	gotExtensions := event.Context.GetExtensions()
+	if _, ok := gotExtensions[sourcesv1.PingSourceFireTimeExtension]; !ok {
+		t.Errorf("Expected event with the %s extension, got: %v", sourcesv1.PingSourceFireTimeExtension, gotExtensions)
+	}
+	gotExtensions = withoutTickExtensions(gotExtensions)
 
 	if extensions == nil && gotExtensions != nil {
 		t.Error("Expected event with no extension overrides, got:", gotExtensions)
@@ -427,6 +532,23 @@ func validateSent(t *testing.T, events []cloudevents.Event, wantData []byte, wan
 	}
 }
 
+// withoutTickExtensions returns the extensions without the ones describing
+// the tick, nil when no extension is left.
+func withoutTickExtensions(extensions map[string]interface{}) map[string]interface{} {
+	var rest map[string]interface{}
+	for k, v := range extensions {
+		switch k {
+		case sourcesv1.PingSourceScheduledTimeExtension, sourcesv1.PingSourceFireTimeExtension, sourcesv1.PingSourceDriftExtension:
+			continue
+		}
+		if rest == nil {
+			rest = make(map[string]interface{}, len(extensions))
+		}
+		rest[k] = v
+	}
+	return rest
+}
+
 func eventsAccumulator() (http.Handler, *[]cloudevents.Event) {
 	var mu sync.Mutex
 	events := make([]cloudevents.Event, 0, 8)
@@ -446,3 +568,96 @@ func eventsAccumulator() (http.Handler, *[]cloudevents.Event) {
 		writer.WriteHeader(http.StatusOK)
 	}), &events
 }
+
+func TestTickExtensions(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	logger := logging.FromContext(ctx)
+
+	h, events := eventsAccumulator()
+	s := httptest.NewServer(h)
+	defer s.Close()
+	url, _ := apis.ParseURL(s.URL)
+
+	src := &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-name",
+			Namespace: "test-ns",
+		},
+		Spec: sourcesv1.PingSourceSpec{
+			Schedule: "CRON_TZ=Europe/Paris * * * * *",
+			Jitter:   pointer.String("PT0S"),
+			Data:     sampleData,
+		},
+		Status: sourcesv1.PingSourceStatus{
+			SourceStatus: duckv1.SourceStatus{
+				SinkURI: url,
+			},
+		},
+	}
+
+	runner := NewCronJobsRunner(adapter.ClientConfig{}, kubeclient.Get(ctx), logger)
+	entryId := runner.AddSchedule(src)
+	entry := runner.cron.Entry(entryId)
+	tick := entry.Schedule.(*tickSchedule)
+
+	// The cron runner computes the next activation time before running the job.
+	scheduled := tick.Next(time.Now())
+	tick.Next(scheduled)
+	entry.Job.Run()
+
+	err := wait.PollUntilContextTimeout(context.Background(), 100*time.Millisecond, 10*time.Second, true, func(ctx context.Context) (done bool, err error) {
+		return len(*events) == 1, nil
+	})
+	if err != nil {
+		t.Fatal("Expected 1 event to be sent, got", len(*events))
+	}
+	event := (*events)[0]
+
+	var gotScheduled string
+	if err := event.ExtensionAs(sourcesv1.PingSourceScheduledTimeExtension, &gotScheduled); err != nil {
+		t.Fatal("Failed to get the scheduled time:", err)
+	}
+	paris, _ := time.LoadLocation("Europe/Paris")
+	if want := scheduled.In(paris).Format(time.RFC3339Nano); gotScheduled != want {
+		t.Errorf("Scheduled time = %q, want %q", gotScheduled, want)
+	}
+
+	var gotFired string
+	if err := event.ExtensionAs(sourcesv1.PingSourceFireTimeExtension, &gotFired); err != nil {
+		t.Fatal("Failed to get the fire time:", err)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, gotFired); err != nil {
+		t.Errorf("Fire time %q is not a RFC 3339 time: %v", gotFired, err)
+	}
+
+	// The tick runs ahead of its schedule in this test.
+	gotDrift, err := types.ToInteger(event.Extensions()[sourcesv1.PingSourceDriftExtension])
+	if err != nil {
+		t.Fatal("Failed to get the drift:", err)
+	}
+	if gotDrift > 0 {
+		t.Errorf("Drift = %d, want a negative drift", gotDrift)
+	}
+}
+
+func TestJitterOf(t *testing.T) {
+	tests := map[string]struct {
+		jitter *string
+		want   time.Duration
+	}{
+		"unset":    {want: defaultJitter},
+		"zero":     {jitter: pointer.String("PT0S"), want: 0},
+		"seconds":  {jitter: pointer.String("PT30S"), want: 30 * time.Second},
+		"minutes":  {jitter: pointer.String("PT2M"), want: 2 * time.Minute},
+		"invalid":  {jitter: pointer.String("30s"), want: defaultJitter},
+		"negative": {jitter: pointer.String("-PT30S"), want: defaultJitter},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			src := &sourcesv1.PingSource{Spec: sourcesv1.PingSourceSpec{Jitter: tc.jitter}}
+			if got := jitterOf(src); got != tc.want {
+				t.Errorf("jitterOf() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
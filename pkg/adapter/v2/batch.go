@@ -0,0 +1,221 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	nethttp "net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+
+	"knative.dev/eventing/pkg/apis"
+)
+
+const (
+	// DefaultBatchMaxBytes is the default maximum size of a batch.
+	DefaultBatchMaxBytes = 1024 * 1024
+	// DefaultBatchWindow is the default maximum duration an event waits for
+	// its batch to fill.
+	DefaultBatchWindow = time.Second
+)
+
+// BatchConfig configures the batching of the events sent to the sink.
+type BatchConfig struct {
+	// MaxEvents is the maximum number of events of a batch.
+	MaxEvents int
+	// MaxBytes is the maximum size of the JSON encoding of a batch. An event
+	// larger than MaxBytes is sent in a batch of its own.
+	MaxBytes int
+	// Window is the maximum duration an event waits for its batch to fill.
+	Window time.Duration
+}
+
+// batchedEvent is an event waiting for its batch to be sent.
+type batchedEvent struct {
+	ctx     context.Context
+	event   event.Event
+	encoded []byte
+	start   time.Time
+}
+
+// batcher buffers events, and sends them in a batch once the batch holds
+// MaxEvents events or MaxBytes bytes, or once Window elapsed since its first
+// event was buffered. The full batches are sent by the goroutine adding their
+// last event, which holds back the producers faster than the sink.
+type batcher struct {
+	config BatchConfig
+	send   func(events []batchedEvent)
+
+	mu     sync.Mutex
+	events []batchedEvent
+	size   int
+	timer  *time.Timer
+	// generation identifies the batch being filled, so that the timer of a
+	// batch already sent doesn't send the next one early.
+	generation uint64
+}
+
+func newBatcher(config BatchConfig, send func(events []batchedEvent)) *batcher {
+	return &batcher{
+		config: config,
+		send:   send,
+	}
+}
+
+// add buffers the event, sending the batches it fills.
+func (b *batcher) add(ctx context.Context, e event.Event) error {
+	encoded, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	var full [][]batchedEvent
+	b.mu.Lock()
+	// The JSON array adds a comma per event.
+	size := len(encoded) + 1
+	if len(b.events) > 0 && b.size+size > b.config.MaxBytes {
+		full = append(full, b.take())
+	}
+	b.events = append(b.events, batchedEvent{
+		// The batch is sent after the producer moved on.
+		ctx:     context.WithoutCancel(ctx),
+		event:   e,
+		encoded: encoded,
+		start:   time.Now(),
+	})
+	b.size += size
+	if len(b.events) >= b.config.MaxEvents || b.size >= b.config.MaxBytes {
+		full = append(full, b.take())
+	} else if len(b.events) == 1 {
+		generation := b.generation
+		b.timer = time.AfterFunc(b.config.Window, func() {
+			b.flushGeneration(generation)
+		})
+	}
+	b.mu.Unlock()
+
+	for _, events := range full {
+		b.send(events)
+	}
+	return nil
+}
+
+// flush sends the buffered events.
+func (b *batcher) flush() {
+	b.mu.Lock()
+	events := b.take()
+	b.mu.Unlock()
+	if len(events) > 0 {
+		b.send(events)
+	}
+}
+
+func (b *batcher) flushGeneration(generation uint64) {
+	b.mu.Lock()
+	if generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	events := b.take()
+	b.mu.Unlock()
+	if len(events) > 0 {
+		b.send(events)
+	}
+}
+
+// take returns the buffered events and starts a new batch. b.mu must be held.
+func (b *batcher) take() []batchedEvent {
+	events := b.events
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.events = nil
+	b.size = 0
+	b.generation++
+	return events
+}
+
+// sendBatch sends a batch of events to the sink in the
+// application/cloudevents-batch+json content type, and reports the result of
+// the request for each event.
+func (c *client) sendBatch(events []batchedEvent) {
+	ctx := events[0].ctx
+	var result protocol.Result
+	var mismatch *atomic.Bool
+	if c.audience != nil && c.oidcServiceAccountName != nil {
+		ctx, result = c.withAuthHeader(ctx)
+	}
+	if result == nil {
+		if c.audience != nil {
+			ctx, mismatch = withAudienceMismatchDetection(ctx)
+		}
+		result = c.postBatch(ctx, events)
+	}
+
+	for _, e := range events {
+		c.reportMetrics(e.ctx, e.event, result)
+		c.logEvent(e.ctx, e.event, result, time.Since(e.start))
+	}
+	if mismatch != nil && mismatch.Load() {
+		c.reportAudienceMismatch(ctx, events[0].event)
+	}
+}
+
+func (c *client) postBatch(ctx context.Context, events []batchedEvent) protocol.Result {
+	var body bytes.Buffer
+	body.WriteByte('[')
+	for i, e := range events {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(e.encoded)
+	}
+	body.WriteByte(']')
+
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, c.batchTarget, &body)
+	if err != nil {
+		return protocol.NewReceipt(false, "%w", err)
+	}
+	for key, values := range http.HeaderFrom(ctx) {
+		req.Header[key] = values
+	}
+	if c.namespace != "" {
+		req.Header.Set(apis.KnNamespaceHeader, c.namespace)
+	}
+	req.Header.Set(http.ContentType, event.ApplicationCloudEventsBatchJSON)
+
+	resp, err := c.batchClient.Do(req)
+	if err != nil {
+		return protocol.NewReceipt(false, "%w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 == 2 {
+		return http.NewResult(resp.StatusCode, "%w", protocol.ResultACK)
+	}
+	return http.NewResult(resp.StatusCode, "%w", protocol.ResultNACK)
+}
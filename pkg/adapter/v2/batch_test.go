@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+)
+
+func batchEvent(id string) cloudevents.Event {
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	e.SetSource("unit/test")
+	e.SetType("unit.type")
+	return e
+}
+
+// batchRecorder records the ids of the events of the batches sent.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]string
+	sent    chan struct{}
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{sent: make(chan struct{}, 10)}
+}
+
+func (r *batchRecorder) send(events []batchedEvent) {
+	ids := make([]string, 0, len(events))
+	for _, e := range events {
+		ids = append(ids, e.event.ID())
+	}
+	r.mu.Lock()
+	r.batches = append(r.batches, ids)
+	r.mu.Unlock()
+	r.sent <- struct{}{}
+}
+
+func (r *batchRecorder) get() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+func TestBatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("max events", func(t *testing.T) {
+		r := newBatchRecorder()
+		b := newBatcher(BatchConfig{MaxEvents: 2, MaxBytes: DefaultBatchMaxBytes, Window: time.Hour}, r.send)
+		for i := 1; i <= 5; i++ {
+			if err := b.add(ctx, batchEvent(strconv.Itoa(i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if diff := cmp.Diff([][]string{{"1", "2"}, {"3", "4"}}, r.get()); diff != "" {
+			t.Error("Unexpected batches (-want, +got):", diff)
+		}
+		b.flush()
+		if diff := cmp.Diff([][]string{{"1", "2"}, {"3", "4"}, {"5"}}, r.get()); diff != "" {
+			t.Error("Unexpected batches after flush (-want, +got):", diff)
+		}
+	})
+
+	t.Run("max bytes", func(t *testing.T) {
+		r := newBatchRecorder()
+		e := batchEvent("1")
+		encoded, _ := e.MarshalJSON()
+		// Two events fit in a batch, not three.
+		b := newBatcher(BatchConfig{MaxEvents: 10, MaxBytes: 2*len(encoded) + 3, Window: time.Hour}, r.send)
+		for i := 1; i <= 3; i++ {
+			if err := b.add(ctx, batchEvent(strconv.Itoa(i))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if diff := cmp.Diff([][]string{{"1", "2"}}, r.get()); diff != "" {
+			t.Error("Unexpected batches (-want, +got):", diff)
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		r := newBatchRecorder()
+		b := newBatcher(BatchConfig{MaxEvents: 10, MaxBytes: DefaultBatchMaxBytes, Window: 10 * time.Millisecond}, r.send)
+		if err := b.add(ctx, batchEvent("1")); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.add(ctx, batchEvent("2")); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-r.sent:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the batch to be sent")
+		}
+		if diff := cmp.Diff([][]string{{"1", "2"}}, r.get()); diff != "" {
+			t.Error("Unexpected batches (-want, +got):", diff)
+		}
+	})
+}
+
+func TestNewClient_batch(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		gotIDs      [][]string
+		contentType string
+		namespace   string
+	)
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		events, err := http.NewEventsFromHTTPRequest(r)
+		if err != nil {
+			t.Error(err)
+		}
+		ids := make([]string, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.ID())
+		}
+		mu.Lock()
+		gotIDs = append(gotIDs, ids)
+		contentType = r.Header.Get("Content-Type")
+		namespace = r.Header.Get("Kn-Namespace")
+		mu.Unlock()
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer server.Close()
+
+	reporter := &mockReporter{}
+	c, err := NewClient(ClientConfig{
+		Env: &EnvConfig{
+			Namespace:          "ns",
+			Sink:               server.URL,
+			EnvSinkTimeout:     "5",
+			SinkBatchMaxEvents: "3",
+			SinkBatchWindow:    "PT1H",
+		},
+		Reporter: reporter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		if result := c.Send(context.Background(), batchEvent(strconv.Itoa(i))); !cloudevents.IsACK(result) {
+			t.Fatal(result)
+		}
+	}
+	c.(*client).flushBatch()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff([][]string{{"1", "2", "3"}, {"4"}}, gotIDs); diff != "" {
+		t.Error("Unexpected batches (-want, +got):", diff)
+	}
+	if !strings.HasPrefix(contentType, event.ApplicationCloudEventsBatchJSON) {
+		t.Errorf("Expected the %s content type, got %q", event.ApplicationCloudEventsBatchJSON, contentType)
+	}
+	if namespace != "ns" {
+		t.Errorf("Expected the Kn-Namespace header to be ns, got %q", namespace)
+	}
+	if reporter.eventCount != 4 {
+		t.Errorf("Expected 4 events to be reported, got %d", reporter.eventCount)
+	}
+}
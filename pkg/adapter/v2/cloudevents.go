@@ -23,6 +23,7 @@ import (
 	"net"
 	nethttp "net/http"
 	"net/url"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -31,21 +32,30 @@ import (
 
 	"knative.dev/eventing/pkg/auth"
 
+	ceobsclient "github.com/cloudevents/sdk-go/observability/opencensus/v2/client"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	ceclient "github.com/cloudevents/sdk-go/v2/client"
 	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracing/propagation/tracecontextb3"
 
 	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
 	"knative.dev/eventing/pkg/apis"
+	"knative.dev/eventing/pkg/ceoverrides"
 	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	"knative.dev/eventing/pkg/observability"
 	obsclient "knative.dev/eventing/pkg/observability/client"
+	"knative.dev/eventing/pkg/signing"
 )
 
 type closeIdler interface {
@@ -137,8 +147,13 @@ func GetClientConfig(ctx context.Context) ClientConfig {
 }
 
 func NewClient(cfg ClientConfig) (Client, error) {
+	base := nethttp.DefaultTransport.(*nethttp.Transport).Clone()
+	// Honor HTTP_PROXY, HTTPS_PROXY and NO_PROXY, never proxying requests to
+	// in-cluster sinks.
+	base.Proxy = kncloudevents.ProxyConfigFromEnvironment().ProxyFunc()
+
 	transport := &ochttp.Transport{
-		Base:        nethttp.DefaultTransport.(*nethttp.Transport),
+		Base:        base,
 		Propagation: tracecontextb3.TraceContextEgress,
 	}
 
@@ -168,6 +183,13 @@ func NewClient(cfg ClientConfig) (Client, error) {
 				return network.DialTLSWithBackOff(ctx, net, addr, tlsConfig)
 			}
 
+			// Requests tunneled through a proxy don't use DialTLSContext.
+			tlsConfig, err := eventingtls.GetTLSClientConfig(clientConfig)
+			if err != nil {
+				return nil, err
+			}
+			httpsTransport.TLSClientConfig = tlsConfig
+
 			transport = &ochttp.Transport{
 				Base:        httpsTransport,
 				Propagation: tracecontextb3.TraceContextEgress,
@@ -185,7 +207,18 @@ func NewClient(cfg ClientConfig) (Client, error) {
 		pOpts = append(pOpts, http.WithHeader(apis.KnNamespaceHeader, cfg.Env.GetNamespace()))
 	}
 
-	httpClient := nethttp.Client{Transport: roundTripperDecorator(transport)}
+	var roundTripper nethttp.RoundTripper = &audienceMismatchRoundTripper{base: transport}
+	var retryAfterMax *time.Duration
+	if cfg.Env != nil {
+		if retryAfterMax = cfg.Env.GetRetryAfterMax(); retryAfterMax != nil {
+			roundTripper = &retryAfterRoundTripper{base: roundTripper}
+		}
+	}
+	if cfg.Env != nil {
+		roundTripper = kncloudevents.NewCompressingRoundTripper(cfg.Env.GetSinkContentEncoding(), roundTripper)
+	}
+
+	httpClient := nethttp.Client{Transport: roundTripperDecorator(roundTripper)}
 
 	// Important: prepend HTTP client option to make sure that other options are applied to this
 	// client and not to the default client.
@@ -194,6 +227,11 @@ func NewClient(cfg ClientConfig) (Client, error) {
 	// Make sure that explicitly set options have priority
 	opts := append(pOpts, cfg.Options...)
 
+	overrides, err := ceoverrides.Compile(ceOverrides)
+	if err != nil {
+		return nil, err
+	}
+
 	ceClient, err := newClientHTTPObserved(opts, nil)
 
 	if cfg.CrStatusEventClient == nil {
@@ -206,16 +244,34 @@ func NewClient(cfg ClientConfig) (Client, error) {
 	client := &client{
 		ceClient:            ceClient,
 		closeIdler:          transport.Base.(*nethttp.Transport),
-		ceOverrides:         ceOverrides,
+		overrides:           overrides,
 		reporter:            cfg.Reporter,
 		crStatusEventClient: cfg.CrStatusEventClient,
 		oidcTokenProvider:   cfg.TokenProvider,
 		scheme:              "http",
+		eventLogger:         newEventLogger("", 0),
+		retryAfterMax:       retryAfterMax,
 	}
 
 	if cfg.Env != nil {
+		client.eventLogger = newEventLogger(cfg.Env.GetSink(), cfg.Env.GetEventLogSamplingRate())
 		client.audience = cfg.Env.GetAudience()
 		client.oidcServiceAccountName = cfg.Env.GetOIDCServiceAccountName()
+		client.traceContextInjection = cfg.Env.GetTraceContextInjection()
+		client.signingKey, err = cfg.Env.GetSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		if batch := cfg.Env.GetSinkBatch(); batch != nil && cfg.Env.GetSink() != "" {
+			batchClient := httpClient
+			if sinkWait := cfg.Env.GetSinktimeout(); sinkWait > 0 {
+				batchClient.Timeout = time.Duration(sinkWait) * time.Second
+			}
+			client.batchClient = &batchClient
+			client.batchTarget = cfg.Env.GetSink()
+			client.namespace = cfg.Env.GetNamespace()
+			client.batcher = newBatcher(*batch, client.sendBatch)
+		}
 		sinkURI := cfg.Env.GetSink()
 		if sinkURI != "" {
 			parsedUrl, err := url.Parse(sinkURI)
@@ -243,7 +299,7 @@ func setTimeOut(duration time.Duration) http.Option {
 
 type client struct {
 	ceClient               cloudevents.Client
-	ceOverrides            *duckv1.CloudEventOverrides
+	overrides              *ceoverrides.Overrides
 	reporter               source.StatsReporter
 	crStatusEventClient    *crstatusevent.CRStatusEventClient
 	closeIdler             closeIdler
@@ -251,17 +307,52 @@ type client struct {
 	oidcTokenProvider      *auth.OIDCTokenProvider
 	audience               *string
 	oidcServiceAccountName *types.NamespacedName
+	eventLogger            *eventLogger
+	signingKey             *signing.Key
+	retryAfterMax          *time.Duration
+	traceContextInjection  bool
+
+	// batcher, when set, buffers the events sent, which are sent to
+	// batchTarget in batches with batchClient.
+	batcher     *batcher
+	batchClient *nethttp.Client
+	batchTarget string
+	namespace   string
 }
 
 func (c *client) CloseIdleConnections() {
 	c.closeIdler.CloseIdleConnections()
 }
 
+// flushBatch sends the events waiting for their batch to fill, if batching
+// is enabled.
+func (c *client) flushBatch() {
+	if c.batcher != nil {
+		c.batcher.flush()
+	}
+}
+
 var _ cloudevents.Client = (*client)(nil)
 
 // Send implements client.Send
 func (c *client) Send(ctx context.Context, out event.Event) protocol.Result {
 	c.applyOverrides(&out)
+	if c.traceContextInjection {
+		var endSpan func()
+		ctx, endSpan = injectTraceContext(ctx, &out)
+		defer endSpan()
+	}
+	if err := c.sign(&out); err != nil {
+		return err
+	}
+	// A batched event is acknowledged once buffered, the result of its
+	// delivery is only reported in the metrics and logs.
+	if c.batcher != nil {
+		if err := c.batcher.add(ctx, out); err != nil {
+			return protocol.NewReceipt(false, "failed to batch the event: %w", err)
+		}
+		return nil
+	}
 	var err error
 
 	if c.audience != nil && c.oidcServiceAccountName != nil {
@@ -271,14 +362,34 @@ func (c *client) Send(ctx context.Context, out event.Event) protocol.Result {
 		}
 	}
 
+	var mismatch *atomic.Bool
+	if c.audience != nil {
+		ctx, mismatch = withAudienceMismatchDetection(ctx)
+	}
+	if c.retryAfterMax != nil {
+		ctx = withRetryAfter(ctx, *c.retryAfterMax)
+	}
+	start := time.Now()
 	res := c.ceClient.Send(ctx, out)
 	c.reportMetrics(ctx, out, res)
+	if mismatch != nil && mismatch.Load() {
+		c.reportAudienceMismatch(ctx, out)
+	}
+	c.logEvent(ctx, out, res, time.Since(start))
 	return res
 }
 
 // Request implements client.Request
 func (c *client) Request(ctx context.Context, out event.Event) (*event.Event, protocol.Result) {
 	c.applyOverrides(&out)
+	if c.traceContextInjection {
+		var endSpan func()
+		ctx, endSpan = injectTraceContext(ctx, &out)
+		defer endSpan()
+	}
+	if err := c.sign(&out); err != nil {
+		return nil, err
+	}
 	var err error
 
 	if c.audience != nil && c.oidcServiceAccountName != nil {
@@ -288,8 +399,20 @@ func (c *client) Request(ctx context.Context, out event.Event) (*event.Event, pr
 		}
 	}
 
+	var mismatch *atomic.Bool
+	if c.audience != nil {
+		ctx, mismatch = withAudienceMismatchDetection(ctx)
+	}
+	if c.retryAfterMax != nil {
+		ctx = withRetryAfter(ctx, *c.retryAfterMax)
+	}
+	start := time.Now()
 	resp, res := c.ceClient.Request(ctx, out)
 	c.reportMetrics(ctx, out, res)
+	if mismatch != nil && mismatch.Load() {
+		c.reportAudienceMismatch(ctx, out)
+	}
+	c.logEvent(ctx, out, res, time.Since(start))
 	return resp, res
 }
 
@@ -299,20 +422,27 @@ func (c *client) StartReceiver(ctx context.Context, fn interface{}) error {
 }
 
 func (c *client) applyOverrides(event *cloudevents.Event) {
-	if c.ceOverrides != nil && c.ceOverrides.Extensions != nil {
-		for n, v := range c.ceOverrides.Extensions {
-			event.SetExtension(n, v)
-		}
+	c.overrides.Apply(event)
+}
+
+// sign signs the event when a signing key is configured. It must run after
+// applyOverrides so that the signature covers the final event.
+func (c *client) sign(event *cloudevents.Event) error {
+	if c.signingKey == nil {
+		return nil
 	}
+	return signing.Sign(event, *c.signingKey)
 }
 
-func (c *client) reportMetrics(ctx context.Context, event cloudevents.Event, result protocol.Result) {
-	if c.reporter == nil {
-		return
+func (c *client) logEvent(ctx context.Context, out event.Event, result protocol.Result, latency time.Duration) {
+	if c.eventLogger != nil {
+		c.eventLogger.log(ctx, out, result, latency)
 	}
+}
 
+func (c *client) reportArgs(ctx context.Context, event cloudevents.Event) *source.ReportArgs {
 	tags := MetricTagFromContext(ctx)
-	reportArgs := &source.ReportArgs{
+	return &source.ReportArgs{
 		Namespace:     tags.Namespace,
 		EventSource:   event.Source(),
 		EventType:     event.Type(),
@@ -320,6 +450,14 @@ func (c *client) reportMetrics(ctx context.Context, event cloudevents.Event, res
 		ResourceGroup: tags.ResourceGroup,
 		EventScheme:   c.scheme,
 	}
+}
+
+func (c *client) reportMetrics(ctx context.Context, event cloudevents.Event, result protocol.Result) {
+	if c.reporter == nil {
+		return
+	}
+
+	reportArgs := c.reportArgs(ctx, event)
 
 	var rres *http.RetriesResult
 	if cloudevents.ResultAs(result, &rres) {
@@ -367,6 +505,116 @@ func (c *client) reportError(reportArgs *source.ReportArgs, result protocol.Resu
 	c.reporter.ReportEventCount(reportArgs, 0)
 }
 
+// reportAudienceMismatch reports that the sink rejected the OIDC token of the
+// source because it was issued for another audience, usually because the
+// audience of the sink changed.
+func (c *client) reportAudienceMismatch(ctx context.Context, event cloudevents.Event) {
+	audience := *c.audience
+	logging.FromContext(ctx).Warnw("The sink rejected the OIDC token because of an audience mismatch",
+		zap.String("audience", audience))
+
+	if c.reporter != nil {
+		_ = c.reporter.ReportSinkAudienceMismatch(c.reportArgs(ctx, event))
+	}
+	c.crStatusEventClient.ReportSinkAudienceMismatch(ctx, audience)
+}
+
+// injectTraceContext records a span with the details of the source of an
+// event sent without the traceparent extension, starting a new trace unless
+// ctx already carries one, and sets the extension to it so that the traces of
+// the delivery of the event downstream aren't orphaned. The returned function
+// ends the span.
+func injectTraceContext(ctx context.Context, event *cloudevents.Event) (context.Context, func()) {
+	if _, ok := extensions.GetDistributedTracingExtension(*event); ok {
+		return ctx, func() {}
+	}
+
+	tags := MetricTagFromContext(ctx)
+	ctx, span := trace.StartSpan(ctx, fmt.Sprintf("%s/%s/%s create", tags.ResourceGroup, tags.Namespace, tags.Name),
+		trace.WithSpanKind(trace.SpanKindClient))
+	span.AddAttributes(observability.K8sAttributes(tags.Name, tags.Namespace, tags.ResourceGroup)...)
+	span.AddAttributes(ceobsclient.EventTraceAttributes(event)...)
+
+	sc := span.SpanContext()
+	extensions.DistributedTracingExtension{
+		TraceParent: fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, sc.TraceOptions),
+	}.AddTracingAttributes(event)
+	return ctx, span.End
+}
+
+type retryAfterKey struct{}
+
+// retryAfterState is the earliest time the next retry of a request can be
+// sent, as requested by the Retry-After header of a response to it. It isn't
+// guarded as the retries of a request are sent sequentially.
+type retryAfterState struct {
+	max       time.Duration
+	notBefore time.Time
+}
+
+// withRetryAfter returns a context in which the retries of the requests sent
+// with it wait for the duration requested by the Retry-After header of a 429
+// or 503 response, bounded by retryAfterMax.
+func withRetryAfter(ctx context.Context, retryAfterMax time.Duration) context.Context {
+	return context.WithValue(ctx, retryAfterKey{}, &retryAfterState{max: retryAfterMax})
+}
+
+// retryAfterRoundTripper delays the retries of a request by the duration
+// requested by a Retry-After header, as the CloudEvents client retries with
+// its own backoff only. The retry is sent after the longest of both delays.
+type retryAfterRoundTripper struct {
+	base nethttp.RoundTripper
+}
+
+func (rt *retryAfterRoundTripper) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	state, ok := req.Context().Value(retryAfterKey{}).(*retryAfterState)
+	if !ok {
+		return rt.base.RoundTrip(req)
+	}
+	if wait := time.Until(state.notBefore); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	resp, err := rt.base.RoundTrip(req)
+	if err == nil {
+		if retryAfter := kncloudevents.RetryAfterDuration(resp, &state.max); retryAfter > 0 {
+			state.notBefore = time.Now().Add(retryAfter)
+		}
+	}
+	return resp, err
+}
+
+type audienceMismatchKey struct{}
+
+// withAudienceMismatchDetection returns a context flagging the requests sent
+// with it that are rejected because of an OIDC audience mismatch.
+func withAudienceMismatchDetection(ctx context.Context) (context.Context, *atomic.Bool) {
+	mismatch := &atomic.Bool{}
+	return context.WithValue(ctx, audienceMismatchKey{}, mismatch), mismatch
+}
+
+// audienceMismatchRoundTripper detects the responses rejecting a request
+// because of an OIDC audience mismatch, as the CloudEvents result of a request
+// doesn't carry the response headers.
+type audienceMismatchRoundTripper struct {
+	base nethttp.RoundTripper
+}
+
+func (rt *audienceMismatchRoundTripper) RoundTrip(req *nethttp.Request) (*nethttp.Response, error) {
+	resp, err := rt.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == nethttp.StatusUnauthorized && auth.IsAudienceMismatch(resp.Header) {
+		if mismatch, ok := req.Context().Value(audienceMismatchKey{}).(*atomic.Bool); ok {
+			mismatch.Store(true)
+		}
+	}
+	return resp, err
+}
+
 // MetricTag context
 type MetricTag struct {
 	Name          string
@@ -17,15 +17,20 @@ limitations under the License.
 package adapter
 
 import (
+	"bytes"
 	"context"
 	nethttp "net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
 	v2client "github.com/cloudevents/sdk-go/v2/client"
+	"github.com/cloudevents/sdk-go/v2/extensions"
 	"github.com/cloudevents/sdk-go/v2/protocol/http"
 	cetest "github.com/cloudevents/sdk-go/v2/test"
 	"github.com/stretchr/testify/assert"
@@ -35,13 +40,18 @@ import (
 	. "knative.dev/pkg/reconciler/testing"
 
 	"knative.dev/eventing/pkg/adapter/v2/test"
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/ceoverrides"
 	"knative.dev/eventing/pkg/eventingtls/eventingtlstesting"
+	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/metrics/source"
+	"knative.dev/eventing/pkg/signing"
 )
 
 type mockReporter struct {
-	eventCount      int
-	retryEventCount int
+	eventCount                int
+	retryEventCount           int
+	sinkAudienceMismatchCount int
 }
 
 var (
@@ -58,6 +68,11 @@ func (r *mockReporter) ReportRetryEventCount(args *source.ReportArgs, responseCo
 	return nil
 }
 
+func (r *mockReporter) ReportSinkAudienceMismatch(args *source.ReportArgs) error {
+	r.sinkAudienceMismatchCount++
+	return nil
+}
+
 func TestNewCloudEventsClient_send(t *testing.T) {
 	demoEvent := func() *cloudevents.Event {
 		event := cloudevents.NewEvent()
@@ -376,6 +391,297 @@ func TestTLS(t *testing.T) {
 	}
 }
 
+func TestNewCloudEventsClient_sign(t *testing.T) {
+	key := signing.Key{ID: "key-1", Secret: []byte("s3cr3t")}
+	innerClient := &test.TestCloudEventsClient{}
+	overrides, err := ceoverrides.Compile(&duckv1.CloudEventOverrides{Extensions: map[string]string{
+		"foo": "bar",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{
+		ceClient:   innerClient,
+		overrides:  overrides,
+		reporter:   &mockReporter{},
+		signingKey: &key,
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetID("abc-123")
+	event.SetSource("unit/test")
+	event.SetType("unit.type")
+	if result := c.Send(context.TODO(), event); !cloudevents.IsACK(result) {
+		t.Fatal(result)
+	}
+
+	validateSent(t, innerClient, "unit.type")
+	if err := signing.Verify(innerClient.Sent()[0], signing.NewKeyring(key)); err != nil {
+		t.Error("Expected sent event to carry a valid signature, got", err)
+	}
+}
+
+func TestNewCloudEventsClient_templatedOverrides(t *testing.T) {
+	innerClient := &test.TestCloudEventsClient{}
+	overrides, err := ceoverrides.Compile(&duckv1.CloudEventOverrides{Extensions: map[string]string{
+		"subject": "{{.type}}-{{.id}}",
+		"origin":  "{{ .source }}",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{
+		ceClient:  innerClient,
+		overrides: overrides,
+		reporter:  &mockReporter{},
+	}
+
+	event := cloudevents.NewEvent()
+	event.SetID("abc-123")
+	event.SetSource("unit/test")
+	event.SetType("unit.type")
+	if result := c.Send(context.TODO(), event); !cloudevents.IsACK(result) {
+		t.Fatal(result)
+	}
+
+	sent := innerClient.Sent()[0]
+	if got, want := sent.Subject(), "unit.type-abc-123"; got != want {
+		t.Errorf("Subject() = %q, want %q", got, want)
+	}
+	if got, want := sent.Extensions()["origin"], "unit/test"; got != want {
+		t.Errorf("origin extension = %v, want %q", got, want)
+	}
+}
+
+func TestNewCloudEventsClient_traceContextInjection(t *testing.T) {
+	const existing = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	tests := map[string]struct {
+		enabled     bool
+		traceParent string
+		want        func(string) bool
+	}{
+		"disabled": {
+			want: func(tp string) bool { return tp == "" },
+		},
+		"enabled": {
+			enabled: true,
+			want:    func(tp string) bool { return strings.HasPrefix(tp, "00-") && tp != existing },
+		},
+		"enabled, existing trace context": {
+			enabled:     true,
+			traceParent: existing,
+			want:        func(tp string) bool { return tp == existing },
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			innerClient := &test.TestCloudEventsClient{}
+			c := &client{
+				ceClient:              innerClient,
+				reporter:              &mockReporter{},
+				traceContextInjection: tc.enabled,
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("abc-123")
+			event.SetSource("unit/test")
+			event.SetType("unit.type")
+			if tc.traceParent != "" {
+				event.SetExtension(extensions.TraceParentExtension, tc.traceParent)
+			}
+			if result := c.Send(context.TODO(), event); !cloudevents.IsACK(result) {
+				t.Fatal(result)
+			}
+
+			var got string
+			if dt, ok := extensions.GetDistributedTracingExtension(innerClient.Sent()[0]); ok {
+				got = dt.TraceParent
+			}
+			if !tc.want(got) {
+				t.Errorf("Unexpected traceparent %q", got)
+			}
+		})
+	}
+}
+
+func TestNewCloudEventsClient_invalidOverrides(t *testing.T) {
+	_, err := NewCloudEventsClient(fakeURL, &duckv1.CloudEventOverrides{Extensions: map[string]string{
+		"subject": "{{.type",
+	}}, &mockReporter{})
+	if err == nil {
+		t.Error("Expected an error for an unterminated template")
+	}
+}
+
+func TestNewClient_compression(t *testing.T) {
+	var gotEncoding string
+	var gotEvent *cloudevents.Event
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		gotEncoding = r.Header.Get("Content-Encoding")
+		if err := kncloudevents.DecompressRequest(r, kncloudevents.DefaultMaxDecompressedSize); err != nil {
+			t.Error(err)
+		}
+		message := http.NewMessageFromHttpRequest(r)
+		defer message.Finish(nil)
+		event, err := binding.ToEvent(context.Background(), message)
+		if err != nil {
+			t.Error(err)
+		}
+		gotEvent = event
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer server.Close()
+
+	c, err := NewClient(ClientConfig{
+		Env: &EnvConfig{
+			Sink:                server.URL,
+			SinkContentEncoding: "gzip",
+		},
+		Reporter: &mockReporter{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]string{"payload": strings.Repeat("knative", 1024)}
+	event := cloudevents.NewEvent()
+	event.SetID("abc-123")
+	event.SetSource("unit/test")
+	event.SetType("unit.type")
+	_ = event.SetData(cloudevents.ApplicationJSON, data)
+
+	if result := c.Send(context.TODO(), event); !cloudevents.IsACK(result) {
+		t.Fatal(result)
+	}
+
+	if gotEncoding != "gzip" {
+		t.Errorf("Expected gzip Content-Encoding, got %q", gotEncoding)
+	}
+	if gotEvent == nil || !bytes.Equal(gotEvent.Data(), event.Data()) {
+		t.Error("Expected the decompressed event data to match the sent event data")
+	}
+}
+
+func TestNewClient_audienceMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       func(nethttp.Header)
+		wantMismatch int
+	}{{
+		name:         "audience mismatch",
+		header:       auth.SetAudienceMismatch,
+		wantMismatch: 1,
+	}, {
+		name:   "other unauthorized error",
+		header: func(nethttp.Header) {},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				tc.header(w.Header())
+				w.WriteHeader(nethttp.StatusUnauthorized)
+			}))
+			defer server.Close()
+
+			reporter := &mockReporter{}
+			c, err := NewClient(ClientConfig{
+				Env: &EnvConfig{
+					Sink:     server.URL,
+					Audience: pointer.String("sink-audience"),
+				},
+				Reporter: reporter,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("abc-123")
+			event.SetSource("unit/test")
+			event.SetType("unit.type")
+
+			if result := c.Send(context.TODO(), event); cloudevents.IsACK(result) {
+				t.Fatal("Expected the event to be rejected")
+			}
+			if reporter.sinkAudienceMismatchCount != tc.wantMismatch {
+				t.Errorf("Expected %d audience mismatch reported, got %d", tc.wantMismatch, reporter.sinkAudienceMismatchCount)
+			}
+		})
+	}
+}
+
+func TestNewClient_retryAfter(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryAfterMax string
+		wantMinWait   time.Duration
+		wantMaxWait   time.Duration
+	}{{
+		name:          "Retry-After respected",
+		retryAfterMax: "PT5S",
+		wantMinWait:   time.Second,
+	}, {
+		name:          "Retry-After bounded",
+		retryAfterMax: "PT0.5S",
+		wantMinWait:   500 * time.Millisecond,
+		wantMaxWait:   time.Second,
+	}, {
+		name:          "Retry-After ignored",
+		retryAfterMax: "PT0S",
+		wantMaxWait:   500 * time.Millisecond,
+	}, {
+		name:        "Retry-After max unset",
+		wantMaxWait: 500 * time.Millisecond,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []time.Time
+			server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+				requests = append(requests, time.Now())
+				if len(requests) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(nethttp.StatusTooManyRequests)
+					return
+				}
+				w.WriteHeader(nethttp.StatusAccepted)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(ClientConfig{
+				Env: &EnvConfig{
+					Sink:          server.URL,
+					RetryAfterMax: tc.retryAfterMax,
+				},
+				Reporter: &mockReporter{},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event := cloudevents.NewEvent()
+			event.SetID("abc-123")
+			event.SetSource("unit/test")
+			event.SetType("unit.type")
+
+			ctx := cloudevents.ContextWithRetriesLinearBackoff(context.TODO(), 10*time.Millisecond, 1)
+			if result := c.Send(ctx, event); !cloudevents.IsACK(result) {
+				t.Fatal("Expected the event to be accepted, got", result)
+			}
+			if len(requests) != 2 {
+				t.Fatalf("Expected 2 requests, got %d", len(requests))
+			}
+			wait := requests[1].Sub(requests[0])
+			if wait < tc.wantMinWait {
+				t.Errorf("Expected the retry to wait at least %v, waited %v", tc.wantMinWait, wait)
+			}
+			if tc.wantMaxWait > 0 && wait >= tc.wantMaxWait {
+				t.Errorf("Expected the retry to wait less than %v, waited %v", tc.wantMaxWait, wait)
+			}
+		})
+	}
+}
+
 func validateSent(t *testing.T, ce *test.TestCloudEventsClient, want string) {
 	if got := len(ce.Sent()); got != 1 {
 		t.Error("Expected 1 event to be sent, got", got)
@@ -19,8 +19,10 @@ import (
 	"encoding/json"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rickb777/date/period"
 	"k8s.io/apimachinery/pkg/types"
 
 	"go.uber.org/zap"
@@ -31,6 +33,9 @@ import (
 	"knative.dev/pkg/metrics"
 	"knative.dev/pkg/tracing"
 	tracingconfig "knative.dev/pkg/tracing/config"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/signing"
 )
 
 type EnvConfigConstructor func() EnvConfigAccessor
@@ -50,6 +55,15 @@ const (
 	EnvConfigTracingConfig        = "K_TRACING_CONFIG"
 	EnvConfigLeaderElectionConfig = "K_LEADER_ELECTION_CONFIG"
 	EnvSinkTimeout                = "K_SINK_TIMEOUT"
+	EnvEventLogSamplingRate       = "K_EVENT_LOG_SAMPLING_RATE"
+	EnvSigningKeyFile             = "K_SIGNING_KEY_FILE"
+	EnvSigningKeyID               = "K_SIGNING_KEY_ID"
+	EnvSinkContentEncoding        = "K_SINK_CONTENT_ENCODING"
+	EnvRetryAfterMax              = "K_RETRY_AFTER_MAX"
+	EnvTraceContextInjection      = "K_TRACE_CONTEXT_INJECTION"
+	EnvSinkBatchMaxEvents         = "K_SINK_BATCH_MAX_EVENTS"
+	EnvSinkBatchMaxBytes          = "K_SINK_BATCH_MAX_BYTES"
+	EnvSinkBatchWindow            = "K_SINK_BATCH_WINDOW"
 )
 
 // EnvConfig is the minimal set of configuration parameters
@@ -107,6 +121,49 @@ type EnvConfig struct {
 	// Time in seconds to wait for sink to respond
 	EnvSinkTimeout string `envconfig:"K_SINK_TIMEOUT"`
 
+	// EventLogSamplingRate is the fraction, between 0 and 1, of successfully
+	// sent events that are logged. Failed deliveries are always logged.
+	EventLogSamplingRate string `envconfig:"K_EVENT_LOG_SAMPLING_RATE"`
+
+	// SigningKeyFile is the path of a file containing the key used to sign
+	// outbound events, usually mounted from a Secret. Events are not signed
+	// when empty.
+	SigningKeyFile string `envconfig:"K_SIGNING_KEY_FILE"`
+
+	// SigningKeyID is the id of the signing key, it defaults to the base name
+	// of SigningKeyFile.
+	SigningKeyID string `envconfig:"K_SIGNING_KEY_ID"`
+
+	// SinkContentEncoding is the content encoding used to compress the body
+	// of requests to the sink, "gzip" or "identity" (the default).
+	SinkContentEncoding string `envconfig:"K_SINK_CONTENT_ENCODING"`
+
+	// RetryAfterMax is the upper bound, in ISO 8601 format, of the duration
+	// requested by the Retry-After header of 429 and 503 responses the
+	// retries of a request wait for. "PT0S" ignores the header, which is
+	// also the case when empty.
+	RetryAfterMax string `envconfig:"K_RETRY_AFTER_MAX"`
+
+	// TraceContextInjection is "enabled" to start a new trace for the events
+	// sent without the traceparent extension, and set the extension to it,
+	// so that the traces of their delivery downstream aren't orphaned.
+	TraceContextInjection string `envconfig:"K_TRACE_CONTEXT_INJECTION"`
+
+	// SinkBatchMaxEvents is the maximum number of events sent to the sink
+	// at once, in the application/cloudevents-batch+json content type. The
+	// events are sent one by one when empty or below 2. A batched event is
+	// acknowledged once buffered, the delivery of its batch isn't retried and
+	// its failure is only reported in the metrics and logs.
+	SinkBatchMaxEvents string `envconfig:"K_SINK_BATCH_MAX_EVENTS"`
+
+	// SinkBatchMaxBytes is the maximum size in bytes of the JSON encoding of
+	// a batch, 1MiB when empty.
+	SinkBatchMaxBytes string `envconfig:"K_SINK_BATCH_MAX_BYTES"`
+
+	// SinkBatchWindow is the maximum duration, in ISO 8601 format, an event
+	// waits for its batch to fill, PT1S when empty.
+	SinkBatchWindow string `envconfig:"K_SINK_BATCH_WINDOW"`
+
 	// cached zap logger
 	logger *zap.SugaredLogger
 }
@@ -150,6 +207,31 @@ type EnvConfigAccessor interface {
 
 	// Get the timeout to apply on a request to a sink
 	GetSinktimeout() int
+
+	// GetEventLogSamplingRate returns the fraction of successfully sent
+	// events that are logged.
+	GetEventLogSamplingRate() float64
+
+	// GetSigningKey returns the key used to sign outbound events, or nil
+	// when signing is disabled.
+	GetSigningKey() (*signing.Key, error)
+
+	// GetSinkContentEncoding returns the content encoding used to compress
+	// requests to the sink.
+	GetSinkContentEncoding() string
+
+	// GetRetryAfterMax returns the upper bound of the duration requested by
+	// Retry-After headers the retries of a request wait for, nil when the
+	// headers are ignored.
+	GetRetryAfterMax() *time.Duration
+
+	// GetTraceContextInjection returns whether a new trace is started for
+	// the events sent without the traceparent extension.
+	GetTraceContextInjection() bool
+
+	// GetSinkBatch returns the configuration of the batching of the events
+	// sent to the sink, nil when the events are sent one by one.
+	GetSinkBatch() *BatchConfig
 }
 
 var _ EnvConfigAccessor = (*EnvConfig)(nil)
@@ -222,6 +304,103 @@ func (e *EnvConfig) GetSinktimeout() int {
 	return -1
 }
 
+func (e *EnvConfig) GetEventLogSamplingRate() float64 {
+	if e.EventLogSamplingRate == "" {
+		return 0
+	}
+	rate, err := strconv.ParseFloat(e.EventLogSamplingRate, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		e.GetLogger().Warnf("Event log sampling rate %q is invalid, default to 0 (only failures are logged)", e.EventLogSamplingRate)
+		return 0
+	}
+	return rate
+}
+
+func (e *EnvConfig) GetSigningKey() (*signing.Key, error) {
+	if e.SigningKeyFile == "" {
+		return nil, nil
+	}
+	key, err := signing.KeyFromFile(e.SigningKeyFile, e.SigningKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (e *EnvConfig) GetSinkContentEncoding() string {
+	if !kncloudevents.IsSupportedContentEncoding(e.SinkContentEncoding) {
+		e.GetLogger().Warnf("Sink content encoding %q is not supported, requests are not compressed", e.SinkContentEncoding)
+		return kncloudevents.ContentEncodingIdentity
+	}
+	if e.SinkContentEncoding == "" {
+		return kncloudevents.ContentEncodingIdentity
+	}
+	return strings.ToLower(e.SinkContentEncoding)
+}
+
+func (e *EnvConfig) GetRetryAfterMax() *time.Duration {
+	if e.RetryAfterMax == "" {
+		return nil
+	}
+	p, err := period.Parse(e.RetryAfterMax)
+	if err != nil || p.IsNegative() {
+		e.GetLogger().Warnf("Retry-After max %q is invalid, Retry-After headers are ignored", e.RetryAfterMax)
+		return nil
+	}
+	retryAfterMax, _ := p.Duration()
+	return &retryAfterMax
+}
+
+func (e *EnvConfig) GetTraceContextInjection() bool {
+	switch strings.ToLower(e.TraceContextInjection) {
+	case "", "disabled":
+		return false
+	case "enabled":
+		return true
+	default:
+		e.GetLogger().Warnf("Trace context injection %q is invalid, it is disabled", e.TraceContextInjection)
+		return false
+	}
+}
+
+func (e *EnvConfig) GetSinkBatch() *BatchConfig {
+	if e.SinkBatchMaxEvents == "" {
+		return nil
+	}
+	maxEvents, err := strconv.Atoi(e.SinkBatchMaxEvents)
+	if err != nil {
+		e.GetLogger().Warnf("Sink batch max events %q is invalid, events are not batched", e.SinkBatchMaxEvents)
+		return nil
+	}
+	if maxEvents < 2 {
+		return nil
+	}
+
+	config := &BatchConfig{
+		MaxEvents: maxEvents,
+		MaxBytes:  DefaultBatchMaxBytes,
+		Window:    DefaultBatchWindow,
+	}
+	if e.SinkBatchMaxBytes != "" {
+		maxBytes, err := strconv.Atoi(e.SinkBatchMaxBytes)
+		if err != nil || maxBytes <= 0 {
+			e.GetLogger().Warnf("Sink batch max bytes %q is invalid, default to %d", e.SinkBatchMaxBytes, DefaultBatchMaxBytes)
+		} else {
+			config.MaxBytes = maxBytes
+		}
+	}
+	if e.SinkBatchWindow != "" {
+		p, err := period.Parse(e.SinkBatchWindow)
+		window, _ := p.Duration()
+		if err != nil || window <= 0 {
+			e.GetLogger().Warnf("Sink batch window %q is invalid, default to %v", e.SinkBatchWindow, DefaultBatchWindow)
+		} else {
+			config.Window = window
+		}
+	}
+	return config
+}
+
 func (e *EnvConfig) SetupTracing(logger *zap.SugaredLogger) (tracing.Tracer, error) {
 	config, err := tracingconfig.JSONToTracingConfig(e.TracingConfigJson)
 	if err != nil {
@@ -289,3 +468,20 @@ func GetSinkTimeout(logger *zap.SugaredLogger) int {
 	}
 	return -1
 }
+
+// GetRetryAfterMax returns the value of the K_RETRY_AFTER_MAX environment
+// variable, or an empty string when it isn't a valid non-negative ISO 8601
+// duration.
+func GetRetryAfterMax(logger *zap.SugaredLogger) string {
+	str := os.Getenv(EnvRetryAfterMax)
+	if str == "" {
+		return ""
+	}
+	if p, err := period.Parse(str); err != nil || p.IsNegative() {
+		if logger != nil {
+			logger.Errorf("%s environment value is invalid. It must be a non-negative ISO 8601 duration. (got %s)", EnvRetryAfterMax, str)
+		}
+		return ""
+	}
+	return str
+}
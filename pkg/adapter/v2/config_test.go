@@ -19,6 +19,8 @@ package adapter
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -26,6 +28,9 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kle "knative.dev/pkg/leaderelection"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/eventing/pkg/signing"
 )
 
 type myEnvConfig struct {
@@ -240,3 +245,216 @@ func TestCACerts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetEventLogSamplingRate(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  float64
+	}{
+		"unset":        {value: "", want: 0},
+		"valid":        {value: "0.25", want: 0.25},
+		"all":          {value: "1", want: 1},
+		"invalid":      {value: "often", want: 0},
+		"out of range": {value: "1.5", want: 0},
+		"negative":     {value: "-0.1", want: 0},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Setenv("K_EVENT_LOG_SAMPLING_RATE", tc.value)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			if got := env.GetEventLogSamplingRate(); got != tc.want {
+				t.Errorf("Expected env.GetEventLogSamplingRate() to be %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGetSigningKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "signing-key")
+	if err := os.WriteFile(keyFile, []byte("s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		file    string
+		id      string
+		want    *signing.Key
+		wantErr bool
+	}{
+		"unset": {},
+		"default id": {
+			file: keyFile,
+			want: &signing.Key{ID: "signing-key", Secret: []byte("s3cr3t")},
+		},
+		"explicit id": {
+			file: keyFile,
+			id:   "key-1",
+			want: &signing.Key{ID: "key-1", Secret: []byte("s3cr3t")},
+		},
+		"missing file": {
+			file:    filepath.Join(dir, "missing"),
+			wantErr: true,
+		},
+		"empty file": {
+			file:    emptyFile,
+			wantErr: true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Setenv("K_SIGNING_KEY_FILE", tc.file)
+			t.Setenv("K_SIGNING_KEY_ID", tc.id)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			got, err := env.GetSigningKey()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error %v, got: %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected signing key (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestGetSinkContentEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":         "identity",
+		"identity": "identity",
+		"gzip":     "gzip",
+		"GZIP":     "gzip",
+		"br":       "identity",
+	}
+	for encoding, want := range tests {
+		t.Run(encoding, func(t *testing.T) {
+			t.Setenv("K_SINK_CONTENT_ENCODING", encoding)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			if got := env.GetSinkContentEncoding(); got != want {
+				t.Errorf("Expected content encoding %q, got %q", want, got)
+			}
+		})
+	}
+}
+
+func TestGetTraceContextInjection(t *testing.T) {
+	tests := map[string]bool{
+		"":         false,
+		"enabled":  true,
+		"disabled": false,
+		"garbage":  false,
+	}
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv("K_TRACE_CONTEXT_INJECTION", value)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			if got := env.GetTraceContextInjection(); got != want {
+				t.Errorf("GetTraceContextInjection() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestGetRetryAfterMax(t *testing.T) {
+	tests := map[string]*time.Duration{
+		"":        nil,
+		"PT0S":    ptr.Duration(0),
+		"PT30S":   ptr.Duration(30 * time.Second),
+		"-PT30S":  nil,
+		"garbage": nil,
+	}
+	for retryAfterMax, want := range tests {
+		t.Run(retryAfterMax, func(t *testing.T) {
+			t.Setenv("K_RETRY_AFTER_MAX", retryAfterMax)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			if diff := cmp.Diff(want, env.GetRetryAfterMax()); diff != "" {
+				t.Error("Unexpected Retry-After max (-want, +got):", diff)
+			}
+
+			wantEnv := retryAfterMax
+			if want == nil {
+				wantEnv = ""
+			}
+			if got := GetRetryAfterMax(nil); got != wantEnv {
+				t.Errorf("Expected %q, got %q", wantEnv, got)
+			}
+		})
+	}
+}
+
+func TestGetSinkBatch(t *testing.T) {
+	tests := map[string]struct {
+		maxEvents string
+		maxBytes  string
+		window    string
+		want      *BatchConfig
+	}{
+		"disabled": {},
+		"single event": {
+			maxEvents: "1",
+		},
+		"invalid max events": {
+			maxEvents: "ten",
+		},
+		"defaults": {
+			maxEvents: "10",
+			want:      &BatchConfig{MaxEvents: 10, MaxBytes: DefaultBatchMaxBytes, Window: DefaultBatchWindow},
+		},
+		"custom": {
+			maxEvents: "100",
+			maxBytes:  "4096",
+			window:    "PT0.5S",
+			want:      &BatchConfig{MaxEvents: 100, MaxBytes: 4096, Window: 500 * time.Millisecond},
+		},
+		"invalid max bytes and window": {
+			maxEvents: "10",
+			maxBytes:  "-1",
+			window:    "1s",
+			want:      &BatchConfig{MaxEvents: 10, MaxBytes: DefaultBatchMaxBytes, Window: DefaultBatchWindow},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			t.Setenv("K_SINK_BATCH_MAX_EVENTS", tc.maxEvents)
+			t.Setenv("K_SINK_BATCH_MAX_BYTES", tc.maxBytes)
+			t.Setenv("K_SINK_BATCH_WINDOW", tc.window)
+
+			var env myEnvConfig
+			if err := envconfig.Process("", &env); err != nil {
+				t.Fatal("Expected no error:", err)
+			}
+
+			if diff := cmp.Diff(tc.want, env.GetSinkBatch()); diff != "" {
+				t.Error("Unexpected batch config (-want, +got):", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	outcomeDelivered = "delivered"
+	outcomeRejected  = "rejected"
+	outcomeFailed    = "failed"
+)
+
+// eventLogger writes a structured log line per sent event, so that a
+// delivery can be correlated with the logs of the other components by its
+// event id. Failed deliveries are always logged, successful ones only with
+// the probability given by the sampling rate.
+type eventLogger struct {
+	sink         string
+	samplingRate float64
+	// sample returns a number in [0.0,1.0), it is replaced in tests.
+	sample func() float64
+}
+
+func newEventLogger(sink string, samplingRate float64) *eventLogger {
+	return &eventLogger{
+		sink:         sink,
+		samplingRate: samplingRate,
+		sample:       rand.Float64,
+	}
+}
+
+func (l *eventLogger) log(ctx context.Context, out event.Event, result protocol.Result, latency time.Duration) {
+	outcome, statusCode, attempts := l.outcome(result)
+	if outcome == outcomeDelivered && (l.samplingRate <= 0 || l.sample() >= l.samplingRate) {
+		return
+	}
+
+	sink := l.sink
+	if target := cecontext.TargetFrom(ctx); target != nil {
+		sink = target.String()
+	}
+	tags := MetricTagFromContext(ctx)
+
+	fields := []interface{}{
+		zap.String("source", tags.Namespace+"/"+tags.Name),
+		zap.String("eventID", out.ID()),
+		zap.String("eventType", out.Type()),
+		zap.String("sink", sink),
+		zap.Int("attempt", attempts),
+		zap.Duration("latency", latency),
+		zap.String("outcome", outcome),
+	}
+	if statusCode != 0 {
+		fields = append(fields, zap.Int("statusCode", statusCode))
+	}
+
+	logger := logging.FromContext(ctx)
+	if outcome == outcomeDelivered {
+		logger.Infow("Event sent", fields...)
+		return
+	}
+	logger.Warnw("Failed to send event", append(fields, zap.Error(result))...)
+}
+
+// outcome returns the outcome of the delivery, the HTTP status code of the
+// last attempt, if any, and the number of attempts.
+func (l *eventLogger) outcome(result protocol.Result) (string, int, int) {
+	attempts := 1
+	var rres *http.RetriesResult
+	if cloudevents.ResultAs(result, &rres) {
+		attempts += rres.Retries
+		result = rres.Result
+	}
+
+	statusCode := 0
+	var res *http.Result
+	if cloudevents.ResultAs(result, &res) {
+		statusCode = res.StatusCode
+	}
+
+	switch {
+	case cloudevents.IsACK(result):
+		return outcomeDelivered, statusCode, attempts
+	case cloudevents.IsNACK(result):
+		return outcomeRejected, statusCode, attempts
+	default:
+		return outcomeFailed, statusCode, attempts
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+	"github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"knative.dev/pkg/logging"
+)
+
+func TestEventLogger(t *testing.T) {
+	event := cloudevents.NewEvent()
+	event.SetID("abc-123")
+	event.SetSource("unit/test")
+	event.SetType("unit.type")
+
+	tests := []struct {
+		name         string
+		samplingRate float64
+		sample       float64
+		target       string
+		result       protocol.Result
+		wantLevel    zapcore.Level
+		wantFields   map[string]interface{}
+	}{{
+		name:   "delivered, not sampled",
+		result: http.NewResult(202, "%w", protocol.ResultACK),
+	}, {
+		name:         "delivered, sampled out",
+		samplingRate: 0.5,
+		sample:       0.7,
+		result:       http.NewResult(202, "%w", protocol.ResultACK),
+	}, {
+		name:         "delivered, sampled",
+		samplingRate: 0.5,
+		sample:       0.2,
+		result:       http.NewResult(202, "%w", protocol.ResultACK),
+		wantLevel:    zapcore.InfoLevel,
+		wantFields: map[string]interface{}{
+			"source":     "ns/name",
+			"eventID":    "abc-123",
+			"eventType":  "unit.type",
+			"sink":       "http://sink.ns.svc.cluster.local",
+			"attempt":    int64(1),
+			"outcome":    outcomeDelivered,
+			"statusCode": int64(202),
+		},
+	}, {
+		name:      "rejected",
+		result:    http.NewResult(400, "%w", protocol.ResultNACK),
+		wantLevel: zapcore.WarnLevel,
+		wantFields: map[string]interface{}{
+			"eventID":    "abc-123",
+			"outcome":    outcomeRejected,
+			"statusCode": int64(400),
+		},
+	}, {
+		name: "rejected after retries",
+		result: http.NewRetriesResult(http.NewResult(503, "%w", protocol.ResultNACK), 2, time.Now(),
+			[]protocol.Result{http.NewResult(503, "%w", protocol.ResultNACK), http.NewResult(503, "%w", protocol.ResultNACK)}),
+		wantLevel: zapcore.WarnLevel,
+		wantFields: map[string]interface{}{
+			"attempt":    int64(3),
+			"outcome":    outcomeRejected,
+			"statusCode": int64(503),
+		},
+	}, {
+		name:      "failed with target from context",
+		target:    "http://other.ns.svc.cluster.local",
+		result:    errors.New("connection refused"),
+		wantLevel: zapcore.WarnLevel,
+		wantFields: map[string]interface{}{
+			"sink":    "http://other.ns.svc.cluster.local",
+			"outcome": outcomeFailed,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			ctx := logging.WithLogger(context.Background(), zap.New(core).Sugar())
+			ctx = ContextWithMetricTag(ctx, &MetricTag{Name: "name", Namespace: "ns"})
+			if tt.target != "" {
+				u, _ := url.Parse(tt.target)
+				ctx = cecontext.WithTarget(ctx, u.String())
+			}
+
+			l := newEventLogger("http://sink.ns.svc.cluster.local", tt.samplingRate)
+			l.sample = func() float64 { return tt.sample }
+			l.log(ctx, event, tt.result, time.Millisecond)
+
+			if tt.wantFields == nil {
+				if logs.Len() != 0 {
+					t.Fatalf("expected no log, got %v", logs.All())
+				}
+				return
+			}
+			if logs.Len() != 1 {
+				t.Fatalf("expected one log, got %v", logs.All())
+			}
+			entry := logs.All()[0]
+			if entry.Level != tt.wantLevel {
+				t.Errorf("expected level %v, got %v", tt.wantLevel, entry.Level)
+			}
+			fields := entry.ContextMap()
+			for k, want := range tt.wantFields {
+				if got := fields[k]; got != want {
+					t.Errorf("field %s: expected %v, got %v", k, want, got)
+				}
+			}
+		})
+	}
+}
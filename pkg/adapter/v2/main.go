@@ -312,6 +312,11 @@ func MainWithInformers(ctx context.Context, component string, env EnvConfigAcces
 	}
 
 	wg.Wait()
+
+	// Send the events still waiting for their batch to fill.
+	if c, ok := eventsClient.(*client); ok {
+		c.flushBatch()
+	}
 }
 
 func ConstructEnvOrDie(ector EnvConfigConstructor) EnvConfigAccessor {
@@ -32,6 +32,17 @@ import (
 	"k8s.io/client-go/tools/record"
 )
 
+const (
+	// SinkAudienceMismatchReason is the reason of the Events telling that the
+	// sink rejected the OIDC token of the source because it was issued for
+	// another audience.
+	SinkAudienceMismatchReason = "SinkAudienceMismatch"
+
+	// SinkAudienceAnnotationKey is the annotation of the SinkAudienceMismatch
+	// Events holding the rejected audience.
+	SinkAudienceAnnotationKey = "sources.knative.dev/sink-audience"
+)
+
 type crStatusEvent struct {
 	Recorder      record.EventRecorder
 	Logf          func(format string, args ...interface{})
@@ -106,6 +117,22 @@ func (c *CRStatusEventClient) ReportCRStatusEvent(ctx context.Context, result pr
 	}
 }
 
+// ReportSinkAudienceMismatch records an Event telling that the sink rejected
+// the OIDC token issued for audience. Unlike the other sink errors it is
+// reported even when sink-event-error-reporting is disabled, as the source
+// reconcilers rely on it to surface the mismatch in the source status.
+func (c *CRStatusEventClient) ReportSinkAudienceMismatch(ctx context.Context, audience string) {
+	a, ok := fromContext(ctx)
+	if !ok {
+		return
+	}
+
+	recorder := a.getRecorder(&ctx, a.kubeEventSink, a.Logf, a.component)
+	recorder.AnnotatedEventf(a.source, map[string]string{SinkAudienceAnnotationKey: audience},
+		corev1.EventTypeWarning, SinkAudienceMismatchReason,
+		"The sink rejected the OIDC token issued for audience %q", audience)
+}
+
 func (a *crStatusEvent) getRecorder(ctx *context.Context, kubeEventSink *record.EventSink, logf func(format string, args ...interface{}), component string) record.EventRecorder {
 	if a.Recorder == nil {
 		eventBroadcaster := record.NewBroadcaster()
@@ -158,6 +158,32 @@ func TestReportCRStatusEvent(t *testing.T) {
 	}
 }
 
+func TestReportSinkAudienceMismatch(t *testing.T) {
+	sink := record.EventSink(fakeSink{Name: "TestReportSinkAudienceMismatch"})
+	ctx := ContextWithCRStatus(context.Background(), &sink, "mycomponent", src, logF)
+
+	// Audience mismatches are reported even when the sink errors are not.
+	crStatusEventClient := NewCRStatusEventClient(map[string]string{"sink-event-error-reporting.enable": "false"})
+	crStatusEventClient.ReportSinkAudienceMismatch(ctx, "my-audience")
+
+	time.Sleep(time.Millisecond * 500)
+	mutex.Lock()
+	defer mutex.Unlock()
+	event := recordTestSinkResults["TestReportSinkAudienceMismatch"]
+	if event == nil {
+		t.Fatal("Wanted event got nil")
+	}
+	if event.Type != corev1.EventTypeWarning {
+		t.Errorf("Type = %q; want %q", event.Type, corev1.EventTypeWarning)
+	}
+	if event.Reason != SinkAudienceMismatchReason {
+		t.Errorf("Reason = %q; want %q", event.Reason, SinkAudienceMismatchReason)
+	}
+	if got := event.Annotations[SinkAudienceAnnotationKey]; got != "my-audience" {
+		t.Errorf("Audience annotation = %q; want %q", got, "my-audience")
+	}
+}
+
 func TestUpdateFromConfigMap(t *testing.T) {
 	testCases := map[string]struct {
 		initEnabled     bool
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+type namespaceDefaultsLookupKey struct{}
+
+// NamespaceDefaults are the defaults of the Brokers and Triggers created in
+// a namespace, set by the BrokerDefaultsPolicies selecting the namespace.
+// The fields set by the resources themselves are never overridden.
+type NamespaceDefaults struct {
+	// Labels are added to the Brokers and Triggers.
+	Labels map[string]string
+	// BrokerClass is the class of the Brokers.
+	BrokerClass string
+	// Delivery is the delivery spec of the Brokers. The Triggers without a
+	// delivery spec use the one of their Broker.
+	Delivery *eventingduckv1.DeliverySpec
+}
+
+// NamespaceDefaultsLookup returns the defaults of the Brokers and Triggers
+// created in a namespace, nil when there are none.
+type NamespaceDefaultsLookup func(ctx context.Context, namespace string) (*NamespaceDefaults, error)
+
+// WithNamespaceDefaultsLookup attaches the lookup of the defaults of the
+// namespaces to the provided context.
+func WithNamespaceDefaultsLookup(ctx context.Context, lookup NamespaceDefaultsLookup) context.Context {
+	return context.WithValue(ctx, namespaceDefaultsLookupKey{}, lookup)
+}
+
+// NamespaceDefaultsFromContext returns the defaults of the Brokers and
+// Triggers created in the namespace. It returns nil outside of creates, when
+// no NamespaceDefaultsLookup is attached to the context, or when the lookup
+// fails, in which case only the defaults of the ConfigMaps are applied.
+func NamespaceDefaultsFromContext(ctx context.Context, namespace string) *NamespaceDefaults {
+	if !apis.IsInCreate(ctx) {
+		return nil
+	}
+	lookup, ok := ctx.Value(namespaceDefaultsLookupKey{}).(NamespaceDefaultsLookup)
+	if !ok {
+		return nil
+	}
+	d, err := lookup(ctx, namespace)
+	if err != nil {
+		logging.FromContext(ctx).Warnw("Failed to look up the defaults of the namespace",
+			zap.String("namespace", namespace), zap.Error(err))
+		return nil
+	}
+	return d
+}
+
+// ApplyLabels adds the labels of the defaults missing from the given labels.
+func (d *NamespaceDefaults) ApplyLabels(labels map[string]string) map[string]string {
+	if d == nil || len(d.Labels) == 0 {
+		return labels
+	}
+	if labels == nil {
+		labels = make(map[string]string, len(d.Labels))
+	}
+	for k, v := range d.Labels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	return labels
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+)
+
+func TestNamespaceDefaultsFromContext(t *testing.T) {
+	defaults := &NamespaceDefaults{BrokerClass: "class"}
+	lookup := func(_ context.Context, namespace string) (*NamespaceDefaults, error) {
+		switch namespace {
+		case "selected":
+			return defaults, nil
+		case "failing":
+			return nil, errors.New("lookup failed")
+		}
+		return nil, nil
+	}
+
+	tests := map[string]struct {
+		ctx       context.Context
+		namespace string
+		want      *NamespaceDefaults
+	}{
+		"create": {
+			ctx:       apis.WithinCreate(WithNamespaceDefaultsLookup(context.Background(), lookup)),
+			namespace: "selected",
+			want:      defaults,
+		},
+		"create, namespace not selected": {
+			ctx:       apis.WithinCreate(WithNamespaceDefaultsLookup(context.Background(), lookup)),
+			namespace: "other",
+		},
+		"create, lookup failure": {
+			ctx:       apis.WithinCreate(WithNamespaceDefaultsLookup(context.Background(), lookup)),
+			namespace: "failing",
+		},
+		"update": {
+			ctx:       apis.WithinUpdate(WithNamespaceDefaultsLookup(context.Background(), lookup), nil),
+			namespace: "selected",
+		},
+		"no lookup": {
+			ctx:       apis.WithinCreate(context.Background()),
+			namespace: "selected",
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := NamespaceDefaultsFromContext(tc.ctx, tc.namespace); got != tc.want {
+				t.Errorf("NamespaceDefaultsFromContext() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNamespaceDefaultsApplyLabels(t *testing.T) {
+	defaults := &NamespaceDefaults{Labels: map[string]string{"team": "payments", "env": "prod"}}
+
+	tests := map[string]struct {
+		defaults *NamespaceDefaults
+		labels   map[string]string
+		want     map[string]string
+	}{
+		"nil defaults": {
+			labels: map[string]string{"team": "search"},
+			want:   map[string]string{"team": "search"},
+		},
+		"no labels": {
+			defaults: defaults,
+			want:     map[string]string{"team": "payments", "env": "prod"},
+		},
+		"existing labels are kept": {
+			defaults: defaults,
+			labels:   map[string]string{"team": "search"},
+			want:     map[string]string{"team": "search", "env": "prod"},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.defaults.ApplyLabels(tc.labels)); diff != "" {
+				t.Error("Unexpected labels (-want, +got):", diff)
+			}
+		})
+	}
+}
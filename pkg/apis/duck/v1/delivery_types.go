@@ -39,7 +39,8 @@ type DeliverySpec struct {
 	// +optional
 	Retry *int32 `json:"retry,omitempty"`
 
-	// Timeout is the timeout of each single request. The value must be greater than 0.
+	// Timeout is the timeout of each single request. The value must be greater than 0,
+	// and at most the delivery-timeout-max of the config-features ConfigMap when it is set.
 	// More information on Duration format:
 	//  - https://www.iso.org/iso-8601-date-and-time-format.html
 	//  - https://en.wikipedia.org/wiki/ISO_8601
@@ -81,6 +82,61 @@ type DeliverySpec struct {
 	//
 	// +optional
 	RetryAfterMax *string `json:"retryAfterMax,omitempty"`
+
+	// Ordering is the order in which the events are delivered to the destination
+	// (ordered, unordered). With ordered delivery, an event is sent once the
+	// previous ones are delivered, including their retries.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime. It depends
+	//       on specific implementations (Channels) choosing to provide this
+	//       capability.
+	//
+	// +optional
+	Ordering *DeliveryOrderType `json:"ordering,omitempty"`
+
+	// Concurrency is the maximum number of events sent concurrently to the
+	// destination, the events beyond it wait for the previous ones. The value
+	// must be greater than 0.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime. It depends
+	//       on specific implementations (Brokers) choosing to provide this
+	//       capability.
+	//
+	// +optional
+	Concurrency *int32 `json:"concurrency,omitempty"`
+
+	// HedgeDelay is the delay after which a second attempt of the request
+	// is sent to the destination when the first one wasn't answered yet,
+	// the first successful response is kept and the other attempt
+	// cancelled. Only set it for destinations handling duplicate events,
+	// e.g. to the p95 latency of the destination. It is an ISO 8601
+	// duration, e.g. PT0.5S.
+	//
+	// Note: This API is EXPERIMENTAL and might be changed at anytime. It depends
+	//       on specific implementations (Brokers) choosing to provide this
+	//       capability.
+	//
+	// +optional
+	HedgeDelay *string `json:"hedgeDelay,omitempty"`
+}
+
+type baselineDeliveryKey struct{}
+
+// WithBaselineDelivery returns a context holding the DeliverySpec of the
+// resource being updated, so that its Timeout is still accepted when it
+// exceeds the delivery-timeout-max.
+func WithBaselineDelivery(ctx context.Context, ds *DeliverySpec) context.Context {
+	return context.WithValue(ctx, baselineDeliveryKey{}, ds)
+}
+
+// isBaselineTimeout returns true when the timeout is the one of the
+// DeliverySpec of the resource being updated.
+func isBaselineTimeout(ctx context.Context, timeout string) bool {
+	if !apis.IsInUpdate(ctx) {
+		return false
+	}
+	ds, _ := ctx.Value(baselineDeliveryKey{}).(*DeliverySpec)
+	return ds != nil && ds.Timeout != nil && *ds.Timeout == timeout
 }
 
 func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
@@ -97,10 +153,16 @@ func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	if ds.Timeout != nil {
-		if feature.FromContext(ctx).IsEnabled(feature.DeliveryTimeout) {
+		features := feature.FromContext(ctx)
+		if features.IsEnabled(feature.DeliveryTimeout) {
 			t, te := period.Parse(*ds.Timeout)
-			if te != nil || t.IsZero() {
+			if te != nil || t.IsZero() || t.IsNegative() {
 				errs = errs.Also(apis.ErrInvalidValue(*ds.Timeout, "timeout"))
+			} else if max := features.DeliveryTimeoutMax(); max > 0 && !isBaselineTimeout(ctx, *ds.Timeout) {
+				if d, _ := t.Duration(); d > max {
+					upper, _ := period.NewOf(max)
+					errs = errs.Also(apis.ErrOutOfBoundsValue(*ds.Timeout, "PT0S", upper.String(), "timeout"))
+				}
 			}
 		} else {
 			errs = errs.Also(apis.ErrDisallowedFields("timeout"))
@@ -134,6 +196,40 @@ func (ds *DeliverySpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ds.Ordering != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryOrder) {
+			switch *ds.Ordering {
+			case DeliveryOrderOrdered, DeliveryOrderUnordered:
+				// nothing
+			default:
+				errs = errs.Also(apis.ErrInvalidValue(*ds.Ordering, "ordering"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("ordering"))
+		}
+	}
+
+	if ds.Concurrency != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryConcurrency) {
+			if *ds.Concurrency <= 0 {
+				errs = errs.Also(apis.ErrInvalidValue(*ds.Concurrency, "concurrency"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("concurrency"))
+		}
+	}
+
+	if ds.HedgeDelay != nil {
+		if feature.FromContext(ctx).IsEnabled(feature.DeliveryHedging) {
+			p, he := period.Parse(*ds.HedgeDelay)
+			if he != nil || p.IsZero() || p.IsNegative() {
+				errs = errs.Also(apis.ErrInvalidValue(*ds.HedgeDelay, "hedgeDelay"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("hedgeDelay"))
+		}
+	}
+
 	return errs
 }
 
@@ -148,6 +244,17 @@ const (
 	BackoffPolicyExponential BackoffPolicyType = "exponential"
 )
 
+// DeliveryOrderType is the type for delivery orders
+type DeliveryOrderType string
+
+const (
+	// Ordered delivery, one event at a time
+	DeliveryOrderOrdered DeliveryOrderType = "ordered"
+
+	// Unordered delivery, the default
+	DeliveryOrderUnordered DeliveryOrderType = "unordered"
+)
+
 // DeliveryStatus contains the Status of an object supporting delivery options. This type is intended to be embedded into a status struct.
 type DeliveryStatus struct {
 	// DeadLetterSink is a KReference that is the reference to the native, platform specific channel
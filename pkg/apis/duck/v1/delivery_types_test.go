@@ -34,9 +34,22 @@ func TestDeliverySpecValidation(t *testing.T) {
 	deliveryRetryAfterEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
 		feature.DeliveryRetryAfter: feature.Enabled,
 	})
+	deliveryOrderEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryOrder: feature.Enabled,
+	})
+	deliveryConcurrencyEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryConcurrency: feature.Enabled,
+	})
+	deliveryHedgingEnabledCtx := feature.ToContext(context.TODO(), feature.Flags{
+		feature.DeliveryHedging: feature.Enabled,
+	})
 
 	invalidString := "invalid time"
 	bop := BackoffPolicyExponential
+	ordered := DeliveryOrderOrdered
+	invalidOrder := DeliveryOrderType("fifo")
+	concurrency := int32(10)
+	invalidConcurrency := int32(0)
 	validDuration := "PT2S"
 	invalidDuration := "1985-04-12T23:20:50.52Z"
 	tests := []struct {
@@ -79,6 +92,62 @@ func TestDeliverySpecValidation(t *testing.T) {
 		want: func() *apis.FieldError {
 			return apis.ErrInvalidValue("PT0S", "timeout")
 		}(),
+	}, {
+		name: "negative timeout",
+		spec: &DeliverySpec{Timeout: pointer.String("-PT5S")},
+		ctx:  deliveryTimeoutEnabledCtx,
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue("-PT5S", "timeout")
+		}(),
+	}, {
+		name: "timeout without maximum",
+		spec: &DeliverySpec{Timeout: pointer.String("PT2H")},
+		ctx:  deliveryTimeoutEnabledCtx,
+		want: nil,
+	}, {
+		name: "timeout above the configured maximum",
+		spec: &DeliverySpec{Timeout: pointer.String("PT2M")},
+		ctx: feature.ToContext(context.TODO(), feature.Flags{
+			feature.DeliveryTimeout:    feature.Enabled,
+			feature.DeliveryTimeoutMax: "PT1M",
+		}),
+		want: func() *apis.FieldError {
+			return apis.ErrOutOfBoundsValue("PT2M", "PT0S", "PT1M", "timeout")
+		}(),
+	}, {
+		name: "timeout at the configured maximum",
+		spec: &DeliverySpec{Timeout: pointer.String("PT1M")},
+		ctx: feature.ToContext(context.TODO(), feature.Flags{
+			feature.DeliveryTimeout:    feature.Enabled,
+			feature.DeliveryTimeoutMax: "PT1M",
+		}),
+		want: nil,
+	}, {
+		name: "timeout above the maximum unchanged by an update",
+		spec: &DeliverySpec{Timeout: pointer.String("PT2M")},
+		ctx: WithBaselineDelivery(apis.WithinUpdate(feature.ToContext(context.TODO(), feature.Flags{
+			feature.DeliveryTimeout:    feature.Enabled,
+			feature.DeliveryTimeoutMax: "PT1M",
+		}), nil), &DeliverySpec{Timeout: pointer.String("PT2M")}),
+		want: nil,
+	}, {
+		name: "timeout above the maximum changed by an update",
+		spec: &DeliverySpec{Timeout: pointer.String("PT3M")},
+		ctx: WithBaselineDelivery(apis.WithinUpdate(feature.ToContext(context.TODO(), feature.Flags{
+			feature.DeliveryTimeout:    feature.Enabled,
+			feature.DeliveryTimeoutMax: "PT1M",
+		}), nil), &DeliverySpec{Timeout: pointer.String("PT2M")}),
+		want: func() *apis.FieldError {
+			return apis.ErrOutOfBoundsValue("PT3M", "PT0S", "PT1M", "timeout")
+		}(),
+	}, {
+		name: "unbounded timeout",
+		spec: &DeliverySpec{Timeout: pointer.String("PT2H")},
+		ctx: feature.ToContext(context.TODO(), feature.Flags{
+			feature.DeliveryTimeout:    feature.Enabled,
+			feature.DeliveryTimeoutMax: feature.Disabled,
+		}),
+		want: nil,
 	}, {
 		name: "disabled timeout",
 		spec: &DeliverySpec{Timeout: &validDuration},
@@ -141,6 +210,60 @@ func TestDeliverySpecValidation(t *testing.T) {
 		want: func() *apis.FieldError {
 			return apis.ErrDisallowedFields("retryAfterMax")
 		}(),
+	}, {
+		name: "valid order",
+		ctx:  deliveryOrderEnabledCtx,
+		spec: &DeliverySpec{Ordering: &ordered},
+		want: nil,
+	}, {
+		name: "invalid order",
+		ctx:  deliveryOrderEnabledCtx,
+		spec: &DeliverySpec{Ordering: &invalidOrder},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidOrder, "ordering")
+		}(),
+	}, {
+		name: "disabled feature with order",
+		spec: &DeliverySpec{Ordering: &ordered},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("ordering")
+		}(),
+	}, {
+		name: "valid concurrency",
+		ctx:  deliveryConcurrencyEnabledCtx,
+		spec: &DeliverySpec{Concurrency: &concurrency},
+		want: nil,
+	}, {
+		name: "invalid concurrency",
+		ctx:  deliveryConcurrencyEnabledCtx,
+		spec: &DeliverySpec{Concurrency: &invalidConcurrency},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidConcurrency, "concurrency")
+		}(),
+	}, {
+		name: "disabled feature with concurrency",
+		spec: &DeliverySpec{Concurrency: &concurrency},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("concurrency")
+		}(),
+	}, {
+		name: "valid hedge delay",
+		ctx:  deliveryHedgingEnabledCtx,
+		spec: &DeliverySpec{HedgeDelay: &validDuration},
+		want: nil,
+	}, {
+		name: "invalid hedge delay",
+		ctx:  deliveryHedgingEnabledCtx,
+		spec: &DeliverySpec{HedgeDelay: &invalidDuration},
+		want: func() *apis.FieldError {
+			return apis.ErrInvalidValue(invalidDuration, "hedgeDelay")
+		}(),
+	}, {
+		name: "disabled feature with hedge delay",
+		spec: &DeliverySpec{HedgeDelay: &validDuration},
+		want: func() *apis.FieldError {
+			return apis.ErrDisallowedFields("hedgeDelay")
+		}(),
 	}}
 
 	for _, test := range tests {
@@ -66,6 +66,18 @@ type SubscriberSpec struct {
 	// ReplyAudience is the OIDC audience for the replyUri.
 	// +optional
 	ReplyAudience *string `json:"replyAudience,omitempty"`
+	// TerminalReplyURI is the endpoint for the replies with the
+	// knativeterminate extension set to true.
+	// +optional
+	TerminalReplyURI *apis.URL `json:"terminalReplyUri,omitempty"`
+	// TerminalReplyCACerts is the Certification Authority (CA) certificates in
+	// PEM format according to https://www.rfc-editor.org/rfc/rfc7468 for the
+	// terminalReplyUri.
+	// +optional
+	TerminalReplyCACerts *string `json:"terminalReplyCACerts,omitempty"`
+	// TerminalReplyAudience is the OIDC audience for the terminalReplyUri.
+	// +optional
+	TerminalReplyAudience *string `json:"terminalReplyAudience,omitempty"`
 	// +optional
 	// DeliverySpec contains options controlling the event delivery
 	// +optional
@@ -91,6 +103,26 @@ type SubscriberStatus struct {
 	// Auth provides the relevant information for OIDC authentication.
 	// +optional
 	Auth *duckv1.AuthStatus `json:"auth,omitempty"`
+	// Delivery reports the health of the event deliveries to the subscriber,
+	// as observed by the channel dispatcher. It is unset when no delivery
+	// to the subscriber has failed.
+	// +optional
+	Delivery *SubscriberDeliveryStatus `json:"delivery,omitempty"`
+}
+
+// SubscriberDeliveryStatus defines the health of the event deliveries to a
+// single subscriber of a Channel.
+type SubscriberDeliveryStatus struct {
+	// RecentFailures is the number of consecutive failed deliveries since
+	// the last successful one.
+	// +optional
+	RecentFailures int32 `json:"recentFailures,omitempty"`
+	// LastFailureTime is the time of the most recent failed delivery.
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastError is the error of the most recent failed delivery.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -201,6 +201,21 @@ func (in *DeliverySpec) DeepCopyInto(out *DeliverySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Ordering != nil {
+		in, out := &in.Ordering, &out.Ordering
+		*out = new(DeliveryOrderType)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HedgeDelay != nil {
+		in, out := &in.HedgeDelay, &out.HedgeDelay
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -352,6 +367,26 @@ func (in *SubscribableStatus) DeepCopy() *SubscribableStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriberDeliveryStatus) DeepCopyInto(out *SubscriberDeliveryStatus) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriberDeliveryStatus.
+func (in *SubscriberDeliveryStatus) DeepCopy() *SubscriberDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriberDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriberSpec) DeepCopyInto(out *SubscriberSpec) {
 	*out = *in
@@ -390,6 +425,21 @@ func (in *SubscriberSpec) DeepCopyInto(out *SubscriberSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TerminalReplyURI != nil {
+		in, out := &in.TerminalReplyURI, &out.TerminalReplyURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminalReplyCACerts != nil {
+		in, out := &in.TerminalReplyCACerts, &out.TerminalReplyCACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.TerminalReplyAudience != nil {
+		in, out := &in.TerminalReplyAudience, &out.TerminalReplyAudience
+		*out = new(string)
+		**out = **in
+	}
 	if in.Delivery != nil {
 		in, out := &in.Delivery, &out.Delivery
 		*out = new(DeliverySpec)
@@ -421,6 +471,11 @@ func (in *SubscriberStatus) DeepCopyInto(out *SubscriberStatus) {
 		*out = new(duckv1.AuthStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(SubscriberDeliveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -52,6 +52,67 @@ const (
 	// handled by the cluster-scoped component
 	ScopeCluster = "cluster"
 
+	// EventValidationAnnotationKey is the Broker annotation key setting how
+	// strictly its ingress validates the events it receives, overriding the
+	// default of the ingress.
+	// Valid values are: minimal, spec-strict, registry-enforced.
+	EventValidationAnnotationKey = GroupName + "/event-validation"
+
+	// EventValidationMinimal only rejects the events that can't be decoded.
+	EventValidationMinimal = "minimal"
+
+	// EventValidationSpecStrict also rejects the events that don't fully
+	// comply with the CloudEvents spec.
+	EventValidationSpecStrict = "spec-strict"
+
+	// EventValidationRegistryEnforced also rejects the events whose type
+	// isn't registered for the Broker by an EventType.
+	EventValidationRegistryEnforced = "registry-enforced"
+
+	// MaintenanceWindowAnnotationKey is the Broker annotation key setting a
+	// maintenance window during which the ingress buffers the events it
+	// receives instead of forwarding them to the channel, and drains them
+	// once the window is over. Its value is a time interval made of two
+	// RFC 3339 timestamps separated by a slash, e.g.
+	// "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z".
+	MaintenanceWindowAnnotationKey = GroupName + "/maintenance-window"
+
+	// IngressRateLimitAnnotationKey is the Broker annotation key limiting
+	// the rate of the events its ingress accepts per event source, overriding
+	// the broker-ingress-rate-limit feature. Its value is a number of events
+	// per second, optionally followed by a comma and the burst of events
+	// accepted at once, e.g. "100,200".
+	IngressRateLimitAnnotationKey = GroupName + "/ingress-rate-limit"
+
+	// IngressHeaderExtensionsAnnotationKey is the Broker annotation key
+	// mapping headers of the requests to its ingress to CloudEvent
+	// extensions, so that the context set by the gateways in front of the
+	// ingress reaches the subscribers. Its value is a comma separated list
+	// of <header>=<extension> mappings, e.g. "X-Request-Id=requestid".
+	IngressHeaderExtensionsAnnotationKey = GroupName + "/ingress-header-extensions"
+
+	// IngressHostnameAnnotationKey is the Broker annotation key exposing its
+	// ingress outside of the cluster at the given hostname, through a Gateway
+	// of the Kubernetes Gateway API, when the broker-ingress-exposure feature
+	// is enabled.
+	IngressHostnameAnnotationKey = GroupName + "/ingress-hostname"
+
+	// IngressGatewayAnnotationKey is the Broker annotation key setting the
+	// Gateway exposing its ingress, as <namespace>/<name> or <name> for a
+	// Gateway in the namespace of the Broker.
+	IngressGatewayAnnotationKey = GroupName + "/ingress-gateway"
+
+	// IngressGatewayListenerAnnotationKey is the Broker annotation key
+	// setting the listener of the Gateway exposing its ingress, all the
+	// listeners of the Gateway when unset.
+	IngressGatewayListenerAnnotationKey = GroupName + "/ingress-gateway-listener"
+
+	// IngressTLSAnnotationKey is the Broker annotation key telling whether
+	// the listener of the Gateway exposing its ingress terminates TLS, so
+	// that the Broker is exposed at an https URL. Its value is "true" or
+	// "false", the default.
+	IngressTLSAnnotationKey = GroupName + "/ingress-tls"
+
 	// EventTypesAnnotationKey is the annotation key to specify
 	// if a Source has event types defines in its CRD.
 	EventTypesAnnotationKey = "registry.knative.dev/eventTypes"
@@ -85,10 +146,45 @@ const (
 	// the triggers to subscribe to.
 	BrokerChannelNameStatusAnnotationKey = "knative.dev/channelName"
 
+	// BrokerPreviousChannelAPIVersionStatusAnnotationKey is the broker status
+	// annotation key used to specify the APIVersion of the channel the
+	// Broker migrated from, until it is deleted.
+	BrokerPreviousChannelAPIVersionStatusAnnotationKey = "knative.dev/previousChannelAPIVersion"
+
+	// BrokerPreviousChannelKindStatusAnnotationKey is the broker status
+	// annotation key used to specify the Kind of the channel the Broker
+	// migrated from, until it is deleted.
+	BrokerPreviousChannelKindStatusAnnotationKey = "knative.dev/previousChannelKind"
+
 	// BrokerChannelNamespaceStatusAnnotationKey is the broker status
 	// annotation key used to specify the namespace of the channel for
 	// the triggers to subscribe to.
 	BrokerChannelNamespaceStatusAnnotationKey = "knative.dev/channelNamespace"
+
+	// BrokerFilterServiceStatusAnnotationKey is the broker status annotation
+	// key used to specify the broker-filter Service the triggers subscribe
+	// through, when the broker is routed to a canary data plane.
+	BrokerFilterServiceStatusAnnotationKey = "knative.dev/filterService"
+
+	// TriggerDeadLetteredEventsStatusAnnotationKey is the trigger status
+	// annotation key used to specify the number of events of the Trigger
+	// delivered to its dead letter sink.
+	TriggerDeadLetteredEventsStatusAnnotationKey = "knative.dev/deadLetteredEvents"
+
+	// TriggerDeadLetterFailuresStatusAnnotationKey is the trigger status
+	// annotation key used to specify the number of events of the Trigger
+	// that couldn't be delivered to its dead letter sink either.
+	TriggerDeadLetterFailuresStatusAnnotationKey = "knative.dev/deadLetterFailures"
+
+	// TriggerLastDeadLetteredTimeStatusAnnotationKey is the trigger status
+	// annotation key used to specify the time, in RFC 3339 format, of the
+	// last event of the Trigger sent to its dead letter sink.
+	TriggerLastDeadLetteredTimeStatusAnnotationKey = "knative.dev/lastDeadLetteredTime"
+
+	// BreakGlassTTLAnnotationKey is the annotation key to mark an EventPolicy
+	// as a break-glass policy. Its value is a duration (e.g. "30m") after the
+	// creation of the EventPolicy at which the policy stops granting access.
+	BreakGlassTTLAnnotationKey = GroupName + "/break-glass-ttl"
 )
 
 var (
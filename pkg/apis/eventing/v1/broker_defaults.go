@@ -28,12 +28,33 @@ import (
 )
 
 func (b *Broker) SetDefaults(ctx context.Context) {
+	// The defaults of the namespace take precedence over the ones of the
+	// ConfigMaps.
+	b.setNamespaceDefaults(ctx)
+
 	// Default Spec fields.
 	withNS := apis.WithinParent(ctx, b.ObjectMeta)
 	b.Spec.SetDefaults(withNS)
 	eventing.DefaultBrokerClassIfUnset(withNS, &b.ObjectMeta)
 }
 
+func (b *Broker) setNamespaceDefaults(ctx context.Context) {
+	d := config.NamespaceDefaultsFromContext(ctx, b.Namespace)
+	if d == nil {
+		return
+	}
+	b.Labels = d.ApplyLabels(b.Labels)
+	if _, present := b.Annotations[eventing.BrokerClassKey]; !present && d.BrokerClass != "" {
+		if b.Annotations == nil {
+			b.Annotations = make(map[string]string, 1)
+		}
+		b.Annotations[eventing.BrokerClassKey] = d.BrokerClass
+	}
+	if b.Spec.Delivery == nil && d.Delivery != nil {
+		b.Spec.Delivery = d.Delivery.DeepCopy()
+	}
+}
+
 func (bs *BrokerSpec) SetDefaults(ctx context.Context) {
 	cfg := config.FromContextOrDefaults(ctx)
 	c, err := cfg.Defaults.GetBrokerConfig(apis.ParentMeta(ctx).Namespace)
@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"knative.dev/eventing/pkg/apis/config"
@@ -471,3 +472,138 @@ func TestBrokerSetDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestBrokerSetNamespaceDefaults(t *testing.T) {
+	nsDefaults := &config.NamespaceDefaults{
+		Labels:      map[string]string{"team": "payments", "env": "prod"},
+		BrokerClass: "namespace-class",
+		Delivery: &eventingduckv1.DeliverySpec{
+			Retry: pointer.Int32(7),
+		},
+	}
+	lookup := func(_ context.Context, namespace string) (*config.NamespaceDefaults, error) {
+		if namespace == "selected" {
+			return nsDefaults, nil
+		}
+		return nil, nil
+	}
+
+	testCases := map[string]struct {
+		create   bool
+		initial  Broker
+		expected Broker
+	}{
+		"namespace defaults take precedence over the configmap": {
+			create: true,
+			initial: Broker{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "selected", Name: "broker"},
+			},
+			expected: Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "selected",
+					Name:        "broker",
+					Labels:      map[string]string{"team": "payments", "env": "prod"},
+					Annotations: map[string]string{eventing.BrokerClassKey: "namespace-class"},
+				},
+				Spec: BrokerSpec{
+					Config: &duckv1.KReference{
+						Kind:       "ConfigMap",
+						Namespace:  "knative-eventing",
+						Name:       "imc-channel",
+						APIVersion: "v1",
+					},
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(7),
+					},
+				},
+			},
+		},
+		"the fields of the broker are kept": {
+			create: true,
+			initial: Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "selected",
+					Name:        "broker",
+					Labels:      map[string]string{"team": "search"},
+					Annotations: map[string]string{eventing.BrokerClassKey: "broker-class"},
+				},
+				Spec: BrokerSpec{
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(1),
+					},
+				},
+			},
+			expected: Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "selected",
+					Name:        "broker",
+					Labels:      map[string]string{"team": "search", "env": "prod"},
+					Annotations: map[string]string{eventing.BrokerClassKey: "broker-class"},
+				},
+				Spec: BrokerSpec{
+					Config: &duckv1.KReference{
+						Kind:       "ConfigMap",
+						Namespace:  "knative-eventing",
+						Name:       "imc-channel",
+						APIVersion: "v1",
+					},
+					Delivery: &eventingduckv1.DeliverySpec{
+						Retry: pointer.Int32(1),
+					},
+				},
+			},
+		},
+		"not on updates": {
+			initial: Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "selected",
+					Name:        "broker",
+					Annotations: map[string]string{eventing.BrokerClassKey: "broker-class"},
+				},
+			},
+			expected: Broker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "selected",
+					Name:        "broker",
+					Annotations: map[string]string{eventing.BrokerClassKey: "broker-class"},
+				},
+				Spec: BrokerSpec{
+					Config: &duckv1.KReference{
+						Kind:       "ConfigMap",
+						Namespace:  "knative-eventing",
+						Name:       "imc-channel",
+						APIVersion: "v1",
+					},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &duckv1.Destination{
+							Ref: &duckv1.KReference{
+								Kind:       "Service",
+								Namespace:  "knative-eventing",
+								Name:       "handle-error",
+								APIVersion: "serving.knative.dev/v1",
+							},
+						},
+						Retry:         pointer.Int32(3),
+						BackoffPolicy: (*eventingduckv1.BackoffPolicyType)(pointer.String("exponential")),
+						BackoffDelay:  pointer.String("5s"),
+					},
+				},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx := config.WithNamespaceDefaultsLookup(config.ToContext(context.Background(), defaultConfig), lookup)
+			if tc.create {
+				ctx = apis.WithinCreate(ctx)
+			}
+			tc.initial.SetDefaults(ctx)
+			if diff := cmp.Diff(tc.expected, tc.initial); diff != "" {
+				t.Fatal("Unexpected defaults (-want, +got):", diff)
+			}
+			if tc.initial.Spec.Delivery == nsDefaults.Delivery {
+				t.Error("Expected the delivery of the namespace defaults to be copied")
+			}
+		})
+	}
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
 	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	v1 "knative.dev/pkg/apis/duck/v1"
 
@@ -32,6 +34,16 @@ const (
 	BrokerConditionFilter                 apis.ConditionType = "FilterReady"
 	BrokerConditionAddressable            apis.ConditionType = "Addressable"
 	BrokerConditionDeadLetterSinkResolved apis.ConditionType = "DeadLetterSinkResolved"
+
+	// BrokerConditionDeletionBlocked has status True while the deletion of the
+	// Broker waits for the Triggers using it to be deleted. It isn't part of
+	// the Ready condition.
+	BrokerConditionDeletionBlocked apis.ConditionType = "DeletionBlocked"
+
+	// BrokerConditionIngressExposed reports whether the ingress of the
+	// Broker is exposed outside of the cluster through a Gateway, when the
+	// Broker asks for it. It isn't part of the Ready condition.
+	BrokerConditionIngressExposed apis.ConditionType = "IngressExposed"
 )
 
 var brokerCondSet = apis.NewLivingConditionSet(
@@ -118,3 +130,43 @@ func (bs *BrokerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat s
 	bs.DeliveryStatus = eventingduck.DeliveryStatus{}
 	bs.GetConditionSet().Manage(bs).MarkFalse(BrokerConditionDeadLetterSinkResolved, reason, messageFormat, messageA...)
 }
+
+// MarkIngressExposed records that the ingress of the Broker is exposed at url.
+func (bs *BrokerStatus) MarkIngressExposed(url *apis.URL) {
+	bs.GetConditionSet().Manage(bs).SetCondition(apis.Condition{
+		Type:     BrokerConditionIngressExposed,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityInfo,
+		Message:  fmt.Sprintf("The Broker is exposed at %s", url),
+	})
+}
+
+// MarkIngressNotExposed records that the ingress of the Broker couldn't be
+// exposed.
+func (bs *BrokerStatus) MarkIngressNotExposed(reason, messageFormat string, messageA ...interface{}) {
+	bs.GetConditionSet().Manage(bs).SetCondition(apis.Condition{
+		Type:     BrokerConditionIngressExposed,
+		Status:   corev1.ConditionFalse,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  fmt.Sprintf(messageFormat, messageA...),
+	})
+}
+
+// ClearIngressExposed removes the IngressExposed condition, when the Broker
+// isn't exposed.
+func (bs *BrokerStatus) ClearIngressExposed() {
+	_ = bs.GetConditionSet().Manage(bs).ClearCondition(BrokerConditionIngressExposed)
+}
+
+// MarkDeletionBlocked records that the deletion of the Broker waits for the
+// Triggers using it to be deleted.
+func (bs *BrokerStatus) MarkDeletionBlocked(reason, messageFormat string, messageA ...interface{}) {
+	bs.GetConditionSet().Manage(bs).SetCondition(apis.Condition{
+		Type:     BrokerConditionDeletionBlocked,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  fmt.Sprintf(messageFormat, messageA...),
+	})
+}
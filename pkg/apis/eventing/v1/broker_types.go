@@ -17,11 +17,22 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -127,3 +138,215 @@ func (b *Broker) GetUntypedSpec() interface{} {
 func (t *Broker) GetStatus() *duckv1.Status {
 	return &t.Status.Status
 }
+
+// MaxMaintenanceWindow is the longest maintenance window a Broker can have.
+const MaxMaintenanceWindow = 24 * time.Hour
+
+// MaintenanceWindow returns the start and the end of the maintenance window
+// set via the maintenance window annotation. The returned bool is false if
+// the Broker has no maintenance window.
+func (b *Broker) MaintenanceWindow() (time.Time, time.Time, bool, error) {
+	value, ok := b.GetAnnotations()[eventing.MaintenanceWindowAnnotationKey]
+	if !ok {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	from, to, found := strings.Cut(value, "/")
+	if !found {
+		return time.Time{}, time.Time{}, true, fmt.Errorf("expected <start>/<end>, got %q", value)
+	}
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, true, err
+	}
+	end, err := time.Parse(time.RFC3339, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, true, err
+	}
+	return start, end, true, nil
+}
+
+// InMaintenance returns true if now is within the maintenance window of the
+// Broker.
+func (b *Broker) InMaintenance(now time.Time) bool {
+	start, end, ok, err := b.MaintenanceWindow()
+	if !ok || err != nil {
+		return false
+	}
+	return !now.Before(start) && now.Before(end)
+}
+
+// IngressRateLimit returns the rate, in events per second, and the burst of
+// the events the ingress accepts per event source for the Broker, set via
+// the ingress rate limit annotation. The returned bool is false if the Broker
+// has no ingress rate limit annotation.
+func (b *Broker) IngressRateLimit() (float64, int, bool, error) {
+	value, ok := b.GetAnnotations()[eventing.IngressRateLimitAnnotationKey]
+	if !ok {
+		return 0, 0, false, nil
+	}
+	rate, burst, err := ParseIngressRateLimit(value)
+	return rate, burst, true, err
+}
+
+// ParseIngressRateLimit parses an ingress rate limit made of a positive
+// number of events per second, optionally followed by a comma and the burst
+// of events accepted at once, which defaults to the rate rounded up.
+func ParseIngressRateLimit(value string) (float64, int, error) {
+	r, b, hasBurst := strings.Cut(value, ",")
+	rate, err := strconv.ParseFloat(strings.TrimSpace(r), 64)
+	if err != nil || rate <= 0 {
+		return 0, 0, fmt.Errorf("expected a positive number of events per second, got %q", r)
+	}
+	if !hasBurst {
+		return rate, int(math.Ceil(rate)), nil
+	}
+	burst, err := strconv.Atoi(strings.TrimSpace(b))
+	if err != nil || burst <= 0 {
+		return 0, 0, fmt.Errorf("expected a positive burst, got %q", b)
+	}
+	return rate, burst, nil
+}
+
+// IngressHeaderExtensions returns the CloudEvent extensions the ingress sets
+// from the headers of the requests for the Broker, keyed by canonical header
+// name, set via the ingress header extensions annotation. The returned bool
+// is false if the Broker has no ingress header extensions annotation.
+func (b *Broker) IngressHeaderExtensions() (map[string]string, bool, error) {
+	value, ok := b.GetAnnotations()[eventing.IngressHeaderExtensionsAnnotationKey]
+	if !ok {
+		return nil, false, nil
+	}
+	mappings, err := ParseIngressHeaderExtensions(value)
+	return mappings, true, err
+}
+
+// reservedExtensionNames are the CloudEvents attributes the headers can't be
+// mapped to.
+var reservedExtensionNames = sets.New("specversion", "id", "source", "type", "subject", "time",
+	"datacontenttype", "dataschema", "data", "data_base64")
+
+// sensitiveHeaders are the headers carrying credentials, which can't be
+// mapped to extensions.
+var sensitiveHeaders = sets.New("Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie")
+
+// ParseIngressHeaderExtensions parses a comma separated list of
+// <header>=<extension> mappings. The extension names must be lowercase
+// alphanumeric, and can't be CloudEvents attributes or start with knative,
+// which is reserved for the extensions set by Knative.
+func ParseIngressHeaderExtensions(value string) (map[string]string, error) {
+	mappings := make(map[string]string)
+	for _, mapping := range strings.Split(value, ",") {
+		h, ext, found := strings.Cut(mapping, "=")
+		header, extension := http.CanonicalHeaderKey(strings.TrimSpace(h)), strings.TrimSpace(ext)
+		if !found || header == "" || extension == "" {
+			return nil, fmt.Errorf("expected <header>=<extension>, got %q", strings.TrimSpace(mapping))
+		}
+		if msgs := validation.IsHTTPHeaderName(header); len(msgs) > 0 {
+			return nil, fmt.Errorf("invalid header %q: %s", header, strings.Join(msgs, ", "))
+		}
+		if sensitiveHeaders.Has(header) {
+			return nil, fmt.Errorf("header %q carries credentials", header)
+		}
+		if !validAttributeName.MatchString(extension) {
+			return nil, fmt.Errorf("invalid extension %q: must start with a letter and only contain lowercase alphanumeric", extension)
+		}
+		if reservedExtensionNames.Has(extension) || strings.HasPrefix(extension, "knative") {
+			return nil, fmt.Errorf("extension %q is reserved", extension)
+		}
+		if _, ok := mappings[header]; ok {
+			return nil, fmt.Errorf("header %q is mapped more than once", header)
+		}
+		mappings[header] = extension
+	}
+	return mappings, nil
+}
+
+// IngressExposure describes how the ingress of a Broker is exposed outside of
+// the cluster through a Gateway of the Kubernetes Gateway API.
+type IngressExposure struct {
+	// Hostname is the hostname the Broker is exposed at.
+	Hostname string
+	// Gateway is the Gateway exposing the Broker.
+	Gateway types.NamespacedName
+	// Listener is the listener of the Gateway exposing the Broker, all the
+	// listeners of the Gateway when empty.
+	Listener string
+	// TLS is true when the listener terminates TLS.
+	TLS bool
+}
+
+// URL returns the URL the Broker is exposed at.
+func (e *IngressExposure) URL() *apis.URL {
+	if e.TLS {
+		return apis.HTTPS(e.Hostname)
+	}
+	return apis.HTTP(e.Hostname)
+}
+
+// ingressExposureAnnotations are the annotations setting the exposure of the
+// ingress of a Broker.
+var ingressExposureAnnotations = []string{
+	eventing.IngressHostnameAnnotationKey,
+	eventing.IngressGatewayAnnotationKey,
+	eventing.IngressGatewayListenerAnnotationKey,
+	eventing.IngressTLSAnnotationKey,
+}
+
+// IngressExposure returns how the ingress of the Broker is exposed outside of
+// the cluster, set via the ingress hostname and gateway annotations, along
+// with the optional ingress gateway listener and TLS annotations. The returned
+// bool is false if the Broker has none of these annotations.
+func (b *Broker) IngressExposure() (*IngressExposure, bool, error) {
+	annotations := b.GetAnnotations()
+	found := false
+	for _, key := range ingressExposureAnnotations {
+		if _, ok := annotations[key]; ok {
+			found = true
+		}
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	e := &IngressExposure{
+		Hostname: annotations[eventing.IngressHostnameAnnotationKey],
+		Listener: annotations[eventing.IngressGatewayListenerAnnotationKey],
+	}
+	if e.Hostname == "" {
+		return nil, true, fmt.Errorf("the %s annotation is required", eventing.IngressHostnameAnnotationKey)
+	}
+	if msgs := validation.IsDNS1123Subdomain(e.Hostname); len(msgs) > 0 {
+		return nil, true, fmt.Errorf("invalid hostname %q: %s", e.Hostname, strings.Join(msgs, ", "))
+	}
+
+	gateway := annotations[eventing.IngressGatewayAnnotationKey]
+	if gateway == "" {
+		return nil, true, fmt.Errorf("the %s annotation is required", eventing.IngressGatewayAnnotationKey)
+	}
+	if ns, name, ok := strings.Cut(gateway, "/"); ok {
+		e.Gateway = types.NamespacedName{Namespace: ns, Name: name}
+	} else {
+		e.Gateway = types.NamespacedName{Namespace: b.Namespace, Name: gateway}
+	}
+	if msgs := validation.IsDNS1123Label(e.Gateway.Namespace); len(msgs) > 0 {
+		return nil, true, fmt.Errorf("invalid gateway namespace %q: %s", e.Gateway.Namespace, strings.Join(msgs, ", "))
+	}
+	if msgs := validation.IsDNS1123Subdomain(e.Gateway.Name); len(msgs) > 0 {
+		return nil, true, fmt.Errorf("invalid gateway name %q: %s", e.Gateway.Name, strings.Join(msgs, ", "))
+	}
+
+	if _, ok := annotations[eventing.IngressGatewayListenerAnnotationKey]; ok {
+		if msgs := validation.IsDNS1123Subdomain(e.Listener); len(msgs) > 0 {
+			return nil, true, fmt.Errorf("invalid gateway listener %q: %s", e.Listener, strings.Join(msgs, ", "))
+		}
+	}
+
+	if value, ok := annotations[eventing.IngressTLSAnnotationKey]; ok {
+		tls, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, true, fmt.Errorf("invalid %s annotation %q: expected true or false", eventing.IngressTLSAnnotationKey, value)
+		}
+		e.TLS = tls
+	}
+	return e, true, nil
+}
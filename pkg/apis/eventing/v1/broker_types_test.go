@@ -16,7 +16,15 @@ limitations under the License.
 
 package v1
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
 
 func TestBrokerGetStatus(t *testing.T) {
 	r := &Broker{
@@ -34,3 +42,244 @@ func TestBroker_GetGroupVersionKind(t *testing.T) {
 		t.Errorf("Should be Broker.")
 	}
 }
+
+func TestBrokerInMaintenance(t *testing.T) {
+	start := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		window string
+		now    time.Time
+		want   bool
+	}{{
+		name: "no window",
+		now:  start,
+	}, {
+		name:   "invalid window",
+		window: "invalid",
+		now:    start,
+	}, {
+		name:   "before the window",
+		window: "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z",
+		now:    start.Add(-time.Second),
+	}, {
+		name:   "start of the window",
+		window: "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z",
+		now:    start,
+		want:   true,
+	}, {
+		name:   "within the window",
+		window: "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z",
+		now:    start.Add(30 * time.Minute),
+		want:   true,
+	}, {
+		name:   "end of the window",
+		window: "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z",
+		now:    start.Add(time.Hour),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &Broker{}
+			if tc.window != "" {
+				b.ObjectMeta = metav1.ObjectMeta{
+					Annotations: map[string]string{"eventing.knative.dev/maintenance-window": tc.window},
+				}
+			}
+			if got := b.InMaintenance(tc.now); got != tc.want {
+				t.Errorf("InMaintenance() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseIngressRateLimit(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantRate  float64
+		wantBurst int
+		wantErr   bool
+	}{{
+		value:     "100",
+		wantRate:  100,
+		wantBurst: 100,
+	}, {
+		value:     "0.5",
+		wantRate:  0.5,
+		wantBurst: 1,
+	}, {
+		value:     "10, 50",
+		wantRate:  10,
+		wantBurst: 50,
+	}, {
+		value:   "",
+		wantErr: true,
+	}, {
+		value:   "-1",
+		wantErr: true,
+	}, {
+		value:   "10,0",
+		wantErr: true,
+	}, {
+		value:   "10,many",
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			rate, burst, err := ParseIngressRateLimit(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseIngressRateLimit() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if rate != tc.wantRate || burst != tc.wantBurst {
+				t.Errorf("ParseIngressRateLimit() = %v, %v, want %v, %v", rate, burst, tc.wantRate, tc.wantBurst)
+			}
+		})
+	}
+}
+
+func TestParseIngressHeaderExtensions(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{{
+		value: "X-Request-Id=requestid",
+		want:  map[string]string{"X-Request-Id": "requestid"},
+	}, {
+		value: "x-request-id = requestid, X-Tenant-Id=tenant",
+		want:  map[string]string{"X-Request-Id": "requestid", "X-Tenant-Id": "tenant"},
+	}, {
+		value:   "",
+		wantErr: true,
+	}, {
+		value:   "X-Request-Id",
+		wantErr: true,
+	}, {
+		value:   "X Request=requestid",
+		wantErr: true,
+	}, {
+		value:   "Authorization=token",
+		wantErr: true,
+	}, {
+		value:   "X-Request-Id=Request-Id",
+		wantErr: true,
+	}, {
+		value:   "X-Source=source",
+		wantErr: true,
+	}, {
+		value:   "X-Subject=knativeauthsubject",
+		wantErr: true,
+	}, {
+		value:   "X-Request-Id=requestid,x-request-id=otherid",
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := ParseIngressHeaderExtensions(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseIngressHeaderExtensions() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected mappings (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestBrokerIngressExposure(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *IngressExposure
+		wantOK      bool
+		wantErr     bool
+	}{{
+		name: "not exposed",
+	}, {
+		name: "gateway in the namespace of the Broker",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname": "events.example.com",
+			"eventing.knative.dev/ingress-gateway":  "public",
+		},
+		want: &IngressExposure{
+			Hostname: "events.example.com",
+			Gateway:  types.NamespacedName{Namespace: "ns", Name: "public"},
+		},
+		wantOK: true,
+	}, {
+		name: "gateway in another namespace, listener and TLS",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname":         "events.example.com",
+			"eventing.knative.dev/ingress-gateway":          "gateways/public",
+			"eventing.knative.dev/ingress-gateway-listener": "https",
+			"eventing.knative.dev/ingress-tls":              "true",
+		},
+		want: &IngressExposure{
+			Hostname: "events.example.com",
+			Gateway:  types.NamespacedName{Namespace: "gateways", Name: "public"},
+			Listener: "https",
+			TLS:      true,
+		},
+		wantOK: true,
+	}, {
+		name: "missing hostname",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-gateway": "public",
+		},
+		wantOK:  true,
+		wantErr: true,
+	}, {
+		name: "invalid hostname",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname": "Events_Example",
+			"eventing.knative.dev/ingress-gateway":  "public",
+		},
+		wantOK:  true,
+		wantErr: true,
+	}, {
+		name: "missing gateway",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname": "events.example.com",
+		},
+		wantOK:  true,
+		wantErr: true,
+	}, {
+		name: "invalid gateway",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname": "events.example.com",
+			"eventing.knative.dev/ingress-gateway":  "a/b/c",
+		},
+		wantOK:  true,
+		wantErr: true,
+	}, {
+		name: "invalid TLS",
+		annotations: map[string]string{
+			"eventing.knative.dev/ingress-hostname": "events.example.com",
+			"eventing.knative.dev/ingress-gateway":  "public",
+			"eventing.knative.dev/ingress-tls":      "yes",
+		},
+		wantOK:  true,
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &Broker{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Annotations: tc.annotations}}
+			got, ok, err := b.IngressExposure()
+			if ok != tc.wantOK || (err != nil) != tc.wantErr {
+				t.Fatalf("IngressExposure() = %v, %v, want %v, wantErr %v", ok, err, tc.wantOK, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected exposure (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestIngressExposureURL(t *testing.T) {
+	e := &IngressExposure{Hostname: "events.example.com"}
+	if got, want := e.URL(), apis.HTTP("events.example.com"); got.String() != want.String() {
+		t.Errorf("URL() = %s, want %s", got, want)
+	}
+	e.TLS = true
+	if got, want := e.URL(), apis.HTTPS("events.example.com"); got.String() != want.String() {
+		t.Errorf("URL() = %s, want %s", got, want)
+	}
+}
@@ -18,6 +18,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 
@@ -25,6 +26,9 @@ import (
 	"knative.dev/pkg/kmp"
 
 	"knative.dev/eventing/pkg/apis/config"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/quota"
 )
 
 const (
@@ -33,6 +37,9 @@ const (
 
 func (b *Broker) Validate(ctx context.Context) *apis.FieldError {
 	ctx = apis.WithinParent(ctx, b.ObjectMeta)
+	if original, ok := apis.GetBaseline(ctx).(*Broker); ok && original != nil && apis.IsInUpdate(ctx) {
+		ctx = eventingduckv1.WithBaselineDelivery(ctx, original.Spec.Delivery)
+	}
 
 	cfg := config.FromContextOrDefaults(ctx)
 	var brConfig *config.ClassAndBrokerConfig
@@ -55,12 +62,52 @@ func (b *Broker) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(apis.ErrMissingField(BrokerClassAnnotationKey))
 	}
 
+	if v, ok := b.GetAnnotations()[eventing.EventValidationAnnotationKey]; ok {
+		switch v {
+		case eventing.EventValidationMinimal, eventing.EventValidationSpecStrict, eventing.EventValidationRegistryEnforced:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(v, eventing.EventValidationAnnotationKey).ViaField("metadata", "annotations"))
+		}
+	}
+
+	errs = errs.Also(b.validateMaintenanceWindow().ViaField("metadata", "annotations"))
+
+	if _, _, ok, err := b.IngressRateLimit(); ok && err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(b.Annotations[eventing.IngressRateLimitAnnotationKey], eventing.IngressRateLimitAnnotationKey, err.Error()).ViaField("metadata", "annotations"))
+	}
+
+	if _, ok, err := b.IngressHeaderExtensions(); ok && err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(b.Annotations[eventing.IngressHeaderExtensionsAnnotationKey], eventing.IngressHeaderExtensionsAnnotationKey, err.Error()).ViaField("metadata", "annotations"))
+	}
+
+	if _, ok, err := b.IngressExposure(); ok && err != nil {
+		errs = errs.Also((&apis.FieldError{
+			Message: "invalid ingress exposure",
+			Paths:   []string{"annotations"},
+			Details: err.Error(),
+		}).ViaField("metadata"))
+	}
+
 	errs = errs.Also(b.Spec.Validate(withNS).ViaField("spec"))
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*Broker)
 		errs = errs.Also(b.CheckImmutableFields(ctx, original))
 	}
-	return errs
+	return errs.Also(quota.Validate(ctx, quota.Brokers, b.Namespace))
+}
+
+func (b *Broker) validateMaintenanceWindow() *apis.FieldError {
+	start, end, ok, err := b.MaintenanceWindow()
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		return apis.ErrInvalidValue(b.Annotations[eventing.MaintenanceWindowAnnotationKey], eventing.MaintenanceWindowAnnotationKey, err.Error())
+	}
+	if d := end.Sub(start); d <= 0 || d > MaxMaintenanceWindow {
+		return apis.ErrOutOfBoundsValue(d, time.Duration(0), MaxMaintenanceWindow, eventing.MaintenanceWindowAnnotationKey)
+	}
+	return nil
 }
 
 func (bs *BrokerSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,6 +30,7 @@ import (
 
 	"knative.dev/eventing/pkg/apis/config"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/quota"
 )
 
 func TestBrokerImmutableFields(t *testing.T) {
@@ -105,6 +107,142 @@ func TestValidate(t *testing.T) {
 				Annotations: map[string]string{"eventing.knative.dev/broker.class": "MTChannelBasedBroker"},
 			},
 		},
+	}, {
+		name: "valid event validation",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":     "MTChannelBasedBroker",
+					"eventing.knative.dev/event-validation": "spec-strict",
+				},
+			},
+		},
+	}, {
+		name: "invalid event validation",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":     "MTChannelBasedBroker",
+					"eventing.knative.dev/event-validation": "strict",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("strict", "metadata.annotations.eventing.knative.dev/event-validation"),
+	}, {
+		name: "valid maintenance window",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/maintenance-window": "2024-06-01T02:00:00Z/2024-06-01T03:00:00Z",
+				},
+			},
+		},
+	}, {
+		name: "malformed maintenance window",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/maintenance-window": "2024-06-01T02:00:00Z",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("2024-06-01T02:00:00Z", "eventing.knative.dev/maintenance-window",
+			`expected <start>/<end>, got "2024-06-01T02:00:00Z"`).ViaField("metadata", "annotations"),
+	}, {
+		name: "maintenance window ending before its start",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/maintenance-window": "2024-06-01T03:00:00Z/2024-06-01T02:00:00Z",
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue(-time.Hour, time.Duration(0), MaxMaintenanceWindow, "eventing.knative.dev/maintenance-window").ViaField("metadata", "annotations"),
+	}, {
+		name: "maintenance window too long",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/maintenance-window": "2024-06-01T02:00:00Z/2024-06-03T02:00:00Z",
+				},
+			},
+		},
+		want: apis.ErrOutOfBoundsValue(48*time.Hour, time.Duration(0), MaxMaintenanceWindow, "eventing.knative.dev/maintenance-window").ViaField("metadata", "annotations"),
+	}, {
+		name: "valid ingress rate limit",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-rate-limit": "0.5,10",
+				},
+			},
+		},
+	}, {
+		name: "invalid ingress rate limit",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":       "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-rate-limit": "0",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("0", "eventing.knative.dev/ingress-rate-limit",
+			`expected a positive number of events per second, got "0"`).ViaField("metadata", "annotations"),
+	}, {
+		name: "valid ingress header extensions",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":              "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-header-extensions": "X-Request-Id=requestid,X-Tenant-Id=tenant",
+				},
+			},
+		},
+	}, {
+		name: "invalid ingress header extensions",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":              "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-header-extensions": "Cookie=session",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("Cookie=session", "eventing.knative.dev/ingress-header-extensions",
+			`header "Cookie" carries credentials`).ViaField("metadata", "annotations"),
+	}, {
+		name: "valid ingress exposure",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":     "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-hostname": "events.example.com",
+					"eventing.knative.dev/ingress-gateway":  "gateways/public",
+					"eventing.knative.dev/ingress-tls":      "true",
+				},
+			},
+		},
+	}, {
+		name: "invalid ingress exposure",
+		b: Broker{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"eventing.knative.dev/broker.class":     "MTChannelBasedBroker",
+					"eventing.knative.dev/ingress-hostname": "events.example.com",
+				},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: "invalid ingress exposure",
+			Paths:   []string{"annotations"},
+			Details: "the eventing.knative.dev/ingress-gateway annotation is required",
+		}).ViaField("metadata"),
 	}, {
 		name: "valid config",
 		b: Broker{
@@ -416,3 +554,48 @@ func TestValidSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestBrokerQuotaValidation(t *testing.T) {
+	b := Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "b",
+			Annotations: map[string]string{"eventing.knative.dev/broker.class": "MTChannelBasedBroker"},
+		},
+	}
+	cfg := &quota.Config{
+		Default:    quota.Limits{quota.Brokers: 1},
+		Namespaces: map[string]quota.Limits{"unlimited": {quota.Brokers: quota.Unlimited}},
+	}
+	counter := func(context.Context, quota.Resource, string) (int, error) {
+		return 1, nil
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		want      *apis.FieldError
+	}{{
+		name:      "namespace quota exceeded",
+		namespace: "ns",
+		want: &apis.FieldError{
+			Message: `namespace "ns" already has 1 Brokers, the maximum allowed is 1`,
+			Details: "the limit is set by the config-namespace-quotas ConfigMap",
+		},
+	}, {
+		name:      "unlimited namespace",
+		namespace: "unlimited",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := quota.ToContext(apis.WithinCreate(context.Background()), cfg)
+			ctx = quota.WithCounter(ctx, counter)
+			b := b.DeepCopy()
+			b.Namespace = test.namespace
+			got := b.Validate(ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("Broker.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/apis/config"
 )
 
 const (
@@ -27,6 +29,10 @@ const (
 )
 
 func (t *Trigger) SetDefaults(ctx context.Context) {
+	if d := config.NamespaceDefaultsFromContext(ctx, t.Namespace); d != nil {
+		t.Labels = d.ApplyLabels(t.Labels)
+	}
+
 	withNS := apis.WithinParent(ctx, t.ObjectMeta)
 	t.Spec.SetDefaults(withNS)
 	setLabels(t)
@@ -39,6 +45,12 @@ func (ts *TriggerSpec) SetDefaults(ctx context.Context) {
 	}
 	// Default the Subscriber namespace
 	ts.Subscriber.SetDefaults(ctx)
+	for i := range ts.Subscribers {
+		ts.Subscribers[i].Destination.SetDefaults(ctx)
+	}
+	if ts.Mirror != nil {
+		ts.Mirror.SetDefaults(ctx)
+	}
 	ts.Delivery.SetDefaults(ctx)
 }
 
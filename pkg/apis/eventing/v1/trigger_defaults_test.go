@@ -22,10 +22,13 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
 
+	"knative.dev/eventing/pkg/apis/config"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 )
 
@@ -154,3 +157,36 @@ func TestTriggerDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestTriggerNamespaceDefaults(t *testing.T) {
+	lookup := func(context.Context, string) (*config.NamespaceDefaults, error) {
+		return &config.NamespaceDefaults{
+			Labels:      map[string]string{"team": "payments", brokerLabel: "ignored"},
+			BrokerClass: "namespace-class",
+			Delivery:    &eventingduckv1.DeliverySpec{Retry: pointer.Int32(7)},
+		}, nil
+	}
+	ctx := apis.WithinCreate(config.WithNamespaceDefaultsLookup(context.Background(), lookup))
+
+	tr := Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    map[string]string{"team": "search"},
+		},
+		Spec: TriggerSpec{Broker: otherBroker},
+	}
+	tr.SetDefaults(ctx)
+
+	// Only the labels apply to Triggers, which inherit the delivery of their
+	// Broker.
+	expected := Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    map[string]string{"team": "search", brokerLabel: otherBroker},
+		},
+		Spec: TriggerSpec{Broker: otherBroker, Filter: emptyTriggerFilter},
+	}
+	if diff := cmp.Diff(expected, tr); diff != "" {
+		t.Error("Unexpected defaults (-want, +got):", diff)
+	}
+}
@@ -26,7 +26,7 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
-var triggerCondSet = apis.NewLivingConditionSet(TriggerConditionBroker, TriggerConditionSubscribed, TriggerConditionDependency, TriggerConditionSubscriberResolved, TriggerConditionDeadLetterSinkResolved, TriggerConditionOIDCIdentityCreated)
+var triggerCondSet = apis.NewLivingConditionSet(TriggerConditionBroker, TriggerConditionSubscribed, TriggerConditionDependency, TriggerConditionDependencies, TriggerConditionSubscriberResolved, TriggerConditionDeadLetterSinkResolved, TriggerConditionOIDCIdentityCreated)
 
 const (
 	// TriggerConditionReady has status True when all subconditions below have been set to True.
@@ -38,12 +38,21 @@ const (
 
 	TriggerConditionDependency apis.ConditionType = "DependencyReady"
 
+	// TriggerConditionDependencies has status True when all the sources
+	// listed in the dependencies of the Trigger are ready.
+	TriggerConditionDependencies apis.ConditionType = "DependenciesReady"
+
 	TriggerConditionSubscriberResolved apis.ConditionType = "SubscriberResolved"
 
 	TriggerConditionDeadLetterSinkResolved apis.ConditionType = "DeadLetterSinkResolved"
 
 	TriggerConditionOIDCIdentityCreated apis.ConditionType = "OIDCIdentityCreated"
 
+	// TriggerConditionDeadLetterSinkReachable reports whether the dead letter
+	// sink of the Trigger answered the last probe. It is informational, and
+	// doesn't affect the readiness of the Trigger.
+	TriggerConditionDeadLetterSinkReachable apis.ConditionType = "DeadLetterSinkReachable"
+
 	// TriggerAnyFilter Constant to represent that we should allow anything.
 	TriggerAnyFilter = ""
 )
@@ -170,6 +179,24 @@ func (ts *TriggerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat
 	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionDeadLetterSinkResolved, reason, messageFormat, messageA...)
 }
 
+func (ts *TriggerStatus) MarkDeadLetterSinkReachable() {
+	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionDeadLetterSinkReachable)
+}
+
+func (ts *TriggerStatus) MarkDeadLetterSinkUnreachable(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionDeadLetterSinkReachable, reason, messageFormat, messageA...)
+}
+
+func (ts *TriggerStatus) MarkDeadLetterSinkReachableUnknown(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkUnknown(TriggerConditionDeadLetterSinkReachable, reason, messageFormat, messageA...)
+}
+
+// ClearDeadLetterSinkReachable removes the DeadLetterSinkReachable condition,
+// when the Trigger has no dead letter sink or it isn't probed.
+func (ts *TriggerStatus) ClearDeadLetterSinkReachable() {
+	_ = triggerCondSet.Manage(ts).ClearCondition(TriggerConditionDeadLetterSinkReachable)
+}
+
 func (ts *TriggerStatus) MarkDependencySucceeded() {
 	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionDependency)
 }
@@ -206,6 +233,18 @@ func (ts *TriggerStatus) PropagateDependencyStatus(ks *duckv1.Source) {
 	}
 }
 
+func (ts *TriggerStatus) MarkDependenciesSucceeded() {
+	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionDependencies)
+}
+
+func (ts *TriggerStatus) MarkDependenciesFailed(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionDependencies, reason, messageFormat, messageA...)
+}
+
+func (ts *TriggerStatus) MarkDependenciesUnknown(reason, messageFormat string, messageA ...interface{}) {
+	triggerCondSet.Manage(ts).MarkUnknown(TriggerConditionDependencies, reason, messageFormat, messageA...)
+}
+
 func (ts *TriggerStatus) MarkOIDCIdentityCreatedSucceeded() {
 	triggerCondSet.Manage(ts).MarkTrue(TriggerConditionOIDCIdentityCreated)
 }
@@ -150,6 +150,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 				}, {
 					Type:   TriggerConditionDeadLetterSinkResolved,
 					Status: corev1.ConditionUnknown,
+				}, {
+					Type:   TriggerConditionDependencies,
+					Status: corev1.ConditionUnknown,
 				}, {
 					Type:   TriggerConditionDependency,
 					Status: corev1.ConditionUnknown,
@@ -187,6 +190,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 				}, {
 					Type:   TriggerConditionDeadLetterSinkResolved,
 					Status: corev1.ConditionUnknown,
+				}, {
+					Type:   TriggerConditionDependencies,
+					Status: corev1.ConditionUnknown,
 				}, {
 					Type:   TriggerConditionDependency,
 					Status: corev1.ConditionUnknown,
@@ -224,6 +230,9 @@ func TestTriggerInitializeConditions(t *testing.T) {
 				}, {
 					Type:   TriggerConditionDeadLetterSinkResolved,
 					Status: corev1.ConditionUnknown,
+				}, {
+					Type:   TriggerConditionDependencies,
+					Status: corev1.ConditionUnknown,
 				}, {
 					Type:   TriggerConditionDependency,
 					Status: corev1.ConditionUnknown,
@@ -409,6 +418,7 @@ func TestTriggerConditionStatus(t *testing.T) {
 					ts.MarkDependencyFailed("The status of dependency is false", "The status of dependency is unknown: nil")
 				}
 			}
+			ts.MarkDependenciesSucceeded()
 			if test.oidcServiceAccountStatus {
 				ts.MarkOIDCIdentityCreatedSucceeded()
 			} else {
@@ -421,3 +431,62 @@ func TestTriggerConditionStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestTriggerDeadLetterSinkReachable(t *testing.T) {
+	ts := &TriggerStatus{}
+	ts.InitializeConditions()
+	ts.PropagateBrokerCondition(TestHelper.ReadyBrokerStatus().GetTopLevelCondition())
+	ts.PropagateSubscriptionCondition(TestHelper.ReadySubscriptionCondition())
+	ts.MarkSubscriberResolvedSucceeded()
+	ts.MarkDeadLetterSinkResolvedSucceeded()
+	ts.MarkDependencySucceeded()
+	ts.MarkDependenciesSucceeded()
+	ts.MarkOIDCIdentityCreatedSucceeded()
+
+	ts.MarkDeadLetterSinkUnreachable("DeadLetterSinkUnreachable", "connection refused")
+	if got := ts.GetCondition(TriggerConditionDeadLetterSinkReachable); got == nil || got.Status != corev1.ConditionFalse || got.Severity != apis.ConditionSeverityInfo {
+		t.Errorf("unexpected DeadLetterSinkReachable condition: %v", got)
+	}
+	if !ts.IsReady() {
+		t.Error("an unreachable dead letter sink must not affect the readiness of the Trigger")
+	}
+
+	ts.MarkDeadLetterSinkReachable()
+	if got := ts.GetCondition(TriggerConditionDeadLetterSinkReachable); got == nil || got.Status != corev1.ConditionTrue {
+		t.Errorf("unexpected DeadLetterSinkReachable condition: %v", got)
+	}
+
+	ts.ClearDeadLetterSinkReachable()
+	if got := ts.GetCondition(TriggerConditionDeadLetterSinkReachable); got != nil {
+		t.Errorf("unexpected DeadLetterSinkReachable condition: %v", got)
+	}
+	if !ts.IsReady() {
+		t.Error("expected the Trigger to be ready")
+	}
+}
+
+func TestTriggerDependenciesNotReady(t *testing.T) {
+	ts := &TriggerStatus{}
+	ts.InitializeConditions()
+	ts.PropagateBrokerCondition(TestHelper.ReadyBrokerStatus().GetTopLevelCondition())
+	ts.PropagateSubscriptionCondition(TestHelper.ReadySubscriptionCondition())
+	ts.MarkSubscriberResolvedSucceeded()
+	ts.MarkDeadLetterSinkResolvedSucceeded()
+	ts.MarkDependencySucceeded()
+	ts.MarkOIDCIdentityCreatedSucceeded()
+
+	ts.MarkDependenciesFailed("DependenciesNotReady", "PingSource test-ping-source does not exist")
+	if got := ts.GetTopLevelCondition().Status; got != corev1.ConditionFalse {
+		t.Errorf("unexpected readiness: want %v, got %v", corev1.ConditionFalse, got)
+	}
+
+	ts.MarkDependenciesUnknown("DependenciesNotReady", "PingSource test-ping-source is not ready yet")
+	if got := ts.GetTopLevelCondition().Status; got != corev1.ConditionUnknown {
+		t.Errorf("unexpected readiness: want %v, got %v", corev1.ConditionUnknown, got)
+	}
+
+	ts.MarkDependenciesSucceeded()
+	if !ts.IsReady() {
+		t.Error("expected the Trigger to be ready")
+	}
+}
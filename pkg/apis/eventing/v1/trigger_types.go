@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
@@ -29,6 +31,7 @@ import (
 const (
 	// DependencyAnnotation is the annotation key used to mark the sources that the Trigger depends on.
 	// This will be used when the kn client creates a source and trigger pair for the user such that the trigger only receives events produced by the paired source.
+	// Deprecated: use TriggerSpec.Dependencies, which supports several dependencies.
 	DependencyAnnotation = "knative.dev/dependency"
 
 	// InjectionAnnotation is the annotation key used to enable knative eventing
@@ -101,12 +104,55 @@ type TriggerSpec struct {
 	Filters []SubscriptionsAPIFilter `json:"filters,omitempty"`
 
 	// Subscriber is the addressable that receives events from the Broker that pass
-	// the Filter. It is required.
+	// the Filter. It is required, unless Subscribers is set.
 	Subscriber duckv1.Destination `json:"subscriber"`
 
+	// Subscribers is an experimental field splitting the events that pass the
+	// Filter between several addressables, each receiving a share of the events
+	// proportional to its weight. It allows, for example, to gradually move the
+	// traffic of a Trigger to a new version of its subscriber. It replaces
+	// Subscriber and requires the trigger-weighted-subscribers feature.
+	//
+	// +optional
+	Subscribers []TriggerSubscriber `json:"subscribers,omitempty"`
+
+	// Mirror is an experimental field for an addressable that receives a copy
+	// of every event that passes the Filter, in addition to the subscriber.
+	// The copy is sent asynchronously, without retries, and its failures are
+	// ignored, so that a new consumer can be tested against the traffic of the
+	// Trigger without affecting its subscriber. It requires the trigger-mirror
+	// feature.
+	//
+	// +optional
+	Mirror *duckv1.Destination `json:"mirror,omitempty"`
+
 	// Delivery contains the delivery spec for this specific trigger.
 	// +optional
 	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Dependencies is an experimental field for the sources the Trigger
+	// depends on, in the namespace of the Trigger. The Trigger is ready only
+	// when all of them are ready. It generalizes the knative.dev/dependency
+	// annotation and requires the trigger-dependencies feature.
+	//
+	// +optional
+	Dependencies []duckv1.KReference `json:"dependencies,omitempty"`
+}
+
+// TriggerSubscriber is an addressable receiving a share of the events of a
+// Trigger.
+type TriggerSubscriber struct {
+	// Name identifies the subscriber in the status and the metrics of the
+	// Trigger. It must be unique within the Trigger.
+	Name string `json:"name"`
+
+	// Destination is the addressable that receives the events.
+	Destination duckv1.Destination `json:"destination"`
+
+	// Weight is the share of the events the subscriber receives, relative to
+	// the sum of the weights of all the subscribers of the Trigger. A
+	// subscriber with a weight of 0 receives no events.
+	Weight int32 `json:"weight"`
 }
 
 type TriggerFilter struct {
@@ -176,6 +222,82 @@ type SubscriptionsAPIFilter struct {
 	//
 	// +optional
 	CESQL string `json:"cesql,omitempty"`
+
+	// Time evaluates to true if the time attribute of the CloudEvent meets
+	// all the conditions set. The CloudEvents without a time attribute don't
+	// match. It requires the trigger-time-filters feature.
+	//
+	// +optional
+	Time *TimeFilter `json:"time,omitempty"`
+}
+
+// TimeFilter matches the CloudEvents on their time attribute. At least one
+// condition must be set, and the CloudEvents must meet all of them.
+type TimeFilter struct {
+	// After matches the CloudEvents whose time is after this time.
+	//
+	// +optional
+	After *metav1.Time `json:"after,omitempty"`
+
+	// Before matches the CloudEvents whose time is before this time. Along
+	// with After, it matches the CloudEvents between the two times.
+	//
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+
+	// MinAge matches the CloudEvents whose time is at least this duration
+	// ago when they are filtered. It is an ISO 8601 duration, e.g. PT1M.
+	//
+	// +optional
+	MinAge *string `json:"minAge,omitempty"`
+
+	// MaxAge matches the CloudEvents whose time is at most this duration ago
+	// when they are filtered. It is an ISO 8601 duration, e.g. PT5M.
+	//
+	// +optional
+	MaxAge *string `json:"maxAge,omitempty"`
+
+	// Window matches the CloudEvents whose time falls in a window recurring
+	// every day, like business hours.
+	//
+	// +optional
+	Window *TimeWindow `json:"window,omitempty"`
+}
+
+// TimeWindowLayout is the layout of the start and end of a TimeWindow.
+const TimeWindowLayout = "15:04"
+
+// Weekdays are the days of the week of a TimeWindow, by name.
+var Weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+// TimeWindow is a window of time recurring on some days of the week.
+type TimeWindow struct {
+	// Start is the time of day the window opens, as HH:MM.
+	Start string `json:"start"`
+
+	// End is the time of day the window closes, as HH:MM, excluded. A window
+	// ending before it starts spans midnight.
+	End string `json:"end"`
+
+	// Days are the days of the week the window opens, as Mon, Tue, Wed, Thu,
+	// Fri, Sat or Sun. The window opens every day by default.
+	//
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// TimeZone is the IANA name of the time zone of the window, e.g.
+	// Europe/Paris. It is UTC by default.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // TriggerFilterAttributes is a map of context attribute names to values for
@@ -203,6 +325,24 @@ type TriggerStatus struct {
 	// +optional
 	SubscriberAudience *string `json:"subscriberAudience,omitempty"`
 
+	// Subscribers are the resolved addressables of the subscribers of a
+	// Trigger splitting its events between several subscribers.
+	// +optional
+	Subscribers []TriggerSubscriberStatus `json:"subscribers,omitempty"`
+
+	// MirrorURI is the resolved URI of the mirror of the Trigger.
+	// +optional
+	MirrorURI *apis.URL `json:"mirrorUri,omitempty"`
+
+	// MirrorCACerts is the Certification Authority (CA) certificates in PEM format
+	// according to https://www.rfc-editor.org/rfc/rfc7468 of the mirror of the Trigger.
+	// +optional
+	MirrorCACerts *string `json:"mirrorCACerts,omitempty"`
+
+	// MirrorAudience is the OIDC audience of the mirror.
+	// +optional
+	MirrorAudience *string `json:"mirrorAudience,omitempty"`
+
 	// DeliveryStatus contains a resolved URL to the dead letter sink address, and any other
 	// resolved delivery options.
 	eventingduckv1.DeliveryStatus `json:",inline"`
@@ -212,6 +352,27 @@ type TriggerStatus struct {
 	Auth *duckv1.AuthStatus `json:"auth,omitempty"`
 }
 
+// TriggerSubscriberStatus is the resolved addressable of a TriggerSubscriber.
+type TriggerSubscriberStatus struct {
+	// Name is the name of the TriggerSubscriber.
+	Name string `json:"name"`
+
+	// URI is the resolved URI of the subscriber.
+	URI *apis.URL `json:"uri,omitempty"`
+
+	// CACerts are the Certification Authority (CA) certificates in PEM format
+	// according to https://www.rfc-editor.org/rfc/rfc7468 of the subscriber.
+	// +optional
+	CACerts *string `json:"CACerts,omitempty"`
+
+	// Audience is the OIDC audience of the subscriber.
+	// +optional
+	Audience *string `json:"audience,omitempty"`
+
+	// Weight is the weight of the TriggerSubscriber.
+	Weight int32 `json:"weight"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // TriggerList is a collection of Triggers.
@@ -21,16 +21,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	cesqlparser "github.com/cloudevents/sdk-go/sql/v2/parser"
+	"github.com/rickb777/date/period"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	cn "knative.dev/eventing/pkg/crossnamespace"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/logging"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/quota"
 )
 
 var (
@@ -40,7 +47,11 @@ var (
 
 // Validate the Trigger.
 func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
+	if original, ok := apis.GetBaseline(ctx).(*Trigger); ok && original != nil && apis.IsInUpdate(ctx) {
+		ctx = eventingduckv1.WithBaselineDelivery(ctx, original.Spec.Delivery)
+	}
 	errs := t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+	errs = errs.Also(t.validateDependencies(ctx).ViaField("spec"))
 	errs = t.validateAnnotation(errs, DependencyAnnotation, t.validateDependencyAnnotation)
 	errs = t.validateAnnotation(errs, InjectionAnnotation, t.validateInjectionAnnotation)
 	if apis.IsInUpdate(ctx) {
@@ -53,7 +64,7 @@ func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(crossNamespaceError)
 		}
 	}
-	return errs
+	return errs.Also(quota.Validate(ctx, quota.Triggers, t.Namespace))
 }
 
 // Validate the TriggerSpec.
@@ -77,12 +88,119 @@ func (ts *TriggerSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	).Also(
 		ValidateSubscriptionAPIFiltersList(ctx, ts.Filters).ViaField("filters"),
 	).Also(
-		ts.Subscriber.Validate(ctx).ViaField("subscriber"),
+		ts.validateSubscribers(ctx),
+	).Also(
+		ts.validateMirror(ctx),
 	).Also(
 		ts.Delivery.Validate(ctx).ViaField("delivery"),
 	)
 }
 
+// validateSubscribers validates Subscriber or, when set, Subscribers.
+func (ts *TriggerSpec) validateSubscribers(ctx context.Context) (errs *apis.FieldError) {
+	if len(ts.Subscribers) == 0 {
+		return ts.Subscriber.Validate(ctx).ViaField("subscriber")
+	}
+
+	if !feature.FromContext(ctx).IsEnabled(feature.TriggerWeightedSubscribers) {
+		fe := apis.ErrDisallowedFields("subscribers")
+		fe.Details = fmt.Sprintf("the %s feature is disabled", feature.TriggerWeightedSubscribers)
+		return fe
+	}
+	if ts.Subscriber.Ref != nil || ts.Subscriber.URI != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("subscriber", "subscribers"))
+	}
+
+	names := make(map[string]struct{}, len(ts.Subscribers))
+	var totalWeight int64
+	for i, sub := range ts.Subscribers {
+		if sub.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("subscribers", i))
+		} else if msgs := validation.IsDNS1123Label(sub.Name); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(sub.Name, "name", strings.Join(msgs, ", ")).ViaFieldIndex("subscribers", i))
+		} else if _, ok := names[sub.Name]; ok {
+			errs = errs.Also(apis.ErrInvalidValue(sub.Name, "name", "duplicate subscriber name").ViaFieldIndex("subscribers", i))
+		}
+		names[sub.Name] = struct{}{}
+
+		if sub.Weight < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(sub.Weight, "weight", "must not be negative").ViaFieldIndex("subscribers", i))
+		} else {
+			totalWeight += int64(sub.Weight)
+		}
+
+		errs = errs.Also(sub.Destination.Validate(ctx).ViaField("destination").ViaFieldIndex("subscribers", i))
+	}
+	if totalWeight == 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "at least one subscriber must have a positive weight",
+			Paths:   []string{"subscribers"},
+		})
+	}
+	return errs
+}
+
+// validateMirror validates Mirror, when set.
+func (ts *TriggerSpec) validateMirror(ctx context.Context) *apis.FieldError {
+	if ts.Mirror == nil {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.TriggerMirror) {
+		fe := apis.ErrDisallowedFields("mirror")
+		fe.Details = fmt.Sprintf("the %s feature is disabled", feature.TriggerMirror)
+		return fe
+	}
+	return ts.Mirror.Validate(ctx).ViaField("mirror")
+}
+
+// validateDependencies validates Dependencies, when set. The dependencies
+// must be in the namespace of the Trigger.
+func (t *Trigger) validateDependencies(ctx context.Context) (errs *apis.FieldError) {
+	if len(t.Spec.Dependencies) == 0 {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.TriggerDependencies) {
+		fe := apis.ErrDisallowedFields("dependencies")
+		fe.Details = fmt.Sprintf("the %s feature is disabled", feature.TriggerDependencies)
+		return fe
+	}
+
+	refs := make(map[duckv1.KReference]struct{}, len(t.Spec.Dependencies))
+	for i, dep := range t.Spec.Dependencies {
+		var fe *apis.FieldError
+		if dep.Namespace != "" && dep.Namespace != t.GetNamespace() {
+			fe = fe.Also(&apis.FieldError{
+				Message: fmt.Sprintf("Namespace must be empty or equal to the trigger namespace %q", t.GetNamespace()),
+				Paths:   []string{"namespace"},
+			})
+		}
+		if dep.Kind == "" {
+			fe = fe.Also(apis.ErrMissingField("kind"))
+		}
+		if dep.Name == "" {
+			fe = fe.Also(apis.ErrMissingField("name"))
+		}
+		if dep.APIVersion == "" {
+			fe = fe.Also(apis.ErrMissingField("apiVersion"))
+		}
+		if dep.Group != "" {
+			fe = fe.Also(apis.ErrDisallowedFields("group"))
+		}
+		if dep.Address != nil {
+			fe = fe.Also(apis.ErrDisallowedFields("address"))
+		}
+
+		key := duckv1.KReference{Kind: dep.Kind, Name: dep.Name, APIVersion: dep.APIVersion}
+		if _, ok := refs[key]; ok && fe == nil {
+			fe = apis.ErrInvalidValue(dep.Name, "name", "duplicate dependency")
+		}
+		refs[key] = struct{}{}
+
+		errs = errs.Also(fe.ViaFieldIndex("dependencies", i))
+	}
+	return errs
+}
+
 // CheckImmutableFields checks that any immutable fields were not changed.
 func (t *Trigger) CheckImmutableFields(ctx context.Context, original *Trigger) *apis.FieldError {
 	if original == nil {
@@ -235,10 +353,78 @@ func ValidateSubscriptionAPIFilter(ctx context.Context, filter *SubscriptionsAPI
 		ValidateSubscriptionAPIFilter(ctx, filter.Not).ViaField("not"),
 	).Also(
 		ValidateCESQLExpression(ctx, filter.CESQL).ViaField("cesql"),
+	).Also(
+		ValidateTimeFilter(ctx, filter.Time).ViaField("time"),
 	)
 	return errs
 }
 
+// ValidateTimeFilter validates the time dialect of a filter, when set.
+func ValidateTimeFilter(ctx context.Context, filter *TimeFilter) (errs *apis.FieldError) {
+	if filter == nil {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.TriggerTimeFilters) {
+		fe := apis.ErrDisallowedFields(apis.CurrentField)
+		fe.Details = fmt.Sprintf("the %s feature is disabled", feature.TriggerTimeFilters)
+		return fe
+	}
+	if filter.After == nil && filter.Before == nil && filter.MinAge == nil && filter.MaxAge == nil && filter.Window == nil {
+		return apis.ErrMissingOneOf("after", "before", "minAge", "maxAge", "window")
+	}
+
+	if filter.After != nil && filter.Before != nil && !filter.Before.After(filter.After.Time) {
+		errs = errs.Also(apis.ErrInvalidValue(filter.Before.Format(time.RFC3339), "before", "must be after the after time"))
+	}
+	minAge, minErr := validateAge(filter.MinAge, "minAge")
+	maxAge, maxErr := validateAge(filter.MaxAge, "maxAge")
+	errs = errs.Also(minErr).Also(maxErr)
+	if minErr == nil && maxErr == nil && filter.MinAge != nil && filter.MaxAge != nil && maxAge <= minAge {
+		errs = errs.Also(apis.ErrInvalidValue(*filter.MaxAge, "maxAge", "must be longer than minAge"))
+	}
+	return errs.Also(filter.Window.validate().ViaField("window"))
+}
+
+func validateAge(age *string, field string) (time.Duration, *apis.FieldError) {
+	if age == nil {
+		return 0, nil
+	}
+	p, err := period.Parse(*age)
+	if err != nil || p.IsZero() || p.IsNegative() {
+		return 0, apis.ErrInvalidValue(*age, field, "must be a positive ISO 8601 duration")
+	}
+	d, _ := p.Duration()
+	return d, nil
+}
+
+func (w *TimeWindow) validate() (errs *apis.FieldError) {
+	if w == nil {
+		return nil
+	}
+	start, err := time.Parse(TimeWindowLayout, w.Start)
+	if err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.Start, "start", "must be a time of day as HH:MM"))
+	}
+	end, err := time.Parse(TimeWindowLayout, w.End)
+	if err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.End, "end", "must be a time of day as HH:MM"))
+	}
+	if errs == nil && start.Equal(end) {
+		errs = errs.Also(apis.ErrInvalidValue(w.End, "end", "must differ from start"))
+	}
+	for i, day := range w.Days {
+		if _, ok := Weekdays[day]; !ok {
+			errs = errs.Also(apis.ErrInvalidArrayValue(day, "days", i))
+		}
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(w.TimeZone, "timeZone", err.Error()))
+		}
+	}
+	return errs
+}
+
 func ValidateOneOf(filter *SubscriptionsAPIFilter) (err *apis.FieldError) {
 	if filter != nil && hasMultipleDialects(filter) {
 		return apis.ErrGeneric("multiple dialects found, filters can have only one dialect set")
@@ -286,7 +472,14 @@ func hasMultipleDialects(filter *SubscriptionsAPIFilter) bool {
 			dialectFound = true
 		}
 	}
-	if filter.CESQL != "" && dialectFound {
+	if filter.CESQL != "" {
+		if dialectFound {
+			return true
+		} else {
+			dialectFound = true
+		}
+	}
+	if filter.Time != nil && dialectFound {
 		return true
 	}
 	return false
@@ -20,11 +20,13 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
@@ -354,6 +356,44 @@ func TestTriggerUpdateValidation(t *testing.T) {
 	}
 }
 
+func TestTriggerUpdateValidationWithDeliveryTimeoutMax(t *testing.T) {
+	trigger := func(timeout string) *Trigger {
+		return &Trigger{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: "test-ns",
+			},
+			Spec: TriggerSpec{
+				Broker:     "test_broker",
+				Filter:     validEmptyTriggerFilter,
+				Subscriber: validSubscriber,
+				Delivery:   &eventingduckv1.DeliverySpec{Timeout: ptr.String(timeout)},
+			}}
+	}
+	ctx := feature.ToContext(context.Background(), feature.Flags{
+		feature.DeliveryTimeout:    feature.Enabled,
+		feature.DeliveryTimeoutMax: "PT10M",
+	})
+
+	// The Triggers created before the maximum was set can still be updated.
+	original := trigger("PT1H")
+	updated := original.DeepCopy()
+	updated.Labels = map[string]string{"foo": "bar"}
+	if err := updated.Validate(apis.WithinUpdate(ctx, original)); err != nil {
+		t.Error("Trigger.Validate() =", err)
+	}
+
+	want := apis.ErrOutOfBoundsValue("PT2H", "PT0S", "PT10M", "spec.delivery.timeout")
+	got := trigger("PT2H").Validate(apis.WithinUpdate(ctx, original))
+	if diff := cmp.Diff(want.Error(), got.Error()); diff != "" {
+		t.Error("Trigger.Validate (-want, +got) =", diff)
+	}
+	want = apis.ErrOutOfBoundsValue("PT1H", "PT0S", "PT10M", "spec.delivery.timeout")
+	got = original.Validate(ctx)
+	if diff := cmp.Diff(want.Error(), got.Error()); diff != "" {
+		t.Error("Trigger.Validate (-want, +got) =", diff)
+	}
+}
+
 func TestTriggerSpecValidation(t *testing.T) {
 	invalidString := "invalid time"
 	tests := []struct {
@@ -511,6 +551,222 @@ func TestTriggerSpecValidation(t *testing.T) {
 	}
 }
 
+func TestTriggerSpecValidationWithWeightedSubscribers(t *testing.T) {
+	enabled := feature.ToContext(context.TODO(), feature.Flags{
+		feature.TriggerWeightedSubscribers: feature.Enabled,
+	})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ts   *TriggerSpec
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ctx:  enabled,
+		ts: &TriggerSpec{
+			Broker: "test_broker",
+			Subscribers: []TriggerSubscriber{
+				{Name: "stable", Destination: validSubscriber, Weight: 90},
+				{Name: "canary", Destination: validSubscriber, Weight: 10},
+			},
+		},
+	}, {
+		name: "feature disabled",
+		ctx:  context.TODO(),
+		ts: &TriggerSpec{
+			Broker: "test_broker",
+			Subscribers: []TriggerSubscriber{
+				{Name: "stable", Destination: validSubscriber, Weight: 1},
+			},
+		},
+		want: func() *apis.FieldError {
+			fe := apis.ErrDisallowedFields("subscribers")
+			fe.Details = "the trigger-weighted-subscribers feature is disabled"
+			return fe
+		}(),
+	}, {
+		name: "subscriber and subscribers",
+		ctx:  enabled,
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Subscribers: []TriggerSubscriber{
+				{Name: "stable", Destination: validSubscriber, Weight: 1},
+			},
+		},
+		want: apis.ErrMultipleOneOf("subscriber", "subscribers"),
+	}, {
+		name: "invalid subscribers",
+		ctx:  enabled,
+		ts: &TriggerSpec{
+			Broker: "test_broker",
+			Subscribers: []TriggerSubscriber{
+				{Destination: validSubscriber},
+				{Name: "Stable", Destination: validSubscriber},
+				{Name: "canary", Destination: invalidSubscriber, Weight: -1},
+				{Name: "canary", Destination: validSubscriber},
+			},
+		},
+		want: func() *apis.FieldError {
+			var errs *apis.FieldError
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("subscribers", 0))
+			errs = errs.Also(apis.ErrInvalidValue("Stable", "name", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')").ViaFieldIndex("subscribers", 1))
+			errs = errs.Also(apis.ErrInvalidValue(int32(-1), "weight", "must not be negative").ViaFieldIndex("subscribers", 2))
+			errs = errs.Also(invalidSubscriber.Validate(context.TODO()).ViaField("destination").ViaFieldIndex("subscribers", 2))
+			errs = errs.Also(apis.ErrInvalidValue("canary", "name", "duplicate subscriber name").ViaFieldIndex("subscribers", 3))
+			errs = errs.Also(&apis.FieldError{
+				Message: "at least one subscriber must have a positive weight",
+				Paths:   []string{"subscribers"},
+			})
+			return errs
+		}(),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ts.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate TriggerSpec (-want, +got) =\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTriggerSpecValidationWithMirror(t *testing.T) {
+	enabled := feature.ToContext(context.TODO(), feature.Flags{
+		feature.TriggerMirror: feature.Enabled,
+	})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ts   *TriggerSpec
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ctx:  enabled,
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Mirror:     &validSubscriber,
+		},
+	}, {
+		name: "feature disabled",
+		ctx:  context.TODO(),
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Mirror:     &validSubscriber,
+		},
+		want: func() *apis.FieldError {
+			fe := apis.ErrDisallowedFields("mirror")
+			fe.Details = "the trigger-mirror feature is disabled"
+			return fe
+		}(),
+	}, {
+		name: "invalid mirror",
+		ctx:  enabled,
+		ts: &TriggerSpec{
+			Broker:     "test_broker",
+			Subscriber: validSubscriber,
+			Mirror:     &invalidSubscriber,
+		},
+		want: invalidSubscriber.Validate(context.TODO()).ViaField("mirror"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ts.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate TriggerSpec (-want, +got) =\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTriggerValidationWithDependencies(t *testing.T) {
+	enabled := feature.ToContext(context.TODO(), feature.Flags{
+		feature.TriggerDependencies: feature.Enabled,
+	})
+	pingSource := duckv1.KReference{
+		Kind:       "PingSource",
+		Name:       "test-ping-source",
+		APIVersion: "sources.knative.dev/v1",
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		deps []duckv1.KReference
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ctx:  enabled,
+		deps: []duckv1.KReference{pingSource, {
+			Kind:       "ApiServerSource",
+			Namespace:  "test-ns",
+			Name:       "test-apiserver-source",
+			APIVersion: "sources.knative.dev/v1",
+		}},
+	}, {
+		name: "feature disabled",
+		ctx:  context.TODO(),
+		deps: []duckv1.KReference{pingSource},
+		want: func() *apis.FieldError {
+			fe := apis.ErrDisallowedFields("spec.dependencies")
+			fe.Details = "the trigger-dependencies feature is disabled"
+			return fe
+		}(),
+	}, {
+		name: "missing fields",
+		ctx:  enabled,
+		deps: []duckv1.KReference{pingSource, {}},
+		want: apis.ErrMissingField("kind", "name", "apiVersion").ViaFieldIndex("dependencies", 1).ViaField("spec"),
+	}, {
+		name: "other namespace",
+		ctx:  enabled,
+		deps: []duckv1.KReference{{
+			Kind:       "PingSource",
+			Namespace:  "other-ns",
+			Name:       "test-ping-source",
+			APIVersion: "sources.knative.dev/v1",
+		}},
+		want: &apis.FieldError{
+			Message: `Namespace must be empty or equal to the trigger namespace "test-ns"`,
+			Paths:   []string{"spec.dependencies[0].namespace"},
+		},
+	}, {
+		name: "group",
+		ctx:  enabled,
+		deps: []duckv1.KReference{{
+			Kind:  "PingSource",
+			Name:  "test-ping-source",
+			Group: "sources.knative.dev",
+		}},
+		want: apis.ErrMissingField("apiVersion").Also(apis.ErrDisallowedFields("group")).ViaFieldIndex("dependencies", 0).ViaField("spec"),
+	}, {
+		name: "duplicate",
+		ctx:  enabled,
+		deps: []duckv1.KReference{pingSource, pingSource},
+		want: apis.ErrInvalidValue("test-ping-source", "name", "duplicate dependency").ViaFieldIndex("dependencies", 1).ViaField("spec"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr := &Trigger{
+				ObjectMeta: v1.ObjectMeta{Namespace: "test-ns"},
+				Spec: TriggerSpec{
+					Broker:       "test_broker",
+					Subscriber:   validSubscriber,
+					Dependencies: test.deps,
+				},
+			}
+			got := tr.Validate(test.ctx)
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate Trigger (-want, +got) =\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestTriggerSpecValidationWithCrossNamespaceEventLinksFeatureEnabled(t *testing.T) {
 	invalidString := "invalid time"
 	tests := []struct {
@@ -947,6 +1203,108 @@ func TestFilterSpecValidation(t *testing.T) {
 	}
 }
 
+func TestFilterSpecValidationWithTimeFilters(t *testing.T) {
+	enabled := feature.ToContext(context.TODO(), feature.Flags{
+		feature.NewTriggerFilters:  feature.Enabled,
+		feature.TriggerTimeFilters: feature.Enabled,
+	})
+	after := v1.NewTime(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+	before := v1.NewTime(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name string
+		ctx  context.Context
+		time *TimeFilter
+		want *apis.FieldError
+	}{{
+		name: "valid between",
+		ctx:  enabled,
+		time: &TimeFilter{After: &after, Before: &before},
+	}, {
+		name: "valid age",
+		ctx:  enabled,
+		time: &TimeFilter{MinAge: ptr.String("PT1M"), MaxAge: ptr.String("PT5M")},
+	}, {
+		name: "valid business hours",
+		ctx:  enabled,
+		time: &TimeFilter{Window: &TimeWindow{
+			Start:    "09:00",
+			End:      "17:30",
+			Days:     []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+			TimeZone: "Europe/Paris",
+		}},
+	}, {
+		name: "valid window spanning midnight",
+		ctx:  enabled,
+		time: &TimeFilter{Window: &TimeWindow{Start: "22:00", End: "06:00"}},
+	}, {
+		name: "feature disabled",
+		ctx: feature.ToContext(context.TODO(), feature.Flags{
+			feature.NewTriggerFilters: feature.Enabled,
+		}),
+		time: &TimeFilter{After: &after},
+		want: func() *apis.FieldError {
+			fe := apis.ErrDisallowedFields("time")
+			fe.Details = "the trigger-time-filters feature is disabled"
+			return fe
+		}(),
+	}, {
+		name: "no condition",
+		ctx:  enabled,
+		time: &TimeFilter{},
+		want: apis.ErrMissingOneOf("after", "before", "minAge", "maxAge", "window").ViaField("time"),
+	}, {
+		name: "before not after after",
+		ctx:  enabled,
+		time: &TimeFilter{After: &before, Before: &after},
+		want: apis.ErrInvalidValue("2024-03-01T00:00:00Z", "before", "must be after the after time").ViaField("time"),
+	}, {
+		name: "invalid ages",
+		ctx:  enabled,
+		time: &TimeFilter{MinAge: ptr.String("5 minutes"), MaxAge: ptr.String("-PT1M")},
+		want: apis.ErrInvalidValue("5 minutes", "minAge", "must be a positive ISO 8601 duration").Also(
+			apis.ErrInvalidValue("-PT1M", "maxAge", "must be a positive ISO 8601 duration")).ViaField("time"),
+	}, {
+		name: "max age shorter than min age",
+		ctx:  enabled,
+		time: &TimeFilter{MinAge: ptr.String("PT5M"), MaxAge: ptr.String("PT1M")},
+		want: apis.ErrInvalidValue("PT1M", "maxAge", "must be longer than minAge").ViaField("time"),
+	}, {
+		name: "invalid window",
+		ctx:  enabled,
+		time: &TimeFilter{Window: &TimeWindow{
+			Start:    "9am",
+			End:      "25:00",
+			Days:     []string{"Mon", "Monday"},
+			TimeZone: "Nowhere/Somewhere",
+		}},
+		want: apis.ErrInvalidValue("9am", "start", "must be a time of day as HH:MM").Also(
+			apis.ErrInvalidValue("25:00", "end", "must be a time of day as HH:MM"),
+			apis.ErrInvalidArrayValue("Monday", "days", 1),
+			apis.ErrInvalidValue("Nowhere/Somewhere", "timeZone", "unknown time zone Nowhere/Somewhere"),
+		).ViaField("window").ViaField("time"),
+	}, {
+		name: "empty window",
+		ctx:  enabled,
+		time: &TimeFilter{Window: &TimeWindow{Start: "09:00", End: "09:00"}},
+		want: apis.ErrInvalidValue("09:00", "end", "must differ from start").ViaField("window").ViaField("time"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := &TriggerSpec{
+				Broker:     "test_broker",
+				Filters:    []SubscriptionsAPIFilter{{Time: test.time}},
+				Subscriber: validSubscriber,
+			}
+			want := test.want.ViaIndex(0).ViaField("filters")
+			got := ts.Validate(test.ctx)
+			if diff := cmp.Diff(want.Error(), got.Error()); diff != "" {
+				t.Errorf("Validate TriggerSpec (-want, +got) =\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestTriggerImmutableFields(t *testing.T) {
 	tests := []struct {
 		name     string
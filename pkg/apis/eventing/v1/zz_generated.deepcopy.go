@@ -178,6 +178,11 @@ func (in *SubscriptionsAPIFilter) DeepCopyInto(out *SubscriptionsAPIFilter) {
 			(*out)[key] = val
 		}
 	}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(TimeFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -191,6 +196,66 @@ func (in *SubscriptionsAPIFilter) DeepCopy() *SubscriptionsAPIFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeFilter) DeepCopyInto(out *TimeFilter) {
+	*out = *in
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = (*in).DeepCopy()
+	}
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = (*in).DeepCopy()
+	}
+	if in.MinAge != nil {
+		in, out := &in.MinAge, &out.MinAge
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(string)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(TimeWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeFilter.
+func (in *TimeFilter) DeepCopy() *TimeFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TimeWindow) DeepCopyInto(out *TimeWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeWindow.
+func (in *TimeWindow) DeepCopy() *TimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Trigger) DeepCopyInto(out *Trigger) {
 	*out = *in
@@ -318,11 +383,30 @@ func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
 		}
 	}
 	in.Subscriber.DeepCopyInto(&out.Subscriber)
+	if in.Subscribers != nil {
+		in, out := &in.Subscribers, &out.Subscribers
+		*out = make([]TriggerSubscriber, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Delivery != nil {
 		in, out := &in.Delivery, &out.Delivery
 		*out = new(apisduckv1.DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]duckv1.KReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -355,6 +439,28 @@ func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Subscribers != nil {
+		in, out := &in.Subscribers, &out.Subscribers
+		*out = make([]TriggerSubscriberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MirrorURI != nil {
+		in, out := &in.MirrorURI, &out.MirrorURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MirrorCACerts != nil {
+		in, out := &in.MirrorCACerts, &out.MirrorCACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.MirrorAudience != nil {
+		in, out := &in.MirrorAudience, &out.MirrorAudience
+		*out = new(string)
+		**out = **in
+	}
 	in.DeliveryStatus.DeepCopyInto(&out.DeliveryStatus)
 	if in.Auth != nil {
 		in, out := &in.Auth, &out.Auth
@@ -373,3 +479,51 @@ func (in *TriggerStatus) DeepCopy() *TriggerStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerSubscriber) DeepCopyInto(out *TriggerSubscriber) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSubscriber.
+func (in *TriggerSubscriber) DeepCopy() *TriggerSubscriber {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerSubscriber)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerSubscriberStatus) DeepCopyInto(out *TriggerSubscriberStatus) {
+	*out = *in
+	if in.URI != nil {
+		in, out := &in.URI, &out.URI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CACerts != nil {
+		in, out := &in.CACerts, &out.CACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSubscriberStatus.
+func (in *TriggerSubscriberStatus) DeepCopy() *TriggerSubscriberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerSubscriberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+func TestBrokerDefaultsPolicyDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		initial  BrokerDefaultsPolicy
+		expected BrokerDefaultsPolicy
+	}{
+		"nil spec": {
+			initial:  BrokerDefaultsPolicy{},
+			expected: BrokerDefaultsPolicy{},
+		},
+		"delivery": {
+			initial: BrokerDefaultsPolicy{
+				Spec: BrokerDefaultsPolicySpec{Delivery: &eventingduckv1.DeliverySpec{Retry: pointer.Int32(3)}},
+			},
+			expected: BrokerDefaultsPolicy{
+				Spec: BrokerDefaultsPolicySpec{Delivery: &eventingduckv1.DeliverySpec{Retry: pointer.Int32(3)}},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			tc.initial.SetDefaults(context.TODO())
+			if diff := cmp.Diff(tc.expected, tc.initial); diff != "" {
+				t.Fatal("Unexpected defaults (-want, +got):", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// BrokerDefaultsPolicyConditionReady has status True when the
+	// BrokerDefaultsPolicy applies to at least one namespace.
+	BrokerDefaultsPolicyConditionReady = apis.ConditionReady
+
+	// BrokerDefaultsPolicyConditionNamespacesSelected has status True when
+	// the namespace selector of the BrokerDefaultsPolicy selects at least one
+	// namespace.
+	BrokerDefaultsPolicyConditionNamespacesSelected apis.ConditionType = "NamespacesSelected"
+)
+
+var brokerDefaultsPolicyCondSet = apis.NewLivingConditionSet(
+	BrokerDefaultsPolicyConditionNamespacesSelected,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*BrokerDefaultsPolicy) GetConditionSet() apis.ConditionSet {
+	return brokerDefaultsPolicyCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (s *BrokerDefaultsPolicyStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return brokerDefaultsPolicyCondSet.Manage(s).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (s *BrokerDefaultsPolicyStatus) IsReady() bool {
+	return s.GetTopLevelCondition().IsTrue()
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (s *BrokerDefaultsPolicyStatus) GetTopLevelCondition() *apis.Condition {
+	return brokerDefaultsPolicyCondSet.Manage(s).GetTopLevelCondition()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (s *BrokerDefaultsPolicyStatus) InitializeConditions() {
+	brokerDefaultsPolicyCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkNamespacesSelected records the number of selected namespaces and sets
+// the NamespacesSelected condition to true.
+func (s *BrokerDefaultsPolicyStatus) MarkNamespacesSelected(count int32) {
+	s.SelectedNamespaces = count
+	brokerDefaultsPolicyCondSet.Manage(s).MarkTrue(BrokerDefaultsPolicyConditionNamespacesSelected)
+}
+
+// MarkNoNamespacesSelected sets the NamespacesSelected condition to false.
+func (s *BrokerDefaultsPolicyStatus) MarkNoNamespacesSelected(reason, messageFormat string, messageA ...interface{}) {
+	s.SelectedNamespaces = 0
+	brokerDefaultsPolicyCondSet.Manage(s).MarkFalse(BrokerDefaultsPolicyConditionNamespacesSelected, reason, messageFormat, messageA...)
+}
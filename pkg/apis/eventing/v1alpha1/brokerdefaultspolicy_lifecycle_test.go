@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestBrokerDefaultsPolicyGetConditionSet(t *testing.T) {
+	p := &BrokerDefaultsPolicy{}
+
+	if got, want := p.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestBrokerDefaultsPolicyStatusIsReady(t *testing.T) {
+	s := &BrokerDefaultsPolicyStatus{}
+	s.InitializeConditions()
+	if got := s.GetCondition(BrokerDefaultsPolicyConditionNamespacesSelected); got == nil || got.Status != corev1.ConditionUnknown {
+		t.Errorf("NamespacesSelected = %v, want Unknown", got)
+	}
+	if s.IsReady() {
+		t.Error("Expected an initialized BrokerDefaultsPolicy not to be ready")
+	}
+
+	s.MarkNamespacesSelected(2)
+	if !s.IsReady() || s.SelectedNamespaces != 2 {
+		t.Errorf("Expected a BrokerDefaultsPolicy selecting 2 namespaces to be ready, got %+v", s)
+	}
+
+	s.MarkNoNamespacesSelected("NoNamespaceSelected", "no namespace matches %s", "team=payments")
+	if s.IsReady() || s.SelectedNamespaces != 0 {
+		t.Errorf("Expected a BrokerDefaultsPolicy selecting no namespace not to be ready, got %+v", s)
+	}
+	if got := s.GetTopLevelCondition(); got.Reason != "NoNamespaceSelected" || got.Message != "no namespace matches team=payments" {
+		t.Errorf("Unexpected Ready condition %+v", got)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BrokerDefaultsPolicy defines the labels, broker class and delivery spec set
+// on the Brokers and Triggers created in the namespaces it selects.
+type BrokerDefaultsPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the BrokerDefaultsPolicy.
+	Spec BrokerDefaultsPolicySpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the BrokerDefaultsPolicy.
+	// This data may be out of date.
+	// +optional
+	Status BrokerDefaultsPolicyStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that BrokerDefaultsPolicy can be validated and defaulted.
+	_ apis.Validatable = (*BrokerDefaultsPolicy)(nil)
+	_ apis.Defaultable = (*BrokerDefaultsPolicy)(nil)
+
+	// Check that BrokerDefaultsPolicy can return its spec untyped.
+	_ apis.HasSpec = (*BrokerDefaultsPolicy)(nil)
+
+	_ runtime.Object = (*BrokerDefaultsPolicy)(nil)
+
+	// Check that we can create OwnerReferences to a BrokerDefaultsPolicy.
+	_ kmeta.OwnerRefable = (*BrokerDefaultsPolicy)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*BrokerDefaultsPolicy)(nil)
+)
+
+// BrokerDefaultsPolicySpec defines the defaults of the Brokers and Triggers.
+// The fields set by the Brokers and Triggers themselves are never
+// overridden, and the defaults only apply when they are created.
+type BrokerDefaultsPolicySpec struct {
+	// NamespaceSelector selects the namespaces the defaults apply to. All
+	// the namespaces are selected when empty.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Labels are added to the Brokers and Triggers.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// BrokerClass is the class of the Brokers, taking precedence over the
+	// class of the config-br-defaults ConfigMap.
+	// +optional
+	BrokerClass string `json:"brokerClass,omitempty"`
+
+	// Delivery is the delivery spec of the Brokers, taking precedence over
+	// the one of the config-br-defaults ConfigMap. The Triggers without a
+	// delivery spec use the one of their Broker.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+}
+
+// BrokerDefaultsPolicyStatus represents the current state of a BrokerDefaultsPolicy.
+type BrokerDefaultsPolicyStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// SelectedNamespaces is the number of namespaces selected by the
+	// BrokerDefaultsPolicy.
+	// +optional
+	SelectedNamespaces int32 `json:"selectedNamespaces,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BrokerDefaultsPolicyList is a collection of BrokerDefaultsPolicy.
+type BrokerDefaultsPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BrokerDefaultsPolicy `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for BrokerDefaultsPolicy
+func (p *BrokerDefaultsPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("BrokerDefaultsPolicy")
+}
+
+// GetUntypedSpec returns the spec of the BrokerDefaultsPolicy.
+func (p *BrokerDefaultsPolicy) GetUntypedSpec() interface{} {
+	return p.Spec
+}
+
+// GetStatus retrieves the status of the BrokerDefaultsPolicy. Implements the KRShaped interface.
+func (p *BrokerDefaultsPolicy) GetStatus() *duckv1.Status {
+	return &p.Status.Status
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+func (p *BrokerDefaultsPolicy) Validate(ctx context.Context) *apis.FieldError {
+	return p.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (ps *BrokerDefaultsPolicySpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if len(ps.Labels) == 0 && ps.BrokerClass == "" && ps.Delivery == nil {
+		errs = errs.Also(apis.ErrMissingOneOf("labels", "brokerClass", "delivery"))
+	}
+	if ps.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(ps.NamespaceSelector); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(ps.NamespaceSelector, "namespaceSelector", err.Error()))
+		}
+	}
+	for k, v := range ps.Labels {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "labels", strings.Join(msgs, ", ")))
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(v, apis.CurrentField, strings.Join(msgs, ", ")).ViaFieldKey("labels", k))
+		}
+	}
+	errs = errs.Also(ps.Delivery.Validate(ctx).ViaField("delivery"))
+	return errs
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+func TestBrokerDefaultsPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *BrokerDefaultsPolicy
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+				Labels:            map[string]string{"app.kubernetes.io/part-of": "payments"},
+				BrokerClass:       "MTChannelBasedBroker",
+				Delivery:          &eventingduckv1.DeliverySpec{Retry: pointer.Int32(3)},
+			},
+		},
+	}, {
+		name: "valid, all namespaces",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				BrokerClass: "MTChannelBasedBroker",
+			},
+		},
+	}, {
+		name: "no defaults",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			},
+		},
+		want: apis.ErrMissingOneOf("labels", "brokerClass", "delivery").ViaField("spec"),
+	}, {
+		name: "invalid namespace selector",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+				},
+				BrokerClass: "MTChannelBasedBroker",
+			},
+		},
+		want: apis.ErrInvalidValue(&metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Unknown"}},
+		}, "namespaceSelector", `"Unknown" is not a valid label selector operator`).ViaField("spec"),
+	}, {
+		name: "invalid labels",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				Labels: map[string]string{"-team": "payments"},
+			},
+		},
+		want: apis.ErrInvalidKeyName("-team", "labels",
+			"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')").
+			ViaField("spec"),
+	}, {
+		name: "invalid label value",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				Labels: map[string]string{"team": "pay ments"},
+			},
+		},
+		want: apis.ErrInvalidValue("pay ments", apis.CurrentField,
+			"a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')").
+			ViaFieldKey("labels", "team").ViaField("spec"),
+	}, {
+		name: "invalid delivery",
+		p: &BrokerDefaultsPolicy{
+			Spec: BrokerDefaultsPolicySpec{
+				Delivery: &eventingduckv1.DeliverySpec{Retry: pointer.Int32(-1)},
+			},
+		},
+		want: apis.ErrInvalidValue(int32(-1), "retry").ViaField("delivery").ViaField("spec"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.p.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
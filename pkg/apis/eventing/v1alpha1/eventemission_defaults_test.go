@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestEventEmissionDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		initial  EventEmission
+		expected EventEmission
+	}{
+		"nil spec": {
+			initial:  EventEmission{},
+			expected: EventEmission{},
+		},
+		"sink ref namespace and data content type": {
+			initial: EventEmission{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+				Spec: EventEmissionSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{Kind: "Broker", APIVersion: "eventing.knative.dev/v1", Name: "default"},
+					},
+					Event: EventEmissionEvent{Data: `{"hello":"world"}`},
+				},
+			},
+			expected: EventEmission{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns"},
+				Spec: EventEmissionSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{Kind: "Broker", APIVersion: "eventing.knative.dev/v1", Name: "default", Namespace: "ns"},
+					},
+					Event: EventEmissionEvent{Data: `{"hello":"world"}`, DataContentType: "application/json"},
+				},
+			},
+		},
+		"data content type is preserved": {
+			initial: EventEmission{
+				Spec: EventEmissionSpec{
+					Event: EventEmissionEvent{Data: "hello", DataContentType: "text/plain"},
+				},
+			},
+			expected: EventEmission{
+				Spec: EventEmissionSpec{
+					Event: EventEmissionEvent{Data: "hello", DataContentType: "text/plain"},
+				},
+			},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			tc.initial.SetDefaults(context.TODO())
+			if diff := cmp.Diff(tc.expected, tc.initial); diff != "" {
+				t.Fatal("Unexpected defaults (-want, +got):", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"net/http"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// EventEmissionConditionReady has status True when the EventEmission
+	// resolved its sink and the last emission was accepted by it.
+	EventEmissionConditionReady = apis.ConditionReady
+
+	// EventEmissionConditionSinkProvided has status True when the
+	// EventEmission has been configured with a sink target.
+	EventEmissionConditionSinkProvided apis.ConditionType = "SinkProvided"
+
+	// EventEmissionConditionEmitted has status True when the last emission
+	// was accepted by the sink with a 2xx response.
+	EventEmissionConditionEmitted apis.ConditionType = "Emitted"
+
+	// EventEmissionConditionOIDCIdentityCreated has status True when the
+	// OIDCIdentity has been created. This condition is only relevant if the
+	// OIDC feature is enabled.
+	EventEmissionConditionOIDCIdentityCreated apis.ConditionType = "OIDCIdentityCreated"
+)
+
+var eventEmissionCondSet = apis.NewLivingConditionSet(
+	EventEmissionConditionSinkProvided,
+	EventEmissionConditionEmitted,
+	EventEmissionConditionOIDCIdentityCreated,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*EventEmission) GetConditionSet() apis.ConditionSet {
+	return eventEmissionCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (s *EventEmissionStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return eventEmissionCondSet.Manage(s).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (s *EventEmissionStatus) IsReady() bool {
+	return s.GetTopLevelCondition().IsTrue()
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (s *EventEmissionStatus) GetTopLevelCondition() *apis.Condition {
+	return eventEmissionCondSet.Manage(s).GetTopLevelCondition()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (s *EventEmissionStatus) InitializeConditions() {
+	eventEmissionCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkSink sets the condition that the source has a sink configured.
+func (s *EventEmissionStatus) MarkSink(addr *duckv1.Addressable) {
+	if addr != nil {
+		s.SinkURI = addr.URL
+		s.SinkCACerts = addr.CACerts
+		s.SinkAudience = addr.Audience
+		eventEmissionCondSet.Manage(s).MarkTrue(EventEmissionConditionSinkProvided)
+	} else {
+		eventEmissionCondSet.Manage(s).MarkFalse(EventEmissionConditionSinkProvided, "SinkEmpty", "Sink has resolved to empty.")
+	}
+}
+
+// MarkNoSink sets the condition that the EventEmission does not have a sink configured.
+func (s *EventEmissionStatus) MarkNoSink(reason, messageFormat string, messageA ...interface{}) {
+	eventEmissionCondSet.Manage(s).MarkFalse(EventEmissionConditionSinkProvided, reason, messageFormat, messageA...)
+}
+
+func (s *EventEmissionStatus) MarkOIDCIdentityCreatedSucceeded() {
+	eventEmissionCondSet.Manage(s).MarkTrue(EventEmissionConditionOIDCIdentityCreated)
+}
+
+func (s *EventEmissionStatus) MarkOIDCIdentityCreatedSucceededWithReason(reason, messageFormat string, messageA ...interface{}) {
+	eventEmissionCondSet.Manage(s).MarkTrueWithReason(EventEmissionConditionOIDCIdentityCreated, reason, messageFormat, messageA...)
+}
+
+func (s *EventEmissionStatus) MarkOIDCIdentityCreatedFailed(reason, messageFormat string, messageA ...interface{}) {
+	eventEmissionCondSet.Manage(s).MarkFalse(EventEmissionConditionOIDCIdentityCreated, reason, messageFormat, messageA...)
+}
+
+// MarkEmissionPending sets the condition that no emission has completed yet.
+func (s *EventEmissionStatus) MarkEmissionPending(reason, messageFormat string, messageA ...interface{}) {
+	eventEmissionCondSet.Manage(s).MarkUnknown(EventEmissionConditionEmitted, reason, messageFormat, messageA...)
+}
+
+// PropagateEmissionResult records the given result as the last emission and
+// sets the Emitted condition based on the HTTP status code returned by the sink.
+func (s *EventEmissionStatus) PropagateEmissionResult(result *EventEmissionResult) {
+	s.LastEmission = result
+	if result == nil {
+		eventEmissionCondSet.Manage(s).MarkUnknown(EventEmissionConditionEmitted, "EmissionPending", "No emission has completed yet")
+		return
+	}
+	if result.StatusCode >= http.StatusOK && result.StatusCode < http.StatusMultipleChoices {
+		eventEmissionCondSet.Manage(s).MarkTrue(EventEmissionConditionEmitted)
+		return
+	}
+	if result.StatusCode == 0 {
+		eventEmissionCondSet.Manage(s).MarkFalse(EventEmissionConditionEmitted, "EmissionFailed", "Failed to send event %q: %s", result.EventID, result.Message)
+		return
+	}
+	eventEmissionCondSet.Manage(s).MarkFalse(EventEmissionConditionEmitted, "EmissionRejected", "Sink responded with status code %d for event %q: %s", result.StatusCode, result.EventID, result.Message)
+}
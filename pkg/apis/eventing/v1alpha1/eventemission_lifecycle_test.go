@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestEventEmissionGetConditionSet(t *testing.T) {
+	r := &EventEmission{}
+
+	if got, want := r.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestEventEmissionInitializeConditions(t *testing.T) {
+	s := &EventEmissionStatus{}
+	s.InitializeConditions()
+
+	for _, c := range []apis.ConditionType{
+		EventEmissionConditionReady,
+		EventEmissionConditionSinkProvided,
+		EventEmissionConditionEmitted,
+		EventEmissionConditionOIDCIdentityCreated,
+	} {
+		if got := s.GetCondition(c); got == nil || got.Status != corev1.ConditionUnknown {
+			t.Errorf("condition %q = %v, want Unknown", c, got)
+		}
+	}
+}
+
+func TestEventEmissionStatusIsReady(t *testing.T) {
+	sink := &duckv1.Addressable{URL: apis.HTTP("sink.example.com")}
+
+	tests := []struct {
+		name       string
+		markSink   bool
+		markOIDC   bool
+		result     *EventEmissionResult
+		wantReady  bool
+		wantReason string
+	}{{
+		name:       "initialized",
+		wantReady:  false,
+		wantReason: "",
+	}, {
+		name:       "sink, oidc, no result",
+		markSink:   true,
+		markOIDC:   true,
+		wantReady:  false,
+		wantReason: "EmissionPending",
+	}, {
+		name:      "sink, oidc, accepted",
+		markSink:  true,
+		markOIDC:  true,
+		result:    &EventEmissionResult{EventID: "1", StatusCode: 202},
+		wantReady: true,
+	}, {
+		name:       "sink, oidc, rejected",
+		markSink:   true,
+		markOIDC:   true,
+		result:     &EventEmissionResult{EventID: "1", StatusCode: 400, Message: "bad request"},
+		wantReady:  false,
+		wantReason: "EmissionRejected",
+	}, {
+		name:       "sink, oidc, failed",
+		markSink:   true,
+		markOIDC:   true,
+		result:     &EventEmissionResult{EventID: "1", Message: "connection refused"},
+		wantReady:  false,
+		wantReason: "EmissionFailed",
+	}, {
+		name:      "no oidc identity",
+		markSink:  true,
+		result:    &EventEmissionResult{EventID: "1", StatusCode: 200},
+		wantReady: false,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &EventEmissionStatus{}
+			s.InitializeConditions()
+			if test.markSink {
+				s.MarkSink(sink)
+			}
+			if test.markOIDC {
+				s.MarkOIDCIdentityCreatedSucceeded()
+			}
+			s.PropagateEmissionResult(test.result)
+
+			if got := s.IsReady(); got != test.wantReady {
+				t.Errorf("IsReady() = %v, want %v", got, test.wantReady)
+			}
+			if test.wantReason != "" {
+				if got := s.GetCondition(EventEmissionConditionEmitted).Reason; got != test.wantReason {
+					t.Errorf("Emitted reason = %q, want %q", got, test.wantReason)
+				}
+			}
+			if diff := cmp.Diff(test.result, s.LastEmission); diff != "" {
+				t.Error("unexpected last emission (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestEventEmissionMarkNoSink(t *testing.T) {
+	s := &EventEmissionStatus{}
+	s.InitializeConditions()
+	s.MarkSink(&duckv1.Addressable{URL: apis.HTTP("sink.example.com")})
+	s.MarkNoSink("NotFound", "")
+
+	if got := s.GetCondition(EventEmissionConditionSinkProvided); got.Status != corev1.ConditionFalse {
+		t.Errorf("SinkProvided = %v, want False", got.Status)
+	}
+	if s.IsReady() {
+		t.Error("IsReady() = true, want false")
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventEmission sends a declared CloudEvent to an addressable, either once or
+// on a schedule, and records the outcome of the last delivery in its status.
+// It is meant for smoke-testing and debugging event wiring.
+type EventEmission struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the EventEmission.
+	Spec EventEmissionSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the EventEmission.
+	// This data may be out of date.
+	// +optional
+	Status EventEmissionStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that EventEmission can be validated and defaulted.
+	_ apis.Validatable = (*EventEmission)(nil)
+	_ apis.Defaultable = (*EventEmission)(nil)
+
+	// Check that EventEmission can return its spec untyped.
+	_ apis.HasSpec = (*EventEmission)(nil)
+
+	_ runtime.Object = (*EventEmission)(nil)
+
+	// Check that we can create OwnerReferences to an EventEmission.
+	_ kmeta.OwnerRefable = (*EventEmission)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*EventEmission)(nil)
+)
+
+type EventEmissionSpec struct {
+	// Sink is the addressable the event is sent to.
+	Sink duckv1.Destination `json:"sink"`
+
+	// Event describes the CloudEvent to send.
+	Event EventEmissionEvent `json:"event"`
+
+	// Schedule is an optional cron expression in the standard 5-field format.
+	// When set, the event is sent on every tick of the schedule, otherwise it
+	// is sent once per generation of the EventEmission.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type EventEmissionEvent struct {
+	// Type is the CloudEvent type attribute.
+	Type string `json:"type"`
+
+	// Source is the CloudEvent source attribute.
+	Source string `json:"source"`
+
+	// ID is the CloudEvent id attribute. When empty, a random id is
+	// generated for every emission.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Subject is the CloudEvent subject attribute.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Extensions are additional CloudEvent extension attributes.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// DataContentType is the media type of Data.
+	// +optional
+	DataContentType string `json:"dataContentType,omitempty"`
+
+	// Data is the payload of the CloudEvent.
+	// +optional
+	Data string `json:"data,omitempty"`
+}
+
+// EventEmissionStatus represents the current state of an EventEmission.
+type EventEmissionStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// SinkURI is the current resolved URI of the sink.
+	// +optional
+	SinkURI *apis.URL `json:"sinkUri,omitempty"`
+
+	// SinkCACerts are the Certification Authority (CA) certificates in PEM
+	// format according to https://www.rfc-editor.org/rfc/rfc7468 of the sink.
+	// +optional
+	SinkCACerts *string `json:"sinkCACerts,omitempty"`
+
+	// SinkAudience is the OIDC audience of the sink.
+	// +optional
+	SinkAudience *string `json:"sinkAudience,omitempty"`
+
+	// Auth provides the relevant information for OIDC authentication.
+	// +optional
+	Auth *duckv1.AuthStatus `json:"auth,omitempty"`
+
+	// LastEmission is the result of the most recent completed emission.
+	// +optional
+	LastEmission *EventEmissionResult `json:"lastEmission,omitempty"`
+}
+
+// EventEmissionResult is the outcome of a single emission.
+type EventEmissionResult struct {
+	// EventID is the id of the CloudEvent that was sent.
+	// +optional
+	EventID string `json:"eventId,omitempty"`
+
+	// Time is when the emission completed.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// StatusCode is the HTTP status code returned by the sink, or 0 when no
+	// response was received.
+	// +optional
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Message describes the error, if any.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EventEmissionList is a collection of EventEmission.
+type EventEmissionList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EventEmission `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for EventEmission
+func (ee *EventEmission) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("EventEmission")
+}
+
+// GetUntypedSpec returns the spec of the EventEmission.
+func (ee *EventEmission) GetUntypedSpec() interface{} {
+	return ee.Spec
+}
+
+// GetStatus retrieves the status of the EventEmission. Implements the KRShaped interface.
+func (ee *EventEmission) GetStatus() *duckv1.Status {
+	return &ee.Status.Status
+}
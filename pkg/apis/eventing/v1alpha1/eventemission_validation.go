@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+	"knative.dev/pkg/apis"
+)
+
+var (
+	// Valid CloudEvents extension attribute names, as defined in the spec.
+	validExtensionName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+	// Context attributes which can't be set through extensions.
+	reservedAttributeNames = map[string]struct{}{
+		"specversion":     {},
+		"id":              {},
+		"source":          {},
+		"type":            {},
+		"subject":         {},
+		"time":            {},
+		"datacontenttype": {},
+		"dataschema":      {},
+		"data":            {},
+		"data_base64":     {},
+	}
+)
+
+func (ee *EventEmission) Validate(ctx context.Context) *apis.FieldError {
+	return ee.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (ees *EventEmissionSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if fe := ees.Sink.Validate(ctx); fe != nil {
+		errs = errs.Also(fe.ViaField("sink"))
+	}
+
+	errs = errs.Also(ees.Event.Validate(ctx).ViaField("event"))
+
+	if ees.Schedule != "" {
+		if _, err := cron.ParseStandard(ees.Schedule); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err, "schedule"))
+		}
+	}
+
+	return errs
+}
+
+func (e *EventEmissionEvent) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if e.Type == "" {
+		errs = errs.Also(apis.ErrMissingField("type"))
+	}
+	if e.Source == "" {
+		errs = errs.Also(apis.ErrMissingField("source"))
+	} else if _, err := apis.ParseURL(e.Source); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(e.Source, "source", err.Error()))
+	}
+
+	for name := range e.Extensions {
+		if !validExtensionName.MatchString(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "extensions", "extension names must only contain lowercase alphanumeric characters"))
+		} else if _, ok := reservedAttributeNames[name]; ok {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "extensions", "extension names must not be a CloudEvents context attribute"))
+		}
+	}
+
+	if e.Data != "" && strings.HasPrefix(e.DataContentType, "application/json") {
+		var data interface{}
+		if err := json.Unmarshal([]byte(e.Data), &data); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err, "data"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestEventEmissionSpecValidation(t *testing.T) {
+	sink := duckv1.Destination{
+		URI: apis.HTTP("sink.example.com"),
+	}
+
+	tests := []struct {
+		name string
+		ee   *EventEmission
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink: sink,
+				Event: EventEmissionEvent{
+					Type:            "dev.knative.test",
+					Source:          "/test",
+					Extensions:      map[string]string{"myext": "value"},
+					DataContentType: "application/json",
+					Data:            `{"hello":"world"}`,
+				},
+				Schedule: "*/5 * * * *",
+			},
+		},
+	}, {
+		name: "missing sink",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Event: EventEmissionEvent{Type: "dev.knative.test", Source: "/test"},
+			},
+		},
+		want: apis.ErrGeneric("expected at least one, got none", "ref", "uri").ViaField("sink").ViaField("spec"),
+	}, {
+		name: "missing type and source",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink: sink,
+			},
+		},
+		want: apis.ErrMissingField("type").Also(apis.ErrMissingField("source")).ViaField("event").ViaField("spec"),
+	}, {
+		name: "invalid extension names",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink: sink,
+				Event: EventEmissionEvent{
+					Type:       "dev.knative.test",
+					Source:     "/test",
+					Extensions: map[string]string{"id": "value"},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName("id", "extensions", "extension names must not be a CloudEvents context attribute").ViaField("event").ViaField("spec"),
+	}, {
+		name: "uppercase extension name",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink: sink,
+				Event: EventEmissionEvent{
+					Type:       "dev.knative.test",
+					Source:     "/test",
+					Extensions: map[string]string{"MyExt": "value"},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName("MyExt", "extensions", "extension names must only contain lowercase alphanumeric characters").ViaField("event").ViaField("spec"),
+	}, {
+		name: "invalid json data",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink: sink,
+				Event: EventEmissionEvent{
+					Type:            "dev.knative.test",
+					Source:          "/test",
+					DataContentType: "application/json",
+					Data:            "{",
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("unexpected end of JSON input", "data").ViaField("event").ViaField("spec"),
+	}, {
+		name: "invalid schedule",
+		ee: &EventEmission{
+			Spec: EventEmissionSpec{
+				Sink:     sink,
+				Event:    EventEmissionEvent{Type: "dev.knative.test", Source: "/test"},
+				Schedule: "not a schedule",
+			},
+		},
+		want: apis.ErrInvalidValue("expected exactly 5 fields, found 3: [not a schedule]", "schedule").ViaField("spec"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.ee.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("EventEmission.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
@@ -17,13 +17,53 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
-var eventPolicyCondSet = apis.NewLivingConditionSet()
+var eventPolicyCondSet = apis.NewLivingConditionSet(
+	EventPolicyConditionRefsResolved,
+	EventPolicyConditionSubjectsResolved,
+	EventPolicyConditionOIDCEnabled,
+)
 
 const (
 	EventPolicyConditionReady = apis.ConditionReady
+
+	// EventPolicyConditionRefsResolved has status True when all the resources
+	// referenced in .spec.to exist.
+	EventPolicyConditionRefsResolved apis.ConditionType = "RefsResolved"
+
+	// EventPolicyConditionSubjectsResolved has status True when all the
+	// resources referenced in .spec.from have been resolved into OIDC subjects.
+	EventPolicyConditionSubjectsResolved apis.ConditionType = "SubjectsResolved"
+
+	// EventPolicyConditionOIDCEnabled has status True when the OIDC
+	// authentication feature is enabled, without which the policy can't be
+	// enforced.
+	EventPolicyConditionOIDCEnabled apis.ConditionType = "OIDCEnabled"
+
+	// EventPolicyConditionActive has status True when the current time is
+	// within .spec.validity. It does not influence the Ready condition, an
+	// inactive policy still applies to its targets but grants no access.
+	EventPolicyConditionActive apis.ConditionType = "Active"
+
+	// EventPolicyConditionRejectionSinkResolved has status True when
+	// .spec.rejectionSink has been resolved, it is not set without a
+	// rejection sink. It does not influence the Ready condition, the policy
+	// is enforced even when the rejections can't be reported.
+	EventPolicyConditionRejectionSinkResolved apis.ConditionType = "RejectionSinkResolved"
+
+	// EventPolicyConditionPooledIdentity has status True, with a warning
+	// severity, when subjects of .spec.from are the pooled OIDC identity of a
+	// namespace, shared by all the resources of the namespace when the
+	// authentication-oidc-shared-identity feature is enabled. It is not set
+	// otherwise and does not influence the Ready condition.
+	EventPolicyConditionPooledIdentity apis.ConditionType = "PooledIdentity"
 )
 
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
@@ -50,3 +90,99 @@ func (et *EventPolicyStatus) GetTopLevelCondition() *apis.Condition {
 func (et *EventPolicyStatus) InitializeConditions() {
 	eventPolicyCondSet.Manage(et).InitializeConditions()
 }
+
+// MarkRefsResolved sets the RefsResolved condition to true.
+func (et *EventPolicyStatus) MarkRefsResolved() {
+	eventPolicyCondSet.Manage(et).MarkTrue(EventPolicyConditionRefsResolved)
+}
+
+// MarkRefsNotResolved sets the RefsResolved condition to false.
+func (et *EventPolicyStatus) MarkRefsNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	eventPolicyCondSet.Manage(et).MarkFalse(EventPolicyConditionRefsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkSubjectsResolved sets the SubjectsResolved condition to true.
+func (et *EventPolicyStatus) MarkSubjectsResolved() {
+	eventPolicyCondSet.Manage(et).MarkTrue(EventPolicyConditionSubjectsResolved)
+}
+
+// MarkSubjectsNotResolved sets the SubjectsResolved condition to false.
+func (et *EventPolicyStatus) MarkSubjectsNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	eventPolicyCondSet.Manage(et).MarkFalse(EventPolicyConditionSubjectsResolved, reason, messageFormat, messageA...)
+}
+
+// MarkOIDCEnabled sets the OIDCEnabled condition to true.
+func (et *EventPolicyStatus) MarkOIDCEnabled() {
+	eventPolicyCondSet.Manage(et).MarkTrue(EventPolicyConditionOIDCEnabled)
+}
+
+// MarkOIDCDisabled sets the OIDCEnabled condition to false.
+func (et *EventPolicyStatus) MarkOIDCDisabled(reason, messageFormat string, messageA ...interface{}) {
+	eventPolicyCondSet.Manage(et).MarkFalse(EventPolicyConditionOIDCEnabled, reason, messageFormat, messageA...)
+}
+
+// MarkActive sets the Active condition to true.
+func (et *EventPolicyStatus) MarkActive() {
+	eventPolicyCondSet.Manage(et).MarkTrue(EventPolicyConditionActive)
+}
+
+// MarkInactive sets the Active condition to false.
+func (et *EventPolicyStatus) MarkInactive(reason, messageFormat string, messageA ...interface{}) {
+	eventPolicyCondSet.Manage(et).MarkFalse(EventPolicyConditionActive, reason, messageFormat, messageA...)
+}
+
+// IsActive returns true unless the policy was marked as inactive.
+func (et *EventPolicyStatus) IsActive() bool {
+	return !et.GetCondition(EventPolicyConditionActive).IsFalse()
+}
+
+// MarkRejectionSinkResolved sets the RejectionSinkResolved condition to true
+// and the resolved rejection sink, or clears them when addr is nil.
+func (et *EventPolicyStatus) MarkRejectionSinkResolved(addr *duckv1.Addressable) {
+	if addr == nil {
+		et.RejectionSinkURI, et.RejectionSinkCACerts, et.RejectionSinkAudience = nil, nil, nil
+		_ = eventPolicyCondSet.Manage(et).ClearCondition(EventPolicyConditionRejectionSinkResolved)
+		return
+	}
+	et.RejectionSinkURI, et.RejectionSinkCACerts, et.RejectionSinkAudience = addr.URL, addr.CACerts, addr.Audience
+	eventPolicyCondSet.Manage(et).MarkTrue(EventPolicyConditionRejectionSinkResolved)
+}
+
+// MarkRejectionSinkNotResolved sets the RejectionSinkResolved condition to
+// false and clears the resolved rejection sink.
+func (et *EventPolicyStatus) MarkRejectionSinkNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	et.RejectionSinkURI, et.RejectionSinkCACerts, et.RejectionSinkAudience = nil, nil, nil
+	eventPolicyCondSet.Manage(et).MarkFalse(EventPolicyConditionRejectionSinkResolved, reason, messageFormat, messageA...)
+}
+
+// RejectionSink returns the resolved rejection sink, or nil if there is
+// none.
+func (et *EventPolicyStatus) RejectionSink() *duckv1.Addressable {
+	if et.RejectionSinkURI == nil {
+		return nil
+	}
+	return &duckv1.Addressable{
+		URL:      et.RejectionSinkURI,
+		CACerts:  et.RejectionSinkCACerts,
+		Audience: et.RejectionSinkAudience,
+	}
+}
+
+// MarkPooledIdentity records that the policy grants access to the pooled OIDC
+// identities of the given subjects, and thus to every resource sharing them.
+func (et *EventPolicyStatus) MarkPooledIdentity(subjects []string) {
+	eventPolicyCondSet.Manage(et).SetCondition(apis.Condition{
+		Type:     EventPolicyConditionPooledIdentity,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   "PooledIdentity",
+		Message: fmt.Sprintf("The policy grants access to the pooled OIDC identities %s, shared by all the resources of their namespace",
+			strings.Join(subjects, ", ")),
+	})
+}
+
+// ClearPooledIdentity removes the PooledIdentity condition, when the policy
+// doesn't grant access to pooled OIDC identities.
+func (et *EventPolicyStatus) ClearPooledIdentity() {
+	_ = eventPolicyCondSet.Manage(et).ClearCondition(EventPolicyConditionPooledIdentity)
+}
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
 )
 
 var (
@@ -87,8 +88,17 @@ func TestEventPolicyInitializeConditions(t *testing.T) {
 			want: &EventPolicyStatus{
 				Status: duckv1.Status{
 					Conditions: []apis.Condition{{
+						Type:   EventPolicyConditionOIDCEnabled,
+						Status: corev1.ConditionUnknown,
+					}, {
 						Type:   EventPolicyConditionReady,
 						Status: corev1.ConditionUnknown,
+					}, {
+						Type:   EventPolicyConditionRefsResolved,
+						Status: corev1.ConditionUnknown,
+					}, {
+						Type:   EventPolicyConditionSubjectsResolved,
+						Status: corev1.ConditionUnknown,
 					},
 					},
 				},
@@ -105,3 +115,138 @@ func TestEventPolicyInitializeConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestEventPolicyReadyConditions(t *testing.T) {
+	tests := []struct {
+		name             string
+		refsResolved     *bool
+		subjectsResolved *bool
+		oidcEnabled      *bool
+		wantReady        corev1.ConditionStatus
+	}{{
+		name:      "nothing resolved yet",
+		wantReady: corev1.ConditionUnknown,
+	}, {
+		name:             "all resolved",
+		refsResolved:     ptr.Bool(true),
+		subjectsResolved: ptr.Bool(true),
+		oidcEnabled:      ptr.Bool(true),
+		wantReady:        corev1.ConditionTrue,
+	}, {
+		name:             "refs not resolved",
+		refsResolved:     ptr.Bool(false),
+		subjectsResolved: ptr.Bool(true),
+		oidcEnabled:      ptr.Bool(true),
+		wantReady:        corev1.ConditionFalse,
+	}, {
+		name:             "subjects not resolved",
+		refsResolved:     ptr.Bool(true),
+		subjectsResolved: ptr.Bool(false),
+		oidcEnabled:      ptr.Bool(true),
+		wantReady:        corev1.ConditionFalse,
+	}, {
+		name:         "subjects pending",
+		refsResolved: ptr.Bool(true),
+		oidcEnabled:  ptr.Bool(true),
+		wantReady:    corev1.ConditionUnknown,
+	}, {
+		name:             "OIDC disabled",
+		refsResolved:     ptr.Bool(true),
+		subjectsResolved: ptr.Bool(true),
+		oidcEnabled:      ptr.Bool(false),
+		wantReady:        corev1.ConditionFalse,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &EventPolicyStatus{}
+			s.InitializeConditions()
+			if test.refsResolved != nil {
+				if *test.refsResolved {
+					s.MarkRefsResolved()
+				} else {
+					s.MarkRefsNotResolved("NotFound", "")
+				}
+			}
+			if test.subjectsResolved != nil {
+				if *test.subjectsResolved {
+					s.MarkSubjectsResolved()
+				} else {
+					s.MarkSubjectsNotResolved("NotFound", "")
+				}
+			}
+			if test.oidcEnabled != nil {
+				if *test.oidcEnabled {
+					s.MarkOIDCEnabled()
+				} else {
+					s.MarkOIDCDisabled("OIDCDisabled", "")
+				}
+			}
+
+			if got := s.GetTopLevelCondition().Status; got != test.wantReady {
+				t.Errorf("unexpected Ready status, want %v, got %v", test.wantReady, got)
+			}
+			if got, want := s.IsReady(), test.wantReady == corev1.ConditionTrue; got != want {
+				t.Errorf("unexpected IsReady, want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestEventPolicyActiveCondition(t *testing.T) {
+	s := &EventPolicyStatus{}
+	s.InitializeConditions()
+	s.MarkRefsResolved()
+	s.MarkSubjectsResolved()
+	s.MarkOIDCEnabled()
+
+	if !s.IsActive() {
+		t.Error("expected policy without Active condition to be active")
+	}
+
+	s.MarkInactive("Expired", "")
+	if s.IsActive() {
+		t.Error("expected policy to be inactive")
+	}
+	if !s.IsReady() {
+		t.Error("expected inactive policy to stay ready")
+	}
+
+	s.MarkActive()
+	if !s.IsActive() {
+		t.Error("expected policy to be active")
+	}
+}
+
+func TestEventPolicyRejectionSinkCondition(t *testing.T) {
+	s := &EventPolicyStatus{}
+	s.InitializeConditions()
+	s.MarkRefsResolved()
+	s.MarkSubjectsResolved()
+	s.MarkOIDCEnabled()
+
+	s.MarkRejectionSinkNotResolved("RejectionSinkNotFound", "")
+	if s.RejectionSink() != nil {
+		t.Error("expected no rejection sink")
+	}
+	if !s.IsReady() {
+		t.Error("expected policy with an unresolved rejection sink to stay ready")
+	}
+
+	url := apis.HTTP("audit.example.com")
+	s.MarkRejectionSinkResolved(&duckv1.Addressable{URL: url, Audience: ptr.String("audit")})
+	if got := s.RejectionSink(); got == nil || got.URL.String() != url.String() || *got.Audience != "audit" {
+		t.Errorf("unexpected rejection sink %v", got)
+	}
+	if !s.GetCondition(EventPolicyConditionRejectionSinkResolved).IsTrue() {
+		t.Error("expected the RejectionSinkResolved condition to be true")
+	}
+
+	s.MarkRejectionSinkResolved(nil)
+	if s.RejectionSink() != nil {
+		t.Error("expected the rejection sink to be cleared")
+	}
+	if s.GetCondition(EventPolicyConditionRejectionSinkResolved) != nil {
+		t.Error("expected the RejectionSinkResolved condition to be cleared")
+	}
+}
@@ -17,12 +17,16 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing/pkg/apis/eventing"
 )
 
 // +genclient
@@ -63,7 +67,9 @@ var (
 
 type EventPolicySpec struct {
 	// To lists all resources for which this policy applies.
-	// Resources in this list must act like an ingress and have an audience.
+	// Resources in this list must act like an ingress and have an audience,
+	// or be Triggers, whose events are authorized by the identity of their
+	// producer at the Broker ingress.
 	// The resources are part of the same namespace as the EventPolicy.
 	// An empty list means it applies to all resources in the EventPolicies namespace
 	// +optional
@@ -71,6 +77,28 @@ type EventPolicySpec struct {
 
 	// From is the list of sources or oidc identities, which are allowed to send events to the targets (.spec.to).
 	From []EventPolicySpecFrom `json:"from,omitempty"`
+
+	// Validity restricts the time window in which this policy grants access.
+	// Outside of the window the policy still applies to the targets in .spec.to,
+	// but does not allow any of the sources in .spec.from.
+	// +optional
+	Validity *EventPolicyValidity `json:"validity,omitempty"`
+
+	// RejectionSink receives a dev.knative.eventpolicy.rejected event for
+	// each event rejected by this policy, so that the rejections can be
+	// audited.
+	// +optional
+	RejectionSink *duckv1.Destination `json:"rejectionSink,omitempty"`
+}
+
+type EventPolicyValidity struct {
+	// NotBefore is the time from which on the policy grants access.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time after which the policy does not grant access anymore.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 type EventPolicySpecTo struct {
@@ -140,6 +168,86 @@ type EventPolicyStatus struct {
 
 	// From is the list of resolved oidc identities from .spec.from
 	From []string `json:"from,omitempty"`
+
+	// RejectionSinkURI is the resolved URI of .spec.rejectionSink.
+	// +optional
+	RejectionSinkURI *apis.URL `json:"rejectionSinkUri,omitempty"`
+
+	// RejectionSinkCACerts are the Certification Authority (CA) certificates
+	// in PEM format of .spec.rejectionSink.
+	// +optional
+	RejectionSinkCACerts *string `json:"rejectionSinkCACerts,omitempty"`
+
+	// RejectionSinkAudience is the OIDC audience of .spec.rejectionSink.
+	// +optional
+	RejectionSinkAudience *string `json:"rejectionSinkAudience,omitempty"`
+}
+
+// MaxBreakGlassTTL is the longest TTL a break-glass EventPolicy can have.
+const MaxBreakGlassTTL = 24 * time.Hour
+
+// BreakGlassTTL returns the TTL set via the break-glass annotation. The
+// returned bool is false if the EventPolicy is not a break-glass policy.
+func (ep *EventPolicy) BreakGlassTTL() (time.Duration, bool, error) {
+	value, ok := ep.GetAnnotations()[eventing.BreakGlassTTLAnnotationKey]
+	if !ok {
+		return 0, false, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, true, err
+	}
+	return ttl, true, nil
+}
+
+// EffectiveValidity returns .spec.validity, narrowed down to end at the
+// break-glass expiry if the EventPolicy is a break-glass policy.
+func (ep *EventPolicy) EffectiveValidity() *EventPolicyValidity {
+	ttl, ok, err := ep.BreakGlassTTL()
+	if !ok || err != nil {
+		return ep.Spec.Validity
+	}
+
+	expiry := metav1.NewTime(ep.CreationTimestamp.Add(ttl))
+	validity := &EventPolicyValidity{NotAfter: &expiry}
+	if ep.Spec.Validity != nil {
+		validity.NotBefore = ep.Spec.Validity.NotBefore
+		if ep.Spec.Validity.NotAfter != nil && ep.Spec.Validity.NotAfter.Before(&expiry) {
+			validity.NotAfter = ep.Spec.Validity.NotAfter
+		}
+	}
+	return validity
+}
+
+// IsActiveAt returns true if the given time is within the validity window.
+// A nil validity is active at any time.
+func (v *EventPolicyValidity) IsActiveAt(t time.Time) bool {
+	if v == nil {
+		return true
+	}
+	if v.NotBefore != nil && t.Before(v.NotBefore.Time) {
+		return false
+	}
+	if v.NotAfter != nil && t.After(v.NotAfter.Time) {
+		return false
+	}
+	return true
+}
+
+// NextTransition returns the next time after t at which the validity window
+// starts or ends. It returns false if there is no such time.
+func (v *EventPolicyValidity) NextTransition(t time.Time) (time.Time, bool) {
+	if v == nil {
+		return time.Time{}, false
+	}
+	if v.NotBefore != nil && t.Before(v.NotBefore.Time) {
+		return v.NotBefore.Time, true
+	}
+	if v.NotAfter != nil && !t.After(v.NotAfter.Time) {
+		// NotAfter is inclusive, the window ends right after it.
+		return v.NotAfter.Time.Add(time.Nanosecond), true
+	}
+	return time.Time{}, false
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -18,6 +18,12 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing/pkg/apis/eventing"
 )
 
 func TestEventPolicyGetStatus(t *testing.T) {
@@ -37,3 +43,113 @@ func TestEventPolicy_GetGroupVersionKind(t *testing.T) {
 		t.Errorf("Should be EventPolicy.")
 	}
 }
+
+func TestEventPolicyValidity(t *testing.T) {
+	notBefore := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	notAfter := metav1.NewTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		validity   *EventPolicyValidity
+		now        time.Time
+		wantActive bool
+		wantNext   time.Time
+		wantNextOk bool
+	}{{
+		name:       "no validity",
+		now:        notBefore.Time,
+		wantActive: true,
+	}, {
+		name:       "before notBefore",
+		validity:   &EventPolicyValidity{NotBefore: &notBefore, NotAfter: &notAfter},
+		now:        notBefore.Add(-time.Hour),
+		wantActive: false,
+		wantNext:   notBefore.Time,
+		wantNextOk: true,
+	}, {
+		name:       "within window",
+		validity:   &EventPolicyValidity{NotBefore: &notBefore, NotAfter: &notAfter},
+		now:        notBefore.Add(time.Hour),
+		wantActive: true,
+		wantNext:   notAfter.Add(time.Nanosecond),
+		wantNextOk: true,
+	}, {
+		name:       "at notAfter",
+		validity:   &EventPolicyValidity{NotAfter: &notAfter},
+		now:        notAfter.Time,
+		wantActive: true,
+		wantNext:   notAfter.Add(time.Nanosecond),
+		wantNextOk: true,
+	}, {
+		name:       "after notAfter",
+		validity:   &EventPolicyValidity{NotBefore: &notBefore, NotAfter: &notAfter},
+		now:        notAfter.Add(time.Hour),
+		wantActive: false,
+	}, {
+		name:       "after notBefore without notAfter",
+		validity:   &EventPolicyValidity{NotBefore: &notBefore},
+		now:        notBefore.Add(time.Hour),
+		wantActive: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.validity.IsActiveAt(tt.now); got != tt.wantActive {
+				t.Errorf("IsActiveAt() = %v, want %v", got, tt.wantActive)
+			}
+			next, ok := tt.validity.NextTransition(tt.now)
+			if ok != tt.wantNextOk || !next.Equal(tt.wantNext) {
+				t.Errorf("NextTransition() = %v, %v, want %v, %v", next, ok, tt.wantNext, tt.wantNextOk)
+			}
+		})
+	}
+}
+
+func TestEventPolicyEffectiveValidity(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	expiry := metav1.NewTime(created.Add(time.Hour))
+	later := metav1.NewTime(created.Add(2 * time.Hour))
+	earlier := metav1.NewTime(created.Add(30 * time.Minute))
+
+	tests := []struct {
+		name     string
+		ttl      string
+		validity *EventPolicyValidity
+		want     *EventPolicyValidity
+	}{{
+		name: "no break-glass",
+	}, {
+		name:     "no break-glass with validity",
+		validity: &EventPolicyValidity{NotAfter: &later},
+		want:     &EventPolicyValidity{NotAfter: &later},
+	}, {
+		name: "break-glass",
+		ttl:  "1h",
+		want: &EventPolicyValidity{NotAfter: &expiry},
+	}, {
+		name:     "break-glass ends before validity",
+		ttl:      "1h",
+		validity: &EventPolicyValidity{NotBefore: &created, NotAfter: &later},
+		want:     &EventPolicyValidity{NotBefore: &created, NotAfter: &expiry},
+	}, {
+		name:     "validity ends before break-glass",
+		ttl:      "1h",
+		validity: &EventPolicyValidity{NotAfter: &earlier},
+		want:     &EventPolicyValidity{NotAfter: &earlier},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep := &EventPolicy{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+				Spec:       EventPolicySpec{Validity: tt.validity},
+			}
+			if tt.ttl != "" {
+				ep.Annotations = map[string]string{eventing.BreakGlassTTLAnnotationKey: tt.ttl}
+			}
+			if diff := cmp.Diff(tt.want, ep.EffectiveValidity()); diff != "" {
+				t.Errorf("EffectiveValidity() (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
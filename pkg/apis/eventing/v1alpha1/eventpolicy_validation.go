@@ -19,12 +19,30 @@ package v1alpha1
 import (
 	"context"
 	"strings"
+	"time"
 
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/apis/eventing"
 )
 
 func (ep *EventPolicy) Validate(ctx context.Context) *apis.FieldError {
-	return ep.Spec.Validate(ctx).ViaField("spec")
+	err := ep.Spec.Validate(ctx).ViaField("spec")
+	return err.Also(ep.validateBreakGlassTTL().ViaField("metadata", "annotations"))
+}
+
+func (ep *EventPolicy) validateBreakGlassTTL() *apis.FieldError {
+	ttl, ok, err := ep.BreakGlassTTL()
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		return apis.ErrInvalidValue(ep.Annotations[eventing.BreakGlassTTLAnnotationKey], eventing.BreakGlassTTLAnnotationKey, err.Error())
+	}
+	if ttl <= 0 || ttl > MaxBreakGlassTTL {
+		return apis.ErrOutOfBoundsValue(ttl, time.Duration(0), MaxBreakGlassTTL, eventing.BreakGlassTTLAnnotationKey)
+	}
+	return nil
 }
 
 func (ets *EventPolicySpec) Validate(ctx context.Context) *apis.FieldError {
@@ -52,6 +70,12 @@ func (ets *EventPolicySpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	err = err.Also(ets.Validity.Validate().ViaField("validity"))
+
+	if ets.RejectionSink != nil {
+		err = err.Also(ets.RejectionSink.Validate(ctx).ViaField("rejectionSink"))
+	}
+
 	return err
 }
 
@@ -100,3 +124,14 @@ func (r *EventPolicyToReference) Validate() *apis.FieldError {
 	}
 	return err
 }
+
+func (v *EventPolicyValidity) Validate() *apis.FieldError {
+	if v == nil {
+		return nil
+	}
+
+	if v.NotBefore != nil && v.NotAfter != nil && !v.NotAfter.After(v.NotBefore.Time) {
+		return apis.ErrInvalidValue(v.NotAfter, "notAfter", "must be after notBefore")
+	}
+	return nil
+}
@@ -19,13 +19,21 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/ptr"
+
+	"knative.dev/eventing/pkg/apis/eventing"
 )
 
 func TestEventPolicySpecValidation(t *testing.T) {
+	notBefore := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	notAfter := metav1.NewTime(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+
 	tests := []struct {
 		name string
 		ep   *EventPolicy
@@ -248,6 +256,71 @@ func TestEventPolicySpecValidation(t *testing.T) {
 				return nil
 			}(),
 		},
+		{
+			name: "valid, validity with notBefore and notAfter",
+			ep: &EventPolicy{
+				Spec: EventPolicySpec{
+					Validity: &EventPolicyValidity{
+						NotBefore: &notBefore,
+						NotAfter:  &notAfter,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "valid, validity with notAfter only",
+			ep: &EventPolicy{
+				Spec: EventPolicySpec{
+					Validity: &EventPolicyValidity{
+						NotAfter: &notAfter,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, validity notAfter before notBefore",
+			ep: &EventPolicy{
+				Spec: EventPolicySpec{
+					Validity: &EventPolicyValidity{
+						NotBefore: &notAfter,
+						NotAfter:  &notBefore,
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrInvalidValue(&notBefore, "notAfter", "must be after notBefore").ViaField("validity").ViaField("spec")
+			}(),
+		},
+		{
+			name: "valid, rejection sink",
+			ep: &EventPolicy{
+				Spec: EventPolicySpec{
+					RejectionSink: &duckv1.Destination{
+						URI: apis.HTTP("audit.example.com"),
+					},
+				},
+			},
+			want: func() *apis.FieldError {
+				return nil
+			}(),
+		},
+		{
+			name: "invalid, empty rejection sink",
+			ep: &EventPolicy{
+				Spec: EventPolicySpec{
+					RejectionSink: &duckv1.Destination{},
+				},
+			},
+			want: func() *apis.FieldError {
+				return apis.ErrGeneric("expected at least one, got none", "ref", "uri").ViaField("rejectionSink").ViaField("spec")
+			}(),
+		},
 	}
 
 	for _, test := range tests {
@@ -259,3 +332,42 @@ func TestEventPolicySpecValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestEventPolicyBreakGlassValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		ttl  string
+		want *apis.FieldError
+	}{{
+		name: "valid ttl",
+		ttl:  "30m",
+	}, {
+		name: "invalid duration",
+		ttl:  "forever",
+		want: apis.ErrInvalidValue("forever", eventing.BreakGlassTTLAnnotationKey, `time: invalid duration "forever"`).ViaField("metadata", "annotations"),
+	}, {
+		name: "ttl too long",
+		ttl:  "48h",
+		want: apis.ErrOutOfBoundsValue(48*time.Hour, time.Duration(0), MaxBreakGlassTTL, eventing.BreakGlassTTLAnnotationKey).ViaField("metadata", "annotations"),
+	}, {
+		name: "zero ttl",
+		ttl:  "0s",
+		want: apis.ErrOutOfBoundsValue(time.Duration(0), time.Duration(0), MaxBreakGlassTTL, eventing.BreakGlassTTLAnnotationKey).ViaField("metadata", "annotations"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ep := &EventPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						eventing.BreakGlassTTLAnnotationKey: test.ttl,
+					},
+				},
+			}
+			got := ep.Validate(context.TODO())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate EventPolicy (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
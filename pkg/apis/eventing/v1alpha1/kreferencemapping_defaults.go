@@ -0,0 +1,31 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/eventing/pkg/kreferencemapping"
+)
+
+func (m *KReferenceMapping) SetDefaults(ctx context.Context) {
+	// The version * maps all the versions, as in the keys of the
+	// config-kreference-mapping ConfigMap.
+	if m.Spec.From.Version == kreferencemapping.AnyVersion {
+		m.Spec.From.Version = ""
+	}
+}
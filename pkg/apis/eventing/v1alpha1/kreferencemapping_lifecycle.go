@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// KReferenceMappingConditionReady has status True when the
+	// KReferenceMapping is used to resolve the references of its kind.
+	KReferenceMappingConditionReady = apis.ConditionReady
+
+	// KReferenceMappingConditionAccepted has status False when another
+	// mapping of the same kind takes precedence over the KReferenceMapping.
+	KReferenceMappingConditionAccepted apis.ConditionType = "Accepted"
+)
+
+var kReferenceMappingCondSet = apis.NewLivingConditionSet(
+	KReferenceMappingConditionAccepted,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*KReferenceMapping) GetConditionSet() apis.ConditionSet {
+	return kReferenceMappingCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (s *KReferenceMappingStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return kReferenceMappingCondSet.Manage(s).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (s *KReferenceMappingStatus) IsReady() bool {
+	return s.GetTopLevelCondition().IsTrue()
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (s *KReferenceMappingStatus) GetTopLevelCondition() *apis.Condition {
+	return kReferenceMappingCondSet.Manage(s).GetTopLevelCondition()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (s *KReferenceMappingStatus) InitializeConditions() {
+	kReferenceMappingCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkAccepted sets the Accepted condition to true.
+func (s *KReferenceMappingStatus) MarkAccepted() {
+	kReferenceMappingCondSet.Manage(s).MarkTrue(KReferenceMappingConditionAccepted)
+}
+
+// MarkNotAccepted sets the Accepted condition to false.
+func (s *KReferenceMappingStatus) MarkNotAccepted(reason, messageFormat string, messageA ...interface{}) {
+	kReferenceMappingCondSet.Manage(s).MarkFalse(KReferenceMappingConditionAccepted, reason, messageFormat, messageA...)
+}
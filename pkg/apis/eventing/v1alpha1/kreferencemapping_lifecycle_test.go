@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestKReferenceMappingGetConditionSet(t *testing.T) {
+	m := &KReferenceMapping{}
+
+	if got, want := m.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestKReferenceMappingStatusIsReady(t *testing.T) {
+	s := &KReferenceMappingStatus{}
+	s.InitializeConditions()
+	if got := s.GetCondition(KReferenceMappingConditionAccepted); got == nil || got.Status != corev1.ConditionUnknown {
+		t.Errorf("Accepted = %v, want Unknown", got)
+	}
+	if s.IsReady() {
+		t.Error("Expected an initialized KReferenceMapping not to be ready")
+	}
+
+	s.MarkNotAccepted("Conflict", "mapping %s takes precedence", "other")
+	if s.IsReady() {
+		t.Error("Expected a KReferenceMapping not accepted not to be ready")
+	}
+	if got := s.GetTopLevelCondition(); got.Reason != "Conflict" || got.Message != "mapping other takes precedence" {
+		t.Errorf("Unexpected Ready condition %+v", got)
+	}
+
+	s.MarkAccepted()
+	if !s.IsReady() {
+		t.Error("Expected an accepted KReferenceMapping to be ready")
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing/pkg/kreferencemapping"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KReferenceMapping maps the references to resources of a kind, which
+// doesn't implement the Addressable duck type, to a URL or to a reference to
+// another kind, so that they can be used as sinks.
+type KReferenceMapping struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the KReferenceMapping.
+	Spec KReferenceMappingSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the KReferenceMapping.
+	// This data may be out of date.
+	// +optional
+	Status KReferenceMappingStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that KReferenceMapping can be validated and defaulted.
+	_ apis.Validatable = (*KReferenceMapping)(nil)
+	_ apis.Defaultable = (*KReferenceMapping)(nil)
+
+	// Check that KReferenceMapping can return its spec untyped.
+	_ apis.HasSpec = (*KReferenceMapping)(nil)
+
+	_ runtime.Object = (*KReferenceMapping)(nil)
+
+	// Check that we can create OwnerReferences to a KReferenceMapping.
+	_ kmeta.OwnerRefable = (*KReferenceMapping)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*KReferenceMapping)(nil)
+)
+
+type KReferenceMappingSpec struct {
+	// From is the kind of the mapped references.
+	From KReferenceMappingFrom `json:"from"`
+
+	// URI is a Go template of the URL the references are mapped to. The
+	// template values are .Name, .Namespace and .UID of the reference, and
+	// .SystemNamespace, the namespace of Knative Eventing.
+	// Exactly one of URI and Ref must be set.
+	// +optional
+	URI string `json:"uri,omitempty"`
+
+	// Ref rewrites the references to references to another kind, in the
+	// same namespace, which are resolved as usual.
+	// Exactly one of URI and Ref must be set.
+	// +optional
+	Ref *KReferenceMappingRef `json:"ref,omitempty"`
+}
+
+type KReferenceMappingFrom struct {
+	// Group of the mapped kind, empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version of the mapped kind. All the versions are mapped when empty.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Kind of the mapped references.
+	Kind string `json:"kind"`
+}
+
+type KReferenceMappingRef struct {
+	// API version of the rewritten references.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the rewritten references.
+	Kind string `json:"kind"`
+
+	// Name is a Go template of the name of the rewritten references, with
+	// the same values as URI. The name of the reference is kept when empty.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// KReferenceMappingStatus represents the current state of a KReferenceMapping.
+type KReferenceMappingStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KReferenceMappingList is a collection of KReferenceMapping.
+type KReferenceMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KReferenceMapping `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for KReferenceMapping
+func (m *KReferenceMapping) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("KReferenceMapping")
+}
+
+// GetUntypedSpec returns the spec of the KReferenceMapping.
+func (m *KReferenceMapping) GetUntypedSpec() interface{} {
+	return m.Spec
+}
+
+// GetStatus retrieves the status of the KReferenceMapping. Implements the KRShaped interface.
+func (m *KReferenceMapping) GetStatus() *duckv1.Status {
+	return &m.Status.Status
+}
+
+// Mapping returns the parsed mapping.
+func (m *KReferenceMapping) Mapping() (*kreferencemapping.Mapping, error) {
+	mapping := &kreferencemapping.Mapping{
+		Name:              m.Name,
+		CreationTimestamp: m.CreationTimestamp.Time,
+		From: schema.GroupVersionKind{
+			Group:   m.Spec.From.Group,
+			Version: m.Spec.From.Version,
+			Kind:    m.Spec.From.Kind,
+		},
+	}
+	if m.Spec.Ref != nil {
+		mapping.Ref = &kreferencemapping.Rewrite{
+			APIVersion: m.Spec.Ref.APIVersion,
+			Kind:       m.Spec.Ref.Kind,
+		}
+		if m.Spec.Ref.Name != "" {
+			tmpl, err := kreferencemapping.ParseTemplate(m.Name, m.Spec.Ref.Name)
+			if err != nil {
+				return nil, err
+			}
+			mapping.Ref.Name = tmpl
+		}
+		return mapping, nil
+	}
+	tmpl, err := kreferencemapping.ParseTemplate(m.Name, m.Spec.URI)
+	if err != nil {
+		return nil, err
+	}
+	mapping.URI = tmpl
+	return mapping, nil
+}
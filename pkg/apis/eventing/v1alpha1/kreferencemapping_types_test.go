@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestKReferenceMappingGetStatus(t *testing.T) {
+	m := &KReferenceMapping{
+		Status: KReferenceMappingStatus{},
+	}
+	if got, want := m.GetStatus(), &m.Status.Status; got != want {
+		t.Errorf("GetStatus=%v, want=%v", got, want)
+	}
+}
+
+func TestKReferenceMappingGetGroupVersionKind(t *testing.T) {
+	m := &KReferenceMapping{}
+	gvk := m.GetGroupVersionKind()
+	if gvk.Kind != "KReferenceMapping" {
+		t.Errorf("Should be KReferenceMapping.")
+	}
+}
+
+func TestKReferenceMappingMapping(t *testing.T) {
+	created := time.Now().Truncate(time.Second)
+	ref := &corev1.ObjectReference{Namespace: "ns", Name: "sink"}
+
+	m := &KReferenceMapping{
+		ObjectMeta: metav1.ObjectMeta{Name: "sinks", CreationTimestamp: metav1.NewTime(created)},
+		Spec: KReferenceMappingSpec{
+			From: KReferenceMappingFrom{Group: "example.com", Kind: "Sink"},
+			URI:  "http://{{ .Name }}.{{ .Namespace }}.svc.cluster.local",
+		},
+	}
+	mapping, err := m.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Name != "sinks" || !mapping.CreationTimestamp.Equal(created) ||
+		mapping.From != (schema.GroupVersionKind{Group: "example.com", Kind: "Sink"}) {
+		t.Errorf("Unexpected mapping %+v", mapping)
+	}
+	if url, _, err := mapping.Map(ref, "knative-eventing"); err != nil || url.String() != "http://sink.ns.svc.cluster.local" {
+		t.Errorf("Map() = %v, %v", url, err)
+	}
+
+	m.Spec.URI = ""
+	m.Spec.Ref = &KReferenceMappingRef{APIVersion: "v1", Kind: "Service", Name: "{{ .Name }}-svc"}
+	mapping, err = m.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink-svc"}
+	if _, got, err := mapping.Map(ref, "knative-eventing"); err != nil || *got != want {
+		t.Errorf("Map() = %+v, %v, want %+v", got, err, want)
+	}
+
+	m.Spec.Ref.Name = "{{ .Name"
+	if _, err := m.Mapping(); err == nil {
+		t.Error("Expected an error for an invalid template")
+	}
+}
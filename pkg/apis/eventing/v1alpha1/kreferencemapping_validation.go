@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+func (m *KReferenceMapping) Validate(ctx context.Context) *apis.FieldError {
+	errs := m.Spec.Validate(ctx).ViaField("spec")
+	if errs != nil {
+		return errs
+	}
+
+	// Check that the templates execute and result in a valid URL or name.
+	mapping, err := m.Mapping()
+	if err == nil {
+		err = mapping.Validate()
+	}
+	if err != nil {
+		if m.Spec.Ref != nil {
+			return apis.ErrInvalidValue(m.Spec.Ref.Name, "name", err.Error()).ViaField("ref").ViaField("spec")
+		}
+		return apis.ErrInvalidValue(m.Spec.URI, "uri", err.Error()).ViaField("spec")
+	}
+	return nil
+}
+
+func (ms *KReferenceMappingSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := ms.From.Validate().ViaField("from")
+
+	switch {
+	case ms.URI == "" && ms.Ref == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("uri", "ref"))
+	case ms.URI != "" && ms.Ref != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("uri", "ref"))
+	case ms.Ref != nil:
+		errs = errs.Also(ms.Ref.Validate(ms.From).ViaField("ref"))
+	}
+
+	return errs
+}
+
+func (f *KReferenceMappingFrom) Validate() *apis.FieldError {
+	if f.Kind == "" {
+		return apis.ErrMissingField("kind")
+	}
+	return nil
+}
+
+func (r *KReferenceMappingRef) Validate(from KReferenceMappingFrom) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if r.APIVersion == "" {
+		errs = errs.Also(apis.ErrMissingField("apiVersion"))
+	} else if gv, err := schema.ParseGroupVersion(r.APIVersion); err != nil || gv.Version == "" {
+		errs = errs.Also(apis.ErrInvalidValue(r.APIVersion, "apiVersion", "apiVersion must be of the form <group>/<version> or <version>"))
+	} else if gv.Group == from.Group && r.Kind == from.Kind {
+		errs = errs.Also(apis.ErrGeneric("ref must be of another kind than from", "apiVersion", "kind"))
+	}
+	if r.Kind == "" {
+		errs = errs.Also(apis.ErrMissingField("kind"))
+	}
+
+	return errs
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+)
+
+func TestKReferenceMappingValidation(t *testing.T) {
+	from := KReferenceMappingFrom{Group: "example.com", Version: "v1", Kind: "Sink"}
+
+	tests := []struct {
+		name string
+		m    *KReferenceMapping
+		want *apis.FieldError
+	}{{
+		name: "valid uri",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				URI:  "http://{{ .Name }}.{{ .Namespace }}.svc.cluster.local/{{ .UID }}",
+			},
+		},
+	}, {
+		name: "valid ref",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: KReferenceMappingFrom{Group: "example.com", Kind: "Sink"},
+				Ref:  &KReferenceMappingRef{APIVersion: "serving.knative.dev/v1", Kind: "Service", Name: "{{ .Name }}-ingress"},
+			},
+		},
+	}, {
+		name: "missing from kind, uri and ref",
+		m:    &KReferenceMapping{},
+		want: apis.ErrMissingField("from.kind").
+			Also(apis.ErrMissingOneOf("uri", "ref")).
+			ViaField("spec"),
+	}, {
+		name: "both uri and ref",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				URI:  "http://{{ .Name }}",
+				Ref:  &KReferenceMappingRef{APIVersion: "v1", Kind: "Service"},
+			},
+		},
+		want: apis.ErrMultipleOneOf("uri", "ref").ViaField("spec"),
+	}, {
+		name: "invalid ref",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				Ref:  &KReferenceMappingRef{APIVersion: "a/b/c"},
+			},
+		},
+		want: apis.ErrInvalidValue("a/b/c", "apiVersion", "apiVersion must be of the form <group>/<version> or <version>").
+			Also(apis.ErrMissingField("kind")).
+			ViaField("ref").ViaField("spec"),
+	}, {
+		name: "ref to the same kind",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				Ref:  &KReferenceMappingRef{APIVersion: "example.com/v2", Kind: "Sink"},
+			},
+		},
+		want: apis.ErrGeneric("ref must be of another kind than from", "apiVersion", "kind").
+			ViaField("ref").ViaField("spec"),
+	}, {
+		name: "uri template not parsing",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				URI:  "http://{{ .Name",
+			},
+		},
+		want: apis.ErrInvalidValue("http://{{ .Name", "uri",
+			`template: :1: unclosed action`).ViaField("spec"),
+	}, {
+		name: "uri template with unknown value",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				URI:  "http://{{ .Host }}",
+			},
+		},
+		want: apis.ErrInvalidValue("http://{{ .Host }}", "uri",
+			`template: :1:10: executing "" at <.Host>: can't evaluate field Host in type kreferencemapping.TemplateValues`).ViaField("spec"),
+	}, {
+		name: "uri not absolute",
+		m: &KReferenceMapping{
+			Spec: KReferenceMappingSpec{
+				From: from,
+				URI:  "/{{ .Name }}",
+			},
+		},
+		want: apis.ErrInvalidValue("/{{ .Name }}", "uri",
+			`the mapping of Sink.example.com resulted in "/name", which isn't an absolute URL`).ViaField("spec"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.m.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+)
+
+func (rp *RedactionPolicy) SetDefaults(ctx context.Context) {
+	for i := range rp.Spec.To {
+		if rp.Spec.To[i].APIVersion != "" {
+			continue
+		}
+		switch rp.Spec.To[i].Kind {
+		case "Trigger":
+			rp.Spec.To[i].APIVersion = "eventing.knative.dev/v1"
+		case "Subscription":
+			rp.Spec.To[i].APIVersion = "messaging.knative.dev/v1"
+		}
+	}
+}
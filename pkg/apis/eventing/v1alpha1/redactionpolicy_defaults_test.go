@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRedactionPolicyDefaults(t *testing.T) {
+	rp := &RedactionPolicy{
+		Spec: RedactionPolicySpec{
+			To: []RedactionPolicyTargetReference{
+				{Kind: "Trigger", Name: "my-trigger"},
+				{Kind: "Subscription", Name: "my-subscription"},
+				{APIVersion: "eventing.knative.dev/v2", Kind: "Trigger", Name: "other"},
+			},
+		},
+	}
+	rp.SetDefaults(context.Background())
+
+	want := []RedactionPolicyTargetReference{
+		{APIVersion: "eventing.knative.dev/v1", Kind: "Trigger", Name: "my-trigger"},
+		{APIVersion: "messaging.knative.dev/v1", Kind: "Subscription", Name: "my-subscription"},
+		{APIVersion: "eventing.knative.dev/v2", Kind: "Trigger", Name: "other"},
+	}
+	if diff := cmp.Diff(want, rp.Spec.To); diff != "" {
+		t.Error("Unexpected defaults (-want, +got):", diff)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// RedactionPolicyConditionReady has status True when all targets of the
+	// RedactionPolicy exist.
+	RedactionPolicyConditionReady = apis.ConditionReady
+
+	// RedactionPolicyConditionTargetsResolved has status True when all
+	// Triggers and Subscriptions in .spec.to exist.
+	RedactionPolicyConditionTargetsResolved apis.ConditionType = "TargetsResolved"
+)
+
+var redactionPolicyCondSet = apis.NewLivingConditionSet(
+	RedactionPolicyConditionTargetsResolved,
+)
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (*RedactionPolicy) GetConditionSet() apis.ConditionSet {
+	return redactionPolicyCondSet
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (s *RedactionPolicyStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return redactionPolicyCondSet.Manage(s).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall.
+func (s *RedactionPolicyStatus) IsReady() bool {
+	return s.GetTopLevelCondition().IsTrue()
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (s *RedactionPolicyStatus) GetTopLevelCondition() *apis.Condition {
+	return redactionPolicyCondSet.Manage(s).GetTopLevelCondition()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (s *RedactionPolicyStatus) InitializeConditions() {
+	redactionPolicyCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkTargetsResolved sets the TargetsResolved condition to true.
+func (s *RedactionPolicyStatus) MarkTargetsResolved() {
+	redactionPolicyCondSet.Manage(s).MarkTrue(RedactionPolicyConditionTargetsResolved)
+}
+
+// MarkTargetsNotResolved sets the TargetsResolved condition to false.
+func (s *RedactionPolicyStatus) MarkTargetsNotResolved(reason, messageFormat string, messageA ...interface{}) {
+	redactionPolicyCondSet.Manage(s).MarkFalse(RedactionPolicyConditionTargetsResolved, reason, messageFormat, messageA...)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestRedactionPolicyGetConditionSet(t *testing.T) {
+	r := &RedactionPolicy{}
+
+	if got, want := r.GetConditionSet().GetTopLevelConditionType(), apis.ConditionReady; got != want {
+		t.Errorf("GetTopLevelCondition=%v, want=%v", got, want)
+	}
+}
+
+func TestRedactionPolicyStatusIsReady(t *testing.T) {
+	s := &RedactionPolicyStatus{}
+	s.InitializeConditions()
+	if got := s.GetCondition(RedactionPolicyConditionTargetsResolved); got == nil || got.Status != corev1.ConditionUnknown {
+		t.Errorf("TargetsResolved = %v, want Unknown", got)
+	}
+	if s.IsReady() {
+		t.Error("Expected an initialized RedactionPolicy not to be ready")
+	}
+
+	s.MarkTargetsNotResolved("NotFound", "trigger %s not found", "foo")
+	if s.IsReady() {
+		t.Error("Expected a RedactionPolicy with unresolved targets not to be ready")
+	}
+	if got := s.GetTopLevelCondition(); got.Reason != "NotFound" || got.Message != "trigger foo not found" {
+		t.Errorf("Unexpected Ready condition %+v", got)
+	}
+
+	s.MarkTargetsResolved()
+	if !s.IsReady() {
+		t.Error("Expected a RedactionPolicy with resolved targets to be ready")
+	}
+}
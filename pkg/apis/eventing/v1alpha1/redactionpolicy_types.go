@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing/pkg/redaction"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedactionPolicy removes or obfuscates fields of the event data before
+// events are delivered to the Triggers and Subscriptions it applies to.
+type RedactionPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the RedactionPolicy.
+	Spec RedactionPolicySpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the RedactionPolicy.
+	// This data may be out of date.
+	// +optional
+	Status RedactionPolicyStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that RedactionPolicy can be validated and defaulted.
+	_ apis.Validatable = (*RedactionPolicy)(nil)
+	_ apis.Defaultable = (*RedactionPolicy)(nil)
+
+	// Check that RedactionPolicy can return its spec untyped.
+	_ apis.HasSpec = (*RedactionPolicy)(nil)
+
+	_ runtime.Object = (*RedactionPolicy)(nil)
+
+	// Check that we can create OwnerReferences to a RedactionPolicy.
+	_ kmeta.OwnerRefable = (*RedactionPolicy)(nil)
+
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*RedactionPolicy)(nil)
+)
+
+type RedactionPolicySpec struct {
+	// To lists the Triggers and Subscriptions this policy applies to.
+	// The resources are part of the same namespace as the RedactionPolicy.
+	To []RedactionPolicyTargetReference `json:"to"`
+
+	// Rules are applied in order to the data of every event delivered to
+	// the targets.
+	Rules []RedactionRule `json:"rules"`
+}
+
+type RedactionPolicyTargetReference struct {
+	// API version of the referent.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the referent, either Trigger or Subscription.
+	Kind string `json:"kind"`
+
+	// Name of the referent.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+	Name string `json:"name"`
+}
+
+// RedactionAction is what happens to the fields selected by a RedactionRule.
+type RedactionAction string
+
+const (
+	// RedactionActionDrop removes the field.
+	RedactionActionDrop RedactionAction = "drop"
+	// RedactionActionHash replaces the field with the SHA-256 of its value.
+	RedactionActionHash RedactionAction = "hash"
+	// RedactionActionMask replaces the field with a fixed placeholder.
+	RedactionActionMask RedactionAction = "mask"
+)
+
+type RedactionRule struct {
+	// Path is a JSONPath expression selecting the fields of the event data
+	// to redact, for example $.user.email or $.items[*].card.
+	Path string `json:"path"`
+
+	// Action is one of drop, hash or mask.
+	Action RedactionAction `json:"action"`
+}
+
+// RedactionPolicyStatus represents the current state of a RedactionPolicy.
+type RedactionPolicyStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Service that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RedactionPolicyList is a collection of RedactionPolicy.
+type RedactionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RedactionPolicy `json:"items"`
+}
+
+// GetGroupVersionKind returns GroupVersionKind for RedactionPolicy
+func (rp *RedactionPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("RedactionPolicy")
+}
+
+// GetUntypedSpec returns the spec of the RedactionPolicy.
+func (rp *RedactionPolicy) GetUntypedSpec() interface{} {
+	return rp.Spec
+}
+
+// GetStatus retrieves the status of the RedactionPolicy. Implements the KRShaped interface.
+func (rp *RedactionPolicy) GetStatus() *duckv1.Status {
+	return &rp.Status.Status
+}
+
+// AppliesTo returns true when the policy targets the resource of the given
+// kind and name.
+func (rp *RedactionPolicy) AppliesTo(kind, name string) bool {
+	for _, to := range rp.Spec.To {
+		if to.Kind == kind && to.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactionRules returns the parsed rules of the policy.
+func (rp *RedactionPolicy) RedactionRules() ([]redaction.Rule, error) {
+	rules := make([]redaction.Rule, 0, len(rp.Spec.Rules))
+	for _, r := range rp.Spec.Rules {
+		p, err := redaction.ParsePath(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, redaction.Rule{Path: p, Action: redaction.Action(r.Action)})
+	}
+	return rules, nil
+}
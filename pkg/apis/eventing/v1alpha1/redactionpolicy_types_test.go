@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestRedactionPolicyGetStatus(t *testing.T) {
+	r := &RedactionPolicy{
+		Status: RedactionPolicyStatus{},
+	}
+	if got, want := r.GetStatus(), &r.Status.Status; got != want {
+		t.Errorf("GetStatus=%v, want=%v", got, want)
+	}
+}
+
+func TestRedactionPolicyGetGroupVersionKind(t *testing.T) {
+	r := &RedactionPolicy{}
+	gvk := r.GetGroupVersionKind()
+	if gvk.Kind != "RedactionPolicy" {
+		t.Errorf("Should be RedactionPolicy.")
+	}
+}
+
+func TestRedactionPolicyAppliesTo(t *testing.T) {
+	r := &RedactionPolicy{
+		Spec: RedactionPolicySpec{
+			To: []RedactionPolicyTargetReference{
+				{Kind: "Trigger", Name: "a"},
+				{Kind: "Subscription", Name: "b"},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		kind, name string
+		want       bool
+	}{
+		{"Trigger", "a", true},
+		{"Subscription", "b", true},
+		{"Subscription", "a", false},
+		{"Trigger", "c", false},
+	} {
+		if got := r.AppliesTo(tc.kind, tc.name); got != tc.want {
+			t.Errorf("AppliesTo(%q, %q) = %v, want %v", tc.kind, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRedactionPolicyRedactionRules(t *testing.T) {
+	r := &RedactionPolicy{
+		Spec: RedactionPolicySpec{
+			Rules: []RedactionRule{
+				{Path: "$.email", Action: RedactionActionMask},
+				{Path: "$.items[*].card", Action: RedactionActionHash},
+			},
+		},
+	}
+	rules, err := r.RedactionRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 || rules[1].Path.String() != "$.items[*].card" || string(rules[1].Action) != "hash" {
+		t.Errorf("Unexpected rules %+v", rules)
+	}
+
+	r.Spec.Rules = append(r.Spec.Rules, RedactionRule{Path: "email", Action: RedactionActionDrop})
+	if _, err := r.RedactionRules(); err == nil {
+		t.Error("Expected an error for an invalid path")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/redaction"
+)
+
+func (rp *RedactionPolicy) Validate(ctx context.Context) *apis.FieldError {
+	return rp.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (rps *RedactionPolicySpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if len(rps.To) == 0 {
+		errs = errs.Also(apis.ErrMissingField("to"))
+	}
+	for i, to := range rps.To {
+		errs = errs.Also(to.Validate().ViaFieldIndex("to", i))
+	}
+
+	if len(rps.Rules) == 0 {
+		errs = errs.Also(apis.ErrMissingField("rules"))
+	}
+	for i, r := range rps.Rules {
+		errs = errs.Also(r.Validate().ViaFieldIndex("rules", i))
+	}
+
+	return errs
+}
+
+func (r *RedactionPolicyTargetReference) Validate() *apis.FieldError {
+	var errs *apis.FieldError
+
+	switch r.Kind {
+	case "":
+		errs = errs.Also(apis.ErrMissingField("kind"))
+	case "Trigger", "Subscription":
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.Kind, "kind", "kind must be Trigger or Subscription"))
+	}
+	if r.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+
+	return errs
+}
+
+func (r *RedactionRule) Validate() *apis.FieldError {
+	var errs *apis.FieldError
+
+	if r.Path == "" {
+		errs = errs.Also(apis.ErrMissingField("path"))
+	} else if _, err := redaction.ParsePath(r.Path); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(r.Path, "path", err.Error()))
+	}
+
+	if r.Action == "" {
+		errs = errs.Also(apis.ErrMissingField("action"))
+	} else if !redaction.Action(r.Action).IsValid() {
+		errs = errs.Also(apis.ErrInvalidValue(r.Action, "action", "action must be one of drop, hash or mask"))
+	}
+
+	return errs
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"knative.dev/pkg/apis"
+)
+
+func TestRedactionPolicySpecValidation(t *testing.T) {
+	to := []RedactionPolicyTargetReference{{Kind: "Trigger", Name: "my-trigger"}}
+	rules := []RedactionRule{{Path: "$.user.email", Action: RedactionActionMask}}
+
+	tests := []struct {
+		name string
+		rp   *RedactionPolicy
+		want *apis.FieldError
+	}{{
+		name: "valid",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To: []RedactionPolicyTargetReference{
+					{Kind: "Trigger", Name: "my-trigger"},
+					{Kind: "Subscription", Name: "my-subscription"},
+				},
+				Rules: []RedactionRule{
+					{Path: "$.user.email", Action: RedactionActionMask},
+					{Path: "$.items[*].card", Action: RedactionActionHash},
+					{Path: "$.password", Action: RedactionActionDrop},
+				},
+			},
+		},
+	}, {
+		name: "missing to and rules",
+		rp:   &RedactionPolicy{},
+		want: apis.ErrMissingField("to", "rules").ViaField("spec"),
+	}, {
+		name: "invalid target",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To:    []RedactionPolicyTargetReference{{Kind: "Broker"}},
+				Rules: rules,
+			},
+		},
+		want: apis.ErrInvalidValue("Broker", "kind", "kind must be Trigger or Subscription").
+			Also(apis.ErrMissingField("name")).
+			ViaFieldIndex("to", 0).ViaField("spec"),
+	}, {
+		name: "missing target kind",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To:    []RedactionPolicyTargetReference{{Name: "my-trigger"}},
+				Rules: rules,
+			},
+		},
+		want: apis.ErrMissingField("kind").ViaFieldIndex("to", 0).ViaField("spec"),
+	}, {
+		name: "invalid path",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To:    to,
+				Rules: []RedactionRule{{Path: "user.email", Action: RedactionActionMask}},
+			},
+		},
+		want: apis.ErrInvalidValue("user.email", "path", `path "user.email" must start with $`).ViaFieldIndex("rules", 0).ViaField("spec"),
+	}, {
+		name: "invalid action",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To:    to,
+				Rules: []RedactionRule{{Path: "$.user.email", Action: "encrypt"}},
+			},
+		},
+		want: apis.ErrInvalidValue(RedactionAction("encrypt"), "action", "action must be one of drop, hash or mask").ViaFieldIndex("rules", 0).ViaField("spec"),
+	}, {
+		name: "missing rule fields",
+		rp: &RedactionPolicy{
+			Spec: RedactionPolicySpec{
+				To:    to,
+				Rules: []RedactionRule{{}},
+			},
+		},
+		want: apis.ErrMissingField("path", "action").ViaFieldIndex("rules", 0).ViaField("spec"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.rp.Validate(context.Background())
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Error("RedactionPolicy.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
@@ -47,6 +47,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&EventPolicy{},
 		&EventPolicyList{},
+		&EventEmission{},
+		&EventEmissionList{},
+		&RedactionPolicy{},
+		&RedactionPolicyList{},
+		&KReferenceMapping{},
+		&KReferenceMappingList{},
+		&BrokerDefaultsPolicy{},
+		&BrokerDefaultsPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -44,6 +44,14 @@ func TestKnownTypes(t *testing.T) {
 	for _, name := range []string{
 		"EventPolicy",
 		"EventPolicyList",
+		"EventEmission",
+		"EventEmissionList",
+		"RedactionPolicy",
+		"RedactionPolicyList",
+		"KReferenceMapping",
+		"KReferenceMappingList",
+		"BrokerDefaultsPolicy",
+		"BrokerDefaultsPolicyList",
 	} {
 		if _, ok := types[name]; !ok {
 			t.Errorf("Did not find %q as registered type", name)
@@ -24,8 +24,286 @@ package v1alpha1
 import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	duckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	apis "knative.dev/pkg/apis"
+	apisduckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDefaultsPolicy) DeepCopyInto(out *BrokerDefaultsPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDefaultsPolicy.
+func (in *BrokerDefaultsPolicy) DeepCopy() *BrokerDefaultsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDefaultsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrokerDefaultsPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDefaultsPolicyList) DeepCopyInto(out *BrokerDefaultsPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BrokerDefaultsPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDefaultsPolicyList.
+func (in *BrokerDefaultsPolicyList) DeepCopy() *BrokerDefaultsPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDefaultsPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrokerDefaultsPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDefaultsPolicySpec) DeepCopyInto(out *BrokerDefaultsPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(duckv1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDefaultsPolicySpec.
+func (in *BrokerDefaultsPolicySpec) DeepCopy() *BrokerDefaultsPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDefaultsPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerDefaultsPolicyStatus) DeepCopyInto(out *BrokerDefaultsPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerDefaultsPolicyStatus.
+func (in *BrokerDefaultsPolicyStatus) DeepCopy() *BrokerDefaultsPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerDefaultsPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmission) DeepCopyInto(out *EventEmission) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmission.
+func (in *EventEmission) DeepCopy() *EventEmission {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventEmission) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmissionEvent) DeepCopyInto(out *EventEmissionEvent) {
+	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmissionEvent.
+func (in *EventEmissionEvent) DeepCopy() *EventEmissionEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmissionEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmissionList) DeepCopyInto(out *EventEmissionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EventEmission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmissionList.
+func (in *EventEmissionList) DeepCopy() *EventEmissionList {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmissionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EventEmissionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmissionResult) DeepCopyInto(out *EventEmissionResult) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmissionResult.
+func (in *EventEmissionResult) DeepCopy() *EventEmissionResult {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmissionResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmissionSpec) DeepCopyInto(out *EventEmissionSpec) {
+	*out = *in
+	in.Sink.DeepCopyInto(&out.Sink)
+	in.Event.DeepCopyInto(&out.Event)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmissionSpec.
+func (in *EventEmissionSpec) DeepCopy() *EventEmissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventEmissionStatus) DeepCopyInto(out *EventEmissionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.SinkURI != nil {
+		in, out := &in.SinkURI, &out.SinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SinkCACerts != nil {
+		in, out := &in.SinkCACerts, &out.SinkCACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.SinkAudience != nil {
+		in, out := &in.SinkAudience, &out.SinkAudience
+		*out = new(string)
+		**out = **in
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(apisduckv1.AuthStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastEmission != nil {
+		in, out := &in.LastEmission, &out.LastEmission
+		*out = new(EventEmissionResult)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventEmissionStatus.
+func (in *EventEmissionStatus) DeepCopy() *EventEmissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EventEmissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EventPolicy) DeepCopyInto(out *EventPolicy) {
 	*out = *in
@@ -146,6 +424,16 @@ func (in *EventPolicySpec) DeepCopyInto(out *EventPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(EventPolicyValidity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RejectionSink != nil {
+		in, out := &in.RejectionSink, &out.RejectionSink
+		*out = new(apisduckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -220,6 +508,21 @@ func (in *EventPolicyStatus) DeepCopyInto(out *EventPolicyStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RejectionSinkURI != nil {
+		in, out := &in.RejectionSinkURI, &out.RejectionSinkURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RejectionSinkCACerts != nil {
+		in, out := &in.RejectionSinkCACerts, &out.RejectionSinkCACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.RejectionSinkAudience != nil {
+		in, out := &in.RejectionSinkAudience, &out.RejectionSinkAudience
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -248,3 +551,295 @@ func (in *EventPolicyToReference) DeepCopy() *EventPolicyToReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventPolicyValidity) DeepCopyInto(out *EventPolicyValidity) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventPolicyValidity.
+func (in *EventPolicyValidity) DeepCopy() *EventPolicyValidity {
+	if in == nil {
+		return nil
+	}
+	out := new(EventPolicyValidity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMapping) DeepCopyInto(out *KReferenceMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMapping.
+func (in *KReferenceMapping) DeepCopy() *KReferenceMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KReferenceMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMappingFrom) DeepCopyInto(out *KReferenceMappingFrom) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMappingFrom.
+func (in *KReferenceMappingFrom) DeepCopy() *KReferenceMappingFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMappingFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMappingList) DeepCopyInto(out *KReferenceMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KReferenceMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMappingList.
+func (in *KReferenceMappingList) DeepCopy() *KReferenceMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KReferenceMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMappingRef) DeepCopyInto(out *KReferenceMappingRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMappingRef.
+func (in *KReferenceMappingRef) DeepCopy() *KReferenceMappingRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMappingRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMappingSpec) DeepCopyInto(out *KReferenceMappingSpec) {
+	*out = *in
+	out.From = in.From
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(KReferenceMappingRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMappingSpec.
+func (in *KReferenceMappingSpec) DeepCopy() *KReferenceMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KReferenceMappingStatus) DeepCopyInto(out *KReferenceMappingStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KReferenceMappingStatus.
+func (in *KReferenceMappingStatus) DeepCopy() *KReferenceMappingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KReferenceMappingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPolicy) DeepCopyInto(out *RedactionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPolicy.
+func (in *RedactionPolicy) DeepCopy() *RedactionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedactionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPolicyList) DeepCopyInto(out *RedactionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedactionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPolicyList.
+func (in *RedactionPolicyList) DeepCopy() *RedactionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedactionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPolicySpec) DeepCopyInto(out *RedactionPolicySpec) {
+	*out = *in
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]RedactionPolicyTargetReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RedactionRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPolicySpec.
+func (in *RedactionPolicySpec) DeepCopy() *RedactionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPolicyStatus) DeepCopyInto(out *RedactionPolicyStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPolicyStatus.
+func (in *RedactionPolicyStatus) DeepCopy() *RedactionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionPolicyTargetReference) DeepCopyInto(out *RedactionPolicyTargetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionPolicyTargetReference.
+func (in *RedactionPolicyTargetReference) DeepCopy() *RedactionPolicyTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionPolicyTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedactionRule) DeepCopyInto(out *RedactionRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedactionRule.
+func (in *RedactionRule) DeepCopy() *RedactionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RedactionRule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -39,6 +39,7 @@ func (source *EventType) ConvertTo(ctx context.Context, obj apis.Convertible) er
 			Source:      source.Spec.Source,
 			Schema:      source.Spec.Schema,
 			SchemaData:  source.Spec.SchemaData,
+			Broker:      source.Spec.Broker,
 			Description: source.Spec.Description,
 		}
 
@@ -77,6 +78,7 @@ func (sink *EventType) ConvertFrom(ctx context.Context, obj apis.Convertible) er
 			Source:      source.Spec.Source,
 			Schema:      source.Spec.Schema,
 			SchemaData:  source.Spec.SchemaData,
+			Broker:      source.Spec.Broker,
 			Reference:   source.Spec.Reference,
 			Description: source.Spec.Description,
 		}
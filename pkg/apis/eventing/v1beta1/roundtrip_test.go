@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	pkgfuzzer "knative.dev/pkg/apis/testing/fuzzer"
+	"knative.dev/pkg/apis/testing/roundtrip"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	"knative.dev/eventing/pkg/apis/eventing/v1beta3"
+)
+
+// FuzzerFuncs includes fuzzing funcs for the knative.dev/eventing EventType
+// versions converted through the v1beta1 hub.
+//
+// For other examples see
+// https://github.com/kubernetes/apimachinery/blob/master/pkg/apis/meta/fuzzer/fuzzer.go
+var FuzzerFuncs = fuzzer.MergeFuzzerFuncs(
+	func(codecs serializer.CodecFactory) []interface{} {
+		return []interface{}{
+			// The URLs are converted to v1beta3 attributes through their
+			// string form, which only preserves the URLs of the form they
+			// are parsed to.
+			func(u *apis.URL, c fuzz.Continue) {
+				u.Scheme = "https"
+				u.Host = randomName(c)
+				u.Path = "/" + randomName(c)
+			},
+			// The EventTypes of a broker reference it once converted, so
+			// that the broker field can be deprecated.
+			func(s *EventTypeSpec, c fuzz.Continue) {
+				c.FuzzNoCustom(s)
+				referenceBroker(&s.Reference, s.Broker)
+			},
+			func(s *v1beta2.EventTypeSpec, c fuzz.Continue) {
+				c.FuzzNoCustom(s)
+				referenceBroker(&s.Reference, s.Broker)
+			},
+		}
+	},
+)
+
+func referenceBroker(ref **duckv1.KReference, broker string) {
+	if *ref == nil && broker != "" {
+		*ref = &duckv1.KReference{
+			APIVersion: "eventing.knative.dev/v1",
+			Kind:       "Broker",
+			Name:       broker,
+		}
+	}
+}
+
+func randomName(c fuzz.Continue) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+	b := make([]byte, 1+c.Intn(16))
+	for i := range b {
+		b[i] = letters[c.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func TestEventTypeRoundTripTypesToJSON(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(AddToScheme(scheme))
+	utilruntime.Must(v1beta2.AddToScheme(scheme))
+	utilruntime.Must(v1beta3.AddToScheme(scheme))
+
+	roundtrip.ExternalTypesViaJSON(t, scheme, fuzzer.MergeFuzzerFuncs(pkgfuzzer.Funcs, FuzzerFuncs))
+}
+
+// TestEventTypeRoundTripTypesViaHub checks that the conversions of the
+// EventTypes through the v1beta1 hub, as done by the conversion webhook,
+// don't drop fields.
+func TestEventTypeRoundTripTypesViaHub(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1beta2.AddToScheme(scheme))
+	utilruntime.Must(v1beta3.AddToScheme(scheme))
+
+	hubs := runtime.NewScheme()
+	utilruntime.Must(AddToScheme(hubs))
+
+	roundtrip.ExternalTypesViaHub(t, scheme, hubs, FuzzerFuncs)
+}
+
+// TestEventTypeRoundTripV1Beta2ViaV1Beta3 checks that the v1beta2 EventTypes
+// converted to v1beta3 EventTypes and back through the v1beta1 hub don't
+// drop fields.
+func TestEventTypeRoundTripV1Beta2ViaV1Beta3(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1beta2.AddToScheme(scheme))
+	f := fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, FuzzerFuncs),
+		rand.NewSource(rand.Int63()),
+		serializer.NewCodecFactory(scheme),
+	)
+
+	for i := 0; i < 100; i++ {
+		in := &v1beta2.EventType{}
+		f.Fuzz(in)
+
+		hub := &EventType{}
+		if err := hub.ConvertFrom(ctx, in); err != nil {
+			t.Fatal("ConvertFrom(v1beta2) =", err)
+		}
+		mid := &v1beta3.EventType{}
+		if err := hub.ConvertTo(ctx, mid); err != nil {
+			t.Fatal("ConvertTo(v1beta3) =", err)
+		}
+
+		hub = &EventType{}
+		if err := hub.ConvertFrom(ctx, mid); err != nil {
+			t.Fatal("ConvertFrom(v1beta3) =", err)
+		}
+		got := &v1beta2.EventType{}
+		if err := hub.ConvertTo(ctx, got); err != nil {
+			t.Fatal("ConvertTo(v1beta2) =", err)
+		}
+
+		if !equality.Semantic.DeepEqual(in, got) {
+			t.Fatal("Round trip through v1beta3 produced a diff (-want, +got):", cmp.Diff(in, got))
+		}
+	}
+}
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-	http://www.apache.org/licenses/LICENSE-2.0
+    http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -18,7 +18,9 @@ package v1beta2
 
 import (
 	"context"
+	"encoding/json"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
@@ -26,6 +28,25 @@ import (
 	"knative.dev/eventing/pkg/apis/eventing/v1beta3"
 )
 
+const (
+	// AttributesAnnotationKey is the annotation of the v1beta2 EventTypes
+	// converted from v1beta3 EventTypes whose attributes can't be derived
+	// from their v1beta2 fields, holding the v1beta3 attributes as JSON.
+	AttributesAnnotationKey = "eventing.knative.dev/v1beta3-attributes"
+
+	// SpecAnnotationKey is the annotation of the v1beta3 EventTypes
+	// converted from v1beta2 EventTypes with a broker or schema data, which
+	// have no v1beta3 counterpart, holding them as JSON.
+	SpecAnnotationKey = "eventing.knative.dev/v1beta2-spec"
+)
+
+// convertedSpec holds the fields of the v1beta2 EventTypes without v1beta3
+// counterpart.
+type convertedSpec struct {
+	Broker     string `json:"broker,omitempty"`
+	SchemaData string `json:"schemaData,omitempty"`
+}
+
 // ConvertTo converts the receiver into `to`.
 func (source *EventType) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	switch sink := to.(type) {
@@ -38,34 +59,30 @@ func (source *EventType) ConvertTo(ctx context.Context, to apis.Convertible) err
 		sink.Spec.Description = source.Spec.Description
 
 		if source.Spec.Reference == nil && source.Spec.Broker != "" {
-			source.Spec.Reference = &duckv1.KReference{
+			sink.Spec.Reference = &duckv1.KReference{
 				Kind:       "Broker",
 				Name:       source.Spec.Broker,
 				APIVersion: eventing.SchemeGroupVersion.String(),
 			}
 		}
 
-		sink.Spec.Attributes = []v1beta3.EventAttributeDefinition{}
-		if source.Spec.Type != "" {
-			sink.Spec.Attributes = append(sink.Spec.Attributes, v1beta3.EventAttributeDefinition{
-				Name:     "type",
-				Required: true,
-				Value:    source.Spec.Type,
-			})
-		}
-		if source.Spec.Schema != nil {
-			sink.Spec.Attributes = append(sink.Spec.Attributes, v1beta3.EventAttributeDefinition{
-				Name:     "schemadata",
-				Required: false,
-				Value:    source.Spec.Schema.String(),
-			})
+		sink.Spec.Attributes = attributesFromSpec(&source.Spec)
+		if raw, ok := source.Annotations[AttributesAnnotationKey]; ok {
+			// The attributes only hold as long as the v1beta2 fields they
+			// are derived from didn't change.
+			var attributes []v1beta3.EventAttributeDefinition
+			if err := json.Unmarshal([]byte(raw), &attributes); err == nil && specMatchesAttributes(&source.Spec, attributes) {
+				sink.Spec.Attributes = attributes
+			}
+			removeAnnotation(&sink.Annotations, AttributesAnnotationKey)
 		}
-		if source.Spec.Source != nil {
-			sink.Spec.Attributes = append(sink.Spec.Attributes, v1beta3.EventAttributeDefinition{
-				Name:     "source",
-				Required: true,
-				Value:    source.Spec.Source.String(),
-			})
+
+		if spec := (convertedSpec{Broker: source.Spec.Broker, SchemaData: source.Spec.SchemaData}); spec != (convertedSpec{}) {
+			raw, err := json.Marshal(spec)
+			if err != nil {
+				return err
+			}
+			setAnnotation(&sink.Annotations, SpecAnnotationKey, string(raw))
 		}
 		return nil
 	default:
@@ -85,19 +102,95 @@ func (sink *EventType) ConvertFrom(ctx context.Context, from apis.Convertible) e
 		sink.Spec.Reference = source.Spec.Reference.DeepCopy()
 		sink.Spec.Description = source.Spec.Description
 
-		for _, at := range source.Spec.Attributes {
-			switch at.Name {
-			case "source":
-				sink.Spec.Source, _ = apis.ParseURL(at.Value)
-			case "type":
-				sink.Spec.Type = at.Value
-			case "schemadata":
-				sink.Spec.Schema, _ = apis.ParseURL(at.Value)
+		specFromAttributes(&sink.Spec, source.Spec.Attributes)
+
+		sink.Spec.Broker, sink.Spec.SchemaData = "", ""
+		if raw, ok := source.Annotations[SpecAnnotationKey]; ok {
+			var spec convertedSpec
+			if err := json.Unmarshal([]byte(raw), &spec); err == nil {
+				sink.Spec.Broker = spec.Broker
+				sink.Spec.SchemaData = spec.SchemaData
 			}
+			removeAnnotation(&sink.Annotations, SpecAnnotationKey)
 		}
 
+		if !equality.Semantic.DeepEqual(attributesFromSpec(&sink.Spec), source.Spec.Attributes) {
+			raw, err := json.Marshal(source.Spec.Attributes)
+			if err != nil {
+				return err
+			}
+			setAnnotation(&sink.Annotations, AttributesAnnotationKey, string(raw))
+		}
 		return nil
 	default:
 		return apis.ConvertFromViaProxy(ctx, from, &v1beta3.EventType{}, sink)
 	}
 }
+
+// attributesFromSpec returns the v1beta3 attributes derived from the v1beta2
+// fields.
+func attributesFromSpec(spec *EventTypeSpec) []v1beta3.EventAttributeDefinition {
+	attributes := []v1beta3.EventAttributeDefinition{}
+	if spec.Type != "" {
+		attributes = append(attributes, v1beta3.EventAttributeDefinition{
+			Name:     "type",
+			Required: true,
+			Value:    spec.Type,
+		})
+	}
+	if spec.Schema != nil {
+		attributes = append(attributes, v1beta3.EventAttributeDefinition{
+			Name:     "schemadata",
+			Required: false,
+			Value:    spec.Schema.String(),
+		})
+	}
+	if spec.Source != nil {
+		attributes = append(attributes, v1beta3.EventAttributeDefinition{
+			Name:     "source",
+			Required: true,
+			Value:    spec.Source.String(),
+		})
+	}
+	return attributes
+}
+
+// specFromAttributes sets the v1beta2 fields derived from the v1beta3
+// attributes.
+func specFromAttributes(spec *EventTypeSpec, attributes []v1beta3.EventAttributeDefinition) {
+	spec.Type, spec.Source, spec.Schema = "", nil, nil
+	for _, at := range attributes {
+		switch at.Name {
+		case "source":
+			spec.Source, _ = apis.ParseURL(at.Value)
+		case "type":
+			spec.Type = at.Value
+		case "schemadata":
+			spec.Schema, _ = apis.ParseURL(at.Value)
+		}
+	}
+}
+
+// specMatchesAttributes returns whether the v1beta2 fields are the ones
+// derived from the v1beta3 attributes.
+func specMatchesAttributes(spec *EventTypeSpec, attributes []v1beta3.EventAttributeDefinition) bool {
+	derived := EventTypeSpec{}
+	specFromAttributes(&derived, attributes)
+	return derived.Type == spec.Type &&
+		derived.Source.String() == spec.Source.String() &&
+		derived.Schema.String() == spec.Schema.String()
+}
+
+func setAnnotation(annotations *map[string]string, key, value string) {
+	if *annotations == nil {
+		*annotations = make(map[string]string, 1)
+	}
+	(*annotations)[key] = value
+}
+
+func removeAnnotation(annotations *map[string]string, key string) {
+	delete(*annotations, key)
+	if len(*annotations) == 0 {
+		*annotations = nil
+	}
+}
@@ -19,7 +19,9 @@ package feature
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/rickb777/date/period"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -71,16 +73,32 @@ type Flags map[string]Flag
 
 func newDefaults() Flags {
 	return map[string]Flag{
-		KReferenceGroup:          Disabled,
-		DeliveryRetryAfter:       Disabled,
-		DeliveryTimeout:          Enabled,
-		KReferenceMapping:        Disabled,
-		NewTriggerFilters:        Enabled,
-		TransportEncryption:      Disabled,
-		OIDCAuthentication:       Disabled,
-		EvenTypeAutoCreate:       Disabled,
-		NewAPIServerFilters:      Disabled,
-		AuthorizationDefaultMode: AuthorizationAllowSameNamespace,
+		KReferenceGroup:             Disabled,
+		DeliveryRetryAfter:          Disabled,
+		DeliveryTimeout:             Enabled,
+		KReferenceMapping:           Disabled,
+		NewTriggerFilters:           Enabled,
+		TriggerWeightedSubscribers:  Disabled,
+		TriggerMirror:               Disabled,
+		TransportEncryption:         Disabled,
+		OIDCAuthentication:          Disabled,
+		OIDCSharedIdentity:          Disabled,
+		EvenTypeAutoCreate:          Disabled,
+		NewAPIServerFilters:         Disabled,
+		AuthorizationDefaultMode:    AuthorizationAllowSameNamespace,
+		DeletionProtection:          Disabled,
+		BrokerSynchronousDelivery:   Disabled,
+		TriggerDeadLetterSinkHealth: Disabled,
+		BrokerDeliveryFailedEvents:  Disabled,
+		DeliveryOrder:               Disabled,
+		DeliveryConcurrency:         Disabled,
+		DeliveryHedging:             Disabled,
+		ReplayProtection:            Disabled,
+		SequenceEarlyExit:           Disabled,
+		TriggerTimeFilters:          Disabled,
+		TriggerDependencies:         Disabled,
+		BrokerIngressExposure:       Disabled,
+		BrokerIngressGRPC:           Disabled,
 	}
 }
 
@@ -118,6 +136,12 @@ func (e Flags) IsOIDCAuthentication() bool {
 	return e != nil && e[OIDCAuthentication] == Enabled
 }
 
+// IsOIDCSharedIdentity returns true if all resources of a namespace share a
+// single OIDC identity instead of having one service account per resource.
+func (e Flags) IsOIDCSharedIdentity() bool {
+	return e.IsOIDCAuthentication() && e[OIDCSharedIdentity] == Enabled
+}
+
 func (e Flags) IsCrossNamespaceEventLinks() bool {
 	return e != nil && e[CrossNamespaceEventLinks] == Enabled
 }
@@ -134,6 +158,42 @@ func (e Flags) IsAuthorizationDefaultModeSameNamespace() bool {
 	return e != nil && e[AuthorizationDefaultMode] == AuthorizationAllowSameNamespace
 }
 
+// BrokerIngressRateLimit returns the rate limit applied by the Broker ingress
+// per event source to the Brokers without the ingress rate limit annotation,
+// empty when their events aren't rate limited.
+func (e Flags) BrokerIngressRateLimit() string {
+	if e == nil || e.IsDisabled(BrokerIngressRateLimit) {
+		return ""
+	}
+	return string(e[BrokerIngressRateLimit])
+}
+
+// DeliveryTimeoutMax returns the maximum value of the Timeout field of the
+// DeliverySpecs, 0 when it isn't bounded, which is the default.
+func (e Flags) DeliveryTimeoutMax() time.Duration {
+	v, ok := e[DeliveryTimeoutMax]
+	if !ok || e.IsDisabled(DeliveryTimeoutMax) {
+		return 0
+	}
+	max, err := parseDeliveryTimeoutMax(string(v))
+	if err != nil {
+		return 0
+	}
+	return max
+}
+
+func parseDeliveryTimeoutMax(v string) (time.Duration, error) {
+	p, err := period.Parse(v)
+	if err != nil {
+		return 0, err
+	}
+	max, _ := p.Duration()
+	if max <= 0 {
+		return 0, fmt.Errorf("the maximum timeout must be greater than 0")
+	}
+	return max, nil
+}
+
 func (e Flags) String() string {
 	return fmt.Sprintf("%+v", map[string]Flag(e))
 }
@@ -185,6 +245,13 @@ func NewFlagsConfigFromMap(data map[string]string) (Flags, error) {
 			flags[sanitizedKey] = AuthorizationAllowSameNamespace
 		} else if strings.Contains(k, NodeSelectorLabel) {
 			flags[sanitizedKey] = Flag(v)
+		} else if sanitizedKey == BrokerIngressRateLimit {
+			flags[sanitizedKey] = Flag(strings.TrimSpace(v))
+		} else if sanitizedKey == DeliveryTimeoutMax {
+			if _, err := parseDeliveryTimeoutMax(strings.TrimSpace(v)); err != nil {
+				return flags, fmt.Errorf("cannot parse the feature flag '%s' = '%s': %w", k, v, err)
+			}
+			flags[sanitizedKey] = Flag(strings.TrimSpace(v))
 		} else {
 			return flags, fmt.Errorf("cannot parse the feature flag '%s' = '%s'", k, v)
 		}
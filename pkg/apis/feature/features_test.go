@@ -18,6 +18,7 @@ package feature_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	_ "knative.dev/pkg/system/testing"
@@ -75,3 +76,50 @@ func TestShouldNotOverrideDefaults(t *testing.T) {
 		t.Errorf("Expected default value for %s to be %s in flags %+v", NewTriggerFilters, Enabled, f)
 	}
 }
+
+func TestFlags_IsOIDCSharedIdentity(t *testing.T) {
+	require.False(t, Flags{
+		OIDCSharedIdentity: Enabled,
+	}.IsOIDCSharedIdentity())
+	require.False(t, Flags{
+		OIDCAuthentication: Enabled,
+	}.IsOIDCSharedIdentity())
+	require.True(t, Flags{
+		OIDCAuthentication: Enabled,
+		OIDCSharedIdentity: Enabled,
+	}.IsOIDCSharedIdentity())
+}
+
+func TestFlags_BrokerIngressRateLimit(t *testing.T) {
+	f, err := NewFlagsConfigFromMap(map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, "", f.BrokerIngressRateLimit())
+
+	f, err = NewFlagsConfigFromMap(map[string]string{BrokerIngressRateLimit: "disabled"})
+	require.NoError(t, err)
+	require.Equal(t, "", f.BrokerIngressRateLimit())
+
+	f, err = NewFlagsConfigFromMap(map[string]string{BrokerIngressRateLimit: " 100,200 "})
+	require.NoError(t, err)
+	require.Equal(t, "100,200", f.BrokerIngressRateLimit())
+}
+
+func TestFlags_DeliveryTimeoutMax(t *testing.T) {
+	f, err := NewFlagsConfigFromMap(map[string]string{})
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), f.DeliveryTimeoutMax())
+
+	f, err = NewFlagsConfigFromMap(map[string]string{DeliveryTimeoutMax: "disabled"})
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), f.DeliveryTimeoutMax())
+
+	f, err = NewFlagsConfigFromMap(map[string]string{DeliveryTimeoutMax: " PT1H "})
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, f.DeliveryTimeoutMax())
+
+	_, err = NewFlagsConfigFromMap(map[string]string{DeliveryTimeoutMax: "1h"})
+	require.Error(t, err)
+
+	_, err = NewFlagsConfigFromMap(map[string]string{DeliveryTimeoutMax: "PT0S"})
+	require.Error(t, err)
+}
@@ -17,16 +17,34 @@ limitations under the License.
 package feature
 
 const (
-	KReferenceGroup          = "kreference-group"
-	DeliveryRetryAfter       = "delivery-retryafter"
-	DeliveryTimeout          = "delivery-timeout"
-	KReferenceMapping        = "kreference-mapping"
-	NewTriggerFilters        = "new-trigger-filters"
-	TransportEncryption      = "transport-encryption"
-	EvenTypeAutoCreate       = "eventtype-auto-create"
-	OIDCAuthentication       = "authentication-oidc"
-	NodeSelectorLabel        = "apiserversources-nodeselector-"
-	CrossNamespaceEventLinks = "cross-namespace-event-links"
-	NewAPIServerFilters      = "new-apiserversource-filters"
-	AuthorizationDefaultMode = "default-authorization-mode"
+	KReferenceGroup             = "kreference-group"
+	DeliveryRetryAfter          = "delivery-retryafter"
+	DeliveryTimeout             = "delivery-timeout"
+	DeliveryTimeoutMax          = "delivery-timeout-max"
+	KReferenceMapping           = "kreference-mapping"
+	NewTriggerFilters           = "new-trigger-filters"
+	TriggerWeightedSubscribers  = "trigger-weighted-subscribers"
+	TriggerMirror               = "trigger-mirror"
+	TransportEncryption         = "transport-encryption"
+	EvenTypeAutoCreate          = "eventtype-auto-create"
+	OIDCAuthentication          = "authentication-oidc"
+	OIDCSharedIdentity          = "authentication-oidc-shared-identity"
+	NodeSelectorLabel           = "apiserversources-nodeselector-"
+	CrossNamespaceEventLinks    = "cross-namespace-event-links"
+	NewAPIServerFilters         = "new-apiserversource-filters"
+	AuthorizationDefaultMode    = "default-authorization-mode"
+	DeletionProtection          = "deletion-protection"
+	BrokerSynchronousDelivery   = "broker-synchronous-delivery"
+	TriggerDeadLetterSinkHealth = "trigger-dead-letter-sink-health"
+	BrokerDeliveryFailedEvents  = "broker-delivery-failed-events"
+	DeliveryOrder               = "delivery-order"
+	DeliveryConcurrency         = "delivery-concurrency"
+	DeliveryHedging             = "delivery-hedging"
+	ReplayProtection            = "replay-protection"
+	SequenceEarlyExit           = "sequence-early-exit"
+	BrokerIngressRateLimit      = "broker-ingress-rate-limit"
+	TriggerTimeFilters          = "trigger-time-filters"
+	TriggerDependencies         = "trigger-dependencies"
+	BrokerIngressExposure       = "broker-ingress-exposure"
+	BrokerIngressGRPC           = "broker-ingress-grpc"
 )
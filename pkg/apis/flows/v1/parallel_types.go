@@ -98,6 +98,20 @@ type ParallelBranch struct {
 	// This includes things like retries, DLS, etc.
 	// +optional
 	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Timeout is the timeout of each request sent to the subscriber of the
+	// branch, it is the delivery timeout of its Subscription.
+	// More information on Duration format:
+	//  - https://www.iso.org/iso-8601-date-and-time-format.html
+	//  - https://en.wikipedia.org/wiki/ISO_8601
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+
+	// Fallback is where the events are sent when the subscriber of the branch
+	// fails or times out once the retries are exhausted, it is the dead
+	// letter sink of its Subscription.
+	// +optional
+	Fallback *duckv1.Destination `json:"fallback,omitempty"`
 }
 
 // ParallelStatus represents the current state of a Parallel.
@@ -19,7 +19,10 @@ package v1
 import (
 	"context"
 
+	"github.com/rickb777/date/period"
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/apis/feature"
 )
 
 func (p *Parallel) Validate(ctx context.Context) *apis.FieldError {
@@ -45,6 +48,17 @@ func (ps *ParallelSpec) Validate(ctx context.Context) *apis.FieldError {
 		if e := s.Reply.Validate(ctx); e != nil {
 			errs = errs.Also(apis.ErrInvalidArrayValue(s, "branches.reply", i))
 		}
+
+		errs = errs.Also(s.validateTimeout(ctx).ViaFieldIndex("branches", i))
+
+		if s.Fallback != nil {
+			if e := s.Fallback.Validate(ctx); e != nil {
+				errs = errs.Also(apis.ErrInvalidArrayValue(s, "branches.fallback", i))
+			}
+			if s.Delivery != nil && s.Delivery.DeadLetterSink != nil {
+				errs = errs.Also(apis.ErrMultipleOneOf("fallback", "delivery.deadLetterSink").ViaFieldIndex("branches", i))
+			}
+		}
 	}
 
 	if ps.ChannelTemplate == nil {
@@ -66,3 +80,20 @@ func (ps *ParallelSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	return errs
 }
+
+func (pb *ParallelBranch) validateTimeout(ctx context.Context) *apis.FieldError {
+	if pb.Timeout == nil {
+		return nil
+	}
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryTimeout) {
+		return apis.ErrDisallowedFields("timeout")
+	}
+	if pb.Delivery != nil && pb.Delivery.Timeout != nil {
+		return apis.ErrMultipleOneOf("timeout", "delivery.timeout")
+	}
+	t, err := period.Parse(*pb.Timeout)
+	if err != nil || t.IsZero() || t.IsNegative() {
+		return apis.ErrInvalidValue(*pb.Timeout, "timeout")
+	}
+	return nil
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/pkg/apis"
 )
@@ -204,3 +206,75 @@ func TestParallelSpecValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestParallelBranchTimeoutAndFallbackValidate(t *testing.T) {
+	timeout := "PT5S"
+	invalidTimeout := "5s"
+
+	tests := []struct {
+		name     string
+		branch   ParallelBranch
+		disabled bool
+		want     *apis.FieldError
+	}{
+		{
+			name: "valid timeout and fallback",
+			branch: ParallelBranch{
+				Subscriber: getValidDestination(),
+				Timeout:    &timeout,
+				Fallback:   getValidDestinationRef(),
+			},
+		},
+		{
+			name: "invalid timeout",
+			branch: ParallelBranch{
+				Subscriber: getValidDestination(),
+				Timeout:    &invalidTimeout,
+			},
+			want: apis.ErrInvalidValue(invalidTimeout, "branches[0].timeout"),
+		},
+		{
+			name: "timeout with delivery-timeout disabled",
+			branch: ParallelBranch{
+				Subscriber: getValidDestination(),
+				Timeout:    &timeout,
+			},
+			disabled: true,
+			want:     apis.ErrDisallowedFields("branches[0].timeout"),
+		},
+		{
+			name: "timeout and delivery timeout",
+			branch: ParallelBranch{
+				Subscriber: getValidDestination(),
+				Timeout:    &timeout,
+				Delivery:   &eventingduckv1.DeliverySpec{Timeout: &timeout},
+			},
+			want: apis.ErrMultipleOneOf("branches[0].timeout", "branches[0].delivery.timeout"),
+		},
+		{
+			name: "fallback and dead letter sink",
+			branch: ParallelBranch{
+				Subscriber: getValidDestination(),
+				Fallback:   getValidDestinationRef(),
+				Delivery:   &eventingduckv1.DeliverySpec{DeadLetterSink: getValidDestinationRef()},
+			},
+			want: apis.ErrMultipleOneOf("branches[0].fallback", "branches[0].delivery.deadLetterSink"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := feature.Flags{feature.DeliveryTimeout: feature.Enabled}
+			if tt.disabled {
+				flags[feature.DeliveryTimeout] = feature.Disabled
+			}
+			ps := &ParallelSpec{
+				Branches:        []ParallelBranch{tt.branch},
+				ChannelTemplate: getValidChannelTemplate(),
+			}
+			got := ps.Validate(feature.ToContext(context.TODO(), flags))
+			if diff := cmp.Diff(tt.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: ParallelSpec.Validate (-want, +got) = %v", tt.name, diff)
+			}
+		})
+	}
+}
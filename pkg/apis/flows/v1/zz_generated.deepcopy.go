@@ -75,6 +75,16 @@ func (in *ParallelBranch) DeepCopyInto(out *ParallelBranch) {
 		*out = new(apisduckv1.DeliverySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -23,6 +23,10 @@ const (
 	// SubscribableDuckVersionAnnotation is the annotation we use to declare
 	// which Subscribable duck version type we conform to.
 	SubscribableDuckVersionAnnotation = "messaging.knative.dev/subscribable"
+	// DispatchWeightAnnotation is the annotation setting the share of the
+	// dispatch capacity of a shared dispatcher given to a channel when the
+	// dispatcher is saturated, relative to the other channels.
+	DispatchWeightAnnotation = GroupName + "/dispatch-weight"
 )
 
 var (
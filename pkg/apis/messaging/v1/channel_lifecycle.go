@@ -17,6 +17,8 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
@@ -50,6 +52,11 @@ const (
 	// ChannelConditionEventPoliciesReady has status True when all the EventPolicies which reference this
 	// Channel are Ready too.
 	ChannelConditionEventPoliciesReady apis.ConditionType = "EventPoliciesReady"
+
+	// ChannelConditionDeletionBlocked has status True while the deletion of the
+	// Channel waits for the Subscriptions using it to be deleted. It isn't part
+	// of the Ready condition.
+	ChannelConditionDeletionBlocked apis.ConditionType = "DeletionBlocked"
 )
 
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
@@ -167,3 +174,15 @@ func (cs *ChannelStatus) MarkEventPoliciesTrue() {
 func (cs *ChannelStatus) MarkEventPoliciesTrueWithReason(reason, messageFormat string, messageA ...interface{}) {
 	chCondSet.Manage(cs).MarkTrueWithReason(ChannelConditionEventPoliciesReady, reason, messageFormat, messageA...)
 }
+
+// MarkDeletionBlocked records that the deletion of the Channel waits for the
+// Subscriptions using it to be deleted.
+func (cs *ChannelStatus) MarkDeletionBlocked(reason, messageFormat string, messageA ...interface{}) {
+	chCondSet.Manage(cs).SetCondition(apis.Condition{
+		Type:     ChannelConditionDeletionBlocked,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   reason,
+		Message:  fmt.Sprintf(messageFormat, messageA...),
+	})
+}
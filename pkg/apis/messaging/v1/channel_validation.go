@@ -22,16 +22,22 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/quota"
 )
 
 func (c *Channel) Validate(ctx context.Context) *apis.FieldError {
 	withNS := apis.WithinParent(ctx, c.ObjectMeta)
+	if original, ok := apis.GetBaseline(ctx).(*Channel); ok && original != nil && apis.IsInUpdate(ctx) {
+		withNS = eventingduckv1.WithBaselineDelivery(withNS, original.Spec.Delivery)
+	}
 	errs := c.Spec.Validate(withNS).ViaField("spec")
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*Channel)
 		errs = errs.Also(c.CheckImmutableFields(ctx, original))
 	}
-	return errs
+	return errs.Also(quota.Validate(ctx, quota.Channels, c.Namespace))
 }
 
 func (cs *ChannelSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -26,6 +26,7 @@ import (
 	"knative.dev/pkg/apis"
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/quota"
 )
 
 func TestChannelValidation(t *testing.T) {
@@ -246,3 +247,31 @@ func TestChannelImmutableFields(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelQuotaValidation(t *testing.T) {
+	c := &Channel{
+		ObjectMeta: v1.ObjectMeta{Namespace: "ns", Name: "c"},
+		Spec: ChannelSpec{
+			ChannelTemplate: &ChannelTemplateSpec{
+				TypeMeta: v1.TypeMeta{
+					Kind:       "InMemoryChannel",
+					APIVersion: SchemeGroupVersion.String(),
+				},
+			},
+		},
+	}
+	ctx := quota.ToContext(apis.WithinCreate(context.Background()), &quota.Config{
+		Default: quota.Limits{quota.Channels: 2},
+	})
+	ctx = quota.WithCounter(ctx, func(context.Context, quota.Resource, string) (int, error) {
+		return 2, nil
+	})
+
+	want := &apis.FieldError{
+		Message: `namespace "ns" already has 2 Channels, the maximum allowed is 2`,
+		Details: "the limit is set by the config-namespace-quotas ConfigMap",
+	}
+	if diff := cmp.Diff(want.Error(), c.Validate(ctx).Error()); diff != "" {
+		t.Error("Channel.Validate (-want, +got) =", diff)
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package v1
 
 import (
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/messaging"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -61,6 +64,14 @@ var (
 	_ duckv1.KRShaped = (*InMemoryChannel)(nil)
 )
 
+const (
+	// DefaultDispatchWeight is the dispatch weight of the channels without
+	// the messaging.knative.dev/dispatch-weight annotation.
+	DefaultDispatchWeight = 1
+	// MaxDispatchWeight is the highest dispatch weight a channel may have.
+	MaxDispatchWeight = 100
+)
+
 // InMemoryChannelSpec defines which subscribers have expressed interest in
 // receiving events from this InMemoryChannel.
 // arguments for a Channel.
@@ -89,3 +100,18 @@ type InMemoryChannelList struct {
 func (t *InMemoryChannel) GetStatus() *duckv1.Status {
 	return &t.Status.Status
 }
+
+// DispatchWeight returns the dispatch weight set by the
+// messaging.knative.dev/dispatch-weight annotation, DefaultDispatchWeight
+// when it isn't set or is invalid.
+func (imc *InMemoryChannel) DispatchWeight() int {
+	w, ok := imc.Annotations[messaging.DispatchWeightAnnotation]
+	if !ok {
+		return DefaultDispatchWeight
+	}
+	weight, err := strconv.Atoi(w)
+	if err != nil || weight < 1 || weight > MaxDispatchWeight {
+		return DefaultDispatchWeight
+	}
+	return weight
+}
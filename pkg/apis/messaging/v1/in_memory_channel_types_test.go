@@ -16,7 +16,13 @@ limitations under the License.
 
 package v1
 
-import "testing"
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing/pkg/apis/messaging"
+)
 
 func TestInMemoryChannelGetStatus(t *testing.T) {
 	r := &InMemoryChannel{
@@ -34,3 +40,22 @@ func TestInMemoryChannelGetGroupVersionKind(t *testing.T) {
 		t.Errorf("Should be InMemoryChannel.")
 	}
 }
+
+func TestInMemoryChannelDispatchWeight(t *testing.T) {
+	for annotation, want := range map[string]int{
+		"":    DefaultDispatchWeight,
+		"7":   7,
+		"100": 100,
+		"101": DefaultDispatchWeight,
+		"0":   DefaultDispatchWeight,
+		"abc": DefaultDispatchWeight,
+	} {
+		imc := InMemoryChannel{}
+		if annotation != "" {
+			imc.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{messaging.DispatchWeightAnnotation: annotation}}
+		}
+		if got := imc.DispatchWeight(); got != want {
+			t.Errorf("DispatchWeight() with annotation %q = %d, want %d", annotation, got, want)
+		}
+	}
+}
@@ -19,11 +19,13 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmp"
 
 	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/messaging"
 )
 
 const eventingControllerSAName = "system:serviceaccount:knative-eventing:eventing-controller"
@@ -40,6 +42,13 @@ func (imc *InMemoryChannel) Validate(ctx context.Context) *apis.FieldError {
 				errs = errs.Also(iv.ViaFieldKey("annotations", eventing.ScopeAnnotationKey).ViaField("metadata"))
 			}
 		}
+		if w, ok := imc.Annotations[messaging.DispatchWeightAnnotation]; ok {
+			if weight, err := strconv.Atoi(w); err != nil || weight < 1 || weight > MaxDispatchWeight {
+				iv := apis.ErrInvalidValue(w, "")
+				iv.Details = fmt.Sprintf("expected an integer between 1 and %d", MaxDispatchWeight)
+				errs = errs.Also(iv.ViaFieldKey("annotations", messaging.DispatchWeightAnnotation).ViaField("metadata"))
+			}
+		}
 	}
 
 	if apis.IsInUpdate(ctx) {
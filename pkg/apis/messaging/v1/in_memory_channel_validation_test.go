@@ -27,6 +27,7 @@ import (
 
 	eventingduck "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/messaging"
 )
 
 var (
@@ -133,6 +134,32 @@ func TestInMemoryChannelValidation(t *testing.T) {
 			fe.Details = "expected either 'cluster' or 'namespace'"
 			return fe
 		}(),
+	}, {
+		name: "valid dispatch weight annotation",
+		cr: &InMemoryChannel{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					messaging.DispatchWeightAnnotation: "5",
+				},
+			},
+			Spec: InMemoryChannelSpec{},
+		},
+		want: nil,
+	}, {
+		name: "invalid dispatch weight annotation",
+		cr: &InMemoryChannel{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					messaging.DispatchWeightAnnotation: "0",
+				},
+			},
+			Spec: InMemoryChannelSpec{},
+		},
+		want: func() *apis.FieldError {
+			fe := apis.ErrInvalidValue("0", "metadata.annotations.[messaging.knative.dev/dispatch-weight]")
+			fe.Details = "expected an integer between 1 and 100"
+			return fe
+		}(),
 	}, {
 		name: "invalid user for spec.subscribers update",
 		cr:   validIMCTwoSubscribers,
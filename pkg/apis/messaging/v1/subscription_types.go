@@ -101,6 +101,13 @@ type SubscriptionSpec struct {
 	// +optional
 	Reply *duckv1.Destination `json:"reply,omitempty"`
 
+	// TerminalReply specifies (optionally) where to send the events returned
+	// from the Subscriber target with the knativeterminate extension set to
+	// true, instead of the Reply. It lets the Sequence steps skip the
+	// remaining steps.
+	// +optional
+	TerminalReply *duckv1.Destination `json:"terminalReply,omitempty"`
+
 	// Delivery configuration
 	// +optional
 	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
@@ -154,6 +161,21 @@ type SubscriptionStatusPhysicalSubscription struct {
 	// +optional
 	ReplyAudience *string `json:"replyAudience,omitempty"`
 
+	// TerminalReplyURI is the fully resolved URI for the spec.terminalReply.
+	// +optional
+	TerminalReplyURI *apis.URL `json:"terminalReplyUri,omitempty"`
+
+	// TerminalReplyCACerts is the Certification Authority (CA) certificates in
+	// PEM format according to https://www.rfc-editor.org/rfc/rfc7468 for the
+	// resolved URI for the spec.terminalReply.
+	// +optional
+	TerminalReplyCACerts *string `json:"terminalReplyCACerts,omitempty"`
+
+	// TerminalReplyAudience is the OIDC audience for the the resolved URI for
+	// spec.terminalReply.
+	// +optional
+	TerminalReplyAudience *string `json:"terminalReplyAudience,omitempty"`
+
 	// DeliveryStatus contains a resolved URL to the dead letter sink address, and any other
 	// resolved delivery options.
 	eventingduckv1.DeliveryStatus `json:",inline"`
@@ -21,7 +21,9 @@ import (
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"k8s.io/apimachinery/pkg/api/equality"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/quota"
 	cn "knative.dev/eventing/pkg/crossnamespace"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -29,6 +31,9 @@ import (
 )
 
 func (s *Subscription) Validate(ctx context.Context) *apis.FieldError {
+	if original, ok := apis.GetBaseline(ctx).(*Subscription); ok && original != nil && apis.IsInUpdate(ctx) {
+		ctx = eventingduckv1.WithBaselineDelivery(ctx, original.Spec.Delivery)
+	}
 	errs := s.Spec.Validate(ctx).ViaField("spec")
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*Subscription)
@@ -41,7 +46,7 @@ func (s *Subscription) Validate(ctx context.Context) *apis.FieldError {
 			errs = errs.Also(crossNamespaceError)
 		}
 	}
-	return errs
+	return errs.Also(quota.Validate(ctx, quota.Subscriptions, s.Namespace))
 }
 
 func (ss *SubscriptionSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -74,6 +79,16 @@ func (ss *SubscriptionSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if !isDestinationNilOrEmpty(ss.TerminalReply) {
+		if feature.FromContext(ctx).IsEnabled(feature.SequenceEarlyExit) {
+			if fe := ss.TerminalReply.Validate(ctx); fe != nil {
+				errs = errs.Also(fe.ViaField("terminalReply"))
+			}
+		} else {
+			errs = errs.Also(apis.ErrDisallowedFields("terminalReply"))
+		}
+	}
+
 	if ss.Delivery != nil {
 		if fe := ss.Delivery.Validate(ctx); fe != nil {
 			errs = errs.Also(fe.ViaField("delivery"))
@@ -93,7 +108,7 @@ func (s *Subscription) CheckImmutableFields(ctx context.Context, original *Subsc
 	}
 
 	// Only Subscriber and Reply are mutable.
-	ignoreArguments := cmpopts.IgnoreFields(SubscriptionSpec{}, "Subscriber", "Reply", "Delivery")
+	ignoreArguments := cmpopts.IgnoreFields(SubscriptionSpec{}, "Subscriber", "Reply", "TerminalReply", "Delivery")
 	if diff, err := kmp.ShortDiff(original.Spec, s.Spec, ignoreArguments); err != nil {
 		return &apis.FieldError{
 			Message: "Failed to diff Subscription",
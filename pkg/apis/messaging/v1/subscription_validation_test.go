@@ -462,6 +462,60 @@ func TestSubscriptionSpecValidationWithKRefGroupFeatureEnabled(t *testing.T) {
 	}
 }
 
+func TestSubscriptionSpecValidationTerminalReply(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		c       *SubscriptionSpec
+		want    *apis.FieldError
+	}{{
+		name:    "valid terminal reply",
+		enabled: true,
+		c: &SubscriptionSpec{
+			Channel:       getValidChannelRef(),
+			Subscriber:    getValidDestination(),
+			Reply:         getValidReply(),
+			TerminalReply: getValidReply(),
+		},
+		want: nil,
+	}, {
+		name:    "invalid terminal reply",
+		enabled: true,
+		c: &SubscriptionSpec{
+			Channel:    getValidChannelRef(),
+			Subscriber: getValidDestination(),
+			TerminalReply: &duckv1.Destination{
+				Ref: &duckv1.KReference{
+					Kind:       channelKind,
+					APIVersion: channelAPIVersion,
+				},
+			},
+		},
+		want: apis.ErrMissingField("terminalReply.ref.name"),
+	}, {
+		name: "terminal reply with the feature disabled",
+		c: &SubscriptionSpec{
+			Channel:       getValidChannelRef(),
+			Subscriber:    getValidDestination(),
+			TerminalReply: getValidReply(),
+		},
+		want: apis.ErrDisallowedFields("terminalReply"),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := feature.Flags{feature.SequenceEarlyExit: feature.Disabled}
+			if test.enabled {
+				flags[feature.SequenceEarlyExit] = feature.Enabled
+			}
+			got := test.c.Validate(feature.ToContext(context.TODO(), flags))
+			if diff := cmp.Diff(test.want.Error(), got.Error()); diff != "" {
+				t.Errorf("%s: Validate (-want, +got) = %v", test.name, diff)
+			}
+		})
+	}
+}
+
 func TestSubscriptionValidationSpecWithCrossNamespaceEventLinksFeatureEnabled(t *testing.T) {
 	tests := []struct {
 		name string
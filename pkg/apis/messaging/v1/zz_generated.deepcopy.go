@@ -333,6 +333,11 @@ func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
 		*out = new(duckv1.Destination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TerminalReply != nil {
+		in, out := &in.TerminalReply, &out.TerminalReply
+		*out = new(duckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Delivery != nil {
 		in, out := &in.Delivery, &out.Delivery
 		*out = new(apisduckv1.DeliverySpec)
@@ -407,6 +412,21 @@ func (in *SubscriptionStatusPhysicalSubscription) DeepCopyInto(out *Subscription
 		*out = new(string)
 		**out = **in
 	}
+	if in.TerminalReplyURI != nil {
+		in, out := &in.TerminalReplyURI, &out.TerminalReplyURI
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminalReplyCACerts != nil {
+		in, out := &in.TerminalReplyCACerts, &out.TerminalReplyCACerts
+		*out = new(string)
+		**out = **in
+	}
+	if in.TerminalReplyAudience != nil {
+		in, out := &in.TerminalReplyAudience, &out.TerminalReplyAudience
+		*out = new(string)
+		**out = **in
+	}
 	in.DeliveryStatus.DeepCopyInto(&out.DeliveryStatus)
 	return
 }
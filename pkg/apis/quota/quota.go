@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota holds the quotas limiting the number of Brokers, Triggers,
+// Channels and Subscriptions of a namespace, which protect the data plane
+// shared by the namespaces of a cluster.
+package quota
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	cm "knative.dev/pkg/configmap"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigName is the name of the ConfigMap holding the quotas.
+	ConfigName = "config-namespace-quotas"
+
+	// NamespaceQuotasKey is the key of the quotas overriding the ones of
+	// every namespace in some namespaces.
+	NamespaceQuotasKey = "namespace-quotas"
+
+	// Unlimited means no limit on the number of objects of a namespace.
+	Unlimited = -1
+)
+
+// Resource is a resource whose objects are limited in number per namespace.
+type Resource string
+
+const (
+	Brokers       Resource = "brokers"
+	Triggers      Resource = "triggers"
+	Channels      Resource = "channels"
+	Subscriptions Resource = "subscriptions"
+)
+
+// Resources are the resources having a quota.
+var Resources = []Resource{Brokers, Triggers, Channels, Subscriptions}
+
+// Kind returns the kind of the objects of the resource, for messages.
+func (r Resource) Kind() string {
+	switch r {
+	case Brokers:
+		return "Brokers"
+	case Triggers:
+		return "Triggers"
+	case Channels:
+		return "Channels"
+	case Subscriptions:
+		return "Subscriptions"
+	}
+	return string(r)
+}
+
+// MaxPerNamespaceKey is the key of the maximum number of objects of the
+// resource allowed in every namespace, e.g. max-brokers-per-namespace.
+func (r Resource) MaxPerNamespaceKey() string {
+	return fmt.Sprintf("max-%s-per-namespace", r)
+}
+
+// Limits are the maximum numbers of objects allowed in a namespace by
+// resource. A resource missing from Limits isn't limited.
+type Limits map[Resource]int64
+
+// Config holds the quotas of the namespaces.
+type Config struct {
+	// Default are the quotas of every namespace.
+	Default Limits
+	// Namespaces are the quotas overriding Default by namespace.
+	Namespaces map[string]Limits
+}
+
+// NewConfigFromConfigMap creates a Config from the supplied ConfigMap.
+func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*Config, error) {
+	return NewConfigFromMap(configMap.Data)
+}
+
+// NewConfigFromMap creates a Config from the supplied map.
+func NewConfigFromMap(data map[string]string) (*Config, error) {
+	nc := &Config{Default: Limits{}}
+
+	opts := make([]cm.ParseFunc, 0, len(Resources)+1)
+	limits := make(map[Resource]*int64, len(Resources))
+	for _, r := range Resources {
+		limit := int64(Unlimited)
+		limits[r] = &limit
+		opts = append(opts, cm.AsInt64(r.MaxPerNamespaceKey(), &limit))
+	}
+	opts = append(opts, asNamespaceLimits(NamespaceQuotasKey, &nc.Namespaces))
+	if err := cm.Parse(data, opts...); err != nil {
+		return nil, err
+	}
+
+	for r, limit := range limits {
+		if *limit >= 0 {
+			nc.Default[r] = *limit
+		}
+	}
+	for ns, l := range nc.Namespaces {
+		for r, limit := range l {
+			if !isResource(r) {
+				return nil, fmt.Errorf("%s: unknown resource %q for namespace %q", NamespaceQuotasKey, r, ns)
+			}
+			if limit < 0 {
+				l[r] = Unlimited
+			}
+		}
+	}
+	return nc, nil
+}
+
+func asNamespaceLimits(key string, target *map[string]Limits) cm.ParseFunc {
+	return func(data map[string]string) error {
+		raw, ok := data[key]
+		if !ok || raw == "" {
+			return nil
+		}
+		var limits map[string]Limits
+		if err := yaml.Unmarshal([]byte(raw), &limits); err != nil {
+			return fmt.Errorf("failed to parse %q: %w", key, err)
+		}
+		*target = limits
+		return nil
+	}
+}
+
+func isResource(r Resource) bool {
+	for _, res := range Resources {
+		if r == res {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit returns the maximum number of objects of the resource allowed in the
+// namespace, Unlimited when there is no limit.
+func (c *Config) Limit(namespace string, r Resource) int64 {
+	if c == nil {
+		return Unlimited
+	}
+	if limit, ok := c.Namespaces[namespace][r]; ok {
+		return limit
+	}
+	if limit, ok := c.Default[r]; ok {
+		return limit
+	}
+	return Unlimited
+}
+
+// DeepCopy returns a deep copy of the Config.
+func (c *Config) DeepCopy() *Config {
+	if c == nil {
+		return nil
+	}
+	out := &Config{Default: c.Default.DeepCopy()}
+	if c.Namespaces != nil {
+		out.Namespaces = make(map[string]Limits, len(c.Namespaces))
+		for ns, l := range c.Namespaces {
+			out.Namespaces[ns] = l.DeepCopy()
+		}
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of the Limits.
+func (l Limits) DeepCopy() Limits {
+	if l == nil {
+		return nil
+	}
+	out := make(Limits, len(l))
+	for r, limit := range l {
+		out[r] = limit
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestNewConfigFromConfigMap(t *testing.T) {
+	actual, example := ConfigMapsFromTestFile(t, ConfigName)
+	for _, cm := range []string{"actual", "example"} {
+		t.Run(cm, func(t *testing.T) {
+			c := actual
+			if cm == "example" {
+				c = example
+			}
+			if _, err := NewConfigFromConfigMap(c); err != nil {
+				t.Error("NewConfigFromConfigMap() =", err)
+			}
+		})
+	}
+}
+
+func TestNewConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    *Config
+		wantErr bool
+	}{{
+		name: "empty",
+		data: map[string]string{},
+		want: &Config{Default: Limits{}},
+	}, {
+		name: "limits of every namespace",
+		data: map[string]string{
+			"max-brokers-per-namespace":       "10",
+			"max-triggers-per-namespace":      "100",
+			"max-channels-per-namespace":      "-1",
+			"max-subscriptions-per-namespace": "0",
+		},
+		want: &Config{Default: Limits{Brokers: 10, Triggers: 100, Subscriptions: 0}},
+	}, {
+		name: "namespace quotas",
+		data: map[string]string{
+			"max-brokers-per-namespace": "10",
+			"namespace-quotas": `
+team-a:
+  brokers: 20
+team-b:
+  brokers: -5
+  triggers: 1
+`,
+		},
+		want: &Config{
+			Default: Limits{Brokers: 10},
+			Namespaces: map[string]Limits{
+				"team-a": {Brokers: 20},
+				"team-b": {Brokers: Unlimited, Triggers: 1},
+			},
+		},
+	}, {
+		name:    "invalid limit",
+		data:    map[string]string{"max-brokers-per-namespace": "ten"},
+		wantErr: true,
+	}, {
+		name:    "invalid namespace quotas",
+		data:    map[string]string{"namespace-quotas": "team-a: 10"},
+		wantErr: true,
+	}, {
+		name:    "unknown resource",
+		data:    map[string]string{"namespace-quotas": "team-a:\n  pingsources: 10"},
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewConfigFromMap(tc.data)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewConfigFromMap() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected config (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestConfigLimit(t *testing.T) {
+	c := &Config{
+		Default: Limits{Brokers: 10, Triggers: 100},
+		Namespaces: map[string]Limits{
+			"team-a": {Brokers: 20},
+			"team-b": {Triggers: Unlimited},
+		},
+	}
+
+	tests := []struct {
+		namespace string
+		resource  Resource
+		want      int64
+	}{
+		{namespace: "default", resource: Brokers, want: 10},
+		{namespace: "default", resource: Channels, want: Unlimited},
+		{namespace: "team-a", resource: Brokers, want: 20},
+		{namespace: "team-a", resource: Triggers, want: 100},
+		{namespace: "team-b", resource: Triggers, want: Unlimited},
+	}
+	for _, tc := range tests {
+		if got := c.Limit(tc.namespace, tc.resource); got != tc.want {
+			t.Errorf("Limit(%q, %q) = %d, want %d", tc.namespace, tc.resource, got, tc.want)
+		}
+	}
+
+	var nilConfig *Config
+	if got := nilConfig.Limit("default", Brokers); got != Unlimited {
+		t.Errorf("Limit() of a nil config = %d, want %d", got, Unlimited)
+	}
+}
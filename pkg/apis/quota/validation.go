@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+type counterKey struct{}
+
+// Counter returns the number of objects of a resource in a namespace.
+type Counter func(ctx context.Context, r Resource, namespace string) (int, error)
+
+// WithCounter attaches the counter used to enforce the quotas to the provided
+// context.
+func WithCounter(ctx context.Context, counter Counter) context.Context {
+	return context.WithValue(ctx, counterKey{}, counter)
+}
+
+// CounterFromContext extracts the Counter from the provided context, nil when
+// none is attached.
+func CounterFromContext(ctx context.Context) Counter {
+	if c, ok := ctx.Value(counterKey{}).(Counter); ok {
+		return c
+	}
+	return nil
+}
+
+// Validate rejects the creation of an object of the resource in a namespace
+// already holding the maximum number of objects allowed by its quota. It is a
+// no-op outside of creates, when the resource isn't limited in the namespace
+// or when no Counter is attached to the context.
+func Validate(ctx context.Context, r Resource, namespace string) *apis.FieldError {
+	if !apis.IsInCreate(ctx) {
+		return nil
+	}
+	limit := FromContextOrDefaults(ctx).Limit(namespace, r)
+	if limit < 0 {
+		return nil
+	}
+	counter := CounterFromContext(ctx)
+	if counter == nil {
+		return nil
+	}
+
+	count, err := counter(ctx, r, namespace)
+	if err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("failed to count the %s in namespace %q", r.Kind(), namespace),
+			Details: err.Error(),
+		}
+	}
+	if int64(count) >= limit {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("namespace %q already has %d %s, the maximum allowed is %d", namespace, count, r.Kind(), limit),
+			Details: fmt.Sprintf("the limit is set by the %s ConfigMap", ConfigName),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"knative.dev/pkg/apis"
+)
+
+func TestValidate(t *testing.T) {
+	counter := func(count int, err error) Counter {
+		return func(context.Context, Resource, string) (int, error) {
+			return count, err
+		}
+	}
+	withMax := func(ctx context.Context, limit int64) context.Context {
+		return ToContext(ctx, &Config{Default: Limits{Brokers: limit}})
+	}
+	create := apis.WithinCreate(context.Background())
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr string
+	}{{
+		name: "no limit",
+		ctx:  WithCounter(create, counter(100, nil)),
+	}, {
+		name: "no counter",
+		ctx:  withMax(create, 1),
+	}, {
+		name: "under the limit",
+		ctx:  WithCounter(withMax(create, 2), counter(1, nil)),
+	}, {
+		name: "update over the limit",
+		ctx:  WithCounter(withMax(apis.WithinUpdate(context.Background(), nil), 2), counter(3, nil)),
+	}, {
+		name:    "at the limit",
+		ctx:     WithCounter(withMax(create, 2), counter(2, nil)),
+		wantErr: `namespace "ns" already has 2 Brokers, the maximum allowed is 2: ` + "\n" + `the limit is set by the config-namespace-quotas ConfigMap`,
+	}, {
+		name:    "zero disallows Brokers",
+		ctx:     WithCounter(withMax(create, 0), counter(0, nil)),
+		wantErr: `namespace "ns" already has 0 Brokers, the maximum allowed is 0: ` + "\n" + `the limit is set by the config-namespace-quotas ConfigMap`,
+	}, {
+		name: "namespace override",
+		ctx: WithCounter(ToContext(create, &Config{
+			Default:    Limits{Brokers: 1},
+			Namespaces: map[string]Limits{"ns": {Brokers: Unlimited}},
+		}), counter(5, nil)),
+	}, {
+		name:    "counter failure",
+		ctx:     WithCounter(withMax(create, 2), counter(0, errors.New("boom"))),
+		wantErr: `failed to count the Brokers in namespace "ns": ` + "\n" + `boom`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.ctx, Brokers, "ns")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Error("Validate() =", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Validate() = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -55,6 +55,32 @@ type JobSinkSpec struct {
 	// Job to run when an event occur.
 	// +optional
 	Job *batchv1.Job `json:"job,omitempty"`
+
+	// Env sets environment variables of the containers of the Job from the
+	// event that triggered it. Every value is also written to a file named
+	// after the variable, next to the serialized event in the directory
+	// K_EVENT_PATH points to.
+	// +optional
+	Env []JobSinkEnvVar `json:"env,omitempty"`
+}
+
+// JobSinkEnvVar is an environment variable whose value is taken from the
+// event. Exactly one of Attribute and DataPath must be set. The variable is
+// unset when the event doesn't have the value.
+type JobSinkEnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Attribute is the name of the CloudEvent attribute or extension the
+	// value is taken from, for example "id" or "type".
+	// +optional
+	Attribute string `json:"attribute,omitempty"`
+
+	// DataPath is a JSONPath expression selecting the value in the JSON data
+	// of the event, for example "$.user.name". Values that aren't strings are
+	// JSON encoded, as are the values selected by a wildcard.
+	// +optional
+	DataPath string `json:"dataPath,omitempty"`
 }
 
 // JobSinkStatus defines the observed state of JobSink.
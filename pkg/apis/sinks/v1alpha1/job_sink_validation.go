@@ -18,14 +18,25 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apiserver/pkg/storage/names"
 	"knative.dev/pkg/apis"
 
 	"knative.dev/eventing/pkg/apis/sinks"
+	"knative.dev/eventing/pkg/redaction"
 )
 
+// reservedEnvNames are set by the JobSink itself.
+var reservedEnvNames = map[string]bool{
+	// The serialized event is stored with this key next to the values of
+	// the env vars.
+	"event":        true,
+	"K_EVENT_PATH": true,
+}
+
 func (sink *JobSink) Validate(ctx context.Context) *apis.FieldError {
 	ctx = apis.WithinParent(ctx, sink.ObjectMeta)
 	return sink.Spec.Validate(ctx).ViaField("spec")
@@ -38,7 +49,16 @@ func (sink *JobSinkSpec) Validate(ctx context.Context) *apis.FieldError {
 		return errs.Also(apis.ErrMissingOneOf("job"))
 	}
 
-	if sink.Job != nil {
+	envNames := make(map[string]bool, len(sink.Env))
+	for i, e := range sink.Env {
+		errs = errs.Also(e.Validate(ctx).ViaFieldIndex("env", i))
+		if envNames[e.Name] {
+			errs = errs.Also(apis.ErrGeneric("duplicate env var name", "name").ViaFieldIndex("env", i))
+		}
+		envNames[e.Name] = true
+	}
+
+	if sink.Job != nil && errs == nil {
 		job := sink.Job.DeepCopy()
 		job.Name = names.SimpleNameGenerator.GenerateName(apis.ParentMeta(ctx).Name)
 		_, err := sinks.GetConfig(ctx).KubeClient.
@@ -55,3 +75,28 @@ func (sink *JobSinkSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	return errs
 }
+
+func (e *JobSinkEnvVar) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if e.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if msgs := validation.IsEnvVarName(e.Name); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(e.Name, "name", strings.Join(msgs, ", ")))
+	} else if reservedEnvNames[e.Name] {
+		errs = errs.Also(apis.ErrInvalidValue(e.Name, "name", "the name is reserved"))
+	}
+
+	switch {
+	case e.Attribute == "" && e.DataPath == "":
+		errs = errs.Also(apis.ErrMissingOneOf("attribute", "dataPath"))
+	case e.Attribute != "" && e.DataPath != "":
+		errs = errs.Also(apis.ErrMultipleOneOf("attribute", "dataPath"))
+	case e.DataPath != "":
+		if _, err := redaction.ParsePath(e.DataPath); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(e.DataPath, "dataPath", err.Error()))
+		}
+	}
+
+	return errs
+}
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -45,3 +46,64 @@ func TestValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestJobSinkEnvVarValidation(t *testing.T) {
+	tests := map[string]struct {
+		env  JobSinkEnvVar
+		want *apis.FieldError
+	}{
+		"attribute": {
+			env: JobSinkEnvVar{Name: "EVENT_ID", Attribute: "id"},
+		},
+		"data path": {
+			env: JobSinkEnvVar{Name: "USER", DataPath: "$.user.name"},
+		},
+		"missing name": {
+			env:  JobSinkEnvVar{Attribute: "id"},
+			want: apis.ErrMissingField("name"),
+		},
+		"invalid name": {
+			env:  JobSinkEnvVar{Name: "1ID", Attribute: "id"},
+			want: apis.ErrInvalidValue("1ID", "name", `a valid environment variable name must consist of alphabetic characters, digits, '_', '-', or '.', and must not start with a digit (e.g. 'my.env-name',  or 'MY_ENV.NAME',  or 'MyEnvName1', regex used for validation is '[-._a-zA-Z][-._a-zA-Z0-9]*')`),
+		},
+		"reserved name": {
+			env:  JobSinkEnvVar{Name: "event", Attribute: "id"},
+			want: apis.ErrInvalidValue("event", "name", "the name is reserved"),
+		},
+		"no source": {
+			env:  JobSinkEnvVar{Name: "ID"},
+			want: apis.ErrMissingOneOf("attribute", "dataPath"),
+		},
+		"both sources": {
+			env:  JobSinkEnvVar{Name: "ID", Attribute: "id", DataPath: "$.id"},
+			want: apis.ErrMultipleOneOf("attribute", "dataPath"),
+		},
+		"invalid data path": {
+			env:  JobSinkEnvVar{Name: "ID", DataPath: "id"},
+			want: apis.ErrInvalidValue("id", "dataPath", `path "id" must start with $`),
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got := tc.env.Validate(context.Background())
+			if diff := cmp.Diff(tc.want.Error(), got.Error()); diff != "" {
+				t.Error("JobSinkEnvVar.Validate (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestJobSinkSpecDuplicateEnv(t *testing.T) {
+	spec := JobSinkSpec{
+		Job: &batchv1.Job{},
+		Env: []JobSinkEnvVar{
+			{Name: "ID", Attribute: "id"},
+			{Name: "ID", DataPath: "$.id"},
+		},
+	}
+	want := apis.ErrGeneric("duplicate env var name", "env[1].name")
+	if diff := cmp.Diff(want.Error(), spec.Validate(context.Background()).Error()); diff != "" {
+		t.Error("JobSinkSpec.Validate (-want, +got) =", diff)
+	}
+}
@@ -54,6 +54,22 @@ func (in *JobSink) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSinkEnvVar) DeepCopyInto(out *JobSinkEnvVar) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSinkEnvVar.
+func (in *JobSinkEnvVar) DeepCopy() *JobSinkEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSinkEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobSinkList) DeepCopyInto(out *JobSinkList) {
 	*out = *in
@@ -95,6 +111,11 @@ func (in *JobSinkSpec) DeepCopyInto(out *JobSinkSpec) {
 		*out = new(v1.Job)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]JobSinkEnvVar, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -34,17 +34,29 @@ const (
 	LegacyDataMaxSizeKey = "dataMaxSize"
 
 	DefaultDataMaxSize = -1
+
+	// MaxPerNamespaceKey is the key of the maximum number of PingSources
+	// allowed in a single namespace.
+	MaxPerNamespaceKey = "max-per-namespace"
+
+	// DefaultMaxPerNamespace means no limit on the number of PingSources
+	// in a namespace.
+	DefaultMaxPerNamespace = -1
 )
 
 // NewPingDefaultsConfigFromMap creates a Defaults from the supplied Map
 func NewPingDefaultsConfigFromMap(data map[string]string) (*PingDefaults, error) {
-	nc := &PingDefaults{DataMaxSize: DefaultDataMaxSize}
+	nc := &PingDefaults{
+		DataMaxSize:     DefaultDataMaxSize,
+		MaxPerNamespace: DefaultMaxPerNamespace,
+	}
 
 	if err := cm.Parse(data,
 		// Legacy for backwards compatibility
 		cm.AsInt64(LegacyDataMaxSizeKey, &nc.DataMaxSize),
 
 		cm.AsInt64(DataMaxSizeKey, &nc.DataMaxSize),
+		cm.AsInt64(MaxPerNamespaceKey, &nc.MaxPerNamespace),
 	); err != nil {
 		return nil, err
 	}
@@ -60,12 +72,19 @@ func NewPingDefaultsConfigFromConfigMap(config *corev1.ConfigMap) (*PingDefaults
 // PingDefaults includes the default values to be populated by the webhook.
 type PingDefaults struct {
 	DataMaxSize int64 `json:"data-max-size"`
+
+	// MaxPerNamespace is the maximum number of PingSources a namespace may
+	// hold, a negative value means no limit.
+	MaxPerNamespace int64 `json:"max-per-namespace"`
 }
 
 func (d *PingDefaults) GetPingConfig() *PingDefaults {
 	if d.DataMaxSize < 0 {
 		d.DataMaxSize = DefaultDataMaxSize
 	}
+	if d.MaxPerNamespace < 0 {
+		d.MaxPerNamespace = DefaultMaxPerNamespace
+	}
 	return d
 
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+)
+
+type pingSourceCounterKey struct{}
+
+// PingSourceCounter returns the number of PingSources in a namespace.
+type PingSourceCounter func(ctx context.Context, namespace string) (int, error)
+
+// WithPingSourceCounter attaches the counter used to enforce the
+// max-per-namespace quota to the provided context.
+func WithPingSourceCounter(ctx context.Context, counter PingSourceCounter) context.Context {
+	return context.WithValue(ctx, pingSourceCounterKey{}, counter)
+}
+
+// PingSourceCounterFromContext extracts the PingSourceCounter from the
+// provided context, nil when none is attached.
+func PingSourceCounterFromContext(ctx context.Context) PingSourceCounter {
+	if c, ok := ctx.Value(pingSourceCounterKey{}).(PingSourceCounter); ok {
+		return c
+	}
+	return nil
+}
+
+// ValidatePingSourceQuota rejects the creation of a PingSource in a namespace
+// already holding the maximum number of PingSources allowed by the
+// max-per-namespace setting. It is a no-op outside of creates, when no limit
+// is set or when no PingSourceCounter is attached to the context.
+func ValidatePingSourceQuota(ctx context.Context, namespace string) *apis.FieldError {
+	if !apis.IsInCreate(ctx) {
+		return nil
+	}
+	limit := FromContextOrDefaults(ctx).PingDefaults.GetPingConfig().MaxPerNamespace
+	if limit < 0 {
+		return nil
+	}
+	counter := PingSourceCounterFromContext(ctx)
+	if counter == nil {
+		return nil
+	}
+
+	count, err := counter(ctx, namespace)
+	if err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("failed to count the PingSources in namespace %q", namespace),
+			Details: err.Error(),
+		}
+	}
+	if int64(count) >= limit {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("namespace %q already has %d PingSources, the maximum allowed is %d", namespace, count, limit),
+			Details: fmt.Sprintf("the limit is set by %q in the %s ConfigMap", MaxPerNamespaceKey, PingDefaultsConfigName),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"knative.dev/pkg/apis"
+)
+
+func TestValidatePingSourceQuota(t *testing.T) {
+	counter := func(count int, err error) PingSourceCounter {
+		return func(context.Context, string) (int, error) {
+			return count, err
+		}
+	}
+	withMax := func(ctx context.Context, limit int64) context.Context {
+		return ToContext(ctx, &Config{PingDefaults: &PingDefaults{DataMaxSize: DefaultDataMaxSize, MaxPerNamespace: limit}})
+	}
+	create := apis.WithinCreate(context.Background())
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr string
+	}{{
+		name: "no limit",
+		ctx:  WithPingSourceCounter(create, counter(100, nil)),
+	}, {
+		name: "no counter",
+		ctx:  withMax(create, 1),
+	}, {
+		name: "under the limit",
+		ctx:  WithPingSourceCounter(withMax(create, 2), counter(1, nil)),
+	}, {
+		name: "update over the limit",
+		ctx:  WithPingSourceCounter(withMax(apis.WithinUpdate(context.Background(), nil), 2), counter(3, nil)),
+	}, {
+		name:    "at the limit",
+		ctx:     WithPingSourceCounter(withMax(create, 2), counter(2, nil)),
+		wantErr: `namespace "ns" already has 2 PingSources, the maximum allowed is 2: ` + "\n" + `the limit is set by "max-per-namespace" in the config-ping-defaults ConfigMap`,
+	}, {
+		name:    "zero disallows PingSources",
+		ctx:     WithPingSourceCounter(withMax(create, 0), counter(0, nil)),
+		wantErr: `namespace "ns" already has 0 PingSources, the maximum allowed is 0: ` + "\n" + `the limit is set by "max-per-namespace" in the config-ping-defaults ConfigMap`,
+	}, {
+		name:    "counter failure",
+		ctx:     WithPingSourceCounter(withMax(create, 2), counter(0, errors.New("boom"))),
+		wantErr: `failed to count the PingSources in namespace "ns": ` + "\n" + `boom`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePingSourceQuota(tc.ctx, "ns")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatal("ValidatePingSourceQuota() =", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.wantErr {
+				t.Fatalf("ValidatePingSourceQuota() = %v, want %s", err, tc.wantErr)
+			}
+		})
+	}
+}
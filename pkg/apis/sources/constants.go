@@ -23,6 +23,8 @@ const (
 	ApiServerSourceUpdateEventType = "dev.knative.apiserver.resource.update"
 	// ApiServerSourceDeleteEventType is the ApiServerSource CloudEvent type for deletions.
 	ApiServerSourceDeleteEventType = "dev.knative.apiserver.resource.delete"
+	// ApiServerSourcePatchEventType is the ApiServerSource CloudEvent type for updates carrying a JSON patch.
+	ApiServerSourcePatchEventType = "dev.knative.apiserver.resource.patch"
 
 	// ApiServerSourceAddRefEventType is the ApiServerSource CloudEvent type for ref adds.
 	ApiServerSourceAddRefEventType = "dev.knative.apiserver.ref.add"
@@ -30,6 +32,15 @@ const (
 	ApiServerSourceUpdateRefEventType = "dev.knative.apiserver.ref.update"
 	// ApiServerSourceDeleteRefEventType is the ApiServerSource CloudEvent type for ref deletions.
 	ApiServerSourceDeleteRefEventType = "dev.knative.apiserver.ref.delete"
+
+	// ApiServerSourceStartedEventType is the ApiServerSource CloudEvent type for the receive adapter starting.
+	ApiServerSourceStartedEventType = "dev.knative.apiserver.source.started"
+	// ApiServerSourceWatchEstablishedEventType is the ApiServerSource CloudEvent type for a watch being established.
+	ApiServerSourceWatchEstablishedEventType = "dev.knative.apiserver.source.watch.established"
+	// ApiServerSourceWatchDroppedEventType is the ApiServerSource CloudEvent type for a watch being dropped.
+	ApiServerSourceWatchDroppedEventType = "dev.knative.apiserver.source.watch.dropped"
+	// ApiServerSourceRBACDeniedEventType is the ApiServerSource CloudEvent type for a watch denied by RBAC.
+	ApiServerSourceRBACDeniedEventType = "dev.knative.apiserver.source.rbac.denied"
 )
 
 // ApiServerSourceEventReferenceModeTypes is the list of CloudEvent types the ApiServerSource with EventMode of ReferenceMode emits.
@@ -45,3 +56,20 @@ var ApiServerSourceEventResourceModeTypes = []string{
 	ApiServerSourceDeleteEventType,
 	ApiServerSourceUpdateEventType,
 }
+
+// ApiServerSourceEventResourceDiffModeTypes is the list of CloudEvent types the ApiServerSource with EventMode of ResourceDiffMode emits.
+var ApiServerSourceEventResourceDiffModeTypes = []string{
+	ApiServerSourceAddEventType,
+	ApiServerSourceDeleteEventType,
+	ApiServerSourceUpdateEventType,
+	ApiServerSourcePatchEventType,
+}
+
+// ApiServerSourceMonitoringEventTypes is the list of CloudEvent types the ApiServerSource with monitoring enabled emits
+// about itself.
+var ApiServerSourceMonitoringEventTypes = []string{
+	ApiServerSourceStartedEventType,
+	ApiServerSourceWatchEstablishedEventType,
+	ApiServerSourceWatchDroppedEventType,
+	ApiServerSourceRBACDeniedEventType,
+}
@@ -32,6 +32,42 @@ const (
 	// SourceDuckLabelValue is the label value to indicate
 	// the CRD is a Source duck type.
 	SourceDuckLabelValue = "true"
+
+	// SinkContentEncodingAnnotationKey is the annotation key on a source to
+	// set the content encoding used to compress the requests to its sink.
+	// Valid values: "gzip" or "identity"
+	SinkContentEncodingAnnotationKey = GroupName + "/sink-content-encoding"
+
+	// ApiServerSourceDataEncodingAnnotationKey is the annotation key on an
+	// ApiServerSource to set the encoding of the Kubernetes objects sent as
+	// data of its Resource mode events.
+	// Valid values: "json" or "protobuf"
+	ApiServerSourceDataEncodingAnnotationKey = GroupName + "/apiserversource-data-encoding"
+
+	// ApiServerSourceReplicasAnnotationKey is the annotation key on an
+	// ApiServerSource to set the number of replicas of its receive adapter.
+	// Only the replica elected as leader sends events, the others stand by.
+	// Valid values: positive integers, defaults to 1
+	ApiServerSourceReplicasAnnotationKey = GroupName + "/apiserversource-replicas"
+
+	// ApiServerSourceQueuePrioritiesAnnotationKey is the annotation key on an
+	// ApiServerSource to order the events its receive adapter queues while
+	// the sink is slow, by the operation on their resource.
+	// Valid values: a comma separated list of the "Add", "Update" and
+	// "Delete" operations, highest priority first, the operations left out
+	// come last. Defaults to "Delete,Add,Update"
+	ApiServerSourceQueuePrioritiesAnnotationKey = GroupName + "/apiserversource-queue-priorities"
+
+	// TraceContextInjectionAnnotationKey is the annotation key on a source to
+	// start a new trace for the events it sends without the traceparent
+	// extension, and set the extension to it.
+	// Valid values: "enabled" or "disabled"
+	TraceContextInjectionAnnotationKey = GroupName + "/trace-context-injection"
+
+	// TraceContextInjectionEnabled and TraceContextInjectionDisabled are the
+	// values of the TraceContextInjectionAnnotationKey annotation.
+	TraceContextInjectionEnabled  = "enabled"
+	TraceContextInjectionDisabled = "disabled"
 )
 
 var (
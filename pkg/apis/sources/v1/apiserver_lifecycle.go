@@ -22,6 +22,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+
 	"knative.dev/pkg/apis"
 )
 
@@ -40,6 +42,10 @@ const (
 
 	// ApiServerConditionOIDCIdentityCreated has status True when the ApiServerSource has created an OIDC identity.
 	ApiServerConditionOIDCIdentityCreated apis.ConditionType = "OIDCIdentityCreated"
+
+	// ApiServerConditionSinkPropagated has status True when the receive adapter of the ApiServerSource
+	// has rolled out the resolved sink URI, CA certificates and audience. It doesn't affect readiness.
+	ApiServerConditionSinkPropagated apis.ConditionType = "SinkPropagated"
 )
 
 var apiserverCondSet = apis.NewLivingConditionSet(
@@ -96,8 +102,25 @@ func (s *ApiServerSourceStatus) MarkNoSink(reason, messageFormat string, message
 	apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkProvided, reason, messageFormat, messageA...)
 }
 
+// MarkDeadLetterSink sets the resolved dead letter sink of the source, or
+// clears it when addr is nil.
+func (s *ApiServerSourceStatus) MarkDeadLetterSink(addr *duckv1.Addressable) {
+	if addr == nil {
+		s.DeliveryStatus = eventingduckv1.DeliveryStatus{}
+		return
+	}
+	s.DeliveryStatus = eventingduckv1.NewDeliveryStatusFromAddressable(addr)
+}
+
+// MarkNoDeadLetterSink sets the condition that the dead letter sink of the
+// source couldn't be resolved.
+func (s *ApiServerSourceStatus) MarkNoDeadLetterSink(reason, messageFormat string, messageA ...interface{}) {
+	s.DeliveryStatus = eventingduckv1.DeliveryStatus{}
+	apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkProvided, reason, messageFormat, messageA...)
+}
+
 // PropagateDeploymentAvailability uses the availability of the provided Deployment to determine if
-// ApiServerConditionDeployed should be marked as true or false.
+// ApiServerConditionDeployed should be marked as true or false, and reports its ready replicas.
 func (s *ApiServerSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deployment) {
 	deploymentAvailableFound := false
 	for _, cond := range d.Status.Conditions {
@@ -115,6 +138,25 @@ func (s *ApiServerSourceStatus) PropagateDeploymentAvailability(d *appsv1.Deploy
 	if !deploymentAvailableFound {
 		apiserverCondSet.Manage(s).MarkUnknown(ApiServerConditionDeployed, "DeploymentUnavailable", "The Deployment '%s' is unavailable.", d.Name)
 	}
+	s.ReadyReplicas = d.Status.ReadyReplicas
+}
+
+// PropagateSinkToDeployment uses the environment and the rollout of the provided receive adapter Deployment
+// to determine if ApiServerConditionSinkPropagated should be marked as true, false or unknown.
+func (s *ApiServerSourceStatus) PropagateSinkToDeployment(d *appsv1.Deployment) {
+	if len(d.Spec.Template.Spec.Containers) == 0 {
+		apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkPropagated, "SinkNotPropagated", "The Deployment '%s' has no container.", d.Name)
+		return
+	}
+	if err := VerifySinkEnv(d.Spec.Template.Spec.Containers[0].Env, &s.SourceStatus); err != nil {
+		apiserverCondSet.Manage(s).MarkFalse(ApiServerConditionSinkPropagated, "SinkNotPropagated", "The Deployment '%s' doesn't use the resolved sink: %v", d.Name, err)
+		return
+	}
+	if d.Status.ObservedGeneration < d.Generation || d.Status.UpdatedReplicas < d.Status.Replicas {
+		apiserverCondSet.Manage(s).MarkUnknown(ApiServerConditionSinkPropagated, "RollingOut", "The Deployment '%s' is rolling out the resolved sink.", d.Name)
+		return
+	}
+	apiserverCondSet.Manage(s).MarkTrue(ApiServerConditionSinkPropagated)
 }
 
 // MarkSufficientPermissions sets the condition that the source has enough permissions to access the resources.
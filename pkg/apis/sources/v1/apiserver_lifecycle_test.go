@@ -23,6 +23,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
+	"knative.dev/eventing/pkg/apis/sources"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "k8s.io/api/apps/v1"
@@ -422,3 +424,113 @@ func TestApiServerSourceGetters(t *testing.T) {
 		t.Errorf("GetUntypedSpec() = %v, want: %v", got, want)
 	}
 }
+
+func TestApiServerSourceStatusPropagateSinkToDeployment(t *testing.T) {
+	sink := apis.HTTPS("sink.example.com")
+	caCerts := "ca-certs"
+	audience := "audience"
+
+	deployment := func(env []corev1.EnvVar, generation, observedGeneration int64, replicas, updatedReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "adapter", Generation: generation},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Env: env}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				Replicas:           replicas,
+				UpdatedReplicas:    updatedReplicas,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		deployment *appsv1.Deployment
+		want       corev1.ConditionStatus
+	}{
+		"propagated": {
+			deployment: deployment(SinkEnvVars(sink.String(), &caCerts, &audience), 2, 2, 1, 1),
+			want:       corev1.ConditionTrue,
+		},
+		"rotated CA certs not propagated": {
+			deployment: deployment(SinkEnvVars(sink.String(), nil, &audience), 2, 2, 1, 1),
+			want:       corev1.ConditionFalse,
+		},
+		"rotated audience not propagated": {
+			deployment: deployment(SinkEnvVars(sink.String(), &caCerts, nil), 2, 2, 1, 1),
+			want:       corev1.ConditionFalse,
+		},
+		"no container": {
+			deployment: &appsv1.Deployment{},
+			want:       corev1.ConditionFalse,
+		},
+		"new generation not observed": {
+			deployment: deployment(SinkEnvVars(sink.String(), &caCerts, &audience), 3, 2, 1, 1),
+			want:       corev1.ConditionUnknown,
+		},
+		"pods rolling out": {
+			deployment: deployment(SinkEnvVars(sink.String(), &caCerts, &audience), 2, 2, 2, 1),
+			want:       corev1.ConditionUnknown,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			s := &ApiServerSourceStatus{}
+			s.InitializeConditions()
+			s.MarkSink(&duckv1.Addressable{URL: sink, CACerts: &caCerts, Audience: &audience})
+			s.PropagateSinkToDeployment(tc.deployment)
+
+			got := s.GetCondition(ApiServerConditionSinkPropagated)
+			if got == nil || got.Status != tc.want {
+				t.Errorf("SinkPropagated condition = %v, want status %s", got, tc.want)
+			}
+			if got != nil && got.Severity != apis.ConditionSeverityInfo {
+				t.Errorf("SinkPropagated severity = %q, want %q", got.Severity, apis.ConditionSeverityInfo)
+			}
+		})
+	}
+}
+
+func TestApiServerSourceStatusPropagateDeploymentReplicas(t *testing.T) {
+	d := availableDeployment.DeepCopy()
+	d.Status.ReadyReplicas = 2
+
+	s := &ApiServerSourceStatus{}
+	s.InitializeConditions()
+	s.PropagateDeploymentAvailability(d)
+
+	if s.ReadyReplicas != 2 {
+		t.Errorf("ReadyReplicas = %d, want 2", s.ReadyReplicas)
+	}
+}
+
+func TestApiServerSourceReplicas(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		want        int32
+	}{
+		"default": {
+			want: 1,
+		},
+		"annotation": {
+			annotations: map[string]string{sources.ApiServerSourceReplicasAnnotationKey: "3"},
+			want:        3,
+		},
+		"invalid annotation": {
+			annotations: map[string]string{sources.ApiServerSourceReplicasAnnotationKey: "-1"},
+			want:        1,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			s := &ApiServerSource{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			if got := s.Replicas(); got != tc.want {
+				t.Errorf("Replicas() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package v1
 
 import (
+	"strconv"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/sources"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
@@ -73,6 +77,8 @@ type ApiServerSourceSpec struct {
 	// EventMode controls the format of the event.
 	// `Reference` sends a dataref event type for the resource under watch.
 	// `Resource` send the full resource lifecycle event.
+	// `ResourceDiff` sends the full resource on add and delete, and a JSON
+	// patch from the previous resource on update.
 	// Defaults to `Reference`
 	// +optional
 	EventMode string `json:"mode,omitempty"`
@@ -96,6 +102,26 @@ type ApiServerSourceSpec struct {
 	//
 	// +optional
 	Filters []eventingv1.SubscriptionsAPIFilter `json:"filters,omitempty"`
+
+	// Delivery contains the retry and dead letter sink configuration of the
+	// events sent to the sink. The events that still can't be sent after the
+	// retries are sent to the dead letter sink.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Monitoring enables the events about the source itself: the receive
+	// adapter starting, and the watches of the resources being established,
+	// dropped or denied by RBAC.
+	// +optional
+	Monitoring *ApiServerSourceMonitoring `json:"monitoring,omitempty"`
+}
+
+// ApiServerSourceMonitoring configures the events about an ApiServerSource.
+type ApiServerSourceMonitoring struct {
+	// Sink is the destination of the events about the source. They are sent
+	// to the sink of the source when not set.
+	// +optional
+	Sink *duckv1.Destination `json:"sink,omitempty"`
 }
 
 // ApiServerSourceStatus defines the observed state of ApiServerSource
@@ -109,8 +135,17 @@ type ApiServerSourceStatus struct {
 	//   Source.
 	duckv1.SourceStatus `json:",inline"`
 
+	// DeliveryStatus contains the resolved dead letter sink of the
+	// ApiServerSource, if any.
+	eventingduckv1.DeliveryStatus `json:",inline"`
+
 	// Namespaces show the namespaces currently watched by the ApiServerSource
 	Namespaces []string `json:"namespaces"`
+
+	// ReadyReplicas is the number of ready replicas of the receive adapter.
+	// Only the replica elected as leader sends events, the others stand by.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
 }
 
 // APIVersionKind is an APIVersion and Kind tuple.
@@ -123,7 +158,8 @@ type APIVersionKind struct {
 	Kind string `json:"kind"`
 }
 
-// APIVersionKindSelector is an APIVersion Kind tuple with a LabelSelector.
+// APIVersionKindSelector is an APIVersion Kind tuple with a LabelSelector
+// and a FieldSelector.
 type APIVersionKindSelector struct {
 	// APIVersion - the API version of the resource to watch.
 	APIVersion string `json:"apiVersion"`
@@ -137,6 +173,21 @@ type APIVersionKindSelector struct {
 	// More info: http://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
 	// +optional
 	LabelSelector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// FieldSelector filters this source to the objects matching the field
+	// selector, as in `status.phase=Running`. Only the fields supported by
+	// the API server for the resource can be used.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/field-selectors/
+	// +optional
+	FieldSelector string `json:"fieldSelector,omitempty"`
+
+	// CELFilter is a CEL expression evaluated against the previous and the
+	// current state of the objects, `old` and `new`, so that the events are
+	// only sent when it evaluates to true, as in
+	// `old.status.phase != new.status.phase`. `old` is null when an object
+	// is added, and `new` is null when it is deleted.
+	// +optional
+	CELFilter string `json:"celFilter,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -148,6 +199,17 @@ type ApiServerSourceList struct {
 	Items           []ApiServerSource `json:"items"`
 }
 
+// Replicas returns the number of replicas of the receive adapter set with
+// the sources.ApiServerSourceReplicasAnnotationKey annotation, 1 by default.
+func (a *ApiServerSource) Replicas() int32 {
+	if replicas, ok := a.Annotations[sources.ApiServerSourceReplicasAnnotationKey]; ok {
+		if r, err := strconv.ParseInt(replicas, 10, 32); err == nil && r > 0 {
+			return int32(r)
+		}
+	}
+	return 1
+}
+
 // GetStatus retrieves the status of the ApiServerSource . Implements the KRShaped interface.
 func (a *ApiServerSource) GetStatus() *duckv1.Status {
 	return &a.Status.Status
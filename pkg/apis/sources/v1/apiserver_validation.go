@@ -18,12 +18,19 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/sources"
+	"knative.dev/eventing/pkg/ceoverrides"
+	"knative.dev/eventing/pkg/objectfilter"
 	"knative.dev/pkg/apis"
 )
 
@@ -32,10 +39,111 @@ const (
 	ReferenceMode = "Reference"
 	// ResourceMode produces payloads of ResourceEvent
 	ResourceMode = "Resource"
+	// ResourceDiffMode produces the payloads of ResourceMode, except for the
+	// updates whose payloads are JSON patches from the previous resource, when
+	// the adapter has seen it
+	ResourceDiffMode = "ResourceDiff"
+
+	// JSONDataEncoding sends the resources of Resource mode events as JSON
+	JSONDataEncoding = "json"
+	// ProtobufDataEncoding sends the resources of Resource mode events in
+	// their Kubernetes protobuf encoding, when they have one
+	ProtobufDataEncoding = "protobuf"
+
+	// AddOperation, UpdateOperation and DeleteOperation are the operations on
+	// the resources ordered by the queue priorities annotation
+	AddOperation    = "Add"
+	UpdateOperation = "Update"
+	DeleteOperation = "Delete"
 )
 
 func (c *ApiServerSource) Validate(ctx context.Context) *apis.FieldError {
-	return c.Spec.Validate(ctx).ViaField("spec")
+	errs := c.Spec.Validate(ctx).ViaField("spec")
+
+	if encoding, ok := c.Annotations[sources.SinkContentEncodingAnnotationKey]; ok {
+		switch encoding {
+		case "gzip", "identity":
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(encoding, sources.SinkContentEncodingAnnotationKey).ViaField("metadata", "annotations"))
+		}
+	}
+
+	errs = errs.Also(validateTraceContextInjection(c.Annotations))
+
+	if encoding, ok := c.Annotations[sources.ApiServerSourceDataEncodingAnnotationKey]; ok {
+		switch encoding {
+		case JSONDataEncoding:
+		case ProtobufDataEncoding:
+			if c.Spec.EventMode != ResourceMode {
+				errs = errs.Also(apis.ErrGeneric("protobuf data encoding requires the Resource mode", sources.ApiServerSourceDataEncodingAnnotationKey).ViaField("metadata", "annotations"))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(encoding, sources.ApiServerSourceDataEncodingAnnotationKey).ViaField("metadata", "annotations"))
+		}
+	}
+
+	if replicas, ok := c.Annotations[sources.ApiServerSourceReplicasAnnotationKey]; ok {
+		if r, err := strconv.ParseInt(replicas, 10, 32); err != nil || r < 1 {
+			errs = errs.Also(apis.ErrInvalidValue(replicas, sources.ApiServerSourceReplicasAnnotationKey).ViaField("metadata", "annotations"))
+		}
+	}
+
+	if priorities, ok := c.Annotations[sources.ApiServerSourceQueuePrioritiesAnnotationKey]; ok {
+		if _, err := ParseQueuePriorities(priorities); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(priorities, sources.ApiServerSourceQueuePrioritiesAnnotationKey, err.Error()).ViaField("metadata", "annotations"))
+		}
+	}
+	return errs
+}
+
+// ParseQueuePriorities parses the value of the queue priorities annotation
+// into its operations, highest priority first.
+func ParseQueuePriorities(value string) ([]string, error) {
+	seen := make(map[string]bool, 3)
+	var operations []string
+	for _, op := range strings.Split(value, ",") {
+		op = strings.TrimSpace(op)
+		switch op {
+		case AddOperation, UpdateOperation, DeleteOperation:
+		default:
+			return nil, fmt.Errorf("unknown operation %q", op)
+		}
+		if seen[op] {
+			return nil, fmt.Errorf("duplicate operation %q", op)
+		}
+		seen[op] = true
+		operations = append(operations, op)
+	}
+	return operations, nil
+}
+
+// validateTraceContextInjection validates the trace context injection
+// annotation of a source.
+func validateTraceContextInjection(annotations map[string]string) *apis.FieldError {
+	if injection, ok := annotations[sources.TraceContextInjectionAnnotationKey]; ok {
+		switch injection {
+		case sources.TraceContextInjectionEnabled, sources.TraceContextInjectionDisabled:
+		default:
+			return apis.ErrInvalidValue(injection, sources.TraceContextInjectionAnnotationKey).ViaField("metadata", "annotations")
+		}
+	}
+	return nil
+}
+
+// validateDelivery validates the delivery of an ApiServerSource, which only
+// supports the retries and the dead letter sink.
+func validateDelivery(ctx context.Context, delivery *eventingduckv1.DeliverySpec) *apis.FieldError {
+	errs := delivery.Validate(ctx)
+	if delivery.Timeout != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("timeout"))
+	}
+	if delivery.RetryAfterMax != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("retryAfterMax"))
+	}
+	if delivery.Ordering != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("ordering"))
+	}
+	return errs
 }
 
 func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -43,7 +151,7 @@ func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 
 	// Validate mode, if can be empty or set as certain value
 	switch cs.EventMode {
-	case ReferenceMode, ResourceMode:
+	case ReferenceMode, ResourceMode, ResourceDiffMode:
 	// EventMode is valid.
 	default:
 		errs = errs.Also(apis.ErrInvalidValue(cs.EventMode, "mode"))
@@ -63,6 +171,24 @@ func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		if strings.TrimSpace(res.Kind) == "" {
 			errs = errs.Also(apis.ErrMissingField("kind").ViaFieldIndex("resources", i))
 		}
+		if res.FieldSelector != "" {
+			if _, err := fields.ParseSelector(res.FieldSelector); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(res.FieldSelector, "fieldSelector", err.Error()).ViaFieldIndex("resources", i))
+			}
+		}
+		if res.CELFilter != "" {
+			if _, err := objectfilter.Compile(res.CELFilter); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(res.CELFilter, "celFilter", err.Error()).ViaFieldIndex("resources", i))
+			}
+		}
+	}
+
+	if cs.Delivery != nil {
+		errs = errs.Also(validateDelivery(ctx, cs.Delivery).ViaField("delivery"))
+	}
+
+	if cs.Monitoring != nil && cs.Monitoring.Sink != nil {
+		errs = errs.Also(cs.Monitoring.Sink.Validate(ctx).ViaField("monitoring", "sink"))
 	}
 
 	if cs.ResourceOwner != nil {
@@ -75,6 +201,7 @@ func (cs *ApiServerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 	errs = errs.Also(cs.SourceSpec.Validate(ctx))
+	errs = errs.Also(ceoverrides.Validate(cs.CloudEventOverrides).ViaField("ceOverrides"))
 	errs = errs.Also(validateSubscriptionAPIFiltersList(ctx, cs.Filters).ViaField("filters"))
 	return errs
 }
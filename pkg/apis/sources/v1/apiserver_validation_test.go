@@ -23,12 +23,17 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/sources"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 )
 
 func TestAPIServerValidation(t *testing.T) {
@@ -55,6 +60,25 @@ func TestAPIServerValidation(t *testing.T) {
 			},
 		},
 		want: nil,
+	}, {
+		name: "valid resource diff mode",
+		spec: ApiServerSourceSpec{
+			EventMode: "ResourceDiff",
+			Resources: []APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Foo",
+			}},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		},
+		want: nil,
 	}, {
 		name: "empty sink",
 		spec: ApiServerSourceSpec{
@@ -129,6 +153,86 @@ func TestAPIServerValidation(t *testing.T) {
 			},
 		},
 		want: errors.New("missing field(s): resources[0].kind"),
+	}, {
+		name: "valid fieldSelector",
+		spec: ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion:    "v1",
+				Kind:          "Pod",
+				FieldSelector: "status.phase=Running,spec.nodeName!=",
+			}},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid fieldSelector",
+		spec: ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion:    "v1",
+				Kind:          "Pod",
+				FieldSelector: "status.phase",
+			}},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("status.phase", "fieldSelector", "invalid selector: 'status.phase'; can't understand 'status.phase'").ViaFieldIndex("resources", 0),
+	}, {
+		name: "valid celFilter",
+		spec: ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				CELFilter:  "old == null || old.status.phase != new.status.phase",
+			}},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid celFilter",
+		spec: ApiServerSourceSpec{
+			EventMode: "Resource",
+			Resources: []APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				CELFilter:  "new.status.phase + '-'",
+			}},
+			SourceSpec: duckv1.SourceSpec{
+				Sink: duckv1.Destination{
+					Ref: &duckv1.KReference{
+						APIVersion: "v1",
+						Kind:       "broker",
+						Name:       "default",
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("new.status.phase + '-'", "celFilter", "the expression must evaluate to a bool, not string").ViaFieldIndex("resources", 0),
 	}, {
 		name: "owner - invalid apiVersion",
 		spec: ApiServerSourceSpec{
@@ -269,6 +373,353 @@ func TestAPIServerValidationCallsSpecValidation(t *testing.T) {
 	assert.EqualError(t, err, "missing field(s): spec.resources", "Spec is not validated!")
 }
 
+func TestAPIServerSinkContentEncodingValidation(t *testing.T) {
+	tests := map[string]struct {
+		encoding string
+		want     string
+	}{
+		"gzip": {
+			encoding: "gzip",
+		},
+		"identity": {
+			encoding: "identity",
+		},
+		"unsupported": {
+			encoding: "br",
+			want:     `invalid value: br: metadata.annotations.sources.knative.dev/sink-content-encoding`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.SinkContentEncodingAnnotationKey: tc.encoding,
+					},
+				},
+				Spec: ApiServerSourceSpec{
+					EventMode: "Resource",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIServerTraceContextInjectionValidation(t *testing.T) {
+	tests := map[string]struct {
+		injection string
+		want      string
+	}{
+		"enabled": {
+			injection: "enabled",
+		},
+		"disabled": {
+			injection: "disabled",
+		},
+		"invalid": {
+			injection: "always",
+			want:      `invalid value: always: metadata.annotations.sources.knative.dev/trace-context-injection`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.TraceContextInjectionAnnotationKey: tc.injection,
+					},
+				},
+				Spec: ApiServerSourceSpec{
+					EventMode: "Resource",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIServerDataEncodingValidation(t *testing.T) {
+	tests := map[string]struct {
+		encoding string
+		mode     string
+		want     string
+	}{
+		"json": {
+			encoding: "json",
+			mode:     "Reference",
+		},
+		"protobuf": {
+			encoding: "protobuf",
+			mode:     "Resource",
+		},
+		"protobuf in reference mode": {
+			encoding: "protobuf",
+			mode:     "Reference",
+			want:     `protobuf data encoding requires the Resource mode: metadata.annotations.sources.knative.dev/apiserversource-data-encoding`,
+		},
+		"protobuf in resource diff mode": {
+			encoding: "protobuf",
+			mode:     "ResourceDiff",
+			want:     `protobuf data encoding requires the Resource mode: metadata.annotations.sources.knative.dev/apiserversource-data-encoding`,
+		},
+		"unsupported": {
+			encoding: "yaml",
+			mode:     "Resource",
+			want:     `invalid value: yaml: metadata.annotations.sources.knative.dev/apiserversource-data-encoding`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.ApiServerSourceDataEncodingAnnotationKey: tc.encoding,
+					},
+				},
+				Spec: ApiServerSourceSpec{
+					EventMode: tc.mode,
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIServerReplicasValidation(t *testing.T) {
+	tests := map[string]struct {
+		replicas string
+		want     string
+	}{
+		"one": {
+			replicas: "1",
+		},
+		"three": {
+			replicas: "3",
+		},
+		"zero": {
+			replicas: "0",
+			want:     `invalid value: 0: metadata.annotations.sources.knative.dev/apiserversource-replicas`,
+		},
+		"not a number": {
+			replicas: "two",
+			want:     `invalid value: two: metadata.annotations.sources.knative.dev/apiserversource-replicas`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.ApiServerSourceReplicasAnnotationKey: tc.replicas,
+					},
+				},
+				Spec: ApiServerSourceSpec{
+					EventMode: "Reference",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIServerDeliveryValidation(t *testing.T) {
+	exponential := eventingduckv1.BackoffPolicyExponential
+	tests := map[string]struct {
+		delivery *eventingduckv1.DeliverySpec
+		want     string
+	}{
+		"no delivery": {},
+		"exponential backoff": {
+			delivery: &eventingduckv1.DeliverySpec{
+				Retry:         ptr.Int32(3),
+				BackoffPolicy: &exponential,
+				BackoffDelay:  ptr.String("PT0.5S"),
+			},
+		},
+		"negative retry": {
+			delivery: &eventingduckv1.DeliverySpec{
+				Retry: ptr.Int32(-1),
+			},
+			want: `invalid value: -1: spec.delivery.retry`,
+		},
+		"invalid backoff delay": {
+			delivery: &eventingduckv1.DeliverySpec{
+				Retry:        ptr.Int32(1),
+				BackoffDelay: ptr.String("1s"),
+			},
+			want: `invalid value: 1s: spec.delivery.backoffDelay`,
+		},
+		"timeout": {
+			delivery: &eventingduckv1.DeliverySpec{
+				Timeout: ptr.String("PT1S"),
+			},
+			want: `must not set the field(s): spec.delivery.timeout`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				Spec: ApiServerSourceSpec{
+					EventMode: "Reference",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+					Delivery: tc.delivery,
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestAPIServerMonitoringValidation(t *testing.T) {
+	tests := map[string]struct {
+		monitoring *ApiServerSourceMonitoring
+		want       string
+	}{
+		"no monitoring": {},
+		"monitoring to the sink": {
+			monitoring: &ApiServerSourceMonitoring{},
+		},
+		"monitoring sink": {
+			monitoring: &ApiServerSourceMonitoring{
+				Sink: &duckv1.Destination{
+					URI: apis.HTTP("ops.example.com"),
+				},
+			},
+		},
+		"empty monitoring sink": {
+			monitoring: &ApiServerSourceMonitoring{
+				Sink: &duckv1.Destination{},
+			},
+			want: `expected at least one, got none: spec.monitoring.sink.ref, spec.monitoring.sink.uri`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				Spec: ApiServerSourceSpec{
+					EventMode: "Reference",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+					Monitoring: tc.monitoring,
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
+
 func TestAPIServerFiltersValidation(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -347,3 +798,59 @@ func TestAPIServerFiltersValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIServerQueuePrioritiesValidation(t *testing.T) {
+	tests := map[string]struct {
+		priorities string
+		want       string
+	}{
+		"all": {
+			priorities: "Delete,Add,Update",
+		},
+		"some with spaces": {
+			priorities: "Update, Delete",
+		},
+		"unknown": {
+			priorities: "Delete,Patch",
+			want:       `invalid value: Delete,Patch: metadata.annotations.sources.knative.dev/apiserversource-queue-priorities` + "\n" + `unknown operation "Patch"`,
+		},
+		"duplicate": {
+			priorities: "Delete,Delete",
+			want:       `invalid value: Delete,Delete: metadata.annotations.sources.knative.dev/apiserversource-queue-priorities` + "\n" + `duplicate operation "Delete"`,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			source := ApiServerSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.ApiServerSourceQueuePrioritiesAnnotationKey: tc.priorities,
+					},
+				},
+				Spec: ApiServerSourceSpec{
+					EventMode: "Reference",
+					Resources: []APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Foo",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			}
+
+			err := source.Validate(context.TODO())
+			if tc.want == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, tc.want)
+			}
+		})
+	}
+}
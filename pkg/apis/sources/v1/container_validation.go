@@ -21,6 +21,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/ceoverrides"
 )
 
 func (c *ContainerSource) Validate(ctx context.Context) *apis.FieldError {
@@ -45,6 +47,7 @@ func (cs *ContainerSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 	errs = errs.Also(cs.SourceSpec.Validate(ctx))
+	errs = errs.Also(ceoverrides.Validate(cs.CloudEventOverrides).ViaField("ceOverrides"))
 	return errs
 }
 
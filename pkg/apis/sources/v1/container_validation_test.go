@@ -133,6 +133,56 @@ func TestContainerSourceValidation(t *testing.T) {
 				errs = errs.Also(fe)
 				return errs
 			}(),
+		}, {
+			name: "templated ceOverrides",
+			spec: ContainerSourceSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "name",
+							Image: "image",
+						}},
+					},
+				},
+				SourceSpec: duckv1.SourceSpec{
+					CloudEventOverrides: &duckv1.CloudEventOverrides{
+						Extensions: map[string]string{"subject": "{{.type}}-{{.id}}"},
+					},
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "v1",
+							Kind:       "broker",
+							Name:       "default",
+						},
+					},
+				},
+			},
+			want: nil,
+		}, {
+			name: "invalid templated ceOverrides",
+			spec: ContainerSourceSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "name",
+							Image: "image",
+						}},
+					},
+				},
+				SourceSpec: duckv1.SourceSpec{
+					CloudEventOverrides: &duckv1.CloudEventOverrides{
+						Extensions: map[string]string{"subject": "{{.type"},
+					},
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "v1",
+							Kind:       "broker",
+							Name:       "default",
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(`unterminated "{{" in "{{.type"`, "ceOverrides.extensions[subject]"),
 		},
 	}
 
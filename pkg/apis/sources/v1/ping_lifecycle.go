@@ -38,6 +38,11 @@ const (
 
 	// PingSourceConditionOIDCIdentityCreated has status True when the PingSource has had it's OIDC identity created.
 	PingSourceConditionOIDCIdentityCreated apis.ConditionType = "OIDCIdentityCreated"
+
+	// PingSourceConditionSinkAudienceMismatch has status True when the sink
+	// rejected the OIDC token of the PingSource because it was issued for
+	// another audience. It doesn't affect the readiness of the PingSource.
+	PingSourceConditionSinkAudienceMismatch apis.ConditionType = "SinkAudienceMismatch"
 )
 
 var PingSourceCondSet = apis.NewLivingConditionSet(
@@ -48,6 +53,19 @@ var PingSourceCondSet = apis.NewLivingConditionSet(
 const (
 	// PingSourceEventType is the default PingSource CloudEvent type.
 	PingSourceEventType = "dev.knative.sources.ping"
+
+	// PingSourceScheduledTimeExtension is the CloudEvent extension set to the
+	// time the tick of the event was scheduled at, in RFC 3339 format in the
+	// time zone of the PingSource.
+	PingSourceScheduledTimeExtension = "pingscheduledtime"
+	// PingSourceFireTimeExtension is the CloudEvent extension set to the time
+	// the event was sent at, in RFC 3339 format in the time zone of the
+	// PingSource.
+	PingSourceFireTimeExtension = "pingfiretime"
+	// PingSourceDriftExtension is the CloudEvent extension set to the delay
+	// in milliseconds between the scheduled time and the fire time of the
+	// event, including the jitter of the PingSource.
+	PingSourceDriftExtension = "pingdrift"
 )
 
 // GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
@@ -143,3 +161,20 @@ func (s *PingSourceStatus) MarkOIDCIdentityCreatedFailed(reason, messageFormat s
 func (s *PingSourceStatus) MarkOIDCIdentityCreatedUnknown(reason, messageFormat string, messageA ...interface{}) {
 	PingSourceCondSet.Manage(s).MarkUnknown(PingSourceConditionOIDCIdentityCreated, reason, messageFormat, messageA...)
 }
+
+// MarkSinkAudienceMismatch sets the informational condition telling that the
+// sink rejected the OIDC token issued for audience.
+func (s *PingSourceStatus) MarkSinkAudienceMismatch(audience string) {
+	PingSourceCondSet.Manage(s).SetCondition(apis.Condition{
+		Type:     PingSourceConditionSinkAudienceMismatch,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   "SinkAudienceMismatch",
+		Message:  fmt.Sprintf("The sink rejected the OIDC token issued for audience %q, the audience of the sink may have changed.", audience),
+	})
+}
+
+// ClearSinkAudienceMismatch removes the SinkAudienceMismatch condition.
+func (s *PingSourceStatus) ClearSinkAudienceMismatch() {
+	_ = PingSourceCondSet.Manage(s).ClearCondition(PingSourceConditionSinkAudienceMismatch)
+}
@@ -125,6 +125,20 @@ func TestPingSourceStatusIsReady(t *testing.T) {
 			wantConditionStatus: corev1.ConditionFalse,
 			want:                false,
 		},
+		{
+			name: "sink audience mismatch",
+			s: func() *PingSourceStatus {
+				s := &PingSourceStatus{}
+				s.InitializeConditions()
+				s.MarkOIDCIdentityCreatedSucceeded()
+				s.MarkSink(exampleAddr)
+				s.PropagateDeploymentAvailability(availableDeployment)
+				s.MarkSinkAudienceMismatch("audience")
+				return s
+			}(),
+			wantConditionStatus: corev1.ConditionTrue,
+			want:                true,
+		},
 	}
 
 	for _, test := range tests {
@@ -68,6 +68,13 @@ type PingSourceSpec struct {
 	// List of valid timezone values: https://en.wikipedia.org/wiki/List_of_tz_database_time_zones
 	Timezone string `json:"timezone,omitempty"`
 
+	// Jitter is the maximum random delay, as an ISO 8601 duration, e.g.
+	// PT30S, the events are sent after their scheduled time, to spread the
+	// load of the PingSources sharing a schedule. When unset, the events are
+	// delayed by up to half a second.
+	// +optional
+	Jitter *string `json:"jitter,omitempty"`
+
 	// ContentType is the media type of Data or DataBase64. Default is empty.
 	// +optional
 	ContentType string `json:"contentType,omitempty"`
@@ -26,14 +26,18 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 
+	"github.com/rickb777/date/period"
 	"github.com/robfig/cron/v3"
 	"knative.dev/pkg/apis"
 
 	"knative.dev/eventing/pkg/apis/sources/config"
+	"knative.dev/eventing/pkg/ceoverrides"
 )
 
 func (c *PingSource) Validate(ctx context.Context) *apis.FieldError {
-	return c.Spec.Validate(ctx).ViaField("spec")
+	errs := c.Spec.Validate(ctx).ViaField("spec")
+	errs = errs.Also(validateTraceContextInjection(c.Annotations))
+	return errs.Also(config.ValidatePingSourceQuota(ctx, c.Namespace))
 }
 
 func (cs *PingSourceSpec) Validate(ctx context.Context) *apis.FieldError {
@@ -60,6 +64,14 @@ func (cs *PingSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if cs.Jitter != nil {
+		if p, err := period.Parse(*cs.Jitter); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(*cs.Jitter, "jitter"))
+		} else if p.IsNegative() {
+			errs = errs.Also(apis.ErrInvalidValue(*cs.Jitter, "jitter", "must not be negative"))
+		}
+	}
+
 	pingConfig := config.FromContextOrDefaults(ctx)
 	pingDefaults := pingConfig.PingDefaults.GetPingConfig()
 
@@ -99,6 +111,7 @@ func (cs *PingSourceSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 	errs = errs.Also(cs.SourceSpec.Validate(ctx))
+	errs = errs.Also(ceoverrides.Validate(cs.CloudEventOverrides).ViaField("ceOverrides"))
 	return errs
 }
 
@@ -27,8 +27,11 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
 
+	"knative.dev/eventing/pkg/apis/sources"
 	"knative.dev/eventing/pkg/apis/sources/config"
 )
 
@@ -133,6 +136,60 @@ func TestPingSourceValidation(t *testing.T) {
 				errs = errs.Also(fe)
 				return errs
 			}(),
+		}, {
+			name: "valid spec with jitter",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Jitter:   ptr.String("PT30S"),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		}, {
+			name: "invalid jitter",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Jitter:   ptr.String("30s"),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("30s", "spec.jitter"),
+		}, {
+			name: "negative jitter",
+			source: PingSource{
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					Jitter:   ptr.String("-PT30S"),
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-PT30S", "spec.jitter", "must not be negative"),
 		}, {
 			name: "empty sink",
 			source: PingSource{
@@ -380,6 +437,36 @@ func TestPingSourceValidation(t *testing.T) {
 				return config.ToContext(ctx, &config.Config{PingDefaults: &config.PingDefaults{DataMaxSize: -1}})
 			},
 			want: nil,
+		}, {
+			name: "namespace quota exceeded",
+			source: PingSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "tenant",
+					Name:      "ping",
+				},
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			ctx: func(ctx context.Context) context.Context {
+				ctx = config.ToContext(apis.WithinCreate(ctx), &config.Config{PingDefaults: &config.PingDefaults{DataMaxSize: -1, MaxPerNamespace: 1}})
+				return config.WithPingSourceCounter(ctx, func(context.Context, string) (int, error) {
+					return 1, nil
+				})
+			},
+			want: &apis.FieldError{
+				Message: `namespace "tenant" already has 1 PingSources, the maximum allowed is 1`,
+				Details: `the limit is set by "max-per-namespace" in the config-ping-defaults ConfigMap`,
+			},
 		}, {
 			name: "big data still ok",
 			source: PingSource{
@@ -475,6 +562,28 @@ func TestPingSourceValidation(t *testing.T) {
 				errs = errs.Also(fe)
 				return errs
 			}(),
+		}, {
+			name: "invalid trace context injection annotation",
+			source: PingSource{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						sources.TraceContextInjectionAnnotationKey: "always",
+					},
+				},
+				Spec: PingSourceSpec{
+					Schedule: "*/2 * * * *",
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "broker",
+								Name:       "default",
+							},
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("always", sources.TraceContextInjectionAnnotationKey).ViaField("metadata", "annotations"),
 		},
 	}
 
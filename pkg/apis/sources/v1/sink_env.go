@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	// SinkEnvVar is the environment variable holding the URI of the
+	// resolved sink of a source.
+	SinkEnvVar = "K_SINK"
+	// SinkCACertsEnvVar is the environment variable holding the CA
+	// certificates of the resolved sink, in PEM format.
+	SinkCACertsEnvVar = "K_CA_CERTS"
+	// SinkAudienceEnvVar is the environment variable holding the OIDC
+	// audience of the resolved sink.
+	SinkAudienceEnvVar = "K_AUDIENCE"
+	// CEOverridesEnvVar is the environment variable holding the JSON
+	// encoded CloudEventOverrides of a source.
+	CEOverridesEnvVar = "K_CE_OVERRIDES"
+)
+
+// SinkEnvVars returns the environment variables a receive adapter uses to
+// reach the resolved sink. K_CA_CERTS and K_AUDIENCE are only set when the
+// sink has CA certificates or an audience.
+func SinkEnvVars(uri string, caCerts, audience *string) []corev1.EnvVar {
+	env := []corev1.EnvVar{{
+		Name:  SinkEnvVar,
+		Value: uri,
+	}}
+	if caCerts != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  SinkCACertsEnvVar,
+			Value: *caCerts,
+		})
+	}
+	if audience != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  SinkAudienceEnvVar,
+			Value: *audience,
+		})
+	}
+	return env
+}
+
+// VerifySinkEnv checks that the environment of a receive adapter matches the
+// sink resolved in the status of its source, as set by SinkEnvVars.
+func VerifySinkEnv(env []corev1.EnvVar, status *duckv1.SourceStatus) error {
+	values := make(map[string]*string, 3)
+	for i := range env {
+		switch env[i].Name {
+		case SinkEnvVar, SinkCACertsEnvVar, SinkAudienceEnvVar:
+			values[env[i].Name] = &env[i].Value
+		}
+	}
+
+	var uri *string
+	if status.SinkURI != nil {
+		s := status.SinkURI.String()
+		uri = &s
+	}
+	for _, want := range []struct {
+		name  string
+		value *string
+	}{
+		{name: SinkEnvVar, value: uri},
+		{name: SinkCACertsEnvVar, value: status.SinkCACerts},
+		{name: SinkAudienceEnvVar, value: status.SinkAudience},
+	} {
+		got := values[want.name]
+		switch {
+		case want.value == nil && got != nil:
+			return fmt.Errorf("unexpected %s %q, the sink does not define it", want.name, *got)
+		case want.value != nil && got == nil:
+			return fmt.Errorf("missing %s, want %q", want.name, *want.value)
+		case want.value != nil && *got != *want.value:
+			return fmt.Errorf("%s is %q, want %q", want.name, *got, *want.value)
+		}
+	}
+	return nil
+}
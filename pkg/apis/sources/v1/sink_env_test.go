@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	"knative.dev/pkg/ptr"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	. "knative.dev/eventing/pkg/scheduler/testing"
+)
+
+func TestVerifySinkEnv(t *testing.T) {
+	status := &duckv1.SourceStatus{
+		SinkURI:      apis.HTTPS("sink.ns.svc.cluster.local"),
+		SinkCACerts:  ptr.String(caCert),
+		SinkAudience: ptr.String("sink-audience"),
+	}
+
+	tests := []struct {
+		name    string
+		env     []corev1.EnvVar
+		status  *duckv1.SourceStatus
+		wantErr string
+	}{{
+		name:   "full sink",
+		env:    SinkEnvVars("https://sink.ns.svc.cluster.local", ptr.String(caCert), ptr.String("sink-audience")),
+		status: status,
+	}, {
+		name:   "sink URI only",
+		env:    SinkEnvVars("http://sink.ns.svc.cluster.local", nil, nil),
+		status: &duckv1.SourceStatus{SinkURI: apis.HTTP("sink.ns.svc.cluster.local")},
+	}, {
+		name:    "missing audience",
+		env:     SinkEnvVars("https://sink.ns.svc.cluster.local", ptr.String(caCert), nil),
+		status:  status,
+		wantErr: "missing K_AUDIENCE",
+	}, {
+		name:    "stale sink",
+		env:     SinkEnvVars("https://old.ns.svc.cluster.local", ptr.String(caCert), ptr.String("sink-audience")),
+		status:  status,
+		wantErr: "K_SINK is",
+	}, {
+		name:    "unexpected CA certs",
+		env:     SinkEnvVars("http://sink.ns.svc.cluster.local", ptr.String(caCert), nil),
+		status:  &duckv1.SourceStatus{SinkURI: apis.HTTP("sink.ns.svc.cluster.local")},
+		wantErr: "unexpected K_CA_CERTS",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySinkEnv(tt.env, tt.status)
+			if tt.wantErr == "" && err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("want error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSinkBindingDoPropagatesSinkEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination duckv1.Destination
+	}{{
+		name: "sink URI only",
+		destination: duckv1.Destination{
+			URI: apis.HTTP("thing.ns.svc.cluster.local"),
+		},
+	}, {
+		name: "sink with CA certs",
+		destination: duckv1.Destination{
+			URI:     apis.HTTPS("thing.ns.svc.cluster.local"),
+			CACerts: ptr.String(caCert),
+		},
+	}, {
+		name: "sink with CA certs and audience",
+		destination: duckv1.Destination{
+			URI:      apis.HTTPS("thing.ns.svc.cluster.local"),
+			CACerts:  ptr.String(caCert),
+			Audience: ptr.String("thing-audience"),
+		},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &duckv1.WithPod{
+				Spec: duckv1.WithPodSpec{
+					Template: duckv1.PodSpecable{
+						Spec: corev1.PodSpec{
+							InitContainers: []corev1.Container{{Name: "setup", Image: "busybox"}},
+							Containers:     []corev1.Container{{Name: "adapter", Image: "adapter"}},
+						},
+					},
+				},
+			}
+			applicationContext, _ := fakedynamicclient.With(context.Background(), scheme.Scheme, got)
+			applicationContext = addressable.WithDuck(applicationContext)
+			r := resolver.NewURIResolverFromTracker(applicationContext, tracker.New(func(types.NamespacedName) {}, 0))
+
+			ctx, _ := SetupFakeContext(t)
+			ctx = WithURIResolver(ctx, r)
+			ctx = WithTrustBundleConfigMapLister(ctx, configmapinformer.Get(ctx).Lister())
+
+			sb := &SinkBinding{
+				Spec: SinkBindingSpec{
+					SourceSpec: duckv1.SourceSpec{Sink: tt.destination},
+				},
+			}
+			sb.Do(ctx, got)
+
+			containers := append(got.Spec.Template.Spec.InitContainers, got.Spec.Template.Spec.Containers...)
+			for _, c := range containers {
+				if err := VerifySinkEnv(c.Env, &sb.Status.SourceStatus); err != nil {
+					t.Errorf("container %s: %v", c.Name, err)
+				}
+			}
+
+			sb.Undo(ctx, got)
+			containers = append(got.Spec.Template.Spec.InitContainers, got.Spec.Template.Spec.Containers...)
+			for _, c := range containers {
+				for _, e := range c.Env {
+					if e.Name == SinkAudienceEnvVar {
+						t.Errorf("container %s still has %s after Undo", c.Name, e.Name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSourcesMarkSinkPropagatesAddress(t *testing.T) {
+	addr := &duckv1.Addressable{
+		URL:      apis.HTTPS("sink.ns.svc.cluster.local"),
+		CACerts:  ptr.String(caCert),
+		Audience: ptr.String("sink-audience"),
+	}
+	want := SinkEnvVars(addr.URL.String(), addr.CACerts, addr.Audience)
+
+	ping := &PingSourceStatus{}
+	apiServer := &ApiServerSourceStatus{}
+	sinkBinding := &SinkBindingStatus{}
+	tests := []struct {
+		name     string
+		markSink func(*duckv1.Addressable)
+		status   *duckv1.SourceStatus
+	}{
+		{name: "PingSource", markSink: ping.MarkSink, status: &ping.SourceStatus},
+		{name: "ApiServerSource", markSink: apiServer.MarkSink, status: &apiServer.SourceStatus},
+		{name: "SinkBinding", markSink: sinkBinding.MarkSink, status: &sinkBinding.SourceStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.markSink(addr)
+			if err := VerifySinkEnv(want, tt.status); err != nil {
+				t.Error("status does not match the resolved sink:", err)
+			}
+		})
+	}
+}
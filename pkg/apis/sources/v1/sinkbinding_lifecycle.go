@@ -31,6 +31,7 @@ import (
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/tracker"
 
@@ -39,6 +40,7 @@ import (
 
 const (
 	oidcTokenVolumeName = "oidc-token"
+	sinkFilesVolumeName = "sinkbinding"
 )
 
 var sbCondSet = apis.NewLivingConditionSet(
@@ -155,46 +157,30 @@ func (sb *SinkBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 	sb.Status.MarkSink(addr)
 
-	var ceOverrides string
-	if sb.Spec.CloudEventOverrides != nil {
-		if co, err := json.Marshal(sb.Spec.SourceSpec.CloudEventOverrides); err != nil {
-			logging.FromContext(ctx).Errorw(fmt.Sprintf("Failed to marshal CloudEventOverrides into JSON for %+v", sb), zap.Error(err))
-		} else if len(co) > 0 {
-			ceOverrides = string(co)
-		}
+	ceOverrides, err := sb.ceOverrides()
+	if err != nil {
+		logging.FromContext(ctx).Errorw(fmt.Sprintf("Failed to marshal CloudEventOverrides into JSON for %+v", sb), zap.Error(err))
 	}
 
-	for i := range ps.Spec.Template.Spec.InitContainers {
-		ps.Spec.Template.Spec.InitContainers[i].Env = append(ps.Spec.Template.Spec.InitContainers[i].Env, corev1.EnvVar{
-			Name:  "K_SINK",
-			Value: addr.URL.String(),
-		})
-		if addr.CACerts != nil {
+	if sb.Spec.Projection == FilesProjection {
+		sb.doFiles(ps)
+	} else {
+		for i := range ps.Spec.Template.Spec.InitContainers {
+			ps.Spec.Template.Spec.InitContainers[i].Env = append(ps.Spec.Template.Spec.InitContainers[i].Env,
+				SinkEnvVars(addr.URL.String(), addr.CACerts, addr.Audience)...)
 			ps.Spec.Template.Spec.InitContainers[i].Env = append(ps.Spec.Template.Spec.InitContainers[i].Env, corev1.EnvVar{
-				Name:  "K_CA_CERTS",
-				Value: *addr.CACerts,
+				Name:  CEOverridesEnvVar,
+				Value: ceOverrides,
 			})
 		}
-		ps.Spec.Template.Spec.InitContainers[i].Env = append(ps.Spec.Template.Spec.InitContainers[i].Env, corev1.EnvVar{
-			Name:  "K_CE_OVERRIDES",
-			Value: ceOverrides,
-		})
-	}
-	for i := range ps.Spec.Template.Spec.Containers {
-		ps.Spec.Template.Spec.Containers[i].Env = append(ps.Spec.Template.Spec.Containers[i].Env, corev1.EnvVar{
-			Name:  "K_SINK",
-			Value: addr.URL.String(),
-		})
-		if addr.CACerts != nil {
+		for i := range ps.Spec.Template.Spec.Containers {
+			ps.Spec.Template.Spec.Containers[i].Env = append(ps.Spec.Template.Spec.Containers[i].Env,
+				SinkEnvVars(addr.URL.String(), addr.CACerts, addr.Audience)...)
 			ps.Spec.Template.Spec.Containers[i].Env = append(ps.Spec.Template.Spec.Containers[i].Env, corev1.EnvVar{
-				Name:  "K_CA_CERTS",
-				Value: *addr.CACerts,
+				Name:  CEOverridesEnvVar,
+				Value: ceOverrides,
 			})
 		}
-		ps.Spec.Template.Spec.Containers[i].Env = append(ps.Spec.Template.Spec.Containers[i].Env, corev1.EnvVar{
-			Name:  "K_CE_OVERRIDES",
-			Value: ceOverrides,
-		})
 	}
 
 	pss, err := eventingtls.AddTrustBundleVolumes(GetTrustBundleConfigMapLister(ctx), sb, &ps.Spec.Template.Spec)
@@ -204,7 +190,8 @@ func (sb *SinkBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 	ps.Spec.Template.Spec = *pss
 
-	if sb.Status.OIDCTokenSecretName != nil {
+	// The files projection holds the OIDC token.
+	if sb.Status.OIDCTokenSecretName != nil && sb.Spec.Projection != FilesProjection {
 		ps.Spec.Template.Spec.Volumes = append(ps.Spec.Template.Spec.Volumes, corev1.Volume{
 			Name: oidcTokenVolumeName,
 			VolumeSource: corev1.VolumeSource{
@@ -237,13 +224,88 @@ func (sb *SinkBinding) Do(ctx context.Context, ps *duckv1.WithPod) {
 	}
 }
 
+// doFiles mounts the ConfigMap holding the sink files, and the OIDC token
+// secret, as a projected volume in every container.
+func (sb *SinkBinding) doFiles(ps *duckv1.WithPod) {
+	sources := []corev1.VolumeProjection{{
+		ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: sb.SinkFilesConfigMapName(),
+			},
+		},
+	}}
+	if sb.Status.OIDCTokenSecretName != nil {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: *sb.Status.OIDCTokenSecretName,
+				},
+			},
+		})
+	}
+	ps.Spec.Template.Spec.Volumes = append(ps.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: sinkFilesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	})
+
+	mount := corev1.VolumeMount{
+		Name:      sinkFilesVolumeName,
+		MountPath: SinkFilesMountPath,
+		ReadOnly:  true,
+	}
+	for i := range ps.Spec.Template.Spec.Containers {
+		ps.Spec.Template.Spec.Containers[i].VolumeMounts = append(ps.Spec.Template.Spec.Containers[i].VolumeMounts, mount)
+	}
+	for i := range ps.Spec.Template.Spec.InitContainers {
+		ps.Spec.Template.Spec.InitContainers[i].VolumeMounts = append(ps.Spec.Template.Spec.InitContainers[i].VolumeMounts, mount)
+	}
+}
+
+// SinkFilesConfigMapName returns the name of the ConfigMap holding the files
+// of the FilesProjection of the sink.
+func (sb *SinkBinding) SinkFilesConfigMapName() string {
+	return kmeta.ChildName(sb.Name, "-sink-files")
+}
+
+// SinkFiles returns the content of the files of the FilesProjection of the
+// sink, by file name.
+func (sb *SinkBinding) SinkFiles(addr *duckv1.Addressable) (map[string]string, error) {
+	ceOverrides, err := sb.ceOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CloudEventOverrides into JSON: %w", err)
+	}
+	files := make(map[string]string, 4)
+	for _, env := range SinkEnvVars(addr.URL.String(), addr.CACerts, addr.Audience) {
+		files[env.Name] = env.Value
+	}
+	files[CEOverridesEnvVar] = ceOverrides
+	return files, nil
+}
+
+// ceOverrides returns the JSON encoded CloudEventOverrides of the
+// SinkBinding, or an empty string when it has none.
+func (sb *SinkBinding) ceOverrides() (string, error) {
+	if sb.Spec.CloudEventOverrides == nil {
+		return "", nil
+	}
+	co, err := json.Marshal(sb.Spec.SourceSpec.CloudEventOverrides)
+	if err != nil {
+		return "", err
+	}
+	return string(co), nil
+}
+
 func (sb *SinkBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 	for i, c := range ps.Spec.Template.Spec.InitContainers {
 		if len(c.Env) > 0 {
 			env := make([]corev1.EnvVar, 0, len(ps.Spec.Template.Spec.InitContainers[i].Env))
 			for j, ev := range c.Env {
 				switch ev.Name {
-				case "K_SINK", "K_CE_OVERRIDES", "K_CA_CERTS":
+				case SinkEnvVar, CEOverridesEnvVar, SinkCACertsEnvVar, SinkAudienceEnvVar:
 					continue
 				default:
 					env = append(env, ps.Spec.Template.Spec.InitContainers[i].Env[j])
@@ -255,7 +317,7 @@ func (sb *SinkBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 		if len(ps.Spec.Template.Spec.InitContainers[i].VolumeMounts) > 0 {
 			volumeMounts := make([]corev1.VolumeMount, 0, len(ps.Spec.Template.Spec.InitContainers[i].VolumeMounts))
 			for j, vol := range c.VolumeMounts {
-				if vol.Name == oidcTokenVolumeName {
+				if vol.Name == oidcTokenVolumeName || vol.Name == sinkFilesVolumeName {
 					continue
 				}
 				if strings.HasPrefix(vol.Name, eventingtls.TrustBundleVolumeNamePrefix) {
@@ -271,7 +333,7 @@ func (sb *SinkBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 			env := make([]corev1.EnvVar, 0, len(ps.Spec.Template.Spec.Containers[i].Env))
 			for j, ev := range c.Env {
 				switch ev.Name {
-				case "K_SINK", "K_CE_OVERRIDES", "K_CA_CERTS":
+				case SinkEnvVar, CEOverridesEnvVar, SinkCACertsEnvVar, SinkAudienceEnvVar:
 					continue
 				default:
 					env = append(env, ps.Spec.Template.Spec.Containers[i].Env[j])
@@ -283,7 +345,7 @@ func (sb *SinkBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 		if len(ps.Spec.Template.Spec.Containers[i].VolumeMounts) > 0 {
 			volumeMounts := make([]corev1.VolumeMount, 0, len(ps.Spec.Template.Spec.Containers[i].VolumeMounts))
 			for j, vol := range c.VolumeMounts {
-				if vol.Name == oidcTokenVolumeName {
+				if vol.Name == oidcTokenVolumeName || vol.Name == sinkFilesVolumeName {
 					continue
 				}
 				if strings.HasPrefix(vol.Name, eventingtls.TrustBundleVolumeNamePrefix) {
@@ -298,7 +360,7 @@ func (sb *SinkBinding) Undo(ctx context.Context, ps *duckv1.WithPod) {
 	if len(ps.Spec.Template.Spec.Volumes) > 0 {
 		volumes := make([]corev1.Volume, 0, len(ps.Spec.Template.Spec.Volumes))
 		for i, vol := range ps.Spec.Template.Spec.Volumes {
-			if vol.Name == oidcTokenVolumeName {
+			if vol.Name == oidcTokenVolumeName || vol.Name == sinkFilesVolumeName {
 				continue
 			}
 			if strings.HasPrefix(vol.Name, eventingtls.TrustBundleVolumeNamePrefix) {
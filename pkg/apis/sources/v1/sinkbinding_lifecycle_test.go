@@ -520,6 +520,7 @@ func TestSinkBindingDo(t *testing.T) {
 		in         *duckv1.WithPod
 		configMaps []*corev1.ConfigMap
 		sbStatus   *SinkBindingStatus
+		projection string
 		want       *duckv1.WithPod
 		ctx        context.Context
 	}{{
@@ -891,6 +892,85 @@ func TestSinkBindingDo(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name:       "projects files",
+		projection: FilesProjection,
+		in: &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{
+							Name:  "init",
+							Image: "busybox",
+						}},
+						Containers: []corev1.Container{{
+							Name:  "blah",
+							Image: "busybox",
+							Env: []corev1.EnvVar{{
+								Name:  "K_SINK",
+								Value: destination.URI.String(),
+							}, {
+								Name:  "K_CA_CERTS",
+								Value: caCert,
+							}, {
+								Name:  "K_CE_OVERRIDES",
+								Value: `{"extensions":{"foo":"bar"}}`,
+							}},
+						}},
+					},
+				},
+			},
+		},
+		sbStatus: &SinkBindingStatus{
+			OIDCTokenSecretName: pointer.String("oidc-token"),
+		},
+		want: &duckv1.WithPod{
+			Spec: duckv1.WithPodSpec{
+				Template: duckv1.PodSpecable{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{
+							Name:  "init",
+							Image: "busybox",
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      sinkFilesVolumeName,
+								MountPath: SinkFilesMountPath,
+								ReadOnly:  true,
+							}},
+						}},
+						Containers: []corev1.Container{{
+							Name:  "blah",
+							Image: "busybox",
+							Env:   []corev1.EnvVar{},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      sinkFilesVolumeName,
+								MountPath: SinkFilesMountPath,
+								ReadOnly:  true,
+							}},
+						}},
+						Volumes: []corev1.Volume{{
+							Name: sinkFilesVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								Projected: &corev1.ProjectedVolumeSource{
+									Sources: []corev1.VolumeProjection{{
+										ConfigMap: &corev1.ConfigMapProjection{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "sb-sink-files",
+											},
+										},
+									}, {
+										Secret: &corev1.SecretProjection{
+											LocalObjectReference: corev1.LocalObjectReference{
+												Name: "oidc-token",
+											},
+										},
+									}},
+								},
+							},
+						}},
+					},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -912,11 +992,16 @@ func TestSinkBindingDo(t *testing.T) {
 			}
 
 			sb := &SinkBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sb",
+				},
 				Spec: SinkBindingSpec{
 					SourceSpec: duckv1.SourceSpec{
 						Sink:                destination,
 						CloudEventOverrides: &overrides,
-					}},
+					},
+					Projection: test.projection,
+				},
 			}
 
 			if test.sbStatus != nil {
@@ -976,3 +1061,30 @@ func TestSinkBindingDoNoURI(t *testing.T) {
 		t.Error("Undo (-want, +got):", cmp.Diff(want, got))
 	}
 }
+
+func TestSinkBindingSinkFiles(t *testing.T) {
+	sb := &SinkBinding{
+		Spec: SinkBindingSpec{
+			SourceSpec: duckv1.SourceSpec{
+				CloudEventOverrides: &duckv1.CloudEventOverrides{Extensions: map[string]string{"foo": "bar"}},
+			},
+		},
+	}
+	addr := &duckv1.Addressable{
+		URL:     apis.HTTP("thing.ns.svc.cluster.local"),
+		CACerts: &caCert,
+	}
+
+	got, err := sb.SinkFiles(addr)
+	if err != nil {
+		t.Fatal("SinkFiles() =", err)
+	}
+	want := map[string]string{
+		"K_SINK":         "http://thing.ns.svc.cluster.local",
+		"K_CA_CERTS":     caCert,
+		"K_CE_OVERRIDES": `{"extensions":{"foo":"bar"}}`,
+	}
+	if !cmp.Equal(got, want) {
+		t.Error("SinkFiles (-want, +got):", cmp.Diff(want, got))
+	}
+}
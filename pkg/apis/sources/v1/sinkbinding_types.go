@@ -67,8 +67,29 @@ type SinkBindingSpec struct {
 	// * Subject - Subject references the resource(s) whose "runtime contract"
 	//   should be augmented by Binding implementations.
 	duckv1.BindingSpec `json:",inline"`
+
+	// Projection controls how the sink is projected into the subject:
+	//   - EnvVars: the K_SINK, K_CA_CERTS, K_AUDIENCE and K_CE_OVERRIDES
+	//     environment variables are set on the containers, the default.
+	//   - Files: the same values are files of a volume mounted at
+	//     /knative/sinkbinding along with the OIDC token, the files are
+	//     updated in place when the sink changes, without restarting the pods.
+	// +optional
+	Projection string `json:"projection,omitempty"`
 }
 
+const (
+	// EnvVarsProjection projects the sink as environment variables.
+	EnvVarsProjection = "EnvVars"
+	// FilesProjection projects the sink as files.
+	FilesProjection = "Files"
+
+	// SinkFilesMountPath is the directory the sink is projected to with the
+	// FilesProjection, it holds a file per environment variable of the
+	// EnvVarsProjection, and the OIDC token in the token file.
+	SinkFilesMountPath = "/knative/sinkbinding"
+)
+
 const (
 	// SinkBindingConditionReady is configured to indicate whether the Binding
 	// has been configured for resources subject to its runtime contract.
@@ -101,6 +122,10 @@ type SinkBindingStatus struct {
 	// OIDCTokenSecretName is the name of the secret containing the token for
 	// this SinkBindings OIDC authentication
 	OIDCTokenSecretName *string `json:"oidcTokenSecretName,omitempty"`
+
+	// SinkFilesConfigMapName is the name of the ConfigMap holding the files
+	// of the FilesProjection of the sink.
+	SinkFilesConfigMapName *string `json:"sinkFilesConfigMapName,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"knative.dev/pkg/apis"
+
+	"knative.dev/eventing/pkg/ceoverrides"
 )
 
 // Validate implements apis.Validatable
@@ -36,5 +38,12 @@ func (fbs *SinkBindingSpec) Validate(ctx context.Context) *apis.FieldError {
 	err := fbs.Subject.Validate(ctx).ViaField("subject").Also(
 		fbs.Sink.Validate(ctx).ViaField("sink"))
 	err = err.Also(fbs.SourceSpec.Validate(ctx))
+	err = err.Also(ceoverrides.Validate(fbs.CloudEventOverrides).ViaField("ceOverrides"))
+	switch fbs.Projection {
+	case "", EnvVarsProjection, FilesProjection:
+	// Projection is valid.
+	default:
+		err = err.Also(apis.ErrInvalidValue(fbs.Projection, "projection"))
+	}
 	return err
 }
@@ -146,6 +146,36 @@ func TestSinkBindingValidation(t *testing.T) {
 			"spec.ceOverrides.extensions",
 			"keys are expected to be alphanumeric",
 		),
+	}, {
+		name: "invalid projection",
+		in: &SinkBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gabo",
+				Namespace: "test",
+			},
+			Spec: SinkBindingSpec{
+				BindingSpec: duckv1.BindingSpec{
+					Subject: tracker.Reference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "jeanne",
+						Namespace:  "test",
+					},
+				},
+				SourceSpec: duckv1.SourceSpec{
+					Sink: duckv1.Destination{
+						Ref: &duckv1.KReference{
+							APIVersion: "serving.knative.dev/v1",
+							Kind:       "Service",
+							Name:       "gemma",
+							Namespace:  "test",
+						},
+					},
+				},
+				Projection: "Volume",
+			},
+		},
+		want: apis.ErrInvalidValue("Volume", "spec.projection"),
 	}}
 
 	for _, test := range tests {
@@ -24,7 +24,9 @@ package v1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	duckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	apisduckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -125,6 +127,27 @@ func (in *ApiServerSourceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApiServerSourceMonitoring) DeepCopyInto(out *ApiServerSourceMonitoring) {
+	*out = *in
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(apisduckv1.Destination)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApiServerSourceMonitoring.
+func (in *ApiServerSourceMonitoring) DeepCopy() *ApiServerSourceMonitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(ApiServerSourceMonitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApiServerSourceSpec) DeepCopyInto(out *ApiServerSourceSpec) {
 	*out = *in
@@ -153,6 +176,16 @@ func (in *ApiServerSourceSpec) DeepCopyInto(out *ApiServerSourceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(duckv1.DeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(ApiServerSourceMonitoring)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -170,6 +203,7 @@ func (in *ApiServerSourceSpec) DeepCopy() *ApiServerSourceSpec {
 func (in *ApiServerSourceStatus) DeepCopyInto(out *ApiServerSourceStatus) {
 	*out = *in
 	in.SourceStatus.DeepCopyInto(&out.SourceStatus)
+	in.DeliveryStatus.DeepCopyInto(&out.DeliveryStatus)
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
@@ -349,6 +383,11 @@ func (in *PingSourceList) DeepCopyObject() runtime.Object {
 func (in *PingSourceSpec) DeepCopyInto(out *PingSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -467,6 +506,11 @@ func (in *SinkBindingStatus) DeepCopyInto(out *SinkBindingStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SinkFilesConfigMapName != nil {
+		in, out := &in.SinkFilesConfigMapName, &out.SinkFilesConfigMapName
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
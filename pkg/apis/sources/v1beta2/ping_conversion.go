@@ -37,6 +37,7 @@ func (source *PingSource) ConvertTo(ctx context.Context, obj apis.Convertible) e
 			SourceSpec:  source.Spec.SourceSpec,
 			Schedule:    source.Spec.Schedule,
 			Timezone:    source.Spec.Timezone,
+			Jitter:      source.Spec.Jitter,
 			ContentType: source.Spec.ContentType,
 			Data:        source.Spec.Data,
 			DataBase64:  source.Spec.DataBase64,
@@ -62,6 +63,7 @@ func (sink *PingSource) ConvertFrom(ctx context.Context, obj apis.Convertible) e
 			SourceSpec:  source.Spec.SourceSpec,
 			Schedule:    source.Spec.Schedule,
 			Timezone:    source.Spec.Timezone,
+			Jitter:      source.Spec.Jitter,
 			ContentType: source.Spec.ContentType,
 			Data:        source.Spec.Data,
 			DataBase64:  source.Spec.DataBase64,
@@ -21,7 +21,11 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+
+	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 )
 
 // implement apis.Convertible
@@ -46,3 +50,27 @@ func TestPingSourceConversionBadType(t *testing.T) {
 		t.Errorf("ConvertFrom() = %#v, wanted error", good)
 	}
 }
+
+func TestPingSourceConversionRoundTrip(t *testing.T) {
+	source := &PingSource{
+		Spec: PingSourceSpec{
+			Schedule:    "*/2 * * * *",
+			Timezone:    "Europe/Paris",
+			Jitter:      ptr.String("PT30S"),
+			ContentType: "text/plain",
+			Data:        "data",
+		},
+	}
+
+	sink := &v1.PingSource{}
+	if err := source.ConvertTo(context.Background(), sink); err != nil {
+		t.Fatal("ConvertTo() =", err)
+	}
+	got := &PingSource{}
+	if err := got.ConvertFrom(context.Background(), sink); err != nil {
+		t.Fatal("ConvertFrom() =", err)
+	}
+	if diff := cmp.Diff(source, got); diff != "" {
+		t.Error("Unexpected round trip (-want, +got):", diff)
+	}
+}
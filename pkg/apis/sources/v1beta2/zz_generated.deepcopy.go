@@ -90,6 +90,11 @@ func (in *PingSourceList) DeepCopyObject() runtime.Object {
 func (in *PingSourceSpec) DeepCopyInto(out *PingSourceSpec) {
 	*out = *in
 	in.SourceSpec.DeepCopyInto(&out.SourceSpec)
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -17,7 +17,9 @@ limitations under the License.
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,3 +39,27 @@ func GetAudienceDirect(gvk schema.GroupVersionKind, ns, name string) string {
 
 	return strings.ToLower(aud)
 }
+
+const (
+	// audienceMismatchDescription is the error description of the
+	// WWW-Authenticate header of the responses rejecting a token issued for
+	// another audience.
+	audienceMismatchDescription = "token audience mismatch"
+)
+
+// ErrAudienceMismatch is returned when a JWT is valid but was issued for
+// another audience, which usually means the sender uses a stale audience.
+var ErrAudienceMismatch = errors.New(audienceMismatchDescription)
+
+// SetAudienceMismatch sets the WWW-Authenticate header of a 401 response to
+// tell the client that its token was issued for another audience, following
+// https://www.rfc-editor.org/rfc/rfc6750#section-3.
+func SetAudienceMismatch(header http.Header) {
+	header.Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, audienceMismatchDescription))
+}
+
+// IsAudienceMismatch returns true if the response headers tell that the
+// token was rejected because it was issued for another audience.
+func IsAudienceMismatch(header http.Header) bool {
+	return strings.Contains(header.Get("WWW-Authenticate"), audienceMismatchDescription)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package auth
 
 import (
+	"net/http"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,3 +64,20 @@ func TestGetAudience(t *testing.T) {
 		})
 	}
 }
+
+func TestAudienceMismatch(t *testing.T) {
+	header := http.Header{}
+	if IsAudienceMismatch(header) {
+		t.Error("IsAudienceMismatch() = true for a response without WWW-Authenticate header")
+	}
+
+	header.Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+	if IsAudienceMismatch(header) {
+		t.Error("IsAudienceMismatch() = true for another invalid token error")
+	}
+
+	SetAudienceMismatch(header)
+	if !IsAudienceMismatch(header) {
+		t.Errorf("IsAudienceMismatch() = false, WWW-Authenticate: %s", header.Get("WWW-Authenticate"))
+	}
+}
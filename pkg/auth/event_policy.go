@@ -17,8 +17,10 @@ limitations under the License.
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
@@ -92,6 +94,38 @@ func GetEventPoliciesForResource(lister listerseventingv1alpha1.EventPolicyListe
 	return relevantPolicies, nil
 }
 
+// GetEventPoliciesTargetingResource returns the EventPolicies whose .spec.to
+// targets the given resource, leaving out the ones applying to all the
+// resources of its namespace.
+func GetEventPoliciesTargetingResource(lister listerseventingv1alpha1.EventPolicyLister, resourceGVK schema.GroupVersionKind, resourceObjectMeta metav1.ObjectMeta) ([]*v1alpha1.EventPolicy, error) {
+	policies, err := GetEventPoliciesForResource(lister, resourceGVK, resourceObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	targeting := make([]*v1alpha1.EventPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if len(policy.Spec.To) > 0 {
+			targeting = append(targeting, policy)
+		}
+	}
+	return targeting, nil
+}
+
+// SubjectAllowed returns true if one of the policies, within its validity
+// window at now, allows the given OIDC subject.
+func SubjectAllowed(policies []*v1alpha1.EventPolicy, sub string, now time.Time) bool {
+	for _, policy := range policies {
+		if !policy.EffectiveValidity().IsActiveAt(now) {
+			continue
+		}
+		if SubjectContained(sub, policy.Status.From) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetApplyingResourcesOfEventPolicyForGK returns all applying resource names of GK of the given event policy.
 // It returns only the names, as the resources are part of the same namespace as the event policy.
 //
@@ -158,13 +192,17 @@ func GetApplyingResourcesOfEventPolicyForGK(eventPolicy *v1alpha1.EventPolicy, g
 }
 
 // ResolveSubjects returns the OIDC service accounts names for the objects referenced in the EventPolicySpecFrom.
+// All the references are resolved, the returned error names every reference
+// which could not be resolved.
 func ResolveSubjects(resolver *resolver.AuthenticatableResolver, eventPolicy *v1alpha1.EventPolicy) ([]string, error) {
 	allSAs := []string{}
+	var errs []error
 	for _, from := range eventPolicy.Spec.From {
 		if from.Ref != nil {
 			sas, err := resolveSubjectsFromReference(resolver, *from.Ref, eventPolicy)
 			if err != nil {
-				return nil, fmt.Errorf("could not resolve subjects from reference: %w", err)
+				errs = append(errs, fmt.Errorf("could not resolve subjects from reference %s %s/%s: %w", from.Ref.Kind, from.Ref.Namespace, from.Ref.Name, err))
+				continue
 			}
 			allSAs = append(allSAs, sas...)
 		} else if from.Sub != nil {
@@ -172,6 +210,9 @@ func ResolveSubjects(resolver *resolver.AuthenticatableResolver, eventPolicy *v1
 		}
 	}
 
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	return allSAs, nil
 }
 
@@ -309,11 +350,15 @@ func UpdateStatusWithEventPolicies(featureFlags feature.Flags, status *eventingd
 
 	if len(applyingEvenPolicies) > 0 {
 		unreadyEventPolicies := []string{}
+		inactiveEventPolicies := []string{}
 		for _, policy := range applyingEvenPolicies {
 			if !policy.Status.IsReady() {
 				unreadyEventPolicies = append(unreadyEventPolicies, policy.Name)
+			} else if !policy.Status.IsActive() {
+				// inactive policies still apply, but grant no access
+				inactiveEventPolicies = append(inactiveEventPolicies, policy.Name)
 			} else {
-				// only add Ready policies to the list
+				// only add Ready and active policies to the list
 				status.Policies = append(status.Policies, eventingduckv1.AppliedEventPolicyRef{
 					Name:       policy.Name,
 					APIVersion: v1alpha1.SchemeGroupVersion.String(),
@@ -321,7 +366,9 @@ func UpdateStatusWithEventPolicies(featureFlags feature.Flags, status *eventingd
 			}
 		}
 
-		if len(unreadyEventPolicies) == 0 {
+		if len(unreadyEventPolicies) == 0 && len(status.Policies) == 0 {
+			statusMarker.MarkEventPoliciesTrueWithReason("EventPoliciesInactive", "event policies %s are not active", strings.Join(inactiveEventPolicies, ", "))
+		} else if len(unreadyEventPolicies) == 0 {
 			statusMarker.MarkEventPoliciesTrue()
 		} else {
 			statusMarker.MarkEventPoliciesFailed("EventPoliciesNotReady", "event policies %s are not ready", strings.Join(unreadyEventPolicies, ", "))
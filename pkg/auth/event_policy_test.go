@@ -21,16 +21,20 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/strings/slices"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/client/clientset/versioned/scheme"
 	brokerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
@@ -529,6 +533,8 @@ func TestResolveSubjects(t *testing.T) {
 		objects []runtime.Object
 		want    []string
 		wantErr bool
+		// wantErrRefs are the references named in the error.
+		wantErrRefs []string
 	}{
 		{
 			name: "simple",
@@ -660,6 +666,33 @@ func TestResolveSubjects(t *testing.T) {
 			},
 			want:    nil,
 			wantErr: true,
+		}, {
+			name: "references not found",
+			froms: []v1alpha1.EventPolicySpecFrom{
+				{
+					Ref: &v1alpha1.EventPolicyFromReference{
+						APIVersion: "sources.knative.dev/v1",
+						Kind:       "PingSource",
+						Name:       "my-pingsource",
+						Namespace:  namespace,
+					},
+				}, {
+					Sub: ptr.String("my-sub"),
+				}, {
+					Ref: &v1alpha1.EventPolicyFromReference{
+						APIVersion: "sources.knative.dev/v1",
+						Kind:       "ApiServerSource",
+						Name:       "my-source",
+						Namespace:  namespace,
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			wantErrRefs: []string{
+				"PingSource my-ns/my-pingsource",
+				"ApiServerSource my-ns/my-source",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -684,6 +717,11 @@ func TestResolveSubjects(t *testing.T) {
 				t.Errorf("ResolveSubjects() error = %v, wantErr %v", gotErr, tt.wantErr)
 				return
 			}
+			for _, ref := range tt.wantErrRefs {
+				if !strings.Contains(gotErr.Error(), ref) {
+					t.Errorf("ResolveSubjects() error = %v, want it to name %s", gotErr, ref)
+				}
+			}
 
 			if !cmp.Equal(got, tt.want) {
 				t.Errorf("Unexpected object (-want, +got) =\n%s", cmp.Diff(got, tt.want))
@@ -769,6 +807,51 @@ func TestSubjectContained(t *testing.T) {
 	}
 }
 
+func TestSubjectAllowed(t *testing.T) {
+	now := time.Date(2024, 6, 1, 2, 0, 0, 0, time.UTC)
+	expired := metav1.NewTime(now.Add(-time.Hour))
+
+	policies := []*v1alpha1.EventPolicy{{
+		Spec: v1alpha1.EventPolicySpec{
+			Validity: &v1alpha1.EventPolicyValidity{NotAfter: &expired},
+		},
+		Status: v1alpha1.EventPolicyStatus{
+			From: []string{"system:serviceaccounts:my-ns:expired-sa"},
+		},
+	}, {
+		Status: v1alpha1.EventPolicyStatus{
+			From: []string{"system:serviceaccounts:my-ns:my-sa", "system:serviceaccounts:other-ns:*"},
+		},
+	}}
+
+	tests := []struct {
+		name string
+		sub  string
+		want bool
+	}{{
+		name: "allowed subject",
+		sub:  "system:serviceaccounts:my-ns:my-sa",
+		want: true,
+	}, {
+		name: "allowed pattern",
+		sub:  "system:serviceaccounts:other-ns:any-sa",
+		want: true,
+	}, {
+		name: "subject of an expired policy",
+		sub:  "system:serviceaccounts:my-ns:expired-sa",
+	}, {
+		name: "unknown subject",
+		sub:  "system:serviceaccounts:my-ns:unknown-sa",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubjectAllowed(policies, tt.sub, now); got != tt.want {
+				t.Errorf("SubjectAllowed(%q) = %v, want %v", tt.sub, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetApplyingResourcesOfEventPolicyForGK(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -1134,3 +1217,92 @@ func TestEventPolicyEventHandler_Update(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateStatusWithEventPolicies(t *testing.T) {
+	readyPolicy := func(name string, active bool) *v1alpha1.EventPolicy {
+		ep := &v1alpha1.EventPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "my-namespace",
+			},
+		}
+		ep.Status.InitializeConditions()
+		ep.Status.MarkRefsResolved()
+		ep.Status.MarkSubjectsResolved()
+		ep.Status.MarkOIDCEnabled()
+		if active {
+			ep.Status.MarkActive()
+		} else {
+			ep.Status.MarkInactive("Expired", "")
+		}
+		return ep
+	}
+
+	tests := []struct {
+		name             string
+		existingPolicies []*v1alpha1.EventPolicy
+		wantPolicies     []string
+		wantReason       string
+	}{
+		{
+			name: "active policy is applied",
+			existingPolicies: []*v1alpha1.EventPolicy{
+				readyPolicy("my-policy-1", true),
+			},
+			wantPolicies: []string{"my-policy-1"},
+		}, {
+			name: "inactive policy is not applied",
+			existingPolicies: []*v1alpha1.EventPolicy{
+				readyPolicy("my-policy-1", true),
+				readyPolicy("my-policy-2", false),
+			},
+			wantPolicies: []string{"my-policy-1"},
+		}, {
+			name: "only inactive policies do not fall back to the default mode",
+			existingPolicies: []*v1alpha1.EventPolicy{
+				readyPolicy("my-policy-1", false),
+			},
+			wantReason: "EventPoliciesInactive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+			for _, p := range tt.existingPolicies {
+				if err := eventpolicyinformerfake.Get(ctx).Informer().GetStore().Add(p); err != nil {
+					t.Fatalf("error adding policies: %v", err)
+				}
+			}
+
+			imc := &messagingv1.InMemoryChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-channel",
+					Namespace: "my-namespace",
+				},
+			}
+			imc.Status.InitializeConditions()
+
+			err := UpdateStatusWithEventPolicies(feature.Flags{}, &imc.Status.AppliedEventPoliciesStatus, &imc.Status, eventpolicyinformerfake.Get(ctx).Lister(), messagingv1.SchemeGroupVersion.WithKind("InMemoryChannel"), imc.ObjectMeta)
+			if err != nil {
+				t.Fatalf("UpdateStatusWithEventPolicies() error = %v", err)
+			}
+
+			gotPolicies := []string{}
+			for _, p := range imc.Status.Policies {
+				gotPolicies = append(gotPolicies, p.Name)
+			}
+			if diff := cmp.Diff(tt.wantPolicies, gotPolicies, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected applied policies (-want, +got) = %s", diff)
+			}
+
+			cond := imc.Status.GetCondition(messagingv1.InMemoryChannelConditionEventPoliciesReady)
+			if !cond.IsTrue() {
+				t.Errorf("expected EventPoliciesReady condition to be true, got %v", cond)
+			}
+			if cond.Reason != tt.wantReason {
+				t.Errorf("unexpected EventPoliciesReady reason, want %q, got %q", tt.wantReason, cond.Reason)
+			}
+		})
+	}
+}
@@ -43,8 +43,25 @@ const (
 
 	// OIDCTokenRoleLabelSelector is the label selector for the OIDC token creator role and rolebinding informers
 	OIDCLabelSelector = OIDCLabelKey
+
+	// PooledOIDCServiceAccountName is the name of the OIDC service account
+	// shared by all resources of a namespace, when the
+	// authentication-oidc-shared-identity feature is enabled.
+	PooledOIDCServiceAccountName = "knative-eventing-oidc-identity"
 )
 
+// GetOIDCServiceAccountName returns the name of the OIDC service account the
+// given resource uses, which is either the pooled service account of the
+// namespace or the service account of the resource. With the pooled service
+// account, the resources of a namespace share the same OIDC subject, so that
+// an EventPolicy allowing one of them allows all of them.
+func GetOIDCServiceAccountName(flags feature.Flags, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) string {
+	if flags.IsOIDCSharedIdentity() {
+		return PooledOIDCServiceAccountName
+	}
+	return GetOIDCServiceAccountNameForResource(gvk, objectMeta)
+}
+
 // GetOIDCServiceAccountNameForResource returns the service account name to use
 // for OIDC authentication for the given resource.
 func GetOIDCServiceAccountNameForResource(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) string {
@@ -62,14 +79,7 @@ func GetOIDCServiceAccountForResource(gvk schema.GroupVersionKind, objectMeta me
 			Name:      GetOIDCServiceAccountNameForResource(gvk, objectMeta),
 			Namespace: objectMeta.GetNamespace(),
 			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         gvk.GroupKind().Group + "/" + gvk.GroupVersion().Version,
-					Kind:               gvk.GroupKind().Kind,
-					Name:               objectMeta.GetName(),
-					UID:                objectMeta.GetUID(),
-					Controller:         ptr.Bool(true),
-					BlockOwnerDeletion: ptr.Bool(false),
-				},
+				oidcOwnerReference(gvk, objectMeta, true),
 			},
 			Annotations: map[string]string{
 				"description": fmt.Sprintf("Service Account for OIDC Authentication for %s %q", gvk.GroupKind().Kind, objectMeta.Name),
@@ -81,6 +91,142 @@ func GetOIDCServiceAccountForResource(gvk schema.GroupVersionKind, objectMeta me
 	}
 }
 
+// IsPooledOIDCSubject returns true when the OIDC subject is the pooled service
+// account of a namespace.
+func IsPooledOIDCSubject(sub string) bool {
+	parts := strings.Split(sub, ":")
+	return len(parts) == 4 && parts[0] == "system" && parts[1] == "serviceaccount" && parts[3] == PooledOIDCServiceAccountName
+}
+
+// GetPooledOIDCServiceAccount returns the OIDC service account shared by the
+// resources of the given namespace. The resources using it are added as owners.
+func GetPooledOIDCServiceAccount(namespace string) *v1.ServiceAccount {
+	return &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PooledOIDCServiceAccountName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				"description": fmt.Sprintf("Shared Service Account for OIDC Authentication for the resources in namespace %q", namespace),
+			},
+			Labels: map[string]string{
+				OIDCLabelKey: "enabled",
+			},
+		},
+	}
+}
+
+func oidcOwnerReference(gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta, controller bool) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         gvk.GroupKind().Group + "/" + gvk.GroupVersion().Version,
+		Kind:               gvk.GroupKind().Kind,
+		Name:               objectMeta.GetName(),
+		UID:                objectMeta.GetUID(),
+		Controller:         ptr.Bool(controller),
+		BlockOwnerDeletion: ptr.Bool(false),
+	}
+}
+
+// EnsureOIDCServiceAccountForResource makes sure the OIDC service account the
+// given resource uses exists. Depending on the feature flags this is either the
+// pooled service account of the namespace or the service account of the
+// resource. The service account of the other kind is cleaned up.
+func EnsureOIDCServiceAccountForResource(ctx context.Context, flags feature.Flags, serviceAccountLister corev1listers.ServiceAccountLister, kubeclient kubernetes.Interface, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) error {
+	if flags.IsOIDCSharedIdentity() {
+		if err := EnsurePooledOIDCServiceAccountForResource(ctx, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
+			return err
+		}
+		return DeleteOIDCServiceAccountIfExists(ctx, serviceAccountLister, kubeclient, gvk, objectMeta)
+	}
+
+	if err := EnsureOIDCServiceAccountExistsForResource(ctx, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
+		return err
+	}
+	return ReleasePooledOIDCServiceAccountForResource(ctx, serviceAccountLister, kubeclient, gvk, objectMeta)
+}
+
+// EnsurePooledOIDCServiceAccountForResource makes sure the pooled OIDC service
+// account of the resources namespace exists and has an owner reference to the
+// resource, so that it gets garbage collected once no resource uses it anymore.
+func EnsurePooledOIDCServiceAccountForResource(ctx context.Context, serviceAccountLister corev1listers.ServiceAccountLister, kubeclient kubernetes.Interface, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) error {
+	ownerRef := oidcOwnerReference(gvk, objectMeta, false)
+	sa, err := serviceAccountLister.ServiceAccounts(objectMeta.Namespace).Get(PooledOIDCServiceAccountName)
+
+	// If the resource doesn't exist, we'll create it.
+	if apierrs.IsNotFound(err) {
+		logging.FromContext(ctx).Debugw("Creating pooled OIDC service account", zap.Error(err))
+
+		expected := GetPooledOIDCServiceAccount(objectMeta.Namespace)
+		expected.OwnerReferences = []metav1.OwnerReference{ownerRef}
+
+		_, err = kubeclient.CoreV1().ServiceAccounts(objectMeta.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("could not create pooled OIDC service account in %s for %s %s: %w", objectMeta.Namespace, gvk.Kind, objectMeta.Name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not get pooled OIDC service account in %s for %s %s: %w", objectMeta.Namespace, gvk.Kind, objectMeta.Name, err)
+	}
+
+	for _, ref := range sa.OwnerReferences {
+		if ref.UID == objectMeta.UID {
+			return nil
+		}
+	}
+
+	sa = sa.DeepCopy()
+	sa.OwnerReferences = append(sa.OwnerReferences, ownerRef)
+	_, err = kubeclient.CoreV1().ServiceAccounts(objectMeta.Namespace).Update(ctx, sa, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("could not add %s %s as owner of the pooled OIDC service account in %s: %w", gvk.Kind, objectMeta.Name, objectMeta.Namespace, err)
+	}
+
+	return nil
+}
+
+// ReleasePooledOIDCServiceAccountForResource removes the owner reference to the
+// given resource from the pooled OIDC service account of its namespace, if any.
+func ReleasePooledOIDCServiceAccountForResource(ctx context.Context, serviceAccountLister corev1listers.ServiceAccountLister, kubeclient kubernetes.Interface, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) error {
+	sa, err := serviceAccountLister.ServiceAccounts(objectMeta.Namespace).Get(PooledOIDCServiceAccountName)
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get pooled OIDC service account in %s for %s %s: %w", objectMeta.Namespace, gvk.Kind, objectMeta.Name, err)
+	}
+
+	ownerRefs := make([]metav1.OwnerReference, 0, len(sa.OwnerReferences))
+	for _, ref := range sa.OwnerReferences {
+		if ref.UID != objectMeta.UID {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	if len(ownerRefs) == len(sa.OwnerReferences) {
+		return nil
+	}
+
+	if len(ownerRefs) == 0 {
+		logging.FromContext(ctx).Debugf("Deleting pooled OIDC service account in %s, as it is not used anymore", objectMeta.Namespace)
+
+		err = kubeclient.CoreV1().ServiceAccounts(objectMeta.Namespace).Delete(ctx, sa.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("could not delete pooled OIDC service account in %s: %w", objectMeta.Namespace, err)
+		}
+		return nil
+	}
+
+	sa = sa.DeepCopy()
+	sa.OwnerReferences = ownerRefs
+	_, err = kubeclient.CoreV1().ServiceAccounts(objectMeta.Namespace).Update(ctx, sa, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("could not remove %s %s as owner of the pooled OIDC service account in %s: %w", gvk.Kind, objectMeta.Name, objectMeta.Namespace, err)
+	}
+
+	return nil
+}
+
 // EnsureOIDCServiceAccountExistsForResource makes sure the given resource has
 // an OIDC service account with an owner reference to the resource set.
 func EnsureOIDCServiceAccountExistsForResource(ctx context.Context, serviceAccountLister corev1listers.ServiceAccountLister, kubeclient kubernetes.Interface, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta) error {
@@ -140,11 +286,11 @@ type OIDCIdentityStatusMarker interface {
 
 func SetupOIDCServiceAccount(ctx context.Context, flags feature.Flags, serviceAccountLister corev1listers.ServiceAccountLister, kubeclient kubernetes.Interface, gvk schema.GroupVersionKind, objectMeta metav1.ObjectMeta, marker OIDCIdentityStatusMarker, setAuthStatus func(a *duckv1.AuthStatus)) pkgreconciler.Event {
 	if flags.IsOIDCAuthentication() {
-		saName := GetOIDCServiceAccountNameForResource(gvk, objectMeta)
+		saName := GetOIDCServiceAccountName(flags, gvk, objectMeta)
 		setAuthStatus(&duckv1.AuthStatus{
 			ServiceAccountName: &saName,
 		})
-		if err := EnsureOIDCServiceAccountExistsForResource(ctx, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
+		if err := EnsureOIDCServiceAccountForResource(ctx, flags, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
 			marker.MarkOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication", "%v", err)
 			return err
 		}
@@ -153,6 +299,9 @@ func SetupOIDCServiceAccount(ctx context.Context, flags feature.Flags, serviceAc
 		if err := DeleteOIDCServiceAccountIfExists(ctx, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
 			return err
 		}
+		if err := ReleasePooledOIDCServiceAccountForResource(ctx, serviceAccountLister, kubeclient, gvk, objectMeta); err != nil {
+			return err
+		}
 		setAuthStatus(nil)
 		marker.MarkOIDCIdentityCreatedSucceededWithReason(fmt.Sprintf("%s feature disabled", feature.OIDCAuthentication), "")
 	}
@@ -289,3 +289,100 @@ func TestDeleteOIDCServiceAccountIfExists(t *testing.T) {
 		t.Errorf("DeleteOIDCServiceAccountIfExists failed to delete the serviceAccount: %+v", sa)
 	}
 }
+
+func TestPooledOIDCServiceAccount(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+	gvk := eventingv1.SchemeGroupVersion.WithKind("Trigger")
+	trigger1 := metav1.ObjectMeta{
+		Name:      "my-trigger-1",
+		Namespace: "my-namespace",
+		UID:       "my-uuid-1",
+	}
+	trigger2 := metav1.ObjectMeta{
+		Name:      "my-trigger-2",
+		Namespace: "my-namespace",
+		UID:       "my-uuid-2",
+	}
+	flags := feature.Flags{
+		feature.OIDCAuthentication: feature.Enabled,
+		feature.OIDCSharedIdentity: feature.Enabled,
+	}
+
+	if got := GetOIDCServiceAccountName(flags, gvk, trigger1); got != PooledOIDCServiceAccountName {
+		t.Errorf("GetOIDCServiceAccountName() = %q, want %q", got, PooledOIDCServiceAccountName)
+	}
+
+	getPooledSA := func() *v1.ServiceAccount {
+		sa, err := kubeclient.Get(ctx).CoreV1().ServiceAccounts("my-namespace").Get(ctx, PooledOIDCServiceAccountName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("could not get pooled service account: %s", err)
+		}
+		return sa
+	}
+	ownerUIDs := func(sa *v1.ServiceAccount) []string {
+		uids := []string{}
+		for _, ref := range sa.OwnerReferences {
+			uids = append(uids, string(ref.UID))
+			if *ref.Controller {
+				t.Errorf("pooled service account must not have a controller, got %+v", ref)
+			}
+		}
+		return uids
+	}
+
+	// The first resource creates the pooled service account.
+	listers := rttestingv1.NewListers(nil)
+	if err := EnsureOIDCServiceAccountForResource(ctx, flags, listers.GetServiceAccountLister(), kubeclient.Get(ctx), gvk, trigger1); err != nil {
+		t.Fatalf("EnsureOIDCServiceAccountForResource failed: %s", err)
+	}
+	if diff := cmp.Diff([]string{"my-uuid-1"}, ownerUIDs(getPooledSA())); diff != "" {
+		t.Errorf("unexpected owners (-want, +got) = %s", diff)
+	}
+
+	// The second resource is added as owner, the per-resource service account is removed.
+	perResourceSA := GetOIDCServiceAccountForResource(gvk, trigger2)
+	if _, err := kubeclient.Get(ctx).CoreV1().ServiceAccounts("my-namespace").Create(ctx, perResourceSA, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("could not create service account: %s", err)
+	}
+	listers = rttestingv1.NewListers([]runtime.Object{getPooledSA(), perResourceSA})
+	if err := EnsureOIDCServiceAccountForResource(ctx, flags, listers.GetServiceAccountLister(), kubeclient.Get(ctx), gvk, trigger2); err != nil {
+		t.Fatalf("EnsureOIDCServiceAccountForResource failed: %s", err)
+	}
+	if diff := cmp.Diff([]string{"my-uuid-1", "my-uuid-2"}, ownerUIDs(getPooledSA())); diff != "" {
+		t.Errorf("unexpected owners (-want, +got) = %s", diff)
+	}
+	if _, err := kubeclient.Get(ctx).CoreV1().ServiceAccounts("my-namespace").Get(ctx, perResourceSA.Name, metav1.GetOptions{}); err == nil {
+		t.Errorf("expected per-resource service account to be deleted")
+	}
+
+	// Releasing the first resource keeps the pooled service account.
+	listers = rttestingv1.NewListers([]runtime.Object{getPooledSA()})
+	if err := ReleasePooledOIDCServiceAccountForResource(ctx, listers.GetServiceAccountLister(), kubeclient.Get(ctx), gvk, trigger1); err != nil {
+		t.Fatalf("ReleasePooledOIDCServiceAccountForResource failed: %s", err)
+	}
+	if diff := cmp.Diff([]string{"my-uuid-2"}, ownerUIDs(getPooledSA())); diff != "" {
+		t.Errorf("unexpected owners (-want, +got) = %s", diff)
+	}
+
+	// Releasing the last resource deletes the pooled service account.
+	listers = rttestingv1.NewListers([]runtime.Object{getPooledSA()})
+	if err := ReleasePooledOIDCServiceAccountForResource(ctx, listers.GetServiceAccountLister(), kubeclient.Get(ctx), gvk, trigger2); err != nil {
+		t.Fatalf("ReleasePooledOIDCServiceAccountForResource failed: %s", err)
+	}
+	if _, err := kubeclient.Get(ctx).CoreV1().ServiceAccounts("my-namespace").Get(ctx, PooledOIDCServiceAccountName, metav1.GetOptions{}); err == nil {
+		t.Errorf("expected pooled service account to be deleted")
+	}
+}
+
+func TestIsPooledOIDCSubject(t *testing.T) {
+	for sub, want := range map[string]bool{
+		"system:serviceaccount:my-namespace:" + PooledOIDCServiceAccountName: true,
+		"system:serviceaccount:my-namespace:my-trigger-oidc":                 false,
+		"system:serviceaccounts:my-namespace:*":                              false,
+		PooledOIDCServiceAccountName:                                         false,
+	} {
+		if got := IsPooledOIDCSubject(sub); got != want {
+			t.Errorf("IsPooledOIDCSubject(%q) = %v, want %v", sub, got, want)
+		}
+	}
+}
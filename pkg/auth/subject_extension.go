@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"knative.dev/eventing/pkg/signing"
+)
+
+const (
+	// AuthSubjectExtension is the CloudEvents extension carrying the OIDC
+	// identity of the producer of an event, as verified by the Broker ingress.
+	AuthSubjectExtension = "authsubject"
+
+	// AuthSubjectSignatureExtension is the CloudEvents extension carrying the
+	// base64 encoded HMAC-SHA256 signature binding AuthSubjectExtension to
+	// the id and the source of the event.
+	AuthSubjectSignatureExtension = "authsubjectsignature"
+
+	// AuthSubjectKeyIDExtension is the CloudEvents extension carrying the id
+	// of the key that produced AuthSubjectSignatureExtension.
+	AuthSubjectKeyIDExtension = "authsubjectkeyid"
+)
+
+// ErrMissingAuthSubject is returned when an event doesn't carry the identity
+// of its producer.
+var ErrMissingAuthSubject = errors.New("event has no auth subject")
+
+// SetAuthSubject sets the OIDC identity of the producer of the event, without
+// signature. An empty subject removes the extensions, so that the producers
+// can't claim an identity that wasn't verified.
+func SetAuthSubject(event *cloudevents.Event, subject string) {
+	event.SetExtension(AuthSubjectSignatureExtension, nil)
+	event.SetExtension(AuthSubjectKeyIDExtension, nil)
+	if subject == "" {
+		event.SetExtension(AuthSubjectExtension, nil)
+		return
+	}
+	event.SetExtension(AuthSubjectExtension, subject)
+}
+
+// SignAuthSubject sets the OIDC identity of the producer of the event along
+// with its signature by the key. An empty subject removes the extensions.
+func SignAuthSubject(event *cloudevents.Event, subject string, key signing.Key) error {
+	if subject == "" {
+		SetAuthSubject(event, "")
+		return nil
+	}
+	if len(key.Secret) == 0 {
+		return errors.New("signing key is empty")
+	}
+	event.SetExtension(AuthSubjectExtension, subject)
+	event.SetExtension(AuthSubjectKeyIDExtension, key.ID)
+	event.SetExtension(AuthSubjectSignatureExtension, base64.StdEncoding.EncodeToString(authSubjectDigest(event, subject, key)))
+	return nil
+}
+
+// GetAuthSubject returns the OIDC identity of the producer of the event,
+// without verifying its signature. The returned bool is false if the event
+// doesn't carry it.
+func GetAuthSubject(event *cloudevents.Event) (string, bool) {
+	subject, ok := event.Extensions()[AuthSubjectExtension].(string)
+	return subject, ok && subject != ""
+}
+
+// VerifyAuthSubject returns the OIDC identity of the producer of the event
+// once it checked its signature against the keyring. It returns
+// ErrMissingAuthSubject if the event doesn't carry it, and the errors of the
+// signing package if its signature can't be verified.
+func VerifyAuthSubject(event *cloudevents.Event, keys signing.Keyring) (string, error) {
+	subject, ok := GetAuthSubject(event)
+	if !ok {
+		return "", ErrMissingAuthSubject
+	}
+	encoded, ok := event.Extensions()[AuthSubjectSignatureExtension].(string)
+	if !ok {
+		return "", signing.ErrMissingSignature
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", signing.ErrInvalidSignature
+	}
+
+	keyID, _ := event.Extensions()[AuthSubjectKeyIDExtension].(string)
+	key, ok := keys[keyID]
+	if !ok {
+		return "", signing.ErrUnknownKey
+	}
+
+	if !hmac.Equal(sig, authSubjectDigest(event, subject, key)) {
+		return "", signing.ErrInvalidSignature
+	}
+	return subject, nil
+}
+
+// authSubjectDigest computes the HMAC of the subject along with the id and the
+// source of the event, so that the signature can't be moved to another event.
+// Each field is length prefixed, so that moving bytes between adjacent fields
+// changes the digest.
+func authSubjectDigest(event *cloudevents.Event, subject string, key signing.Key) []byte {
+	mac := hmac.New(sha256.New, key.Secret)
+	for _, v := range []string{event.ID(), event.Source(), subject} {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(v)))
+		mac.Write(l[:])
+		mac.Write([]byte(v))
+	}
+	return mac.Sum(nil)
+}
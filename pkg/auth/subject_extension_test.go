@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"knative.dev/eventing/pkg/signing"
+)
+
+func TestVerifyAuthSubject(t *testing.T) {
+	key1 := signing.Key{ID: "key-1", Secret: []byte("secret-1")}
+	key2 := signing.Key{ID: "key-2", Secret: []byte("secret-2")}
+	subject := "system:serviceaccounts:ns:producer"
+
+	tests := map[string]struct {
+		mutate  func(e *cloudevents.Event)
+		keys    signing.Keyring
+		wantErr error
+	}{
+		"valid signature": {
+			keys: signing.NewKeyring(key1),
+		},
+		"rotated keyring": {
+			keys: signing.NewKeyring(key2, key1),
+		},
+		"unknown key": {
+			keys:    signing.NewKeyring(key2),
+			wantErr: signing.ErrUnknownKey,
+		},
+		"tampered subject": {
+			mutate: func(e *cloudevents.Event) {
+				e.SetExtension(AuthSubjectExtension, "system:serviceaccounts:ns:other")
+			},
+			keys:    signing.NewKeyring(key1),
+			wantErr: signing.ErrInvalidSignature,
+		},
+		"signature moved to another event": {
+			mutate: func(e *cloudevents.Event) {
+				e.SetID("other")
+			},
+			keys:    signing.NewKeyring(key1),
+			wantErr: signing.ErrInvalidSignature,
+		},
+		"malformed signature": {
+			mutate: func(e *cloudevents.Event) {
+				e.SetExtension(AuthSubjectSignatureExtension, "not base64!")
+			},
+			keys:    signing.NewKeyring(key1),
+			wantErr: signing.ErrInvalidSignature,
+		},
+		"unsigned subject": {
+			mutate: func(e *cloudevents.Event) {
+				SetAuthSubject(e, subject)
+			},
+			keys:    signing.NewKeyring(key1),
+			wantErr: signing.ErrMissingSignature,
+		},
+		"no subject": {
+			mutate: func(e *cloudevents.Event) {
+				SetAuthSubject(e, "")
+			},
+			keys:    signing.NewKeyring(key1),
+			wantErr: ErrMissingAuthSubject,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			e := cloudevents.NewEvent()
+			e.SetID("id")
+			e.SetSource("/source")
+			e.SetType("dev.knative.test")
+			if err := SignAuthSubject(&e, subject, key1); err != nil {
+				t.Fatal(err)
+			}
+			if tc.mutate != nil {
+				tc.mutate(&e)
+			}
+
+			got, err := VerifyAuthSubject(&e, tc.keys)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("VerifyAuthSubject() error = %v, want %v", err, tc.wantErr)
+			}
+			if err == nil && got != subject {
+				t.Errorf("VerifyAuthSubject() = %q, want %q", got, subject)
+			}
+		})
+	}
+}
+
+func TestSetAuthSubjectRemovesSignature(t *testing.T) {
+	e := cloudevents.NewEvent()
+	e.SetID("id")
+	e.SetSource("/source")
+	if err := SignAuthSubject(&e, "subject", signing.Key{ID: "key", Secret: []byte("secret")}); err != nil {
+		t.Fatal(err)
+	}
+
+	SetAuthSubject(&e, "")
+	for _, ext := range []string{AuthSubjectExtension, AuthSubjectSignatureExtension, AuthSubjectKeyIDExtension} {
+		if _, ok := e.Extensions()[ext]; ok {
+			t.Errorf("Expected the %s extension to be removed", ext)
+		}
+	}
+}
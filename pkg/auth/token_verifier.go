@@ -19,6 +19,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -76,6 +77,12 @@ func (c *OIDCTokenVerifier) VerifyJWT(ctx context.Context, jwt, audience string)
 
 	token, err := verifier.Verify(ctx, jwt)
 	if err != nil {
+		// Tell apart the tokens which are valid but were issued for another
+		// audience, so that the client can be told to refresh its audience.
+		anyAudience := c.provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
+		if _, aerr := anyAudience.Verify(ctx, jwt); aerr == nil {
+			return nil, fmt.Errorf("could not verify JWT: %w", ErrAudienceMismatch)
+		}
 		return nil, fmt.Errorf("could not verify JWT: %w", err)
 	}
 
@@ -165,6 +172,9 @@ func (tokenVerifier *OIDCTokenVerifier) VerifyJWTFromRequest(ctx context.Context
 	}
 
 	if _, err := tokenVerifier.VerifyJWT(ctx, token, *audience); err != nil {
+		if errors.Is(err, ErrAudienceMismatch) {
+			SetAudienceMismatch(response.Header())
+		}
 		response.WriteHeader(http.StatusUnauthorized)
 		return fmt.Errorf("failed to verify JWT: %w", err)
 	}
@@ -62,3 +62,23 @@ func GetJWTExpiry(token string) (time.Time, error) {
 
 	return claims.Expiry.Time(), nil
 }
+
+// GetJWTSubject returns the subject of the token, which is expected to be
+// verified already.
+func GetJWTSubject(token string) (string, error) {
+	t, err := jwt.ParseSigned(token)
+	if err != nil {
+		return "", err
+	}
+
+	var claims jwt.Claims
+	if err := t.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return "", err
+	}
+
+	if claims.Subject == "" {
+		return "", fmt.Errorf("no subject set in JWT")
+	}
+
+	return claims.Subject, nil
+}
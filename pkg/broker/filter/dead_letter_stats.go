@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1client "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
+)
+
+// defaultDeadLetterStatsInterval is the time between two updates of the
+// status of the Triggers with events sent to their dead letter sink.
+const defaultDeadLetterStatsInterval = 30 * time.Second
+
+// DeadLetterStats counts the events of the Triggers sent to their dead letter
+// sink, and periodically adds them to the counters in the status annotations
+// of the Triggers. As the counters are shared by all the broker-filter
+// replicas, the pending counts are added to the current values of the
+// counters, and kept for the next update when it fails.
+type DeadLetterStats struct {
+	client   eventingv1client.TriggersGetter
+	logger   *zap.Logger
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[types.NamespacedName]*deadLetterCounts
+}
+
+type deadLetterCounts struct {
+	delivered int64
+	failed    int64
+	last      time.Time
+}
+
+// NewDeadLetterStats creates a DeadLetterStats updating the status of the
+// Triggers with the given client.
+func NewDeadLetterStats(client eventingv1client.TriggersGetter, logger *zap.Logger) *DeadLetterStats {
+	return &DeadLetterStats{
+		client:   client,
+		logger:   logger,
+		interval: defaultDeadLetterStatsInterval,
+		pending:  make(map[types.NamespacedName]*deadLetterCounts),
+	}
+}
+
+// Record records that an event of the Trigger was sent to its dead letter
+// sink, successfully or not.
+func (s *DeadLetterStats) Record(trigger types.NamespacedName, delivered bool) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.pending[trigger]
+	if !ok {
+		c = &deadLetterCounts{}
+		s.pending[trigger] = c
+	}
+	if delivered {
+		c.delivered++
+	} else {
+		c.failed++
+	}
+	c.last = time.Now()
+}
+
+// Start updates the status of the Triggers every interval, until ctx is done.
+func (s *DeadLetterStats) Start(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.flush(ctx)
+			}
+		}
+	}()
+}
+
+func (s *DeadLetterStats) flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[types.NamespacedName]*deadLetterCounts)
+	s.mu.Unlock()
+
+	for trigger, counts := range pending {
+		if err := s.update(ctx, trigger, counts); err != nil {
+			s.logger.Warn("Failed to update the dead letter counters of the Trigger",
+				zap.String("trigger", trigger.String()), zap.Error(err))
+			s.restore(trigger, counts)
+		}
+	}
+}
+
+func (s *DeadLetterStats) update(ctx context.Context, trigger types.NamespacedName, counts *deadLetterCounts) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		t, err := s.client.Triggers(trigger.Namespace).Get(ctx, trigger.Name, metav1.GetOptions{})
+		if apierrs.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		t = t.DeepCopy()
+		if t.Status.Annotations == nil {
+			t.Status.Annotations = make(map[string]string)
+		}
+		addCounter(t.Status.Annotations, eventing.TriggerDeadLetteredEventsStatusAnnotationKey, counts.delivered)
+		addCounter(t.Status.Annotations, eventing.TriggerDeadLetterFailuresStatusAnnotationKey, counts.failed)
+		t.Status.Annotations[eventing.TriggerLastDeadLetteredTimeStatusAnnotationKey] = counts.last.UTC().Format(time.RFC3339)
+
+		_, err = s.client.Triggers(trigger.Namespace).UpdateStatus(ctx, t, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// restore adds back counts to the pending counts of the Trigger.
+func (s *DeadLetterStats) restore(trigger types.NamespacedName, counts *deadLetterCounts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.pending[trigger]
+	if !ok {
+		s.pending[trigger] = counts
+		return
+	}
+	c.delivered += counts.delivered
+	c.failed += counts.failed
+	if counts.last.After(c.last) {
+		c.last = counts.last
+	}
+}
+
+// addCounter adds n to the counter in the given annotation. A counter that
+// isn't a number is reset.
+func addCounter(annotations map[string]string, key string, n int64) {
+	current, _ := strconv.ParseInt(annotations[key], 10, 64)
+	annotations[key] = strconv.FormatInt(current+n, 10)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/client/clientset/versioned/fake"
+)
+
+func TestDeadLetterStats(t *testing.T) {
+	ctx := context.Background()
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "trigger"},
+	}
+	trigger.Status.Annotations = map[string]string{
+		eventing.TriggerDeadLetteredEventsStatusAnnotationKey: "5",
+		"other": "value",
+	}
+	client := fake.NewSimpleClientset(trigger)
+	key := types.NamespacedName{Namespace: "ns", Name: "trigger"}
+
+	s := NewDeadLetterStats(client.EventingV1(), zap.NewNop())
+	s.Record(key, true)
+	s.Record(key, true)
+	s.Record(key, false)
+	// The counts of deleted Triggers are dropped.
+	s.Record(types.NamespacedName{Namespace: "ns", Name: "deleted"}, true)
+	s.flush(ctx)
+
+	got, err := client.EventingV1().Triggers("ns").Get(ctx, "trigger", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		eventing.TriggerDeadLetteredEventsStatusAnnotationKey: "7",
+		eventing.TriggerDeadLetterFailuresStatusAnnotationKey: "1",
+		"other": "value",
+	}
+	for k, v := range want {
+		if got.Status.Annotations[k] != v {
+			t.Errorf("Annotation %q = %q, want %q", k, got.Status.Annotations[k], v)
+		}
+	}
+	last, err := time.Parse(time.RFC3339, got.Status.Annotations[eventing.TriggerLastDeadLetteredTimeStatusAnnotationKey])
+	if err != nil || time.Since(last) > time.Minute {
+		t.Errorf("Unexpected last dead lettered time %q", got.Status.Annotations[eventing.TriggerLastDeadLetteredTimeStatusAnnotationKey])
+	}
+	if len(s.pending) != 0 {
+		t.Errorf("Expected no pending counts, got %d", len(s.pending))
+	}
+}
+
+func TestDeadLetterStats_updateFailure(t *testing.T) {
+	ctx := context.Background()
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "trigger"},
+	}
+	client := fake.NewSimpleClientset(trigger)
+	fail := true
+	client.PrependReactor("update", "triggers", func(clientgotesting.Action) (bool, runtime.Object, error) {
+		return fail, nil, errors.New("inducing failure")
+	})
+	key := types.NamespacedName{Namespace: "ns", Name: "trigger"}
+
+	s := NewDeadLetterStats(client.EventingV1(), zap.NewNop())
+	s.Record(key, true)
+	s.flush(ctx)
+
+	// The counts are kept for the next update.
+	s.Record(key, false)
+	fail = false
+	s.flush(ctx)
+
+	got, err := client.EventingV1().Triggers("ns").Get(ctx, "trigger", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := got.Status.Annotations[eventing.TriggerDeadLetteredEventsStatusAnnotationKey]; v != "1" {
+		t.Errorf("Dead lettered events = %q, want 1", v)
+	}
+	if v := got.Status.Annotations[eventing.TriggerDeadLetterFailuresStatusAnnotationKey]; v != "1" {
+		t.Errorf("Dead letter failures = %q, want 1", v)
+	}
+}
+
+func TestDeadLetterStats_nil(t *testing.T) {
+	var s *DeadLetterStats
+	s.Record(types.NamespacedName{Namespace: "ns", Name: "trigger"}, true)
+	s.Start(context.Background())
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/kncloudevents/attributes"
+)
+
+const (
+	// DeliveryFailedEventType is the type of the events reporting an event
+	// whose retries are exhausted for a Trigger without dead letter sink.
+	DeliveryFailedEventType = "dev.knative.delivery.failed"
+
+	// deliveryFailedTimeout bounds the requests to the delivery failed sink.
+	deliveryFailedTimeout = 10 * time.Second
+)
+
+// DeliveryFailure is the data of the dev.knative.delivery.failed events. It
+// describes the failed event with its metadata only, never its data.
+type DeliveryFailure struct {
+	Namespace string `json:"namespace"`
+	Broker    string `json:"broker"`
+	Trigger   string `json:"trigger"`
+
+	// Event holds the context attributes of the failed event.
+	Event FailedEvent `json:"event"`
+
+	// ErrorCode is the status code of the last attempt, if any.
+	ErrorCode int `json:"errorCode,omitempty"`
+	// ErrorDestination is the destination of the last attempt, if known.
+	ErrorDestination string `json:"errorDestination,omitempty"`
+}
+
+// FailedEvent holds the context attributes of a failed event.
+type FailedEvent struct {
+	ID      string     `json:"id"`
+	Type    string     `json:"type"`
+	Source  string     `json:"source"`
+	Subject string     `json:"subject,omitempty"`
+	Time    *time.Time `json:"time,omitempty"`
+}
+
+// MakeDeliveryFailedEvent creates the dev.knative.delivery.failed event
+// reporting that the given event couldn't be delivered to the subscriber of
+// the Trigger.
+func MakeDeliveryFailedEvent(t *eventingv1.Trigger, broker string, failed *cloudevents.Event) (cloudevents.Event, error) {
+	failure := DeliveryFailure{
+		Namespace: t.Namespace,
+		Broker:    broker,
+		Trigger:   t.Name,
+		Event: FailedEvent{
+			ID:      failed.ID(),
+			Type:    failed.Type(),
+			Source:  failed.Source(),
+			Subject: failed.Subject(),
+		},
+	}
+	if tm := failed.Time(); !tm.IsZero() {
+		failure.Event.Time = &tm
+	}
+
+	extensions := failed.Extensions()
+	if code, ok := extensions[attributes.KnativeErrorCodeExtensionKey]; ok {
+		if c, err := strconv.Atoi(fmt.Sprint(code)); err == nil {
+			failure.ErrorCode = c
+		}
+	}
+	if dest, ok := extensions[attributes.KnativeErrorDestExtensionKey]; ok {
+		failure.ErrorDestination = fmt.Sprint(dest)
+	}
+
+	e := cloudevents.NewEvent()
+	e.SetID(uuid.New().String())
+	e.SetType(DeliveryFailedEventType)
+	e.SetSource(fmt.Sprintf("/apis/%s/namespaces/%s/triggers/%s", eventingv1.SchemeGroupVersion.String(), t.Namespace, t.Name))
+	e.SetSubject(failed.ID())
+	e.SetTime(time.Now())
+	if err := e.SetData(cloudevents.ApplicationJSON, failure); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+// sendDeliveryFailed reports the failed event to the DeliveryFailedSink and
+// answers with the outcome, so that a failure to report it is surfaced to the
+// sender like a failure of a dead letter sink.
+func (h *Handler) sendDeliveryFailed(ctx context.Context, writer http.ResponseWriter, t *eventingv1.Trigger, broker string, event *cloudevents.Event) {
+	e, err := MakeDeliveryFailedEvent(t, broker, event)
+	if err != nil {
+		h.logger.Error("failed to create delivery failed event", zap.Error(err))
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	retryConfig := kncloudevents.NoRetries()
+	retryConfig.RequestTimeout = deliveryFailedTimeout
+
+	dispatchInfo, err := h.eventDispatcher.SendEvent(ctx, e, *h.DeliveryFailedSink, kncloudevents.WithRetryConfig(&retryConfig))
+	if err != nil {
+		h.logger.Warn("failed to send delivery failed event",
+			zap.String("trigger", fmt.Sprintf("%s/%s", t.Namespace, t.Name)),
+			zap.String("id", event.ID()),
+			zap.Error(err))
+		if dispatchInfo.ResponseCode <= 0 {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writer.WriteHeader(dispatchInfo.ResponseCode)
+		return
+	}
+	writer.WriteHeader(http.StatusAccepted)
+}
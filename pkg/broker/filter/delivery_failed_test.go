@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
+	reconcilertesting "knative.dev/pkg/reconciler/testing"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	brokerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
+	triggerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger/fake"
+	"knative.dev/eventing/pkg/kncloudevents/attributes"
+	"knative.dev/eventing/pkg/reconciler/sugar/trigger/path"
+)
+
+func TestMakeDeliveryFailedEvent(t *testing.T) {
+	failed := makeEventWithoutTTL()
+	failed.SetData(cloudevents.ApplicationJSON, map[string]string{"secret": "value"})
+	failed.SetExtension(attributes.KnativeErrorCodeExtensionKey, "503")
+	failed.SetExtension(attributes.KnativeErrorDestExtensionKey, "http://subscriber.ns.svc.cluster.local")
+	failed.SetExtension(attributes.KnativeErrorDataExtensionKey, "unavailable")
+
+	e, err := MakeDeliveryFailedEvent(makeTrigger(), "default", failed)
+	if err != nil {
+		t.Fatal("MakeDeliveryFailedEvent() =", err)
+	}
+	if err := e.Validate(); err != nil {
+		t.Error("Invalid event:", err)
+	}
+	if e.Type() != DeliveryFailedEventType {
+		t.Errorf("Expected the type %q, got %q", DeliveryFailedEventType, e.Type())
+	}
+	if e.Subject() != failed.ID() {
+		t.Errorf("Expected the subject %q, got %q", failed.ID(), e.Subject())
+	}
+
+	var got DeliveryFailure
+	if err := e.DataAs(&got); err != nil {
+		t.Fatal("Unable to read the data:", err)
+	}
+	want := DeliveryFailure{
+		Namespace: testNS,
+		Broker:    "default",
+		Trigger:   triggerName,
+		Event: FailedEvent{
+			ID:     failed.ID(),
+			Type:   eventType,
+			Source: eventSource,
+		},
+		ErrorCode:        http.StatusServiceUnavailable,
+		ErrorDestination: "http://subscriber.ns.svc.cluster.local",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Unexpected data (-want, +got) =", diff)
+	}
+}
+
+func TestReceiver_DeliveryFailed(t *testing.T) {
+	testCases := map[string]struct {
+		enabled    bool
+		sinkStatus int
+		wantStatus int
+		wantEvent  bool
+	}{
+		"Sink accepts the event": {
+			enabled:    true,
+			sinkStatus: http.StatusAccepted,
+			wantStatus: http.StatusAccepted,
+			wantEvent:  true,
+		},
+		"Sink fails": {
+			enabled:    true,
+			sinkStatus: http.StatusInternalServerError,
+			wantStatus: http.StatusInternalServerError,
+			wantEvent:  true,
+		},
+		"Feature disabled": {
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+			received := make(chan *cloudevents.Event, 1)
+			sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+				if err != nil {
+					t.Error("Unable to read the delivery failed event:", err)
+				}
+				received <- e
+				w.WriteHeader(tc.sinkStatus)
+			}))
+			defer sink.Close()
+
+			trig := makeTrigger(func(t *eventingv1.Trigger) {
+				t.Spec.Broker = "default"
+			})
+			triggerinformerfake.Get(ctx).Informer().GetStore().Add(trig)
+			brokerinformerfake.Get(ctx).Informer().GetStore().Add(&eventingv1.Broker{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "default"},
+			})
+
+			flags := feature.Flags{feature.BrokerDeliveryFailedEvents: feature.Disabled}
+			if tc.enabled {
+				flags[feature.BrokerDeliveryFailedEvents] = feature.Enabled
+			}
+			r, err := NewHandler(
+				zaptest.NewLogger(t),
+				auth.NewOIDCTokenVerifier(ctx),
+				auth.NewOIDCTokenProvider(ctx),
+				triggerinformerfake.Get(ctx),
+				brokerinformerfake.Get(ctx),
+				&mockReporter{},
+				configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+				func(ctx context.Context) context.Context { return feature.ToContext(ctx, flags) },
+			)
+			if err != nil {
+				t.Fatal("Unable to create receiver:", err)
+			}
+			sinkURL, _ := apis.ParseURL(sink.URL)
+			r.DeliveryFailedSink = &duckv1.Addressable{URL: sinkURL}
+
+			b, err := makeEventWithoutTTL().MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, path.GenerateDLS(trig), bytes.NewBuffer(b))
+			request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+			responseWriter := httptest.NewRecorder()
+			r.ServeHTTP(responseWriter, request)
+
+			if got := responseWriter.Result().StatusCode; got != tc.wantStatus {
+				t.Errorf("Unexpected status. Expected %v. Actual %v.", tc.wantStatus, got)
+			}
+
+			select {
+			case e := <-received:
+				if !tc.wantEvent {
+					t.Error("Unexpected delivery failed event", e)
+				} else if e != nil && e.Type() != DeliveryFailedEventType {
+					t.Errorf("Expected an event of type %q, got %q", DeliveryFailedEventType, e.Type())
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.wantEvent {
+					t.Error("Expected the sink to receive the delivery failed event")
+				}
+			}
+		})
+	}
+}
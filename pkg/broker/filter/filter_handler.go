@@ -23,7 +23,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	opencensusclient "github.com/cloudevents/sdk-go/observability/opencensus/v2/client"
@@ -31,8 +35,10 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/event"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/rickb777/date/period"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -40,21 +46,27 @@ import (
 	"knative.dev/pkg/logging"
 
 	"knative.dev/eventing/pkg/apis"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/utils"
 
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/apis/feature"
 	eventingbroker "knative.dev/eventing/pkg/broker"
 	v1 "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
 	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	eventingv1alpha1listers "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/eventfilter"
 	"knative.dev/eventing/pkg/eventfilter/attributes"
 	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
 	"knative.dev/eventing/pkg/eventtype"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/eventing/pkg/reconciler/sugar/trigger/path"
+	"knative.dev/eventing/pkg/redaction"
+	"knative.dev/eventing/pkg/signing"
 	"knative.dev/eventing/pkg/tracing"
 )
 
@@ -70,6 +82,12 @@ const (
 
 	FilterAudience = "mt-broker-filter"
 	skipTTL        = -1
+
+	// defaultMirrorTimeout bounds the requests to the mirror of a Trigger
+	// without a delivery timeout.
+	defaultMirrorTimeout = 30 * time.Second
+
+	retryAfterHeader = "Retry-After"
 )
 
 // Handler parses Cloud Events, determines if they pass a filter, and sends them to a subscriber.
@@ -85,7 +103,59 @@ type Handler struct {
 	withContext      func(ctx context.Context) context.Context
 	filtersMap       *subscriptionsapi.FiltersMap
 	tokenVerifier    *auth.OIDCTokenVerifier
+	replayProtector  *kncloudevents.ReplayProtector
 	EventTypeCreator *eventtype.EventTypeAutoHandler
+
+	// RedactionPolicyLister, when set, is used to redact the data of events
+	// sent to Triggers targeted by a RedactionPolicy.
+	RedactionPolicyLister eventingv1alpha1listers.RedactionPolicyLister
+
+	// EventPolicyLister, when set, is used to authorize the producers of the
+	// events sent to Triggers targeted by an EventPolicy when the
+	// feature.OIDCAuthentication feature is enabled.
+	EventPolicyLister eventingv1alpha1listers.EventPolicyLister
+
+	// AuthSubjectKeys, when set, are the keys the auth.AuthSubjectExtension
+	// extension of the events must be signed with to be authorized.
+	AuthSubjectKeys signing.Keyring
+
+	// ResponseHeaderPolicy, when set, selects the subscriber response
+	// headers kept on the reply besides the ones always passed through.
+	ResponseHeaderPolicy *ResponseHeaderPolicy
+
+	// TriggerPools, when set, bounds the number of events dispatched
+	// concurrently for each Trigger.
+	TriggerPools *TriggerPools
+
+	// Partitions, when set, dispatches the events with a partition key in
+	// order for each key of a Trigger.
+	Partitions *Partitions
+
+	// EventIndex, when set, records the outcome of the events sent to the
+	// subscribers of the Triggers.
+	EventIndex *eventindex.Index
+
+	// DeadLetterStats, when set, counts the events of the Triggers sent to
+	// their dead letter sink when the feature.TriggerDeadLetterSinkHealth
+	// feature is enabled.
+	DeadLetterStats *DeadLetterStats
+
+	// DeliveryFailedSink, when set, receives the dev.knative.delivery.failed
+	// events reporting the events of the Triggers without dead letter sink
+	// whose retries are exhausted.
+	DeliveryFailedSink *duckv1.Addressable
+
+	// intn returns a random number in [0,n), it picks the subscriber of
+	// Triggers splitting their events between several subscribers.
+	intn func(n int) int
+
+	// concurrency bounds the number of events dispatched concurrently for
+	// the Triggers whose delivery spec sets a concurrency.
+	concurrency *TriggerPools
+
+	// policyRejectedSlots bounds the number of policy rejected events sent
+	// concurrently.
+	policyRejectedSlots chan struct{}
 }
 
 // NewHandler creates a new Handler and its associated EventReceiver.
@@ -108,23 +178,27 @@ func NewHandler(logger *zap.Logger, tokenVerifier *auth.OIDCTokenVerifier, oidcT
 				return
 			}
 			logger.Debug("Adding filter to filtersMap")
-			fm.Set(trigger, createSubscriptionsAPIFilters(logger, trigger))
-			kncloudevents.AddOrUpdateAddressableHandler(clientConfig, duckv1.Addressable{
-				URL:     trigger.Status.SubscriberURI,
-				CACerts: trigger.Status.SubscriberCACerts,
+			fm.GetOrCreate(trigger, func() eventfilter.Filter {
+				return createSubscriptionsAPIFilters(logger, trigger)
 			})
+			for _, addr := range triggerAddressables(trigger) {
+				kncloudevents.AddOrUpdateAddressableHandler(clientConfig, addr)
+			}
 		},
 		UpdateFunc: func(_, obj interface{}) {
 			trigger, ok := obj.(*eventingv1.Trigger)
 			if !ok {
 				return
 			}
+			// The filter is only compiled again when the generation of
+			// the Trigger changed, not on resyncs.
 			logger.Debug("Updating filter in filtersMap")
-			fm.Set(trigger, createSubscriptionsAPIFilters(logger, trigger))
-			kncloudevents.AddOrUpdateAddressableHandler(clientConfig, duckv1.Addressable{
-				URL:     trigger.Status.SubscriberURI,
-				CACerts: trigger.Status.SubscriberCACerts,
+			fm.GetOrCreate(trigger, func() eventfilter.Filter {
+				return createSubscriptionsAPIFilters(logger, trigger)
 			})
+			for _, addr := range triggerAddressables(trigger) {
+				kncloudevents.AddOrUpdateAddressableHandler(clientConfig, addr)
+			}
 		},
 		DeleteFunc: func(obj interface{}) {
 			trigger, ok := obj.(*eventingv1.Trigger)
@@ -133,25 +207,96 @@ func NewHandler(logger *zap.Logger, tokenVerifier *auth.OIDCTokenVerifier, oidcT
 			}
 			logger.Debug("Deleting filter in filtersMap")
 			fm.Delete(trigger)
-			kncloudevents.DeleteAddressableHandler(duckv1.Addressable{
-				URL:     trigger.Status.SubscriberURI,
-				CACerts: trigger.Status.SubscriberCACerts,
-			})
+			for _, addr := range triggerAddressables(trigger) {
+				kncloudevents.DeleteAddressableHandler(addr)
+			}
 		},
 	})
 
 	return &Handler{
-		reporter:        reporter,
-		eventDispatcher: kncloudevents.NewDispatcher(clientConfig, oidcTokenProvider),
-		triggerLister:   triggerInformer.Lister(),
-		brokerLister:    brokerInformer.Lister(),
-		logger:          logger,
-		tokenVerifier:   tokenVerifier,
-		withContext:     wc,
-		filtersMap:      fm,
+		reporter:            reporter,
+		eventDispatcher:     kncloudevents.NewDispatcher(clientConfig, oidcTokenProvider),
+		triggerLister:       triggerInformer.Lister(),
+		brokerLister:        brokerInformer.Lister(),
+		logger:              logger,
+		tokenVerifier:       tokenVerifier,
+		replayProtector:     kncloudevents.NewReplayProtector(kncloudevents.DefaultReplayWindow, kncloudevents.DefaultReplayCacheSize),
+		withContext:         wc,
+		filtersMap:          fm,
+		intn:                rand.Intn,
+		concurrency:         newTriggerPools(),
+		policyRejectedSlots: make(chan struct{}, maxPolicyRejectedInFlight),
 	}, nil
 }
 
+// triggerAddressables returns the resolved addressables of the subscribers and
+// the mirror of a Trigger.
+func triggerAddressables(trigger *eventingv1.Trigger) []duckv1.Addressable {
+	var addrs []duckv1.Addressable
+	if len(trigger.Status.Subscribers) == 0 {
+		addrs = append(addrs, duckv1.Addressable{
+			URL:     trigger.Status.SubscriberURI,
+			CACerts: trigger.Status.SubscriberCACerts,
+		})
+	}
+	for _, sub := range trigger.Status.Subscribers {
+		addrs = append(addrs, duckv1.Addressable{
+			URL:     sub.URI,
+			CACerts: sub.CACerts,
+		})
+	}
+	if trigger.Status.MirrorURI != nil {
+		addrs = append(addrs, duckv1.Addressable{
+			URL:     trigger.Status.MirrorURI,
+			CACerts: trigger.Status.MirrorCACerts,
+		})
+	}
+	return addrs
+}
+
+// pickSubscriber returns the addressable an event of the Trigger is sent to,
+// with the name of the subscriber when the Trigger splits its events between
+// several subscribers. It returns false when the Trigger has no resolved
+// subscriber.
+func pickSubscriber(trigger *eventingv1.Trigger, intn func(n int) int) (duckv1.Addressable, string, bool) {
+	if len(trigger.Status.Subscribers) == 0 {
+		if trigger.Status.SubscriberURI == nil {
+			return duckv1.Addressable{}, "", false
+		}
+		return duckv1.Addressable{
+			URL:      trigger.Status.SubscriberURI,
+			CACerts:  trigger.Status.SubscriberCACerts,
+			Audience: trigger.Status.SubscriberAudience,
+		}, "", true
+	}
+
+	total := 0
+	for _, sub := range trigger.Status.Subscribers {
+		if sub.Weight > 0 && sub.URI != nil {
+			total += int(sub.Weight)
+		}
+	}
+	if total == 0 {
+		return duckv1.Addressable{}, "", false
+	}
+	n := intn(total)
+	for _, sub := range trigger.Status.Subscribers {
+		if sub.Weight <= 0 || sub.URI == nil {
+			continue
+		}
+		if n < int(sub.Weight) {
+			return duckv1.Addressable{
+				URL:      sub.URI,
+				CACerts:  sub.CACerts,
+				Audience: sub.Audience,
+			}, sub.Name, true
+		}
+		n -= int(sub.Weight)
+	}
+	// Unreachable, n is lower than the sum of the weights.
+	return duckv1.Addressable{}, "", false
+}
+
 func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	ctx := h.withContext(request.Context())
 
@@ -176,10 +321,10 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
-	event, err := cehttp.NewEventFromHTTPRequest(request)
+	event, err := kncloudevents.NewEventFromHTTPRequest(request)
 	if err != nil {
 		h.logger.Warn("failed to extract event from request", zap.Error(err))
-		writer.WriteHeader(http.StatusBadRequest)
+		kncloudevents.WriteEventDecodingError(writer, err)
 		return
 	}
 
@@ -210,6 +355,18 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 			return
 		}
 
+		if features.IsEnabled(feature.ReplayProtection) {
+			if err := h.replayProtector.Verify(request); err != nil {
+				h.logger.Warn("Request failed the replay protection", zap.Error(err))
+				if errors.Is(err, kncloudevents.ErrNonceStoreUnavailable) {
+					writer.WriteHeader(http.StatusServiceUnavailable)
+				} else {
+					writer.WriteHeader(http.StatusUnauthorized)
+				}
+				return
+			}
+		}
+
 		h.logger.Debug("Request contained a valid JWT. Continuing...")
 	}
 
@@ -296,6 +453,17 @@ func (h *Handler) handleDispatchToDLSRequest(ctx context.Context, trigger *event
 		}
 	}
 
+	if target == nil {
+		if h.DeliveryFailedSink != nil && feature.FromContext(ctx).IsEnabled(feature.BrokerDeliveryFailedEvents) {
+			h.logger.Info("sending delivery failed event", zap.Any("target", h.DeliveryFailedSink))
+			h.sendDeliveryFailed(ctx, writer, trigger, broker.Name, event)
+			return
+		}
+		h.logger.Info("No dead letter sink for the Trigger", zap.String("trigger", fmt.Sprintf("%s/%s", trigger.Namespace, trigger.Name)))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	reportArgs := &ReportArgs{
 		ns:          trigger.Namespace,
 		trigger:     trigger.Name,
@@ -309,8 +477,23 @@ func (h *Handler) handleDispatchToDLSRequest(ctx context.Context, trigger *event
 		reportArgs.requestScheme = "http"
 	}
 
+	if err := h.redact(trigger, event); err != nil {
+		h.logger.Warn("Failed to redact event", zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	h.logger.Info("sending to dls", zap.Any("target", target))
 
+	if h.DeadLetterStats != nil && feature.FromContext(ctx).IsEnabled(feature.TriggerDeadLetterSinkHealth) {
+		sw := &statusWriter{ResponseWriter: writer, statusCode: http.StatusOK}
+		writer = sw
+		defer func() {
+			delivered := sw.statusCode >= http.StatusOK && sw.statusCode < http.StatusMultipleChoices
+			h.DeadLetterStats.Record(types.NamespacedName{Namespace: trigger.Namespace, Name: trigger.Name}, delivered)
+		}()
+	}
+
 	// since the broker-filter acts here like a proxy, we don't filter headers
 	h.send(ctx, writer, request.Header, *target, reportArgs, event, trigger, skipTTL)
 }
@@ -328,6 +511,19 @@ func (h *Handler) handleDispatchToSubscriberRequest(ctx context.Context, trigger
 		Namespace: trigger.Namespace,
 	}
 
+	var filtered bool
+	if h.EventIndex != nil {
+		sw := &statusWriter{ResponseWriter: writer, statusCode: http.StatusOK}
+		writer = sw
+		defer func() {
+			outcome := eventindex.OutcomeForStatus(sw.statusCode)
+			if filtered {
+				outcome = eventindex.OutcomeFiltered
+			}
+			h.EventIndex.Add(eventindex.NewRecord(event, "trigger "+triggerRef.String(), outcome, sw.statusCode, nil))
+		}()
+	}
+
 	// Remove the TTL attribute that is used by the Broker.
 	ttl, err := eventingbroker.GetTTL(event.Context)
 	if err != nil {
@@ -342,6 +538,12 @@ func (h *Handler) handleDispatchToSubscriberRequest(ctx context.Context, trigger
 	if err := eventingbroker.DeleteTTL(event.Context); err != nil {
 		h.logger.Warn("Failed to delete TTL.", zap.Error(err))
 	}
+	// Remove the synchronous delivery reply URL set by the Broker ingress, the
+	// response of the subscriber is posted to it.
+	replyTo, syncDelivery := eventingbroker.GetSyncReplyTo(event.Context)
+	if syncDelivery {
+		_ = eventingbroker.DeleteSyncReplyTo(event.Context)
+	}
 
 	reportArgs := &ReportArgs{
 		ns:          trigger.Namespace,
@@ -357,13 +559,18 @@ func (h *Handler) handleDispatchToSubscriberRequest(ctx context.Context, trigger
 		reportArgs.requestScheme = "http"
 	}
 
-	subscriberURI := trigger.Status.SubscriberURI
-	if subscriberURI == nil {
+	intn := h.intn
+	if intn == nil {
+		intn = rand.Intn
+	}
+	target, subscriber, ok := pickSubscriber(trigger, intn)
+	if !ok {
 		// Record the event count.
 		writer.WriteHeader(http.StatusBadRequest)
 		_ = h.reporter.ReportEventCount(reportArgs, http.StatusBadRequest)
 		return
 	}
+	reportArgs.subscriber = subscriber
 
 	// Check if the event should be sent.
 	ctx = logging.WithLogger(ctx, h.logger.Sugar().With(zap.String("trigger", fmt.Sprintf("%s/%s", trigger.GetNamespace(), trigger.GetName()))))
@@ -372,21 +579,138 @@ func (h *Handler) handleDispatchToSubscriberRequest(ctx context.Context, trigger
 	if filterResult == eventfilter.FailFilter {
 		// We do not count the event. The event will be counted in the broker ingress.
 		// If the filter didn't pass, it means that the event wasn't meant for this Trigger.
+		filtered = true
 		return
 	}
 
 	h.reportArrivalTime(event, reportArgs)
 
+	if err := h.authorize(ctx, trigger, event); err != nil {
+		h.logger.Info("Event not authorized", zap.Any("triggerRef", triggerRef), zap.Error(err))
+		writer.WriteHeader(http.StatusForbidden)
+		_ = h.reporter.ReportEventCount(reportArgs, http.StatusForbidden)
+		return
+	}
+
+	if err := h.redact(trigger, event); err != nil {
+		h.logger.Warn("Failed to redact event", zap.Any("triggerRef", triggerRef), zap.Error(err))
+		writer.WriteHeader(http.StatusBadRequest)
+		_ = h.reporter.ReportEventCount(reportArgs, http.StatusBadRequest)
+		return
+	}
+
+	if syncDelivery && feature.FromContext(ctx).IsEnabled(feature.BrokerSynchronousDelivery) {
+		rw := &syncResultWriter{ResponseWriter: writer, statusCode: http.StatusOK}
+		writer = rw
+		defer h.postSyncResult(ctx, replyTo, trigger, rw)
+	}
+
+	headers := utils.PassThroughHeaders(request.Header)
+	h.mirror(ctx, headers, event, trigger)
+
+	if key, ok := PartitionKey(event); ok && h.Partitions != nil {
+		dispatched := h.Partitions.dispatch(ctx, trigger.UID, key, func() {
+			h.send(ctx, writer, headers, target, reportArgs, event, trigger, ttl)
+		})
+		if !dispatched {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			_ = h.reporter.ReportEventCount(reportArgs, http.StatusServiceUnavailable)
+		}
+		return
+	}
+	h.send(ctx, writer, headers, target, reportArgs, event, trigger, ttl)
+}
+
+// postSyncResult posts the response written for the subscriber of the Trigger
+// to the Broker ingress waiting for it. It is posted asynchronously, and its
+// failures are only logged, as the ingress only waits for the first result
+// and answers the producer on its own once it times out.
+func (h *Handler) postSyncResult(ctx context.Context, replyTo string, t *eventingv1.Trigger, rw *syncResultWriter) {
+	header := rw.Header().Clone()
+	header.Del("Allow")
+	// The TTL is only meaningful to the Broker the reply is sent back to.
+	header.Del("Ce-" + eventingbroker.TTLAttribute)
+	body := rw.body.Bytes()
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := eventingbroker.PostSyncResult(ctx, replyTo, rw.statusCode, header, body); err != nil {
+			h.logger.Debug("failed to post synchronous delivery result",
+				zap.String("trigger", fmt.Sprintf("%s/%s", t.Namespace, t.Name)),
+				zap.Error(err))
+		}
+	}()
+}
+
+// mirror sends a copy of the event to the mirror of the Trigger, if any. The
+// copy is sent asynchronously, without retries, and its failures are only
+// logged, so that the delivery to the subscriber isn't affected.
+func (h *Handler) mirror(ctx context.Context, headers http.Header, event *cloudevents.Event, t *eventingv1.Trigger) {
+	if t.Status.MirrorURI == nil {
+		return
+	}
 	target := duckv1.Addressable{
-		URL:      trigger.Status.SubscriberURI,
-		CACerts:  trigger.Status.SubscriberCACerts,
-		Audience: trigger.Status.SubscriberAudience,
+		URL:      t.Status.MirrorURI,
+		CACerts:  t.Status.MirrorCACerts,
+		Audience: t.Status.MirrorAudience,
+	}
+
+	additionalHeaders := headers.Clone()
+	additionalHeaders.Set(apis.KnNamespaceHeader, t.GetNamespace())
+
+	retryConfig := kncloudevents.NoRetries()
+	retryConfig.RequestTimeout = defaultMirrorTimeout
+	if timeout := h.requestTimeout(ctx, t); timeout > 0 {
+		retryConfig.RequestTimeout = timeout
+	}
+
+	opts := []kncloudevents.SendOption{
+		kncloudevents.WithHeader(additionalHeaders),
+		kncloudevents.WithRetryConfig(&retryConfig),
+	}
+	if t.Status.Auth != nil && t.Status.Auth.ServiceAccountName != nil {
+		opts = append(opts, kncloudevents.WithOIDCAuthentication(&types.NamespacedName{
+			Name:      *t.Status.Auth.ServiceAccountName,
+			Namespace: t.Namespace,
+		}))
 	}
 
-	h.send(ctx, writer, utils.PassThroughHeaders(request.Header), target, reportArgs, event, trigger, ttl)
+	// The request to the mirror must outlive the request from the Broker.
+	ctx = context.WithoutCancel(ctx)
+	e := event.Clone()
+	go func() {
+		if _, err := h.eventDispatcher.SendEvent(ctx, e, target, opts...); err != nil {
+			h.logger.Debug("failed to send event to mirror",
+				zap.String("trigger", fmt.Sprintf("%s/%s", t.Namespace, t.Name)),
+				zap.Error(err))
+		}
+	}()
 }
 
 func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers http.Header, target duckv1.Addressable, reportArgs *ReportArgs, event *cloudevents.Event, t *eventingv1.Trigger, ttl int32) {
+	release, ok := h.TriggerPools.acquire(t.UID)
+	if !ok {
+		h.logger.Info("Too many events being dispatched for the Trigger, rejecting the event",
+			zap.String("trigger", fmt.Sprintf("%s/%s", t.GetNamespace(), t.GetName())))
+		writer.WriteHeader(http.StatusTooManyRequests)
+		_ = h.reporter.ReportEventCount(reportArgs, http.StatusTooManyRequests)
+		return
+	}
+	defer release()
+
+	// The events beyond the concurrency of the Trigger wait for the previous
+	// ones to be dispatched, until the request is canceled.
+	releaseConcurrency, err := h.concurrency.wait(ctx, t.UID, h.concurrencyLimit(ctx, t))
+	if err != nil {
+		h.logger.Debug("Request canceled while waiting for the concurrency of the Trigger",
+			zap.String("trigger", fmt.Sprintf("%s/%s", t.GetNamespace(), t.GetName())),
+			zap.Error(err))
+		writer.WriteHeader(http.StatusServiceUnavailable)
+		_ = h.reporter.ReportEventCount(reportArgs, http.StatusServiceUnavailable)
+		return
+	}
+	defer releaseConcurrency()
+
 	additionalHeaders := headers.Clone()
 	additionalHeaders.Set(apis.KnNamespaceHeader, t.GetNamespace())
 
@@ -407,6 +731,12 @@ func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers
 		))
 	}
 
+	if timeout := h.requestTimeout(ctx, t); timeout > 0 {
+		retryConfig := kncloudevents.NoRetries()
+		retryConfig.RequestTimeout = timeout
+		opts = append(opts, kncloudevents.WithRetryConfig(&retryConfig))
+	}
+
 	if t.Status.Auth != nil && t.Status.Auth.ServiceAccountName != nil {
 		opts = append(opts, kncloudevents.WithOIDCAuthentication(&types.NamespacedName{
 			Name:      *t.Status.Auth.ServiceAccountName,
@@ -414,10 +744,18 @@ func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers
 		}))
 	}
 
-	dispatchInfo, err := h.eventDispatcher.SendEvent(ctx, *event, target, opts...)
+	dispatchInfo, err := h.sendHedged(ctx, h.hedgeDelay(ctx, t), reportArgs, *event, target, opts...)
 	if err != nil {
 		h.logger.Error("failed to send event", zap.Error(err))
 
+		// A request that exceeded the delivery timeout is reported as such, so that
+		// the sender can retry it according to its delivery spec.
+		if isTimeout(err) {
+			writer.WriteHeader(http.StatusGatewayTimeout)
+			_ = h.reporter.ReportEventCount(reportArgs, http.StatusGatewayTimeout)
+			return
+		}
+
 		// If error is not because of the response, it should respond with http.StatusInternalServerError
 		if dispatchInfo.ResponseCode <= 0 {
 			writer.WriteHeader(http.StatusInternalServerError)
@@ -427,6 +765,7 @@ func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers
 
 		h.reporter.ReportEventDispatchTime(reportArgs, dispatchInfo.ResponseCode, dispatchInfo.Duration)
 
+		h.boundRetryAfter(ctx, t, dispatchInfo)
 		writeHeaders(utils.PassThroughHeaders(dispatchInfo.ResponseHeader), writer)
 		writer.WriteHeader(dispatchInfo.ResponseCode)
 
@@ -461,6 +800,102 @@ func (h *Handler) send(ctx context.Context, writer http.ResponseWriter, headers
 	_ = h.reporter.ReportEventCount(reportArgs, statusCode)
 }
 
+// deliverySpec returns the delivery spec applying to the given Trigger. Like
+// the Subscription created for the Trigger, it is the Trigger's delivery spec
+// and falls back to the Broker's one when that is unset.
+func (h *Handler) deliverySpec(ctx context.Context, t *eventingv1.Trigger) *eventingduckv1.DeliverySpec {
+	if t.Spec.Delivery != nil {
+		return t.Spec.Delivery
+	}
+	brokerRef, brokerNamespace := t.Spec.Broker, t.Namespace
+	if feature.FromContext(ctx).IsEnabled(feature.CrossNamespaceEventLinks) && t.Spec.BrokerRef != nil && t.Spec.BrokerRef.Namespace != "" {
+		brokerRef, brokerNamespace = t.Spec.BrokerRef.Name, t.Spec.BrokerRef.Namespace
+	}
+	b, err := h.brokerLister.Brokers(brokerNamespace).Get(brokerRef)
+	if err != nil {
+		return nil
+	}
+	return b.Spec.Delivery
+}
+
+// concurrencyLimit returns the number of events dispatched concurrently to
+// the subscriber of the given Trigger, or 0 when it is unbounded.
+func (h *Handler) concurrencyLimit(ctx context.Context, t *eventingv1.Trigger) int {
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryConcurrency) {
+		return 0
+	}
+
+	delivery := h.deliverySpec(ctx, t)
+	if delivery == nil || delivery.Concurrency == nil {
+		return 0
+	}
+	return int(*delivery.Concurrency)
+}
+
+// requestTimeout returns the timeout of a single request sent on behalf of the
+// given Trigger.
+func (h *Handler) requestTimeout(ctx context.Context, t *eventingv1.Trigger) time.Duration {
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryTimeout) {
+		return 0
+	}
+
+	delivery := h.deliverySpec(ctx, t)
+	if delivery == nil || delivery.Timeout == nil {
+		return 0
+	}
+
+	p, err := period.Parse(*delivery.Timeout)
+	if err != nil {
+		h.logger.Warn("Invalid delivery timeout", zap.String("timeout", *delivery.Timeout), zap.Error(err))
+		return 0
+	}
+	timeout, _ := p.Duration()
+	return timeout
+}
+
+// boundRetryAfter bounds the Retry-After header of a 429 or 503 response of
+// the subscriber by the retryAfterMax of the Trigger's delivery spec, and
+// drops it when Retry-After headers are to be ignored, so that the sender
+// retries the same way whether or not it supports retryAfterMax itself.
+func (h *Handler) boundRetryAfter(ctx context.Context, t *eventingv1.Trigger, dispatchInfo *kncloudevents.DispatchInfo) {
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryRetryAfter) || dispatchInfo.ResponseHeader.Get(retryAfterHeader) == "" {
+		return
+	}
+	if dispatchInfo.ResponseCode != http.StatusTooManyRequests && dispatchInfo.ResponseCode != http.StatusServiceUnavailable {
+		return
+	}
+
+	delivery := h.deliverySpec(ctx, t)
+	if delivery == nil || delivery.RetryAfterMax == nil {
+		return
+	}
+	p, err := period.Parse(*delivery.RetryAfterMax)
+	if err != nil {
+		h.logger.Warn("Invalid delivery retryAfterMax", zap.String("retryAfterMax", *delivery.RetryAfterMax), zap.Error(err))
+		return
+	}
+	retryAfterMax, _ := p.Duration()
+
+	retryAfter := kncloudevents.RetryAfterDuration(&http.Response{
+		StatusCode: dispatchInfo.ResponseCode,
+		Header:     dispatchInfo.ResponseHeader,
+	}, &retryAfterMax)
+	if retryAfter <= 0 {
+		dispatchInfo.ResponseHeader.Del(retryAfterHeader)
+		return
+	}
+	dispatchInfo.ResponseHeader.Set(retryAfterHeader, strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+}
+
+// isTimeout reports whether err was caused by a request exceeding its timeout.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // The return values are the status
 func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter, dispatchInfo *kncloudevents.DispatchInfo, ttl int32, target string) (int, error) {
 	response := cehttp.NewMessage(dispatchInfo.ResponseHeader, io.NopCloser(bytes.NewReader(dispatchInfo.ResponseBody)))
@@ -481,7 +916,7 @@ func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter,
 			return http.StatusBadGateway, errors.New("received a non-empty response not recognized as CloudEvent. The response MUST be either empty or a valid CloudEvent")
 		}
 
-		writeHeaders(utils.PassThroughHeaders(dispatchInfo.ResponseHeader), writer) // Proxy original Response Headers for downstream use
+		h.writeResponseHeaders(dispatchInfo.ResponseHeader, writer) // Proxy original Response Headers for downstream use
 		h.logger.Debug("Response doesn't contain a CloudEvent, replying with an empty response", zap.Any("target", target))
 		writer.WriteHeader(dispatchInfo.ResponseCode)
 		return dispatchInfo.ResponseCode, nil
@@ -504,11 +939,16 @@ func (h *Handler) writeResponse(ctx context.Context, writer http.ResponseWriter,
 		}
 	}
 
+	if err := h.ResponseHeaderPolicy.SetExtensions(event, dispatchInfo.ResponseHeader); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		return http.StatusInternalServerError, err
+	}
+
 	eventResponse := binding.ToMessage(event)
 	defer eventResponse.Finish(nil)
 
 	// Proxy the original Response Headers for downstream use
-	writeHeaders(utils.PassThroughHeaders(dispatchInfo.ResponseHeader), writer)
+	h.writeResponseHeaders(dispatchInfo.ResponseHeader, writer)
 
 	if err := cehttp.WriteResponseWriter(ctx, eventResponse, dispatchInfo.ResponseCode, writer); err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("failed to write response event: %w", err)
@@ -531,6 +971,83 @@ func (h *Handler) reportArrivalTime(event *event.Event, reportArgs *ReportArgs)
 	}
 }
 
+// authorize checks that the producer of the event, whose identity the
+// Broker ingress set in its auth.AuthSubjectExtension extension, is allowed
+// by one of the EventPolicies targeting the trigger, if any.
+func (h *Handler) authorize(ctx context.Context, trigger *eventingv1.Trigger, event *cloudevents.Event) error {
+	if h.EventPolicyLister == nil || !feature.FromContext(ctx).IsOIDCAuthentication() {
+		return nil
+	}
+	policies, err := auth.GetEventPoliciesTargetingResource(h.EventPolicyLister, eventingv1.SchemeGroupVersion.WithKind("Trigger"), trigger.ObjectMeta)
+	if err != nil {
+		return fmt.Errorf("failed to get the event policies of the trigger: %w", err)
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	if err := h.authorizeSubject(policies, event); err != nil {
+		h.sendPolicyRejected(ctx, policies, trigger, event, err)
+		return err
+	}
+	return nil
+}
+
+// authorizeSubject checks that the producer of the event is allowed by one
+// of the policies.
+func (h *Handler) authorizeSubject(policies []*eventingv1alpha1.EventPolicy, event *cloudevents.Event) error {
+	subject, ok := auth.GetAuthSubject(event)
+	if !ok {
+		return fmt.Errorf("event has no %s extension", auth.AuthSubjectExtension)
+	}
+	if h.AuthSubjectKeys != nil {
+		var err error
+		if subject, err = auth.VerifyAuthSubject(event, h.AuthSubjectKeys); err != nil {
+			return fmt.Errorf("failed to verify the %s extension: %w", auth.AuthSubjectExtension, err)
+		}
+	}
+	if !auth.SubjectAllowed(policies, subject, time.Now()) {
+		return fmt.Errorf("subject %q is not allowed by the event policies of the trigger", subject)
+	}
+	return nil
+}
+
+// redact applies the rules of the RedactionPolicies targeting the trigger
+// to the event data.
+func (h *Handler) redact(trigger *eventingv1.Trigger, event *cloudevents.Event) error {
+	if h.RedactionPolicyLister == nil {
+		return nil
+	}
+	policies, err := h.RedactionPolicyLister.RedactionPolicies(trigger.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list redaction policies: %w", err)
+	}
+
+	var rules []redaction.Rule
+	for _, p := range policies {
+		if !p.AppliesTo("Trigger", trigger.Name) {
+			continue
+		}
+		r, err := p.RedactionRules()
+		if err != nil {
+			return fmt.Errorf("invalid redaction policy %s/%s: %w", p.Namespace, p.Name, err)
+		}
+		rules = append(rules, r...)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	res, err := redaction.Redact(event, rules)
+	if err != nil {
+		return err
+	}
+	if err := redaction.ReportRedactedFields(trigger.Namespace, "Trigger", res); err != nil {
+		h.logger.Debug("Failed to report redacted fields", zap.Error(err))
+	}
+	return nil
+}
+
 func (h *Handler) getTrigger(ref path.NamespacedNameUID) (*eventingv1.Trigger, error) {
 	t, err := h.triggerLister.Triggers(ref.Namespace).Get(ref.Name)
 	if err != nil {
@@ -546,11 +1063,11 @@ func (h *Handler) filterEvent(ctx context.Context, trigger *eventingv1.Trigger,
 	switch {
 	case feature.FromContext(ctx).IsEnabled(feature.NewTriggerFilters) && len(trigger.Spec.Filters) > 0:
 		logging.FromContext(ctx).Debugw("New trigger filters feature is enabled. Applying new filters.", zap.Any("filters", trigger.Spec.Filters))
-		filter, ok := h.filtersMap.Get(trigger)
-		if !ok {
-			// trigger filters haven't updated in the map yet - need to create them on the fly
-			return applySubscriptionsAPIFilters(ctx, trigger, event)
-		}
+		// The trigger filters may not be in the map yet, or compiled from a
+		// previous generation of the trigger.
+		filter := h.filtersMap.GetOrCreate(trigger, func() eventfilter.Filter {
+			return createSubscriptionsAPIFilters(logging.FromContext(ctx).Desugar(), trigger)
+		})
 		return filter.Filter(ctx, event)
 	case trigger.Spec.Filter != nil:
 		logging.FromContext(ctx).Debugw("Applying attributes filter.", zap.Any("filter", trigger.Spec.Filter))
@@ -561,10 +1078,6 @@ func (h *Handler) filterEvent(ctx context.Context, trigger *eventingv1.Trigger,
 	}
 }
 
-func applySubscriptionsAPIFilters(ctx context.Context, trigger *eventingv1.Trigger, event cloudevents.Event) eventfilter.FilterResult {
-	return createSubscriptionsAPIFilters(logging.FromContext(ctx).Desugar(), trigger).Filter(ctx, event)
-}
-
 func createSubscriptionsAPIFilters(logger *zap.Logger, trigger *eventingv1.Trigger) eventfilter.Filter {
 	if len(trigger.Spec.Filters) == 0 {
 		logger.Debug("Found no filters for trigger", zap.Any("trigger.Spec", trigger.Spec))
@@ -610,6 +1123,11 @@ func materializeSubscriptionsAPIFilter(logger *zap.Logger, filter eventingv1.Sub
 			logger.Debug("Found an Invalid CE SQL expression", zap.String("expression", filter.CESQL))
 			return nil
 		}
+	case filter.Time != nil:
+		if materializedFilter, err = subscriptionsapi.NewTimeFilter(filter.Time); err != nil {
+			logger.Debug("Found an invalid time filter", zap.Any("filter", filter.Time), zap.Error(err))
+			return nil
+		}
 	}
 	return materializedFilter
 }
@@ -646,6 +1164,17 @@ func triggerFilterAttribute(filter *eventingv1.TriggerFilter, attributeName stri
 }
 
 // writeHeaders adds the specified HTTP Headers to the ResponseWriter.
+// writeResponseHeaders writes the headers of the subscriber response passed
+// through and the ones preserved by the ResponseHeaderPolicy.
+func (h *Handler) writeResponseHeaders(responseHeader http.Header, writer http.ResponseWriter) {
+	writeHeaders(utils.PassThroughHeaders(responseHeader), writer)
+	for key, values := range h.ResponseHeaderPolicy.PreservedHeaders(responseHeader) {
+		if writer.Header().Get(key) == "" {
+			writer.Header()[key] = values
+		}
+	}
+}
+
 func writeHeaders(httpHeader http.Header, writer http.ResponseWriter) {
 	for headerKey, headerValues := range httpHeader {
 		for _, headerValue := range headerValues {
@@ -653,3 +1182,32 @@ func writeHeaders(httpHeader http.Header, writer http.ResponseWriter) {
 		}
 	}
 }
+
+// statusWriter is an http.ResponseWriter keeping the status code written.
+type statusWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+// syncResultWriter is an http.ResponseWriter keeping the status code and the
+// body written, to post them to the Broker ingress.
+type syncResultWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *syncResultWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *syncResultWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
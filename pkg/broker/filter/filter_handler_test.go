@@ -37,17 +37,22 @@ import (
 	"go.uber.org/zap/zaptest"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	"knative.dev/pkg/logging"
 	reconcilertesting "knative.dev/pkg/reconciler/testing"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	v1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/broker"
+	eventingv1alpha1listers "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/eventfilter/subscriptionsapi"
+	"knative.dev/eventing/pkg/signing"
 
 	brokerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
 	triggerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger/fake"
@@ -85,7 +90,15 @@ func TestReceiver(t *testing.T) {
 		event                  *cloudevents.Event
 		requestFails           bool
 		failureStatus          int
+		responseDelay          time.Duration
 		additionalReplyHeaders http.Header
+		responseHeaderPolicy   *ResponseHeaderPolicy
+		retryAfterEnabled      bool
+		triggerPools           func() *TriggerPools
+		// inFlight is the number of events of the Trigger being dispatched.
+		inFlight int
+		// requestTimeout, when set, cancels the request once elapsed.
+		requestTimeout time.Duration
 
 		// expectations
 		expectedResponseEvent       *cloudevents.Event
@@ -97,6 +110,7 @@ func TestReceiver(t *testing.T) {
 		expectedEventDispatchTime   bool
 		expectedEventProcessingTime bool
 		expectedResponseHeaders     http.Header
+		droppedResponseHeaders      []string
 	}{
 		"Not POST": {
 			request:        httptest.NewRequest(http.MethodGet, validPath, nil),
@@ -405,6 +419,57 @@ func TestReceiver(t *testing.T) {
 			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}},
 			expectedResponseHeaders:   http.Header{"Retry-After": []string{"10"}},
 		},
+		"Bound Retry-After by the retryAfterMax of the Trigger": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withRetryAfterMax("PT5S")),
+			},
+			retryAfterEnabled:         true,
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedStatus:            http.StatusTooManyRequests,
+			expectedResponse:          makeEmptyResponse(http.StatusTooManyRequests),
+			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}},
+			expectedResponseHeaders:   http.Header{"Retry-After": []string{"5"}},
+		},
+		"Keep Retry-After below the retryAfterMax of the Trigger": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withRetryAfterMax("PT30S")),
+			},
+			retryAfterEnabled:         true,
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedStatus:            http.StatusServiceUnavailable,
+			expectedResponse:          makeEmptyResponse(http.StatusServiceUnavailable),
+			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}},
+			expectedResponseHeaders:   http.Header{"Retry-After": []string{"10"}},
+		},
+		"Drop Retry-After with a zero retryAfterMax": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withRetryAfterMax("PT0S")),
+			},
+			retryAfterEnabled:         true,
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedStatus:            http.StatusTooManyRequests,
+			expectedResponse:          makeEmptyResponse(http.StatusTooManyRequests),
+			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}},
+			droppedResponseHeaders:    []string{"Retry-After"},
+		},
+		"Ignore retryAfterMax with retry-after disabled": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withRetryAfterMax("PT5S")),
+			},
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedStatus:            http.StatusTooManyRequests,
+			expectedResponse:          makeEmptyResponse(http.StatusTooManyRequests),
+			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}},
+			expectedResponseHeaders:   http.Header{"Retry-After": []string{"10"}},
+		},
 		"Do not proxy disallowed response headers": {
 			triggers: []*eventingv1.Trigger{
 				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{})),
@@ -416,6 +481,93 @@ func TestReceiver(t *testing.T) {
 			additionalReplyHeaders:    http.Header{"Retry-After": []string{"10"}, "Test-Header": []string{"TestValue"}},
 			expectedResponseHeaders:   http.Header{"Retry-After": []string{"10"}},
 		},
+		"Preserve allowlisted response headers": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{})),
+			},
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedResponseEvent:     makeDifferentEvent(),
+			responseHeaderPolicy:      mustParseResponseHeaderPolicy("", "x-correlation-id"),
+			additionalReplyHeaders:    http.Header{"X-Correlation-Id": []string{"abc"}, "Test-Header": []string{"TestValue"}},
+			expectedResponseHeaders:   http.Header{"X-Correlation-Id": []string{"abc"}},
+		},
+		"Propagate allowlisted response headers as extensions": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{})),
+			},
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedResponseEvent: func() *cloudevents.Event {
+				e := makeDifferentEvent()
+				e.SetExtension("xcorrelationid", "abc")
+				return e
+			}(),
+			responseHeaderPolicy:   mustParseResponseHeaderPolicy("X-Correlation-Id,X-Missing", ""),
+			additionalReplyHeaders: http.Header{"X-Correlation-Id": []string{"abc"}},
+		},
+		"Trigger dispatch pool exhausted": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{})),
+			},
+			triggerPools: func() *TriggerPools {
+				p := NewTriggerPools(1)
+				p.acquire(triggerUID)
+				return p
+			},
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedEventCount: true,
+		},
+		"Trigger dispatch pool with a free slot": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{})),
+			},
+			triggerPools: func() *TriggerPools {
+				return NewTriggerPools(1)
+			},
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+		},
+		"Trigger concurrency exceeded until the request is canceled": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withDeliveryConcurrency(1)),
+			},
+			inFlight:           1,
+			requestTimeout:     100 * time.Millisecond,
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedEventCount: true,
+		},
+		"Trigger concurrency not exceeded": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withDeliveryConcurrency(2)),
+			},
+			inFlight:                  1,
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+		},
+		"Subscriber exceeds delivery timeout": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withDeliveryTimeout("PT0.1S")),
+			},
+			responseDelay:      500 * time.Millisecond,
+			expectedDispatch:   true,
+			expectedEventCount: true,
+			expectedStatus:     http.StatusGatewayTimeout,
+		},
+		"Subscriber responds within delivery timeout": {
+			triggers: []*eventingv1.Trigger{
+				makeTrigger(withAttributesFilter(&eventingv1.TriggerFilter{}), withDeliveryTimeout("PT5S")),
+			},
+			responseDelay:             100 * time.Millisecond,
+			expectedDispatch:          true,
+			expectedEventCount:        true,
+			expectedEventDispatchTime: true,
+			expectedResponseEvent:     makeDifferentEvent(),
+		},
 	}
 	for n, tc := range testCases {
 		t.Run(n, func(t *testing.T) {
@@ -427,6 +579,7 @@ func TestReceiver(t *testing.T) {
 			fh := fakeHandler{
 				failRequest:            tc.requestFails,
 				failStatus:             tc.failureStatus,
+				responseDelay:          tc.responseDelay,
 				expectedResponseEvent:  tc.expectedResponseEvent,
 				expectedRequestHeaders: tc.expectedHeaders,
 				t:                      t,
@@ -472,12 +625,28 @@ func TestReceiver(t *testing.T) {
 				reporter,
 				configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
 				func(ctx context.Context) context.Context {
-					return ctx
+					flags := feature.Flags{
+						feature.DeliveryTimeout:     feature.Enabled,
+						feature.DeliveryConcurrency: feature.Enabled,
+					}
+					if tc.retryAfterEnabled {
+						flags[feature.DeliveryRetryAfter] = feature.Enabled
+					}
+					return feature.ToContext(ctx, flags)
 				},
 			)
 			if err != nil {
 				t.Fatal("Unable to create receiver:", err)
 			}
+			r.ResponseHeaderPolicy = tc.responseHeaderPolicy
+			if tc.triggerPools != nil {
+				r.TriggerPools = tc.triggerPools()
+			}
+			for i := 0; i < tc.inFlight; i++ {
+				if _, err := r.concurrency.wait(context.Background(), triggerUID, tc.inFlight); err != nil {
+					t.Fatal("Unable to fill the concurrency of the Trigger:", err)
+				}
+			}
 
 			e := tc.event
 			if e == nil {
@@ -491,6 +660,11 @@ func TestReceiver(t *testing.T) {
 				tc.request = httptest.NewRequest(http.MethodPost, validPath, bytes.NewBuffer(b))
 				tc.request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
 			}
+			if tc.requestTimeout > 0 {
+				reqCtx, cancel := context.WithTimeout(tc.request.Context(), tc.requestTimeout)
+				defer cancel()
+				tc.request = tc.request.WithContext(reqCtx)
+			}
 			responseWriter := httptest.NewRecorder()
 			r.ServeHTTP(&responseWriterWithInvocationsCheck{
 				ResponseWriter: responseWriter,
@@ -506,6 +680,11 @@ func TestReceiver(t *testing.T) {
 						t.Errorf("Response header proxy failed for header '%v'. Expected %v, Actual %v", expectedHeaderKey, expectedHeaderValues[0], response.Header[expectedHeaderKey])
 					}
 				}
+				for _, droppedHeaderKey := range tc.droppedResponseHeaders {
+					if response.Header[droppedHeaderKey] != nil {
+						t.Errorf("Unexpected response header '%v': %v", droppedHeaderKey, response.Header[droppedHeaderKey])
+					}
+				}
 			}
 
 			if tc.expectedStatus != 0 && tc.expectedStatus != response.StatusCode {
@@ -745,6 +924,8 @@ type mockReporter struct {
 	eventCountReported          bool
 	eventDispatchTimeReported   bool
 	eventProcessingTimeReported bool
+	hedgeWinners                []string
+	policyRejectedDropped       int
 }
 
 func (r *mockReporter) ReportEventCount(args *ReportArgs, responseCode int) error {
@@ -762,12 +943,23 @@ func (r *mockReporter) ReportEventProcessingTime(args *ReportArgs, d time.Durati
 	return nil
 }
 
+func (r *mockReporter) ReportEventHedged(args *ReportArgs, winner string) error {
+	r.hedgeWinners = append(r.hedgeWinners, winner)
+	return nil
+}
+
+func (r *mockReporter) ReportPolicyRejectedDropped(args *ReportArgs) error {
+	r.policyRejectedDropped++
+	return nil
+}
+
 type fakeHandler struct {
 	t *testing.T
 
 	// input
 	failRequest            bool
 	failStatus             int
+	responseDelay          time.Duration
 	additionalReplyHeaders http.Header
 
 	// expectations
@@ -794,6 +986,8 @@ func (h *fakeHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	time.Sleep(h.responseDelay)
+
 	if h.failRequest {
 		if h.failStatus != 0 {
 			resp.WriteHeader(h.failStatus)
@@ -837,6 +1031,440 @@ func (h *fakeHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	}
 }
 
+func TestRedact(t *testing.T) {
+	policy := func(name, trigger string, rules ...eventingv1alpha1.RedactionRule) *eventingv1alpha1.RedactionPolicy {
+		return &eventingv1alpha1.RedactionPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: name},
+			Spec: eventingv1alpha1.RedactionPolicySpec{
+				To:    []eventingv1alpha1.RedactionPolicyTargetReference{{Kind: "Trigger", Name: trigger}},
+				Rules: rules,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		policies []*eventingv1alpha1.RedactionPolicy
+		data     string
+		want     string
+		wantErr  bool
+	}{
+		"no policies": {
+			data: `{"email":"jane@example.com","name":"jane"}`,
+			want: `{"email":"jane@example.com","name":"jane"}`,
+		},
+		"policy for another trigger": {
+			policies: []*eventingv1alpha1.RedactionPolicy{
+				policy("other", "other-trigger", eventingv1alpha1.RedactionRule{Path: "$.email", Action: eventingv1alpha1.RedactionActionDrop}),
+			},
+			data: `{"email":"jane@example.com","name":"jane"}`,
+			want: `{"email":"jane@example.com","name":"jane"}`,
+		},
+		"rules of all matching policies": {
+			policies: []*eventingv1alpha1.RedactionPolicy{
+				policy("drop", triggerName, eventingv1alpha1.RedactionRule{Path: "$.email", Action: eventingv1alpha1.RedactionActionDrop}),
+				policy("mask", triggerName, eventingv1alpha1.RedactionRule{Path: "$.name", Action: eventingv1alpha1.RedactionActionMask}),
+			},
+			data: `{"email":"jane@example.com","name":"jane"}`,
+			want: `{"name":"****"}`,
+		},
+		"invalid data": {
+			policies: []*eventingv1alpha1.RedactionPolicy{
+				policy("drop", triggerName, eventingv1alpha1.RedactionRule{Path: "$.email", Action: eventingv1alpha1.RedactionActionDrop}),
+			},
+			data:    `{"email":`,
+			wantErr: true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, p := range tc.policies {
+				if err := indexer.Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			h := &Handler{
+				logger:                zaptest.NewLogger(t),
+				RedactionPolicyLister: eventingv1alpha1listers.NewRedactionPolicyLister(indexer),
+			}
+
+			e := makeEvent()
+			if err := e.SetData(event.ApplicationJSON, []byte(tc.data)); err != nil {
+				t.Fatal(err)
+			}
+
+			err := h.redact(makeTrigger(), e)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("redact() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got := string(e.Data()); got != tc.want {
+				t.Errorf("Unexpected data. Expected %s, Actual %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	policy := func(name string, to []eventingv1alpha1.EventPolicySpecTo, from ...string) *eventingv1alpha1.EventPolicy {
+		return &eventingv1alpha1.EventPolicy{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: name},
+			Spec:       eventingv1alpha1.EventPolicySpec{To: to},
+			Status:     eventingv1alpha1.EventPolicyStatus{From: from},
+		}
+	}
+	toTrigger := func(name string) []eventingv1alpha1.EventPolicySpecTo {
+		return []eventingv1alpha1.EventPolicySpecTo{{
+			Ref: &eventingv1alpha1.EventPolicyToReference{APIVersion: "eventing.knative.dev/v1", Kind: "Trigger", Name: name},
+		}}
+	}
+	oidc := feature.Flags{feature.OIDCAuthentication: feature.Enabled}
+	key := signing.Key{ID: "key", Secret: []byte("secret")}
+
+	testCases := map[string]struct {
+		flags    feature.Flags
+		policies []*eventingv1alpha1.EventPolicy
+		subject  string
+		// signWith, when set, signs the subject with the key.
+		signWith *signing.Key
+		keys     signing.Keyring
+		wantErr  bool
+	}{
+		"no policies": {
+			flags: oidc,
+		},
+		"namespace wide policy": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("all", nil, "system:serviceaccounts:ns:allowed")},
+		},
+		"policy for another trigger": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("other", toTrigger("other-trigger"), "system:serviceaccounts:ns:allowed")},
+		},
+		"allowed subject": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			subject:  "system:serviceaccounts:ns:allowed",
+		},
+		"subject not allowed": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			subject:  "system:serviceaccounts:ns:denied",
+			wantErr:  true,
+		},
+		"no subject": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			wantErr:  true,
+		},
+		"signed subject": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			subject:  "system:serviceaccounts:ns:allowed",
+			signWith: &key,
+			keys:     signing.NewKeyring(key),
+		},
+		"unsigned subject with keys": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			subject:  "system:serviceaccounts:ns:allowed",
+			keys:     signing.NewKeyring(key),
+			wantErr:  true,
+		},
+		"subject signed with an unknown key": {
+			flags:    oidc,
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+			subject:  "system:serviceaccounts:ns:allowed",
+			signWith: &signing.Key{ID: "other", Secret: []byte("other")},
+			keys:     signing.NewKeyring(key),
+			wantErr:  true,
+		},
+		"OIDC authentication disabled": {
+			policies: []*eventingv1alpha1.EventPolicy{policy("trigger", toTrigger(triggerName), "system:serviceaccounts:ns:allowed")},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, p := range tc.policies {
+				if err := indexer.Add(p); err != nil {
+					t.Fatal(err)
+				}
+			}
+			h := &Handler{
+				logger:            zaptest.NewLogger(t),
+				EventPolicyLister: eventingv1alpha1listers.NewEventPolicyLister(indexer),
+				AuthSubjectKeys:   tc.keys,
+			}
+
+			e := makeEvent()
+			if tc.signWith != nil {
+				if err := auth.SignAuthSubject(e, tc.subject, *tc.signWith); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				auth.SetAuthSubject(e, tc.subject)
+			}
+
+			err := h.authorize(feature.ToContext(context.Background(), tc.flags), makeTrigger(), e)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("authorize() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPickSubscriber(t *testing.T) {
+	stable := apis.HTTP("stable.example.com")
+	canary := apis.HTTP("canary.example.com")
+	weighted := func(subs ...eventingv1.TriggerSubscriberStatus) *eventingv1.Trigger {
+		return makeTrigger(withoutSubscriberURI(), func(t *eventingv1.Trigger) {
+			t.Status.Subscribers = subs
+		})
+	}
+
+	tests := map[string]struct {
+		trigger  *eventingv1.Trigger
+		n        int
+		wantURL  *apis.URL
+		wantName string
+		wantOK   bool
+	}{
+		"single subscriber": {
+			trigger: makeTrigger(),
+			wantURL: &apis.URL{Host: "toBeReplaced"},
+			wantOK:  true,
+		},
+		"no subscriber": {
+			trigger: makeTrigger(withoutSubscriberURI()),
+		},
+		"first share": {
+			trigger: weighted(
+				eventingv1.TriggerSubscriberStatus{Name: "stable", URI: stable, Weight: 90},
+				eventingv1.TriggerSubscriberStatus{Name: "canary", URI: canary, Weight: 10},
+			),
+			n:        89,
+			wantURL:  stable,
+			wantName: "stable",
+			wantOK:   true,
+		},
+		"second share": {
+			trigger: weighted(
+				eventingv1.TriggerSubscriberStatus{Name: "stable", URI: stable, Weight: 90},
+				eventingv1.TriggerSubscriberStatus{Name: "canary", URI: canary, Weight: 10},
+			),
+			n:        90,
+			wantURL:  canary,
+			wantName: "canary",
+			wantOK:   true,
+		},
+		"zero weight is skipped": {
+			trigger: weighted(
+				eventingv1.TriggerSubscriberStatus{Name: "stable", URI: stable, Weight: 0},
+				eventingv1.TriggerSubscriberStatus{Name: "canary", URI: canary, Weight: 1},
+			),
+			wantURL:  canary,
+			wantName: "canary",
+			wantOK:   true,
+		},
+		"all weights zero": {
+			trigger: weighted(
+				eventingv1.TriggerSubscriberStatus{Name: "stable", URI: stable, Weight: 0},
+			),
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			target, name, ok := pickSubscriber(tc.trigger, func(total int) int {
+				if tc.n >= total {
+					t.Fatalf("Random number %d out of [0,%d)", tc.n, total)
+				}
+				return tc.n
+			})
+			if ok != tc.wantOK {
+				t.Fatalf("Expected ok %v, got %v", tc.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if target.URL.String() != tc.wantURL.String() {
+				t.Errorf("Expected target %s, got %s", tc.wantURL, target.URL)
+			}
+			if name != tc.wantName {
+				t.Errorf("Expected subscriber %q, got %q", tc.wantName, name)
+			}
+		})
+	}
+}
+
+func TestReceiver_Mirror(t *testing.T) {
+	testCases := map[string]struct {
+		mirrorStatus int
+	}{
+		"Mirror accepts the event": {
+			mirrorStatus: http.StatusAccepted,
+		},
+		"Mirror fails": {
+			mirrorStatus: http.StatusInternalServerError,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+			fh := fakeHandler{t: t}
+			s := httptest.NewServer(&fh)
+			defer s.Close()
+
+			mirrored := make(chan *cloudevents.Event, 1)
+			mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+				if err != nil {
+					t.Error("Unable to read the mirrored event:", err)
+				}
+				mirrored <- e
+				w.WriteHeader(tc.mirrorStatus)
+			}))
+			defer mirror.Close()
+
+			subscriberURL, _ := apis.ParseURL(s.URL)
+			mirrorURL, _ := apis.ParseURL(mirror.URL)
+			trig := makeTrigger(func(t *eventingv1.Trigger) {
+				t.Status.SubscriberURI = subscriberURL
+				t.Status.MirrorURI = mirrorURL
+			})
+			triggerinformerfake.Get(ctx).Informer().GetStore().Add(trig)
+
+			r, err := NewHandler(
+				zaptest.NewLogger(t),
+				auth.NewOIDCTokenVerifier(ctx),
+				auth.NewOIDCTokenProvider(ctx),
+				triggerinformerfake.Get(ctx),
+				brokerinformerfake.Get(ctx),
+				&mockReporter{},
+				configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+				func(ctx context.Context) context.Context { return ctx },
+			)
+			if err != nil {
+				t.Fatal("Unable to create receiver:", err)
+			}
+
+			b, err := makeEvent().MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, validPath, bytes.NewBuffer(b))
+			request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+			responseWriter := httptest.NewRecorder()
+			r.ServeHTTP(responseWriter, request)
+
+			if got := responseWriter.Result().StatusCode; got != http.StatusAccepted {
+				t.Errorf("Unexpected status. Expected %v. Actual %v.", http.StatusAccepted, got)
+			}
+			if !fh.requestReceived {
+				t.Error("Expected the subscriber to receive the event")
+			}
+
+			select {
+			case e := <-mirrored:
+				if e == nil {
+					return
+				}
+				if e.ID() != makeEvent().ID() {
+					t.Errorf("Expected the mirror to receive event %q, got %q", makeEvent().ID(), e.ID())
+				}
+				if _, err := broker.GetTTL(e.Context); err == nil {
+					t.Error("Broker TTL should not be seen by the mirror")
+				}
+			case <-time.After(5 * time.Second):
+				t.Error("Expected the mirror to receive the event")
+			}
+		})
+	}
+}
+
+func TestReceiver_SyncDelivery(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ce-"+broker.SyncReplyToAttribute) != "" {
+			t.Error("The synchronous delivery reply URL should not be seen by the subscriber")
+		}
+		message := binding.ToMessage(makeDifferentEvent())
+		defer message.Finish(nil)
+		if err := cehttp.WriteResponseWriter(r.Context(), message, http.StatusOK, w); err != nil {
+			t.Error("Unable to write the reply:", err)
+		}
+	}))
+	defer s.Close()
+
+	posted := make(chan *http.Request, 1)
+	ingress := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted <- r
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ingress.Close()
+
+	subscriberURL, _ := apis.ParseURL(s.URL)
+	trig := makeTrigger(func(t *eventingv1.Trigger) {
+		t.Status.SubscriberURI = subscriberURL
+	})
+	triggerinformerfake.Get(ctx).Informer().GetStore().Add(trig)
+
+	r, err := NewHandler(
+		zaptest.NewLogger(t),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		triggerinformerfake.Get(ctx),
+		brokerinformerfake.Get(ctx),
+		&mockReporter{},
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return feature.ToContext(ctx, feature.Flags{feature.BrokerSynchronousDelivery: feature.Enabled})
+		},
+	)
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+
+	e := makeEvent()
+	if err := broker.SetSyncReplyTo(e.Context, ingress.URL+"/id"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := httptest.NewRequest(http.MethodPost, validPath, bytes.NewBuffer(b))
+	request.Header.Set(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+	responseWriter := httptest.NewRecorder()
+	r.ServeHTTP(responseWriter, request)
+
+	if got := responseWriter.Result().StatusCode; got != http.StatusOK {
+		t.Errorf("Unexpected status. Expected %v. Actual %v.", http.StatusOK, got)
+	}
+
+	select {
+	case req := <-posted:
+		if req.URL.Path != "/id" {
+			t.Errorf("Expected the result posted to /id, got %q", req.URL.Path)
+		}
+		if got := req.Header.Get(broker.SyncStatusHeader); got != "200" {
+			t.Errorf("Expected the status code 200 in the result, got %q", got)
+		}
+		if got := req.Header.Get("Ce-Id"); got != makeDifferentEvent().ID() {
+			t.Errorf("Expected the reply event %q in the result, got %q", makeDifferentEvent().ID(), got)
+		}
+		if req.Header.Get("Ce-"+broker.TTLAttribute) != "" {
+			t.Error("Broker TTL should not be seen in the result")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected the result to be posted to the ingress")
+	}
+}
+
 func makeTrigger(options ...TriggerOption) *eventingv1.Trigger {
 	t := &eventingv1.Trigger{
 		TypeMeta: metav1.TypeMeta{
@@ -871,6 +1499,24 @@ func withAttributesFilter(filter *eventingv1.TriggerFilter) TriggerOption {
 	}
 }
 
+func withDeliveryTimeout(timeout string) TriggerOption {
+	return func(t *eventingv1.Trigger) {
+		t.Spec.Delivery = &eventingduckv1.DeliverySpec{Timeout: &timeout}
+	}
+}
+
+func withDeliveryConcurrency(concurrency int32) TriggerOption {
+	return func(t *eventingv1.Trigger) {
+		t.Spec.Delivery = &eventingduckv1.DeliverySpec{Concurrency: &concurrency}
+	}
+}
+
+func withRetryAfterMax(retryAfterMax string) TriggerOption {
+	return func(t *eventingv1.Trigger) {
+		t.Spec.Delivery = &eventingduckv1.DeliverySpec{RetryAfterMax: &retryAfterMax}
+	}
+}
+
 func withoutSubscriberURI() TriggerOption {
 	return func(t *eventingv1.Trigger) {
 		t.Status.SubscriberURI = nil
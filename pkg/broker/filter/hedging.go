@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/rickb777/date/period"
+	"go.uber.org/zap"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// hedgeDelay returns the delay after which the event is sent a second time to
+// the subscriber of the given Trigger, or 0 when it isn't.
+func (h *Handler) hedgeDelay(ctx context.Context, t *eventingv1.Trigger) time.Duration {
+	if !feature.FromContext(ctx).IsEnabled(feature.DeliveryHedging) {
+		return 0
+	}
+
+	delivery := h.deliverySpec(ctx, t)
+	if delivery == nil || delivery.HedgeDelay == nil {
+		return 0
+	}
+
+	p, err := period.Parse(*delivery.HedgeDelay)
+	if err != nil {
+		h.logger.Warn("Invalid hedge delay", zap.String("hedgeDelay", *delivery.HedgeDelay), zap.Error(err))
+		return 0
+	}
+	delay, _ := p.Duration()
+	return delay
+}
+
+type hedgeResult struct {
+	hedge bool
+	info  *kncloudevents.DispatchInfo
+	err   error
+}
+
+// sendHedged sends the event to the target and, when the target didn't answer
+// within the delay, sends it a second time. The first successful response is
+// returned, and the other attempt cancelled. When both attempts fail, the
+// failure of the last one is returned. A delay of 0 sends the event once.
+func (h *Handler) sendHedged(ctx context.Context, delay time.Duration, reportArgs *ReportArgs, event cloudevents.Event, target duckv1.Addressable, opts ...kncloudevents.SendOption) (*kncloudevents.DispatchInfo, error) {
+	if delay <= 0 {
+		return h.eventDispatcher.SendEvent(ctx, event, target, opts...)
+	}
+
+	// Cancelling the context cancels the attempt that lost, once the other
+	// one answered.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	results := make(chan hedgeResult, 2)
+	attempt := func(hedge bool) {
+		info, err := h.eventDispatcher.SendEvent(ctx, event, target, opts...)
+		results <- hedgeResult{hedge: hedge, info: info, err: err}
+	}
+	go attempt(false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	pending, hedged := 1, false
+	for {
+		select {
+		case <-timer.C:
+			h.logger.Debug("Subscriber slow to answer, sending the event a second time",
+				zap.String("id", event.ID()), zap.Duration("hedgeDelay", delay))
+			hedged = true
+			pending++
+			go attempt(true)
+
+		case r := <-results:
+			pending--
+			if !hedged {
+				return r.info, r.err
+			}
+			if r.err != nil && pending > 0 {
+				continue
+			}
+
+			winner := HedgeWinnerPrimary
+			if r.err != nil {
+				winner = HedgeWinnerNone
+			} else if r.hedge {
+				winner = HedgeWinnerHedge
+			}
+			_ = h.reporter.ReportEventHedged(reportArgs, winner)
+
+			// The latency seen by the sender includes the delay before the
+			// second attempt.
+			if r.info != nil {
+				r.info.Duration = time.Since(start)
+			}
+			return r.info, r.err
+		}
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap/zaptest"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func TestSendHedged(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	testCases := map[string]struct {
+		delay time.Duration
+		// respond answers the n-th request, starting at 1.
+		respond      func(n int32, w http.ResponseWriter, r *http.Request)
+		wantRequests int32
+		wantCode     int
+		wantErr      bool
+		wantWinners  []string
+		// wantCancelled is whether the first request is cancelled.
+		wantCancelled bool
+	}{
+		"no hedge delay": {
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				time.Sleep(2 * delay)
+				w.WriteHeader(http.StatusAccepted)
+			},
+			wantRequests: 1,
+			wantCode:     http.StatusAccepted,
+		},
+		"answered within the hedge delay": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			wantRequests: 1,
+			wantCode:     http.StatusAccepted,
+		},
+		"failed within the hedge delay": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantRequests: 1,
+			wantCode:     http.StatusInternalServerError,
+			wantErr:      true,
+		},
+		"hedge wins": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				if n == 1 {
+					// Wait for the request to be cancelled.
+					select {
+					case <-r.Context().Done():
+					case <-time.After(10 * delay):
+					}
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			},
+			wantRequests:  2,
+			wantCode:      http.StatusAccepted,
+			wantWinners:   []string{HedgeWinnerHedge},
+			wantCancelled: true,
+		},
+		"primary wins": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				if n == 1 {
+					time.Sleep(delay + delay/2)
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+				time.Sleep(10 * delay)
+			},
+			wantRequests: 2,
+			wantCode:     http.StatusAccepted,
+			wantWinners:  []string{HedgeWinnerPrimary},
+		},
+		"primary fails, hedge wins": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				if n == 1 {
+					time.Sleep(delay + delay/2)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				time.Sleep(delay)
+				w.WriteHeader(http.StatusAccepted)
+			},
+			wantRequests: 2,
+			wantCode:     http.StatusAccepted,
+			wantWinners:  []string{HedgeWinnerHedge},
+		},
+		"both fail": {
+			delay: delay,
+			respond: func(n int32, w http.ResponseWriter, r *http.Request) {
+				if n == 1 {
+					time.Sleep(delay + delay/2)
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantRequests: 2,
+			wantCode:     http.StatusServiceUnavailable,
+			wantErr:      true,
+			wantWinners:  []string{HedgeWinnerNone},
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			var requests atomic.Int32
+			cancelled := make(chan struct{}, 1)
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+				tc.respond(n, w, r)
+				if n == 1 && r.Context().Err() != nil {
+					cancelled <- struct{}{}
+				}
+			}))
+			defer s.Close()
+
+			reporter := &mockReporter{}
+			h := &Handler{
+				logger:          zaptest.NewLogger(t),
+				reporter:        reporter,
+				eventDispatcher: kncloudevents.NewDispatcher(eventingtls.ClientConfig{}, nil),
+			}
+
+			target := duckv1.Addressable{URL: apis.HTTP(s.Listener.Addr().String())}
+			info, err := h.sendHedged(context.Background(), tc.delay, &ReportArgs{}, *makeEvent(), target)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("sendHedged() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if info.ResponseCode != tc.wantCode {
+				t.Errorf("Expected the response code %d, got %d", tc.wantCode, info.ResponseCode)
+			}
+			if got := requests.Load(); got != tc.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tc.wantRequests, got)
+			}
+			if diff := cmp.Diff(tc.wantWinners, reporter.hedgeWinners); diff != "" {
+				t.Error("Unexpected hedge winners (-want, +got):", diff)
+			}
+			if tc.wantCancelled {
+				select {
+				case <-cancelled:
+				case <-time.After(5 * time.Second):
+					t.Error("Expected the first request to be cancelled")
+				}
+			}
+		})
+	}
+}
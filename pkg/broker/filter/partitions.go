@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PartitionKeyExtension is the CloudEvents partitioning extension holding
+// the key of the events which must be dispatched in order.
+const PartitionKeyExtension = "partitionkey"
+
+// Partitions dispatches the events of a Trigger with the same partition key
+// one at a time, in the order they were received. Every Trigger and key has
+// its own queue, drained by a goroutine which exits once the queue is empty,
+// so a slow subscriber only delays the events of its Trigger with the same
+// key.
+type Partitions struct {
+	ctx context.Context
+
+	mu     sync.Mutex
+	queues map[partition]*partitionQueue
+}
+
+// partition identifies the events of a Trigger with the same key.
+type partition struct {
+	uid types.UID
+	key string
+}
+
+type partitionQueue struct {
+	// pending holds the dispatches waiting for the running one, in order.
+	pending []*partitionDispatch
+}
+
+type partitionDispatch struct {
+	fn   func()
+	done chan struct{}
+	// started is set once fn is called, canceled when the dispatch was
+	// given up before, both guarded by the mutex of the Partitions.
+	started  bool
+	canceled bool
+}
+
+// NewPartitions returns Partitions dispatching the events until ctx is done.
+func NewPartitions(ctx context.Context) *Partitions {
+	return &Partitions{
+		ctx:    ctx,
+		queues: make(map[partition]*partitionQueue),
+	}
+}
+
+// PartitionKey returns the partition key of the event, if any.
+func PartitionKey(event *cloudevents.Event) (string, bool) {
+	key, err := event.Context.GetExtension(PartitionKeyExtension)
+	if err != nil {
+		return "", false
+	}
+	s, ok := key.(string)
+	return s, ok && s != ""
+}
+
+// dispatch calls fn after the previous events of the key for the Trigger and
+// waits for it to return. ok is false when ctx, or the context of the
+// Partitions, is done before fn is called, which it then never is.
+func (p *Partitions) dispatch(ctx context.Context, uid types.UID, key string, fn func()) (ok bool) {
+	if p == nil {
+		fn()
+		return true
+	}
+
+	if ctx.Err() != nil || p.ctx.Err() != nil {
+		return false
+	}
+
+	d := &partitionDispatch{fn: fn, done: make(chan struct{})}
+	id := partition{uid: uid, key: key}
+	p.mu.Lock()
+	q, running := p.queues[id]
+	if !running {
+		q = &partitionQueue{}
+		p.queues[id] = q
+	}
+	q.pending = append(q.pending, d)
+	p.mu.Unlock()
+	if !running {
+		go p.drain(id, q)
+	}
+
+	select {
+	case <-d.done:
+		return true
+	case <-ctx.Done():
+	case <-p.ctx.Done():
+	}
+
+	p.mu.Lock()
+	if !d.started {
+		d.canceled = true
+		p.mu.Unlock()
+		return false
+	}
+	p.mu.Unlock()
+	<-d.done
+	return true
+}
+
+// drain calls the pending dispatches of the queue in order, and removes the
+// queue once it is empty.
+func (p *Partitions) drain(id partition, q *partitionQueue) {
+	for {
+		p.mu.Lock()
+		if len(q.pending) == 0 {
+			delete(p.queues, id)
+			p.mu.Unlock()
+			return
+		}
+		d := q.pending[0]
+		q.pending = q.pending[1:]
+		if d.canceled {
+			p.mu.Unlock()
+			continue
+		}
+		d.started = true
+		p.mu.Unlock()
+
+		d.fn()
+		close(d.done)
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestPartitionsDisabled(t *testing.T) {
+	var p *Partitions
+	called := false
+	if ok := p.dispatch(context.Background(), "uid", "key", func() { called = true }); !ok || !called {
+		t.Fatalf("dispatch() on nil partitions = %t, called %t", ok, called)
+	}
+}
+
+func TestPartitionsIndependentTriggers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewPartitions(ctx)
+
+	// The subscriber of the first Trigger is stuck.
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	go p.dispatch(ctx, "slow", "key", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+
+	// The other Triggers, with the same key or not, and the other keys of
+	// the first Trigger aren't delayed.
+	for _, tc := range []struct {
+		uid types.UID
+		key string
+	}{{"other", "key"}, {"other", "other-key"}, {"slow", "other-key"}} {
+		done := make(chan bool)
+		go func() {
+			done <- p.dispatch(ctx, tc.uid, tc.key, func() {})
+		}()
+		select {
+		case ok := <-done:
+			if !ok {
+				t.Errorf("dispatch(%s, %s) failed", tc.uid, tc.key)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("dispatch(%s, %s) was delayed by another Trigger", tc.uid, tc.key)
+		}
+	}
+
+	// The next events of the stuck key wait.
+	queued := make(chan bool)
+	go func() {
+		queued <- p.dispatch(ctx, "slow", "key", func() {})
+	}()
+	select {
+	case <-queued:
+		t.Fatal("dispatch() didn't wait for the previous event of the key")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if ok := <-queued; !ok {
+		t.Error("dispatch() failed")
+	}
+
+	// The idle queues are removed.
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return len(p.queues) == 0, nil
+	}); err != nil {
+		t.Error("the idle queues weren't removed:", err)
+	}
+}
+
+func TestPartitionsCanceled(t *testing.T) {
+	p := NewPartitions(context.Background())
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	go p.dispatch(context.Background(), "uid", "key", func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+
+	// The dispatch given up while the previous event of the key is sent is
+	// never called.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	called := false
+	if ok := p.dispatch(ctx, "uid", "key", func() { called = true }); ok {
+		t.Error("dispatch() = true once its context is done")
+	}
+	close(release)
+	if ok := p.dispatch(context.Background(), "uid", "key", func() {}); !ok {
+		t.Error("dispatch() failed")
+	}
+	if called {
+		t.Error("the canceled dispatch was called")
+	}
+}
+
+func TestPartitionsOrdering(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewPartitions(ctx)
+
+	const (
+		uid    types.UID = "uid"
+		events           = 50
+	)
+
+	var mu sync.Mutex
+	var got []int
+	var active int
+
+	// Dispatch the events one after the other, each waiting for the previous
+	// one to be queued, as the sender of ordered events does.
+	for i := 0; i < events; i++ {
+		i := i
+		if ok := p.dispatch(ctx, uid, "key", func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if active++; active > 1 {
+				t.Error("events with the same key dispatched concurrently")
+			}
+			got = append(got, i)
+			active--
+		}); !ok {
+			t.Fatal("dispatch() failed")
+		}
+	}
+
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("got events %v, want them in order", got)
+		}
+	}
+}
+
+func TestPartitionsConcurrentKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := NewPartitions(ctx)
+
+	var mu sync.Mutex
+	running := make(map[string]bool)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprint("key-", i%10)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.dispatch(ctx, "uid", key, func() {
+				mu.Lock()
+				if running[key] {
+					t.Errorf("events with the key %q dispatched concurrently", key)
+				}
+				running[key] = true
+				mu.Unlock()
+
+				mu.Lock()
+				running[key] = false
+				mu.Unlock()
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPartitionsStopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPartitions(ctx)
+	cancel()
+
+	called := false
+	if ok := p.dispatch(ctx, "uid", "key", func() { called = true }); ok || called {
+		t.Errorf("dispatch() once stopped = %t, called %t", ok, called)
+	}
+}
+
+func TestPartitionKey(t *testing.T) {
+	e := makeEventWithoutTTL()
+	if _, ok := PartitionKey(e); ok {
+		t.Error("PartitionKey() of an event without key succeeded")
+	}
+	e.SetExtension(PartitionKeyExtension, "order-42")
+	if key, ok := PartitionKey(e); !ok || key != "order-42" {
+		t.Errorf("PartitionKey() = %q, %t, want order-42", key, ok)
+	}
+}
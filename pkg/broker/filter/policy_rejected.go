@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// PolicyRejectedEventType is the type of the events reporting an event
+	// rejected by the EventPolicies targeting a Trigger.
+	PolicyRejectedEventType = "dev.knative.eventpolicy.rejected"
+
+	// policyRejectedTimeout bounds the requests to the rejection sinks.
+	policyRejectedTimeout = 10 * time.Second
+
+	// maxPolicyRejectedInFlight is the number of policy rejected events
+	// sent at once, beyond which they are dropped.
+	maxPolicyRejectedInFlight = 100
+)
+
+// PolicyRejection is the data of the dev.knative.eventpolicy.rejected
+// events. It describes the rejected event with its metadata only, never its
+// data.
+type PolicyRejection struct {
+	Namespace   string `json:"namespace"`
+	EventPolicy string `json:"eventPolicy"`
+	Trigger     string `json:"trigger"`
+
+	// Subject is the identity of the producer of the event, as claimed by
+	// its auth subject extension, if any.
+	Subject string `json:"subject,omitempty"`
+	// Reason explains why the event was rejected.
+	Reason string `json:"reason"`
+
+	// Event holds the context attributes of the rejected event.
+	Event FailedEvent `json:"event"`
+}
+
+// MakePolicyRejectedEvent creates the dev.knative.eventpolicy.rejected event
+// reporting that the EventPolicy rejected the given event sent to the
+// Trigger.
+func MakePolicyRejectedEvent(policy *v1alpha1.EventPolicy, t *eventingv1.Trigger, rejected *cloudevents.Event, reason error) (cloudevents.Event, error) {
+	rejection := PolicyRejection{
+		Namespace:   t.Namespace,
+		EventPolicy: policy.Name,
+		Trigger:     t.Name,
+		Reason:      reason.Error(),
+		Event: FailedEvent{
+			ID:      rejected.ID(),
+			Type:    rejected.Type(),
+			Source:  rejected.Source(),
+			Subject: rejected.Subject(),
+		},
+	}
+	if subject, ok := auth.GetAuthSubject(rejected); ok {
+		rejection.Subject = subject
+	}
+	if tm := rejected.Time(); !tm.IsZero() {
+		rejection.Event.Time = &tm
+	}
+
+	e := cloudevents.NewEvent()
+	e.SetID(uuid.New().String())
+	e.SetType(PolicyRejectedEventType)
+	e.SetSource(fmt.Sprintf("/apis/%s/namespaces/%s/eventpolicies/%s", v1alpha1.SchemeGroupVersion.String(), policy.Namespace, policy.Name))
+	e.SetSubject(rejected.ID())
+	e.SetTime(time.Now())
+	if err := e.SetData(cloudevents.ApplicationJSON, rejection); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+// sendPolicyRejected reports the event rejected by the EventPolicies to
+// their rejection sinks, if any. The reports are sent in the background, they
+// don't delay the response to the sender. They are dropped and counted when
+// maxPolicyRejectedInFlight reports are already being sent, so that a flood
+// of rejected events can't pile up requests to slow sinks.
+func (h *Handler) sendPolicyRejected(ctx context.Context, policies []*v1alpha1.EventPolicy, t *eventingv1.Trigger, event *cloudevents.Event, reason error) {
+	retryConfig := kncloudevents.NoRetries()
+	retryConfig.RequestTimeout = policyRejectedTimeout
+
+	opts := []kncloudevents.SendOption{kncloudevents.WithRetryConfig(&retryConfig)}
+	if t.Status.Auth != nil && t.Status.Auth.ServiceAccountName != nil {
+		opts = append(opts, kncloudevents.WithOIDCAuthentication(&types.NamespacedName{
+			Name:      *t.Status.Auth.ServiceAccountName,
+			Namespace: t.Namespace,
+		}))
+	}
+
+	reportArgs := &ReportArgs{
+		ns:          t.Namespace,
+		trigger:     t.Name,
+		broker:      t.Spec.Broker,
+		requestType: "policy_rejected",
+	}
+
+	// The requests to the rejection sinks must outlive the request from the
+	// Broker.
+	ctx = context.WithoutCancel(ctx)
+	for _, policy := range policies {
+		target := policy.Status.RejectionSink()
+		if target == nil {
+			continue
+		}
+		e, err := MakePolicyRejectedEvent(policy, t, event, reason)
+		if err != nil {
+			h.logger.Error("failed to create policy rejected event", zap.Error(err))
+			continue
+		}
+		select {
+		case h.policyRejectedSlots <- struct{}{}:
+		default:
+			h.logger.Warn("dropping policy rejected event, too many are being sent",
+				zap.String("eventPolicy", fmt.Sprintf("%s/%s", t.Namespace, policy.Name)),
+				zap.String("id", event.ID()))
+			_ = h.reporter.ReportPolicyRejectedDropped(reportArgs)
+			continue
+		}
+		go func(policy string) {
+			defer func() { <-h.policyRejectedSlots }()
+			if _, err := h.eventDispatcher.SendEvent(ctx, e, *target, opts...); err != nil {
+				h.logger.Warn("failed to send policy rejected event",
+					zap.String("eventPolicy", fmt.Sprintf("%s/%s", t.Namespace, policy)),
+					zap.String("id", event.ID()),
+					zap.Error(err))
+			}
+		}(policy.Name)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	eventingv1alpha1listers "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func TestMakePolicyRejectedEvent(t *testing.T) {
+	rejected := makeEventWithoutTTL()
+	rejected.SetData(cloudevents.ApplicationJSON, map[string]string{"secret": "value"})
+	auth.SetAuthSubject(rejected, "system:serviceaccounts:ns:denied")
+	policy := &eventingv1alpha1.EventPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "policy"},
+	}
+
+	e, err := MakePolicyRejectedEvent(policy, makeTrigger(), rejected, errors.New("not allowed"))
+	if err != nil {
+		t.Fatal("MakePolicyRejectedEvent() =", err)
+	}
+	if err := e.Validate(); err != nil {
+		t.Error("Invalid event:", err)
+	}
+	if e.Type() != PolicyRejectedEventType {
+		t.Errorf("Expected the type %q, got %q", PolicyRejectedEventType, e.Type())
+	}
+	if want := "/apis/eventing.knative.dev/v1alpha1/namespaces/" + testNS + "/eventpolicies/policy"; e.Source() != want {
+		t.Errorf("Expected the source %q, got %q", want, e.Source())
+	}
+	if e.Subject() != rejected.ID() {
+		t.Errorf("Expected the subject %q, got %q", rejected.ID(), e.Subject())
+	}
+
+	var got PolicyRejection
+	if err := e.DataAs(&got); err != nil {
+		t.Fatal("Unable to read the data:", err)
+	}
+	want := PolicyRejection{
+		Namespace:   testNS,
+		EventPolicy: "policy",
+		Trigger:     triggerName,
+		Subject:     "system:serviceaccounts:ns:denied",
+		Reason:      "not allowed",
+		Event: FailedEvent{
+			ID:     rejected.ID(),
+			Type:   eventType,
+			Source: eventSource,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Unexpected data (-want, +got) =", diff)
+	}
+}
+
+func TestAuthorize_RejectionSink(t *testing.T) {
+	received := make(chan cloudevents.Event, 1)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := cehttp.NewEventFromHTTPRequest(r)
+		if err != nil {
+			t.Error("Unable to read the event:", err)
+		} else {
+			received <- *e
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+
+	sinkURI := apis.HTTP(sink.Listener.Addr().String())
+	policy := &eventingv1alpha1.EventPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "policy"},
+		Spec: eventingv1alpha1.EventPolicySpec{
+			To: []eventingv1alpha1.EventPolicySpecTo{{
+				Ref: &eventingv1alpha1.EventPolicyToReference{APIVersion: "eventing.knative.dev/v1", Kind: "Trigger", Name: triggerName},
+			}},
+		},
+		Status: eventingv1alpha1.EventPolicyStatus{
+			From:             []string{"system:serviceaccounts:ns:allowed"},
+			RejectionSinkURI: sinkURI,
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(policy); err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{
+		logger:              zaptest.NewLogger(t),
+		reporter:            &mockReporter{},
+		EventPolicyLister:   eventingv1alpha1listers.NewEventPolicyLister(indexer),
+		eventDispatcher:     kncloudevents.NewDispatcher(eventingtls.ClientConfig{}, nil),
+		policyRejectedSlots: make(chan struct{}, maxPolicyRejectedInFlight),
+	}
+
+	e := makeEvent()
+	auth.SetAuthSubject(e, "system:serviceaccounts:ns:denied")
+	ctx := feature.ToContext(context.Background(), feature.Flags{feature.OIDCAuthentication: feature.Enabled})
+	if err := h.authorize(ctx, makeTrigger(), e); err == nil {
+		t.Fatal("Expected the event to be rejected")
+	}
+
+	select {
+	case got := <-received:
+		if got.Type() != PolicyRejectedEventType {
+			t.Errorf("Expected the type %q, got %q", PolicyRejectedEventType, got.Type())
+		}
+		if got.Subject() != e.ID() {
+			t.Errorf("Expected the subject %q, got %q", e.ID(), got.Subject())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the policy rejected event")
+	}
+}
+
+func TestSendPolicyRejected_Overflow(t *testing.T) {
+	received := make(chan struct{}, 2)
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer sink.Close()
+
+	policy := &eventingv1alpha1.EventPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNS, Name: "policy"},
+		Status: eventingv1alpha1.EventPolicyStatus{
+			RejectionSinkURI: apis.HTTP(sink.Listener.Addr().String()),
+		},
+	}
+	reporter := &mockReporter{}
+	h := &Handler{
+		logger:              zaptest.NewLogger(t),
+		reporter:            reporter,
+		eventDispatcher:     kncloudevents.NewDispatcher(eventingtls.ClientConfig{}, nil),
+		policyRejectedSlots: make(chan struct{}, 1),
+	}
+
+	// The only slot is taken, the report is dropped and counted.
+	h.policyRejectedSlots <- struct{}{}
+	policies := []*eventingv1alpha1.EventPolicy{policy}
+	h.sendPolicyRejected(context.Background(), policies, makeTrigger(), makeEvent(), errors.New("not allowed"))
+	if reporter.policyRejectedDropped != 1 {
+		t.Errorf("Expected 1 dropped policy rejected event, got %d", reporter.policyRejectedDropped)
+	}
+
+	// Once the slot is released, the reports are sent again.
+	<-h.policyRejectedSlots
+	h.sendPolicyRejected(context.Background(), policies, makeTrigger(), makeEvent(), errors.New("not allowed"))
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the policy rejected event")
+	}
+	if reporter.policyRejectedDropped != 1 {
+		t.Errorf("Expected 1 dropped policy rejected event, got %d", reporter.policyRejectedDropped)
+	}
+	select {
+	case <-received:
+		t.Error("Expected the dropped policy rejected event not to be sent")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding/spec"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ResponseHeaderPolicy selects the subscriber response headers kept on the
+// reply, on top of the ones always passed through (see
+// utils.PassThroughHeaders).
+type ResponseHeaderPolicy struct {
+	// extensions maps the canonical names of the headers propagated onto
+	// the reply event to the name of the extension they are set as.
+	extensions map[string]string
+	// preserved are the canonical names of the headers preserved on the
+	// HTTP response.
+	preserved sets.Set[string]
+}
+
+// ParseResponseHeaderPolicy parses the comma separated lists of headers
+// propagated onto the reply event as extensions and of headers preserved on
+// the HTTP response. The extension name of a header is its lower cased name
+// without the characters not allowed in extension names, e.g.
+// X-Correlation-Id is propagated as the xcorrelationid extension.
+func ParseResponseHeaderPolicy(asExtensions, preserved string) (*ResponseHeaderPolicy, error) {
+	p := &ResponseHeaderPolicy{
+		extensions: make(map[string]string),
+		preserved:  sets.New[string](),
+	}
+	names := make(map[string]string)
+	for _, h := range splitHeaders(asExtensions) {
+		name := headerExtensionName(h)
+		if name == "" {
+			return nil, fmt.Errorf("header %q has no valid extension name", h)
+		}
+		if spec.V1.Attribute(name) != nil {
+			return nil, fmt.Errorf("header %q maps to the CloudEvents attribute %q", h, name)
+		}
+		if other, ok := names[name]; ok && other != h {
+			return nil, fmt.Errorf("headers %q and %q map to the same extension %q", other, h, name)
+		}
+		names[name] = h
+		p.extensions[h] = name
+	}
+	p.preserved.Insert(splitHeaders(preserved)...)
+	return p, nil
+}
+
+func splitHeaders(s string) []string {
+	var headers []string
+	for _, h := range strings.Split(s, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, http.CanonicalHeaderKey(h))
+		}
+	}
+	return headers
+}
+
+func headerExtensionName(header string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(header) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// PreservedHeaders returns the headers of the response preserved on the HTTP
+// response.
+func (p *ResponseHeaderPolicy) PreservedHeaders(header http.Header) http.Header {
+	preserved := make(http.Header)
+	if p == nil {
+		return preserved
+	}
+	for h := range p.preserved {
+		if values := header.Values(h); len(values) > 0 {
+			preserved[h] = values
+		}
+	}
+	return preserved
+}
+
+// SetExtensions sets the headers of the response propagated onto the reply
+// event as extensions. Extensions already set by the subscriber are left
+// unchanged.
+func (p *ResponseHeaderPolicy) SetExtensions(e *event.Event, header http.Header) error {
+	if p == nil {
+		return nil
+	}
+	for h, name := range p.extensions {
+		value := header.Get(h)
+		if value == "" {
+			continue
+		}
+		if _, ok := e.Extensions()[name]; ok {
+			continue
+		}
+		if err := e.Context.SetExtension(name, value); err != nil {
+			return fmt.Errorf("failed to set extension %q from header %q: %w", name, h, err)
+		}
+	}
+	return nil
+}
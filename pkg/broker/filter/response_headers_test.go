@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"net/http"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustParseResponseHeaderPolicy(asExtensions, preserved string) *ResponseHeaderPolicy {
+	p, err := ParseResponseHeaderPolicy(asExtensions, preserved)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestParseResponseHeaderPolicy(t *testing.T) {
+	tests := map[string]struct {
+		asExtensions string
+		wantErr      bool
+	}{
+		"empty": {},
+		"headers": {
+			asExtensions: " X-Correlation-Id , x-tenant ,",
+		},
+		"same header twice": {
+			asExtensions: "X-Tenant,x-tenant",
+		},
+		"no valid extension name": {
+			asExtensions: "--",
+			wantErr:      true,
+		},
+		"CloudEvents attribute": {
+			asExtensions: "Source",
+			wantErr:      true,
+		},
+		"extension name collision": {
+			asExtensions: "X-Tenant,XTenant",
+			wantErr:      true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			_, err := ParseResponseHeaderPolicy(tc.asExtensions, "")
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ParseResponseHeaderPolicy() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestResponseHeaderPolicy(t *testing.T) {
+	p := mustParseResponseHeaderPolicy("X-Correlation-Id,X-Tenant", "x-correlation-id")
+	header := http.Header{
+		"X-Correlation-Id": []string{"abc"},
+		"X-Tenant":         []string{"t1"},
+		"X-Other":          []string{"other"},
+	}
+
+	want := http.Header{"X-Correlation-Id": []string{"abc"}}
+	if diff := cmp.Diff(want, p.PreservedHeaders(header)); diff != "" {
+		t.Error("Unexpected preserved headers (-want +got):", diff)
+	}
+
+	e := cloudevents.NewEvent()
+	e.SetExtension("xtenant", "own")
+	if err := p.SetExtensions(&e, header); err != nil {
+		t.Fatal("SetExtensions() =", err)
+	}
+	wantExtensions := map[string]interface{}{
+		"xcorrelationid": "abc",
+		"xtenant":        "own",
+	}
+	if diff := cmp.Diff(wantExtensions, e.Extensions()); diff != "" {
+		t.Error("Unexpected extensions (-want +got):", diff)
+	}
+
+	var nilPolicy *ResponseHeaderPolicy
+	if got := nilPolicy.PreservedHeaders(header); len(got) != 0 {
+		t.Error("Unexpected preserved headers of a nil policy:", got)
+	}
+}
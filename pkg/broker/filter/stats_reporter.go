@@ -62,6 +62,42 @@ var (
 		stats.UnitMilliseconds,
 	)
 
+	// subscriberEventCountM is a counter which records the number of events
+	// sent to each subscriber of a Trigger splitting its events between
+	// several subscribers.
+	subscriberEventCountM = stats.Int64(
+		"subscriber_event_count",
+		"Number of events sent to each subscriber of a Trigger with weighted subscribers",
+		stats.UnitDimensionless,
+	)
+
+	// subscriberDispatchTimeInMsecM records the time spent dispatching an
+	// event to each subscriber of a Trigger splitting its events between
+	// several subscribers, in milliseconds.
+	subscriberDispatchTimeInMsecM = stats.Float64(
+		"subscriber_event_dispatch_latencies",
+		"The time spent dispatching an event to each subscriber of a Trigger with weighted subscribers",
+		stats.UnitMilliseconds,
+	)
+
+	// hedgeCountM is a counter which records the number of events sent a
+	// second time to a Trigger subscriber slow to answer, by attempt
+	// answering first.
+	hedgeCountM = stats.Int64(
+		"event_hedge_count",
+		"Number of events sent a second time to a Trigger subscriber slow to answer",
+		stats.UnitDimensionless,
+	)
+
+	// policyRejectedDroppedCountM is a counter which records the number of
+	// dev.knative.eventpolicy.rejected events dropped because too many of
+	// them were being sent.
+	policyRejectedDroppedCountM = stats.Int64(
+		"policy_rejected_dropped_count",
+		"Number of policy rejected events dropped because too many were being sent",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -72,6 +108,17 @@ var (
 	triggerFilterRequestSchemeKey = tag.MustNewKey(eventingmetrics.LabelEventScheme)
 	responseCodeKey               = tag.MustNewKey(eventingmetrics.LabelResponseCode)
 	responseCodeClassKey          = tag.MustNewKey(eventingmetrics.LabelResponseCodeClass)
+	triggerSubscriberKey          = tag.MustNewKey("trigger_subscriber")
+	hedgeWinnerKey                = tag.MustNewKey("hedge_winner")
+)
+
+// The attempts answering first an event sent a second time, see
+// ReportEventHedged.
+const (
+	HedgeWinnerPrimary = "primary"
+	HedgeWinnerHedge   = "hedge"
+	// HedgeWinnerNone is reported when both attempts failed.
+	HedgeWinnerNone = "none"
 )
 
 type ReportArgs struct {
@@ -81,6 +128,9 @@ type ReportArgs struct {
 	filterType    string
 	requestType   string
 	requestScheme string
+	// subscriber is the name of the subscriber the event is sent to, for
+	// Triggers splitting their events between several subscribers.
+	subscriber string
 }
 
 func init() {
@@ -92,6 +142,8 @@ type StatsReporter interface {
 	ReportEventCount(args *ReportArgs, responseCode int) error
 	ReportEventDispatchTime(args *ReportArgs, responseCode int, d time.Duration) error
 	ReportEventProcessingTime(args *ReportArgs, d time.Duration) error
+	ReportEventHedged(args *ReportArgs, winner string) error
+	ReportPolicyRejectedDropped(args *ReportArgs) error
 }
 
 var _ StatsReporter = (*reporter)(nil)
@@ -132,6 +184,30 @@ func register() {
 			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1, 2, 5, 10, 20, 50, 100, 1000, 5000, 10000
 			TagKeys:     []tag.Key{triggerFilterTypeKey, triggerFilterRequestTypeKey, triggerFilterRequestSchemeKey, broker.UniqueTagKey, broker.ContainerTagKey},
 		},
+		&view.View{
+			Description: subscriberEventCountM.Description(),
+			Measure:     subscriberEventCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{triggerSubscriberKey, triggerFilterRequestSchemeKey, responseCodeKey, responseCodeClassKey, broker.UniqueTagKey, broker.ContainerTagKey},
+		},
+		&view.View{
+			Description: subscriberDispatchTimeInMsecM.Description(),
+			Measure:     subscriberDispatchTimeInMsecM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1, 2, 5, 10, 20, 50, 100, 1000, 5000, 10000
+			TagKeys:     []tag.Key{triggerSubscriberKey, triggerFilterRequestSchemeKey, responseCodeKey, responseCodeClassKey, broker.UniqueTagKey, broker.ContainerTagKey},
+		},
+		&view.View{
+			Description: hedgeCountM.Description(),
+			Measure:     hedgeCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{triggerFilterTypeKey, triggerFilterRequestTypeKey, triggerFilterRequestSchemeKey, hedgeWinnerKey, broker.UniqueTagKey, broker.ContainerTagKey},
+		},
+		&view.View{
+			Description: policyRejectedDroppedCountM.Description(),
+			Measure:     policyRejectedDroppedCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{broker.UniqueTagKey, broker.ContainerTagKey},
+		},
 	)
 	if err != nil {
 		log.Printf("failed to register opencensus views, %s", err)
@@ -147,6 +223,12 @@ func (r *reporter) ReportEventCount(args *ReportArgs, responseCode int) error {
 		return err
 	}
 	metrics.Record(ctx, eventCountM.M(1))
+	if args.subscriber != "" {
+		if ctx, err = tag.New(ctx, tag.Insert(triggerSubscriberKey, args.subscriber)); err != nil {
+			return err
+		}
+		metrics.Record(ctx, subscriberEventCountM.M(1))
+	}
 	return nil
 }
 
@@ -160,6 +242,12 @@ func (r *reporter) ReportEventDispatchTime(args *ReportArgs, responseCode int, d
 	}
 	// convert time.Duration in nanoseconds to milliseconds.
 	metrics.Record(ctx, dispatchTimeInMsecM.M(float64(d/time.Millisecond)))
+	if args.subscriber != "" {
+		if ctx, err = tag.New(ctx, tag.Insert(triggerSubscriberKey, args.subscriber)); err != nil {
+			return err
+		}
+		metrics.Record(ctx, subscriberDispatchTimeInMsecM.M(float64(d/time.Millisecond)))
+	}
 	return nil
 }
 
@@ -175,6 +263,28 @@ func (r *reporter) ReportEventProcessingTime(args *ReportArgs, d time.Duration)
 	return nil
 }
 
+// ReportEventHedged captures an event sent a second time, along with the
+// attempt that answered first.
+func (r *reporter) ReportEventHedged(args *ReportArgs, winner string) error {
+	ctx, err := r.generateTag(args, tag.Insert(hedgeWinnerKey, winner))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, hedgeCountM.M(1))
+	return nil
+}
+
+// ReportPolicyRejectedDropped captures a policy rejected event dropped
+// because too many were being sent.
+func (r *reporter) ReportPolicyRejectedDropped(args *ReportArgs) error {
+	ctx, err := r.generateTag(args)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, policyRejectedDroppedCountM.M(1))
+	return nil
+}
+
 func (r *reporter) generateTag(args *ReportArgs, tags ...tag.Mutator) (context.Context, error) {
 	ctx := metricskey.WithResource(emptyContext, resource.Resource{
 		Type: eventingmetrics.ResourceTypeKnativeTrigger,
@@ -139,6 +139,124 @@ func TestReporterEmptySourceAndTypeFilter(t *testing.T) {
 	metricstest.AssertMetric(t, metricstest.IntMetric("event_count", 4, wantTags).WithResource(&resource))
 }
 
+func TestReporterSubscriber(t *testing.T) {
+	setup()
+
+	args := &ReportArgs{
+		ns:            "testns",
+		trigger:       "testtrigger",
+		broker:        "testbroker",
+		requestScheme: "http",
+		subscriber:    "canary",
+	}
+
+	r := NewStatsReporter("testcontainer", "testpod")
+
+	wantTags := map[string]string{
+		"trigger_subscriber":           "canary",
+		metrics.LabelResponseCode:      "202",
+		metrics.LabelResponseCodeClass: "2xx",
+		broker.LabelContainerName:      "testcontainer",
+		broker.LabelUniqueName:         "testpod",
+		metrics.LabelEventScheme:       "http",
+	}
+
+	resource := resource.Resource{
+		Type: metrics.ResourceTypeKnativeTrigger,
+		Labels: map[string]string{
+			metrics.LabelNamespaceName: "testns",
+			metrics.LabelTriggerName:   "testtrigger",
+			metrics.LabelBrokerName:    "testbroker",
+		},
+	}
+
+	expectSuccess(t, func() error {
+		return r.ReportEventCount(args, http.StatusAccepted)
+	})
+	expectSuccess(t, func() error {
+		return r.ReportEventDispatchTime(args, http.StatusAccepted, 1100*time.Millisecond)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("subscriber_event_count", 1, wantTags).WithResource(&resource))
+	metricstest.CheckDistributionData(t, "subscriber_event_dispatch_latencies", wantTags, 1, 1100.0, 1100.0)
+
+	// Triggers with a single subscriber aren't reported per subscriber.
+	args.subscriber = ""
+	expectSuccess(t, func() error {
+		return r.ReportEventCount(args, http.StatusAccepted)
+	})
+	metricstest.CheckCountData(t, "subscriber_event_count", wantTags, 1)
+}
+
+func TestReporterHedged(t *testing.T) {
+	setup()
+
+	args := &ReportArgs{
+		ns:            "testns",
+		trigger:       "testtrigger",
+		broker:        "testbroker",
+		requestScheme: "http",
+	}
+
+	r := NewStatsReporter("testcontainer", "testpod")
+
+	wantTags := map[string]string{
+		"hedge_winner":            HedgeWinnerHedge,
+		metrics.LabelFilterType:   anyValue,
+		broker.LabelContainerName: "testcontainer",
+		broker.LabelUniqueName:    "testpod",
+		metrics.LabelEventScheme:  "http",
+	}
+
+	resource := resource.Resource{
+		Type: metrics.ResourceTypeKnativeTrigger,
+		Labels: map[string]string{
+			metrics.LabelNamespaceName: "testns",
+			metrics.LabelTriggerName:   "testtrigger",
+			metrics.LabelBrokerName:    "testbroker",
+		},
+	}
+
+	expectSuccess(t, func() error {
+		return r.ReportEventHedged(args, HedgeWinnerHedge)
+	})
+	expectSuccess(t, func() error {
+		return r.ReportEventHedged(args, HedgeWinnerHedge)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("event_hedge_count", 2, wantTags).WithResource(&resource))
+}
+
+func TestReporterPolicyRejectedDropped(t *testing.T) {
+	setup()
+
+	args := &ReportArgs{
+		ns:          "testns",
+		trigger:     "testtrigger",
+		broker:      "testbroker",
+		requestType: "policy_rejected",
+	}
+
+	r := NewStatsReporter("testcontainer", "testpod")
+
+	wantTags := map[string]string{
+		broker.LabelContainerName: "testcontainer",
+		broker.LabelUniqueName:    "testpod",
+	}
+
+	resource := resource.Resource{
+		Type: metrics.ResourceTypeKnativeTrigger,
+		Labels: map[string]string{
+			metrics.LabelNamespaceName: "testns",
+			metrics.LabelTriggerName:   "testtrigger",
+			metrics.LabelBrokerName:    "testbroker",
+		},
+	}
+
+	expectSuccess(t, func() error {
+		return r.ReportPolicyRejectedDropped(args)
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("policy_rejected_dropped_count", 1, wantTags).WithResource(&resource))
+}
+
 func expectSuccess(t *testing.T, f func() error) {
 	t.Helper()
 	if err := f(); err != nil {
@@ -155,6 +273,10 @@ func resetMetrics() {
 	metricstest.Unregister(
 		"event_count",
 		"event_dispatch_latencies",
-		"event_processing_latencies")
+		"event_processing_latencies",
+		"subscriber_event_count",
+		"subscriber_event_dispatch_latencies",
+		"event_hedge_count",
+		"policy_rejected_dropped_count")
 	register()
 }
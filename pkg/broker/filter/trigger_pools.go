@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TriggerPools bounds the number of events dispatched concurrently for each
+// Trigger, so that a Trigger with a stuck subscriber can't exhaust the
+// goroutines and connections shared with the other Triggers. The events of a
+// Trigger whose pool is full are either rejected, to be retried by the
+// sender, or wait for a slot to be released.
+type TriggerPools struct {
+	size int
+
+	mu     sync.Mutex
+	active map[types.UID]int
+	// released is closed when a slot of the pool of a Trigger is released,
+	// waking up the dispatches waiting for it.
+	released map[types.UID]chan struct{}
+}
+
+// NewTriggerPools returns TriggerPools of the given size, or nil, which
+// doesn't bound the dispatches, when size isn't positive.
+func NewTriggerPools(size int) *TriggerPools {
+	if size <= 0 {
+		return nil
+	}
+	p := newTriggerPools()
+	p.size = size
+	return p
+}
+
+// newTriggerPools returns TriggerPools whose size is given by each call to
+// wait.
+func newTriggerPools() *TriggerPools {
+	return &TriggerPools{
+		active:   make(map[types.UID]int),
+		released: make(map[types.UID]chan struct{}),
+	}
+}
+
+// acquire takes a slot of the pool of the Trigger, ok is false when the pool
+// is full. release must be called once the event is dispatched.
+func (p *TriggerPools) acquire(uid types.UID) (release func(), ok bool) {
+	if p == nil {
+		return func() {}, true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active[uid] >= p.size {
+		return nil, false
+	}
+	p.active[uid]++
+	return p.releaser(uid), true
+}
+
+// wait takes a slot of the pool of the Trigger of the given size, waiting
+// for one to be released while the pool is full, until ctx is done. The pool
+// is unbounded when size isn't positive. release must be called once the
+// event is dispatched.
+func (p *TriggerPools) wait(ctx context.Context, uid types.UID, size int) (release func(), err error) {
+	if p == nil || size <= 0 {
+		return func() {}, nil
+	}
+
+	for {
+		p.mu.Lock()
+		if p.active[uid] < size {
+			p.active[uid]++
+			p.mu.Unlock()
+			return p.releaser(uid), nil
+		}
+		released, ok := p.released[uid]
+		if !ok {
+			released = make(chan struct{})
+			p.released[uid] = released
+		}
+		p.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// releaser returns the function releasing a slot of the pool of the Trigger,
+// which frees a single slot however many times it is called.
+func (p *TriggerPools) releaser(uid types.UID) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			// Forget the idle pools, so that the deleted Triggers don't leak.
+			if p.active[uid]--; p.active[uid] <= 0 {
+				delete(p.active, uid)
+			}
+			if released, ok := p.released[uid]; ok {
+				close(released)
+				delete(p.released, uid)
+			}
+		})
+	}
+}
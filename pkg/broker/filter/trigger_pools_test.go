@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTriggerPoolsUnbounded(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		p := NewTriggerPools(size)
+		if p != nil {
+			t.Fatalf("NewTriggerPools(%d) = %v, want nil", size, p)
+		}
+		for i := 0; i < 100; i++ {
+			if _, ok := p.acquire("uid"); !ok {
+				t.Fatalf("acquire() on unbounded pools failed")
+			}
+		}
+	}
+}
+
+func TestTriggerPoolsAcquireRelease(t *testing.T) {
+	const (
+		uid   types.UID = "uid"
+		other types.UID = "other"
+	)
+	p := NewTriggerPools(2)
+
+	release1, ok := p.acquire(uid)
+	if !ok {
+		t.Fatal("first acquire() failed")
+	}
+	release2, ok := p.acquire(uid)
+	if !ok {
+		t.Fatal("second acquire() failed")
+	}
+	if _, ok := p.acquire(uid); ok {
+		t.Fatal("acquire() on a full pool succeeded")
+	}
+
+	// The pools of the Triggers are independent.
+	releaseOther, ok := p.acquire(other)
+	if !ok {
+		t.Fatal("acquire() of another Trigger failed")
+	}
+	releaseOther()
+
+	// Releasing twice frees a single slot.
+	release1()
+	release1()
+	release3, ok := p.acquire(uid)
+	if !ok {
+		t.Fatal("acquire() after release failed")
+	}
+	if _, ok := p.acquire(uid); ok {
+		t.Fatal("double release freed two slots")
+	}
+
+	release2()
+	release3()
+	if n := len(p.active); n != 0 {
+		t.Errorf("got %d pools once idle, want 0", n)
+	}
+}
+
+func TestTriggerPoolsWait(t *testing.T) {
+	const uid types.UID = "uid"
+	p := newTriggerPools()
+
+	for i := 0; i < 100; i++ {
+		if _, err := p.wait(context.Background(), uid, 0); err != nil {
+			t.Fatal("wait() on an unbounded pool failed:", err)
+		}
+	}
+	if n := len(p.active); n != 0 {
+		t.Errorf("got %d pools for unbounded acquisitions, want 0", n)
+	}
+
+	release, err := p.wait(context.Background(), uid, 1)
+	if err != nil {
+		t.Fatal("first wait() failed:", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		release, err := p.wait(context.Background(), uid, 1)
+		if err != nil {
+			t.Error("wait() on a released pool failed:", err)
+		}
+		acquired <- release
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("wait() on a full pool didn't wait")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case release := <-acquired:
+		release()
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait() didn't take the released slot")
+	}
+	if n := len(p.active); n != 0 {
+		t.Errorf("got %d pools once idle, want 0", n)
+	}
+	if n := len(p.released); n != 0 {
+		t.Errorf("got %d released channels once idle, want 0", n)
+	}
+}
+
+func TestTriggerPoolsWaitCanceled(t *testing.T) {
+	const uid types.UID = "uid"
+	p := newTriggerPools()
+
+	release, err := p.wait(context.Background(), uid, 1)
+	if err != nil {
+		t.Fatal("first wait() failed:", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := p.wait(ctx, uid, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wait() on a full pool = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
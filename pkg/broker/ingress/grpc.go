@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	format "github.com/cloudevents/sdk-go/binding/format/protobuf/v2"
+	cepb "github.com/cloudevents/sdk-go/binding/format/protobuf/v2/pb"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/broker/ingress/pb"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	// GRPCServiceName is the name of the gRPC service of the ingress,
+	// described in pb/ingress.proto.
+	GRPCServiceName = "dev.knative.eventing.broker.v1.BrokerIngress"
+
+	// grpcScheme is the scheme of the events received with gRPC, as
+	// reported in the metrics.
+	grpcScheme = "grpc"
+)
+
+// grpcIngress is the interface of the handlers of the BrokerIngress service.
+type grpcIngress interface {
+	publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error)
+}
+
+var grpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: GRPCServiceName,
+	HandlerType: (*grpcIngress)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Publish",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			req := &pb.PublishRequest{}
+			if err := dec(req); err != nil {
+				return nil, status.Error(codes.InvalidArgument, status.Convert(err).Message())
+			}
+			if interceptor == nil {
+				return srv.(grpcIngress).publish(ctx, req)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + GRPCServiceName + "/Publish",
+			}
+			return interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(grpcIngress).publish(ctx, req.(*pb.PublishRequest))
+			})
+		},
+	}},
+	Metadata: "broker/ingress/pb/ingress.proto",
+}
+
+// NewGRPCServer returns the gRPC server receiving the events published with
+// the BrokerIngress service, encoded in the CloudEvents protobuf format.
+func NewGRPCServer(h *Handler) *grpc.Server {
+	server := grpc.NewServer()
+	server.RegisterService(&grpcServiceDesc, h)
+	return server
+}
+
+// StartGRPCServer serves the BrokerIngress gRPC service on the given port in
+// the background, until ctx is done. It does nothing when the port isn't
+// positive.
+func (h *Handler) StartGRPCServer(ctx context.Context, port int, opts ...kncloudevents.HTTPEventReceiverOption) {
+	if port <= 0 {
+		return
+	}
+
+	listener, err := kncloudevents.Listen(port, opts...)
+	if err != nil {
+		h.Logger.Error("Failed to listen on the gRPC port", zap.Int("port", port), zap.Error(err))
+		return
+	}
+	server := NewGRPCServer(h)
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+	go func() {
+		h.Logger.Info("Starting gRPC server", zap.Int("port", port))
+		if err := server.Serve(listener); err != nil {
+			h.Logger.Error("gRPC server returned an error", zap.Error(err))
+		}
+	}()
+}
+
+// publish forwards the events of the request to the channel of the broker,
+// like a batch of events received over HTTP. The request fails only when none
+// of its events can be received, the outcome of every event is reported in
+// the response otherwise.
+func (h *Handler) publish(ctx context.Context, req *pb.PublishRequest) (*pb.PublishResponse, error) {
+	ctx = h.withContext(ctx)
+	features := feature.FromContext(ctx)
+	if !features.IsEnabled(feature.BrokerIngressGRPC) {
+		return nil, status.Errorf(codes.Unimplemented, "the %s feature is disabled", feature.BrokerIngressGRPC)
+	}
+	if req.GetNamespace() == "" || req.GetBroker() == "" {
+		return nil, status.Error(codes.InvalidArgument, "the namespace and the name of the broker are required")
+	}
+	if len(req.GetEvents()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no events")
+	}
+
+	events := make([]*cloudevents.Event, 0, len(req.GetEvents()))
+	for _, e := range req.GetEvents() {
+		event, err := eventFromProto(e)
+		if err != nil {
+			h.Logger.Warn("failed to decode event from gRPC request", zap.Error(err))
+			return nil, status.Errorf(codes.InvalidArgument, "invalid event %d: %v", len(events), err)
+		}
+		events = append(events, event)
+	}
+
+	broker, err := h.getBroker(req.GetBroker(), req.GetNamespace())
+	if apierrors.IsNotFound(err) {
+		return nil, status.Errorf(codes.NotFound, "broker %s/%s not found", req.GetNamespace(), req.GetBroker())
+	} else if err != nil {
+		h.Logger.Warn("Failed to retrieve broker", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve broker %s/%s", req.GetNamespace(), req.GetBroker())
+	}
+
+	headers := grpcHeaders(ctx)
+	var subject string
+	if features.IsOIDCAuthentication() {
+		if subject, err = h.verifyGRPCToken(ctx, headers, broker); err != nil {
+			return nil, err
+		}
+		if features.IsEnabled(feature.ReplayProtection) {
+			// The signature of the replay protection headers covers the
+			// gRPC method, which is the path of the HTTP/2 request.
+			method, _ := grpc.Method(ctx)
+			replayReq := (&http.Request{Method: http.MethodPost, URL: &url.URL{Path: method}, Header: headers}).WithContext(ctx)
+			if err := h.replayProtector.Verify(replayReq); err != nil {
+				h.Logger.Warn("Request failed the replay protection", zap.Error(err))
+				if errors.Is(err, kncloudevents.ErrNonceStoreUnavailable) {
+					return nil, status.Error(codes.Unavailable, err.Error())
+				}
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+		}
+	}
+	headerExtensions := h.headerExtensions(broker)
+	for _, event := range events {
+		setHeaderExtensions(headers, event, headerExtensions)
+		if err := h.setAuthSubject(event, subject); err != nil {
+			h.Logger.Warn("Failed to sign the auth subject of the event", zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to sign the auth subject of the events")
+		}
+	}
+
+	statuses, statusCode, failure := h.receiveEvents(ctx, grpcScheme, headers, events, broker)
+	trailer := http.Header{}
+	if failure != nil {
+		setRateLimitRetryAfter(trailer, failure)
+	} else {
+		h.setRetryAfter(trailer, statusCode, broker)
+	}
+	if retryAfter := trailer.Get("Retry-After"); retryAfter != "" {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", retryAfter))
+	}
+	resp := &pb.PublishResponse{Statuses: make([]*pb.EventStatus, 0, len(statuses))}
+	for _, s := range statuses {
+		resp.Statuses = append(resp.Statuses, &pb.EventStatus{
+			Id:         s.ID,
+			Source:     s.Source,
+			StatusCode: int32(s.StatusCode),
+			Error:      s.Error,
+		})
+	}
+	return resp, nil
+}
+
+// eventFromProto converts an event in the CloudEvents protobuf format and
+// validates it.
+func eventFromProto(e *cepb.CloudEvent) (*cloudevents.Event, error) {
+	event, err := format.FromProto(e)
+	if err != nil {
+		return nil, err
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// verifyGRPCToken verifies the JWT of the authorization metadata of the
+// request, like the JWT of the HTTP requests, and returns its subject.
+func (h *Handler) verifyGRPCToken(ctx context.Context, headers http.Header, broker *eventingv1.Broker) (string, error) {
+	token := auth.GetJWTFromHeader(headers)
+	if token == "" {
+		h.Logger.Warn("No JWT token found in the gRPC request")
+		return "", status.Error(codes.Unauthenticated, "no JWT token found in request")
+	}
+	if broker.Status.Address == nil || broker.Status.Address.Audience == nil {
+		return "", status.Error(codes.Internal, "no audience is provided")
+	}
+	if _, err := h.tokenVerifier.VerifyJWT(ctx, token, *broker.Status.Address.Audience); err != nil {
+		h.Logger.Warn("Error when validating the JWT token in the request", zap.Error(err))
+		if errors.Is(err, auth.ErrAudienceMismatch) {
+			header := http.Header{}
+			auth.SetAudienceMismatch(header)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("www-authenticate", header.Get("WWW-Authenticate")))
+		}
+		return "", status.Errorf(codes.Unauthenticated, "failed to verify JWT: %v", err)
+	}
+
+	subject, err := auth.GetJWTSubject(token)
+	if err != nil {
+		h.Logger.Warn("Failed to get the subject of the JWT token in the request", zap.Error(err))
+	}
+	return subject, nil
+}
+
+// grpcHeaders returns the metadata of the gRPC request as HTTP headers, so
+// that they are handled like the headers of the HTTP requests.
+func grpcHeaders(ctx context.Context) http.Header {
+	headers := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+	return headers
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	format "github.com/cloudevents/sdk-go/binding/format/protobuf/v2"
+	cepb "github.com/cloudevents/sdk-go/binding/format/protobuf/v2/pb"
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
+	reconcilertesting "knative.dev/pkg/reconciler/testing"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/broker/ingress/pb"
+	brokerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
+)
+
+func TestHandler_GRPC(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	// The channel rejects the events with the id "fail".
+	var (
+		mu       sync.Mutex
+		received []string
+		tenants  []string
+	)
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		id := request.Header.Get("Ce-Id")
+		received = append(received, id)
+		tenants = append(tenants, request.Header.Get("Ce-Tenant"))
+		if id == "fail" {
+			writer.WriteHeader(nethttp.StatusInternalServerError)
+			return
+		}
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := withIngressHeaderExtensions(makeBroker("name", "ns"), "X-Tenant-Id=tenant")
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled := true
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			if !enabled {
+				return ctx
+			}
+			return feature.ToContext(ctx, feature.Flags{feature.BrokerIngressGRPC: feature.Enabled})
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewGRPCServer(h)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	publish := func(namespace, name string, events ...*cepb.CloudEvent) (*pb.PublishResponse, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "acme")
+		resp := &pb.PublishResponse{}
+		err := conn.Invoke(ctx, "/"+GRPCServiceName+"/Publish", &pb.PublishRequest{
+			Namespace: namespace,
+			Broker:    name,
+			Events:    events,
+		}, resp)
+		return resp, err
+	}
+
+	// The events following a rejected event are still forwarded, and the
+	// status of every event is reported.
+	resp, err := publish("ns", "name", protoEvent("1"), protoEvent("fail"), protoEvent("2"))
+	if err != nil {
+		t.Fatal("Publish() =", err)
+	}
+	wantStatuses := []*pb.EventStatus{
+		{Id: "1", Source: "/s", StatusCode: senderResponseStatusCode},
+		{Id: "fail", Source: "/s", StatusCode: nethttp.StatusInternalServerError},
+		{Id: "2", Source: "/s", StatusCode: senderResponseStatusCode},
+	}
+	if diff := cmp.Diff(wantStatuses, resp.GetStatuses(), protocmp.Transform()); diff != "" {
+		t.Error("unexpected statuses (-want +got)", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "fail", "2"}, received); diff != "" {
+		t.Error("unexpected forwarded events (-want +got)", diff)
+	}
+	// The metadata of the request is mapped like the headers of the HTTP
+	// requests.
+	if diff := cmp.Diff([]string{"acme", "acme", "acme"}, tenants); diff != "" {
+		t.Error("unexpected tenant extensions (-want +got)", diff)
+	}
+
+	tests := []struct {
+		name      string
+		disabled  bool
+		namespace string
+		broker    string
+		events    []*cepb.CloudEvent
+		want      codes.Code
+	}{{
+		name:      "disabled",
+		disabled:  true,
+		namespace: "ns",
+		broker:    "name",
+		events:    []*cepb.CloudEvent{protoEvent("3")},
+		want:      codes.Unimplemented,
+	}, {
+		name:      "unknown broker",
+		namespace: "ns",
+		broker:    "unknown",
+		events:    []*cepb.CloudEvent{protoEvent("3")},
+		want:      codes.NotFound,
+	}, {
+		name:   "missing broker",
+		events: []*cepb.CloudEvent{protoEvent("3")},
+		want:   codes.InvalidArgument,
+	}, {
+		name:      "no events",
+		namespace: "ns",
+		broker:    "name",
+		want:      codes.InvalidArgument,
+	}, {
+		// A request with an invalid event is rejected as a whole.
+		name:      "invalid event",
+		namespace: "ns",
+		broker:    "name",
+		events:    []*cepb.CloudEvent{protoEvent("3"), protoEvent("")},
+		want:      codes.InvalidArgument,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			received = nil
+			enabled = !tc.disabled
+			defer func() { enabled = true }()
+
+			_, err := publish(tc.namespace, tc.broker, tc.events...)
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("Publish() = %v, want code %v", err, tc.want)
+			}
+			if len(received) != 0 {
+				t.Errorf("expected no event forwarded, got %v", received)
+			}
+		})
+	}
+}
+
+func TestEventFromProto(t *testing.T) {
+	e := event.New()
+	e.SetID("1")
+	e.SetSource("/source")
+	e.SetType("type")
+	e.SetSubject("subject")
+	e.SetDataSchema("https://example.com/schema")
+	e.SetTime(time.Date(2024, 6, 1, 12, 0, 0, 500, time.UTC))
+	e.SetExtension("boolean", true)
+	e.SetExtension("integer", int32(-42))
+	e.SetExtension("string", "value")
+	e.SetExtension("binary", []byte{0, 1, 2})
+	e.SetExtension("uri", types.ParseURI("https://example.com"))
+	e.SetExtension("uriref", types.ParseURIRef("/ref"))
+	e.SetExtension("timestamp", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := e.SetData(event.ApplicationJSON, map[string]string{"hello": "world"}); err != nil {
+		t.Fatal(err)
+	}
+
+	pe, err := format.ToProto(&e)
+	if err != nil {
+		t.Fatal("ToProto() =", err)
+	}
+	got, err := eventFromProto(pe)
+	if err != nil {
+		t.Fatal("eventFromProto() =", err)
+	}
+	if diff := cmp.Diff(e.String(), got.String()); diff != "" {
+		t.Error("unexpected event (-want +got)", diff)
+	}
+
+	// The data set as text or as a google.protobuf.Any.
+	text := minimalProtoEvent()
+	text.Attributes = map[string]*cepb.CloudEventAttributeValue{
+		"datacontenttype": {Attr: &cepb.CloudEventAttributeValue_CeString{CeString: "text/plain"}},
+	}
+	text.Data = &cepb.CloudEvent_TextData{TextData: "hello"}
+	got, err = eventFromProto(text)
+	if err != nil {
+		t.Fatal("eventFromProto() =", err)
+	}
+	if string(got.Data()) != "hello" {
+		t.Errorf("expected the text data, got %q", got.Data())
+	}
+
+	message := minimalProtoEvent()
+	message.Data = &cepb.CloudEvent_ProtoData{ProtoData: &anypb.Any{
+		TypeUrl: "type.googleapis.com/example.Message",
+		Value:   []byte{8, 1},
+	}}
+	got, err = eventFromProto(message)
+	if err != nil {
+		t.Fatal("eventFromProto() =", err)
+	}
+	if got.DataContentType() != format.ContentTypeProtobuf || string(got.Data()) != string([]byte{8, 1}) {
+		t.Errorf("unexpected protobuf data %q, content type %q", got.Data(), got.DataContentType())
+	}
+
+	missingID := minimalProtoEvent()
+	missingID.Id = ""
+	unknownSpec := minimalProtoEvent()
+	unknownSpec.SpecVersion = "2.0"
+	invalidExtension := minimalProtoEvent()
+	invalidExtension.Attributes = map[string]*cepb.CloudEventAttributeValue{
+		"Invalid-Name": {Attr: &cepb.CloudEventAttributeValue_CeString{CeString: "v"}},
+	}
+	for name, e := range map[string]*cepb.CloudEvent{
+		"missing id":        missingID,
+		"unknown spec":      unknownSpec,
+		"invalid extension": invalidExtension,
+	} {
+		if _, err := eventFromProto(e); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func protoEvent(id string) *cepb.CloudEvent {
+	e := minimalProtoEvent()
+	e.Id = id
+	return e
+}
+
+func minimalProtoEvent() *cepb.CloudEvent {
+	return &cepb.CloudEvent{
+		Id:          "1",
+		Source:      "/s",
+		SpecVersion: "1.0",
+		Type:        "t",
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.uber.org/zap"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+// maxHeaderExtensionLength bounds the length, in bytes, of the extensions set
+// from the headers.
+const maxHeaderExtensionLength = 256
+
+// headerExtensions returns the extensions set from the headers of the
+// requests for the broker, keyed by canonical header name, if any.
+func (h *Handler) headerExtensions(broker *eventingv1.Broker) map[string]string {
+	mappings, ok, err := broker.IngressHeaderExtensions()
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		h.Logger.Warn("Ignoring invalid ingress header extensions", zap.String("broker", broker.Namespace+"/"+broker.Name), zap.Error(err))
+		return nil
+	}
+	return mappings
+}
+
+// setHeaderExtensions sets the extensions of the event from the headers of
+// the request, overriding the extensions the producer set. The headers that
+// are missing, or empty once sanitized, are skipped.
+func setHeaderExtensions(header http.Header, event *cloudevents.Event, mappings map[string]string) {
+	for name, extension := range mappings {
+		value := sanitizeHeaderValue(header.Get(name))
+		if value == "" {
+			continue
+		}
+		event.SetExtension(extension, value)
+	}
+}
+
+// sanitizeHeaderValue removes the control characters and the invalid UTF-8
+// of a header value, and truncates it to maxHeaderExtensionLength.
+func sanitizeHeaderValue(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(value, ""))
+	value = strings.TrimSpace(value)
+	if len(value) > maxHeaderExtensionLength {
+		// Drop the rune cut in the middle, if any.
+		value = strings.ToValidUTF8(value[:maxHeaderExtensionLength], "")
+	}
+	return value
+}
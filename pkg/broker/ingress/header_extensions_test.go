@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHeaderValue(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		want  string
+	}{
+		"empty": {},
+		"unchanged": {
+			value: "tenant-a",
+			want:  "tenant-a",
+		},
+		"spaces trimmed": {
+			value: "  tenant-a ",
+			want:  "tenant-a",
+		},
+		"control characters removed": {
+			value: "tenant\r\n-a\x00",
+			want:  "tenant-a",
+		},
+		"invalid UTF-8 removed": {
+			value: "tenant\xff-a",
+			want:  "tenant-a",
+		},
+		"truncated": {
+			value: strings.Repeat("a", maxHeaderExtensionLength+10),
+			want:  strings.Repeat("a", maxHeaderExtensionLength),
+		},
+		"truncated on a rune": {
+			value: strings.Repeat("a", maxHeaderExtensionLength-1) + "é",
+			want:  strings.Repeat("a", maxHeaderExtensionLength-1),
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := sanitizeHeaderValue(tc.value); got != tc.want {
+				t.Errorf("sanitizeHeaderValue() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
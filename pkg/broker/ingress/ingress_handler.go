@@ -18,19 +18,25 @@ package ingress
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	opencensusclient "github.com/cloudevents/sdk-go/observability/opencensus/v2/client"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
-	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/client"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"go.opencensus.io/trace"
 	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -45,9 +51,12 @@ import (
 	"knative.dev/eventing/pkg/broker"
 	v1 "knative.dev/eventing/pkg/client/informers/externalversions/eventing/v1"
 	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	eventingv1beta2listers "knative.dev/eventing/pkg/client/listers/eventing/v1beta2"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/eventtype"
 	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/signing"
 	"knative.dev/eventing/pkg/tracing"
 	"knative.dev/eventing/pkg/utils"
 )
@@ -55,6 +64,15 @@ import (
 const (
 	defaultMaxIdleConnections        = 1000
 	defaultMaxIdleConnectionsPerHost = 1000
+
+	// maintenanceFlushInterval is how often the buffered events the channels
+	// didn't accept are retried when the ingress shuts down.
+	maintenanceFlushInterval = time.Second
+
+	// Reasons for rejecting an event reported in the metrics.
+	rejectionReasonSpecViolation  = "spec_violation"
+	rejectionReasonUnregistered   = "unregistered_type"
+	rejectionReasonRegistryFailed = "registry_unavailable"
 )
 
 type Handler struct {
@@ -67,12 +85,47 @@ type Handler struct {
 
 	EvenTypeHandler *eventtype.EventTypeAutoHandler
 
+	// EventValidation is the event validation level applied to the Brokers
+	// without the eventing.EventValidationAnnotationKey annotation.
+	EventValidation string
+	// EventTypeLister gets the EventTypes the registry-enforced event
+	// validation level checks the events against.
+	EventTypeLister eventingv1beta2listers.EventTypeLister
+
+	// EventIndex, when set, records the outcome of the events received.
+	EventIndex *eventindex.Index
+
+	// MaintenanceBuffer, when set, holds the events received by the Brokers
+	// in their maintenance window until the window is over.
+	MaintenanceBuffer *MaintenanceBuffer
+
+	// RateLimiter, when set, limits the rate of the events accepted per
+	// event source for the Brokers with the eventing.IngressRateLimitAnnotationKey
+	// annotation, or for all the Brokers with the feature.BrokerIngressRateLimit
+	// feature.
+	RateLimiter *RateLimiter
+
+	// SyncDelivery, when set, lets the producers wait for the result of the
+	// delivery of their events to a Trigger when the
+	// feature.BrokerSynchronousDelivery feature is enabled.
+	SyncDelivery *SyncDelivery
+
+	// AuthSubjectKey, when set, signs the auth.AuthSubjectExtension extension
+	// of the events, so that the subscribers can verify it.
+	AuthSubjectKey *signing.Key
+
+	// MaxDecompressedSize is the maximum size of the decompressed body of
+	// the compressed requests, which isn't bounded when not positive.
+	MaxDecompressedSize int64
+
 	Logger *zap.Logger
 
 	eventDispatcher *kncloudevents.Dispatcher
 
 	tokenVerifier *auth.OIDCTokenVerifier
 
+	replayProtector *kncloudevents.ReplayProtector
+
 	withContext func(ctx context.Context) context.Context
 }
 
@@ -121,13 +174,15 @@ func NewHandler(logger *zap.Logger, reporter StatsReporter, defaulter client.Eve
 	})
 
 	return &Handler{
-		Defaulter:       defaulter,
-		Reporter:        reporter,
-		Logger:          logger,
-		BrokerLister:    brokerInformer.Lister(),
-		eventDispatcher: kncloudevents.NewDispatcher(clientConfig, oidcTokenProvider),
-		tokenVerifier:   tokenVerifier,
-		withContext:     withContext,
+		Defaulter:           defaulter,
+		Reporter:            reporter,
+		MaxDecompressedSize: kncloudevents.DefaultMaxDecompressedSize,
+		Logger:              logger,
+		BrokerLister:        brokerInformer.Lister(),
+		eventDispatcher:     kncloudevents.NewDispatcher(clientConfig, oidcTokenProvider),
+		tokenVerifier:       tokenVerifier,
+		replayProtector:     kncloudevents.NewReplayProtector(kncloudevents.DefaultReplayWindow, kncloudevents.DefaultReplayCacheSize),
+		withContext:         withContext,
 	}, nil
 }
 
@@ -198,30 +253,8 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 
 	ctx := h.withContext(request.Context())
 
-	message := cehttp.NewMessageFromHttpRequest(request)
-	defer message.Finish(nil)
-
-	event, err := binding.ToEvent(ctx, message)
-	if err != nil {
-		h.Logger.Warn("failed to extract event from request", zap.Error(err))
-		writer.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	// run validation for the extracted event
-	validationErr := event.Validate()
-	if validationErr != nil {
-		h.Logger.Warn("failed to validate extracted event", zap.Error(validationErr))
-		writer.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
 	brokerNamespace := nsBrokerName[1]
 	brokerName := nsBrokerName[2]
-	brokerNamespacedName := types.NamespacedName{
-		Name:      brokerName,
-		Namespace: brokerNamespace,
-	}
 
 	broker, err := h.getBroker(brokerName, brokerNamespace)
 	if err != nil {
@@ -230,6 +263,9 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 		return
 	}
 
+	// The events only carry the identity of their producer once verified, so
+	// that the EventPolicies targeting the Triggers can authorize it.
+	var subject string
 	features := feature.FromContext(ctx)
 	if features.IsOIDCAuthentication() {
 		h.Logger.Debug("OIDC authentication is enabled")
@@ -240,16 +276,185 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 			return
 		}
 
+		subject, err = auth.GetJWTSubject(auth.GetJWTFromHeader(request.Header))
+		if err != nil {
+			h.Logger.Warn("Failed to get the subject of the JWT token in the request", zap.Error(err))
+		}
+
+		if features.IsEnabled(feature.ReplayProtection) {
+			if err := h.replayProtector.Verify(request); err != nil {
+				h.Logger.Warn("Request failed the replay protection", zap.Error(err))
+				if errors.Is(err, kncloudevents.ErrNonceStoreUnavailable) {
+					writer.WriteHeader(http.StatusServiceUnavailable)
+				} else {
+					writer.WriteHeader(http.StatusUnauthorized)
+				}
+				return
+			}
+		}
+
 		h.Logger.Debug("Request contained a valid JWT. Continuing...")
 	}
 
+	// The body is only decompressed once the request is authenticated, and
+	// up to a maximum size, so that compression bombs can't exhaust the
+	// memory of the ingress.
+	if err := kncloudevents.DecompressRequest(request, h.MaxDecompressedSize); err != nil {
+		h.Logger.Warn("failed to decompress request", zap.Error(err))
+		switch {
+		case errors.Is(err, kncloudevents.ErrUnsupportedContentEncoding):
+			writer.WriteHeader(http.StatusUnsupportedMediaType)
+		case errors.Is(err, kncloudevents.ErrDecompressedBodyTooLarge):
+			writer.WriteHeader(http.StatusRequestEntityTooLarge)
+		default:
+			writer.WriteHeader(http.StatusBadRequest)
+		}
+		return
+	}
+
+	// A batch of events is received at once, and each event forwarded on its
+	// own.
+	batch := cehttp.IsHTTPBatch(request.Header)
+	var events []*cloudevents.Event
+	if batch {
+		events, err = kncloudevents.NewEventsFromHTTPRequest(request)
+	} else {
+		var event *cloudevents.Event
+		event, err = kncloudevents.NewEventFromHTTPRequest(request)
+		events = []*cloudevents.Event{event}
+	}
+	if err != nil {
+		h.Logger.Warn("failed to extract event from request", zap.Error(err))
+		kncloudevents.WriteEventDecodingError(writer, err)
+		return
+	}
+
+	headerExtensions := h.headerExtensions(broker)
+	for _, event := range events {
+		setHeaderExtensions(request.Header, event, headerExtensions)
+		if err := h.setAuthSubject(event, subject); err != nil {
+			h.Logger.Warn("Failed to sign the auth subject of the event", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if batch {
+		h.receiveBatch(ctx, writer, request, events, broker)
+		return
+	}
+	event := events[0]
+
+	syncResults, syncDone := h.prepareSyncDelivery(ctx, request, event, broker)
+	defer syncDone()
+
+	statusCode, err := h.receiveEvent(ctx, requestScheme(request), request.Header, event, broker)
+	if err != nil {
+		setRateLimitRetryAfter(writer.Header(), err)
+		http.Error(writer, err.Error(), statusCode)
+		return
+	}
+	h.setRetryAfter(writer.Header(), statusCode, broker)
+	if syncResults == nil || statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		writer.WriteHeader(statusCode)
+	} else {
+		h.writeSyncResult(ctx, writer, syncResults)
+	}
+
+	// EventType auto-create feature handling
+	if h.EvenTypeHandler != nil {
+		h.EvenTypeHandler.AutoCreateEventType(ctx, event, toKReference(broker), broker.GetUID())
+	}
+}
+
+// BatchEventStatus is the outcome of an event of a batch, as reported in the
+// body of the response to the batch.
+type BatchEventStatus struct {
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	// StatusCode is the status code the event would have been answered
+	// with on its own.
+	StatusCode int `json:"statusCode"`
+	// Error is the reason the event was rejected, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// receiveBatch forwards every event of a batch to the channel of the broker.
+// The response carries the status code of the first event that wasn't
+// accepted, so that the producer retries the batch, or 202 Accepted, and the
+// status of every event in its body, so that the producer can retry the
+// events that weren't accepted only. The events of a batch aren't delivered
+// synchronously.
+func (h *Handler) receiveBatch(ctx context.Context, writer http.ResponseWriter, request *http.Request, events []*cloudevents.Event, brokerObj *eventingv1.Broker) {
+	statuses, statusCode, failure := h.receiveEvents(ctx, requestScheme(request), request.Header, events, brokerObj)
+	if failure != nil {
+		setRateLimitRetryAfter(writer.Header(), failure)
+	} else {
+		h.setRetryAfter(writer.Header(), statusCode, brokerObj)
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	if err := json.NewEncoder(writer).Encode(statuses); err != nil {
+		h.Logger.Warn("Failed to write the status of the events of the batch", zap.Error(err))
+	}
+}
+
+// receiveEvents forwards every event of a batch to the channel of the broker.
+// It returns the status of every event, along with the status code of the
+// first event that wasn't accepted, or 202 Accepted, and the error it was
+// rejected with, if any. The events of a batch aren't delivered synchronously.
+func (h *Handler) receiveEvents(ctx context.Context, scheme string, headers http.Header, events []*cloudevents.Event, brokerObj *eventingv1.Broker) ([]BatchEventStatus, int, error) {
+	statusCode := http.StatusAccepted
+	var failure error
+	statuses := make([]BatchEventStatus, 0, len(events))
+	for _, event := range events {
+		_ = broker.DeleteSyncReplyTo(event.Context)
+
+		code, err := h.receiveEvent(ctx, scheme, headers, event, brokerObj)
+		status := BatchEventStatus{ID: event.ID(), Source: event.Source(), StatusCode: code}
+		if err != nil {
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+		if code < http.StatusOK || code >= http.StatusMultipleChoices {
+			if statusCode == http.StatusAccepted {
+				statusCode = code
+				if err != nil {
+					failure = fmt.Errorf("event %s: %w", event.ID(), err)
+				}
+			}
+			continue
+		}
+
+		if h.EvenTypeHandler != nil {
+			h.EvenTypeHandler.AutoCreateEventType(ctx, event, toKReference(brokerObj), brokerObj.GetUID())
+		}
+	}
+	return statuses, statusCode, failure
+}
+
+// receiveEvent validates an event received by the broker and forwards it to
+// the channel of the broker, reporting the outcome. It returns the status code
+// of the response to the event, along with the error the event was rejected
+// with when it failed the validation. The scheme the event was received with
+// is reported in the metrics.
+func (h *Handler) receiveEvent(ctx context.Context, scheme string, headers http.Header, event *cloudevents.Event, broker *eventingv1.Broker) (int, error) {
+	brokerNamespacedName := types.NamespacedName{
+		Name:      broker.Name,
+		Namespace: broker.Namespace,
+	}
+
 	ctx, span := trace.StartSpan(ctx, tracing.BrokerMessagingDestination(brokerNamespacedName))
 	defer span.End()
 
 	if span.IsRecordingEvents() {
+		protocol := tracing.MessagingProtocolHTTP
+		if scheme == grpcScheme {
+			protocol = tracing.MessagingProtocolGRPC
+		}
 		span.AddAttributes(
 			tracing.MessagingSystemAttribute,
-			tracing.MessagingProtocolHTTP,
+			protocol,
 			tracing.BrokerMessagingDestinationAttribute(brokerNamespacedName),
 			tracing.MessagingMessageIDAttribute(event.ID()),
 		)
@@ -257,29 +462,202 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	}
 
 	reporterArgs := &ReportArgs{
-		ns:        brokerNamespace,
-		broker:    brokerName,
-		eventType: event.Type(),
+		ns:          broker.Namespace,
+		broker:      broker.Name,
+		eventType:   event.Type(),
+		eventScheme: scheme,
 	}
 
-	if request.TLS != nil {
-		reporterArgs.eventScheme = "https"
-	} else {
-		reporterArgs.eventScheme = "http"
+	if err := h.rateLimit(ctx, event, broker); err != nil {
+		h.Logger.Info("Event rate limited",
+			zap.String("source", event.Source()),
+			zap.Error(err))
+		_ = h.Reporter.ReportEventCount(reporterArgs, http.StatusTooManyRequests)
+		h.EventIndex.Add(eventindex.NewRecord(event, brokerResource(broker), eventindex.OutcomeRejected, http.StatusTooManyRequests, err))
+		return http.StatusTooManyRequests, err
 	}
 
-	statusCode, dispatchTime := h.receive(ctx, utils.PassThroughHeaders(request.Header), event, broker)
+	if level := h.eventValidationLevel(broker); level != eventing.EventValidationMinimal {
+		if reason, err := h.validateEvent(level, event, broker); err != nil {
+			h.Logger.Info("Event failed validation",
+				zap.String("level", level),
+				zap.String("reason", reason),
+				zap.Error(err))
+			statusCode := http.StatusBadRequest
+			if reason == rejectionReasonRegistryFailed {
+				statusCode = http.StatusInternalServerError
+			} else {
+				_ = h.Reporter.ReportEventRejected(reporterArgs, level, reason)
+			}
+			_ = h.Reporter.ReportEventCount(reporterArgs, statusCode)
+			h.EventIndex.Add(eventindex.NewRecord(event, brokerResource(broker), eventindex.OutcomeRejected, statusCode, err))
+			return statusCode, err
+		}
+	}
+
+	statusCode, dispatchTime := h.receive(ctx, utils.PassThroughHeaders(headers), event, broker)
 	if dispatchTime > kncloudevents.NoDuration {
 		_ = h.Reporter.ReportEventDispatchTime(reporterArgs, statusCode, dispatchTime)
 	}
 	_ = h.Reporter.ReportEventCount(reporterArgs, statusCode)
+	h.EventIndex.Add(eventindex.NewRecord(event, brokerResource(broker), eventindex.OutcomeForStatus(statusCode), statusCode, nil))
+	return statusCode, nil
+}
 
-	writer.WriteHeader(statusCode)
+// setRetryAfter asks the producer to retry once the maintenance window of the
+// broker is over, when its maintenance buffer is full.
+func (h *Handler) setRetryAfter(header http.Header, statusCode int, broker *eventingv1.Broker) {
+	if statusCode != http.StatusServiceUnavailable || h.MaintenanceBuffer == nil || !broker.InMaintenance(time.Now()) {
+		return
+	}
+	if _, end, _, _ := broker.MaintenanceWindow(); time.Until(end) > 0 {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(end).Seconds()))))
+	}
+}
 
-	// EventType auto-create feature handling
-	if h.EvenTypeHandler != nil {
-		h.EvenTypeHandler.AutoCreateEventType(ctx, event, toKReference(broker), broker.GetUID())
+// setAuthSubject sets the verified identity of the producer of the event,
+// signed with the AuthSubjectKey if any.
+func (h *Handler) setAuthSubject(event *cloudevents.Event, subject string) error {
+	if h.AuthSubjectKey == nil {
+		auth.SetAuthSubject(event, subject)
+		return nil
 	}
+	return auth.SignAuthSubject(event, subject, *h.AuthSubjectKey)
+}
+
+// rateLimit takes a token from the rate limiter of the source of the event,
+// when the broker or the feature.BrokerIngressRateLimit feature set a rate
+// limit. It returns a *RateLimitedError if the source exceeded it.
+func (h *Handler) rateLimit(ctx context.Context, event *cloudevents.Event, broker *eventingv1.Broker) error {
+	if h.RateLimiter == nil {
+		return nil
+	}
+	limit, burst, ok, err := broker.IngressRateLimit()
+	if !ok {
+		value := feature.FromContext(ctx).BrokerIngressRateLimit()
+		if value == "" {
+			return nil
+		}
+		limit, burst, err = eventingv1.ParseIngressRateLimit(value)
+	}
+	if err != nil {
+		h.Logger.Warn("Ignoring an invalid ingress rate limit", zap.String("broker", broker.Namespace+"/"+broker.Name), zap.Error(err))
+		return nil
+	}
+	return h.RateLimiter.Allow(types.NamespacedName{Namespace: broker.Namespace, Name: broker.Name}, event.Source(), limit, burst)
+}
+
+// setRateLimitRetryAfter asks the producer to retry once its rate limit lets
+// it, when its event was rate limited.
+func setRateLimitRetryAfter(header http.Header, err error) {
+	var rateLimited *RateLimitedError
+	if errors.As(err, &rateLimited) {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+	}
+}
+
+// prepareSyncDelivery removes the broker.SyncReplyToAttribute extension the
+// producer may have set, and, when the producer asked for synchronous delivery
+// and it is enabled, sets it to where the result of the delivery is to be
+// posted. It returns the channel receiving the result, nil when the delivery
+// isn't synchronous, and a function to call once done waiting for it.
+func (h *Handler) prepareSyncDelivery(ctx context.Context, request *http.Request, event *cloudevents.Event, brokerObj *eventingv1.Broker) (<-chan SyncResult, func()) {
+	_ = broker.DeleteSyncReplyTo(event.Context)
+
+	if h.SyncDelivery == nil || !feature.FromContext(ctx).IsEnabled(feature.BrokerSynchronousDelivery) {
+		return nil, func() {}
+	}
+	if sync, _ := strconv.ParseBool(request.Header.Get(broker.SyncDeliveryHeader)); !sync {
+		return nil, func() {}
+	}
+	if h.MaintenanceBuffer != nil && brokerObj.InMaintenance(time.Now()) {
+		// The event is only delivered once the maintenance window is over.
+		return nil, func() {}
+	}
+
+	replyTo, results, done := h.SyncDelivery.Register()
+	if err := broker.SetSyncReplyTo(event.Context, replyTo); err != nil {
+		h.Logger.Warn("Failed to set the synchronous delivery reply URL", zap.Error(err))
+		done()
+		return nil, func() {}
+	}
+	return results, done
+}
+
+// writeSyncResult writes the response of the first subscriber receiving the
+// event to the producer, or 202 Accepted if none responded in time.
+func (h *Handler) writeSyncResult(ctx context.Context, writer http.ResponseWriter, results <-chan SyncResult) {
+	result, ok := h.SyncDelivery.Wait(ctx, results)
+	if !ok {
+		writer.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	for key, values := range result.Header {
+		writer.Header()[key] = values
+	}
+	writer.WriteHeader(result.StatusCode)
+	if _, err := writer.Write(result.Body); err != nil {
+		h.Logger.Warn("Failed to write the synchronous delivery result", zap.Error(err))
+	}
+}
+
+// eventValidationLevel returns the event validation level of the broker,
+// falling back to the default level of the handler.
+func (h *Handler) eventValidationLevel(broker *eventingv1.Broker) string {
+	if level, ok := broker.GetAnnotations()[eventing.EventValidationAnnotationKey]; ok {
+		return level
+	}
+	if h.EventValidation == "" {
+		return eventing.EventValidationMinimal
+	}
+	return h.EventValidation
+}
+
+// validateEvent validates the event according to the given validation level,
+// and returns the reason of the rejection along with the error.
+func (h *Handler) validateEvent(level string, event *cloudevents.Event, broker *eventingv1.Broker) (string, error) {
+	if err := kncloudevents.ValidateEventStrict(event); err != nil {
+		return rejectionReasonSpecViolation, err
+	}
+	if level != eventing.EventValidationRegistryEnforced {
+		return "", nil
+	}
+
+	if h.EventTypeLister == nil {
+		return rejectionReasonRegistryFailed, errors.New("event type registry is not available")
+	}
+	eventTypes, err := h.EventTypeLister.EventTypes(broker.Namespace).List(labels.Everything())
+	if err != nil {
+		return rejectionReasonRegistryFailed, fmt.Errorf("failed to list event types: %w", err)
+	}
+	for _, et := range eventTypes {
+		if et.Spec.Type != event.Type() {
+			continue
+		}
+		if et.Spec.Source != nil && et.Spec.Source.String() != event.Source() {
+			continue
+		}
+		if ref := et.Spec.Reference; ref != nil {
+			if ref.Kind != "Broker" || ref.Name != broker.Name || (ref.Namespace != "" && ref.Namespace != broker.Namespace) {
+				continue
+			}
+		}
+		return "", nil
+	}
+	return rejectionReasonUnregistered, fmt.Errorf("event type %q from source %q is not registered for broker %s/%s", event.Type(), event.Source(), broker.Namespace, broker.Name)
+}
+
+// requestScheme returns the scheme of the request, as reported in the metrics.
+func requestScheme(request *http.Request) string {
+	if request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func brokerResource(broker *eventingv1.Broker) string {
+	return fmt.Sprintf("broker %s/%s", broker.Namespace, broker.Name)
 }
 
 func toKReference(broker *eventingv1.Broker) *duckv1.KReference {
@@ -312,6 +690,19 @@ func (h *Handler) receive(ctx context.Context, headers http.Header, event *cloud
 		return http.StatusBadRequest, kncloudevents.NoDuration
 	}
 
+	if h.MaintenanceBuffer != nil && brokerObj.InMaintenance(time.Now()) {
+		if !h.MaintenanceBuffer.Add(types.NamespacedName{Namespace: brokerObj.Namespace, Name: brokerObj.Name}, *event, headers) {
+			h.Logger.Warn("maintenance buffer of the broker is full", zap.String("broker", brokerObj.Namespace+"/"+brokerObj.Name))
+			return http.StatusServiceUnavailable, kncloudevents.NoDuration
+		}
+		return http.StatusAccepted, kncloudevents.NoDuration
+	}
+
+	return h.send(ctx, headers, event, brokerObj)
+}
+
+// send forwards the event to the channel of the broker.
+func (h *Handler) send(ctx context.Context, headers http.Header, event *cloudevents.Event, brokerObj *eventingv1.Broker) (int, time.Duration) {
 	channelAddress, err := h.getChannelAddress(brokerObj)
 	if err != nil {
 		h.Logger.Warn("could not get channel address from broker", zap.Error(err))
@@ -334,3 +725,75 @@ func (h *Handler) receive(ctx context.Context, headers http.Header, event *cloud
 
 	return dispatchInfo.ResponseCode, dispatchInfo.Duration
 }
+
+// DrainMaintenanceBuffer forwards the events buffered for the Brokers whose
+// maintenance window is over to their channel, every interval until ctx is
+// done.
+func (h *Handler) DrainMaintenanceBuffer(ctx context.Context, interval time.Duration) {
+	if h.MaintenanceBuffer == nil {
+		return
+	}
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		h.drainMaintenanceBuffer(ctx, false)
+	}, interval)
+}
+
+// FlushMaintenanceBuffer forwards all the buffered events to the channel of
+// their Broker, even when its maintenance window isn't over, so that they
+// aren't lost when the ingress shuts down. It gives up when ctx is done, the
+// events which couldn't be forwarded by then are lost.
+func (h *Handler) FlushMaintenanceBuffer(ctx context.Context) {
+	if h.MaintenanceBuffer == nil {
+		return
+	}
+	for {
+		h.drainMaintenanceBuffer(ctx, true)
+		if h.MaintenanceBuffer.Total() == 0 {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			h.Logger.Error("Lost the events buffered in the maintenance windows of the Brokers",
+				zap.Int("events", h.MaintenanceBuffer.Total()))
+			return
+		case <-time.After(maintenanceFlushInterval):
+		}
+	}
+}
+
+func (h *Handler) drainMaintenanceBuffer(ctx context.Context, flush bool) {
+	ctx = h.withContext(ctx)
+	for _, key := range h.MaintenanceBuffer.Brokers() {
+		brokerObj, err := h.BrokerLister.Brokers(key.Namespace).Get(key.Name)
+		if apierrors.IsNotFound(err) {
+			h.Logger.Warn("Dropping the events buffered for a deleted broker",
+				zap.String("broker", key.String()),
+				zap.Int("events", h.MaintenanceBuffer.Len(key)))
+			h.MaintenanceBuffer.Drop(key)
+			continue
+		} else if err != nil {
+			h.Logger.Warn("Failed to retrieve broker", zap.String("broker", key.String()), zap.Error(err))
+			continue
+		}
+		if !flush && brokerObj.InMaintenance(time.Now()) {
+			continue
+		}
+
+		h.MaintenanceBuffer.Drain(key, func(event cloudevents.Event, headers http.Header) bool {
+			statusCode, _ := h.send(ctx, headers, &event, brokerObj)
+			switch {
+			case statusCode >= http.StatusInternalServerError, statusCode == http.StatusTooManyRequests:
+				// Keep the event until the channel recovers.
+				return false
+			case statusCode >= http.StatusMultipleChoices:
+				h.Logger.Warn("Dropping a buffered event rejected by the channel",
+					zap.String("broker", key.String()),
+					zap.String("event.id", event.ID()),
+					zap.Int("statusCode", statusCode))
+			}
+			h.EventIndex.Add(eventindex.NewRecord(&event, brokerResource(brokerObj), eventindex.OutcomeForStatus(statusCode), statusCode, nil))
+			return true
+		})
+	}
+}
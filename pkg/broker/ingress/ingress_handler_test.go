@@ -18,7 +18,9 @@ package ingress
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"io"
 	nethttp "net/http"
 	"net/http/httptest"
@@ -32,6 +34,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -40,10 +43,15 @@ import (
 
 	"knative.dev/eventing/pkg/apis/eventing"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingv1beta2 "knative.dev/eventing/pkg/apis/eventing/v1beta2"
+	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/eventindex"
+	"knative.dev/eventing/pkg/signing"
 
 	brokerinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
+	eventtypeinformerfake "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype/fake"
 
 	// Fake injection client
 	_ "knative.dev/pkg/client/injection/kube/client/fake"
@@ -66,10 +74,17 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		headers         nethttp.Header
 		expectedHeaders nethttp.Header
 		statusCode      int
+		expectedBody    string
 		handler         nethttp.Handler
 		reporter        StatsReporter
 		defaulter       client.EventDefaulter
 		brokers         []*eventingv1.Broker
+		eventTypes      []*eventingv1beta2.EventType
+		eventValidation string
+		// maxDecompressedSize overrides the default maximum decompressed size
+		// when set.
+		maxDecompressedSize int64
+		indexedOutcome      eventindex.Outcome
 	}{
 		{
 			name:       "invalid method PATCH",
@@ -142,6 +157,7 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			brokers: []*eventingv1.Broker{
 				makeBroker("name", "ns"),
 			},
+			indexedOutcome: eventindex.OutcomeDelivered,
 		},
 		{
 			name:   "valid - ignore trailing slash (happy path POST)",
@@ -160,13 +176,14 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			},
 		},
 		{
-			name:       "invalid event",
-			method:     nethttp.MethodPost,
-			uri:        "/ns/name",
-			body:       getInvalidEvent(),
-			statusCode: nethttp.StatusBadRequest,
-			handler:    handler(),
-			reporter:   &mockReporter{},
+			name:         "invalid event",
+			method:       nethttp.MethodPost,
+			uri:          "/ns/name",
+			body:         getInvalidEvent(),
+			statusCode:   nethttp.StatusBadRequest,
+			expectedBody: "invalid CloudEvent: source: must be a non-empty URI-reference\n",
+			handler:      handler(),
+			reporter:     &mockReporter{},
 			brokers: []*eventingv1.Broker{
 				makeBroker("name", "ns"),
 			},
@@ -196,13 +213,14 @@ func TestHandler_ServeHTTP(t *testing.T) {
 			},
 		},
 		{
-			name:       "malformed event",
-			method:     nethttp.MethodPost,
-			uri:        "/ns/name",
-			body:       strings.NewReader("not an event"),
-			statusCode: nethttp.StatusBadRequest,
-			handler:    handler(),
-			reporter:   &mockReporter{},
+			name:         "malformed event",
+			method:       nethttp.MethodPost,
+			uri:          "/ns/name",
+			body:         strings.NewReader("not an event"),
+			statusCode:   nethttp.StatusBadRequest,
+			expectedBody: "invalid CloudEvent: malformed structured CloudEvent: invalid character 'o' in literal null (expecting 'u')\n",
+			handler:      handler(),
+			reporter:     &mockReporter{},
 			brokers: []*eventingv1.Broker{
 				makeBroker("name", "ns"),
 			},
@@ -220,6 +238,56 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				withUninitializedAnnotations(makeBroker("name", "ns")),
 			},
 		},
+		{
+			name:   "gzip compressed event",
+			method: nethttp.MethodPost,
+			uri:    "/ns/name",
+			body:   gzipped(getValidEvent()),
+			headers: nethttp.Header{
+				cehttp.ContentType: []string{event.ApplicationCloudEventsJSON},
+				"Content-Encoding": []string{"gzip"},
+			},
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				makeBroker("name", "ns"),
+			},
+		},
+		{
+			name:   "gzip compressed event above the maximum decompressed size",
+			method: nethttp.MethodPost,
+			uri:    "/ns/name",
+			body:   gzipped(getValidEvent()),
+			headers: nethttp.Header{
+				cehttp.ContentType: []string{event.ApplicationCloudEventsJSON},
+				"Content-Encoding": []string{"gzip"},
+			},
+			maxDecompressedSize: 16,
+			statusCode:          nethttp.StatusRequestEntityTooLarge,
+			handler:             handler(),
+			reporter:            &mockReporter{},
+			brokers: []*eventingv1.Broker{
+				makeBroker("name", "ns"),
+			},
+		},
+		{
+			name:   "unsupported content encoding",
+			method: nethttp.MethodPost,
+			uri:    "/ns/name",
+			body:   getValidEvent(),
+			headers: nethttp.Header{
+				cehttp.ContentType: []string{event.ApplicationCloudEventsJSON},
+				"Content-Encoding": []string{"br"},
+			},
+			statusCode: nethttp.StatusUnsupportedMediaType,
+			handler:    handler(),
+			reporter:   &mockReporter{},
+			brokers: []*eventingv1.Broker{
+				makeBroker("name", "ns"),
+			},
+		},
 		{
 			name:       "root request URI",
 			method:     nethttp.MethodPost,
@@ -256,6 +324,83 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				makeBroker("name", "ns"),
 			},
 		},
+		{
+			name:         "spec-strict broker rejects non compliant event",
+			method:       nethttp.MethodPost,
+			uri:          "/ns/name",
+			body:         strings.NewReader(`{"specversion":"1.0","id":"1234","source":"source","type":"type","averyveryverylongextension":"x"}`),
+			statusCode:   nethttp.StatusBadRequest,
+			expectedBody: "invalid CloudEvent: averyveryverylongextension: attribute names must not exceed 20 characters\n",
+			handler:      handler(),
+			reporter:     &mockReporter{StatusCode: nethttp.StatusBadRequest, RejectionReason: "spec_violation"},
+			defaulter:    broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventValidation(makeBroker("name", "ns"), eventing.EventValidationSpecStrict),
+			},
+		},
+		{
+			name:            "spec-strict default accepts compliant event",
+			method:          nethttp.MethodPost,
+			uri:             "/ns/name",
+			body:            getValidEvent(),
+			statusCode:      senderResponseStatusCode,
+			handler:         handler(),
+			reporter:        &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:       broker.TTLDefaulter(logger, 100),
+			eventValidation: eventing.EventValidationSpecStrict,
+			brokers: []*eventingv1.Broker{
+				makeBroker("name", "ns"),
+			},
+		},
+		{
+			name:            "minimal broker overrides default",
+			method:          nethttp.MethodPost,
+			uri:             "/ns/name",
+			body:            getValidEvent(),
+			statusCode:      senderResponseStatusCode,
+			handler:         handler(),
+			reporter:        &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:       broker.TTLDefaulter(logger, 100),
+			eventValidation: eventing.EventValidationRegistryEnforced,
+			brokers: []*eventingv1.Broker{
+				withEventValidation(makeBroker("name", "ns"), eventing.EventValidationMinimal),
+			},
+		},
+		{
+			name:         "registry-enforced rejects unregistered event type",
+			method:       nethttp.MethodPost,
+			uri:          "/ns/name",
+			body:         getValidEvent(),
+			statusCode:   nethttp.StatusBadRequest,
+			expectedBody: "event type \"type\" from source \"source\" is not registered for broker ns/name\n",
+			handler:      handler(),
+			reporter:     &mockReporter{StatusCode: nethttp.StatusBadRequest, RejectionReason: "unregistered_type"},
+			defaulter:    broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventValidation(makeBroker("name", "ns"), eventing.EventValidationRegistryEnforced),
+			},
+			eventTypes: []*eventingv1beta2.EventType{
+				makeEventType("other-type", "ns", "other.type", "name"),
+				makeEventType("other-broker", "ns", "type", "other"),
+			},
+			indexedOutcome: eventindex.OutcomeRejected,
+		},
+		{
+			name:       "registry-enforced accepts registered event type",
+			method:     nethttp.MethodPost,
+			uri:        "/ns/name",
+			body:       getValidEvent(),
+			statusCode: senderResponseStatusCode,
+			handler:    handler(),
+			reporter:   &mockReporter{StatusCode: senderResponseStatusCode, EventDispatchTimeReported: true},
+			defaulter:  broker.TTLDefaulter(logger, 100),
+			brokers: []*eventingv1.Broker{
+				withEventValidation(makeBroker("name", "ns"), eventing.EventValidationRegistryEnforced),
+			},
+			eventTypes: []*eventingv1beta2.EventType{
+				makeEventType("et", "ns", "type", "name"),
+			},
+		},
 	}
 
 	for _, tc := range tt {
@@ -287,6 +432,9 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				}
 				brokerinformerfake.Get(ctx).Informer().GetStore().Add(b)
 			}
+			for _, et := range tc.eventTypes {
+				eventtypeinformerfake.Get(ctx).Informer().GetStore().Add(et)
+			}
 
 			tokenProvider := auth.NewOIDCTokenProvider(ctx)
 			tokenVerifier := auth.NewOIDCTokenVerifier(ctx)
@@ -305,12 +453,24 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				t.Fatal("Unable to create receiver:", err)
 			}
 
+			h.EventValidation = tc.eventValidation
+			if tc.maxDecompressedSize != 0 {
+				h.MaxDecompressedSize = tc.maxDecompressedSize
+			}
+			h.EventTypeLister = eventtypeinformerfake.Get(ctx).Lister()
+			h.EventIndex = eventindex.New("ingress", 10)
+
 			h.ServeHTTP(recorder, request)
 
 			result := recorder.Result()
 			if result.StatusCode != tc.statusCode {
 				t.Errorf("expected status code %d got %d", tc.statusCode, result.StatusCode)
 			}
+			if tc.expectedBody != "" {
+				if diff := cmp.Diff(tc.expectedBody, recorder.Body.String()); diff != "" {
+					t.Error("unexpected body (-want +got)", diff)
+				}
+			}
 
 			if svc, ok := tc.handler.(*svc); ok {
 				for k, expValue := range tc.expectedHeaders {
@@ -322,6 +482,13 @@ func TestHandler_ServeHTTP(t *testing.T) {
 				}
 			}
 
+			if tc.indexedOutcome != "" {
+				records := h.EventIndex.Lookup("1234")
+				if len(records) != 1 || records[0].Outcome != tc.indexedOutcome || records[0].Resource != "broker ns/name" {
+					t.Errorf("expected a %s record of broker ns/name in the event index, got %v", tc.indexedOutcome, records)
+				}
+			}
+
 			if diff := cmp.Diff(tc.reporter, h.Reporter); diff != "" {
 				t.Errorf("expected reporter state %+v got %+v - diff %s", tc.reporter, h.Reporter, diff)
 			}
@@ -329,6 +496,450 @@ func TestHandler_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestHandler_MaintenanceWindow(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	var received []string
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		received = append(received, request.Header.Get("Ce-Id"))
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	b := withMaintenanceWindow(makeBroker("name", "ns"), now.Add(-time.Minute), now.Add(time.Hour))
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	brokerStore := brokerinformerfake.Get(ctx).Informer().GetStore()
+	if err := brokerStore.Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return ctx
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+	h.MaintenanceBuffer = NewMaintenanceBuffer(1, 0)
+
+	send := func() *nethttp.Response {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	if got := send().StatusCode; got != nethttp.StatusAccepted {
+		t.Errorf("expected status code %d got %d", nethttp.StatusAccepted, got)
+	}
+	result := send()
+	if result.StatusCode != nethttp.StatusServiceUnavailable {
+		t.Errorf("expected status code %d got %d", nethttp.StatusServiceUnavailable, result.StatusCode)
+	}
+	if result.Header.Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header when the maintenance buffer is full")
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no event forwarded in the maintenance window, got %v", received)
+	}
+
+	// The maintenance buffer isn't drained in the maintenance window.
+	key := types.NamespacedName{Namespace: "ns", Name: "name"}
+	h.drainMaintenanceBuffer(ctx, false)
+	if got := h.MaintenanceBuffer.Len(key); got != 1 {
+		t.Errorf("expected 1 buffered event got %d", got)
+	}
+
+	// The buffered events are forwarded when the ingress shuts down, even in
+	// the maintenance window.
+	h.FlushMaintenanceBuffer(ctx)
+	if diff := cmp.Diff([]string{"1234"}, received); diff != "" {
+		t.Error("unexpected flushed events (-want +got)", diff)
+	}
+	if got := h.MaintenanceBuffer.Total(); got != 0 {
+		t.Errorf("expected an empty maintenance buffer after a flush got %d buffered events", got)
+	}
+	if got := send().StatusCode; got != nethttp.StatusAccepted {
+		t.Errorf("expected status code %d got %d", nethttp.StatusAccepted, got)
+	}
+
+	b = withMaintenanceWindow(b.DeepCopy(), now.Add(-2*time.Hour), now.Add(-time.Hour))
+	if err := brokerStore.Update(b); err != nil {
+		t.Fatal(err)
+	}
+	h.drainMaintenanceBuffer(ctx, false)
+	if diff := cmp.Diff([]string{"1234", "1234"}, received); diff != "" {
+		t.Error("unexpected forwarded events (-want +got)", diff)
+	}
+	if got := h.MaintenanceBuffer.Len(key); got != 0 {
+		t.Errorf("expected an empty maintenance buffer got %d buffered events", got)
+	}
+
+	if got := send().StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	annotated := withIngressRateLimit(makeBroker("annotated", "ns"), "0.1,1")
+	annotated.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	other := makeBroker("other", "ns")
+	other.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	for _, b := range []*eventingv1.Broker{annotated, other} {
+		if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	flags := feature.Flags{feature.BrokerIngressRateLimit: "0.1,2"}
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return feature.ToContext(ctx, flags)
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+	h.RateLimiter = NewRateLimiter(0)
+
+	send := func(brokerName string) *nethttp.Response {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/"+brokerName, getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	// The annotation of the Broker overrides the feature.
+	if got := send("annotated").StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+	result := send("annotated")
+	if result.StatusCode != nethttp.StatusTooManyRequests {
+		t.Errorf("expected status code %d got %d", nethttp.StatusTooManyRequests, result.StatusCode)
+	}
+	if got := result.Header.Get("Retry-After"); got != "10" {
+		t.Errorf("expected a Retry-After header of 10 seconds got %q", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if got := send("other").StatusCode; got != senderResponseStatusCode {
+			t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+		}
+	}
+	if got := send("other").StatusCode; got != nethttp.StatusTooManyRequests {
+		t.Errorf("expected status code %d got %d", nethttp.StatusTooManyRequests, got)
+	}
+
+	flags = feature.Flags{feature.BrokerIngressRateLimit: feature.Disabled}
+	if got := send("other").StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d without rate limit got %d", senderResponseStatusCode, got)
+	}
+}
+
+func TestHandler_HeaderExtensions(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	var requestID, tenant string
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		requestID = request.Header.Get("Ce-Requestid")
+		tenant = request.Header.Get("Ce-Tenant")
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := withIngressHeaderExtensions(makeBroker("name", "ns"), "X-Request-Id=requestid,X-Tenant-Id=tenant")
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return ctx
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+
+	e := event.New()
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetID("1234")
+	e.SetExtension("requestid", "set-by-producer")
+	body, _ := e.MarshalJSON()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", bytes.NewBuffer(body))
+	request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+	request.Header.Add("X-Request-Id", " abc\t-123 ")
+	h.ServeHTTP(recorder, request)
+
+	if got := recorder.Result().StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+	if requestID != "abc-123" {
+		t.Errorf("expected the requestid extension to be set from the header got %q", requestID)
+	}
+	if tenant != "" {
+		t.Errorf("expected the tenant extension to be unset without header got %q", tenant)
+	}
+}
+
+func TestHandler_AuthSubjectNotVerified(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	var subject, signature string
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		subject = request.Header.Get("Ce-" + auth.AuthSubjectExtension)
+		signature = request.Header.Get("Ce-" + auth.AuthSubjectSignatureExtension)
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := makeBroker("name", "ns")
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return ctx
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+	h.AuthSubjectKey = &signing.Key{ID: "key", Secret: []byte("secret")}
+
+	e := event.New()
+	e.SetType("type")
+	e.SetSource("source")
+	e.SetID("1234")
+	e.SetExtension(auth.AuthSubjectExtension, "system:serviceaccounts:ns:forged")
+	e.SetExtension(auth.AuthSubjectSignatureExtension, "Zm9yZ2Vk")
+	body, _ := e.MarshalJSON()
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", bytes.NewBuffer(body))
+	request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+	h.ServeHTTP(recorder, request)
+
+	if got := recorder.Result().StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+	if subject != "" {
+		t.Errorf("expected the unverified %s extension to be removed got %q", auth.AuthSubjectExtension, subject)
+	}
+	if signature != "" {
+		t.Errorf("expected the unverified %s extension to be removed got %q", auth.AuthSubjectSignatureExtension, signature)
+	}
+}
+
+func TestHandler_Batch(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	// The channel rejects the events with the id "fail".
+	var received []string
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		id := request.Header.Get("Ce-Id")
+		received = append(received, id)
+		if id == "fail" {
+			writer.WriteHeader(nethttp.StatusInternalServerError)
+			return
+		}
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := makeBroker("name", "ns")
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return ctx
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+
+	send := func(body string) *nethttp.Response {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", strings.NewReader(body))
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsBatchJSON)
+		h.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	if got := send(`[{"specversion":"1.0","id":"1","source":"/s","type":"t"},{"specversion":"1.0","id":"2","source":"/s","type":"t"}]`).StatusCode; got != nethttp.StatusAccepted {
+		t.Errorf("expected status code %d got %d", nethttp.StatusAccepted, got)
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, received); diff != "" {
+		t.Error("unexpected forwarded events (-want +got)", diff)
+	}
+
+	// The events following a rejected event are still forwarded, and the
+	// batch is answered with the status code of the rejected event, and the
+	// status of every event.
+	received = nil
+	result := send(`[{"specversion":"1.0","id":"fail","source":"/s","type":"t"},{"specversion":"1.0","id":"3","source":"/s","type":"t"}]`)
+	if got := result.StatusCode; got != nethttp.StatusInternalServerError {
+		t.Errorf("expected status code %d got %d", nethttp.StatusInternalServerError, got)
+	}
+	if diff := cmp.Diff([]string{"fail", "3"}, received); diff != "" {
+		t.Error("unexpected forwarded events (-want +got)", diff)
+	}
+	var statuses []BatchEventStatus
+	if err := json.NewDecoder(result.Body).Decode(&statuses); err != nil {
+		t.Fatal("Unable to read the statuses of the events:", err)
+	}
+	wantStatuses := []BatchEventStatus{
+		{ID: "fail", Source: "/s", StatusCode: nethttp.StatusInternalServerError},
+		{ID: "3", Source: "/s", StatusCode: senderResponseStatusCode},
+	}
+	if diff := cmp.Diff(wantStatuses, statuses); diff != "" {
+		t.Error("unexpected statuses (-want +got)", diff)
+	}
+
+	// A batch with an invalid event is rejected as a whole.
+	received = nil
+	if got := send(`[{"specversion":"1.0","id":"4","source":"/s","type":"t"},{"specversion":"1.0","id":"","source":"/s","type":"t"}]`).StatusCode; got != nethttp.StatusBadRequest {
+		t.Errorf("expected status code %d got %d", nethttp.StatusBadRequest, got)
+	}
+	if len(received) != 0 {
+		t.Errorf("expected no event forwarded, got %v", received)
+	}
+}
+
+func TestHandler_SyncDelivery(t *testing.T) {
+	ctx, _ := reconcilertesting.SetupFakeContext(t)
+
+	// The channel server posts the response of a subscriber replying with an
+	// event, as the Broker filter would.
+	var replyTo string
+	s := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		replyTo = request.Header.Get("Ce-" + broker.SyncReplyToAttribute)
+		if replyTo != "" {
+			header := nethttp.Header{}
+			header.Set("Ce-Id", "reply")
+			header.Set("Ce-Source", "/subscriber")
+			header.Set("Ce-Type", "dev.knative.reply")
+			header.Set("Ce-Specversion", "1.0")
+			header.Set("Content-Type", "application/json")
+			if err := broker.PostSyncResult(request.Context(), replyTo, nethttp.StatusOK, header, []byte(`{"answer":42}`)); err != nil {
+				t.Error("PostSyncResult() =", err)
+			}
+		}
+		writer.WriteHeader(senderResponseStatusCode)
+	}))
+	defer s.Close()
+
+	b := makeBroker("name", "ns")
+	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = s.URL
+	if err := brokerinformerfake.Get(ctx).Informer().GetStore().Add(b); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := feature.Flags{feature.BrokerSynchronousDelivery: feature.Enabled}
+	h, err := NewHandler(zap.NewNop(),
+		&mockReporter{},
+		broker.TTLDefaulter(zap.NewNop(), 100),
+		brokerinformerfake.Get(ctx),
+		auth.NewOIDCTokenVerifier(ctx),
+		auth.NewOIDCTokenProvider(ctx),
+		configmapinformer.Get(ctx).Lister().ConfigMaps("ns"),
+		func(ctx context.Context) context.Context {
+			return feature.ToContext(ctx, flags)
+		})
+	if err != nil {
+		t.Fatal("Unable to create receiver:", err)
+	}
+
+	results := httptest.NewServer(nethttp.HandlerFunc(func(writer nethttp.ResponseWriter, request *nethttp.Request) {
+		h.SyncDelivery.ServeHTTP(writer, request)
+	}))
+	defer results.Close()
+	h.SyncDelivery = NewSyncDelivery(results.URL, 5*time.Second)
+
+	send := func(sync bool) *nethttp.Response {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(nethttp.MethodPost, "/ns/name", getValidEvent())
+		request.Header.Add(cehttp.ContentType, event.ApplicationCloudEventsJSON)
+		if sync {
+			request.Header.Set(broker.SyncDeliveryHeader, "true")
+		}
+		h.ServeHTTP(recorder, request)
+		return recorder.Result()
+	}
+
+	result := send(true)
+	if result.StatusCode != nethttp.StatusOK {
+		t.Errorf("expected status code %d got %d", nethttp.StatusOK, result.StatusCode)
+	}
+	if got := result.Header.Get("Ce-Id"); got != "reply" {
+		t.Errorf("expected the reply event got Ce-Id %q", got)
+	}
+	if body, _ := io.ReadAll(result.Body); string(body) != `{"answer":42}` {
+		t.Errorf("expected the reply event data got %q", body)
+	}
+
+	if got := send(false).StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+	if replyTo != "" {
+		t.Errorf("expected no reply URL when the producer doesn't ask for synchronous delivery got %q", replyTo)
+	}
+
+	flags = feature.Flags{feature.BrokerSynchronousDelivery: feature.Disabled}
+	if got := send(true).StatusCode; got != senderResponseStatusCode {
+		t.Errorf("expected status code %d got %d", senderResponseStatusCode, got)
+	}
+	if replyTo != "" {
+		t.Errorf("expected no reply URL when the feature is disabled got %q", replyTo)
+	}
+}
+
 type svc struct {
 	receivedHeaders nethttp.Header
 }
@@ -347,6 +958,7 @@ func handler() nethttp.Handler {
 type mockReporter struct {
 	StatusCode                int
 	EventDispatchTimeReported bool
+	RejectionReason           string
 }
 
 func (r *mockReporter) ReportEventCount(_ *ReportArgs, responseCode int) error {
@@ -359,6 +971,11 @@ func (r *mockReporter) ReportEventDispatchTime(_ *ReportArgs, _ int, _ time.Dura
 	return nil
 }
 
+func (r *mockReporter) ReportEventRejected(_ *ReportArgs, _, reason string) error {
+	r.RejectionReason = reason
+	return nil
+}
+
 func getValidEvent() io.Reader {
 	e := event.New()
 	e.SetType("type")
@@ -395,7 +1012,60 @@ func makeBroker(name, namespace string) *eventingv1.Broker {
 	}
 }
 
+func withEventValidation(b *eventingv1.Broker, level string) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.EventValidationAnnotationKey: level,
+	}
+	return b
+}
+
+func withMaintenanceWindow(b *eventingv1.Broker, start, end time.Time) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.MaintenanceWindowAnnotationKey: start.Format(time.RFC3339) + "/" + end.Format(time.RFC3339),
+	}
+	return b
+}
+
+func withIngressRateLimit(b *eventingv1.Broker, limit string) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.IngressRateLimitAnnotationKey: limit,
+	}
+	return b
+}
+
+func withIngressHeaderExtensions(b *eventingv1.Broker, mappings string) *eventingv1.Broker {
+	b.Annotations = map[string]string{
+		eventing.IngressHeaderExtensionsAnnotationKey: mappings,
+	}
+	return b
+}
+
+func makeEventType(name, namespace, eventType, brokerName string) *eventingv1beta2.EventType {
+	return &eventingv1beta2.EventType{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1beta2.EventTypeSpec{
+			Type: eventType,
+			Reference: &duckv1.KReference{
+				APIVersion: "eventing.knative.dev/v1",
+				Kind:       "Broker",
+				Name:       brokerName,
+			},
+		},
+	}
+}
+
 func withUninitializedAnnotations(b *eventingv1.Broker) *eventingv1.Broker {
 	b.Status.Annotations = nil
 	return b
 }
+
+func gzipped(r io.Reader) io.Reader {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	_, _ = io.Copy(w, r)
+	_ = w.Close()
+	return &b
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// MaintenanceBuffer holds the events received by the Brokers in maintenance
+// until they can be forwarded to their channel. The events are only held in
+// memory, they are lost when the process is killed before they are
+// forwarded.
+type MaintenanceBuffer struct {
+	size     int
+	maxTotal int
+
+	mu     sync.Mutex
+	total  int
+	events map[types.NamespacedName][]bufferedEvent
+}
+
+type bufferedEvent struct {
+	event   cloudevents.Event
+	headers http.Header
+}
+
+// NewMaintenanceBuffer returns a MaintenanceBuffer holding up to size events
+// per Broker and up to maxTotal events across all the Brokers, or nil if size
+// isn't positive, which disables buffering. maxTotal defaults to size when it
+// isn't positive.
+func NewMaintenanceBuffer(size, maxTotal int) *MaintenanceBuffer {
+	if size <= 0 {
+		return nil
+	}
+	if maxTotal <= 0 {
+		maxTotal = size
+	}
+	return &MaintenanceBuffer{
+		size:     size,
+		maxTotal: maxTotal,
+		events:   make(map[types.NamespacedName][]bufferedEvent),
+	}
+}
+
+// Add buffers the event for the broker, and returns false if the buffer of
+// the broker or the buffer of all the brokers is full.
+func (b *MaintenanceBuffer) Add(broker types.NamespacedName, event cloudevents.Event, headers http.Header) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.events[broker]) >= b.size || b.total >= b.maxTotal {
+		return false
+	}
+	b.events[broker] = append(b.events[broker], bufferedEvent{
+		event:   event,
+		headers: headers.Clone(),
+	})
+	b.total++
+	return true
+}
+
+// Len returns the number of events buffered for the broker.
+func (b *MaintenanceBuffer) Len(broker types.NamespacedName) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.events[broker])
+}
+
+// Total returns the number of events buffered for all the brokers.
+func (b *MaintenanceBuffer) Total() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.total
+}
+
+// Brokers returns the Brokers with buffered events.
+func (b *MaintenanceBuffer) Brokers() []types.NamespacedName {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	brokers := make([]types.NamespacedName, 0, len(b.events))
+	for broker := range b.events {
+		brokers = append(brokers, broker)
+	}
+	return brokers
+}
+
+// Drain passes the events buffered for the broker to send in the order they
+// were received, and removes them from the buffer. It stops at the first
+// event send returns false for, which is put back at the front of the buffer
+// for the next drain. Every event is removed from the buffer before it is
+// passed to send, so that concurrent drains never send the same event.
+func (b *MaintenanceBuffer) Drain(broker types.NamespacedName, send func(event cloudevents.Event, headers http.Header) bool) {
+	for {
+		next, ok := b.pop(broker)
+		if !ok {
+			return
+		}
+		if !send(next.event, next.headers) {
+			b.pushFront(broker, next)
+			return
+		}
+	}
+}
+
+// pop removes the oldest event buffered for the broker.
+func (b *MaintenanceBuffer) pop(broker types.NamespacedName) (bufferedEvent, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	events := b.events[broker]
+	if len(events) == 0 {
+		delete(b.events, broker)
+		return bufferedEvent{}, false
+	}
+	b.events[broker] = events[1:]
+	b.total--
+	return events[0], true
+}
+
+// pushFront puts an event which couldn't be sent back at the front of the
+// buffer of the broker. It isn't bounded by the size of the buffers, the
+// event was already counted when it was added.
+func (b *MaintenanceBuffer) pushFront(broker types.NamespacedName, event bufferedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.events[broker] = append([]bufferedEvent{event}, b.events[broker]...)
+	b.total++
+}
+
+// Drop removes the events buffered for the broker.
+func (b *MaintenanceBuffer) Drop(broker types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.total -= len(b.events[broker])
+	delete(b.events, broker)
+}
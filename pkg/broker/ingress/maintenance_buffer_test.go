@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestMaintenanceBuffer(t *testing.T) {
+	if b := NewMaintenanceBuffer(0, 10); b != nil {
+		t.Errorf("expected no buffer when the size is 0, got %v", b)
+	}
+
+	b := NewMaintenanceBuffer(2, 3)
+	broker := types.NamespacedName{Namespace: "ns", Name: "broker"}
+	other := types.NamespacedName{Namespace: "ns", Name: "other"}
+
+	for _, id := range []string{"1", "2", "3"} {
+		e := cloudevents.NewEvent()
+		e.SetID(id)
+		if added, want := b.Add(broker, e, http.Header{"Knative-Foo": []string{id}}), id != "3"; added != want {
+			t.Errorf("Add(%s) = %v, want %v", id, added, want)
+		}
+	}
+	if !b.Add(other, cloudevents.NewEvent(), nil) {
+		t.Error("expected the buffers of the brokers to be independent")
+	}
+	if b.Add(types.NamespacedName{Namespace: "ns", Name: "third"}, cloudevents.NewEvent(), nil) {
+		t.Error("expected the events beyond the total size to be refused")
+	}
+	if got := b.Total(); got != 3 {
+		t.Errorf("Total() = %d, want 3", got)
+	}
+	if got := b.Len(broker); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	var sent []string
+	b.Drain(broker, func(event cloudevents.Event, headers http.Header) bool {
+		if headers.Get("Knative-Foo") != event.ID() {
+			t.Errorf("unexpected headers %v for event %s", headers, event.ID())
+		}
+		if event.ID() == "2" && len(sent) == 1 {
+			// Fail the first attempt to send the second event.
+			sent = append(sent, "failed")
+			return false
+		}
+		sent = append(sent, event.ID())
+		return true
+	})
+	if got := b.Len(broker); got != 1 {
+		t.Errorf("Len() = %d after a failed send, want 1", got)
+	}
+
+	b.Drain(broker, func(event cloudevents.Event, _ http.Header) bool {
+		sent = append(sent, event.ID())
+		return true
+	})
+	if diff := cmp.Diff([]string{"1", "failed", "2"}, sent); diff != "" {
+		t.Error("unexpected sent events (-want +got)", diff)
+	}
+	if diff := cmp.Diff([]types.NamespacedName{other}, b.Brokers()); diff != "" {
+		t.Error("unexpected brokers (-want +got)", diff)
+	}
+
+	b.Drop(other)
+	if got := b.Brokers(); len(got) != 0 {
+		t.Errorf("expected no brokers after Drop, got %v", got)
+	}
+	if got := b.Total(); got != 0 {
+		t.Errorf("Total() = %d after Drop, want 0", got)
+	}
+}
+
+func TestMaintenanceBuffer_ConcurrentDrain(t *testing.T) {
+	const events = 1000
+	b := NewMaintenanceBuffer(events, events)
+	broker := types.NamespacedName{Namespace: "ns", Name: "broker"}
+	for i := 0; i < events; i++ {
+		e := cloudevents.NewEvent()
+		e.SetID(strconv.Itoa(i))
+		b.Add(broker, e, nil)
+	}
+
+	var (
+		mu   sync.Mutex
+		sent = make(map[string]int)
+		wg   sync.WaitGroup
+		// Both drains are sending their first event at the same time.
+		started sync.WaitGroup
+	)
+	started.Add(2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(drainer int) {
+			defer wg.Done()
+			attempts := 0
+			for b.Total() > 0 {
+				b.Drain(broker, func(event cloudevents.Event, _ http.Header) bool {
+					attempts++
+					if attempts == 1 {
+						started.Done()
+						started.Wait()
+					}
+					// Fail some sends, so that the events are put back
+					// while the other drain runs.
+					if attempts%(7+drainer) == 0 {
+						return false
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					sent[event.ID()]++
+					return true
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(sent); got != events {
+		t.Errorf("sent %d events, want %d", got, events)
+	}
+	for id, n := range sent {
+		if n != 1 {
+			t.Errorf("event %s was sent %d times", id, n)
+		}
+	}
+	if got := b.Total(); got != 0 {
+		t.Errorf("Total() = %d after the drains, want 0", got)
+	}
+}
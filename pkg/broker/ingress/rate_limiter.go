@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultRateLimiterSize is the default maximum number of sources whose
+// bucket is kept by a RateLimiter.
+const DefaultRateLimiterSize = 10000
+
+// RateLimiter limits the rate of the events the Brokers accept per event
+// source with token buckets. It keeps the buckets of a maximum number of
+// sources, after which the buckets of the sources that sent an event the
+// longest time ago are removed.
+type RateLimiter struct {
+	size int
+
+	mu sync.Mutex
+	// limiters maps the sources to their element in lru.
+	limiters map[rateLimiterKey]*list.Element
+	// lru holds the rateLimiterEntry of the sources, the most recently used
+	// first.
+	lru *list.List
+}
+
+type rateLimiterKey struct {
+	broker types.NamespacedName
+	source string
+}
+
+type rateLimiterEntry struct {
+	key     rateLimiterKey
+	limiter *rate.Limiter
+}
+
+// RateLimitedError is the error the events of the sources exceeding their
+// rate limit are rejected with.
+type RateLimitedError struct {
+	Source string
+	// RetryAfter is the time after which the source can send its next event.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit of the source %q exceeded, retry after %v", e.Source, e.RetryAfter)
+}
+
+// NewRateLimiter returns an empty RateLimiter keeping the buckets of up to
+// size sources, DefaultRateLimiterSize when size isn't positive.
+func NewRateLimiter(size int) *RateLimiter {
+	if size <= 0 {
+		size = DefaultRateLimiterSize
+	}
+	return &RateLimiter{
+		size:     size,
+		limiters: make(map[rateLimiterKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Allow takes a token from the bucket of the source for the broker, filled
+// with limit tokens per second up to burst tokens. It returns a
+// RateLimitedError if the bucket is empty.
+func (r *RateLimiter) Allow(broker types.NamespacedName, source string, limit float64, burst int) error {
+	now := time.Now()
+	key := rateLimiterKey{broker: broker, source: source}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := r.limiter(key, now, limit, burst)
+	reservation := l.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return &RateLimitedError{Source: source, RetryAfter: delay}
+	}
+	return nil
+}
+
+// limiter returns the bucket of the source, with the given limit and burst,
+// and marks it as the most recently used one. A new bucket evicts the least
+// recently used one when there are size buckets. It must be called with mu
+// held.
+func (r *RateLimiter) limiter(key rateLimiterKey, now time.Time, limit float64, burst int) *rate.Limiter {
+	if e, ok := r.limiters[key]; ok {
+		r.lru.MoveToFront(e)
+		l := e.Value.(*rateLimiterEntry).limiter
+		if l.Limit() != rate.Limit(limit) || l.Burst() != burst {
+			l.SetLimitAt(now, rate.Limit(limit))
+			l.SetBurstAt(now, burst)
+		}
+		return l
+	}
+
+	for r.lru.Len() >= r.size {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.limiters, oldest.Value.(*rateLimiterEntry).key)
+	}
+	l := rate.NewLimiter(rate.Limit(limit), burst)
+	r.limiters[key] = r.lru.PushFront(&rateLimiterEntry{key: key, limiter: l})
+	return l
+}
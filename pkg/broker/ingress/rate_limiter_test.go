@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRateLimiter(t *testing.T) {
+	r := NewRateLimiter(0)
+	broker := types.NamespacedName{Namespace: "ns", Name: "broker"}
+	other := types.NamespacedName{Namespace: "ns", Name: "other"}
+
+	for i := 0; i < 2; i++ {
+		if err := r.Allow(broker, "source", 1, 2); err != nil {
+			t.Errorf("Allow() = %v within the burst", err)
+		}
+	}
+	err := r.Allow(broker, "source", 1, 2)
+	var rateLimited *RateLimitedError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("Allow() = %v, want a RateLimitedError", err)
+	}
+	if rateLimited.RetryAfter <= 0 || rateLimited.RetryAfter > time.Second {
+		t.Errorf("unexpected RetryAfter %v", rateLimited.RetryAfter)
+	}
+
+	// The sources and the brokers have their own buckets.
+	if err := r.Allow(broker, "other-source", 1, 2); err != nil {
+		t.Errorf("Allow() = %v for another source", err)
+	}
+	if err := r.Allow(other, "source", 1, 2); err != nil {
+		t.Errorf("Allow() = %v for another broker", err)
+	}
+
+	// A new limit applies to the existing bucket.
+	_ = r.Allow(broker, "source", 1000, 1000)
+	if l := r.limiters[rateLimiterKey{broker: broker, source: "source"}].Value.(*rateLimiterEntry).limiter; l.Limit() != 1000 || l.Burst() != 1000 {
+		t.Errorf("expected the new limit to apply, got %v and a burst of %d", l.Limit(), l.Burst())
+	}
+}
+
+func TestRateLimiterSize(t *testing.T) {
+	r := NewRateLimiter(2)
+	broker := types.NamespacedName{Namespace: "ns", Name: "broker"}
+
+	for _, source := range []string{"first", "second", "first", "third"} {
+		if err := r.Allow(broker, source, 1000, 10); err != nil {
+			t.Fatal("Allow() =", err)
+		}
+	}
+	if r.lru.Len() != 2 || len(r.limiters) != 2 {
+		t.Errorf("Got %d buckets in the list and %d in the map, want 2", r.lru.Len(), len(r.limiters))
+	}
+	// The least recently used bucket is evicted, even if it isn't full.
+	if _, ok := r.limiters[rateLimiterKey{broker: broker, source: "second"}]; ok {
+		t.Error("expected the bucket of the least recently used source to be evicted")
+	}
+	for _, source := range []string{"first", "third"} {
+		if _, ok := r.limiters[rateLimiterKey{broker: broker, source: source}]; !ok {
+			t.Errorf("expected the bucket of the source %q to be kept", source)
+		}
+	}
+}
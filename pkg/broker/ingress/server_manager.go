@@ -19,36 +19,36 @@ package ingress
 import (
 	"context"
 	"crypto/tls"
+	"net/http"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/kncloudevents"
 	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/system"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	secretinformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret"
 )
 
-func NewServerManager(ctx context.Context, logger *zap.Logger, cmw configmap.Watcher, httpPort, httpsPort int, handler *Handler) (*eventingtls.ServerManager, error) {
+func NewServerManager(ctx context.Context, logger *zap.Logger, cmw configmap.Watcher, httpPort, httpsPort int, handler http.Handler, opts ...kncloudevents.HTTPEventReceiverOption) (*eventingtls.ServerManager, error) {
 	tlsConfig, err := getServerTLSConfig(ctx)
 	if err != nil {
 		logger.Info("failed to get TLS server config", zap.Error(err))
 	}
 
-	httpReceiver := kncloudevents.NewHTTPEventReceiver(httpPort)
-	httpsReceiver := kncloudevents.NewHTTPEventReceiver(httpsPort, kncloudevents.WithTLSConfig(tlsConfig))
+	httpReceiver := kncloudevents.NewHTTPEventReceiver(httpPort, opts...)
+	httpsReceiver := kncloudevents.NewHTTPEventReceiver(httpsPort, append(opts, kncloudevents.WithTLSConfig(tlsConfig))...)
 
 	return eventingtls.NewServerManager(ctx, httpReceiver, httpsReceiver, handler, cmw)
 }
 
 func getServerTLSConfig(ctx context.Context) (*tls.Config, error) {
 	secret := types.NamespacedName{
-		Namespace: "knative-eventing",
+		Namespace: system.Namespace(),
 		Name:      eventingtls.BrokerIngressServerTLSSecretName,
 	}
 
-	serverTLSConfig := eventingtls.NewDefaultServerConfig()
-	serverTLSConfig.GetCertificate = eventingtls.GetCertificateFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
-	return eventingtls.GetTLSServerConfig(serverTLSConfig)
+	return eventingtls.GetTLSServerConfigFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
 }
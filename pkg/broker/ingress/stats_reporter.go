@@ -49,6 +49,14 @@ var (
 		stats.UnitMilliseconds,
 	)
 
+	// eventRejectedCountM is a counter which records the number of events
+	// rejected by the event validation of the Broker.
+	eventRejectedCountM = stats.Int64(
+		"event_validation_rejected_count",
+		"Number of events rejected by the event validation of a Broker",
+		stats.UnitDimensionless,
+	)
+
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -58,6 +66,8 @@ var (
 	eventSchemeKey       = tag.MustNewKey(eventingmetrics.LabelEventScheme)
 	responseCodeKey      = tag.MustNewKey(eventingmetrics.LabelResponseCode)
 	responseCodeClassKey = tag.MustNewKey(eventingmetrics.LabelResponseCodeClass)
+	validationLevelKey   = tag.MustNewKey(eventingmetrics.LabelValidationLevel)
+	rejectionReasonKey   = tag.MustNewKey(eventingmetrics.LabelRejectionReason)
 )
 
 type ReportArgs struct {
@@ -75,6 +85,7 @@ func init() {
 type StatsReporter interface {
 	ReportEventCount(args *ReportArgs, responseCode int) error
 	ReportEventDispatchTime(args *ReportArgs, responseCode int, d time.Duration) error
+	ReportEventRejected(args *ReportArgs, validationLevel, reason string) error
 }
 
 var (
@@ -120,6 +131,19 @@ func register() {
 			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...), // 1, 2, 5, 10, 20, 50, 100, 500, 1000, 5000, 10000
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Description: eventRejectedCountM.Description(),
+			Measure:     eventRejectedCountM,
+			Aggregation: view.Count(),
+			TagKeys: []tag.Key{
+				eventTypeKey,
+				eventSchemeKey,
+				validationLevelKey,
+				rejectionReasonKey,
+				broker.ContainerTagKey,
+				broker.UniqueTagKey,
+			},
+		},
 	)
 	if err != nil {
 		log.Printf("failed to register opencensus views, %s", err)
@@ -147,16 +171,36 @@ func (r *reporter) ReportEventDispatchTime(args *ReportArgs, responseCode int, d
 	return nil
 }
 
-func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
-	ctx := metricskey.WithResource(emptyContext, resource.Resource{
+// ReportEventRejected captures the events rejected by the event validation.
+func (r *reporter) ReportEventRejected(args *ReportArgs, validationLevel, reason string) error {
+	ctx, err := tag.New(
+		r.resourceContext(args),
+		tag.Insert(broker.ContainerTagKey, r.container),
+		tag.Insert(broker.UniqueTagKey, r.uniqueName),
+		tag.Insert(eventTypeKey, args.eventType),
+		tag.Insert(eventSchemeKey, args.eventScheme),
+		tag.Insert(validationLevelKey, validationLevel),
+		tag.Insert(rejectionReasonKey, reason))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, eventRejectedCountM.M(1))
+	return nil
+}
+
+func (r *reporter) resourceContext(args *ReportArgs) context.Context {
+	return metricskey.WithResource(emptyContext, resource.Resource{
 		Type: eventingmetrics.ResourceTypeKnativeBroker,
 		Labels: map[string]string{
 			eventingmetrics.LabelNamespaceName: args.ns,
 			eventingmetrics.LabelBrokerName:    args.broker,
 		},
 	})
+}
+
+func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
 	return tag.New(
-		ctx,
+		r.resourceContext(args),
 		tag.Insert(broker.ContainerTagKey, r.container),
 		tag.Insert(broker.UniqueTagKey, r.uniqueName),
 		tag.Insert(eventTypeKey, args.eventType),
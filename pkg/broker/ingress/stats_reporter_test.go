@@ -75,6 +75,19 @@ func TestStatsReporter(t *testing.T) {
 	})
 	metricstest.AssertMetric(t, metricstest.DistributionCountOnlyMetric("event_dispatch_latencies", 2, wantTags))
 	metricstest.CheckDistributionData(t, "event_dispatch_latencies", wantTags, 2, 1100.0, 9100.0)
+
+	// test ReportEventRejected
+	expectSuccess(t, func() error {
+		return r.ReportEventRejected(args, "registry-enforced", "unregistered_type")
+	})
+	metricstest.AssertMetric(t, metricstest.IntMetric("event_validation_rejected_count", 1, map[string]string{
+		metrics.LabelEventType:       "testeventtype",
+		metrics.LabelEventScheme:     "http",
+		metrics.LabelValidationLevel: "registry-enforced",
+		metrics.LabelRejectionReason: "unregistered_type",
+		broker.LabelUniqueName:       "testpod",
+		broker.LabelContainerName:    "testcontainer",
+	}).WithResource(&resource))
 }
 
 func expectSuccess(t *testing.T, f func() error) {
@@ -92,6 +105,7 @@ func resetMetrics() {
 	// OpenCensus metrics carry global state that need to be reset between unit tests.
 	metricstest.Unregister(
 		"event_count",
-		"event_dispatch_latencies")
+		"event_dispatch_latencies",
+		"event_validation_rejected_count")
 	register()
 }
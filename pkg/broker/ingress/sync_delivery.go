@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/broker"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// maxSyncResultSize bounds the size of the body of the results posted by the
+// Broker filters.
+const maxSyncResultSize = 4 << 20
+
+// SyncDelivery correlates the events sent in synchronous delivery mode with
+// the results of their delivery to the Triggers, posted by the Broker filters
+// to the URL carried by the broker.SyncReplyToAttribute extension.
+type SyncDelivery struct {
+	replyURL string
+	timeout  time.Duration
+
+	mu      sync.Mutex
+	pending map[string]chan SyncResult
+}
+
+// SyncResult is the response of a subscriber to an event sent in synchronous
+// delivery mode.
+type SyncResult struct {
+	StatusCode int
+	Header     http.Header
+	// Body holds the reply event, if any.
+	Body []byte
+}
+
+// NewSyncDelivery returns a SyncDelivery waiting up to timeout for the
+// results posted under replyURL, or nil if replyURL is empty, which disables
+// the synchronous delivery mode.
+func NewSyncDelivery(replyURL string, timeout time.Duration) *SyncDelivery {
+	if replyURL == "" {
+		return nil
+	}
+	return &SyncDelivery{
+		replyURL: strings.TrimSuffix(replyURL, "/") + "/",
+		timeout:  timeout,
+		pending:  make(map[string]chan SyncResult),
+	}
+}
+
+// Register registers an event waiting for a result, and returns the URL the
+// result is to be posted to, the channel receiving the first result and a
+// function unregistering the event, to be called once done waiting.
+func (s *SyncDelivery) Register() (string, <-chan SyncResult, func()) {
+	id := uuid.New().String()
+	results := make(chan SyncResult, 1)
+
+	s.mu.Lock()
+	s.pending[id] = results
+	s.mu.Unlock()
+
+	return s.replyURL + id, results, func() {
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+	}
+}
+
+// Wait waits for the first result on results, until the timeout of the
+// SyncDelivery is over or ctx is done.
+func (s *SyncDelivery) Wait(ctx context.Context, results <-chan SyncResult) (SyncResult, bool) {
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return result, true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return SyncResult{}, false
+}
+
+// ServeHTTP receives the results posted by the Broker filters. Only the first
+// result of an event is kept, the other ones are answered 404 Not Found like
+// the results of unknown events.
+func (s *SyncDelivery) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		writer.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	statusCode, err := strconv.Atoi(request.Header.Get(broker.SyncStatusHeader))
+	if err != nil || statusCode < 100 || statusCode > 599 {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	id := strings.TrimPrefix(request.URL.Path, "/")
+	s.mu.Lock()
+	results, ok := s.pending[id]
+	delete(s.pending, id)
+	s.mu.Unlock()
+	if !ok {
+		writer.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(request.Body, maxSyncResultSize))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Keep the headers of the subscriber response, not the ones of the
+	// request posting it.
+	header := request.Header.Clone()
+	for _, key := range []string{broker.SyncStatusHeader, "Content-Length", "User-Agent", "Accept-Encoding"} {
+		header.Del(key)
+	}
+	results <- SyncResult{StatusCode: statusCode, Header: header, Body: body}
+	writer.WriteHeader(http.StatusAccepted)
+}
+
+// StartServer serves the endpoint receiving the results on the given port in
+// the background, until ctx is done. It does nothing when the SyncDelivery is
+// nil or the port isn't positive.
+func (s *SyncDelivery) StartServer(ctx context.Context, logger *zap.Logger, port int, opts ...kncloudevents.HTTPEventReceiverOption) {
+	if s == nil || port <= 0 {
+		return
+	}
+
+	receiver := kncloudevents.NewHTTPEventReceiver(port, opts...)
+	go func() {
+		logger.Info("Starting synchronous delivery results server", zap.Int("port", port))
+		if err := receiver.StartListen(ctx, s); err != nil {
+			logger.Error("Synchronous delivery results server returned an error", zap.Error(err))
+		}
+	}()
+}
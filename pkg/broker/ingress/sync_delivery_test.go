@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"knative.dev/eventing/pkg/broker"
+)
+
+func TestSyncDelivery(t *testing.T) {
+	if s := NewSyncDelivery("", time.Second); s != nil {
+		t.Fatal("NewSyncDelivery() with no reply URL =", s)
+	}
+
+	s := NewSyncDelivery("http://10.0.0.1:8091", 10*time.Millisecond)
+	replyTo, results, done := s.Register()
+	defer done()
+	if !strings.HasPrefix(replyTo, "http://10.0.0.1:8091/") {
+		t.Fatal("Register() reply URL =", replyTo)
+	}
+
+	post := func(status string) int {
+		request := httptest.NewRequest(http.MethodPost, strings.TrimPrefix(replyTo, "http://10.0.0.1:8091"), strings.NewReader("reply"))
+		request.Header.Set(broker.SyncStatusHeader, status)
+		request.Header.Set("Ce-Id", "reply")
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	if got := post("abc"); got != http.StatusBadRequest {
+		t.Errorf("expected status code %d for an invalid status got %d", http.StatusBadRequest, got)
+	}
+	if got := post("201"); got != http.StatusAccepted {
+		t.Errorf("expected status code %d got %d", http.StatusAccepted, got)
+	}
+	// Only the first result is kept.
+	if got := post("500"); got != http.StatusNotFound {
+		t.Errorf("expected status code %d for a second result got %d", http.StatusNotFound, got)
+	}
+
+	result, ok := s.Wait(context.Background(), results)
+	if !ok {
+		t.Fatal("Wait() got no result")
+	}
+	if result.StatusCode != http.StatusCreated || string(result.Body) != "reply" || result.Header.Get("Ce-Id") != "reply" {
+		t.Errorf("Wait() = %+v", result)
+	}
+	if result.Header.Get(broker.SyncStatusHeader) != "" {
+		t.Error("expected the status header to be removed from the result")
+	}
+
+	_, results, done = s.Register()
+	defer done()
+	if _, ok := s.Wait(context.Background(), results); ok {
+		t.Error("Wait() got a result, want a timeout")
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"net/http"
+	"strings"
+
+	"knative.dev/pkg/network"
+)
+
+// DefaultUnauthenticatedPaths are the request paths the broker data plane
+// answers without authentication when no allowlist is configured.
+const DefaultUnauthenticatedPaths = "/healthz,/readyz"
+
+// UnauthenticatedPaths is the set of request paths the broker data plane
+// serves without authentication, like the health endpoints probed by the
+// kubelet or by service meshes.
+type UnauthenticatedPaths map[string]struct{}
+
+// ParseUnauthenticatedPaths parses a comma separated list of request paths.
+// Empty entries are ignored and a trailing slash is not significant.
+func ParseUnauthenticatedPaths(s string) UnauthenticatedPaths {
+	paths := make(UnauthenticatedPaths)
+	for _, p := range strings.Split(s, ",") {
+		p = normalizePath(p)
+		if p == "" {
+			continue
+		}
+		paths[p] = struct{}{}
+	}
+	return paths
+}
+
+// Has returns true when path is part of the allowlist.
+func (p UnauthenticatedPaths) Has(path string) bool {
+	path = normalizePath(path)
+	if path == "" {
+		return false
+	}
+	_, ok := p[path]
+	return ok
+}
+
+// Handler returns a handler which answers GET and HEAD requests for an
+// allowlisted path with a 200 before any authentication happens, and passes
+// every other request on to next.
+func (p UnauthenticatedPaths) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method == http.MethodGet || r.Method == http.MethodHead) && p.Has(r.URL.Path) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ProbeHandler returns a handler which answers the health checks of an
+// allowlisted path with the readiness of the event server listening at
+// target, so that a dedicated probe port reports a 503 while the event
+// server isn't serving yet or is draining. Any other request gets a 404.
+func (p UnauthenticatedPaths) ProbeHandler(target string, client *http.Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method != http.MethodGet && r.Method != http.MethodHead) || !p.Has(r.URL.Path) {
+			http.NotFound(w, r)
+			return
+		}
+
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, "http://"+target+r.URL.Path, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// The drainer of the event server answers kubelet probes with its
+		// draining state.
+		req.Header.Set(network.UserAgentKey, network.KubeProbeUAPrefix+"broker")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			http.Error(w, "event server not ready", http.StatusServiceUnavailable)
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			http.Error(w, "event server not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func normalizePath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" || p == "/" {
+		return ""
+	}
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return strings.TrimSuffix(p, "/")
+}
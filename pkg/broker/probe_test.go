@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"knative.dev/pkg/network"
+)
+
+func TestUnauthenticatedPathsHandler(t *testing.T) {
+	paths := ParseUnauthenticatedPaths(" /healthz, readyz/ ,,")
+
+	tests := map[string]struct {
+		method string
+		path   string
+		want   int
+	}{
+		"allowlisted path": {
+			method: http.MethodGet,
+			path:   "/healthz",
+			want:   http.StatusOK,
+		},
+		"allowlisted path normalized": {
+			method: http.MethodHead,
+			path:   "/readyz/",
+			want:   http.StatusOK,
+		},
+		"allowlisted path with event method": {
+			method: http.MethodPost,
+			path:   "/healthz",
+			want:   http.StatusUnauthorized,
+		},
+		"event path": {
+			method: http.MethodPost,
+			path:   "/ns/broker",
+			want:   http.StatusUnauthorized,
+		},
+		"root path": {
+			method: http.MethodGet,
+			path:   "/",
+			want:   http.StatusUnauthorized,
+		},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			paths.Handler(next).ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+			if w.Code != tc.want {
+				t.Errorf("want status %d, got %d", tc.want, w.Code)
+			}
+		})
+	}
+}
+
+func TestUnauthenticatedPathsProbeHandler(t *testing.T) {
+	paths := ParseUnauthenticatedPaths(DefaultUnauthenticatedPaths)
+
+	tests := map[string]struct {
+		method      string
+		path        string
+		eventServer int
+		stopped     bool
+		want        int
+	}{
+		"event server ready": {
+			method:      http.MethodGet,
+			path:        "/healthz",
+			eventServer: http.StatusOK,
+			want:        http.StatusOK,
+		},
+		"event server draining": {
+			method:      http.MethodHead,
+			path:        "/readyz",
+			eventServer: http.StatusServiceUnavailable,
+			want:        http.StatusServiceUnavailable,
+		},
+		"event server not listening": {
+			method:  http.MethodGet,
+			path:    "/healthz",
+			stopped: true,
+			want:    http.StatusServiceUnavailable,
+		},
+		"not allowlisted path": {
+			method:      http.MethodGet,
+			path:        "/ns/broker",
+			eventServer: http.StatusOK,
+			want:        http.StatusNotFound,
+		},
+		"event method": {
+			method:      http.MethodPost,
+			path:        "/healthz",
+			eventServer: http.StatusOK,
+			want:        http.StatusNotFound,
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !network.IsKubeletProbe(r) {
+					t.Errorf("want a kubelet probe, got User-Agent %q", r.UserAgent())
+				}
+				w.WriteHeader(tc.eventServer)
+			}))
+			defer server.Close()
+			if tc.stopped {
+				server.Close()
+			}
+
+			w := httptest.NewRecorder()
+			target := strings.TrimPrefix(server.URL, "http://")
+			paths.ProbeHandler(target, server.Client()).ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+			if w.Code != tc.want {
+				t.Errorf("want status %d, got %d", tc.want, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	// SyncReplyToAttribute is the name of the CloudEvents extension attribute
+	// carrying the URL the Broker filter posts the result of the delivery of
+	// an event sent in synchronous delivery mode to. It is set by the Broker
+	// ingress and removed before the event is sent to the subscribers.
+	SyncReplyToAttribute = "knativesyncreplyto"
+
+	// SyncDeliveryHeader is the header a producer sets to "true" to wait for
+	// the result of the delivery of its event to a Trigger.
+	SyncDeliveryHeader = "Knative-Sync-Delivery"
+
+	// SyncStatusHeader is the header carrying the status code of the
+	// subscriber response in the results posted to the Broker ingress.
+	SyncStatusHeader = "Knative-Sync-Status"
+
+	// syncResultTimeout bounds the requests posting a result to the Broker
+	// ingress.
+	syncResultTimeout = 5 * time.Second
+)
+
+var syncResultClient = &http.Client{Timeout: syncResultTimeout}
+
+// GetSyncReplyTo returns the URL the result of the delivery of the event is
+// posted to, if the event was sent in synchronous delivery mode.
+func GetSyncReplyTo(ctx cloudevents.EventContext) (string, bool) {
+	replyTo, err := ctx.GetExtension(SyncReplyToAttribute)
+	if err != nil {
+		return "", false
+	}
+	s, err := cetypes.ToString(replyTo)
+	if err != nil || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+// SetSyncReplyTo sets the URL the result of the delivery of the event is
+// posted to.
+func SetSyncReplyTo(ctx cloudevents.EventContext, replyTo string) error {
+	return ctx.SetExtension(SyncReplyToAttribute, replyTo)
+}
+
+// DeleteSyncReplyTo removes the SyncReplyToAttribute CE extension attribute.
+func DeleteSyncReplyTo(ctx cloudevents.EventContext) error {
+	return ctx.SetExtension(SyncReplyToAttribute, nil)
+}
+
+// PostSyncResult posts the response of a subscriber, its status code, its
+// headers and its body holding the reply event if any, to replyTo.
+func PostSyncResult(ctx context.Context, replyTo string, statusCode int, header http.Header, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, replyTo, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set(SyncStatusHeader, strconv.Itoa(statusCode))
+
+	resp, err := syncResultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func TestSyncReplyTo(t *testing.T) {
+	event := cloudevents.NewEvent()
+	if _, ok := GetSyncReplyTo(event.Context); ok {
+		t.Fatal("GetSyncReplyTo() found a reply URL on a new event")
+	}
+
+	if err := SetSyncReplyTo(event.Context, "http://10.0.0.1:8091/id"); err != nil {
+		t.Fatal("SetSyncReplyTo() =", err)
+	}
+	if got, ok := GetSyncReplyTo(event.Context); !ok || got != "http://10.0.0.1:8091/id" {
+		t.Errorf("GetSyncReplyTo() = %q, %v", got, ok)
+	}
+
+	if err := DeleteSyncReplyTo(event.Context); err != nil {
+		t.Fatal("DeleteSyncReplyTo() =", err)
+	}
+	if _, ok := GetSyncReplyTo(event.Context); ok {
+		t.Error("GetSyncReplyTo() found a deleted reply URL")
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ceoverrides implements the spec.ceOverrides of the sources, whose
+// values may be templates referencing the attributes of the overridden event.
+package ceoverrides
+
+import (
+	"fmt"
+	"sort"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// Overrides are the compiled ceOverrides of a source.
+type Overrides struct {
+	names     []string
+	templates map[string]Template
+}
+
+// Compile parses every value of the given ceOverrides. A nil ceOverrides
+// compiles to nil Overrides, which apply no change.
+func Compile(ceOverrides *duckv1.CloudEventOverrides) (*Overrides, error) {
+	if ceOverrides == nil || len(ceOverrides.Extensions) == 0 {
+		return nil, nil
+	}
+	o := &Overrides{
+		names:     make([]string, 0, len(ceOverrides.Extensions)),
+		templates: make(map[string]Template, len(ceOverrides.Extensions)),
+	}
+	for name, value := range ceOverrides.Extensions {
+		t, err := Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("ceOverrides %q: %w", name, err)
+		}
+		o.names = append(o.names, name)
+		o.templates[name] = t
+	}
+	sort.Strings(o.names)
+	return o, nil
+}
+
+// Apply sets the overrides on the event. Every template is rendered against
+// the event as it was before any override is applied, so the overrides don't
+// depend on each other.
+//
+// The optional subject attribute is overridden in place, the other names are
+// set as extensions.
+func (o *Overrides) Apply(event *cloudevents.Event) {
+	if o == nil {
+		return
+	}
+	values := make([]string, len(o.names))
+	for i, name := range o.names {
+		values[i] = o.templates[name].Execute(*event)
+	}
+	for i, name := range o.names {
+		if name == "subject" {
+			event.SetSubject(values[i])
+			continue
+		}
+		event.SetExtension(name, values[i])
+	}
+}
+
+// Validate checks that every value of the given ceOverrides is a valid
+// template.
+func Validate(ceOverrides *duckv1.CloudEventOverrides) *apis.FieldError {
+	if ceOverrides == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	for name, value := range ceOverrides.Extensions {
+		if _, err := Parse(value); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), apis.CurrentField).ViaKey(name).ViaField("extensions"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceoverrides
+
+import (
+	"testing"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestOverridesApply(t *testing.T) {
+	o, err := Compile(&duckv1.CloudEventOverrides{Extensions: map[string]string{
+		"subject": "{{.type}}-{{.id}}",
+		"origin":  "{{.source}}",
+		"tenant":  "{{.tenant}}-{{.subject}}",
+		"static":  "value",
+	}})
+	if err != nil {
+		t.Fatal("Compile() =", err)
+	}
+
+	event := testEvent()
+	o.Apply(&event)
+
+	if err := event.Validate(); err != nil {
+		t.Fatal("Validate() =", err)
+	}
+	// Every template sees the event as it was before the overrides.
+	if got, want := event.Subject(), "unit.type-abc-123"; got != want {
+		t.Errorf("Subject() = %q, want %q", got, want)
+	}
+	if got, want := event.Extensions()["tenant"], "acme-"; got != want {
+		t.Errorf("tenant = %v, want %q", got, want)
+	}
+	if got, want := event.Extensions()["origin"], "unit/test"; got != want {
+		t.Errorf("origin = %v, want %q", got, want)
+	}
+	if got, want := event.Extensions()["static"], "value"; got != want {
+		t.Errorf("static = %v, want %q", got, want)
+	}
+}
+
+func TestOverridesNil(t *testing.T) {
+	o, err := Compile(nil)
+	if err != nil || o != nil {
+		t.Fatalf("Compile(nil) = %v, %v, want nil, nil", o, err)
+	}
+	event := testEvent()
+	o.Apply(&event)
+	if got, want := event.Type(), "unit.type"; got != want {
+		t.Errorf("Type() = %q, want %q", got, want)
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	if _, err := Compile(&duckv1.CloudEventOverrides{Extensions: map[string]string{"subject": "{{.type"}}); err == nil {
+		t.Error("Compile() = nil, want an error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(nil); err != nil {
+		t.Error("Validate(nil) =", err)
+	}
+	if err := Validate(&duckv1.CloudEventOverrides{Extensions: map[string]string{"subject": "{{.type}}"}}); err != nil {
+		t.Error("Validate() =", err)
+	}
+	err := Validate(&duckv1.CloudEventOverrides{Extensions: map[string]string{"subject": "{{type}}"}})
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	if got, want := err.Error(), `invalid value: invalid expression in "{{type}}": "type" must be an attribute reference like .type: extensions[subject]`; got != want {
+		t.Errorf("Validate() = %q, want %q", got, want)
+	}
+}
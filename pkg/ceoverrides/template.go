@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceoverrides
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+const (
+	openDelim  = "{{"
+	closeDelim = "}}"
+)
+
+// part is a single piece of a Template, either a literal or a reference to
+// an attribute of the event.
+type part struct {
+	literal   string
+	attribute string
+}
+
+// Template is a parsed ceOverrides value.
+//
+// The supported language is deliberately small: literal text with any number
+// of "{{.name}}" references to a CloudEvents attribute or extension of the
+// event, for example "{{.type}}-{{.id}}". There are no functions, pipelines
+// or conditionals, so evaluating a Template can't fail nor loop. A reference
+// to an attribute the event doesn't have renders as the empty string.
+type Template struct {
+	raw   string
+	parts []part
+}
+
+// String returns the value the Template was parsed from.
+func (t Template) String() string {
+	return t.raw
+}
+
+// IsLiteral reports whether the Template has no attribute references.
+func (t Template) IsLiteral() bool {
+	for _, p := range t.parts {
+		if p.attribute != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses a ceOverrides value.
+func Parse(value string) (Template, error) {
+	t := Template{raw: value}
+	rest := value
+	for rest != "" {
+		start := strings.Index(rest, openDelim)
+		if start < 0 {
+			t.parts = append(t.parts, part{literal: rest})
+			break
+		}
+		if start > 0 {
+			t.parts = append(t.parts, part{literal: rest[:start]})
+		}
+		rest = rest[start+len(openDelim):]
+
+		end := strings.Index(rest, closeDelim)
+		if end < 0 {
+			return Template{}, fmt.Errorf("unterminated %q in %q", openDelim, value)
+		}
+		name, err := parseReference(rest[:end])
+		if err != nil {
+			return Template{}, fmt.Errorf("invalid expression in %q: %w", value, err)
+		}
+		t.parts = append(t.parts, part{attribute: name})
+		rest = rest[end+len(closeDelim):]
+	}
+	return t, nil
+}
+
+// parseReference parses the content of a "{{ }}" pair, which must be a dot
+// followed by the name of an attribute.
+func parseReference(expr string) (string, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return "", fmt.Errorf("%q must be an attribute reference like .type", expr)
+	}
+	name := expr[1:]
+	if name == "" {
+		return "", fmt.Errorf("%q is missing the attribute name", expr)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return "", fmt.Errorf("%q is not a valid attribute name, only lowercase letters and digits are allowed", name)
+		}
+	}
+	return name, nil
+}
+
+// Execute renders the Template against the given event.
+func (t Template) Execute(event cloudevents.Event) string {
+	if len(t.parts) == 1 && t.parts[0].attribute == "" {
+		return t.parts[0].literal
+	}
+	var b strings.Builder
+	for _, p := range t.parts {
+		if p.attribute == "" {
+			b.WriteString(p.literal)
+			continue
+		}
+		b.WriteString(attribute(event, p.attribute))
+	}
+	return b.String()
+}
+
+// attribute returns the string form of the named attribute of the event.
+func attribute(event cloudevents.Event, name string) string {
+	switch name {
+	case "id":
+		return event.ID()
+	case "type":
+		return event.Type()
+	case "source":
+		return event.Source()
+	case "subject":
+		return event.Subject()
+	case "specversion":
+		return event.SpecVersion()
+	case "datacontenttype":
+		return event.DataContentType()
+	case "dataschema":
+		return event.DataSchema()
+	case "time":
+		if event.Time().IsZero() {
+			return ""
+		}
+		return event.Time().UTC().Format(time.RFC3339Nano)
+	}
+	v, ok := event.Extensions()[name]
+	if !ok {
+		return ""
+	}
+	s, err := types.ToString(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceoverrides
+
+import (
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func testEvent() cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID("abc-123")
+	event.SetSource("unit/test")
+	event.SetType("unit.type")
+	event.SetTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	event.SetExtension("tenant", "acme")
+	event.SetExtension("attempt", 3)
+	return event
+}
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		literal bool
+	}{
+		{value: "", want: "", literal: true},
+		{value: "plain", want: "plain", literal: true},
+		{value: "{{.type}}-{{.id}}", want: "unit.type-abc-123"},
+		{value: "{{ .source }}", want: "unit/test"},
+		{value: "at {{.time}}", want: "at 2024-01-02T03:04:05Z"},
+		{value: "{{.tenant}}/{{.attempt}}", want: "acme/3"},
+		{value: "[{{.subject}}]", want: "[]"},
+		{value: "{{.missing}}", want: ""},
+		{value: "a}}b", want: "a}}b", literal: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			tmpl, err := Parse(tc.value)
+			if err != nil {
+				t.Fatal("Parse() =", err)
+			}
+			if got := tmpl.Execute(testEvent()); got != tc.want {
+				t.Errorf("Execute() = %q, want %q", got, tc.want)
+			}
+			if got := tmpl.IsLiteral(); got != tc.literal {
+				t.Errorf("IsLiteral() = %v, want %v", got, tc.literal)
+			}
+			if got := tmpl.String(); got != tc.value {
+				t.Errorf("String() = %q, want %q", got, tc.value)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, value := range []string{
+		"{{.type",
+		"{{}}",
+		"{{type}}",
+		"{{.}}",
+		"{{.Type}}",
+		"{{.type | upper}}",
+		`{{printf "%s" .type}}`,
+	} {
+		t.Run(value, func(t *testing.T) {
+			if _, err := Parse(value); err == nil {
+				t.Errorf("Parse(%q) = nil, want an error", value)
+			}
+		})
+	}
+}
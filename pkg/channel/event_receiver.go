@@ -70,6 +70,7 @@ type EventReceiver struct {
 	pathToChannelFunc    ResolveChannelFromPathFunc
 	reporter             StatsReporter
 	tokenVerifier        *auth.OIDCTokenVerifier
+	replayProtector      *kncloudevents.ReplayProtector
 	audience             string
 	withContext          func(context.Context) context.Context
 }
@@ -130,6 +131,7 @@ func NewEventReceiver(receiverFunc EventReceiverFunc, logger *zap.Logger, report
 		httpBindingsReceiver: bindingsReceiver,
 		receiverFunc:         receiverFunc,
 		hostToChannelFunc:    ResolveChannelFromHostFunc(ParseChannelFromHost),
+		replayProtector:      kncloudevents.NewReplayProtector(kncloudevents.DefaultReplayWindow, kncloudevents.DefaultReplayCacheSize),
 		logger:               logger,
 		reporter:             reporter,
 	}
@@ -261,6 +263,17 @@ func (r *EventReceiver) ServeHTTP(response nethttp.ResponseWriter, request *neth
 			r.logger.Warn("Error when validating the JWT token in the request", zap.Error(err))
 			return
 		}
+		if features.IsEnabled(feature.ReplayProtection) {
+			if err := r.replayProtector.Verify(request); err != nil {
+				r.logger.Warn("Request failed the replay protection", zap.Error(err))
+				if errors.Is(err, kncloudevents.ErrNonceStoreUnavailable) {
+					response.WriteHeader(nethttp.StatusServiceUnavailable)
+				} else {
+					response.WriteHeader(nethttp.StatusUnauthorized)
+				}
+				return
+			}
+		}
 		r.logger.Debug("Request contained a valid JWT. Continuing...")
 	}
 
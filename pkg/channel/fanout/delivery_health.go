@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// minHealthReportInterval is the minimum time between two notifications
+	// of a subscriber that keeps failing.
+	minHealthReportInterval = 30 * time.Second
+)
+
+// SubscriberHealth is the delivery health of a single subscriber.
+type SubscriberHealth struct {
+	// RecentFailures is the number of consecutive failed deliveries since the
+	// last successful one.
+	RecentFailures int32
+	// LastFailureTime is the time of the most recent failed delivery.
+	LastFailureTime time.Time
+	// LastError is the error of the most recent failed delivery.
+	LastError string
+}
+
+// DeliveryHealth records the outcome of the deliveries to the subscribers of
+// a channel. It is safe for concurrent use and can be shared by the handlers
+// serving the same channel.
+type DeliveryHealth struct {
+	mu          sync.RWMutex
+	subscribers map[types.UID]*subscriberHealth
+
+	// onChange is called, outside of the lock, when the health of a
+	// subscriber changed in a way that should be reported.
+	onChange func()
+	now      func() time.Time
+}
+
+type subscriberHealth struct {
+	SubscriberHealth
+	lastReport time.Time
+}
+
+// NewDeliveryHealth creates a DeliveryHealth calling onChange when a
+// subscriber starts or stops failing and, at most every
+// minHealthReportInterval, while it keeps failing. onChange may be nil.
+func NewDeliveryHealth(onChange func()) *DeliveryHealth {
+	return &DeliveryHealth{
+		subscribers: make(map[types.UID]*subscriberHealth),
+		onChange:    onChange,
+		now:         time.Now,
+	}
+}
+
+// Get returns the health of the subscriber with the given UID, and false if
+// no delivery to it has failed yet.
+func (h *DeliveryHealth) Get(uid types.UID) (SubscriberHealth, bool) {
+	if h == nil {
+		return SubscriberHealth{}, false
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	s, ok := h.subscribers[uid]
+	if !ok {
+		return SubscriberHealth{}, false
+	}
+	return s.SubscriberHealth, true
+}
+
+// Retain forgets the subscribers that are not part of uids.
+func (h *DeliveryHealth) Retain(uids ...types.UID) {
+	if h == nil {
+		return
+	}
+	keep := make(map[types.UID]struct{}, len(uids))
+	for _, uid := range uids {
+		keep[uid] = struct{}{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for uid := range h.subscribers {
+		if _, ok := keep[uid]; !ok {
+			delete(h.subscribers, uid)
+		}
+	}
+}
+
+// Record records the outcome of a delivery to the subscriber with the given UID.
+func (h *DeliveryHealth) Record(uid types.UID, err error) {
+	if h == nil || uid == "" {
+		return
+	}
+	if h.update(uid, err) && h.onChange != nil {
+		h.onChange()
+	}
+}
+
+func (h *DeliveryHealth) update(uid types.UID, err error) bool {
+	now := h.now()
+
+	if err == nil {
+		h.mu.RLock()
+		s, ok := h.subscribers[uid]
+		healthy := !ok || s.RecentFailures == 0
+		h.mu.RUnlock()
+		if healthy {
+			return false
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.subscribers[uid]
+	if !ok {
+		s = &subscriberHealth{}
+		h.subscribers[uid] = s
+	}
+
+	if err == nil {
+		if s.RecentFailures == 0 {
+			return false
+		}
+		s.RecentFailures = 0
+		s.lastReport = now
+		return true
+	}
+
+	s.RecentFailures++
+	s.LastFailureTime = now
+	s.LastError = err.Error()
+	if s.RecentFailures == 1 || now.Sub(s.lastReport) >= minHealthReportInterval {
+		s.lastReport = now
+		return true
+	}
+	return false
+}
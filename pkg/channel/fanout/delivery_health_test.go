@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/injection"
+
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func TestDeliveryHealth(t *testing.T) {
+	const uid = types.UID("sub-uid")
+
+	changes := 0
+	h := NewDeliveryHealth(func() { changes++ })
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return now }
+
+	h.Record(uid, nil)
+	if _, ok := h.Get(uid); ok {
+		t.Error("Expected no health for a subscriber that never failed")
+	}
+	if changes != 0 {
+		t.Errorf("Expected no change, got %d", changes)
+	}
+
+	h.Record(uid, errors.New("first"))
+	now = now.Add(time.Second)
+	h.Record(uid, errors.New("second"))
+	got, ok := h.Get(uid)
+	if !ok {
+		t.Fatal("Expected health for a failing subscriber")
+	}
+	want := SubscriberHealth{RecentFailures: 2, LastFailureTime: now, LastError: "second"}
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if changes != 1 {
+		t.Errorf("Expected the first failure only to be reported, got %d changes", changes)
+	}
+
+	now = now.Add(minHealthReportInterval)
+	h.Record(uid, errors.New("third"))
+	if changes != 2 {
+		t.Errorf("Expected a failing subscriber to be reported again after %v, got %d changes", minHealthReportInterval, changes)
+	}
+
+	h.Record(uid, nil)
+	got, _ = h.Get(uid)
+	if got.RecentFailures != 0 || got.LastError != "third" {
+		t.Errorf("Expected failures to be reset and the last error kept, got %+v", got)
+	}
+	if changes != 3 {
+		t.Errorf("Expected recovery to be reported, got %d changes", changes)
+	}
+
+	h.Retain("other")
+	if _, ok := h.Get(uid); ok {
+		t.Error("Expected health of removed subscriber to be forgotten")
+	}
+}
+
+func TestDeliveryHealthNil(t *testing.T) {
+	var h *DeliveryHealth
+	h.Record("uid", errors.New("boom"))
+	h.Retain()
+	if _, ok := h.Get("uid"); ok {
+		t.Error("Expected no health from a nil DeliveryHealth")
+	}
+}
+
+func TestFanoutEventHandler_RecordsDeliveryHealth(t *testing.T) {
+	ctx := context.Background()
+	ctx, _ = fakekubeclient.With(ctx)
+	ctx = injection.WithConfig(ctx, &rest.Config{})
+
+	status := http.StatusInternalServerError
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer subscriber.Close()
+	deadLetter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer deadLetter.Close()
+
+	sub := Subscription{
+		UID:        "sub-uid",
+		Subscriber: duckv1.Addressable{URL: apis.HTTP(subscriber.URL[7:])},
+		DeadLetter: &duckv1.Addressable{URL: apis.HTTP(deadLetter.URL[7:])},
+	}
+	health := NewDeliveryHealth(nil)
+
+	h, err := NewFanoutEventHandler(
+		zap.NewNop(),
+		Config{Subscriptions: []Subscription{sub}, DeliveryHealth: health},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+		nil,
+		nil,
+		nil,
+		kncloudevents.NewDispatcher(eventingtls.NewDefaultClientConfig(), auth.NewOIDCTokenProvider(ctx)),
+	)
+	if err != nil {
+		t.Fatal("NewFanoutEventHandler failed =", err)
+	}
+
+	// The event reaches the dead letter sink, but the subscriber is failing.
+	if r := h.dispatch(ctx, h.GetSubscriptions(ctx), makeCloudEvent(), http.Header{}); r.err != nil {
+		t.Fatal("Unexpected dispatch error:", r.err)
+	}
+	if got, ok := health.Get(sub.UID); !ok || got.RecentFailures != 1 {
+		t.Errorf("Expected one recent failure, got %+v", got)
+	}
+
+	status = http.StatusAccepted
+	if r := h.dispatch(ctx, h.GetSubscriptions(ctx), makeCloudEvent(), http.Header{}); r.err != nil {
+		t.Fatal("Unexpected dispatch error:", r.err)
+	}
+	if got, _ := health.Get(sub.UID); got.RecentFailures != 0 {
+		t.Errorf("Expected recent failures to be reset, got %+v", got)
+	}
+}
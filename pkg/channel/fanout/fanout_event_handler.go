@@ -24,6 +24,7 @@ package fanout
 import (
 	"context"
 	"errors"
+	"fmt"
 	nethttp "net/http"
 	"sync"
 	"time"
@@ -38,8 +39,10 @@ import (
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventtype"
 	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/redaction"
 )
 
 const (
@@ -55,6 +58,15 @@ type Subscription struct {
 	Name           string
 	Namespace      string
 	UID            types.UID
+	// TerminalReply receives the replies of the subscriber with the
+	// knativeterminate extension set to true instead of Reply.
+	TerminalReply *duckv1.Addressable
+	// Redaction are the rules applied to the event data before it is sent
+	// to the subscriber.
+	Redaction []redaction.Rule
+	// Ordered delivers the events to the subscriber one at a time, in the
+	// order they were received, retries included.
+	Ordered bool
 }
 
 // Config for a fanout.EventHandler.
@@ -63,6 +75,20 @@ type Config struct {
 	// Deprecated: AsyncHandler controls whether the Subscriptions are called synchronous or asynchronously.
 	// It is expected to be false when used as a sidecar.
 	AsyncHandler bool `json:"asyncHandler,omitempty"`
+	// DeliveryHealth, when set, records the outcome of the deliveries to
+	// each subscription.
+	DeliveryHealth *DeliveryHealth `json:"-"`
+	// RetryState, when set, persists the deliveries being retried and
+	// resumes those of the subscriptions once they are set.
+	RetryState *RetryState `json:"-"`
+	// EventIndex, when set, records the outcome of the deliveries to each
+	// subscription.
+	EventIndex *eventindex.Index `json:"-"`
+	// Scheduler, when set, bounds the deliveries in flight across the
+	// channels sharing the dispatcher, the deliveries of this channel are
+	// scheduled as those of Channel.
+	Scheduler *DispatchScheduler   `json:"-"`
+	Channel   types.NamespacedName `json:"-"`
 }
 
 // EventHandler is an http.Handler but has methods for managing
@@ -83,6 +109,15 @@ type FanoutEventHandler struct {
 
 	subscriptionsMutex sync.RWMutex
 	subscriptions      []Subscription
+	// sequencers hold the turns of the deliveries to the ordered
+	// subscriptions, by UID.
+	sequencers map[types.UID]*sequencer
+
+	deliveryHealth *DeliveryHealth
+	retryState     *RetryState
+	eventIndex     *eventindex.Index
+	scheduler      *DispatchScheduler
+	channel        types.NamespacedName
 
 	receiver *channel.EventReceiver
 
@@ -117,6 +152,11 @@ func NewFanoutEventHandler(
 		timeout:          defaultTimeout,
 		reporter:         reporter,
 		asyncHandler:     config.AsyncHandler,
+		deliveryHealth:   config.DeliveryHealth,
+		retryState:       config.RetryState,
+		eventIndex:       config.EventIndex,
+		scheduler:        config.Scheduler,
+		channel:          config.Channel,
 		eventTypeHandler: eventTypeHandler,
 		channelRef:       channelRef,
 		channelUID:       channelUID,
@@ -152,6 +192,15 @@ func SubscriberSpecToFanoutConfig(sub eventingduckv1.SubscriberSpec) (*Subscript
 		}
 	}
 
+	var terminalReply *duckv1.Addressable
+	if sub.TerminalReplyURI != nil {
+		terminalReply = &duckv1.Addressable{
+			URL:      sub.TerminalReplyURI,
+			CACerts:  sub.TerminalReplyCACerts,
+			Audience: sub.TerminalReplyAudience,
+		}
+	}
+
 	var deadLetter *duckv1.Addressable
 	if sub.Delivery != nil && sub.Delivery.DeadLetterSink != nil && sub.Delivery.DeadLetterSink.URI != nil {
 		// Subscription reconcilers resolves the URI.
@@ -171,7 +220,10 @@ func SubscriberSpecToFanoutConfig(sub eventingduckv1.SubscriberSpec) (*Subscript
 		}
 	}
 
-	s := &Subscription{Subscriber: destination, Reply: reply, DeadLetter: deadLetter, RetryConfig: retryConfig, UID: sub.UID}
+	s := &Subscription{Subscriber: destination, Reply: reply, TerminalReply: terminalReply, DeadLetter: deadLetter, RetryConfig: retryConfig, UID: sub.UID}
+	if sub.Delivery != nil && sub.Delivery.Ordering != nil {
+		s.Ordered = *sub.Delivery.Ordering == eventingduckv1.DeliveryOrderOrdered
+	}
 
 	if sub.Name != nil {
 		s.Name = *sub.Name
@@ -186,6 +238,7 @@ func (f *FanoutEventHandler) SetSubscriptions(ctx context.Context, subs []Subscr
 	s := make([]Subscription, len(subs))
 	copy(s, subs)
 	f.subscriptions = s
+	f.setSequencers(s)
 
 	for _, sub := range f.subscriptions {
 		if sub.Subscriber.URL != nil && sub.Subscriber.URL.Scheme == "https" {
@@ -194,6 +247,30 @@ func (f *FanoutEventHandler) SetSubscriptions(ctx context.Context, subs []Subscr
 			f.hasHttpSubs = true
 		}
 	}
+
+	f.resumePendingDeliveries(f.subscriptions)
+}
+
+// resumePendingDeliveries resumes, in the background, the deliveries to subs
+// persisted by the retry state that are not in flight.
+func (f *FanoutEventHandler) resumePendingDeliveries(subs []Subscription) {
+	if f.retryState == nil {
+		return
+	}
+	for _, sub := range subs {
+		if sub.UID == "" {
+			continue
+		}
+		for _, d := range f.retryState.claim(sub.UID) {
+			f.logger.Info("Resuming delivery",
+				zap.String("subscription", string(sub.UID)),
+				zap.String("event", d.pending.Event.ID()),
+				zap.Int("attempts", d.attempts()))
+			go func(s Subscription, d *trackedDelivery) {
+				_ = f.dispatchToSubscription(context.Background(), d.pending.Event, d.pending.Header, s, d)
+			}(sub, d)
+		}
+	}
 }
 
 func (f *FanoutEventHandler) GetSubscriptions(ctx context.Context) []Subscription {
@@ -231,12 +308,15 @@ func createEventReceiverFunction(f *FanoutEventHandler) func(context.Context, ch
 			}
 
 			parentSpan := trace.FromContext(ctx)
+			// The turns are taken before dispatching asynchronously, to keep
+			// the order in which the events are received.
+			turns := f.takeTurns(subs)
 
 			go func(e event.Event, h nethttp.Header, s *trace.Span) {
 				// Run async dispatch with background context.
 				ctx = trace.NewContext(context.Background(), s)
 				// Any returned error is already logged in f.dispatch().
-				_ = f.dispatch(ctx, subs, e, h)
+				_ = f.dispatchInTurns(ctx, subs, turns, e, h)
 
 			}(evnt, additionalHeaders, parentSpan)
 			return nil
@@ -284,23 +364,27 @@ func ParseDispatchResultAndReportMetrics(result DispatchResult, reporter channel
 // dispatch takes the event, fans it out to each subscription in subs. If all the fanned out
 // events return successfully, then return nil. Else, return an error.
 func (f *FanoutEventHandler) dispatch(ctx context.Context, subs []Subscription, event event.Event, additionalHeaders nethttp.Header) DispatchResult {
+	return f.dispatchInTurns(ctx, subs, f.takeTurns(subs), event, additionalHeaders)
+}
+
+// dispatchInTurns is dispatch, the event is delivered to the ordered
+// subscriptions once the turns, as returned by takeTurns, start.
+func (f *FanoutEventHandler) dispatchInTurns(ctx context.Context, subs []Subscription, turns []*turn, event event.Event, additionalHeaders nethttp.Header) DispatchResult {
 	results := make(chan DispatchResult, len(subs))
-	for _, sub := range subs {
-		go func(s Subscription) {
+	for i, sub := range subs {
+		var t *turn
+		if turns != nil {
+			t = turns[i]
+		}
+		go func(s Subscription, t *turn) {
+			t.wait()
+			defer t.done()
+
 			h := additionalHeaders.Clone()
 			h.Set(apis.KnNamespaceHeader, s.Namespace)
 
-			dispatchedResultPerSub, err := f.makeFanoutRequest(ctx, event, h, s)
-			r := DispatchResult{err: err, info: dispatchedResultPerSub}
-			results <- r
-
-			args := channel.ReportArgs{
-				Ns:          s.Namespace,
-				EventType:   event.Type(),
-				EventScheme: r.info.Scheme,
-			}
-			_ = ParseDispatchResultAndReportMetrics(r, f.reporter, args)
-		}(sub)
+			results <- f.dispatchToSubscription(ctx, event, h, s, f.trackDelivery(s, event, h))
+		}(sub, t)
 	}
 
 	var totalDispatchTimeForFanout time.Duration = kncloudevents.NoDuration
@@ -338,14 +422,95 @@ func (f *FanoutEventHandler) dispatch(ctx context.Context, subs []Subscription,
 	return dispatchResultForFanout
 }
 
+// trackDelivery starts tracking the delivery of event to sub in the retry
+// state, if any and if the delivery can be retried.
+func (f *FanoutEventHandler) trackDelivery(sub Subscription, event event.Event, additionalHeaders nethttp.Header) *trackedDelivery {
+	if f.retryState == nil || sub.UID == "" || sub.RetryConfig == nil || sub.RetryConfig.RetryMax == 0 {
+		return nil
+	}
+	return f.retryState.track(sub.UID, event, additionalHeaders, 0)
+}
+
+// dispatchToSubscription sends the event to exactly one subscription, and
+// records the outcome. tracked, when not nil, is the delivery tracked in the
+// retry state, it is done when this returns.
+func (f *FanoutEventHandler) dispatchToSubscription(ctx context.Context, event event.Event, additionalHeaders nethttp.Header, sub Subscription, tracked *trackedDelivery) DispatchResult {
+	release, err := f.scheduler.Acquire(ctx, f.channel)
+	if err != nil {
+		if tracked != nil {
+			tracked.done()
+		}
+		return DispatchResult{
+			err: fmt.Errorf("failed to schedule the delivery: %w", err),
+			info: &kncloudevents.DispatchInfo{
+				Duration:     kncloudevents.NoDuration,
+				ResponseCode: kncloudevents.NoResponse,
+			},
+		}
+	}
+	dispatchedResultPerSub, err := f.makeFanoutRequest(ctx, event, additionalHeaders, sub, tracked)
+	release()
+	f.deliveryHealth.Record(sub.UID, subscriberError(dispatchedResultPerSub, err))
+	if f.eventIndex != nil {
+		f.eventIndex.Add(indexRecord(&event, sub, dispatchedResultPerSub, err))
+	}
+	r := DispatchResult{err: err, info: dispatchedResultPerSub}
+
+	args := channel.ReportArgs{
+		Ns:          sub.Namespace,
+		EventType:   event.Type(),
+		EventScheme: r.info.Scheme,
+	}
+	_ = ParseDispatchResultAndReportMetrics(r, f.reporter, args)
+	return r
+}
+
+// indexRecord returns the record of the outcome of the delivery of event to
+// sub for the event index.
+func indexRecord(event *event.Event, sub Subscription, info *kncloudevents.DispatchInfo, err error) eventindex.Record {
+	outcome := eventindex.OutcomeDelivered
+	var statusCode int
+	if info != nil {
+		statusCode = info.ResponseCode
+	}
+	switch {
+	case err != nil:
+		outcome = eventindex.OutcomeFailed
+	case info != nil && info.DeadLetterCause != nil:
+		outcome = eventindex.OutcomeDeadLettered
+		err = info.DeadLetterCause
+	}
+	return eventindex.NewRecord(event, fmt.Sprintf("subscription %s/%s", sub.Namespace, sub.Name), outcome, statusCode, err)
+}
+
+// subscriberError returns the error of the delivery to the subscriber itself,
+// including when it was recovered by sending the event to the dead letter sink.
+func subscriberError(info *kncloudevents.DispatchInfo, err error) error {
+	if err == nil && info != nil {
+		return info.DeadLetterCause
+	}
+	return err
+}
+
 // makeFanoutRequest sends the request to exactly one subscription. It handles both the `call` and
-// the `sink` portions of the subscription.
-func (f *FanoutEventHandler) makeFanoutRequest(ctx context.Context, event event.Event, additionalHeaders nethttp.Header, sub Subscription) (*kncloudevents.DispatchInfo, error) {
+// the `sink` portions of the subscription. When tracked is not nil, the attempts to the subscriber
+// are persisted, and continue from those already made.
+func (f *FanoutEventHandler) makeFanoutRequest(ctx context.Context, event event.Event, additionalHeaders nethttp.Header, sub Subscription, tracked *trackedDelivery) (*kncloudevents.DispatchInfo, error) {
+	retryConfig := sub.RetryConfig
+	if tracked != nil {
+		defer tracked.done()
+		retryConfig = resumeRetryConfig(retryConfig, tracked.attempts())
+	}
+
 	dispatchOptions := []kncloudevents.SendOption{
 		kncloudevents.WithHeader(additionalHeaders),
 		kncloudevents.WithReply(sub.Reply),
+		kncloudevents.WithTerminalReply(sub.TerminalReply),
 		kncloudevents.WithDeadLetterSink(sub.DeadLetter),
-		kncloudevents.WithRetryConfig(sub.RetryConfig),
+		kncloudevents.WithRetryConfig(retryConfig),
+	}
+	if tracked != nil {
+		dispatchOptions = append(dispatchOptions, kncloudevents.WithAttemptObserver(tracked.observe))
 	}
 
 	if f.eventTypeHandler != nil && sub.Name != "" && sub.Namespace != "" && sub.UID != types.UID("") {
@@ -365,6 +530,19 @@ func (f *FanoutEventHandler) makeFanoutRequest(ctx context.Context, event event.
 		dispatchOptions = append(dispatchOptions, kncloudevents.WithOIDCAuthentication(sub.ServiceAccount))
 	}
 
+	if len(sub.Redaction) > 0 {
+		// The event is shared by all subscriptions, redact a copy.
+		event = event.Clone()
+		res, err := redaction.Redact(&event, sub.Redaction)
+		if err != nil {
+			return &kncloudevents.DispatchInfo{
+				Duration:     kncloudevents.NoDuration,
+				ResponseCode: kncloudevents.NoResponse,
+			}, fmt.Errorf("failed to redact event: %w", err)
+		}
+		_ = redaction.ReportRedactedFields(sub.Namespace, "Subscription", res)
+	}
+
 	return f.eventDispatcher.SendEvent(ctx, event, sub.Subscriber, dispatchOptions...)
 }
 
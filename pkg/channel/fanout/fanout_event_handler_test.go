@@ -34,6 +34,7 @@ import (
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/kncloudevents"
 
@@ -156,6 +157,48 @@ func TestGetSetSubscriptions(t *testing.T) {
 
 }
 
+func TestIndexRecord(t *testing.T) {
+	event := makeCloudEvent()
+	sub := Subscription{Namespace: "ns", Name: "sub"}
+	deliveryErr := errors.New("unavailable")
+
+	tests := []struct {
+		name string
+		info *kncloudevents.DispatchInfo
+		err  error
+		want eventindex.Record
+	}{{
+		name: "delivered",
+		info: &kncloudevents.DispatchInfo{ResponseCode: http.StatusAccepted},
+		want: eventindex.Record{Outcome: eventindex.OutcomeDelivered, StatusCode: http.StatusAccepted},
+	}, {
+		name: "dead-lettered",
+		info: &kncloudevents.DispatchInfo{ResponseCode: http.StatusAccepted, DeadLetterCause: deliveryErr},
+		want: eventindex.Record{Outcome: eventindex.OutcomeDeadLettered, StatusCode: http.StatusAccepted, Error: "unavailable"},
+	}, {
+		name: "failed",
+		info: &kncloudevents.DispatchInfo{ResponseCode: http.StatusServiceUnavailable},
+		err:  deliveryErr,
+		want: eventindex.Record{Outcome: eventindex.OutcomeFailed, StatusCode: http.StatusServiceUnavailable, Error: "unavailable"},
+	}, {
+		name: "failed without response",
+		err:  deliveryErr,
+		want: eventindex.Record{Outcome: eventindex.OutcomeFailed, Error: "unavailable"},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.want.ID = event.ID()
+			tc.want.Source = event.Source()
+			tc.want.Type = event.Type()
+			tc.want.Resource = "subscription ns/sub"
+			if diff := cmp.Diff(tc.want, indexRecord(&event, sub, tc.info, tc.err)); diff != "" {
+				t.Error("unexpected record (-want +got)", diff)
+			}
+		})
+	}
+}
+
 func TestFanoutEventHandler_ServeHTTP(t *testing.T) {
 	testCases := map[string]struct {
 		receiverFunc        channel.EventReceiverFunc
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// sequencer hands out the turns to deliver the events to an ordered
+// subscription, in the order they are taken.
+type sequencer struct {
+	mu sync.Mutex
+	// last is closed once the last turn taken is done.
+	last chan struct{}
+}
+
+func newSequencer() *sequencer {
+	last := make(chan struct{})
+	close(last)
+	return &sequencer{last: last}
+}
+
+// turn is the turn of a delivery to an ordered subscription.
+type turn struct {
+	// previous is closed once the previous turn is done.
+	previous <-chan struct{}
+	current  chan struct{}
+}
+
+// take returns the next turn, which starts once the turns taken before it
+// are done.
+func (s *sequencer) take() *turn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &turn{previous: s.last, current: make(chan struct{})}
+	s.last = t.current
+	return t
+}
+
+// wait blocks until the turn starts, it is a no-op on a nil turn.
+func (t *turn) wait() {
+	if t != nil {
+		<-t.previous
+	}
+}
+
+// done ends the turn, starting the next one, it is a no-op on a nil turn.
+func (t *turn) done() {
+	if t != nil {
+		close(t.current)
+	}
+}
+
+// setSequencers keeps the sequencers of the ordered subscriptions among subs,
+// creating the missing ones. It must be called with subscriptionsMutex held.
+func (f *FanoutEventHandler) setSequencers(subs []Subscription) {
+	sequencers := make(map[types.UID]*sequencer)
+	for _, sub := range subs {
+		if !sub.Ordered || sub.UID == "" {
+			continue
+		}
+		if s, ok := f.sequencers[sub.UID]; ok {
+			sequencers[sub.UID] = s
+		} else {
+			sequencers[sub.UID] = newSequencer()
+		}
+	}
+	f.sequencers = sequencers
+}
+
+// takeTurns takes the turns of the event for the ordered subscriptions among
+// subs, the turns are nil for the other subscriptions. It must be called in
+// the order the events are received.
+func (f *FanoutEventHandler) takeTurns(subs []Subscription) []*turn {
+	f.subscriptionsMutex.RLock()
+	defer f.subscriptionsMutex.RUnlock()
+	if len(f.sequencers) == 0 {
+		return nil
+	}
+	turns := make([]*turn, len(subs))
+	for i, sub := range subs {
+		if s, ok := f.sequencers[sub.UID]; ok && sub.Ordered {
+			turns[i] = s.take()
+		}
+	}
+	return turns
+}
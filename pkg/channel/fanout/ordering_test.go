@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/injection"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func TestSequencer(t *testing.T) {
+	s := newSequencer()
+
+	first := s.take()
+	second := s.take()
+
+	// The first turn starts right away.
+	first.wait()
+
+	started := make(chan struct{})
+	go func() {
+		second.wait()
+		close(started)
+	}()
+	select {
+	case <-started:
+		t.Fatal("The second turn started before the first one was done")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	first.done()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The second turn didn't start once the first one was done")
+	}
+	second.done()
+
+	// nil turns are no-ops.
+	var none *turn
+	none.wait()
+	none.done()
+}
+
+func TestSubscriberSpecToFanoutConfigOrdered(t *testing.T) {
+	ordered := eventingduckv1.DeliveryOrderOrdered
+	unordered := eventingduckv1.DeliveryOrderUnordered
+
+	for order, want := range map[*eventingduckv1.DeliveryOrderType]bool{nil: false, &ordered: true, &unordered: false} {
+		got, err := SubscriberSpecToFanoutConfig(eventingduckv1.SubscriberSpec{
+			SubscriberURI: apis.HTTP("subscriber.example.com"),
+			Delivery:      &eventingduckv1.DeliverySpec{Ordering: order},
+		})
+		if err != nil {
+			t.Fatal("SubscriberSpecToFanoutConfig failed =", err)
+		}
+		if got.Ordered != want {
+			t.Errorf("Ordered = %t, want %t", got.Ordered, want)
+		}
+	}
+}
+
+func TestFanoutEventHandler_OrderedDelivery(t *testing.T) {
+	ctx := context.Background()
+	ctx, _ = fakekubeclient.With(ctx)
+	ctx = injection.WithConfig(ctx, &rest.Config{})
+
+	const events = 10
+
+	var mu sync.Mutex
+	var received []string
+	inFlight := 0
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e, err := binding.ToEvent(r.Context(), cehttp.NewMessageFromHttpRequest(r))
+		if err != nil {
+			t.Error("Unable to read the event:", err)
+		}
+
+		mu.Lock()
+		if inFlight++; inFlight > 1 {
+			t.Error("Events delivered concurrently to an ordered subscription")
+		}
+		mu.Unlock()
+
+		// Let the next events wait for their turn.
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		received = append(received, e.ID())
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer subscriber.Close()
+
+	h, err := NewFanoutEventHandler(
+		zap.NewNop(),
+		Config{Subscriptions: []Subscription{{
+			UID:        "sub-uid",
+			Subscriber: duckv1.Addressable{URL: apis.HTTP(subscriber.URL[7:])},
+			Ordered:    true,
+		}}},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+		nil,
+		nil,
+		nil,
+		kncloudevents.NewDispatcher(eventingtls.NewDefaultClientConfig(), auth.NewOIDCTokenProvider(ctx)),
+	)
+	if err != nil {
+		t.Fatal("NewFanoutEventHandler failed =", err)
+	}
+
+	// The turns are taken in the order the events are received, while the
+	// deliveries run concurrently.
+	subs := h.GetSubscriptions(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < events; i++ {
+		e := makeCloudEvent()
+		e.SetID(fmt.Sprint(i))
+		turns := h.takeTurns(subs)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r := h.dispatchInTurns(ctx, subs, turns, e, http.Header{}); r.err != nil {
+				t.Error("Dispatch failed =", r.err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(received) != events {
+		t.Fatalf("Expected %d events, got %v", events, received)
+	}
+	for i, id := range received {
+		if id != fmt.Sprint(i) {
+			t.Fatalf("Expected the events in order, got %v", received)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+const (
+	retryStateFileSuffix = ".json"
+)
+
+// RetryState persists the deliveries to subscribers that are being retried
+// in a directory, so that a dispatcher restarted with the same directory
+// resumes them, with the attempts already made, instead of dropping them.
+//
+// A delivery is only written once it is retried, deliveries succeeding on the
+// first attempt never touch the disk. It is safe for concurrent use and is
+// meant to be shared by all the handlers of a dispatcher.
+type RetryState struct {
+	dir    string
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// active holds the keys of the deliveries in flight in this process.
+	active map[string]struct{}
+}
+
+// pendingDelivery is the persisted state of a delivery being retried.
+type pendingDelivery struct {
+	Subscription types.UID      `json:"subscription"`
+	Event        event.Event    `json:"event"`
+	Header       nethttp.Header `json:"header,omitempty"`
+	// Attempts is the number of attempts made to deliver the event to the
+	// subscriber.
+	Attempts int `json:"attempts"`
+}
+
+// NewRetryState creates a RetryState persisting the deliveries in dir,
+// creating it if needed.
+func NewRetryState(logger *zap.Logger, dir string) (*RetryState, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create retry state directory: %w", err)
+	}
+	return &RetryState{
+		dir:    dir,
+		logger: logger,
+		active: make(map[string]struct{}),
+	}, nil
+}
+
+// track starts tracking the delivery of e to the subscription sub, after
+// attempts attempts were already made. It returns nil when the delivery is
+// already in flight.
+func (s *RetryState) track(sub types.UID, e event.Event, header nethttp.Header, attempts int) *trackedDelivery {
+	key := retryStateKey(sub, e)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.active[key]; ok {
+		return nil
+	}
+	s.active[key] = struct{}{}
+	return &trackedDelivery{
+		state:   s,
+		key:     key,
+		written: attempts > 0,
+		pending: pendingDelivery{
+			Subscription: sub,
+			Event:        e,
+			Header:       header,
+			Attempts:     attempts,
+		},
+	}
+}
+
+// claim returns the persisted deliveries to the subscription sub that are not
+// in flight, and starts tracking them.
+func (s *RetryState) claim(sub types.UID) []*trackedDelivery {
+	paths, err := filepath.Glob(filepath.Join(s.dir, string(sub)+".*"+retryStateFileSuffix))
+	if err != nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var claimed []*trackedDelivery
+	for _, path := range paths {
+		key := strings.TrimSuffix(filepath.Base(path), retryStateFileSuffix)
+		if _, ok := s.active[key]; ok {
+			continue
+		}
+		d := &trackedDelivery{state: s, key: key, written: true}
+		if err := readPendingDelivery(path, &d.pending); err != nil {
+			// Don't keep a file we can't resume from forever.
+			s.logger.Warn("Dropping unreadable retry state", zap.String("path", path), zap.Error(err))
+			_ = os.Remove(path)
+			continue
+		}
+		s.active[key] = struct{}{}
+		claimed = append(claimed, d)
+	}
+	return claimed
+}
+
+func (s *RetryState) path(key string) string {
+	return filepath.Join(s.dir, key+retryStateFileSuffix)
+}
+
+// write atomically replaces the persisted state of a delivery.
+func (s *RetryState) write(key string, pending *pendingDelivery) error {
+	b, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+func readPendingDelivery(path string, pending *pendingDelivery) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, pending); err != nil {
+		return err
+	}
+	return pending.Event.Validate()
+}
+
+// retryStateKey identifies the delivery of an event to a subscription, it is
+// the name of the file holding its state without suffix.
+func retryStateKey(sub types.UID, e event.Event) string {
+	h := sha256.Sum256([]byte(e.Source() + "\x00" + e.ID()))
+	return string(sub) + "." + hex.EncodeToString(h[:])
+}
+
+// trackedDelivery is a delivery tracked by a RetryState.
+type trackedDelivery struct {
+	state   *RetryState
+	key     string
+	written bool
+	pending pendingDelivery
+}
+
+// attempts is the number of attempts already made.
+func (d *trackedDelivery) attempts() int {
+	return d.pending.Attempts
+}
+
+// observe is a kncloudevents attempt observer persisting the delivery once it
+// is retried.
+func (d *trackedDelivery) observe(attempt int) {
+	attempts := d.pending.Attempts + attempt
+	if attempts == 0 || (d.written && attempt == 0) {
+		return
+	}
+	p := d.pending
+	p.Attempts = attempts
+	if err := d.state.write(d.key, &p); err != nil {
+		d.state.logger.Warn("Failed to persist retry state", zap.String("subscription", string(p.Subscription)), zap.Error(err))
+		return
+	}
+	d.written = true
+}
+
+// done stops tracking the delivery, removing its persisted state.
+func (d *trackedDelivery) done() {
+	if d.written {
+		if err := os.Remove(d.state.path(d.key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			d.state.logger.Warn("Failed to remove retry state", zap.String("subscription", string(d.pending.Subscription)), zap.Error(err))
+		}
+	}
+	d.state.mu.Lock()
+	delete(d.state.active, d.key)
+	d.state.mu.Unlock()
+}
+
+// resumeRetryConfig returns the retry config to use for a delivery after
+// attempts attempts were already made, that is with as many retries less and
+// the backoff continuing where it stopped.
+func resumeRetryConfig(rc *kncloudevents.RetryConfig, attempts int) *kncloudevents.RetryConfig {
+	if rc == nil || attempts == 0 {
+		return rc
+	}
+	resumed := *rc
+	resumed.RetryMax -= attempts
+	if resumed.RetryMax < 0 {
+		resumed.RetryMax = 0
+	}
+	if rc.Backoff != nil {
+		resumed.Backoff = func(attemptNum int, resp *nethttp.Response) time.Duration {
+			return rc.Backoff(attemptNum+attempts, resp)
+		}
+	}
+	return &resumed
+}
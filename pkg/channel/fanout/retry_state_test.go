@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/injection"
+
+	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/channel"
+	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+func retryStateFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+retryStateFileSuffix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestRetryState(t *testing.T) {
+	const uid = types.UID("sub-uid")
+	dir := t.TempDir()
+	s, err := NewRetryState(zap.NewNop(), dir)
+	if err != nil {
+		t.Fatal("NewRetryState failed =", err)
+	}
+
+	d := s.track(uid, makeCloudEvent(), http.Header{"Foo": {"bar"}}, 0)
+	if d == nil {
+		t.Fatal("Expected the delivery to be tracked")
+	}
+	if s.track(uid, makeCloudEvent(), nil, 0) != nil {
+		t.Error("Expected a delivery in flight not to be tracked twice")
+	}
+
+	d.observe(0)
+	if files := retryStateFiles(t, dir); len(files) != 0 {
+		t.Errorf("Expected the first attempt not to be persisted, got %v", files)
+	}
+	d.observe(1)
+	d.observe(2)
+	if files := retryStateFiles(t, dir); len(files) != 1 {
+		t.Fatalf("Expected the retried delivery to be persisted, got %v", files)
+	}
+	if claimed := s.claim(uid); len(claimed) != 0 {
+		t.Errorf("Expected a delivery in flight not to be claimed, got %d", len(claimed))
+	}
+
+	// Another process using the same directory resumes the delivery.
+	other, err := NewRetryState(zap.NewNop(), dir)
+	if err != nil {
+		t.Fatal("NewRetryState failed =", err)
+	}
+	claimed := other.claim(uid)
+	if len(claimed) != 1 {
+		t.Fatalf("Expected one delivery to be claimed, got %d", len(claimed))
+	}
+	if got := claimed[0].attempts(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+	if got := claimed[0].pending.Header.Get("Foo"); got != "bar" {
+		t.Errorf("Expected the header to be persisted, got %q", got)
+	}
+	if got := claimed[0].pending.Event.ID(); got != makeCloudEvent().ID() {
+		t.Errorf("Expected event %q, got %q", makeCloudEvent().ID(), got)
+	}
+	if len(other.claim(uid)) != 0 || len(other.claim("other-uid")) != 0 {
+		t.Error("Expected nothing else to be claimed")
+	}
+
+	claimed[0].done()
+	d.done()
+	if files := retryStateFiles(t, dir); len(files) != 0 {
+		t.Errorf("Expected the done delivery to be removed, got %v", files)
+	}
+}
+
+func TestRetryStateDropsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub-uid.bad"+retryStateFileSuffix)
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewRetryState(zap.NewNop(), dir)
+	if err != nil {
+		t.Fatal("NewRetryState failed =", err)
+	}
+	if claimed := s.claim("sub-uid"); len(claimed) != 0 {
+		t.Errorf("Expected nothing to be claimed, got %d", len(claimed))
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the unreadable file to be removed, got %v", err)
+	}
+}
+
+func TestResumeRetryConfig(t *testing.T) {
+	rc := &kncloudevents.RetryConfig{
+		RetryMax: 3,
+		Backoff: func(attemptNum int, _ *http.Response) time.Duration {
+			return time.Duration(attemptNum) * time.Second
+		},
+	}
+
+	if got := resumeRetryConfig(rc, 0); got != rc {
+		t.Error("Expected the retry config to be unchanged without attempts")
+	}
+	if got := resumeRetryConfig(nil, 2); got != nil {
+		t.Errorf("Expected no retry config, got %+v", got)
+	}
+
+	got := resumeRetryConfig(rc, 2)
+	if got.RetryMax != 1 {
+		t.Errorf("Expected 1 retry left, got %d", got.RetryMax)
+	}
+	if d := got.Backoff(1, nil); d != 3*time.Second {
+		t.Errorf("Expected the backoff to continue, got %v", d)
+	}
+	if got := resumeRetryConfig(rc, 5); got.RetryMax != 0 {
+		t.Errorf("Expected no retry left, got %d", got.RetryMax)
+	}
+}
+
+func TestFanoutEventHandler_ResumesPendingDeliveries(t *testing.T) {
+	ctx := context.Background()
+	ctx, _ = fakekubeclient.With(ctx)
+	ctx = injection.WithConfig(ctx, &rest.Config{})
+
+	var requests atomic.Int32
+	received := make(chan struct{}, 10)
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		received <- struct{}{}
+	}))
+	defer subscriber.Close()
+
+	sub := Subscription{
+		UID:        "sub-uid",
+		Subscriber: duckv1.Addressable{URL: apis.HTTP(subscriber.URL[7:])},
+		RetryConfig: &kncloudevents.RetryConfig{
+			RetryMax:   3,
+			CheckRetry: kncloudevents.SelectiveRetry,
+			Backoff: func(int, *http.Response) time.Duration {
+				return time.Millisecond
+			},
+		},
+	}
+
+	// A previous dispatcher made two attempts before stopping.
+	dir := t.TempDir()
+	previous, err := NewRetryState(zap.NewNop(), dir)
+	if err != nil {
+		t.Fatal("NewRetryState failed =", err)
+	}
+	previous.track(sub.UID, makeCloudEvent(), http.Header{}, 0).observe(2)
+
+	s, err := NewRetryState(zap.NewNop(), dir)
+	if err != nil {
+		t.Fatal("NewRetryState failed =", err)
+	}
+	_, err = NewFanoutEventHandler(
+		zap.NewNop(),
+		Config{Subscriptions: []Subscription{sub}, RetryState: s},
+		channel.NewStatsReporter("testcontainer", "testpod"),
+		nil,
+		nil,
+		nil,
+		kncloudevents.NewDispatcher(eventingtls.NewDefaultClientConfig(), auth.NewOIDCTokenProvider(ctx)),
+	)
+	if err != nil {
+		t.Fatal("NewFanoutEventHandler failed =", err)
+	}
+
+	// The remaining two attempts are made.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-received:
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for the delivery to be resumed")
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(retryStateFiles(t, dir)) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the delivery to be removed once done")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 attempts, got %d", got)
+	}
+}
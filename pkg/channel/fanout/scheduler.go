@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DispatchScheduler bounds the deliveries in flight in a dispatcher shared
+// by many channels. Once the capacity is reached, the deliveries wait for a
+// slot, which are handed to the waiting channels in weighted round-robin
+// order, so that a channel receiving many events can't starve the others.
+type DispatchScheduler struct {
+	capacity int
+
+	mu       sync.Mutex
+	inFlight int
+	channels map[types.NamespacedName]*channelQueue
+	// ring holds the channels with waiting deliveries, in round-robin order.
+	ring []types.NamespacedName
+	// next is the index in ring of the channel the next slot is handed to.
+	next int
+}
+
+// channelQueue holds the state of a channel in a DispatchScheduler.
+type channelQueue struct {
+	weight int
+	// credit is the number of slots the channel may still be handed in the
+	// current round.
+	credit   int
+	inFlight int
+	waiters  []chan struct{}
+}
+
+// NewDispatchScheduler returns a scheduler letting capacity deliveries in
+// flight, nil when capacity isn't positive. A nil DispatchScheduler doesn't
+// bound the deliveries.
+func NewDispatchScheduler(capacity int) *DispatchScheduler {
+	if capacity <= 0 {
+		return nil
+	}
+	return &DispatchScheduler{
+		capacity: capacity,
+		channels: make(map[types.NamespacedName]*channelQueue),
+	}
+}
+
+// SetWeight sets the share of the slots handed to the channel, relative to
+// the other channels, when deliveries are waiting.
+func (s *DispatchScheduler) SetWeight(channel types.NamespacedName, weight int) {
+	if s == nil {
+		return
+	}
+	if weight < 1 {
+		weight = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.queue(channel)
+	q.weight = weight
+	if len(q.waiters) == 0 || q.credit > weight {
+		q.credit = weight
+	}
+}
+
+// Weight returns the weight of the channel, 1 unless set otherwise.
+func (s *DispatchScheduler) Weight(channel types.NamespacedName) int {
+	if s == nil {
+		return 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if q, ok := s.channels[channel]; ok {
+		return q.weight
+	}
+	return 1
+}
+
+// Forget drops the state of a deleted channel. Its deliveries in flight or
+// waiting are unaffected.
+func (s *DispatchScheduler) Forget(channel types.NamespacedName) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if q, ok := s.channels[channel]; ok && q.inFlight == 0 && len(q.waiters) == 0 {
+		delete(s.channels, channel)
+		reportDispatchQueue(channel, &channelQueue{})
+	}
+}
+
+// Acquire waits for a slot to deliver an event of the channel, until ctx is
+// done. The returned function releases the slot, it must be called once the
+// delivery completes.
+func (s *DispatchScheduler) Acquire(ctx context.Context, channel types.NamespacedName) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+
+	s.mu.Lock()
+	q := s.queue(channel)
+	if s.inFlight < s.capacity && len(s.ring) == 0 {
+		s.grant(q)
+		reportDispatchQueue(channel, q)
+		s.mu.Unlock()
+		reportDispatchWait(channel, 0)
+		return s.releaseFunc(channel), nil
+	}
+
+	start := time.Now()
+	waiter := make(chan struct{})
+	q.waiters = append(q.waiters, waiter)
+	if len(q.waiters) == 1 {
+		s.ring = append(s.ring, channel)
+	}
+	reportDispatchQueue(channel, q)
+	s.mu.Unlock()
+
+	select {
+	case <-waiter:
+		reportDispatchWait(channel, time.Since(start))
+		return s.releaseFunc(channel), nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-waiter:
+		// The slot was handed over concurrently, give it back.
+		s.release(channel)
+	default:
+		s.removeWaiter(channel, q, waiter)
+		reportDispatchQueue(channel, q)
+	}
+	return nil, ctx.Err()
+}
+
+func (s *DispatchScheduler) releaseFunc(channel types.NamespacedName) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.release(channel)
+		})
+	}
+}
+
+// queue returns the state of the channel, creating it when needed. It must
+// be called with mu held.
+func (s *DispatchScheduler) queue(channel types.NamespacedName) *channelQueue {
+	q, ok := s.channels[channel]
+	if !ok {
+		q = &channelQueue{weight: 1, credit: 1}
+		s.channels[channel] = q
+	}
+	return q
+}
+
+// grant hands a slot to the channel. It must be called with mu held.
+func (s *DispatchScheduler) grant(q *channelQueue) {
+	s.inFlight++
+	q.inFlight++
+}
+
+// release gives the slot of a delivery of the channel back, and hands the
+// free slots to the waiting deliveries. It must be called with mu held.
+func (s *DispatchScheduler) release(channel types.NamespacedName) {
+	s.inFlight--
+	q := s.queue(channel)
+	q.inFlight--
+	reportDispatchQueue(channel, q)
+
+	for s.inFlight < s.capacity && len(s.ring) > 0 {
+		if s.next >= len(s.ring) {
+			s.next = 0
+		}
+		name := s.ring[s.next]
+		next := s.channels[name]
+
+		waiter := next.waiters[0]
+		next.waiters = next.waiters[1:]
+		next.credit--
+		s.grant(next)
+		close(waiter)
+
+		if len(next.waiters) == 0 {
+			// The channel has nothing left to deliver, it leaves the ring
+			// with a full credit for the next time it waits.
+			next.credit = next.weight
+			s.ring = append(s.ring[:s.next], s.ring[s.next+1:]...)
+		} else if next.credit <= 0 {
+			next.credit = next.weight
+			s.next++
+		}
+		reportDispatchQueue(name, next)
+	}
+}
+
+// removeWaiter removes a waiter that gave up. It must be called with mu
+// held.
+func (s *DispatchScheduler) removeWaiter(channel types.NamespacedName, q *channelQueue, waiter chan struct{}) {
+	for i, w := range q.waiters {
+		if w == waiter {
+			q.waiters = append(q.waiters[:i], q.waiters[i+1:]...)
+			break
+		}
+	}
+	if len(q.waiters) > 0 {
+		return
+	}
+	q.credit = q.weight
+	for i, name := range s.ring {
+		if name == channel {
+			s.ring = append(s.ring[:i], s.ring[i+1:]...)
+			if i < s.next {
+				s.next--
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics"
+
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+const (
+	// LabelChannelName is the label for the name of the channel.
+	LabelChannelName = "channel_name"
+)
+
+var (
+	// dispatchInFlightM records the number of deliveries of a channel in
+	// flight.
+	dispatchInFlightM = stats.Int64(
+		"channel_dispatch_in_flight",
+		"Number of deliveries of the channel in flight",
+		stats.UnitDimensionless,
+	)
+
+	// dispatchQueuedM records the number of deliveries of a channel waiting
+	// for the dispatcher capacity, which is non zero when the dispatcher is
+	// saturated.
+	dispatchQueuedM = stats.Int64(
+		"channel_dispatch_queued",
+		"Number of deliveries of the channel waiting for the dispatcher capacity",
+		stats.UnitDimensionless,
+	)
+
+	// dispatchWaitM records the time the deliveries of a channel waited for
+	// the dispatcher capacity.
+	dispatchWaitM = stats.Float64(
+		"channel_dispatch_wait_time",
+		"Time the deliveries of the channel waited for the dispatcher capacity",
+		stats.UnitMilliseconds,
+	)
+
+	namespaceKey   = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+	channelNameKey = tag.MustNewKey(LabelChannelName)
+)
+
+func init() {
+	registerSchedulerViews()
+}
+
+func registerSchedulerViews() {
+	tagKeys := []tag.Key{namespaceKey, channelNameKey}
+	if err := view.Register(
+		&view.View{
+			Description: dispatchInFlightM.Description(),
+			Measure:     dispatchInFlightM,
+			Aggregation: view.LastValue(),
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: dispatchQueuedM.Description(),
+			Measure:     dispatchQueuedM,
+			Aggregation: view.LastValue(),
+			TagKeys:     tagKeys,
+		},
+		&view.View{
+			Description: dispatchWaitM.Description(),
+			Measure:     dispatchWaitM,
+			Aggregation: view.Distribution(metrics.Buckets125(1, 10000)...),
+			TagKeys:     tagKeys,
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+func channelTags(channel types.NamespacedName) (context.Context, error) {
+	return tag.New(context.Background(),
+		tag.Insert(namespaceKey, channel.Namespace),
+		tag.Insert(channelNameKey, channel.Name))
+}
+
+func reportDispatchQueue(channel types.NamespacedName, q *channelQueue) {
+	ctx, err := channelTags(channel)
+	if err != nil {
+		return
+	}
+	metrics.RecordBatch(ctx, dispatchInFlightM.M(int64(q.inFlight)), dispatchQueuedM.M(int64(len(q.waiters))))
+}
+
+func reportDispatchWait(channel types.NamespacedName, wait time.Duration) {
+	ctx, err := channelTags(channel)
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, dispatchWaitM.M(float64(wait)/float64(time.Millisecond)))
+}
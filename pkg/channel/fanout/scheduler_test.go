@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+var (
+	hotChannel  = types.NamespacedName{Namespace: "ns", Name: "hot"}
+	coldChannel = types.NamespacedName{Namespace: "ns", Name: "cold"}
+)
+
+type grant struct {
+	channel types.NamespacedName
+	release func()
+}
+
+// queueDelivery starts acquiring a slot for the channel in the background,
+// and waits until the delivery waits in the scheduler.
+func queueDelivery(t *testing.T, s *DispatchScheduler, channel types.NamespacedName, grants chan<- grant) {
+	t.Helper()
+	s.mu.Lock()
+	want := len(s.queue(channel).waiters) + 1
+	s.mu.Unlock()
+
+	go func() {
+		release, err := s.Acquire(context.Background(), channel)
+		if err != nil {
+			t.Error("Acquire() =", err)
+			return
+		}
+		grants <- grant{channel: channel, release: release}
+	}()
+
+	if err := waitFor(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.queue(channel).waiters) == want
+	}); err != nil {
+		t.Fatal("Delivery never queued")
+	}
+}
+
+func waitFor(cond func() bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for !cond() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+	return nil
+}
+
+func TestDispatchSchedulerWeightedRoundRobin(t *testing.T) {
+	s := NewDispatchScheduler(1)
+	s.SetWeight(hotChannel, 2)
+	s.SetWeight(coldChannel, 1)
+	if got := s.Weight(hotChannel); got != 2 {
+		t.Fatalf("Weight() = %d, want 2", got)
+	}
+
+	release, err := s.Acquire(context.Background(), hotChannel)
+	if err != nil {
+		t.Fatal("Acquire() =", err)
+	}
+
+	grants := make(chan grant, 10)
+	for i := 0; i < 4; i++ {
+		queueDelivery(t, s, hotChannel, grants)
+	}
+	for i := 0; i < 2; i++ {
+		queueDelivery(t, s, coldChannel, grants)
+	}
+
+	release()
+	var got []string
+	for i := 0; i < 6; i++ {
+		select {
+		case g := <-grants:
+			got = append(got, g.channel.Name)
+			g.release()
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a slot, got", got)
+		}
+	}
+
+	want := []string{"hot", "hot", "cold", "hot", "hot", "cold"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Slots handed in order %v, want %v", got, want)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight != 0 || len(s.ring) != 0 {
+		t.Errorf("inFlight = %d, ring = %v, want no delivery left", s.inFlight, s.ring)
+	}
+}
+
+func TestDispatchSchedulerCancel(t *testing.T) {
+	s := NewDispatchScheduler(1)
+	release, err := s.Acquire(context.Background(), hotChannel)
+	if err != nil {
+		t.Fatal("Acquire() =", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx, coldChannel); err == nil {
+		t.Fatal("Acquire() = nil, want the context error")
+	}
+
+	s.mu.Lock()
+	if len(s.ring) != 0 || len(s.queue(coldChannel).waiters) != 0 {
+		t.Errorf("ring = %v, want the canceled delivery removed", s.ring)
+	}
+	s.mu.Unlock()
+
+	release()
+	// Releasing twice is a no-op.
+	release()
+	if release, err = s.Acquire(context.Background(), coldChannel); err != nil {
+		t.Fatal("Acquire() =", err)
+	}
+	release()
+}
+
+func TestDispatchSchedulerNil(t *testing.T) {
+	s := NewDispatchScheduler(0)
+	if s != nil {
+		t.Fatal("NewDispatchScheduler(0) =", s)
+	}
+	s.SetWeight(hotChannel, 2)
+	s.Forget(hotChannel)
+	release, err := s.Acquire(context.Background(), hotChannel)
+	if err != nil {
+		t.Fatal("Acquire() =", err)
+	}
+	release()
+}
+
+func TestDispatchSchedulerMetrics(t *testing.T) {
+	metricstest.Unregister("channel_dispatch_in_flight", "channel_dispatch_queued", "channel_dispatch_wait_time")
+	registerSchedulerViews()
+
+	s := NewDispatchScheduler(1)
+	release, err := s.Acquire(context.Background(), hotChannel)
+	if err != nil {
+		t.Fatal("Acquire() =", err)
+	}
+	tags := map[string]string{
+		eventingmetrics.LabelNamespaceName: "ns",
+		LabelChannelName:                   "hot",
+	}
+	metricstest.CheckLastValueData(t, "channel_dispatch_in_flight", tags, 1)
+	metricstest.CheckLastValueData(t, "channel_dispatch_queued", tags, 0)
+
+	release()
+	metricstest.CheckLastValueData(t, "channel_dispatch_in_flight", tags, 0)
+	metricstest.CheckDistributionCount(t, "channel_dispatch_wait_time", tags, 1)
+}
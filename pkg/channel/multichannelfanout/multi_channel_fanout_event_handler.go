@@ -132,6 +132,12 @@ func (h *EventHandler) ServeHTTP(response http.ResponseWriter, request *http.Req
 	}
 
 	fh := h.GetChannelHandler(channelKey)
+	if fh == nil && request.URL.Path == "/" {
+		// The Host header may include the port, handlers are registered
+		// by host name.
+		channelKey = channel.HostWithoutPort(channelKey)
+		fh = h.GetChannelHandler(channelKey)
+	}
 	if fh == nil {
 		h.logger.Info("Unable to find a handler for request", zap.String("channelKey", channelKey))
 		response.WriteHeader(http.StatusInternalServerError)
@@ -298,6 +298,27 @@ func TestServeHTTPEventHandler(t *testing.T) {
 			hostKey:            "second-channel.default",
 			expectedStatusCode: http.StatusAccepted,
 		},
+		"host with port": {
+			config: Config{
+				ChannelConfigs: []ChannelConfig{
+					{
+						Namespace: "default",
+						Name:      "first-channel",
+						HostName:  "first-channel.default",
+						FanoutConfig: fanout.Config{
+							Subscriptions: []fanout.Subscription{
+								{
+									Subscriber: replaceDomain,
+								},
+							},
+						},
+					},
+				},
+			},
+			respStatusCode:     http.StatusOK,
+			hostKey:            "first-channel.default:8080",
+			expectedStatusCode: http.StatusAccepted,
+		},
 		"path based": {
 			config: Config{
 				ChannelConfigs: []ChannelConfig{
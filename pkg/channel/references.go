@@ -18,6 +18,7 @@ package channel
 
 import (
 	"fmt"
+	"net"
 	"strings"
 )
 
@@ -34,7 +35,7 @@ func (r *ChannelReference) String() string {
 
 // ParseChannelFromHost determines a Channel reference from a host
 func ParseChannelFromHost(host string) (ChannelReference, error) {
-	chunks := strings.Split(host, ".")
+	chunks := strings.Split(HostWithoutPort(host), ".")
 	if len(chunks) < 2 {
 		return ChannelReference{}, BadRequestError(fmt.Sprintf("bad host format %q", host))
 	}
@@ -56,3 +57,12 @@ func ParseChannelFromPath(path string) (ChannelReference, error) {
 		Name:      splitPath[2],
 	}, nil
 }
+
+// HostWithoutPort returns the host of a Host header without its port, if
+// any, and without the brackets enclosing IPv6 literals.
+func HostWithoutPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.Trim(host, "[]")
+}
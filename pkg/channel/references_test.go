@@ -54,10 +54,22 @@ func TestParseChannelFromHost(t *testing.T) {
 				Name:      "test-channel",
 			},
 		},
+		"host with port": {
+			host:    "test-channel.test-namespace.svc.cluster.local:8080",
+			wantErr: false,
+			expectedChannelRef: ChannelReference{
+				Namespace: "test-namespace",
+				Name:      "test-channel",
+			},
+		},
 		"bad host format should return error": {
 			host:    "test-channel",
 			wantErr: true,
 		},
+		"IPv6 literal should return error": {
+			host:    "[fd00::1]:8080",
+			wantErr: true,
+		},
 	}
 
 	for n, tc := range testCases {
@@ -128,3 +140,23 @@ func TestParseChannelFromPath(t *testing.T) {
 		})
 	}
 }
+
+func TestHostWithoutPort(t *testing.T) {
+	testCases := map[string]string{
+		"test-channel.test-namespace.svc":      "test-channel.test-namespace.svc",
+		"test-channel.test-namespace.svc:8080": "test-channel.test-namespace.svc",
+		"10.0.0.1":                             "10.0.0.1",
+		"10.0.0.1:8080":                        "10.0.0.1",
+		"[fd00::1]:8080":                       "fd00::1",
+		"[fd00::1]":                            "fd00::1",
+		"fd00::1":                              "fd00::1",
+	}
+
+	for host, want := range testCases {
+		t.Run(host, func(t *testing.T) {
+			if got := HostWithoutPort(host); got != want {
+				t.Errorf("HostWithoutPort(%q) = %q, want %q", host, got, want)
+			}
+		})
+	}
+}
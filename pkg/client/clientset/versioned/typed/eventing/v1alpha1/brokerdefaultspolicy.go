@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	scheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
+)
+
+// BrokerDefaultsPoliciesGetter has a method to return a BrokerDefaultsPolicyInterface.
+// A group's client should implement this interface.
+type BrokerDefaultsPoliciesGetter interface {
+	BrokerDefaultsPolicies() BrokerDefaultsPolicyInterface
+}
+
+// BrokerDefaultsPolicyInterface has methods to work with BrokerDefaultsPolicy resources.
+type BrokerDefaultsPolicyInterface interface {
+	Create(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.CreateOptions) (*v1alpha1.BrokerDefaultsPolicy, error)
+	Update(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (*v1alpha1.BrokerDefaultsPolicy, error)
+	UpdateStatus(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (*v1alpha1.BrokerDefaultsPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.BrokerDefaultsPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.BrokerDefaultsPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BrokerDefaultsPolicy, err error)
+	BrokerDefaultsPolicyExpansion
+}
+
+// brokerDefaultsPolicies implements BrokerDefaultsPolicyInterface
+type brokerDefaultsPolicies struct {
+	client rest.Interface
+}
+
+// newBrokerDefaultsPolicies returns a BrokerDefaultsPolicies
+func newBrokerDefaultsPolicies(c *EventingV1alpha1Client) *brokerDefaultsPolicies {
+	return &brokerDefaultsPolicies{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the brokerDefaultsPolicy, and returns the corresponding brokerDefaultsPolicy object, and an error if there is any.
+func (c *brokerDefaultsPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	result = &v1alpha1.BrokerDefaultsPolicy{}
+	err = c.client.Get().
+		Resource("brokerdefaultspolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BrokerDefaultsPolicies that match those selectors.
+func (c *brokerDefaultsPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.BrokerDefaultsPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.BrokerDefaultsPolicyList{}
+	err = c.client.Get().
+		Resource("brokerdefaultspolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested brokerDefaultsPolicies.
+func (c *brokerDefaultsPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("brokerdefaultspolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a brokerDefaultsPolicy and creates it.  Returns the server's representation of the brokerDefaultsPolicy, and an error, if there is any.
+func (c *brokerDefaultsPolicies) Create(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.CreateOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	result = &v1alpha1.BrokerDefaultsPolicy{}
+	err = c.client.Post().
+		Resource("brokerdefaultspolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(brokerDefaultsPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a brokerDefaultsPolicy and updates it. Returns the server's representation of the brokerDefaultsPolicy, and an error, if there is any.
+func (c *brokerDefaultsPolicies) Update(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	result = &v1alpha1.BrokerDefaultsPolicy{}
+	err = c.client.Put().
+		Resource("brokerdefaultspolicies").
+		Name(brokerDefaultsPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(brokerDefaultsPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *brokerDefaultsPolicies) UpdateStatus(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	result = &v1alpha1.BrokerDefaultsPolicy{}
+	err = c.client.Put().
+		Resource("brokerdefaultspolicies").
+		Name(brokerDefaultsPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(brokerDefaultsPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the brokerDefaultsPolicy and deletes it. Returns an error if one occurs.
+func (c *brokerDefaultsPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("brokerdefaultspolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *brokerDefaultsPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("brokerdefaultspolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched brokerDefaultsPolicy.
+func (c *brokerDefaultsPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	result = &v1alpha1.BrokerDefaultsPolicy{}
+	err = c.client.Patch(pt).
+		Resource("brokerdefaultspolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -28,7 +28,11 @@ import (
 
 type EventingV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	BrokerDefaultsPoliciesGetter
+	EventEmissionsGetter
 	EventPoliciesGetter
+	KReferenceMappingsGetter
+	RedactionPoliciesGetter
 }
 
 // EventingV1alpha1Client is used to interact with features provided by the eventing.knative.dev group.
@@ -36,10 +40,26 @@ type EventingV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *EventingV1alpha1Client) BrokerDefaultsPolicies() BrokerDefaultsPolicyInterface {
+	return newBrokerDefaultsPolicies(c)
+}
+
+func (c *EventingV1alpha1Client) EventEmissions(namespace string) EventEmissionInterface {
+	return newEventEmissions(c, namespace)
+}
+
 func (c *EventingV1alpha1Client) EventPolicies(namespace string) EventPolicyInterface {
 	return newEventPolicies(c, namespace)
 }
 
+func (c *EventingV1alpha1Client) KReferenceMappings() KReferenceMappingInterface {
+	return newKReferenceMappings(c)
+}
+
+func (c *EventingV1alpha1Client) RedactionPolicies(namespace string) RedactionPolicyInterface {
+	return newRedactionPolicies(c, namespace)
+}
+
 // NewForConfig creates a new EventingV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
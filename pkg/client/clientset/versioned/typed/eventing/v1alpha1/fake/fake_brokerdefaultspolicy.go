@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// FakeBrokerDefaultsPolicies implements BrokerDefaultsPolicyInterface
+type FakeBrokerDefaultsPolicies struct {
+	Fake *FakeEventingV1alpha1
+}
+
+var brokerdefaultspoliciesResource = v1alpha1.SchemeGroupVersion.WithResource("brokerdefaultspolicies")
+
+var brokerdefaultspoliciesKind = v1alpha1.SchemeGroupVersion.WithKind("BrokerDefaultsPolicy")
+
+// Get takes name of the brokerDefaultsPolicy, and returns the corresponding brokerDefaultsPolicy object, and an error if there is any.
+func (c *FakeBrokerDefaultsPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(brokerdefaultspoliciesResource, name), &v1alpha1.BrokerDefaultsPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BrokerDefaultsPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of BrokerDefaultsPolicies that match those selectors.
+func (c *FakeBrokerDefaultsPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.BrokerDefaultsPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(brokerdefaultspoliciesResource, brokerdefaultspoliciesKind, opts), &v1alpha1.BrokerDefaultsPolicyList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.BrokerDefaultsPolicyList{ListMeta: obj.(*v1alpha1.BrokerDefaultsPolicyList).ListMeta}
+	for _, item := range obj.(*v1alpha1.BrokerDefaultsPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested brokerDefaultsPolicies.
+func (c *FakeBrokerDefaultsPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(brokerdefaultspoliciesResource, opts))
+}
+
+// Create takes the representation of a brokerDefaultsPolicy and creates it.  Returns the server's representation of the brokerDefaultsPolicy, and an error, if there is any.
+func (c *FakeBrokerDefaultsPolicies) Create(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.CreateOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(brokerdefaultspoliciesResource, brokerDefaultsPolicy), &v1alpha1.BrokerDefaultsPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BrokerDefaultsPolicy), err
+}
+
+// Update takes the representation of a brokerDefaultsPolicy and updates it. Returns the server's representation of the brokerDefaultsPolicy, and an error, if there is any.
+func (c *FakeBrokerDefaultsPolicies) Update(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(brokerdefaultspoliciesResource, brokerDefaultsPolicy), &v1alpha1.BrokerDefaultsPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BrokerDefaultsPolicy), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBrokerDefaultsPolicies) UpdateStatus(ctx context.Context, brokerDefaultsPolicy *v1alpha1.BrokerDefaultsPolicy, opts v1.UpdateOptions) (*v1alpha1.BrokerDefaultsPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(brokerdefaultspoliciesResource, "status", brokerDefaultsPolicy), &v1alpha1.BrokerDefaultsPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BrokerDefaultsPolicy), err
+}
+
+// Delete takes name of the brokerDefaultsPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeBrokerDefaultsPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(brokerdefaultspoliciesResource, name, opts), &v1alpha1.BrokerDefaultsPolicy{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeBrokerDefaultsPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(brokerdefaultspoliciesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.BrokerDefaultsPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched brokerDefaultsPolicy.
+func (c *FakeBrokerDefaultsPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.BrokerDefaultsPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(brokerdefaultspoliciesResource, name, pt, data, subresources...), &v1alpha1.BrokerDefaultsPolicy{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.BrokerDefaultsPolicy), err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// FakeEventEmissions implements EventEmissionInterface
+type FakeEventEmissions struct {
+	Fake *FakeEventingV1alpha1
+	ns   string
+}
+
+var eventemissionsResource = v1alpha1.SchemeGroupVersion.WithResource("eventemissions")
+
+var eventemissionsKind = v1alpha1.SchemeGroupVersion.WithKind("EventEmission")
+
+// Get takes name of the eventEmission, and returns the corresponding eventEmission object, and an error if there is any.
+func (c *FakeEventEmissions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.EventEmission, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(eventemissionsResource, c.ns, name), &v1alpha1.EventEmission{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventEmission), err
+}
+
+// List takes label and field selectors, and returns the list of EventEmissions that match those selectors.
+func (c *FakeEventEmissions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.EventEmissionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(eventemissionsResource, eventemissionsKind, c.ns, opts), &v1alpha1.EventEmissionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.EventEmissionList{ListMeta: obj.(*v1alpha1.EventEmissionList).ListMeta}
+	for _, item := range obj.(*v1alpha1.EventEmissionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested eventEmissions.
+func (c *FakeEventEmissions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(eventemissionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a eventEmission and creates it.  Returns the server's representation of the eventEmission, and an error, if there is any.
+func (c *FakeEventEmissions) Create(ctx context.Context, eventEmission *v1alpha1.EventEmission, opts v1.CreateOptions) (result *v1alpha1.EventEmission, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(eventemissionsResource, c.ns, eventEmission), &v1alpha1.EventEmission{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventEmission), err
+}
+
+// Update takes the representation of a eventEmission and updates it. Returns the server's representation of the eventEmission, and an error, if there is any.
+func (c *FakeEventEmissions) Update(ctx context.Context, eventEmission *v1alpha1.EventEmission, opts v1.UpdateOptions) (result *v1alpha1.EventEmission, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(eventemissionsResource, c.ns, eventEmission), &v1alpha1.EventEmission{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventEmission), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeEventEmissions) UpdateStatus(ctx context.Context, eventEmission *v1alpha1.EventEmission, opts v1.UpdateOptions) (*v1alpha1.EventEmission, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(eventemissionsResource, "status", c.ns, eventEmission), &v1alpha1.EventEmission{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventEmission), err
+}
+
+// Delete takes name of the eventEmission and deletes it. Returns an error if one occurs.
+func (c *FakeEventEmissions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(eventemissionsResource, c.ns, name, opts), &v1alpha1.EventEmission{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeEventEmissions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(eventemissionsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.EventEmissionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched eventEmission.
+func (c *FakeEventEmissions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.EventEmission, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(eventemissionsResource, c.ns, name, pt, data, subresources...), &v1alpha1.EventEmission{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.EventEmission), err
+}
@@ -28,10 +28,26 @@ type FakeEventingV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeEventingV1alpha1) BrokerDefaultsPolicies() v1alpha1.BrokerDefaultsPolicyInterface {
+	return &FakeBrokerDefaultsPolicies{c}
+}
+
+func (c *FakeEventingV1alpha1) EventEmissions(namespace string) v1alpha1.EventEmissionInterface {
+	return &FakeEventEmissions{c, namespace}
+}
+
 func (c *FakeEventingV1alpha1) EventPolicies(namespace string) v1alpha1.EventPolicyInterface {
 	return &FakeEventPolicies{c, namespace}
 }
 
+func (c *FakeEventingV1alpha1) KReferenceMappings() v1alpha1.KReferenceMappingInterface {
+	return &FakeKReferenceMappings{c}
+}
+
+func (c *FakeEventingV1alpha1) RedactionPolicies(namespace string) v1alpha1.RedactionPolicyInterface {
+	return &FakeRedactionPolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeEventingV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// FakeKReferenceMappings implements KReferenceMappingInterface
+type FakeKReferenceMappings struct {
+	Fake *FakeEventingV1alpha1
+}
+
+var kreferencemappingsResource = v1alpha1.SchemeGroupVersion.WithResource("kreferencemappings")
+
+var kreferencemappingsKind = v1alpha1.SchemeGroupVersion.WithKind("KReferenceMapping")
+
+// Get takes name of the kReferenceMapping, and returns the corresponding kReferenceMapping object, and an error if there is any.
+func (c *FakeKReferenceMappings) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(kreferencemappingsResource, name), &v1alpha1.KReferenceMapping{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KReferenceMapping), err
+}
+
+// List takes label and field selectors, and returns the list of KReferenceMappings that match those selectors.
+func (c *FakeKReferenceMappings) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KReferenceMappingList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(kreferencemappingsResource, kreferencemappingsKind, opts), &v1alpha1.KReferenceMappingList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KReferenceMappingList{ListMeta: obj.(*v1alpha1.KReferenceMappingList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KReferenceMappingList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested kReferenceMappings.
+func (c *FakeKReferenceMappings) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(kreferencemappingsResource, opts))
+}
+
+// Create takes the representation of a kReferenceMapping and creates it.  Returns the server's representation of the kReferenceMapping, and an error, if there is any.
+func (c *FakeKReferenceMappings) Create(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.CreateOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(kreferencemappingsResource, kReferenceMapping), &v1alpha1.KReferenceMapping{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KReferenceMapping), err
+}
+
+// Update takes the representation of a kReferenceMapping and updates it. Returns the server's representation of the kReferenceMapping, and an error, if there is any.
+func (c *FakeKReferenceMappings) Update(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(kreferencemappingsResource, kReferenceMapping), &v1alpha1.KReferenceMapping{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KReferenceMapping), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKReferenceMappings) UpdateStatus(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (*v1alpha1.KReferenceMapping, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(kreferencemappingsResource, "status", kReferenceMapping), &v1alpha1.KReferenceMapping{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KReferenceMapping), err
+}
+
+// Delete takes name of the kReferenceMapping and deletes it. Returns an error if one occurs.
+func (c *FakeKReferenceMappings) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(kreferencemappingsResource, name, opts), &v1alpha1.KReferenceMapping{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKReferenceMappings) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(kreferencemappingsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KReferenceMappingList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched kReferenceMapping.
+func (c *FakeKReferenceMappings) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KReferenceMapping, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(kreferencemappingsResource, name, pt, data, subresources...), &v1alpha1.KReferenceMapping{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KReferenceMapping), err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// FakeRedactionPolicies implements RedactionPolicyInterface
+type FakeRedactionPolicies struct {
+	Fake *FakeEventingV1alpha1
+	ns   string
+}
+
+var redactionpoliciesResource = v1alpha1.SchemeGroupVersion.WithResource("redactionpolicies")
+
+var redactionpoliciesKind = v1alpha1.SchemeGroupVersion.WithKind("RedactionPolicy")
+
+// Get takes name of the redactionPolicy, and returns the corresponding redactionPolicy object, and an error if there is any.
+func (c *FakeRedactionPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(redactionpoliciesResource, c.ns, name), &v1alpha1.RedactionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RedactionPolicy), err
+}
+
+// List takes label and field selectors, and returns the list of RedactionPolicies that match those selectors.
+func (c *FakeRedactionPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RedactionPolicyList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(redactionpoliciesResource, redactionpoliciesKind, c.ns, opts), &v1alpha1.RedactionPolicyList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.RedactionPolicyList{ListMeta: obj.(*v1alpha1.RedactionPolicyList).ListMeta}
+	for _, item := range obj.(*v1alpha1.RedactionPolicyList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested redactionPolicies.
+func (c *FakeRedactionPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(redactionpoliciesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a redactionPolicy and creates it.  Returns the server's representation of the redactionPolicy, and an error, if there is any.
+func (c *FakeRedactionPolicies) Create(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.CreateOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(redactionpoliciesResource, c.ns, redactionPolicy), &v1alpha1.RedactionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RedactionPolicy), err
+}
+
+// Update takes the representation of a redactionPolicy and updates it. Returns the server's representation of the redactionPolicy, and an error, if there is any.
+func (c *FakeRedactionPolicies) Update(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(redactionpoliciesResource, c.ns, redactionPolicy), &v1alpha1.RedactionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RedactionPolicy), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRedactionPolicies) UpdateStatus(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (*v1alpha1.RedactionPolicy, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(redactionpoliciesResource, "status", c.ns, redactionPolicy), &v1alpha1.RedactionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RedactionPolicy), err
+}
+
+// Delete takes name of the redactionPolicy and deletes it. Returns an error if one occurs.
+func (c *FakeRedactionPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(redactionpoliciesResource, c.ns, name, opts), &v1alpha1.RedactionPolicy{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRedactionPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(redactionpoliciesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.RedactionPolicyList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched redactionPolicy.
+func (c *FakeRedactionPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RedactionPolicy, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(redactionpoliciesResource, c.ns, name, pt, data, subresources...), &v1alpha1.RedactionPolicy{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RedactionPolicy), err
+}
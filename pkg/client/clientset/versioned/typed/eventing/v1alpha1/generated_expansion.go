@@ -18,4 +18,12 @@ limitations under the License.
 
 package v1alpha1
 
+type BrokerDefaultsPolicyExpansion interface{}
+
+type EventEmissionExpansion interface{}
+
 type EventPolicyExpansion interface{}
+
+type KReferenceMappingExpansion interface{}
+
+type RedactionPolicyExpansion interface{}
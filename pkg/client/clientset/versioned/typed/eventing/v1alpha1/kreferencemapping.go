@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	scheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
+)
+
+// KReferenceMappingsGetter has a method to return a KReferenceMappingInterface.
+// A group's client should implement this interface.
+type KReferenceMappingsGetter interface {
+	KReferenceMappings() KReferenceMappingInterface
+}
+
+// KReferenceMappingInterface has methods to work with KReferenceMapping resources.
+type KReferenceMappingInterface interface {
+	Create(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.CreateOptions) (*v1alpha1.KReferenceMapping, error)
+	Update(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (*v1alpha1.KReferenceMapping, error)
+	UpdateStatus(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (*v1alpha1.KReferenceMapping, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KReferenceMapping, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KReferenceMappingList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KReferenceMapping, err error)
+	KReferenceMappingExpansion
+}
+
+// kReferenceMappings implements KReferenceMappingInterface
+type kReferenceMappings struct {
+	client rest.Interface
+}
+
+// newKReferenceMappings returns a KReferenceMappings
+func newKReferenceMappings(c *EventingV1alpha1Client) *kReferenceMappings {
+	return &kReferenceMappings{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the kReferenceMapping, and returns the corresponding kReferenceMapping object, and an error if there is any.
+func (c *kReferenceMappings) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	result = &v1alpha1.KReferenceMapping{}
+	err = c.client.Get().
+		Resource("kreferencemappings").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KReferenceMappings that match those selectors.
+func (c *kReferenceMappings) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KReferenceMappingList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.KReferenceMappingList{}
+	err = c.client.Get().
+		Resource("kreferencemappings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested kReferenceMappings.
+func (c *kReferenceMappings) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("kreferencemappings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a kReferenceMapping and creates it.  Returns the server's representation of the kReferenceMapping, and an error, if there is any.
+func (c *kReferenceMappings) Create(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.CreateOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	result = &v1alpha1.KReferenceMapping{}
+	err = c.client.Post().
+		Resource("kreferencemappings").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kReferenceMapping).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a kReferenceMapping and updates it. Returns the server's representation of the kReferenceMapping, and an error, if there is any.
+func (c *kReferenceMappings) Update(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	result = &v1alpha1.KReferenceMapping{}
+	err = c.client.Put().
+		Resource("kreferencemappings").
+		Name(kReferenceMapping.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kReferenceMapping).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *kReferenceMappings) UpdateStatus(ctx context.Context, kReferenceMapping *v1alpha1.KReferenceMapping, opts v1.UpdateOptions) (result *v1alpha1.KReferenceMapping, err error) {
+	result = &v1alpha1.KReferenceMapping{}
+	err = c.client.Put().
+		Resource("kreferencemappings").
+		Name(kReferenceMapping.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kReferenceMapping).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the kReferenceMapping and deletes it. Returns an error if one occurs.
+func (c *kReferenceMappings) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("kreferencemappings").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *kReferenceMappings) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("kreferencemappings").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched kReferenceMapping.
+func (c *kReferenceMappings) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KReferenceMapping, err error) {
+	result = &v1alpha1.KReferenceMapping{}
+	err = c.client.Patch(pt).
+		Resource("kreferencemappings").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
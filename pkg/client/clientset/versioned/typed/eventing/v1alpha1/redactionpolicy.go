@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	scheme "knative.dev/eventing/pkg/client/clientset/versioned/scheme"
+)
+
+// RedactionPoliciesGetter has a method to return a RedactionPolicyInterface.
+// A group's client should implement this interface.
+type RedactionPoliciesGetter interface {
+	RedactionPolicies(namespace string) RedactionPolicyInterface
+}
+
+// RedactionPolicyInterface has methods to work with RedactionPolicy resources.
+type RedactionPolicyInterface interface {
+	Create(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.CreateOptions) (*v1alpha1.RedactionPolicy, error)
+	Update(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (*v1alpha1.RedactionPolicy, error)
+	UpdateStatus(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (*v1alpha1.RedactionPolicy, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.RedactionPolicy, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RedactionPolicyList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RedactionPolicy, err error)
+	RedactionPolicyExpansion
+}
+
+// redactionPolicies implements RedactionPolicyInterface
+type redactionPolicies struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRedactionPolicies returns a RedactionPolicies
+func newRedactionPolicies(c *EventingV1alpha1Client, namespace string) *redactionPolicies {
+	return &redactionPolicies{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the redactionPolicy, and returns the corresponding redactionPolicy object, and an error if there is any.
+func (c *redactionPolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	result = &v1alpha1.RedactionPolicy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of RedactionPolicies that match those selectors.
+func (c *redactionPolicies) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.RedactionPolicyList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.RedactionPolicyList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested redactionPolicies.
+func (c *redactionPolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a redactionPolicy and creates it.  Returns the server's representation of the redactionPolicy, and an error, if there is any.
+func (c *redactionPolicies) Create(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.CreateOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	result = &v1alpha1.RedactionPolicy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(redactionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a redactionPolicy and updates it. Returns the server's representation of the redactionPolicy, and an error, if there is any.
+func (c *redactionPolicies) Update(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	result = &v1alpha1.RedactionPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		Name(redactionPolicy.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(redactionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *redactionPolicies) UpdateStatus(ctx context.Context, redactionPolicy *v1alpha1.RedactionPolicy, opts v1.UpdateOptions) (result *v1alpha1.RedactionPolicy, err error) {
+	result = &v1alpha1.RedactionPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		Name(redactionPolicy.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(redactionPolicy).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the redactionPolicy and deletes it. Returns an error if one occurs.
+func (c *redactionPolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *redactionPolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched redactionPolicy.
+func (c *redactionPolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.RedactionPolicy, err error) {
+	result = &v1alpha1.RedactionPolicy{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("redactionpolicies").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -24,8 +24,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// BrokerDefaultsPolicies returns a BrokerDefaultsPolicyInformer.
+	BrokerDefaultsPolicies() BrokerDefaultsPolicyInformer
+	// EventEmissions returns a EventEmissionInformer.
+	EventEmissions() EventEmissionInformer
 	// EventPolicies returns a EventPolicyInformer.
 	EventPolicies() EventPolicyInformer
+	// KReferenceMappings returns a KReferenceMappingInformer.
+	KReferenceMappings() KReferenceMappingInformer
+	// RedactionPolicies returns a RedactionPolicyInformer.
+	RedactionPolicies() RedactionPolicyInformer
 }
 
 type version struct {
@@ -39,7 +47,27 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// BrokerDefaultsPolicies returns a BrokerDefaultsPolicyInformer.
+func (v *version) BrokerDefaultsPolicies() BrokerDefaultsPolicyInformer {
+	return &brokerDefaultsPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// EventEmissions returns a EventEmissionInformer.
+func (v *version) EventEmissions() EventEmissionInformer {
+	return &eventEmissionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // EventPolicies returns a EventPolicyInformer.
 func (v *version) EventPolicies() EventPolicyInformer {
 	return &eventPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// KReferenceMappings returns a KReferenceMappingInformer.
+func (v *version) KReferenceMappings() KReferenceMappingInformer {
+	return &kReferenceMappingInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RedactionPolicies returns a RedactionPolicyInformer.
+func (v *version) RedactionPolicies() RedactionPolicyInformer {
+	return &redactionPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -68,8 +68,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1().Triggers().Informer()}, nil
 
 		// Group=eventing.knative.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("brokerdefaultspolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().BrokerDefaultsPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("eventemissions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().EventEmissions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("eventpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().EventPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("kreferencemappings"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().KReferenceMappings().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("redactionpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().RedactionPolicies().Informer()}, nil
 
 		// Group=eventing.knative.dev, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("eventtypes"):
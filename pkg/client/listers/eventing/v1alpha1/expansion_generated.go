@@ -18,6 +18,18 @@ limitations under the License.
 
 package v1alpha1
 
+// BrokerDefaultsPolicyListerExpansion allows custom methods to be added to
+// BrokerDefaultsPolicyLister.
+type BrokerDefaultsPolicyListerExpansion interface{}
+
+// EventEmissionListerExpansion allows custom methods to be added to
+// EventEmissionLister.
+type EventEmissionListerExpansion interface{}
+
+// EventEmissionNamespaceListerExpansion allows custom methods to be added to
+// EventEmissionNamespaceLister.
+type EventEmissionNamespaceListerExpansion interface{}
+
 // EventPolicyListerExpansion allows custom methods to be added to
 // EventPolicyLister.
 type EventPolicyListerExpansion interface{}
@@ -25,3 +37,15 @@ type EventPolicyListerExpansion interface{}
 // EventPolicyNamespaceListerExpansion allows custom methods to be added to
 // EventPolicyNamespaceLister.
 type EventPolicyNamespaceListerExpansion interface{}
+
+// KReferenceMappingListerExpansion allows custom methods to be added to
+// KReferenceMappingLister.
+type KReferenceMappingListerExpansion interface{}
+
+// RedactionPolicyListerExpansion allows custom methods to be added to
+// RedactionPolicyLister.
+type RedactionPolicyListerExpansion interface{}
+
+// RedactionPolicyNamespaceListerExpansion allows custom methods to be added to
+// RedactionPolicyNamespaceLister.
+type RedactionPolicyNamespaceListerExpansion interface{}
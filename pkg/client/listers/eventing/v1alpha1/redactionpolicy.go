@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	v1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// RedactionPolicyLister helps list RedactionPolicies.
+// All objects returned here must be treated as read-only.
+type RedactionPolicyLister interface {
+	// List lists all RedactionPolicies in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.RedactionPolicy, err error)
+	// RedactionPolicies returns an object that can list and get RedactionPolicies.
+	RedactionPolicies(namespace string) RedactionPolicyNamespaceLister
+	RedactionPolicyListerExpansion
+}
+
+// redactionPolicyLister implements the RedactionPolicyLister interface.
+type redactionPolicyLister struct {
+	indexer cache.Indexer
+}
+
+// NewRedactionPolicyLister returns a new RedactionPolicyLister.
+func NewRedactionPolicyLister(indexer cache.Indexer) RedactionPolicyLister {
+	return &redactionPolicyLister{indexer: indexer}
+}
+
+// List lists all RedactionPolicies in the indexer.
+func (s *redactionPolicyLister) List(selector labels.Selector) (ret []*v1alpha1.RedactionPolicy, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RedactionPolicy))
+	})
+	return ret, err
+}
+
+// RedactionPolicies returns an object that can list and get RedactionPolicies.
+func (s *redactionPolicyLister) RedactionPolicies(namespace string) RedactionPolicyNamespaceLister {
+	return redactionPolicyNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RedactionPolicyNamespaceLister helps list and get RedactionPolicies.
+// All objects returned here must be treated as read-only.
+type RedactionPolicyNamespaceLister interface {
+	// List lists all RedactionPolicies in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.RedactionPolicy, err error)
+	// Get retrieves the RedactionPolicy from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.RedactionPolicy, error)
+	RedactionPolicyNamespaceListerExpansion
+}
+
+// redactionPolicyNamespaceLister implements the RedactionPolicyNamespaceLister
+// interface.
+type redactionPolicyNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RedactionPolicies in the indexer for a given namespace.
+func (s redactionPolicyNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.RedactionPolicy, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.RedactionPolicy))
+	})
+	return ret, err
+}
+
+// Get retrieves the RedactionPolicy from the indexer for a given namespace and name.
+func (s redactionPolicyNamespaceLister) Get(name string) (*v1alpha1.RedactionPolicy, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("redactionpolicy"), name)
+	}
+	return obj.(*v1alpha1.RedactionPolicy), nil
+}
@@ -20,48 +20,97 @@ import (
 	"fmt"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/types"
+
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
 	"knative.dev/eventing/pkg/eventfilter"
 )
 
+// FiltersMap caches the filters compiled from the spec of the Triggers, so
+// that their expressions are parsed once rather than for each event. The
+// filter of a Trigger is compiled again once its UID or generation changes.
 type FiltersMap struct {
-	filtersMap map[string]eventfilter.Filter
+	filtersMap map[string]filtersMapEntry
 	rwMutex    sync.RWMutex
 }
 
+type filtersMapEntry struct {
+	uid        types.UID
+	generation int64
+	filter     eventfilter.Filter
+}
+
 func NewFiltersMap() *FiltersMap {
 	return &FiltersMap{
-		filtersMap: make(map[string]eventfilter.Filter),
+		filtersMap: make(map[string]filtersMapEntry),
 	}
 }
+
 func (fm *FiltersMap) Set(trigger *eventingv1.Trigger, filter eventfilter.Filter) {
 	key := keyFromTrigger(trigger)
 	fm.rwMutex.Lock()
 	defer fm.rwMutex.Unlock()
-	if filter, found := fm.filtersMap[key]; found {
-		filter.Cleanup()
-	}
-	fm.filtersMap[key] = filter
+	fm.set(key, trigger, filter)
 }
 
+// Get returns the filter of the trigger, unless it was compiled from
+// another UID or generation of the trigger.
 func (fm *FiltersMap) Get(trigger *eventingv1.Trigger) (eventfilter.Filter, bool) {
 	key := keyFromTrigger(trigger)
 	fm.rwMutex.RLock()
 	defer fm.rwMutex.RUnlock()
 	res, found := fm.filtersMap[key]
-	return res, found
+	if !found || !res.matches(trigger) {
+		return nil, false
+	}
+	return res.filter, true
+}
+
+// GetOrCreate returns the filter of the trigger, or the one returned by
+// create, which replaces the filter of the previous UID or generation of
+// the trigger, if any.
+func (fm *FiltersMap) GetOrCreate(trigger *eventingv1.Trigger, create func() eventfilter.Filter) eventfilter.Filter {
+	if filter, ok := fm.Get(trigger); ok {
+		return filter
+	}
+
+	key := keyFromTrigger(trigger)
+	fm.rwMutex.Lock()
+	defer fm.rwMutex.Unlock()
+	// Another goroutine may have created it in the meantime.
+	if res, found := fm.filtersMap[key]; found && res.matches(trigger) {
+		return res.filter
+	}
+	filter := create()
+	fm.set(key, trigger, filter)
+	return filter
 }
 
 func (fm *FiltersMap) Delete(trigger *eventingv1.Trigger) {
 	key := keyFromTrigger(trigger)
 	fm.rwMutex.Lock()
 	defer fm.rwMutex.Unlock()
-	if filter, found := fm.filtersMap[key]; found {
-		filter.Cleanup()
+	if res, found := fm.filtersMap[key]; found {
+		res.filter.Cleanup()
 	}
 	delete(fm.filtersMap, key)
 }
 
+func (fm *FiltersMap) set(key string, trigger *eventingv1.Trigger, filter eventfilter.Filter) {
+	if res, found := fm.filtersMap[key]; found {
+		res.filter.Cleanup()
+	}
+	fm.filtersMap[key] = filtersMapEntry{
+		uid:        trigger.UID,
+		generation: trigger.Generation,
+		filter:     filter,
+	}
+}
+
+func (e filtersMapEntry) matches(trigger *eventingv1.Trigger) bool {
+	return e.uid == trigger.UID && e.generation == trigger.Generation
+}
+
 func keyFromTrigger(trigger *eventingv1.Trigger) string {
 	return fmt.Sprintf("%s.%s", trigger.Namespace, trigger.Name)
 }
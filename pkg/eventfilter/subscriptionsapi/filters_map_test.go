@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
 )
 
 func TestFiltersMap(t *testing.T) {
@@ -55,3 +56,44 @@ func TestFiltersMap(t *testing.T) {
 	_, ok = fm.Get(prefixTrigger)
 	assert.False(t, ok)
 }
+
+func TestFiltersMapGetOrCreate(t *testing.T) {
+	fm := NewFiltersMap()
+	trigger := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cesql",
+			Namespace:  "default",
+			UID:        "uid",
+			Generation: 1,
+		},
+	}
+	compiled := 0
+	create := func() eventfilter.Filter {
+		compiled++
+		f, err := NewCESQLFilter("type = 'sample.event.type'")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	first := fm.GetOrCreate(trigger, create)
+	assert.Same(t, first, fm.GetOrCreate(trigger, create))
+	assert.Equal(t, 1, compiled)
+
+	// A new generation of the trigger invalidates its filter.
+	updated := trigger.DeepCopy()
+	updated.Generation = 2
+	_, ok := fm.Get(updated)
+	assert.False(t, ok)
+	assert.NotSame(t, first, fm.GetOrCreate(updated, create))
+	assert.Equal(t, 2, compiled)
+
+	// So does a trigger recreated with the same name.
+	recreated := updated.DeepCopy()
+	recreated.UID = "other"
+	_, ok = fm.Get(recreated)
+	assert.False(t, ok)
+	fm.GetOrCreate(recreated, create)
+	assert.Equal(t, 3, compiled)
+}
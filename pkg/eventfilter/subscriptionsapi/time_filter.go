@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriptionsapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/rickb777/date/period"
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+)
+
+type timeFilter struct {
+	after  *time.Time
+	before *time.Time
+	minAge *time.Duration
+	maxAge *time.Duration
+	window *timeWindow
+
+	now func() time.Time
+}
+
+type timeWindow struct {
+	// start and end are the offsets of the window from midnight.
+	start time.Duration
+	end   time.Duration
+	// days are the days the window opens, all of them when nil.
+	days     map[time.Weekday]bool
+	location *time.Location
+}
+
+// NewTimeFilter returns an event filter which passes if the time attribute of
+// the CloudEvent meets all the conditions of the time filter.
+func NewTimeFilter(filter *eventingv1.TimeFilter) (eventfilter.Filter, error) {
+	return newTimeFilter(filter, time.Now)
+}
+
+func newTimeFilter(filter *eventingv1.TimeFilter, now func() time.Time) (eventfilter.Filter, error) {
+	if filter == nil {
+		return nil, fmt.Errorf("invalid arguments, the time filter can't be empty")
+	}
+	f := &timeFilter{now: now}
+	if filter.After != nil {
+		f.after = &filter.After.Time
+	}
+	if filter.Before != nil {
+		f.before = &filter.Before.Time
+	}
+
+	var err error
+	if f.minAge, err = parseAge(filter.MinAge); err != nil {
+		return nil, err
+	}
+	if f.maxAge, err = parseAge(filter.MaxAge); err != nil {
+		return nil, err
+	}
+	if f.window, err = parseTimeWindow(filter.Window); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func parseAge(age *string) (*time.Duration, error) {
+	if age == nil {
+		return nil, nil
+	}
+	p, err := period.Parse(*age)
+	if err != nil {
+		return nil, fmt.Errorf("invalid age %q: %w", *age, err)
+	}
+	d, _ := p.Duration()
+	return &d, nil
+}
+
+func parseTimeWindow(w *eventingv1.TimeWindow) (*timeWindow, error) {
+	if w == nil {
+		return nil, nil
+	}
+	start, err := time.Parse(eventingv1.TimeWindowLayout, w.Start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window start %q: %w", w.Start, err)
+	}
+	end, err := time.Parse(eventingv1.TimeWindowLayout, w.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid window end %q: %w", w.End, err)
+	}
+	location := time.UTC
+	if w.TimeZone != "" {
+		if location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid window time zone %q: %w", w.TimeZone, err)
+		}
+	}
+
+	window := &timeWindow{
+		start:    sinceMidnight(start),
+		end:      sinceMidnight(end),
+		location: location,
+	}
+	if len(w.Days) > 0 {
+		window.days = make(map[time.Weekday]bool, len(w.Days))
+		for _, day := range w.Days {
+			weekday, ok := eventingv1.Weekdays[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid window day %q", day)
+			}
+			window.days[weekday] = true
+		}
+	}
+	return window, nil
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// contains returns whether the window contains t. The part of a window
+// spanning midnight after midnight belongs to the day the window opened.
+func (w *timeWindow) contains(t time.Time) bool {
+	t = t.In(w.location)
+	offset := sinceMidnight(t)
+	day := t.Weekday()
+	if w.start < w.end {
+		if offset < w.start || offset >= w.end {
+			return false
+		}
+	} else if offset < w.end {
+		day = t.AddDate(0, 0, -1).Weekday()
+	} else if offset < w.start {
+		return false
+	}
+	return w.days == nil || w.days[day]
+}
+
+func (filter *timeFilter) Filter(ctx context.Context, event cloudevents.Event) eventfilter.FilterResult {
+	if filter == nil {
+		return eventfilter.NoFilter
+	}
+	logger := logging.FromContext(ctx)
+	logger.Debugw("Performing a time match ", zap.Any("event", event))
+
+	t := event.Time()
+	if t.IsZero() {
+		logger.Debugw("Couldn't find the time attribute in event. Time match failed.", zap.Any("event", event))
+		return eventfilter.FailFilter
+	}
+	if filter.after != nil && !t.After(*filter.after) {
+		return eventfilter.FailFilter
+	}
+	if filter.before != nil && !t.Before(*filter.before) {
+		return eventfilter.FailFilter
+	}
+	age := filter.now().Sub(t)
+	if filter.minAge != nil && age < *filter.minAge {
+		return eventfilter.FailFilter
+	}
+	if filter.maxAge != nil && age > *filter.maxAge {
+		return eventfilter.FailFilter
+	}
+	if filter.window != nil && !filter.window.contains(t) {
+		return eventfilter.FailFilter
+	}
+	return eventfilter.PassFilter
+}
+
+func (filter *timeFilter) Cleanup() {}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriptionsapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/ptr"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/eventfilter"
+)
+
+func TestTimeFilter(t *testing.T) {
+	// Wednesday, at 10:00 in New York.
+	now := time.Date(2024, time.March, 6, 15, 0, 0, 0, time.UTC)
+	after := metav1.NewTime(now.Add(-time.Hour))
+	before := metav1.NewTime(now.Add(time.Hour))
+	businessHours := &eventingv1.TimeWindow{
+		Start:    "09:00",
+		End:      "17:00",
+		Days:     []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		TimeZone: "America/New_York",
+	}
+	night := &eventingv1.TimeWindow{
+		Start: "22:00",
+		End:   "06:00",
+		Days:  []string{"Fri"},
+	}
+
+	tests := map[string]struct {
+		filter *eventingv1.TimeFilter
+		time   time.Time
+		want   eventfilter.FilterResult
+	}{
+		"Missing time": {
+			filter: &eventingv1.TimeFilter{After: &after},
+			want:   eventfilter.FailFilter,
+		},
+		"After": {
+			filter: &eventingv1.TimeFilter{After: &after},
+			time:   now,
+			want:   eventfilter.PassFilter,
+		},
+		"Not after": {
+			filter: &eventingv1.TimeFilter{After: &after},
+			time:   after.Time,
+			want:   eventfilter.FailFilter,
+		},
+		"Between": {
+			filter: &eventingv1.TimeFilter{After: &after, Before: &before},
+			time:   now,
+			want:   eventfilter.PassFilter,
+		},
+		"Not before": {
+			filter: &eventingv1.TimeFilter{After: &after, Before: &before},
+			time:   before.Add(time.Minute),
+			want:   eventfilter.FailFilter,
+		},
+		"Recent enough": {
+			filter: &eventingv1.TimeFilter{MaxAge: ptr.String("PT5M")},
+			time:   now.Add(-4 * time.Minute),
+			want:   eventfilter.PassFilter,
+		},
+		"Too old": {
+			filter: &eventingv1.TimeFilter{MaxAge: ptr.String("PT5M")},
+			time:   now.Add(-6 * time.Minute),
+			want:   eventfilter.FailFilter,
+		},
+		"Too recent": {
+			filter: &eventingv1.TimeFilter{MinAge: ptr.String("PT5M")},
+			time:   now.Add(-4 * time.Minute),
+			want:   eventfilter.FailFilter,
+		},
+		"In business hours": {
+			filter: &eventingv1.TimeFilter{Window: businessHours},
+			time:   now,
+			want:   eventfilter.PassFilter,
+		},
+		"After business hours": {
+			// 17:00 in New York.
+			filter: &eventingv1.TimeFilter{Window: businessHours},
+			time:   time.Date(2024, time.March, 6, 22, 0, 0, 0, time.UTC),
+			want:   eventfilter.FailFilter,
+		},
+		"Business hours on Saturday": {
+			filter: &eventingv1.TimeFilter{Window: businessHours},
+			time:   time.Date(2024, time.March, 9, 15, 0, 0, 0, time.UTC),
+			want:   eventfilter.FailFilter,
+		},
+		"Friday night before midnight": {
+			filter: &eventingv1.TimeFilter{Window: night},
+			time:   time.Date(2024, time.March, 8, 23, 0, 0, 0, time.UTC),
+			want:   eventfilter.PassFilter,
+		},
+		"Friday night after midnight": {
+			filter: &eventingv1.TimeFilter{Window: night},
+			time:   time.Date(2024, time.March, 9, 5, 59, 0, 0, time.UTC),
+			want:   eventfilter.PassFilter,
+		},
+		"Thursday night after midnight": {
+			filter: &eventingv1.TimeFilter{Window: night},
+			time:   time.Date(2024, time.March, 8, 1, 0, 0, 0, time.UTC),
+			want:   eventfilter.FailFilter,
+		},
+		"Friday daytime": {
+			filter: &eventingv1.TimeFilter{Window: night},
+			time:   time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC),
+			want:   eventfilter.FailFilter,
+		},
+		"All conditions": {
+			filter: &eventingv1.TimeFilter{After: &after, MaxAge: ptr.String("PT5M"), Window: businessHours},
+			time:   now.Add(-time.Minute),
+			want:   eventfilter.PassFilter,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			e := makeEvent()
+			e.SetTime(tc.time)
+			f, err := newTimeFilter(tc.filter, func() time.Time { return now })
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.Filter(context.TODO(), *e); got != tc.want {
+				t.Errorf("Filter() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeFilterInvalid(t *testing.T) {
+	tests := map[string]*eventingv1.TimeFilter{
+		"No filter":         nil,
+		"Invalid age":       {MaxAge: ptr.String("5 minutes")},
+		"Invalid start":     {Window: &eventingv1.TimeWindow{Start: "9am", End: "17:00"}},
+		"Invalid day":       {Window: &eventingv1.TimeWindow{Start: "09:00", End: "17:00", Days: []string{"Monday"}}},
+		"Invalid time zone": {Window: &eventingv1.TimeWindow{Start: "09:00", End: "17:00", TimeZone: "Nowhere"}},
+	}
+	for n, filter := range tests {
+		t.Run(n, func(t *testing.T) {
+			if _, err := NewTimeFilter(filter); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventindex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"knative.dev/eventing/pkg/kncloudevents"
+)
+
+// Path is the path of the debug endpoint querying the index.
+const Path = "/events"
+
+// LookupResponse is the body of the responses of the debug endpoint.
+type LookupResponse struct {
+	ID      string   `json:"id"`
+	Records []Record `json:"records"`
+}
+
+// Handler returns the debug endpoint answering GET requests for Path with
+// the records of the event whose ID is the "id" query parameter. It answers
+// 404 when no record of the event is in the index.
+func (i *Index) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, `missing "id" query parameter`, http.StatusBadRequest)
+			return
+		}
+
+		records := i.Lookup(id)
+		if records == nil {
+			records = []Record{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if len(records) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(LookupResponse{ID: id, Records: records})
+	})
+	return mux
+}
+
+// StartServer serves the debug endpoint of the index on the given port in
+// the background, until ctx is done. It does nothing when the index is nil or
+// the port isn't positive.
+func (i *Index) StartServer(ctx context.Context, logger *zap.Logger, port int, opts ...kncloudevents.HTTPEventReceiverOption) {
+	if i == nil || port <= 0 {
+		return
+	}
+
+	receiver := kncloudevents.NewHTTPEventReceiver(port, opts...)
+	go func() {
+		logger.Info("Starting event index server", zap.Int("port", port))
+		if err := receiver.StartListen(ctx, i.Handler()); err != nil {
+			logger.Error("Event index server returned an error", zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventindex
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := New("filter", 10)
+	i.now = func() time.Time { return now }
+	i.Add(Record{ID: "1", Resource: "trigger ns/name", Outcome: OutcomeFiltered})
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		wantStatus  int
+		wantRecords []Record
+	}{{
+		name:       "found",
+		method:     http.MethodGet,
+		target:     "/events?id=1",
+		wantStatus: http.StatusOK,
+		wantRecords: []Record{
+			{Time: now, Component: "filter", ID: "1", Resource: "trigger ns/name", Outcome: OutcomeFiltered},
+		},
+	}, {
+		name:        "not found",
+		method:      http.MethodGet,
+		target:      "/events?id=2",
+		wantStatus:  http.StatusNotFound,
+		wantRecords: []Record{},
+	}, {
+		name:       "missing id",
+		method:     http.MethodGet,
+		target:     "/events",
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name:       "invalid method",
+		method:     http.MethodPost,
+		target:     "/events?id=1",
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		name:       "unknown path",
+		method:     http.MethodGet,
+		target:     "/other?id=1",
+		wantStatus: http.StatusNotFound,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			i.Handler().ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.target, nil))
+
+			if recorder.Code != tc.wantStatus {
+				t.Fatalf("expected status code %d got %d", tc.wantStatus, recorder.Code)
+			}
+			if tc.wantRecords == nil {
+				return
+			}
+			var got LookupResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+				t.Fatal("failed to decode the response:", err)
+			}
+			if diff := cmp.Diff(tc.wantRecords, got.Records); diff != "" {
+				t.Error("unexpected records (-want +got)", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventindex keeps an in-memory index of the events recently seen by
+// a data plane component along with their outcome, so that what happened to
+// a given event can be queried while investigating event loss.
+package eventindex
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// Outcome is what a component did with an event.
+type Outcome string
+
+const (
+	// OutcomeDelivered means the event was sent to the next hop, which
+	// accepted it.
+	OutcomeDelivered Outcome = "delivered"
+	// OutcomeDeadLettered means the event couldn't be delivered and was sent
+	// to the dead letter sink instead.
+	OutcomeDeadLettered Outcome = "dead-lettered"
+	// OutcomeFailed means the event couldn't be delivered.
+	OutcomeFailed Outcome = "failed"
+	// OutcomeRejected means the event was refused before being delivered,
+	// e.g. because it failed validation.
+	OutcomeRejected Outcome = "rejected"
+	// OutcomeFiltered means the event didn't pass the filter of a Trigger.
+	OutcomeFiltered Outcome = "filtered"
+)
+
+// OutcomeForStatus returns the outcome of a delivery answered with the given
+// HTTP status code.
+func OutcomeForStatus(statusCode int) Outcome {
+	if statusCode >= 200 && statusCode < 300 {
+		return OutcomeDelivered
+	}
+	return OutcomeFailed
+}
+
+// Record is the outcome of an event in a component.
+type Record struct {
+	// Time is when the outcome was recorded.
+	Time time.Time `json:"time"`
+	// Component is the name of the component which recorded the outcome.
+	Component string `json:"component"`
+	// ID, Source and Type are the attributes of the event.
+	ID     string `json:"id"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+	// Resource identifies the resource the event was handled for, e.g.
+	// "broker default/my-broker".
+	Resource string `json:"resource"`
+	// Outcome is what the component did with the event.
+	Outcome Outcome `json:"outcome"`
+	// StatusCode is the HTTP status code of the delivery, if any.
+	StatusCode int `json:"statusCode,omitempty"`
+	// Error describes why the event wasn't delivered, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// NewRecord returns the record of the outcome of e for the given resource.
+// err may be nil.
+func NewRecord(e *event.Event, resource string, outcome Outcome, statusCode int, err error) Record {
+	r := Record{
+		ID:         e.ID(),
+		Source:     e.Source(),
+		Type:       e.Type(),
+		Resource:   resource,
+		Outcome:    outcome,
+		StatusCode: statusCode,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// Index is a fixed size ring buffer of the most recent records of a
+// component. It is safe for concurrent use. A nil *Index records nothing, so
+// that the index can be optional.
+type Index struct {
+	component string
+
+	mu      sync.Mutex
+	records []Record
+	// next is the position of the next record in records.
+	next int
+	// full is true once records has wrapped around.
+	full bool
+
+	now func() time.Time
+}
+
+// New creates an Index of the component keeping the given number of records.
+// It returns nil when size isn't positive.
+func New(component string, size int) *Index {
+	if size <= 0 {
+		return nil
+	}
+	return &Index{
+		component: component,
+		records:   make([]Record, size),
+		now:       time.Now,
+	}
+}
+
+// Add adds a record to the index, evicting the oldest one when it is full.
+func (i *Index) Add(r Record) {
+	if i == nil {
+		return
+	}
+	r.Component = i.component
+	r.Time = i.now()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.records[i.next] = r
+	i.next++
+	if i.next == len(i.records) {
+		i.next = 0
+		i.full = true
+	}
+}
+
+// Lookup returns the records of the event with the given ID still in the
+// index, oldest first.
+func (i *Index) Lookup(id string) []Record {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var found []Record
+	start, n := 0, i.next
+	if i.full {
+		start, n = i.next, len(i.records)
+	}
+	for j := 0; j < n; j++ {
+		if r := i.records[(start+j)%len(i.records)]; r.ID == id {
+			found = append(found, r)
+		}
+	}
+	return found
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventindex
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIndex(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	i := New("ingress", 3)
+	i.now = func() time.Time { return now }
+
+	i.Add(Record{ID: "1", Outcome: OutcomeDelivered})
+	i.Add(Record{ID: "2", Outcome: OutcomeRejected})
+	i.Add(Record{ID: "1", Outcome: OutcomeFailed})
+
+	want := []Record{
+		{Time: now, Component: "ingress", ID: "1", Outcome: OutcomeDelivered},
+		{Time: now, Component: "ingress", ID: "1", Outcome: OutcomeFailed},
+	}
+	if diff := cmp.Diff(want, i.Lookup("1")); diff != "" {
+		t.Error("unexpected records (-want +got)", diff)
+	}
+
+	// Evicts the oldest record.
+	i.Add(Record{ID: "3", Outcome: OutcomeFiltered})
+	want = []Record{
+		{Time: now, Component: "ingress", ID: "1", Outcome: OutcomeFailed},
+	}
+	if diff := cmp.Diff(want, i.Lookup("1")); diff != "" {
+		t.Error("unexpected records after eviction (-want +got)", diff)
+	}
+	if got := i.Lookup("4"); got != nil {
+		t.Errorf("expected no record, got %v", got)
+	}
+}
+
+func TestIndexNil(t *testing.T) {
+	i := New("ingress", 0)
+	if i != nil {
+		t.Fatalf("expected nil index, got %v", i)
+	}
+	i.Add(Record{ID: "1"})
+	if got := i.Lookup("1"); got != nil {
+		t.Errorf("expected no record, got %v", got)
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	e := event.New()
+	e.SetID("1")
+	e.SetSource("/source")
+	e.SetType("type")
+
+	got := NewRecord(&e, "broker ns/name", OutcomeForStatus(http.StatusServiceUnavailable), http.StatusServiceUnavailable, errors.New("unavailable"))
+	want := Record{
+		ID:         "1",
+		Source:     "/source",
+		Type:       "type",
+		Resource:   "broker ns/name",
+		Outcome:    OutcomeFailed,
+		StatusCode: http.StatusServiceUnavailable,
+		Error:      "unavailable",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected record (-want +got)", diff)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package eventingtls
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -25,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"go.uber.org/zap"
@@ -93,6 +95,11 @@ type GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 //
 // The secret is expected to have at least 2 keys in data: see TLSKey and TLSCrt constants for
 // knowing the key names.
+//
+// The certificate is looked up on every TLS handshake, so a rotated certificate is served to
+// new connections as soon as the informer observes the change, while established connections
+// are left untouched. When the secret is deleted or holds an invalid key pair, the last valid
+// certificate keeps being served.
 func GetCertificateFromSecret(ctx context.Context, informer coreinformersv1.SecretInformer, kube kubernetes.Interface, secret types.NamespacedName) GetCertificate {
 
 	certHolder := atomic.Value{}
@@ -100,6 +107,13 @@ func GetCertificateFromSecret(ctx context.Context, informer coreinformersv1.Secr
 	logger := logging.FromContext(ctx).Desugar().
 		With(zap.String("tls.secret", secret.String()))
 
+	// Informer resyncs deliver the same secret over and over, keep the raw
+	// data of the stored key pair around to skip parsing it again.
+	var (
+		mu               sync.Mutex
+		lastCrt, lastKey []byte
+	)
+
 	store := func(obj interface{}) {
 		s, ok := obj.(*corev1.Secret)
 		if !ok {
@@ -112,6 +126,13 @@ func GetCertificateFromSecret(ctx context.Context, informer coreinformersv1.Secr
 			return
 		}
 
+		mu.Lock()
+		defer mu.Unlock()
+
+		if bytes.Equal(crt, lastCrt) && bytes.Equal(key, lastKey) {
+			return
+		}
+
 		logger.Debug("Loading key pair")
 
 		certificate, err := tls.X509KeyPair(crt, key)
@@ -120,8 +141,15 @@ func GetCertificateFromSecret(ctx context.Context, informer coreinformersv1.Secr
 			return
 		}
 
-		logger.Debug("certificate stored")
+		rotated := lastCrt != nil
+		lastCrt, lastKey = crt, key
 		certHolder.Store(&certificate)
+
+		if rotated {
+			logger.Info("Certificate rotated")
+		} else {
+			logger.Debug("certificate stored")
+		}
 	}
 
 	informer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -186,6 +214,15 @@ func GetTLSServerConfig(config ServerConfig) (*tls.Config, error) {
 	}, nil
 }
 
+// GetTLSServerConfigFromSecret returns the tls.Config of a data plane HTTPS server serving the
+// certificate in the given secret. The certificate is reloaded whenever the secret changes,
+// see GetCertificateFromSecret.
+func GetTLSServerConfigFromSecret(ctx context.Context, informer coreinformersv1.SecretInformer, kube kubernetes.Interface, secret types.NamespacedName) (*tls.Config, error) {
+	serverTLSConfig := NewDefaultServerConfig()
+	serverTLSConfig.GetCertificate = GetCertificateFromSecret(ctx, informer, kube, secret)
+	return GetTLSServerConfig(serverTLSConfig)
+}
+
 // IsHttpsSink returns true if the sink has scheme equal to https.
 func IsHttpsSink(sink string) bool {
 	s, err := apis.ParseURL(sink)
@@ -17,10 +17,29 @@ limitations under the License.
 package eventingtls
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
 )
 
@@ -129,3 +148,186 @@ func WithCerts(pool *x509.CertPool, caCerts string) *x509.CertPool {
 	}
 	return pool
 }
+
+func TestGetCertificateFromSecretRotation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := newKeyPair(t, 1)
+	second := newKeyPair(t, 2)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "knative-eventing",
+			Name:      "server-tls",
+		},
+		Data: map[string][]byte{
+			TLSCrt: first.crt,
+			TLSKey: first.key,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+
+	kube := fake.NewSimpleClientset(secret)
+	factory := informers.NewSharedInformerFactory(kube, 0)
+	informer := factory.Core().V1().Secrets()
+
+	tlsConfig, err := GetTLSServerConfigFromSecret(ctx, informer, kube, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+	url := "https://" + listener.Addr().String()
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(first.crt)
+	pool.AppendCertsFromPEM(second.crt)
+
+	if got := servedSerial(t, listener.Addr(), pool); got != 1 {
+		t.Fatalf("want certificate with serial 1 before rotation, got %d", got)
+	}
+
+	// Send requests over both long-lived and per-request connections while
+	// the certificate is rotated, none of them are expected to fail.
+	var (
+		wg       sync.WaitGroup
+		requests atomic.Int64
+		failures atomic.Int64
+	)
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		client := &http.Client{
+			Timeout: 5 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{RootCAs: pool, MinVersion: DefaultMinTLSVersion},
+				DisableKeepAlives: i%2 == 0,
+			},
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer client.CloseIdleConnections()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				requests.Add(1)
+				resp, err := client.Get(url)
+				if err != nil {
+					t.Log(err)
+					failures.Add(1)
+					continue
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+				if resp.StatusCode != http.StatusAccepted {
+					failures.Add(1)
+				}
+			}
+		}()
+	}
+
+	update := func(data map[string][]byte) {
+		s := secret.DeepCopy()
+		s.Data = data
+		if _, err := kube.CoreV1().Secrets(s.Namespace).Update(ctx, s, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	update(map[string][]byte{TLSCrt: second.crt, TLSKey: second.key})
+
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		return servedSerial(t, listener.Addr(), pool) == 2, nil
+	})
+	if err != nil {
+		t.Fatal("rotated certificate was not served:", err)
+	}
+
+	// An invalid key pair and the deletion of the secret keep the last
+	// valid certificate.
+	update(map[string][]byte{TLSCrt: first.crt, TLSKey: second.key})
+	if err := kube.CoreV1().Secrets(secret.Namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	close(stop)
+	wg.Wait()
+
+	if got := servedSerial(t, listener.Addr(), pool); got != 2 {
+		t.Errorf("want certificate with serial 2 to be kept, got %d", got)
+	}
+	if n := failures.Load(); n > 0 {
+		t.Errorf("%d out of %d requests failed during rotation", n, requests.Load())
+	}
+}
+
+type keyPair struct {
+	crt []byte
+	key []byte
+}
+
+// newKeyPair returns a self-signed certificate for 127.0.0.1 with the given
+// serial number.
+func newKeyPair(t *testing.T, serial int64) keyPair {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyPair{
+		crt: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// servedSerial opens a new connection to addr and returns the serial number
+// of the certificate presented by the server.
+func servedSerial(t *testing.T, addr net.Addr, pool *x509.CertPool) int64 {
+	t.Helper()
+
+	conn, err := tls.Dial("tcp", addr.String(), &tls.Config{RootCAs: pool, MinVersion: DefaultMinTLSVersion})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
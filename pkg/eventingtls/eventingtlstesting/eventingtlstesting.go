@@ -65,9 +65,7 @@ func StartServer(ctx context.Context, t *testing.T, port int, handler http.Handl
 		Type: corev1.SecretTypeTLS,
 	})
 
-	serverTLSConfig := eventingtls.NewDefaultServerConfig()
-	serverTLSConfig.GetCertificate = eventingtls.GetCertificateFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
-	tlsConfig, err := eventingtls.GetTLSServerConfig(serverTLSConfig)
+	tlsConfig, err := eventingtls.GetTLSServerConfigFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
 	assert.Nil(t, err)
 
 	receiver := kncloudevents.NewHTTPEventReceiver(port,
@@ -33,11 +33,50 @@ import (
 	"knative.dev/eventing/pkg/apis/feature"
 	eventingv1beta2 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1beta2"
 	v1beta22 "knative.dev/eventing/pkg/client/listers/eventing/v1beta2"
+	duckresources "knative.dev/eventing/pkg/reconciler/source/duck/resources"
 	"knative.dev/eventing/pkg/utils"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
+const (
+	// The sources set these extensions on the events they emit to identify
+	// themselves, so that the EventTypes auto-created for the events
+	// reference them.
+	SourceAPIVersionExtension = "knsourceapiversion"
+	SourceKindExtension       = "knsourcekind"
+	SourceNamespaceExtension  = "knsourcenamespace"
+	SourceNameExtension       = "knsourcename"
+)
+
+// SetSourceExtensions sets the extensions identifying the source emitting
+// the event.
+func SetSourceExtensions(e *event.Event, source *duckv1.KReference) {
+	e.SetExtension(SourceAPIVersionExtension, source.APIVersion)
+	e.SetExtension(SourceKindExtension, source.Kind)
+	e.SetExtension(SourceNamespaceExtension, source.Namespace)
+	e.SetExtension(SourceNameExtension, source.Name)
+}
+
+// SourceReference returns the source that emitted the event according to
+// its extensions, or nil when they don't identify one.
+func SourceReference(e *event.Event) *duckv1.KReference {
+	ext := func(name string) string {
+		v, _ := e.Extensions()[name].(string)
+		return v
+	}
+	ref := &duckv1.KReference{
+		APIVersion: ext(SourceAPIVersionExtension),
+		Kind:       ext(SourceKindExtension),
+		Namespace:  ext(SourceNamespaceExtension),
+		Name:       ext(SourceNameExtension),
+	}
+	if ref.Kind == "" || ref.Name == "" {
+		return nil
+	}
+	return ref
+}
+
 type EventTypeAutoHandler struct {
 	EventTypeLister v1beta22.EventTypeLister
 	EventingClient  eventingv1beta2.EventingV1beta2Interface
@@ -64,7 +103,14 @@ func (h *EventTypeAutoHandler) AutoCreateEventType(ctx context.Context, event *e
 	go func() {
 		h.Logger.Debug("Event Types auto creation is enabled")
 
-		eventTypeName := generateEventTypeName(addressable.Name, addressable.Namespace, event.Type(), event.Source())
+		// The events of different sources get different EventTypes, even
+		// when their type and source attributes are the same.
+		sourceRef := SourceReference(event)
+		eventSource := event.Source()
+		if sourceRef != nil {
+			eventSource += sourceRef.Kind + sourceRef.Namespace + sourceRef.Name
+		}
+		eventTypeName := generateEventTypeName(addressable.Name, addressable.Namespace, event.Type(), eventSource)
 
 		exists, err := h.EventTypeLister.EventTypes(addressable.Namespace).Get(eventTypeName)
 		if err != nil && !apierrs.IsNotFound(err) {
@@ -77,7 +123,10 @@ func (h *EventTypeAutoHandler) AutoCreateEventType(ctx context.Context, event *e
 			return
 		}
 
-		source, _ := apis.ParseURL(event.Source())
+		source, err := apis.ParseURL(event.Source())
+		if err != nil {
+			h.Logger.Debug("Event source isn't a valid URI", zap.String("source", event.Source()), zap.Error(err))
+		}
 		schema, _ := apis.ParseURL(event.DataSchema())
 
 		et := &v1beta2.EventType{
@@ -102,6 +151,10 @@ func (h *EventTypeAutoHandler) AutoCreateEventType(ctx context.Context, event *e
 				Description: "Event Type auto-created by controller",
 			},
 		}
+		if sourceRef != nil {
+			et.Labels = duckresources.Labels(sourceRef.Name)
+			et.Spec.Description = fmt.Sprintf("Event Type auto-created by controller for %s %s/%s", sourceRef.Kind, sourceRef.Namespace, sourceRef.Name)
+		}
 
 		_, err = h.EventingClient.EventTypes(et.Namespace).Create(ctx, et, metav1.CreateOptions{})
 		if err != nil && !apierrs.IsAlreadyExists(err) {
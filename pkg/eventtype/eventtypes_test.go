@@ -111,6 +111,79 @@ func TestEventTypeAutoHandler_AutoCreateEventType(t *testing.T) {
 
 }
 
+func TestEventTypeAutoHandler_AutoCreateEventTypeWithSource(t *testing.T) {
+	ctx := context.TODO()
+	listers := reconcilertestingv1beta2.NewListers(nil)
+	eventingClient := fakeeventingclientset.NewSimpleClientset()
+
+	handler := &EventTypeAutoHandler{
+		EventTypeLister: listers.GetEventTypeLister(),
+		EventingClient:  eventingClient.EventingV1beta2(),
+		FeatureStore:    initFeatureStore(t, "enabled"),
+		Logger:          zap.NewNop(),
+	}
+	broker := &duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1",
+		Kind:       "Broker",
+		Namespace:  "default",
+		Name:       "broker",
+	}
+
+	for _, name := range []string{"source-a", "source-b"} {
+		e := initEvent("dev.knative.apiserver.resource.add")
+		e.SetSource("https://10.96.0.1:443")
+		SetSourceExtensions(&e, &duckv1.KReference{
+			APIVersion: "sources.knative.dev/v1",
+			Kind:       "ApiServerSource",
+			Namespace:  "default",
+			Name:       name,
+		})
+		handler.AutoCreateEventType(ctx, &e, broker, types.UID("owner-uid"))
+		time.Sleep(time.Millisecond * 500) // autocreate runs in a different goroutine, need to wait for it to finish
+	}
+
+	ets, err := eventingClient.EventingV1beta2().EventTypes("default").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ets.Items) != 2 {
+		t.Fatalf("expected an EventType per source, got %d", len(ets.Items))
+	}
+	for _, et := range ets.Items {
+		name := et.Labels["eventing.knative.dev/sourceName"]
+		if name != "source-a" && name != "source-b" {
+			t.Errorf("unexpected source name label %q", name)
+		}
+		if want := "Event Type auto-created by controller for ApiServerSource default/" + name; et.Spec.Description != want {
+			t.Errorf("expected description %q, got %q", want, et.Spec.Description)
+		}
+		if et.Spec.Source.String() != "https://10.96.0.1:443" {
+			t.Errorf("expected the event source, got %q", et.Spec.Source)
+		}
+		if !reflect.DeepEqual(et.Spec.Reference, broker) {
+			t.Errorf("expected reference %v, got %v", broker, et.Spec.Reference)
+		}
+	}
+}
+
+func TestSourceReference(t *testing.T) {
+	e := initEvent("")
+	if ref := SourceReference(&e); ref != nil {
+		t.Errorf("expected no source reference, got %v", ref)
+	}
+
+	want := &duckv1.KReference{
+		APIVersion: "sources.knative.dev/v1",
+		Kind:       "ApiServerSource",
+		Namespace:  "default",
+		Name:       "source",
+	}
+	SetSourceExtensions(&e, want)
+	if got := SourceReference(&e); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected source reference %v, got %v", want, got)
+	}
+}
+
 func TestEventTypeAutoHandler_GenerateEventTypeName(t *testing.T) {
 	testCases := []struct {
 		name         string
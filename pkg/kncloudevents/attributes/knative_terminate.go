@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attributes
+
+import (
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// KnativeTerminateExtensionKey is the extension a subscriber sets to true on
+// its reply to send it to the terminal reply of its Subscription, skipping
+// the remaining steps of a Sequence.
+const KnativeTerminateExtensionKey = "knativeterminate"
+
+// IsTerminal returns whether the KnativeTerminateExtensionKey extension of
+// the event is true.
+func IsTerminal(e *event.Event) bool {
+	v, ok := e.Extensions()[KnativeTerminateExtensionKey]
+	if !ok {
+		return false
+	}
+	terminal, err := types.ToBool(v)
+	return err == nil && terminal
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attributes
+
+import (
+	"testing"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+)
+
+func TestIsTerminal(t *testing.T) {
+	testCases := map[string]struct {
+		value interface{}
+		want  bool
+	}{
+		"not set":       {},
+		"true":          {value: true, want: true},
+		"true string":   {value: "true", want: true},
+		"false":         {value: false},
+		"invalid value": {value: "yes"},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			e := cetest.FullEvent()
+			if tc.value != nil {
+				e.SetExtension(KnativeTerminateExtensionKey, tc.value)
+			}
+			if got := IsTerminal(&e); got != tc.want {
+				t.Errorf("IsTerminal() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
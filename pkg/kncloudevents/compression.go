@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ContentEncodingGzip is the gzip content encoding.
+	ContentEncodingGzip = "gzip"
+	// ContentEncodingIdentity is the content encoding of uncompressed requests.
+	ContentEncodingIdentity = "identity"
+
+	// DefaultMaxDecompressedSize is the default maximum size of a decoded
+	// request body.
+	DefaultMaxDecompressedSize = 10 * 1024 * 1024
+
+	// compressionMinSize is the minimum size of a request body to be
+	// compressed, smaller bodies don't benefit from compression.
+	compressionMinSize = 1024
+)
+
+var (
+	// ErrUnsupportedContentEncoding is returned when a request body is encoded
+	// with an unsupported content encoding.
+	ErrUnsupportedContentEncoding = errors.New("unsupported content encoding")
+	// ErrDecompressedBodyTooLarge is returned when a decoded request body is
+	// larger than allowed.
+	ErrDecompressedBodyTooLarge = errors.New("decompressed request body too large")
+)
+
+// IsSupportedContentEncoding returns true when encoding can be used to
+// compress requests, the empty encoding means identity.
+func IsSupportedContentEncoding(encoding string) bool {
+	switch strings.ToLower(encoding) {
+	case "", ContentEncodingIdentity, ContentEncodingGzip:
+		return true
+	}
+	return false
+}
+
+// NewCompressingRoundTripper returns a http.RoundTripper which compresses the
+// body of outgoing requests with the given content encoding before passing
+// them on to next. Requests with small bodies or which are already encoded
+// are sent as is.
+func NewCompressingRoundTripper(encoding string, next http.RoundTripper) http.RoundTripper {
+	if !strings.EqualFold(encoding, ContentEncodingGzip) {
+		return next
+	}
+	return &compressingRoundTripper{next: next}
+}
+
+type compressingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (rt *compressingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.Header.Get("Content-Encoding") != "" {
+		return rt.next.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if len(body) < compressionMinSize {
+		return rt.next.RoundTrip(withBody(req, body))
+	}
+
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	if _, err := w.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress request body: %w", err)
+	}
+
+	req = withBody(req, compressed.Bytes())
+	req.Header.Set("Content-Encoding", ContentEncodingGzip)
+	return rt.next.RoundTrip(req)
+}
+
+// withBody returns a shallow copy of req with the given body, as a
+// RoundTripper must not modify the request it was given.
+func withBody(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	r.ContentLength = int64(len(body))
+	return r
+}
+
+// DecompressRequest replaces the body of a request encoded with a supported
+// content encoding with its decoded form, and removes the Content-Encoding
+// header. ErrUnsupportedContentEncoding is returned for other encodings, and
+// ErrDecompressedBodyTooLarge when the decoded body is larger than maxSize
+// bytes, which protects the receivers from compression bombs. maxSize isn't
+// enforced when it is not positive.
+func DecompressRequest(req *http.Request, maxSize int64) error {
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "", ContentEncodingIdentity:
+		return nil
+	case ContentEncodingGzip:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentEncoding, encoding)
+	}
+
+	r, err := gzip.NewReader(req.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip request body: %w", err)
+	}
+	defer r.Close()
+
+	var decoded io.Reader = r
+	if maxSize > 0 {
+		decoded = io.LimitReader(r, maxSize+1)
+	}
+	body, err := io.ReadAll(decoded)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip request body: %w", err)
+	}
+	if maxSize > 0 && int64(len(body)) > maxSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrDecompressedBodyTooLarge, maxSize)
+	}
+	_ = req.Body.Close()
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.Header.Del("Content-Encoding")
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	req.ContentLength = int64(len(body))
+	return nil
+}
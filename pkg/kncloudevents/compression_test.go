@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressingRoundTripper(t *testing.T) {
+	large := strings.Repeat("knative", compressionMinSize)
+	small := "knative"
+
+	tests := map[string]struct {
+		encoding     string
+		body         string
+		header       http.Header
+		wantEncoding string
+	}{
+		"gzip large body": {
+			encoding:     ContentEncodingGzip,
+			body:         large,
+			wantEncoding: ContentEncodingGzip,
+		},
+		"gzip small body": {
+			encoding: ContentEncodingGzip,
+			body:     small,
+		},
+		"gzip already encoded body": {
+			encoding:     ContentEncodingGzip,
+			body:         large,
+			header:       http.Header{"Content-Encoding": []string{"br"}},
+			wantEncoding: "br",
+		},
+		"identity": {
+			encoding: ContentEncodingIdentity,
+			body:     large,
+		},
+		"no encoding": {
+			body: large,
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			var gotEncoding, gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotEncoding = r.Header.Get("Content-Encoding")
+				if err := DecompressRequest(r, 0); err != nil && !errors.Is(err, ErrUnsupportedContentEncoding) {
+					t.Error(err)
+				}
+				b, _ := io.ReadAll(r.Body)
+				gotBody = string(b)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: NewCompressingRoundTripper(tc.encoding, http.DefaultTransport)}
+			req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(tc.body))
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if gotEncoding != tc.wantEncoding {
+				t.Errorf("want Content-Encoding %q, got %q", tc.wantEncoding, gotEncoding)
+			}
+			if tc.wantEncoding != "br" && gotBody != tc.body {
+				t.Errorf("unexpected body after decompression, got %d bytes, want %d bytes", len(gotBody), len(tc.body))
+			}
+		})
+	}
+}
+
+func TestDecompressRequest(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, _ = w.Write([]byte("knative"))
+	_ = w.Close()
+
+	tests := map[string]struct {
+		encoding string
+		body     []byte
+		maxSize  int64
+		want     string
+		wantErr  bool
+	}{
+		"gzip": {
+			encoding: "GZIP",
+			body:     compressed.Bytes(),
+			want:     "knative",
+		},
+		"identity": {
+			encoding: ContentEncodingIdentity,
+			body:     []byte("knative"),
+			want:     "knative",
+		},
+		"none": {
+			body: []byte("knative"),
+			want: "knative",
+		},
+		"unsupported": {
+			encoding: "br",
+			body:     []byte("knative"),
+			wantErr:  true,
+		},
+		"invalid gzip": {
+			encoding: ContentEncodingGzip,
+			body:     []byte("knative"),
+			wantErr:  true,
+		},
+		"at the maximum size": {
+			encoding: ContentEncodingGzip,
+			body:     compressed.Bytes(),
+			maxSize:  int64(len("knative")),
+			want:     "knative",
+		},
+		"above the maximum size": {
+			encoding: ContentEncodingGzip,
+			body:     compressed.Bytes(),
+			maxSize:  int64(len("knative")) - 1,
+			wantErr:  true,
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.body))
+			if tc.encoding != "" {
+				req.Header.Set("Content-Encoding", tc.encoding)
+			}
+
+			err := DecompressRequest(req, tc.maxSize)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := req.Header.Get("Content-Encoding"); got != "" && got != ContentEncodingIdentity {
+				t.Errorf("want Content-Encoding to be removed, got %q", got)
+			}
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.want {
+				t.Errorf("want body %q, got %q", tc.want, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/event"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"github.com/cloudevents/sdk-go/v2/types"
+)
+
+// binaryHeaderPrefix is the prefix of the headers carrying the attributes of
+// binary mode CloudEvents, in canonical form.
+const binaryHeaderPrefix = "Ce-"
+
+// AttributeError describes a missing or invalid CloudEvent attribute.
+type AttributeError struct {
+	Attribute string
+	Reason    string
+}
+
+func (e AttributeError) Error() string {
+	return e.Attribute + ": " + e.Reason
+}
+
+// EventDecodingError is returned by NewEventFromHTTPRequest when a request
+// doesn't carry a valid CloudEvent.
+type EventDecodingError struct {
+	// Attributes are the invalid attributes, sorted by name. It is empty when
+	// the request is not a CloudEvent at all.
+	Attributes []AttributeError
+	// Err is the underlying decoding error, if any.
+	Err error
+}
+
+func (e *EventDecodingError) Error() string {
+	if len(e.Attributes) == 0 {
+		return fmt.Sprintf("invalid CloudEvent: %v", e.Err)
+	}
+	reasons := make([]string, 0, len(e.Attributes))
+	for _, a := range e.Attributes {
+		reasons = append(reasons, a.Error())
+	}
+	return "invalid CloudEvent: " + strings.Join(reasons, "; ")
+}
+
+func (e *EventDecodingError) Unwrap() error {
+	return e.Err
+}
+
+// NewEventFromHTTPRequest decodes the CloudEvent of a request in binary or
+// structured mode and validates it. Unlike the SDK, it rejects repeated
+// attribute headers and reports every invalid attribute by name in the
+// returned *EventDecodingError.
+func NewEventFromHTTPRequest(request *nethttp.Request) (*event.Event, error) {
+	message := cehttp.NewMessageFromHttpRequest(request)
+	defer message.Finish(nil)
+
+	var attrErrs []AttributeError
+	switch message.ReadEncoding() {
+	case binding.EncodingBinary:
+		attrErrs = validateBinaryHeaders(request.Header)
+	case binding.EncodingStructured:
+		body, err := io.ReadAll(message.BodyReader)
+		if err != nil {
+			return nil, &EventDecodingError{Err: fmt.Errorf("failed to read body: %w", err)}
+		}
+		message.BodyReader = io.NopCloser(bytes.NewReader(body))
+		if attrErrs, err = validateStructuredAttributes(body); err != nil {
+			return nil, &EventDecodingError{Err: err}
+		}
+	case binding.EncodingBatch:
+		return nil, &EventDecodingError{Err: errors.New("batched CloudEvents are not supported")}
+	default:
+		if specVersion := request.Header.Get(binaryHeaderPrefix + "Specversion"); specVersion != "" {
+			return nil, newEventDecodingError([]AttributeError{{
+				Attribute: "specversion",
+				Reason:    fmt.Sprintf("unsupported version %q", specVersion),
+			}})
+		}
+		return nil, &EventDecodingError{Err: errors.New("missing ce-specversion header or structured CloudEvents content type")}
+	}
+	if len(attrErrs) > 0 {
+		return nil, newEventDecodingError(attrErrs)
+	}
+
+	e, err := binding.ToEvent(request.Context(), message)
+	if err != nil {
+		return nil, &EventDecodingError{Err: err}
+	}
+
+	if err := e.Validate(); err != nil {
+		var validationErr event.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil, &EventDecodingError{Err: err}
+		}
+		for attr, reason := range validationErr {
+			attrErrs = append(attrErrs, AttributeError{Attribute: attr, Reason: reason.Error()})
+		}
+		return nil, newEventDecodingError(attrErrs)
+	}
+	return e, nil
+}
+
+// NewEventsFromHTTPRequest decodes the batched CloudEvents of a request in the
+// application/cloudevents-batch+json content type and validates them. The
+// invalid attributes are named after the index of their event in the batch,
+// e.g. "1.id", in the returned *EventDecodingError.
+func NewEventsFromHTTPRequest(request *nethttp.Request) ([]*event.Event, error) {
+	if !cehttp.IsHTTPBatch(request.Header) {
+		return nil, &EventDecodingError{Err: fmt.Errorf("content type %q is not %s", request.Header.Get("Content-Type"), event.ApplicationCloudEventsBatchJSON)}
+	}
+	events, err := cehttp.NewEventsFromHTTPRequest(request)
+	if err != nil {
+		return nil, &EventDecodingError{Err: err}
+	}
+	if len(events) == 0 {
+		return nil, &EventDecodingError{Err: errors.New("empty batch of CloudEvents")}
+	}
+
+	var attrErrs []AttributeError
+	batch := make([]*event.Event, 0, len(events))
+	for i := range events {
+		if err := events[i].Validate(); err != nil {
+			var validationErr event.ValidationError
+			if !errors.As(err, &validationErr) {
+				return nil, &EventDecodingError{Err: fmt.Errorf("event %d: %w", i, err)}
+			}
+			for attr, reason := range validationErr {
+				attrErrs = append(attrErrs, AttributeError{Attribute: fmt.Sprintf("%d.%s", i, attr), Reason: reason.Error()})
+			}
+		}
+		batch = append(batch, &events[i])
+	}
+	if len(attrErrs) > 0 {
+		return nil, newEventDecodingError(attrErrs)
+	}
+	return batch, nil
+}
+
+// WriteEventDecodingError responds to a request whose CloudEvent couldn't be
+// decoded with 400 Bad Request, describing the error in the body.
+func WriteEventDecodingError(writer nethttp.ResponseWriter, err error) {
+	nethttp.Error(writer, err.Error(), nethttp.StatusBadRequest)
+}
+
+func newEventDecodingError(attrErrs []AttributeError) *EventDecodingError {
+	sort.Slice(attrErrs, func(i, j int) bool {
+		return attrErrs[i].Attribute < attrErrs[j].Attribute
+	})
+	return &EventDecodingError{Attributes: attrErrs}
+}
+
+func validateBinaryHeaders(header nethttp.Header) []AttributeError {
+	var attrErrs []AttributeError
+	for key, values := range header {
+		if len(key) <= len(binaryHeaderPrefix) || !strings.HasPrefix(key, binaryHeaderPrefix) {
+			continue
+		}
+		name := strings.ToLower(key[len(binaryHeaderPrefix):])
+		if len(values) > 1 {
+			attrErrs = append(attrErrs, AttributeError{Attribute: name, Reason: "set more than once"})
+			continue
+		}
+		if attrErr := validateAttribute(name, values[0]); attrErr != nil {
+			attrErrs = append(attrErrs, *attrErr)
+		}
+	}
+	return attrErrs
+}
+
+func validateStructuredAttributes(body []byte) ([]AttributeError, error) {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(body, &attributes); err != nil {
+		return nil, fmt.Errorf("malformed structured CloudEvent: %w", err)
+	}
+
+	var attrErrs []AttributeError
+	for name, raw := range attributes {
+		if name == "data" || name == "data_base64" {
+			continue
+		}
+		if !isContextAttribute(name) {
+			if attrErr := validateAttribute(name, ""); attrErr != nil {
+				attrErrs = append(attrErrs, *attrErr)
+			}
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			attrErrs = append(attrErrs, AttributeError{Attribute: name, Reason: "must be a string"})
+			continue
+		}
+		if attrErr := validateAttribute(name, value); attrErr != nil {
+			attrErrs = append(attrErrs, *attrErr)
+		}
+	}
+	return attrErrs, nil
+}
+
+func isContextAttribute(name string) bool {
+	switch name {
+	case "specversion", "id", "source", "type", "subject", "time", "dataschema", "schemaurl", "datacontenttype", "datacontentencoding":
+		return true
+	}
+	return false
+}
+
+// validateAttribute validates an attribute name and, for context attributes,
+// its value.
+func validateAttribute(name, value string) *AttributeError {
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return &AttributeError{Attribute: name, Reason: "attribute names must only contain ASCII letters and digits"}
+		}
+	}
+
+	var reason string
+	switch name {
+	case "specversion":
+		if value != event.CloudEventsVersionV1 && value != event.CloudEventsVersionV03 {
+			reason = fmt.Sprintf("unsupported version %q", value)
+		}
+	case "id", "type":
+		if value == "" {
+			reason = "must be a non-empty string"
+		}
+	case "source":
+		if value == "" {
+			reason = "must be a non-empty URI-reference"
+		} else if types.ParseURIRef(value) == nil {
+			reason = fmt.Sprintf("invalid URI-reference %q", value)
+		}
+	case "dataschema", "schemaurl":
+		if value != "" && types.ParseURI(value) == nil {
+			reason = fmt.Sprintf("invalid URI %q", value)
+		}
+	case "time":
+		if _, err := types.ParseTime(value); err != nil {
+			reason = fmt.Sprintf("invalid RFC 3339 timestamp %q", value)
+		}
+	}
+	if reason == "" {
+		return nil
+	}
+	return &AttributeError{Attribute: name, Reason: reason}
+}
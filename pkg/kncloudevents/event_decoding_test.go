@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"errors"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newBinaryRequest(headers map[string][]string, body string) *nethttp.Request {
+	request := httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(body))
+	for k, v := range map[string]string{
+		"Ce-Specversion": "1.0",
+		"Ce-Id":          "1234",
+		"Ce-Source":      "/source",
+		"Ce-Type":        "dev.knative.test",
+	} {
+		request.Header.Set(k, v)
+	}
+	for k, v := range headers {
+		if v == nil {
+			request.Header.Del(k)
+			continue
+		}
+		request.Header[k] = v
+	}
+	return request
+}
+
+func newStructuredRequest(body string) *nethttp.Request {
+	request := httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/cloudevents+json")
+	return request
+}
+
+func TestNewEventFromHTTPRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *nethttp.Request
+		wantAttributes []string
+		wantErr        string
+	}{{
+		name:    "valid binary event",
+		request: newBinaryRequest(map[string][]string{"Ce-Time": {"2024-06-01T10:00:00Z"}, "Ce-Myext": {"value"}}, `{"hello":"world"}`),
+	}, {
+		name:    "valid structured event",
+		request: newStructuredRequest(`{"specversion":"1.0","id":"1234","source":"/source","type":"dev.knative.test","myext":1,"data":{"hello":"world"}}`),
+	}, {
+		name:    "not a CloudEvent",
+		request: httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader("hello")),
+		wantErr: "invalid CloudEvent: missing ce-specversion header or structured CloudEvents content type",
+	}, {
+		name:           "unsupported binary specversion",
+		request:        newBinaryRequest(map[string][]string{"Ce-Specversion": {"2.0"}}, ""),
+		wantAttributes: []string{"specversion"},
+	}, {
+		name:           "missing binary id",
+		request:        newBinaryRequest(map[string][]string{"Ce-Id": nil}, ""),
+		wantAttributes: []string{"id"},
+	}, {
+		name:           "invalid binary attributes",
+		request:        newBinaryRequest(map[string][]string{"Ce-Time": {"yesterday"}, "Ce-Source": {"%%%"}, "Ce-Bad_ext": {"x"}}, ""),
+		wantAttributes: []string{"bad_ext", "source", "time"},
+		wantErr:        `invalid CloudEvent: bad_ext: attribute names must only contain ASCII letters and digits; source: invalid URI-reference "%%%"; time: invalid RFC 3339 timestamp "yesterday"`,
+	}, {
+		name:           "repeated binary attribute",
+		request:        newBinaryRequest(map[string][]string{"Ce-Type": {"a", "b"}}, ""),
+		wantAttributes: []string{"type"},
+	}, {
+		name:    "malformed structured event",
+		request: newStructuredRequest(`{"specversion":`),
+		wantErr: "invalid CloudEvent: malformed structured CloudEvent: unexpected end of JSON input",
+	}, {
+		name:           "invalid structured attributes",
+		request:        newStructuredRequest(`{"specversion":"1.0","id":1234,"source":"/source","type":"","dataschema":"::"}`),
+		wantAttributes: []string{"dataschema", "id", "type"},
+	}, {
+		name:    "batched events",
+		request: httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader("[]")),
+		wantErr: "invalid CloudEvent: batched CloudEvents are not supported",
+	}}
+
+	tests[len(tests)-1].request.Header.Set("Content-Type", "application/cloudevents-batch+json")
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := NewEventFromHTTPRequest(tc.request)
+			if tc.wantAttributes == nil && tc.wantErr == "" {
+				require.NoError(t, err)
+				require.Equal(t, "1234", e.ID())
+				require.NoError(t, e.Validate())
+				return
+			}
+
+			var decodingErr *EventDecodingError
+			require.True(t, errors.As(err, &decodingErr), "expected an EventDecodingError, got %v", err)
+			if tc.wantErr != "" {
+				require.Equal(t, tc.wantErr, err.Error())
+			}
+			attributes := make([]string, 0, len(decodingErr.Attributes))
+			for _, a := range decodingErr.Attributes {
+				attributes = append(attributes, a.Attribute)
+			}
+			if tc.wantAttributes != nil {
+				require.Equal(t, tc.wantAttributes, attributes)
+			}
+		})
+	}
+}
+
+func TestNewEventsFromHTTPRequest(t *testing.T) {
+	newBatchRequest := func(body string) *nethttp.Request {
+		request := httptest.NewRequest(nethttp.MethodPost, "/", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/cloudevents-batch+json")
+		return request
+	}
+
+	tests := []struct {
+		name           string
+		request        *nethttp.Request
+		wantIDs        []string
+		wantAttributes []string
+		wantErr        string
+	}{{
+		name:    "valid batch",
+		request: newBatchRequest(`[{"specversion":"1.0","id":"1","source":"/s","type":"t"},{"specversion":"1.0","id":"2","source":"/s","type":"t","data":{"a":1}}]`),
+		wantIDs: []string{"1", "2"},
+	}, {
+		name:           "invalid events",
+		request:        newBatchRequest(`[{"specversion":"1.0","id":"1","source":"/s","type":"t"},{"specversion":"1.0","id":"","source":"/s","type":""}]`),
+		wantAttributes: []string{"1.id", "1.type"},
+	}, {
+		name:    "empty batch",
+		request: newBatchRequest(`[]`),
+		wantErr: "invalid CloudEvent: empty batch of CloudEvents",
+	}, {
+		name:    "malformed batch",
+		request: newBatchRequest(`[{"specversion":`),
+	}, {
+		name:    "structured event",
+		request: newStructuredRequest(`{"specversion":"1.0","id":"1","source":"/s","type":"t"}`),
+		wantErr: `invalid CloudEvent: content type "application/cloudevents+json" is not application/cloudevents-batch+json`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := NewEventsFromHTTPRequest(tc.request)
+			if tc.wantIDs != nil {
+				require.NoError(t, err)
+				ids := make([]string, 0, len(events))
+				for _, e := range events {
+					ids = append(ids, e.ID())
+				}
+				require.Equal(t, tc.wantIDs, ids)
+				return
+			}
+
+			var decodingErr *EventDecodingError
+			require.True(t, errors.As(err, &decodingErr), "expected an EventDecodingError, got %v", err)
+			if tc.wantErr != "" {
+				require.Equal(t, tc.wantErr, err.Error())
+			}
+			if tc.wantAttributes != nil {
+				attributes := make([]string, 0, len(decodingErr.Attributes))
+				for _, a := range decodingErr.Attributes {
+					attributes = append(attributes, a.Attribute)
+				}
+				require.Equal(t, tc.wantAttributes, attributes)
+			}
+		})
+	}
+}
+
+func TestWriteEventDecodingError(t *testing.T) {
+	_, err := NewEventFromHTTPRequest(newBinaryRequest(map[string][]string{"Ce-Id": {""}}, ""))
+	require.Error(t, err)
+
+	recorder := httptest.NewRecorder()
+	WriteEventDecodingError(recorder, err)
+
+	require.Equal(t, nethttp.StatusBadRequest, recorder.Code)
+	require.Equal(t, "invalid CloudEvent: id: must be a non-empty string\n", recorder.Body.String())
+}
+
+// FuzzNewEventFromHTTPRequest checks that decoding arbitrary requests never
+// panics and either returns a valid event or an EventDecodingError.
+func FuzzNewEventFromHTTPRequest(f *testing.F) {
+	f.Add("", "Ce-Myext", "value", `{"hello":"world"}`)
+	f.Add("", "Ce-Time", "2024-06-01T10:00:00Z", "")
+	f.Add("", "Ce-Specversion", "0.3", "")
+	f.Add("application/cloudevents+json", "", "", `{"specversion":"1.0","id":"1","source":"/s","type":"t","data":"x"}`)
+	f.Add("application/cloudevents+json; charset=utf-8", "", "", `{"specversion":"1.0","id":"1","source":"/s","type":"t","data_base64":"eA=="}`)
+	f.Add("application/cloudevents-batch+json", "", "", `[]`)
+
+	f.Fuzz(func(t *testing.T, contentType, headerName, headerValue, body string) {
+		request := newBinaryRequest(nil, body)
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		if headerName != "" {
+			request.Header[headerName] = []string{headerValue}
+		}
+
+		e, err := NewEventFromHTTPRequest(request)
+		if err != nil {
+			var decodingErr *EventDecodingError
+			if !errors.As(err, &decodingErr) {
+				t.Fatalf("Expected an EventDecodingError, got %T: %v", err, err)
+			}
+			return
+		}
+		if err := e.Validate(); err != nil {
+			t.Fatalf("Decoded an invalid event: %v", err)
+		}
+	})
+}
@@ -64,6 +64,9 @@ type DispatchInfo struct {
 	ResponseHeader http.Header
 	ResponseBody   []byte
 	Scheme         string
+	// DeadLetterCause is the error that caused the message to be sent to
+	// the dead letter sink instead, if it was.
+	DeadLetterCause error
 }
 
 type SendOption func(*senderConfig) error
@@ -76,6 +79,17 @@ func WithReply(reply *duckv1.Addressable) SendOption {
 	}
 }
 
+// WithTerminalReply sends the replies with the
+// attributes.KnativeTerminateExtensionKey extension set to true to
+// terminalReply instead of the reply.
+func WithTerminalReply(terminalReply *duckv1.Addressable) SendOption {
+	return func(sc *senderConfig) error {
+		sc.terminalReply = terminalReply
+
+		return nil
+	}
+}
+
 func WithDeadLetterSink(dls *duckv1.Addressable) SendOption {
 	return func(sc *senderConfig) error {
 		sc.deadLetterSink = dls
@@ -92,6 +106,17 @@ func WithRetryConfig(retryConfig *RetryConfig) SendOption {
 	}
 }
 
+// WithAttemptObserver calls observer before every attempt to send the event
+// to the destination, with the number of attempts already made. It is not
+// called for the reply and the dead letter sink, nor without a retry config.
+func WithAttemptObserver(observer func(attempt int)) SendOption {
+	return func(sc *senderConfig) error {
+		sc.attemptObserver = observer
+
+		return nil
+	}
+}
+
 func WithHeader(header http.Header) SendOption {
 	return func(sc *senderConfig) error {
 		sc.additionalHeaders = header
@@ -134,9 +159,11 @@ func WithEventTypeAutoHandler(handler *eventtype.EventTypeAutoHandler, ref *duck
 
 type senderConfig struct {
 	reply                *duckv1.Addressable
+	terminalReply        *duckv1.Addressable
 	deadLetterSink       *duckv1.Addressable
 	additionalHeaders    http.Header
 	retryConfig          *RetryConfig
+	attemptObserver      func(attempt int)
 	transformers         binding.Transformers
 	oidcServiceAccount   *types.NamespacedName
 	eventTypeAutoHandler *eventtype.EventTypeAutoHandler
@@ -203,6 +230,7 @@ func (d *Dispatcher) send(ctx context.Context, message binding.Message, destinat
 	// sanitize eventual host-only URLs
 	destination = *sanitizeAddressable(&destination)
 	config.reply = sanitizeAddressable(config.reply)
+	config.terminalReply = sanitizeAddressable(config.terminalReply)
 	config.deadLetterSink = sanitizeAddressable(config.deadLetterSink)
 
 	// send to destination
@@ -214,18 +242,19 @@ func (d *Dispatcher) send(ctx context.Context, message binding.Message, destinat
 	}
 	additionalHeadersForDestination.Set("Prefer", "reply")
 
-	ctx, responseMessage, dispatchExecutionInfo, err := d.executeRequest(ctx, destination, message, additionalHeadersForDestination, config.retryConfig, config.oidcServiceAccount, config.transformers)
+	ctx, responseMessage, dispatchExecutionInfo, err := d.executeRequest(ctx, destination, message, additionalHeadersForDestination, config.retryConfig, config.attemptObserver, config.oidcServiceAccount, config.transformers)
 	if err != nil {
 		// If DeadLetter is configured, then send original message with knative error extensions
 		if config.deadLetterSink != nil {
 			dispatchTransformers := dispatchExecutionInfoTransformers(destination.URL, dispatchExecutionInfo)
-			_, deadLetterResponse, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, *config.deadLetterSink, message, config.additionalHeaders, config.retryConfig, config.oidcServiceAccount, append(config.transformers, dispatchTransformers))
+			_, deadLetterResponse, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, *config.deadLetterSink, message, config.additionalHeaders, config.retryConfig, nil, config.oidcServiceAccount, append(config.transformers, dispatchTransformers))
 			if deadLetterErr != nil {
 				return dispatchExecutionInfo, fmt.Errorf("unable to complete request to either %s (%v) or %s (%v)", destination.URL, err, config.deadLetterSink.URL, deadLetterErr)
 			}
 			if deadLetterResponse != nil {
 				messagesToFinish = append(messagesToFinish, deadLetterResponse)
 			}
+			dispatchExecutionInfo.DeadLetterCause = err
 
 			return dispatchExecutionInfo, nil
 		}
@@ -257,29 +286,43 @@ func (d *Dispatcher) send(ctx context.Context, message binding.Message, destinat
 		}
 	}
 
-	if config.reply == nil {
+	reply := config.reply
+	if config.terminalReply != nil {
+		// messages can only be read once, so we need to make a copy of it
+		responseMessage, err = buffering.CopyMessage(ctx, responseMessage)
+		if err != nil {
+			return dispatchExecutionInfo, fmt.Errorf("failed to read the reply: %w", err)
+		}
+		messagesToFinish = append(messagesToFinish, responseMessage)
+		if isTerminal(ctx, responseMessage) {
+			reply = config.terminalReply
+		}
+	}
+
+	if reply == nil {
 		return dispatchExecutionInfo, nil
 	}
 
 	// send reply
 
-	ctx, responseResponseMessage, dispatchExecutionInfo, err := d.executeRequest(ctx, *config.reply, responseMessage, responseAdditionalHeaders, config.retryConfig, config.oidcServiceAccount, config.transformers)
+	ctx, responseResponseMessage, dispatchExecutionInfo, err := d.executeRequest(ctx, *reply, responseMessage, responseAdditionalHeaders, config.retryConfig, nil, config.oidcServiceAccount, config.transformers)
 	if err != nil {
 		// If DeadLetter is configured, then send original message with knative error extensions
 		if config.deadLetterSink != nil {
-			dispatchTransformers := dispatchExecutionInfoTransformers(config.reply.URL, dispatchExecutionInfo)
-			_, deadLetterResponse, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, *config.deadLetterSink, message, responseAdditionalHeaders, config.retryConfig, config.oidcServiceAccount, append(config.transformers, dispatchTransformers))
+			dispatchTransformers := dispatchExecutionInfoTransformers(reply.URL, dispatchExecutionInfo)
+			_, deadLetterResponse, dispatchExecutionInfo, deadLetterErr := d.executeRequest(ctx, *config.deadLetterSink, message, responseAdditionalHeaders, config.retryConfig, nil, config.oidcServiceAccount, append(config.transformers, dispatchTransformers))
 			if deadLetterErr != nil {
-				return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s (%v) and failed to send it to the dead letter sink %s (%v)", config.reply.URL, err, config.deadLetterSink.URL, deadLetterErr)
+				return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s (%v) and failed to send it to the dead letter sink %s (%v)", reply.URL, err, config.deadLetterSink.URL, deadLetterErr)
 			}
 			if deadLetterResponse != nil {
 				messagesToFinish = append(messagesToFinish, deadLetterResponse)
 			}
+			dispatchExecutionInfo.DeadLetterCause = err
 
 			return dispatchExecutionInfo, nil
 		}
 		// No DeadLetter, just fail
-		return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s: %w", config.reply.URL, err)
+		return dispatchExecutionInfo, fmt.Errorf("failed to forward reply to %s: %w", reply.URL, err)
 	}
 	if responseResponseMessage != nil {
 		messagesToFinish = append(messagesToFinish, responseResponseMessage)
@@ -288,7 +331,7 @@ func (d *Dispatcher) send(ctx context.Context, message binding.Message, destinat
 	return dispatchExecutionInfo, nil
 }
 
-func (d *Dispatcher) executeRequest(ctx context.Context, target duckv1.Addressable, message cloudevents.Message, additionalHeaders http.Header, retryConfig *RetryConfig, oidcServiceAccount *types.NamespacedName, transformers ...binding.Transformer) (context.Context, cloudevents.Message, *DispatchInfo, error) {
+func (d *Dispatcher) executeRequest(ctx context.Context, target duckv1.Addressable, message cloudevents.Message, additionalHeaders http.Header, retryConfig *RetryConfig, attemptObserver func(attempt int), oidcServiceAccount *types.NamespacedName, transformers ...binding.Transformer) (context.Context, cloudevents.Message, *DispatchInfo, error) {
 	var scheme string
 	if target.URL != nil {
 		scheme = target.URL.Scheme
@@ -321,7 +364,7 @@ func (d *Dispatcher) executeRequest(ctx context.Context, target duckv1.Addressab
 	}
 
 	start := time.Now()
-	response, err := client.DoWithRetries(req, retryConfig)
+	response, err := client.DoWithRetries(req, retryConfig, attemptObserver)
 	dispatchInfo.Duration = time.Since(start)
 	if err != nil {
 		dispatchInfo.ResponseCode = http.StatusInternalServerError
@@ -378,6 +421,14 @@ func (d *Dispatcher) handleAutocreate(ctx context.Context, msg binding.Message,
 	config.eventTypeAutoHandler.AutoCreateEventType(ctx, responseEvent, config.eventTypeRef, config.eventTypeOnwerUID)
 }
 
+func isTerminal(ctx context.Context, msg binding.Message) bool {
+	responseEvent, err := binding.ToEvent(ctx, msg)
+	if err != nil {
+		return false
+	}
+	return attributes.IsTerminal(responseEvent)
+}
+
 func (d *Dispatcher) createRequest(ctx context.Context, message binding.Message, target duckv1.Addressable, additionalHeaders http.Header, oidcServiceAccount *types.NamespacedName, transformers ...binding.Transformer) (*http.Request, error) {
 	request, err := http.NewRequestWithContext(ctx, "POST", target.URL.String(), nil)
 	if err != nil {
@@ -399,6 +450,9 @@ func (d *Dispatcher) createRequest(ctx context.Context, message binding.Message,
 				return nil, fmt.Errorf("could not get JWT: %w", err)
 			}
 			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+			if replayProtectionEnabled.Load() {
+				SetReplayProtectionHeaders(request)
+			}
 		}
 	}
 
@@ -425,7 +479,7 @@ func (c *client) Do(req *http.Request) (*http.Response, error) {
 	return c.Client.Do(req)
 }
 
-func (c *client) DoWithRetries(req *http.Request, retryConfig *RetryConfig) (*http.Response, error) {
+func (c *client) DoWithRetries(req *http.Request, retryConfig *RetryConfig, attemptObserver func(attempt int)) (*http.Response, error) {
 	if retryConfig == nil {
 		return c.Do(req)
 	}
@@ -451,6 +505,16 @@ func (c *client) DoWithRetries(req *http.Request, retryConfig *RetryConfig) (*ht
 			return resp, err
 		},
 	}
+	retryableClient.RequestLogHook = func(_ retryablehttp.Logger, r *http.Request, attempt int) {
+		// Retries are new requests for the receivers protected against
+		// replays.
+		if attempt > 0 && r.Header.Get(RequestNonceHeader) != "" {
+			SetReplayProtectionHeaders(r)
+		}
+		if attemptObserver != nil {
+			attemptObserver(attempt)
+		}
+	}
 
 	retryableReq, err := retryablehttp.FromRequest(req)
 	if err != nil {
@@ -467,7 +531,7 @@ func dispatchExecutionInfoTransformers(destination *apis.URL, dispatchExecutionI
 	}
 
 	httpResponseBody := dispatchExecutionInfo.ResponseBody
-	if destination.Host == network.GetServiceHostname("broker-filter", system.Namespace()) {
+	if destination.URL().Hostname() == network.GetServiceHostname("broker-filter", system.Namespace()) {
 
 		var errExtensionInfo broker.ErrExtensionInfo
 
@@ -52,6 +52,7 @@ import (
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/eventingtls/eventingtlstesting"
 	"knative.dev/eventing/pkg/kncloudevents"
+	"knative.dev/eventing/pkg/kncloudevents/attributes"
 	"knative.dev/eventing/pkg/utils"
 )
 
@@ -927,6 +928,93 @@ func TestSendEvent(t *testing.T) {
 	}
 }
 
+func TestSendEventWithAttemptObserver(t *testing.T) {
+	ctx, _ := rectesting.SetupFakeContext(t)
+
+	failures := 2
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer destination.Close()
+	deadLetter := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer deadLetter.Close()
+
+	retryConfig := kncloudevents.RetryConfig{
+		RetryMax:   3,
+		CheckRetry: kncloudevents.SelectiveRetry,
+		Backoff: func(int, *http.Response) time.Duration {
+			return time.Millisecond
+		},
+	}
+	var attempts []int
+	dispatcher := kncloudevents.NewDispatcher(eventingtls.NewDefaultClientConfig(), auth.NewOIDCTokenProvider(ctx))
+	info, err := dispatcher.SendEvent(ctx, test.FullEvent(), duckv1.Addressable{URL: apis.HTTP(destination.URL[7:])},
+		kncloudevents.WithRetryConfig(&retryConfig),
+		kncloudevents.WithDeadLetterSink(&duckv1.Addressable{URL: apis.HTTP(deadLetter.URL[7:])}),
+		kncloudevents.WithAttemptObserver(func(attempt int) {
+			attempts = append(attempts, attempt)
+		}),
+	)
+	require.Nil(t, err)
+	require.Equal(t, http.StatusAccepted, info.ResponseCode)
+	require.Equal(t, []int{0, 1, 2}, attempts)
+}
+
+func TestSendEventWithTerminalReply(t *testing.T) {
+	for name, terminal := range map[string]bool{"terminal reply": true, "regular reply": false} {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := rectesting.SetupFakeContext(t)
+
+			destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("ce-specversion", "1.0")
+				w.Header().Set("ce-id", "reply-id")
+				w.Header().Set("ce-type", testCeType)
+				w.Header().Set("ce-source", testCeSource)
+				if terminal {
+					w.Header().Set("ce-"+attributes.KnativeTerminateExtensionKey, "true")
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer destination.Close()
+
+			var mu sync.Mutex
+			received := make(map[string]int)
+			sink := func(name string) *httptest.Server {
+				return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					mu.Lock()
+					received[name]++
+					mu.Unlock()
+					w.WriteHeader(http.StatusAccepted)
+				}))
+			}
+			reply := sink("reply")
+			defer reply.Close()
+			terminalReply := sink("terminalReply")
+			defer terminalReply.Close()
+
+			dispatcher := kncloudevents.NewDispatcher(eventingtls.NewDefaultClientConfig(), auth.NewOIDCTokenProvider(ctx))
+			_, err := dispatcher.SendEvent(ctx, test.FullEvent(), duckv1.Addressable{URL: apis.HTTP(destination.URL[7:])},
+				kncloudevents.WithReply(&duckv1.Addressable{URL: apis.HTTP(reply.URL[7:])}),
+				kncloudevents.WithTerminalReply(&duckv1.Addressable{URL: apis.HTTP(terminalReply.URL[7:])}),
+			)
+			require.Nil(t, err)
+
+			want := map[string]int{"reply": 1}
+			if terminal {
+				want = map[string]int{"terminalReply": 1}
+			}
+			require.Equal(t, want, received)
+		})
+	}
+}
+
 func TestDispatchMessageToTLSEndpoint(t *testing.T) {
 	var wg sync.WaitGroup
 	ctx, _ := rectesting.SetupFakeContext(t)
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.opencensus.io/plugin/ochttp"
@@ -31,11 +33,55 @@ import (
 
 const (
 	DefaultShutdownTimeout = time.Minute * 1
+
+	// DefaultReadHeaderTimeout bounds the time clients have to send the
+	// request headers, independently of the ReadTimeout which may be long to
+	// allow large events, so that slow clients can't hold connections open.
+	DefaultReadHeaderTimeout = 10 * time.Second
 )
 
+// AddressFamily is the IP address family a HTTPEventReceiver listens on.
+type AddressFamily string
+
+const (
+	// AddressFamilyDual listens on both IPv4 and IPv6, when available.
+	AddressFamilyDual AddressFamily = "dual"
+	// AddressFamilyIPv4 only listens on IPv4.
+	AddressFamilyIPv4 AddressFamily = "ipv4"
+	// AddressFamilyIPv6 only listens on IPv6.
+	AddressFamilyIPv6 AddressFamily = "ipv6"
+)
+
+// ParseAddressFamily parses an AddressFamily, the empty string being
+// AddressFamilyDual.
+func ParseAddressFamily(s string) (AddressFamily, error) {
+	switch f := AddressFamily(strings.ToLower(strings.TrimSpace(s))); f {
+	case "", AddressFamilyDual:
+		return AddressFamilyDual, nil
+	case AddressFamilyIPv4, AddressFamilyIPv6:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported address family %q, expected one of %q, %q or %q", s, AddressFamilyDual, AddressFamilyIPv4, AddressFamilyIPv6)
+	}
+}
+
+func (f AddressFamily) network() string {
+	switch f {
+	case AddressFamilyIPv4:
+		return "tcp4"
+	case AddressFamilyIPv6:
+		return "tcp6"
+	default:
+		return "tcp"
+	}
+}
+
 type HTTPEventReceiver struct {
 	port int
 
+	bindAddress   string
+	addressFamily AddressFamily
+
 	server   *http.Server
 	listener net.Listener
 
@@ -79,6 +125,16 @@ func WithDrainQuietPeriod(duration time.Duration) HTTPEventReceiverOption {
 	}
 }
 
+// WithBindAddress configures the address and address family the receiver
+// listens on. An empty address listens on all the addresses of the family,
+// IPv6 literals don't need to be enclosed in brackets.
+func WithBindAddress(address string, family AddressFamily) HTTPEventReceiverOption {
+	return func(h *HTTPEventReceiver) {
+		h.bindAddress = strings.Trim(address, "[]")
+		h.addressFamily = family
+	}
+}
+
 // WithTLSConfig configures the TLS config for the receiver.
 func WithTLSConfig(cfg *tls.Config) HTTPEventReceiverOption {
 	return func(h *HTTPEventReceiver) {
@@ -102,6 +158,18 @@ func WithWriteTimeout(duration time.Duration) HTTPEventReceiverOption {
 	}
 }
 
+// WithReadHeaderTimeout sets the HTTP server's ReadHeaderTimeout, the time
+// allowed to read the request headers. It defaults to DefaultReadHeaderTimeout.
+func WithReadHeaderTimeout(duration time.Duration) HTTPEventReceiverOption {
+	return func(h *HTTPEventReceiver) {
+		if h.server == nil {
+			h.server = newServer()
+		}
+
+		h.server.ReadHeaderTimeout = duration
+	}
+}
+
 // WithReadTimeout sets the HTTP server's ReadTimeout. It covers the duration from reading the entire request
 // (Headers + Body)
 func WithReadTimeout(duration time.Duration) HTTPEventReceiverOption {
@@ -114,6 +182,17 @@ func WithReadTimeout(duration time.Duration) HTTPEventReceiverOption {
 	}
 }
 
+// Listen listens on the port, on the address and address family set by the
+// options, like a HTTPEventReceiver does, for the servers of other protocols.
+func Listen(port int, o ...HTTPEventReceiverOption) (net.Listener, error) {
+	return NewHTTPEventReceiver(port, o...).listen()
+}
+
+func (recv *HTTPEventReceiver) listen() (net.Listener, error) {
+	addr := net.JoinHostPort(recv.bindAddress, strconv.Itoa(recv.port))
+	return net.Listen(recv.addressFamily.network(), addr)
+}
+
 func (recv *HTTPEventReceiver) GetAddr() string {
 	if recv.server != nil {
 		return recv.server.Addr
@@ -125,7 +204,7 @@ func (recv *HTTPEventReceiver) GetAddr() string {
 // Blocking
 func (recv *HTTPEventReceiver) StartListen(ctx context.Context, handler http.Handler) error {
 	var err error
-	if recv.listener, err = net.Listen("tcp", fmt.Sprintf(":%d", recv.port)); err != nil {
+	if recv.listener, err = recv.listen(); err != nil {
 		return err
 	}
 
@@ -189,6 +268,7 @@ func CreateHandler(handler http.Handler) http.Handler {
 
 func newServer() *http.Server {
 	return &http.Server{
-		ReadTimeout: 10 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
 	}
 }
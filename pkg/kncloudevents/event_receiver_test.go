@@ -18,6 +18,8 @@ package kncloudevents
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net"
 	"net/http"
 	"testing"
@@ -199,6 +201,50 @@ func TestWithWriteTimeout(t *testing.T) {
 	assert.Equal(t, writeTimeout, eventReceiver.server.WriteTimeout)
 }
 
+func TestWithReadHeaderTimeout(t *testing.T) {
+	assert.Equal(t, DefaultReadHeaderTimeout, NewHTTPEventReceiver(0, WithReadTimeout(time.Minute)).server.ReadHeaderTimeout)
+
+	readHeaderTimeout := 30 * time.Second
+
+	eventReceiver := NewHTTPEventReceiver(0, WithReadHeaderTimeout(readHeaderTimeout))
+
+	assert.Equal(t, readHeaderTimeout, eventReceiver.server.ReadHeaderTimeout)
+}
+
+func TestStartListenClosesSlowHeaders(t *testing.T) {
+	receiver := NewHTTPEventReceiver(0,
+		WithReadTimeout(time.Hour),
+		WithReadHeaderTimeout(100*time.Millisecond),
+		WithDrainQuietPeriod(time.Millisecond),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error)
+	go func() {
+		errChan <- receiver.StartListen(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+	}()
+	<-receiver.Ready
+
+	conn, err := net.Dial("tcp", receiver.GetAddr())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	// Send part of the headers and never complete them.
+	_, err = conn.Write([]byte("POST / HTTP/1.1\r\nHost: example.com\r\nCe-Id: "))
+	assert.NoError(t, err)
+
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = io.ReadAll(conn)
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		t.Error("Expected the server to close the connection with incomplete headers")
+	}
+
+	cancel()
+	assert.NoError(t, <-errChan)
+}
+
 func TestWithReadTimeout(t *testing.T) {
 	readTimeout := 30 * time.Second
 
@@ -238,3 +284,98 @@ func (bh *blockingHandler) ServeHTTP(writer http.ResponseWriter, request *http.R
 	time.Sleep(bh.blockFor)
 	writer.WriteHeader(http.StatusOK)
 }
+
+func TestParseAddressFamily(t *testing.T) {
+	testCases := map[string]struct {
+		family  string
+		want    AddressFamily
+		wantErr bool
+	}{
+		"empty":   {family: "", want: AddressFamilyDual},
+		"dual":    {family: "dual", want: AddressFamilyDual},
+		"ipv4":    {family: "ipv4", want: AddressFamilyIPv4},
+		"ipv6":    {family: " IPv6 ", want: AddressFamilyIPv6},
+		"invalid": {family: "ipx", wantErr: true},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			got, err := ParseAddressFamily(tc.family)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestStartListenOnBindAddress(t *testing.T) {
+	testCases := map[string]struct {
+		address  string
+		family   AddressFamily
+		wantHost string
+		ipv6     bool
+	}{
+		"all addresses": {
+			family: AddressFamilyDual,
+		},
+		"IPv4 loopback": {
+			address:  "127.0.0.1",
+			family:   AddressFamilyIPv4,
+			wantHost: "127.0.0.1",
+		},
+		"IPv6 loopback": {
+			address:  "::1",
+			family:   AddressFamilyIPv6,
+			wantHost: "::1",
+			ipv6:     true,
+		},
+		"bracketed IPv6 loopback": {
+			address:  "[::1]",
+			family:   AddressFamilyDual,
+			wantHost: "::1",
+			ipv6:     true,
+		},
+	}
+
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if tc.ipv6 {
+				l, err := net.Listen("tcp6", "[::1]:0")
+				if err != nil {
+					t.Skip("IPv6 loopback is not available:", err)
+				}
+				l.Close()
+			}
+
+			receiver := NewHTTPEventReceiver(0, WithBindAddress(tc.address, tc.family), WithDrainQuietPeriod(time.Millisecond))
+			ctx, cancel := context.WithCancel(context.Background())
+			errChan := make(chan error)
+			go func() {
+				errChan <- receiver.StartListen(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusAccepted)
+				}))
+			}()
+			<-receiver.Ready
+
+			host, port, err := net.SplitHostPort(receiver.GetAddr())
+			assert.NoError(t, err)
+			if tc.wantHost != "" {
+				assert.Equal(t, tc.wantHost, host)
+			} else {
+				host = "localhost"
+			}
+
+			resp, err := http.Get("http://" + net.JoinHostPort(host, port))
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+				assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+			}
+
+			cancel()
+			assert.NoError(t, <-errChan)
+		})
+	}
+}
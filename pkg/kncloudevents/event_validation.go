@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// maxAttributeNameLength is the length attribute names should not exceed
+// according to the CloudEvents spec.
+const maxAttributeNameLength = 20
+
+// ValidateEventStrict checks that an event decoded by NewEventFromHTTPRequest
+// fully complies with the CloudEvents 1.0 spec, including the requirements
+// the decoding tolerates for interoperability. It returns an
+// *EventDecodingError describing every violation.
+func ValidateEventStrict(e *event.Event) error {
+	var attrErrs []AttributeError
+
+	if e.SpecVersion() != event.CloudEventsVersionV1 {
+		attrErrs = append(attrErrs, AttributeError{
+			Attribute: "specversion",
+			Reason:    fmt.Sprintf("unsupported version %q, %s is required", e.SpecVersion(), event.CloudEventsVersionV1),
+		})
+	}
+
+	for name := range e.Extensions() {
+		if reason := validateStrictAttributeName(name); reason != "" {
+			attrErrs = append(attrErrs, AttributeError{Attribute: name, Reason: reason})
+		}
+	}
+
+	if isJSONMediaType(e.DataMediaType()) && len(e.Data()) > 0 && !e.DataBase64 && !json.Valid(e.Data()) {
+		attrErrs = append(attrErrs, AttributeError{
+			Attribute: "data",
+			Reason:    fmt.Sprintf("invalid JSON for data content type %q", e.DataContentType()),
+		})
+	}
+
+	if len(attrErrs) > 0 {
+		return newEventDecodingError(attrErrs)
+	}
+	return nil
+}
+
+func validateStrictAttributeName(name string) string {
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return "attribute names must only contain lower-case ASCII letters and digits"
+		}
+	}
+	if len(name) > maxAttributeNameLength {
+		return fmt.Sprintf("attribute names must not exceed %d characters", maxAttributeNameLength)
+	}
+	return ""
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == event.ApplicationJSON || mediaType == event.TextJSON || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"errors"
+	nethttp "net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEventStrict(t *testing.T) {
+	tests := []struct {
+		name           string
+		request        *nethttp.Request
+		wantAttributes []string
+		wantErr        string
+	}{{
+		name:    "valid event",
+		request: newStructuredRequest(`{"specversion":"1.0","id":"1234","source":"/source","type":"dev.knative.test","myext":"x","datacontenttype":"application/json","data":{"hello":"world"}}`),
+	}, {
+		name:    "valid text data",
+		request: newStructuredRequest(`{"specversion":"1.0","id":"1234","source":"/source","type":"dev.knative.test","datacontenttype":"text/plain","data":"hello"}`),
+	}, {
+		name:           "legacy specversion",
+		request:        newStructuredRequest(`{"specversion":"0.3","id":"1234","source":"/source","type":"dev.knative.test"}`),
+		wantAttributes: []string{"specversion"},
+		wantErr:        `invalid CloudEvent: specversion: unsupported version "0.3", 1.0 is required`,
+	}, {
+		name:           "long extension name",
+		request:        newStructuredRequest(`{"specversion":"1.0","id":"1234","source":"/source","type":"dev.knative.test","averyveryverylongextension":"x"}`),
+		wantAttributes: []string{"averyveryverylongextension"},
+		wantErr:        "invalid CloudEvent: averyveryverylongextension: attribute names must not exceed 20 characters",
+	}, {
+		name:           "invalid JSON data",
+		request:        newBinaryRequest(map[string][]string{"Content-Type": {"application/json"}}, "{"),
+		wantAttributes: []string{"data"},
+		wantErr:        `invalid CloudEvent: data: invalid JSON for data content type "application/json"`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := NewEventFromHTTPRequest(tc.request)
+			require.NoError(t, err)
+
+			err = ValidateEventStrict(e)
+			if tc.wantAttributes == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var decodingErr *EventDecodingError
+			require.True(t, errors.As(err, &decodingErr), "expected an EventDecodingError, got %v", err)
+			if tc.wantErr != "" {
+				require.Equal(t, tc.wantErr, err.Error())
+			}
+			attributes := make([]string, 0, len(decodingErr.Attributes))
+			for _, a := range decodingErr.Attributes {
+				attributes = append(attributes, a.Attribute)
+			}
+			require.Equal(t, tc.wantAttributes, attributes)
+		})
+	}
+}
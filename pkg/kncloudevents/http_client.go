@@ -47,6 +47,7 @@ type clientsHolder struct {
 	clients         map[string]*nethttp.Client
 	timerMu         sync.Mutex
 	connectionArgs  *ConnectionArgs
+	proxyConfig     *ProxyConfig
 	cleanupInterval time.Duration
 	cancelCleanup   context.CancelFunc
 }
@@ -98,8 +99,17 @@ func createNewClient(cfg eventingtls.ClientConfig, addressable duckv1.Addressabl
 			}
 			return network.DialTLSWithBackOff(ctx, net, addr, tlsConfig)
 		}
+
+		// DialTLSContext isn't used for requests tunneled through a proxy,
+		// which are secured with TLSClientConfig instead.
+		tlsConfig, err := eventingtls.GetTLSClientConfig(clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS client config: %w", err)
+		}
+		base.TLSClientConfig = tlsConfig
 	}
 
+	base.Proxy = clients.proxyConfig.ProxyFunc()
 	clients.connectionArgs.configureTransport(base)
 	client := &nethttp.Client{
 		// Add output tracing.
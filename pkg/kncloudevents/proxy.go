@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	nethttp "net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/network"
+)
+
+const (
+	// ProxyConfigMapName is the name of the ConfigMap in the system namespace
+	// configuring the proxy used by dispatchers.
+	ProxyConfigMapName = "config-proxy"
+
+	HTTPProxyKey  = "http-proxy"
+	HTTPSProxyKey = "https-proxy"
+	NoProxyKey    = "no-proxy"
+)
+
+// ProxyConfig configures the HTTP proxy used to deliver events, with the same
+// semantics as the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+//
+// Destinations in the cluster, that is hosts ending in ".svc" or
+// ".svc.<cluster domain>", are never sent through the proxy.
+type ProxyConfig struct {
+	// HTTPProxy is the proxy used for http destinations.
+	HTTPProxy string
+	// HTTPSProxy is the proxy used for https destinations.
+	HTTPSProxy string
+	// NoProxy is a comma-separated list of hosts, domains, IP addresses or
+	// CIDR ranges, optionally with a port, bypassing the proxy.
+	NoProxy string
+}
+
+// ProxyConfigFromEnvironment returns the ProxyConfig described by the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables (or their
+// lowercase versions).
+func ProxyConfigFromEnvironment() *ProxyConfig {
+	cfg := httpproxy.FromEnvironment()
+	return &ProxyConfig{
+		HTTPProxy:  cfg.HTTPProxy,
+		HTTPSProxy: cfg.HTTPSProxy,
+		NoProxy:    cfg.NoProxy,
+	}
+}
+
+// NewProxyConfigFromConfigMap returns the ProxyConfig defined by the given
+// ConfigMap. When the ConfigMap is nil or doesn't set any proxy, the proxy
+// environment variables are used.
+func NewProxyConfigFromConfigMap(cm *corev1.ConfigMap) *ProxyConfig {
+	if cm == nil {
+		return ProxyConfigFromEnvironment()
+	}
+	cfg := &ProxyConfig{
+		HTTPProxy:  strings.TrimSpace(cm.Data[HTTPProxyKey]),
+		HTTPSProxy: strings.TrimSpace(cm.Data[HTTPSProxyKey]),
+		NoProxy:    strings.TrimSpace(cm.Data[NoProxyKey]),
+	}
+	if cfg.HTTPProxy == "" && cfg.HTTPSProxy == "" {
+		return ProxyConfigFromEnvironment()
+	}
+	return cfg
+}
+
+// ProxyFunc returns a function to be used as net/http.Transport.Proxy.
+// A nil ProxyConfig uses the proxy environment variables.
+func (pc *ProxyConfig) ProxyFunc() func(*nethttp.Request) (*url.URL, error) {
+	if pc == nil {
+		pc = ProxyConfigFromEnvironment()
+	}
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  pc.HTTPProxy,
+		HTTPSProxy: pc.HTTPSProxy,
+		NoProxy:    pc.NoProxy,
+	}).ProxyFunc()
+
+	clusterDomain := ".svc." + network.GetClusterDomainName()
+	return func(req *nethttp.Request) (*url.URL, error) {
+		host := req.URL.Hostname()
+		if strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, clusterDomain) {
+			return nil, nil
+		}
+		return proxyFunc(req.URL)
+	}
+}
+
+func (pc *ProxyConfig) equal(other *ProxyConfig) bool {
+	if pc == nil || other == nil {
+		return pc == other
+	}
+	return *pc == *other
+}
+
+// ConfigureProxy configures the proxy used by the clients dispatching events.
+// A nil ProxyConfig uses the proxy environment variables.
+// Like ConfigureConnectionArgs, changing the proxy resets the existing clients.
+func ConfigureProxy(pc *ProxyConfig) {
+	if pc == nil {
+		pc = ProxyConfigFromEnvironment()
+	}
+
+	clients.clientsMu.Lock()
+	defer clients.clientsMu.Unlock()
+
+	if pc.equal(clients.proxyConfig) {
+		return
+	}
+
+	for _, client := range clients.clients {
+		client.CloseIdleConnections()
+	}
+	clients.clients = make(map[string]*nethttp.Client)
+	clients.proxyConfig = pc
+}
+
+// WatchProxyConfig configures the proxy used by the clients dispatching
+// events from ProxyConfigMapName, falling back to the proxy environment
+// variables when the ConfigMap doesn't exist.
+func WatchProxyConfig(logger *zap.SugaredLogger, cmw configmap.Watcher) {
+	update := func(cm *corev1.ConfigMap) {
+		pc := NewProxyConfigFromConfigMap(cm)
+		// Proxy URLs may contain credentials, so they aren't logged.
+		logger.Infow("Updating proxy configuration",
+			zap.Bool("httpProxy", pc.HTTPProxy != ""),
+			zap.Bool("httpsProxy", pc.HTTPSProxy != ""),
+			zap.String("noProxy", pc.NoProxy))
+		ConfigureProxy(pc)
+	}
+
+	if dcmw, ok := cmw.(configmap.DefaultingWatcher); ok {
+		dcmw.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ProxyConfigMapName},
+			Data:       map[string]string{},
+		}, update)
+	} else {
+		cmw.Watch(ProxyConfigMapName, update)
+	}
+}
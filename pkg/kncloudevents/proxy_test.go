@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
+
+	"knative.dev/eventing/pkg/eventingtls"
+)
+
+func TestProxyFunc(t *testing.T) {
+	pc := &ProxyConfig{
+		HTTPProxy:  "http://http-proxy.example.com:3128",
+		HTTPSProxy: "http://https-proxy.example.com:3128",
+		NoProxy:    "internal.example.com,.corp.example.com,10.0.0.0/8",
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{{
+		name: "http destination",
+		url:  "http://sink.example.com/",
+		want: "http://http-proxy.example.com:3128",
+	}, {
+		name: "https destination",
+		url:  "https://sink.example.com/",
+		want: "http://https-proxy.example.com:3128",
+	}, {
+		name: "bypassed host",
+		url:  "https://internal.example.com/",
+	}, {
+		name: "bypassed domain",
+		url:  "http://sink.corp.example.com/",
+	}, {
+		name: "bypassed CIDR",
+		url:  "http://10.1.2.3:8080/",
+	}, {
+		name: "service",
+		url:  "http://sink.ns.svc/",
+	}, {
+		name: "service with cluster domain",
+		url:  "http://sink.ns.svc." + network.GetClusterDomainName() + "/",
+	}}
+
+	proxy := pc.ProxyFunc()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+
+			got, err := proxy(&nethttp.Request{URL: u})
+			require.NoError(t, err)
+			if tc.want == "" {
+				require.Nil(t, got)
+			} else {
+				require.Equal(t, tc.want, got.String())
+			}
+		})
+	}
+}
+
+func TestNewProxyConfigFromConfigMap(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+	t.Setenv("NO_PROXY", "internal.example.com")
+
+	fromEnv := &ProxyConfig{
+		HTTPSProxy: "http://env-proxy.example.com:3128",
+		NoProxy:    "internal.example.com",
+	}
+
+	tests := []struct {
+		name string
+		cm   *corev1.ConfigMap
+		want *ProxyConfig
+	}{{
+		name: "nil",
+		want: fromEnv,
+	}, {
+		name: "no proxy configured",
+		cm: &corev1.ConfigMap{Data: map[string]string{
+			"_example": "http-proxy: http://proxy.example.com",
+			NoProxyKey: "other.example.com",
+		}},
+		want: fromEnv,
+	}, {
+		name: "proxy configured",
+		cm: &corev1.ConfigMap{Data: map[string]string{
+			HTTPSProxyKey: " http://proxy.example.com:3128 ",
+			NoProxyKey:    "other.example.com",
+		}},
+		want: &ProxyConfig{
+			HTTPSProxy: "http://proxy.example.com:3128",
+			NoProxy:    "other.example.com",
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, NewProxyConfigFromConfigMap(tc.cm))
+		})
+	}
+}
+
+func TestConfigureProxy(t *testing.T) {
+	t.Cleanup(func() { ConfigureProxy(nil) })
+
+	proxied := make(chan string, 1)
+	proxy := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		proxied <- r.URL.String()
+		w.WriteHeader(nethttp.StatusAccepted)
+	}))
+	defer proxy.Close()
+
+	addressable := duckv1.Addressable{URL: apis.HTTP("sink.example.com")}
+
+	ConfigureProxy(&ProxyConfig{})
+	before, err := getClientForAddressable(eventingtls.ClientConfig{}, addressable)
+	require.NoError(t, err)
+
+	ConfigureProxy(&ProxyConfig{HTTPProxy: proxy.URL})
+	client, err := getClientForAddressable(eventingtls.ClientConfig{}, addressable)
+	require.NoError(t, err)
+	require.NotSame(t, before, client, "changing the proxy should reset the clients")
+
+	// The same configuration keeps the existing clients.
+	ConfigureProxy(&ProxyConfig{HTTPProxy: proxy.URL})
+	same, err := getClientForAddressable(eventingtls.ClientConfig{}, addressable)
+	require.NoError(t, err)
+	require.Same(t, client, same)
+
+	resp, err := client.Get(addressable.URL.String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, nethttp.StatusAccepted, resp.StatusCode)
+	require.Equal(t, "http://sink.example.com/", <-proxied)
+}
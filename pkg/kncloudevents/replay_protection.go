@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/configmap"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/signing"
+)
+
+const (
+	// RequestTimestampHeader is the header holding the Unix time, in
+	// seconds, at which an authenticated request was sent.
+	RequestTimestampHeader = "Knative-Request-Timestamp"
+	// RequestNonceHeader is the header holding the unique identifier of an
+	// authenticated request.
+	RequestNonceHeader = "Knative-Request-Nonce"
+	// RequestSignatureHeader is the header holding the base64 encoded
+	// HMAC-SHA256 signature of the timestamp, the nonce, the method, the path
+	// and the Authorization header of an authenticated request.
+	RequestSignatureHeader = "Knative-Request-Signature"
+	// RequestSignatureKeyIDHeader is the header holding the id of the key
+	// that produced RequestSignatureHeader.
+	RequestSignatureKeyIDHeader = "Knative-Request-Signature-Key-Id"
+
+	// DefaultReplayWindow is the default maximum difference between the
+	// timestamp of a request and the time it is received.
+	DefaultReplayWindow = 5 * time.Minute
+	// DefaultReplayCacheSize is the default maximum number of nonces
+	// remembered by a ReplayProtector without a shared NonceStore.
+	DefaultReplayCacheSize = 100_000
+)
+
+var (
+	// ErrReplayedRequest is returned when the nonce of a request was seen
+	// before.
+	ErrReplayedRequest = errors.New("request was replayed")
+
+	// ErrInvalidRequestSignature is returned when the replay protection
+	// headers of a request aren't signed with a known key.
+	ErrInvalidRequestSignature = errors.New("request signature is invalid")
+
+	// ErrNonceStoreUnavailable is returned when the nonce of a request can't
+	// be checked against the NonceStore.
+	ErrNonceStoreUnavailable = errors.New("nonce store is unavailable")
+)
+
+// replayProtection is the configuration set by ConfigureReplayProtection.
+type replayProtection struct {
+	signingKey *signing.Key
+	keys       signing.Keyring
+	store      NonceStore
+}
+
+var (
+	replayProtectionConfig atomic.Pointer[replayProtection]
+
+	// replayProtectionEnabled is whether the replay-protection feature is
+	// enabled, so that the dispatched requests carry the replay protection
+	// headers.
+	replayProtectionEnabled atomic.Bool
+)
+
+// ConfigureReplayProtection sets the keys the authenticated requests are
+// signed with, the first one, and verified with, and the NonceStore shared by
+// all the ReplayProtectors. The ReplayProtectors remember the nonces in memory
+// when the store is nil.
+func ConfigureReplayProtection(keys []signing.Key, store NonceStore) {
+	cfg := &replayProtection{
+		keys:  signing.NewKeyring(keys...),
+		store: store,
+	}
+	if len(keys) > 0 {
+		cfg.signingKey = &keys[0]
+	}
+	replayProtectionConfig.Store(cfg)
+}
+
+// LoadReplayProtection configures the replay protection with the keys read
+// from keyFiles, the nonces being remembered in memory.
+func LoadReplayProtection(keyFiles []string) error {
+	keys := make([]signing.Key, 0, len(keyFiles))
+	for _, file := range keyFiles {
+		key, err := signing.KeyFromFile(file, "")
+		if err != nil {
+			return fmt.Errorf("invalid replay protection key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	ConfigureReplayProtection(keys, nil)
+	return nil
+}
+
+// WatchReplayProtection sets the replay protection headers on the dispatched
+// authenticated requests while the replay-protection feature is enabled in
+// the config-features ConfigMap. It reports once, when the feature is
+// enabled, that all the authenticated requests are rejected because no key
+// is configured.
+func WatchReplayProtection(logger *zap.SugaredLogger, cmw configmap.Watcher) {
+	cmw.Watch(feature.FlagsConfigName, func(cm *corev1.ConfigMap) {
+		flags, err := feature.NewFlagsConfigFromConfigMap(cm)
+		if err != nil {
+			logger.Warnw("Invalid feature flags, keeping the replay protection configuration", zap.Error(err))
+			return
+		}
+		enabled := flags.IsEnabled(feature.ReplayProtection)
+		if replayProtectionEnabled.Swap(enabled) == enabled || !enabled {
+			return
+		}
+		if cfg := replayProtectionConfig.Load(); cfg == nil || len(cfg.keys) == 0 {
+			logger.Errorf("The %s feature is enabled but REPLAY_PROTECTION_KEY_FILES is empty, all the authenticated requests are rejected", feature.ReplayProtection)
+			return
+		}
+		logger.Infof("The %s feature is enabled", feature.ReplayProtection)
+	})
+}
+
+// SetReplayProtectionHeaders sets the timestamp, a new nonce and their
+// signature with the configured key on an authenticated request.
+func SetReplayProtectionHeaders(r *http.Request) {
+	r.Header.Set(RequestTimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+	r.Header.Set(RequestNonceHeader, uuid.NewString())
+
+	cfg := replayProtectionConfig.Load()
+	if cfg == nil || cfg.signingKey == nil {
+		r.Header.Del(RequestSignatureHeader)
+		r.Header.Del(RequestSignatureKeyIDHeader)
+		return
+	}
+	r.Header.Set(RequestSignatureKeyIDHeader, cfg.signingKey.ID)
+	r.Header.Set(RequestSignatureHeader, base64.StdEncoding.EncodeToString(requestDigest(r, *cfg.signingKey)))
+}
+
+// verifyRequestSignature checks the signature of the replay protection
+// headers of a request against the keyring.
+func verifyRequestSignature(r *http.Request, keys signing.Keyring) error {
+	sig, err := base64.StdEncoding.DecodeString(r.Header.Get(RequestSignatureHeader))
+	if err != nil || len(sig) == 0 {
+		return ErrInvalidRequestSignature
+	}
+	key, ok := keys[r.Header.Get(RequestSignatureKeyIDHeader)]
+	if !ok {
+		return ErrInvalidRequestSignature
+	}
+	if !hmac.Equal(sig, requestDigest(r, key)) {
+		return ErrInvalidRequestSignature
+	}
+	return nil
+}
+
+// requestDigest computes the HMAC of the replay protection headers, the
+// method, the path and the Authorization header of a request, so that none
+// of them can be changed, and a captured token can't be sent with new
+// headers.
+func requestDigest(r *http.Request, key signing.Key) []byte {
+	path := "/"
+	if r.URL != nil && r.URL.EscapedPath() != "" {
+		path = r.URL.EscapedPath()
+	}
+	mac := hmac.New(sha256.New, key.Secret)
+	writeDigestField(mac, r.Header.Get(RequestTimestampHeader))
+	writeDigestField(mac, r.Header.Get(RequestNonceHeader))
+	writeDigestField(mac, r.Method)
+	writeDigestField(mac, path)
+	writeDigestField(mac, r.Header.Get("Authorization"))
+	return mac.Sum(nil)
+}
+
+// writeDigestField writes a length prefixed field, so that moving bytes
+// between adjacent fields changes the digest.
+func writeDigestField(h hash.Hash, v string) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(v)))
+	h.Write(l[:])
+	h.Write([]byte(v))
+}
+
+// NonceStore remembers the nonces of the requests.
+type NonceStore interface {
+	// Remember records the nonce until expiry and returns false when it is
+	// already recorded.
+	Remember(ctx context.Context, nonce string, expiry time.Time) (bool, error)
+}
+
+// ReplayProtector rejects the requests whose timestamp is outside of its
+// window, whose replay protection headers aren't signed with one of the keys
+// set by ConfigureReplayProtection, or whose nonce was already seen. The
+// nonces are remembered until their request would be rejected for its
+// timestamp, in the NonceStore set by ConfigureReplayProtection so that the
+// replicas of a receiver share them, or else in memory.
+type ReplayProtector struct {
+	window time.Duration
+	memory *memoryNonceStore
+
+	now func() time.Time
+}
+
+// NewReplayProtector creates a ReplayProtector accepting the requests whose
+// timestamp is within window of the current time and remembering up to size
+// nonces when there is no shared NonceStore.
+func NewReplayProtector(window time.Duration, size int) *ReplayProtector {
+	return &ReplayProtector{
+		window: window,
+		memory: newMemoryNonceStore(size),
+		now:    time.Now,
+	}
+}
+
+// Verify returns an error when the request lacks the replay protection
+// headers, its timestamp is outside of the window, its headers aren't
+// signed or its nonce was seen before, otherwise it remembers the nonce.
+func (p *ReplayProtector) Verify(r *http.Request) error {
+	ts := r.Header.Get(RequestTimestampHeader)
+	nonce := r.Header.Get(RequestNonceHeader)
+	if ts == "" || nonce == "" {
+		return fmt.Errorf("missing %s or %s header", RequestTimestampHeader, RequestNonceHeader)
+	}
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header %q: %w", RequestTimestampHeader, ts, err)
+	}
+	sent := time.Unix(secs, 0)
+
+	now := p.now()
+	if sent.Before(now.Add(-p.window)) || sent.After(now.Add(p.window)) {
+		return fmt.Errorf("request timestamp %s is outside of the %s window", sent.UTC().Format(time.RFC3339), p.window)
+	}
+
+	cfg := replayProtectionConfig.Load()
+	if cfg == nil || len(cfg.keys) == 0 {
+		return errors.New("no replay protection key is configured")
+	}
+	if err := verifyRequestSignature(r, cfg.keys); err != nil {
+		return err
+	}
+
+	var store NonceStore = p.memory
+	if cfg.store != nil {
+		store = cfg.store
+	}
+	ok, err := store.Remember(r.Context(), nonce, sent.Add(p.window))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNonceStoreUnavailable, err)
+	}
+	if !ok {
+		return ErrReplayedRequest
+	}
+	return nil
+}
+
+// memoryNonceStore is a NonceStore remembering the nonces in memory, up to a
+// maximum number of nonces after which the oldest ones are forgotten.
+type memoryNonceStore struct {
+	size int
+
+	mu sync.Mutex
+	// seen maps the nonces to the time they can be forgotten.
+	seen map[string]time.Time
+	// nonces holds the nonces in the order they were seen.
+	nonces *list.List
+
+	now func() time.Time
+}
+
+func newMemoryNonceStore(size int) *memoryNonceStore {
+	return &memoryNonceStore{
+		size:   size,
+		seen:   make(map[string]time.Time),
+		nonces: list.New(),
+		now:    time.Now,
+	}
+}
+
+// Remember implements NonceStore.
+func (s *memoryNonceStore) Remember(_ context.Context, nonce string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.forget(now)
+	if e, ok := s.seen[nonce]; ok && now.Before(e) {
+		return false, nil
+	}
+	s.seen[nonce] = expiry
+	s.nonces.PushBack(nonce)
+	return true, nil
+}
+
+// forget drops the oldest nonces when they expired or when there are too
+// many of them. It must be called with mu held.
+func (s *memoryNonceStore) forget(now time.Time) {
+	for e := s.nonces.Front(); e != nil; e = s.nonces.Front() {
+		nonce := e.Value.(string)
+		if expiry := s.seen[nonce]; now.Before(expiry) && s.nonces.Len() < s.size {
+			return
+		}
+		s.nonces.Remove(e)
+		delete(s.seen, nonce)
+	}
+}
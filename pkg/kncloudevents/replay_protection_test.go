@@ -0,0 +1,380 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kncloudevents
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/signing"
+)
+
+var testReplayKey = signing.Key{ID: "test", Secret: []byte("replay-protection-key")}
+
+// configureTestReplayProtection configures the replay protection for the
+// duration of a test.
+func configureTestReplayProtection(t *testing.T, keys []signing.Key, store NonceStore) {
+	t.Helper()
+	ConfigureReplayProtection(keys, store)
+	t.Cleanup(func() {
+		replayProtectionConfig.Store(nil)
+	})
+}
+
+func makeProtectedRequest(sent time.Time, nonce string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/ns/broker", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	r.Header.Set(RequestTimestampHeader, strconv.FormatInt(sent.Unix(), 10))
+	r.Header.Set(RequestNonceHeader, nonce)
+	signTestRequest(r, testReplayKey)
+	return r
+}
+
+func signTestRequest(r *http.Request, key signing.Key) {
+	r.Header.Set(RequestSignatureKeyIDHeader, key.ID)
+	r.Header.Set(RequestSignatureHeader, base64Digest(r, key))
+}
+
+func base64Digest(r *http.Request, key signing.Key) string {
+	return base64.StdEncoding.EncodeToString(requestDigest(r, key))
+}
+
+func newTestReplayProtector(now *time.Time) *ReplayProtector {
+	p := NewReplayProtector(5*time.Minute, 10)
+	p.now = func() time.Time { return *now }
+	p.memory.now = p.now
+	return p
+}
+
+func TestReplayProtector(t *testing.T) {
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, nil)
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := map[string]struct {
+		request *http.Request
+		wantErr error
+	}{
+		"valid request": {
+			request: makeProtectedRequest(now, "nonce"),
+		},
+		"slightly early clock": {
+			request: makeProtectedRequest(now.Add(time.Minute), "nonce"),
+		},
+		"missing headers": {
+			request: httptest.NewRequest(http.MethodPost, "/", nil),
+			wantErr: errAny,
+		},
+		"invalid timestamp": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Header.Set(RequestTimestampHeader, "yesterday")
+				return r
+			}(),
+			wantErr: errAny,
+		},
+		"expired timestamp": {
+			request: makeProtectedRequest(now.Add(-10*time.Minute), "nonce"),
+			wantErr: errAny,
+		},
+		"future timestamp": {
+			request: makeProtectedRequest(now.Add(10*time.Minute), "nonce"),
+			wantErr: errAny,
+		},
+		"missing signature": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Header.Del(RequestSignatureHeader)
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"unknown key": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				signTestRequest(r, signing.Key{ID: "other", Secret: []byte("other-key")})
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"changed nonce": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Header.Set(RequestNonceHeader, "other")
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"changed timestamp": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Header.Set(RequestTimestampHeader, strconv.FormatInt(now.Add(time.Second).Unix(), 10))
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"changed path": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.URL.Path = "/ns/other"
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"changed method": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Method = http.MethodPut
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+		"other token": {
+			request: func() *http.Request {
+				r := makeProtectedRequest(now, "nonce")
+				r.Header.Set("Authorization", "Bearer other")
+				return r
+			}(),
+			wantErr: ErrInvalidRequestSignature,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			p := newTestReplayProtector(&now)
+			err := p.Verify(tc.request)
+			switch {
+			case tc.wantErr == nil && err != nil:
+				t.Error("Verify() =", err)
+			case tc.wantErr == errAny && err == nil:
+				t.Error("Verify() = nil, want an error")
+			case tc.wantErr != nil && tc.wantErr != errAny && !errors.Is(err, tc.wantErr):
+				t.Errorf("Verify() = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// errAny is the error expected in the test cases failing with any error.
+var errAny = errors.New("any error")
+
+func TestReplayProtectorWithoutKeys(t *testing.T) {
+	configureTestReplayProtection(t, nil, nil)
+	now := time.Unix(1_700_000_000, 0)
+	p := newTestReplayProtector(&now)
+
+	if err := p.Verify(makeProtectedRequest(now, "nonce")); err == nil {
+		t.Error("Verify() without a configured key = nil, want an error")
+	}
+}
+
+func TestReplayProtectorKeyRotation(t *testing.T) {
+	next := signing.Key{ID: "next", Secret: []byte("next-replay-protection-key")}
+	configureTestReplayProtection(t, []signing.Key{next, testReplayKey}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/ns/broker", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	SetReplayProtectionHeaders(r)
+	if got := r.Header.Get(RequestSignatureKeyIDHeader); got != next.ID {
+		t.Errorf("Got the request signed with the key %q, want %q", got, next.ID)
+	}
+	if err := NewReplayProtector(DefaultReplayWindow, DefaultReplayCacheSize).Verify(r); err != nil {
+		t.Error("Verify() of a request signed with the first key =", err)
+	}
+	// The requests signed with the previous key are still accepted.
+	if err := NewReplayProtector(DefaultReplayWindow, DefaultReplayCacheSize).Verify(makeProtectedRequest(time.Now(), "nonce")); err != nil {
+		t.Error("Verify() of a request signed with the second key =", err)
+	}
+}
+
+func TestReplayProtectorReplay(t *testing.T) {
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, nil)
+	now := time.Unix(1_700_000_000, 0)
+	p := newTestReplayProtector(&now)
+
+	if err := p.Verify(makeProtectedRequest(now, "nonce")); err != nil {
+		t.Fatal("Verify() =", err)
+	}
+	if err := p.Verify(makeProtectedRequest(now, "nonce")); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("Verify() of a replayed request = %v, want %v", err, ErrReplayedRequest)
+	}
+	if err := p.Verify(makeProtectedRequest(now, "other")); err != nil {
+		t.Error("Verify() of another request =", err)
+	}
+
+	// The nonces are forgotten once their requests expired.
+	now = now.Add(6 * time.Minute)
+	p.Verify(makeProtectedRequest(now, "next"))
+	if p.memory.nonces.Len() != 1 || len(p.memory.seen) != 1 {
+		t.Errorf("Got %d nonces in the list and %d in the map, want 1", p.memory.nonces.Len(), len(p.memory.seen))
+	}
+}
+
+func TestReplayProtectorSize(t *testing.T) {
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, nil)
+	now := time.Unix(1_700_000_000, 0)
+	p := newTestReplayProtector(&now)
+
+	for i := 0; i < 100; i++ {
+		if err := p.Verify(makeProtectedRequest(now, fmt.Sprint(i))); err != nil {
+			t.Fatal("Verify() =", err)
+		}
+	}
+	if p.memory.nonces.Len() != 10 || len(p.memory.seen) != 10 {
+		t.Errorf("Got %d nonces in the list and %d in the map, want 10", p.memory.nonces.Len(), len(p.memory.seen))
+	}
+	// The most recent nonces are still remembered.
+	if err := p.Verify(makeProtectedRequest(now, "99")); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("Verify() of a replayed request = %v, want %v", err, ErrReplayedRequest)
+	}
+}
+
+type fakeNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	err    error
+}
+
+func (s *fakeNonceStore) Remember(_ context.Context, nonce string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.nonces[nonce]; ok {
+		return false, nil
+	}
+	s.nonces[nonce] = expiry
+	return true, nil
+}
+
+func TestReplayProtectorSharedStore(t *testing.T) {
+	store := &fakeNonceStore{nonces: map[string]time.Time{}}
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, store)
+	now := time.Unix(1_700_000_000, 0)
+
+	// A request replayed to another replica is rejected.
+	if err := newTestReplayProtector(&now).Verify(makeProtectedRequest(now, "nonce")); err != nil {
+		t.Fatal("Verify() =", err)
+	}
+	if err := newTestReplayProtector(&now).Verify(makeProtectedRequest(now, "nonce")); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("Verify() of a request replayed to another replica = %v, want %v", err, ErrReplayedRequest)
+	}
+	if want := now.Add(5 * time.Minute); !store.nonces["nonce"].Equal(want) {
+		t.Errorf("Got the nonce remembered until %s, want %s", store.nonces["nonce"], want)
+	}
+
+	store.err = errors.New("connection refused")
+	if err := newTestReplayProtector(&now).Verify(makeProtectedRequest(now, "other")); !errors.Is(err, ErrNonceStoreUnavailable) {
+		t.Errorf("Verify() with an unavailable store = %v, want %v", err, ErrNonceStoreUnavailable)
+	}
+}
+
+func TestReplayProtectionRetries(t *testing.T) {
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, nil)
+	p := NewReplayProtector(DefaultReplayWindow, DefaultReplayCacheSize)
+
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Verify(r); err != nil {
+			t.Error("Verify() =", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if attempts++; attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	SetReplayProtectionHeaders(req)
+
+	c := &client{Client: *server.Client()}
+	resp, err := c.DoWithRetries(req, &RetryConfig{
+		RetryMax:   5,
+		CheckRetry: SelectiveRetry,
+		Backoff: func(attemptNum int, resp *http.Response) time.Duration {
+			return time.Millisecond
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal("DoWithRetries() =", err)
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("Got status %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+}
+
+func TestWatchReplayProtection(t *testing.T) {
+	t.Cleanup(func() {
+		replayProtectionEnabled.Store(false)
+	})
+	features := func(replayProtection string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: feature.FlagsConfigName},
+			Data:       map[string]string{feature.ReplayProtection: replayProtection},
+		}
+	}
+
+	// Enabling the feature without keys is reported once.
+	configureTestReplayProtection(t, nil, nil)
+	core, logs := observer.New(zap.ErrorLevel)
+	cmw := configmap.NewStaticWatcher(features("enabled"))
+	WatchReplayProtection(zap.New(core).Sugar(), cmw)
+	if err := cmw.Start(nil); err != nil {
+		t.Fatal("Start() =", err)
+	}
+	if !replayProtectionEnabled.Load() {
+		t.Error("expected the replay protection headers to be set")
+	}
+	if got := logs.FilterMessageSnippet("REPLAY_PROTECTION_KEY_FILES").Len(); got != 1 {
+		t.Errorf("got %d errors about the missing keys, want 1", got)
+	}
+
+	configureTestReplayProtection(t, []signing.Key{testReplayKey}, nil)
+	cmw = configmap.NewStaticWatcher(features("disabled"))
+	WatchReplayProtection(zap.New(core).Sugar(), cmw)
+	if err := cmw.Start(nil); err != nil {
+		t.Fatal("Start() =", err)
+	}
+	if replayProtectionEnabled.Load() {
+		t.Error("expected no replay protection headers while the feature is disabled")
+	}
+}
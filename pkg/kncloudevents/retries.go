@@ -215,17 +215,7 @@ func generateBackoffFn(config *RetryConfig) retryablehttp.Backoff {
 		//
 
 		// If Response is 429 / 503, Then Parse Any Retry-After Header Durations & Enforce Optional MaxDuration
-		var retryAfterDuration time.Duration
-		// TODO - Remove this check when experimental-feature moves to Stable/GA to convert behavior from opt-in to opt-out
-		if config.RetryAfterMaxDuration != nil {
-			// TODO - Keep this logic as is (no change required) when experimental-feature is Stable/GA
-			if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
-				retryAfterDuration = parseRetryAfterDuration(resp)
-				if config.RetryAfterMaxDuration != nil && *config.RetryAfterMaxDuration < retryAfterDuration {
-					retryAfterDuration = *config.RetryAfterMaxDuration
-				}
-			}
-		}
+		retryAfterDuration := RetryAfterDuration(resp, config.RetryAfterMaxDuration)
 
 		// Calculate The RetryConfig Backoff Duration
 		backoffDuration := config.Backoff(attemptNum, resp)
@@ -238,6 +228,25 @@ func generateBackoffFn(config *RetryConfig) retryablehttp.Backoff {
 	}
 }
 
+// RetryAfterDuration returns the duration a 429 or 503 response asks to wait
+// before retrying, bounded by maxDuration. Like RetryConfig.RetryAfterMaxDuration,
+// a nil maxDuration means the Retry-After header is ignored, so 0 is returned.
+func RetryAfterDuration(resp *http.Response, maxDuration *time.Duration) time.Duration {
+	// TODO - Remove this check when experimental-feature moves to Stable/GA to convert behavior from opt-in to opt-out
+	if maxDuration == nil {
+		return 0
+	}
+	// TODO - Keep this logic as is (no change required) when experimental-feature is Stable/GA
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0
+	}
+	retryAfterDuration := parseRetryAfterDuration(resp)
+	if *maxDuration < retryAfterDuration {
+		retryAfterDuration = *maxDuration
+	}
+	return retryAfterDuration
+}
+
 // parseRetryAfterDuration returns a Duration expressing the amount of time
 // requested to wait by a Retry-After header, or 0 if not present or invalid.
 // According to the spec (https://tools.ietf.org/html/rfc7231#section-7.1.3)
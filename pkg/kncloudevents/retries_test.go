@@ -394,6 +394,7 @@ func TestGenerateBackoffFnWithRetryAfter(t *testing.T) {
 	retryAfterDuration := 30 * time.Second         // The Retry-After header Duration to use in HTTP Response.
 	smallRetryAfterMaxDuration := 10 * time.Second // Value must exceed retryBackoffDuration while being less than retryAfterDuration to force use of retryAfterMax value.
 	largeRetryAfterMaxDuration := 90 * time.Second // Value must exceed retryBackoffDuration and retryAfterDuration so that Retry-After header is used.
+	zeroRetryAfterMaxDuration := time.Duration(0)  // Value disables the use of Retry-After header.
 
 	// Define The TestCases
 	testCases := []struct {
@@ -448,6 +449,23 @@ func TestGenerateBackoffFnWithRetryAfter(t *testing.T) {
 			expectedBackoff: retryBackoffDuration, // Uses Standard Backoff
 		},
 
+		// Zero Max Tests (Retry-After Ignored)
+
+		{
+			name:            "zero max 429 with Retry-After seconds",
+			retryAfterMax:   &zeroRetryAfterMaxDuration,
+			statusCode:      http.StatusTooManyRequests,
+			format:          Seconds,
+			expectedBackoff: retryBackoffDuration, // Uses Standard Backoff
+		},
+		{
+			name:            "zero max 503 with Retry-After seconds",
+			retryAfterMax:   &zeroRetryAfterMaxDuration,
+			statusCode:      http.StatusServiceUnavailable,
+			format:          Seconds,
+			expectedBackoff: retryBackoffDuration, // Uses Standard Backoff
+		},
+
 		// Large Max Tests (Greater Than Retry-After Value)
 
 		{
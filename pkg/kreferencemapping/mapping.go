@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kreferencemapping maps the KReferences to resources of arbitrary
+// kinds, which don't implement the Addressable duck type, to a URL or to a
+// reference to another resource.
+package kreferencemapping
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// AnyVersion is the version in a mapping key matching all the versions
+	// of a kind, as in Service.*.serving.knative.dev.
+	AnyVersion = "*"
+
+	// RefPrefix prefixes the ConfigMap values rewriting the reference to
+	// another kind, as in ref:Service.v1.serving.knative.dev, instead of
+	// mapping it to a URL.
+	RefPrefix = "ref:"
+
+	// ExampleKey is the key of the documentation of the ConfigMap.
+	ExampleKey = "_example"
+)
+
+// TemplateValues are the values available to the templates of a mapping.
+type TemplateValues struct {
+	// Name of the reference.
+	Name string
+	// Namespace of the reference.
+	Namespace string
+	// SystemNamespace is the namespace of Knative Eventing.
+	SystemNamespace string
+	// UID of the reference.
+	UID types.UID
+}
+
+// sampleValues are used to check that the templates execute and result in
+// a valid URL or name.
+var sampleValues = TemplateValues{
+	Name:            "name",
+	Namespace:       "namespace",
+	SystemNamespace: "knative-eventing",
+	UID:             "00000000-0000-0000-0000-000000000000",
+}
+
+// Mapping maps the references to a kind.
+type Mapping struct {
+	// Name of the KReferenceMapping defining the mapping, empty for the
+	// mappings of the config-kreference-mapping ConfigMap.
+	Name string
+	// CreationTimestamp of the KReferenceMapping defining the mapping.
+	CreationTimestamp time.Time
+
+	// From is the kind of the mapped references. An empty version matches
+	// all the versions of the kind.
+	From schema.GroupVersionKind
+
+	// URI maps the references to a URL. Exactly one of URI and Ref is set.
+	URI *template.Template
+	// Ref rewrites the references to references to another kind, resolved
+	// as usual.
+	Ref *Rewrite
+}
+
+// Rewrite rewrites a reference to a reference to another kind.
+type Rewrite struct {
+	APIVersion string
+	Kind       string
+	// Name is the name of the rewritten reference. The name of the original
+	// reference is kept when nil.
+	Name *template.Template
+}
+
+// ParseKey parses the key of a mapping of the ConfigMap, of the form
+// <kind>.<version>.<group>, <kind>.<version> for the core API group, or
+// <kind>.*.<group> for all the versions of a kind.
+func ParseKey(key string) (schema.GroupVersionKind, error) {
+	gvk, gk := schema.ParseKindArg(key)
+	if gvk == nil {
+		// Try <kind>.<version> (core k8s API)
+		if gk.Group == "" || strings.Contains(gk.Group, ".") {
+			return schema.GroupVersionKind{}, fmt.Errorf("invalid key %q, must be of the form <kind>.<version>(.<group>)?", key)
+		}
+		gvk = &schema.GroupVersionKind{Kind: gk.Kind, Version: gk.Group}
+	}
+	if gvk.Kind == "" || gvk.Version == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("invalid key %q, must be of the form <kind>.<version>(.<group>)?", key)
+	}
+	if gvk.Version == AnyVersion {
+		gvk.Version = ""
+	}
+	return *gvk, nil
+}
+
+// Key returns the key of the mapping in the ConfigMap.
+func (m *Mapping) Key() string {
+	version := m.From.Version
+	if version == "" {
+		version = AnyVersion
+	}
+	if m.From.Group == "" {
+		return m.From.Kind + "." + version
+	}
+	return m.From.Kind + "." + version + "." + m.From.Group
+}
+
+// ParseTemplate parses a template of a mapping.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Parse(text)
+}
+
+// FromConfigMap parses the mappings of the config-kreference-mapping
+// ConfigMap. The valid mappings are returned along with the errors of the
+// others.
+func FromConfigMap(data map[string]string) (Mappings, error) {
+	var (
+		mappings Mappings
+		errs     []error
+	)
+	for key, value := range data {
+		if key == ExampleKey {
+			continue
+		}
+		gvk, err := ParseKey(key)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m := Mapping{From: gvk}
+		if target, ok := strings.CutPrefix(value, RefPrefix); ok {
+			to, err := ParseKey(strings.TrimSpace(target))
+			if err != nil || to.Version == "" {
+				errs = append(errs, fmt.Errorf("invalid value %q of key %q, must be of the form %s<kind>.<version>(.<group>)?", value, key, RefPrefix))
+				continue
+			}
+			apiVersion, kind := to.ToAPIVersionAndKind()
+			m.Ref = &Rewrite{APIVersion: apiVersion, Kind: kind}
+		} else {
+			m.URI, err = ParseTemplate(key, value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid template of key %q: %w", key, err))
+				continue
+			}
+		}
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].From.String() < mappings[j].From.String()
+	})
+	return mappings, errors.Join(errs...)
+}
+
+// NewFromConfigMap parses and validates the mappings of the
+// config-kreference-mapping ConfigMap, failing on any invalid mapping.
+func NewFromConfigMap(cm *corev1.ConfigMap) (Mappings, error) {
+	mappings, err := FromConfigMap(cm.Data)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mappings {
+		if err := mappings[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid mapping %q: %w", mappings[i].Key(), err)
+		}
+	}
+	return mappings, nil
+}
+
+// Validate checks that the templates of the mapping execute and result in
+// a valid URL or name.
+func (m *Mapping) Validate() error {
+	ref := &corev1.ObjectReference{
+		Name:      sampleValues.Name,
+		Namespace: sampleValues.Namespace,
+		UID:       sampleValues.UID,
+	}
+	_, _, err := m.Map(ref, sampleValues.SystemNamespace)
+	return err
+}
+
+// Map maps the reference either to a URL, or to a reference to another kind.
+func (m *Mapping) Map(ref *corev1.ObjectReference, systemNamespace string) (*apis.URL, *corev1.ObjectReference, error) {
+	data := TemplateValues{
+		Name:            ref.Name,
+		Namespace:       ref.Namespace,
+		SystemNamespace: systemNamespace,
+		UID:             ref.UID,
+	}
+
+	if m.Ref != nil {
+		rewritten := &corev1.ObjectReference{
+			APIVersion: m.Ref.APIVersion,
+			Kind:       m.Ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		}
+		if m.Ref.Name != nil {
+			name, err := execute(m.Ref.Name, data)
+			if err != nil {
+				return nil, nil, err
+			}
+			if name == "" {
+				return nil, nil, fmt.Errorf("the name template of the mapping of %s resulted in an empty name", m.From.GroupKind())
+			}
+			rewritten.Name = name
+		}
+		return nil, rewritten, nil
+	}
+
+	s, err := execute(m.URI, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	url, err := apis.ParseURL(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if url == nil || !url.URL().IsAbs() {
+		return nil, nil, fmt.Errorf("the mapping of %s resulted in %q, which isn't an absolute URL", m.From.GroupKind(), s)
+	}
+	return url, nil, nil
+}
+
+func execute(tmpl *template.Template, data TemplateValues) (string, error) {
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Precedes returns true when the mapping takes precedence over the other
+// one. A mapping of a given version takes precedence over a mapping of all
+// the versions of a kind. Otherwise the mappings of the ConfigMap take
+// precedence over the KReferenceMappings, and the oldest KReferenceMappings
+// take precedence over the newer ones.
+func (m *Mapping) Precedes(o *Mapping) bool {
+	if (m.From.Version == "") != (o.From.Version == "") {
+		return m.From.Version != ""
+	}
+	if (m.Name == "") != (o.Name == "") {
+		return m.Name == ""
+	}
+	if !m.CreationTimestamp.Equal(o.CreationTimestamp) {
+		return m.CreationTimestamp.Before(o.CreationTimestamp)
+	}
+	return m.Name < o.Name
+}
+
+// Matches returns true when the mapping applies to the references to the
+// given kind.
+func (m *Mapping) Matches(gvk schema.GroupVersionKind) bool {
+	return m.From.Group == gvk.Group && m.From.Kind == gvk.Kind &&
+		(m.From.Version == "" || m.From.Version == gvk.Version)
+}
+
+// Overlaps returns true when the mappings apply to the references to the
+// same kind and version.
+func (m *Mapping) Overlaps(o *Mapping) bool {
+	return m.From.Group == o.From.Group && m.From.Kind == o.From.Kind &&
+		m.From.Version == o.From.Version
+}
+
+// Mappings is a set of mappings.
+type Mappings []Mapping
+
+// Lookup returns the mapping of the references to the given kind, taking
+// precedence over the other ones.
+func (ms Mappings) Lookup(gvk schema.GroupVersionKind) (*Mapping, bool) {
+	var found *Mapping
+	for i := range ms {
+		if ms[i].Matches(gvk) && (found == nil || ms[i].Precedes(found)) {
+			found = &ms[i]
+		}
+	}
+	return found, found != nil
+}
+
+// Conflict returns the mapping of the same kind and version as m, taking
+// precedence over it.
+func (ms Mappings) Conflict(m *Mapping) (*Mapping, bool) {
+	for i := range ms {
+		o := &ms[i]
+		if o.Name != m.Name && o.Overlaps(m) && o.Precedes(m) {
+			return o, true
+		}
+	}
+	return nil, false
+}
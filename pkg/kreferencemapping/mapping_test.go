@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kreferencemapping
+
+import (
+	"testing"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := map[string]struct {
+		want    schema.GroupVersionKind
+		wantErr bool
+	}{
+		"Pod.v1":                         {want: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}},
+		"Service.v1.serving.knative.dev": {want: schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Service"}},
+		"Service.*.serving.knative.dev":  {want: schema.GroupVersionKind{Group: "serving.knative.dev", Kind: "Service"}},
+		"Pod":                            {wantErr: true},
+		"Pod.":                           {wantErr: true},
+		".v1.serving.knative.dev":        {wantErr: true},
+		"Service..serving.knative.dev":   {wantErr: true},
+	}
+	for key, tc := range tests {
+		t.Run(key, func(t *testing.T) {
+			got, err := ParseKey(key)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseKey() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseKey() = %v, want %v", got, tc.want)
+			}
+			if m := (&Mapping{From: got}); !tc.wantErr && m.Key() != key {
+				t.Errorf("Key() = %q, want %q", m.Key(), key)
+			}
+		})
+	}
+}
+
+func TestFromConfigMap(t *testing.T) {
+	mappings, err := FromConfigMap(map[string]string{
+		ExampleKey:                       "ignored",
+		"Pod.v1":                         "https://addressable-pod.{{ .SystemNamespace }}.svc.cluster.local/{{ .Name }}",
+		"Service.*.serving.knative.dev":  "ref:Service.v1",
+		"Broken.v1.example.com":          "https://{{ .Name",
+		"Invalid":                        "https://example.com",
+		"BadRef.v1.example.com":          "ref:Service",
+		"AnyVersion.v1.example.com":      "ref:Service.*.example.com",
+		"Gateway.v1beta1.networking.dev": "ref: Service.v1",
+	})
+	if err == nil {
+		t.Error("Expected errors for the invalid mappings")
+	}
+	if len(mappings) != 3 {
+		t.Fatalf("Expected 3 valid mappings, got %+v", mappings)
+	}
+
+	m, ok := mappings.Lookup(schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Service"})
+	if !ok || m.Ref == nil || m.Ref.APIVersion != "v1" || m.Ref.Kind != "Service" {
+		t.Errorf("Unexpected mapping of Services %+v", m)
+	}
+	m, ok = mappings.Lookup(schema.GroupVersionKind{Group: "networking.dev", Version: "v1beta1", Kind: "Gateway"})
+	if !ok || m.Ref == nil || m.Ref.Kind != "Service" {
+		t.Errorf("Unexpected mapping of Gateways %+v", m)
+	}
+	if _, ok := mappings.Lookup(schema.GroupVersionKind{Version: "v2", Kind: "Pod"}); ok {
+		t.Error("Unexpected mapping of Pods v2")
+	}
+}
+
+func TestNewFromConfigMap(t *testing.T) {
+	tests := map[string]struct {
+		data    map[string]string
+		wantErr bool
+	}{
+		"valid": {
+			data: map[string]string{
+				ExampleKey:                      "ignored",
+				"Pod.v1":                        "https://addressable-pod.{{ .SystemNamespace }}.svc.cluster.local/{{ .Name }}",
+				"Service.*.serving.knative.dev": "ref:Service.v1",
+			},
+		},
+		"invalid key": {
+			data:    map[string]string{"Pod": "https://example.com"},
+			wantErr: true,
+		},
+		"unknown template value": {
+			data:    map[string]string{"Pod.v1": "https://{{ .Host }}"},
+			wantErr: true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			_, err := NewFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewFromConfigMap() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMappingMap(t *testing.T) {
+	ref := &corev1.ObjectReference{
+		APIVersion: "example.com/v1",
+		Kind:       "Sink",
+		Namespace:  "ns",
+		Name:       "sink",
+		UID:        "uid",
+	}
+	from := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Sink"}
+
+	tests := map[string]struct {
+		uri, name string
+		ref       bool
+		wantURL   string
+		wantRef   *corev1.ObjectReference
+		wantErr   bool
+	}{
+		"uri": {
+			uri:     "http://{{ .Name }}.{{ .Namespace }}.svc.cluster.local/{{ .UID }}",
+			wantURL: "http://sink.ns.svc.cluster.local/uid",
+		},
+		"uri, unknown value": {
+			uri:     "http://{{ .Unknown }}",
+			wantErr: true,
+		},
+		"uri, not a URL": {
+			uri:     "%",
+			wantErr: true,
+		},
+		"uri, relative URL": {
+			uri:     "/{{ .Name }}",
+			wantErr: true,
+		},
+		"ref": {
+			ref:     true,
+			wantRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink"},
+		},
+		"ref, name": {
+			ref:     true,
+			name:    "{{ .Name }}-ingress",
+			wantRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Service", Namespace: "ns", Name: "sink-ingress"},
+		},
+		"ref, empty name": {
+			ref:     true,
+			name:    "{{ if false }}x{{ end }}",
+			wantErr: true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			m := &Mapping{From: from}
+			if tc.ref {
+				m.Ref = &Rewrite{APIVersion: "v1", Kind: "Service"}
+				if tc.name != "" {
+					m.Ref.Name = mustParse(t, tc.name)
+				}
+			} else {
+				m.URI = mustParse(t, tc.uri)
+			}
+
+			if err := m.Validate(); (err != nil) != tc.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, tc.wantErr)
+			}
+			url, rewritten, err := m.Map(ref, "knative-eventing")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Map() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if tc.wantURL != "" && (url == nil || url.String() != tc.wantURL) {
+				t.Errorf("Map() URL = %v, want %s", url, tc.wantURL)
+			}
+			if tc.wantRef != nil && (rewritten == nil || *rewritten != *tc.wantRef) {
+				t.Errorf("Map() ref = %+v, want %+v", rewritten, tc.wantRef)
+			}
+		})
+	}
+}
+
+func TestMappingsPrecedence(t *testing.T) {
+	now := time.Now()
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Sink"}
+	anyVersion := schema.GroupVersionKind{Group: "example.com", Kind: "Sink"}
+
+	configMap := Mapping{From: gvk}
+	older := Mapping{Name: "older", From: gvk, CreationTimestamp: now.Add(-time.Hour)}
+	newer := Mapping{Name: "newer", From: gvk, CreationTimestamp: now}
+	wildcard := Mapping{Name: "wildcard", From: anyVersion, CreationTimestamp: now.Add(-2 * time.Hour)}
+
+	tests := map[string]struct {
+		mappings Mappings
+		want     string
+	}{
+		"kreferencemappings, oldest wins": {
+			mappings: Mappings{newer, older},
+			want:     "older",
+		},
+		"configmap wins": {
+			mappings: Mappings{older, configMap, newer},
+			want:     "",
+		},
+		"version wins over any version": {
+			mappings: Mappings{wildcard, newer},
+			want:     "newer",
+		},
+		"any version": {
+			mappings: Mappings{wildcard},
+			want:     "wildcard",
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, ok := tc.mappings.Lookup(gvk)
+			if !ok || got.Name != tc.want {
+				t.Errorf("Lookup() = %+v, want %q", got, tc.want)
+			}
+		})
+	}
+
+	all := Mappings{configMap, older, newer, wildcard}
+	if c, ok := all.Conflict(&newer); !ok || c.Name != "" {
+		t.Errorf("Conflict(newer) = %+v, want the ConfigMap mapping", c)
+	}
+	if c, ok := (Mappings{older, newer}).Conflict(&older); ok {
+		t.Errorf("Conflict(older) = %+v, want none", c)
+	}
+	if c, ok := all.Conflict(&wildcard); ok {
+		t.Errorf("Conflict(wildcard) = %+v, want none", c)
+	}
+}
+
+func mustParse(t *testing.T, text string) *template.Template {
+	t.Helper()
+	tmpl, err := ParseTemplate("test", text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpl
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leaderelection loads the leader election configuration of the
+// eventing components from config-leader-election, where any of the global
+// keys may be overridden for a single component by prefixing it with the
+// name of the component, e.g.
+//
+//	lease-duration: "15s"
+//	pingsource-mt-adapter.lease-duration: "60s"
+//	mt-broker-controller.buckets: "5"
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/environment"
+	kle "knative.dev/pkg/leaderelection"
+	"knative.dev/pkg/system"
+)
+
+// overridableKeys are the keys of config-leader-election that can be
+// overridden per component.
+var overridableKeys = sets.New(
+	"lease-duration",
+	"renew-deadline",
+	"retry-period",
+	"buckets",
+)
+
+// splitComponentKey returns the component and the key of a per-component
+// override, ok is false when key isn't one.
+func splitComponentKey(key string) (component, overridden string, ok bool) {
+	component, overridden, ok = strings.Cut(key, ".")
+	if !ok || component == "" || !overridableKeys.Has(overridden) {
+		return "", "", false
+	}
+	return component, overridden, true
+}
+
+// NewComponentConfigFromMap returns the leader election Config of the given
+// component, the global keys being overridden by the ones of the component.
+func NewComponentConfigFromMap(data map[string]string, component string) (*kle.Config, error) {
+	merged := make(map[string]string, len(data))
+	overrides := make(map[string]string)
+	for k, v := range data {
+		if c, key, ok := splitComponentKey(k); ok {
+			if c == component {
+				overrides[key] = v
+			}
+			continue
+		}
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+
+	config, err := kle.NewConfigFromMap(merged)
+	if err != nil {
+		if len(overrides) > 0 {
+			return nil, fmt.Errorf("component %q: %w", component, err)
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// NewComponentConfigFromConfigMap returns the leader election Config of the
+// given component from the given ConfigMap, the defaults when it is nil.
+func NewComponentConfigFromConfigMap(configMap *corev1.ConfigMap, component string) (*kle.Config, error) {
+	if configMap == nil {
+		return kle.NewConfigFromConfigMap(nil)
+	}
+	return NewComponentConfigFromMap(configMap.Data, component)
+}
+
+// NewConfigFromConfigMap returns the global leader election Config from the
+// given ConfigMap, after checking the overrides of every component it
+// contains. It is meant to validate config-leader-election.
+func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*kle.Config, error) {
+	if configMap == nil {
+		return kle.NewConfigFromConfigMap(nil)
+	}
+
+	components := sets.New[string]()
+	for k := range configMap.Data {
+		if c, _, ok := splitComponentKey(k); ok {
+			components.Insert(c)
+		}
+	}
+	for _, c := range sets.List(components) {
+		if _, err := NewComponentConfigFromMap(configMap.Data, c); err != nil {
+			return nil, err
+		}
+	}
+	return NewComponentConfigFromMap(configMap.Data, "")
+}
+
+// GetComponentConfig reads config-leader-election with the given client and
+// returns the leader election Config of the given component, the defaults
+// when the ConfigMap doesn't exist.
+func GetComponentConfig(ctx context.Context, client kubernetes.Interface, component string) (*kle.Config, error) {
+	configMap, err := client.CoreV1().ConfigMaps(system.Namespace()).Get(ctx, kle.ConfigMapName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return NewComponentConfigFromConfigMap(nil, component)
+	} else if err != nil {
+		return nil, err
+	}
+	return NewComponentConfigFromConfigMap(configMap, component)
+}
+
+// WithComponentConfig associates the leader election Config of the given
+// component with the context, so that sharedmain uses it instead of the
+// global one. config-leader-election is read with a client built from the
+// in-cluster configuration, or from $KUBECONFIG when running out of cluster,
+// as it must happen before sharedmain sets up the injected clients.
+func WithComponentConfig(ctx context.Context, component string) (context.Context, error) {
+	cfg, err := (&environment.ClientConfig{}).GetRESTConfig()
+	if err != nil {
+		return ctx, err
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return ctx, err
+	}
+	config, err := GetComponentConfig(ctx, client, component)
+	if err != nil {
+		return ctx, err
+	}
+	return kle.WithConfig(ctx, config), nil
+}
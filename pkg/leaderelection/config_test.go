@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	kle "knative.dev/pkg/leaderelection"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing"
+)
+
+func TestNewComponentConfigFromMap(t *testing.T) {
+	data := map[string]string{
+		"lease-duration":                       "15s",
+		"renew-deadline":                       "10s",
+		"retry-period":                         "2s",
+		"buckets":                              "1",
+		"pingsource-mt-adapter.lease-duration": "60s",
+		"pingsource-mt-adapter.renew-deadline": "40s",
+		"mt-broker-controller.buckets":         "5",
+		"map-lease-prefix.reconciler":          "prefix",
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]string
+		component string
+		want      *kle.Config
+		wantErr   bool
+	}{{
+		name:      "no override",
+		data:      data,
+		component: "controller",
+		want: &kle.Config{
+			LeaseDuration:           15 * time.Second,
+			RenewDeadline:           10 * time.Second,
+			RetryPeriod:             2 * time.Second,
+			Buckets:                 1,
+			LeaseNamesPrefixMapping: map[string]string{"reconciler": "prefix"},
+		},
+	}, {
+		name:      "durations overridden",
+		data:      data,
+		component: "pingsource-mt-adapter",
+		want: &kle.Config{
+			LeaseDuration:           60 * time.Second,
+			RenewDeadline:           40 * time.Second,
+			RetryPeriod:             2 * time.Second,
+			Buckets:                 1,
+			LeaseNamesPrefixMapping: map[string]string{"reconciler": "prefix"},
+		},
+	}, {
+		name:      "buckets overridden",
+		data:      data,
+		component: "mt-broker-controller",
+		want: &kle.Config{
+			LeaseDuration:           15 * time.Second,
+			RenewDeadline:           10 * time.Second,
+			RetryPeriod:             2 * time.Second,
+			Buckets:                 5,
+			LeaseNamesPrefixMapping: map[string]string{"reconciler": "prefix"},
+		},
+	}, {
+		name:      "invalid override of another component",
+		data:      map[string]string{"mt-broker-controller.buckets": "20"},
+		component: "controller",
+		want: &kle.Config{
+			LeaseDuration: 60 * time.Second,
+			RenewDeadline: 40 * time.Second,
+			RetryPeriod:   10 * time.Second,
+			Buckets:       1,
+		},
+	}, {
+		name:      "invalid override",
+		data:      map[string]string{"mt-broker-controller.buckets": "20"},
+		component: "mt-broker-controller",
+		wantErr:   true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewComponentConfigFromMap(tt.data, tt.component)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewComponentConfigFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error("unexpected config (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestNewConfigFromConfigMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr bool
+	}{{
+		name: "valid",
+		data: map[string]string{
+			"lease-duration":               "15s",
+			"mt-broker-controller.buckets": "5",
+		},
+	}, {
+		name: "invalid global",
+		data: map[string]string{
+			"lease-duration": "fifteen",
+		},
+		wantErr: true,
+	}, {
+		name: "invalid override",
+		data: map[string]string{
+			"lease-duration":                       "15s",
+			"pingsource-mt-adapter.lease-duration": "fifteen",
+		},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfigFromConfigMap(&corev1.ConfigMap{Data: tt.data})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfigFromConfigMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetComponentConfig(t *testing.T) {
+	ctx := context.Background()
+
+	got, err := GetComponentConfig(ctx, fake.NewSimpleClientset(), "controller")
+	if err != nil {
+		t.Fatal("GetComponentConfig() with no ConfigMap:", err)
+	}
+	if got.Buckets != 1 || got.LeaseDuration != 60*time.Second {
+		t.Errorf("GetComponentConfig() with no ConfigMap = %+v, want the defaults", got)
+	}
+
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kle.ConfigMapName(),
+			Namespace: system.Namespace(),
+		},
+		Data: map[string]string{
+			"controller.buckets": "3",
+		},
+	})
+	got, err = GetComponentConfig(ctx, client, "controller")
+	if err != nil {
+		t.Fatal("GetComponentConfig() =", err)
+	}
+	if got.Buckets != 3 {
+		t.Errorf("GetComponentConfig().Buckets = %d, want 3", got.Buckets)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controller reports the reconciliations of the controllers by kind
+// of the reconciled resource, so that the struggling reconcilers can be told
+// apart in the metrics of a process running many of them.
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	pkgcontroller "knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/metrics"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+const (
+	// LabelResourceKind is the label for the group qualified kind of the
+	// reconciled resource, e.g. PingSource.sources.knative.dev.
+	LabelResourceKind = "resource_kind"
+
+	// LabelSuccess is the label for the outcome of a reconciliation.
+	LabelSuccess = "success"
+)
+
+var (
+	// reconcileCountM is a counter which records the number of
+	// reconciliations.
+	reconcileCountM = stats.Int64(
+		"kind_reconcile_count",
+		"Number of reconcile operations by kind of resource",
+		stats.UnitDimensionless,
+	)
+
+	// reconcileLatencyM records the latency of the reconciliations.
+	reconcileLatencyM = stats.Int64(
+		"kind_reconcile_latency",
+		"Latency of reconcile operations by kind of resource",
+		stats.UnitMilliseconds,
+	)
+
+	// workQueueDepthM records the depth of the work queue when a
+	// reconciliation starts.
+	workQueueDepthM = stats.Int64(
+		"kind_work_queue_depth",
+		"Depth of the work queue by kind of resource",
+		stats.UnitDimensionless,
+	)
+
+	// Create the tag keys that will be used to add tags to our measurements.
+	// Tag keys must conform to the restrictions described in
+	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
+	// - length between 1 and 255 inclusive
+	// - characters are printable US-ASCII
+	resourceKindKey = tag.MustNewKey(LabelResourceKind)
+	successKey      = tag.MustNewKey(LabelSuccess)
+	namespaceKey    = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+
+	reconcileDistribution = view.Distribution(10, 100, 1000, 10000, 30000, 60000)
+)
+
+func init() {
+	register()
+}
+
+// WithKindMetrics wraps the given controller constructor, so that the
+// reconciliations of the controller it builds are reported tagged with the
+// kind of the reconciled resource.
+func WithKindMetrics(gvk schema.GroupVersionKind, ctor injection.ControllerConstructor) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *pkgcontroller.Impl {
+		impl := ctor(ctx, cmw)
+		impl.Reconciler = newKindReconciler(gvk, impl)
+		return impl
+	}
+}
+
+// newKindReconciler wraps the Reconciler of impl, keeping it leader aware
+// when it is.
+func newKindReconciler(gvk schema.GroupVersionKind, impl *pkgcontroller.Impl) pkgcontroller.Reconciler {
+	// The kind is static, create a context containing it and cache it.
+	ctx, _ := tag.New(context.Background(), tag.Insert(resourceKindKey, gvk.GroupKind().String()))
+
+	r := &kindReconciler{
+		Reconciler: impl.Reconciler,
+		ctx:        ctx,
+		workQueue:  impl.WorkQueue(),
+	}
+	if la, ok := impl.Reconciler.(pkgreconciler.LeaderAware); ok {
+		return &leaderAwareKindReconciler{kindReconciler: r, LeaderAware: la}
+	}
+	return r
+}
+
+type kindReconciler struct {
+	pkgcontroller.Reconciler
+
+	ctx       context.Context
+	workQueue interface{ Len() int }
+}
+
+type leaderAwareKindReconciler struct {
+	*kindReconciler
+	pkgreconciler.LeaderAware
+}
+
+var (
+	_ pkgcontroller.Reconciler  = (*kindReconciler)(nil)
+	_ pkgcontroller.Reconciler  = (*leaderAwareKindReconciler)(nil)
+	_ pkgreconciler.LeaderAware = (*leaderAwareKindReconciler)(nil)
+)
+
+// Reconcile implements controller.Reconciler.
+func (r *kindReconciler) Reconcile(ctx context.Context, key string) error {
+	metrics.Record(r.ctx, workQueueDepthM.M(int64(r.workQueue.Len())))
+
+	start := time.Now()
+	err := r.Reconciler.Reconcile(ctx, key)
+	r.reportReconcile(key, time.Since(start), err == nil)
+	return err
+}
+
+func (r *kindReconciler) reportReconcile(key string, duration time.Duration, success bool) {
+	namespace, _, _ := cache.SplitMetaNamespaceKey(key)
+	ctx, err := tag.New(r.ctx,
+		tag.Insert(successKey, strconv.FormatBool(success)),
+		metrics.MaybeInsertStringTag(namespaceKey, namespace, namespace != ""),
+	)
+	if err != nil {
+		return
+	}
+	metrics.RecordBatch(ctx, reconcileCountM.M(1), reconcileLatencyM.M(duration.Milliseconds()))
+}
+
+func register() {
+	// Create view to see our measurements.
+	if err := view.Register(
+		&view.View{
+			Description: workQueueDepthM.Description(),
+			Measure:     workQueueDepthM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{resourceKindKey},
+		},
+		&view.View{
+			Description: reconcileCountM.Description(),
+			Measure:     reconcileCountM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{resourceKindKey, successKey, namespaceKey},
+		},
+		&view.View{
+			Description: reconcileLatencyM.Description(),
+			Measure:     reconcileLatencyM,
+			Aggregation: reconcileDistribution,
+			TagKeys:     []tag.Key{resourceKindKey, successKey, namespaceKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/configmap"
+	pkgcontroller "knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+var pingSourceGVK = schema.GroupVersionKind{Group: "sources.knative.dev", Version: "v1", Kind: "PingSource"}
+
+type fakeReconciler struct {
+	err error
+}
+
+func (r *fakeReconciler) Reconcile(context.Context, string) error {
+	return r.err
+}
+
+type fakeLeaderAwareReconciler struct {
+	fakeReconciler
+	pkgreconciler.LeaderAwareFuncs
+}
+
+func TestWithKindMetrics(t *testing.T) {
+	resetMetrics()
+
+	ctx := logtesting.TestContextWithLogger(t)
+	r := &fakeReconciler{}
+	ctor := WithKindMetrics(pingSourceGVK, func(ctx context.Context, _ configmap.Watcher) *pkgcontroller.Impl {
+		return pkgcontroller.NewContext(ctx, r, pkgcontroller.ControllerOptions{
+			WorkQueueName: "test",
+			Logger:        logtesting.TestLogger(t),
+		})
+	})
+	impl := ctor(ctx, configmap.NewStaticWatcher())
+
+	if err := impl.Reconciler.Reconcile(ctx, "ns/name"); err != nil {
+		t.Fatal("Reconcile() =", err)
+	}
+	metricstest.CheckCountData(t, "kind_reconcile_count", map[string]string{
+		LabelResourceKind:                  "PingSource.sources.knative.dev",
+		LabelSuccess:                       "true",
+		eventingmetrics.LabelNamespaceName: "ns",
+	}, 1)
+	metricstest.CheckLastValueData(t, "kind_work_queue_depth", map[string]string{
+		LabelResourceKind: "PingSource.sources.knative.dev",
+	}, 0)
+
+	resetMetrics()
+	r.err = errors.New("failed")
+	if err := impl.Reconciler.Reconcile(ctx, "ns/name"); err == nil {
+		t.Fatal("Reconcile() = nil, want the error of the wrapped reconciler")
+	}
+	if err := impl.Reconciler.Reconcile(ctx, "ns/name"); err == nil {
+		t.Fatal("Reconcile() = nil, want the error of the wrapped reconciler")
+	}
+
+	metricstest.CheckCountData(t, "kind_reconcile_count", map[string]string{
+		LabelResourceKind:                  "PingSource.sources.knative.dev",
+		LabelSuccess:                       "false",
+		eventingmetrics.LabelNamespaceName: "ns",
+	}, 2)
+
+	if _, ok := impl.Reconciler.(pkgreconciler.LeaderAware); ok {
+		t.Error("Reconciler is leader aware, want it not to be as the wrapped one isn't")
+	}
+}
+
+func TestWithKindMetricsLeaderAware(t *testing.T) {
+	resetMetrics()
+
+	ctx := logtesting.TestContextWithLogger(t)
+	ctor := WithKindMetrics(pingSourceGVK, func(ctx context.Context, _ configmap.Watcher) *pkgcontroller.Impl {
+		return pkgcontroller.NewContext(ctx, &fakeLeaderAwareReconciler{}, pkgcontroller.ControllerOptions{
+			WorkQueueName: "test",
+			Logger:        logtesting.TestLogger(t),
+		})
+	})
+	impl := ctor(ctx, configmap.NewStaticWatcher())
+
+	if _, ok := impl.Reconciler.(pkgreconciler.LeaderAware); !ok {
+		t.Error("Reconciler isn't leader aware, want it to be as the wrapped one is")
+	}
+
+	// Cluster scoped resources have no namespace tag.
+	if err := impl.Reconciler.Reconcile(ctx, "name"); err != nil {
+		t.Fatal("Reconcile() =", err)
+	}
+	metricstest.CheckCountData(t, "kind_reconcile_count", map[string]string{
+		LabelResourceKind: "PingSource.sources.knative.dev",
+		LabelSuccess:      "true",
+	}, 1)
+}
+
+func resetMetrics() {
+	// OpenCensus metrics carry global state that need to be reset between unit tests.
+	metricstest.Unregister("kind_reconcile_count", "kind_reconcile_latency", "kind_work_queue_depth")
+	register()
+}
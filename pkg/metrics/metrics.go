@@ -71,4 +71,10 @@ const (
 
 	// LabelResponseTimeout is the label timeout.
 	LabelResponseTimeout = metricskey.LabelResponseTimeout
+
+	// LabelValidationLevel is the label for the event validation level of a Broker.
+	LabelValidationLevel = "validation_level"
+
+	// LabelRejectionReason is the label for the reason an event was rejected.
+	LabelRejectionReason = "rejection_reason"
 )
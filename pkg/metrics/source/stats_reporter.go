@@ -42,6 +42,15 @@ var (
 		"Number of retry events sent",
 		stats.UnitDimensionless,
 	)
+
+	// sinkAudienceMismatchCountM is a counter which records the number of
+	// events rejected by the sink because the OIDC token of the source was
+	// issued for another audience.
+	sinkAudienceMismatchCountM = stats.Int64(
+		"sink_audience_mismatch_count",
+		"Number of events rejected by the sink because of an OIDC audience mismatch",
+		stats.UnitDimensionless,
+	)
 	// Create the tag keys that will be used to add tags to our measurements.
 	// Tag keys must conform to the restrictions described in
 	// go.opencensus.io/tag/validate.go. Currently those restrictions are:
@@ -80,6 +89,9 @@ type StatsReporter interface {
 	// ReportEventCount captures the event count. It records one per call.
 	ReportEventCount(args *ReportArgs, responseCode int) error
 	ReportRetryEventCount(args *ReportArgs, responseCode int) error
+	// ReportSinkAudienceMismatch captures an event rejected by the sink
+	// because of an OIDC audience mismatch.
+	ReportSinkAudienceMismatch(args *ReportArgs) error
 }
 
 var _ StatsReporter = (*reporter)(nil)
@@ -118,6 +130,15 @@ func (r *reporter) ReportRetryEventCount(args *ReportArgs, responseCode int) err
 	return nil
 }
 
+func (r *reporter) ReportSinkAudienceMismatch(args *ReportArgs) error {
+	ctx, err := r.generateTag(args, 401)
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, sinkAudienceMismatchCountM.M(1))
+	return nil
+}
+
 func (r *reporter) generateTag(args *ReportArgs, responseCode int) (context.Context, error) {
 	return tag.New(
 		r.ctx,
@@ -161,6 +182,12 @@ func register() {
 			Aggregation: view.Count(),
 			TagKeys:     tagKeys,
 		},
+		&view.View{
+			Description: sinkAudienceMismatchCountM.Description(),
+			Measure:     sinkAudienceMismatchCountM,
+			Aggregation: view.Count(),
+			TagKeys:     tagKeys,
+		},
 	); err != nil {
 		panic(err)
 	}
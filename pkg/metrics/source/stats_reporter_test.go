@@ -79,6 +79,21 @@ func TestStatsReporter(t *testing.T) {
 	})
 	metricstest.CheckCountData(t, "event_count", wantTags, 2)
 	metricstest.CheckCountData(t, "retry_event_count", retryWantTags, 2)
+
+	mismatchWantTags := map[string]string{
+		metrics.LabelNamespaceName:     "testns",
+		metrics.LabelEventType:         "dev.knative.event",
+		metrics.LabelEventSource:       "unit-test",
+		metrics.LabelName:              "testsource",
+		metrics.LabelResourceGroup:     "testresourcegroup",
+		metrics.LabelResponseCode:      "401",
+		metrics.LabelResponseCodeClass: "4xx",
+		metrics.LabelEventScheme:       "http",
+	}
+	expectSuccess(t, func() error {
+		return r.ReportSinkAudienceMismatch(args)
+	})
+	metricstest.CheckCountData(t, "sink_audience_mismatch_count", mismatchWantTags, 1)
 }
 
 func TestBadValues(t *testing.T) {
@@ -116,5 +131,6 @@ func resetMetrics() {
 	// OpenCensus metrics carry global state that need to be reset between unit tests.
 	metricstest.Unregister("event_count")
 	metricstest.Unregister("retry_event_count")
+	metricstest.Unregister("sink_audience_mismatch_count")
 	register()
 }
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectfilter filters the changes of Kubernetes objects with CEL
+// expressions evaluated against the old and the new object.
+package objectfilter
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// OldVariable is the variable of the expressions holding the object
+	// before the change, null when the object is added.
+	OldVariable = "old"
+	// NewVariable is the variable of the expressions holding the object
+	// after the change, null when the object is deleted.
+	NewVariable = "new"
+
+	// costLimit bounds the cost of the evaluation of an expression, so that
+	// an expression can't stall the watch of the resources.
+	costLimit = 1000000
+)
+
+// Filter is a compiled CEL expression.
+type Filter struct {
+	expression string
+	program    cel.Program
+}
+
+// Compile compiles the expression, which must evaluate to a bool.
+func Compile(expression string) (*Filter, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(OldVariable, cel.DynType),
+		cel.Variable(NewVariable, cel.DynType),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("the expression must evaluate to a bool, not %s", t)
+	}
+	program, err := env.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{expression: expression, program: program}, nil
+}
+
+// String returns the expression of the filter.
+func (f *Filter) String() string {
+	return f.expression
+}
+
+// Matches evaluates the expression against the old and the new object,
+// either of which may be nil.
+func (f *Filter) Matches(oldObj, newObj *unstructured.Unstructured) (bool, error) {
+	out, _, err := f.program.Eval(map[string]interface{}{
+		OldVariable: value(oldObj),
+		NewVariable: value(newObj),
+	})
+	if err != nil {
+		return false, err
+	}
+	matches, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("the expression evaluated to %v, not a bool", out.Value())
+	}
+	return matches, nil
+}
+
+func value(u *unstructured.Unstructured) interface{} {
+	if u == nil {
+		return types.NullValue
+	}
+	return u.Object
+}
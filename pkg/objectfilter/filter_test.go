@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectfilter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestCompile(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		wantErr    bool
+	}{
+		"bool":          {expression: "old.status.phase != new.status.phase"},
+		"null check":    {expression: "old == null || has(new.spec)"},
+		"syntax error":  {expression: "old.status.phase !=", wantErr: true},
+		"unknown var":   {expression: "obj.status.phase == 'Running'", wantErr: true},
+		"not a bool":    {expression: "'Running'", wantErr: true},
+		"integer value": {expression: "1 + 2", wantErr: true},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			_, err := Compile(tc.expression)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Compile() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	pending := pod("Pending", 1)
+	running := pod("Running", 2)
+
+	tests := map[string]struct {
+		expression     string
+		oldObj, newObj *unstructured.Unstructured
+		want           bool
+		wantErr        bool
+	}{
+		"phase changed": {
+			expression: "old.status.phase != new.status.phase",
+			oldObj:     pending,
+			newObj:     running,
+			want:       true,
+		},
+		"phase unchanged": {
+			expression: "old.status.phase != new.status.phase",
+			oldObj:     running,
+			newObj:     running,
+			want:       false,
+		},
+		"added": {
+			expression: "old == null",
+			newObj:     running,
+			want:       true,
+		},
+		"deleted": {
+			expression: "new == null && old.status.phase == 'Running'",
+			oldObj:     running,
+			want:       true,
+		},
+		"integer field": {
+			expression: "new.metadata.generation > 1",
+			oldObj:     pending,
+			newObj:     running,
+			want:       true,
+		},
+		"missing field": {
+			expression: "old.status.phase != new.status.phase",
+			newObj:     running,
+			wantErr:    true,
+		},
+		"dyn, not a bool": {
+			expression: "new.status.phase",
+			newObj:     running,
+			wantErr:    true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			f, err := Compile(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := f.Matches(tc.oldObj, tc.newObj)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Matches() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Matches() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func pod(phase string, generation int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"namespace":  "ns",
+				"name":       "pod",
+				"generation": generation,
+			},
+			"status": map[string]interface{}{
+				"phase": phase,
+			},
+		},
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -52,6 +53,7 @@ import (
 	apiserversourcereconciler "knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/apiserversource"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/reconciler/apiserversource/resources"
+	"knative.dev/eventing/pkg/reconciler/dryrun"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 )
 
@@ -68,11 +70,31 @@ func newWarningSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
 	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "SinkNotFound", "Sink not found: %s", string(b))
 }
 
+func newWarningMonitoringSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
+	b, _ := json.Marshal(sink)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "MonitoringSinkNotFound", "Monitoring sink not found: %s", string(b))
+}
+
+func newWarningDeadLetterSinkNotFound(deadLetterSink *duckv1.Destination) pkgreconciler.Event {
+	b, _ := json.Marshal(deadLetterSink)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "DeadLetterSinkNotFound", "Dead letter sink not found: %s", string(b))
+}
+
 // Reconciler reconciles a ApiServerSource object
 type Reconciler struct {
 	kubeClientSet kubernetes.Interface
 
 	receiveAdapterImage string
+	// eventLogSamplingRate is passed to the receive adapters as is.
+	eventLogSamplingRate string
+	// sinkContentEncoding is the default content encoding of the receive
+	// adapters, sources override it with the sink content encoding annotation.
+	sinkContentEncoding string
+	// stripManagedFields, stripLastAppliedConfiguration and memoryWatermark
+	// are the memory guardrails of the receive adapters.
+	stripManagedFields            bool
+	stripLastAppliedConfiguration bool
+	memoryWatermark               int64
 
 	ceSource     string
 	sinkResolver *resolver.URIResolver
@@ -80,6 +102,9 @@ type Reconciler struct {
 	configs         reconcilersource.ConfigAccessor
 	namespaceLister clientv1.NamespaceLister
 
+	// deploymentConfig holds the settings applied to the receive adapters.
+	deploymentConfig *reconcilersource.DeploymentConfigWatcher
+
 	serviceAccountLister       clientv1.ServiceAccountLister
 	roleLister                 rbacv1listers.RoleLister
 	roleBindingLister          rbacv1listers.RoleBindingLister
@@ -107,6 +132,13 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 		}
 	}
 
+	if dryrun.Enabled(source) {
+		return r.reconcileDryRun(ctx, source, dest)
+	}
+	if err := dryrun.Cleanup(ctx, r.kubeClientSet, source); err != nil {
+		return err
+	}
+
 	// OIDC authentication
 	featureFlags := feature.FromContext(ctx)
 	if err := auth.SetupOIDCServiceAccount(ctx, featureFlags, r.serviceAccountLister, r.kubeClientSet, v1.SchemeGroupVersion.WithKind("ApiServerSource"), source.ObjectMeta, &source.Status, func(as *duckv1.AuthStatus) {
@@ -129,6 +161,18 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 			logging.FromContext(ctx).Errorw("Failed when creating the OIDC RoleBinding for ApiServerSource", zap.Error(err))
 			return err
 		}
+	} else {
+		// Remove the role and rolebinding, they are not needed without OIDC
+		if err := r.deleteOIDCRBAC(ctx, source, resources.GetOIDCTokenRoleName(source.Name), resources.GetOIDCTokenRoleBindingName(source.Name)); err != nil {
+			logging.FromContext(ctx).Errorw("Failed when deleting the OIDC Role and RoleBinding for ApiServerSource", zap.Error(err))
+			return err
+		}
+	}
+
+	// Remove the role and rolebinding with the fixed name of older versions
+	if err := r.deleteOIDCRBAC(ctx, source, resources.LegacyOIDCTokenRoleName, resources.LegacyOIDCTokenRoleName); err != nil {
+		logging.FromContext(ctx).Errorw("Failed when deleting the legacy OIDC Role and RoleBinding for ApiServerSource", zap.Error(err))
+		return err
 	}
 
 	sinkAddr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, source)
@@ -138,6 +182,15 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 	}
 	source.Status.MarkSink(sinkAddr)
 
+	if err := r.resolveDeadLetterSink(ctx, source); err != nil {
+		return err
+	}
+
+	monitoringSinkAddr, err := r.resolveMonitoringSink(ctx, source, sinkAddr)
+	if err != nil {
+		return err
+	}
+
 	// resolve namespaces to watch
 	namespaces, err := r.namespacesFromSelector(source)
 	if err != nil {
@@ -158,13 +211,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 
 	// An empty selector targets all namespaces.
 	allNamespaces := isEmptySelector(source.Spec.NamespaceSelector)
-	ra, err := r.createReceiveAdapter(ctx, source, sinkAddr, namespaces, allNamespaces)
+	ra, err := r.createReceiveAdapter(ctx, source, sinkAddr, monitoringSinkAddr, namespaces, allNamespaces, nil)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Unable to create the receive adapter", zap.Error(err))
 		return err
 	}
 
 	source.Status.PropagateDeploymentAvailability(ra)
+	source.Status.PropagateSinkToDeployment(ra)
 
 	cloudEventAttributes, err := r.createCloudEventAttributes(source)
 	if err != nil {
@@ -176,6 +230,84 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ApiServerSour
 	return nil
 }
 
+// reconcileDryRun publishes the changes of the receive adapter in a report
+// instead of applying them. The OIDC Role and RoleBinding, and the trust
+// bundles, are left untouched.
+func (r *Reconciler) reconcileDryRun(ctx context.Context, source *v1.ApiServerSource, dest *duckv1.Destination) pkgreconciler.Event {
+	sinkAddr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, source)
+	if err != nil {
+		source.Status.MarkNoSink("NotFound", "")
+		return newWarningSinkNotFound(dest)
+	}
+	source.Status.MarkSink(sinkAddr)
+
+	if err := r.resolveDeadLetterSink(ctx, source); err != nil {
+		return err
+	}
+
+	monitoringSinkAddr, err := r.resolveMonitoringSink(ctx, source, sinkAddr)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := r.namespacesFromSelector(source)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("cannot retrieve namespaces to watch", zap.Error(err))
+		return err
+	}
+
+	plan := dryrun.NewPlan(source)
+	if _, err := r.createReceiveAdapter(ctx, source, sinkAddr, monitoringSinkAddr, namespaces, isEmptySelector(source.Spec.NamespaceSelector), plan); err != nil {
+		logging.FromContext(ctx).Errorw("Unable to plan the receive adapter", zap.Error(err))
+		return err
+	}
+	return dryrun.Publish(ctx, r.kubeClientSet, plan)
+}
+
+// resolveDeadLetterSink resolves the dead letter sink of the source, if any,
+// and sets it in its status.
+func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, source *v1.ApiServerSource) pkgreconciler.Event {
+	if source.Spec.Delivery == nil || source.Spec.Delivery.DeadLetterSink == nil {
+		source.Status.MarkDeadLetterSink(nil)
+		return nil
+	}
+
+	dest := source.Spec.Delivery.DeadLetterSink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = source.GetNamespace()
+	}
+	addr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, source)
+	if err != nil {
+		source.Status.MarkNoDeadLetterSink("DeadLetterSinkNotFound", "The dead letter sink couldn't be resolved: %v", err)
+		return newWarningDeadLetterSinkNotFound(dest)
+	}
+	source.Status.MarkDeadLetterSink(addr)
+	return nil
+}
+
+// resolveMonitoringSink resolves the sink of the events about the source,
+// falling back to the sink of the source. It returns nil when the monitoring
+// of the source is disabled.
+func (r *Reconciler) resolveMonitoringSink(ctx context.Context, source *v1.ApiServerSource, sinkAddr *duckv1.Addressable) (*duckv1.Addressable, pkgreconciler.Event) {
+	if source.Spec.Monitoring == nil {
+		return nil, nil
+	}
+	if source.Spec.Monitoring.Sink == nil {
+		return sinkAddr, nil
+	}
+
+	dest := source.Spec.Monitoring.Sink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = source.GetNamespace()
+	}
+	addr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, source)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Unable to resolve the monitoring sink", zap.Error(err))
+		return nil, newWarningMonitoringSinkNotFound(dest)
+	}
+	return addr, nil
+}
+
 func (r *Reconciler) FinalizeKind(ctx context.Context, source *v1.ApiServerSource) pkgreconciler.Event {
 	logging.FromContext(ctx).Info("Deleting source")
 	// Allow for eventtypes to be cleaned up
@@ -218,7 +350,7 @@ func isEmptySelector(selector *metav1.LabelSelector) bool {
 	return false
 }
 
-func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServerSource, sinkAddr *duckv1.Addressable, namespaces []string, allNamespaces bool) (*appsv1.Deployment, error) {
+func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServerSource, sinkAddr, monitoringSinkAddr *duckv1.Addressable, namespaces []string, allNamespaces bool, plan *dryrun.Plan) (*appsv1.Deployment, error) {
 	// TODO: missing.
 	// if err := checkResourcesStatus(src); err != nil {
 	// 	return nil, err
@@ -226,6 +358,11 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 
 	featureFlags := feature.FromContext(ctx)
 
+	sinkContentEncoding := r.sinkContentEncoding
+	if encoding, ok := src.Annotations[apisources.SinkContentEncodingAnnotationKey]; ok {
+		sinkContentEncoding = encoding
+	}
+
 	adapterArgs := resources.ReceiveAdapterArgs{
 		Image:         r.receiveAdapterImage,
 		Source:        src,
@@ -237,6 +374,27 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 		Namespaces:    namespaces,
 		AllNamespaces: allNamespaces,
 		NodeSelector:  featureFlags.NodeSelector(),
+
+		EventLogSamplingRate: r.eventLogSamplingRate,
+		SinkContentEncoding:  sinkContentEncoding,
+
+		StripManagedFields:            r.stripManagedFields,
+		StripLastAppliedConfiguration: r.stripLastAppliedConfiguration,
+		MemoryWatermark:               r.memoryWatermark,
+
+		DeploymentConfig: r.deploymentConfig.Config(),
+
+		TraceContextInjection: src.Annotations[apisources.TraceContextInjectionAnnotationKey],
+
+		MonitoringSink: monitoringSinkAddr,
+	}
+
+	if src.Status.DeliveryStatus.IsSet() {
+		adapterArgs.DeadLetterSink = &duckv1.Addressable{
+			URL:      src.Status.DeadLetterSinkURI,
+			CACerts:  src.Status.DeadLetterSinkCACerts,
+			Audience: src.Status.DeadLetterSinkAudience,
+		}
 	}
 
 	expected, err := resources.MakeReceiveAdapter(&adapterArgs)
@@ -252,6 +410,9 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 
 	ra, err := r.kubeClientSet.AppsV1().Deployments(src.Namespace).Get(ctx, expected.Name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
+		if plan != nil {
+			return nil, plan.Record(dryrun.Create, "Deployment", expected)
+		}
 		ra, err = r.kubeClientSet.AppsV1().Deployments(src.Namespace).Create(ctx, expected, metav1.CreateOptions{})
 		msg := "Deployment created"
 		if err != nil {
@@ -263,8 +424,12 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 		return nil, fmt.Errorf("error getting receive adapter: %v", err)
 	} else if !metav1.IsControlledBy(ra, src) {
 		return nil, fmt.Errorf("deployment %q is not owned by ApiServerSource %q", ra.Name, src.Name)
-	} else if r.podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) {
+	} else if r.podSpecChanged(ra.Spec.Template.Spec, expected.Spec.Template.Spec) || !equality.Semantic.DeepEqual(ra.Spec.Replicas, expected.Spec.Replicas) {
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
+		ra.Spec.Replicas = expected.Spec.Replicas
+		if plan != nil {
+			return nil, plan.Record(dryrun.Update, "Deployment", ra)
+		}
 		if ra, err = r.kubeClientSet.AppsV1().Deployments(src.Namespace).Update(ctx, ra, metav1.UpdateOptions{}); err != nil {
 			return ra, err
 		}
@@ -273,6 +438,9 @@ func (r *Reconciler) createReceiveAdapter(ctx context.Context, src *v1.ApiServer
 	} else {
 		logging.FromContext(ctx).Debugw("Reusing existing receive adapter", zap.Any("receiveAdapter", ra))
 	}
+	if plan != nil {
+		return ra, plan.Record(dryrun.Unchanged, "Deployment", ra)
+	}
 	return ra, nil
 }
 
@@ -320,30 +488,9 @@ func (r *Reconciler) runAccessCheck(ctx context.Context, src *v1.ApiServerSource
 		gvr, _ := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Kind: res.Kind, Group: gv.Group, Version: gv.Version}) // TODO: Test for nil Kind.
 
 		for _, ns := range namespaces {
-			missingVerbs := ""
-			sep1 := ""
-			for _, verb := range verbs {
-				sar := &authorizationv1.SubjectAccessReview{
-					Spec: authorizationv1.SubjectAccessReviewSpec{
-						ResourceAttributes: &authorizationv1.ResourceAttributes{
-							Namespace: ns,
-							Verb:      verb,
-							Group:     gv.Group,
-							Resource:  gvr.Resource,
-						},
-						User: user,
-					},
-				}
-
-				response, err := r.kubeClientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
-				if err != nil {
-					return err
-				}
-
-				if !response.Status.Allowed {
-					missingVerbs += sep1 + verb
-					sep1 = ", "
-				}
+			missingVerbs, err := r.missingVerbs(ctx, user, ns, gv.Group, gvr.Resource, verbs)
+			if err != nil {
+				return err
 			}
 
 			if missingVerbs != "" {
@@ -352,6 +499,18 @@ func (r *Reconciler) runAccessCheck(ctx context.Context, src *v1.ApiServerSource
 			}
 		}
 	}
+
+	// The replicas of the receive adapter elect a leader with a Lease.
+	if src.Replicas() > 1 {
+		missingVerbs, err := r.missingVerbs(ctx, user, src.Namespace, coordinationv1.GroupName, "leases", []string{"get", "create", "update"})
+		if err != nil {
+			return err
+		}
+
+		if missingVerbs != "" {
+			missing += sep + missingVerbs + ` resource "leases" in API group "` + coordinationv1.GroupName + `" in Namespace "` + src.Namespace + `"`
+		}
+	}
 	if missing == "" {
 		src.Status.MarkSufficientPermissions()
 		return nil
@@ -361,15 +520,53 @@ func (r *Reconciler) runAccessCheck(ctx context.Context, src *v1.ApiServerSource
 	return fmt.Errorf("insufficient permissions: User %s cannot %s", user, missing)
 }
 
+// missingVerbs returns the comma separated verbs the user isn't allowed to
+// use on the resource in the namespace.
+func (r *Reconciler) missingVerbs(ctx context.Context, user, namespace, group, resource string, verbs []string) (string, error) {
+	missingVerbs := ""
+	sep := ""
+	for _, verb := range verbs {
+		sar := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     group,
+					Resource:  resource,
+				},
+				User: user,
+			},
+		}
+
+		response, err := r.kubeClientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		if !response.Status.Allowed {
+			missingVerbs += sep + verb
+			sep = ", "
+		}
+	}
+	return missingVerbs, nil
+}
+
 func (r *Reconciler) createCloudEventAttributes(src *v1.ApiServerSource) ([]duckv1.CloudEventAttributes, error) {
 	var eventTypes []string
 	if src.Spec.EventMode == v1.ReferenceMode {
 		eventTypes = apisources.ApiServerSourceEventReferenceModeTypes
 	} else if src.Spec.EventMode == v1.ResourceMode {
 		eventTypes = apisources.ApiServerSourceEventResourceModeTypes
+	} else if src.Spec.EventMode == v1.ResourceDiffMode {
+		eventTypes = apisources.ApiServerSourceEventResourceDiffModeTypes
 	} else {
 		return []duckv1.CloudEventAttributes{}, fmt.Errorf("no EventType available for EventMode: %s", src.Spec.EventMode)
 	}
+	// The events about the source are only registered when they are sent to
+	// the sink of the source.
+	if src.Spec.Monitoring != nil && src.Spec.Monitoring.Sink == nil {
+		eventTypes = append(eventTypes[:len(eventTypes):len(eventTypes)], apisources.ApiServerSourceMonitoringEventTypes...)
+	}
 	ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(eventTypes))
 	for _, apiServerSourceType := range eventTypes {
 		ceAttributes = append(ceAttributes, duckv1.CloudEventAttributes{
@@ -400,6 +597,11 @@ func (r *Reconciler) createOIDCRole(ctx context.Context, source *v1.ApiServerSou
 		if err != nil {
 			return fmt.Errorf("could not create OIDC service account role %s/%s for %s: %w", source.GetName(), source.GetNamespace(), "ApiServerSource", err)
 		}
+	} else if err != nil {
+		return fmt.Errorf("could not get OIDC service account role %s/%s: %w", source.GetNamespace(), roleName, err)
+	} else if !metav1.IsControlledBy(role, source) {
+		// Never touch a role, which belongs to someone else
+		return fmt.Errorf("role %s not owned by ApiServerSource %s", roleName, source.GetName())
 	} else {
 		// If the role does exist, we will check whether an update is needed
 		// By comparing the role's rule
@@ -438,6 +640,11 @@ func (r *Reconciler) createOIDCRoleBinding(ctx context.Context, source *v1.ApiSe
 		if err != nil {
 			return fmt.Errorf("could not create OIDC service account rolebinding %s/%s for %s: %w", source.GetName(), source.GetNamespace(), "apiserversource", err)
 		}
+	} else if err != nil {
+		return fmt.Errorf("could not get OIDC service account rolebinding %s/%s: %w", source.GetNamespace(), roleBindingName, err)
+	} else if !metav1.IsControlledBy(roleBinding, source) {
+		// Never touch a rolebinding, which belongs to someone else
+		return fmt.Errorf("rolebinding %s not owned by ApiServerSource %s", roleBindingName, source.GetName())
 	} else {
 		// If the role does exist, we will check whether an update is needed
 		// By comparing the role's rule
@@ -458,6 +665,35 @@ func (r *Reconciler) createOIDCRoleBinding(ctx context.Context, source *v1.ApiSe
 	return nil
 }
 
+// deleteOIDCRBAC deletes the given OIDC role and rolebinding, if they exist
+// and are controlled by the source. Objects owned by other sources are left
+// untouched.
+func (r *Reconciler) deleteOIDCRBAC(ctx context.Context, source *v1.ApiServerSource, roleName, roleBindingName string) error {
+	roleBinding, err := r.roleBindingLister.RoleBindings(source.GetNamespace()).Get(roleBindingName)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("could not get OIDC service account rolebinding %s/%s: %w", source.GetNamespace(), roleBindingName, err)
+	}
+	if err == nil && metav1.IsControlledBy(roleBinding, source) {
+		err = r.kubeClientSet.RbacV1().RoleBindings(source.GetNamespace()).Delete(ctx, roleBindingName, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("could not delete OIDC service account rolebinding %s/%s: %w", source.GetNamespace(), roleBindingName, err)
+		}
+	}
+
+	role, err := r.roleLister.Roles(source.GetNamespace()).Get(roleName)
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("could not get OIDC service account role %s/%s: %w", source.GetNamespace(), roleName, err)
+	}
+	if err == nil && metav1.IsControlledBy(role, source) {
+		err = r.kubeClientSet.RbacV1().Roles(source.GetNamespace()).Delete(ctx, roleName, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("could not delete OIDC service account role %s/%s: %w", source.GetNamespace(), roleName, err)
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) propagateTrustBundles(ctx context.Context, source *v1.ApiServerSource) error {
 	gvk := schema.GroupVersionKind{
 		Group:   v1.SchemeGroupVersion.Group,
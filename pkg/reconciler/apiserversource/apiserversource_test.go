@@ -18,6 +18,7 @@ package apiserversource
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -31,13 +32,17 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 
+	"knative.dev/eventing/pkg/adapter/apiserver"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	apisources "knative.dev/eventing/pkg/apis/sources"
 	"knative.dev/eventing/pkg/eventingtls"
 
 	"knative.dev/pkg/apis"
@@ -49,6 +54,7 @@ import (
 	"knative.dev/pkg/controller"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/network"
+	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/tracker"
 
@@ -103,6 +109,13 @@ var (
 		URL:      sinkURL,
 		Audience: &sinkAudience,
 	}
+	sinkRotatedCACerts     = "rotated-ca-certs"
+	sinkRotatedAddressable = &duckv1.Addressable{
+		Name:     &sinkURL.Scheme,
+		URL:      sinkURL,
+		CACerts:  &sinkRotatedCACerts,
+		Audience: &sinkAudience,
+	}
 	sinkOIDCDest = duckv1.Destination{
 		Ref: &duckv1.KReference{
 			Name:       sinkName,
@@ -111,6 +124,19 @@ var (
 		},
 		Audience: &sinkAudience,
 	}
+
+	deadLetterSinkDest = duckv1.Destination{
+		Ref: &duckv1.KReference{
+			Name:       deadLetterSinkName,
+			Kind:       "Channel",
+			APIVersion: "messaging.knative.dev/v1",
+		},
+	}
+	deadLetterSinkURL         = apis.HTTP("dls.mynamespace.svc." + network.GetClusterDomainName())
+	deadLetterSinkAddressable = &duckv1.Addressable{
+		Name: &deadLetterSinkURL.Scheme,
+		URL:  deadLetterSinkURL,
+	}
 )
 
 const (
@@ -119,8 +145,9 @@ const (
 	sourceUID  = "1234"
 	testNS     = "testnamespace"
 
-	sinkName = "testsink"
-	source   = "apiserveraddr"
+	sinkName           = "testsink"
+	deadLetterSinkName = "testdls"
+	source             = "apiserveraddr"
 
 	generation = 1
 )
@@ -229,6 +256,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -318,6 +346,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -409,6 +438,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -486,6 +516,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -592,6 +623,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -652,6 +684,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceNamespaceSelector(metav1.LabelSelector{MatchLabels: map[string]string{"target": "yes"}}),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{"test-a", "test-b"}),
@@ -720,6 +753,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceNamespaceSelector(metav1.LabelSelector{}),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{"test-a", "test-b", "test-c"}),
@@ -789,6 +823,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceResourceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -845,6 +880,7 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -908,7 +944,7 @@ func TestReconcile(t *testing.T) {
 		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
 	}, {
-		Name: "receive adapter does not exist, fails to create",
+		Name: "valid with dead letter sink",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -917,6 +953,10 @@ func TestReconcile(t *testing.T) {
 						Kind:       "Namespace",
 					}},
 					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &deadLetterSinkDest,
+						Retry:          ptr.Int32(3),
+					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
@@ -925,19 +965,16 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithInitChannelConditions,
 				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
+			rttestingv1.NewChannel(deadLetterSinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(deadLetterSinkAddressable),
+			),
+			makeAvailableReceiveAdapterWithDelivery(t, &apiserver.DeliveryConfig{
+				Retry:          3,
+				DeadLetterSink: &duckv1.Addressable{URL: deadLetterSinkURL},
+			}),
 		},
-		Key:     testNS + "/" + sourceName,
-		WantErr: true,
-		WantEvents: []string{
-			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
-			Eventf(corev1.EventTypeNormal, apiserversourceDeploymentCreated,
-				"Deployment created, error:inducing failure for create deployments"),
-			Eventf(corev1.EventTypeWarning, "InternalError",
-				"inducing failure for create deployments"),
-		},
-		WantPatches: []clientgotesting.PatchActionImpl{
-			patchFinalizers(sourceName, testNS),
-		},
+		Key: testNS + "/" + sourceName,
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -946,14 +983,22 @@ func TestReconcile(t *testing.T) {
 						Kind:       "Namespace",
 					}},
 					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &deadLetterSinkDest,
+						Retry:          ptr.Int32(3),
+					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeployed,
 				rttestingv1.WithApiServerSourceSink(sinkURI),
-				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceDeadLetterSink(&duckv1.Addressable{URL: deadLetterSinkURL}),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 			),
@@ -962,16 +1007,17 @@ func TestReconcile(t *testing.T) {
 			makeSubjectAccessReview("namespaces", "get", "default"),
 			makeSubjectAccessReview("namespaces", "list", "default"),
 			makeSubjectAccessReview("namespaces", "watch", "default"),
-			makeReceiveAdapter(t),
 		},
-		WithReactors: []clientgotesting.ReactionFunc{
-			subjectAccessReviewCreateReactor(true),
-			InduceFailure("create", "Deployments"),
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
 		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
-
 	}, {
-		Name: "valid with relative uri reference",
+		Name: "missing dead letter sink",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -979,11 +1025,9 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{
-						Sink: duckv1.Destination{
-							Ref: sinkDest.Ref,
-							URI: &apis.URL{Path: sinkURIReference},
-						},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &deadLetterSinkDest,
 					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
@@ -993,9 +1037,16 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithInitChannelConditions,
 				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
-			makeAvailableReceiveAdapterWithTargetURI(t),
 		},
 		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeWarning, "DeadLetterSinkNotFound",
+				`Dead letter sink not found: {"ref":{"kind":"Channel","namespace":"testnamespace","name":"testdls","apiVersion":"messaging.knative.dev/v1"}}`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1003,21 +1054,68 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{
-						Sink: duckv1.Destination{
-							Ref: sinkDest.Ref,
-							URI: &apis.URL{Path: sinkURIReference},
-						},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Delivery: &eventingduckv1.DeliverySpec{
+						DeadLetterSink: &deadLetterSinkDest,
 					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceDeadLetterSinkNotFound(
+					`The dead letter sink couldn't be resolved: failed to get object testnamespace/testdls: channels.messaging.knative.dev "testdls" not found`),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "valid with monitoring to the sink",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
+			),
+			makeAvailableReceiveAdapterWithMonitoring(t, &apiserver.MonitoringConfig{
+				Sink: sinkAddressable,
+			}),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
 				rttestingv1.WithApiServerSourceDeployed,
-				rttestingv1.WithApiServerSourceSink(sinkTargetURI),
+				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceMonitoringEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -1037,7 +1135,7 @@ func TestReconcile(t *testing.T) {
 		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
 	}, {
-		Name: "deployment update due to env",
+		Name: "valid with monitoring sink",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1046,6 +1144,7 @@ func TestReconcile(t *testing.T) {
 						Kind:       "Namespace",
 					}},
 					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{Sink: &deadLetterSinkDest},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
@@ -1054,16 +1153,15 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithInitChannelConditions,
 				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
-			makeReceiveAdapterWithDifferentEnv(t),
+			rttestingv1.NewChannel(deadLetterSinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(deadLetterSinkAddressable),
+			),
+			makeAvailableReceiveAdapterWithMonitoring(t, &apiserver.MonitoringConfig{
+				Sink: deadLetterSinkAddressable,
+			}),
 		},
 		Key: testNS + "/" + sourceName,
-		WantEvents: []string{
-			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
-			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
-		},
-		WantPatches: []clientgotesting.PatchActionImpl{
-			patchFinalizers(sourceName, testNS),
-		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1072,32 +1170,37 @@ func TestReconcile(t *testing.T) {
 						Kind:       "Namespace",
 					}},
 					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{Sink: &deadLetterSinkDest},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeployed,
 				rttestingv1.WithApiServerSourceSink(sinkURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
-				rttestingv1.WithApiServerSourceDeploymentUnavailable,
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 			),
 		}},
-		WantUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: makeReceiveAdapter(t),
-		}},
 		WantCreates: []runtime.Object{
 			makeSubjectAccessReview("namespaces", "get", "default"),
 			makeSubjectAccessReview("namespaces", "list", "default"),
 			makeSubjectAccessReview("namespaces", "watch", "default"),
 		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
 		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
 	}, {
-		Name: "deployment update due to service account",
+		Name: "missing monitoring sink",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1105,10 +1208,8 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{
-						Sink: sinkDest,
-					},
-					ServiceAccountName: "malin",
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{Sink: &deadLetterSinkDest},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
@@ -1117,12 +1218,12 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithInitChannelConditions,
 				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
-			makeReceiveAdapterWithDifferentServiceAccount(t, "morgan"),
 		},
 		Key: testNS + "/" + sourceName,
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
-			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+			Eventf(corev1.EventTypeWarning, "MonitoringSinkNotFound",
+				`Monitoring sink not found: {"ref":{"kind":"Channel","namespace":"testnamespace","name":"testdls","apiVersion":"messaging.knative.dev/v1"}}`),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchFinalizers(sourceName, testNS),
@@ -1134,36 +1235,22 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{
-						Sink: sinkDest,
-					},
-					ServiceAccountName: "malin",
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					Monitoring: &sourcesv1.ApiServerSourceMonitoring{Sink: &deadLetterSinkDest},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
-				rttestingv1.WithApiServerSourceDeploymentUnavailable,
 				rttestingv1.WithApiServerSourceSink(sinkURI),
-				rttestingv1.WithApiServerSourceSufficientPermissions,
-				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
-				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 			),
 		}},
-		WantUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: makeReceiveAdapterWithDifferentServiceAccount(t, "malin"),
-		}},
-		WantCreates: []runtime.Object{
-			makeSubjectAccessReview("namespaces", "get", "malin"),
-			makeSubjectAccessReview("namespaces", "list", "malin"),
-			makeSubjectAccessReview("namespaces", "watch", "malin"),
-		},
 		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
 	}, {
-		Name: "deployment update due to container count",
+		Name: "receive adapter does not exist, fails to create",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1180,12 +1267,15 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithInitChannelConditions,
 				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
-			makeReceiveAdapterWithDifferentContainerCount(t),
 		},
-		Key: testNS + "/" + sourceName,
+		Key:     testNS + "/" + sourceName,
+		WantErr: true,
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
-			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+			Eventf(corev1.EventTypeNormal, apiserversourceDeploymentCreated,
+				"Deployment created, error:inducing failure for create deployments"),
+			Eventf(corev1.EventTypeWarning, "InternalError",
+				"inducing failure for create deployments"),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchFinalizers(sourceName, testNS),
@@ -1203,27 +1293,27 @@ func TestReconcile(t *testing.T) {
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
-				rttestingv1.WithApiServerSourceDeploymentUnavailable,
 				rttestingv1.WithApiServerSourceSink(sinkURI),
-				rttestingv1.WithApiServerSourceSufficientPermissions,
-				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 			),
 		}},
-		WantUpdates: []clientgotesting.UpdateActionImpl{{
-			Object: makeReceiveAdapter(t),
-		}},
 		WantCreates: []runtime.Object{
 			makeSubjectAccessReview("namespaces", "get", "default"),
 			makeSubjectAccessReview("namespaces", "list", "default"),
 			makeSubjectAccessReview("namespaces", "watch", "default"),
+			makeReceiveAdapter(t),
+		},
+		WithReactors: []clientgotesting.ReactionFunc{
+			subjectAccessReviewCreateReactor(true),
+			InduceFailure("create", "Deployments"),
 		},
-		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+
 	}, {
-		Name: "valid with broker sink",
+		Name: "valid with relative uri reference",
 		Objects: []runtime.Object{
 			rttestingv1.NewApiServerSource(sourceName, testNS,
 				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
@@ -1231,16 +1321,21 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{Sink: brokerDest},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: sinkDest.Ref,
+							URI: &apis.URL{Path: sinkURIReference},
+						},
+					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 			),
-			rttestingv1.NewBroker(sinkName, testNS,
-				rttestingv1.WithInitBrokerConditions,
-				rttestingv1.WithBrokerAddressURI(apis.HTTP(sinkDNS)),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
 			),
-			makeAvailableReceiveAdapter(t),
+			makeAvailableReceiveAdapterWithTargetURI(t),
 		},
 		Key: testNS + "/" + sourceName,
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
@@ -1250,78 +1345,458 @@ func TestReconcile(t *testing.T) {
 						APIVersion: "v1",
 						Kind:       "Namespace",
 					}},
-					SourceSpec: duckv1.SourceSpec{Sink: brokerDest},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: duckv1.Destination{
+							Ref: sinkDest.Ref,
+							URI: &apis.URL{Path: sinkURIReference},
+						},
+					},
 				}),
 				rttestingv1.WithApiServerSourceUID(sourceUID),
 				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
 				// Status Update:
 				rttestingv1.WithInitApiServerSourceConditions,
 				rttestingv1.WithApiServerSourceDeployed,
-				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSink(sinkTargetURI),
 				rttestingv1.WithApiServerSourceSufficientPermissions,
 				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
 				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
 			),
 		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+		},
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchFinalizers(sourceName, testNS),
 		},
-		WantCreates: []runtime.Object{
-			makeSubjectAccessReview("namespaces", "get", "default"),
-			makeSubjectAccessReview("namespaces", "list", "default"),
-			makeSubjectAccessReview("namespaces", "watch", "default"),
-		},
 		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
 		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
-	},
-		{
-			Name: "OIDC: creates OIDC service account",
-			Ctx: feature.ToContext(context.Background(), feature.Flags{
-				feature.OIDCAuthentication: feature.Enabled,
-			}),
-			Objects: []runtime.Object{
-				rttestingv1.NewApiServerSource(sourceName, testNS,
-					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
-						Resources: []sourcesv1.APIVersionKindSelector{{
-							APIVersion: "v1",
-							Kind:       "Namespace",
-						}},
-						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
-					}),
-					rttestingv1.WithApiServerSourceUID(sourceUID),
-					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
-				),
-				rttestingv1.NewChannel(sinkName, testNS,
-					rttestingv1.WithInitChannelConditions,
-					rttestingv1.WithChannelAddress(sinkOIDCAddressable),
-				),
-				makeOIDCRole(),
-				makeOIDCRoleBinding(),
-				makeAvailableReceiveAdapterWithOIDC(t),
-			},
-			Key: testNS + "/" + sourceName,
-			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
-				Object: rttestingv1.NewApiServerSource(sourceName, testNS,
-					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
-						Resources: []sourcesv1.APIVersionKindSelector{{
-							APIVersion: "v1",
-							Kind:       "Namespace",
-						}},
-						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
-					}),
-					rttestingv1.WithApiServerSourceUID(sourceUID),
-					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
-					// Status Update:
-					rttestingv1.WithInitApiServerSourceConditions,
+	}, {
+		Name: "deployment update due to env",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
+			),
+			makeReceiveAdapterWithDifferentEnv(t),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceDeploymentUnavailable,
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeReceiveAdapter(t),
+		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "deployment update due to sink CA certs and audience rotation",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkRotatedAddressable),
+			),
+			makeAvailableReceiveAdapter(t),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeployed,
+				rttestingv1.WithApiServerSourceSinkAddressable(sinkRotatedAddressable),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeAvailableReceiveAdapterWithSink(t, sinkRotatedAddressable),
+		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "deployment update due to replicas",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				rttestingv1.WithApiServerSourceAnnotation(apisources.ApiServerSourceReplicasAnnotationKey, "2"),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
+			),
+			makeAvailableReceiveAdapter(t),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				rttestingv1.WithApiServerSourceAnnotation(apisources.ApiServerSourceReplicasAnnotationKey, "2"),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeployed,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeAvailableReceiveAdapterWithReplicas(t, 2),
+		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+			makeLeaseSubjectAccessReview("get", "default"),
+			makeLeaseSubjectAccessReview("create", "default"),
+			makeLeaseSubjectAccessReview("update", "default"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "deployment update due to service account",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: sinkDest,
+					},
+					ServiceAccountName: "malin",
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
+			),
+			makeReceiveAdapterWithDifferentServiceAccount(t, "morgan"),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{
+						Sink: sinkDest,
+					},
+					ServiceAccountName: "malin",
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeploymentUnavailable,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeReceiveAdapterWithDifferentServiceAccount(t, "malin"),
+		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "malin"),
+			makeSubjectAccessReview("namespaces", "list", "malin"),
+			makeSubjectAccessReview("namespaces", "watch", "malin"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "deployment update due to container count",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewChannel(sinkName, testNS,
+				rttestingv1.WithInitChannelConditions,
+				rttestingv1.WithChannelAddress(sinkAddressable),
+			),
+			makeReceiveAdapterWithDifferentContainerCount(t),
+		},
+		Key: testNS + "/" + sourceName,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			Eventf(corev1.EventTypeNormal, "ApiServerSourceDeploymentUpdated", `Deployment "apiserversource-test-apiserver-source-1234" updated`),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeploymentUnavailable,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeReceiveAdapter(t),
+		}},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	}, {
+		Name: "valid with broker sink",
+		Objects: []runtime.Object{
+			rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: brokerDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+			),
+			rttestingv1.NewBroker(sinkName, testNS,
+				rttestingv1.WithInitBrokerConditions,
+				rttestingv1.WithBrokerAddressURI(apis.HTTP(sinkDNS)),
+			),
+			makeAvailableReceiveAdapter(t),
+		},
+		Key: testNS + "/" + sourceName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+				rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+					Resources: []sourcesv1.APIVersionKindSelector{{
+						APIVersion: "v1",
+						Kind:       "Namespace",
+					}},
+					SourceSpec: duckv1.SourceSpec{Sink: brokerDest},
+				}),
+				rttestingv1.WithApiServerSourceUID(sourceUID),
+				rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				// Status Update:
+				rttestingv1.WithInitApiServerSourceConditions,
+				rttestingv1.WithApiServerSourceDeployed,
+				rttestingv1.WithApiServerSourceSink(sinkURI),
+				rttestingv1.WithApiServerSourceSufficientPermissions,
+				rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+				rttestingv1.WithApiServerSourceSinkPropagated,
+				rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+				rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+				rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(sourceName, testNS),
+		},
+		WantCreates: []runtime.Object{
+			makeSubjectAccessReview("namespaces", "get", "default"),
+			makeSubjectAccessReview("namespaces", "list", "default"),
+			makeSubjectAccessReview("namespaces", "watch", "default"),
+		},
+		WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+		SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+	},
+		{
+			Name: "OIDC: creates OIDC service account",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				),
+				rttestingv1.NewChannel(sinkName, testNS,
+					rttestingv1.WithInitChannelConditions,
+					rttestingv1.WithChannelAddress(sinkOIDCAddressable),
+				),
+				makeOIDCRole(),
+				makeOIDCRoleBinding(),
+				makeAvailableReceiveAdapterWithOIDC(t),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rttestingv1.WithInitApiServerSourceConditions,
 					rttestingv1.WithApiServerSourceDeployed,
 					rttestingv1.WithApiServerSourceSinkAddressable(sinkOIDCAddressable),
 					rttestingv1.WithApiServerSourceSufficientPermissions,
 					rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+					rttestingv1.WithApiServerSourceSinkPropagated,
 					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 					rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceeded(),
@@ -1439,6 +1914,122 @@ func TestReconcile(t *testing.T) {
 					rttestingv1.WithApiServerSourceSinkAddressable(sinkOIDCAddressable),
 					rttestingv1.WithApiServerSourceSufficientPermissions,
 					rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+					rttestingv1.WithApiServerSourceSinkPropagated,
+					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+					rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceeded(),
+					rttestingv1.WithApiServerSourceOIDCServiceAccountName(makeApiServerSourceOIDCServiceAccount().Name),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeOIDCRole(),
+				makeOIDCRoleBinding(),
+				makeSubjectAccessReview("namespaces", "get", "default"),
+				makeSubjectAccessReview("namespaces", "list", "default"),
+				makeSubjectAccessReview("namespaces", "watch", "default"),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+			WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+			SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+		}, {
+			Name: "OIDC: role not owned by ApiServerSource",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				),
+				makeOIDCRoleWithoutOwnerRef(resources.GetOIDCTokenRoleName(sourceName)),
+				makeApiServerSourceOIDCServiceAccount(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rttestingv1.WithInitApiServerSourceConditions,
+					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceeded(),
+					rttestingv1.WithApiServerSourceOIDCServiceAccountName(makeApiServerSourceOIDCServiceAccount().Name),
+				),
+			}},
+			WantErr: true,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+				Eventf(corev1.EventTypeWarning, "InternalError", fmt.Sprintf("role %s not owned by ApiServerSource %s", resources.GetOIDCTokenRoleName(sourceName), sourceName)),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+		}, {
+			Name: "OIDC: deletes legacy role and rolebinding",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				),
+				rttestingv1.NewChannel(sinkName, testNS,
+					rttestingv1.WithInitChannelConditions,
+					rttestingv1.WithChannelAddress(sinkOIDCAddressable),
+				),
+				makeAvailableReceiveAdapterWithOIDC(t),
+				makeApiServerSourceOIDCServiceAccount(),
+				makeOIDCRole(),
+				makeOIDCRoleBinding(),
+				makeLegacyOIDCRole(),
+				makeLegacyOIDCRoleBinding(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkOIDCDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rttestingv1.WithInitApiServerSourceConditions,
+					rttestingv1.WithApiServerSourceDeployed,
+					rttestingv1.WithApiServerSourceSinkAddressable(sinkOIDCAddressable),
+					rttestingv1.WithApiServerSourceSufficientPermissions,
+					rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+					rttestingv1.WithApiServerSourceSinkPropagated,
 					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 					rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceeded(),
@@ -1446,12 +2037,79 @@ func TestReconcile(t *testing.T) {
 				),
 			}},
 			WantCreates: []runtime.Object{
+				makeSubjectAccessReview("namespaces", "get", "default"),
+				makeSubjectAccessReview("namespaces", "list", "default"),
+				makeSubjectAccessReview("namespaces", "watch", "default"),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				deleteOIDCRoleBinding(resources.LegacyOIDCTokenRoleName),
+				deleteOIDCRole(resources.LegacyOIDCTokenRoleName),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+			WithReactors:            []clientgotesting.ReactionFunc{subjectAccessReviewCreateReactor(true)},
+			SkipNamespaceValidation: true, // SubjectAccessReview objects are cluster-scoped.
+		}, {
+			Name: "OIDC disabled: deletes role and rolebinding",
+			Objects: []runtime.Object{
+				rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+				),
+				rttestingv1.NewChannel(sinkName, testNS,
+					rttestingv1.WithInitChannelConditions,
+					rttestingv1.WithChannelAddress(sinkAddressable),
+				),
+				makeAvailableReceiveAdapter(t),
 				makeOIDCRole(),
 				makeOIDCRoleBinding(),
+				// Owned by someone else and must be kept.
+				makeOIDCRoleWithoutOwnerRef(resources.LegacyOIDCTokenRoleName),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rttestingv1.NewApiServerSource(sourceName, testNS,
+					rttestingv1.WithApiServerSourceSpec(sourcesv1.ApiServerSourceSpec{
+						Resources: []sourcesv1.APIVersionKindSelector{{
+							APIVersion: "v1",
+							Kind:       "Namespace",
+						}},
+						SourceSpec: duckv1.SourceSpec{Sink: sinkDest},
+					}),
+					rttestingv1.WithApiServerSourceUID(sourceUID),
+					rttestingv1.WithApiServerSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rttestingv1.WithInitApiServerSourceConditions,
+					rttestingv1.WithApiServerSourceDeployed,
+					rttestingv1.WithApiServerSourceSink(sinkURI),
+					rttestingv1.WithApiServerSourceSufficientPermissions,
+					rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+					rttestingv1.WithApiServerSourceSinkPropagated,
+					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
+					rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
+					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				),
+			}},
+			WantCreates: []runtime.Object{
 				makeSubjectAccessReview("namespaces", "get", "default"),
 				makeSubjectAccessReview("namespaces", "list", "default"),
 				makeSubjectAccessReview("namespaces", "watch", "default"),
 			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{
+				deleteOIDCRoleBinding(resources.GetOIDCTokenRoleBindingName(sourceName)),
+				deleteOIDCRole(resources.GetOIDCTokenRoleName(sourceName)),
+			},
 			WantEvents: []string{
 				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
 			},
@@ -1503,6 +2161,7 @@ func TestReconcile(t *testing.T) {
 					rttestingv1.WithApiServerSourceSink(sinkURI),
 					rttestingv1.WithApiServerSourceSufficientPermissions,
 					rttestingv1.WithApiServerSourceReferenceModeEventTypes(source),
+					rttestingv1.WithApiServerSourceSinkPropagated,
 					rttestingv1.WithApiServerSourceStatusObservedGeneration(generation),
 					rttestingv1.WithApiServerSourceStatusNamespaces([]string{testNS}),
 					rttestingv1.WithApiServerSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
@@ -1780,6 +2439,84 @@ func makeAvailableReceiveAdapterWithNamespaces(t *testing.T, namespaces []string
 	return ra
 }
 
+func makeAvailableReceiveAdapterWithSink(t *testing.T, sinkAddr *duckv1.Addressable) *appsv1.Deployment {
+	ra := makeAvailableReceiveAdapter(t)
+	env := ra.Spec.Template.Spec.Containers[0].Env
+	// Replace K_SINK with the environment variables of the sink.
+	for i := range env {
+		if env[i].Name == sourcesv1.SinkEnvVar {
+			sinkEnv := sourcesv1.SinkEnvVars(sinkAddr.URL.String(), sinkAddr.CACerts, sinkAddr.Audience)
+			env = append(env[:i], append(sinkEnv, env[i+1:]...)...)
+			break
+		}
+	}
+	ra.Spec.Template.Spec.Containers[0].Env = env
+	return ra
+}
+
+func makeAvailableReceiveAdapterWithReplicas(t *testing.T, replicas int32) *appsv1.Deployment {
+	ra := makeAvailableReceiveAdapter(t)
+	ra.Spec.Replicas = &replicas
+	// The replicas elect the one sending events with a Lease named after the
+	// Deployment.
+	env := ra.Spec.Template.Spec.Containers[0].Env
+	for i := range env {
+		if env[i].Name == "K_SOURCE_CONFIG" {
+			var cfg apiserver.Config
+			if err := json.Unmarshal([]byte(env[i].Value), &cfg); err != nil {
+				t.Fatal(err)
+			}
+			cfg.LeaseName = ra.Name
+			b, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			env[i].Value = string(b)
+		}
+	}
+	return ra
+}
+
+func makeAvailableReceiveAdapterWithDelivery(t *testing.T, delivery *apiserver.DeliveryConfig) *appsv1.Deployment {
+	ra := makeAvailableReceiveAdapter(t)
+	env := ra.Spec.Template.Spec.Containers[0].Env
+	for i := range env {
+		if env[i].Name == "K_SOURCE_CONFIG" {
+			var cfg apiserver.Config
+			if err := json.Unmarshal([]byte(env[i].Value), &cfg); err != nil {
+				t.Fatal(err)
+			}
+			cfg.Delivery = delivery
+			b, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			env[i].Value = string(b)
+		}
+	}
+	return ra
+}
+
+func makeAvailableReceiveAdapterWithMonitoring(t *testing.T, monitoring *apiserver.MonitoringConfig) *appsv1.Deployment {
+	ra := makeAvailableReceiveAdapter(t)
+	env := ra.Spec.Template.Spec.Containers[0].Env
+	for i := range env {
+		if env[i].Name == "K_SOURCE_CONFIG" {
+			var cfg apiserver.Config
+			if err := json.Unmarshal([]byte(env[i].Value), &cfg); err != nil {
+				t.Fatal(err)
+			}
+			cfg.Monitoring = monitoring
+			b, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			env[i].Value = string(b)
+		}
+	}
+	return ra
+}
+
 func makeReceiveAdapterWithDifferentEnv(t *testing.T) *appsv1.Deployment {
 	ra := makeReceiveAdapter(t)
 	ra.Spec.Template.Spec.Containers[0].Env = append(ra.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
@@ -1819,6 +2556,12 @@ func makeSubjectAccessReview(resource, verb, sa string) *authorizationv1.Subject
 	return makeNamespacedSubjectAccessReview(resource, verb, sa, testNS)
 }
 
+func makeLeaseSubjectAccessReview(verb, sa string) *authorizationv1.SubjectAccessReview {
+	sar := makeSubjectAccessReview("leases", verb, sa)
+	sar.Spec.ResourceAttributes.Group = coordinationv1.GroupName
+	return sar
+}
+
 func makeOIDCRole() *rbacv1.Role {
 	src := rttestingv1.NewApiServerSource(sourceName, testNS,
 		rttestingv1.WithApiServerSourceUID(sourceUID),
@@ -1882,6 +2625,46 @@ func makeOIDCRoleBinding() *rbacv1.RoleBinding {
 	}
 }
 
+func makeOIDCRoleWithoutOwnerRef(name string) *rbacv1.Role {
+	role := makeOIDCRole()
+	role.Name = name
+	role.OwnerReferences = nil
+	return role
+}
+
+func makeLegacyOIDCRole() *rbacv1.Role {
+	role := makeOIDCRole()
+	role.Name = resources.LegacyOIDCTokenRoleName
+	return role
+}
+
+func makeLegacyOIDCRoleBinding() *rbacv1.RoleBinding {
+	roleBinding := makeOIDCRoleBinding()
+	roleBinding.Name = resources.LegacyOIDCTokenRoleName
+	roleBinding.RoleRef.Name = resources.LegacyOIDCTokenRoleName
+	return roleBinding
+}
+
+func deleteOIDCRole(name string) clientgotesting.DeleteActionImpl {
+	return clientgotesting.DeleteActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: testNS,
+			Resource:  rbacv1.SchemeGroupVersion.WithResource("roles"),
+		},
+		Name: name,
+	}
+}
+
+func deleteOIDCRoleBinding(name string) clientgotesting.DeleteActionImpl {
+	return clientgotesting.DeleteActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: testNS,
+			Resource:  rbacv1.SchemeGroupVersion.WithResource("rolebindings"),
+		},
+		Name: name,
+	}
+}
+
 func subjectAccessReviewCreateReactor(allowed bool) clientgotesting.ReactionFunc {
 	return func(action clientgotesting.Action) (handled bool, ret runtime.Object, err error) {
 		if action.GetVerb() == "create" && action.GetResource().Resource == "subjectaccessreviews" {
@@ -19,6 +19,9 @@ package apiserversource
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered"
@@ -57,6 +60,22 @@ import (
 // NewController will panic.
 type envConfig struct {
 	Image string `envconfig:"APISERVER_RA_IMAGE" required:"true"`
+	// EventLogSamplingRate is the fraction of successfully sent events the
+	// receive adapters log, see adapter.EnvConfig.
+	EventLogSamplingRate string `envconfig:"APISERVER_RA_EVENT_LOG_SAMPLING_RATE"`
+	// SinkContentEncoding is the default content encoding the receive
+	// adapters use to compress requests to their sink, see
+	// adapter.EnvConfig.
+	SinkContentEncoding string `envconfig:"APISERVER_RA_SINK_CONTENT_ENCODING"`
+	// StripManagedFields makes the receive adapters remove the managed
+	// fields of the resources they watch.
+	StripManagedFields bool `envconfig:"APISERVER_RA_STRIP_MANAGED_FIELDS"`
+	// StripLastAppliedConfiguration makes the receive adapters remove the
+	// last applied configuration annotation of the resources they watch.
+	StripLastAppliedConfiguration bool `envconfig:"APISERVER_RA_STRIP_LAST_APPLIED_CONFIGURATION"`
+	// MemoryWatermark is the heap size, e.g. 400Mi, beyond which the receive
+	// adapters watch the resources with the most objects metadata-only.
+	MemoryWatermark string `envconfig:"APISERVER_RA_MEMORY_WATERMARK"`
 }
 
 // NewController initializes the controller and is called by the generated code
@@ -87,9 +106,20 @@ func NewController(
 	featureStore.WatchConfigs(cmw)
 
 	r := &Reconciler{
-		kubeClientSet:              kubeclient.Get(ctx),
-		ceSource:                   GetCfgHost(ctx),
-		configs:                    reconcilersource.WatchConfigurations(ctx, component, cmw),
+		kubeClientSet: kubeclient.Get(ctx),
+		ceSource:      GetCfgHost(ctx),
+		// Redeploy the receive adapters when the configurations they are
+		// given change.
+		configs: reconcilersource.WatchConfigurations(ctx, component, cmw, reconcilersource.WithOnChange(func() {
+			if globalResync != nil {
+				globalResync(nil)
+			}
+		})),
+		deploymentConfig: reconcilersource.WatchDeploymentConfig(ctx, cmw, func() {
+			if globalResync != nil {
+				globalResync(nil)
+			}
+		}),
 		namespaceLister:            namespaceInformer.Lister(),
 		serviceAccountLister:       oidcServiceaccountInformer.Lister(),
 		roleLister:                 roleInformer.Lister(),
@@ -102,6 +132,17 @@ func NewController(
 		logging.FromContext(ctx).Panicf("unable to process APIServerSource's required environment variables: %v", err)
 	}
 	r.receiveAdapterImage = env.Image
+	r.eventLogSamplingRate = env.EventLogSamplingRate
+	r.sinkContentEncoding = env.SinkContentEncoding
+	r.stripManagedFields = env.StripManagedFields
+	r.stripLastAppliedConfiguration = env.StripLastAppliedConfiguration
+	if env.MemoryWatermark != "" {
+		watermark, err := resource.ParseQuantity(env.MemoryWatermark)
+		if err != nil {
+			logging.FromContext(ctx).Panicf("invalid APISERVER_RA_MEMORY_WATERMARK %q: %v", env.MemoryWatermark, err)
+		}
+		r.memoryWatermark = watermark.Value()
+	}
 
 	impl := apiserversourcereconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{
@@ -138,8 +179,14 @@ func NewController(
 	}
 
 	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { cb() },
-		UpdateFunc: func(oldObj, newObj interface{}) { cb() },
+		AddFunc: func(obj interface{}) { cb() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// Only the labels of a namespace select it, the other updates
+			// and the periodic resyncs don't change the watched namespaces.
+			if namespaceLabelsChanged(oldObj, newObj) {
+				cb()
+			}
+		},
 		DeleteFunc: func(obj interface{}) { cb() },
 	})
 
@@ -173,3 +220,17 @@ func NewController(
 
 	return impl
 }
+
+// namespaceLabelsChanged returns whether the labels of the updated namespace
+// changed.
+func namespaceLabelsChanged(oldObj, newObj interface{}) bool {
+	oldNs, ok := oldObj.(*corev1.Namespace)
+	if !ok {
+		return true
+	}
+	newNs, ok := newObj.(*corev1.Namespace)
+	if !ok {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(oldNs.Labels, newNs.Labels)
+}
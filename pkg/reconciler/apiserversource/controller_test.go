@@ -25,6 +25,7 @@ import (
 
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/eventingtls"
+	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 
 	"knative.dev/eventing/pkg/apis/feature"
 
@@ -90,6 +91,11 @@ func TestNew(t *testing.T) {
 			Name:      feature.FlagsConfigName,
 			Namespace: "knative-eventing",
 		},
+	}, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      reconcilersource.DeploymentConfigName,
+			Namespace: "knative-eventing",
+		},
 	}))
 
 	if c == nil {
@@ -101,3 +107,44 @@ func SetUpInformerSelector(ctx context.Context) context.Context {
 	ctx = filteredFactory.WithSelectors(ctx, eventingtls.TrustBundleLabelSelector, auth.OIDCLabelSelector)
 	return ctx
 }
+
+func TestNamespaceLabelsChanged(t *testing.T) {
+	ns := func(labels map[string]string, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels, Annotations: annotations}}
+	}
+	testCases := map[string]struct {
+		oldObj, newObj interface{}
+		want           bool
+	}{
+		"resync": {
+			oldObj: ns(map[string]string{"target": "yes"}, nil),
+			newObj: ns(map[string]string{"target": "yes"}, nil),
+		},
+		"annotations changed": {
+			oldObj: ns(map[string]string{"target": "yes"}, nil),
+			newObj: ns(map[string]string{"target": "yes"}, map[string]string{"owner": "team"}),
+		},
+		"label added": {
+			oldObj: ns(nil, nil),
+			newObj: ns(map[string]string{"target": "yes"}, nil),
+			want:   true,
+		},
+		"label changed": {
+			oldObj: ns(map[string]string{"target": "yes"}, nil),
+			newObj: ns(map[string]string{"target": "no"}, nil),
+			want:   true,
+		},
+		"not a namespace": {
+			oldObj: ns(nil, nil),
+			newObj: &corev1.ConfigMap{},
+			want:   true,
+		},
+	}
+	for n, tc := range testCases {
+		t.Run(n, func(t *testing.T) {
+			if got := namespaceLabelsChanged(tc.oldObj, tc.newObj); got != tc.want {
+				t.Errorf("namespaceLabelsChanged() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,11 @@ import (
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 )
 
+// LegacyOIDCTokenRoleName is the name older versions used for the role and
+// rolebinding of every ApiServerSource in a namespace. The objects are removed
+// by the owning ApiServerSource, as they are replaced by per-source objects.
+const LegacyOIDCTokenRoleName = "create-oidc-token"
+
 // GetOIDCTokenRoleName will return the name of the role for creating the JWT token
 func GetOIDCTokenRoleName(sourceName string) string {
 	return kmeta.ChildName(sourceName, "-create-oidc-token")
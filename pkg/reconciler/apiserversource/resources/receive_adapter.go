@@ -27,11 +27,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/eventing/pkg/adapter/v2"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 	"knative.dev/pkg/system"
 
 	"knative.dev/eventing/pkg/adapter/apiserver"
+	"knative.dev/eventing/pkg/apis/sources"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 )
@@ -49,22 +51,41 @@ type ReceiveAdapterArgs struct {
 	Namespaces    []string
 	AllNamespaces bool
 	NodeSelector  map[string]string
+	// EventLogSamplingRate is optional, see adapter.EnvConfig.
+	EventLogSamplingRate string
+	// SinkContentEncoding is optional, see adapter.EnvConfig.
+	SinkContentEncoding string
+	// StripManagedFields is optional, see apiserver.Config.
+	StripManagedFields bool
+	// StripLastAppliedConfiguration is optional, see apiserver.Config.
+	StripLastAppliedConfiguration bool
+	// MemoryWatermark is optional, see apiserver.Config.
+	MemoryWatermark int64
+	// TraceContextInjection is optional, see adapter.EnvConfig.
+	TraceContextInjection string
+	// DeploymentConfig is optional, see reconcilersource.DeploymentConfig.
+	DeploymentConfig *reconcilersource.DeploymentConfig
+	// DeadLetterSink is the resolved dead letter sink of the source, if any.
+	DeadLetterSink *duckv1.Addressable
+	// MonitoringSink is the resolved sink of the events about the source,
+	// when its monitoring is enabled.
+	MonitoringSink *duckv1.Addressable
 }
 
 // MakeReceiveAdapter generates (but does not insert into K8s) the Receive Adapter Deployment for
 // ApiServer Sources.
 func MakeReceiveAdapter(args *ReceiveAdapterArgs) (*appsv1.Deployment, error) {
-	replicas := int32(1)
+	replicas := args.Source.Replicas()
 
 	env, err := makeEnv(args)
 	if err != nil {
 		return nil, fmt.Errorf("error generating env vars: %w", err)
 	}
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: args.Source.Namespace,
-			Name:      kmeta.ChildName(fmt.Sprintf("apiserversource-%s-", args.Source.Name), string(args.Source.GetUID())),
+			Name:      receiveAdapterName(args.Source),
 			Labels:    args.Labels,
 			OwnerReferences: []metav1.OwnerReference{
 				*kmeta.NewControllerRef(args.Source),
@@ -117,7 +138,15 @@ func MakeReceiveAdapter(args *ReceiveAdapterArgs) (*appsv1.Deployment, error) {
 				},
 			},
 		},
-	}, nil
+	}
+	args.DeploymentConfig.Apply(&deployment.Spec.Template.Spec, args.Labels)
+	return deployment, nil
+}
+
+// receiveAdapterName returns the name of the receive adapter Deployment, which
+// is also the name of the Lease used to elect the replica sending the events.
+func receiveAdapterName(src *v1.ApiServerSource) string {
+	return kmeta.ChildName(fmt.Sprintf("apiserversource-%s-", src.Name), string(src.GetUID()))
 }
 
 func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
@@ -126,8 +155,44 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 		Resources:     make([]apiserver.ResourceWatch, 0, len(args.Source.Spec.Resources)),
 		ResourceOwner: args.Source.Spec.ResourceOwner,
 		EventMode:     args.Source.Spec.EventMode,
+		DataEncoding:  args.Source.Annotations[sources.ApiServerSourceDataEncodingAnnotationKey],
 		AllNamespaces: args.AllNamespaces,
 		Filters:       args.Source.Spec.Filters,
+
+		StripManagedFields:            args.StripManagedFields,
+		StripLastAppliedConfiguration: args.StripLastAppliedConfiguration,
+		MemoryWatermark:               args.MemoryWatermark,
+	}
+
+	if args.Source.Replicas() > 1 {
+		cfg.LeaseName = receiveAdapterName(args.Source)
+	}
+
+	if priorities, ok := args.Source.Annotations[sources.ApiServerSourceQueuePrioritiesAnnotationKey]; ok {
+		operations, err := v1.ParseQueuePriorities(priorities)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", sources.ApiServerSourceQueuePrioritiesAnnotationKey, err)
+		}
+		cfg.QueuePriorities = operations
+	}
+
+	if delivery := args.Source.Spec.Delivery; delivery != nil {
+		cfg.Delivery = &apiserver.DeliveryConfig{
+			DeadLetterSink: args.DeadLetterSink,
+		}
+		if delivery.Retry != nil {
+			cfg.Delivery.Retry = *delivery.Retry
+		}
+		if delivery.BackoffPolicy != nil {
+			cfg.Delivery.BackoffPolicy = *delivery.BackoffPolicy
+		}
+		if delivery.BackoffDelay != nil {
+			cfg.Delivery.BackoffDelay = *delivery.BackoffDelay
+		}
+	}
+
+	if args.MonitoringSink != nil {
+		cfg.Monitoring = &apiserver.MonitoringConfig{Sink: args.MonitoringSink}
 	}
 
 	for _, r := range args.Source.Spec.Resources {
@@ -137,7 +202,7 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 		}
 		gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(r.Kind))
 
-		rw := apiserver.ResourceWatch{GVR: gvr}
+		rw := apiserver.ResourceWatch{GVR: gvr, FieldSelector: r.FieldSelector, CELFilter: r.CELFilter}
 
 		if r.LabelSelector != nil {
 			selector, _ := metav1.LabelSelectorAsSelector(r.LabelSelector)
@@ -152,11 +217,9 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 		config = string(b)
 	}
 
-	envs := []corev1.EnvVar{
+	envs := v1.SinkEnvVars(args.SinkURI, args.CACerts, args.Audience)
+	envs = append(envs, []corev1.EnvVar{
 		{
-			Name:  adapter.EnvConfigSink,
-			Value: args.SinkURI,
-		}, {
 			Name:  "K_SOURCE_CONFIG",
 			Value: config,
 		}, {
@@ -176,19 +239,26 @@ func makeEnv(args *ReceiveAdapterArgs) ([]corev1.EnvVar, error) {
 			Name:  "METRICS_DOMAIN",
 			Value: "knative.dev/eventing",
 		},
+	}...)
+
+	if args.EventLogSamplingRate != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  adapter.EnvEventLogSamplingRate,
+			Value: args.EventLogSamplingRate,
+		})
 	}
 
-	if args.CACerts != nil {
+	if args.SinkContentEncoding != "" {
 		envs = append(envs, corev1.EnvVar{
-			Name:  adapter.EnvConfigCACert,
-			Value: *args.CACerts,
+			Name:  adapter.EnvSinkContentEncoding,
+			Value: args.SinkContentEncoding,
 		})
 	}
 
-	if args.Audience != nil {
+	if args.TraceContextInjection != "" {
 		envs = append(envs, corev1.EnvVar{
-			Name:  adapter.EnvConfigAudience,
-			Value: *args.Audience,
+			Name:  adapter.EnvTraceContextInjection,
+			Value: args.TraceContextInjection,
 		})
 	}
 
@@ -23,13 +23,18 @@ import (
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
 
+	"knative.dev/eventing/pkg/adapter/v2"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/sources"
 	v1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/reconciler/source"
 
@@ -79,12 +84,14 @@ O2dgzikq8iSy1BlRsVw=
 			}, {
 				APIVersion: "batch/v1",
 				Kind:       "Job",
+				CELFilter:  "new == null",
 			}, {
 				APIVersion: "",
 				Kind:       "Pod",
 				LabelSelector: &metav1.LabelSelector{
 					MatchLabels: map[string]string{"test-key1": "test-value1"},
 				},
+				FieldSelector: "status.phase=Running",
 			}},
 			ResourceOwner: &v1.APIVersionKind{
 				APIVersion: "custom/v1",
@@ -150,9 +157,12 @@ O2dgzikq8iSy1BlRsVw=
 								{
 									Name:  "K_SINK",
 									Value: "sink-uri",
+								}, {
+									Name:  "K_CA_CERTS",
+									Value: testCert,
 								}, {
 									Name:  "K_SOURCE_CONFIG",
-									Value: `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"","Resource":"namespaces"}},{"gvr":{"Group":"batch","Version":"v1","Resource":"jobs"}},{"gvr":{"Group":"","Version":"","Resource":"pods"},"selector":"test-key1=test-value1"}],"owner":{"apiVersion":"custom/v1","kind":"Parent"},"mode":"Resource"}`,
+									Value: `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"","Resource":"namespaces"}},{"gvr":{"Group":"batch","Version":"v1","Resource":"jobs"},"celFilter":"new == null"},{"gvr":{"Group":"","Version":"","Resource":"pods"},"selector":"test-key1=test-value1","fieldSelector":"status.phase=Running"}],"owner":{"apiVersion":"custom/v1","kind":"Parent"},"mode":"Resource"}`,
 								}, {
 									Name:  "SYSTEM_NAMESPACE",
 									Value: "knative-testing",
@@ -169,9 +179,6 @@ O2dgzikq8iSy1BlRsVw=
 								}, {
 									Name:  "METRICS_DOMAIN",
 									Value: "knative.dev/eventing",
-								}, {
-									Name:  "K_CA_CERTS",
-									Value: testCert,
 								}, {
 									Name:  source.EnvLoggingCfg,
 									Value: "",
@@ -248,3 +255,402 @@ O2dgzikq8iSy1BlRsVw=
 		})
 	}
 }
+
+func TestMakeReceiveAdapterSinkEnv(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		status duckv1.SourceStatus
+	}{{
+		name:   "sink URI only",
+		status: duckv1.SourceStatus{SinkURI: apis.HTTP("sink.ns.svc.cluster.local")},
+	}, {
+		name: "sink with CA certs and audience",
+		status: duckv1.SourceStatus{
+			SinkURI:      apis.HTTPS("sink.ns.svc.cluster.local"),
+			SinkCACerts:  ptr.String("-----BEGIN CERTIFICATE-----"),
+			SinkAudience: ptr.String("sink-audience"),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+				Image:      "test-image",
+				Source:     src,
+				SinkURI:    tt.status.SinkURI.String(),
+				CACerts:    tt.status.SinkCACerts,
+				Audience:   tt.status.SinkAudience,
+				Configs:    &source.EmptyVarsGenerator{},
+				Namespaces: []string{"source-namespace"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range got.Spec.Template.Spec.Containers {
+				if err := v1.VerifySinkEnv(c.Env, &tt.status); err != nil {
+					t.Errorf("container %s: %v", c.Name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMakeReceiveAdapterSinkContentEncoding(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+		},
+	}
+
+	for _, encoding := range []string{"", "gzip"} {
+		t.Run(encoding, func(t *testing.T) {
+			got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+				Image:               "test-image",
+				Source:              src,
+				SinkURI:             "http://sink.ns.svc.cluster.local",
+				Configs:             &source.EmptyVarsGenerator{},
+				Namespaces:          []string{"source-namespace"},
+				SinkContentEncoding: encoding,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var found *corev1.EnvVar
+			for i, e := range got.Spec.Template.Spec.Containers[0].Env {
+				if e.Name == adapter.EnvSinkContentEncoding {
+					found = &got.Spec.Template.Spec.Containers[0].Env[i]
+				}
+			}
+			if encoding == "" && found != nil {
+				t.Errorf("Expected no %s env var, got %q", adapter.EnvSinkContentEncoding, found.Value)
+			}
+			if encoding != "" && (found == nil || found.Value != encoding) {
+				t.Errorf("Expected %s env var to be %q, got %v", adapter.EnvSinkContentEncoding, encoding, found)
+			}
+		})
+	}
+}
+
+func TestMakeReceiveAdapterDataEncoding(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+			Annotations: map[string]string{
+				sources.ApiServerSourceDataEncodingAnnotationKey: v1.ProtobufDataEncoding,
+			},
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+			EventMode: v1.ResourceMode,
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"mode":"Resource","dataEncoding":"protobuf"}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+}
+
+func TestMakeReceiveAdapterQueuePriorities(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+			Annotations: map[string]string{
+				sources.ApiServerSourceQueuePrioritiesAnnotationKey: "Update, Delete",
+			},
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+			EventMode: v1.ResourceMode,
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"mode":"Resource","queuePriorities":["Update","Delete"]}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+
+	src.Annotations[sources.ApiServerSourceQueuePrioritiesAnnotationKey] = "Patch"
+	if _, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+	}); err == nil {
+		t.Error("Expected an error for invalid queue priorities")
+	}
+}
+
+func TestMakeReceiveAdapterReplicas(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+			Annotations: map[string]string{
+				sources.ApiServerSourceReplicasAnnotationKey: "3",
+			},
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Spec.Replicas == nil || *got.Spec.Replicas != 3 {
+		t.Errorf("Expected 3 replicas, got %v", got.Spec.Replicas)
+	}
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"leaseName":"` + got.Name + `"}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+}
+
+func TestMakeReceiveAdapterDelivery(t *testing.T) {
+	exponential := eventingduckv1.BackoffPolicyExponential
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+			Delivery: &eventingduckv1.DeliverySpec{
+				Retry:         ptr.Int32(3),
+				BackoffPolicy: &exponential,
+				BackoffDelay:  ptr.String("PT0.5S"),
+			},
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+		DeadLetterSink: &duckv1.Addressable{
+			URL: apis.HTTP("dls.ns.svc.cluster.local"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"delivery":{"retry":3,"backoffPolicy":"exponential","backoffDelay":"PT0.5S","deadLetterSink":{"url":"http://dls.ns.svc.cluster.local"}}}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+}
+
+func TestMakeReceiveAdapterMonitoring(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+			Monitoring: &v1.ApiServerSourceMonitoring{},
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+		MonitoringSink: &duckv1.Addressable{
+			URL: apis.HTTP("ops.ns.svc.cluster.local"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"monitoring":{"sink":{"url":"http://ops.ns.svc.cluster.local"}}}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+}
+
+func TestMakeReceiveAdapterMemoryGuardrails(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+		},
+	}
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:                         "test-image",
+		Source:                        src,
+		SinkURI:                       "http://sink.ns.svc.cluster.local",
+		Configs:                       &source.EmptyVarsGenerator{},
+		Namespaces:                    []string{"source-namespace"},
+		StripManagedFields:            true,
+		StripLastAppliedConfiguration: true,
+		MemoryWatermark:               400 << 20,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"namespaces":["source-namespace"],"allNamespaces":false,"resources":[{"gvr":{"Group":"","Version":"v1","Resource":"namespaces"}}],"stripManagedFields":true,"stripLastAppliedConfiguration":true,"memoryWatermark":419430400}`
+	for _, e := range got.Spec.Template.Spec.Containers[0].Env {
+		if e.Name == "K_SOURCE_CONFIG" && e.Value != want {
+			t.Errorf("Expected K_SOURCE_CONFIG to be %s, got %s", want, e.Value)
+		}
+	}
+}
+
+func TestMakeReceiveAdapterDeploymentConfig(t *testing.T) {
+	src := &v1.ApiServerSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "source-name",
+			Namespace: "source-namespace",
+			UID:       "1234",
+		},
+		Spec: v1.ApiServerSourceSpec{
+			Resources: []v1.APIVersionKindSelector{{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			}},
+		},
+	}
+	labels := Labels(src.Name)
+
+	got, err := MakeReceiveAdapter(&ReceiveAdapterArgs{
+		Image:      "test-image",
+		Source:     src,
+		Labels:     labels,
+		SinkURI:    "http://sink.ns.svc.cluster.local",
+		Configs:    &source.EmptyVarsGenerator{},
+		Namespaces: []string{"source-namespace"},
+		DeploymentConfig: &source.DeploymentConfig{
+			PriorityClassName: "data-plane",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := got.Spec.Template.Spec
+	if spec.PriorityClassName != "data-plane" {
+		t.Errorf("Expected priority class data-plane, got %q", spec.PriorityClassName)
+	}
+	if cpu := spec.Containers[0].Resources.Requests[corev1.ResourceCPU]; cpu.String() != "100m" {
+		t.Errorf("Expected 100m CPU requested, got %s", cpu.String())
+	}
+	wantConstraints := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+	}}
+	if diff := cmp.Diff(wantConstraints, spec.TopologySpreadConstraints); diff != "" {
+		t.Error("unexpected topology spread constraints (-want, +got) =", diff)
+	}
+}
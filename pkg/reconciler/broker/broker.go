@@ -18,6 +18,7 @@ package broker
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -28,8 +29,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/kmeta"
@@ -42,6 +45,7 @@ import (
 	"knative.dev/pkg/network"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/system"
+	"knative.dev/pkg/tracker"
 
 	duckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/pkg/apis/eventing"
@@ -54,7 +58,9 @@ import (
 	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
 	ducklib "knative.dev/eventing/pkg/duck"
 	"knative.dev/eventing/pkg/eventingtls"
+	"knative.dev/eventing/pkg/reconciler/broker/canary"
 	"knative.dev/eventing/pkg/reconciler/broker/resources"
+	"knative.dev/eventing/pkg/reconciler/dryrun"
 	"knative.dev/eventing/pkg/reconciler/names"
 )
 
@@ -64,6 +70,7 @@ const (
 )
 
 type Reconciler struct {
+	kubeClientSet     kubernetes.Interface
 	eventingClientSet clientset.Interface
 	dynamicClientSet  dynamic.Interface
 
@@ -75,8 +82,16 @@ type Reconciler struct {
 
 	channelableTracker ducklib.ListableTracker
 
+	// configmapTracker tracks the ConfigMaps referenced by the Brokers, so
+	// that they are reconciled when their channel template changes.
+	configmapTracker tracker.Interface
+
 	uriResolver *resolver.URIResolver
 
+	// canary routes some Brokers to a canary version of the broker-ingress
+	// and broker-filter, if set.
+	canary *canary.Rollout
+
 	// If specified, only reconcile brokers with these labels
 	brokerClass string
 }
@@ -127,13 +142,36 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 		return err
 	}
 
-	triggerChan, err := r.reconcileChannel(ctx, chanMan.inf, chanMan.ref, c)
+	if dryrun.Enabled(b) {
+		return r.reconcileDryRun(ctx, b, chanMan, c)
+	}
+	if err := dryrun.Cleanup(ctx, r.kubeClientSet, b); err != nil {
+		return err
+	}
+
+	triggerChan, err := r.reconcileChannel(ctx, chanMan, c)
+	if errors.Is(err, errChannelRecreating) {
+		b.Status.MarkTriggerChannelFailed("ChannelRecreating", "Channel is being recreated to apply its template.")
+		// Ok to return nil for error here, once the channel is deleted, this will get requeued.
+		return nil
+	}
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Problem reconciling the trigger channel", zap.Error(err))
 		b.Status.MarkTriggerChannelFailed("ChannelFailure", "%v", err)
 		return fmt.Errorf("failed to reconcile trigger channel: %v", err)
 	}
 
+	// While migrating to a channel of another kind, keep using the current
+	// channel until the new one is addressable.
+	triggerChanRef := chanMan.ref
+	if !isChannelAddressable(triggerChan) {
+		if current, currentRef, ok := r.currentChannel(ctx, b, chanMan); ok {
+			logging.FromContext(ctx).Infow("Waiting for the new trigger channel to be addressable",
+				zap.Any("current", currentRef), zap.Any("new", chanMan.ref))
+			triggerChan, triggerChanRef = current, currentRef
+		}
+	}
+
 	if triggerChan.Status.Address == nil {
 		logging.FromContext(ctx).Debugw("Trigger Channel does not have an address", zap.Any("triggerChan", triggerChan))
 		b.Status.MarkTriggerChannelFailed("NoAddress", "Channel does not have an address.")
@@ -152,10 +190,17 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 	if b.Status.Annotations == nil {
 		b.Status.Annotations = make(map[string]string, 1)
 	}
+	if kind, apiVersion := b.Status.Annotations[eventing.BrokerChannelKindStatusAnnotationKey], b.Status.Annotations[eventing.BrokerChannelAPIVersionStatusAnnotationKey]; kind != "" &&
+		(kind != triggerChanRef.Kind || apiVersion != triggerChanRef.APIVersion) {
+		// The Broker migrates to a channel of another kind, the previous one
+		// is deleted once the Triggers moved to the new one.
+		b.Status.Annotations[eventing.BrokerPreviousChannelKindStatusAnnotationKey] = kind
+		b.Status.Annotations[eventing.BrokerPreviousChannelAPIVersionStatusAnnotationKey] = apiVersion
+	}
 	b.Status.Annotations[eventing.BrokerChannelAddressStatusAnnotationKey] = triggerChan.Status.Address.URL.String()
-	b.Status.Annotations[eventing.BrokerChannelKindStatusAnnotationKey] = chanMan.ref.Kind
-	b.Status.Annotations[eventing.BrokerChannelAPIVersionStatusAnnotationKey] = chanMan.ref.APIVersion
-	b.Status.Annotations[eventing.BrokerChannelNameStatusAnnotationKey] = chanMan.ref.Name
+	b.Status.Annotations[eventing.BrokerChannelKindStatusAnnotationKey] = triggerChanRef.Kind
+	b.Status.Annotations[eventing.BrokerChannelAPIVersionStatusAnnotationKey] = triggerChanRef.APIVersion
+	b.Status.Annotations[eventing.BrokerChannelNameStatusAnnotationKey] = triggerChanRef.Name
 
 	if caCerts := triggerChan.Status.Address.CACerts; caCerts != nil && *caCerts != "" {
 		b.Status.Annotations[eventing.BrokerChannelCACertsStatusAnnotationKey] = *caCerts
@@ -172,7 +217,21 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 
 	b.Status.PropagateTriggerChannelReadiness(channelStatus)
 
-	filterEndpoints, err := r.endpointsLister.Endpoints(system.Namespace()).Get(names.BrokerFilterName)
+	if err := r.deletePreviousChannel(ctx, b); err != nil {
+		logging.FromContext(ctx).Errorw("Problem deleting the previous trigger channel", zap.Error(err))
+		return err
+	}
+
+	// The Broker and its Triggers are routed to the canary data plane, or to
+	// the stable one.
+	ingressName, filterName := r.canary.ServiceNames(b)
+	if filterName != names.BrokerFilterName {
+		b.Status.Annotations[eventing.BrokerFilterServiceStatusAnnotationKey] = filterName
+	} else {
+		delete(b.Status.Annotations, eventing.BrokerFilterServiceStatusAnnotationKey)
+	}
+
+	filterEndpoints, err := r.endpointsLister.Endpoints(system.Namespace()).Get(filterName)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Problem getting endpoints for filter", zap.String("namespace", system.Namespace()), zap.Error(err))
 		b.Status.MarkFilterFailed("ServiceFailure", "%v", err)
@@ -180,7 +239,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 	}
 	b.Status.PropagateFilterAvailability(filterEndpoints)
 
-	ingressEndpoints, err := r.endpointsLister.Endpoints(system.Namespace()).Get(names.BrokerIngressName)
+	ingressEndpoints, err := r.endpointsLister.Endpoints(system.Namespace()).Get(ingressName)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Problem getting endpoints for ingress", zap.String("namespace", system.Namespace()), zap.Error(err))
 		b.Status.MarkIngressFailed("ServiceFailure", "%v", err)
@@ -212,8 +271,8 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 			return err
 		}
 
-		httpAddress := r.httpAddress(b)
-		httpsAddress := r.httpsAddress(caCerts, b)
+		httpAddress := r.httpAddress(ingressName, b)
+		httpsAddress := r.httpsAddress(ingressName, caCerts, b)
 		// Permissive mode:
 		// - status.address http address with path-based routing
 		// - status.addresses:
@@ -231,11 +290,11 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 			return err
 		}
 
-		httpsAddress := r.httpsAddress(caCerts, b)
+		httpsAddress := r.httpsAddress(ingressName, caCerts, b)
 		b.Status.Addresses = []pkgduckv1.Addressable{httpsAddress}
 		b.Status.Address = &httpsAddress
 	} else {
-		httpAddress := r.httpAddress(b)
+		httpAddress := r.httpAddress(ingressName, b)
 		b.Status.Address = &httpAddress
 	}
 
@@ -261,10 +320,15 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pk
 	return nil
 }
 
+// errChannelRecreating is returned when the trigger channel is being deleted
+// to be recreated.
+var errChannelRecreating = errors.New("trigger channel is being recreated")
+
 type channelTemplate struct {
 	ref      corev1.ObjectReference
 	inf      dynamic.ResourceInterface
 	template messagingv1.ChannelTemplateSpec
+	strategy ChannelTemplateUpdateStrategy
 }
 
 func (r *Reconciler) getChannelTemplate(ctx context.Context, b *eventingv1.Broker) (*channelTemplate, error) {
@@ -274,6 +338,7 @@ func (r *Reconciler) getChannelTemplate(ctx context.Context, b *eventingv1.Broke
 		Namespace: b.Namespace,
 	}
 	var template *messagingv1.ChannelTemplateSpec
+	strategy := ChannelTemplateUpdateInPlace
 
 	if b.Spec.Config != nil {
 		if b.Spec.Config.Kind == "ConfigMap" {
@@ -283,6 +348,15 @@ func (r *Reconciler) getChannelTemplate(ctx context.Context, b *eventingv1.Broke
 				return nil, errors.New("Broker.Spec.Config name and namespace are required")
 			}
 
+			if err := r.configmapTracker.TrackReference(tracker.Reference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Namespace:  b.Spec.Config.Namespace,
+				Name:       b.Spec.Config.Name,
+			}, b); err != nil {
+				return nil, fmt.Errorf("unable to track changes to the Broker config: %v", err)
+			}
+
 			cm, err := r.configmapLister.ConfigMaps(b.Spec.Config.Namespace).Get(b.Spec.Config.Name)
 			if err != nil {
 				return nil, err
@@ -293,6 +367,7 @@ func (r *Reconciler) getChannelTemplate(ctx context.Context, b *eventingv1.Broke
 				return nil, err
 			} else if config != nil {
 				template = &config.DefaultChannelTemplate
+				strategy = config.ChannelTemplateUpdateStrategy
 			}
 			logging.FromContext(ctx).Info("Using channel template = ", template)
 		} else {
@@ -323,11 +398,141 @@ func (r *Reconciler) getChannelTemplate(ctx context.Context, b *eventingv1.Broke
 		ref:      ref,
 		inf:      inf,
 		template: *template,
+		strategy: strategy,
 	}, nil
 }
 
+// isChannelAddressable returns true when the channel has an address the
+// ingress can send events to.
+func isChannelAddressable(c *duckv1.Channelable) bool {
+	return c.Status.Address != nil && c.Status.Address.URL != nil && c.Status.Address.URL.Host != ""
+}
+
+// currentChannel returns the addressable channel the Broker currently uses,
+// when it is of another kind than the one of its channel template.
+func (r *Reconciler) currentChannel(ctx context.Context, b *eventingv1.Broker, chanMan *channelTemplate) (*duckv1.Channelable, corev1.ObjectReference, bool) {
+	ref := corev1.ObjectReference{
+		APIVersion: b.Status.Annotations[eventing.BrokerChannelAPIVersionStatusAnnotationKey],
+		Kind:       b.Status.Annotations[eventing.BrokerChannelKindStatusAnnotationKey],
+		Name:       b.Status.Annotations[eventing.BrokerChannelNameStatusAnnotationKey],
+		Namespace:  b.Namespace,
+	}
+	if ref.Kind == "" || ref.APIVersion == "" || ref.Name == "" ||
+		(ref.Kind == chanMan.ref.Kind && ref.APIVersion == chanMan.ref.APIVersion) {
+		return nil, ref, false
+	}
+
+	if err := r.channelableTracker.TrackInNamespace(ctx, b)(ref); err != nil {
+		logging.FromContext(ctx).Warnw("Unable to track the current trigger channel", zap.Any("channel", ref), zap.Error(err))
+		return nil, ref, false
+	}
+	lister, err := r.channelableTracker.ListerFor(ref)
+	if err != nil {
+		return nil, ref, false
+	}
+	obj, err := lister.ByNamespace(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, ref, false
+	}
+	c, ok := obj.(*duckv1.Channelable)
+	if !ok || c.DeletionTimestamp != nil || !isChannelAddressable(c) {
+		return nil, ref, false
+	}
+	return c, ref, true
+}
+
+// deletePreviousChannel deletes the channel the Broker migrated from, once no
+// Subscription uses it anymore, so that the events it holds are delivered.
+func (r *Reconciler) deletePreviousChannel(ctx context.Context, b *eventingv1.Broker) error {
+	ref := corev1.ObjectReference{
+		APIVersion: b.Status.Annotations[eventing.BrokerPreviousChannelAPIVersionStatusAnnotationKey],
+		Kind:       b.Status.Annotations[eventing.BrokerPreviousChannelKindStatusAnnotationKey],
+		Name:       b.Status.Annotations[eventing.BrokerChannelNameStatusAnnotationKey],
+		Namespace:  b.Namespace,
+	}
+	if ref.Kind == "" || ref.APIVersion == "" {
+		return nil
+	}
+	if ref.Kind == b.Status.Annotations[eventing.BrokerChannelKindStatusAnnotationKey] &&
+		ref.APIVersion == b.Status.Annotations[eventing.BrokerChannelAPIVersionStatusAnnotationKey] {
+		// The Broker migrated back to the previous channel.
+		delete(b.Status.Annotations, eventing.BrokerPreviousChannelKindStatusAnnotationKey)
+		delete(b.Status.Annotations, eventing.BrokerPreviousChannelAPIVersionStatusAnnotationKey)
+		return nil
+	}
+
+	subs, err := r.subscriptionLister.Subscriptions(b.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	for _, sub := range subs {
+		if sub.Spec.Channel.Kind == ref.Kind && sub.Spec.Channel.APIVersion == ref.APIVersion && sub.Spec.Channel.Name == ref.Name {
+			logging.FromContext(ctx).Debugw("Previous trigger channel still in use", zap.Any("channel", ref), zap.String("subscription", sub.Name))
+			return nil
+		}
+	}
+
+	logging.FromContext(ctx).Infow("Deleting the previous trigger channel", zap.Any("channel", ref))
+	gvr, _ := meta.UnsafeGuessKindToResource(ref.GroupVersionKind())
+	err = r.dynamicClientSet.Resource(gvr).Namespace(ref.Namespace).Delete(ctx, ref.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the previous trigger channel %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	delete(b.Status.Annotations, eventing.BrokerPreviousChannelKindStatusAnnotationKey)
+	delete(b.Status.Annotations, eventing.BrokerPreviousChannelAPIVersionStatusAnnotationKey)
+	return nil
+}
+
+// reconcileDryRun publishes the changes of the trigger channel in a report
+// instead of applying them.
+func (r *Reconciler) reconcileDryRun(ctx context.Context, b *eventingv1.Broker, chanMan *channelTemplate, newChannel *unstructured.Unstructured) pkgreconciler.Event {
+	plan := dryrun.NewPlan(b)
+	if err := r.planChannel(ctx, plan, chanMan, newChannel); err != nil {
+		logging.FromContext(ctx).Errorw("Problem planning the trigger channel", zap.Error(err))
+		return err
+	}
+	return dryrun.Publish(ctx, r.kubeClientSet, plan)
+}
+
+// planChannel records the changes reconcileChannel would apply to the
+// trigger channel: its delivery and the spec of its template.
+func (r *Reconciler) planChannel(ctx context.Context, plan *dryrun.Plan, chanMan *channelTemplate, newChannel *unstructured.Unstructured) error {
+	kind, name := chanMan.ref.Kind, chanMan.ref.Name
+	existing, err := chanMan.inf.Get(ctx, name, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return plan.Record(dryrun.Create, kind, newChannel)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get channel %s/%s: %w", chanMan.ref.Namespace, name, err)
+	}
+
+	planned := existing.DeepCopy()
+	if delivery, ok, _ := unstructured.NestedFieldCopy(newChannel.Object, "spec", "delivery"); ok {
+		if err := unstructured.SetNestedField(planned.Object, delivery, "spec", "delivery"); err != nil {
+			return err
+		}
+	} else {
+		unstructured.RemoveNestedField(planned.Object, "spec", "delivery")
+	}
+	spec, err := channelTemplateSpecFields(&chanMan.template)
+	if err != nil {
+		return fmt.Errorf("invalid channel template spec: %w", err)
+	}
+	for k, v := range spec {
+		if err := unstructured.SetNestedField(planned.Object, v, "spec", k); err != nil {
+			return err
+		}
+	}
+
+	if equality.Semantic.DeepEqual(existing.Object, planned.Object) {
+		return plan.Record(dryrun.Unchanged, kind, planned)
+	}
+	return plan.Record(dryrun.Update, kind, planned)
+}
+
 // reconcileChannel reconciles Broker's 'b' underlying channel.
-func (r *Reconciler) reconcileChannel(ctx context.Context, channelResourceInterface dynamic.ResourceInterface, channelObjRef corev1.ObjectReference, newChannel *unstructured.Unstructured) (*duckv1.Channelable, error) {
+func (r *Reconciler) reconcileChannel(ctx context.Context, chanMan *channelTemplate, newChannel *unstructured.Unstructured) (*duckv1.Channelable, error) {
+	channelResourceInterface, channelObjRef := chanMan.inf, chanMan.ref
 	lister, err := r.channelableTracker.ListerFor(channelObjRef)
 	if err != nil {
 		logging.FromContext(ctx).Errorw(fmt.Sprintf("Error getting lister for Channel: %s/%s", channelObjRef.Namespace, channelObjRef.Name), zap.Error(err))
@@ -366,6 +571,13 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, channelResourceInterf
 		logging.FromContext(ctx).Errorw(fmt.Sprintf("Failed to convert to Channelable Object: %s/%s", channelObjRef.Namespace, channelObjRef.Name), zap.Error(err))
 		return nil, err
 	}
+	if channelable.DeletionTimestamp != nil {
+		return nil, errChannelRecreating
+	}
+
+	if err := r.reconcileChannelTemplate(ctx, chanMan); err != nil {
+		return nil, err
+	}
 
 	// We are only interested in comparing mutable properties, all
 	// others should not change. Any mutable property added to Channel based Brokers
@@ -420,6 +632,48 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, channelResourceInterf
 	return channelable, nil
 }
 
+// reconcileChannelTemplate applies the spec of the channel template to the
+// existing trigger channel, according to the update strategy of the template.
+func (r *Reconciler) reconcileChannelTemplate(ctx context.Context, chanMan *channelTemplate) error {
+	spec, err := channelTemplateSpecFields(&chanMan.template)
+	if err != nil {
+		return fmt.Errorf("invalid channel template spec: %w", err)
+	}
+	if len(spec) == 0 {
+		return nil
+	}
+
+	name := chanMan.ref.Name
+	existing, err := chanMan.inf.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get channel %s/%s: %w", chanMan.ref.Namespace, name, err)
+	}
+	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	if equality.Semantic.DeepDerivative(spec, existingSpec) {
+		return nil
+	}
+
+	if chanMan.strategy == ChannelTemplateUpdateRecreate {
+		logging.FromContext(ctx).Infow("Recreating Channel to apply its template", zap.String("namespace", chanMan.ref.Namespace), zap.String("name", name))
+		err := chanMan.inf.Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("failed to delete channel %s/%s: %w", chanMan.ref.Namespace, name, err)
+		}
+		return errChannelRecreating
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		return fmt.Errorf("marshalling merge patch for channel %s/%s: %w", chanMan.ref.Namespace, name, err)
+	}
+	patched, err := chanMan.inf.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("patching channel %s/%s with its template: %w", chanMan.ref.Namespace, name, err)
+	}
+	logging.FromContext(ctx).Info("Patched Channel with its template", zap.Any("patched", patched))
+	return nil
+}
+
 // TriggerChannelLabels are all the labels placed on the Trigger Channel for the given brokerName. This
 // should only be used by Broker and Trigger code.
 func TriggerChannelLabels(brokerName string) map[string]string {
@@ -441,21 +695,21 @@ func (r *Reconciler) getCaCerts() (*string, error) {
 	return pointer.String(string(caCerts)), nil
 }
 
-func (r *Reconciler) httpAddress(b *eventingv1.Broker) pkgduckv1.Addressable {
+func (r *Reconciler) httpAddress(ingressName string, b *eventingv1.Broker) pkgduckv1.Addressable {
 	// http address uses path-based routing
 	httpAddress := pkgduckv1.Addressable{
 		Name: pointer.String("http"),
-		URL:  apis.HTTP(network.GetServiceHostname(names.BrokerIngressName, system.Namespace())),
+		URL:  apis.HTTP(network.GetServiceHostname(ingressName, system.Namespace())),
 	}
 	httpAddress.URL.Path = fmt.Sprintf("/%s/%s", b.Namespace, b.Name)
 	return httpAddress
 }
 
-func (r *Reconciler) httpsAddress(caCerts *string, b *eventingv1.Broker) pkgduckv1.Addressable {
+func (r *Reconciler) httpsAddress(ingressName string, caCerts *string, b *eventingv1.Broker) pkgduckv1.Addressable {
 	// https address uses path-based routing
 	httpsAddress := pkgduckv1.Addressable{
 		Name:    pointer.String("https"),
-		URL:     apis.HTTPS(fmt.Sprintf("%s.%s.svc.%s", names.BrokerIngressName, system.Namespace(), network.GetClusterDomainName())),
+		URL:     apis.HTTPS(fmt.Sprintf("%s.%s.svc.%s", ingressName, system.Namespace(), network.GetClusterDomainName())),
 		CACerts: caCerts,
 	}
 	httpsAddress.URL.Path = fmt.Sprintf("/%s/%s", b.Namespace, b.Name)
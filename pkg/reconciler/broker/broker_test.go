@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
@@ -36,6 +37,7 @@ import (
 	v1addr "knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	v1a1addr "knative.dev/pkg/client/injection/ducks/duck/v1alpha1/addressable"
 	v1b1addr "knative.dev/pkg/client/injection/ducks/duck/v1beta1/addressable"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
@@ -52,6 +54,7 @@ import (
 	"knative.dev/eventing/pkg/client/injection/ducks/duck/v1/channelable"
 	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
 	"knative.dev/eventing/pkg/duck"
+	"knative.dev/eventing/pkg/reconciler/dryrun"
 
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
 	. "knative.dev/pkg/reconciler/testing"
@@ -75,9 +78,16 @@ const (
 	triggerChannelKind       = "InMemoryChannel"
 	triggerChannelName       = "test-broker-kne-trigger"
 
+	dryRunReportName = "broker-" + brokerName + "-dry-run"
+
 	imcSpec = `
 apiVersion: "messaging.knative.dev/v1"
 kind: "InMemoryChannel"
+`
+
+	channelSpec = `
+apiVersion: "messaging.knative.dev/v1"
+kind: "Channel"
 `
 )
 
@@ -136,6 +146,22 @@ var (
 	dls = duckv1.Addressable{
 		URL: apis.HTTP("test-dls.test-namespace.svc.cluster.local"),
 	}
+
+	inMemoryChannelGVR = schema.GroupVersionResource{
+		Group:    "messaging.knative.dev",
+		Version:  "v1",
+		Resource: "inmemorychannels",
+	}
+	imcGVK = metav1.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1",
+		Kind:    "InMemoryChannel",
+	}
+	channelGVK = metav1.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1",
+		Kind:    "Channel",
+	}
 )
 
 func TestReconcile(t *testing.T) {
@@ -388,6 +414,28 @@ func TestReconcile(t *testing.T) {
 					WithChannelNameAnnotation(triggerChannelName),
 					WithDLSNotConfigured()),
 			}},
+		}, {
+			Name: "Dry-run, trigger channel planned",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerAnnotation(dryrun.Annotation, "true"),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions),
+				imcConfigMap(),
+			},
+			WantCreates: []runtime.Object{
+				makeDryRunReport(t, dryrun.Create, createChannel()),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerAnnotation(dryrun.Annotation, "true"),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions,
+					WithBrokerStatusAnnotation(dryrun.ReportStatusAnnotation, dryRunReportName)),
+			}},
 		}, {
 			Name: "Successful Reconciliation with a Channel with CA certs",
 			Key:  testKey,
@@ -782,6 +830,221 @@ func TestReconcile(t *testing.T) {
 			Ctx: feature.ToContext(context.Background(), feature.Flags{
 				feature.OIDCAuthentication: feature.Enabled,
 			}),
+		}, {
+			Name: "Channel template spec changed, channel patched in place",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions),
+				createChannel(withChannelReady, withChannelSpecField("foo", "customValue")),
+				channelConfigMap(imcSpec+`spec:
+  customValue: bar
+`, ""),
+				NewEndpoints(filterServiceName, systemNS,
+					WithEndpointsLabels(FilterLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				NewEndpoints(ingressServiceName, systemNS,
+					WithEndpointsLabels(IngressLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithBrokerReady,
+					WithBrokerAddressURI(brokerAddress),
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation(triggerChannelKind),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithDLSNotConfigured()),
+			}},
+			WantPatches: []clientgotesting.PatchActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+				},
+				Name:  triggerChannelName,
+				Patch: []byte(`{"spec":{"customValue":"bar"}}`),
+			}},
+		}, {
+			Name: "Channel template spec unchanged",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions),
+				createChannel(withChannelReady, withChannelSpecField(int64(3), "partitions"), withChannelSpecField("defaulted", "other")),
+				channelConfigMap(imcSpec+`spec:
+  partitions: 3
+`, "recreate"),
+				NewEndpoints(filterServiceName, systemNS,
+					WithEndpointsLabels(FilterLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				NewEndpoints(ingressServiceName, systemNS,
+					WithEndpointsLabels(IngressLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithBrokerReady,
+					WithBrokerAddressURI(brokerAddress),
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation(triggerChannelKind),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithDLSNotConfigured()),
+			}},
+		}, {
+			Name: "Channel template spec changed, channel recreated",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions),
+				createChannel(withChannelReady, withChannelSpecField(int64(3), "partitions")),
+				channelConfigMap(imcSpec+`spec:
+  partitions: 6
+`, "recreate"),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+					Resource:  inMemoryChannelGVR,
+				},
+				Name: triggerChannelName,
+			}},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions,
+					WithTriggerChannelFailed("ChannelRecreating", "Channel is being recreated to apply its template.")),
+			}},
+		}, {
+			Name: "Channel kind changed, new channel not yet addressable",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions,
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation(triggerChannelKind),
+					WithChannelNameAnnotation(triggerChannelName)),
+				createChannel(withChannelReady),
+				channelConfigMap(channelSpec, ""),
+				NewEndpoints(filterServiceName, systemNS,
+					WithEndpointsLabels(FilterLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				NewEndpoints(ingressServiceName, systemNS,
+					WithEndpointsLabels(IngressLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+			},
+			WantCreates: []runtime.Object{
+				createChannel(withChannelKind("Channel")),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithBrokerReady,
+					WithBrokerAddressURI(brokerAddress),
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation(triggerChannelKind),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithDLSNotConfigured()),
+			}},
+		}, {
+			Name: "Channel kind changed, migrates to the new channel",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions,
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation(triggerChannelKind),
+					WithChannelNameAnnotation(triggerChannelName)),
+				createChannel(withChannelReady),
+				createChannel(withChannelKind("Channel"), withChannelReady),
+				channelConfigMap(channelSpec, ""),
+				NewSubscription("test-trigger-sub", testNS,
+					WithSubscriptionLabels(map[string]string{eventing.BrokerLabelKey: brokerName}),
+					WithSubscriptionChannel(imcGVK, triggerChannelName)),
+				NewEndpoints(filterServiceName, systemNS,
+					WithEndpointsLabels(FilterLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				NewEndpoints(ingressServiceName, systemNS,
+					WithEndpointsLabels(IngressLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithBrokerReady,
+					WithBrokerAddressURI(brokerAddress),
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation("Channel"),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithPreviousChannelAnnotations(triggerChannelAPIVersion, triggerChannelKind),
+					WithDLSNotConfigured()),
+			}},
+		}, {
+			Name: "Channel kind changed, previous channel deleted once unused",
+			Key:  testKey,
+			Objects: []runtime.Object{
+				NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithInitBrokerConditions,
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation("Channel"),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithPreviousChannelAnnotations(triggerChannelAPIVersion, triggerChannelKind)),
+				createChannel(withChannelReady),
+				createChannel(withChannelKind("Channel"), withChannelReady),
+				channelConfigMap(channelSpec, ""),
+				NewSubscription("test-trigger-sub", testNS,
+					WithSubscriptionLabels(map[string]string{eventing.BrokerLabelKey: brokerName}),
+					WithSubscriptionChannel(channelGVK, triggerChannelName)),
+				NewEndpoints(filterServiceName, systemNS,
+					WithEndpointsLabels(FilterLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+				NewEndpoints(ingressServiceName, systemNS,
+					WithEndpointsLabels(IngressLabels()),
+					WithEndpointsAddresses(corev1.EndpointAddress{IP: "127.0.0.1"})),
+			},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+					Resource:  inMemoryChannelGVR,
+				},
+				Name: triggerChannelName,
+			}},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewBroker(brokerName, testNS,
+					WithBrokerClass(eventing.MTChannelBrokerClassValue),
+					WithBrokerConfig(config()),
+					WithBrokerReady,
+					WithBrokerAddressURI(brokerAddress),
+					WithChannelAddressAnnotation(triggerChannelURL),
+					WithChannelAPIVersionAnnotation(triggerChannelAPIVersion),
+					WithChannelKindAnnotation("Channel"),
+					WithChannelNameAnnotation(triggerChannelName),
+					WithDLSNotConfigured()),
+			}},
 		},
 	}
 
@@ -802,6 +1065,7 @@ func TestReconcile(t *testing.T) {
 		}
 
 		r := &Reconciler{
+			kubeClientSet:      fakekubeclient.Get(ctx),
 			eventingClientSet:  fakeeventingclient.Get(ctx),
 			dynamicClientSet:   fakedynamicclient.Get(ctx),
 			subscriptionLister: listers.GetSubscriptionLister(),
@@ -809,6 +1073,7 @@ func TestReconcile(t *testing.T) {
 			configmapLister:    listers.GetConfigMapLister(),
 			secretLister:       listers.GetSecretLister(),
 			channelableTracker: duck.NewListableTrackerFromTracker(ctx, channelable.Get, tracker.New(func(types.NamespacedName) {}, 0)),
+			configmapTracker:   tracker.New(func(types.NamespacedName) {}, 0),
 			uriResolver:        resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
 		}
 		return broker.NewReconciler(ctx, logger,
@@ -855,6 +1120,31 @@ func imcConfigMapLegacy() *corev1.ConfigMap {
 // unstructuredOption modifies *unstructured.Unstructured contents.
 type unstructuredOption func(*unstructured.Unstructured)
 
+// channelConfigMap returns the Broker config with the given channel template
+// and update strategy.
+func channelConfigMap(template, strategy string) *corev1.ConfigMap {
+	data := map[string]string{"channel-template-spec": template}
+	if strategy != "" {
+		data["channel-template-update-strategy"] = strategy
+	}
+	return NewConfigMap(configMapName, testNS, WithConfigMapData(data))
+}
+
+func withChannelKind(kind string) unstructuredOption {
+	return func(channel *unstructured.Unstructured) {
+		channel.SetKind(kind)
+	}
+}
+
+func withChannelSpecField(value interface{}, fields ...string) unstructuredOption {
+	return func(channel *unstructured.Unstructured) {
+		if err := unstructured.SetNestedField(channel.Object, value,
+			append([]string{"spec"}, fields...)...); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func withChannelStatusAddress(url string) unstructuredOption {
 	return func(channel *unstructured.Unstructured) {
 		if err := unstructured.SetNestedField(channel.Object, url,
@@ -906,6 +1196,15 @@ func withChannelStatusAudience(aud string) unstructuredOption {
 	}
 }
 
+func makeDryRunReport(t *testing.T, action dryrun.Action, channel *unstructured.Unstructured) *corev1.ConfigMap {
+	t.Helper()
+	plan := dryrun.NewPlan(NewBroker(brokerName, testNS))
+	if err := plan.Record(action, triggerChannelKind, channel); err != nil {
+		t.Fatal(err)
+	}
+	return plan.MakeReport()
+}
+
 func withChannelReady(channel *unstructured.Unstructured) {
 	withChannelStatusAddress(triggerChannelURL)(channel)
 	withChannelStatusDeadLetterSinkURI(dls.URL.String())(channel)
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package canary routes a percentage of the MT channel based Brokers to a
+// canary version of the broker-ingress and broker-filter deployments, and
+// falls them back to the stable version when the canary reports elevated
+// error rates.
+package canary
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	cm "knative.dev/pkg/configmap"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/reconciler/names"
+)
+
+const (
+	// ConfigName is the name of the ConfigMap configuring the canary
+	// rollout of the Broker data plane.
+	ConfigName = "config-br-canary"
+
+	selectorKey           = "selector"
+	percentageKey         = "percentage"
+	ingressServiceKey     = "ingress-service"
+	filterServiceKey      = "filter-service"
+	errorRateThresholdKey = "error-rate-threshold"
+	minEventsKey          = "min-events"
+
+	// DefaultIngressServiceName is the default Service of the canary
+	// broker-ingress.
+	DefaultIngressServiceName = names.BrokerIngressName + "-canary"
+	// DefaultFilterServiceName is the default Service of the canary
+	// broker-filter.
+	DefaultFilterServiceName = names.BrokerFilterName + "-canary"
+	// DefaultErrorRateThreshold is the default error rate of the canary above
+	// which the Brokers fall back to the stable data plane.
+	DefaultErrorRateThreshold = 0.05
+	// DefaultMinEvents is the default number of events the canary handles
+	// before its error rate is considered.
+	DefaultMinEvents = 100
+)
+
+// Config is the configuration of the canary rollout of the Broker data
+// plane.
+type Config struct {
+	// Selector selects the Brokers that may be routed to the canary.
+	Selector labels.Selector
+	// Percentage is the percentage of the selected Brokers routed to the
+	// canary, 0 disables the canary.
+	Percentage int64
+	// IngressServiceName is the Service of the canary broker-ingress, in the
+	// system namespace.
+	IngressServiceName string
+	// FilterServiceName is the Service of the canary broker-filter, in the
+	// system namespace.
+	FilterServiceName string
+	// ErrorRateThreshold is the ratio of the events answered with a 5xx
+	// status code by the canary above which the Brokers fall back to the
+	// stable data plane.
+	ErrorRateThreshold float64
+	// MinEvents is the number of events the canary handles between two
+	// checks before its error rate is considered.
+	MinEvents int64
+}
+
+// NewConfigFromConfigMap creates a Config from the supplied ConfigMap.
+func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*Config, error) {
+	return NewConfigFromMap(configMap.Data)
+}
+
+// NewConfigFromMap creates a Config from the supplied map.
+func NewConfigFromMap(data map[string]string) (*Config, error) {
+	c := &Config{
+		Selector:           labels.Everything(),
+		IngressServiceName: DefaultIngressServiceName,
+		FilterServiceName:  DefaultFilterServiceName,
+		ErrorRateThreshold: DefaultErrorRateThreshold,
+		MinEvents:          DefaultMinEvents,
+	}
+
+	var selector string
+	if err := cm.Parse(data,
+		cm.AsString(selectorKey, &selector),
+		cm.AsInt64(percentageKey, &c.Percentage),
+		cm.AsString(ingressServiceKey, &c.IngressServiceName),
+		cm.AsString(filterServiceKey, &c.FilterServiceName),
+		cm.AsFloat64(errorRateThresholdKey, &c.ErrorRateThreshold),
+		cm.AsInt64(minEventsKey, &c.MinEvents),
+	); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	if selector != "" {
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", selectorKey, selector, err)
+		}
+		c.Selector = s
+	}
+	if c.Percentage < 0 || c.Percentage > 100 {
+		return nil, fmt.Errorf("%s must be between 0 and 100, was %d", percentageKey, c.Percentage)
+	}
+	if c.IngressServiceName == "" || c.IngressServiceName == names.BrokerIngressName {
+		return nil, fmt.Errorf("%s must name a Service other than %s", ingressServiceKey, names.BrokerIngressName)
+	}
+	if c.FilterServiceName == "" || c.FilterServiceName == names.BrokerFilterName {
+		return nil, fmt.Errorf("%s must name a Service other than %s", filterServiceKey, names.BrokerFilterName)
+	}
+	if c.ErrorRateThreshold <= 0 || c.ErrorRateThreshold > 1 {
+		return nil, fmt.Errorf("%s must be greater than 0 and at most 1, was %v", errorRateThresholdKey, c.ErrorRateThreshold)
+	}
+	if c.MinEvents < 0 {
+		return nil, fmt.Errorf("%s must not be negative, was %d", minEventsKey, c.MinEvents)
+	}
+	return c, nil
+}
+
+// Enabled tells whether some Brokers are routed to the canary.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Percentage > 0
+}
+
+// Selects tells whether the Broker is routed to the canary. The Brokers are
+// spread by the hash of their namespace and name, so that a Broker stays on
+// the canary while the percentage doesn't decrease.
+func (c *Config) Selects(b *eventingv1.Broker) bool {
+	if !c.Enabled() || !c.Selector.Matches(labels.Set(b.Labels)) {
+		return false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(b.Namespace + "/" + b.Name))
+	return int64(h.Sum32()%100) < c.Percentage
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "knative.dev/pkg/configmap/testing"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+func TestNewConfigFromConfigMap(t *testing.T) {
+	actual, example := ConfigMapsFromTestFile(t, ConfigName)
+
+	c, err := NewConfigFromConfigMap(actual)
+	if err != nil {
+		t.Fatal("NewConfigFromConfigMap(actual) =", err)
+	}
+	if c.Enabled() {
+		t.Error("Expected the actual config to disable the canary")
+	}
+
+	c, err = NewConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatal("NewConfigFromConfigMap(example) =", err)
+	}
+	if got, want := c.Selector.String(), "eventing.knative.dev/canary=true"; got != want {
+		t.Errorf("Selector = %q, want %q", got, want)
+	}
+	if c.Percentage != 10 || c.IngressServiceName != DefaultIngressServiceName || c.FilterServiceName != DefaultFilterServiceName ||
+		c.ErrorRateThreshold != DefaultErrorRateThreshold || c.MinEvents != DefaultMinEvents {
+		t.Errorf("Unexpected example config %+v", c)
+	}
+}
+
+func TestNewConfigFromMapErrors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"invalid selector":             {selectorKey: "a b"},
+		"invalid percentage":           {percentageKey: "ten"},
+		"percentage out of range":      {percentageKey: "101"},
+		"stable ingress":               {ingressServiceKey: "broker-ingress"},
+		"empty filter":                 {filterServiceKey: ""},
+		"zero error rate threshold":    {errorRateThresholdKey: "0"},
+		"error rate threshold above 1": {errorRateThresholdKey: "1.5"},
+		"negative min events":          {minEventsKey: "-1"},
+	}
+	for n, data := range tests {
+		t.Run(n, func(t *testing.T) {
+			if c, err := NewConfigFromMap(data); err == nil {
+				t.Errorf("NewConfigFromMap() = %+v, wanted an error", c)
+			}
+		})
+	}
+}
+
+func TestConfigSelects(t *testing.T) {
+	c, err := NewConfigFromMap(map[string]string{
+		selectorKey:   "canary=true",
+		percentageKey: "50",
+	})
+	if err != nil {
+		t.Fatal("NewConfigFromMap() =", err)
+	}
+
+	selected := 0
+	for i := 0; i < 200; i++ {
+		b := newBroker(fmt.Sprintf("b%d", i), map[string]string{"canary": "true"})
+		if c.Selects(b) {
+			selected++
+		}
+		if c.Selects(newBroker(b.Name, nil)) {
+			t.Errorf("Expected the Broker %s without the label not to be selected", b.Name)
+		}
+	}
+	// The hash spreads the Brokers roughly evenly.
+	if selected < 70 || selected > 130 {
+		t.Errorf("Expected about 100 Brokers out of 200 to be selected, got %d", selected)
+	}
+
+	c.Percentage = 100
+	if !c.Selects(newBroker("b", map[string]string{"canary": "true"})) {
+		t.Error("Expected every selected Broker to be routed to the canary at 100%")
+	}
+
+	var disabled *Config
+	if disabled.Selects(newBroker("b", nil)) {
+		t.Error("Expected a nil config not to select any Broker")
+	}
+}
+
+func newBroker(name string, labels map[string]string) *eventingv1.Broker {
+	return &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+}
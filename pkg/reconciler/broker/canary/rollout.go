@@ -0,0 +1,422 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+	"knative.dev/eventing/pkg/reconciler/names"
+)
+
+const (
+	// metricsPortName is the name of the port the data plane Services expose
+	// the Prometheus metrics of their pods on.
+	metricsPortName = "http-metrics"
+	// defaultMetricsPort is the metrics port of the data plane pods, when
+	// their Service doesn't name it.
+	defaultMetricsPort = 9092
+
+	// errorResponseCodeClass is the response code class of the events
+	// counted as errors.
+	errorResponseCodeClass = "5xx"
+
+	// FallbackAnnotationKey is the annotation of the config-br-canary
+	// ConfigMap set to the hash of its data when the canary reported elevated
+	// error rates, so that all the replicas of the controller fall back to
+	// the stable data plane until the data changes.
+	FallbackAnnotationKey = "eventing.knative.dev/canary-fallback"
+)
+
+// The metrics of the data plane the error rates are computed from, by
+// response code class.
+const (
+	// ingressEventCountMetric counts the events received by the
+	// broker-ingress.
+	ingressEventCountMetric = "mt_broker_ingress_event_count"
+	// filterEventCountMetric counts the events dispatched by the
+	// broker-filter, including the ones answered with an error by the
+	// subscribers of the Triggers.
+	filterEventCountMetric = "mt_broker_filter_event_count"
+	// filterDispatchLatenciesMetric records the events answered by the
+	// subscribers of the Triggers, with their response code, whose errors
+	// aren't the canary's.
+	filterDispatchLatenciesMetric = "mt_broker_filter_event_dispatch_latencies"
+)
+
+// counts are the events counted by a data plane pod.
+type counts struct {
+	total  float64
+	errors float64
+}
+
+// Rollout routes the Brokers selected by the config-br-canary ConfigMap to
+// the canary data plane, until the canary reports elevated error rates.
+//
+// Only the leader for the config-br-canary ConfigMap checks the error rates,
+// and it persists a fallback with the FallbackAnnotationKey annotation of the
+// ConfigMap.
+type Rollout struct {
+	logger          *zap.SugaredLogger
+	kubeClient      kubernetes.Interface
+	endpointsLister corev1listers.EndpointsLister
+	client          *http.Client
+
+	// onChange is called when Brokers move between the stable and the
+	// canary data planes.
+	onChange func()
+
+	// leader tells whether this replica checks the error rates.
+	leader atomic.Bool
+
+	mu     sync.RWMutex
+	config *Config
+	// configHash is the hash of the data of the config-br-canary ConfigMap.
+	configHash string
+	// fallback is set once the canary reported elevated error rates, all the
+	// Brokers are routed to the stable data plane until the config changes.
+	fallback bool
+	// persisted tells whether the fallback is persisted in the ConfigMap.
+	persisted bool
+	// previous are the counts of the canary pods at the previous check, by
+	// metrics URL.
+	previous map[string]counts
+}
+
+// NewRollout creates a Rollout routing all the Brokers to the stable data
+// plane until its config is set.
+func NewRollout(logger *zap.SugaredLogger, kubeClient kubernetes.Interface, endpointsLister corev1listers.EndpointsLister, onChange func()) *Rollout {
+	return &Rollout{
+		logger:          logger,
+		kubeClient:      kubeClient,
+		endpointsLister: endpointsLister,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		onChange:        onChange,
+	}
+}
+
+// UpdateFromConfigMap sets the config of the Rollout, it is meant to be used
+// as a configmap.Watcher observer. The Brokers fall back to the stable data
+// plane while the FallbackAnnotationKey annotation matches the data of the
+// ConfigMap, so that new data ends a fallback and a fixed canary is rolled
+// out again.
+func (r *Rollout) UpdateFromConfigMap(configMap *corev1.ConfigMap) {
+	config, err := NewConfigFromConfigMap(configMap)
+	if err != nil {
+		r.logger.Errorw("Failed to parse the canary config, keeping the current one", zap.Error(err))
+		return
+	}
+	hash := dataHash(configMap.Data)
+	fallback := configMap.Annotations[FallbackAnnotationKey] == hash
+
+	r.mu.Lock()
+	if r.configHash != hash {
+		r.previous = nil
+	}
+	r.config = config
+	r.configHash = hash
+	r.fallback = fallback
+	r.persisted = fallback
+	r.mu.Unlock()
+
+	r.onChange()
+}
+
+// Promote makes this replica check the error rates of the canary when it
+// is the leader for the config-br-canary ConfigMap. It is meant to be used
+// as the controller.Options PromoteFunc of the Broker reconciler.
+func (r *Rollout) Promote(b reconciler.Bucket) {
+	if b.Has(configKey()) {
+		r.mu.Lock()
+		r.previous = nil
+		r.mu.Unlock()
+		r.leader.Store(true)
+	}
+}
+
+// Demote stops the checks of the error rates of the canary when this
+// replica isn't the leader for the config-br-canary ConfigMap anymore. It is
+// meant to be used as the controller.Options DemoteFunc of the Broker
+// reconciler.
+func (r *Rollout) Demote(b reconciler.Bucket) {
+	if b.Has(configKey()) {
+		r.leader.Store(false)
+	}
+}
+
+func configKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: system.Namespace(), Name: ConfigName}
+}
+
+// ServiceNames returns the broker-ingress and broker-filter Services the
+// Broker is routed to.
+func (r *Rollout) ServiceNames(b *eventingv1.Broker) (ingress string, filter string) {
+	if r == nil {
+		return names.BrokerIngressName, names.BrokerFilterName
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.fallback || !r.config.Selects(b) {
+		return names.BrokerIngressName, names.BrokerFilterName
+	}
+	return r.config.IngressServiceName, r.config.FilterServiceName
+}
+
+// IsCanaryService tells whether the Service in the system namespace is one
+// of the canary data plane.
+func (r *Rollout) IsCanaryService(name string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config.Enabled() && (name == r.config.IngressServiceName || name == r.config.FilterServiceName)
+}
+
+// Run checks the error rates of the canary every interval until ctx is done,
+// while this replica is the leader.
+func (r *Rollout) Run(ctx context.Context, interval time.Duration) {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Check(ctx); err != nil {
+			r.logger.Warnw("Failed to check the canary data plane", zap.Error(err))
+		}
+	}, interval)
+}
+
+// Check scrapes the metrics of the canary pods, and falls all the Brokers back
+// to the stable data plane when the ratio of the events answered with a 5xx
+// status code since the previous check is above the threshold. The 5xx
+// responses of the subscribers of the Triggers, forwarded by the
+// broker-filter, aren't counted as errors of the canary. It does
+// nothing when this replica isn't the leader.
+func (r *Rollout) Check(ctx context.Context) error {
+	if !r.leader.Load() {
+		return nil
+	}
+	r.mu.RLock()
+	config, hash, fallback, persisted := r.config, r.configHash, r.fallback, r.persisted
+	r.mu.RUnlock()
+	if !config.Enabled() {
+		return nil
+	}
+	if fallback {
+		if persisted {
+			return nil
+		}
+		return r.persistFallback(ctx, hash)
+	}
+
+	var urls []string
+	for _, name := range []string{config.IngressServiceName, config.FilterServiceName} {
+		endpoints, err := r.endpointsLister.Endpoints(system.Namespace()).Get(name)
+		if apierrs.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get the endpoints of %s: %w", name, err)
+		}
+		urls = append(urls, metricsURLs(endpoints)...)
+	}
+
+	var errs []error
+	current := make(map[string]counts, len(urls))
+	for _, url := range urls {
+		c, err := r.scrape(ctx, url)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		current[url] = c
+	}
+
+	r.mu.Lock()
+	// The config changed while scraping.
+	if r.config != config || r.fallback || !r.leader.Load() {
+		r.mu.Unlock()
+		return errors.Join(errs...)
+	}
+	var delta counts
+	for url, c := range current {
+		previous := r.previous[url]
+		// The counters of a restarted pod start over.
+		if c.total < previous.total {
+			previous = counts{}
+		}
+		delta.total += c.total - previous.total
+		delta.errors += c.errors - previous.errors
+	}
+	r.previous = current
+	elevated := delta.total > 0 && delta.total >= float64(config.MinEvents) &&
+		delta.errors/delta.total > config.ErrorRateThreshold
+	if elevated {
+		r.fallback = true
+	}
+	r.mu.Unlock()
+
+	if elevated {
+		r.logger.Warnw("Falling back to the stable data plane, the canary error rate is elevated",
+			zap.Float64("events", delta.total), zap.Float64("errors", delta.errors),
+			zap.Float64("threshold", config.ErrorRateThreshold))
+		r.onChange()
+		if err := r.persistFallback(ctx, hash); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// persistFallback sets the FallbackAnnotationKey annotation of the
+// config-br-canary ConfigMap to the hash of its data, so that the other
+// replicas and the next leaders fall back too.
+func (r *Rollout) persistFallback(ctx context.Context, hash string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{FallbackAnnotationKey: hash},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.kubeClient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, ConfigName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to persist the fallback in %s: %w", ConfigName, err)
+	}
+
+	r.mu.Lock()
+	if r.configHash == hash {
+		r.persisted = true
+	}
+	r.mu.Unlock()
+	return nil
+}
+
+// dataHash returns a hash of the data of a ConfigMap.
+func dataHash(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(data[k]), data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// scrape returns the events counted by the pod serving its metrics at url.
+func (r *Rollout) scrape(ctx context.Context, url string) (counts, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return counts{}, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return counts{}, fmt.Errorf("failed to scrape %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return counts{}, fmt.Errorf("failed to scrape %s: status code %d", url, resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return counts{}, fmt.Errorf("failed to parse the metrics of %s: %w", url, err)
+	}
+
+	var c counts
+	for _, name := range []string{ingressEventCountMetric, filterEventCountMetric} {
+		total, errors := sumByClass(families[name])
+		c.total += total
+		c.errors += errors
+	}
+	// The events answered with an error by the subscribers are counted by
+	// the broker-filter as well, they don't tell whether the canary works.
+	_, subscriberErrors := sumByClass(families[filterDispatchLatenciesMetric])
+	c.errors -= subscriberErrors
+	if c.errors < 0 {
+		c.errors = 0
+	}
+	return c, nil
+}
+
+// sumByClass returns the number of events counted by the metric family, and
+// the ones with the error response code class. family may be nil.
+func sumByClass(family *dto.MetricFamily) (total float64, errors float64) {
+	for _, m := range family.GetMetric() {
+		var v float64
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			v = m.GetCounter().GetValue()
+		case dto.MetricType_HISTOGRAM:
+			v = float64(m.GetHistogram().GetSampleCount())
+		default:
+			v = m.GetUntyped().GetValue()
+		}
+		total += v
+		for _, l := range m.GetLabel() {
+			if l.GetName() == eventingmetrics.LabelResponseCodeClass && l.GetValue() == errorResponseCodeClass {
+				errors += v
+			}
+		}
+	}
+	return total, errors
+}
+
+// metricsURLs returns the URLs of the metrics of the pods behind endpoints.
+func metricsURLs(endpoints *corev1.Endpoints) []string {
+	var urls []string
+	for _, subset := range endpoints.Subsets {
+		port := int32(defaultMetricsPort)
+		for _, p := range subset.Ports {
+			if p.Name == metricsPortName {
+				port = p.Port
+			}
+		}
+		for _, addr := range subset.Addresses {
+			urls = append(urls, fmt.Sprintf("http://%s/metrics", net.JoinHostPort(addr.IP, strconv.Itoa(int(port)))))
+		}
+	}
+	return urls
+}
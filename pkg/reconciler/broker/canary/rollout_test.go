@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing"
+
+	"knative.dev/eventing/pkg/reconciler/names"
+)
+
+// fakeMetrics serves the event counts of a canary pod.
+type fakeMetrics struct {
+	total  atomic.Int64
+	errors atomic.Int64
+	// subscriberErrors are the events the broker-filter dispatched to
+	// subscribers answering with a 5xx status code.
+	subscriberErrors atomic.Int64
+}
+
+func (m *fakeMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	fmt.Fprintln(w, "# TYPE mt_broker_ingress_event_count counter")
+	fmt.Fprintf(w, "mt_broker_ingress_event_count{response_code_class=\"2xx\"} %d\n", m.total.Load()-m.errors.Load())
+	fmt.Fprintf(w, "mt_broker_ingress_event_count{response_code_class=\"5xx\"} %d\n", m.errors.Load())
+
+	subscriberErrors := m.subscriberErrors.Load()
+	fmt.Fprintln(w, "# TYPE mt_broker_filter_event_count counter")
+	fmt.Fprintf(w, "mt_broker_filter_event_count{response_code_class=\"5xx\"} %d\n", subscriberErrors)
+	fmt.Fprintln(w, "# TYPE mt_broker_filter_event_dispatch_latencies histogram")
+	fmt.Fprintf(w, "mt_broker_filter_event_dispatch_latencies_bucket{response_code_class=\"5xx\",le=\"+Inf\"} %d\n", subscriberErrors)
+	fmt.Fprintf(w, "mt_broker_filter_event_dispatch_latencies_sum{response_code_class=\"5xx\"} %d\n", 10*subscriberErrors)
+	fmt.Fprintf(w, "mt_broker_filter_event_dispatch_latencies_count{response_code_class=\"5xx\"} %d\n", subscriberErrors)
+}
+
+func (m *fakeMetrics) add(total, errors int64) {
+	m.total.Add(total)
+	m.errors.Add(errors)
+}
+
+// testRollout is a Rollout with the fake metrics of its canary pod and the
+// fake client holding its ConfigMap.
+type testRollout struct {
+	*Rollout
+	metrics    *fakeMetrics
+	changes    *atomic.Int32
+	fakeClient *fake.Clientset
+}
+
+// configMap returns the config-br-canary ConfigMap in the fake client.
+func (r *testRollout) configMap(t *testing.T) *corev1.ConfigMap {
+	t.Helper()
+	cm, err := r.fakeClient.CoreV1().ConfigMaps(system.Namespace()).Get(context.Background(), ConfigName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cm
+}
+
+func newTestRollout(t *testing.T, data map[string]string) *testRollout {
+	t.Helper()
+
+	metrics := &fakeMetrics{}
+	server := httptest.NewServer(metrics)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := strconv.Atoi(port)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(&corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: DefaultIngressServiceName},
+		Subsets: []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: host}},
+			Ports:     []corev1.EndpointPort{{Name: "http", Port: 8080}, {Name: metricsPortName, Port: int32(p)}},
+		}},
+	})
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: ConfigName},
+		Data:       data,
+	}
+	client := fake.NewSimpleClientset(cm)
+	changes := &atomic.Int32{}
+	r := NewRollout(logtesting.TestLogger(t), client, corev1listers.NewEndpointsLister(indexer), func() { changes.Add(1) })
+	r.UpdateFromConfigMap(cm)
+	r.Promote(reconciler.UniversalBucket())
+	return &testRollout{Rollout: r, metrics: metrics, changes: changes, fakeClient: client}
+}
+
+func TestRolloutServiceNames(t *testing.T) {
+	var disabled *Rollout
+	if ingress, filter := disabled.ServiceNames(newBroker("b", nil)); ingress != names.BrokerIngressName || filter != names.BrokerFilterName {
+		t.Errorf("ServiceNames() = %s, %s, want the stable Services", ingress, filter)
+	}
+
+	r := newTestRollout(t, map[string]string{percentageKey: "100"})
+	if r.changes.Load() != 1 {
+		t.Errorf("Expected a config update to call onChange once, got %d", r.changes.Load())
+	}
+	if ingress, filter := r.ServiceNames(newBroker("b", nil)); ingress != DefaultIngressServiceName || filter != DefaultFilterServiceName {
+		t.Errorf("ServiceNames() = %s, %s, want the canary Services", ingress, filter)
+	}
+	if !r.IsCanaryService(DefaultFilterServiceName) || r.IsCanaryService(names.BrokerFilterName) {
+		t.Error("Unexpected canary Services")
+	}
+}
+
+func TestRolloutFallback(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRollout(t, map[string]string{
+		percentageKey:         "100",
+		errorRateThresholdKey: "0.1",
+		minEventsKey:          "10",
+	})
+	b := newBroker("b", nil)
+
+	// The first check counts the events since the pods started.
+	r.metrics.add(100, 5)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != DefaultIngressServiceName {
+		t.Fatalf("Expected the Broker to stay on the canary, got %s", ingress)
+	}
+
+	// Too few events to consider the error rate.
+	r.metrics.add(5, 5)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != DefaultIngressServiceName {
+		t.Fatalf("Expected the Broker to stay on the canary under min-events, got %s", ingress)
+	}
+
+	// An error rate under the threshold.
+	r.metrics.add(100, 5)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != DefaultIngressServiceName {
+		t.Fatalf("Expected the Broker to stay on the canary under the threshold, got %s", ingress)
+	}
+
+	// An elevated error rate.
+	r.metrics.add(100, 20)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, filter := r.ServiceNames(b); ingress != names.BrokerIngressName || filter != names.BrokerFilterName {
+		t.Fatalf("Expected the Broker to fall back to the stable Services, got %s, %s", ingress, filter)
+	}
+	if r.changes.Load() != 2 {
+		t.Errorf("Expected the fallback to call onChange, got %d calls", r.changes.Load())
+	}
+	if !r.IsCanaryService(DefaultIngressServiceName) {
+		t.Error("Expected the canary Services to be known after a fallback")
+	}
+
+	// The fallback is persisted in the ConfigMap, so that the other replicas
+	// and the next leaders fall back too.
+	cm := r.configMap(t)
+	if got, want := cm.Annotations[FallbackAnnotationKey], dataHash(cm.Data); got != want {
+		t.Fatalf("Got the %s annotation %q, want %q", FallbackAnnotationKey, got, want)
+	}
+	other := NewRollout(logtesting.TestLogger(t), r.fakeClient, r.endpointsLister, func() {})
+	other.UpdateFromConfigMap(cm)
+	if ingress, _ := other.ServiceNames(b); ingress != names.BrokerIngressName {
+		t.Errorf("Expected another replica to fall back to the stable Services, got %s", ingress)
+	}
+	r.UpdateFromConfigMap(cm)
+	if ingress, _ := r.ServiceNames(b); ingress != names.BrokerIngressName {
+		t.Errorf("Expected the persisted fallback to be kept, got %s", ingress)
+	}
+
+	// A new config rolls the canary out again.
+	cm = cm.DeepCopy()
+	cm.Data[minEventsKey] = "20"
+	r.UpdateFromConfigMap(cm)
+	if ingress, _ := r.ServiceNames(b); ingress != DefaultIngressServiceName {
+		t.Errorf("Expected a new config to route the Broker to the canary, got %s", ingress)
+	}
+}
+
+func TestRolloutSubscriberErrors(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRollout(t, map[string]string{
+		percentageKey:         "100",
+		errorRateThresholdKey: "0.1",
+		minEventsKey:          "10",
+	})
+	b := newBroker("b", nil)
+
+	// The subscribers answering with a 5xx status code don't tell whether
+	// the canary works.
+	r.metrics.add(100, 0)
+	r.metrics.subscriberErrors.Add(100)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != DefaultIngressServiceName {
+		t.Fatalf("Expected the Broker to stay on the canary with failing subscribers, got %s", ingress)
+	}
+	if r.changes.Load() != 1 {
+		t.Errorf("Expected no fallback, got %d calls of onChange", r.changes.Load())
+	}
+
+	// The errors of the canary still make it fall back.
+	r.metrics.add(100, 40)
+	r.metrics.subscriberErrors.Add(100)
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != names.BrokerIngressName {
+		t.Fatalf("Expected the Broker to fall back to the stable Services, got %s", ingress)
+	}
+}
+
+func TestRolloutFallbackPersistError(t *testing.T) {
+	ctx := context.Background()
+	r := newTestRollout(t, map[string]string{percentageKey: "100", minEventsKey: "0"})
+	b := newBroker("b", nil)
+
+	fail := true
+	r.fakeClient.PrependReactor("patch", "configmaps", func(clientgotesting.Action) (bool, runtime.Object, error) {
+		if fail {
+			return true, nil, fmt.Errorf("inducing failure for patch configmaps")
+		}
+		return false, nil, nil
+	})
+
+	r.metrics.add(100, 100)
+	if err := r.Check(ctx); err == nil {
+		t.Error("Expected Check() to fail when the fallback can't be persisted")
+	}
+	if ingress, _ := r.ServiceNames(b); ingress != names.BrokerIngressName {
+		t.Errorf("Expected the leader to fall back even if the fallback isn't persisted, got %s", ingress)
+	}
+
+	// The next check persists the fallback.
+	fail = false
+	if err := r.Check(ctx); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if cm := r.configMap(t); cm.Annotations[FallbackAnnotationKey] != dataHash(cm.Data) {
+		t.Errorf("Expected the fallback to be persisted, got the annotations %v", cm.Annotations)
+	}
+}
+
+func TestRolloutCheckNotLeader(t *testing.T) {
+	r := newTestRollout(t, map[string]string{percentageKey: "100", minEventsKey: "0"})
+	r.Demote(reconciler.UniversalBucket())
+
+	r.metrics.add(100, 100)
+	if err := r.Check(context.Background()); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(newBroker("b", nil)); ingress != DefaultIngressServiceName {
+		t.Errorf("Expected a replica that isn't the leader not to check the canary, got %s", ingress)
+	}
+
+	// The leader for another bucket doesn't check the canary either.
+	r.Promote(otherBucket{})
+	if err := r.Check(context.Background()); err != nil {
+		t.Fatal("Check() =", err)
+	}
+	if ingress, _ := r.ServiceNames(newBroker("b", nil)); ingress != DefaultIngressServiceName {
+		t.Errorf("Expected the leader of another bucket not to check the canary, got %s", ingress)
+	}
+}
+
+// otherBucket is a bucket that doesn't have the config-br-canary ConfigMap.
+type otherBucket struct{}
+
+func (otherBucket) Name() string { return "other" }
+
+func (otherBucket) Has(types.NamespacedName) bool { return false }
+
+func TestRolloutCheckScrapeError(t *testing.T) {
+	r := newTestRollout(t, map[string]string{percentageKey: "100"})
+	r.client.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})
+	if err := r.Check(context.Background()); err == nil {
+		t.Error("Expected Check() to fail when the canary can't be scraped")
+	}
+	if ingress, _ := r.ServiceNames(newBroker("b", nil)); ingress != DefaultIngressServiceName {
+		t.Errorf("Expected a scrape error not to fall back, got %s", ingress)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -24,8 +24,10 @@ import (
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
 	"sigs.k8s.io/yaml"
 
+	"knative.dev/pkg/apis"
 	cm "knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
 
@@ -34,25 +36,52 @@ import (
 
 type Config struct {
 	DefaultChannelTemplate messagingv1.ChannelTemplateSpec
+
+	// ChannelTemplateUpdateStrategy is how the trigger channel of a Broker is
+	// updated when the spec of its channel template changes.
+	ChannelTemplateUpdateStrategy ChannelTemplateUpdateStrategy
 }
 
+// ChannelTemplateUpdateStrategy is how the trigger channel of a Broker is
+// updated when the spec of its channel template changes. A change of the kind
+// of the channel always creates a new channel, to which the Broker migrates
+// once it is ready.
+type ChannelTemplateUpdateStrategy string
+
+const (
+	// ChannelTemplateUpdateInPlace patches the spec of the existing channel.
+	ChannelTemplateUpdateInPlace ChannelTemplateUpdateStrategy = "in-place"
+	// ChannelTemplateUpdateRecreate deletes the existing channel and creates
+	// it again, for the channels whose spec is immutable. The Broker doesn't
+	// accept events until the channel is recreated.
+	ChannelTemplateUpdateRecreate ChannelTemplateUpdateStrategy = "recreate"
+)
+
 const (
-	channelTemplateSpec       = "channel-template-spec"
-	legacyChannelTemplateSpec = "channelTemplateSpec"
+	channelTemplateSpec           = "channel-template-spec"
+	legacyChannelTemplateSpec     = "channelTemplateSpec"
+	channelTemplateUpdateStrategy = "channel-template-update-strategy"
 )
 
+// brokerManagedChannelFields are the fields of the spec of the trigger channel
+// that are set by the Broker and its Triggers, rather than by the template.
+var brokerManagedChannelFields = []string{"delivery", "subscribers"}
+
 func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.ConfigMap) (*Config, error) {
 	return func(configMap *corev1.ConfigMap) (*Config, error) {
 		config := &Config{
-			DefaultChannelTemplate: messagingv1.ChannelTemplateSpec{},
+			DefaultChannelTemplate:        messagingv1.ChannelTemplateSpec{},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateInPlace,
 		}
 
 		temp := ""
+		strategy := string(ChannelTemplateUpdateInPlace)
 		if err := cm.Parse(configMap.Data,
 			// Legacy for backwards compatibility
 			cm.AsString(legacyChannelTemplateSpec, &temp),
 
 			cm.AsString(channelTemplateSpec, &temp),
+			cm.AsString(channelTemplateUpdateStrategy, &strategy),
 		); err != nil {
 			return nil, fmt.Errorf("ConfigMap's could not be parsed: %w", err)
 		}
@@ -70,7 +99,55 @@ func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.Conf
 		if err := json.Unmarshal(j, &config.DefaultChannelTemplate); err != nil {
 			return nil, fmt.Errorf("ConfigMap's value could not be unmarshaled. %w, %s", err, string(j))
 		}
+		if err := validateChannelTemplate(&config.DefaultChannelTemplate); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", channelTemplateSpec, err)
+		}
+
+		switch s := ChannelTemplateUpdateStrategy(strategy); s {
+		case ChannelTemplateUpdateInPlace, ChannelTemplateUpdateRecreate:
+			config.ChannelTemplateUpdateStrategy = s
+		default:
+			return nil, fmt.Errorf("invalid %s %q, must be one of [%s, %s]", channelTemplateUpdateStrategy, strategy,
+				ChannelTemplateUpdateInPlace, ChannelTemplateUpdateRecreate)
+		}
 
 		return config, nil
 	}
 }
+
+// validateChannelTemplate checks that the template describes a channel kind
+// and, when set, a spec object leaving the fields managed by the Broker unset.
+func validateChannelTemplate(template *messagingv1.ChannelTemplateSpec) error {
+	errs := messagingv1.IsValidChannelTemplate(template)
+
+	if spec, err := channelTemplateSpecFields(template); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(string(template.Spec.Raw), "spec", "must be an object"))
+	} else {
+		for _, field := range brokerManagedChannelFields {
+			if _, ok := spec[field]; ok {
+				fe := apis.ErrDisallowedFields(field)
+				fe.Details = "the field is managed by the Broker"
+				errs = errs.Also(fe.ViaField("spec"))
+			}
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+	return nil
+}
+
+// channelTemplateSpecFields returns the fields of the spec of the template,
+// decoded as in unstructured objects so that they can be compared with those
+// of the channel.
+func channelTemplateSpecFields(template *messagingv1.ChannelTemplateSpec) (map[string]interface{}, error) {
+	if template.Spec == nil || len(template.Spec.Raw) == 0 {
+		return nil, nil
+	}
+	var spec map[string]interface{}
+	if err := utiljson.Unmarshal(template.Spec.Raw, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
@@ -31,7 +31,7 @@ import (
 
 func TestOurConfig(t *testing.T) {
 	actual, example := ConfigMapsFromTestFile(t, "config-broker")
-	exampleSpec := runtime.RawExtension{Raw: []byte(`"customValue: foo\n"`)}
+	exampleSpec := runtime.RawExtension{Raw: []byte(`{"customValue":"foo"}`)}
 
 	// Using legacy ConfiMap with to be deprecated element.
 	actualLegacy, exampleLegacy := ConfigMapsFromTestFile(t, "config-broker")
@@ -60,7 +60,9 @@ func TestOurConfig(t *testing.T) {
 					Kind:       "InMemoryChannel",
 				},
 				Spec: &exampleSpec,
-			}},
+			},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateInPlace,
+		},
 		data: example,
 	}, {
 		name: "Example config. Legacy configuration",
@@ -71,7 +73,9 @@ func TestOurConfig(t *testing.T) {
 					Kind:       "InMemoryChannel",
 				},
 				Spec: &exampleSpec,
-			}},
+			},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateInPlace,
+		},
 		data: exampleLegacy,
 	}, {
 		name:    "Empty string for config",
@@ -100,6 +104,7 @@ func TestOurConfig(t *testing.T) {
 					Kind:       "Bar",
 				},
 			},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateInPlace,
 		},
 		data: &corev1.ConfigMap{
 			Data: map[string]string{
@@ -118,12 +123,86 @@ func TestOurConfig(t *testing.T) {
 					Kind:       "Bar",
 				},
 			},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateInPlace,
 		},
 		data: &corev1.ConfigMap{
 			Data: map[string]string{
 				"channelTemplateSpec": `
       apiVersion: Foo/v1
       kind: Bar
+`,
+			},
+		},
+	}, {
+		name: "With recreate update strategy",
+		want: &Config{
+			DefaultChannelTemplate: messagingv1.ChannelTemplateSpec{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "Foo/v1",
+					Kind:       "Bar",
+				},
+				Spec: &runtime.RawExtension{Raw: []byte(`{"partitions":3}`)},
+			},
+			ChannelTemplateUpdateStrategy: ChannelTemplateUpdateRecreate,
+		},
+		data: &corev1.ConfigMap{
+			Data: map[string]string{
+				"channel-template-spec": `
+      apiVersion: Foo/v1
+      kind: Bar
+      spec:
+        partitions: 3
+`,
+				"channel-template-update-strategy": "recreate",
+			},
+		},
+	}, {
+		name:    "Invalid update strategy",
+		wantErr: `invalid channel-template-update-strategy "rolling", must be one of [in-place, recreate]`,
+		data: &corev1.ConfigMap{
+			Data: map[string]string{
+				"channel-template-spec": `
+      apiVersion: Foo/v1
+      kind: Bar
+`,
+				"channel-template-update-strategy": "rolling",
+			},
+		},
+	}, {
+		name:    "Missing kind",
+		wantErr: "invalid channel-template-spec: missing field(s): kind",
+		data: &corev1.ConfigMap{
+			Data: map[string]string{
+				"channel-template-spec": `
+      apiVersion: Foo/v1
+`,
+			},
+		},
+	}, {
+		name:    "Spec is not an object",
+		wantErr: "invalid channel-template-spec: invalid value: \"partitions: 3\\n\": spec\nmust be an object",
+		data: &corev1.ConfigMap{
+			Data: map[string]string{
+				"channel-template-spec": `
+      apiVersion: Foo/v1
+      kind: Bar
+      spec: |
+        partitions: 3
+`,
+			},
+		},
+	}, {
+		name:    "Spec sets fields managed by the Broker",
+		wantErr: "invalid channel-template-spec: must not set the field(s): spec.delivery, spec.subscribers\nthe field is managed by the Broker",
+		data: &corev1.ConfigMap{
+			Data: map[string]string{
+				"channel-template-spec": `
+      apiVersion: Foo/v1
+      kind: Bar
+      spec:
+        delivery:
+          retry: 3
+        subscribers: []
 `,
 			},
 		},
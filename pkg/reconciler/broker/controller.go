@@ -18,10 +18,14 @@ package broker
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/apis"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	"knative.dev/pkg/configmap"
@@ -44,6 +48,7 @@ import (
 	subscriptioninformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
 	brokerreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1/broker"
 	"knative.dev/eventing/pkg/duck"
+	"knative.dev/eventing/pkg/reconciler/broker/canary"
 	"knative.dev/eventing/pkg/reconciler/names"
 )
 
@@ -55,6 +60,10 @@ const (
 	BrokerConditionAddressable    apis.ConditionType = "Addressable"
 )
 
+// canaryCheckInterval is how often the error rates of the canary data plane
+// are checked.
+const canaryCheckInterval = 30 * time.Second
+
 var Tracer tracing.Tracer
 
 // NewController initializes the controller and is called by the generated code
@@ -94,6 +103,7 @@ func NewController(
 	brokerFilter := pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, eventing.MTChannelBrokerClassValue, false /*allowUnset*/)
 
 	r := &Reconciler{
+		kubeClientSet:      kubeclient.Get(ctx),
 		eventingClientSet:  eventingclient.Get(ctx),
 		dynamicClientSet:   dynamicclient.Get(ctx),
 		endpointsLister:    endpointsInformer.Lister(),
@@ -102,16 +112,32 @@ func NewController(
 		configmapLister:    configmapInformer.Lister(),
 		secretLister:       secretInformer.Lister(),
 	}
+	// Route some Brokers to the canary data plane, and every Broker back to
+	// the stable one when the canary error rates are elevated.
+	r.canary = canary.NewRollout(logger, r.kubeClientSet, endpointsInformer.Lister(), func() {
+		if globalResync != nil {
+			globalResync(nil)
+		}
+	})
+
 	impl := brokerreconciler.NewImpl(ctx, r, eventing.MTChannelBrokerClassValue, func(impl *controller.Impl) controller.Options {
 		return controller.Options{
 			ConfigStore:       featureStore,
 			PromoteFilterFunc: brokerFilter,
+			// Only the leader for the config-br-canary ConfigMap checks the
+			// canary error rates.
+			PromoteFunc: r.canary.Promote,
+			DemoteFunc:  r.canary.Demote,
 		}
 	})
 
 	r.channelableTracker = duck.NewListableTrackerFromTracker(ctx, channelable.Get, impl.Tracker)
+	r.configmapTracker = impl.Tracker
 	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
 
+	cmw.Watch(canary.ConfigName, r.canary.UpdateFromConfigMap)
+	go r.canary.Run(ctx, canaryCheckInterval)
+
 	brokerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: brokerFilter,
 		Handler:    controller.HandleAll(impl.Enqueue),
@@ -140,10 +166,36 @@ func NewController(
 			pkgreconciler.NameFilterFunc(names.BrokerIngressName)),
 		Handler: controller.HandleAll(globalResync),
 	})
+	// Resync for the canary filter and ingress.
+	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.ChainFilterFuncs(
+			pkgreconciler.NamespaceFilterFunc(system.Namespace()),
+			func(obj interface{}) bool {
+				object, ok := obj.(metav1.Object)
+				return ok && r.canary.IsCanaryService(object.GetName())
+			}),
+		Handler: controller.HandleAll(globalResync),
+	})
 	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterWithName(ingressServerTLSSecretName),
 		Handler:    controller.HandleAll(globalResync),
 	})
 
+	// Reconcile the Brokers when their config changes, as it holds the
+	// template of their trigger channel.
+	configmapInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			r.configmapTracker.OnChanged,
+			corev1.SchemeGroupVersion.WithKind("ConfigMap"),
+		),
+	))
+
+	// Reconcile the Brokers when the Subscriptions of their Triggers change,
+	// so that the channel they migrated from is deleted once unused.
+	subscriptionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.LabelExistsFilterFunc(eventing.BrokerLabelKey),
+		Handler:    controller.HandleAll(impl.EnqueueLabelOfNamespaceScopedResource("", eventing.BrokerLabelKey)),
+	})
+
 	return impl
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressexposure
+
+import (
+	"context"
+
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+)
+
+// ReconcilerName is the name of the Broker ingress exposure reconciler.
+const ReconcilerName = "BrokerIngressExposure"
+
+// NewController initializes the controller exposing the ingress of the
+// Brokers and is called by the generated code.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	brokerInformer := brokerinformer.Get(ctx)
+
+	r := &Reconciler{
+		eventingClientSet: eventingclient.Get(ctx),
+		dynamicClientSet:  dynamicclient.Get(ctx),
+		brokerLister:      brokerInformer.Lister(),
+		namespace:         system.Namespace(),
+	}
+
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		Logger: logger, WorkQueueName: ReconcilerName,
+	})
+
+	// Expose or unexpose the Brokers when the feature is toggled.
+	r.featureStore = feature.NewStore(logger.Named("feature-config-store"), func(string, interface{}) {
+		impl.GlobalResync(brokerInformer.Informer())
+	})
+	r.featureStore.WatchConfigs(cmw)
+
+	// The Brokers of the other classes are filtered by the reconciler, so
+	// that the deleted Brokers release the ReferenceGrant of their namespace.
+	brokerInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	return impl
+}
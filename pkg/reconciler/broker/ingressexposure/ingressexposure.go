@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ingressexposure exposes the ingress of the MTChannelBasedBrokers
+// outside of the cluster with the HTTPRoutes of the Kubernetes Gateway API,
+// when the broker-ingress-exposure feature is enabled.
+package ingressexposure
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	clientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+)
+
+const (
+	// The reasons of the IngressExposed condition of the Brokers whose
+	// ingress isn't exposed.
+	InvalidExposureReason      = "InvalidExposure"
+	ReferenceGrantFailedReason = "ReferenceGrantFailed"
+	HTTPRouteFailedReason      = "HTTPRouteFailed"
+	HTTPRouteNotAcceptedReason = "HTTPRouteNotAccepted"
+
+	// referenceGrantLabelKey is the label of the ReferenceGrants set to the
+	// namespace of the HTTPRoutes they allow.
+	referenceGrantLabelKey = "eventing.knative.dev/broker-ingress-exposure"
+)
+
+// Reconciler keeps an HTTPRoute attached to the Gateway set on the Brokers
+// asking for the exposure of their ingress, along with the ReferenceGrant
+// allowing the HTTPRoutes of their namespace to send the requests to the
+// broker-ingress Service, and reports the exposure in the IngressExposed
+// condition of the Brokers.
+type Reconciler struct {
+	eventingClientSet clientset.Interface
+	dynamicClientSet  dynamic.Interface
+	brokerLister      eventinglisters.BrokerLister
+	featureStore      *feature.Store
+	// namespace is the namespace of the broker-ingress Service.
+	namespace string
+}
+
+// Reconcile implements controller.Reconciler.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Errorw("Invalid resource key", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	b, err := r.brokerLister.Brokers(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		// The HTTPRoute is garbage collected with the Broker, the
+		// ReferenceGrant may not be needed anymore.
+		return r.reconcileReferenceGrant(ctx, namespace)
+	} else if err != nil {
+		return err
+	}
+	if b.GetAnnotations()[eventing.BrokerClassKey] != eventing.MTChannelBrokerClassValue {
+		return nil
+	}
+
+	exposure, ok, err := b.IngressExposure()
+	exposed := b.Status.GetCondition(eventingv1.BrokerConditionIngressExposed) != nil
+	if !ok || !b.DeletionTimestamp.IsZero() || !r.isEnabled(ctx) {
+		if !exposed {
+			return nil
+		}
+		return r.unexpose(ctx, b)
+	}
+
+	desired := b.DeepCopy()
+	if err != nil {
+		desired.Status.MarkIngressNotExposed(InvalidExposureReason, "%v", err)
+		return r.updateStatus(ctx, b, desired)
+	}
+	if err := r.reconcileReferenceGrant(ctx, namespace); err != nil {
+		desired.Status.MarkIngressNotExposed(ReferenceGrantFailedReason, "%v", err)
+		return r.failed(ctx, b, desired, err)
+	}
+	route, err := r.reconcileHTTPRoute(ctx, makeHTTPRoute(b, exposure, r.namespace))
+	if err != nil {
+		desired.Status.MarkIngressNotExposed(HTTPRouteFailedReason, "%v", err)
+		return r.failed(ctx, b, desired, err)
+	}
+	if message, rejected := notAccepted(route); rejected {
+		desired.Status.MarkIngressNotExposed(HTTPRouteNotAcceptedReason, "The HTTPRoute %s wasn't accepted: %s", route.GetName(), message)
+	} else {
+		desired.Status.MarkIngressExposed(exposure.URL())
+	}
+	return r.updateStatus(ctx, b, desired)
+}
+
+// unexpose deletes the HTTPRoute of the Broker, and the ReferenceGrant of its
+// namespace when no other Broker needs it, and clears its IngressExposed
+// condition.
+func (r *Reconciler) unexpose(ctx context.Context, b *eventingv1.Broker) error {
+	err := r.dynamicClientSet.Resource(httpRouteGVR).Namespace(b.Namespace).Delete(ctx, httpRouteName(b), metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the HTTPRoute %s: %w", httpRouteName(b), err)
+	}
+	desired := b.DeepCopy()
+	desired.Status.ClearIngressExposed()
+	if err := r.updateStatus(ctx, b, desired); err != nil {
+		return err
+	}
+	return r.reconcileReferenceGrant(ctx, b.Namespace)
+}
+
+// reconcileHTTPRoute creates or updates the HTTPRoute, returning it as stored.
+func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	routes := r.dynamicClientSet.Resource(httpRouteGVR).Namespace(desired.GetNamespace())
+	route, err := routes.Get(ctx, desired.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		route, err = routes.Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the HTTPRoute %s: %w", desired.GetName(), err)
+		}
+		return route, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get the HTTPRoute %s: %w", desired.GetName(), err)
+	}
+
+	if equality.Semantic.DeepEqual(route.Object["spec"], desired.Object["spec"]) &&
+		equality.Semantic.DeepEqual(route.GetLabels(), desired.GetLabels()) {
+		return route, nil
+	}
+	route = route.DeepCopy()
+	route.Object["spec"] = desired.Object["spec"]
+	route.SetLabels(desired.GetLabels())
+	route, err = routes.Update(ctx, route, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update the HTTPRoute %s: %w", desired.GetName(), err)
+	}
+	return route, nil
+}
+
+// reconcileReferenceGrant creates the ReferenceGrant of the namespace while
+// one of its Brokers is exposed, and deletes it otherwise.
+func (r *Reconciler) reconcileReferenceGrant(ctx context.Context, namespace string) error {
+	needed, err := r.needsReferenceGrant(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	grants := r.dynamicClientSet.Resource(referenceGrantGVR).Namespace(r.namespace)
+	name := referenceGrantName(namespace)
+	_, err = grants.Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err != nil && !apierrors.IsNotFound(err):
+		return fmt.Errorf("failed to get the ReferenceGrant %s: %w", name, err)
+	case err == nil && !needed:
+		if err := grants.Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete the ReferenceGrant %s: %w", name, err)
+		}
+	case err != nil && needed:
+		if _, err := grants.Create(ctx, makeReferenceGrant(namespace, r.namespace), metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create the ReferenceGrant %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// needsReferenceGrant returns true if one of the Brokers of the namespace is
+// exposed.
+func (r *Reconciler) needsReferenceGrant(ctx context.Context, namespace string) (bool, error) {
+	if !r.isEnabled(ctx) {
+		return false, nil
+	}
+	brokers, err := r.brokerLister.Brokers(namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, b := range brokers {
+		if b.GetAnnotations()[eventing.BrokerClassKey] != eventing.MTChannelBrokerClassValue || !b.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if _, ok, err := b.IngressExposure(); ok && err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, b, desired *eventingv1.Broker) error {
+	if equality.Semantic.DeepEqual(b.Status, desired.Status) {
+		return nil
+	}
+	_, err := r.eventingClientSet.EventingV1().Brokers(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+// failed reports the failure to expose the Broker in its status, and returns
+// err so that the Broker is reconciled again.
+func (r *Reconciler) failed(ctx context.Context, b, desired *eventingv1.Broker, err error) error {
+	if statusErr := r.updateStatus(ctx, b, desired); statusErr != nil {
+		logging.FromContext(ctx).Warnw("Failed to update the status of the Broker", zap.Error(statusErr))
+	}
+	return err
+}
+
+// isEnabled returns true when the broker-ingress-exposure feature is enabled
+// in the features of ctx or, when set, of the store.
+func (r *Reconciler) isEnabled(ctx context.Context) bool {
+	if r.featureStore != nil {
+		ctx = r.featureStore.ToContext(ctx)
+	}
+	return feature.FromContext(ctx).IsEnabled(feature.BrokerIngressExposure)
+}
+
+// notAccepted returns the message of the Accepted condition of the HTTPRoute
+// when one of its Gateways rejected it.
+func notAccepted(route *unstructured.Unstructured) (string, bool) {
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, p := range parents {
+		parent, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions, _, _ := unstructured.NestedSlice(parent, "conditions")
+		for _, c := range conditions {
+			cond, ok := c.(map[string]interface{})
+			if !ok || cond["type"] != "Accepted" || cond["status"] != string(corev1.ConditionFalse) {
+				continue
+			}
+			message, _ := cond["message"].(string)
+			return message, true
+		}
+	}
+	return "", false
+}
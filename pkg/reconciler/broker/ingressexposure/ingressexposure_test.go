@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressexposure
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	fakeeventingclientset "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+const (
+	testNS           = "test-namespace"
+	brokerName       = "test-broker"
+	systemNamespace  = "knative-eventing"
+	gatewayNamespace = "gateways"
+)
+
+var exposureEnabled = feature.ToContext(context.Background(), feature.Flags{
+	feature.BrokerIngressExposure: feature.Enabled,
+})
+
+func TestReconcile(t *testing.T) {
+	exposed := newBroker(brokerName, WithBrokerAnnotation(eventing.IngressTLSAnnotationKey, "true"))
+	exposedRoute := makeHTTPRoute(exposed, mustExposure(t, exposed), systemNamespace)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		key     string
+		brokers []*eventingv1.Broker
+		objects []runtime.Object
+
+		wantRoute     *unstructured.Unstructured
+		wantGrant     bool
+		wantCondition *apis.Condition
+	}{{
+		name:    "disabled",
+		ctx:     context.Background(),
+		brokers: []*eventingv1.Broker{exposed},
+	}, {
+		name: "other class",
+		ctx:  exposureEnabled,
+		brokers: []*eventingv1.Broker{newBroker(brokerName,
+			WithBrokerClass("OtherBroker"))},
+	}, {
+		name:      "exposed",
+		ctx:       exposureEnabled,
+		brokers:   []*eventingv1.Broker{exposed},
+		wantRoute: exposedRoute,
+		wantGrant: true,
+		wantCondition: &apis.Condition{
+			Type:     eventingv1.BrokerConditionIngressExposed,
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Message:  "The Broker is exposed at https://events.example.com",
+		},
+	}, {
+		name:    "route updated",
+		ctx:     exposureEnabled,
+		brokers: []*eventingv1.Broker{exposed},
+		objects: []runtime.Object{
+			withHostname(exposedRoute, "old.example.com"),
+			makeReferenceGrant(testNS, systemNamespace),
+		},
+		wantRoute: exposedRoute,
+		wantGrant: true,
+		wantCondition: &apis.Condition{
+			Type:     eventingv1.BrokerConditionIngressExposed,
+			Status:   corev1.ConditionTrue,
+			Severity: apis.ConditionSeverityInfo,
+			Message:  "The Broker is exposed at https://events.example.com",
+		},
+	}, {
+		name:    "route not accepted",
+		ctx:     exposureEnabled,
+		brokers: []*eventingv1.Broker{exposed},
+		objects: []runtime.Object{
+			withNotAccepted(exposedRoute, "NotAllowedByListeners"),
+		},
+		wantRoute: withNotAccepted(exposedRoute, "NotAllowedByListeners"),
+		wantGrant: true,
+		wantCondition: &apis.Condition{
+			Type:     eventingv1.BrokerConditionIngressExposed,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   HTTPRouteNotAcceptedReason,
+			Message:  "The HTTPRoute test-broker-ingress wasn't accepted: NotAllowedByListeners",
+		},
+	}, {
+		name: "invalid exposure",
+		ctx:  exposureEnabled,
+		brokers: []*eventingv1.Broker{NewBroker(brokerName, testNS,
+			WithBrokerClass(eventing.MTChannelBrokerClassValue),
+			WithBrokerAnnotation(eventing.IngressHostnameAnnotationKey, "events.example.com"))},
+		wantCondition: &apis.Condition{
+			Type:     eventingv1.BrokerConditionIngressExposed,
+			Status:   corev1.ConditionFalse,
+			Severity: apis.ConditionSeverityWarning,
+			Reason:   InvalidExposureReason,
+			Message:  "the eventing.knative.dev/ingress-gateway annotation is required",
+		},
+	}, {
+		name: "unexposed",
+		ctx:  exposureEnabled,
+		brokers: []*eventingv1.Broker{NewBroker(brokerName, testNS,
+			WithBrokerClass(eventing.MTChannelBrokerClassValue),
+			withIngressExposed)},
+		objects: []runtime.Object{
+			exposedRoute,
+			makeReferenceGrant(testNS, systemNamespace),
+		},
+	}, {
+		name: "unexposed when disabled",
+		ctx:  context.Background(),
+		brokers: []*eventingv1.Broker{newBroker(brokerName,
+			WithBrokerAnnotation(eventing.IngressTLSAnnotationKey, "true"),
+			withIngressExposed)},
+		objects: []runtime.Object{
+			exposedRoute,
+			makeReferenceGrant(testNS, systemNamespace),
+		},
+	}, {
+		name: "unexposed, grant kept for the other Brokers",
+		ctx:  exposureEnabled,
+		brokers: []*eventingv1.Broker{
+			NewBroker(brokerName, testNS,
+				WithBrokerClass(eventing.MTChannelBrokerClassValue),
+				withIngressExposed),
+			newBroker("other-broker"),
+		},
+		objects: []runtime.Object{
+			exposedRoute,
+			makeReferenceGrant(testNS, systemNamespace),
+		},
+		wantGrant: true,
+	}, {
+		name: "deleted",
+		ctx:  exposureEnabled,
+		objects: []runtime.Object{
+			makeReferenceGrant(testNS, systemNamespace),
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := logging.WithLogger(tc.ctx, logtesting.TestLogger(t))
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			brokers := make([]runtime.Object, 0, len(tc.brokers))
+			for _, b := range tc.brokers {
+				if err := indexer.Add(b); err != nil {
+					t.Fatal(err)
+				}
+				brokers = append(brokers, b)
+			}
+			eventingClient := fakeeventingclientset.NewSimpleClientset(brokers...)
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				httpRouteGVR:      "HTTPRouteList",
+				referenceGrantGVR: "ReferenceGrantList",
+			}, tc.objects...)
+
+			r := &Reconciler{
+				eventingClientSet: eventingClient,
+				dynamicClientSet:  dynamicClient,
+				brokerLister:      eventinglisters.NewBrokerLister(indexer),
+				namespace:         systemNamespace,
+			}
+
+			key := tc.key
+			if key == "" {
+				key = testNS + "/" + brokerName
+			}
+			if err := r.Reconcile(ctx, key); err != nil {
+				t.Fatal("Reconcile() =", err)
+			}
+
+			route, err := dynamicClient.Resource(httpRouteGVR).Namespace(testNS).Get(ctx, brokerName+"-ingress", metav1.GetOptions{})
+			if tc.wantRoute == nil {
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("Expected no HTTPRoute, got %v, %v", route, err)
+				}
+			} else if err != nil {
+				t.Error("Failed to get the HTTPRoute:", err)
+			} else if diff := cmp.Diff(tc.wantRoute.Object, route.Object); diff != "" {
+				t.Error("Unexpected HTTPRoute (-want, +got):", diff)
+			}
+
+			_, err = dynamicClient.Resource(referenceGrantGVR).Namespace(systemNamespace).Get(ctx, referenceGrantName(testNS), metav1.GetOptions{})
+			if got := err == nil; got != tc.wantGrant {
+				t.Errorf("Expected the ReferenceGrant to exist: %t, got %t (%v)", tc.wantGrant, got, err)
+			}
+
+			if len(tc.brokers) == 0 {
+				return
+			}
+			b, err := eventingClient.EventingV1().Brokers(testNS).Get(ctx, brokerName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := b.Status.GetCondition(eventingv1.BrokerConditionIngressExposed)
+			if diff := cmp.Diff(tc.wantCondition, got, cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Error("Unexpected IngressExposed condition (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func newBroker(name string, o ...BrokerOption) *eventingv1.Broker {
+	return NewBroker(name, testNS, append([]BrokerOption{
+		WithBrokerClass(eventing.MTChannelBrokerClassValue),
+		WithBrokerAnnotation(eventing.IngressHostnameAnnotationKey, "events.example.com"),
+		WithBrokerAnnotation(eventing.IngressGatewayAnnotationKey, gatewayNamespace+"/public"),
+		WithBrokerAnnotation(eventing.IngressGatewayListenerAnnotationKey, "https"),
+	}, o...)...)
+}
+
+func withIngressExposed(b *eventingv1.Broker) {
+	b.Status.MarkIngressExposed(apis.HTTPS("events.example.com"))
+}
+
+func mustExposure(t *testing.T, b *eventingv1.Broker) *eventingv1.IngressExposure {
+	e, _, err := b.IngressExposure()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func withHostname(route *unstructured.Unstructured, hostname string) *unstructured.Unstructured {
+	route = route.DeepCopy()
+	if err := unstructured.SetNestedStringSlice(route.Object, []string{hostname}, "spec", "hostnames"); err != nil {
+		panic(err)
+	}
+	return route
+}
+
+func withNotAccepted(route *unstructured.Unstructured, message string) *unstructured.Unstructured {
+	route = route.DeepCopy()
+	route.Object["status"] = map[string]interface{}{
+		"parents": []interface{}{map[string]interface{}{
+			"controllerName": "example.com/gateway-controller",
+			"conditions": []interface{}{map[string]interface{}{
+				"type":    "Accepted",
+				"status":  "False",
+				"reason":  "NotAllowedByListeners",
+				"message": message,
+			}},
+		}},
+	}
+	return route
+}
+
+func TestMakeHTTPRoute(t *testing.T) {
+	b := newBroker(brokerName)
+	route := makeHTTPRoute(b, mustExposure(t, b), systemNamespace)
+
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	want := []interface{}{map[string]interface{}{
+		"group":       "gateway.networking.k8s.io",
+		"kind":        "Gateway",
+		"namespace":   gatewayNamespace,
+		"name":        "public",
+		"sectionName": "https",
+	}}
+	if diff := cmp.Diff(want, parentRefs); diff != "" {
+		t.Error("Unexpected parentRefs (-want, +got):", diff)
+	}
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	prefix, _, _ := unstructured.NestedString(rules[0].(map[string]interface{})["filters"].([]interface{})[0].(map[string]interface{}),
+		"urlRewrite", "path", "replacePrefixMatch")
+	if want := "/" + testNS + "/" + brokerName; prefix != want {
+		t.Errorf("Expected the path to be rewritten to %q, got %q", want, prefix)
+	}
+	if owners := route.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != brokerName || owners[0].Kind != "Broker" {
+		t.Errorf("Expected the HTTPRoute to be owned by the Broker, got %v", owners)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressexposure
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/reconciler/names"
+)
+
+const (
+	// gatewayGroup is the API group of the Kubernetes Gateway API.
+	gatewayGroup = "gateway.networking.k8s.io"
+
+	// ingressPort is the port of the HTTP endpoint of the broker-ingress
+	// Service.
+	ingressPort = 80
+)
+
+var (
+	httpRouteGVR      = schema.GroupVersionResource{Group: gatewayGroup, Version: "v1", Resource: "httproutes"}
+	referenceGrantGVR = schema.GroupVersionResource{Group: gatewayGroup, Version: "v1beta1", Resource: "referencegrants"}
+)
+
+// httpRouteName returns the name of the HTTPRoute exposing the Broker.
+func httpRouteName(b *eventingv1.Broker) string {
+	return kmeta.ChildName(b.Name, "-ingress")
+}
+
+// referenceGrantName returns the name of the ReferenceGrant allowing the
+// HTTPRoutes of the namespace to send the requests to the broker-ingress
+// Service.
+func referenceGrantName(namespace string) string {
+	return kmeta.ChildName("broker-ingress-", namespace)
+}
+
+// makeHTTPRoute returns the HTTPRoute sending the requests for the hostname
+// of the Broker to the broker-ingress Service, in the ingress namespace,
+// rewriting their path to the path of the Broker. All the fields defaulted by
+// the API server are set, so that the spec of the HTTPRoute can be compared.
+func makeHTTPRoute(b *eventingv1.Broker, e *eventingv1.IngressExposure, ingressNamespace string) *unstructured.Unstructured {
+	parentRef := map[string]interface{}{
+		"group":     gatewayGroup,
+		"kind":      "Gateway",
+		"namespace": e.Gateway.Namespace,
+		"name":      e.Gateway.Name,
+	}
+	if e.Listener != "" {
+		parentRef["sectionName"] = e.Listener
+	}
+
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": httpRouteGVR.GroupVersion().String(),
+		"kind":       "HTTPRoute",
+		"metadata": map[string]interface{}{
+			"name":      httpRouteName(b),
+			"namespace": b.Namespace,
+			"labels": map[string]interface{}{
+				eventing.BrokerLabelKey: b.Name,
+			},
+			"ownerReferences": []interface{}{ownerReference(b)},
+		},
+		"spec": map[string]interface{}{
+			"parentRefs": []interface{}{parentRef},
+			"hostnames":  []interface{}{e.Hostname},
+			"rules": []interface{}{map[string]interface{}{
+				"matches": []interface{}{map[string]interface{}{
+					"path": map[string]interface{}{
+						"type":  "PathPrefix",
+						"value": "/",
+					},
+				}},
+				"filters": []interface{}{map[string]interface{}{
+					"type": "URLRewrite",
+					"urlRewrite": map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":               "ReplacePrefixMatch",
+							"replacePrefixMatch": fmt.Sprintf("/%s/%s", b.Namespace, b.Name),
+						},
+					},
+				}},
+				"backendRefs": []interface{}{map[string]interface{}{
+					"group":     "",
+					"kind":      "Service",
+					"namespace": ingressNamespace,
+					"name":      names.BrokerIngressName,
+					"port":      int64(ingressPort),
+					"weight":    int64(1),
+				}},
+			}},
+		},
+	}}
+}
+
+// makeReferenceGrant returns the ReferenceGrant allowing the HTTPRoutes of
+// the namespace to send the requests to the broker-ingress Service, in the
+// ingress namespace.
+func makeReferenceGrant(namespace, ingressNamespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": referenceGrantGVR.GroupVersion().String(),
+		"kind":       "ReferenceGrant",
+		"metadata": map[string]interface{}{
+			"name":      referenceGrantName(namespace),
+			"namespace": ingressNamespace,
+			"labels": map[string]interface{}{
+				referenceGrantLabelKey: namespace,
+			},
+		},
+		"spec": map[string]interface{}{
+			"from": []interface{}{map[string]interface{}{
+				"group":     gatewayGroup,
+				"kind":      "HTTPRoute",
+				"namespace": namespace,
+			}},
+			"to": []interface{}{map[string]interface{}{
+				"group": "",
+				"kind":  "Service",
+				"name":  names.BrokerIngressName,
+			}},
+		},
+	}}
+}
+
+func ownerReference(b *eventingv1.Broker) map[string]interface{} {
+	ref := metav1.NewControllerRef(b, eventingv1.SchemeGroupVersion.WithKind("Broker"))
+	return map[string]interface{}{
+		"apiVersion":         ref.APIVersion,
+		"kind":               ref.Kind,
+		"name":               ref.Name,
+		"uid":                string(ref.UID),
+		"controller":         true,
+		"blockOwnerDeletion": true,
+	}
+}
@@ -89,6 +89,7 @@ func NewController(
 		}
 	})
 	r.impl = impl
+	r.dlsProber = newDeadLetterSinkProber(impl.EnqueueKeyAfter)
 
 	r.sourceTracker = duck.NewListableTrackerFromTracker(ctx, source.Get, impl.Tracker)
 	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
@@ -98,6 +99,19 @@ func NewController(
 		Handler:    controller.HandleAll(impl.Enqueue),
 	})
 
+	// Stop probing the dead letter sinks of the deleted Triggers
+	triggerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if t, ok := obj.(*eventing.Trigger); ok {
+				r.dlsProber.Forget(t.UID)
+			} else if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				if t, ok := tombstone.Obj.(*eventing.Trigger); ok {
+					r.dlsProber.Forget(t.UID)
+				}
+			}
+		},
+	})
+
 	// Filter Brokers and enqueue associated Triggers
 	brokerFilter := pkgreconciler.AnnotationFilterFunc(brokerreconciler.ClassAnnotationKey, apiseventing.MTChannelBrokerClassValue, false /*allowUnset*/)
 	brokerInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
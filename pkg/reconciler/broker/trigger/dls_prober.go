@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mttrigger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/eventingtls"
+)
+
+const (
+	// deadLetterSinkProbeInterval is the time between two probes of the dead
+	// letter sink of a Trigger.
+	deadLetterSinkProbeInterval = 5 * time.Minute
+
+	// deadLetterSinkProbeTimeout bounds a probe of a dead letter sink.
+	deadLetterSinkProbeTimeout = 5 * time.Second
+)
+
+// deadLetterSinkProber probes the dead letter sinks of the Triggers in the
+// background, so that a dead letter sink that can't be reached is noticed
+// before events are sent to it. The Triggers are enqueued each time the probe
+// of their dead letter sink completes, and once it is due again.
+type deadLetterSinkProber struct {
+	interval time.Duration
+
+	// probe sends a probe to the given dead letter sink.
+	probe func(ctx context.Context, addr duckv1.Addressable) error
+	// enqueueAfter enqueues the Trigger with the given key after a delay.
+	enqueueAfter func(key types.NamespacedName, delay time.Duration)
+	now          func() time.Time
+
+	mu      sync.Mutex
+	results map[types.UID]*deadLetterSinkProbe
+}
+
+type deadLetterSinkProbe struct {
+	url     string
+	probing bool
+	done    bool
+	time    time.Time
+	err     error
+}
+
+func newDeadLetterSinkProber(enqueueAfter func(key types.NamespacedName, delay time.Duration)) *deadLetterSinkProber {
+	return &deadLetterSinkProber{
+		interval:     deadLetterSinkProbeInterval,
+		probe:        probeDeadLetterSink,
+		enqueueAfter: enqueueAfter,
+		now:          time.Now,
+		results:      make(map[types.UID]*deadLetterSinkProbe),
+	}
+}
+
+// Result returns whether the dead letter sink of the Trigger was probed, and
+// the error of its last probe. A probe is started when the dead letter sink
+// was never probed, when it changed, or when the last probe is older than the
+// probe interval.
+func (p *deadLetterSinkProber) Result(ctx context.Context, key types.NamespacedName, uid types.UID, addr duckv1.Addressable) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	url := addr.URL.String()
+	r, ok := p.results[uid]
+	if !ok || r.url != url {
+		r = &deadLetterSinkProbe{url: url}
+		p.results[uid] = r
+	}
+	if !r.probing && (!r.done || p.now().Sub(r.time) >= p.interval) {
+		r.probing = true
+		go p.run(context.WithoutCancel(ctx), key, uid, r, addr)
+	}
+	return r.done, r.err
+}
+
+func (p *deadLetterSinkProber) run(ctx context.Context, key types.NamespacedName, uid types.UID, r *deadLetterSinkProbe, addr duckv1.Addressable) {
+	err := p.probe(ctx, addr)
+
+	p.mu.Lock()
+	r.probing = false
+	r.done = true
+	r.time = p.now()
+	r.err = err
+	// Don't enqueue the Triggers forgotten or whose dead letter sink changed
+	// in the meantime.
+	current := p.results[uid] == r
+	p.mu.Unlock()
+
+	if current {
+		p.enqueueAfter(key, 0)
+		p.enqueueAfter(key, p.interval)
+	}
+}
+
+// Forget forgets the dead letter sink of the Trigger, when it no longer has
+// one or is deleted.
+func (p *deadLetterSinkProber) Forget(uid types.UID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.results, uid)
+}
+
+// probeDeadLetterSink sends an OPTIONS request to the dead letter sink, as in
+// the abuse protection handshake of the CloudEvents HTTP webhook spec. Any
+// response but a server error means the dead letter sink is reachable, as
+// most sinks only accept POST requests carrying events.
+func probeDeadLetterSink(ctx context.Context, addr duckv1.Addressable) error {
+	tlsConfig, err := eventingtls.GetTLSClientConfig(eventingtls.ClientConfig{CACerts: addr.CACerts})
+	if err != nil {
+		return fmt.Errorf("failed to load the CA certs of the dead letter sink: %w", err)
+	}
+	client := &http.Client{
+		Timeout:   deadLetterSinkProbeTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodOptions, addr.URL.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("the dead letter sink answered with status code %d", resp.StatusCode)
+	}
+	return nil
+}
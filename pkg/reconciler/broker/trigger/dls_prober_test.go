@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mttrigger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestProbeDeadLetterSink(t *testing.T) {
+	tests := map[string]struct {
+		statusCode int
+		wantErr    bool
+	}{
+		"ok":                 {statusCode: http.StatusOK},
+		"method not allowed": {statusCode: http.StatusMethodNotAllowed},
+		"unauthorized":       {statusCode: http.StatusUnauthorized},
+		"unavailable":        {statusCode: http.StatusServiceUnavailable, wantErr: true},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			var method string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method = r.Method
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			err := probeDeadLetterSink(context.Background(), duckv1.Addressable{URL: apis.HTTP(server.Listener.Addr().String())})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("wantErr %t, got %v", tc.wantErr, err)
+			}
+			if method != http.MethodOptions {
+				t.Errorf("Expected an OPTIONS request, got %q", method)
+			}
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		addr := server.Listener.Addr().String()
+		server.Close()
+
+		if err := probeDeadLetterSink(context.Background(), duckv1.Addressable{URL: apis.HTTP(addr)}); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
+
+func TestDeadLetterSinkProber(t *testing.T) {
+	key := types.NamespacedName{Namespace: "ns", Name: "trigger"}
+	uid := types.UID("uid")
+	dls := duckv1.Addressable{URL: apis.HTTP("dls.ns.svc.cluster.local")}
+
+	probeErr := errors.New("connection refused")
+	probes := make(chan struct{}, 10)
+	enqueued := make(chan time.Duration, 10)
+	now := time.Now()
+
+	p := newDeadLetterSinkProber(func(k types.NamespacedName, delay time.Duration) {
+		if k != key {
+			t.Errorf("Enqueued %v, want %v", k, key)
+		}
+		enqueued <- delay
+	})
+	p.probe = func(context.Context, duckv1.Addressable) error {
+		probes <- struct{}{}
+		return probeErr
+	}
+	p.now = func() time.Time { return now }
+
+	waitProbe := func() {
+		t.Helper()
+		select {
+		case <-probes:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for a probe")
+		}
+		for _, want := range []time.Duration{0, p.interval} {
+			select {
+			case got := <-enqueued:
+				if got != want {
+					t.Errorf("Enqueued after %v, want %v", got, want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("Timed out waiting for the Trigger to be enqueued")
+			}
+		}
+	}
+
+	if done, _ := p.Result(context.Background(), key, uid, dls); done {
+		t.Error("Expected no result before the first probe")
+	}
+	waitProbe()
+
+	done, err := p.Result(context.Background(), key, uid, dls)
+	if !done || !errors.Is(err, probeErr) {
+		t.Errorf("Result() = %t, %v, want true, %v", done, err, probeErr)
+	}
+	select {
+	case <-probes:
+		t.Error("Unexpected probe before the probe interval")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	now = now.Add(p.interval)
+	probeErr = nil
+	done, err = p.Result(context.Background(), key, uid, dls)
+	if !done || err == nil {
+		t.Errorf("Result() = %t, %v, want the previous result while probing again", done, err)
+	}
+	waitProbe()
+	if done, err := p.Result(context.Background(), key, uid, dls); !done || err != nil {
+		t.Errorf("Result() = %t, %v, want true, <nil>", done, err)
+	}
+
+	p.Forget(uid)
+	if done, _ := p.Result(context.Background(), key, uid, dls); done {
+		t.Error("Expected no result once forgotten")
+	}
+	waitProbe()
+}
@@ -20,12 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	corev1listers "k8s.io/client-go/listers/core/v1"
@@ -53,6 +55,7 @@ import (
 	"knative.dev/eventing/pkg/duck"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/reconciler/broker/resources"
+	"knative.dev/eventing/pkg/reconciler/names"
 	"knative.dev/eventing/pkg/reconciler/sugar/trigger/path"
 )
 
@@ -86,6 +89,10 @@ type Reconciler struct {
 	// Dynamic tracker to track AddressableTypes. In particular, it tracks Trigger subscribers.
 	uriResolver *resolver.URIResolver
 	impl        *controller.Impl
+
+	// dlsProber probes the dead letter sinks of the Triggers when the
+	// feature.TriggerDeadLetterSinkHealth feature is enabled.
+	dlsProber *deadLetterSinkProber
 }
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) pkgreconciler.Event {
@@ -130,31 +137,26 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) p
 		t.Status.MarkBrokerFailed("MissingBrokerChannel", "Failed to get broker %q annotations: %s", t.Spec.Broker, err)
 		return fmt.Errorf("failed to find Broker's Trigger channel: %s", err)
 	}
-	if t.Spec.Subscriber.Ref != nil && t.Spec.Subscriber.Ref.Namespace == "" {
-		// To call URIFromDestinationV1(ctx context.Context, dest v1.Destination, parent interface{}), dest.Ref must have a Namespace
-		// If Subscriber.Ref.Namespace is nil, We will use the Namespace of Trigger as the Namespace of dest.Ref
-		t.Spec.Subscriber.Ref.Namespace = t.GetNamespace()
-	}
-
-	subscriberAddr, err := r.uriResolver.AddressableFromDestinationV1(ctx, t.Spec.Subscriber, b)
-	if err != nil {
-		logging.FromContext(ctx).Errorw("Unable to get the Subscriber's URI", zap.Error(err))
-		t.Status.MarkSubscriberResolvedFailed("Unable to get the Subscriber's URI", "%v", err)
-		t.Status.SubscriberURI = nil
-		t.Status.SubscriberCACerts = nil
-		t.Status.SubscriberAudience = nil
-		return err
+	if len(t.Spec.Subscribers) > 0 {
+		if err := r.resolveSubscribers(ctx, b, t); err != nil {
+			return err
+		}
+	} else {
+		t.Status.Subscribers = nil
+		if err := r.resolveSubscriber(ctx, b, t); err != nil {
+			return err
+		}
 	}
-	t.Status.SubscriberURI = subscriberAddr.URL
-	t.Status.SubscriberCACerts = subscriberAddr.CACerts
-	t.Status.SubscriberAudience = subscriberAddr.Audience
 	t.Status.MarkSubscriberResolvedSucceeded()
 
+	r.resolveMirror(ctx, b, t)
+
 	if err := r.resolveDeadLetterSink(ctx, b, t); err != nil {
 		return err
 	}
 
 	featureFlags := feature.FromContext(ctx)
+	r.probeDeadLetterSink(ctx, featureFlags, t)
 	if err = auth.SetupOIDCServiceAccount(ctx, featureFlags, r.serviceAccountLister, r.kubeclient, eventingv1.SchemeGroupVersion.WithKind("Trigger"), t.ObjectMeta, &t.Status, func(as *duckv1.AuthStatus) {
 		t.Status.Auth = as
 	}); err != nil {
@@ -173,9 +175,94 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) p
 		return err
 	}
 
+	if err := r.checkDependencies(ctx, t); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Reconciler) resolveSubscriber(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) error {
+	if t.Spec.Subscriber.Ref != nil && t.Spec.Subscriber.Ref.Namespace == "" {
+		// To call URIFromDestinationV1(ctx context.Context, dest v1.Destination, parent interface{}), dest.Ref must have a Namespace
+		// If Subscriber.Ref.Namespace is nil, We will use the Namespace of Trigger as the Namespace of dest.Ref
+		t.Spec.Subscriber.Ref.Namespace = t.GetNamespace()
+	}
+
+	subscriberAddr, err := r.uriResolver.AddressableFromDestinationV1(ctx, t.Spec.Subscriber, b)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Unable to get the Subscriber's URI", zap.Error(err))
+		t.Status.MarkSubscriberResolvedFailed("Unable to get the Subscriber's URI", "%v", err)
+		t.Status.SubscriberURI = nil
+		t.Status.SubscriberCACerts = nil
+		t.Status.SubscriberAudience = nil
+		return err
+	}
+	t.Status.SubscriberURI = subscriberAddr.URL
+	t.Status.SubscriberCACerts = subscriberAddr.CACerts
+	t.Status.SubscriberAudience = subscriberAddr.Audience
+	return nil
+}
+
+// resolveSubscribers resolves the subscribers of a Trigger splitting its
+// events between several subscribers. The subscriber fields of the status are
+// left empty, as no single subscriber receives all the events.
+func (r *Reconciler) resolveSubscribers(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) error {
+	t.Status.SubscriberURI = nil
+	t.Status.SubscriberCACerts = nil
+	t.Status.SubscriberAudience = nil
+
+	subscribers := make([]eventingv1.TriggerSubscriberStatus, 0, len(t.Spec.Subscribers))
+	for _, sub := range t.Spec.Subscribers {
+		dest := *sub.Destination.DeepCopy()
+		if dest.Ref != nil && dest.Ref.Namespace == "" {
+			dest.Ref.Namespace = t.GetNamespace()
+		}
+
+		addr, err := r.uriResolver.AddressableFromDestinationV1(ctx, dest, b)
+		if err != nil {
+			logging.FromContext(ctx).Errorw("Unable to get the Subscriber's URI", zap.String("subscriber", sub.Name), zap.Error(err))
+			t.Status.MarkSubscriberResolvedFailed("Unable to get the Subscriber's URI", "subscriber %q: %v", sub.Name, err)
+			t.Status.Subscribers = nil
+			return err
+		}
+		subscribers = append(subscribers, eventingv1.TriggerSubscriberStatus{
+			Name:     sub.Name,
+			URI:      addr.URL,
+			CACerts:  addr.CACerts,
+			Audience: addr.Audience,
+			Weight:   sub.Weight,
+		})
+	}
+	t.Status.Subscribers = subscribers
 	return nil
 }
 
+// resolveMirror resolves the mirror of a Trigger. As the failures of the
+// mirror must not affect the Trigger, the mirror is only left unresolved, and
+// receives no events, when its URI can't be resolved.
+func (r *Reconciler) resolveMirror(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) {
+	t.Status.MirrorURI = nil
+	t.Status.MirrorCACerts = nil
+	t.Status.MirrorAudience = nil
+	if t.Spec.Mirror == nil {
+		return
+	}
+
+	dest := *t.Spec.Mirror.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = t.GetNamespace()
+	}
+	mirrorAddr, err := r.uriResolver.AddressableFromDestinationV1(ctx, dest, b)
+	if err != nil {
+		logging.FromContext(ctx).Warnw("Unable to get the mirror's URI", zap.Error(err))
+		return
+	}
+	t.Status.MirrorURI = mirrorAddr.URL
+	t.Status.MirrorCACerts = mirrorAddr.CACerts
+	t.Status.MirrorAudience = mirrorAddr.Audience
+}
+
 func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger) error {
 	// resolve the trigger's dls first, fall back to the broker's
 	if t.Spec.Delivery != nil && t.Spec.Delivery.DeadLetterSink != nil {
@@ -207,10 +294,43 @@ func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, b *eventingv1.Br
 	return nil
 }
 
+// probeDeadLetterSink reports whether the resolved dead letter sink of the
+// Trigger answered its last probe. The probes are run in the background, the
+// Trigger being reconciled again once they complete.
+func (r *Reconciler) probeDeadLetterSink(ctx context.Context, featureFlags feature.Flags, t *eventingv1.Trigger) {
+	if r.dlsProber == nil || !featureFlags.IsEnabled(feature.TriggerDeadLetterSinkHealth) || t.Status.DeadLetterSinkURI == nil {
+		if r.dlsProber != nil {
+			r.dlsProber.Forget(t.UID)
+		}
+		t.Status.ClearDeadLetterSinkReachable()
+		return
+	}
+
+	addr := duckv1.Addressable{
+		URL:     t.Status.DeadLetterSinkURI,
+		CACerts: t.Status.DeadLetterSinkCACerts,
+	}
+	done, err := r.dlsProber.Result(ctx, types.NamespacedName{Namespace: t.Namespace, Name: t.Name}, t.UID, addr)
+	switch {
+	case !done:
+		t.Status.MarkDeadLetterSinkReachableUnknown("DeadLetterSinkNotProbed", "The dead letter sink hasn't been probed yet.")
+	case err != nil:
+		t.Status.MarkDeadLetterSinkUnreachable("DeadLetterSinkUnreachable", "%v", err)
+	default:
+		t.Status.MarkDeadLetterSinkReachable()
+	}
+}
+
 // subscribeToBrokerChannel subscribes service 'svc' to the Broker's channels.
 func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1.Broker, t *eventingv1.Trigger, brokerTrigger *corev1.ObjectReference) (*messagingv1.Subscription, error) {
 	var dest, reply, dls *duckv1.Destination
 	featureFlags := feature.FromContext(ctx)
+
+	// The Broker may be routed to a canary broker-filter.
+	filterName := names.BrokerFilterName
+	if name := b.Status.Annotations[eventing.BrokerFilterServiceStatusAnnotationKey]; name != "" {
+		filterName = name
+	}
 	if featureFlags.IsPermissiveTransportEncryption() || featureFlags.IsStrictTransportEncryption() {
 		caCerts, err := r.getCaCerts()
 		if err != nil {
@@ -220,7 +340,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		dest = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "https",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.Generate(t),
 			},
 			CACerts: caCerts,
@@ -229,7 +349,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		reply = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "https",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.GenerateReply(t),
 			},
 			CACerts: caCerts,
@@ -238,7 +358,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		dls = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "https",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.GenerateDLS(t),
 			},
 			CACerts: caCerts,
@@ -247,7 +367,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		dest = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "http",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.Generate(t),
 			},
 		}
@@ -255,7 +375,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		reply = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "http",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.GenerateReply(t),
 			},
 		}
@@ -263,7 +383,7 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		dls = &duckv1.Destination{
 			URI: &apis.URL{
 				Scheme: "http",
-				Host:   network.GetServiceHostname("broker-filter", system.Namespace()),
+				Host:   network.GetServiceHostname(filterName, system.Namespace()),
 				Path:   path.GenerateDLS(t),
 			},
 		}
@@ -274,6 +394,15 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 		delivery = b.Spec.Delivery.DeepCopy() // copy object to avoid in-place update bugs
 	}
 
+	// The events without dead letter sink are routed through broker-filter
+	// once their retries are exhausted, which reports them as failed.
+	if featureFlags.IsEnabled(feature.BrokerDeliveryFailedEvents) && (delivery == nil || delivery.DeadLetterSink == nil) {
+		if delivery == nil {
+			delivery = &eventingduckv1.DeliverySpec{}
+		}
+		delivery.DeadLetterSink = dls
+	}
+
 	recorder := controller.GetEventRecorder(ctx)
 
 	var expected *messagingv1.Subscription
@@ -288,6 +417,12 @@ func (r *Reconciler) subscribeToBrokerChannel(ctx context.Context, b *eventingv1
 
 		expected = resources.NewSubscription(ctx, t, brokerTrigger, dest, reply, delivery)
 	} else {
+		// The events sent to the dead letter sink are routed through
+		// broker-filter, which counts them, to report them on the Trigger.
+		if featureFlags.IsEnabled(feature.TriggerDeadLetterSinkHealth) && delivery != nil && delivery.DeadLetterSink != nil {
+			delivery.DeadLetterSink = dls
+		}
+
 		// in case OIDC is not enabled, we don't need to route everything throuh
 		// broker-filter because we need it only then to add the token from the
 		// trigger OIDC service account
@@ -405,6 +540,95 @@ func (r *Reconciler) propagateDependencyReadiness(ctx context.Context, t *eventi
 	return nil
 }
 
+// checkDependencies tracks the sources listed in the dependencies of the
+// Trigger and marks it ready only when all of them are. The tracker enqueues the
+// Trigger when one of its own dependencies changes, not the other Triggers.
+func (r *Reconciler) checkDependencies(ctx context.Context, t *eventingv1.Trigger) error {
+	if len(t.Spec.Dependencies) == 0 {
+		t.Status.MarkDependenciesSucceeded()
+		return nil
+	}
+
+	trackSource := r.sourceTracker.TrackInNamespace(ctx, t)
+	var failed, unknown []string
+	for _, dep := range t.Spec.Dependencies {
+		ref := corev1.ObjectReference{
+			APIVersion: dep.APIVersion,
+			Kind:       dep.Kind,
+			Namespace:  t.GetNamespace(),
+			Name:       dep.Name,
+		}
+		if err := trackSource(ref); err != nil {
+			t.Status.MarkDependenciesUnknown("DependencyTrackingFailed", "Failed to track the dependency %s %s: %v", ref.Kind, ref.Name, err)
+			return fmt.Errorf("tracking dependency %s %s: %v", ref.Kind, ref.Name, err)
+		}
+		status, message, err := r.dependencyStatus(ctx, ref)
+		if err != nil {
+			t.Status.MarkDependenciesUnknown("DependencyGetFailed", "Failed to get the dependency %s %s: %v", ref.Kind, ref.Name, err)
+			return fmt.Errorf("getting dependency %s %s: %v", ref.Kind, ref.Name, err)
+		}
+		switch status {
+		case corev1.ConditionTrue:
+		case corev1.ConditionFalse:
+			failed = append(failed, message)
+		default:
+			unknown = append(unknown, message)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		t.Status.MarkDependenciesFailed("DependenciesNotReady", "%s", strings.Join(append(failed, unknown...), "; "))
+	case len(unknown) > 0:
+		t.Status.MarkDependenciesUnknown("DependenciesNotReady", "%s", strings.Join(unknown, "; "))
+	default:
+		t.Status.MarkDependenciesSucceeded()
+	}
+	return nil
+}
+
+// dependencyStatus returns the readiness of the source, with a message
+// explaining why it isn't ready.
+func (r *Reconciler) dependencyStatus(ctx context.Context, ref corev1.ObjectReference) (corev1.ConditionStatus, string, error) {
+	lister, err := r.sourceTracker.ListerFor(ref)
+	if err != nil {
+		return corev1.ConditionUnknown, "", fmt.Errorf("retrieving lister: %v", err)
+	}
+	obj, err := lister.ByNamespace(ref.Namespace).Get(ref.Name)
+	if apierrs.IsNotFound(err) {
+		return corev1.ConditionFalse, fmt.Sprintf("%s %s does not exist", ref.Kind, ref.Name), nil
+	} else if err != nil {
+		return corev1.ConditionUnknown, "", err
+	}
+	dependency := obj.(*duckv1.Source)
+
+	// The dependency hasn't yet reconciled our latest changes to
+	// its desired state, so its conditions are outdated.
+	if dependency.GetGeneration() != dependency.Status.ObservedGeneration {
+		logging.FromContext(ctx).Debugw("The dependency hasn't observed its latest generation",
+			zap.String("kind", ref.Kind), zap.String("name", ref.Name))
+		return corev1.ConditionUnknown, fmt.Sprintf("%s %s has not reconciled its generation %d yet", ref.Kind, ref.Name, dependency.GetGeneration()), nil
+	}
+
+	cond := dependency.Status.GetCondition(apis.ConditionReady)
+	if cond == nil {
+		return corev1.ConditionUnknown, fmt.Sprintf("%s %s has not been reconciled yet", ref.Kind, ref.Name), nil
+	}
+	if cond.Status == corev1.ConditionTrue {
+		return corev1.ConditionTrue, "", nil
+	}
+	status := corev1.ConditionUnknown
+	message := fmt.Sprintf("%s %s is not ready yet", ref.Kind, ref.Name)
+	if cond.Status == corev1.ConditionFalse {
+		status = corev1.ConditionFalse
+		message = fmt.Sprintf("%s %s is not ready", ref.Kind, ref.Name)
+	}
+	if cond.Message != "" {
+		message += ": " + cond.Message
+	}
+	return status, message, nil
+}
+
 func getBrokerChannelRef(b *eventingv1.Broker) (*corev1.ObjectReference, error) {
 	if b.Status.Annotations != nil {
 		ref := &corev1.ObjectReference{
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerdefaultspolicy
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	brokerdefaultspolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/brokerdefaultspolicy"
+)
+
+const (
+	noNamespaceSelected = "NoNamespaceSelected"
+)
+
+type Reconciler struct {
+	namespaceLister corev1listers.NamespaceLister
+}
+
+// Check that our Reconciler implements interface
+var _ brokerdefaultspolicyreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+// It reports the namespaces selected by the BrokerDefaultsPolicy.
+//
+// The defaults themselves are applied by the defaulting webhook to the
+// Brokers and Triggers created in the selected namespaces.
+func (r *Reconciler) ReconcileKind(ctx context.Context, p *v1alpha1.BrokerDefaultsPolicy) pkgreconciler.Event {
+	namespaces, err := r.namespaceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var selected int32
+	for _, ns := range namespaces {
+		if Selects(p, ns) {
+			selected++
+		}
+	}
+	if selected == 0 {
+		p.Status.MarkNoNamespacesSelected(noNamespaceSelected, "The namespace selector doesn't select any namespace")
+		return nil
+	}
+	p.Status.MarkNamespacesSelected(selected)
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerdefaultspolicy
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/brokerdefaultspolicy"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	policyName  = "test-policy"
+	brokerClass = "MTChannelBasedBroker"
+)
+
+var paymentsSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "not-found",
+	}, {
+		Name: "all namespaces",
+		Key:  policyName,
+		Objects: []runtime.Object{
+			NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+			),
+			NewNamespace("payments"),
+			NewNamespace("search"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+				WithInitBrokerDefaultsPolicyConditions,
+				WithBrokerDefaultsPolicyNamespacesSelected(2),
+			),
+		}},
+	}, {
+		Name: "selected namespaces",
+		Key:  policyName,
+		Objects: []runtime.Object{
+			NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyNamespaceSelector(paymentsSelector),
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+			),
+			NewNamespace("payments", WithNamespaceLabeled(map[string]string{"team": "payments"})),
+			NewNamespace("search", WithNamespaceLabeled(map[string]string{"team": "search"})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyNamespaceSelector(paymentsSelector),
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+				WithInitBrokerDefaultsPolicyConditions,
+				WithBrokerDefaultsPolicyNamespacesSelected(1),
+			),
+		}},
+	}, {
+		Name: "no namespace selected",
+		Key:  policyName,
+		Objects: []runtime.Object{
+			NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyNamespaceSelector(paymentsSelector),
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+			),
+			NewNamespace("search", WithNamespaceLabeled(map[string]string{"team": "search"})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewBrokerDefaultsPolicy(policyName,
+				WithBrokerDefaultsPolicyNamespaceSelector(paymentsSelector),
+				WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+				WithInitBrokerDefaultsPolicyConditions,
+				WithBrokerDefaultsPolicyNoNamespacesSelected(noNamespaceSelected, "The namespace selector doesn't select any namespace"),
+			),
+		}},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			namespaceLister: listers.GetNamespaceLister(),
+		}
+		return brokerdefaultspolicy.NewReconciler(ctx, logger,
+			fakeeventingclient.Get(ctx), listers.GetBrokerDefaultsPolicyLister(),
+			controller.GetEventRecorder(ctx), r)
+	},
+		false,
+		logger,
+	))
+}
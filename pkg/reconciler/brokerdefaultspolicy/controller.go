@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerdefaultspolicy
+
+import (
+	"context"
+
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+
+	brokerdefaultspolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/brokerdefaultspolicy"
+	brokerdefaultspolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/brokerdefaultspolicy"
+)
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	brokerDefaultsPolicyInformer := brokerdefaultspolicyinformer.Get(ctx)
+	namespaceInformer := namespace.Get(ctx)
+
+	r := &Reconciler{
+		namespaceLister: namespaceInformer.Lister(),
+	}
+	impl := brokerdefaultspolicyreconciler.NewImpl(ctx, r)
+
+	brokerDefaultsPolicyInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// The namespaces selected by the policies change with the labels of
+	// the namespaces.
+	namespaceInformer.Informer().AddEventHandler(controller.HandleAll(func(interface{}) {
+		impl.GlobalResync(brokerDefaultsPolicyInformer.Informer())
+	}))
+
+	return impl
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerdefaultspolicy
+
+import (
+	"context"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/eventing/pkg/apis/config"
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
+)
+
+// NewNamespaceDefaultsLookup returns the lookup of the defaults of the
+// Brokers and Triggers of a namespace, merging the BrokerDefaultsPolicies
+// selecting the namespace.
+func NewNamespaceDefaultsLookup(namespaceLister corev1listers.NamespaceLister, policyLister eventinglisters.BrokerDefaultsPolicyLister) config.NamespaceDefaultsLookup {
+	return func(_ context.Context, namespace string) (*config.NamespaceDefaults, error) {
+		ns, err := namespaceLister.Get(namespace)
+		if err != nil {
+			return nil, err
+		}
+		policies, err := policyLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var selecting []*v1alpha1.BrokerDefaultsPolicy
+		for _, p := range policies {
+			if Selects(p, ns) {
+				selecting = append(selecting, p)
+			}
+		}
+		return Merge(selecting), nil
+	}
+}
+
+// Selects returns true when the BrokerDefaultsPolicy applies to the
+// namespace.
+func Selects(p *v1alpha1.BrokerDefaultsPolicy, ns *corev1.Namespace) bool {
+	if p.Spec.NamespaceSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+	if err != nil {
+		// The selector is validated by the webhook, an invalid selector
+		// selects nothing.
+		return false
+	}
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
+// Merge merges the defaults of the BrokerDefaultsPolicies. The oldest
+// policies take precedence over the newer ones, for each field and each
+// label. It returns nil when there are no policies.
+func Merge(policies []*v1alpha1.BrokerDefaultsPolicy) *config.NamespaceDefaults {
+	if len(policies) == 0 {
+		return nil
+	}
+	policies = append([]*v1alpha1.BrokerDefaultsPolicy(nil), policies...)
+	sort.Slice(policies, func(i, j int) bool {
+		if !policies[i].CreationTimestamp.Equal(&policies[j].CreationTimestamp) {
+			return policies[i].CreationTimestamp.Before(&policies[j].CreationTimestamp)
+		}
+		return policies[i].Name < policies[j].Name
+	})
+
+	d := &config.NamespaceDefaults{}
+	for _, p := range policies {
+		for k, v := range p.Spec.Labels {
+			if _, ok := d.Labels[k]; !ok {
+				if d.Labels == nil {
+					d.Labels = make(map[string]string, len(p.Spec.Labels))
+				}
+				d.Labels[k] = v
+			}
+		}
+		if d.BrokerClass == "" {
+			d.BrokerClass = p.Spec.BrokerClass
+		}
+		if d.Delivery == nil {
+			d.Delivery = p.Spec.Delivery
+		}
+	}
+	return d
+}
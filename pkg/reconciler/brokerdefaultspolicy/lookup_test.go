@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerdefaultspolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	"knative.dev/eventing/pkg/apis/config"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+func TestNamespaceDefaultsLookup(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	older := now.Add(-time.Hour)
+
+	listers := NewListers([]runtime.Object{
+		NewNamespace("payments", WithNamespaceLabeled(map[string]string{"team": "payments"})),
+		NewNamespace("search", WithNamespaceLabeled(map[string]string{"team": "search"})),
+		NewBrokerDefaultsPolicy("all",
+			WithBrokerDefaultsPolicyCreationTimestamp(now),
+			WithBrokerDefaultsPolicyLabels(map[string]string{"team": "unknown", "env": "prod"}),
+			WithBrokerDefaultsPolicyBrokerClass("Kafka"),
+			WithBrokerDefaultsPolicyDelivery(&eventingduckv1.DeliverySpec{Retry: pointer.Int32(1)}),
+		),
+		NewBrokerDefaultsPolicy("payments",
+			WithBrokerDefaultsPolicyCreationTimestamp(older),
+			WithBrokerDefaultsPolicyNamespaceSelector(paymentsSelector),
+			WithBrokerDefaultsPolicyLabels(map[string]string{"team": "payments"}),
+			WithBrokerDefaultsPolicyBrokerClass(brokerClass),
+		),
+	})
+	lookup := NewNamespaceDefaultsLookup(listers.GetNamespaceLister(), listers.GetBrokerDefaultsPolicyLister())
+
+	tests := map[string]struct {
+		namespace string
+		want      *config.NamespaceDefaults
+		wantErr   bool
+	}{
+		"older policy takes precedence": {
+			namespace: "payments",
+			want: &config.NamespaceDefaults{
+				Labels:      map[string]string{"team": "payments", "env": "prod"},
+				BrokerClass: brokerClass,
+				Delivery:    &eventingduckv1.DeliverySpec{Retry: pointer.Int32(1)},
+			},
+		},
+		"policy of all namespaces": {
+			namespace: "search",
+			want: &config.NamespaceDefaults{
+				Labels:      map[string]string{"team": "unknown", "env": "prod"},
+				BrokerClass: "Kafka",
+				Delivery:    &eventingduckv1.DeliverySpec{Retry: pointer.Int32(1)},
+			},
+		},
+		"unknown namespace": {
+			namespace: "unknown",
+			wantErr:   true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := lookup(context.Background(), tc.namespace)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("lookup() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("Unexpected defaults (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestMergeNoPolicies(t *testing.T) {
+	if got := Merge(nil); got != nil {
+		t.Errorf("Merge(nil) = %+v, want nil", got)
+	}
+}
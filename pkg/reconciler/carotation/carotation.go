@@ -0,0 +1,527 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing/pkg/eventingtls"
+)
+
+const (
+	// RotationAnnotationKey is set by users on the CA Certificate to request a
+	// rotation of the CA. A new rotation starts whenever its value changes.
+	RotationAnnotationKey = "eventing.knative.dev/ca-rotation"
+	// ObservedRotationAnnotationKey holds the value of RotationAnnotationKey of
+	// the last completed rotation.
+	ObservedRotationAnnotationKey = "eventing.knative.dev/ca-rotation-observed"
+	// PhaseAnnotationKey reports the phase of the current rotation.
+	PhaseAnnotationKey = "eventing.knative.dev/ca-rotation-phase"
+	// TransitionTimeAnnotationKey is the time of the last phase transition.
+	TransitionTimeAnnotationKey = "eventing.knative.dev/ca-rotation-transition-time"
+	// ServingCertificatesAnnotationKey lists the serving Certificates which
+	// have to be issued by the new CA for the rotation to complete.
+	ServingCertificatesAnnotationKey = "eventing.knative.dev/ca-rotation-serving-certificates"
+
+	// PreviousCAConfigMapName is the name of the ConfigMap holding the
+	// certificate of the CA being replaced, it is a source of the trust
+	// Bundle while the rotation is in progress.
+	PreviousCAConfigMapName = "knative-eventing-ca-previous"
+	// PreviousCAConfigMapKey is the key of the certificate in
+	// PreviousCAConfigMapName.
+	PreviousCAConfigMapKey = "ca.crt"
+
+	// issuingCondition is the condition of cert-manager Certificates which
+	// triggers the issuance of a new certificate, like `cmctl renew` does.
+	issuingCondition = "Issuing"
+)
+
+var (
+	certificatesGVR = schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"}
+	bundlesGVR      = schema.GroupVersionResource{Group: "trust.cert-manager.io", Version: "v1alpha1", Resource: "bundles"}
+
+	// CertificateGVK is the kind of the cert-manager Certificates reconciled
+	// by the CA rotation.
+	CertificateGVK = certificatesGVR.GroupVersion().WithKind("Certificate")
+)
+
+// Phase is a phase of a CA rotation.
+type Phase string
+
+const (
+	// PhaseCAReissuing means that the current CA was added to the trust
+	// Bundle as the previous CA, and that cert-manager is reissuing the CA.
+	PhaseCAReissuing Phase = "CAReissuing"
+	// PhaseTrustPropagating means that the trust bundle holds both the
+	// previous and the new CA, and that consumers are given time to load it.
+	PhaseTrustPropagating Phase = "TrustPropagating"
+	// PhaseServingCertsRotating means that cert-manager is reissuing the
+	// serving certificates with the new CA.
+	PhaseServingCertsRotating Phase = "ServingCertsRotating"
+	// PhaseCompleted means that the serving certificates are issued by the new
+	// CA and that the previous CA was removed from the trust Bundle.
+	PhaseCompleted Phase = "Completed"
+)
+
+// ServingCertificateNames are the names of the cert-manager Certificates of
+// the data plane servers, and of the secrets they are issued to.
+var ServingCertificateNames = []string{
+	eventingtls.BrokerIngressServerTLSSecretName,
+	eventingtls.BrokerFilterServerTLSSecretName,
+	eventingtls.IMCDispatcherServerTLSSecretName,
+	eventingtls.JobSinkDispatcherServerTLSSecretName,
+}
+
+// Reconciler rotates the eventing CA, which is issued by cert-manager and
+// distributed by trust-manager, without a window in which clients don't trust
+// the serving certificates:
+//  1. the current CA is added to the trust Bundle as the previous CA, and
+//     cert-manager is asked to reissue the CA Certificate,
+//  2. once the trust bundle with both CAs had time to propagate, cert-manager
+//     is asked to reissue the serving Certificates,
+//  3. once all serving certificates are issued by the new CA, the previous CA
+//     is removed from the trust Bundle.
+//
+// The progress is reported through annotations on the CA Certificate.
+type Reconciler struct {
+	kubeClientSet    kubernetes.Interface
+	dynamicClientSet dynamic.Interface
+	secretLister     corev1listers.SecretNamespaceLister
+	configMapLister  corev1listers.ConfigMapNamespaceLister
+
+	namespace               string
+	caNamespace             string
+	caCertificateName       string
+	bundleName              string
+	servingCertificateNames []string
+	propagationDelay        time.Duration
+	pollInterval            time.Duration
+
+	enqueueAfter func(key types.NamespacedName, delay time.Duration)
+	now          func() time.Time
+}
+
+// Reconcile implements controller.Reconciler.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("invalid resource key", zap.String("key", key))
+		return nil
+	}
+	if namespace != r.caNamespace || name != r.caCertificateName {
+		return nil
+	}
+
+	ca, err := r.certificates(r.caNamespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	annotations := ca.GetAnnotations()
+	requested := annotations[RotationAnnotationKey]
+	if requested == "" || requested == annotations[ObservedRotationAnnotationKey] {
+		return nil
+	}
+
+	switch Phase(annotations[PhaseAnnotationKey]) {
+	case PhaseCAReissuing:
+		return r.awaitCA(ctx, ca)
+	case PhaseTrustPropagating:
+		return r.rotateServingCerts(ctx, ca)
+	case PhaseServingCertsRotating:
+		return r.completeRotation(ctx, ca)
+	default:
+		return r.reissueCA(ctx, ca)
+	}
+}
+
+// reissueCA adds the current CA to the trust Bundle as the previous CA and
+// asks cert-manager to reissue the CA.
+func (r *Reconciler) reissueCA(ctx context.Context, ca *unstructured.Unstructured) error {
+	crt, err := r.caCert(ctx, ca)
+	if err != nil {
+		return err
+	}
+	if len(crt) == 0 {
+		return fmt.Errorf("CA Certificate %s/%s isn't issued yet", r.caNamespace, r.caCertificateName)
+	}
+
+	if err := r.reconcilePreviousCA(ctx, crt); err != nil {
+		return err
+	}
+	if err := r.reconcileBundleSource(ctx, true); err != nil {
+		return err
+	}
+
+	// The phase is recorded first, as triggering the issuance updates the
+	// status of the CA Certificate.
+	if err := r.transition(ctx, ca, PhaseCAReissuing); err != nil {
+		return err
+	}
+	if err := r.triggerIssuance(ctx, r.caNamespace, r.caCertificateName); err != nil {
+		return err
+	}
+	r.enqueueAfter(r.caKey(), r.pollInterval)
+	return nil
+}
+
+// awaitCA waits for the new CA to be issued and for the trust bundle to hold
+// both the previous and the new CA.
+func (r *Reconciler) awaitCA(ctx context.Context, ca *unstructured.Unstructured) error {
+	previous, err := r.kubeClientSet.CoreV1().ConfigMaps(r.caNamespace).Get(ctx, PreviousCAConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		logging.FromContext(ctx).Warn("Previous CA not found, restarting the rotation")
+		return r.reissueCA(ctx, ca)
+	} else if err != nil {
+		return err
+	}
+	previousCrt := []byte(previous.Data[PreviousCAConfigMapKey])
+
+	crt, err := r.caCert(ctx, ca)
+	if err != nil {
+		return err
+	}
+	if len(crt) == 0 || sameCerts(crt, previousCrt) {
+		// Triggering the issuance is a no-op while it is in progress.
+		if err := r.triggerIssuance(ctx, r.caNamespace, r.caCertificateName); err != nil {
+			return err
+		}
+		logging.FromContext(ctx).Info("Waiting for the CA to be reissued")
+		r.enqueueAfter(r.caKey(), r.pollInterval)
+		return nil
+	}
+
+	bundle, err := r.trustBundle(ctx)
+	if err != nil {
+		return err
+	}
+	if !containsCerts(bundle, previousCrt) || !containsCerts(bundle, crt) {
+		logging.FromContext(ctx).Info("Waiting for the trust bundle to hold the previous and the new CA")
+		r.enqueueAfter(r.caKey(), r.pollInterval)
+		return nil
+	}
+
+	if err := r.transition(ctx, ca, PhaseTrustPropagating); err != nil {
+		return err
+	}
+	r.enqueueAfter(r.caKey(), r.propagationDelay)
+	return nil
+}
+
+// rotateServingCerts asks cert-manager to reissue the serving certificates
+// once the trust bundle with both CAs had time to propagate.
+func (r *Reconciler) rotateServingCerts(ctx context.Context, ca *unstructured.Unstructured) error {
+	if remaining := r.remaining(ca, r.propagationDelay); remaining > 0 {
+		r.enqueueAfter(r.caKey(), remaining)
+		return nil
+	}
+
+	crt, err := r.caCert(ctx, ca)
+	if err != nil {
+		return err
+	}
+
+	servingCertificates := make([]string, 0, len(r.servingCertificateNames))
+	for _, name := range r.servingCertificateNames {
+		if _, err := r.certificates(r.namespace).Get(ctx, name, metav1.GetOptions{}); apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		servingCertificates = append(servingCertificates, name)
+
+		secret, err := r.secretLister.Get(name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if secret != nil && isSignedBy(secret.Data[corev1.TLSCertKey], crt) {
+			continue
+		}
+		if err := r.triggerIssuance(ctx, r.namespace, name); err != nil {
+			return err
+		}
+	}
+
+	annotations := ca.GetAnnotations()
+	annotations[ServingCertificatesAnnotationKey] = strings.Join(servingCertificates, ",")
+	ca.SetAnnotations(annotations)
+	if err := r.transition(ctx, ca, PhaseServingCertsRotating); err != nil {
+		return err
+	}
+	r.enqueueAfter(r.caKey(), r.pollInterval)
+	return nil
+}
+
+// completeRotation waits for the serving certificates to be issued by the new
+// CA, and removes the previous CA from the trust Bundle once all of them are.
+func (r *Reconciler) completeRotation(ctx context.Context, ca *unstructured.Unstructured) error {
+	crt, err := r.caCert(ctx, ca)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, name := range strings.Split(ca.GetAnnotations()[ServingCertificatesAnnotationKey], ",") {
+		if name == "" {
+			continue
+		}
+		secret, err := r.secretLister.Get(name)
+		if apierrors.IsNotFound(err) {
+			pending++
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !isSignedBy(secret.Data[corev1.TLSCertKey], crt) {
+			pending++
+		}
+	}
+	if pending > 0 {
+		logging.FromContext(ctx).Infow("Waiting for serving certificates to be reissued", zap.Int("pending", pending))
+		r.enqueueAfter(r.caKey(), r.pollInterval)
+		return nil
+	}
+
+	if err := r.reconcileBundleSource(ctx, false); err != nil {
+		return err
+	}
+	err = r.kubeClientSet.CoreV1().ConfigMaps(r.caNamespace).Delete(ctx, PreviousCAConfigMapName, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ConfigMap %s: %w", PreviousCAConfigMapName, err)
+	}
+
+	annotations := ca.GetAnnotations()
+	delete(annotations, ServingCertificatesAnnotationKey)
+	annotations[ObservedRotationAnnotationKey] = annotations[RotationAnnotationKey]
+	ca.SetAnnotations(annotations)
+	return r.transition(ctx, ca, PhaseCompleted)
+}
+
+// caCert returns the certificate of the CA currently issued by cert-manager.
+func (r *Reconciler) caCert(ctx context.Context, ca *unstructured.Unstructured) ([]byte, error) {
+	secretName, _, _ := unstructured.NestedString(ca.Object, "spec", "secretName")
+	secret, err := r.kubeClientSet.CoreV1().Secrets(r.caNamespace).Get(ctx, secretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get CA secret %s: %w", secretName, err)
+	}
+	return secret.Data[corev1.TLSCertKey], nil
+}
+
+// reconcilePreviousCA makes sure the previous CA ConfigMap holds crt.
+func (r *Reconciler) reconcilePreviousCA(ctx context.Context, crt []byte) error {
+	configMaps := r.kubeClientSet.CoreV1().ConfigMaps(r.caNamespace)
+
+	cm, err := configMaps.Get(ctx, PreviousCAConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: r.caNamespace,
+				Name:      PreviousCAConfigMapName,
+			},
+			Data: map[string]string{
+				PreviousCAConfigMapKey: string(crt),
+			},
+		}
+		if _, err := configMaps.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s: %w", PreviousCAConfigMapName, err)
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if cm.Data[PreviousCAConfigMapKey] == string(crt) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string, 1)
+	}
+	cm.Data[PreviousCAConfigMapKey] = string(crt)
+	if _, err := configMaps.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s: %w", PreviousCAConfigMapName, err)
+	}
+	return nil
+}
+
+// reconcileBundleSource adds or removes the previous CA ConfigMap from the
+// sources of the trust Bundle.
+func (r *Reconciler) reconcileBundleSource(ctx context.Context, present bool) error {
+	bundles := r.dynamicClientSet.Resource(bundlesGVR)
+
+	bundle, err := bundles.Get(ctx, r.bundleName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Bundle %s: %w", r.bundleName, err)
+	}
+
+	sources, _, err := unstructured.NestedSlice(bundle.Object, "spec", "sources")
+	if err != nil {
+		return fmt.Errorf("invalid sources in Bundle %s: %w", r.bundleName, err)
+	}
+
+	updated := make([]interface{}, 0, len(sources)+1)
+	found := false
+	for _, s := range sources {
+		if isPreviousCASource(s) {
+			found = true
+			if !present {
+				continue
+			}
+		}
+		updated = append(updated, s)
+	}
+	if found == present {
+		return nil
+	}
+	if present {
+		updated = append(updated, map[string]interface{}{
+			"configMap": map[string]interface{}{
+				"name": PreviousCAConfigMapName,
+				"key":  PreviousCAConfigMapKey,
+			},
+		})
+	}
+
+	if err := unstructured.SetNestedSlice(bundle.Object, updated, "spec", "sources"); err != nil {
+		return err
+	}
+	if _, err := bundles.Update(ctx, bundle, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update Bundle %s: %w", r.bundleName, err)
+	}
+	return nil
+}
+
+// trustBundle returns the certificates of the trust bundle distributed by
+// trust-manager to the system namespace.
+func (r *Reconciler) trustBundle(ctx context.Context) ([]byte, error) {
+	bundle, err := r.dynamicClientSet.Resource(bundlesGVR).Get(ctx, r.bundleName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Bundle %s: %w", r.bundleName, err)
+	}
+	key, _, _ := unstructured.NestedString(bundle.Object, "spec", "target", "configMap", "key")
+
+	cm, err := r.configMapLister.Get(r.bundleName)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return []byte(cm.Data[key]), nil
+}
+
+// triggerIssuance asks cert-manager to issue a new certificate for the given
+// Certificate.
+func (r *Reconciler) triggerIssuance(ctx context.Context, namespace, name string) error {
+	certificates := r.certificates(namespace)
+
+	crt, err := certificates.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Certificate %s/%s: %w", namespace, name, err)
+	}
+
+	conditions, _, err := unstructured.NestedSlice(crt.Object, "status", "conditions")
+	if err != nil {
+		return fmt.Errorf("invalid conditions in Certificate %s/%s: %w", namespace, name, err)
+	}
+	updated := make([]interface{}, 0, len(conditions)+1)
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok && cond["type"] == issuingCondition {
+			if cond["status"] == string(metav1.ConditionTrue) {
+				// An issuance is already in progress.
+				return nil
+			}
+			continue
+		}
+		updated = append(updated, c)
+	}
+	updated = append(updated, map[string]interface{}{
+		"type":               issuingCondition,
+		"status":             string(metav1.ConditionTrue),
+		"reason":             "ManuallyTriggered",
+		"message":            "Certificate re-issuance triggered by the eventing CA rotation",
+		"lastTransitionTime": r.now().UTC().Format(time.RFC3339),
+	})
+
+	if err := unstructured.SetNestedSlice(crt.Object, updated, "status", "conditions"); err != nil {
+		return err
+	}
+	if _, err := certificates.UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to trigger the issuance of Certificate %s/%s: %w", namespace, name, err)
+	}
+	logging.FromContext(ctx).Infow("Triggered the issuance of a Certificate", zap.String("namespace", namespace), zap.String("name", name))
+	return nil
+}
+
+// transition records the given phase on the CA Certificate and updates it.
+func (r *Reconciler) transition(ctx context.Context, ca *unstructured.Unstructured, phase Phase) error {
+	annotations := ca.GetAnnotations()
+	annotations[PhaseAnnotationKey] = string(phase)
+	annotations[TransitionTimeAnnotationKey] = r.now().UTC().Format(time.RFC3339)
+	ca.SetAnnotations(annotations)
+
+	if _, err := r.certificates(r.caNamespace).Update(ctx, ca, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update Certificate %s/%s: %w", r.caNamespace, ca.GetName(), err)
+	}
+	logging.FromContext(ctx).Infow("CA rotation phase changed", zap.String("phase", string(phase)))
+	return nil
+}
+
+// remaining returns how long is left until delay elapsed since the last
+// phase transition.
+func (r *Reconciler) remaining(ca *unstructured.Unstructured, delay time.Duration) time.Duration {
+	t, err := time.Parse(time.RFC3339, ca.GetAnnotations()[TransitionTimeAnnotationKey])
+	if err != nil {
+		return 0
+	}
+	return t.Add(delay).Sub(r.now())
+}
+
+func (r *Reconciler) certificates(namespace string) dynamic.ResourceInterface {
+	return r.dynamicClientSet.Resource(certificatesGVR).Namespace(namespace)
+}
+
+func (r *Reconciler) caKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.caNamespace, Name: r.caCertificateName}
+}
+
+func isPreviousCASource(s interface{}) bool {
+	source, ok := s.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	cm, ok := source["configMap"].(map[string]interface{})
+	return ok && cm["name"] == PreviousCAConfigMapName
+}
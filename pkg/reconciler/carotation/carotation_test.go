@@ -0,0 +1,435 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing"
+
+	"knative.dev/eventing/pkg/eventingtls"
+)
+
+const (
+	caNamespace       = "cert-manager"
+	caCertificateName = "knative-eventing-selfsigned-ca"
+	caSecretName      = "knative-eventing-ca"
+	bundleName        = "knative-eventing-bundle"
+	bundleKey         = "knative-eventing-bundle.pem"
+	propagationDelay  = 5 * time.Minute
+	pollInterval      = time.Minute
+	rotation          = "2024-06-01"
+)
+
+var (
+	now = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	oldCACrt, oldCAKey = mustNewCA()
+	newCACrt, newCAKey = mustNewCA()
+
+	staleServingCrt = mustNewServingCert(oldCACrt, oldCAKey)
+	servingCrt      = mustNewServingCert(newCACrt, newCAKey)
+)
+
+// fixture simulates cert-manager and trust-manager around the Reconciler.
+type fixture struct {
+	t             *testing.T
+	ctx           context.Context
+	kubeClient    *kubefake.Clientset
+	dynamicClient *dynamicfake.FakeDynamicClient
+	secrets       cache.Indexer
+	configMaps    cache.Indexer
+	enqueued      []time.Duration
+	now           time.Time
+	r             *Reconciler
+}
+
+func newFixture(t *testing.T, caAnnotations map[string]string) *fixture {
+	f := &fixture{
+		t:          t,
+		ctx:        logtesting.TestContextWithLogger(t),
+		kubeClient: kubefake.NewSimpleClientset(),
+		secrets:    cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		configMaps: cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		now:        now,
+	}
+	f.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		certificate(caNamespace, caCertificateName, caSecretName, caAnnotations),
+		certificate(system.Namespace(), eventingtls.BrokerIngressServerTLSSecretName, eventingtls.BrokerIngressServerTLSSecretName, nil),
+		certificate(system.Namespace(), eventingtls.BrokerFilterServerTLSSecretName, eventingtls.BrokerFilterServerTLSSecretName, nil),
+		bundle(),
+	)
+
+	f.setSecret(caNamespace, caSecretName, oldCACrt)
+	f.setSecret(system.Namespace(), eventingtls.BrokerIngressServerTLSSecretName, staleServingCrt)
+	f.setSecret(system.Namespace(), eventingtls.BrokerFilterServerTLSSecretName, staleServingCrt)
+	f.setTrustBundle(oldCACrt)
+
+	f.r = &Reconciler{
+		kubeClientSet:           f.kubeClient,
+		dynamicClientSet:        f.dynamicClient,
+		secretLister:            corev1listers.NewSecretLister(f.secrets).Secrets(system.Namespace()),
+		configMapLister:         corev1listers.NewConfigMapLister(f.configMaps).ConfigMaps(system.Namespace()),
+		namespace:               system.Namespace(),
+		caNamespace:             caNamespace,
+		caCertificateName:       caCertificateName,
+		bundleName:              bundleName,
+		servingCertificateNames: ServingCertificateNames,
+		propagationDelay:        propagationDelay,
+		pollInterval:            pollInterval,
+		enqueueAfter: func(_ types.NamespacedName, delay time.Duration) {
+			f.enqueued = append(f.enqueued, delay)
+		},
+		now: func() time.Time { return f.now },
+	}
+	return f
+}
+
+func (f *fixture) reconcile() {
+	f.t.Helper()
+	f.enqueued = nil
+	if err := f.r.Reconcile(f.ctx, caNamespace+"/"+caCertificateName); err != nil {
+		f.t.Fatal("Reconcile() =", err)
+	}
+}
+
+// setSecret issues crt to a secret, like cert-manager does.
+func (f *fixture) setSecret(namespace, name string, crt []byte) {
+	f.t.Helper()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: crt},
+	}
+	secrets := f.kubeClient.CoreV1().Secrets(namespace)
+	if _, err := secrets.Update(f.ctx, secret, metav1.UpdateOptions{}); apierrors.IsNotFound(err) {
+		_, err = secrets.Create(f.ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			f.t.Fatal(err)
+		}
+	} else if err != nil {
+		f.t.Fatal(err)
+	}
+	if err := f.secrets.Add(secret); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// setTrustBundle distributes the trust bundle, like trust-manager does.
+func (f *fixture) setTrustBundle(certs ...[]byte) {
+	f.t.Helper()
+	var b []byte
+	for _, c := range certs {
+		b = append(b, c...)
+	}
+	err := f.configMaps.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: bundleName},
+		Data:       map[string]string{bundleKey: string(b)},
+	})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// issued completes the issuance of a Certificate, like cert-manager does.
+func (f *fixture) issued(namespace, name string, crt []byte) {
+	f.t.Helper()
+	certificates := f.dynamicClient.Resource(certificatesGVR).Namespace(namespace)
+	c, err := certificates.Get(f.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	unstructured.RemoveNestedField(c.Object, "status", "conditions")
+	if _, err := certificates.UpdateStatus(f.ctx, c, metav1.UpdateOptions{}); err != nil {
+		f.t.Fatal(err)
+	}
+	secretName, _, _ := unstructured.NestedString(c.Object, "spec", "secretName")
+	f.setSecret(namespace, secretName, crt)
+}
+
+func (f *fixture) certificate(namespace, name string) *unstructured.Unstructured {
+	f.t.Helper()
+	c, err := f.dynamicClient.Resource(certificatesGVR).Namespace(namespace).Get(f.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return c
+}
+
+func (f *fixture) wantPhase(want Phase) {
+	f.t.Helper()
+	if got := Phase(f.certificate(caNamespace, caCertificateName).GetAnnotations()[PhaseAnnotationKey]); got != want {
+		f.t.Fatalf("want phase %q, got %q", want, got)
+	}
+}
+
+func (f *fixture) wantIssuing(namespace, name string, want bool) {
+	f.t.Helper()
+	conditions, _, _ := unstructured.NestedSlice(f.certificate(namespace, name).Object, "status", "conditions")
+	got := false
+	for _, c := range conditions {
+		if cond := c.(map[string]interface{}); cond["type"] == issuingCondition && cond["status"] == "True" {
+			got = true
+		}
+	}
+	if got != want {
+		f.t.Fatalf("want Certificate %s/%s issuing %v, got %v", namespace, name, want, got)
+	}
+}
+
+func (f *fixture) wantPreviousCASource(want bool) {
+	f.t.Helper()
+	b, err := f.dynamicClient.Resource(bundlesGVR).Get(f.ctx, bundleName, metav1.GetOptions{})
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	sources, _, _ := unstructured.NestedSlice(b.Object, "spec", "sources")
+	got := 0
+	for _, s := range sources {
+		if isPreviousCASource(s) {
+			got++
+		}
+	}
+	if want && got != 1 || !want && got != 0 {
+		f.t.Fatalf("want previous CA source %v, got %d of them in %v", want, got, sources)
+	}
+	if len(sources)-got != 1 {
+		f.t.Fatalf("want the CA secret source to be kept, got %v", sources)
+	}
+}
+
+func TestReconcileRotation(t *testing.T) {
+	f := newFixture(t, map[string]string{RotationAnnotationKey: rotation})
+
+	// The current CA is kept in the trust Bundle and the CA is reissued.
+	f.reconcile()
+	f.wantPhase(PhaseCAReissuing)
+	f.wantIssuing(caNamespace, caCertificateName, true)
+	f.wantPreviousCASource(true)
+	previous, err := f.kubeClient.CoreV1().ConfigMaps(caNamespace).Get(f.ctx, PreviousCAConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := previous.Data[PreviousCAConfigMapKey]; got != string(oldCACrt) {
+		t.Fatalf("want the previous CA to be the current CA, got %q", got)
+	}
+
+	f.reconcile()
+	f.wantPhase(PhaseCAReissuing)
+
+	// The new CA is issued, but the trust bundle doesn't hold it yet.
+	f.issued(caNamespace, caCertificateName, newCACrt)
+	f.reconcile()
+	f.wantPhase(PhaseCAReissuing)
+	f.wantIssuing(caNamespace, caCertificateName, false)
+
+	// The trust bundle holds both CAs, and is given time to propagate.
+	f.setTrustBundle(oldCACrt, newCACrt)
+	f.reconcile()
+	f.wantPhase(PhaseTrustPropagating)
+
+	f.now = now.Add(time.Minute)
+	f.reconcile()
+	f.wantPhase(PhaseTrustPropagating)
+	f.wantIssuing(system.Namespace(), eventingtls.BrokerIngressServerTLSSecretName, false)
+	if len(f.enqueued) != 1 || f.enqueued[0] != propagationDelay-time.Minute {
+		t.Fatalf("want the CA to be enqueued after %v, got %v", propagationDelay-time.Minute, f.enqueued)
+	}
+
+	// The serving certificates are reissued once the bundle propagated.
+	f.now = now.Add(propagationDelay)
+	f.reconcile()
+	f.wantPhase(PhaseServingCertsRotating)
+	f.wantIssuing(system.Namespace(), eventingtls.BrokerIngressServerTLSSecretName, true)
+	f.wantIssuing(system.Namespace(), eventingtls.BrokerFilterServerTLSSecretName, true)
+
+	f.issued(system.Namespace(), eventingtls.BrokerIngressServerTLSSecretName, servingCrt)
+	f.reconcile()
+	f.wantPhase(PhaseServingCertsRotating)
+	f.wantPreviousCASource(true)
+
+	// The previous CA is removed from the trust Bundle once all the serving
+	// certificates are issued by the new CA.
+	f.issued(system.Namespace(), eventingtls.BrokerFilterServerTLSSecretName, servingCrt)
+	f.reconcile()
+	f.wantPhase(PhaseCompleted)
+	f.wantPreviousCASource(false)
+	if _, err := f.kubeClient.CoreV1().ConfigMaps(caNamespace).Get(f.ctx, PreviousCAConfigMapName, metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatal("want the previous CA ConfigMap to be deleted, got", err)
+	}
+	if got := f.certificate(caNamespace, caCertificateName).GetAnnotations()[ObservedRotationAnnotationKey]; got != rotation {
+		t.Fatalf("want observed rotation %q, got %q", rotation, got)
+	}
+
+	f.reconcile()
+	f.wantPhase(PhaseCompleted)
+}
+
+func TestReconcileNoRotation(t *testing.T) {
+	tests := map[string]struct {
+		key         string
+		annotations map[string]string
+	}{
+		"bad workqueue key": {
+			key: "too/many/parts",
+		},
+		"other Certificate": {
+			key:         system.Namespace() + "/" + eventingtls.BrokerIngressServerTLSSecretName,
+			annotations: map[string]string{RotationAnnotationKey: rotation},
+		},
+		"no rotation requested": {
+			key: caNamespace + "/" + caCertificateName,
+		},
+		"rotation completed": {
+			key: caNamespace + "/" + caCertificateName,
+			annotations: map[string]string{
+				RotationAnnotationKey:         rotation,
+				ObservedRotationAnnotationKey: rotation,
+				PhaseAnnotationKey:            string(PhaseCompleted),
+			},
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			f := newFixture(t, tc.annotations)
+			f.kubeClient.ClearActions()
+			f.dynamicClient.ClearActions()
+			if err := f.r.Reconcile(f.ctx, tc.key); err != nil {
+				t.Fatal("Reconcile() =", err)
+			}
+			for _, a := range append(f.dynamicClient.Actions(), f.kubeClient.Actions()...) {
+				if a.GetVerb() != "get" {
+					t.Errorf("unexpected action %v", a)
+				}
+			}
+		})
+	}
+}
+
+func certificate(namespace, name, secretName string, annotations map[string]string) *unstructured.Unstructured {
+	c := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"secretName": secretName,
+		},
+	}}
+	c.SetGroupVersionKind(CertificateGVK)
+	c.SetNamespace(namespace)
+	c.SetName(name)
+	c.SetAnnotations(annotations)
+	return c
+}
+
+func bundle() *unstructured.Unstructured {
+	b := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"sources": []interface{}{
+				map[string]interface{}{
+					"secret": map[string]interface{}{
+						"name": caSecretName,
+						"key":  corev1.TLSCertKey,
+					},
+				},
+			},
+			"target": map[string]interface{}{
+				"configMap": map[string]interface{}{
+					"key": bundleKey,
+				},
+			},
+		},
+	}}
+	b.SetGroupVersionKind(bundlesGVR.GroupVersion().WithKind("Bundle"))
+	b.SetName(bundleName)
+	return b
+}
+
+func mustNewCA() ([]byte, []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "selfsigned-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		panic(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func mustNewServingCert(caCrt, caKey []byte) []byte {
+	ca, err := tls.X509KeyPair(caCrt, caKey)
+	if err != nil {
+		panic(err)
+	}
+	parent, err := x509.ParseCertificate(ca.Certificate[0])
+	if err != nil {
+		panic(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "broker-ingress.knative-eventing.svc"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &privateKey.PublicKey, ca.PrivateKey)
+	if err != nil {
+		panic(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
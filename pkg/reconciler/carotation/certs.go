@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+)
+
+// parseCerts returns the certificates PEM encoded in b, skipping the blocks
+// which can't be parsed.
+func parseCerts(b []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for rest := b; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return certs
+		}
+		crt, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certs = append(certs, crt)
+	}
+}
+
+// containsCerts returns true when all the certificates in crtPEM are part of
+// bundlePEM.
+func containsCerts(bundlePEM, crtPEM []byte) bool {
+	bundle := parseCerts(bundlePEM)
+	crts := parseCerts(crtPEM)
+	if len(crts) == 0 {
+		return false
+	}
+	for _, crt := range crts {
+		found := false
+		for _, b := range bundle {
+			if crt.Equal(b) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCerts returns true when a and b hold the same certificates.
+func sameCerts(a, b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b)) ||
+		(containsCerts(a, b) && containsCerts(b, a))
+}
+
+// isSignedBy returns true when the first certificate in crtPEM is signed by
+// one of the certificates in caPEM.
+func isSignedBy(crtPEM, caPEM []byte) bool {
+	crts := parseCerts(crtPEM)
+	if len(crts) == 0 {
+		return false
+	}
+	for _, ca := range parseCerts(caPEM) {
+		if crts[0].CheckSignatureFrom(ca) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package carotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/filtered"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	secretinformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+
+	"knative.dev/eventing/pkg/eventingtls"
+)
+
+const (
+	// ReconcilerName is the name of the reconciler.
+	ReconcilerName = "CARotation"
+)
+
+type envConfig struct {
+	// CANamespace is the namespace of the cert-manager Certificate of the
+	// eventing CA, which is also the trust-manager trust namespace.
+	CANamespace string `envconfig:"CA_ROTATION_CA_NAMESPACE" default:"cert-manager"`
+	// CACertificateName is the name of the cert-manager Certificate of the
+	// eventing CA.
+	CACertificateName string `envconfig:"CA_ROTATION_CA_CERTIFICATE" default:"knative-eventing-selfsigned-ca"`
+	// BundleName is the name of the trust-manager Bundle distributing the
+	// eventing CA, and of the ConfigMaps it targets.
+	BundleName string `envconfig:"CA_ROTATION_BUNDLE" default:"knative-eventing-bundle"`
+	// PropagationDelay is how long to wait after the trust bundle holds the
+	// new CA before the serving certificates are issued by it, it has to
+	// cover the time it takes for ConfigMap volumes to be updated.
+	PropagationDelay time.Duration `envconfig:"CA_ROTATION_PROPAGATION_DELAY" default:"5m"`
+	// PollInterval is how often rotation requests on the CA Certificate and
+	// the progress of cert-manager and trust-manager are checked.
+	PollInterval time.Duration `envconfig:"CA_ROTATION_POLL_INTERVAL" default:"1m"`
+}
+
+// NewController initializes the controller and is called by the generated code.
+// Registers event handlers to enqueue events.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		logger.Panicf("unable to process CA rotation environment variables: %v", err)
+	}
+
+	secretInformer := secretinformer.Get(ctx)
+	trustBundleConfigMapInformer := configmapinformer.Get(ctx, eventingtls.TrustBundleLabelSelector)
+
+	r := &Reconciler{
+		kubeClientSet:           kubeclient.Get(ctx),
+		dynamicClientSet:        dynamicclient.Get(ctx),
+		secretLister:            secretInformer.Lister().Secrets(system.Namespace()),
+		configMapLister:         trustBundleConfigMapInformer.Lister().ConfigMaps(system.Namespace()),
+		namespace:               system.Namespace(),
+		caNamespace:             env.CANamespace,
+		caCertificateName:       env.CACertificateName,
+		bundleName:              env.BundleName,
+		servingCertificateNames: ServingCertificateNames,
+		propagationDelay:        env.PropagationDelay,
+		pollInterval:            env.PollInterval,
+		now:                     time.Now,
+	}
+
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		Logger: logger, WorkQueueName: ReconcilerName,
+	})
+	r.enqueueAfter = impl.EnqueueKeyAfter
+
+	caKey := types.NamespacedName{Namespace: env.CANamespace, Name: env.CACertificateName}
+	enqueueCA := func(interface{}) {
+		impl.EnqueueKey(caKey)
+	}
+
+	// The serving certificates and the trust bundle drive the rotation.
+	watched := make(map[string]struct{}, len(ServingCertificateNames))
+	for _, name := range ServingCertificateNames {
+		watched[name] = struct{}{}
+	}
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			object, err := kmeta.DeletionHandlingAccessor(obj)
+			if err != nil || object.GetNamespace() != system.Namespace() {
+				return false
+			}
+			_, ok := watched[object.GetName()]
+			return ok
+		},
+		Handler: controller.HandleAll(enqueueCA),
+	})
+
+	trustBundleConfigMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterWithNameAndNamespace(system.Namespace(), env.BundleName),
+		Handler:    controller.HandleAll(enqueueCA),
+	})
+
+	// cert-manager resources aren't watched, so that the controller doesn't
+	// depend on their CRDs, rotation requests are polled instead.
+	go func() {
+		ticker := time.NewTicker(env.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				impl.EnqueueKey(caKey)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return impl
+}
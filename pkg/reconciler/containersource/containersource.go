@@ -39,6 +39,8 @@ import (
 	listers "knative.dev/eventing/pkg/client/listers/sources/v1"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/reconciler/containersource/resources"
+	"knative.dev/eventing/pkg/reconciler/dryrun"
+	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 )
 
 const (
@@ -66,6 +68,9 @@ type Reconciler struct {
 	sinkBindingLister          listers.SinkBindingLister
 	deploymentLister           appsv1listers.DeploymentLister
 	trustBundleConfigMapLister corev1listers.ConfigMapLister
+
+	// deploymentConfig holds the settings applied to the Deployments.
+	deploymentConfig *reconcilersource.DeploymentConfigWatcher
 }
 
 // Check that our Reconciler implements Interface
@@ -73,22 +78,34 @@ var _ containersource.Interface = (*Reconciler)(nil)
 
 // ReconcileKind implements Interface.ReconcileKind.
 func (r *Reconciler) ReconcileKind(ctx context.Context, source *v1.ContainerSource) pkgreconciler.Event {
-	_, err := r.reconcileSinkBinding(ctx, source)
+	// In dry-run mode, the changes of the SinkBinding and the Deployment are
+	// published in a report instead of being applied.
+	var plan *dryrun.Plan
+	if dryrun.Enabled(source) {
+		plan = dryrun.NewPlan(source)
+	} else if err := dryrun.Cleanup(ctx, r.kubeClientSet, source); err != nil {
+		return err
+	}
+
+	_, err := r.reconcileSinkBinding(ctx, source, plan)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Error reconciling SinkBinding", zap.Error(err))
 		return err
 	}
 
-	_, err = r.reconcileReceiveAdapter(ctx, source)
+	_, err = r.reconcileReceiveAdapter(ctx, source, plan)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Error reconciling ReceiveAdapter", zap.Error(err))
 		return err
 	}
 
+	if plan != nil {
+		return dryrun.Publish(ctx, r.kubeClientSet, plan)
+	}
 	return newReconciledNormal(source.Namespace, source.Name)
 }
 
-func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *v1.ContainerSource) (*appsv1.Deployment, error) {
+func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *v1.ContainerSource, plan *dryrun.Plan) (*appsv1.Deployment, error) {
 	podTemplate, err := eventingtls.AddTrustBundleVolumes(r.trustBundleConfigMapLister, source, &source.Spec.Template.Spec)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add trust bundle volumes: %w", err)
@@ -97,9 +114,13 @@ func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *v1.Con
 	updatedSource := source.DeepCopy() // Avoid update Spec of the given object
 	updatedSource.Spec.Template.Spec = *podTemplate
 	expected := resources.MakeDeployment(updatedSource)
+	r.deploymentConfig.Config().Apply(&expected.Spec.Template.Spec, expected.Spec.Template.Labels)
 
 	ra, err := r.deploymentLister.Deployments(expected.Namespace).Get(expected.Name)
 	if apierrors.IsNotFound(err) {
+		if plan != nil {
+			return nil, plan.Record(dryrun.Create, "Deployment", expected)
+		}
 		ra, err = r.kubeClientSet.AppsV1().Deployments(expected.Namespace).Create(ctx, expected, metav1.CreateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("creating new Deployment: %v", err)
@@ -110,7 +131,11 @@ func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *v1.Con
 	} else if !metav1.IsControlledBy(ra, source) {
 		return nil, fmt.Errorf("deployment %q is not owned by ContainerSource %q", ra.Name, source.Name)
 	} else if r.podSpecChanged(&ra.Spec.Template.Spec, &expected.Spec.Template.Spec) {
+		ra = ra.DeepCopy()
 		ra.Spec.Template.Spec = expected.Spec.Template.Spec
+		if plan != nil {
+			return nil, plan.Record(dryrun.Update, "Deployment", ra)
+		}
 		ra, err = r.kubeClientSet.AppsV1().Deployments(expected.Namespace).Update(ctx, ra, metav1.UpdateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("updating Deployment: %v", err)
@@ -118,18 +143,26 @@ func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *v1.Con
 		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, deploymentUpdated, "Deployment updated %q", ra.Name)
 	} else {
 		logging.FromContext(ctx).Debugw("Reusing existing Deployment", zap.Any("Deployment", ra))
+		if plan != nil {
+			if err := plan.Record(dryrun.Unchanged, "Deployment", ra); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	source.Status.PropagateReceiveAdapterStatus(ra)
 	return ra, nil
 }
 
-func (r *Reconciler) reconcileSinkBinding(ctx context.Context, source *v1.ContainerSource) (*v1.SinkBinding, error) {
+func (r *Reconciler) reconcileSinkBinding(ctx context.Context, source *v1.ContainerSource, plan *dryrun.Plan) (*v1.SinkBinding, error) {
 
 	expected := resources.MakeSinkBinding(source)
 
 	sb, err := r.sinkBindingLister.SinkBindings(source.Namespace).Get(expected.Name)
 	if apierrors.IsNotFound(err) {
+		if plan != nil {
+			return nil, plan.Record(dryrun.Create, "SinkBinding", expected)
+		}
 		sb, err = r.eventingClientSet.SourcesV1().SinkBindings(source.Namespace).Create(ctx, expected, metav1.CreateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("creating new SinkBinding: %v", err)
@@ -140,7 +173,11 @@ func (r *Reconciler) reconcileSinkBinding(ctx context.Context, source *v1.Contai
 	} else if !metav1.IsControlledBy(sb, source) {
 		return nil, fmt.Errorf("SinkBinding %q is not owned by ContainerSource %q", sb.Name, source.Name)
 	} else if r.sinkBindingSpecChanged(&sb.Spec, &expected.Spec) {
+		sb = sb.DeepCopy()
 		sb.Spec = expected.Spec
+		if plan != nil {
+			return nil, plan.Record(dryrun.Update, "SinkBinding", sb)
+		}
 		sb, err = r.eventingClientSet.SourcesV1().SinkBindings(source.Namespace).Update(ctx, sb, metav1.UpdateOptions{})
 		if err != nil {
 			return nil, fmt.Errorf("updating SinkBinding: %v", err)
@@ -148,6 +185,11 @@ func (r *Reconciler) reconcileSinkBinding(ctx context.Context, source *v1.Contai
 		controller.GetEventRecorder(ctx).Eventf(source, corev1.EventTypeNormal, sinkBindingUpdated, "SinkBinding updated %q", sb.Name)
 	} else {
 		logging.FromContext(ctx).Debugw("Reusing existing SinkBinding", zap.Any("SinkBinding", sb))
+		if plan != nil {
+			if err := plan.Record(dryrun.Unchanged, "SinkBinding", sb); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	source.Status.PropagateSinkBindingStatus(&sb.Status)
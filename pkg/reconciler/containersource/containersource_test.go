@@ -41,6 +41,7 @@ import (
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/containersource"
 	"knative.dev/eventing/pkg/reconciler/containersource/resources"
+	"knative.dev/eventing/pkg/reconciler/dryrun"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
@@ -70,6 +71,9 @@ var (
 
 	conditionTrue = corev1.ConditionTrue
 
+	dryRunAnnotations = map[string]string{dryrun.Annotation: "true"}
+	dryRunReportName  = "containersource-" + sourceName + "-dry-run"
+
 	sinkDest = duckv1.Destination{
 		Ref: &duckv1.KReference{
 			Name:       sinkName,
@@ -159,6 +163,118 @@ func TestAllCases(t *testing.T) {
 					WithContainerSourceUID(sourceUID),
 				), nil),
 			},
+		}, {
+			Name: "dry-run, report created",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerUnobservedGeneration(),
+					WithContainerSourceStatusAnnotation(dryrun.ReportStatusAnnotation, dryRunReportName),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeDryRunReport(t, NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+				), dryrun.Create, dryrun.Create),
+			},
+		}, {
+			Name: "dry-run, existing sink binding and deployment",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				withImage(makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue), "old-image"),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourceStatusAnnotation(dryrun.ReportStatusAnnotation, dryRunReportName),
+				),
+			}},
+			WantCreates: []runtime.Object{
+				makeDryRunReport(t, NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceAnnotations(dryRunAnnotations),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+				), dryrun.Unchanged, dryrun.Update),
+			},
+		}, {
+			Name: "dry-run disabled, report deleted",
+			Objects: []runtime.Object{
+				NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithContainerSourceStatusAnnotation(dryrun.ReportStatusAnnotation, dryRunReportName),
+				),
+				makeSinkBinding(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+				makeDeployment(NewContainerSource(sourceName, testNS,
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceUID(sourceUID),
+				), &conditionTrue),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, sourceReconciled, `ContainerSource reconciled: "%s/%s"`, testNS, sourceName),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewContainerSource(sourceName, testNS,
+					WithContainerSourceUID(sourceUID),
+					WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+					WithContainerSourceObjectMetaGeneration(generation),
+					WithInitContainerSourceConditions,
+					WithContainerSourceStatusObservedGeneration(generation),
+					WithContainerSourcePropagateSinkbindingStatus(makeSinkBindingStatus(&conditionTrue)),
+					WithContainerSourcePropagateReceiveAdapterStatus(makeDeployment(NewContainerSource(sourceName, testNS,
+						WithContainerSourceSpec(makeContainerSourceSpec(sinkDest)),
+						WithContainerSourceUID(sourceUID),
+					), &conditionTrue)),
+				),
+			}},
+			WantDeletes: []clientgotesting.DeleteActionImpl{{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: testNS,
+					Verb:      "delete",
+					Resource:  corev1.SchemeGroupVersion.WithResource("configmaps"),
+				},
+				Name: dryRunReportName,
+			}},
 		}, {
 			Name: "successfully reconciled and not ready",
 			Objects: []runtime.Object{
@@ -294,6 +410,31 @@ func TestAllCases(t *testing.T) {
 	))
 }
 
+func makeDryRunReport(t *testing.T, source *sourcesv1.ContainerSource, sinkBinding, deployment dryrun.Action) *corev1.ConfigMap {
+	t.Helper()
+	plan := dryrun.NewPlan(source)
+	sb := makeSinkBinding(source, nil)
+	if sinkBinding == dryrun.Unchanged {
+		sb = makeSinkBinding(source, &conditionTrue)
+	}
+	if err := plan.Record(sinkBinding, "SinkBinding", sb); err != nil {
+		t.Fatal(err)
+	}
+	d := makeDeployment(source, nil)
+	if deployment == dryrun.Update {
+		d = makeDeployment(source, &conditionTrue)
+	}
+	if err := plan.Record(deployment, "Deployment", d); err != nil {
+		t.Fatal(err)
+	}
+	return plan.MakeReport()
+}
+
+func withImage(d *appsv1.Deployment, image string) *appsv1.Deployment {
+	d.Spec.Template.Spec.Containers[0].Image = image
+	return d
+}
+
 func makeSinkBinding(source *sourcesv1.ContainerSource, ready *corev1.ConditionStatus) *sourcesv1.SinkBinding {
 	sb := &sourcesv1.SinkBinding{
 		ObjectMeta: metav1.ObjectMeta{
@@ -39,6 +39,7 @@ import (
 	sinkbindinginformer "knative.dev/eventing/pkg/client/injection/informers/sources/v1/sinkbinding"
 	v1containersource "knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/containersource"
 	"knative.dev/eventing/pkg/eventingtls"
+	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 )
 
 // NewController creates a Reconciler for ContainerSource and returns the result of NewImpl.
@@ -70,6 +71,11 @@ func NewController(
 		deploymentLister:           deploymentInformer.Lister(),
 		sinkBindingLister:          sinkbindingInformer.Lister(),
 		trustBundleConfigMapLister: trustBundleConfigMapInformer.Lister(),
+		deploymentConfig: reconcilersource.WatchDeploymentConfig(ctx, cmw, func() {
+			if globalResync != nil {
+				globalResync(nil)
+			}
+		}),
 	}
 	impl := v1containersource.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
 		return controller.Options{ConfigStore: featureStore}
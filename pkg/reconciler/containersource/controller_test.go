@@ -37,6 +37,7 @@ import (
 	_ "knative.dev/eventing/pkg/client/injection/informers/sources/v1/containersource/fake"
 	_ "knative.dev/eventing/pkg/client/injection/informers/sources/v1/sinkbinding/fake"
 	"knative.dev/eventing/pkg/eventingtls"
+	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 )
 
 func TestNew(t *testing.T) {
@@ -48,6 +49,11 @@ func TestNew(t *testing.T) {
 				Name: feature.FlagsConfigName,
 			},
 		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: reconcilersource.DeploymentConfigName,
+			},
+		},
 	))
 
 	if c == nil {
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	clientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+)
+
+const (
+	// ActiveTriggersReason is the reason of the DeletionBlocked condition of
+	// the Brokers whose deletion waits for their Triggers.
+	ActiveTriggersReason = "ActiveTriggers"
+)
+
+// BrokerReconciler keeps the deletion protection finalizer on the Brokers,
+// and removes it from those being deleted once no Trigger uses them.
+type BrokerReconciler struct {
+	eventingClientSet clientset.Interface
+	brokerLister      eventinglisters.BrokerLister
+	triggerLister     eventinglisters.TriggerLister
+	featureStore      *feature.Store
+}
+
+// Reconcile implements controller.Reconciler.
+func (r *BrokerReconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Errorw("Invalid resource key", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	b, err := r.brokerLister.Brokers(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	enabled := isEnabled(ctx, r.featureStore)
+	if !hasFinalizer(b) {
+		if enabled && b.DeletionTimestamp.IsZero() {
+			return r.patchFinalizer(ctx, b, true)
+		}
+		return nil
+	}
+	if !enabled {
+		return r.patchFinalizer(ctx, b, false)
+	}
+	if b.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	triggers, err := r.activeTriggers(b)
+	if err != nil {
+		return err
+	}
+	if len(triggers) == 0 {
+		logger.Infow("Releasing the deletion of the Broker", zap.String("broker", key))
+		return r.patchFinalizer(ctx, b, false)
+	}
+
+	desired := b.DeepCopy()
+	desired.Status.MarkDeletionBlocked(ActiveTriggersReason,
+		"The Broker is still used by %d Trigger(s): %s", len(triggers), describeDependents(triggers))
+	if equality.Semantic.DeepEqual(b.Status, desired.Status) {
+		return nil
+	}
+	_, err = r.eventingClientSet.EventingV1().Brokers(namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+// activeTriggers returns the names of the Triggers of b which aren't being
+// deleted, prefixed with their namespace when it isn't the one of b.
+func (r *BrokerReconciler) activeTriggers(b *eventingv1.Broker) ([]string, error) {
+	triggers, err := r.triggerLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, t := range triggers {
+		if !t.DeletionTimestamp.IsZero() || triggerBroker(t) != (types.NamespacedName{Namespace: b.Namespace, Name: b.Name}) {
+			continue
+		}
+		if t.Namespace == b.Namespace {
+			names = append(names, t.Name)
+		} else {
+			names = append(names, t.Namespace+"/"+t.Name)
+		}
+	}
+	return names, nil
+}
+
+func (r *BrokerReconciler) patchFinalizer(ctx context.Context, b *eventingv1.Broker, add bool) error {
+	patch, err := finalizersPatch(b, add)
+	if err != nil {
+		return fmt.Errorf("failed to create the finalizers patch: %w", err)
+	}
+	_, err = r.eventingClientSet.EventingV1().Brokers(b.Namespace).Patch(ctx, b.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// triggerBroker returns the Broker the Trigger t refers to.
+func triggerBroker(t *eventingv1.Trigger) types.NamespacedName {
+	if ref := t.Spec.BrokerRef; ref != nil && ref.Name != "" {
+		ns := ref.Namespace
+		if ns == "" {
+			ns = t.Namespace
+		}
+		return types.NamespacedName{Namespace: ns, Name: ref.Name}
+	}
+	return types.NamespacedName{Namespace: t.Namespace, Name: t.Spec.Broker}
+}
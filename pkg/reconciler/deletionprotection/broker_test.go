@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	testNS     = "test-namespace"
+	brokerName = "test-broker"
+)
+
+var (
+	brokerGVK = metav1.GroupVersionKind{
+		Group:   "eventing.knative.dev",
+		Version: "v1",
+		Kind:    "Broker",
+	}
+
+	protectionEnabled = feature.ToContext(context.Background(), feature.Flags{
+		feature.DeletionProtection: feature.Enabled,
+	})
+)
+
+func TestBrokerReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: testNS + "/" + brokerName,
+		Ctx: protectionEnabled,
+	}, {
+		Name: "disabled",
+		Key:  testNS + "/" + brokerName,
+		Objects: []runtime.Object{
+			newBroker(),
+		},
+	}, {
+		Name: "enabled, adds the finalizer",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(WithBrokerFinalizers("other")),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, `["other","`+FinalizerName+`"]`),
+		},
+	}, {
+		Name: "enabled, finalizer already set",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(WithBrokerFinalizers(FinalizerName)),
+			NewTrigger("trigger", testNS, brokerName),
+		},
+	}, {
+		Name: "disabled, removes the finalizer",
+		Key:  testNS + "/" + brokerName,
+		Objects: []runtime.Object{
+			newBroker(
+				WithBrokerFinalizers("other", FinalizerName),
+				WithBrokerDeletionTimestamp),
+			NewTrigger("trigger", testNS, brokerName),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, `["other"]`),
+		},
+	}, {
+		Name: "deleted without the finalizer",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(WithBrokerDeletionTimestamp),
+			NewTrigger("trigger", testNS, brokerName),
+		},
+	}, {
+		Name: "deleted with active triggers",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(
+				WithBrokerFinalizers(FinalizerName),
+				WithBrokerDeletionTimestamp),
+			NewTrigger("trigger", testNS, brokerName),
+			NewTriggerWithBrokerRef("remote", "other-namespace",
+				WithTriggerBrokerRef(brokerGVK, brokerName, testNS)),
+			NewTrigger("deleted", testNS, brokerName, WithTriggerDeleted),
+			NewTrigger("other-broker", testNS, "other"),
+			NewTrigger("other-namespace", "other-namespace", brokerName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newBroker(
+				WithBrokerFinalizers(FinalizerName),
+				WithBrokerDeletionTimestamp,
+				WithBrokerDeletionBlocked(ActiveTriggersReason, "The Broker is still used by 2 Trigger(s): other-namespace/remote, trigger")),
+		}},
+	}, {
+		Name: "deletion already blocked",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(
+				WithBrokerFinalizers(FinalizerName),
+				WithBrokerDeletionTimestamp,
+				WithBrokerDeletionBlocked(ActiveTriggersReason, "The Broker is still used by 1 Trigger(s): trigger")),
+			NewTrigger("trigger", testNS, brokerName),
+		},
+	}, {
+		Name: "deleted without active triggers",
+		Key:  testNS + "/" + brokerName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newBroker(
+				WithBrokerFinalizers(FinalizerName),
+				WithBrokerDeletionTimestamp,
+				WithBrokerDeletionBlocked(ActiveTriggersReason, "The Broker is still used by 1 Trigger(s): trigger")),
+			NewTrigger("trigger", testNS, brokerName, WithTriggerDeleted),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, brokerName, `[]`),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		return &BrokerReconciler{
+			eventingClientSet: fakeeventingclient.Get(ctx),
+			brokerLister:      listers.GetBrokerLister(),
+			triggerLister:     listers.GetTriggerLister(),
+		}
+	},
+		false,
+		logger,
+	))
+}
+
+func patchFinalizers(namespace, name, finalizers string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	patch := `{"metadata":{"finalizers":` + finalizers + `,"resourceVersion":""}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func newBroker(opts ...BrokerOption) *eventingv1.Broker {
+	return NewBroker(brokerName, testNS, append([]BrokerOption{WithBrokerClass(eventing.MTChannelBrokerClassValue)}, opts...)...)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	clientset "knative.dev/eventing/pkg/client/clientset/versioned"
+	messaginglisters "knative.dev/eventing/pkg/client/listers/messaging/v1"
+)
+
+const (
+	// ActiveSubscriptionsReason is the reason of the DeletionBlocked
+	// condition of the Channels whose deletion waits for their Subscriptions.
+	ActiveSubscriptionsReason = "ActiveSubscriptions"
+)
+
+// ChannelReconciler keeps the deletion protection finalizer on the Channels,
+// and removes it from those being deleted once no Subscription uses them.
+type ChannelReconciler struct {
+	eventingClientSet  clientset.Interface
+	channelLister      messaginglisters.ChannelLister
+	subscriptionLister messaginglisters.SubscriptionLister
+	featureStore       *feature.Store
+}
+
+// Reconcile implements controller.Reconciler.
+func (r *ChannelReconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		logger.Errorw("Invalid resource key", zap.String("key", key), zap.Error(err))
+		return nil
+	}
+	c, err := r.channelLister.Channels(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	enabled := isEnabled(ctx, r.featureStore)
+	if !hasFinalizer(c) {
+		if enabled && c.DeletionTimestamp.IsZero() {
+			return r.patchFinalizer(ctx, c, true)
+		}
+		return nil
+	}
+	if !enabled {
+		return r.patchFinalizer(ctx, c, false)
+	}
+	if c.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	subscriptions, err := r.activeSubscriptions(c)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		logger.Infow("Releasing the deletion of the Channel", zap.String("channel", key))
+		return r.patchFinalizer(ctx, c, false)
+	}
+
+	desired := c.DeepCopy()
+	desired.Status.MarkDeletionBlocked(ActiveSubscriptionsReason,
+		"The Channel is still used by %d Subscription(s): %s", len(subscriptions), describeDependents(subscriptions))
+	if equality.Semantic.DeepEqual(c.Status, desired.Status) {
+		return nil
+	}
+	_, err = r.eventingClientSet.MessagingV1().Channels(namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+// activeSubscriptions returns the names of the Subscriptions of c which
+// aren't being deleted, prefixed with their namespace when it isn't the one
+// of c.
+func (r *ChannelReconciler) activeSubscriptions(c *messagingv1.Channel) ([]string, error) {
+	subscriptions, err := r.subscriptionLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, s := range subscriptions {
+		if !s.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if ch, ok := subscriptionChannel(s); !ok || ch != (types.NamespacedName{Namespace: c.Namespace, Name: c.Name}) {
+			continue
+		}
+		if s.Namespace == c.Namespace {
+			names = append(names, s.Name)
+		} else {
+			names = append(names, s.Namespace+"/"+s.Name)
+		}
+	}
+	return names, nil
+}
+
+func (r *ChannelReconciler) patchFinalizer(ctx context.Context, c *messagingv1.Channel, add bool) error {
+	patch, err := finalizersPatch(c, add)
+	if err != nil {
+		return fmt.Errorf("failed to create the finalizers patch: %w", err)
+	}
+	_, err = r.eventingClientSet.MessagingV1().Channels(c.Namespace).Patch(ctx, c.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// subscriptionChannel returns the Channel the Subscription s refers to, and
+// false when s refers to another kind of channel.
+func subscriptionChannel(s *messagingv1.Subscription) (types.NamespacedName, bool) {
+	ref := s.Spec.Channel
+	group := ref.Group
+	if ref.APIVersion != "" {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return types.NamespacedName{}, false
+		}
+		group = gv.Group
+	}
+	if group != messagingv1.SchemeGroupVersion.Group || ref.Kind != "Channel" {
+		return types.NamespacedName{}, false
+	}
+	ns := ref.Namespace
+	if ns == "" {
+		ns = s.Namespace
+	}
+	return types.NamespacedName{Namespace: ns, Name: ref.Name}, true
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const channelName = "test-channel"
+
+var (
+	channelGVK = metav1.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1",
+		Kind:    "Channel",
+	}
+
+	imcGVK = metav1.GroupVersionKind{
+		Group:   "messaging.knative.dev",
+		Version: "v1",
+		Kind:    "InMemoryChannel",
+	}
+)
+
+func TestChannelReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: testNS + "/" + channelName,
+		Ctx: protectionEnabled,
+	}, {
+		Name: "disabled",
+		Key:  testNS + "/" + channelName,
+		Objects: []runtime.Object{
+			newChannel(),
+		},
+	}, {
+		Name: "enabled, adds the finalizer",
+		Key:  testNS + "/" + channelName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newChannel(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, channelName, `["`+FinalizerName+`"]`),
+		},
+	}, {
+		Name: "disabled, removes the finalizer",
+		Key:  testNS + "/" + channelName,
+		Objects: []runtime.Object{
+			newChannel(
+				WithChannelFinalizers(FinalizerName, "other"),
+				WithChannelDeleted),
+			NewSubscription("subscription", testNS,
+				WithSubscriptionChannel(channelGVK, channelName)),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, channelName, `["other"]`),
+		},
+	}, {
+		Name: "deleted with active subscriptions",
+		Key:  testNS + "/" + channelName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newChannel(
+				WithChannelFinalizers(FinalizerName),
+				WithChannelDeleted),
+			NewSubscription("subscription", testNS,
+				WithSubscriptionChannel(channelGVK, channelName)),
+			NewSubscription("grouped", testNS,
+				WithSubscriptionChannelUsingGroup(channelGVK, channelName)),
+			NewSubscription("remote", "other-namespace",
+				WithSubscriptionChannelRef(channelGVK, channelName, testNS)),
+			NewSubscription("deleted", testNS,
+				WithSubscriptionChannel(channelGVK, channelName),
+				WithSubscriptionDeleted),
+			NewSubscription("imc", testNS,
+				WithSubscriptionChannel(imcGVK, channelName)),
+			NewSubscription("other-channel", testNS,
+				WithSubscriptionChannel(channelGVK, "other")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newChannel(
+				WithChannelFinalizers(FinalizerName),
+				WithChannelDeleted,
+				WithChannelDeletionBlocked(ActiveSubscriptionsReason, "The Channel is still used by 3 Subscription(s): grouped, other-namespace/remote, subscription")),
+		}},
+	}, {
+		Name: "deletion already blocked",
+		Key:  testNS + "/" + channelName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newChannel(
+				WithChannelFinalizers(FinalizerName),
+				WithChannelDeleted,
+				WithChannelDeletionBlocked(ActiveSubscriptionsReason, "The Channel is still used by 1 Subscription(s): subscription")),
+			NewSubscription("subscription", testNS,
+				WithSubscriptionChannel(channelGVK, channelName)),
+		},
+	}, {
+		Name: "deleted without active subscriptions",
+		Key:  testNS + "/" + channelName,
+		Ctx:  protectionEnabled,
+		Objects: []runtime.Object{
+			newChannel(
+				WithChannelFinalizers(FinalizerName),
+				WithChannelDeleted),
+			NewSubscription("subscription", testNS,
+				WithSubscriptionChannel(channelGVK, channelName),
+				WithSubscriptionDeleted),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(testNS, channelName, `[]`),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		return &ChannelReconciler{
+			eventingClientSet:  fakeeventingclient.Get(ctx),
+			channelLister:      listers.GetMessagingChannelLister(),
+			subscriptionLister: listers.GetSubscriptionLister(),
+		}
+	},
+		false,
+		logger,
+	))
+}
+
+func newChannel(opts ...ChannelOption) *messagingv1.Channel {
+	return NewChannel(channelName, testNS, append([]ChannelOption{WithChannelTemplate(metav1.TypeMeta{
+		APIVersion: "messaging.knative.dev/v1",
+		Kind:       "InMemoryChannel",
+	})}, opts...)...)
+}
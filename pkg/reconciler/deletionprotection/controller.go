@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/eventing/pkg/apis/feature"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	brokerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker"
+	triggerinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger"
+	channelinformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/channel"
+	subscriptioninformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription"
+)
+
+const (
+	// BrokerReconcilerName is the name of the Broker deletion protection
+	// reconciler.
+	BrokerReconcilerName = "BrokerDeletionProtection"
+
+	// ChannelReconcilerName is the name of the Channel deletion protection
+	// reconciler.
+	ChannelReconcilerName = "ChannelDeletionProtection"
+)
+
+// NewBrokerController initializes the controller protecting the Brokers and
+// is called by the generated code.
+func NewBrokerController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	brokerInformer := brokerinformer.Get(ctx)
+	triggerInformer := triggerinformer.Get(ctx)
+
+	r := &BrokerReconciler{
+		eventingClientSet: eventingclient.Get(ctx),
+		brokerLister:      brokerInformer.Lister(),
+		triggerLister:     triggerInformer.Lister(),
+	}
+
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		Logger: logger, WorkQueueName: BrokerReconcilerName,
+	})
+
+	// Add or remove the finalizers when the feature is toggled.
+	r.featureStore = feature.NewStore(logger.Named("feature-config-store"), func(string, interface{}) {
+		impl.GlobalResync(brokerInformer.Informer())
+	})
+	r.featureStore.WatchConfigs(cmw)
+
+	brokerInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Deleting a Trigger may release the deletion of its Broker.
+	triggerInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		if t, ok := tombstoneObj(obj).(*eventingv1.Trigger); ok {
+			impl.EnqueueKey(triggerBroker(t))
+		}
+	}))
+
+	return impl
+}
+
+// NewChannelController initializes the controller protecting the Channels
+// and is called by the generated code.
+func NewChannelController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	channelInformer := channelinformer.Get(ctx)
+	subscriptionInformer := subscriptioninformer.Get(ctx)
+
+	r := &ChannelReconciler{
+		eventingClientSet:  eventingclient.Get(ctx),
+		channelLister:      channelInformer.Lister(),
+		subscriptionLister: subscriptionInformer.Lister(),
+	}
+
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		Logger: logger, WorkQueueName: ChannelReconcilerName,
+	})
+
+	// Add or remove the finalizers when the feature is toggled.
+	r.featureStore = feature.NewStore(logger.Named("feature-config-store"), func(string, interface{}) {
+		impl.GlobalResync(channelInformer.Informer())
+	})
+	r.featureStore.WatchConfigs(cmw)
+
+	channelInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Deleting a Subscription may release the deletion of its Channel.
+	subscriptionInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		if s, ok := tombstoneObj(obj).(*messagingv1.Subscription); ok {
+			if ch, ok := subscriptionChannel(s); ok {
+				impl.EnqueueKey(ch)
+			}
+		}
+	}))
+
+	return impl
+}
+
+// tombstoneObj returns the deleted object of a tombstone, or obj when it
+// isn't one.
+func tombstoneObj(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
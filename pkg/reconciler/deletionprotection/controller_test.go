@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/configmap"
+
+	"knative.dev/eventing/pkg/apis/feature"
+
+	. "knative.dev/pkg/reconciler/testing"
+
+	// Fake injection informers
+	_ "knative.dev/eventing/pkg/client/injection/client/fake"
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/broker/fake"
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1/trigger/fake"
+	_ "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/channel/fake"
+	_ "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/subscription/fake"
+)
+
+func TestNewBrokerController(t *testing.T) {
+	ctx, _ := SetupFakeContext(t)
+
+	c := NewBrokerController(ctx, newConfigWatcher())
+
+	if c == nil {
+		t.Fatal("Expected NewBrokerController to return a non-nil value")
+	}
+}
+
+func TestNewChannelController(t *testing.T) {
+	ctx, _ := SetupFakeContext(t)
+
+	c := NewChannelController(ctx, newConfigWatcher())
+
+	if c == nil {
+		t.Fatal("Expected NewChannelController to return a non-nil value")
+	}
+}
+
+func newConfigWatcher() configmap.Watcher {
+	return configmap.NewStaticWatcher(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      feature.FlagsConfigName,
+			Namespace: "knative-eventing",
+		},
+	})
+}
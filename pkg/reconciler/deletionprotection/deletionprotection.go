@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deletionprotection blocks the deletion of the Brokers and Channels
+// still used by Triggers and Subscriptions when the deletion-protection
+// feature is enabled, so that their events aren't lost by accident.
+package deletionprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"knative.dev/eventing/pkg/apis/feature"
+)
+
+const (
+	// FinalizerName is the finalizer blocking the deletion of the protected
+	// resources.
+	FinalizerName = "eventing.knative.dev/deletion-protection"
+
+	// maxListedDependents is the maximum number of dependents named in the
+	// DeletionBlocked condition.
+	maxListedDependents = 5
+)
+
+// isEnabled returns true when the deletion-protection feature is enabled in
+// the features of ctx or, when set, of the store.
+func isEnabled(ctx context.Context, store *feature.Store) bool {
+	if store != nil {
+		ctx = store.ToContext(ctx)
+	}
+	return feature.FromContext(ctx).IsEnabled(feature.DeletionProtection)
+}
+
+// hasFinalizer returns true when obj has the deletion protection finalizer.
+func hasFinalizer(obj metav1.Object) bool {
+	return sets.New(obj.GetFinalizers()...).Has(FinalizerName)
+}
+
+// finalizersPatch returns the merge patch adding or removing the deletion
+// protection finalizer of obj. The resource version makes the patch fail
+// when obj is stale.
+func finalizersPatch(obj metav1.Object, add bool) ([]byte, error) {
+	finalizers := make([]string, 0, len(obj.GetFinalizers())+1)
+	for _, f := range obj.GetFinalizers() {
+		if f != FinalizerName {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if add {
+		finalizers = append(finalizers, FinalizerName)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": obj.GetResourceVersion(),
+		},
+	})
+}
+
+// describeDependents returns the sorted names of the dependents, truncated to
+// maxListedDependents.
+func describeDependents(names []string) string {
+	sort.Strings(names)
+	if len(names) <= maxListedDependents {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxListedDependents], ", "), len(names)-maxListedDependents)
+}
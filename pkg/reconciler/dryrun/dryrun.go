@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun implements the dry-run mode of the reconcilers. The
+// reconcilers of the resources annotated with knative.dev/dry-run: "true"
+// don't create or update their child resources, they publish the changes
+// they would apply in a report ConfigMap instead.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Annotation enables the dry-run mode of a resource when set to "true".
+	Annotation = "knative.dev/dry-run"
+
+	// ReportStatusAnnotation is the status annotation of the resources in
+	// dry-run mode with the name of their report ConfigMap.
+	ReportStatusAnnotation = "knative.dev/dry-run-report"
+
+	// ReportLabel labels the report ConfigMaps.
+	ReportLabel = "eventing.knative.dev/dry-run-report"
+)
+
+// Action is the action a reconciler would apply to a child resource.
+type Action string
+
+const (
+	// Create is the creation of a missing child resource.
+	Create Action = "create"
+	// Update is the update of an existing child resource.
+	Update Action = "update"
+	// Unchanged is an existing child resource which is up to date.
+	Unchanged Action = "unchanged"
+)
+
+// Owner is a resource whose reconciler supports the dry-run mode.
+type Owner interface {
+	kmeta.OwnerRefable
+	GetStatus() *duckv1.Status
+}
+
+// Enabled returns true when the resource is in dry-run mode.
+func Enabled(obj metav1.Object) bool {
+	return strings.EqualFold(obj.GetAnnotations()[Annotation], "true")
+}
+
+// Plan is the set of changes a reconciler would apply to the child resources
+// of a resource.
+type Plan struct {
+	owner   Owner
+	changes map[string]string
+}
+
+// NewPlan returns an empty plan of the changes of the child resources of the
+// owner.
+func NewPlan(owner Owner) *Plan {
+	return &Plan{
+		owner:   owner,
+		changes: make(map[string]string),
+	}
+}
+
+// Record records the action the reconciler would apply to the child resource
+// of the given kind, along with the resulting resource.
+func (p *Plan) Record(action Action, kind string, obj metav1.Object) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s %q: %w", kind, obj.GetName(), err)
+	}
+	p.changes[Key(action, kind, obj.GetName())] = string(b)
+	return nil
+}
+
+// Key returns the key of the change of a child resource in the report
+// ConfigMap, as in create.deployment.my-source.
+func Key(action Action, kind, name string) string {
+	return string(action) + "." + strings.ToLower(kind) + "." + name
+}
+
+// ReportName returns the name of the report ConfigMap of a resource.
+func ReportName(owner Owner) string {
+	return kmeta.ChildName(strings.ToLower(owner.GetGroupVersionKind().Kind)+"-"+owner.GetObjectMeta().GetName(), "-dry-run")
+}
+
+// MakeReport returns the report ConfigMap of the plan, owned by the resource.
+func (p *Plan) MakeReport() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ReportName(p.owner),
+			Namespace:       p.owner.GetObjectMeta().GetNamespace(),
+			Labels:          map[string]string{ReportLabel: "true"},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(p.owner)},
+		},
+		Data: p.changes,
+	}
+}
+
+// Publish creates or updates the report ConfigMap of the plan, and records
+// its name in the status annotations of the resource.
+func Publish(ctx context.Context, kubeClient kubernetes.Interface, p *Plan) error {
+	expected := p.MakeReport()
+	configMaps := kubeClient.CoreV1().ConfigMaps(expected.Namespace)
+
+	cm, err := configMaps.Get(ctx, expected.Name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		if _, err := configMaps.Create(ctx, expected, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create the dry-run report %q: %w", expected.Name, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get the dry-run report %q: %w", expected.Name, err)
+	case !metav1.IsControlledBy(cm, p.owner.GetObjectMeta()):
+		return fmt.Errorf("ConfigMap %q is not owned by %s %q", cm.Name, p.owner.GetGroupVersionKind().Kind, p.owner.GetObjectMeta().GetName())
+	case !equality.Semantic.DeepEqual(cm.Data, expected.Data):
+		cm = cm.DeepCopy()
+		cm.Data = expected.Data
+		if _, err := configMaps.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update the dry-run report %q: %w", expected.Name, err)
+		}
+	}
+
+	status := p.owner.GetStatus()
+	if status.Annotations == nil {
+		status.Annotations = make(map[string]string, 1)
+	}
+	status.Annotations[ReportStatusAnnotation] = expected.Name
+	return nil
+}
+
+// Cleanup deletes the report ConfigMap of a resource which is no longer in
+// dry-run mode.
+func Cleanup(ctx context.Context, kubeClient kubernetes.Interface, owner Owner) error {
+	status := owner.GetStatus()
+	name, ok := status.Annotations[ReportStatusAnnotation]
+	if !ok {
+		return nil
+	}
+	err := kubeClient.CoreV1().ConfigMaps(owner.GetObjectMeta().GetNamespace()).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the dry-run report %q: %w", name, err)
+	}
+	delete(status.Annotations, ReportStatusAnnotation)
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+)
+
+func TestEnabled(t *testing.T) {
+	for value, want := range map[string]bool{"true": true, "True": true, "false": false, "": false} {
+		obj := &metav1.ObjectMeta{Annotations: map[string]string{Annotation: value}}
+		if got := Enabled(obj); got != want {
+			t.Errorf("Enabled(%q) = %t, want %t", value, got, want)
+		}
+	}
+}
+
+func TestPublishAndCleanup(t *testing.T) {
+	ctx := context.Background()
+	source := &sourcesv1.ContainerSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source", UID: "uid"},
+	}
+	kubeClient := fakekubeclientset.NewSimpleClientset()
+
+	plan := NewPlan(source)
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source-deployment"}}
+	if err := plan.Record(Create, "Deployment", deployment); err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, kubeClient, plan); err != nil {
+		t.Fatal("Publish() =", err)
+	}
+
+	name := source.Status.Annotations[ReportStatusAnnotation]
+	if name != "containersource-source-dry-run" {
+		t.Errorf("Unexpected report name %q", name)
+	}
+	cm, err := kubeClient.CoreV1().ConfigMaps("ns").Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Data["create.deployment.source-deployment"]; !ok || len(cm.Data) != 1 {
+		t.Errorf("Unexpected report %v", cm.Data)
+	}
+	if !metav1.IsControlledBy(cm, source) {
+		t.Error("Expected the report to be owned by the source")
+	}
+
+	// The report is updated with the new plan.
+	plan = NewPlan(source)
+	if err := plan.Record(Unchanged, "Deployment", deployment); err != nil {
+		t.Fatal(err)
+	}
+	if err := Publish(ctx, kubeClient, plan); err != nil {
+		t.Fatal("Publish() =", err)
+	}
+	cm, err = kubeClient.CoreV1().ConfigMaps("ns").Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Data["unchanged.deployment.source-deployment"]; !ok || len(cm.Data) != 1 {
+		t.Errorf("Unexpected report %v", cm.Data)
+	}
+
+	if err := Cleanup(ctx, kubeClient, source); err != nil {
+		t.Fatal("Cleanup() =", err)
+	}
+	if _, ok := source.Status.Annotations[ReportStatusAnnotation]; ok {
+		t.Error("Expected the report status annotation to be removed")
+	}
+	if _, err := kubeClient.CoreV1().ConfigMaps("ns").Get(ctx, name, metav1.GetOptions{}); err == nil {
+		t.Error("Expected the report to be deleted")
+	}
+}
+
+func TestPublishNotOwned(t *testing.T) {
+	source := &sourcesv1.ContainerSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "source", UID: "uid"},
+	}
+	kubeClient := fakekubeclientset.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "containersource-source-dry-run"},
+	})
+	if err := Publish(context.Background(), kubeClient, NewPlan(source)); err == nil {
+		t.Error("Expected an error publishing into a ConfigMap which isn't owned by the source")
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemission
+
+import (
+	"context"
+
+	"github.com/kelseyhightower/envconfig"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	cronjobinformer "knative.dev/pkg/client/injection/kube/informers/batch/v1/cronjob/filtered"
+	jobinformer "knative.dev/pkg/client/injection/kube/informers/batch/v1/job/filtered"
+	podinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
+	serviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/filtered"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	eventemissioninformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/eventemission"
+	eventemissionreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventemission"
+	"knative.dev/eventing/pkg/reconciler/eventemission/resources"
+)
+
+// envConfig will be used to extract the required environment variables using
+// github.com/kelseyhightower/envconfig. If this configuration cannot be extracted, then
+// NewController will panic.
+type envConfig struct {
+	Image string `envconfig:"EVENT_EMITTER_IMAGE" required:"true"`
+}
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	eventEmissionInformer := eventemissioninformer.Get(ctx)
+	jobInformer := jobinformer.Get(ctx, resources.LabelSelector)
+	cronJobInformer := cronjobinformer.Get(ctx, resources.LabelSelector)
+	podInformer := podinformer.Get(ctx, resources.LabelSelector)
+	oidcServiceaccountInformer := serviceaccountinformer.Get(ctx, auth.OIDCLabelSelector)
+
+	var globalResync func(obj interface{})
+
+	featureStore := feature.NewStore(logging.FromContext(ctx).Named("feature-config-store"), func(name string, value interface{}) {
+		if globalResync != nil {
+			globalResync(nil)
+		}
+	})
+	featureStore.WatchConfigs(cmw)
+
+	env := &envConfig{}
+	if err := envconfig.Process("", env); err != nil {
+		logging.FromContext(ctx).Panicf("unable to process EventEmission's required environment variables: %v", err)
+	}
+
+	r := &Reconciler{
+		kubeClientSet:        kubeclient.Get(ctx),
+		emitterImage:         env.Image,
+		jobLister:            jobInformer.Lister(),
+		cronJobLister:        cronJobInformer.Lister(),
+		podLister:            podInformer.Lister(),
+		serviceAccountLister: oidcServiceaccountInformer.Lister(),
+	}
+
+	impl := eventemissionreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{
+			ConfigStore: featureStore,
+		}
+	})
+
+	globalResync = func(interface{}) {
+		impl.GlobalResync(eventEmissionInformer.Informer())
+	}
+
+	r.sinkResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+
+	eventEmissionInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&v1alpha1.EventEmission{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	cronJobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&v1alpha1.EventEmission{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Pods are owned by Jobs, so they are mapped back to their EventEmission
+	// through the name label.
+	podInformer.Informer().AddEventHandler(controller.HandleAll(func(i interface{}) {
+		obj, err := kmeta.DeletionHandlingAccessor(i)
+		if err != nil {
+			return
+		}
+		name, ok := obj.GetLabels()[resources.EventEmissionNameLabelKey]
+		if !ok {
+			return
+		}
+		impl.EnqueueKey(types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      name,
+		})
+	}))
+
+	oidcServiceaccountInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&v1alpha1.EventEmission{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	eventemissionreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventemission"
+	"knative.dev/eventing/pkg/reconciler/eventemission/resources"
+)
+
+const (
+	// Name of the corev1.Events emitted from the reconciliation process
+	eventEmissionJobCreated     = "EventEmissionJobCreated"
+	eventEmissionCronJobCreated = "EventEmissionCronJobCreated"
+	eventEmissionCronJobUpdated = "EventEmissionCronJobUpdated"
+)
+
+func newWarningSinkNotFound(sink *duckv1.Destination) pkgreconciler.Event {
+	b, _ := json.Marshal(sink)
+	return pkgreconciler.NewEvent(corev1.EventTypeWarning, "SinkNotFound", "Sink not found: %s", string(b))
+}
+
+// Reconciler reconciles EventEmission objects.
+type Reconciler struct {
+	kubeClientSet kubernetes.Interface
+
+	emitterImage string
+	sinkResolver *resolver.URIResolver
+
+	jobLister            batchv1listers.JobLister
+	cronJobLister        batchv1listers.CronJobLister
+	podLister            corev1listers.PodLister
+	serviceAccountLister corev1listers.ServiceAccountLister
+}
+
+var _ eventemissionreconciler.Interface = (*Reconciler)(nil)
+
+func (r *Reconciler) ReconcileKind(ctx context.Context, ee *v1alpha1.EventEmission) pkgreconciler.Event {
+	featureFlags := feature.FromContext(ctx)
+	if err := auth.SetupOIDCServiceAccount(ctx, featureFlags, r.serviceAccountLister, r.kubeClientSet, v1alpha1.SchemeGroupVersion.WithKind("EventEmission"), ee.ObjectMeta, &ee.Status, func(as *duckv1.AuthStatus) {
+		ee.Status.Auth = as
+	}); err != nil {
+		return err
+	}
+
+	dest := ee.Spec.Sink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = ee.GetNamespace()
+	}
+
+	sinkAddr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, ee)
+	if err != nil {
+		ee.Status.MarkNoSink("NotFound", "")
+		return newWarningSinkNotFound(dest)
+	}
+	ee.Status.MarkSink(sinkAddr)
+
+	args := &resources.EmitterArgs{
+		Image:         r.emitterImage,
+		EventEmission: ee,
+		SinkURI:       sinkAddr.URL.String(),
+		CACerts:       sinkAddr.CACerts,
+		Audience:      sinkAddr.Audience,
+		NodeSelector:  featureFlags.NodeSelector(),
+	}
+
+	var selector labels.Selector
+	if ee.Spec.Schedule == "" {
+		if err := r.deleteCronJob(ctx, ee); err != nil {
+			return err
+		}
+		if err := r.reconcileJob(ctx, ee, args); err != nil {
+			logging.FromContext(ctx).Errorw("Unable to reconcile the emission Job", zap.Error(err))
+			return err
+		}
+		selector = labels.SelectorFromSet(resources.JobLabels(ee.Name, ee.Generation))
+	} else {
+		if err := r.deleteJobs(ctx, ee, ""); err != nil {
+			return err
+		}
+		if err := r.reconcileCronJob(ctx, ee, args); err != nil {
+			logging.FromContext(ctx).Errorw("Unable to reconcile the emission CronJob", zap.Error(err))
+			return err
+		}
+		selector = labels.SelectorFromSet(resources.Labels(ee.Name))
+	}
+
+	result, err := r.lastEmissionResult(ee.Namespace, selector)
+	if err != nil {
+		return fmt.Errorf("failed to get the last emission result: %w", err)
+	}
+	if result == nil {
+		ee.Status.LastEmission = nil
+		ee.Status.MarkEmissionPending("EmissionPending", "No emission has completed yet")
+		return nil
+	}
+	ee.Status.PropagateEmissionResult(result)
+
+	return nil
+}
+
+func (r *Reconciler) reconcileJob(ctx context.Context, ee *v1alpha1.EventEmission, args *resources.EmitterArgs) error {
+	expected, err := resources.MakeJob(args)
+	if err != nil {
+		return err
+	}
+
+	job, err := r.jobLister.Jobs(ee.Namespace).Get(expected.Name)
+	if apierrors.IsNotFound(err) {
+		job, err = r.kubeClientSet.BatchV1().Jobs(ee.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("creating new Job: %w", err)
+		}
+		controller.GetEventRecorder(ctx).Eventf(ee, corev1.EventTypeNormal, eventEmissionJobCreated, "Job created %q", job.Name)
+	} else if err != nil {
+		return fmt.Errorf("getting Job: %w", err)
+	} else if !metav1.IsControlledBy(job, ee) {
+		return fmt.Errorf("job %q is not owned by EventEmission %q", job.Name, ee.Name)
+	}
+
+	// Jobs of previous generations have already sent their event.
+	return r.deleteJobs(ctx, ee, job.Name)
+}
+
+// deleteJobs deletes the one-shot Jobs of the EventEmission, except the one
+// with the given name.
+func (r *Reconciler) deleteJobs(ctx context.Context, ee *v1alpha1.EventEmission, keep string) error {
+	jobs, err := r.jobLister.Jobs(ee.Namespace).List(labels.SelectorFromSet(resources.Labels(ee.Name)))
+	if err != nil {
+		return fmt.Errorf("listing Jobs: %w", err)
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	for _, job := range jobs {
+		if job.Name == keep || !metav1.IsControlledBy(job, ee) {
+			continue
+		}
+		err := r.kubeClientSet.BatchV1().Jobs(ee.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagation,
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting Job %q: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileCronJob(ctx context.Context, ee *v1alpha1.EventEmission, args *resources.EmitterArgs) error {
+	expected, err := resources.MakeCronJob(args)
+	if err != nil {
+		return err
+	}
+
+	cj, err := r.cronJobLister.CronJobs(ee.Namespace).Get(expected.Name)
+	if apierrors.IsNotFound(err) {
+		cj, err = r.kubeClientSet.BatchV1().CronJobs(ee.Namespace).Create(ctx, expected, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("creating new CronJob: %w", err)
+		}
+		controller.GetEventRecorder(ctx).Eventf(ee, corev1.EventTypeNormal, eventEmissionCronJobCreated, "CronJob created %q", cj.Name)
+	} else if err != nil {
+		return fmt.Errorf("getting CronJob: %w", err)
+	} else if !metav1.IsControlledBy(cj, ee) {
+		return fmt.Errorf("cronjob %q is not owned by EventEmission %q", cj.Name, ee.Name)
+	} else if !equality.Semantic.DeepDerivative(expected.Spec, cj.Spec) {
+		cj = cj.DeepCopy()
+		cj.Spec = expected.Spec
+		cj, err = r.kubeClientSet.BatchV1().CronJobs(ee.Namespace).Update(ctx, cj, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("updating CronJob: %w", err)
+		}
+		controller.GetEventRecorder(ctx).Eventf(ee, corev1.EventTypeNormal, eventEmissionCronJobUpdated, "CronJob updated %q", cj.Name)
+	} else {
+		logging.FromContext(ctx).Debugw("Reusing existing CronJob", zap.Any("CronJob", cj))
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteCronJob(ctx context.Context, ee *v1alpha1.EventEmission) error {
+	name := resources.CronJobName(ee)
+	cj, err := r.cronJobLister.CronJobs(ee.Namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting CronJob: %w", err)
+	} else if !metav1.IsControlledBy(cj, ee) {
+		return nil
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	err = r.kubeClientSet.BatchV1().CronJobs(ee.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &propagation,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting CronJob %q: %w", name, err)
+	}
+	return nil
+}
+
+// lastEmissionResult returns the result reported by the most recently
+// terminated emitter container among the Pods matching the selector, or nil
+// when none of them terminated yet.
+func (r *Reconciler) lastEmissionResult(namespace string, selector labels.Selector) (*v1alpha1.EventEmissionResult, error) {
+	pods, err := r.podLister.Pods(namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *corev1.ContainerStateTerminated
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != resources.EmitterContainerName || cs.State.Terminated == nil {
+				continue
+			}
+			if last == nil || last.FinishedAt.Before(&cs.State.Terminated.FinishedAt) {
+				last = cs.State.Terminated
+			}
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	result := &v1alpha1.EventEmissionResult{}
+	if err := json.Unmarshal([]byte(last.Message), result); err != nil {
+		// The emitter didn't get to write its result, e.g. it crashed.
+		result = &v1alpha1.EventEmissionResult{
+			Message: fmt.Sprintf("emitter terminated with exit code %d: %s", last.ExitCode, last.Reason),
+		}
+	}
+	finishedAt := last.FinishedAt
+	result.Time = &finishedAt
+	return result, nil
+}
@@ -0,0 +1,533 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventemission
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	. "knative.dev/pkg/reconciler/testing"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventemission"
+	"knative.dev/eventing/pkg/reconciler/eventemission/resources"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+)
+
+const (
+	emitterImage = "github.com/knative/test/emitter"
+	emissionName = "test-emission"
+	emissionUID  = "1234-5678-90"
+	testNS       = "testnamespace"
+	sinkName     = "testsink"
+	schedule     = "*/2 * * * *"
+	generation   = 2
+)
+
+var (
+	sinkDest = duckv1.Destination{
+		Ref: &duckv1.KReference{
+			Name:       sinkName,
+			Kind:       "Channel",
+			APIVersion: "messaging.knative.dev/v1",
+		},
+	}
+
+	sinkDNS         = "sink.mynamespace.svc." + "cluster.local"
+	sinkURL         = apis.HTTP(sinkDNS)
+	sinkAddressable = &duckv1.Addressable{
+		Name: &sinkURL.Scheme,
+		URL:  sinkURL,
+	}
+
+	sinkAudience        = "sink-oidc-audience"
+	sinkOIDCAddressable = &duckv1.Addressable{
+		Name:     &sinkURL.Scheme,
+		URL:      sinkURL,
+		Audience: &sinkAudience,
+	}
+
+	finishedAt = metav1.NewTime(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+)
+
+func TestAllCases(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "sink not found",
+		Objects: []runtime.Object{
+			newEventEmission(),
+		},
+		Key: testNS + "/" + emissionName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionNoSink("NotFound", ""),
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "SinkNotFound",
+				`Sink not found: {"ref":{"kind":"Channel","namespace":"testnamespace","name":"testsink","apiVersion":"messaging.knative.dev/v1"}}`),
+		},
+	}, {
+		Name: "creates the Job",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantCreates: []runtime.Object{
+			makeJob(t, newEventEmission(), sinkAddressable),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, eventEmissionJobCreated, "Job created %q", resources.JobName(newEventEmission())),
+		},
+	}, {
+		Name: "error creating the Job",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WithReactors: []clientgotesting.ReactionFunc{
+			InduceFailure("create", "jobs"),
+		},
+		WantErr: true,
+		WantCreates: []runtime.Object{
+			makeJob(t, newEventEmission(), sinkAddressable),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", "creating new Job: inducing failure for create jobs"),
+		},
+	}, {
+		Name: "deletes the Jobs of the previous generations",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+			makeJob(t, newEventEmission(WithEventEmissionGeneration(generation-1)), sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS,
+				Verb:      "delete",
+				Resource:  batchv1.SchemeGroupVersion.WithResource("jobs"),
+			},
+			Name: resources.JobName(newEventEmission(WithEventEmissionGeneration(generation - 1))),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+	}, {
+		Name: "propagates the accepted emission",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+			makePod("pod-1", resources.JobLabels(emissionName, generation), `{"eventId":"1","statusCode":202}`, 0),
+		},
+		Key: testNS + "/" + emissionName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionResult(&v1alpha1.EventEmissionResult{
+					EventID:    "1",
+					StatusCode: 202,
+					Time:       &finishedAt,
+				}),
+			),
+		}},
+	}, {
+		Name: "propagates the rejected emission",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+			makePod("pod-1", resources.JobLabels(emissionName, generation), `{"eventId":"1","statusCode":500,"message":"boom"}`, 1),
+		},
+		Key: testNS + "/" + emissionName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionResult(&v1alpha1.EventEmissionResult{
+					EventID:    "1",
+					StatusCode: 500,
+					Message:    "boom",
+					Time:       &finishedAt,
+				}),
+			),
+		}},
+	}, {
+		Name: "propagates the crashed emitter",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+			makePod("pod-1", resources.JobLabels(emissionName, generation), "", 2),
+		},
+		Key: testNS + "/" + emissionName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionResult(&v1alpha1.EventEmissionResult{
+					Message: "emitter terminated with exit code 2: Error",
+					Time:    &finishedAt,
+				}),
+			),
+		}},
+	}, {
+		Name: "creates the CronJob",
+		Objects: []runtime.Object{
+			newEventEmission(WithEventEmissionSchedule(schedule)),
+			newSink(sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantCreates: []runtime.Object{
+			makeCronJob(t, newEventEmission(WithEventEmissionSchedule(schedule)), sinkAddressable),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithEventEmissionSchedule(schedule),
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, eventEmissionCronJobCreated, "CronJob created %q", resources.CronJobName(newEventEmission())),
+		},
+	}, {
+		Name: "updates the CronJob and deletes the one-shot Job",
+		Objects: []runtime.Object{
+			newEventEmission(WithEventEmissionSchedule(schedule)),
+			newSink(sinkAddressable),
+			makeCronJob(t, newEventEmission(WithEventEmissionSchedule("0 * * * *")), sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: makeCronJob(t, newEventEmission(WithEventEmissionSchedule(schedule)), sinkAddressable),
+		}},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS,
+				Verb:      "delete",
+				Resource:  batchv1.SchemeGroupVersion.WithResource("jobs"),
+			},
+			Name: resources.JobName(newEventEmission()),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithEventEmissionSchedule(schedule),
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, eventEmissionCronJobUpdated, "CronJob updated %q", resources.CronJobName(newEventEmission())),
+		},
+	}, {
+		Name: "deletes the CronJob when the schedule is removed",
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkAddressable),
+			makeCronJob(t, newEventEmission(WithEventEmissionSchedule(schedule)), sinkAddressable),
+			makeJob(t, newEventEmission(), sinkAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: testNS,
+				Verb:      "delete",
+				Resource:  batchv1.SchemeGroupVersion.WithResource("cronjobs"),
+			},
+			Name: resources.CronJobName(newEventEmission()),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+	}, {
+		Name: "propagates the last emission of the CronJob",
+		Objects: []runtime.Object{
+			newEventEmission(WithEventEmissionSchedule(schedule)),
+			newSink(sinkAddressable),
+			makeCronJob(t, newEventEmission(WithEventEmissionSchedule(schedule)), sinkAddressable),
+			makePod("pod-1", resources.Labels(emissionName), `{"eventId":"1","statusCode":500}`, 1),
+			withFinishedAt(makePod("pod-2", resources.Labels(emissionName), `{"eventId":"2","statusCode":200}`, 0), finishedAt.Add(time.Minute)),
+		},
+		Key: testNS + "/" + emissionName,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithEventEmissionSchedule(schedule),
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				WithEventEmissionSink(sinkAddressable),
+				WithEventEmissionResult(&v1alpha1.EventEmissionResult{
+					EventID:    "2",
+					StatusCode: 200,
+					Time:       &metav1.Time{Time: finishedAt.Add(time.Minute)},
+				}),
+			),
+		}},
+	}, {
+		Name: "OIDC: creates OIDC service account",
+		Ctx: feature.ToContext(context.Background(), feature.Flags{
+			feature.OIDCAuthentication: feature.Enabled,
+		}),
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkOIDCAddressable),
+		},
+		Key: testNS + "/" + emissionName,
+		WantCreates: []runtime.Object{
+			makeOIDCServiceAccount(),
+			makeJob(t, newEventEmission(WithEventEmissionOIDCServiceAccountName(makeOIDCServiceAccount().Name)), sinkOIDCAddressable),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCIdentityCreatedSucceeded(),
+				WithEventEmissionOIDCServiceAccountName(makeOIDCServiceAccount().Name),
+				WithEventEmissionSink(sinkOIDCAddressable),
+				WithEventEmissionEmissionPending,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, eventEmissionJobCreated, "Job created %q", resources.JobName(newEventEmission())),
+		},
+	}, {
+		Name: "OIDC: EventEmission not ready on invalid OIDC service account",
+		Ctx: feature.ToContext(context.Background(), feature.Flags{
+			feature.OIDCAuthentication: feature.Enabled,
+		}),
+		Objects: []runtime.Object{
+			newEventEmission(),
+			newSink(sinkOIDCAddressable),
+			makeOIDCServiceAccountWithoutOwnerRef(),
+		},
+		Key:     testNS + "/" + emissionName,
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newEventEmission(
+				WithInitEventEmissionConditions,
+				WithEventEmissionObservedGeneration(generation),
+				WithEventEmissionOIDCServiceAccountName(makeOIDCServiceAccount().Name),
+				WithEventEmissionOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication",
+					fmt.Sprintf("service account %s not owned by EventEmission %s", makeOIDCServiceAccount().Name, emissionName)),
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError",
+				fmt.Sprintf("service account %s not owned by EventEmission %s", makeOIDCServiceAccount().Name, emissionName)),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = addressable.WithDuck(ctx)
+		r := &Reconciler{
+			kubeClientSet:        fakekubeclient.Get(ctx),
+			emitterImage:         emitterImage,
+			sinkResolver:         resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			jobLister:            listers.GetJobLister(),
+			cronJobLister:        listers.GetCronJobLister(),
+			podLister:            listers.GetPodLister(),
+			serviceAccountLister: listers.GetServiceAccountLister(),
+		}
+		return eventemission.NewReconciler(ctx, logger,
+			fakeeventingclient.Get(ctx), listers.GetEventEmissionLister(),
+			controller.GetEventRecorder(ctx), r)
+	},
+		true,
+		logger,
+	))
+}
+
+func newEventEmission(o ...EventEmissionOption) *v1alpha1.EventEmission {
+	return NewEventEmission(emissionName, testNS, append([]EventEmissionOption{
+		WithEventEmissionUID(emissionUID),
+		WithEventEmissionGeneration(generation),
+		WithEventEmissionSpec(v1alpha1.EventEmissionSpec{
+			Sink: *sinkDest.DeepCopy(),
+			Event: v1alpha1.EventEmissionEvent{
+				Type:   "dev.knative.test",
+				Source: "/test",
+			},
+		}),
+	}, o...)...)
+}
+
+func newSink(addr *duckv1.Addressable) runtime.Object {
+	return NewChannel(sinkName, testNS,
+		WithInitChannelConditions,
+		WithChannelAddress(addr),
+	)
+}
+
+func makeJob(t *testing.T, ee *v1alpha1.EventEmission, addr *duckv1.Addressable) *batchv1.Job {
+	t.Helper()
+	job, err := resources.MakeJob(makeEmitterArgs(ee, addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func makeCronJob(t *testing.T, ee *v1alpha1.EventEmission, addr *duckv1.Addressable) *batchv1.CronJob {
+	t.Helper()
+	cj, err := resources.MakeCronJob(makeEmitterArgs(ee, addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cj
+}
+
+func makeEmitterArgs(ee *v1alpha1.EventEmission, addr *duckv1.Addressable) *resources.EmitterArgs {
+	return &resources.EmitterArgs{
+		Image:         emitterImage,
+		EventEmission: ee,
+		SinkURI:       addr.URL.String(),
+		CACerts:       addr.CACerts,
+		Audience:      addr.Audience,
+		NodeSelector:  map[string]string{},
+	}
+}
+
+// makePod returns a Pod whose emitter container terminated with the given
+// result and exit code.
+func makePod(name string, labels map[string]string, result string, exitCode int32) *corev1.Pod {
+	reason := "Completed"
+	if exitCode != 0 {
+		reason = "Error"
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNS,
+			Name:      name,
+			Labels:    labels,
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: resources.EmitterContainerName,
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   exitCode,
+						Reason:     reason,
+						Message:    result,
+						FinishedAt: finishedAt,
+					},
+				},
+			}},
+		},
+	}
+}
+
+func withFinishedAt(pod *corev1.Pod, t time.Time) *corev1.Pod {
+	pod.Status.ContainerStatuses[0].State.Terminated.FinishedAt = metav1.NewTime(t)
+	return pod
+}
+
+func makeOIDCServiceAccount() *corev1.ServiceAccount {
+	return auth.GetOIDCServiceAccountForResource(v1alpha1.SchemeGroupVersion.WithKind("EventEmission"), metav1.ObjectMeta{
+		Name:      emissionName,
+		Namespace: testNS,
+		UID:       emissionUID,
+	})
+}
+
+func makeOIDCServiceAccountWithoutOwnerRef() *corev1.ServiceAccount {
+	sa := makeOIDCServiceAccount()
+	sa.OwnerReferences = nil
+	return sa
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+const (
+	// EnvEvent is the environment variable holding the JSON encoded
+	// EventEmissionEvent the emitter sends.
+	EnvEvent = "K_EVENT"
+
+	// EnvSink is the environment variable holding the URI of the sink.
+	EnvSink = "K_SINK"
+
+	// EnvCACerts is the environment variable holding the CA certificates of
+	// the sink.
+	EnvCACerts = "K_CA_CERTS"
+
+	// OIDCTokenMountPath is the directory the OIDC token for the sink
+	// audience is mounted into.
+	OIDCTokenMountPath = "/oidc"
+
+	// OIDCTokenFileName is the name of the OIDC token file in OIDCTokenMountPath.
+	OIDCTokenFileName = "token"
+
+	// EmitterContainerName is the name of the container sending the event.
+	EmitterContainerName = "emitter"
+
+	oidcTokenVolumeName = "oidc-token"
+
+	// oidcTokenExpirationSeconds is the lifetime of the projected token, the
+	// emitter only needs it for a single request.
+	oidcTokenExpirationSeconds = int64(600)
+)
+
+// EmitterArgs are the arguments needed to create the workloads sending the
+// event of an EventEmission.
+type EmitterArgs struct {
+	Image         string
+	EventEmission *v1alpha1.EventEmission
+	SinkURI       string
+	CACerts       *string
+	Audience      *string
+	NodeSelector  map[string]string
+}
+
+// JobName returns the name of the one-shot Job for the current generation of
+// the EventEmission.
+func JobName(ee *v1alpha1.EventEmission) string {
+	return kmeta.ChildName(ee.Name, fmt.Sprintf("-emission-%d", ee.Generation))
+}
+
+// CronJobName returns the name of the CronJob for scheduled EventEmissions.
+func CronJobName(ee *v1alpha1.EventEmission) string {
+	return kmeta.ChildName(ee.Name, "-emission")
+}
+
+// MakeJob generates (but does not insert into K8s) the Job sending the event
+// of the EventEmission once.
+func MakeJob(args *EmitterArgs) (*batchv1.Job, error) {
+	labels := JobLabels(args.EventEmission.Name, args.EventEmission.Generation)
+	template, err := makePodTemplate(args, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.EventEmission.Namespace,
+			Name:      JobName(args.EventEmission),
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.EventEmission),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.Int32(0),
+			Template:     *template,
+		},
+	}, nil
+}
+
+// MakeCronJob generates (but does not insert into K8s) the CronJob sending the
+// event of the EventEmission on every tick of its schedule.
+func MakeCronJob(args *EmitterArgs) (*batchv1.CronJob, error) {
+	labels := Labels(args.EventEmission.Name)
+	template, err := makePodTemplate(args, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: args.EventEmission.Namespace,
+			Name:      CronJobName(args.EventEmission),
+			Labels:    labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(args.EventEmission),
+			},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   args.EventEmission.Spec.Schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.Int32(1),
+			FailedJobsHistoryLimit:     ptr.Int32(1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.Int32(0),
+					Template:     *template,
+				},
+			},
+		},
+	}, nil
+}
+
+func makePodTemplate(args *EmitterArgs, labels map[string]string) (*corev1.PodTemplateSpec, error) {
+	event, err := json.Marshal(args.EventEmission.Spec.Event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	env := []corev1.EnvVar{{
+		Name:  EnvSink,
+		Value: args.SinkURI,
+	}, {
+		Name:  EnvEvent,
+		Value: string(event),
+	}}
+	if args.CACerts != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  EnvCACerts,
+			Value: *args.CACerts,
+		})
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:      corev1.RestartPolicyNever,
+		NodeSelector:       args.NodeSelector,
+		EnableServiceLinks: ptr.Bool(false),
+		Containers: []corev1.Container{{
+			Name:                     EmitterContainerName,
+			Image:                    args.Image,
+			Env:                      env,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.Bool(false),
+				ReadOnlyRootFilesystem:   ptr.Bool(true),
+				RunAsNonRoot:             ptr.Bool(true),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		}},
+	}
+
+	auth := args.EventEmission.Status.Auth
+	if args.Audience != nil && auth != nil && auth.ServiceAccountName != nil {
+		podSpec.ServiceAccountName = *auth.ServiceAccountName
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: oidcTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          *args.Audience,
+							ExpirationSeconds: ptr.Int64(oidcTokenExpirationSeconds),
+							Path:              OIDCTokenFileName,
+						},
+					}},
+				},
+			},
+		})
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      oidcTokenVolumeName,
+			MountPath: OIDCTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: labels,
+		},
+		Spec: podSpec,
+	}, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+func newEventEmission(schedule string) *v1alpha1.EventEmission {
+	return &v1alpha1.EventEmission{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "ns",
+			Name:       "emission",
+			UID:        "1234",
+			Generation: 2,
+		},
+		Spec: v1alpha1.EventEmissionSpec{
+			Event: v1alpha1.EventEmissionEvent{
+				Type:   "dev.knative.test",
+				Source: "/test",
+			},
+			Schedule: schedule,
+		},
+	}
+}
+
+func expectedPodTemplate(labels map[string]string, env []corev1.EnvVar) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:      corev1.RestartPolicyNever,
+			EnableServiceLinks: ptr.Bool(false),
+			Containers: []corev1.Container{{
+				Name:                     EmitterContainerName,
+				Image:                    "emitter-image",
+				Env:                      env,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: ptr.Bool(false),
+					ReadOnlyRootFilesystem:   ptr.Bool(true),
+					RunAsNonRoot:             ptr.Bool(true),
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				},
+			}},
+		},
+	}
+}
+
+func TestMakeJob(t *testing.T) {
+	ee := newEventEmission("")
+	got, err := MakeJob(&EmitterArgs{
+		Image:         "emitter-image",
+		EventEmission: ee,
+		SinkURI:       "http://sink.ns.svc.cluster.local",
+		CACerts:       ptr.String("certs"),
+	})
+	if err != nil {
+		t.Fatal("MakeJob() =", err)
+	}
+
+	labels := JobLabels("emission", 2)
+	want := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "ns",
+			Name:            "emission-emission-2",
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(ee)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.Int32(0),
+			Template: expectedPodTemplate(labels, []corev1.EnvVar{
+				{Name: EnvSink, Value: "http://sink.ns.svc.cluster.local"},
+				{Name: EnvEvent, Value: `{"type":"dev.knative.test","source":"/test"}`},
+				{Name: EnvCACerts, Value: "certs"},
+			}),
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected Job (-want, +got) =", diff)
+	}
+}
+
+func TestMakeCronJob(t *testing.T) {
+	ee := newEventEmission("*/5 * * * *")
+	got, err := MakeCronJob(&EmitterArgs{
+		Image:         "emitter-image",
+		EventEmission: ee,
+		SinkURI:       "http://sink.ns.svc.cluster.local",
+	})
+	if err != nil {
+		t.Fatal("MakeCronJob() =", err)
+	}
+
+	labels := Labels("emission")
+	want := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "ns",
+			Name:            "emission-emission",
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(ee)},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   "*/5 * * * *",
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			SuccessfulJobsHistoryLimit: ptr.Int32(1),
+			FailedJobsHistoryLimit:     ptr.Int32(1),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.Int32(0),
+					Template: expectedPodTemplate(labels, []corev1.EnvVar{
+						{Name: EnvSink, Value: "http://sink.ns.svc.cluster.local"},
+						{Name: EnvEvent, Value: `{"type":"dev.knative.test","source":"/test"}`},
+					}),
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("unexpected CronJob (-want, +got) =", diff)
+	}
+}
+
+func TestMakeJobOIDC(t *testing.T) {
+	ee := newEventEmission("")
+	ee.Status.Auth = &duckv1.AuthStatus{ServiceAccountName: ptr.String("oidc-sa")}
+
+	got, err := MakeJob(&EmitterArgs{
+		Image:         "emitter-image",
+		EventEmission: ee,
+		SinkURI:       "http://sink.ns.svc.cluster.local",
+		Audience:      ptr.String("sink-audience"),
+	})
+	if err != nil {
+		t.Fatal("MakeJob() =", err)
+	}
+
+	spec := got.Spec.Template.Spec
+	if spec.ServiceAccountName != "oidc-sa" {
+		t.Errorf("ServiceAccountName = %q, want %q", spec.ServiceAccountName, "oidc-sa")
+	}
+	if len(spec.Volumes) != 1 || spec.Volumes[0].Projected.Sources[0].ServiceAccountToken.Audience != "sink-audience" {
+		t.Errorf("unexpected volumes %+v", spec.Volumes)
+	}
+	wantMounts := []corev1.VolumeMount{{Name: oidcTokenVolumeName, MountPath: OIDCTokenMountPath, ReadOnly: true}}
+	if diff := cmp.Diff(wantMounts, spec.Containers[0].VolumeMounts); diff != "" {
+		t.Error("unexpected volume mounts (-want, +got) =", diff)
+	}
+}
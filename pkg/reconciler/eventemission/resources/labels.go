@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strconv"
+)
+
+const (
+	// EventEmissionLabelKey is set on every Job, CronJob and Pod created for
+	// an EventEmission.
+	EventEmissionLabelKey = "eventing.knative.dev/event-emission"
+
+	// EventEmissionNameLabelKey holds the name of the owning EventEmission.
+	EventEmissionNameLabelKey = "eventing.knative.dev/event-emission-name"
+
+	// EventEmissionGenerationLabelKey holds the generation of the owning
+	// EventEmission the Job was created for.
+	EventEmissionGenerationLabelKey = "eventing.knative.dev/event-emission-generation"
+
+	// LabelSelector selects every resource created for EventEmissions.
+	LabelSelector = EventEmissionLabelKey + "=true"
+)
+
+// Labels returns the labels applied to the resources created for the
+// EventEmission with the given name.
+func Labels(name string) map[string]string {
+	return map[string]string{
+		EventEmissionLabelKey:     "true",
+		EventEmissionNameLabelKey: name,
+	}
+}
+
+// JobLabels returns the labels applied to the one-shot Job, and its Pod, for
+// the given generation of the EventEmission.
+func JobLabels(name string, generation int64) map[string]string {
+	labels := Labels(name)
+	labels[EventEmissionGenerationLabelKey] = strconv.FormatInt(generation, 10)
+	return labels
+}
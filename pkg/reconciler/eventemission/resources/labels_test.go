@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLabels(t *testing.T) {
+	want := map[string]string{
+		EventEmissionLabelKey:     "true",
+		EventEmissionNameLabelKey: "name",
+	}
+	if diff := cmp.Diff(want, Labels("name")); diff != "" {
+		t.Error("unexpected labels (-want, +got) =", diff)
+	}
+}
+
+func TestJobLabels(t *testing.T) {
+	want := map[string]string{
+		EventEmissionLabelKey:           "true",
+		EventEmissionNameLabelKey:       "name",
+		EventEmissionGenerationLabelKey: "3",
+	}
+	if diff := cmp.Diff(want, JobLabels("name", 3)); diff != "" {
+		t.Error("unexpected labels (-want, +got) =", diff)
+	}
+}
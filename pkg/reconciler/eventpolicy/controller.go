@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"context"
+	"time"
+
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgresolver "knative.dev/pkg/resolver"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	eventpolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/eventpolicy"
+	eventpolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventpolicy"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	eventPolicyInformer := eventpolicyinformer.Get(ctx)
+
+	var globalResync func(obj interface{})
+
+	featureStore := feature.NewStore(logging.FromContext(ctx).Named("feature-config-store"), func(name string, value interface{}) {
+		if globalResync != nil {
+			globalResync(nil)
+		}
+	})
+	featureStore.WatchConfigs(cmw)
+
+	r := &Reconciler{
+		now: time.Now,
+	}
+	impl := eventpolicyreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{
+			ConfigStore: featureStore,
+		}
+	})
+
+	globalResync = func(_ interface{}) {
+		impl.GlobalResync(eventPolicyInformer.Informer())
+	}
+
+	eventPolicyInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Tracker is used to notify us that a resource referenced by an EventPolicy
+	// has changed, so that its subjects and rejection sink are resolved again.
+	r.kReferenceResolver = resolver.NewKReferenceResolverFromTracker(ctx, impl.Tracker)
+	r.authResolver = pkgresolver.NewAuthenticatableResolverFromTracker(ctx, impl.Tracker)
+	r.sinkResolver = pkgresolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+
+	return impl
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	pkgresolver "knative.dev/pkg/resolver"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	eventpolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventpolicy"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+const (
+	refsNotResolved       = "RefsNotResolved"
+	subjectsNotResolved   = "SubjectsNotResolved"
+	oidcDisabled          = "OIDCDisabled"
+	rejectionSinkNotFound = "RejectionSinkNotFound"
+	notYetActive          = "NotYetActive"
+	expired               = "Expired"
+
+	// Audit events for break-glass EventPolicies.
+	breakGlassActivated = "BreakGlassActivated"
+	breakGlassExpired   = "BreakGlassExpired"
+)
+
+type Reconciler struct {
+	kReferenceResolver *resolver.KReferenceResolver
+	authResolver       *pkgresolver.AuthenticatableResolver
+	sinkResolver       *pkgresolver.URIResolver
+
+	// now returns the current time, it is overridden in tests.
+	now func() time.Time
+}
+
+// Check that our Reconciler implements interface
+var _ eventpolicyreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+// 1. Verify the resources referenced in .spec.to exist.
+// 2. Resolve the resources referenced in .spec.from into OIDC subjects,
+// warning when they are pooled OIDC identities.
+// 3. Check that the OIDC authentication, without which the policy is not
+// enforced, is enabled.
+// 4. Check whether the current time is within .spec.validity, narrowed down
+// by the break-glass TTL, if any.
+// 5. Resolve .spec.rejectionSink, if any, where the data plane reports the
+// rejected events.
+//
+// The referenced resources are tracked, so that the EventPolicy is reconciled
+// again when any of them changes. When the validity starts or ends in the
+// future, the EventPolicy is requeued for that time.
+func (r *Reconciler) ReconcileKind(ctx context.Context, ep *v1alpha1.EventPolicy) pkgreconciler.Event {
+	now := r.now()
+	validity := ep.EffectiveValidity()
+	wasActive := ep.Status.GetCondition(v1alpha1.EventPolicyConditionActive)
+	r.reconcileValidity(ep, validity, now)
+
+	if err := r.resolveRefs(ctx, ep); err != nil {
+		logging.FromContext(ctx).Infow("Unable to resolve .spec.to references", zap.Error(err))
+		ep.Status.MarkRefsNotResolved(refsNotResolved, "%v", err)
+	} else {
+		ep.Status.MarkRefsResolved()
+	}
+
+	subjects, err := auth.ResolveSubjects(r.authResolver, ep)
+	if err != nil {
+		logging.FromContext(ctx).Infow("Unable to resolve .spec.from subjects", zap.Error(err))
+		// Do not keep subjects of a previous resolution around, they might
+		// not be allowed anymore.
+		ep.Status.From = nil
+		ep.Status.MarkSubjectsNotResolved(subjectsNotResolved, "%v", err)
+	} else {
+		ep.Status.From = subjects
+		ep.Status.MarkSubjectsResolved()
+	}
+	r.reconcilePooledIdentity(ep)
+
+	if feature.FromContext(ctx).IsOIDCAuthentication() {
+		ep.Status.MarkOIDCEnabled()
+	} else {
+		ep.Status.MarkOIDCDisabled(oidcDisabled, "the %s feature is disabled, the policy is not enforced", feature.OIDCAuthentication)
+	}
+
+	r.resolveRejectionSink(ctx, ep)
+
+	if _, ok, _ := ep.BreakGlassTTL(); ok {
+		recordBreakGlassTransition(ctx, ep, validity, wasActive)
+	}
+
+	if next, ok := validity.NextTransition(now); ok {
+		return controller.NewRequeueAfter(next.Sub(now))
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileValidity(ep *v1alpha1.EventPolicy, validity *v1alpha1.EventPolicyValidity, now time.Time) {
+	switch {
+	case validity.IsActiveAt(now):
+		ep.Status.MarkActive()
+	case validity.NotBefore != nil && now.Before(validity.NotBefore.Time):
+		ep.Status.MarkInactive(notYetActive, "policy is active from %s", validity.NotBefore.UTC().Format(time.RFC3339))
+	default:
+		ep.Status.MarkInactive(expired, "policy expired at %s", validity.NotAfter.UTC().Format(time.RFC3339))
+	}
+}
+
+// reconcilePooledIdentity warns when the subjects of the policy are pooled
+// OIDC identities, which every resource of their namespace shares with the
+// authentication-oidc-shared-identity feature, so that the policy grants
+// access to more than the resources it references.
+func (r *Reconciler) reconcilePooledIdentity(ep *v1alpha1.EventPolicy) {
+	var pooled []string
+	for _, sub := range ep.Status.From {
+		if auth.IsPooledOIDCSubject(sub) {
+			pooled = append(pooled, sub)
+		}
+	}
+	if len(pooled) == 0 {
+		ep.Status.ClearPooledIdentity()
+		return
+	}
+	ep.Status.MarkPooledIdentity(pooled)
+}
+
+func (r *Reconciler) resolveRefs(ctx context.Context, ep *v1alpha1.EventPolicy) error {
+	var errs []error
+	for _, to := range ep.Spec.To {
+		if to.Ref == nil {
+			continue
+		}
+		ref := &duckv1.KReference{
+			APIVersion: to.Ref.APIVersion,
+			Kind:       to.Ref.Kind,
+			Name:       to.Ref.Name,
+			Namespace:  ep.Namespace,
+		}
+		if _, err := r.kReferenceResolver.Resolve(ctx, ref, ep); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Reconciler) resolveRejectionSink(ctx context.Context, ep *v1alpha1.EventPolicy) {
+	if ep.Spec.RejectionSink == nil {
+		ep.Status.MarkRejectionSinkResolved(nil)
+		return
+	}
+
+	dest := ep.Spec.RejectionSink.DeepCopy()
+	if dest.Ref != nil && dest.Ref.Namespace == "" {
+		dest.Ref.Namespace = ep.Namespace
+	}
+	addr, err := r.sinkResolver.AddressableFromDestinationV1(ctx, *dest, ep)
+	if err != nil {
+		logging.FromContext(ctx).Infow("Unable to resolve .spec.rejectionSink", zap.Error(err))
+		ep.Status.MarkRejectionSinkNotResolved(rejectionSinkNotFound, "%v", err)
+		return
+	}
+	ep.Status.MarkRejectionSinkResolved(addr)
+}
+
+// recordBreakGlassTransition emits a warning event when a break-glass
+// EventPolicy starts or stops granting access, so that opening traffic during
+// an incident leaves an audit trail.
+func recordBreakGlassTransition(ctx context.Context, ep *v1alpha1.EventPolicy, validity *v1alpha1.EventPolicyValidity, before *apis.Condition) {
+	after := ep.Status.GetCondition(v1alpha1.EventPolicyConditionActive)
+	recorder := controller.GetEventRecorder(ctx)
+
+	switch {
+	case after.IsTrue() && !before.IsTrue():
+		recorder.Eventf(ep, corev1.EventTypeWarning, breakGlassActivated, "Break-glass EventPolicy grants access from %v until %s",
+			ep.Status.From, validity.NotAfter.UTC().Format(time.RFC3339))
+	case after.IsFalse() && after.Reason == expired && (before == nil || before.Reason != expired):
+		recorder.Eventf(ep, corev1.EventTypeWarning, breakGlassExpired, "Break-glass EventPolicy expired at %s",
+			validity.NotAfter.UTC().Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,454 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/addressable"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/authstatus"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/kresource"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	pkgresolver "knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing/pkg/apis/eventing"
+	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/auth"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/eventpolicy"
+	"knative.dev/eventing/pkg/reconciler/sugar/resources"
+	"knative.dev/eventing/pkg/resolver"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	testNS          = "test-namespace"
+	eventPolicyName = "test-eventpolicy"
+	brokerName      = "test-broker"
+	pingSourceName  = "test-pingsource"
+	pingSourceSA    = "test-pingsource-oidc-sa"
+)
+
+var (
+	testKey = fmt.Sprintf("%s/%s", testNS, eventPolicyName)
+
+	now       = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notBefore = metav1.NewTime(now.Add(12 * time.Hour))
+	notAfter  = metav1.NewTime(now.Add(48 * time.Hour))
+	started   = metav1.NewTime(now.Add(-12 * time.Hour))
+	expiredAt = metav1.NewTime(now.Add(-12 * time.Hour))
+
+	rejectionSinkURL = apis.HTTP("audit.example.com")
+
+	breakGlassAnnotations = map[string]string{
+		eventing.BreakGlassTTLAnnotationKey: "24h",
+	}
+
+	brokerGVK = metav1.GroupVersionKind{
+		Group:   "eventing.knative.dev",
+		Version: "v1",
+		Kind:    "Broker",
+	}
+	pingSourceGVK = metav1.GroupVersionKind{
+		Group:   "sources.knative.dev",
+		Version: "v1",
+		Kind:    "PingSource",
+	}
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "EventPolicy without references",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "To reference found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyToRef(brokerGVK, brokerName),
+			),
+			resources.MakeBroker(testNS, brokerName),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyToRef(brokerGVK, brokerName),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "To reference not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyToRef(brokerGVK, brokerName),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyToRef(brokerGVK, brokerName),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsNotResolved(refsNotResolved, fmt.Sprintf("failed to get object %s/%s: brokers.eventing.knative.dev %q not found", testNS, brokerName, brokerName)),
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "From reference resolved into subject",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+			),
+			NewPingSource(pingSourceName, testNS,
+				WithPingSourceOIDCServiceAccountName(pingSourceSA),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+				WithEventPolicyStatusFromSub([]string{
+					fmt.Sprintf("system:serviceaccount:%s:%s", testNS, pingSourceSA),
+				}),
+			),
+		}},
+	}, {
+		Name: "From reference resolved into the pooled identity",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+			),
+			NewPingSource(pingSourceName, testNS,
+				WithPingSourceOIDCServiceAccountName(auth.PooledOIDCServiceAccountName),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+				WithEventPolicyStatusFromSub([]string{
+					fmt.Sprintf("system:serviceaccount:%s:%s", testNS, auth.PooledOIDCServiceAccountName),
+				}),
+				WithEventPolicyPooledIdentity(fmt.Sprintf("system:serviceaccount:%s:%s", testNS, auth.PooledOIDCServiceAccountName)),
+			),
+		}},
+	}, {
+		Name: "From reference not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsNotResolved(subjectsNotResolved, fmt.Sprintf("could not resolve subjects from reference PingSource %s/%s: could not resolve auth status: failed to get authenticatable %s/%s: failed to get object %s/%s: pingsources.sources.knative.dev %q not found", testNS, pingSourceName, testNS, pingSourceName, testNS, pingSourceName, pingSourceName)),
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "Validity not yet started",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(&notBefore, &notAfter),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(&notBefore, &notAfter),
+				WithInitEventPolicyConditions,
+				WithEventPolicyInactive(notYetActive, "policy is active from 2024-01-01T12:00:00Z"),
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+		// Requeued for notBefore.
+		WantErr: true,
+	}, {
+		Name: "Validity started",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(&started, &notAfter),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(&started, &notAfter),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+		// Requeued for notAfter.
+		WantErr: true,
+	}, {
+		Name: "Validity expired",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(nil, &expiredAt),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyValidity(nil, &expiredAt),
+				WithInitEventPolicyConditions,
+				WithEventPolicyInactive(expired, "policy expired at 2023-12-31T12:00:00Z"),
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "Break-glass policy activated",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyAnnotations(breakGlassAnnotations),
+				WithEventPolicyCreationTimestamp(started),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyAnnotations(breakGlassAnnotations),
+				WithEventPolicyCreationTimestamp(started),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, breakGlassActivated, "Break-glass EventPolicy grants access from [] until 2024-01-01T12:00:00Z"),
+		},
+		// Requeued for the break-glass expiry.
+		WantErr: true,
+	}, {
+		Name: "Break-glass policy expired",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyAnnotations(breakGlassAnnotations),
+				WithEventPolicyCreationTimestamp(metav1.NewTime(now.Add(-48*time.Hour))),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyAnnotations(breakGlassAnnotations),
+				WithEventPolicyCreationTimestamp(metav1.NewTime(now.Add(-48*time.Hour))),
+				WithInitEventPolicyConditions,
+				WithEventPolicyInactive(expired, "policy expired at 2023-12-31T00:00:00Z"),
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, breakGlassExpired, "Break-glass EventPolicy expired at 2023-12-31T00:00:00Z"),
+		},
+	}, {
+		Name: "Break-glass policy stays expired",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyAnnotations(breakGlassAnnotations),
+				WithEventPolicyCreationTimestamp(metav1.NewTime(now.Add(-48*time.Hour))),
+				WithInitEventPolicyConditions,
+				WithEventPolicyInactive(expired, "policy expired at 2023-12-31T00:00:00Z"),
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+			),
+		},
+	}, {
+		Name: "From references partially not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+				WithEventPolicyFrom(pingSourceGVK, "other-pingsource", testNS),
+			),
+			NewPingSource(pingSourceName, testNS,
+				WithPingSourceOIDCServiceAccountName(pingSourceSA),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyFrom(pingSourceGVK, pingSourceName, testNS),
+				WithEventPolicyFrom(pingSourceGVK, "other-pingsource", testNS),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsNotResolved(subjectsNotResolved, fmt.Sprintf("could not resolve subjects from reference PingSource %s/other-pingsource: could not resolve auth status: failed to get authenticatable %s/other-pingsource: failed to get object %s/other-pingsource: pingsources.sources.knative.dev %q not found", testNS, testNS, testNS, "other-pingsource")),
+				WithEventPolicyOIDCEnabled,
+			),
+		}},
+	}, {
+		Name: "OIDC authentication disabled",
+		Key:  testKey,
+		Ctx:  feature.ToContext(context.Background(), feature.Flags{feature.OIDCAuthentication: feature.Disabled}),
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCDisabled(oidcDisabled, "the authentication-oidc feature is disabled, the policy is not enforced"),
+			),
+		}},
+	}, {
+		Name: "rejection sink resolved",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyRejectionSink(duckv1.Destination{URI: rejectionSinkURL}),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyRejectionSink(duckv1.Destination{URI: rejectionSinkURL}),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+				WithEventPolicyRejectionSinkResolved(&duckv1.Addressable{URL: rejectionSinkURL}),
+			),
+		}},
+	}, {
+		Name: "rejection sink not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyRejectionSink(duckv1.Destination{Ref: &duckv1.KReference{
+					APIVersion: "eventing.knative.dev/v1",
+					Kind:       "Broker",
+					Name:       brokerName,
+				}}),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewEventPolicy(eventPolicyName, testNS,
+				WithEventPolicyRejectionSink(duckv1.Destination{Ref: &duckv1.KReference{
+					APIVersion: "eventing.knative.dev/v1",
+					Kind:       "Broker",
+					Name:       brokerName,
+				}}),
+				WithInitEventPolicyConditions,
+				WithEventPolicyActive,
+				WithEventPolicyRefsResolved,
+				WithEventPolicySubjectsResolved,
+				WithEventPolicyOIDCEnabled,
+				WithEventPolicyRejectionSinkNotResolved(rejectionSinkNotFound,
+					fmt.Sprintf("failed to get object %s/%s: brokers.eventing.knative.dev %q not found", testNS, brokerName, brokerName)),
+			),
+		}},
+	}}
+
+	// The EventPolicies are enforced with the OIDC authentication only.
+	for i := range table {
+		if table[i].Ctx == nil {
+			table[i].Ctx = feature.ToContext(context.Background(), feature.Flags{feature.OIDCAuthentication: feature.Enabled})
+		}
+	}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = kresource.WithDuck(ctx)
+		ctx = authstatus.WithDuck(ctx)
+		ctx = addressable.WithDuck(ctx)
+		r := &Reconciler{
+			kReferenceResolver: resolver.NewKReferenceResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			authResolver:       pkgresolver.NewAuthenticatableResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			sinkResolver:       pkgresolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			now:                func() time.Time { return now },
+		}
+		return eventpolicy.NewReconciler(ctx, logger,
+			fakeeventingclient.Get(ctx), listers.GetEventPolicyLister(),
+			controller.GetEventRecorder(ctx), r)
+	},
+		false,
+		logger,
+	))
+}
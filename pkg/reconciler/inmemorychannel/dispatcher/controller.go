@@ -23,6 +23,7 @@ import (
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/system"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
@@ -31,7 +32,9 @@ import (
 	"knative.dev/pkg/kmeta"
 
 	"knative.dev/eventing/pkg/auth"
+	"knative.dev/eventing/pkg/channel/fanout"
 	"knative.dev/eventing/pkg/channel/multichannelfanout"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/kncloudevents"
 
@@ -54,6 +57,7 @@ import (
 	"knative.dev/eventing/pkg/apis/feature"
 	"knative.dev/eventing/pkg/channel"
 	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	redactionpolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/redactionpolicy"
 	eventtypeinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype"
 	inmemorychannelinformer "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/inmemorychannel"
 	inmemorychannelreconciler "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
@@ -77,6 +81,35 @@ type envConfig struct {
 	MaxIdleConns int `envconfig:"MAX_IDLE_CONNS" required:"true"`
 	// MaxIdleConnsPerHost refers to the max idle connections per host, as in net/http/transport.
 	MaxIdleConnsPerHost int `envconfig:"MAX_IDLE_CONNS_PER_HOST" required:"true"`
+
+	// BindAddress is the address the dispatcher listens on, all the addresses
+	// of BindAddressFamily when empty.
+	BindAddress string `envconfig:"BIND_ADDRESS"`
+	// BindAddressFamily is one of dual, ipv4 or ipv6.
+	BindAddressFamily string `envconfig:"BIND_ADDRESS_FAMILY" default:"dual"`
+
+	// RetryStateDir is the directory in which the deliveries being retried
+	// are persisted, so that they are resumed after a restart. Retries are
+	// only kept in memory when empty.
+	RetryStateDir string `envconfig:"RETRY_STATE_DIR"`
+
+	// EventIndexSize is the number of recent delivery outcomes kept in the
+	// event index, which is disabled when 0.
+	EventIndexSize int `envconfig:"EVENT_INDEX_SIZE" default:"0"`
+	// EventIndexPort is the port of the debug endpoint querying the event
+	// index, which isn't served when 0.
+	EventIndexPort int `envconfig:"EVENT_INDEX_PORT" default:"0"`
+
+	// DispatchCapacity is the maximum number of deliveries in flight across
+	// all the channels, which are unbounded when 0. Once it is reached, the
+	// slots are shared between the channels by weighted round-robin.
+	DispatchCapacity int `envconfig:"DISPATCH_CAPACITY" default:"0"`
+
+	// ReplayProtectionKeyFiles lists the files holding the keys the
+	// replay protection headers of the authenticated requests are signed
+	// with, the first one, and verified with, each one identified by the base
+	// name of its file. They are required by the replay-protection feature.
+	ReplayProtectionKeyFiles []string `envconfig:"REPLAY_PROTECTION_KEY_FILES"`
 }
 
 // NewController initializes the controller and is called by the generated code.
@@ -107,16 +140,36 @@ func NewController(
 	if env.MaxIdleConnsPerHost <= 0 {
 		logger.Panicf("MAX_IDLE_CONNS_PER_HOST = %d. It must be greater than 0", env.MaxIdleConnsPerHost)
 	}
+	addressFamily, err := kncloudevents.ParseAddressFamily(env.BindAddressFamily)
+	if err != nil {
+		logger.Panicw("Invalid BIND_ADDRESS_FAMILY", zap.Error(err))
+	}
+	bindAddress := kncloudevents.WithBindAddress(env.BindAddress, addressFamily)
+
 	kncloudevents.ConfigureConnectionArgs(&kncloudevents.ConnectionArgs{
 		MaxIdleConns:        env.MaxIdleConns,
 		MaxIdleConnsPerHost: env.MaxIdleConnsPerHost,
 	})
+	kncloudevents.WatchProxyConfig(logger, cmw)
+	if err := kncloudevents.LoadReplayProtection(env.ReplayProtectionKeyFiles); err != nil {
+		logger.Panicw("Invalid replay protection configuration", zap.Error(err))
+	}
+	kncloudevents.WatchReplayProtection(logger, cmw)
+
+	var retryState *fanout.RetryState
+	if env.RetryStateDir != "" {
+		retryState, err = fanout.NewRetryState(logger.Desugar().Named("retry-state"), env.RetryStateDir)
+		if err != nil {
+			logger.Panicw("Failed to set up the retry state", zap.Error(err))
+		}
+	}
 
 	reporter := channel.NewStatsReporter(env.ContainerName, kmeta.ChildName(env.PodName, uuid.New().String()))
 
 	sh := multichannelfanout.NewEventHandler(ctx, logger.Desugar())
 
 	inmemorychannelInformer := inmemorychannelinformer.Get(ctx)
+	redactionPolicyInformer := redactionpolicyinformer.Get(ctx)
 
 	readinessChecker := &DispatcherReadyChecker{
 		chLister:     inmemorychannelInformer.Lister(),
@@ -135,9 +188,13 @@ func NewController(
 		messagingClientSet:       eventingclient.Get(ctx).MessagingV1(),
 		eventingClient:           eventingclient.Get(ctx).EventingV1beta2(),
 		eventTypeLister:          eventtypeinformer.Get(ctx).Lister(),
+		redactionPolicyLister:    redactionPolicyInformer.Lister(),
 		eventDispatcher:          kncloudevents.NewDispatcher(clientConfig, oidcTokenProvider),
 		tokenVerifier:            auth.NewOIDCTokenVerifier(ctx),
 		clientConfig:             clientConfig,
+		retryState:               retryState,
+		eventIndex:               eventindex.New("imc-dispatcher", env.EventIndexSize),
+		scheduler:                fanout.NewDispatchScheduler(env.DispatchCapacity),
 	}
 
 	var globalResync func(obj interface{})
@@ -158,6 +215,7 @@ func NewController(
 	}
 
 	r.featureStore = featureStore
+	r.enqueueKey = impl.EnqueueKey
 
 	// Watch for inmemory channels.
 	inmemorychannelInformer.Informer().AddEventHandler(
@@ -169,6 +227,25 @@ func NewController(
 				DeleteFunc: r.deleteFunc,
 			}})
 
+	// Reconcile the channels of a namespace when a RedactionPolicy in it
+	// changes, as it may apply to their subscriptions.
+	redactionPolicyInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		rp, err := kmeta.DeletionHandlingAccessor(obj)
+		if err != nil {
+			return
+		}
+		channels, err := inmemorychannelInformer.Lister().InMemoryChannels(rp.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return
+		}
+		for _, imc := range channels {
+			impl.EnqueueKey(types.NamespacedName{
+				Namespace: imc.Namespace,
+				Name:      imc.Name,
+			})
+		}
+	}))
+
 	httpArgs := &inmemorychannel.InMemoryEventDispatcherArgs{
 		Port:         httpPort,
 		ReadTimeout:  readTimeout,
@@ -178,6 +255,7 @@ func NewController(
 
 		HTTPEventReceiverOptions: []kncloudevents.HTTPEventReceiverOption{
 			kncloudevents.WithChecker(readinessCheckerHTTPHandler(readinessChecker)),
+			bindAddress,
 		},
 	}
 	httpDispatcher := inmemorychannel.NewEventDispatcher(httpArgs)
@@ -187,9 +265,7 @@ func NewController(
 		Namespace: system.Namespace(),
 		Name:      eventingtls.IMCDispatcherServerTLSSecretName,
 	}
-	serverTLSConfig := eventingtls.NewDefaultServerConfig()
-	serverTLSConfig.GetCertificate = eventingtls.GetCertificateFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
-	tlsConfig, err := eventingtls.GetTLSServerConfig(serverTLSConfig)
+	tlsConfig, err := eventingtls.GetTLSServerConfigFromSecret(ctx, secretinformer.Get(ctx), kubeclient.Get(ctx), secret)
 	if err != nil {
 		logger.Panicf("unable to get tls config: %s", err)
 	}
@@ -200,7 +276,7 @@ func NewController(
 		Handler:      sh,
 		Logger:       logger.Desugar(),
 
-		HTTPEventReceiverOptions: []kncloudevents.HTTPEventReceiverOption{kncloudevents.WithTLSConfig(tlsConfig)},
+		HTTPEventReceiverOptions: []kncloudevents.HTTPEventReceiverOption{kncloudevents.WithTLSConfig(tlsConfig), bindAddress},
 	}
 	httpsDispatcher := inmemorychannel.NewEventDispatcher(httpsArgs)
 	httpsReceiver := httpsDispatcher.GetReceiver()
@@ -210,6 +286,8 @@ func NewController(
 		logger.Panicf("unable to initialize server manager: %s", err)
 	}
 
+	r.eventIndex.StartServer(ctx, logger.Desugar(), env.EventIndexPort, bindAddress)
+
 	// Start the dispatcher.
 	go func() {
 		err := s.StartServers(ctx)
@@ -24,12 +24,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
 
 	"knative.dev/eventing/pkg/apis/eventing"
 	"knative.dev/eventing/pkg/eventingtls"
 
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	configmap "knative.dev/pkg/configmap/informer"
 	. "knative.dev/pkg/reconciler/testing"
 
@@ -40,6 +42,7 @@ import (
 	_ "knative.dev/pkg/client/injection/kube/informers/factory/filtered/fake"
 	_ "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret/fake"
 
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/redactionpolicy/fake"
 	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype/fake"
 	_ "knative.dev/eventing/pkg/client/injection/informers/messaging/v1/inmemorychannel/fake"
 )
@@ -57,7 +60,7 @@ func TestNew(t *testing.T) {
 	os.Setenv("CONTAINER_NAME", "testcontainer")
 	os.Setenv("MAX_IDLE_CONNS", "2000")
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
-	c := NewController(ctx, &configmap.InformedWatcher{})
+	c := NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 
 	if c == nil {
 		t.Fatal("Expected NewController to return a non-nil value")
@@ -76,7 +79,7 @@ func TestNewInNamespace(t *testing.T) {
 	os.Setenv("CONTAINER_NAME", "testcontainer")
 	os.Setenv("MAX_IDLE_CONNS", "2000")
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
-	c := NewController(ctx, &configmap.InformedWatcher{})
+	c := NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 
 	if c == nil {
 		t.Fatal("Expected NewController to return a non-nil value")
@@ -97,7 +100,7 @@ func TestMaxIdleConnsEqualToZero(t *testing.T) {
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "200")
 
 	require.Panics(t, func() {
-		NewController(ctx, &configmap.InformedWatcher{})
+		NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 	})
 }
 
@@ -115,7 +118,7 @@ func TestMaxIdleConnsPerHostEqualToZero(t *testing.T) {
 	os.Setenv("MAX_IDLE_CONNS_PER_HOST", "0")
 
 	require.Panics(t, func() {
-		NewController(ctx, &configmap.InformedWatcher{})
+		NewController(ctx, configmap.NewInformedWatcher(fakekubeclient.Get(ctx), system.Namespace()))
 	})
 }
 
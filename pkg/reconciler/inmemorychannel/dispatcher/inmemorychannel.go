@@ -19,12 +19,14 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
 	"knative.dev/pkg/apis/duck"
@@ -43,7 +45,9 @@ import (
 	eventingv1beta2 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1beta2"
 	messagingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/messaging/v1"
 	reconcilerv1 "knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
+	eventingv1alpha1listers "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/client/listers/eventing/v1beta2"
+	"knative.dev/eventing/pkg/eventindex"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/eventtype"
 	"knative.dev/eventing/pkg/kncloudevents"
@@ -55,12 +59,30 @@ type Reconciler struct {
 	reporter                 channel.StatsReporter
 	messagingClientSet       messagingv1.MessagingV1Interface
 	eventTypeLister          v1beta2.EventTypeLister
+	redactionPolicyLister    eventingv1alpha1listers.RedactionPolicyLister
 	eventingClient           eventingv1beta2.EventingV1beta2Interface
 	featureStore             *feature.Store
 	eventDispatcher          *kncloudevents.Dispatcher
 	tokenVerifier            *auth.OIDCTokenVerifier
 
 	clientConfig eventingtls.ClientConfig
+
+	// retryState, when not nil, persists the deliveries being retried.
+	retryState *fanout.RetryState
+
+	// eventIndex, when not nil, records the outcome of the deliveries.
+	eventIndex *eventindex.Index
+
+	// scheduler, when not nil, bounds the deliveries in flight across the
+	// channels.
+	scheduler *fanout.DispatchScheduler
+
+	// deliveryHealth holds the *fanout.DeliveryHealth of every channel, keyed
+	// by the channel types.NamespacedName.
+	deliveryHealth sync.Map
+	// enqueueKey enqueues a channel, so that changes in the delivery health
+	// of its subscribers are reported in its status.
+	enqueueKey func(types.NamespacedName)
 }
 
 // Check the interfaces Reconciler should implement
@@ -97,6 +119,10 @@ func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) rec
 		logging.FromContext(ctx).Error("Error creating config for in memory channels", zap.Error(err))
 		return err
 	}
+	if err := r.setRedactionRules(imc, config.FanoutConfig.Subscriptions); err != nil {
+		logging.FromContext(ctx).Error("Error setting redaction rules for in memory channels", zap.Error(err))
+		return err
+	}
 	var eventTypeAutoHandler *eventtype.EventTypeAutoHandler
 	var channelRef *duckv1.KReference
 	var UID *types.UID
@@ -119,6 +145,17 @@ func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) rec
 		return r.featureStore.ToContext(ctx)
 	}
 
+	deliveryHealth := r.deliveryHealthFor(imc)
+	deliveryHealth.Retain(subscriberUIDs(imc.Spec.Subscribers)...)
+	config.FanoutConfig.DeliveryHealth = deliveryHealth
+	config.FanoutConfig.RetryState = r.retryState
+	config.FanoutConfig.EventIndex = r.eventIndex
+
+	channelKey := types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name}
+	r.scheduler.SetWeight(channelKey, imc.DispatchWeight())
+	config.FanoutConfig.Scheduler = r.scheduler
+	config.FanoutConfig.Channel = channelKey
+
 	// First grab the host based MultiChannelFanoutMessage httpHandler
 	httpHandler := r.multiChannelEventHandler.GetChannelHandler(config.HostName)
 	if httpHandler == nil {
@@ -192,12 +229,15 @@ func (r *Reconciler) reconcile(ctx context.Context, imc *v1.InMemoryChannel) rec
 func (r *Reconciler) patchSubscriberStatus(ctx context.Context, imc *v1.InMemoryChannel) error {
 	after := imc.DeepCopy()
 
+	deliveryHealth := r.deliveryHealthFor(imc)
+
 	after.Status.Subscribers = make([]eventingduckv1.SubscriberStatus, 0)
 	for _, sub := range imc.Spec.Subscribers {
 		after.Status.Subscribers = append(after.Status.Subscribers, eventingduckv1.SubscriberStatus{
 			UID:                sub.UID,
 			ObservedGeneration: sub.Generation,
 			Ready:              corev1.ConditionTrue,
+			Delivery:           subscriberDeliveryStatus(deliveryHealth, sub.UID),
 		})
 	}
 	jsonPatch, err := duck.CreatePatch(imc, after)
@@ -222,6 +262,41 @@ func (r *Reconciler) patchSubscriberStatus(ctx context.Context, imc *v1.InMemory
 	return nil
 }
 
+// deliveryHealthFor returns the delivery health of the subscribers of the given channel.
+func (r *Reconciler) deliveryHealthFor(imc *v1.InMemoryChannel) *fanout.DeliveryHealth {
+	key := types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name}
+	if h, ok := r.deliveryHealth.Load(key); ok {
+		return h.(*fanout.DeliveryHealth)
+	}
+	h, _ := r.deliveryHealth.LoadOrStore(key, fanout.NewDeliveryHealth(func() {
+		if r.enqueueKey != nil {
+			r.enqueueKey(key)
+		}
+	}))
+	return h.(*fanout.DeliveryHealth)
+}
+
+func subscriberDeliveryStatus(deliveryHealth *fanout.DeliveryHealth, uid types.UID) *eventingduckv1.SubscriberDeliveryStatus {
+	health, ok := deliveryHealth.Get(uid)
+	if !ok {
+		return nil
+	}
+	lastFailureTime := metav1.NewTime(health.LastFailureTime)
+	return &eventingduckv1.SubscriberDeliveryStatus{
+		RecentFailures:  health.RecentFailures,
+		LastFailureTime: &lastFailureTime,
+		LastError:       health.LastError,
+	}
+}
+
+func subscriberUIDs(subscribers []eventingduckv1.SubscriberSpec) []types.UID {
+	uids := make([]types.UID, 0, len(subscribers))
+	for _, sub := range subscribers {
+		uids = append(uids, sub.UID)
+	}
+	return uids
+}
+
 // newConfigForInMemoryChannel creates a new Config for a single inmemory channel.
 func newConfigForInMemoryChannel(ctx context.Context, imc *v1.InMemoryChannel) (*multichannelfanout.ChannelConfig, error) {
 	featureFlags := feature.FromContext(ctx)
@@ -234,6 +309,15 @@ func newConfigForInMemoryChannel(ctx context.Context, imc *v1.InMemoryChannel) (
 			return nil, err
 		}
 
+		if conf.RetryConfig != nil && !featureFlags.IsEnabled(feature.DeliveryRetryAfter) {
+			// Retry-After headers are only respected while the feature is enabled.
+			conf.RetryConfig.RetryAfterMaxDuration = nil
+		}
+		if !featureFlags.IsEnabled(feature.DeliveryOrder) {
+			// The events are delivered in order only while the feature is enabled.
+			conf.Ordered = false
+		}
+
 		conf.Namespace = imc.Namespace
 		if isOIDCEnabled {
 			conf.ServiceAccount = &types.NamespacedName{
@@ -257,6 +341,34 @@ func newConfigForInMemoryChannel(ctx context.Context, imc *v1.InMemoryChannel) (
 	}, nil
 }
 
+// setRedactionRules sets the rules of the RedactionPolicies targeting each
+// Subscription.
+func (r *Reconciler) setRedactionRules(imc *v1.InMemoryChannel, subs []fanout.Subscription) error {
+	if r.redactionPolicyLister == nil {
+		return nil
+	}
+	policies, err := r.redactionPolicyLister.RedactionPolicies(imc.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for i := range subs {
+		if subs[i].Name == "" {
+			continue
+		}
+		for _, p := range policies {
+			if !p.AppliesTo("Subscription", subs[i].Name) {
+				continue
+			}
+			rules, err := p.RedactionRules()
+			if err != nil {
+				return fmt.Errorf("invalid redaction policy %s/%s: %w", p.Namespace, p.Name, err)
+			}
+			subs[i].Redaction = append(subs[i].Redaction, rules...)
+		}
+	}
+	return nil
+}
+
 func (r *Reconciler) deleteFunc(obj interface{}) {
 	if obj == nil {
 		return
@@ -275,6 +387,9 @@ func (r *Reconciler) deleteFunc(obj interface{}) {
 		}
 	}
 
+	r.deliveryHealth.Delete(types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name})
+	r.scheduler.Forget(types.NamespacedName{Namespace: imc.Namespace, Name: imc.Name})
+
 	handleSubscribers(imc.Spec.Subscribers, kncloudevents.DeleteAddressableHandler)
 }
 
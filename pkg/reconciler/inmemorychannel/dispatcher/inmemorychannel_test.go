@@ -18,8 +18,10 @@ package dispatcher
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -38,12 +41,15 @@ import (
 	. "knative.dev/pkg/reconciler/testing"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/apis/feature"
+	"knative.dev/eventing/pkg/apis/messaging"
 	v1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/pkg/channel/fanout"
 	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 	"knative.dev/eventing/pkg/client/injection/reconciler/messaging/v1/inmemorychannel"
+	eventingv1alpha1listers "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/eventingtls"
 	"knative.dev/eventing/pkg/kncloudevents"
 	. "knative.dev/eventing/pkg/reconciler/testing/v1"
@@ -66,6 +72,7 @@ var (
 
 	linear      = eventingduckv1.BackoffPolicyLinear
 	exponential = eventingduckv1.BackoffPolicyExponential
+	ordered     = eventingduckv1.DeliveryOrderOrdered
 
 	subscriber1UID        = types.UID("2f9b5e8e-deb6-11e8-9f32-f2801f1b9fd1")
 	subscriber2UID        = types.UID("34c5aec8-deb6-11e8-9f32-f2801f1b9fd1")
@@ -91,6 +98,27 @@ var (
 		},
 	}
 
+	subscriber1WithRetryAfterMax = eventingduckv1.SubscriberSpec{
+		UID:           subscriber1UID,
+		Generation:    subscriber1Generation,
+		SubscriberURI: apis.HTTP("call1"),
+		ReplyURI:      apis.HTTP("sink2"),
+		Delivery: &eventingduckv1.DeliverySpec{
+			Retry:         ptr.Int32(3),
+			BackoffPolicy: &linear,
+			RetryAfterMax: ptr.String("PT10S"),
+		},
+	}
+
+	subscriber1Ordered = eventingduckv1.SubscriberSpec{
+		UID:           subscriber1UID,
+		Generation:    subscriber1Generation,
+		SubscriberURI: apis.HTTP("call1"),
+		Delivery: &eventingduckv1.DeliverySpec{
+			Ordering: &ordered,
+		},
+	}
+
 	subscriber2 = eventingduckv1.SubscriberSpec{
 		UID:           subscriber2UID,
 		Generation:    subscriber2Generation,
@@ -334,6 +362,7 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 
 	testCases := map[string]struct {
 		imc        *v1.InMemoryChannel
+		features   feature.Flags
 		subs       []fanout.Subscription
 		wantSubs   []fanout.Subscription
 		wantResult reconciler.Event
@@ -510,13 +539,106 @@ func TestReconciler_ReconcileKind(t *testing.T) {
 					RetryConfig: &kncloudevents.RetryConfig{RetryMax: 3, BackoffPolicy: &linear}},
 			},
 		},
+		"with one subscriber, with retryAfterMax": {
+			imc: NewInMemoryChannel(imcName, testNS,
+				WithInitInMemoryChannelConditions,
+				WithInMemoryChannelDeploymentReady(),
+				WithInMemoryChannelServiceReady(),
+				WithInMemoryChannelEndpointsReady(),
+				WithInMemoryChannelChannelServiceReady(),
+				WithInMemoryChannelSubscribers([]eventingduckv1.SubscriberSpec{subscriber1WithRetryAfterMax}),
+				WithInMemoryChannelAddress(channelServiceAddress),
+				WithInMemoryChannelDLSUnknown(),
+				WithInMemoryChannelEventPoliciesReady()),
+			features: feature.Flags{
+				feature.DeliveryRetryAfter: feature.Enabled,
+			},
+			wantSubs: []fanout.Subscription{
+				{
+					Namespace: testNS,
+					Subscriber: duckv1.Addressable{
+						URL: apis.HTTP("call1"),
+					},
+					Reply: &duckv1.Addressable{
+						URL: apis.HTTP("sink2"),
+					},
+					RetryConfig: &kncloudevents.RetryConfig{RetryMax: 3, BackoffPolicy: &linear, RetryAfterMaxDuration: ptr.Duration(10 * time.Second)}},
+			},
+		},
+		"with one subscriber, ordered": {
+			imc: NewInMemoryChannel(imcName, testNS,
+				WithInitInMemoryChannelConditions,
+				WithInMemoryChannelDeploymentReady(),
+				WithInMemoryChannelServiceReady(),
+				WithInMemoryChannelEndpointsReady(),
+				WithInMemoryChannelChannelServiceReady(),
+				WithInMemoryChannelSubscribers([]eventingduckv1.SubscriberSpec{subscriber1Ordered}),
+				WithInMemoryChannelAddress(channelServiceAddress),
+				WithInMemoryChannelDLSUnknown(),
+				WithInMemoryChannelEventPoliciesReady()),
+			features: feature.Flags{
+				feature.DeliveryOrder: feature.Enabled,
+			},
+			wantSubs: []fanout.Subscription{
+				{
+					Namespace: testNS,
+					Subscriber: duckv1.Addressable{
+						URL: apis.HTTP("call1"),
+					},
+					RetryConfig: &kncloudevents.RetryConfig{},
+					Ordered:     true,
+				},
+			},
+		},
+		"with one subscriber, ordered and delivery-order disabled": {
+			imc: NewInMemoryChannel(imcName, testNS,
+				WithInitInMemoryChannelConditions,
+				WithInMemoryChannelDeploymentReady(),
+				WithInMemoryChannelServiceReady(),
+				WithInMemoryChannelEndpointsReady(),
+				WithInMemoryChannelChannelServiceReady(),
+				WithInMemoryChannelSubscribers([]eventingduckv1.SubscriberSpec{subscriber1Ordered}),
+				WithInMemoryChannelAddress(channelServiceAddress),
+				WithInMemoryChannelDLSUnknown(),
+				WithInMemoryChannelEventPoliciesReady()),
+			wantSubs: []fanout.Subscription{
+				{
+					Namespace: testNS,
+					Subscriber: duckv1.Addressable{
+						URL: apis.HTTP("call1"),
+					},
+					RetryConfig: &kncloudevents.RetryConfig{},
+				},
+			},
+		},
+		"with one subscriber, with retryAfterMax and retry-after disabled": {
+			imc: NewInMemoryChannel(imcName, testNS,
+				WithInitInMemoryChannelConditions,
+				WithInMemoryChannelDeploymentReady(),
+				WithInMemoryChannelServiceReady(),
+				WithInMemoryChannelEndpointsReady(),
+				WithInMemoryChannelChannelServiceReady(),
+				WithInMemoryChannelSubscribers([]eventingduckv1.SubscriberSpec{subscriber1WithRetryAfterMax}),
+				WithInMemoryChannelAddress(channelServiceAddress),
+				WithInMemoryChannelDLSUnknown(),
+				WithInMemoryChannelEventPoliciesReady()),
+			wantSubs: []fanout.Subscription{
+				{
+					Namespace: testNS,
+					Subscriber: duckv1.Addressable{
+						URL: apis.HTTP("call1"),
+					},
+					Reply: &duckv1.Addressable{
+						URL: apis.HTTP("sink2"),
+					},
+					RetryConfig: &kncloudevents.RetryConfig{RetryMax: 3, BackoffPolicy: &linear}},
+			},
+		},
 	}
 	for n, tc := range testCases {
 		ctx, _ := SetupFakeContext(t, SetUpInformerSelector)
 		ctx, fakeEventingClient := fakeeventingclient.With(ctx, tc.imc)
-		feature.ToContext(ctx, feature.Flags{
-			feature.EvenTypeAutoCreate: feature.Disabled,
-		})
+		ctx = feature.ToContext(ctx, tc.features)
 
 		oidcTokenProvider := auth.NewOIDCTokenProvider(ctx)
 		dispatcher := kncloudevents.NewDispatcher(eventingtls.ClientConfig{}, oidcTokenProvider)
@@ -627,6 +749,121 @@ func TestReconciler_Deletion(t *testing.T) {
 	}
 }
 
+func TestReconciler_DeliveryHealth(t *testing.T) {
+	imc := NewInMemoryChannel(imcName, testNS,
+		WithInMemoryChannelSubscribers(subscribers),
+		WithInMemoryChannelAddress(channelServiceAddress))
+
+	var enqueued []types.NamespacedName
+	r := &Reconciler{
+		multiChannelEventHandler: newFakeMultiChannelHandler(),
+		enqueueKey: func(key types.NamespacedName) {
+			enqueued = append(enqueued, key)
+		},
+	}
+
+	health := r.deliveryHealthFor(imc)
+	if health != r.deliveryHealthFor(imc) {
+		t.Fatal("Expected the delivery health of a channel to be reused")
+	}
+	if got := subscriberDeliveryStatus(health, subscriber1UID); got != nil {
+		t.Errorf("Expected no delivery status for a healthy subscriber, got %+v", got)
+	}
+
+	health.Record(subscriber1UID, errors.New("subscriber unavailable"))
+
+	want := []types.NamespacedName{{Namespace: testNS, Name: imcName}}
+	if diff := cmp.Diff(want, enqueued); diff != "" {
+		t.Error("Unexpected enqueued keys (-want +got):", diff)
+	}
+	got := subscriberDeliveryStatus(health, subscriber1UID)
+	if got == nil || got.RecentFailures != 1 || got.LastError != "subscriber unavailable" || got.LastFailureTime == nil {
+		t.Errorf("Unexpected delivery status %+v", got)
+	}
+	if got := subscriberDeliveryStatus(health, subscriber2UID); got != nil {
+		t.Errorf("Expected no delivery status for a healthy subscriber, got %+v", got)
+	}
+
+	r.deleteFunc(imc)
+	if r.deliveryHealthFor(imc) == health {
+		t.Error("Expected the delivery health to be removed with the channel")
+	}
+}
+
+func TestReconciler_DispatchWeight(t *testing.T) {
+	ctx, _ := SetupFakeContext(t, SetUpInformerSelector)
+	imc := NewInMemoryChannel(imcName, testNS,
+		WithInitInMemoryChannelConditions,
+		WithInMemoryChannelDeploymentReady(),
+		WithInMemoryChannelServiceReady(),
+		WithInMemoryChannelEndpointsReady(),
+		WithInMemoryChannelChannelServiceReady(),
+		WithInMemoryChannelAddress(channelServiceAddress),
+		WithInMemoryChannelDLSUnknown(),
+		WithInMemoryChannelEventPoliciesReady())
+	imc.Annotations = map[string]string{messaging.DispatchWeightAnnotation: "3"}
+
+	r := &Reconciler{
+		multiChannelEventHandler: newFakeMultiChannelHandler(),
+		featureStore:             feature.NewStore(logtesting.TestLogger(t)),
+		scheduler:                fanout.NewDispatchScheduler(10),
+	}
+	if err := r.ObserveKind(ctx, imc); err != nil {
+		t.Fatal("ObserveKind() =", err)
+	}
+
+	key := types.NamespacedName{Namespace: testNS, Name: imcName}
+	if got := r.scheduler.Weight(key); got != 3 {
+		t.Errorf("Weight() = %d, want 3", got)
+	}
+
+	r.deleteFunc(imc)
+	if got := r.scheduler.Weight(key); got != 1 {
+		t.Errorf("Weight() = %d after the channel is deleted, want 1", got)
+	}
+}
+
+func TestReconciler_SetRedactionRules(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	policies := []*eventingv1alpha1.RedactionPolicy{
+		NewRedactionPolicy("mask", testNS,
+			WithRedactionPolicyTarget("Subscription", "sub-1"),
+			WithRedactionPolicyRule("$.email", eventingv1alpha1.RedactionActionMask)),
+		NewRedactionPolicy("drop", testNS,
+			WithRedactionPolicyTarget("Subscription", "sub-1"),
+			WithRedactionPolicyTarget("Trigger", "sub-2"),
+			WithRedactionPolicyRule("$.name", eventingv1alpha1.RedactionActionDrop)),
+		NewRedactionPolicy("other-namespace", "other",
+			WithRedactionPolicyTarget("Subscription", "sub-2"),
+			WithRedactionPolicyRule("$.name", eventingv1alpha1.RedactionActionDrop)),
+	}
+	for _, p := range policies {
+		if err := indexer.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := &Reconciler{
+		redactionPolicyLister: eventingv1alpha1listers.NewRedactionPolicyLister(indexer),
+	}
+
+	imc := NewInMemoryChannel(imcName, testNS)
+	subs := []fanout.Subscription{{Name: "sub-1"}, {Name: "sub-2"}, {}}
+	if err := r.setRedactionRules(imc, subs); err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([][]string, len(subs))
+	for i, s := range subs {
+		for _, rule := range s.Redaction {
+			got[i] = append(got[i], string(rule.Action)+" "+rule.Path.String())
+		}
+	}
+	want := [][]string{{"drop $.name", "mask $.email"}, nil, nil}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Error("Unexpected redaction rules (-want +got):", diff)
+	}
+}
+
 func makePatch(namespace, name, patch string) clientgotesting.PatchActionImpl {
 	return clientgotesting.PatchActionImpl{
 		ActionImpl: clientgotesting.ActionImpl{
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kreferencemapping
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	kreferencemappinginformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping"
+	kreferencemappingreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/kreferencemapping"
+	"knative.dev/eventing/pkg/kreferencemapping"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	kReferenceMappingInformer := kreferencemappinginformer.Get(ctx)
+
+	r := &Reconciler{
+		kReferenceMappingLister: kReferenceMappingInformer.Lister(),
+	}
+	impl := kreferencemappingreconciler.NewImpl(ctx, r)
+
+	globalResync := func(interface{}) {
+		impl.GlobalResync(kReferenceMappingInformer.Informer())
+	}
+
+	// Whether a KReferenceMapping is accepted depends on the other mappings
+	// of the same kind, so all of them are reconciled on any change.
+	kReferenceMappingInformer.Informer().AddEventHandler(controller.HandleAll(globalResync))
+
+	cmw.Watch(resolver.ConfigMapName, func(cm *corev1.ConfigMap) {
+		mappings, err := kreferencemapping.FromConfigMap(cm.Data)
+		if err != nil {
+			logger.Warnw("Failed to parse the kreference mappings", zap.Error(err))
+		}
+		r.setConfigMapMappings(mappings)
+		globalResync(nil)
+	})
+
+	return impl
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kreferencemapping
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	kreferencemappingreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/kreferencemapping"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/kreferencemapping"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+const (
+	invalidMapping = "InvalidMapping"
+	conflict       = "Conflict"
+)
+
+type Reconciler struct {
+	kReferenceMappingLister eventinglisters.KReferenceMappingLister
+
+	mu                sync.RWMutex
+	configMapMappings kreferencemapping.Mappings
+}
+
+// Check that our Reconciler implements interface
+var _ kreferencemappingreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+// It verifies that no other mapping of the same kind and version, either in
+// the config-kreference-mapping ConfigMap or an older KReferenceMapping,
+// takes precedence over the KReferenceMapping.
+//
+// The mappings themselves are used by the resolvers of the sinks.
+func (r *Reconciler) ReconcileKind(ctx context.Context, m *v1alpha1.KReferenceMapping) pkgreconciler.Event {
+	mapping, err := m.Mapping()
+	if err != nil {
+		logging.FromContext(ctx).Infow("Unable to parse the mapping", zap.Error(err))
+		m.Status.MarkNotAccepted(invalidMapping, "%v", err)
+		return nil
+	}
+
+	r.mu.RLock()
+	configMapMappings := r.configMapMappings
+	r.mu.RUnlock()
+
+	mappings, err := resolver.ListMappings(configMapMappings, r.kReferenceMappingLister)
+	if err != nil {
+		return err
+	}
+
+	if other, ok := mappings.Conflict(mapping); ok {
+		if other.Name == "" {
+			m.Status.MarkNotAccepted(conflict, "%s is already mapped by the %s ConfigMap", mapping.Key(), resolver.ConfigMapName)
+		} else {
+			m.Status.MarkNotAccepted(conflict, "%s is already mapped by the KReferenceMapping %s", mapping.Key(), other.Name)
+		}
+		return nil
+	}
+	m.Status.MarkAccepted()
+	return nil
+}
+
+func (r *Reconciler) setConfigMapMappings(mappings kreferencemapping.Mappings) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configMapMappings = mappings
+}
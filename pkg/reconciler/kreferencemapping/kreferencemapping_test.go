@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kreferencemapping
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/kreferencemapping"
+	mappings "knative.dev/eventing/pkg/kreferencemapping"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	mappingName = "test-mapping"
+	otherName   = "other-mapping"
+	sinkURI     = "http://{{ .Name }}.{{ .Namespace }}.svc.cluster.local"
+)
+
+var (
+	now   = time.Now().Truncate(time.Second)
+	older = now.Add(-time.Hour)
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "not-found",
+	}, {
+		Name: "accepted",
+		Key:  mappingName,
+		Objects: []runtime.Object{
+			NewKReferenceMapping(mappingName,
+				WithKReferenceMappingCreationTimestamp(now),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+			),
+			// Maps another version.
+			NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("example.com", "v2", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewKReferenceMapping(mappingName,
+				WithKReferenceMappingCreationTimestamp(now),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+				WithInitKReferenceMappingConditions,
+				WithKReferenceMappingAccepted,
+			),
+		}},
+	}, {
+		Name: "accepted, older than the other mapping",
+		Key:  otherName,
+		Objects: []runtime.Object{
+			NewKReferenceMapping(mappingName,
+				WithKReferenceMappingCreationTimestamp(now),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+			),
+			NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingRef("v1", "Service", ""),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingRef("v1", "Service", ""),
+				WithInitKReferenceMappingConditions,
+				WithKReferenceMappingAccepted,
+			),
+		}},
+	}, {
+		Name: "conflict with an older mapping",
+		Key:  mappingName,
+		Objects: []runtime.Object{
+			NewKReferenceMapping(mappingName,
+				WithKReferenceMappingCreationTimestamp(now),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+			),
+			NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingRef("v1", "Service", ""),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewKReferenceMapping(mappingName,
+				WithKReferenceMappingCreationTimestamp(now),
+				WithKReferenceMappingFrom("example.com", "v1", "Sink"),
+				WithKReferenceMappingURI(sinkURI),
+				WithInitKReferenceMappingConditions,
+				WithKReferenceMappingNotAccepted(conflict, "Sink.v1.example.com is already mapped by the KReferenceMapping "+otherName),
+			),
+		}},
+	}, {
+		Name: "conflict with the configmap",
+		Key:  otherName,
+		Objects: []runtime.Object{
+			NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("", "v1", "Pod"),
+				WithKReferenceMappingURI(sinkURI),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewKReferenceMapping(otherName,
+				WithKReferenceMappingCreationTimestamp(older),
+				WithKReferenceMappingFrom("", "v1", "Pod"),
+				WithKReferenceMappingURI(sinkURI),
+				WithInitKReferenceMappingConditions,
+				WithKReferenceMappingNotAccepted(conflict, "Pod.v1 is already mapped by the config-kreference-mapping ConfigMap"),
+			),
+		}},
+	}}
+
+	configMapMappings, err := mappings.FromConfigMap(map[string]string{
+		"Pod.v1": "https://addressable-pod.{{ .SystemNamespace }}.svc.cluster.local/{{ .Name }}",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			kReferenceMappingLister: listers.GetKReferenceMappingLister(),
+		}
+		r.setConfigMapMappings(configMapMappings)
+		return kreferencemapping.NewReconciler(ctx, logger,
+			fakeeventingclient.Get(ctx), listers.GetKReferenceMappingLister(),
+			controller.GetEventRecorder(ctx), r)
+	},
+		false,
+		logger,
+	))
+}
@@ -19,11 +19,13 @@ package resources
 import (
 	"fmt"
 
+	"k8s.io/utils/pointer"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	v1 "knative.dev/eventing/pkg/apis/flows/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 )
@@ -114,5 +116,20 @@ func NewSubscription(branchNumber int, p *v1.Parallel) *messagingv1.Subscription
 	} else if p.Spec.Reply != nil {
 		r.Spec.Reply = p.Spec.Reply.DeepCopy()
 	}
+
+	// The timeout and the fallback of the branch are the delivery timeout
+	// and dead letter sink of the subscriber.
+	if timeout := p.Spec.Branches[branchNumber].Timeout; timeout != nil {
+		if r.Spec.Delivery == nil {
+			r.Spec.Delivery = &eventingduckv1.DeliverySpec{}
+		}
+		r.Spec.Delivery.Timeout = pointer.String(*timeout)
+	}
+	if fallback := p.Spec.Branches[branchNumber].Fallback; fallback != nil {
+		if r.Spec.Delivery == nil {
+			r.Spec.Delivery = &eventingduckv1.DeliverySpec{}
+		}
+		r.Spec.Delivery.DeadLetterSink = fallback.DeepCopy()
+	}
 	return r
 }
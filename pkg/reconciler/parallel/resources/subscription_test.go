@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	flowsv1 "knative.dev/eventing/pkg/apis/flows/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	"knative.dev/pkg/apis"
@@ -175,3 +176,73 @@ func TestNewFilterSubscription(t *testing.T) {
 		})
 	}
 }
+
+func TestNewSubscriptionTimeoutAndFallback(t *testing.T) {
+	fallback := &duckv1.Destination{URI: apis.HTTP("example.com/fallback")}
+	retry := int32(3)
+
+	tests := []struct {
+		name   string
+		branch flowsv1.ParallelBranch
+		want   *eventingduckv1.DeliverySpec
+	}{
+		{
+			name: "without timeout and fallback",
+			branch: flowsv1.ParallelBranch{
+				Subscriber: duckv1.Destination{URI: apis.HTTP("example.com/subscriber")},
+			},
+		},
+		{
+			name: "with timeout and fallback",
+			branch: flowsv1.ParallelBranch{
+				Subscriber: duckv1.Destination{URI: apis.HTTP("example.com/subscriber")},
+				Timeout:    pointer.String("PT5S"),
+				Fallback:   fallback,
+			},
+			want: &eventingduckv1.DeliverySpec{
+				Timeout:        pointer.String("PT5S"),
+				DeadLetterSink: fallback,
+			},
+		},
+		{
+			name: "with delivery",
+			branch: flowsv1.ParallelBranch{
+				Subscriber: duckv1.Destination{URI: apis.HTTP("example.com/subscriber")},
+				Delivery:   &eventingduckv1.DeliverySpec{Retry: &retry},
+				Fallback:   fallback,
+			},
+			want: &eventingduckv1.DeliverySpec{
+				Retry:          &retry,
+				DeadLetterSink: fallback,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &flowsv1.Parallel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-parallel",
+					Namespace: "test-ns",
+				},
+				Spec: flowsv1.ParallelSpec{
+					ChannelTemplate: &messagingv1.ChannelTemplateSpec{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "messaging.knative.dev/v1",
+							Kind:       "InMemoryChannel",
+						},
+					},
+					Branches: []flowsv1.ParallelBranch{tt.branch},
+				},
+			}
+
+			got := NewSubscription(0, p)
+
+			if diff := cmp.Diff(tt.want, got.Spec.Delivery); diff != "" {
+				t.Errorf("NewSubscription() delivery (-want, +got):\n%s", diff)
+			}
+			if tt.branch.Delivery != nil && tt.branch.Delivery.DeadLetterSink != nil {
+				t.Error("NewSubscription() modified the delivery of the branch")
+			}
+		})
+	}
+}
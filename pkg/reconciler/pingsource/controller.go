@@ -27,20 +27,23 @@ import (
 	"go.uber.org/zap"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
-	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/system"
 
 	"knative.dev/eventing/pkg/adapter/v2"
 	"knative.dev/eventing/pkg/apis/feature"
+	pingdefaultconfig "knative.dev/eventing/pkg/apis/sources/config"
+	kreferencemappinginformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping"
 	pingsourceinformer "knative.dev/eventing/pkg/client/injection/informers/sources/v1/pingsource"
 	pingsourcereconciler "knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/pingsource"
+	"knative.dev/eventing/pkg/leaderelection"
 	reconcilersource "knative.dev/eventing/pkg/reconciler/source"
 	"knative.dev/eventing/pkg/resolver"
 )
@@ -53,8 +56,9 @@ func NewController(
 ) *controller.Impl {
 	logger := logging.FromContext(ctx)
 
-	// Retrieve leader election config
-	leaderElectionConfig, err := sharedmain.GetLeaderElectionConfig(ctx)
+	// Retrieve the leader election config of the adapter, which may override
+	// the one of the controller.
+	leaderElectionConfig, err := leaderelection.GetComponentConfig(ctx, kubeclient.Get(ctx), mtadapterName)
 	if err != nil {
 		logger.Fatalw("Error loading leader election configuration", zap.Error(err))
 	}
@@ -80,10 +84,18 @@ func NewController(
 	pingSourceInformer := pingsourceinformer.Get(ctx)
 	oidcServiceaccountInformer := serviceaccountinformer.Get(ctx, auth.OIDCLabelSelector)
 
+	// Update the mt adapter when the configurations it is given change.
+	configAcc := reconcilersource.WatchConfigurations(ctx, component, cmw, reconcilersource.WithOnChange(func() {
+		if globalResync != nil {
+			globalResync(nil)
+		}
+	}))
+
 	r := &Reconciler{
 		kubeClientSet:        kubeclient.Get(ctx),
 		leConfig:             leConfig,
-		configAcc:            reconcilersource.WatchConfigurations(ctx, component, cmw),
+		retryAfterMax:        adapter.GetRetryAfterMax(logger),
+		configAcc:            configAcc,
 		serviceAccountLister: oidcServiceaccountInformer.Lister(),
 	}
 
@@ -99,8 +111,20 @@ func NewController(
 
 	r.sinkResolver = resolver.NewURIResolver(ctx, cmw, impl.Tracker)
 
+	// Resolve the sinks again when a KReferenceMapping changes.
+	kreferencemappinginformer.Get(ctx).Informer().AddEventHandler(controller.HandleAll(globalResync))
+
 	pingSourceInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
+	// Report the usage of the max-per-namespace quota of PingSources.
+	quota := newQuotaReporter(pingSourceInformer.Lister())
+	pingSourceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    quota.reportObject,
+		DeleteFunc: quota.reportObject,
+	})
+	pingStore := pingdefaultconfig.NewStore(logger.Named("ping-config-store"), quota.onConfigChanged)
+	pingStore.WatchConfigs(cmw)
+
 	// Tracker is used to notify us that the pingsource-mt-adapter Deployment has changed so that
 	// we can reconcile PingSources that depend on it
 	r.tracker = impl.Tracker
@@ -119,5 +143,13 @@ func NewController(
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Reconcile the PingSources whose sink rejected their OIDC token because
+	// of an audience mismatch, as reported by the mt adapter.
+	sinkAudienceMismatchInformer := reconcilersource.NewSinkAudienceMismatchInformer(ctx)
+	r.sinkAudienceMismatchLister = corev1listers.NewEventLister(sinkAudienceMismatchInformer.GetIndexer())
+	sinkAudienceMismatchInformer.AddEventHandler(controller.HandleAll(
+		reconcilersource.EnqueueSinkAudienceMismatchSource("PingSource", impl.EnqueueKey)))
+	go sinkAudienceMismatchInformer.Run(ctx.Done())
+
 	return impl
 }
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing/pkg/apis/feature"
+	pingdefaultconfig "knative.dev/eventing/pkg/apis/sources/config"
 
 	"knative.dev/eventing/pkg/auth"
 	filteredFactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
@@ -32,6 +33,7 @@ import (
 	"knative.dev/pkg/tracing/config"
 
 	// Fake injection informers
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping/fake"
 	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1beta2/eventtype/fake"
 	_ "knative.dev/eventing/pkg/client/injection/informers/sources/v1/pingsource/fake"
 	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
@@ -87,6 +89,14 @@ func TestNew(t *testing.T) {
 			Data: map[string]string{
 				"_example": "test-config",
 			},
+		}, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pingdefaultconfig.PingDefaultsConfigName,
+				Namespace: "knative-eventing",
+			},
+			Data: map[string]string{
+				"_example": "test-config",
+			},
 		},
 	))
 
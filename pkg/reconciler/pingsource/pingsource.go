@@ -79,7 +79,15 @@ type Reconciler struct {
 	// Leader election configuration for the mt receive adapter
 	leConfig string
 
+	// retryAfterMax is the Retry-After max of the mt receive adapter, set
+	// while the delivery-retryafter feature is enabled.
+	retryAfterMax string
+
 	serviceAccountLister v1.ServiceAccountLister
+
+	// sinkAudienceMismatchLister lists the Events of the mt adapter telling
+	// that a sink rejected the OIDC token of a PingSource.
+	sinkAudienceMismatchLister v1.EventLister
 }
 
 // Check that our Reconciler implements ReconcileKind
@@ -119,6 +127,7 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.PingSo
 		return newWarningSinkNotFound(dest)
 	}
 	source.Status.MarkSink(sinkAddr)
+	r.reconcileSinkAudienceMismatch(ctx, source)
 
 	// Make sure the global mt receive adapter is running
 	d, err := r.reconcileReceiveAdapter(ctx, source)
@@ -149,6 +158,22 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, source *sourcesv1.PingSo
 	return nil
 }
 
+// reconcileSinkAudienceMismatch surfaces in the status that the sink rejected
+// the OIDC token of the PingSource because it was issued for another audience,
+// as reported by the mt adapter.
+func (r *Reconciler) reconcileSinkAudienceMismatch(ctx context.Context, source *sourcesv1.PingSource) {
+	if source.Status.SinkAudience != nil && r.sinkAudienceMismatchLister != nil {
+		mismatch, err := reconcilersource.SinkAudienceMismatch(r.sinkAudienceMismatchLister, "PingSource", source, *source.Status.SinkAudience)
+		if err != nil {
+			logging.FromContext(ctx).Warnw("Unable to list the sink audience mismatch events", zap.Error(err))
+		} else if mismatch {
+			source.Status.MarkSinkAudienceMismatch(*source.Status.SinkAudience)
+			return
+		}
+	}
+	source.Status.ClearSinkAudienceMismatch()
+}
+
 func (r *Reconciler) FinalizeKind(ctx context.Context, source *sourcesv1.PingSource) pkgreconciler.Event {
 	logging.FromContext(ctx).Info("Deleting source")
 	// Allow for eventtypes to be cleaned up
@@ -163,6 +188,9 @@ func (r *Reconciler) reconcileReceiveAdapter(ctx context.Context, source *source
 		NoShutdownAfter: mtping.GetNoShutDownAfterValue(),
 		SinkTimeout:     adapter.GetSinkTimeout(logging.FromContext(ctx)),
 	}
+	if feature.FromContext(ctx).IsEnabled(feature.DeliveryRetryAfter) {
+		args.RetryAfterMax = r.retryAfterMax
+	}
 	expected := resources.MakeReceiveAdapterEnvVar(args)
 
 	d, err := r.kubeClientSet.AppsV1().Deployments(system.Namespace()).Get(ctx, mtadapterName, metav1.GetOptions{})
@@ -50,6 +50,7 @@ import (
 
 	"knative.dev/eventing/pkg/adapter/mtping"
 	"knative.dev/eventing/pkg/adapter/v2"
+	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
 	"knative.dev/eventing/pkg/client/injection/reconciler/sources/v1/pingsource"
@@ -112,6 +113,8 @@ const (
 	testData        = "data"
 	testDataBase64  = "ZGF0YQ==" // "data"
 
+	testRetryAfterMax = "PT30S"
+
 	sinkName   = "testsink"
 	generation = 1
 )
@@ -421,6 +424,62 @@ func TestAllCases(t *testing.T) {
 			WantUpdates: []clientgotesting.UpdateActionImpl{{
 				Object: makeAvailableMTAdapter(),
 			}},
+		}, {
+			Name: "deployment update due to retry-after max",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.DeliveryRetryAfter: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkAddressable),
+				),
+				makeAvailableMTAdapter(),
+			},
+			Key: testNS + "/" + sourceName,
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+				Eventf(corev1.EventTypeNormal, pingSourceDeploymentUpdated, `PingSource adapter deployment updated`),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkAddressable),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+					rtv1.WithPingSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled(),
+				),
+			}},
+			WantUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: makeAvailableMTAdapterWithRetryAfterMax(testRetryAfterMax),
+			}},
 		}, {
 			Name: "valid",
 			Objects: []runtime.Object{
@@ -577,6 +636,119 @@ func TestAllCases(t *testing.T) {
 				patchFinalizers(sourceName, testNS),
 			},
 		},
+		{
+			Name: "OIDC: sink audience mismatch reported by the adapter",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkOIDCDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkOIDCAddressable),
+				),
+				makeAvailableMTAdapter(),
+				makePingSourceOIDCServiceAccount(),
+				makeSinkAudienceMismatchEvent(sinkAudience),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkOIDCDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkOIDCAddressable),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+					rtv1.WithPingSourceOIDCIdentityCreatedSucceeded(),
+					rtv1.WithPingSourceOIDCServiceAccountName(makePingSourceOIDCServiceAccount().Name),
+					rtv1.WithPingSourceSinkAudienceMismatch(sinkAudience),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+		},
+		{
+			Name: "OIDC: sink audience mismatch reported for a previous audience",
+			Ctx: feature.ToContext(context.Background(), feature.Flags{
+				feature.OIDCAuthentication: feature.Enabled,
+			}),
+			Objects: []runtime.Object{
+				rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkOIDCDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+				),
+				rtv1.NewChannel(sinkName, testNS,
+					rtv1.WithInitChannelConditions,
+					rtv1.WithChannelAddress(sinkOIDCAddressable),
+				),
+				makeAvailableMTAdapter(),
+				makePingSourceOIDCServiceAccount(),
+				makeSinkAudienceMismatchEvent("previous-audience"),
+			},
+			Key: testNS + "/" + sourceName,
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: rtv1.NewPingSource(sourceName, testNS,
+					rtv1.WithPingSourceSpec(sourcesv1.PingSourceSpec{
+						Schedule:    testSchedule,
+						ContentType: testContentType,
+						Data:        testData,
+						SourceSpec: duckv1.SourceSpec{
+							Sink: sinkOIDCDest,
+						},
+					}),
+					rtv1.WithPingSource(sourceUID),
+					rtv1.WithPingSourceObjectMetaGeneration(generation),
+					// Status Update:
+					rtv1.WithInitPingSourceConditions,
+					rtv1.WithPingSourceDeployed,
+					rtv1.WithPingSourceSink(sinkOIDCAddressable),
+					rtv1.WithPingSourceCloudEventAttributes,
+					rtv1.WithPingSourceStatusObservedGeneration(generation),
+					rtv1.WithPingSourceOIDCIdentityCreatedSucceeded(),
+					rtv1.WithPingSourceOIDCServiceAccountName(makePingSourceOIDCServiceAccount().Name),
+				),
+			}},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", sourceName),
+			},
+			WantPatches: []clientgotesting.PatchActionImpl{
+				patchFinalizers(sourceName, testNS),
+			},
+		},
 		{
 			Name: "OIDC: PingSource not ready on invalid OIDC service account",
 			Ctx: feature.ToContext(context.Background(), feature.Flags{
@@ -637,10 +809,12 @@ func TestAllCases(t *testing.T) {
 	table.Test(t, rtv1.MakeFactory(func(ctx context.Context, listers *rtv1.Listers, cmw configmap.Watcher) controller.Reconciler {
 		ctx = addressable.WithDuck(ctx)
 		r := &Reconciler{
-			configAcc:            &reconcilersource.EmptyVarsGenerator{},
-			kubeClientSet:        fakekubeclient.Get(ctx),
-			tracker:              tracker.New(func(types.NamespacedName) {}, 0),
-			serviceAccountLister: listers.GetServiceAccountLister(),
+			configAcc:                  &reconcilersource.EmptyVarsGenerator{},
+			kubeClientSet:              fakekubeclient.Get(ctx),
+			tracker:                    tracker.New(func(types.NamespacedName) {}, 0),
+			serviceAccountLister:       listers.GetServiceAccountLister(),
+			sinkAudienceMismatchLister: listers.GetEventLister(),
+			retryAfterMax:              testRetryAfterMax,
 		}
 		r.sinkResolver = resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0))
 
@@ -654,10 +828,15 @@ func TestAllCases(t *testing.T) {
 }
 
 func MakeMTAdapter() *appsv1.Deployment {
+	return makeMTAdapter("")
+}
+
+func makeMTAdapter(retryAfterMax string) *appsv1.Deployment {
 	args := resources.Args{
 		ConfigEnvVars:   (&reconcilersource.EmptyVarsGenerator{}).ToEnvVars(),
 		NoShutdownAfter: mtping.GetNoShutDownAfterValue(),
 		SinkTimeout:     adapter.GetSinkTimeout(nil),
+		RetryAfterMax:   retryAfterMax,
 	}
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -683,6 +862,12 @@ func makeAvailableMTAdapter(options ...DeploymentOption) *appsv1.Deployment {
 	return ma
 }
 
+func makeAvailableMTAdapterWithRetryAfterMax(retryAfterMax string) *appsv1.Deployment {
+	ma := makeMTAdapter(retryAfterMax)
+	WithDeploymentAvailable()(ma)
+	return ma
+}
+
 func makeAvailableMTAdapterWithDifferentEnv() *appsv1.Deployment {
 	os.Setenv("K_SINK_TIMEOUT", "500")
 	ma := MakeMTAdapter()
@@ -718,3 +903,23 @@ func makePingSourceOIDCServiceAccountWithoutOwnerRef() *corev1.ServiceAccount {
 
 	return sa
 }
+
+func makeSinkAudienceMismatchEvent(audience string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sourceName + ".sink-audience-mismatch",
+			Namespace: testNS,
+			Annotations: map[string]string{
+				crstatusevent.SinkAudienceAnnotationKey: audience,
+			},
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      "PingSource",
+			Namespace: testNS,
+			Name:      sourceName,
+			UID:       sourceUID,
+		},
+		Reason: crstatusevent.SinkAudienceMismatchReason,
+		Type:   corev1.EventTypeWarning,
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingsource
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/metrics"
+
+	"knative.dev/eventing/pkg/apis/sources/config"
+	sourceslisters "knative.dev/eventing/pkg/client/listers/sources/v1"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+var (
+	// namespaceCountM records the number of PingSources in a namespace.
+	namespaceCountM = stats.Int64(
+		"pingsource_namespace_count",
+		"Number of PingSources in the namespace",
+		stats.UnitDimensionless,
+	)
+
+	// namespaceQuotaM records the max-per-namespace quota of PingSources,
+	// -1 when there is no limit.
+	namespaceQuotaM = stats.Int64(
+		"pingsource_namespace_quota",
+		"Maximum number of PingSources allowed in the namespace",
+		stats.UnitDimensionless,
+	)
+
+	namespaceKey = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+)
+
+func init() {
+	register()
+}
+
+func register() {
+	if err := view.Register(
+		&view.View{
+			Description: namespaceCountM.Description(),
+			Measure:     namespaceCountM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey},
+		},
+		&view.View{
+			Description: namespaceQuotaM.Description(),
+			Measure:     namespaceQuotaM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// quotaReporter reports the usage of the max-per-namespace quota of
+// PingSources enforced by the webhook.
+type quotaReporter struct {
+	lister sourceslisters.PingSourceLister
+	limit  atomic.Int64
+}
+
+func newQuotaReporter(lister sourceslisters.PingSourceLister) *quotaReporter {
+	r := &quotaReporter{lister: lister}
+	r.limit.Store(config.DefaultMaxPerNamespace)
+	return r
+}
+
+// onConfigChanged is called by the config-ping-defaults store, it records the
+// new quota for every namespace.
+func (r *quotaReporter) onConfigChanged(_ string, value interface{}) {
+	defaults, ok := value.(*config.PingDefaults)
+	if !ok {
+		return
+	}
+	r.limit.Store(defaults.DeepCopy().GetPingConfig().MaxPerNamespace)
+	r.reportAll()
+}
+
+// reportObject reports the quota usage of the namespace of the given
+// PingSource, it is meant to be used as an informer event handler.
+func (r *quotaReporter) reportObject(obj interface{}) {
+	accessor, err := kmeta.DeletionHandlingAccessor(obj)
+	if err != nil {
+		return
+	}
+	r.report(accessor.GetNamespace())
+}
+
+// reportAll reports the quota usage of every namespace holding PingSources.
+func (r *quotaReporter) reportAll() {
+	pingSources, err := r.lister.List(labels.Everything())
+	if err != nil {
+		return
+	}
+	namespaces := make(map[string]struct{})
+	for _, ps := range pingSources {
+		namespaces[ps.Namespace] = struct{}{}
+	}
+	for ns := range namespaces {
+		r.report(ns)
+	}
+}
+
+func (r *quotaReporter) report(namespace string) {
+	pingSources, err := r.lister.PingSources(namespace).List(labels.Everything())
+	if err != nil {
+		return
+	}
+	ctx, err := tag.New(context.Background(), tag.Insert(namespaceKey, namespace))
+	if err != nil {
+		return
+	}
+	metrics.RecordBatch(ctx, namespaceCountM.M(int64(len(pingSources))), namespaceQuotaM.M(r.limit.Load()))
+}
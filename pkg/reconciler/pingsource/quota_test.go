@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingsource
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
+
+	"knative.dev/eventing/pkg/apis/sources/config"
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	sourceslisters "knative.dev/eventing/pkg/client/listers/sources/v1"
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+)
+
+func TestQuotaReporter(t *testing.T) {
+	resetMetrics()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	ps1 := newQuotaPingSource("ns1", "ps1")
+	for _, ps := range []*sourcesv1.PingSource{ps1, newQuotaPingSource("ns1", "ps2")} {
+		if err := indexer.Add(ps); err != nil {
+			t.Fatal("indexer.Add() =", err)
+		}
+	}
+	r := newQuotaReporter(sourceslisters.NewPingSourceLister(indexer))
+
+	r.reportObject(ps1)
+	metricstest.CheckLastValueData(t, "pingsource_namespace_count", map[string]string{eventingmetrics.LabelNamespaceName: "ns1"}, 2)
+	metricstest.CheckLastValueData(t, "pingsource_namespace_quota", map[string]string{eventingmetrics.LabelNamespaceName: "ns1"}, config.DefaultMaxPerNamespace)
+
+	// A config change reports the new quota for every namespace.
+	resetMetrics()
+	r.onConfigChanged(config.PingDefaultsConfigName, &config.PingDefaults{MaxPerNamespace: 5})
+	metricstest.CheckLastValueData(t, "pingsource_namespace_quota", map[string]string{eventingmetrics.LabelNamespaceName: "ns1"}, 5)
+
+	// Deleted PingSources may be delivered as tombstones.
+	if err := indexer.Delete(ps1); err != nil {
+		t.Fatal("indexer.Delete() =", err)
+	}
+	r.reportObject(cache.DeletedFinalStateUnknown{Key: "ns1/ps1", Obj: ps1})
+	metricstest.CheckLastValueData(t, "pingsource_namespace_count", map[string]string{eventingmetrics.LabelNamespaceName: "ns1"}, 1)
+}
+
+func newQuotaPingSource(namespace, name string) *sourcesv1.PingSource {
+	return &sourcesv1.PingSource{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+func resetMetrics() {
+	// OpenCensus metrics carry global state that need to be reset between unit tests.
+	metricstest.Unregister("pingsource_namespace_count", "pingsource_namespace_quota")
+	register()
+}
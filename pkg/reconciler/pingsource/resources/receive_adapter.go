@@ -30,6 +30,7 @@ type Args struct {
 	LeConfig        string
 	NoShutdownAfter int
 	SinkTimeout     int
+	RetryAfterMax   string
 }
 
 // MakeReceiveAdapterEnvVar generates the environment variables for the pingsources
@@ -58,6 +59,9 @@ func MakeReceiveAdapterEnvVar(args Args) []corev1.EnvVar {
 	}, {
 		Name:  adapter.EnvSinkTimeout,
 		Value: strconv.Itoa(args.SinkTimeout),
+	}, {
+		Name:  adapter.EnvRetryAfterMax,
+		Value: args.RetryAfterMax,
 	}}
 
 	return append(envs, args.ConfigEnvVars...)
@@ -32,6 +32,7 @@ func TestMakePingAdapter(t *testing.T) {
 		ConfigEnvVars:   (&reconcilersource.EmptyVarsGenerator{}).ToEnvVars(),
 		NoShutdownAfter: 40,
 		SinkTimeout:     48,
+		RetryAfterMax:   "PT30S",
 	}
 
 	want := []corev1.EnvVar{{
@@ -58,6 +59,9 @@ func TestMakePingAdapter(t *testing.T) {
 	}, {
 		Name:  "K_SINK_TIMEOUT",
 		Value: "48",
+	}, {
+		Name:  "K_RETRY_AFTER_MAX",
+		Value: "PT30S",
 	}, {
 		Name:  "K_LOGGING_CONFIG",
 		Value: "",
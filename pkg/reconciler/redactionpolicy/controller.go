@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redactionpolicy
+
+import (
+	"context"
+
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+
+	redactionpolicyinformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/redactionpolicy"
+	redactionpolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/redactionpolicy"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	redactionPolicyInformer := redactionpolicyinformer.Get(ctx)
+
+	r := &Reconciler{}
+	impl := redactionpolicyreconciler.NewImpl(ctx, r)
+
+	redactionPolicyInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Tracker is used to notify us that a Trigger or Subscription referenced
+	// by a RedactionPolicy has been created or deleted.
+	r.kReferenceResolver = resolver.NewKReferenceResolverFromTracker(ctx, impl.Tracker)
+
+	return impl
+}
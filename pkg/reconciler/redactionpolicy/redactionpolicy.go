@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redactionpolicy
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	redactionpolicyreconciler "knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/redactionpolicy"
+	"knative.dev/eventing/pkg/resolver"
+)
+
+const (
+	targetsNotResolved = "TargetsNotResolved"
+)
+
+type Reconciler struct {
+	kReferenceResolver *resolver.KReferenceResolver
+}
+
+// Check that our Reconciler implements interface
+var _ redactionpolicyreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+// It verifies that the Triggers and Subscriptions referenced in .spec.to
+// exist. The referenced resources are tracked, so that the RedactionPolicy is
+// reconciled again when any of them changes.
+//
+// The rules themselves are applied by the data plane components delivering
+// events to the targets.
+func (r *Reconciler) ReconcileKind(ctx context.Context, rp *v1alpha1.RedactionPolicy) pkgreconciler.Event {
+	if err := r.resolveTargets(ctx, rp); err != nil {
+		logging.FromContext(ctx).Infow("Unable to resolve .spec.to references", zap.Error(err))
+		rp.Status.MarkTargetsNotResolved(targetsNotResolved, "%v", err)
+		return nil
+	}
+	rp.Status.MarkTargetsResolved()
+	return nil
+}
+
+func (r *Reconciler) resolveTargets(ctx context.Context, rp *v1alpha1.RedactionPolicy) error {
+	var errs []error
+	for _, to := range rp.Spec.To {
+		ref := &duckv1.KReference{
+			APIVersion: to.APIVersion,
+			Kind:       to.Kind,
+			Name:       to.Name,
+			Namespace:  rp.Namespace,
+		}
+		if _, err := r.kReferenceResolver.Resolve(ctx, ref, rp); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redactionpolicy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/client/injection/ducks/duck/v1/kresource"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	fakeeventingclient "knative.dev/eventing/pkg/client/injection/client/fake"
+	"knative.dev/eventing/pkg/client/injection/reconciler/eventing/v1alpha1/redactionpolicy"
+	"knative.dev/eventing/pkg/resolver"
+
+	. "knative.dev/eventing/pkg/reconciler/testing/v1"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	testNS              = "test-namespace"
+	redactionPolicyName = "test-redactionpolicy"
+	triggerName         = "test-trigger"
+	subscriptionName    = "test-subscription"
+	brokerName          = "test-broker"
+)
+
+var (
+	testKey = fmt.Sprintf("%s/%s", testNS, redactionPolicyName)
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "targets found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewRedactionPolicy(redactionPolicyName, testNS,
+				WithRedactionPolicyTarget("Trigger", triggerName),
+				WithRedactionPolicyTarget("Subscription", subscriptionName),
+				WithRedactionPolicyRule("$.user.email", v1alpha1.RedactionActionMask),
+			),
+			NewTrigger(triggerName, testNS, brokerName),
+			NewSubscription(subscriptionName, testNS),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewRedactionPolicy(redactionPolicyName, testNS,
+				WithRedactionPolicyTarget("Trigger", triggerName),
+				WithRedactionPolicyTarget("Subscription", subscriptionName),
+				WithRedactionPolicyRule("$.user.email", v1alpha1.RedactionActionMask),
+				WithInitRedactionPolicyConditions,
+				WithRedactionPolicyTargetsResolved,
+			),
+		}},
+	}, {
+		Name: "target not found",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewRedactionPolicy(redactionPolicyName, testNS,
+				WithRedactionPolicyTarget("Trigger", triggerName),
+				WithRedactionPolicyRule("$.user.email", v1alpha1.RedactionActionMask),
+			),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewRedactionPolicy(redactionPolicyName, testNS,
+				WithRedactionPolicyTarget("Trigger", triggerName),
+				WithRedactionPolicyRule("$.user.email", v1alpha1.RedactionActionMask),
+				WithInitRedactionPolicyConditions,
+				WithRedactionPolicyTargetsNotResolved(targetsNotResolved, fmt.Sprintf("failed to get object %s/%s: triggers.eventing.knative.dev %q not found", testNS, triggerName, triggerName)),
+			),
+		}},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		ctx = kresource.WithDuck(ctx)
+		r := &Reconciler{
+			kReferenceResolver: resolver.NewKReferenceResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+		}
+		return redactionpolicy.NewReconciler(ctx, logger,
+			fakeeventingclient.Get(ctx), listers.GetRedactionPolicyLister(),
+			controller.GetEventRecorder(ctx), r)
+	},
+		false,
+		logger,
+	))
+}
@@ -81,3 +81,18 @@ func NewSubscription(stepNumber int, s *v1.Sequence) *messagingv1.Subscription {
 	}
 	return r
 }
+
+// TerminalReply returns the terminal reply of the Subscription of a step,
+// which is the reply of the Sequence for all but the last step, so that the
+// steps can skip the remaining ones.
+func TerminalReply(stepNumber int, s *v1.Sequence) *duckv1.Destination {
+	if stepNumber >= len(s.Spec.Steps)-1 || s.Spec.Reply == nil {
+		return nil
+	}
+	return &duckv1.Destination{
+		Ref:      s.Spec.Reply.Ref,
+		URI:      s.Spec.Reply.URI,
+		Audience: s.Spec.Reply.Audience,
+		CACerts:  s.Spec.Reply.CACerts,
+	}
+}
@@ -35,6 +35,7 @@ import (
 	pkgreconciler "knative.dev/pkg/reconciler"
 
 	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/feature"
 	v1 "knative.dev/eventing/pkg/apis/flows/v1"
 	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
 	clientset "knative.dev/eventing/pkg/client/clientset/versioned"
@@ -170,6 +171,9 @@ func (r *Reconciler) reconcileChannel(ctx context.Context, channelResourceInterf
 
 func (r *Reconciler) reconcileSubscription(ctx context.Context, step int, p *v1.Sequence) (*messagingv1.Subscription, error) {
 	expected := resources.NewSubscription(step, p)
+	if feature.FromContext(ctx).IsEnabled(feature.SequenceEarlyExit) {
+		expected.Spec.TerminalReply = resources.TerminalReply(step, p)
+	}
 
 	subName := resources.SequenceSubscriptionName(p.Name, step)
 	sub, err := r.subscriptionLister.Subscriptions(p.Namespace).Get(subName)
@@ -90,15 +90,27 @@ func (s *SinkBindingSubResourcesReconciler) Reconcile(ctx context.Context, b psb
 	}
 	sb.Status.MarkSink(addr)
 
+	if sb.Spec.Projection == v1.FilesProjection {
+		if err := s.reconcileSinkFilesConfigMap(ctx, sb, addr); err != nil {
+			sb.Status.MarkBindingUnavailable("SinkFiles", err.Error())
+			return err
+		}
+	} else {
+		if err := s.removeSinkFilesConfigMapEventually(ctx, sb); err != nil {
+			return err
+		}
+		sb.Status.SinkFilesConfigMapName = nil
+	}
+
 	featureFlags := s.featureStore.Load()
 	if featureFlags.IsOIDCAuthentication() {
 		if sb.Status.SinkAudience != nil {
-			saName := auth.GetOIDCServiceAccountNameForResource(v1.SchemeGroupVersion.WithKind("SinkBinding"), sb.ObjectMeta)
+			saName := auth.GetOIDCServiceAccountName(featureFlags, v1.SchemeGroupVersion.WithKind("SinkBinding"), sb.ObjectMeta)
 			sb.Status.Auth = &duckv1.AuthStatus{
 				ServiceAccountName: &saName,
 			}
 
-			if err := auth.EnsureOIDCServiceAccountExistsForResource(ctx, s.serviceAccountLister, s.kubeclient, v1.SchemeGroupVersion.WithKind("SinkBinding"), sb.ObjectMeta); err != nil {
+			if err := auth.EnsureOIDCServiceAccountForResource(ctx, featureFlags, s.serviceAccountLister, s.kubeclient, v1.SchemeGroupVersion.WithKind("SinkBinding"), sb.ObjectMeta); err != nil {
 				sb.Status.MarkOIDCIdentityCreatedFailed("Unable to resolve service account for OIDC authentication", "%v", err)
 				return err
 			}
@@ -239,6 +251,50 @@ func (s *SinkBindingSubResourcesReconciler) removeOIDCTokenSecretEventually(ctx
 	return s.kubeclient.CoreV1().Secrets(sb.Namespace).Delete(ctx, *sb.Status.OIDCTokenSecretName, metav1.DeleteOptions{})
 }
 
+// reconcileSinkFilesConfigMap creates or updates the ConfigMap holding the
+// files of the FilesProjection of the sink, the kubelet updates the files of
+// the pods in place when it changes.
+func (s *SinkBindingSubResourcesReconciler) reconcileSinkFilesConfigMap(ctx context.Context, sb *v1.SinkBinding, addr *duckv1.Addressable) error {
+	files, err := sb.SinkFiles(addr)
+	if err != nil {
+		return err
+	}
+	configMapName := sb.SinkFilesConfigMapName()
+
+	apiVersion := fmt.Sprintf("%s/%s", v1.SchemeGroupVersion.Group, v1.SchemeGroupVersion.Version)
+	applyConfig := applyconfigurationcorev1.ConfigMap(configMapName, sb.Namespace).
+		WithOwnerReferences(&applyconfigurationmetav1.OwnerReferenceApplyConfiguration{
+			APIVersion:         &apiVersion,
+			Kind:               pointer.String("SinkBinding"),
+			Name:               &sb.Name,
+			UID:                &sb.UID,
+			Controller:         pointer.Bool(true),
+			BlockOwnerDeletion: pointer.Bool(false),
+		}).
+		WithData(files)
+
+	_, err = s.kubeclient.CoreV1().ConfigMaps(sb.Namespace).Apply(ctx, applyConfig, metav1.ApplyOptions{FieldManager: controllerAgentName})
+	if err != nil {
+		return fmt.Errorf("could not create or update sink files ConfigMap for SinkBinding %s/%s: %w", sb.Namespace, sb.Name, err)
+	}
+
+	sb.Status.SinkFilesConfigMapName = &configMapName
+
+	return nil
+}
+
+func (s *SinkBindingSubResourcesReconciler) removeSinkFilesConfigMapEventually(ctx context.Context, sb *v1.SinkBinding) error {
+	if sb.Status.SinkFilesConfigMapName == nil {
+		return nil
+	}
+
+	err := s.kubeclient.CoreV1().ConfigMaps(sb.Namespace).Delete(ctx, *sb.Status.SinkFilesConfigMapName, metav1.DeleteOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return fmt.Errorf("could not delete sink files ConfigMap for SinkBinding %s/%s: %w", sb.Namespace, sb.Name, err)
+	}
+	return nil
+}
+
 func (s *SinkBindingSubResourcesReconciler) propagateTrustBundles(ctx context.Context, sb *v1.SinkBinding) error {
 	gvk := schema.GroupVersionKind{
 		Group:   v1.SchemeGroupVersion.Group,
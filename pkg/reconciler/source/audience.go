@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	corev1informers "k8s.io/client-go/informers/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+
+	"knative.dev/eventing/pkg/adapter/v2/util/crstatusevent"
+)
+
+// NewSinkAudienceMismatchInformer returns an informer of the Events telling
+// that the sink of a source rejected its OIDC token because it was issued for
+// another audience, see crstatusevent.ReportSinkAudienceMismatch. The caller
+// is responsible for running it.
+func NewSinkAudienceMismatchInformer(ctx context.Context) cache.SharedIndexInformer {
+	return corev1informers.NewFilteredEventInformer(
+		kubeclient.Get(ctx),
+		metav1.NamespaceAll,
+		controller.GetResyncPeriod(ctx),
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("reason", crstatusevent.SinkAudienceMismatchReason).String()
+		},
+	)
+}
+
+// SinkAudienceMismatch returns true if an Event tells that the sink of the
+// source of the given kind rejected the OIDC token issued for audience.
+func SinkAudienceMismatch(lister corev1listers.EventLister, kind string, source kmeta.Accessor, audience string) (bool, error) {
+	events, err := lister.Events(source.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	for _, e := range events {
+		if isSinkAudienceMismatchOf(e, kind, source) && e.Annotations[crstatusevent.SinkAudienceAnnotationKey] == audience {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isSinkAudienceMismatchOf(e *corev1.Event, kind string, source kmeta.Accessor) bool {
+	return e.Reason == crstatusevent.SinkAudienceMismatchReason &&
+		e.InvolvedObject.Kind == kind &&
+		e.InvolvedObject.Name == source.GetName() &&
+		e.InvolvedObject.UID == source.GetUID()
+}
+
+// EnqueueSinkAudienceMismatchSource returns an event handler enqueuing the
+// source of the given kind a SinkAudienceMismatch Event is about.
+func EnqueueSinkAudienceMismatchSource(kind string, enqueue func(types.NamespacedName)) func(obj interface{}) {
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		e, ok := obj.(*corev1.Event)
+		if !ok || e.InvolvedObject.Kind != kind {
+			return
+		}
+		enqueue(types.NamespacedName{
+			Namespace: e.InvolvedObject.Namespace,
+			Name:      e.InvolvedObject.Name,
+		})
+	}
+}
@@ -59,14 +59,17 @@ type ConfigWatcher struct {
 	loggingCfg *logging.Config
 	metricsCfg *metrics.ExporterOptions
 	tracingCfg *tracingconfig.Config
+
+	// onChange is called, if not nil, every time a configuration is updated.
+	onChange func()
 }
 
 // configWatcherOption is a function option for ConfigWatchers.
 type configWatcherOption func(*ConfigWatcher, configmap.Watcher)
 
 // WatchConfigurations returns a ConfigWatcher initialized with the given
-// options. If no option is passed, the ConfigWatcher observes ConfigMaps for
-// logging, metrics and tracing.
+// options. If no option observing a ConfigMap is passed, the ConfigWatcher
+// observes ConfigMaps for logging, metrics and tracing.
 func WatchConfigurations(loggingCtx context.Context, component string,
 	cmw configmap.Watcher, opts ...configWatcherOption) *ConfigWatcher {
 
@@ -75,20 +78,28 @@ func WatchConfigurations(loggingCtx context.Context, component string,
 		component: component,
 	}
 
-	if len(opts) == 0 {
+	for _, opt := range opts {
+		opt(cw, cmw)
+	}
+
+	if cw.loggingCfg == nil && cw.metricsCfg == nil && cw.tracingCfg == nil {
 		WithLogging(cw, cmw)
 		WithMetrics(cw, cmw)
 		WithTracing(cw, cmw)
-
-	} else {
-		for _, opt := range opts {
-			opt(cw, cmw)
-		}
 	}
 
 	return cw
 }
 
+// WithOnChange calls onChange every time one of the observed configurations
+// is updated, so that the adapters they are injected into can be reconciled
+// again.
+func WithOnChange(onChange func()) configWatcherOption {
+	return func(cw *ConfigWatcher, _ configmap.Watcher) {
+		cw.onChange = onChange
+	}
+}
+
 // WithLogging observes a logging ConfigMap.
 func WithLogging(cw *ConfigWatcher, cmw configmap.Watcher) {
 	cw.loggingCfg = &logging.Config{}
@@ -159,6 +170,7 @@ func (cw *ConfigWatcher) updateFromLoggingConfigMap(cfg *corev1.ConfigMap) {
 	cw.loggingCfg = loggingCfg
 
 	cw.logger.Debugw("Updated logging config from ConfigMap", zap.Any("ConfigMap", cfg))
+	cw.notifyChange()
 }
 
 func (cw *ConfigWatcher) updateFromMetricsConfigMap(cfg *corev1.ConfigMap) {
@@ -175,6 +187,7 @@ func (cw *ConfigWatcher) updateFromMetricsConfigMap(cfg *corev1.ConfigMap) {
 	}
 
 	cw.logger.Debugw("Updated metrics config from ConfigMap", zap.Any("ConfigMap", cfg))
+	cw.notifyChange()
 }
 
 func (cw *ConfigWatcher) updateFromTracingConfigMap(cfg *corev1.ConfigMap) {
@@ -193,6 +206,13 @@ func (cw *ConfigWatcher) updateFromTracingConfigMap(cfg *corev1.ConfigMap) {
 	cw.tracingCfg = tracingCfg
 
 	cw.logger.Debugw("Updated tracing config from ConfigMap", zap.Any("ConfigMap", cfg))
+	cw.notifyChange()
+}
+
+func (cw *ConfigWatcher) notifyChange() {
+	if cw.onChange != nil {
+		cw.onChange()
+	}
 }
 
 // ToEnvVars serializes the contents of the ConfigWatcher to individual
@@ -137,6 +137,29 @@ func TestNewConfigWatcher_withOptions(t *testing.T) {
 	}
 }
 
+func TestNewConfigWatcher_withOnChange(t *testing.T) {
+	ctx := loggingtesting.TestContextWithLogger(t)
+	cmw := &configmap.ManualWatcher{}
+
+	changes := 0
+	cw := WatchConfigurations(ctx, testComponent, cmw,
+		WithOnChange(func() { changes++ }),
+	)
+
+	assert.NotNil(t, cw.LoggingConfig(), "logging config should be enabled")
+	assert.NotNil(t, cw.MetricsConfig(), "metrics config should be enabled")
+	assert.NotNil(t, cw.TracingConfig(), "tracing config should be enabled")
+
+	cmw.OnChange(newTestConfigMap(logging.ConfigMapName(), loggingConfigMapData()))
+	cmw.OnChange(newTestConfigMap(metrics.ConfigMapName(), metricsConfigMapData()))
+	cmw.OnChange(newTestConfigMap(tracingconfig.ConfigName, tracingConfigMapData()))
+	assert.Equal(t, 3, changes, "every configuration update should be notified")
+
+	envs := cw.ToEnvVars()
+	require.Len(t, envs, 3)
+	assert.Contains(t, envs[1].Value, `"ConfigMap":{"metrics.backend":"test"}`)
+}
+
 func TestLoggingConfigWithCustomLoggingLevel(t *testing.T) {
 	ctx := loggingtesting.TestContextWithLogger(t)
 	cw := WatchConfigurations(ctx, testComponentWithCustomLogLevel, configMapWatcherWithSampleData())
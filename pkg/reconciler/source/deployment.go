@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/logging"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DeploymentConfigName is the name of the ConfigMap holding the settings
+	// applied to the data plane Deployments created by the reconcilers.
+	DeploymentConfigName = "config-deployment"
+
+	// PriorityClassNameKey is the key of the priority class of the pods.
+	PriorityClassNameKey = "priority-class-name"
+	// ResourcesKey is the key of the compute resources of the containers,
+	// as YAML.
+	ResourcesKey = "resources"
+	// TopologySpreadConstraintsKey is the key of the topology spread
+	// constraints of the pods, as a YAML list.
+	TopologySpreadConstraintsKey = "topology-spread-constraints"
+)
+
+// DeploymentConfig holds the settings applied to the data plane Deployments.
+// They are presets: the fields already set, e.g. in the template of a
+// ContainerSource, are kept.
+type DeploymentConfig struct {
+	// PriorityClassName is the priority class of the pods.
+	PriorityClassName string
+	// Resources are the compute resources of the containers which don't
+	// request nor limit any.
+	Resources corev1.ResourceRequirements
+	// TopologySpreadConstraints of the pods. The pods of the Deployment are
+	// selected by the constraints without a label selector.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
+}
+
+// NewDeploymentConfigFromConfigMap creates a DeploymentConfig from the
+// supplied ConfigMap.
+func NewDeploymentConfigFromConfigMap(cm *corev1.ConfigMap) (*DeploymentConfig, error) {
+	cfg := &DeploymentConfig{}
+	if cm == nil {
+		return cfg, nil
+	}
+
+	if name, ok := cm.Data[PriorityClassNameKey]; ok {
+		name = strings.TrimSpace(name)
+		if errs := validation.IsDNS1123Subdomain(name); name != "" && len(errs) > 0 {
+			return nil, fmt.Errorf("invalid %s %q: %s", PriorityClassNameKey, name, strings.Join(errs, ", "))
+		}
+		cfg.PriorityClassName = name
+	}
+	if resources, ok := cm.Data[ResourcesKey]; ok {
+		if err := yaml.UnmarshalStrict([]byte(resources), &cfg.Resources); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ResourcesKey, err)
+		}
+	}
+	if constraints, ok := cm.Data[TopologySpreadConstraintsKey]; ok {
+		if err := yaml.UnmarshalStrict([]byte(constraints), &cfg.TopologySpreadConstraints); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", TopologySpreadConstraintsKey, err)
+		}
+		for i, c := range cfg.TopologySpreadConstraints {
+			if c.TopologyKey == "" || c.MaxSkew < 1 {
+				return nil, fmt.Errorf("invalid %s[%d]: topologyKey and a positive maxSkew are required", TopologySpreadConstraintsKey, i)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// Apply sets the settings which aren't already set on the pod spec of a
+// Deployment whose pods have the given labels.
+func (cfg *DeploymentConfig) Apply(spec *corev1.PodSpec, podLabels map[string]string) {
+	if cfg == nil {
+		return
+	}
+
+	if spec.PriorityClassName == "" {
+		spec.PriorityClassName = cfg.PriorityClassName
+	}
+
+	if len(cfg.Resources.Requests) > 0 || len(cfg.Resources.Limits) > 0 {
+		for i := range spec.Containers {
+			c := &spec.Containers[i]
+			if len(c.Resources.Requests) == 0 && len(c.Resources.Limits) == 0 {
+				c.Resources = *cfg.Resources.DeepCopy()
+			}
+		}
+	}
+
+	if len(spec.TopologySpreadConstraints) == 0 && len(cfg.TopologySpreadConstraints) > 0 {
+		spec.TopologySpreadConstraints = make([]corev1.TopologySpreadConstraint, 0, len(cfg.TopologySpreadConstraints))
+		for _, c := range cfg.TopologySpreadConstraints {
+			c := *c.DeepCopy()
+			if c.LabelSelector == nil {
+				c.LabelSelector = &metav1.LabelSelector{MatchLabels: podLabels}
+			}
+			spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, c)
+		}
+	}
+}
+
+// DeploymentConfigWatcher keeps track of the DeploymentConfig by watching
+// its ConfigMap.
+type DeploymentConfigWatcher struct {
+	logger   *zap.SugaredLogger
+	cfg      atomic.Pointer[DeploymentConfig]
+	onChange func()
+}
+
+// WatchDeploymentConfig returns a DeploymentConfigWatcher calling onChange,
+// if not nil, every time the DeploymentConfig is updated, so that the
+// Deployments can be reconciled again.
+func WatchDeploymentConfig(ctx context.Context, cmw configmap.Watcher, onChange func()) *DeploymentConfigWatcher {
+	w := &DeploymentConfigWatcher{
+		logger:   logging.FromContext(ctx),
+		onChange: onChange,
+	}
+	w.cfg.Store(&DeploymentConfig{})
+	watchConfigMap(cmw, DeploymentConfigName, w.updateFromConfigMap)
+	return w
+}
+
+// Config returns the current DeploymentConfig.
+func (w *DeploymentConfigWatcher) Config() *DeploymentConfig {
+	if w == nil {
+		return nil
+	}
+	return w.cfg.Load()
+}
+
+func (w *DeploymentConfigWatcher) updateFromConfigMap(cm *corev1.ConfigMap) {
+	cfg, err := NewDeploymentConfigFromConfigMap(cm)
+	if err != nil {
+		w.logger.Warnw("failed to create deployment config from ConfigMap", zap.String("cfg.Name", cm.Name), zap.Error(err))
+		return
+	}
+	w.cfg.Store(cfg)
+
+	w.logger.Debugw("Updated deployment config from ConfigMap", zap.Any("ConfigMap", cm))
+	if w.onChange != nil {
+		w.onChange()
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/configmap"
+	. "knative.dev/pkg/configmap/testing"
+	loggingtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestDeploymentConfigFromFile(t *testing.T) {
+	_, example := ConfigMapsFromTestFile(t, DeploymentConfigName)
+	cfg, err := NewDeploymentConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatal("NewDeploymentConfigFromConfigMap(example) =", err)
+	}
+	if cfg.PriorityClassName == "" || len(cfg.Resources.Requests) == 0 || len(cfg.TopologySpreadConstraints) == 0 {
+		t.Errorf("Expected all the settings of the example, got %+v", cfg)
+	}
+}
+
+func TestNewDeploymentConfigFromConfigMap(t *testing.T) {
+	testCases := []struct {
+		name    string
+		data    map[string]string
+		want    *DeploymentConfig
+		wantErr bool
+	}{{
+		name: "empty",
+		want: &DeploymentConfig{},
+	}, {
+		name: "all settings",
+		data: map[string]string{
+			PriorityClassNameKey: " data-plane ",
+			ResourcesKey: `
+requests:
+  cpu: 100m
+  memory: 64Mi
+limits:
+  memory: 256Mi`,
+			TopologySpreadConstraintsKey: `
+- maxSkew: 1
+  topologyKey: topology.kubernetes.io/zone
+  whenUnsatisfiable: ScheduleAnyway`,
+		},
+		want: &DeploymentConfig{
+			PriorityClassName: "data-plane",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("64Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+	}, {
+		name:    "invalid priority class name",
+		data:    map[string]string{PriorityClassNameKey: "Data_Plane"},
+		wantErr: true,
+	}, {
+		name:    "invalid resources",
+		data:    map[string]string{ResourcesKey: "requests: 100m"},
+		wantErr: true,
+	}, {
+		name:    "unknown resources field",
+		data:    map[string]string{ResourcesKey: "request:\n  cpu: 100m"},
+		wantErr: true,
+	}, {
+		name:    "constraint without topology key",
+		data:    map[string]string{TopologySpreadConstraintsKey: "- maxSkew: 1"},
+		wantErr: true,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewDeploymentConfigFromConfigMap(&corev1.ConfigMap{Data: tc.data})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewDeploymentConfigFromConfigMap() = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error("unexpected config (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentConfigApply(t *testing.T) {
+	labels := map[string]string{"app": "adapter"}
+	cfg := &DeploymentConfig{
+		PriorityClassName: "data-plane",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:     1,
+			TopologyKey: "topology.kubernetes.io/zone",
+		}},
+	}
+
+	spec := &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "adapter",
+		}, {
+			Name: "sidecar",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")},
+			},
+		}},
+	}
+	cfg.Apply(spec, labels)
+
+	want := &corev1.PodSpec{
+		PriorityClassName: "data-plane",
+		Containers: []corev1.Container{{
+			Name: "adapter",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+		}, {
+			Name: "sidecar",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("32Mi")},
+			},
+		}},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+			MaxSkew:       1,
+			TopologyKey:   "topology.kubernetes.io/zone",
+			LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+		}},
+	}
+	if diff := cmp.Diff(want, spec); diff != "" {
+		t.Error("unexpected pod spec (-want, +got) =", diff)
+	}
+	if cfg.TopologySpreadConstraints[0].LabelSelector != nil {
+		t.Error("Apply modified the config")
+	}
+
+	// The settings of the pod spec are kept.
+	spec = &corev1.PodSpec{
+		PriorityClassName:         "user",
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname"}},
+	}
+	cfg.Apply(spec, labels)
+	assert.Equal(t, "user", spec.PriorityClassName)
+	assert.Equal(t, "kubernetes.io/hostname", spec.TopologySpreadConstraints[0].TopologyKey)
+
+	// A nil config is a no-op.
+	(*DeploymentConfig)(nil).Apply(spec, labels)
+}
+
+func TestWatchDeploymentConfig(t *testing.T) {
+	cmw := configmap.NewStaticWatcher(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DeploymentConfigName},
+		Data:       map[string]string{PriorityClassNameKey: "data-plane"},
+	})
+
+	changes := 0
+	w := WatchDeploymentConfig(loggingtesting.TestContextWithLogger(t), cmw, func() { changes++ })
+	require.NoError(t, cmw.Start(context.Background().Done()))
+	assert.Equal(t, "data-plane", w.Config().PriorityClassName)
+	assert.Equal(t, 1, changes)
+
+	// Invalid updates are ignored.
+	w.updateFromConfigMap(&corev1.ConfigMap{Data: map[string]string{PriorityClassNameKey: "Data_Plane"}})
+	assert.Equal(t, "data-plane", w.Config().PriorityClassName)
+	assert.Equal(t, 1, changes)
+
+	assert.Nil(t, (*DeploymentConfigWatcher)(nil).Config())
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apiextensions/storageversion"
+	apixclient "knative.dev/pkg/client/injection/apiextensions/client"
+	crdinformer "knative.dev/pkg/client/injection/apiextensions/informers/apiextensions/v1/customresourcedefinition"
+	crdreconciler "knative.dev/pkg/client/injection/apiextensions/reconciler/apiextensions/v1/customresourcedefinition"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// ReconcilerName is the name of the reconciler.
+	ReconcilerName = "StorageVersionMigration"
+
+	// crdLabelKey and crdLabelValue select the CRDs installed with Knative
+	// Eventing.
+	crdLabelKey   = "app.kubernetes.io/name"
+	crdLabelValue = "knative-eventing"
+)
+
+// NewController creates a Reconciler migrating the stored objects of the
+// Knative Eventing CRDs to their storage version and returns the result of
+// NewImpl.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	crdInformer := crdinformer.Get(ctx)
+
+	r := &Reconciler{
+		migrator:        storageversion.NewMigrator(dynamicclient.Get(ctx), apixclient.Get(ctx)),
+		kubeClient:      kubeclient.Get(ctx),
+		configMapLister: configmapinformer.Get(ctx).Lister().ConfigMaps(system.Namespace()),
+		namespace:       system.Namespace(),
+	}
+	filterFunc := pkgreconciler.LabelFilterFunc(crdLabelKey, crdLabelValue, false)
+	impl := crdreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{
+			AgentName:         ReconcilerName,
+			PromoteFilterFunc: filterFunc,
+		}
+	})
+
+	crdInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: filterFunc,
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	return impl
+}
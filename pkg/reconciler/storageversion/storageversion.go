@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apiextensions/storageversion"
+	crdreconciler "knative.dev/pkg/client/injection/apiextensions/reconciler/apiextensions/v1/customresourcedefinition"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the system namespace
+	// reporting the progress of the migrations, keyed by CRD name.
+	ConfigMapName = "storage-version-migration"
+
+	// The states of the migration of a CRD.
+	StateMigrating = "Migrating"
+	StateMigrated  = "Migrated"
+	StateFailed    = "Failed"
+
+	storageVersionMigrated = "StorageVersionMigrated"
+)
+
+// MigrationStatus is the progress of the migration of the objects of a CRD,
+// as reported in the ConfigMap.
+type MigrationStatus struct {
+	// StorageVersion is the version the objects are migrated to.
+	StorageVersion string `json:"storageVersion"`
+	// State is Migrating, Migrated or Failed.
+	State string `json:"state"`
+	// Message explains why the migration failed.
+	Message string `json:"message,omitempty"`
+}
+
+// Reconciler migrates the stored objects of a CRD to its storage version when
+// the CRD still records objects stored in other versions, so that the older
+// versions can be removed from the CRD.
+type Reconciler struct {
+	migrator   *storageversion.Migrator
+	kubeClient kubernetes.Interface
+	// configMapLister lists the ConfigMaps of the namespace of the progress
+	// ConfigMap.
+	configMapLister corev1listers.ConfigMapNamespaceLister
+	// namespace is the namespace of the progress ConfigMap.
+	namespace string
+}
+
+// Check that our Reconciler implements crdreconciler.Interface
+var _ crdreconciler.Interface = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition) pkgreconciler.Event {
+	version := storageVersion(crd)
+	if version == "" {
+		return fmt.Errorf("unable to determine the storage version of %s", crd.Name)
+	}
+
+	// The ConfigMap returned by each report is passed to the next one, as the
+	// lister doesn't reflect the reports made during this reconciliation.
+	cm, err := r.configMapLister.Get(ConfigMapName)
+	if apierrs.IsNotFound(err) {
+		cm = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get the ConfigMap %s: %w", ConfigMapName, err)
+	}
+
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == version {
+		_, err := r.reportStatus(ctx, cm, crd.Name, MigrationStatus{StorageVersion: version, State: StateMigrated})
+		return err
+	}
+
+	if cm, err = r.reportStatus(ctx, cm, crd.Name, MigrationStatus{StorageVersion: version, State: StateMigrating}); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Infow("Migrating the stored objects", zap.String("crd", crd.Name),
+		zap.Strings("storedVersions", crd.Status.StoredVersions), zap.String("storageVersion", version))
+	gr := schema.GroupResource{Group: crd.Spec.Group, Resource: crd.Spec.Names.Plural}
+	if err := r.migrator.Migrate(ctx, gr); err != nil {
+		status := MigrationStatus{StorageVersion: version, State: StateFailed, Message: err.Error()}
+		if _, reportErr := r.reportStatus(ctx, cm, crd.Name, status); reportErr != nil {
+			logging.FromContext(ctx).Errorw("Unable to report the failed migration", zap.Error(reportErr))
+		}
+		return fmt.Errorf("failed to migrate the stored objects of %s to %s: %w", crd.Name, version, err)
+	}
+
+	if _, err := r.reportStatus(ctx, cm, crd.Name, MigrationStatus{StorageVersion: version, State: StateMigrated}); err != nil {
+		return err
+	}
+	return pkgreconciler.NewEvent(corev1.EventTypeNormal, storageVersionMigrated, "Stored objects migrated to %s", version)
+}
+
+// reportStatus records the status of the migration of the CRD in the
+// progress ConfigMap cm, creating it when cm is nil, and returns the ConfigMap
+// as recorded.
+func (r *Reconciler) reportStatus(ctx context.Context, cm *corev1.ConfigMap, crdName string, status MigrationStatus) (*corev1.ConfigMap, error) {
+	raw, err := json.Marshal(status)
+	if err != nil {
+		return cm, err
+	}
+
+	configMaps := r.kubeClient.CoreV1().ConfigMaps(r.namespace)
+	if cm == nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ConfigMapName,
+				Namespace: r.namespace,
+				Labels: map[string]string{
+					crdLabelKey: crdLabelValue,
+				},
+			},
+			Data: map[string]string{crdName: string(raw)},
+		}
+		created, err := configMaps.Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the ConfigMap %s: %w", ConfigMapName, err)
+		}
+		return created, nil
+	}
+
+	if cm.Data[crdName] == string(raw) {
+		return cm, nil
+	}
+	desired := cm.DeepCopy()
+	if desired.Data == nil {
+		desired.Data = make(map[string]string, 1)
+	}
+	desired.Data[crdName] = string(raw)
+	updated, err := configMaps.Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		return cm, fmt.Errorf("failed to update the ConfigMap %s: %w", ConfigMapName, err)
+	}
+	return updated, nil
+}
+
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apiextensions/storageversion"
+	fakeapixclient "knative.dev/pkg/client/injection/apiextensions/client/fake"
+	crdreconciler "knative.dev/pkg/client/injection/apiextensions/reconciler/apiextensions/v1/customresourcedefinition"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"knative.dev/eventing/pkg/reconciler/sugar/resources"
+
+	. "knative.dev/eventing/pkg/reconciler/testing"
+	. "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	crdName         = "brokers.eventing.knative.dev"
+	systemNamespace = "knative-eventing"
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "not-found",
+	}, {
+		Name: "no storage version",
+		Key:  crdName,
+		Objects: []runtime.Object{
+			NewCustomResourceDefinition(crdName),
+		},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", "unable to determine the storage version of %s", crdName),
+		},
+	}, {
+		Name: "already migrated",
+		Key:  crdName,
+		// The progress ConfigMap is in the system namespace.
+		SkipNamespaceValidation: true,
+		Objects: []runtime.Object{
+			newCRD(WithCustomResourceDefinitionStoredVersions("v1")),
+		},
+		WantCreates: []runtime.Object{
+			newProgressConfigMap(t, MigrationStatus{StorageVersion: "v1", State: StateMigrated}),
+		},
+	}, {
+		Name: "already migrated and reported",
+		Key:  crdName,
+		Objects: []runtime.Object{
+			newCRD(WithCustomResourceDefinitionStoredVersions("v1")),
+			newProgressConfigMap(t, MigrationStatus{StorageVersion: "v1", State: StateMigrated}),
+		},
+	}, {
+		Name: "stored objects migrated",
+		Key:  crdName,
+		// The progress ConfigMap is in the system namespace.
+		SkipNamespaceValidation: true,
+		Objects: []runtime.Object{
+			newCRD(WithCustomResourceDefinitionStoredVersions("v1beta1", "v1")),
+			resources.MakeBroker("ns", "broker"),
+		},
+		WantCreates: []runtime.Object{
+			newProgressConfigMap(t, MigrationStatus{StorageVersion: "v1", State: StateMigrating}),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newProgressConfigMap(t, MigrationStatus{StorageVersion: "v1", State: StateMigrated}),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{Namespace: "ns"},
+			Name:       "broker",
+			PatchType:  "application/merge-patch+json",
+			Patch:      []byte(`{}`),
+		}, {
+			Name:      crdName,
+			PatchType: "application/strategic-merge-patch+json",
+			Patch:     []byte(`{"status":{"storedVersions":["v1"]}}`),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, storageVersionMigrated, "Stored objects migrated to v1"),
+		},
+	}, {
+		Name: "migration failed",
+		Key:  crdName,
+		// The progress ConfigMap is in the system namespace.
+		SkipNamespaceValidation: true,
+		Objects: []runtime.Object{
+			newCRD(WithCustomResourceDefinitionStoredVersions("v1beta1", "v1")),
+			newProgressConfigMap(t, MigrationStatus{StorageVersion: "v1", State: StateMigrating}),
+		},
+		WithReactors: []clientgotesting.ReactionFunc{
+			InduceFailure("patch", "customresourcedefinitions"),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: newProgressConfigMap(t, MigrationStatus{
+				StorageVersion: "v1",
+				State:          StateFailed,
+				Message:        "unable to drop storage version definition brokers.eventing.knative.dev - inducing failure for patch customresourcedefinitions",
+			}),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{{
+			Name:      crdName,
+			PatchType: "application/strategic-merge-patch+json",
+			Patch:     []byte(`{"status":{"storedVersions":["v1"]}}`),
+		}},
+		WantErr: true,
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "InternalError", "failed to migrate the stored objects of %s to v1: unable to drop storage version definition brokers.eventing.knative.dev - inducing failure for patch customresourcedefinitions", crdName),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			migrator:        storageversion.NewMigrator(fakedynamicclient.Get(ctx), fakeapixclient.Get(ctx)),
+			kubeClient:      fakekubeclient.Get(ctx),
+			configMapLister: listers.GetConfigMapLister().ConfigMaps(systemNamespace),
+			namespace:       systemNamespace,
+		}
+		return crdreconciler.NewReconciler(ctx, logger,
+			fakeapixclient.Get(ctx), listers.GetCustomResourceDefinitionLister(),
+			controller.GetEventRecorder(ctx), r)
+	}, false, logger))
+}
+
+func newCRD(o ...CustomResourceDefinitionOption) *apiextensionsv1.CustomResourceDefinition {
+	return NewCustomResourceDefinition(crdName, append([]CustomResourceDefinitionOption{
+		WithCustomResourceDefinitionLabels(map[string]string{crdLabelKey: crdLabelValue}),
+		WithCustomResourceDefinitionGroup("eventing.knative.dev"),
+		WithCustomResourceDefinitionNames(apiextensionsv1.CustomResourceDefinitionNames{
+			Kind:   "Broker",
+			Plural: "brokers",
+		}),
+		WithCustomResourceDefinitionVersions([]apiextensionsv1.CustomResourceDefinitionVersion{{
+			Name:   "v1beta1",
+			Served: true,
+		}, {
+			Name:    "v1",
+			Served:  true,
+			Storage: true,
+		}}),
+	}, o...)...)
+}
+
+func newProgressConfigMap(t *testing.T, status MigrationStatus) *corev1.ConfigMap {
+	raw, err := json.Marshal(status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewConfigMap(ConfigMapName, systemNamespace,
+		WithConfigMapLabels(metav1.LabelSelector{MatchLabels: map[string]string{crdLabelKey: crdLabelValue}}),
+		WithConfigMapData(map[string]string{crdName: string(raw)}),
+	)
+}
@@ -58,6 +58,7 @@ const (
 	channelReferenceFailed              = "ChannelReferenceFailed"
 	subscriberResolveFailed             = "SubscriberResolveFailed"
 	replyResolveFailed                  = "ReplyResolveFailed"
+	terminalReplyResolveFailed          = "TerminalReplyResolveFailed"
 	deadLetterSinkResolveFailed         = "DeadLetterSinkResolveFailed"
 )
 
@@ -200,6 +201,10 @@ func (r *Reconciler) resolveSubscriptionURIs(ctx context.Context, subscription *
 		return err
 	}
 
+	if err := r.resolveTerminalReply(ctx, subscription); err != nil {
+		return err
+	}
+
 	if err := r.resolveDeadLetterSink(ctx, subscription, channel); err != nil {
 		return err
 	}
@@ -285,6 +290,35 @@ func (r *Reconciler) resolveReply(ctx context.Context, subscription *v1.Subscrip
 	return nil
 }
 
+func (r *Reconciler) resolveTerminalReply(ctx context.Context, subscription *v1.Subscription) pkgreconciler.Event {
+	// Resolve TerminalReply.
+	terminalReply := subscription.Spec.TerminalReply.DeepCopy()
+	ctx = apis.WithinParent(ctx, subscription.ObjectMeta)
+
+	if !isNilOrEmptyDestination(terminalReply) {
+		terminalReply.SetDefaults(ctx)
+
+		terminalReplyAddr, err := r.destinationResolver.AddressableFromDestinationV1(ctx, *terminalReply, subscription)
+		if err != nil {
+			logging.FromContext(ctx).Warnw("Failed to resolve terminal reply",
+				zap.Error(err),
+				zap.Any("terminalReply", terminalReply))
+			subscription.Status.MarkReferencesNotResolved(terminalReplyResolveFailed, "Failed to resolve spec.terminalReply: %v", err)
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, terminalReplyResolveFailed, "Failed to resolve spec.terminalReply: %w", err)
+		}
+
+		logging.FromContext(ctx).Debugw("Resolved terminal reply", zap.Any("terminalReply", terminalReplyAddr))
+		subscription.Status.PhysicalSubscription.TerminalReplyURI = terminalReplyAddr.URL
+		subscription.Status.PhysicalSubscription.TerminalReplyCACerts = terminalReplyAddr.CACerts
+		subscription.Status.PhysicalSubscription.TerminalReplyAudience = terminalReplyAddr.Audience
+	} else {
+		subscription.Status.PhysicalSubscription.TerminalReplyURI = nil
+		subscription.Status.PhysicalSubscription.TerminalReplyCACerts = nil
+		subscription.Status.PhysicalSubscription.TerminalReplyAudience = nil
+	}
+	return nil
+}
+
 func (r *Reconciler) resolveDeadLetterSink(ctx context.Context, subscription *v1.Subscription, channel *eventingduckv1.Channelable) pkgreconciler.Event {
 	// resolve the Subscription's dls first, fall back to the Channels's
 	if subscription.Spec.Delivery != nil && subscription.Spec.Delivery.DeadLetterSink != nil {
@@ -532,6 +566,9 @@ func (r *Reconciler) updateChannelAddSubscriptionSpec(channel *eventingduckv1.Ch
 			channel.Spec.Subscribers[i].ReplyURI = sub.Status.PhysicalSubscription.ReplyURI
 			channel.Spec.Subscribers[i].ReplyCACerts = sub.Status.PhysicalSubscription.ReplyCACerts
 			channel.Spec.Subscribers[i].ReplyAudience = sub.Status.PhysicalSubscription.ReplyAudience
+			channel.Spec.Subscribers[i].TerminalReplyURI = sub.Status.PhysicalSubscription.TerminalReplyURI
+			channel.Spec.Subscribers[i].TerminalReplyCACerts = sub.Status.PhysicalSubscription.TerminalReplyCACerts
+			channel.Spec.Subscribers[i].TerminalReplyAudience = sub.Status.PhysicalSubscription.TerminalReplyAudience
 			channel.Spec.Subscribers[i].Delivery = deliverySpec(sub, channel)
 			channel.Spec.Subscribers[i].Auth = sub.Status.Auth
 			return
@@ -539,17 +576,20 @@ func (r *Reconciler) updateChannelAddSubscriptionSpec(channel *eventingduckv1.Ch
 	}
 
 	toAdd := eventingduckv1.SubscriberSpec{
-		Name:               &sub.Name,
-		UID:                sub.UID,
-		Generation:         sub.Generation,
-		SubscriberURI:      sub.Status.PhysicalSubscription.SubscriberURI,
-		SubscriberCACerts:  sub.Status.PhysicalSubscription.SubscriberCACerts,
-		SubscriberAudience: sub.Status.PhysicalSubscription.SubscriberAudience,
-		ReplyURI:           sub.Status.PhysicalSubscription.ReplyURI,
-		ReplyCACerts:       sub.Status.PhysicalSubscription.ReplyCACerts,
-		ReplyAudience:      sub.Status.PhysicalSubscription.ReplyAudience,
-		Delivery:           deliverySpec(sub, channel),
-		Auth:               sub.Status.Auth,
+		Name:                  &sub.Name,
+		UID:                   sub.UID,
+		Generation:            sub.Generation,
+		SubscriberURI:         sub.Status.PhysicalSubscription.SubscriberURI,
+		SubscriberCACerts:     sub.Status.PhysicalSubscription.SubscriberCACerts,
+		SubscriberAudience:    sub.Status.PhysicalSubscription.SubscriberAudience,
+		ReplyURI:              sub.Status.PhysicalSubscription.ReplyURI,
+		ReplyCACerts:          sub.Status.PhysicalSubscription.ReplyCACerts,
+		ReplyAudience:         sub.Status.PhysicalSubscription.ReplyAudience,
+		TerminalReplyURI:      sub.Status.PhysicalSubscription.TerminalReplyURI,
+		TerminalReplyCACerts:  sub.Status.PhysicalSubscription.TerminalReplyCACerts,
+		TerminalReplyAudience: sub.Status.PhysicalSubscription.TerminalReplyAudience,
+		Delivery:              deliverySpec(sub, channel),
+		Auth:                  sub.Status.Auth,
 	}
 
 	// Must not have been found. Add it.
@@ -569,7 +609,8 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			channel.Spec.Delivery.Retry != nil ||
 			channel.Spec.Delivery.BackoffPolicy != nil ||
 			channel.Spec.Delivery.Timeout != nil ||
-			channel.Spec.Delivery.RetryAfterMax != nil {
+			channel.Spec.Delivery.RetryAfterMax != nil ||
+			channel.Spec.Delivery.Ordering != nil {
 			if delivery == nil {
 				delivery = &eventingduckv1.DeliverySpec{}
 			}
@@ -578,6 +619,7 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			delivery.BackoffDelay = channel.Spec.Delivery.BackoffDelay
 			delivery.Timeout = channel.Spec.Delivery.Timeout
 			delivery.RetryAfterMax = channel.Spec.Delivery.RetryAfterMax
+			delivery.Ordering = channel.Spec.Delivery.Ordering
 		}
 		return
 	}
@@ -595,7 +637,8 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 			sub.Spec.Delivery.Retry != nil ||
 			sub.Spec.Delivery.BackoffPolicy != nil ||
 			sub.Spec.Delivery.Timeout != nil ||
-			sub.Spec.Delivery.RetryAfterMax != nil) {
+			sub.Spec.Delivery.RetryAfterMax != nil ||
+			sub.Spec.Delivery.Ordering != nil) {
 		if delivery == nil {
 			delivery = &eventingduckv1.DeliverySpec{}
 		}
@@ -604,6 +647,7 @@ func deliverySpec(sub *v1.Subscription, channel *eventingduckv1.Channelable) (de
 		delivery.BackoffDelay = sub.Spec.Delivery.BackoffDelay
 		delivery.Timeout = sub.Spec.Delivery.Timeout
 		delivery.RetryAfterMax = sub.Spec.Delivery.RetryAfterMax
+		delivery.Ordering = sub.Spec.Delivery.Ordering
 	}
 	return
 }
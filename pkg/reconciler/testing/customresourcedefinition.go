@@ -70,3 +70,9 @@ func WithCustomResourceDefinitionDeletionTimestamp() CustomResourceDefinitionOpt
 		crd.ObjectMeta.SetDeletionTimestamp(&t)
 	}
 }
+
+func WithCustomResourceDefinitionStoredVersions(versions ...string) CustomResourceDefinitionOption {
+	return func(crd *apiextensionsv1.CustomResourceDefinition) {
+		crd.Status.StoredVersions = versions
+	}
+}
@@ -78,6 +78,18 @@ func WithApiServerSourceSinkAddressable(sinkAddr *duckv1.Addressable) ApiServerS
 	}
 }
 
+func WithApiServerSourceDeadLetterSink(addr *duckv1.Addressable) ApiServerSourceOption {
+	return func(s *v1.ApiServerSource) {
+		s.Status.MarkDeadLetterSink(addr)
+	}
+}
+
+func WithApiServerSourceDeadLetterSinkNotFound(message string) ApiServerSourceOption {
+	return func(s *v1.ApiServerSource) {
+		s.Status.MarkNoDeadLetterSink("DeadLetterSinkNotFound", "%s", message)
+	}
+}
+
 func WithApiServerSourceDeploymentUnavailable(s *v1.ApiServerSource) {
 	// The Deployment uses GenerateName, so its name is empty.
 	name := kmeta.ChildName(fmt.Sprintf("apiserversource-%s-", s.Name), string(s.GetUID()))
@@ -88,6 +100,11 @@ func WithApiServerSourceDeployed(s *v1.ApiServerSource) {
 	s.Status.PropagateDeploymentAvailability(testing.NewDeployment("any", "any", testing.WithDeploymentAvailable()))
 }
 
+func WithApiServerSourceSinkPropagated(s *v1.ApiServerSource) {
+	s.Status.PropagateSinkToDeployment(testing.NewDeployment("any", "any",
+		testing.WithDeploymentContainer("any", "any", nil, nil, v1.SinkEnvVars(s.Status.SinkURI.String(), s.Status.SinkCACerts, s.Status.SinkAudience), nil)))
+}
+
 func WithApiServerSourceReferenceModeEventTypes(source string) ApiServerSourceOption {
 	return func(s *v1.ApiServerSource) {
 		ceAttributes := make([]duckv1.CloudEventAttributes, 0, len(apisources.ApiServerSourceEventReferenceModeTypes))
@@ -114,6 +131,19 @@ func WithApiServerSourceResourceModeEventTypes(source string) ApiServerSourceOpt
 	}
 }
 
+// WithApiServerSourceMonitoringEventTypes adds the types of the events about
+// the source to its CloudEventAttributes.
+func WithApiServerSourceMonitoringEventTypes(source string) ApiServerSourceOption {
+	return func(s *v1.ApiServerSource) {
+		for _, apiServerSourceType := range apisources.ApiServerSourceMonitoringEventTypes {
+			s.Status.CloudEventAttributes = append(s.Status.CloudEventAttributes, duckv1.CloudEventAttributes{
+				Type:   apiServerSourceType,
+				Source: source,
+			})
+		}
+	}
+}
+
 func WithApiServerSourceSufficientPermissions(s *v1.ApiServerSource) {
 	s.Status.MarkSufficientPermissions()
 }
@@ -145,6 +175,17 @@ func WithApiServerSourceObjectMetaGeneration(generation int64) ApiServerSourceOp
 	}
 }
 
+func WithApiServerSourceAnnotation(key, value string) ApiServerSourceOption {
+	return func(c *v1.ApiServerSource) {
+		annotations := c.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[key] = value
+		c.SetAnnotations(annotations)
+	}
+}
+
 func WithApiServerSourceNamespaceSelector(nsSelector metav1.LabelSelector) ApiServerSourceOption {
 	return func(c *v1.ApiServerSource) {
 		c.Spec.NamespaceSelector = &nsSelector
@@ -80,6 +80,13 @@ func WithBrokerDeletionTimestamp(b *v1.Broker) {
 	b.ObjectMeta.SetDeletionTimestamp(&t)
 }
 
+// WithBrokerDeletionBlocked marks the deletion of the Broker as blocked.
+func WithBrokerDeletionBlocked(reason, message string) BrokerOption {
+	return func(b *v1.Broker) {
+		b.Status.MarkDeletionBlocked(reason, "%s", message)
+	}
+}
+
 // WithBrokerChannel sets the Broker's ChannelTemplateSpec to the specified CRD.
 func WithBrokerConfig(config *duckv1.KReference) BrokerOption {
 	return func(b *v1.Broker) {
@@ -165,6 +172,28 @@ func WithBrokerClass(bc string) BrokerOption {
 	}
 }
 
+// WithBrokerAnnotation sets an annotation on the Broker.
+func WithBrokerAnnotation(key, value string) BrokerOption {
+	return func(b *v1.Broker) {
+		annotations := b.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string, 1)
+		}
+		annotations[key] = value
+		b.SetAnnotations(annotations)
+	}
+}
+
+// WithBrokerStatusAnnotation sets an annotation on the status of the Broker.
+func WithBrokerStatusAnnotation(key, value string) BrokerOption {
+	return func(b *v1.Broker) {
+		if b.Status.Annotations == nil {
+			b.Status.Annotations = make(map[string]string, 1)
+		}
+		b.Status.Annotations[key] = value
+	}
+}
+
 func WithChannelAddressAnnotation(address string) BrokerOption {
 	return func(b *v1.Broker) {
 		if b.Status.Annotations == nil {
@@ -207,6 +236,16 @@ func WithChannelAPIVersionAnnotation(apiVersion string) BrokerOption {
 	}
 }
 
+func WithPreviousChannelAnnotations(apiVersion, kind string) BrokerOption {
+	return func(b *v1.Broker) {
+		if b.Status.Annotations == nil {
+			b.Status.Annotations = make(map[string]string, 2)
+		}
+		b.Status.Annotations[eventing.BrokerPreviousChannelAPIVersionStatusAnnotationKey] = apiVersion
+		b.Status.Annotations[eventing.BrokerPreviousChannelKindStatusAnnotationKey] = kind
+	}
+}
+
 func WithChannelKindAnnotation(kind string) BrokerOption {
 	return func(b *v1.Broker) {
 		if b.Status.Annotations == nil {
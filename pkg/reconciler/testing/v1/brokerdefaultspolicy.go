@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// BrokerDefaultsPolicyOption enables further configuration of a BrokerDefaultsPolicy.
+type BrokerDefaultsPolicyOption func(*v1alpha1.BrokerDefaultsPolicy)
+
+// NewBrokerDefaultsPolicy creates a BrokerDefaultsPolicy with BrokerDefaultsPolicyOptions.
+func NewBrokerDefaultsPolicy(name string, o ...BrokerDefaultsPolicyOption) *v1alpha1.BrokerDefaultsPolicy {
+	p := &v1alpha1.BrokerDefaultsPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for _, opt := range o {
+		opt(p)
+	}
+	p.SetDefaults(context.Background())
+
+	return p
+}
+
+func WithInitBrokerDefaultsPolicyConditions(p *v1alpha1.BrokerDefaultsPolicy) {
+	p.Status.InitializeConditions()
+}
+
+func WithBrokerDefaultsPolicyNamespacesSelected(count int32) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Status.MarkNamespacesSelected(count)
+	}
+}
+
+func WithBrokerDefaultsPolicyNoNamespacesSelected(reason, message string) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Status.MarkNoNamespacesSelected(reason, "%s", message)
+	}
+}
+
+func WithBrokerDefaultsPolicyNamespaceSelector(selector *metav1.LabelSelector) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Spec.NamespaceSelector = selector
+	}
+}
+
+func WithBrokerDefaultsPolicyLabels(labels map[string]string) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Spec.Labels = labels
+	}
+}
+
+func WithBrokerDefaultsPolicyBrokerClass(class string) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Spec.BrokerClass = class
+	}
+}
+
+func WithBrokerDefaultsPolicyDelivery(delivery *eventingduckv1.DeliverySpec) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.Spec.Delivery = delivery
+	}
+}
+
+func WithBrokerDefaultsPolicyCreationTimestamp(t time.Time) BrokerDefaultsPolicyOption {
+	return func(p *v1alpha1.BrokerDefaultsPolicy) {
+		p.CreationTimestamp = metav1.NewTime(t)
+	}
+}
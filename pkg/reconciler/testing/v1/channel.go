@@ -82,6 +82,19 @@ func WithChannelDeleted(c *eventingv1.Channel) {
 	c.ObjectMeta.SetDeletionTimestamp(&t)
 }
 
+func WithChannelFinalizers(finalizers ...string) ChannelOption {
+	return func(c *eventingv1.Channel) {
+		c.Finalizers = finalizers
+	}
+}
+
+// WithChannelDeletionBlocked marks the deletion of the Channel as blocked.
+func WithChannelDeletionBlocked(reason, message string) ChannelOption {
+	return func(c *eventingv1.Channel) {
+		c.Status.MarkDeletionBlocked(reason, "%s", message)
+	}
+}
+
 func WithChannelTemplate(typeMeta metav1.TypeMeta) ChannelOption {
 	return func(c *eventingv1.Channel) {
 		c.Spec.ChannelTemplate = &messagingv1.ChannelTemplateSpec{
@@ -104,3 +104,18 @@ func WithContainerSourceOIDCServiceAccountName(name string) ContainerSourceOptio
 		c.Status.Auth.ServiceAccountName = &name
 	}
 }
+
+func WithContainerSourceAnnotations(annotations map[string]string) ContainerSourceOption {
+	return func(c *v1.ContainerSource) {
+		c.Annotations = annotations
+	}
+}
+
+func WithContainerSourceStatusAnnotation(key, value string) ContainerSourceOption {
+	return func(c *v1.ContainerSource) {
+		if c.Status.Annotations == nil {
+			c.Status.Annotations = make(map[string]string, 1)
+		}
+		c.Status.Annotations[key] = value
+	}
+}
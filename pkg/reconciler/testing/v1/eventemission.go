@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/apis/feature"
+)
+
+// EventEmissionOption enables further configuration of an EventEmission.
+type EventEmissionOption func(*v1alpha1.EventEmission)
+
+// NewEventEmission creates an EventEmission with EventEmissionOptions.
+func NewEventEmission(name, namespace string, o ...EventEmissionOption) *v1alpha1.EventEmission {
+	ee := &v1alpha1.EventEmission{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	for _, opt := range o {
+		opt(ee)
+	}
+	ee.SetDefaults(context.Background())
+
+	return ee
+}
+
+func WithEventEmissionUID(uid string) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.UID = types.UID(uid)
+	}
+}
+
+func WithEventEmissionGeneration(generation int64) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Generation = generation
+	}
+}
+
+func WithEventEmissionSpec(spec v1alpha1.EventEmissionSpec) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Spec = spec
+	}
+}
+
+func WithEventEmissionSchedule(schedule string) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Spec.Schedule = schedule
+	}
+}
+
+func WithInitEventEmissionConditions(ee *v1alpha1.EventEmission) {
+	ee.Status.InitializeConditions()
+}
+
+func WithEventEmissionObservedGeneration(generation int64) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.ObservedGeneration = generation
+	}
+}
+
+func WithEventEmissionSink(addr *duckv1.Addressable) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.MarkSink(addr)
+	}
+}
+
+func WithEventEmissionNoSink(reason, message string) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.MarkNoSink(reason, message)
+	}
+}
+
+func WithEventEmissionEmissionPending(ee *v1alpha1.EventEmission) {
+	ee.Status.MarkEmissionPending("EmissionPending", "No emission has completed yet")
+}
+
+func WithEventEmissionResult(result *v1alpha1.EventEmissionResult) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.PropagateEmissionResult(result)
+	}
+}
+
+func WithEventEmissionOIDCIdentityCreatedSucceeded() EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.MarkOIDCIdentityCreatedSucceeded()
+	}
+}
+
+func WithEventEmissionOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled() EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.MarkOIDCIdentityCreatedSucceededWithReason(fmt.Sprintf("%s feature disabled", feature.OIDCAuthentication), "")
+	}
+}
+
+func WithEventEmissionOIDCIdentityCreatedFailed(reason, message string) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		ee.Status.MarkOIDCIdentityCreatedFailed(reason, message)
+	}
+}
+
+func WithEventEmissionOIDCServiceAccountName(name string) EventEmissionOption {
+	return func(ee *v1alpha1.EventEmission) {
+		if ee.Status.Auth == nil {
+			ee.Status.Auth = &duckv1.AuthStatus{}
+		}
+
+		ee.Status.Auth.ServiceAccountName = &name
+	}
+}
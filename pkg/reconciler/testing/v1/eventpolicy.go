@@ -23,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 // EventPolicyOption enables further configuration of an EventPolicy.
@@ -66,6 +67,85 @@ func WithUnreadyEventPolicyCondition(ep *v1alpha1.EventPolicy) {
 	}
 }
 
+func WithEventPolicyRefsResolved(ep *v1alpha1.EventPolicy) {
+	ep.Status.MarkRefsResolved()
+}
+
+func WithEventPolicyRefsNotResolved(reason, message string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkRefsNotResolved(reason, "%s", message)
+	}
+}
+
+func WithEventPolicySubjectsResolved(ep *v1alpha1.EventPolicy) {
+	ep.Status.MarkSubjectsResolved()
+}
+
+func WithEventPolicySubjectsNotResolved(reason, message string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkSubjectsNotResolved(reason, "%s", message)
+	}
+}
+
+func WithEventPolicyOIDCEnabled(ep *v1alpha1.EventPolicy) {
+	ep.Status.MarkOIDCEnabled()
+}
+
+func WithEventPolicyOIDCDisabled(reason, message string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkOIDCDisabled(reason, "%s", message)
+	}
+}
+
+func WithEventPolicyActive(ep *v1alpha1.EventPolicy) {
+	ep.Status.MarkActive()
+}
+
+func WithEventPolicyInactive(reason, message string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkInactive(reason, "%s", message)
+	}
+}
+
+func WithEventPolicyValidity(notBefore, notAfter *metav1.Time) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Spec.Validity = &v1alpha1.EventPolicyValidity{
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		}
+	}
+}
+
+func WithEventPolicyRejectionSink(dest duckv1.Destination) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Spec.RejectionSink = &dest
+	}
+}
+
+func WithEventPolicyRejectionSinkResolved(addr *duckv1.Addressable) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkRejectionSinkResolved(addr)
+	}
+}
+
+func WithEventPolicyRejectionSinkNotResolved(reason, message string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkRejectionSinkNotResolved(reason, "%s", message)
+	}
+}
+
+func WithEventPolicyPooledIdentity(subjects ...string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.MarkPooledIdentity(subjects)
+	}
+}
+
+func WithEventPolicyStatusFromSub(subs []string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.Status.From = append(ep.Status.From, subs...)
+	}
+}
+
 func WithEventPolicyToRef(gvk metav1.GroupVersionKind, name string) EventPolicyOption {
 	return func(ep *v1alpha1.EventPolicy) {
 		ep.Spec.To = append(ep.Spec.To, v1alpha1.EventPolicySpecTo{
@@ -97,6 +177,18 @@ func WithEventPolicyLabels(labels map[string]string) EventPolicyOption {
 	}
 }
 
+func WithEventPolicyAnnotations(annotations map[string]string) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.ObjectMeta.Annotations = annotations
+	}
+}
+
+func WithEventPolicyCreationTimestamp(t metav1.Time) EventPolicyOption {
+	return func(ep *v1alpha1.EventPolicy) {
+		ep.ObjectMeta.CreationTimestamp = t
+	}
+}
+
 func WithEventPolicyOwnerReferences(ownerRefs ...metav1.OwnerReference) EventPolicyOption {
 	return func(ep *v1alpha1.EventPolicy) {
 		ep.ObjectMeta.OwnerReferences = append(ep.ObjectMeta.OwnerReferences, ownerRefs...)
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package testing
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// KReferenceMappingOption enables further configuration of a KReferenceMapping.
+type KReferenceMappingOption func(*v1alpha1.KReferenceMapping)
+
+// NewKReferenceMapping creates a KReferenceMapping with KReferenceMappingOptions.
+func NewKReferenceMapping(name string, o ...KReferenceMappingOption) *v1alpha1.KReferenceMapping {
+	m := &v1alpha1.KReferenceMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	for _, opt := range o {
+		opt(m)
+	}
+	m.SetDefaults(context.Background())
+
+	return m
+}
+
+func WithInitKReferenceMappingConditions(m *v1alpha1.KReferenceMapping) {
+	m.Status.InitializeConditions()
+}
+
+func WithKReferenceMappingAccepted(m *v1alpha1.KReferenceMapping) {
+	m.Status.MarkAccepted()
+}
+
+func WithKReferenceMappingNotAccepted(reason, message string) KReferenceMappingOption {
+	return func(m *v1alpha1.KReferenceMapping) {
+		m.Status.MarkNotAccepted(reason, "%s", message)
+	}
+}
+
+func WithKReferenceMappingFrom(group, version, kind string) KReferenceMappingOption {
+	return func(m *v1alpha1.KReferenceMapping) {
+		m.Spec.From = v1alpha1.KReferenceMappingFrom{
+			Group:   group,
+			Version: version,
+			Kind:    kind,
+		}
+	}
+}
+
+func WithKReferenceMappingURI(uri string) KReferenceMappingOption {
+	return func(m *v1alpha1.KReferenceMapping) {
+		m.Spec.URI = uri
+	}
+}
+
+func WithKReferenceMappingRef(apiVersion, kind, name string) KReferenceMappingOption {
+	return func(m *v1alpha1.KReferenceMapping) {
+		m.Spec.Ref = &v1alpha1.KReferenceMappingRef{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       name,
+		}
+	}
+}
+
+func WithKReferenceMappingCreationTimestamp(t time.Time) KReferenceMappingOption {
+	return func(m *v1alpha1.KReferenceMapping) {
+		m.CreationTimestamp = metav1.NewTime(t)
+	}
+}
@@ -18,6 +18,7 @@ package testing
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
@@ -118,6 +120,22 @@ func (l *Listers) GetEventPolicyLister() eventingv1alpha1listers.EventPolicyList
 	return eventingv1alpha1listers.NewEventPolicyLister(l.indexerFor(&eventingv1alpha1.EventPolicy{}))
 }
 
+func (l *Listers) GetEventEmissionLister() eventingv1alpha1listers.EventEmissionLister {
+	return eventingv1alpha1listers.NewEventEmissionLister(l.indexerFor(&eventingv1alpha1.EventEmission{}))
+}
+
+func (l *Listers) GetRedactionPolicyLister() eventingv1alpha1listers.RedactionPolicyLister {
+	return eventingv1alpha1listers.NewRedactionPolicyLister(l.indexerFor(&eventingv1alpha1.RedactionPolicy{}))
+}
+
+func (l *Listers) GetKReferenceMappingLister() eventingv1alpha1listers.KReferenceMappingLister {
+	return eventingv1alpha1listers.NewKReferenceMappingLister(l.indexerFor(&eventingv1alpha1.KReferenceMapping{}))
+}
+
+func (l *Listers) GetBrokerDefaultsPolicyLister() eventingv1alpha1listers.BrokerDefaultsPolicyLister {
+	return eventingv1alpha1listers.NewBrokerDefaultsPolicyLister(l.indexerFor(&eventingv1alpha1.BrokerDefaultsPolicy{}))
+}
+
 func (l *Listers) GetPingSourceLister() sourcelisters.PingSourceLister {
 	return sourcelisters.NewPingSourceLister(l.indexerFor(&sourcesv1.PingSource{}))
 }
@@ -166,6 +184,14 @@ func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.indexerFor(&appsv1.Deployment{}))
 }
 
+func (l *Listers) GetJobLister() batchv1listers.JobLister {
+	return batchv1listers.NewJobLister(l.indexerFor(&batchv1.Job{}))
+}
+
+func (l *Listers) GetCronJobLister() batchv1listers.CronJobLister {
+	return batchv1listers.NewCronJobLister(l.indexerFor(&batchv1.CronJob{}))
+}
+
 func (l *Listers) GetK8sServiceLister() corev1listers.ServiceLister {
 	return corev1listers.NewServiceLister(l.indexerFor(&corev1.Service{}))
 }
@@ -210,6 +236,10 @@ func (l *Listers) GetNodeLister() corev1listers.NodeLister {
 	return corev1listers.NewNodeLister(l.indexerFor(&corev1.Node{}))
 }
 
+func (l *Listers) GetEventLister() corev1listers.EventLister {
+	return corev1listers.NewEventLister(l.indexerFor(&corev1.Event{}))
+}
+
 func (l *Listers) GetPodLister() corev1listers.PodLister {
 	return corev1listers.NewPodLister(l.indexerFor(&corev1.Pod{}))
 }
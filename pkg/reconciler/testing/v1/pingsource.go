@@ -117,6 +117,12 @@ func WithPingSourceOIDCIdentityCreatedSucceeded() PingSourceOption {
 	}
 }
 
+func WithPingSourceSinkAudienceMismatch(audience string) PingSourceOption {
+	return func(c *v1.PingSource) {
+		c.Status.MarkSinkAudienceMismatch(audience)
+	}
+}
+
 func WithPingSourceOIDCIdentityCreatedSucceededBecauseOIDCFeatureDisabled() PingSourceOption {
 	return func(c *v1.PingSource) {
 		c.Status.MarkOIDCIdentityCreatedSucceededWithReason(fmt.Sprintf("%s feature disabled", feature.OIDCAuthentication), "")
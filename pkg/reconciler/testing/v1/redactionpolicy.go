@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+)
+
+// RedactionPolicyOption enables further configuration of a RedactionPolicy.
+type RedactionPolicyOption func(*v1alpha1.RedactionPolicy)
+
+// NewRedactionPolicy creates a RedactionPolicy with RedactionPolicyOptions.
+func NewRedactionPolicy(name, namespace string, o ...RedactionPolicyOption) *v1alpha1.RedactionPolicy {
+	rp := &v1alpha1.RedactionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+	for _, opt := range o {
+		opt(rp)
+	}
+	rp.SetDefaults(context.Background())
+
+	return rp
+}
+
+func WithInitRedactionPolicyConditions(rp *v1alpha1.RedactionPolicy) {
+	rp.Status.InitializeConditions()
+}
+
+func WithRedactionPolicyTargetsResolved(rp *v1alpha1.RedactionPolicy) {
+	rp.Status.MarkTargetsResolved()
+}
+
+func WithRedactionPolicyTargetsNotResolved(reason, message string) RedactionPolicyOption {
+	return func(rp *v1alpha1.RedactionPolicy) {
+		rp.Status.MarkTargetsNotResolved(reason, "%s", message)
+	}
+}
+
+func WithRedactionPolicyTarget(kind, name string) RedactionPolicyOption {
+	return func(rp *v1alpha1.RedactionPolicy) {
+		rp.Spec.To = append(rp.Spec.To, v1alpha1.RedactionPolicyTargetReference{
+			Kind: kind,
+			Name: name,
+		})
+	}
+}
+
+func WithRedactionPolicyRule(path string, action v1alpha1.RedactionAction) RedactionPolicyOption {
+	return func(rp *v1alpha1.RedactionPolicy) {
+		rp.Spec.Rules = append(rp.Spec.Rules, v1alpha1.RedactionRule{
+			Path:   path,
+			Action: action,
+		})
+	}
+}
@@ -259,6 +259,17 @@ func WithSubscriptionPhysicalSubscriptionReply(reply *duckv1.Addressable) Subscr
 	}
 }
 
+func WithSubscriptionPhysicalSubscriptionTerminalReply(terminalReply *duckv1.Addressable) SubscriptionOption {
+	return func(s *v1.Subscription) {
+		if terminalReply == nil {
+			panic(errors.New("nil terminal reply"))
+		}
+		s.Status.PhysicalSubscription.TerminalReplyURI = terminalReply.URL
+		s.Status.PhysicalSubscription.TerminalReplyCACerts = terminalReply.CACerts
+		s.Status.PhysicalSubscription.TerminalReplyAudience = terminalReply.Audience
+	}
+}
+
 func WithSubscriptionDeadLetterSink(dls *duckv1.Addressable) SubscriptionOption {
 	return func(s *v1.Subscription) {
 		if dls == nil {
@@ -319,6 +330,19 @@ func WithSubscriptionReply(gvk metav1.GroupVersionKind, name, namespace string)
 	}
 }
 
+func WithSubscriptionTerminalReply(gvk metav1.GroupVersionKind, name, namespace string) SubscriptionOption {
+	return func(s *v1.Subscription) {
+		s.Spec.TerminalReply = &duckv1.Destination{
+			Ref: &duckv1.KReference{
+				APIVersion: apiVersion(gvk),
+				Kind:       gvk.Kind,
+				Name:       name,
+				Namespace:  namespace,
+			},
+		}
+	}
+}
+
 func WithSubscriptionOIDCIdentityCreatedSucceeded() SubscriptionOption {
 	return func(s *v1.Subscription) {
 		s.Status.MarkOIDCIdentityCreatedSucceeded()
@@ -77,6 +77,19 @@ func WithTriggerSubscriberURI(rawurl string) TriggerOption {
 	}
 }
 
+func WithTriggerSubscribers(subs ...v1.TriggerSubscriber) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Spec.Subscriber = duckv1.Destination{}
+		t.Spec.Subscribers = subs
+	}
+}
+
+func WithTriggerMirror(mirror duckv1.Destination) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Spec.Mirror = &mirror
+	}
+}
+
 func WithTriggerSubscriber(sub duckv1.Destination) TriggerOption {
 	if err := sub.Validate(context.Background()).Filter(apis.ErrorLevel); err != nil {
 		panic(err)
@@ -221,6 +234,19 @@ func WithTriggerStatusSubscriberURI(uri string) TriggerOption {
 	}
 }
 
+func WithTriggerStatusMirrorURI(uri string) TriggerOption {
+	return func(t *v1.Trigger) {
+		u, _ := apis.ParseURL(uri)
+		t.Status.MirrorURI = u
+	}
+}
+
+func WithTriggerStatusSubscribers(subs ...v1.TriggerSubscriberStatus) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Status.Subscribers = subs
+	}
+}
+
 func WithTriggerStatusSubscriberCACerts(caCerts string) TriggerOption {
 	return func(t *v1.Trigger) {
 		t.Status.SubscriberCACerts = &caCerts
@@ -278,6 +304,30 @@ func WithTriggerDependencyUnknown(reason, message string) TriggerOption {
 	}
 }
 
+func WithTriggerDependencies(deps ...duckv1.KReference) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Spec.Dependencies = deps
+	}
+}
+
+func WithTriggerDependenciesReady() TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Status.MarkDependenciesSucceeded()
+	}
+}
+
+func WithTriggerDependenciesFailed(reason, message string) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Status.MarkDependenciesFailed(reason, message)
+	}
+}
+
+func WithTriggerDependenciesUnknown(reason, message string) TriggerOption {
+	return func(t *v1.Trigger) {
+		t.Status.MarkDependenciesUnknown(reason, message)
+	}
+}
+
 func WithTriggerSubscriberResolvedSucceeded() TriggerOption {
 	return func(t *v1.Trigger) {
 		t.Status.MarkSubscriberResolvedSucceeded()
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redaction
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a Path. Exactly one of key, index or wildcard
+// is set.
+type segment struct {
+	key      string
+	index    int
+	wildcard bool
+}
+
+func (s segment) isIndex() bool {
+	return s.key == "" && !s.wildcard
+}
+
+// Path is a parsed JSONPath expression selecting fields in the event data.
+//
+// The supported subset is a leading "$" followed by any number of
+// ".field", "[n]" and "[*]" steps, for example "$.user.email" or
+// "$.items[*].card.number".
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// String returns the expression the Path was parsed from.
+func (p Path) String() string {
+	return p.raw
+}
+
+// ParsePath parses a JSONPath expression.
+func ParsePath(expr string) (Path, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return Path{}, fmt.Errorf("path %q must start with $", expr)
+	}
+
+	p := Path{raw: expr}
+	rest := expr[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			key := rest[:end]
+			if key == "" {
+				return Path{}, fmt.Errorf("path %q contains an empty field name", expr)
+			}
+			if key == "*" {
+				p.segments = append(p.segments, segment{wildcard: true})
+			} else {
+				p.segments = append(p.segments, segment{key: key})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return Path{}, fmt.Errorf("path %q contains an unterminated [", expr)
+			}
+			sel := rest[1:end]
+			if sel == "*" {
+				p.segments = append(p.segments, segment{wildcard: true})
+			} else {
+				i, err := strconv.Atoi(sel)
+				if err != nil || i < 0 {
+					return Path{}, fmt.Errorf("path %q contains an invalid index %q", expr, sel)
+				}
+				p.segments = append(p.segments, segment{index: i})
+			}
+			rest = rest[end+1:]
+		default:
+			return Path{}, fmt.Errorf("path %q contains an unexpected character %q", expr, rest[0])
+		}
+	}
+
+	if len(p.segments) == 0 {
+		return Path{}, fmt.Errorf("path %q must select a field", expr)
+	}
+	return p, nil
+}
+
+// Select returns the values selected by the path below node, which is
+// left untouched.
+func (p Path) Select(node interface{}) []interface{} {
+	var values []interface{}
+	p.apply(node, func(v interface{}) (interface{}, bool) {
+		values = append(values, v)
+		return v, false
+	})
+	return values
+}
+
+// apply calls fn for every value selected by the path below node. fn
+// returns the replacement for the value and whether the value should be
+// removed instead. apply returns the updated node and the number of
+// selected values.
+func (p Path) apply(node interface{}, fn func(interface{}) (interface{}, bool)) (interface{}, int) {
+	return applySegments(node, p.segments, fn)
+}
+
+func applySegments(node interface{}, segments []segment, fn func(interface{}) (interface{}, bool)) (interface{}, int) {
+	seg, last := segments[0], len(segments) == 1
+	count := 0
+
+	visit := func(v interface{}) (interface{}, bool) {
+		if last {
+			count++
+			return fn(v)
+		}
+		updated, n := applySegments(v, segments[1:], fn)
+		count += n
+		return updated, false
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if seg.isIndex() {
+			return node, 0
+		}
+		for k, v := range n {
+			if !seg.wildcard && k != seg.key {
+				continue
+			}
+			updated, remove := visit(v)
+			if remove {
+				delete(n, k)
+			} else {
+				n[k] = updated
+			}
+		}
+		return n, count
+	case []interface{}:
+		if seg.key != "" {
+			return node, 0
+		}
+		out := n[:0]
+		for i, v := range n {
+			if seg.wildcard || i == seg.index {
+				updated, remove := visit(v)
+				if remove {
+					continue
+				}
+				v = updated
+			}
+			out = append(out, v)
+		}
+		return out, count
+	default:
+		return node, 0
+	}
+}
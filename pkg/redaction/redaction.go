@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redaction removes or obfuscates fields of CloudEvent data before
+// the event is delivered to a subscriber.
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+)
+
+// Action is what happens to a field selected by a Rule.
+type Action string
+
+const (
+	// ActionDrop removes the field.
+	ActionDrop Action = "drop"
+	// ActionHash replaces the field with the hex encoded SHA-256 of its
+	// value, so that equal values can still be correlated.
+	ActionHash Action = "hash"
+	// ActionMask replaces the field with MaskValue.
+	ActionMask Action = "mask"
+
+	// MaskValue is the value masked fields are replaced with.
+	MaskValue = "****"
+)
+
+// IsValid returns true when a is a known Action.
+func (a Action) IsValid() bool {
+	switch a {
+	case ActionDrop, ActionHash, ActionMask:
+		return true
+	}
+	return false
+}
+
+// Rule redacts the fields selected by Path using Action.
+type Rule struct {
+	Path   Path
+	Action Action
+}
+
+// Result describes what Redact changed.
+type Result struct {
+	// Fields counts the redacted fields by action.
+	Fields map[Action]int
+}
+
+// Total returns the number of redacted fields.
+func (r Result) Total() int {
+	total := 0
+	for _, n := range r.Fields {
+		total += n
+	}
+	return total
+}
+
+// Redact applies the rules to the data of the event. Only events with JSON
+// data are supported, other events are left untouched.
+func Redact(e *event.Event, rules []Rule) (Result, error) {
+	res := Result{Fields: map[Action]int{}}
+	if len(rules) == 0 || len(e.Data()) == 0 || !isJSON(e.DataContentType()) {
+		return res, nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(e.Data(), &data); err != nil {
+		return res, fmt.Errorf("failed to decode event data: %w", err)
+	}
+
+	for _, r := range rules {
+		var n int
+		data, n = r.Path.apply(data, actionFunc(r.Action))
+		if n > 0 {
+			res.Fields[r.Action] += n
+		}
+	}
+
+	if res.Total() == 0 {
+		return res, nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return res, fmt.Errorf("failed to encode event data: %w", err)
+	}
+	if err := e.SetData(e.DataContentType(), b); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+func actionFunc(a Action) func(interface{}) (interface{}, bool) {
+	switch a {
+	case ActionDrop:
+		return func(interface{}) (interface{}, bool) { return nil, true }
+	case ActionHash:
+		return func(v interface{}) (interface{}, bool) { return hash(v), false }
+	default:
+		return func(interface{}) (interface{}, bool) { return MaskValue, false }
+	}
+}
+
+func hash(v interface{}) string {
+	var b []byte
+	if s, ok := v.(string); ok {
+		b = []byte(s)
+	} else {
+		b, _ = json.Marshal(v)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		// CloudEvents defaults to application/json.
+		return true
+	}
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == event.ApplicationJSON || mediaType == event.TextJSON || strings.HasSuffix(mediaType, "+json")
+}
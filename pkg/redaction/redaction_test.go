@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := map[string]struct {
+		path    string
+		want    []segment
+		wantErr bool
+	}{
+		"field": {
+			path: "$.user",
+			want: []segment{{key: "user"}},
+		},
+		"nested field": {
+			path: "$.user.email",
+			want: []segment{{key: "user"}, {key: "email"}},
+		},
+		"index and wildcard": {
+			path: "$.items[1].cards[*].number",
+			want: []segment{{key: "items"}, {index: 1}, {key: "cards"}, {wildcard: true}, {key: "number"}},
+		},
+		"field wildcard": {
+			path: "$.user.*",
+			want: []segment{{key: "user"}, {wildcard: true}},
+		},
+		"root only":            {path: "$", wantErr: true},
+		"missing root":         {path: "user.email", wantErr: true},
+		"empty field":          {path: "$..email", wantErr: true},
+		"unterminated bracket": {path: "$.items[1", wantErr: true},
+		"negative index":       {path: "$.items[-1]", wantErr: true},
+		"quoted key":           {path: "$['user']", wantErr: true},
+		"unexpected character": {path: "$user", wantErr: true},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := ParsePath(tc.path)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParsePath() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.segments, cmp.AllowUnexported(segment{})); diff != "" {
+				t.Error("Unexpected segments (-want, +got):", diff)
+			}
+			if got.String() != tc.path {
+				t.Errorf("String() = %q, want %q", got.String(), tc.path)
+			}
+		})
+	}
+}
+
+func TestPathSelect(t *testing.T) {
+	const data = `{"user": {"name": "jane", "age": 42}, "items": [{"id": "a"}, {"id": "b"}]}`
+
+	tests := map[string]struct {
+		path string
+		want []interface{}
+	}{
+		"string":   {path: "$.user.name", want: []interface{}{"jane"}},
+		"number":   {path: "$.user.age", want: []interface{}{float64(42)}},
+		"index":    {path: "$.items[1].id", want: []interface{}{"b"}},
+		"wildcard": {path: "$.items[*].id", want: []interface{}{"a", "b"}},
+		"missing":  {path: "$.user.email"},
+		"mismatch": {path: "$.user[0]"},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			var node interface{}
+			if err := json.Unmarshal([]byte(data), &node); err != nil {
+				t.Fatal(err)
+			}
+			p, err := ParsePath(tc.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, p.Select(node)); diff != "" {
+				t.Error("Unexpected values (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	const data = `{
+		"user": {"name": "jane", "email": "jane@example.com", "age": 42},
+		"items": [
+			{"card": "4111111111111111", "amount": 1},
+			{"card": "5500000000000004", "amount": 2}
+		]
+	}`
+
+	tests := map[string]struct {
+		contentType string
+		data        string
+		rules       []Rule
+		want        string
+		wantFields  map[Action]int
+		wantErr     bool
+	}{
+		"drop": {
+			rules:      []Rule{rule(t, "$.user.email", ActionDrop)},
+			want:       `{"user":{"name":"jane","age":42},"items":[{"card":"4111111111111111","amount":1},{"card":"5500000000000004","amount":2}]}`,
+			wantFields: map[Action]int{ActionDrop: 1},
+		},
+		"mask wildcard": {
+			rules:      []Rule{rule(t, "$.items[*].card", ActionMask)},
+			want:       `{"user":{"name":"jane","email":"jane@example.com","age":42},"items":[{"card":"****","amount":1},{"card":"****","amount":2}]}`,
+			wantFields: map[Action]int{ActionMask: 2},
+		},
+		"hash": {
+			rules:      []Rule{rule(t, "$.user.name", ActionHash), rule(t, "$.user.age", ActionHash)},
+			want:       `{"user":{"name":"81f8f6dde88365f3928796ec7aa53f72820b06db8664f5fe76a7eb13e24546a2","email":"jane@example.com","age":"73475cb40a568e8da8a045ced110137e159f890ac4da883b6b17dc651b3a8049"},"items":[{"card":"4111111111111111","amount":1},{"card":"5500000000000004","amount":2}]}`,
+			wantFields: map[Action]int{ActionHash: 2},
+		},
+		"drop array element": {
+			rules:      []Rule{rule(t, "$.items[0]", ActionDrop)},
+			want:       `{"user":{"name":"jane","email":"jane@example.com","age":42},"items":[{"card":"5500000000000004","amount":2}]}`,
+			wantFields: map[Action]int{ActionDrop: 1},
+		},
+		"no match": {
+			rules:      []Rule{rule(t, "$.user.phone", ActionDrop)},
+			wantFields: map[Action]int{},
+		},
+		"type mismatch": {
+			rules:      []Rule{rule(t, "$.user[0]", ActionDrop), rule(t, "$.items.card", ActionDrop)},
+			wantFields: map[Action]int{},
+		},
+		"not json": {
+			contentType: "text/plain",
+			data:        "jane@example.com",
+			rules:       []Rule{rule(t, "$.user", ActionDrop)},
+			wantFields:  map[Action]int{},
+		},
+		"invalid json": {
+			data:    "{",
+			rules:   []Rule{rule(t, "$.user", ActionDrop)},
+			wantErr: true,
+		},
+		"cloudevents json suffix": {
+			contentType: "application/vnd.example+json; charset=utf-8",
+			rules:       []Rule{rule(t, "$.items", ActionDrop)},
+			want:        `{"user":{"name":"jane","email":"jane@example.com","age":42}}`,
+			wantFields:  map[Action]int{ActionDrop: 1},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			contentType := tc.contentType
+			if contentType == "" {
+				contentType = event.ApplicationJSON
+			}
+			in := tc.data
+			if in == "" {
+				in = data
+			}
+			e := event.New()
+			if err := e.SetData(contentType, []byte(in)); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := Redact(&e, tc.rules)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Redact() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantFields, res.Fields); diff != "" {
+				t.Error("Unexpected redacted fields (-want, +got):", diff)
+			}
+
+			if tc.want == "" {
+				if string(e.Data()) != in {
+					t.Errorf("Expected data to be unchanged, got %s", e.Data())
+				}
+				return
+			}
+			var want, got interface{}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(e.Data(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Error("Unexpected data (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func rule(t *testing.T, path string, action Action) Rule {
+	p, err := ParsePath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Rule{Path: p, Action: action}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redaction
+
+import (
+	"context"
+	"log"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	eventingmetrics "knative.dev/eventing/pkg/metrics"
+	"knative.dev/pkg/metrics"
+)
+
+var (
+	// redactedFieldCountM is a counter which records the number of event
+	// data fields redacted before delivery.
+	redactedFieldCountM = stats.Int64(
+		"redacted_field_count",
+		"Number of event data fields redacted before delivery",
+		stats.UnitDimensionless,
+	)
+
+	namespaceKey = tag.MustNewKey(eventingmetrics.LabelNamespaceName)
+	kindKey      = tag.MustNewKey("subscription_kind")
+	actionKey    = tag.MustNewKey("redaction_action")
+)
+
+func init() {
+	register()
+}
+
+func register() {
+	err := metrics.RegisterResourceView(
+		&view.View{
+			Description: redactedFieldCountM.Description(),
+			Measure:     redactedFieldCountM,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{namespaceKey, kindKey, actionKey},
+		},
+	)
+	if err != nil {
+		log.Print("failed to register opencensus views, " + err.Error())
+	}
+}
+
+// ReportRedactedFields records the fields redacted for a Trigger or
+// Subscription, identified by kind, in the given namespace.
+func ReportRedactedFields(namespace, kind string, res Result) error {
+	for action, n := range res.Fields {
+		ctx, err := tag.New(
+			context.Background(),
+			tag.Insert(namespaceKey, namespace),
+			tag.Insert(kindKey, kind),
+			tag.Insert(actionKey, string(action)))
+		if err != nil {
+			return err
+		}
+		metrics.Record(ctx, redactedFieldCountM.M(int64(n)))
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package resolver
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/tracker"
@@ -28,6 +29,21 @@ import (
 // for a given listableType (Listable) passed to the URIResolver's tracker.
 func NewURIResolver(ctx context.Context, cmw configmap.Watcher, t tracker.Interface) *resolver.URIResolver {
 	mr := NewMappingResolver(ctx, cmw, t)
+	mr.refResolver = resolver.NewURIResolverFromTracker(ctx, untracked{t})
 
 	return resolver.NewURIResolverFromTracker(ctx, t, mr.MappingURIFromObjectReference)
 }
+
+// untracked doesn't track the references, as the parent of the references
+// rewritten by the mappings isn't known.
+type untracked struct {
+	tracker.Interface
+}
+
+func (untracked) Track(corev1.ObjectReference, interface{}) error {
+	return nil
+}
+
+func (untracked) TrackReference(tracker.Reference, interface{}) error {
+	return nil
+}
@@ -26,6 +26,8 @@ import (
 	_ "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	. "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/tracker"
+
+	_ "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping/fake"
 )
 
 func TestNewURIResolver(t *testing.T) {
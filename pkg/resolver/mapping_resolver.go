@@ -17,43 +17,53 @@ limitations under the License.
 package resolver
 
 import (
-	"bytes"
 	"context"
-	"text/template"
+	"errors"
+	"sync"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
-	"knative.dev/eventing/pkg/apis/feature"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/system"
 	"knative.dev/pkg/tracker"
+
+	"knative.dev/eventing/pkg/apis/feature"
+	kreferencemappinginformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping"
+	eventinglisters "knative.dev/eventing/pkg/client/listers/eventing/v1alpha1"
+	"knative.dev/eventing/pkg/kreferencemapping"
 )
 
 const (
 	ConfigMapName = "config-kreference-mapping"
 )
 
+// MappingResolver resolves the references to the kinds mapped by the
+// config-kreference-mapping ConfigMap and by the KReferenceMappings.
 type MappingResolver struct {
-	logger    *zap.SugaredLogger
-	templates map[schema.GroupVersionKind]*template.Template
-	tracker   tracker.Interface
-}
+	logger  *zap.SugaredLogger
+	tracker tracker.Interface
+	lister  eventinglisters.KReferenceMappingLister
 
-type MappingResolverTemplateValues struct {
-	Name            string
-	Namespace       string
-	SystemNamespace string
-	UID             types.UID
+	mu       sync.RWMutex
+	mappings kreferencemapping.Mappings
+
+	// refResolver resolves the references rewritten by a mapping. It
+	// doesn't use the mappings, so that references are rewritten at most
+	// once.
+	refResolver *resolver.URIResolver
 }
 
+type MappingResolverTemplateValues = kreferencemapping.TemplateValues
+
 func NewMappingResolver(ctx context.Context, cmw configmap.Watcher, t tracker.Interface) *MappingResolver {
 	resolver := MappingResolver{
 		logger:  logging.FromContext(ctx),
 		tracker: t,
+		lister:  kreferencemappinginformer.Get(ctx).Lister(),
 	}
 	cmw.Watch(ConfigMapName, resolver.updateFromConfigMap)
 
@@ -78,35 +88,35 @@ func (mr *MappingResolver) MappingURIFromObjectReference(ctx context.Context, re
 	//	return nil, apierrs.NewNotFound(gvr.GroupResource(), ref.Name)
 	//}
 
-	if mr.templates == nil {
-		mr.logger.Infow("reference not handled", zap.Any("gvk", ref.GroupVersionKind()))
-		return false, nil, nil
+	mr.mu.RLock()
+	configMappings := mr.mappings
+	mr.mu.RUnlock()
+
+	mappings, err := ListMappings(configMappings, mr.lister)
+	if err != nil {
+		return false, nil, err
 	}
 
-	tmpl, ok := mr.templates[ref.GroupVersionKind()]
+	mapping, ok := mappings.Lookup(ref.GroupVersionKind())
 	if !ok {
 		mr.logger.Infow("reference not handled", zap.Any("gvk", ref.GroupVersionKind()))
 		return false, nil, nil
 	}
 
-	data := MappingResolverTemplateValues{
-		Name:            ref.Name,
-		Namespace:       ref.Namespace,
-		UID:             ref.UID,
-		SystemNamespace: system.Namespace(),
-	}
-
-	buf := bytes.Buffer{}
-	if err := tmpl.Execute(&buf, data); err != nil {
-		// Configuration error
-		return true, nil, err
-	}
-
-	url, err := apis.ParseURL(buf.String())
+	url, rewritten, err := mapping.Map(ref, system.Namespace())
 	if err != nil {
 		// Configuration error
 		return true, nil, err
 	}
+	if rewritten != nil {
+		if mr.refResolver == nil {
+			return true, nil, errors.New("kreference mapping rewrites are not supported by this resolver")
+		}
+		url, err = mr.refResolver.URIFromObjectReference(ctx, rewritten, nil)
+		if err != nil {
+			return true, nil, err
+		}
+	}
 
 	return true, url, nil
 }
@@ -117,37 +127,36 @@ func (mr *MappingResolver) updateFromConfigMap(cfg *corev1.ConfigMap) {
 	}
 	mr.logger.Infow("loading kreference mapping configmap")
 
-	mr.templates = make(map[schema.GroupVersionKind]*template.Template)
+	mappings, err := kreferencemapping.FromConfigMap(cfg.Data)
+	if err != nil {
+		mr.logger.Warnw("failed to parse kreference mappings", zap.Error(err))
+	}
+
+	mr.mu.Lock()
+	mr.mappings = mappings
+	mr.mu.Unlock()
+	mr.logger.Infow("mappings loaded", zap.Int("count", len(mappings)))
+}
 
-	for key, stmpl := range cfg.Data {
-		if key == "_example" {
+// ListMappings returns the mappings of the ConfigMap and of the
+// KReferenceMappings. The KReferenceMappings which don't parse are skipped,
+// as they are rejected by the webhook.
+func ListMappings(configMap kreferencemapping.Mappings, lister eventinglisters.KReferenceMappingLister) (kreferencemapping.Mappings, error) {
+	crs, err := lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	mappings := make(kreferencemapping.Mappings, 0, len(configMap)+len(crs))
+	mappings = append(mappings, configMap...)
+	for _, cr := range crs {
+		if !cr.DeletionTimestamp.IsZero() {
 			continue
 		}
-		mr.logger.Infow("processing mapping", zap.String("key", key), zap.String("template", stmpl))
-
-		gvk, gk := schema.ParseKindArg(key)
-
-		if gvk == nil {
-			// Try <kind>.<version> (core k8s API)
-			if gk.Group == "" {
-				// Wrong key format
-				mr.logger.Warnw("failed to parse kreference mapping key. Must be of the form <kind>.<version>(.<group>)?", zap.String("key", key))
-				continue
-			}
-
-			gvk = &schema.GroupVersionKind{
-				Kind:    gk.Kind,
-				Version: gk.Group,
-			}
-		}
-
-		tmpl, err := template.New(key).Parse(stmpl)
+		m, err := cr.Mapping()
 		if err != nil {
-			mr.logger.Warnw("failed to parse kreference mapping template", zap.String("template", stmpl), zap.Error(err))
 			continue
 		}
-
-		mr.templates[*gvk] = tmpl
-		mr.logger.Infow("mapping template loaded", zap.String("template", stmpl), zap.Error(err))
+		mappings = append(mappings, *m)
 	}
+	return mappings, nil
 }
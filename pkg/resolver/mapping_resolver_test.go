@@ -18,16 +18,23 @@ package resolver
 
 import (
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 
+	_ "knative.dev/pkg/client/injection/ducks/duck/v1/addressable/fake"
 	"knative.dev/pkg/configmap"
+	fakedynamicclient "knative.dev/pkg/injection/clients/dynamicclient/fake"
 	. "knative.dev/pkg/reconciler/testing"
+	"knative.dev/pkg/resolver"
 	"knative.dev/pkg/tracker"
 
+	"knative.dev/eventing/pkg/apis/eventing/v1alpha1"
 	"knative.dev/eventing/pkg/apis/feature"
+	kreferencemappinginformer "knative.dev/eventing/pkg/client/injection/informers/eventing/v1alpha1/kreferencemapping/fake"
 )
 
 func TestMappingResolver(t *testing.T) {
@@ -153,6 +160,144 @@ func TestMappingResolver(t *testing.T) {
 	}
 }
 
+func TestMappingResolverKReferenceMappings(t *testing.T) {
+	testingNamespace := "testns"
+	ref := &corev1.ObjectReference{
+		APIVersion: "example.com/v1",
+		Kind:       "Sink",
+		Namespace:  testingNamespace,
+		Name:       "asink",
+	}
+	now := time.Now()
+
+	tests := map[string]struct {
+		mappings    []*v1alpha1.KReferenceMapping
+		cm          map[string]string
+		wantHandled bool
+		wantURI     string
+		wantErr     bool
+	}{
+		"not handled": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMapping("pods", now, "", "v1", "Pod", "http://{{ .Name }}"),
+			},
+		},
+		"uri": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMapping("sinks", now, "example.com", "", "Sink", "http://{{ .Name }}.{{ .Namespace }}.svc.cluster.local"),
+			},
+			wantHandled: true,
+			wantURI:     "http://asink.testns.svc.cluster.local",
+		},
+		"oldest mapping wins": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMapping("newer", now, "example.com", "v1", "Sink", "http://newer"),
+				kreferenceMapping("older", now.Add(-time.Minute), "example.com", "v1", "Sink", "http://older"),
+			},
+			wantHandled: true,
+			wantURI:     "http://older",
+		},
+		"configmap wins": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMapping("sinks", now, "example.com", "v1", "Sink", "http://kreferencemapping"),
+			},
+			cm: map[string]string{
+				"Sink.v1.example.com": "http://configmap",
+			},
+			wantHandled: true,
+			wantURI:     "http://configmap",
+		},
+		"rewrite to a service": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMappingRef("sinks", now, "example.com", "Sink", "{{ .Name }}-ingress"),
+			},
+			wantHandled: true,
+			wantURI:     "http://asink-ingress.testns.svc.cluster.local",
+		},
+		"rewrite to a missing service": {
+			mappings: []*v1alpha1.KReferenceMapping{
+				kreferenceMappingRef("sinks", now, "example.com", "Sink", "{{ .Name }}-missing"),
+			},
+			wantHandled: true,
+			wantErr:     true,
+		},
+		"rewrite in the configmap": {
+			cm: map[string]string{
+				"Sink.*.example.com": "ref:Service.v1",
+			},
+			wantHandled: true,
+			wantURI:     "http://asink.testns.svc.cluster.local",
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			ctx, _ := SetupFakeContext(t)
+			for _, name := range []string{"asink", "asink-ingress"} {
+				err := fakedynamicclient.Get(ctx).Tracker().Add(&unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "Service",
+						"metadata": map[string]interface{}{
+							"namespace": testingNamespace,
+							"name":      name,
+						},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			ctx = feature.ToContext(ctx, feature.Flags{feature.KReferenceMapping: feature.Enabled})
+			for _, m := range tc.mappings {
+				if err := kreferencemappinginformer.Get(ctx).Informer().GetIndexer().Add(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			mw := &configmap.ManualWatcher{Namespace: testingNamespace}
+			track := tracker.New(func(types.NamespacedName) {}, 0)
+
+			r := NewMappingResolver(ctx, mw, track)
+			r.refResolver = resolver.NewURIResolverFromTracker(ctx, untracked{track})
+			mw.OnChange(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: testingNamespace},
+				Data:       tc.cm,
+			})
+
+			handled, uri, err := r.MappingURIFromObjectReference(ctx, ref)
+			if handled != tc.wantHandled {
+				t.Errorf("Unexpected handled value. Got %t, want %t", handled, tc.wantHandled)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Unexpected error %v, wantErr %t", err, tc.wantErr)
+			}
+			if tc.wantURI != "" && (uri == nil || uri.String() != tc.wantURI) {
+				t.Errorf("Unexpected URI. Got %v, want %s", uri, tc.wantURI)
+			}
+		})
+	}
+}
+
+func kreferenceMapping(name string, created time.Time, group, version, kind, uri string) *v1alpha1.KReferenceMapping {
+	return &v1alpha1.KReferenceMapping{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec: v1alpha1.KReferenceMappingSpec{
+			From: v1alpha1.KReferenceMappingFrom{Group: group, Version: version, Kind: kind},
+			URI:  uri,
+		},
+	}
+}
+
+func kreferenceMappingRef(name string, created time.Time, group, kind, refName string) *v1alpha1.KReferenceMapping {
+	return &v1alpha1.KReferenceMapping{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+		Spec: v1alpha1.KReferenceMappingSpec{
+			From: v1alpha1.KReferenceMappingFrom{Group: group, Kind: kind},
+			Ref:  &v1alpha1.KReferenceMappingRef{APIVersion: "v1", Kind: "Service", Name: refName},
+		},
+	}
+}
+
 func serviceObjRef(ns string) *corev1.ObjectReference {
 	return &corev1.ObjectReference{
 		Kind:       "Service",
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signing
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+)
+
+// VerificationHandler is an http.Handler that verifies the signature of
+// incoming CloudEvents before passing the request to the next handler.
+type VerificationHandler struct {
+	keys             Keyring
+	next             http.Handler
+	requireSignature bool
+	logger           *zap.Logger
+}
+
+// NewVerificationHandler returns a VerificationHandler. When
+// requireSignature is false, unsigned events are passed through while events
+// carrying an invalid signature are still rejected.
+func NewVerificationHandler(logger *zap.Logger, keys Keyring, requireSignature bool, next http.Handler) *VerificationHandler {
+	return &VerificationHandler{
+		keys:             keys,
+		next:             next,
+		requireSignature: requireSignature,
+		logger:           logger,
+	}
+}
+
+func (h *VerificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.logger.Warn("Failed to read request body", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_ = r.Body.Close()
+
+	parse := r.Clone(r.Context())
+	parse.Body = io.NopCloser(bytes.NewReader(body))
+	e, err := cehttp.NewEventFromHTTPRequest(parse)
+	if err != nil {
+		h.logger.Warn("Failed to parse event", zap.Error(err))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := Verify(*e, h.keys); err != nil {
+		if err != ErrMissingSignature || h.requireSignature {
+			h.logger.Info("Rejecting event",
+				zap.String("id", e.ID()),
+				zap.String("source", e.Source()),
+				zap.Error(err))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	h.next.ServeHTTP(w, r)
+}
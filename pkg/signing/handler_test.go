@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
+	"go.uber.org/zap"
+)
+
+func TestVerificationHandler(t *testing.T) {
+	tests := map[string]struct {
+		sign             bool
+		tamper           bool
+		requireSignature bool
+		wantStatus       int
+	}{
+		"signed": {
+			sign:       true,
+			wantStatus: http.StatusAccepted,
+		},
+		"tampered": {
+			sign:       true,
+			tamper:     true,
+			wantStatus: http.StatusForbidden,
+		},
+		"unsigned allowed": {
+			wantStatus: http.StatusAccepted,
+		},
+		"unsigned required": {
+			requireSignature: true,
+			wantStatus:       http.StatusForbidden,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			e := newEvent(t)
+			if tc.sign {
+				if err := Sign(&e, key1); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.tamper {
+				e.SetSubject("tampered")
+			}
+
+			var received bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, err := cehttp.NewEventFromHTTPRequest(r)
+				if err != nil {
+					t.Error("Failed to read forwarded event:", err)
+				} else if got.ID() != e.ID() {
+					t.Errorf("Forwarded event id = %q, want %q", got.ID(), e.ID())
+				}
+				received = true
+				w.WriteHeader(http.StatusAccepted)
+			})
+			h := NewVerificationHandler(zap.NewNop(), NewKeyring(key1), tc.requireSignature, next)
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if err := cehttp.WriteRequest(context.Background(), binding.ToMessage(&e), req); err != nil {
+				t.Fatal(err)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("Status = %d, want %d", rec.Code, tc.wantStatus)
+			}
+			if received != (tc.wantStatus == http.StatusAccepted) {
+				t.Errorf("Next handler called = %v", received)
+			}
+		})
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package signing implements optional content integrity for CloudEvents.
+//
+// A producer signs an event with a shared namespace key and attaches the
+// signature as an extension attribute. Every hop forwards extensions
+// unchanged, so a consumer holding the same key can detect whether the
+// event attributes or payload were modified on the way.
+package signing
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SignatureExtension is the CloudEvent extension attribute holding the
+	// base64 encoded HMAC-SHA256 signature of the event.
+	SignatureExtension = "knsignature"
+
+	// KeyIDExtension is the CloudEvent extension attribute holding the id of
+	// the key that produced SignatureExtension.
+	KeyIDExtension = "knsignaturekeyid"
+
+	// SecretKeyDataKey is the key in a Secret's data holding the signing key.
+	SecretKeyDataKey = "key"
+
+	// SecretKeyIDDataKey is the optional key in a Secret's data holding the
+	// key id. When missing, the name of the Secret is used as key id.
+	SecretKeyIDDataKey = "keyId"
+)
+
+var (
+	// ErrMissingSignature is returned when an event doesn't carry a signature.
+	ErrMissingSignature = errors.New("event is not signed")
+
+	// ErrUnknownKey is returned when an event is signed with a key that is
+	// not part of the keyring.
+	ErrUnknownKey = errors.New("event is signed with an unknown key")
+
+	// ErrInvalidSignature is returned when the signature doesn't match the
+	// event content.
+	ErrInvalidSignature = errors.New("event signature is invalid")
+)
+
+// Key is a symmetric signing key.
+type Key struct {
+	// ID identifies the key, it is sent along with the signature so that
+	// consumers can pick the right key during key rotation.
+	ID string
+	// Secret is the key material.
+	Secret []byte
+}
+
+// Keyring maps key ids to keys accepted for verification.
+type Keyring map[string]Key
+
+// NewKeyring returns a Keyring containing the given keys.
+func NewKeyring(keys ...Key) Keyring {
+	kr := make(Keyring, len(keys))
+	for _, k := range keys {
+		kr[k.ID] = k
+	}
+	return kr
+}
+
+// KeyFromSecret builds a Key from a Secret.
+func KeyFromSecret(secret *corev1.Secret) (Key, error) {
+	if secret == nil {
+		return Key{}, errors.New("secret is nil")
+	}
+	material, ok := secret.Data[SecretKeyDataKey]
+	if !ok || len(material) == 0 {
+		return Key{}, fmt.Errorf("secret %s/%s is missing the %q data key", secret.Namespace, secret.Name, SecretKeyDataKey)
+	}
+	id := secret.Name
+	if v, ok := secret.Data[SecretKeyIDDataKey]; ok && len(v) > 0 {
+		id = strings.TrimSpace(string(v))
+	}
+	return Key{ID: id, Secret: material}, nil
+}
+
+// KeyFromFile builds a Key from a file containing the key material, usually
+// mounted from a Secret. The id defaults to the base name of the file.
+func KeyFromFile(path, id string) (Key, error) {
+	material, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	material = bytes.TrimSpace(material)
+	if len(material) == 0 {
+		return Key{}, fmt.Errorf("signing key file %s is empty", path)
+	}
+	if id == "" {
+		id = filepath.Base(path)
+	}
+	return Key{ID: id, Secret: material}, nil
+}
+
+// Sign computes the signature of the event with the given key and sets the
+// SignatureExtension and KeyIDExtension attributes. An existing signature is
+// replaced.
+func Sign(e *event.Event, key Key) error {
+	if len(key.Secret) == 0 {
+		return errors.New("signing key is empty")
+	}
+	sig := digest(e, key)
+	e.SetExtension(KeyIDExtension, key.ID)
+	e.SetExtension(SignatureExtension, base64.StdEncoding.EncodeToString(sig))
+	return nil
+}
+
+// Verify checks the signature of the event against the keyring.
+func Verify(e event.Event, keys Keyring) error {
+	raw, ok := e.Extensions()[SignatureExtension]
+	if !ok {
+		return ErrMissingSignature
+	}
+	encoded, ok := raw.(string)
+	if !ok {
+		return ErrInvalidSignature
+	}
+	sig, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	keyID, _ := e.Extensions()[KeyIDExtension].(string)
+	key, ok := keys[keyID]
+	if !ok {
+		return ErrUnknownKey
+	}
+
+	if !hmac.Equal(sig, digest(&e, key)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// digest computes the HMAC of the event context attributes and data.
+// Extension attributes are excluded since intermediaries, like brokers and
+// channels, legitimately add or change them.
+func digest(e *event.Event, key Key) []byte {
+	mac := hmac.New(sha256.New, key.Secret)
+	writeField(mac, e.SpecVersion())
+	writeField(mac, e.ID())
+	writeField(mac, e.Source())
+	writeField(mac, e.Type())
+	writeField(mac, e.Subject())
+	writeField(mac, e.DataContentType())
+	writeField(mac, e.DataSchema())
+	t := ""
+	if !e.Time().IsZero() {
+		t = e.Time().UTC().Format(time.RFC3339Nano)
+	}
+	writeField(mac, t)
+	writeField(mac, string(e.Data()))
+	return mac.Sum(nil)
+}
+
+// writeField writes a length prefixed field, so that moving bytes between
+// adjacent attributes changes the digest.
+func writeField(h hash.Hash, v string) {
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(v)))
+	h.Write(l[:])
+	h.Write([]byte(v))
+}
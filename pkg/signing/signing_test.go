@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/event"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	key1 = Key{ID: "key-1", Secret: []byte("secret-1")}
+	key2 = Key{ID: "key-2", Secret: []byte("secret-2")}
+)
+
+func newEvent(t *testing.T) event.Event {
+	e := event.New()
+	e.SetID("id")
+	e.SetSource("/source")
+	e.SetType("dev.knative.test")
+	e.SetTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err := e.SetData(event.ApplicationJSON, map[string]string{"hello": "world"}); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestSignVerify(t *testing.T) {
+	tests := map[string]struct {
+		modify func(e *event.Event)
+		keys   Keyring
+		want   error
+	}{
+		"valid": {
+			keys: NewKeyring(key1),
+		},
+		"valid with rotated keyring": {
+			keys: NewKeyring(key2, key1),
+		},
+		"extensions added by a hop": {
+			modify: func(e *event.Event) {
+				e.SetExtension("knativearrivaltime", "2024-01-01T00:00:00Z")
+			},
+			keys: NewKeyring(key1),
+		},
+		"data tampered": {
+			modify: func(e *event.Event) {
+				_ = e.SetData(event.ApplicationJSON, map[string]string{"hello": "mallory"})
+			},
+			keys: NewKeyring(key1),
+			want: ErrInvalidSignature,
+		},
+		"type tampered": {
+			modify: func(e *event.Event) {
+				e.SetType("dev.knative.other")
+			},
+			keys: NewKeyring(key1),
+			want: ErrInvalidSignature,
+		},
+		"attribute boundary shifted": {
+			modify: func(e *event.Event) {
+				e.SetID("id/")
+				e.SetSource("source")
+			},
+			keys: NewKeyring(key1),
+			want: ErrInvalidSignature,
+		},
+		"unknown key": {
+			keys: NewKeyring(key2),
+			want: ErrUnknownKey,
+		},
+		"key id swapped": {
+			modify: func(e *event.Event) {
+				e.SetExtension(KeyIDExtension, key2.ID)
+			},
+			keys: NewKeyring(key1, key2),
+			want: ErrInvalidSignature,
+		},
+		"signature removed": {
+			modify: func(e *event.Event) {
+				e.SetExtension(SignatureExtension, nil)
+			},
+			keys: NewKeyring(key1),
+			want: ErrMissingSignature,
+		},
+		"signature not base64": {
+			modify: func(e *event.Event) {
+				e.SetExtension(SignatureExtension, "!!!")
+			},
+			keys: NewKeyring(key1),
+			want: ErrInvalidSignature,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			e := newEvent(t)
+			if err := Sign(&e, key1); err != nil {
+				t.Fatal(err)
+			}
+			if tc.modify != nil {
+				tc.modify(&e)
+			}
+			if err := Verify(e, tc.keys); !errors.Is(err, tc.want) {
+				t.Errorf("Verify() = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestSignEmptyKey(t *testing.T) {
+	e := newEvent(t)
+	if err := Sign(&e, Key{ID: "empty"}); err == nil {
+		t.Error("Expected an error signing with an empty key")
+	}
+}
+
+func TestKeyFromSecret(t *testing.T) {
+	tests := map[string]struct {
+		secret  *corev1.Secret
+		want    Key
+		wantErr bool
+	}{
+		"nil secret": {
+			wantErr: true,
+		},
+		"missing key": {
+			secret:  &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "signing"}},
+			wantErr: true,
+		},
+		"name as key id": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "signing"},
+				Data:       map[string][]byte{SecretKeyDataKey: []byte("s3cr3t")},
+			},
+			want: Key{ID: "signing", Secret: []byte("s3cr3t")},
+		},
+		"explicit key id": {
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "signing"},
+				Data: map[string][]byte{
+					SecretKeyDataKey:   []byte("s3cr3t"),
+					SecretKeyIDDataKey: []byte("2024-01\n"),
+				},
+			},
+			want: Key{ID: "2024-01", Secret: []byte("s3cr3t")},
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := KeyFromSecret(tc.secret)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("KeyFromSecret() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got.ID != tc.want.ID || string(got.Secret) != string(tc.want.Secret) {
+				t.Errorf("KeyFromSecret() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "2024-01")
+	if err := os.WriteFile(path, []byte("s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		path    string
+		id      string
+		want    Key
+		wantErr bool
+	}{
+		"file name as key id": {
+			path: path,
+			want: Key{ID: "2024-01", Secret: []byte("s3cr3t")},
+		},
+		"explicit key id": {
+			path: path,
+			id:   "signing",
+			want: Key{ID: "signing", Secret: []byte("s3cr3t")},
+		},
+		"empty file": {
+			path:    empty,
+			wantErr: true,
+		},
+		"missing file": {
+			path:    filepath.Join(dir, "missing"),
+			wantErr: true,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			got, err := KeyFromFile(tc.path, tc.id)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("KeyFromFile() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got.ID != tc.want.ID || string(got.Secret) != string(tc.want.Secret) {
+				t.Errorf("KeyFromFile() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ const (
 var (
 	MessagingSystemAttribute trace.Attribute = trace.StringAttribute(MessagingSystemAttributeName, "knative")
 	MessagingProtocolHTTP    trace.Attribute = MessagingProtocolAttribute("HTTP")
+	MessagingProtocolGRPC    trace.Attribute = MessagingProtocolAttribute("gRPC")
 )
 
 func MessagingProtocolAttribute(protocol string) trace.Attribute {
@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openzipkin/zipkin-go/model"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/test/logging"
+	"knative.dev/pkg/test/zipkin"
+)
+
+const (
+	// ZipkinBackend queries traces from the Zipkin instance that is port
+	// forwarded by knative.dev/pkg/test/zipkin. This is the default.
+	ZipkinBackend = "zipkin"
+	// JaegerBackend queries traces from the Jaeger query HTTP API.
+	JaegerBackend = "jaeger"
+	// TempoBackend queries traces in OTLP JSON format from the Grafana Tempo
+	// HTTP API.
+	TempoBackend = "tempo"
+	// OTLPBackend is an alias of TempoBackend for any query service that
+	// serves OTLP JSON traces at /api/traces/{traceID}.
+	OTLPBackend = "otlp"
+)
+
+// Backend fetches the spans of a trace from a tracing backend.
+type Backend interface {
+	// Setup prepares the backend to be queried, failing the test if it
+	// cannot. It may be called multiple times.
+	Setup(ctx context.Context, t testing.TB, kubeClientset kubernetes.Interface, configMapNamespace string)
+
+	// TracePred continually fetches the trace with the given traceID until
+	// its spans satisfy the predicate. If the timeout is reached then the
+	// last fetched trace is returned along with an error.
+	TracePred(traceID string, timeout time.Duration, pred func([]model.SpanModel) bool) ([]model.SpanModel, error)
+
+	// Cleanup releases whatever Setup acquired. It should be called exactly
+	// once, after all tests ran.
+	Cleanup(logf logging.FormatLogger)
+}
+
+// NewBackend returns the Backend for the given name. The endpoint is the base
+// URL of the query API and is ignored by the Zipkin backend, which port
+// forwards to the in-cluster Zipkin on its own.
+func NewBackend(name, endpoint string) (Backend, error) {
+	switch strings.ToLower(name) {
+	case "", ZipkinBackend:
+		return zipkinBackend{}, nil
+	case JaegerBackend:
+		if endpoint == "" {
+			return nil, fmt.Errorf("an endpoint is required for the %q tracing backend", name)
+		}
+		return &queryBackend{endpoint: strings.TrimSuffix(endpoint, "/"), parse: parseJaegerTrace}, nil
+	case TempoBackend, OTLPBackend:
+		if endpoint == "" {
+			return nil, fmt.Errorf("an endpoint is required for the %q tracing backend", name)
+		}
+		return &queryBackend{endpoint: strings.TrimSuffix(endpoint, "/"), parse: parseOTLPTrace}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing backend %q, expected one of %q, %q, %q or %q",
+			name, ZipkinBackend, JaegerBackend, TempoBackend, OTLPBackend)
+	}
+}
+
+var defaultBackend Backend = zipkinBackend{}
+
+// SetDefaultBackend sets the Backend returned by DefaultBackend. It is meant
+// to be called from TestMain once the flags have been parsed.
+func SetDefaultBackend(b Backend) {
+	defaultBackend = b
+}
+
+// DefaultBackend returns the Backend tracing tests should query.
+func DefaultBackend() Backend {
+	return defaultBackend
+}
+
+// zipkinBackend delegates to knative.dev/pkg/test/zipkin.
+type zipkinBackend struct{}
+
+func (zipkinBackend) Setup(ctx context.Context, t testing.TB, kubeClientset kubernetes.Interface, configMapNamespace string) {
+	zipkin.SetupZipkinTracingFromConfigTracingOrFail(ctx, t, kubeClientset, configMapNamespace)
+}
+
+func (zipkinBackend) TracePred(traceID string, timeout time.Duration, pred func([]model.SpanModel) bool) ([]model.SpanModel, error) {
+	return zipkin.JSONTracePred(traceID, timeout, pred)
+}
+
+func (zipkinBackend) Cleanup(logf logging.FormatLogger) {
+	zipkin.CleanupZipkinTracingSetup(logf)
+}
+
+// queryBackend fetches traces from an HTTP query API serving them at
+// {endpoint}/api/traces/{traceID}, which both Jaeger and Tempo do, and
+// converts them to Zipkin spans with parse.
+type queryBackend struct {
+	endpoint string
+	parse    func([]byte) ([]model.SpanModel, error)
+}
+
+// Setup is a no-op: the endpoint is expected to be reachable from where the
+// tests run.
+func (*queryBackend) Setup(context.Context, testing.TB, kubernetes.Interface, string) {}
+
+func (b *queryBackend) TracePred(traceID string, timeout time.Duration, pred func([]model.SpanModel) bool) (trace []model.SpanModel, err error) {
+	t := time.After(timeout)
+	for !pred(trace) {
+		select {
+		case <-t:
+			return trace, fmt.Errorf("timeout getting trace %s, most recent error: %v", traceID, err)
+		default:
+			trace, err = b.trace(traceID)
+			if err != nil {
+				time.Sleep(time.Second)
+			}
+		}
+	}
+	return trace, err
+}
+
+func (*queryBackend) Cleanup(logging.FormatLogger) {}
+
+func (b *queryBackend) trace(traceID string) ([]model.SpanModel, error) {
+	req, err := http.NewRequest(http.MethodGet, b.endpoint+"/api/traces/"+traceID, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d getting trace %s: %q", resp.StatusCode, traceID, body)
+	}
+	return b.parse(body)
+}
+
+func parseHexID(s string) (model.ID, error) {
+	id, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid span ID %q: %w", s, err)
+	}
+	return model.ID(id), nil
+}
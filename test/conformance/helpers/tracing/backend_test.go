@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openzipkin/zipkin-go/model"
+)
+
+func TestNewBackend(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		Name     string
+		Backend  string
+		Endpoint string
+		WantErr  bool
+	}{{
+		Name: "default",
+	}, {
+		Name:    "zipkin",
+		Backend: ZipkinBackend,
+	}, {
+		Name:     "jaeger",
+		Backend:  JaegerBackend,
+		Endpoint: "http://localhost:16686",
+	}, {
+		Name:     "tempo",
+		Backend:  "Tempo",
+		Endpoint: "http://localhost:3200",
+	}, {
+		Name:    "otlp without endpoint",
+		Backend: OTLPBackend,
+		WantErr: true,
+	}, {
+		Name:     "unknown",
+		Backend:  "unknown",
+		Endpoint: "http://localhost",
+		WantErr:  true,
+	}}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			b, err := NewBackend(tc.Backend, tc.Endpoint)
+			if (err != nil) != tc.WantErr {
+				t.Fatalf("NewBackend() = %v, wantErr %v", err, tc.WantErr)
+			}
+			if err == nil && b == nil {
+				t.Fatal("NewBackend() returned a nil Backend")
+			}
+		})
+	}
+}
+
+var (
+	wantTraceID, _ = model.TraceIDFromHex("5b8efff798038103d269b633813fc60c")
+	wantParentID   = model.ID(0xeee19b7ec3c1b174)
+	wantSpans      = []model.SpanModel{{
+		SpanContext: model.SpanContext{
+			TraceID: wantTraceID,
+			ID:      wantParentID,
+		},
+		Name:          "broker-ingress",
+		Kind:          model.Server,
+		Timestamp:     time.UnixMicro(1700000000000000),
+		Duration:      2 * time.Millisecond,
+		LocalEndpoint: &model.Endpoint{ServiceName: "broker-ingress.knative-eventing"},
+		Tags: map[string]string{
+			"http.method":      "POST",
+			"http.status_code": "202",
+		},
+	}, {
+		SpanContext: model.SpanContext{
+			TraceID:  wantTraceID,
+			ID:       model.ID(0xeee19b7ec3c1b173),
+			ParentID: &wantParentID,
+		},
+		Name:          "broker-filter",
+		Kind:          model.Client,
+		Timestamp:     time.UnixMicro(1700000000001000),
+		Duration:      time.Millisecond,
+		LocalEndpoint: &model.Endpoint{ServiceName: "broker-ingress.knative-eventing"},
+		Tags: map[string]string{
+			"http.host": "broker-filter.knative-eventing.svc.cluster.local",
+		},
+	}}
+)
+
+func TestParseJaegerTrace(t *testing.T) {
+	t.Parallel()
+	body := `{"data":[{"traceID":"5b8efff798038103d269b633813fc60c","spans":[{
+		"traceID":"5b8efff798038103d269b633813fc60c","spanID":"eee19b7ec3c1b174","operationName":"broker-ingress",
+		"references":[],"startTime":1700000000000000,"duration":2000,"processID":"p1",
+		"tags":[{"key":"span.kind","type":"string","value":"server"},{"key":"http.method","type":"string","value":"POST"},
+			{"key":"http.status_code","type":"int64","value":202}]
+	},{
+		"traceID":"5b8efff798038103d269b633813fc60c","spanID":"eee19b7ec3c1b173","operationName":"broker-filter",
+		"references":[{"refType":"CHILD_OF","traceID":"5b8efff798038103d269b633813fc60c","spanID":"eee19b7ec3c1b174"}],
+		"startTime":1700000000001000,"duration":1000,"processID":"p1",
+		"tags":[{"key":"span.kind","type":"string","value":"client"},
+			{"key":"http.host","type":"string","value":"broker-filter.knative-eventing.svc.cluster.local"}]
+	}],"processes":{"p1":{"serviceName":"broker-ingress.knative-eventing"}}}]}`
+
+	got, err := parseJaegerTrace([]byte(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantSpans, got); diff != "" {
+		t.Error("unexpected spans (-want, +got) =", diff)
+	}
+}
+
+func TestParseOTLPTrace(t *testing.T) {
+	t.Parallel()
+	tcs := []struct {
+		Name string
+		Body string
+	}{{
+		Name: "tempo batches with base64 ids",
+		Body: `{"batches":[{
+			"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"broker-ingress.knative-eventing"}}]},
+			"scopeSpans":[{"spans":[{
+				"traceId":"W47/95gDgQPSabYzgT/GDA==","spanId":"7uGbfsPBsXQ=","name":"broker-ingress","kind":"SPAN_KIND_SERVER",
+				"startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000000002000000",
+				"attributes":[{"key":"http.method","value":{"stringValue":"POST"}},{"key":"http.status_code","value":{"intValue":"202"}}]
+			},{
+				"traceId":"W47/95gDgQPSabYzgT/GDA==","spanId":"7uGbfsPBsXM=","parentSpanId":"7uGbfsPBsXQ=","name":"broker-filter",
+				"kind":"SPAN_KIND_CLIENT","startTimeUnixNano":"1700000000001000000","endTimeUnixNano":"1700000000002000000",
+				"attributes":[{"key":"http.host","value":{"stringValue":"broker-filter.knative-eventing.svc.cluster.local"}}]
+			}]}]
+		}]}`,
+	}, {
+		Name: "otlp json with hex ids",
+		Body: `{"resourceSpans":[{
+			"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"broker-ingress.knative-eventing"}}]},
+			"scopeSpans":[{"spans":[{
+				"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174","name":"broker-ingress","kind":2,
+				"startTimeUnixNano":"1700000000000000000","endTimeUnixNano":"1700000000002000000",
+				"attributes":[{"key":"http.method","value":{"stringValue":"POST"}},{"key":"http.status_code","value":{"intValue":202}}]
+			}]}]
+		},{
+			"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"broker-ingress.knative-eventing"}}]},
+			"instrumentationLibrarySpans":[{"spans":[{
+				"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b173","parentSpanId":"eee19b7ec3c1b174",
+				"name":"broker-filter","kind":3,"startTimeUnixNano":"1700000000001000000","endTimeUnixNano":"1700000000002000000",
+				"attributes":[{"key":"http.host","value":{"stringValue":"broker-filter.knative-eventing.svc.cluster.local"}}]
+			}]}]
+		}]}`,
+	}}
+	for _, tc := range tcs {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseOTLPTrace([]byte(tc.Body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(wantSpans, got); diff != "" {
+				t.Error("unexpected spans (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestQueryBackendTracePred(t *testing.T) {
+	t.Parallel()
+	const traceID = "5b8efff798038103d269b633813fc60c"
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/traces/"+traceID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		if requests == 1 {
+			// The trace is not complete yet.
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"spans":[{"traceID":"` + traceID + `","spanID":"eee19b7ec3c1b174"}]}]}`))
+	}))
+	defer s.Close()
+
+	b, err := NewBackend(JaegerBackend, s.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace, err := b.TracePred(traceID, time.Minute, func(trace []model.SpanModel) bool {
+		return len(trace) == 1
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := trace[0].ID; got != wantParentID {
+		t.Errorf("unexpected span ID, got %v want %v", got, wantParentID)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openzipkin/zipkin-go/model"
+)
+
+// jaegerTrace is the subset of the Jaeger query API response we need.
+type jaegerTrace struct {
+	Data []struct {
+		Spans []struct {
+			TraceID    string `json:"traceID"`
+			SpanID     string `json:"spanID"`
+			Name       string `json:"operationName"`
+			References []struct {
+				RefType string `json:"refType"`
+				TraceID string `json:"traceID"`
+				SpanID  string `json:"spanID"`
+			} `json:"references"`
+			StartTime int64 `json:"startTime"`
+			Duration  int64 `json:"duration"`
+			Tags      []struct {
+				Key   string      `json:"key"`
+				Value interface{} `json:"value"`
+			} `json:"tags"`
+			ProcessID string `json:"processID"`
+		} `json:"spans"`
+		Processes map[string]struct {
+			ServiceName string `json:"serviceName"`
+		} `json:"processes"`
+	} `json:"data"`
+}
+
+// parseJaegerTrace converts the response of the Jaeger query API to Zipkin
+// spans.
+func parseJaegerTrace(body []byte) ([]model.SpanModel, error) {
+	var jt jaegerTrace
+	if err := json.Unmarshal(body, &jt); err != nil {
+		return nil, fmt.Errorf("got an error in unmarshalling JSON %q: %w", body, err)
+	}
+
+	var spans []model.SpanModel
+	for _, d := range jt.Data {
+		for _, s := range d.Spans {
+			traceID, err := model.TraceIDFromHex(s.TraceID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trace ID %q: %w", s.TraceID, err)
+			}
+			id, err := parseHexID(s.SpanID)
+			if err != nil {
+				return nil, err
+			}
+			span := model.SpanModel{
+				SpanContext: model.SpanContext{
+					TraceID: traceID,
+					ID:      id,
+				},
+				Name:      s.Name,
+				Timestamp: time.UnixMicro(s.StartTime),
+				Duration:  time.Duration(s.Duration) * time.Microsecond,
+				Tags:      make(map[string]string, len(s.Tags)),
+			}
+			for _, ref := range s.References {
+				if ref.RefType != "CHILD_OF" || ref.TraceID != s.TraceID {
+					continue
+				}
+				parentID, err := parseHexID(ref.SpanID)
+				if err != nil {
+					return nil, err
+				}
+				span.ParentID = &parentID
+				break
+			}
+			for _, tag := range s.Tags {
+				if tag.Key == "span.kind" {
+					span.Kind = model.Kind(strings.ToUpper(fmt.Sprint(tag.Value)))
+					continue
+				}
+				span.Tags[tag.Key] = fmt.Sprint(tag.Value)
+			}
+			if p, ok := d.Processes[s.ProcessID]; ok {
+				span.LocalEndpoint = &model.Endpoint{ServiceName: p.ServiceName}
+			}
+			spans = append(spans, span)
+		}
+	}
+	return spans, nil
+}
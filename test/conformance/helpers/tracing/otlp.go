@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openzipkin/zipkin-go/model"
+)
+
+// otlpTrace is the subset of an OTLP JSON trace we need. Tempo serves the
+// resource spans under "batches", the OTLP JSON encoding under
+// "resourceSpans".
+type otlpTrace struct {
+	Batches       []otlpResourceSpans `json:"batches"`
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	} `json:"resource"`
+	ScopeSpans []struct {
+		Spans []otlpSpan `json:"spans"`
+	} `json:"scopeSpans"`
+	// InstrumentationLibrarySpans is the name of ScopeSpans prior to OTLP 0.15.
+	InstrumentationLibrarySpans []struct {
+		Spans []otlpSpan `json:"spans"`
+	} `json:"instrumentationLibrarySpans"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId"`
+	Name              string          `json:"name"`
+	Kind              json.RawMessage `json:"kind"`
+	StartTimeUnixNano json.Number     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   json.Number     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue *string      `json:"stringValue"`
+		IntValue    *json.Number `json:"intValue"`
+		DoubleValue *json.Number `json:"doubleValue"`
+		BoolValue   *bool        `json:"boolValue"`
+	} `json:"value"`
+}
+
+func (a otlpAttribute) String() string {
+	switch {
+	case a.Value.StringValue != nil:
+		return *a.Value.StringValue
+	case a.Value.IntValue != nil:
+		return a.Value.IntValue.String()
+	case a.Value.DoubleValue != nil:
+		return a.Value.DoubleValue.String()
+	case a.Value.BoolValue != nil:
+		return fmt.Sprint(*a.Value.BoolValue)
+	}
+	return ""
+}
+
+// otlpKinds maps the OTLP span kinds, which are encoded either by name or by
+// number, to Zipkin's.
+var otlpKinds = map[string]model.Kind{
+	"SPAN_KIND_SERVER":   model.Server,
+	"SPAN_KIND_CLIENT":   model.Client,
+	"SPAN_KIND_PRODUCER": model.Producer,
+	"SPAN_KIND_CONSUMER": model.Consumer,
+	"2":                  model.Server,
+	"3":                  model.Client,
+	"4":                  model.Producer,
+	"5":                  model.Consumer,
+}
+
+// parseOTLPTrace converts an OTLP JSON trace to Zipkin spans.
+func parseOTLPTrace(body []byte) ([]model.SpanModel, error) {
+	var ot otlpTrace
+	if err := json.Unmarshal(body, &ot); err != nil {
+		return nil, fmt.Errorf("got an error in unmarshalling JSON %q: %w", body, err)
+	}
+
+	var spans []model.SpanModel
+	for _, rs := range append(ot.Batches, ot.ResourceSpans...) {
+		var serviceName string
+		for _, attr := range rs.Resource.Attributes {
+			if attr.Key == "service.name" {
+				serviceName = attr.String()
+			}
+		}
+
+		var otlpSpans []otlpSpan
+		for _, ss := range rs.ScopeSpans {
+			otlpSpans = append(otlpSpans, ss.Spans...)
+		}
+		for _, ils := range rs.InstrumentationLibrarySpans {
+			otlpSpans = append(otlpSpans, ils.Spans...)
+		}
+
+		for _, s := range otlpSpans {
+			span, err := s.toSpanModel()
+			if err != nil {
+				return nil, err
+			}
+			if serviceName != "" {
+				span.LocalEndpoint = &model.Endpoint{ServiceName: serviceName}
+			}
+			spans = append(spans, span)
+		}
+	}
+	return spans, nil
+}
+
+func (s otlpSpan) toSpanModel() (model.SpanModel, error) {
+	traceID, err := decodeOTLPID(s.TraceID, 16)
+	if err != nil {
+		return model.SpanModel{}, fmt.Errorf("invalid trace ID %q: %w", s.TraceID, err)
+	}
+	tid, err := model.TraceIDFromHex(traceID)
+	if err != nil {
+		return model.SpanModel{}, fmt.Errorf("invalid trace ID %q: %w", s.TraceID, err)
+	}
+	spanID, err := decodeOTLPID(s.SpanID, 8)
+	if err != nil {
+		return model.SpanModel{}, fmt.Errorf("invalid span ID %q: %w", s.SpanID, err)
+	}
+	id, err := parseHexID(spanID)
+	if err != nil {
+		return model.SpanModel{}, err
+	}
+
+	span := model.SpanModel{
+		SpanContext: model.SpanContext{
+			TraceID: tid,
+			ID:      id,
+		},
+		Name: s.Name,
+		Kind: otlpKinds[strings.Trim(string(s.Kind), `"`)],
+		Tags: make(map[string]string, len(s.Attributes)),
+	}
+	if s.ParentSpanID != "" {
+		parentSpanID, err := decodeOTLPID(s.ParentSpanID, 8)
+		if err != nil {
+			return model.SpanModel{}, fmt.Errorf("invalid parent span ID %q: %w", s.ParentSpanID, err)
+		}
+		parentID, err := parseHexID(parentSpanID)
+		if err != nil {
+			return model.SpanModel{}, err
+		}
+		span.ParentID = &parentID
+	}
+	start, _ := s.StartTimeUnixNano.Int64()
+	end, _ := s.EndTimeUnixNano.Int64()
+	if start > 0 {
+		span.Timestamp = time.Unix(0, start)
+		if end > start {
+			span.Duration = time.Duration(end - start)
+		}
+	}
+	for _, attr := range s.Attributes {
+		span.Tags[attr.Key] = attr.String()
+	}
+	return span, nil
+}
+
+// decodeOTLPID returns the hex form of an OTLP trace or span ID of the given
+// byte length. The OTLP JSON encoding uses hex, while Tempo serves the
+// protobuf JSON mapping, which uses base64.
+func decodeOTLPID(s string, length int) (string, error) {
+	if len(s) == 2*length {
+		if _, err := hex.DecodeString(s); err == nil {
+			return s, nil
+		}
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	if len(b) != length {
+		return "", fmt.Errorf("expected %d bytes, got %d", length, len(b))
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -27,7 +27,6 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/system"
-	"knative.dev/pkg/test/zipkin"
 
 	tracinghelper "knative.dev/eventing/test/conformance/helpers/tracing"
 	testlib "knative.dev/eventing/test/lib"
@@ -60,9 +59,10 @@ func tracingTest(
 	client := testlib.Setup(t, true, setupClient)
 	defer testlib.TearDown(client)
 
-	// Do NOT call zipkin.CleanupZipkinTracingSetup. That will be called exactly once in
+	// Do NOT call the backend's Cleanup. That will be called exactly once in
 	// TestMain.
-	zipkin.SetupZipkinTracingFromConfigTracingOrFail(context.Background(), t, client.Kube, system.Namespace())
+	backend := tracinghelper.DefaultBackend()
+	backend.Setup(context.Background(), t, client.Kube, system.Namespace())
 
 	// Start the event info store. Note this is done _before_ we setup the infrastructure, which
 	// sends the event.
@@ -79,7 +79,7 @@ func tracingTest(
 
 	// Match the trace
 	traceID := getTraceIDHeader(t, matches)
-	trace, err := zipkin.JSONTracePred(traceID, 5*time.Minute, func(trace []model.SpanModel) bool {
+	trace, err := backend.TracePred(traceID, 5*time.Minute, func(trace []model.SpanModel) bool {
 		tree, err := tracinghelper.GetTraceTree(trace)
 		if err != nil {
 			return false
@@ -29,9 +29,9 @@ import (
 	"knative.dev/pkg/system"
 
 	"knative.dev/eventing/test"
+	tracinghelper "knative.dev/eventing/test/conformance/helpers/tracing"
 	testlib "knative.dev/eventing/test/lib"
 	"knative.dev/eventing/test/lib/setupclientoptions"
-	"knative.dev/pkg/test/zipkin"
 )
 
 const (
@@ -66,10 +66,15 @@ func TestMain(m *testing.M) {
 		brokerClass = test.BrokerClass
 
 		addSourcesInitializers()
-		// Any tests may SetupZipkinTracing, it will only actually be done once. This should be the ONLY
-		// place that cleans it up. If an individual test calls this instead, then it will break other
-		// tests that need the tracing in place.
-		defer zipkin.CleanupZipkinTracingSetup(log.Printf)
+		tracingBackend, err := tracinghelper.NewBackend(test.EventingFlags.TracingBackend, test.EventingFlags.TracingEndpoint)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tracinghelper.SetDefaultBackend(tracingBackend)
+		// Any tests may set up the tracing backend, it will only actually be done once. This should be
+		// the ONLY place that cleans it up. If an individual test calls this instead, then it will break
+		// other tests that need the tracing in place.
+		defer tracingBackend.Cleanup(log.Printf)
 
 		exit := m.Run()
 		// Collect logs only when test failed.
@@ -43,6 +43,10 @@ const (
 		"won't create their own."
 	BrokerNamespaceUsage = "When testing a pre-existing broker, this variable specifies the namespace the broker can be found in."
 	BrokerClass          = "MTChannelBasedBroker"
+	TracingBackendUsage  = "The tracing backend the tracing conformance tests query for traces, " +
+		"one of zipkin, jaeger, tempo or otlp."
+	TracingEndpointUsage = "The base URL of the tracing backend query API, e.g. http://localhost:16686 " +
+		"for Jaeger or http://localhost:3200 for Tempo. Ignored by the zipkin backend."
 )
 
 // EventingFlags holds the command line flags specific to knative/eventing.
@@ -63,4 +67,6 @@ func InitializeEventingFlags() {
 	// Might be useful in restricted environments where namespaces need to be
 	// created by a user with increased privileges (admin).
 	flag.BoolVar(&EventingFlags.ReuseNamespace, "reusenamespace", false, "Whether to re-use namespace for a test if it already exists.")
+	flag.StringVar(&EventingFlags.TracingBackend, "tracingbackend", "zipkin", TracingBackendUsage)
+	flag.StringVar(&EventingFlags.TracingEndpoint, "tracingendpoint", "", TracingEndpointUsage)
 }
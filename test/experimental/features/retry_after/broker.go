@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retry_after
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+	"k8s.io/utils/pointer"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/resources/service"
+	"knative.dev/reconciler-test/pkg/state"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/trigger"
+)
+
+const (
+	BrokerNameKey           = "BrokerNameKey"
+	TriggerNameKey          = "TriggerNameKey"
+	RetryAfterMaxSecondsKey = "RetryAfterMaxSecondsKey"
+)
+
+// ConfigureBrokerDataPlane creates a Feature which sets up the specified
+// Broker, Trigger and EventsHub Receiver so that it is ready to receive
+// CloudEvents. The Receiver rejects the first events with a 503 response and
+// a Retry-After header, and the Trigger bounds it with its retryAfterMax.
+func ConfigureBrokerDataPlane(ctx context.Context, t *testing.T) *feature.Feature {
+
+	// Get Component Names From Context
+	var retryAttempts, retryAfterSeconds, retryAfterMaxSeconds int
+	brokerName := state.GetStringOrFail(ctx, t, BrokerNameKey)
+	triggerName := state.GetStringOrFail(ctx, t, TriggerNameKey)
+	receiverName := state.GetStringOrFail(ctx, t, ReceiverNameKey)
+	state.GetOrFail(ctx, t, RetryAttemptsKey, &retryAttempts)
+	state.GetOrFail(ctx, t, RetryAfterSecondsKey, &retryAfterSeconds)
+	state.GetOrFail(ctx, t, RetryAfterMaxSecondsKey, &retryAfterMaxSeconds)
+
+	backoffPolicy := eventingduckv1.BackoffPolicyLinear
+
+	// Create A Feature To Configure The DataPlane (Broker, Trigger, Receiver)
+	f := feature.NewFeatureNamed("Configure Broker Data-Plane")
+	f.Setup("Install An EventsHub Receiver", eventshub.Install(receiverName,
+		eventshub.StartReceiver,
+		eventshub.DropFirstN(uint(retryAttempts)),
+		eventshub.DropEventsResponseCode(http.StatusServiceUnavailable),
+		eventshub.DropEventsResponseHeaders(map[string]string{"Retry-After": strconv.Itoa(retryAfterSeconds)})))
+	f.Setup("Install A Broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("Install A Trigger", trigger.Install(triggerName, brokerName,
+		trigger.WithSubscriber(service.AsKReference(receiverName), ""),
+		trigger.WithRetry(int32(retryAttempts), &backoffPolicy, pointer.String("PT0.5S")),
+		trigger.WithRetryAfterMax(fmt.Sprintf("PT%dS", retryAfterMaxSeconds))))
+	f.Assert("Broker Is Ready", broker.IsReady(brokerName))
+	f.Assert("Trigger Is Ready", trigger.IsReady(triggerName))
+
+	// Return The ConfigureBrokerDataPlane Feature
+	return f
+}
+
+// SendEventToBroker creates a Feature which sends a CloudEvent to the
+// specified Broker and verifies that its retries are received after the
+// Retry-After duration bounded by the retryAfterMax of the Trigger. It is
+// assumed that the Broker / Trigger / EventsHub Receiver are in place and
+// ready to receive the event.
+func SendEventToBroker(ctx context.Context, t *testing.T) *feature.Feature {
+
+	// Get Component Names From Context
+	var retryAttempts, retryAfterSeconds, retryAfterMaxSeconds, expectedIntervalMargin int
+	brokerName := state.GetStringOrFail(ctx, t, BrokerNameKey)
+	senderName := state.GetStringOrFail(ctx, t, SenderNameKey)
+	receiverName := state.GetStringOrFail(ctx, t, ReceiverNameKey)
+	state.GetOrFail(ctx, t, RetryAttemptsKey, &retryAttempts)
+	state.GetOrFail(ctx, t, RetryAfterSecondsKey, &retryAfterSeconds)
+	state.GetOrFail(ctx, t, RetryAfterMaxSecondsKey, &retryAfterMaxSeconds)
+	state.GetOrFail(ctx, t, ExpectedIntervalMargingKey, &expectedIntervalMargin)
+
+	wait := time.Duration(retryAfterSeconds) * time.Second
+	if retryAfterMax := time.Duration(retryAfterMaxSeconds) * time.Second; retryAfterMax < wait {
+		wait = retryAfterMax
+	}
+
+	// Create The Base CloudEvent To Send (ID will be set by the EventsHub Sender)
+	event := cetest.FullEvent()
+
+	// Create A New Feature To Send An Event And Verify Retry-After Duration
+	f := feature.NewFeatureNamed("Send Events To Broker")
+	f.Setup("Install An EventsHub Sender", eventshub.Install(senderName, eventshub.StartSenderToResource(broker.GVR(), brokerName), eventshub.InputEvent(event)))
+	f.Assert("Rejected Events Received", assert.OnStore(receiverName).MatchRejectedEvent(cetest.HasId(event.ID())).Exact(retryAttempts)) // `retryAttempts` dropped events
+	f.Assert("Received Events Received", assert.OnStore(receiverName).MatchReceivedEvent(cetest.HasId(event.ID())).Exact(1))             // One Successful Response
+	f.Assert("Event Timing Verified of received event", assert.OnStore(receiverName).
+		Match(receivedAtRegularInterval(event.ID(), wait, time.Duration(expectedIntervalMargin)*time.Second)).Exact(retryAttempts+1))
+
+	// Return The SendEventToBroker Feature
+	return f
+}
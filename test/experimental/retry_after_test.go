@@ -75,3 +75,39 @@ func TestRetryAfter(t *testing.T) {
 	env.Test(ctx, t, retry_after.ConfigureDataPlane(ctx, t))
 	env.Test(ctx, t, retry_after.SendEvent(ctx, t))
 }
+
+func TestRetryAfterBroker(t *testing.T) {
+
+	// Run Test In Parallel With Others
+	t.Parallel()
+
+	// Create The Test Context / Environment
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+	)
+
+	// Generate A Unique K8S Safe Prefix For The Test Components
+	retryAfterPrefix := feature.MakeRandomK8sName("retryafter")
+
+	// Generate Unique Component Names And Add To Context Store
+	ctx = state.ContextWith(ctx, &state.KVStore{})
+	state.SetOrFail(ctx, t, retry_after.BrokerNameKey, retryAfterPrefix+"-broker")
+	state.SetOrFail(ctx, t, retry_after.TriggerNameKey, retryAfterPrefix+"-trigger")
+	state.SetOrFail(ctx, t, retry_after.SenderNameKey, retryAfterPrefix+"-sender")
+	state.SetOrFail(ctx, t, retry_after.ReceiverNameKey, retryAfterPrefix+"-receiver")
+	state.SetOrFail(ctx, t, retry_after.RetryAttemptsKey, 3)
+
+	// The Retry-After header returned by the receiver is longer than the
+	// retryAfterMax of the Trigger, retries are expected after the latter.
+	state.SetOrFail(ctx, t, retry_after.RetryAfterSecondsKey, 10)
+	state.SetOrFail(ctx, t, retry_after.RetryAfterMaxSecondsKey, 5)
+	state.SetOrFail(ctx, t, retry_after.ExpectedIntervalMargingKey, 3)
+
+	// Configure DataPlane & Send An Event
+	env.Test(ctx, t, retry_after.ConfigureBrokerDataPlane(ctx, t))
+	env.Test(ctx, t, retry_after.SendEventToBroker(ctx, t))
+}
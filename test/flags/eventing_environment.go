@@ -26,4 +26,6 @@ type EventingEnvironmentFlags struct {
 	BrokerName      string
 	BrokerNamespace string
 	ReuseNamespace  bool
+	TracingBackend  string
+	TracingEndpoint string
 }
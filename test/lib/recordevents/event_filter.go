@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordevents
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventFilter selects the events the recordevents pod records. Events are
+// recorded only if they match every non-empty criterion. Requests that
+// cannot be decoded as a CloudEvent are always recorded, so that decoding
+// errors are never hidden by a filter.
+type EventFilter struct {
+	// Types are the accepted CloudEvent types.
+	Types []string
+	// Sources are the accepted CloudEvent sources.
+	Sources []string
+	// Extensions are the extension attributes, and their values, the
+	// CloudEvent must have.
+	Extensions map[string]string
+}
+
+// IsEmpty returns true if the filter accepts every event.
+func (f EventFilter) IsEmpty() bool {
+	return len(f.Types) == 0 && len(f.Sources) == 0 && len(f.Extensions) == 0
+}
+
+// Matches returns true if the EventInfo is accepted by the filter.
+func (f EventFilter) Matches(info EventInfo) bool {
+	if f.IsEmpty() || info.Event == nil {
+		return true
+	}
+	if len(f.Types) != 0 && !contains(f.Types, info.Event.Type()) {
+		return false
+	}
+	if len(f.Sources) != 0 && !contains(f.Sources, info.Event.Source()) {
+		return false
+	}
+	exts := info.Event.Extensions()
+	for k, v := range f.Extensions {
+		ext, ok := exts[k]
+		if !ok || fmt.Sprint(ext) != v {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// serializeExtensions encodes extensions as a comma separated list of
+// key=value pairs, sorted by key.
+func serializeExtensions(extensions map[string]string) string {
+	pairs := make([]string, 0, len(extensions))
+	for k, v := range extensions {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseExtensions decodes the output of serializeExtensions.
+func ParseExtensions(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	extensions := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid extension filter %q, expected key=value", pair)
+		}
+		extensions[k] = v
+	}
+	return extensions, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package recordevents
 
 import (
+	"crypto/tls"
 	"fmt"
 	"strings"
 	"time"
@@ -56,12 +57,63 @@ type EventInfo struct {
 
 	StatusCode int `json:"statusCode,omitempty"`
 
+	// Set if the http request was received over TLS
+	TLS *TLSInfo `json:"tls,omitempty"`
+	// Set if the http request carried a JWT that the pod validated
+	OIDCIdentity *OIDCIdentity `json:"oidcIdentity,omitempty"`
+
 	Origin   string    `json:"origin,omitempty"`
 	Observer string    `json:"observer,omitempty"`
 	Time     time.Time `json:"time,omitempty"`
 	Sequence uint64    `json:"sequence"`
 }
 
+// TLSInfo holds the properties of the TLS connection a request was received on.
+type TLSInfo struct {
+	Version     string `json:"version"`
+	CipherSuite string `json:"cipherSuite"`
+	ServerName  string `json:"serverName,omitempty"`
+	// PeerCertificates are the certificates presented by the client, if any.
+	PeerCertificates []CertificateInfo `json:"peerCertificates,omitempty"`
+}
+
+// CertificateInfo holds the identity of a certificate.
+type CertificateInfo struct {
+	Subject  string    `json:"subject"`
+	Issuer   string    `json:"issuer"`
+	DNSNames []string  `json:"dnsNames,omitempty"`
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// NewTLSInfo returns the TLSInfo of the given connection state, or nil when
+// the request wasn't received over TLS.
+func NewTLSInfo(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &TLSInfo{
+		Version:     tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ServerName:  state.ServerName,
+	}
+	for _, cert := range state.PeerCertificates {
+		info.PeerCertificates = append(info.PeerCertificates, CertificateInfo{
+			Subject:  cert.Subject.String(),
+			Issuer:   cert.Issuer.String(),
+			DNSNames: cert.DNSNames,
+			NotAfter: cert.NotAfter,
+		})
+	}
+	return info
+}
+
+// OIDCIdentity holds the claims of the JWT validated by the recordevents pod.
+type OIDCIdentity struct {
+	Subject  string   `json:"subject"`
+	Issuer   string   `json:"issuer"`
+	Audience []string `json:"audience"`
+}
+
 // Pretty print the event. Meant for debugging.
 func (ei *EventInfo) String() string {
 	var sb strings.Builder
@@ -92,6 +144,15 @@ func (ei *EventInfo) String() string {
 	if ei.StatusCode != 0 {
 		sb.WriteString(fmt.Sprintf("--- Status Code: %d ---\n", ei.StatusCode))
 	}
+	if ei.TLS != nil {
+		sb.WriteString(fmt.Sprintf("--- TLS: %s %s ---\n", ei.TLS.Version, ei.TLS.CipherSuite))
+		for _, cert := range ei.TLS.PeerCertificates {
+			sb.WriteString("  Peer certificate: " + cert.Subject + "\n")
+		}
+	}
+	if ei.OIDCIdentity != nil {
+		sb.WriteString("--- OIDC subject: '" + ei.OIDCIdentity.Subject + "' ---\n")
+	}
 	sb.WriteString("--- Origin: '" + ei.Origin + "' ---\n")
 	sb.WriteString("--- Observer: '" + ei.Observer + "' ---\n")
 	sb.WriteString("--- Time: " + ei.Time.String() + " ---\n")
@@ -94,6 +94,55 @@ func MatchKind(kind EventKind) EventInfoMatcher {
 	}
 }
 
+// IsTLS matches the EventInfo of requests received over TLS
+func IsTLS() EventInfoMatcher {
+	return func(info EventInfo) error {
+		if info.TLS == nil {
+			return fmt.Errorf("request wasn't received over TLS")
+		}
+		return nil
+	}
+}
+
+// HasPeerCertificateSubject matches the EventInfo of requests received over TLS
+// from a client presenting a certificate with the given subject
+func HasPeerCertificateSubject(subject string) EventInfoMatcher {
+	return func(info EventInfo) error {
+		if info.TLS == nil {
+			return fmt.Errorf("request wasn't received over TLS")
+		}
+		for _, cert := range info.TLS.PeerCertificates {
+			if cert.Subject == subject {
+				return nil
+			}
+		}
+		return fmt.Errorf("cannot find the peer certificate with subject '%s' between the %d peer certificates", subject, len(info.TLS.PeerCertificates))
+	}
+}
+
+// HasOIDCSubject matches the EventInfo of requests carrying a validated JWT with the given subject
+func HasOIDCSubject(subject string) EventInfoMatcher {
+	return func(info EventInfo) error {
+		if info.OIDCIdentity == nil {
+			return fmt.Errorf("request didn't carry a validated JWT")
+		}
+		if info.OIDCIdentity.Subject != subject {
+			return fmt.Errorf("OIDC subject don't match. Expected: '%s', Actual: '%s'", subject, info.OIDCIdentity.Subject)
+		}
+		return nil
+	}
+}
+
+// HasNoOIDCIdentity matches the EventInfo of requests without a validated JWT
+func HasNoOIDCIdentity() EventInfoMatcher {
+	return func(info EventInfo) error {
+		if info.OIDCIdentity != nil {
+			return fmt.Errorf("request carried a validated JWT with subject '%s'", info.OIDCIdentity.Subject)
+		}
+		return nil
+	}
+}
+
 // MatchHeartBeatsImageMessage matches that the data field of the event, in the format of the heartbeats image, contains the following msg field
 func MatchHeartBeatsImageMessage(expectedMsg string) cetest.EventMatcher {
 	return cetest.AllOf(
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordevents
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+)
+
+func TestNewTLSInfo(t *testing.T) {
+	if info := NewTLSInfo(nil); info != nil {
+		t.Errorf("NewTLSInfo(nil) = %v, want nil", info)
+	}
+
+	info := NewTLSInfo(&tls.ConnectionState{
+		Version:     tls.VersionTLS13,
+		CipherSuite: tls.TLS_AES_128_GCM_SHA256,
+		ServerName:  "sink.example.com",
+		PeerCertificates: []*x509.Certificate{{
+			Subject:  pkix.Name{CommonName: "client"},
+			Issuer:   pkix.Name{CommonName: "ca"},
+			DNSNames: []string{"client.example.com"},
+		}},
+	})
+	if info.Version != "TLS 1.3" || info.CipherSuite != "TLS_AES_128_GCM_SHA256" || info.ServerName != "sink.example.com" {
+		t.Errorf("Unexpected TLS info %+v", info)
+	}
+	if len(info.PeerCertificates) != 1 || info.PeerCertificates[0].Subject != "CN=client" || info.PeerCertificates[0].Issuer != "CN=ca" {
+		t.Errorf("Unexpected peer certificates %+v", info.PeerCertificates)
+	}
+}
+
+func TestTLSAndOIDCMatchers(t *testing.T) {
+	plain := EventInfo{}
+	secured := EventInfo{
+		TLS: &TLSInfo{
+			Version:          "TLS 1.3",
+			PeerCertificates: []CertificateInfo{{Subject: "CN=client"}},
+		},
+		OIDCIdentity: &OIDCIdentity{Subject: "system:serviceaccount:ns:sa"},
+	}
+
+	tests := map[string]struct {
+		matcher EventInfoMatcher
+		info    EventInfo
+		wantErr bool
+	}{
+		"tls":                       {matcher: IsTLS(), info: secured},
+		"no tls":                    {matcher: IsTLS(), info: plain, wantErr: true},
+		"peer certificate":          {matcher: HasPeerCertificateSubject("CN=client"), info: secured},
+		"other peer certificate":    {matcher: HasPeerCertificateSubject("CN=other"), info: secured, wantErr: true},
+		"peer certificate, no tls":  {matcher: HasPeerCertificateSubject("CN=client"), info: plain, wantErr: true},
+		"oidc subject":              {matcher: HasOIDCSubject("system:serviceaccount:ns:sa"), info: secured},
+		"other oidc subject":        {matcher: HasOIDCSubject("system:serviceaccount:ns:other"), info: secured, wantErr: true},
+		"oidc subject, no identity": {matcher: HasOIDCSubject("system:serviceaccount:ns:sa"), info: plain, wantErr: true},
+		"no oidc identity":          {matcher: HasNoOIDCIdentity(), info: plain},
+		"unexpected oidc identity":  {matcher: HasNoOIDCIdentity(), info: secured, wantErr: true},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if err := tc.matcher(tc.info); (err != nil) != tc.wantErr {
+				t.Errorf("matcher() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
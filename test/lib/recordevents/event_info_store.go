@@ -63,18 +63,46 @@ type EventInfoStore struct {
 	lock      sync.Mutex
 	collected []EventInfo
 
-	eventsSeen    int
-	eventsNotMine int
+	// filter, if set, drops the events not matching it when they are collected.
+	filter EventInfoMatcher
+	// maxEvents, if greater than 0, is the number of most recent events retained.
+	maxEvents int
+
+	eventsSeen     int
+	eventsNotMine  int
+	eventsFiltered int
+	eventsEvicted  int
+}
+
+// EventInfoStoreOption configures an EventInfoStore.
+type EventInfoStoreOption func(*EventInfoStore)
+
+// WithStoreFilter makes the EventInfoStore retain only the events matching the
+// given matchers, so that Find and the asserts don't have to scan the others.
+func WithStoreFilter(matchers ...EventInfoMatcher) EventInfoStoreOption {
+	return func(store *EventInfoStore) {
+		store.filter = AllOf(matchers...)
+	}
+}
+
+// WithMaxEvents makes the EventInfoStore retain only the max most recent events.
+func WithMaxEvents(max int) EventInfoStoreOption {
+	return func(store *EventInfoStore) {
+		store.maxEvents = max
+	}
 }
 
 // Creates an EventInfoStore that is used to iteratively download events recorded by the
 // recordevents pod.
-func NewEventInfoStore(client *testlib.Client, podName string, podNamespace string) (*EventInfoStore, error) {
+func NewEventInfoStore(client *testlib.Client, podName string, podNamespace string, opts ...EventInfoStoreOption) (*EventInfoStore, error) {
 	store := &EventInfoStore{
 		tb:           client.T,
 		podName:      podName,
 		podNamespace: podNamespace,
 	}
+	for _, opt := range opts {
+		opt(store)
+	}
 
 	numEventsAlreadyPresent := client.EventListener.AddHandler(store.handle)
 	client.T.Logf("EventInfoStore added to the EventListener, which has already seen %v events", numEventsAlreadyPresent)
@@ -83,7 +111,10 @@ func NewEventInfoStore(client *testlib.Client, podName string, podNamespace stri
 }
 
 func (ei *EventInfoStore) getDebugInfo() string {
-	return fmt.Sprintf("Pod '%s' in namespace '%s'", ei.podName, ei.podNamespace)
+	ei.lock.Lock()
+	defer ei.lock.Unlock()
+	return fmt.Sprintf("Pod '%s' in namespace '%s' (events filtered %d, events evicted %d)",
+		ei.podName, ei.podNamespace, ei.eventsFiltered, ei.eventsEvicted)
 }
 
 func (ei *EventInfoStore) getEventInfo() []EventInfo {
@@ -113,7 +144,18 @@ func (ei *EventInfoStore) handle(event *corev1.Event) {
 		return
 	}
 
+	if ei.filter != nil && ei.filter(eventInfo) != nil {
+		ei.eventsFiltered += 1
+		return
+	}
+
 	ei.collected = append(ei.collected, eventInfo)
+	if ei.maxEvents > 0 && len(ei.collected) > ei.maxEvents {
+		evicted := len(ei.collected) - ei.maxEvents
+		ei.eventsEvicted += evicted
+		// Copy to let the evicted events be garbage collected.
+		ei.collected = append([]EventInfo(nil), ei.collected[evicted:]...)
+	}
 }
 
 func (ei *EventInfoStore) isMyEvent(event *corev1.Event) bool {
@@ -163,6 +205,37 @@ func (ei *EventInfoStore) Find(matchers ...EventInfoMatcher) ([]EventInfo, Searc
 	return allMatch, sInfo, nonMatchingErrors, nil
 }
 
+// FindPage returns the limit matching events starting from the offset-th one, in
+// the order they were recorded, as well as the total number of matching events.
+// A limit lower or equal to 0 returns all the matching events after offset.
+func (ei *EventInfoStore) FindPage(offset, limit int, matchers ...EventInfoMatcher) ([]EventInfo, int, error) {
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("offset must not be negative, got %d", offset)
+	}
+	allMatch, _, _, err := ei.Find(matchers...)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := len(allMatch)
+	if offset >= total {
+		return []EventInfo{}, total, nil
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return allMatch[offset:end], total, nil
+}
+
+// FindQuery is like Find, but matches the events with a query parsed by ParseQuery.
+func (ei *EventInfoStore) FindQuery(query string) ([]EventInfo, SearchedInfo, []error, error) {
+	m, err := ParseQuery(query)
+	if err != nil {
+		return nil, SearchedInfo{}, nil, err
+	}
+	return ei.Find(m)
+}
+
 // Assert that there are at least min number of match for the provided matchers.
 // This method fails the test if the assert is not fulfilled.
 func (ei *EventInfoStore) AssertAtLeast(min int, matchers ...EventInfoMatcher) []EventInfo {
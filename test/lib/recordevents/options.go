@@ -18,10 +18,15 @@ package recordevents
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
 	testlib "knative.dev/eventing/test/lib"
+	"knative.dev/eventing/test/lib/resources"
 )
 
 type EventRecordOption = func(*corev1.Pod, *testlib.Client) error
@@ -112,3 +117,66 @@ func InputEncoding(encoding cloudevents.Encoding) EventRecordOption {
 func InputHeaders(headers map[string]string) EventRecordOption {
 	return envOption("INPUT_HEADERS", serializeHeaders(headers))
 }
+
+// RecordOnlyTypes lets the recordevents pod record only the events of the given types.
+func RecordOnlyTypes(types ...string) EventRecordOption {
+	return envOption("FILTER_TYPES", strings.Join(types, ","))
+}
+
+// RecordOnlySources lets the recordevents pod record only the events from the given sources.
+func RecordOnlySources(sources ...string) EventRecordOption {
+	return envOption("FILTER_SOURCES", strings.Join(sources, ","))
+}
+
+// RecordOnlyExtensions lets the recordevents pod record only the events with the given
+// extension attributes and values.
+func RecordOnlyExtensions(extensions map[string]string) EventRecordOption {
+	return envOption("FILTER_EXTENSIONS", serializeExtensions(extensions))
+}
+
+// MaxRecordedEvents lets the recordevents pod stop recording after the given number of events.
+// Events received afterward are still handled, but not recorded.
+func MaxRecordedEvents(max int) EventRecordOption {
+	return envOption("MAX_RECORDED_EVENTS", strconv.Itoa(max))
+}
+
+// VerifyOIDCAudience lets the recordevents pod validate the JWTs of the requests for the given audience
+// and record their identity.
+func VerifyOIDCAudience(audience string) EventRecordOption {
+	return envOption("OIDC_AUDIENCE", audience)
+}
+
+// ServeTLS lets the recordevents pod also receive events over TLS on EventRecordReceiveTLSPort,
+// serving the certificate of the given kubernetes.io/tls Secret, and record the TLS properties.
+// The TLS port is exposed on port 443 of the Service named after the pod with the "-tls" suffix.
+func ServeTLS(secretName string) EventRecordOption {
+	const mountPath = "/etc/recordevents/tls"
+	return compose(
+		func(pod *corev1.Pod, client *testlib.Client) error {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: "tls",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{SecretName: secretName},
+				},
+			})
+			pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "tls",
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+			return nil
+		},
+		func(pod *corev1.Pod, client *testlib.Client) error {
+			svc := resources.Service(pod.Name+"-tls", pod.Labels, []corev1.ServicePort{{
+				Name:       "https",
+				Port:       443,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt(EventRecordReceiveTLSPort),
+			}})
+			client.CreateServiceOrFail(svc)
+			return nil
+		},
+		envOption("TLS_CERT_FILE", mountPath+"/"+corev1.TLSCertKey),
+		envOption("TLS_KEY_FILE", mountPath+"/"+corev1.TLSPrivateKeyKey),
+	)
+}
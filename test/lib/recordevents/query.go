@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordevents
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ParseQuery parses a query into an EventInfoMatcher. A query is a list of
+// clauses joined by "and", all of which have to match:
+//
+//	type == 'dev.knative.test' and source =~ '^/apis/v1/' and ext.myext != "x"
+//
+// A clause is made of a field, an operator and a value. The supported fields
+// are kind, error, id, type, source, subject, datacontenttype, data,
+// ext.<name> for CloudEvent extensions and header.<name> for HTTP headers.
+// The supported operators are == and != for exact comparisons and =~ and !~
+// for regular expressions. Values may be quoted with single or double quotes
+// and must be if they contain whitespace.
+func ParseQuery(query string) (EventInfoMatcher, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return Any(), nil
+	}
+
+	var matchers []EventInfoMatcher
+	for {
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete clause %q in query %q", strings.Join(tokens, " "), query)
+		}
+		m, err := parseClause(tokens[0], tokens[1], tokens[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid query %q: %w", query, err)
+		}
+		matchers = append(matchers, m)
+		tokens = tokens[3:]
+		if len(tokens) == 0 {
+			return AllOf(matchers...), nil
+		}
+		if !strings.EqualFold(tokens[0], "and") {
+			return nil, fmt.Errorf("expected \"and\" but got %q in query %q", tokens[0], query)
+		}
+		tokens = tokens[1:]
+	}
+}
+
+// MustParseQuery is like ParseQuery but panics if the query is invalid.
+func MustParseQuery(query string) EventInfoMatcher {
+	m, err := ParseQuery(query)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func parseClause(field, op, value string) (EventInfoMatcher, error) {
+	get, err := queryField(field)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches func(string, bool) bool
+	negate := false
+	switch op {
+	case "==", "!=":
+		matches = func(v string, found bool) bool { return found && v == value }
+		negate = op == "!="
+	case "=~", "!~":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %w", value, err)
+		}
+		matches = func(v string, found bool) bool { return found && re.MatchString(v) }
+		negate = op == "!~"
+	default:
+		return nil, fmt.Errorf("unknown operator %q, expected one of ==, !=, =~ or !~", op)
+	}
+
+	return func(info EventInfo) error {
+		v, found := get(info)
+		if matches(v, found) == negate {
+			return fmt.Errorf("%s %s %q does not hold, got %q", field, op, value, v)
+		}
+		return nil
+	}, nil
+}
+
+// queryField returns the accessor of the given query field. The accessor
+// returns false if the field is not set.
+func queryField(field string) (func(EventInfo) (string, bool), error) {
+	lower := strings.ToLower(field)
+	switch {
+	case lower == "kind":
+		return func(info EventInfo) (string, bool) { return string(info.Kind), true }, nil
+	case lower == "error":
+		return func(info EventInfo) (string, bool) { return info.Error, true }, nil
+	case strings.HasPrefix(lower, "header."):
+		name := field[len("header."):]
+		return func(info EventInfo) (string, bool) {
+			for k, v := range info.HTTPHeaders {
+				if strings.EqualFold(k, name) && len(v) > 0 {
+					return v[0], true
+				}
+			}
+			return "", false
+		}, nil
+	case strings.HasPrefix(lower, "ext."):
+		name := lower[len("ext."):]
+		return func(info EventInfo) (string, bool) {
+			if info.Event == nil {
+				return "", false
+			}
+			ext, ok := info.Event.Extensions()[name]
+			if !ok {
+				return "", false
+			}
+			return fmt.Sprint(ext), true
+		}, nil
+	}
+
+	var get func(EventInfo) string
+	switch lower {
+	case "id":
+		get = func(info EventInfo) string { return info.Event.ID() }
+	case "type":
+		get = func(info EventInfo) string { return info.Event.Type() }
+	case "source":
+		get = func(info EventInfo) string { return info.Event.Source() }
+	case "subject":
+		get = func(info EventInfo) string { return info.Event.Subject() }
+	case "datacontenttype":
+		get = func(info EventInfo) string { return info.Event.DataContentType() }
+	case "data":
+		get = func(info EventInfo) string { return string(info.Event.Data()) }
+	default:
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+	return func(info EventInfo) (string, bool) {
+		if info.Event == nil {
+			return "", false
+		}
+		return get(info), true
+	}, nil
+}
+
+// tokenizeQuery splits a query into fields, operators, values and "and"
+// keywords.
+func tokenizeQuery(query string) ([]string, error) {
+	var tokens []string
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value in query %q", query)
+			}
+			tokens = append(tokens, string(runes[i+1:end]))
+			i = end + 1
+		case strings.ContainsRune("=!", r):
+			if i+1 >= len(runes) || !strings.ContainsRune("=~", runes[i+1]) {
+				return nil, fmt.Errorf("invalid operator at offset %d in query %q", i, query)
+			}
+			tokens = append(tokens, string(runes[i:i+2]))
+			i += 2
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !isOperatorStart(runes, end) {
+				end++
+			}
+			tokens = append(tokens, string(runes[i:end]))
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func isOperatorStart(runes []rune, i int) bool {
+	return strings.ContainsRune("=!", runes[i]) && i+1 < len(runes) && strings.ContainsRune("=~", runes[i+1])
+}
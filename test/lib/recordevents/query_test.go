@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordevents
+
+import (
+	"encoding/json"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newTestEventInfo(id, eventType, source string, extensions map[string]string) EventInfo {
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	e.SetType(eventType)
+	e.SetSource(source)
+	for k, v := range extensions {
+		e.SetExtension(k, v)
+	}
+	_ = e.SetData(cloudevents.ApplicationJSON, map[string]string{"hello": "world"})
+	return EventInfo{
+		Kind:        EventReceived,
+		Event:       &e,
+		HTTPHeaders: map[string][]string{"Traceparent": {"00-abc"}},
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	info := newTestEventInfo("1", "dev.knative.test", "/apis/v1/namespaces/ns", map[string]string{"myext": "value one"})
+
+	tests := []struct {
+		name     string
+		query    string
+		matches  bool
+		parseErr bool
+	}{{
+		name:    "empty",
+		query:   "",
+		matches: true,
+	}, {
+		name:    "type equals",
+		query:   "type == dev.knative.test",
+		matches: true,
+	}, {
+		name:    "type not equals",
+		query:   "type != dev.knative.test",
+		matches: false,
+	}, {
+		name:    "no whitespace",
+		query:   "type==dev.knative.test",
+		matches: true,
+	}, {
+		name:    "source regexp and quoted extension",
+		query:   `source =~ '^/apis/v1/' and ext.myext == "value one"`,
+		matches: true,
+	}, {
+		name:    "missing extension",
+		query:   "ext.other == x",
+		matches: false,
+	}, {
+		name:    "missing extension negated",
+		query:   "ext.other != x",
+		matches: true,
+	}, {
+		name:    "regexp not matching",
+		query:   "id !~ '^[0-9]+$'",
+		matches: false,
+	}, {
+		name:    "kind and header",
+		query:   "kind == Received AND header.traceparent =~ ^00-",
+		matches: true,
+	}, {
+		name:    "data",
+		query:   `data =~ "world"`,
+		matches: true,
+	}, {
+		name:     "unknown field",
+		query:    "foo == bar",
+		parseErr: true,
+	}, {
+		name:     "unknown operator",
+		query:    "type = bar",
+		parseErr: true,
+	}, {
+		name:     "incomplete clause",
+		query:    "type == bar and source",
+		parseErr: true,
+	}, {
+		name:     "missing and",
+		query:    "type == bar source == baz",
+		parseErr: true,
+	}, {
+		name:     "unterminated quote",
+		query:    "type == 'bar",
+		parseErr: true,
+	}, {
+		name:     "invalid regexp",
+		query:    "type =~ '('",
+		parseErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseQuery(tt.query)
+			if tt.parseErr != (err != nil) {
+				t.Fatalf("ParseQuery() error = %v, want error %v", err, tt.parseErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := m(info); tt.matches != (err == nil) {
+				t.Errorf("matcher error = %v, want match %v", err, tt.matches)
+			}
+		})
+	}
+}
+
+func TestEventFilter(t *testing.T) {
+	info := newTestEventInfo("1", "dev.knative.test", "source", map[string]string{"myext": "value"})
+
+	tests := []struct {
+		name    string
+		filter  EventFilter
+		info    EventInfo
+		matches bool
+	}{{
+		name:    "empty filter",
+		info:    info,
+		matches: true,
+	}, {
+		name:    "matching type and source",
+		filter:  EventFilter{Types: []string{"other", "dev.knative.test"}, Sources: []string{"source"}},
+		info:    info,
+		matches: true,
+	}, {
+		name:    "not matching type",
+		filter:  EventFilter{Types: []string{"other"}},
+		info:    info,
+		matches: false,
+	}, {
+		name:    "matching extension",
+		filter:  EventFilter{Extensions: map[string]string{"myext": "value"}},
+		info:    info,
+		matches: true,
+	}, {
+		name:    "not matching extension",
+		filter:  EventFilter{Extensions: map[string]string{"myext": "other"}},
+		info:    info,
+		matches: false,
+	}, {
+		name:    "undecodable request",
+		filter:  EventFilter{Types: []string{"other"}},
+		info:    EventInfo{Error: "not a CloudEvent"},
+		matches: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.info); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	want := map[string]string{"a": "1", "b": "x=y"}
+	got, err := ParseExtensions(serializeExtensions(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) || got["a"] != "1" || got["b"] != "x=y" {
+		t.Errorf("ParseExtensions() = %v, want %v", got, want)
+	}
+	if _, err := ParseExtensions("novalue"); err == nil {
+		t.Error("ParseExtensions() expected an error for a pair without value")
+	}
+}
+
+func TestEventInfoStoreRetentionAndPagination(t *testing.T) {
+	store := &EventInfoStore{
+		tb:           t,
+		podName:      "recordevents",
+		podNamespace: "ns",
+		filter:       MustParseQuery("type == keep"),
+		maxEvents:    3,
+	}
+	for i, eventType := range []string{"keep", "drop", "keep", "keep", "keep", "keep"} {
+		info := newTestEventInfo(string(rune('a'+i)), eventType, "source", nil)
+		b, err := json.Marshal(info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store.handle(&corev1.Event{
+			Type:    corev1.EventTypeNormal,
+			Reason:  CloudEventObservedReason,
+			Message: string(b),
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      "recordevents",
+				Namespace: "ns",
+			},
+		})
+	}
+
+	if store.eventsFiltered != 1 || store.eventsEvicted != 2 {
+		t.Errorf("got %d events filtered and %d evicted, want 1 and 2", store.eventsFiltered, store.eventsEvicted)
+	}
+
+	page, total, err := store.FindPage(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 {
+		t.Errorf("FindPage() total = %d, want 3", total)
+	}
+	if len(page) != 1 || page[0].Event.ID() != "e" {
+		t.Errorf("FindPage() = %v, want the event with id e", page)
+	}
+
+	page, _, err = store.FindPage(5, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 0 {
+		t.Errorf("FindPage() past the end = %v, want no events", page)
+	}
+
+	if _, _, err := store.FindPage(-1, 1); err == nil {
+		t.Error("FindPage() expected an error for a negative offset")
+	}
+}
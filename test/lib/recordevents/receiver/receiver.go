@@ -18,6 +18,7 @@ package receiver
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"go.uber.org/zap"
 	"knative.dev/pkg/logging"
 
+	"knative.dev/eventing/pkg/auth"
 	"knative.dev/eventing/test/lib/recordevents"
 )
 
@@ -47,6 +49,20 @@ type Receiver struct {
 	dropSeq   uint64
 	replyFunc func(context.Context, http.ResponseWriter, recordevents.EventInfo)
 	counter   *dropevents.CounterHandler
+
+	// filter selects the events to vent.
+	filter recordevents.EventFilter
+	// maxRecorded is the maximum number of events to vent, 0 means unlimited.
+	maxRecorded uint64
+	recorded    uint64
+
+	// audience is the audience of the JWTs validated by the receiver.
+	audience      string
+	tokenVerifier *auth.OIDCTokenVerifier
+	// tlsCertFile and tlsKeyFile are the files of the certificate served
+	// on the TLS port, if set.
+	tlsCertFile string
+	tlsKeyFile  string
 }
 
 type envConfig struct {
@@ -75,6 +91,28 @@ type envConfig struct {
 	// If events should be dropped according to Linear policy, this controls
 	// how many events are dropped.
 	SkipCounter uint64 `envconfig:"SKIP_COUNTER" default:"0" required:"false"`
+
+	// If set, only events of these types are recorded.
+	FilterTypes []string `envconfig:"FILTER_TYPES" required:"false"`
+
+	// If set, only events from these sources are recorded.
+	FilterSources []string `envconfig:"FILTER_SOURCES" required:"false"`
+
+	// If set, only events with these extensions, formatted as comma separated
+	// key=value pairs, are recorded.
+	FilterExtensions string `envconfig:"FILTER_EXTENSIONS" default:"" required:"false"`
+
+	// If greater than 0, the receiver stops recording after this many events.
+	MaxRecordedEvents uint64 `envconfig:"MAX_RECORDED_EVENTS" default:"0" required:"false"`
+
+	// If set, the JWTs of the requests are validated for this audience and
+	// their identity is recorded.
+	OIDCAudience string `envconfig:"OIDC_AUDIENCE" default:"" required:"false"`
+
+	// If set, the receiver also serves the certificate in these files on
+	// the TLS port.
+	TLSCertFile string `envconfig:"TLS_CERT_FILE" default:"" required:"false"`
+	TLSKeyFile  string `envconfig:"TLS_KEY_FILE" default:"" required:"false"`
 }
 
 func NewFromEnv(ctx context.Context, eventLogs *recordevents.EventLogs) *Receiver {
@@ -106,12 +144,33 @@ func NewFromEnv(ctx context.Context, eventLogs *recordevents.EventLogs) *Receive
 		}
 	}
 
+	extensions, err := recordevents.ParseExtensions(env.FilterExtensions)
+	if err != nil {
+		logging.FromContext(ctx).Fatal("Failed to parse the extensions filter", err)
+	}
+
+	var tokenVerifier *auth.OIDCTokenVerifier
+	if env.OIDCAudience != "" {
+		logging.FromContext(ctx).Infof("Receiver will validate the JWTs for the audience %q", env.OIDCAudience)
+		tokenVerifier = auth.NewOIDCTokenVerifier(ctx)
+	}
+
 	return &Receiver{
 		Name:      env.ReceiverName,
 		EventLogs: eventLogs,
 		ctx:       ctx,
 		replyFunc: replyFunc,
 		counter:   counter,
+		filter: recordevents.EventFilter{
+			Types:      env.FilterTypes,
+			Sources:    env.FilterSources,
+			Extensions: extensions,
+		},
+		maxRecorded:   env.MaxRecordedEvents,
+		audience:      env.OIDCAudience,
+		tokenVerifier: tokenVerifier,
+		tlsCertFile:   env.TLSCertFile,
+		tlsKeyFile:    env.TLSKeyFile,
 	}
 }
 
@@ -136,14 +195,37 @@ func (o *Receiver) Start(ctx context.Context, handlerFuncs ...func(handler http.
 		err = server.ListenAndServe()
 	}()
 
+	var tlsServer *http.Server
+	var tlsErr error
+	if o.tlsCertFile != "" && o.tlsKeyFile != "" {
+		tlsServer = &http.Server{
+			Addr:    fmt.Sprintf(":%d", recordevents.EventRecordReceiveTLSPort),
+			Handler: mux,
+			// Ask for the client certificates to record them, without
+			// requiring them.
+			TLSConfig: &tls.Config{ClientAuth: tls.RequestClientCert},
+		}
+		go func() {
+			tlsErr = tlsServer.ListenAndServeTLS(o.tlsCertFile, o.tlsKeyFile)
+		}()
+	}
+
 	<-ctx.Done()
 
 	if err != nil {
 		return fmt.Errorf("error while starting the HTTP server: %w", err)
 	}
+	if tlsErr != nil {
+		return fmt.Errorf("error while starting the HTTPS server: %w", tlsErr)
+	}
 
 	logging.FromContext(ctx).Info("Closing the HTTP server")
 
+	if tlsServer != nil {
+		if err := tlsServer.Close(); err != nil {
+			return err
+		}
+	}
 	return server.Close()
 }
 
@@ -180,18 +262,22 @@ func (o *Receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 	}
 
 	eventInfo := recordevents.EventInfo{
-		Error:       eventErrStr,
-		Event:       event,
-		HTTPHeaders: headers,
-		Origin:      request.RemoteAddr,
-		Observer:    o.Name,
-		Time:        time.Now(),
-		Sequence:    s,
-		Kind:        kind,
+		Error:        eventErrStr,
+		Event:        event,
+		HTTPHeaders:  headers,
+		Origin:       request.RemoteAddr,
+		Observer:     o.Name,
+		Time:         time.Now(),
+		Sequence:     s,
+		Kind:         kind,
+		TLS:          recordevents.NewTLSInfo(request.TLS),
+		OIDCIdentity: o.oidcIdentity(request),
 	}
 
-	if err := o.EventLogs.Vent(eventInfo); err != nil {
-		logging.FromContext(o.ctx).Fatalw("Error while venting the recorded event", zap.Error(err))
+	if o.shouldRecord(eventInfo) {
+		if err := o.EventLogs.Vent(eventInfo); err != nil {
+			logging.FromContext(o.ctx).Fatalw("Error while venting the recorded event", zap.Error(err))
+		}
 	}
 
 	if shouldSkip {
@@ -201,3 +287,34 @@ func (o *Receiver) ServeHTTP(writer http.ResponseWriter, request *http.Request)
 		o.replyFunc(o.ctx, writer, eventInfo)
 	}
 }
+
+// shouldRecord returns true if the event passes the filter and the maximum
+// number of recorded events has not been reached yet.
+func (o *Receiver) shouldRecord(eventInfo recordevents.EventInfo) bool {
+	if !o.filter.Matches(eventInfo) {
+		return false
+	}
+	return o.maxRecorded == 0 || atomic.AddUint64(&o.recorded, 1) <= o.maxRecorded
+}
+
+// oidcIdentity returns the identity of the JWT of the request, or nil when
+// the receiver doesn't validate the JWTs or the request has no valid JWT.
+func (o *Receiver) oidcIdentity(request *http.Request) *recordevents.OIDCIdentity {
+	if o.tokenVerifier == nil {
+		return nil
+	}
+	jwt := auth.GetJWTFromHeader(request.Header)
+	if jwt == "" {
+		return nil
+	}
+	token, err := o.tokenVerifier.VerifyJWT(request.Context(), jwt, o.audience)
+	if err != nil {
+		logging.FromContext(o.ctx).Warnw("Failed to validate the JWT of the request", zap.Error(err))
+		return nil
+	}
+	return &recordevents.OIDCIdentity{
+		Subject:  token.Subject,
+		Issuer:   token.Issuer,
+		Audience: token.Audience,
+	}
+}
@@ -30,7 +30,10 @@ import (
 	testlib "knative.dev/eventing/test/lib"
 )
 
-const EventRecordReceivePort = 8080
+const (
+	EventRecordReceivePort    = 8080
+	EventRecordReceiveTLSPort = 8443
+)
 
 func noop(pod *corev1.Pod, client *testlib.Client) error {
 	return nil
@@ -111,6 +114,10 @@ func recordEventsPod(imageName string, name string, serviceAccountName string, r
 						Name:          "receive",
 						ContainerPort: EventRecordReceivePort,
 					},
+					{
+						Name:          "receive-tls",
+						ContainerPort: EventRecordReceiveTLSPort,
+					},
 				},
 			}},
 			ServiceAccountName: serviceAccountName,
@@ -18,7 +18,9 @@ package sender
 
 import (
 	"encoding/json"
+	"strconv"
 	"strings"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -86,6 +88,68 @@ func WithAdditionalHeaders(headers map[string]string) func(*corev1.Pod) {
 	}
 }
 
+// WithConcurrency sets the number of senders sending events concurrently from the sender pod. Supported only by event-sender
+// Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
+func WithConcurrency(concurrency int) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Args = append(
+			pod.Spec.Containers[0].Args,
+			"-concurrency",
+			strconv.Itoa(concurrency),
+		)
+	}
+}
+
+// WithRampUp starts the senders of the sender pod one after the other over the given duration. Supported only by event-sender
+// Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
+func WithRampUp(duration time.Duration) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Args = append(
+			pod.Spec.Containers[0].Args,
+			"-load-profile",
+			"ramp",
+			"-ramp-duration",
+			strconv.Itoa(int(duration.Seconds())),
+		)
+	}
+}
+
+// WithMixedEncoding alternates between the binary and structured encodings of the events sent from the sender pod. Supported only by event-sender
+// Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
+func WithMixedEncoding() func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Args = append(
+			pod.Spec.Containers[0].Args,
+			"-event-encoding",
+			"mixed",
+		)
+	}
+}
+
+// WithInvalidEventRate sends the given share, between 0 and 1, of the events from the sender pod as invalid events. Supported only by event-sender
+// Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
+func WithInvalidEventRate(rate float64) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Args = append(
+			pod.Spec.Containers[0].Args,
+			"-invalid-event-rate",
+			strconv.FormatFloat(rate, 'f', -1, 64),
+		)
+	}
+}
+
+// WithResultsInTerminationMessage writes the JSON results of the sender pod to its termination message. Supported only by event-sender
+// Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
+func WithResultsInTerminationMessage() func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.Containers[0].Args = append(
+			pod.Spec.Containers[0].Args,
+			"-result-file",
+			corev1.TerminationMessagePathDefault,
+		)
+	}
+}
+
 // EventSenderPod creates a Pod that sends events to the given address.
 // Deprecated: Now you should use recordevents.DeployEventSenderOrFail to send events
 func EventSenderPod(imageName string, name string, sink string, event cloudevents.Event, options ...func(*corev1.Pod)) (*corev1.Pod, error) {
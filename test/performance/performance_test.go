@@ -0,0 +1,197 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package performance
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	pkgtest "knative.dev/pkg/test"
+
+	"knative.dev/eventing/test"
+	testlib "knative.dev/eventing/test/lib"
+	"knative.dev/eventing/test/lib/recordevents"
+	"knative.dev/eventing/test/lib/resources"
+)
+
+var (
+	scenarioPath string
+	baselinePath string
+	resultsPath  string
+	brokerClass  string
+)
+
+func TestMain(m *testing.M) {
+	test.InitializeEventingFlags()
+	flag.StringVar(&scenarioPath, "scenario", "scenarios/broker-smoke.yaml", "Path of the scenario to run.")
+	flag.StringVar(&baselinePath, "baseline", "", "Path of the results to compare against, if any.")
+	flag.StringVar(&resultsPath, "results", "", "Path the results are written to. Defaults to $ARTIFACTS/<scenario>.json.")
+	flag.StringVar(&brokerClass, "brokerclass", "", "Overrides the broker class of Broker scenarios.")
+	flag.Parse()
+	os.Exit(m.Run())
+}
+
+// TestPerformance runs the scenario given with -scenario and fails if its
+// latency exceeds the scenario thresholds or regresses over -baseline.
+func TestPerformance(t *testing.T) {
+	s, err := LoadScenario(scenarioPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client := testlib.Setup(t, true)
+	defer testlib.TearDown(client)
+
+	target := setupTopology(ctx, t, client, s)
+	results := runSender(ctx, t, client, s, target)
+
+	path := resultsPath
+	if path == "" {
+		path = filepath.Join(os.Getenv("ARTIFACTS"), s.Name+".json")
+	}
+	if err := results.Write(path); err != nil {
+		t.Fatal("Failed to write results:", err)
+	}
+	t.Logf("Results written to %s", path)
+
+	var baseline *Results
+	if baselinePath != "" {
+		if baseline, err = LoadResults(baselinePath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range Compare(results, baseline, s.Thresholds) {
+		t.Error(v)
+	}
+}
+
+// setupTopology creates the scenario topology and returns the URL events are
+// sent to.
+func setupTopology(ctx context.Context, t *testing.T, client *testlib.Client, s *Scenario) string {
+	const (
+		sinkName   = "performance-sink"
+		targetName = "performance-target"
+	)
+	// The sink records a single event, as recording every event would load the API server.
+	recordevents.DeployEventRecordOrFail(ctx, client, sinkName, recordevents.MaxRecordedEvents(1))
+
+	var typeMeta *metav1.TypeMeta
+	switch s.Topology.Kind {
+	case BrokerTopology:
+		class := brokerClass
+		if class == "" {
+			class = s.Topology.BrokerClass
+		}
+		if class == "" {
+			class = test.BrokerClass
+		}
+		client.CreateBrokerOrFail(targetName, resources.WithBrokerClassForBroker(class))
+		for i := 0; i < s.Topology.Subscribers; i++ {
+			client.CreateTriggerOrFail(fmt.Sprintf("%s-%d", targetName, i),
+				resources.WithBroker(targetName),
+				resources.WithSubscriberServiceRefForTrigger(sinkName),
+			)
+		}
+		typeMeta = testlib.BrokerTypeMeta
+	case ChannelTopology:
+		channel := testlib.DefaultChannel
+		if s.Topology.Channel != "" {
+			apiVersion, kind, _ := strings.Cut(s.Topology.Channel, ":")
+			channel = metav1.TypeMeta{APIVersion: apiVersion, Kind: kind}
+		}
+		client.CreateChannelOrFail(targetName, &channel)
+		for i := 0; i < s.Topology.Subscribers; i++ {
+			client.CreateSubscriptionOrFail(fmt.Sprintf("%s-%d", targetName, i), targetName, &channel,
+				resources.WithSubscriberForSubscription(sinkName),
+			)
+		}
+		typeMeta = &channel
+	}
+
+	client.WaitForAllTestResourcesReadyOrFail(ctx)
+
+	target, err := client.GetAddressableURI(targetName, typeMeta)
+	if err != nil {
+		t.Fatal("Failed to get the target address:", err)
+	}
+	return target
+}
+
+// runSender runs the scenario from a pod and returns its results.
+func runSender(ctx context.Context, t *testing.T, client *testlib.Client, s *Scenario, target string) *Results {
+	const senderName = "performance-sender"
+
+	scenario, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.CreatePodOrFail(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: senderName},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:  senderName,
+				Image: pkgtest.ImagePath(senderName),
+				Env: []corev1.EnvVar{
+					{Name: "SCENARIO", Value: string(scenario)},
+					{Name: "TARGET", Value: target},
+				},
+			}},
+		},
+	})
+
+	var terminated *corev1.ContainerStateTerminated
+	err = wait.PollUntilContextTimeout(ctx, 5*time.Second, s.Duration()+5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		pod, err := client.Kube.CoreV1().Pods(client.Namespace).Get(ctx, senderName, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == senderName && cs.State.Terminated != nil {
+				terminated = cs.State.Terminated
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal("Sender did not complete:", err)
+	}
+	if terminated.ExitCode != 0 {
+		t.Fatalf("Sender failed with exit code %d: %s", terminated.ExitCode, terminated.Message)
+	}
+
+	results := &Results{}
+	if err := json.Unmarshal([]byte(terminated.Message), results); err != nil {
+		t.Fatalf("Failed to parse the sender results %q: %v", terminated.Message, err)
+	}
+	return results
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package performance
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseScenario(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		want    *Scenario
+		wantErr string
+	}{{
+		name: "defaults",
+		yaml: `
+name: minimal
+topology:
+  kind: Broker
+stages:
+- rps: 10
+  duration: 5s
+`,
+		want: &Scenario{
+			Name:         "minimal",
+			Topology:     Topology{Kind: BrokerTopology, Subscribers: 1},
+			Stages:       []Stage{{RPS: 10, Duration: metav1.Duration{Duration: 5 * time.Second}}},
+			PayloadSizes: []int{0},
+		},
+	}, {
+		name: "full",
+		yaml: `
+name: full
+topology:
+  kind: Channel
+  channel: messaging.knative.dev/v1:InMemoryChannel
+  subscribers: 3
+stages:
+- rps: 10
+  duration: 5s
+- rps: 100
+  duration: 1m
+payloadSizes: [0, 1024]
+thresholds:
+  p99: 250ms
+  maxErrorRate: 0.05
+  maxRegression: 0.1
+`,
+		want: &Scenario{
+			Name:     "full",
+			Topology: Topology{Kind: ChannelTopology, Channel: "messaging.knative.dev/v1:InMemoryChannel", Subscribers: 3},
+			Stages: []Stage{
+				{RPS: 10, Duration: metav1.Duration{Duration: 5 * time.Second}},
+				{RPS: 100, Duration: metav1.Duration{Duration: time.Minute}},
+			},
+			PayloadSizes: []int{0, 1024},
+			Thresholds: Thresholds{
+				P99:           metav1.Duration{Duration: 250 * time.Millisecond},
+				MaxErrorRate:  0.05,
+				MaxRegression: 0.1,
+			},
+		},
+	}, {
+		name:    "unknown field",
+		yaml:    "name: x\nrate: 10\n",
+		wantErr: "unknown field",
+	}, {
+		name: "invalid",
+		yaml: `
+topology:
+  kind: Pipe
+stages:
+- rps: 0
+  duration: 10ms
+payloadSizes: [-1]
+thresholds:
+  maxErrorRate: 2
+`,
+		wantErr: "name is required",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScenario([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseScenario() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Error("unexpected scenario (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestScenarioValidateReportsEveryError(t *testing.T) {
+	s := &Scenario{
+		Topology:     Topology{Kind: "Pipe"},
+		Stages:       []Stage{{RPS: 0, Duration: metav1.Duration{Duration: time.Millisecond}}},
+		PayloadSizes: []int{-1},
+		Thresholds:   Thresholds{MaxErrorRate: 2, MaxRegression: -1},
+	}
+	err := s.Validate()
+	for _, want := range []string{"name", "topology.kind", "stages[0].rps", "stages[0].duration", "payloadSizes[0]", "maxErrorRate", "maxRegression"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, want an error about %s", err, want)
+		}
+	}
+}
+
+func TestComputePercentiles(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	want := Percentiles{
+		P50: 50 * time.Millisecond,
+		P90: 90 * time.Millisecond,
+		P99: 99 * time.Millisecond,
+		Max: 100 * time.Millisecond,
+	}
+	if diff := cmp.Diff(want, ComputePercentiles(latencies)); diff != "" {
+		t.Error("unexpected percentiles (-want, +got):", diff)
+	}
+	if diff := cmp.Diff(Percentiles{}, ComputePercentiles(nil)); diff != "" {
+		t.Error("unexpected percentiles of no latencies (-want, +got):", diff)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	result := func(payload, sent, errors int, p99 time.Duration) Result {
+		return Result{
+			RPS:         10,
+			PayloadSize: payload,
+			Sent:        sent,
+			Errors:      errors,
+			Percentiles: Percentiles{P50: p99 / 2, P90: p99 / 2, P99: p99, Max: p99},
+		}
+	}
+	baseline := &Results{Results: []Result{
+		result(0, 100, 0, 100*time.Millisecond),
+		result(1024, 100, 0, 100*time.Millisecond),
+	}}
+	thresholds := Thresholds{
+		P99:           metav1.Duration{Duration: 200 * time.Millisecond},
+		MaxErrorRate:  0.05,
+		MaxRegression: 0.2,
+	}
+
+	tests := []struct {
+		name     string
+		current  *Results
+		baseline *Results
+		want     int
+	}{{
+		name:     "within thresholds",
+		current:  &Results{Results: []Result{result(0, 100, 1, 110*time.Millisecond)}},
+		baseline: baseline,
+	}, {
+		name:    "absolute threshold exceeded",
+		current: &Results{Results: []Result{result(0, 100, 0, 300*time.Millisecond)}},
+		want:    1,
+	}, {
+		name:    "error rate exceeded",
+		current: &Results{Results: []Result{result(0, 100, 10, 10*time.Millisecond)}},
+		want:    1,
+	}, {
+		name:     "regression over baseline",
+		current:  &Results{Results: []Result{result(1024, 100, 0, 150*time.Millisecond)}},
+		baseline: baseline,
+		want:     3,
+	}, {
+		name:     "no matching baseline",
+		current:  &Results{Results: []Result{result(4096, 100, 0, 150*time.Millisecond)}},
+		baseline: baseline,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.current, tt.baseline, thresholds)
+			if len(got) != tt.want {
+				t.Errorf("Compare() = %v, want %d violations", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	s := &Scenario{
+		Name:         "run",
+		Topology:     Topology{Kind: BrokerTopology},
+		Stages:       []Stage{{RPS: 20, Duration: metav1.Duration{Duration: time.Second}}},
+		PayloadSizes: []int{1, 8},
+	}
+
+	var calls int32
+	send := func(ctx context.Context, id string, payload []byte) error {
+		if atomic.AddInt32(&calls, 1)%5 == 0 {
+			return errors.New("rejected")
+		}
+		return nil
+	}
+
+	results, err := Run(context.Background(), s, send)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatalf("got %d results, want one per payload size", len(results.Results))
+	}
+	sent, failed := 0, 0
+	for _, r := range results.Results {
+		sent += r.Sent
+		failed += r.Errors
+	}
+	if sent != int(atomic.LoadInt32(&calls)) || sent < 10 {
+		t.Errorf("got %d sent events for %d calls", sent, calls)
+	}
+	if failed != sent/5 {
+		t.Errorf("got %d errors, want %d", failed, sent/5)
+	}
+}
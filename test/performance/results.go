@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package performance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Results are the latencies measured for a scenario.
+type Results struct {
+	// Scenario is the name of the scenario.
+	Scenario string `json:"scenario"`
+	// Results has one entry per stage and payload size.
+	Results []Result `json:"results"`
+}
+
+// Result is the latency measured for a payload size during a stage.
+type Result struct {
+	Stage       int `json:"stage"`
+	RPS         int `json:"rps"`
+	PayloadSize int `json:"payloadSize"`
+	Sent        int `json:"sent"`
+	Errors      int `json:"errors"`
+	Percentiles
+}
+
+// Percentiles of the latency of the successful sends.
+type Percentiles struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// ErrorRate returns the ratio of failed sends.
+func (r Result) ErrorRate() float64 {
+	if r.Sent == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Sent)
+}
+
+func (r Result) key() string {
+	return fmt.Sprintf("stage %d (%d rps), payload %d bytes", r.Stage, r.RPS, r.PayloadSize)
+}
+
+// ComputePercentiles returns the percentiles of the latencies, using the
+// nearest-rank method. The latencies are sorted in place.
+func ComputePercentiles(latencies []time.Duration) Percentiles {
+	if len(latencies) == 0 {
+		return Percentiles{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	rank := func(p int) time.Duration {
+		// ceil(p/100 * n) - 1
+		i := (p*len(latencies)+99)/100 - 1
+		return latencies[i]
+	}
+	return Percentiles{
+		P50: rank(50),
+		P90: rank(90),
+		P99: rank(99),
+		Max: latencies[len(latencies)-1],
+	}
+}
+
+// LoadResults reads results written by Write.
+func LoadResults(path string) (*Results, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results %s: %w", path, err)
+	}
+	r := &Results{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("invalid results %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Write writes the results as JSON to the given path.
+func (r *Results) Write(path string) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// Compare checks the results against the thresholds and, if not nil, against
+// the baseline results of the same scenario. It returns a description of
+// every violation, so an empty slice means the results are acceptable.
+func Compare(current, baseline *Results, th Thresholds) []string {
+	var violations []string
+
+	baselines := make(map[string]Result)
+	if baseline != nil {
+		for _, r := range baseline.Results {
+			baselines[r.key()] = r
+		}
+	}
+
+	for _, r := range current.Results {
+		check := func(name string, got, limit time.Duration) {
+			if limit > 0 && got > limit {
+				violations = append(violations, fmt.Sprintf("%s: %s latency %s exceeds threshold %s", r.key(), name, got, limit))
+			}
+		}
+		check("p50", r.P50, th.P50.Duration)
+		check("p90", r.P90, th.P90.Duration)
+		check("p99", r.P99, th.P99.Duration)
+
+		if rate := r.ErrorRate(); rate > th.MaxErrorRate {
+			violations = append(violations, fmt.Sprintf("%s: error rate %.4f exceeds threshold %.4f", r.key(), rate, th.MaxErrorRate))
+		}
+
+		base, ok := baselines[r.key()]
+		if !ok || th.MaxRegression == 0 {
+			continue
+		}
+		regressed := func(name string, got, was time.Duration) {
+			if was > 0 && float64(got) > float64(was)*(1+th.MaxRegression) {
+				violations = append(violations, fmt.Sprintf("%s: %s latency %s regressed by more than %.0f%% over baseline %s",
+					r.key(), name, got, th.MaxRegression*100, was))
+			}
+		}
+		regressed("p50", r.P50, base.P50)
+		regressed("p90", r.P90, base.P90)
+		regressed("p99", r.P99, base.P99)
+	}
+	return violations
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package performance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// EventType is the type of the events sent by Run.
+	EventType = "dev.knative.eventing.performance"
+	// EventSource is the source of the events sent by Run.
+	EventSource = "knative.dev/eventing/test/performance"
+)
+
+// SendFunc sends an event with the given id and payload, returning an error
+// if it was not accepted.
+type SendFunc func(ctx context.Context, id string, payload []byte) error
+
+// HTTPSender returns a SendFunc posting binary mode CloudEvents to target.
+func HTTPSender(client *http.Client, target string) SendFunc {
+	return func(ctx context.Context, id string, payload []byte) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Ce-Specversion", "1.0")
+		req.Header.Set("Ce-Id", id)
+		req.Header.Set("Ce-Type", EventType)
+		req.Header.Set("Ce-Source", EventSource)
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// Run runs every stage of the scenario, sending events with send, and
+// returns the latencies measured per stage and payload size.
+func Run(ctx context.Context, s *Scenario, send SendFunc) (*Results, error) {
+	payloads := make([][]byte, len(s.PayloadSizes))
+	for i, size := range s.PayloadSizes {
+		payloads[i] = bytes.Repeat([]byte{'x'}, size)
+	}
+
+	results := &Results{Scenario: s.Name}
+	id := 0
+	for i, stage := range s.Stages {
+		stageResults, err := runStage(ctx, i, stage, s.PayloadSizes, payloads, send, &id)
+		if err != nil {
+			return results, err
+		}
+		results.Results = append(results.Results, stageResults...)
+	}
+	return results, nil
+}
+
+func runStage(ctx context.Context, index int, stage Stage, sizes []int, payloads [][]byte, send SendFunc, id *int) ([]Result, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		latencies = make([][]time.Duration, len(payloads))
+		results   = make([]Result, len(payloads))
+	)
+	for i := range results {
+		results[i] = Result{Stage: index, RPS: stage.RPS, PayloadSize: sizes[i]}
+	}
+
+	ticker := time.NewTicker(time.Second / time.Duration(stage.RPS))
+	defer ticker.Stop()
+	deadline := time.After(stage.Duration.Duration)
+
+	n := 0
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-deadline:
+			break loop
+		case <-ticker.C:
+		}
+
+		p := n % len(payloads)
+		n++
+		*id++
+		eventID := strconv.Itoa(*id)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			err := send(ctx, eventID, payloads[p])
+			latency := time.Since(start)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[p].Sent++
+			if err != nil {
+				results[p].Errors++
+				return
+			}
+			latencies[p] = append(latencies[p], latency)
+		}()
+	}
+	wg.Wait()
+
+	for i := range results {
+		results[i].Percentiles = ComputePercentiles(latencies[i])
+	}
+	return results, ctx.Err()
+}
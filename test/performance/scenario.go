@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package performance contains a harness to measure the latency of the
+// eventing data plane for declarative scenarios, and to compare the results
+// against thresholds and a baseline so that CI can fail on regressions.
+package performance
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// BrokerTopology sends events to a Broker with a Trigger per subscriber.
+	BrokerTopology = "Broker"
+	// ChannelTopology sends events to a Channel with a Subscription per
+	// subscriber.
+	ChannelTopology = "Channel"
+)
+
+// Scenario declares the load sent to a topology and the latency it must be
+// served with.
+type Scenario struct {
+	// Name identifies the scenario in the results.
+	Name string `json:"name"`
+	// Topology is what the events are sent through.
+	Topology Topology `json:"topology"`
+	// Stages are run in order, each sending events at a constant rate.
+	// Consecutive stages with increasing rates make a ramp.
+	Stages []Stage `json:"stages"`
+	// PayloadSizes are the sizes in bytes of the event payloads. Each stage
+	// cycles through them, and results are reported per stage and size.
+	// Defaults to a single empty payload.
+	PayloadSizes []int `json:"payloadSizes,omitempty"`
+	// Thresholds fail the run when exceeded.
+	Thresholds Thresholds `json:"thresholds,omitempty"`
+}
+
+// Topology describes the resources created for a scenario.
+type Topology struct {
+	// Kind is either Broker or Channel.
+	Kind string `json:"kind"`
+	// BrokerClass is the class of the Broker. Defaults to the broker class
+	// the tests run against.
+	BrokerClass string `json:"brokerClass,omitempty"`
+	// Channel is the type of the Channel, as apiVersion:kind. Defaults to
+	// the channel type the tests run against.
+	Channel string `json:"channel,omitempty"`
+	// Subscribers is the number of Triggers or Subscriptions. Defaults to 1.
+	Subscribers int `json:"subscribers,omitempty"`
+}
+
+// Stage is a period of constant load.
+type Stage struct {
+	// RPS is the number of events sent per second.
+	RPS int `json:"rps"`
+	// Duration is how long the events are sent for.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// Thresholds are the limits a run must stay within. Zero values are not
+// enforced.
+type Thresholds struct {
+	P50 metav1.Duration `json:"p50,omitempty"`
+	P90 metav1.Duration `json:"p90,omitempty"`
+	P99 metav1.Duration `json:"p99,omitempty"`
+	// MaxErrorRate is the maximum ratio of failed sends, between 0 and 1.
+	MaxErrorRate float64 `json:"maxErrorRate,omitempty"`
+	// MaxRegression is the maximum ratio by which a percentile may exceed
+	// the baseline one, e.g. 0.1 allows the latency to grow by 10%.
+	MaxRegression float64 `json:"maxRegression,omitempty"`
+}
+
+// LoadScenario reads a scenario from a YAML or JSON file.
+func LoadScenario(path string) (*Scenario, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario %s: %w", path, err)
+	}
+	s, err := ParseScenario(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseScenario parses, defaults and validates a YAML or JSON scenario.
+func ParseScenario(b []byte) (*Scenario, error) {
+	s := &Scenario{}
+	if err := yaml.UnmarshalStrict(b, s); err != nil {
+		return nil, err
+	}
+	s.SetDefaults()
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetDefaults fills in the optional fields.
+func (s *Scenario) SetDefaults() {
+	if len(s.PayloadSizes) == 0 {
+		s.PayloadSizes = []int{0}
+	}
+	if s.Topology.Subscribers == 0 {
+		s.Topology.Subscribers = 1
+	}
+}
+
+// Validate returns an error describing every invalid field.
+func (s *Scenario) Validate() error {
+	var errs []error
+	if s.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	switch s.Topology.Kind {
+	case BrokerTopology:
+	case ChannelTopology:
+		if s.Topology.Channel != "" && !strings.Contains(s.Topology.Channel, ":") {
+			errs = append(errs, fmt.Errorf("topology.channel %q must be formatted as apiVersion:kind", s.Topology.Channel))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("topology.kind must be %s or %s, got %q", BrokerTopology, ChannelTopology, s.Topology.Kind))
+	}
+	if s.Topology.Subscribers < 0 {
+		errs = append(errs, fmt.Errorf("topology.subscribers must not be negative, got %d", s.Topology.Subscribers))
+	}
+	if len(s.Stages) == 0 {
+		errs = append(errs, errors.New("at least one stage is required"))
+	}
+	for i, stage := range s.Stages {
+		if stage.RPS <= 0 {
+			errs = append(errs, fmt.Errorf("stages[%d].rps must be positive, got %d", i, stage.RPS))
+		}
+		if stage.Duration.Duration < time.Second {
+			errs = append(errs, fmt.Errorf("stages[%d].duration must be at least 1s, got %s", i, stage.Duration.Duration))
+		}
+	}
+	for i, size := range s.PayloadSizes {
+		if size < 0 {
+			errs = append(errs, fmt.Errorf("payloadSizes[%d] must not be negative, got %d", i, size))
+		}
+	}
+	if r := s.Thresholds.MaxErrorRate; r < 0 || r > 1 {
+		errs = append(errs, fmt.Errorf("thresholds.maxErrorRate must be between 0 and 1, got %v", r))
+	}
+	if s.Thresholds.MaxRegression < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.maxRegression must not be negative, got %v", s.Thresholds.MaxRegression))
+	}
+	return errors.Join(errs...)
+}
+
+// Duration returns how long the scenario runs for.
+func (s *Scenario) Duration() time.Duration {
+	var d time.Duration
+	for _, stage := range s.Stages {
+		d += stage.Duration.Duration
+	}
+	return d
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package rekt
 
 import (
+	"flag"
 	"testing"
 	"time"
 
@@ -34,10 +35,13 @@ import (
 	"knative.dev/reconciler-test/pkg/tracing"
 
 	"knative.dev/eventing/test/rekt/features/broker"
+	"knative.dev/eventing/test/rekt/features/eventpolicy"
 	"knative.dev/eventing/test/rekt/features/oidc"
 	brokerresources "knative.dev/eventing/test/rekt/resources/broker"
 )
 
+var dualStack = flag.Bool("dual-stack", false, "Run the tests requiring an IPv6 or dual-stack cluster.")
+
 func TestBrokerWithManyTriggers(t *testing.T) {
 	t.Parallel()
 
@@ -190,6 +194,23 @@ func TestBrokerRedelivery(t *testing.T) {
 	env.TestSet(ctx, t, broker.BrokerRedelivery())
 }
 
+// TestBrokerTriggerDeliveryTimeout tests that the Trigger delivery timeout is
+// enforced per attempt and that timed out requests are retried.
+func TestBrokerTriggerDeliveryTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+		environment.WithPollTimings(5*time.Second, 4*time.Minute),
+	)
+
+	env.TestSet(ctx, t, broker.TriggerDeliveryTimeout())
+}
+
 func TestBrokerDeadLetterSinkExtensions(t *testing.T) {
 	t.Parallel()
 
@@ -270,6 +291,25 @@ func TestBrokerSupportsOIDC(t *testing.T) {
 	env.TestSet(ctx, t, oidc.AddressableOIDCConformance(brokerresources.GVR(), "Broker", name, env.Namespace()))
 }
 
+func TestBrokerSupportsAuthZ(t *testing.T) {
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+		environment.WithPollTimings(4*time.Second, 12*time.Minute),
+		eventshub.WithTLS(t),
+	)
+
+	name := feature.MakeRandomK8sName("broker")
+	env.Prerequisite(ctx, t, broker.GoesReady(name, brokerresources.WithEnvConfig()...))
+
+	env.TestSet(ctx, t, eventpolicy.AddressableAuthZConformance(brokerresources.GVR(), "Broker", name))
+}
+
 func TestBrokerSendsEventsWithOIDCSupport(t *testing.T) {
 	t.Parallel()
 
@@ -284,3 +324,40 @@ func TestBrokerSendsEventsWithOIDCSupport(t *testing.T) {
 
 	env.TestSet(ctx, t, broker.BrokerSendEventWithOIDC())
 }
+
+// TestBrokerIPv6Sink tests delivery to a subscriber addressed by an IPv6
+// literal. It requires an IPv6 or dual-stack cluster and the -dual-stack flag.
+func TestBrokerIPv6Sink(t *testing.T) {
+	if !*dualStack {
+		t.Skip("Requires an IPv6 or dual-stack cluster, enable with -dual-stack")
+	}
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+		environment.WithPollTimings(5*time.Second, 4*time.Minute),
+	)
+
+	env.Test(ctx, t, broker.SourceToIPv6Sink())
+}
+
+// TestBrokerTriggerMetrics tests that the events sent through a Trigger are
+// recorded in the Broker ingress and filter metrics.
+func TestBrokerTriggerMetrics(t *testing.T) {
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+		environment.WithPollTimings(5*time.Second, 4*time.Minute),
+	)
+
+	env.Test(ctx, t, broker.TriggerMetrics())
+}
@@ -118,3 +118,18 @@ func TestContainerSourceSendsEventsWithOIDC(t *testing.T) {
 
 	env.Test(ctx, t, containersource.SendsEventsWithSinkRefOIDC())
 }
+
+func TestContainerSourceConformance(t *testing.T) {
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+		eventshub.WithTLS(t),
+	)
+
+	env.TestSet(ctx, t, containersource.Conformance())
+}
@@ -17,9 +17,19 @@ limitations under the License.
 package broker
 
 import (
+	"time"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+	"github.com/google/uuid"
+	"knative.dev/pkg/ptr"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
 	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/resources/service"
 
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/trigger"
 )
 
 func DefaultDeliverySpec() *feature.Feature {
@@ -41,3 +51,109 @@ func DefaultDeliverySpec() *feature.Feature {
 
 	return f
 }
+
+// TriggerDeliveryTimeout tests that the per-request timeout of a Trigger's
+// delivery spec is honored, and that a timed out request is retried before
+// being sent to the dead letter sink.
+func TriggerDeliveryTimeout() *feature.FeatureSet {
+	return &feature.FeatureSet{
+		Name: "Knative Broker - Trigger delivery timeout",
+		Features: []*feature.Feature{
+			triggerDeliveryTimeoutThenRetry(2),
+			triggerDeliveryWithinTimeout(),
+		},
+	}
+}
+
+func triggerDeliveryTimeoutThenRetry(retryNum int32) *feature.Feature {
+	f := feature.NewFeatureNamed("Trigger delivery times out and is retried before reaching the dead letter sink")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	sink := feature.MakeRandomK8sName("sink")
+	deadLetterSink := feature.MakeRandomK8sName("dls")
+	source := feature.MakeRandomK8sName("source")
+
+	event := cetest.FullEvent()
+	event.SetID(uuid.New().String())
+
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("broker is addressable", broker.IsAddressable(brokerName))
+
+	// The sink answers well after the timeout of the Trigger.
+	f.Setup("install sink", eventshub.Install(sink,
+		eventshub.StartReceiver,
+		eventshub.ResponseWaitTime(10*time.Second),
+	))
+	f.Setup("install dead letter sink", eventshub.Install(deadLetterSink, eventshub.StartReceiver))
+
+	linear := eventingduckv1.BackoffPolicyLinear
+	f.Setup("install trigger", trigger.Install(triggerName, brokerName,
+		trigger.WithSubscriber(service.AsKReference(sink), ""),
+		trigger.WithDeadLetterSink(service.AsKReference(deadLetterSink), ""),
+		trigger.WithRetry(retryNum, &linear, ptr.String("PT0.5S")),
+		trigger.WithTimeout("PT2S"),
+	))
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+
+	f.Requirement("install source", eventshub.Install(source,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(event),
+	))
+
+	f.Assert("sink receives every attempt", assert.OnStore(sink).
+		MatchReceivedEvent(cetest.HasId(event.ID())).
+		Exact(int(retryNum)+1))
+	f.Assert("dead letter sink receives the event", assert.OnStore(deadLetterSink).
+		MatchReceivedEvent(cetest.HasId(event.ID())).
+		Exact(1))
+
+	return f
+}
+
+func triggerDeliveryWithinTimeout() *feature.Feature {
+	f := feature.NewFeatureNamed("Trigger delivery answered within the timeout is not retried")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	sink := feature.MakeRandomK8sName("sink")
+	deadLetterSink := feature.MakeRandomK8sName("dls")
+	source := feature.MakeRandomK8sName("source")
+
+	event := cetest.FullEvent()
+	event.SetID(uuid.New().String())
+
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("broker is addressable", broker.IsAddressable(brokerName))
+
+	f.Setup("install sink", eventshub.Install(sink,
+		eventshub.StartReceiver,
+		eventshub.ResponseWaitTime(time.Second),
+	))
+	f.Setup("install dead letter sink", eventshub.Install(deadLetterSink, eventshub.StartReceiver))
+
+	linear := eventingduckv1.BackoffPolicyLinear
+	f.Setup("install trigger", trigger.Install(triggerName, brokerName,
+		trigger.WithSubscriber(service.AsKReference(sink), ""),
+		trigger.WithDeadLetterSink(service.AsKReference(deadLetterSink), ""),
+		trigger.WithRetry(2, &linear, ptr.String("PT0.5S")),
+		trigger.WithTimeout("PT10S"),
+	))
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+
+	f.Requirement("install source", eventshub.Install(source,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(event),
+	))
+
+	f.Assert("sink receives the event once", assert.OnStore(sink).
+		MatchReceivedEvent(cetest.HasId(event.ID())).
+		Exact(1))
+	f.Assert("dead letter sink receives nothing", assert.OnStore(deadLetterSink).
+		MatchReceivedEvent(cetest.HasId(event.ID())).
+		Not())
+
+	return f
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/cloudevents/sdk-go/v2/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+
+	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/trigger"
+)
+
+// SourceToIPv6Sink tests that a Broker delivers events to a subscriber
+// addressed by an IPv6 literal, which requires an IPv6 or dual-stack cluster.
+//
+// source ---> broker --[trigger]--> http://[sink pod IPv6]:8080
+func SourceToIPv6Sink() *feature.Feature {
+	f := feature.NewFeatureNamed("Source to IPv6 sink")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	source := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+
+	event := test.FullEvent()
+
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install trigger to the sink IPv6 address", func(ctx context.Context, t feature.T) {
+		ip, err := podIPv6(ctx, "app=eventshub-"+sink)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uri := "http://" + net.JoinHostPort(ip, "8080")
+		trigger.Install(triggerName, brokerName, trigger.WithSubscriber(nil, uri))(ctx, t)
+	})
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+
+	f.Requirement("install source", eventshub.Install(source,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(event),
+	))
+
+	f.Assert("event delivered to the IPv6 sink",
+		assert.OnStore(sink).MatchEvent(test.HasId(event.ID())).Exact(1))
+
+	return f
+}
+
+// podIPv6 returns the IPv6 address of the first pod matching the selector in
+// the test namespace, waiting for it to be assigned.
+func podIPv6(ctx context.Context, selector string) (string, error) {
+	namespace := environment.FromContext(ctx).Namespace()
+	interval, timeout := environment.PollTimingsFromContext(ctx)
+
+	var ip string
+	err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := kubeclient.Get(ctx).CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return false, err
+		}
+		for _, p := range pods.Items {
+			for _, podIP := range p.Status.PodIPs {
+				if parsed := net.ParseIP(podIP.IP); parsed != nil && parsed.To4() == nil {
+					ip = podIP.IP
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("no pod matching %q in namespace %s has an IPv6 address: %w", selector, namespace, err)
+	}
+	return ip, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package broker
+
+import (
+	"context"
+
+	"github.com/cloudevents/sdk-go/v2/test"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/resources/service"
+
+	"knative.dev/eventing/test/rekt/features/metrics"
+	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/trigger"
+)
+
+// TriggerMetrics tests that the Broker ingress and filter record the events
+// sent through a Trigger in their metrics.
+//
+// source ---> broker --[trigger]--> sink
+func TriggerMetrics() *feature.Feature {
+	f := feature.NewFeatureNamed("Trigger metrics")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	source := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+
+	event := test.FullEvent()
+
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install trigger", trigger.Install(triggerName, brokerName, trigger.WithSubscriber(service.AsKReference(sink), "")))
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+
+	f.Setup("snapshot broker ingress metrics", metrics.Snapshot("ingress", metrics.BrokerIngress))
+	f.Setup("snapshot broker filter metrics", metrics.Snapshot("filter", metrics.BrokerFilter))
+
+	f.Requirement("install source", eventshub.Install(source,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(event),
+	))
+
+	f.Assert("event delivered to the sink",
+		assert.OnStore(sink).MatchEvent(test.HasId(event.ID())).Exact(1))
+	f.Assert("broker ingress event_count incremented", func(ctx context.Context, t feature.T) {
+		labels := map[string]string{
+			"namespace_name": environment.FromContext(ctx).Namespace(),
+			"broker_name":    brokerName,
+		}
+		metrics.CounterIncreased("ingress", metrics.BrokerIngress, "mt_broker_ingress_event_count", labels, 1)(ctx, t)
+	})
+	f.Assert("broker filter event_count incremented", func(ctx context.Context, t feature.T) {
+		labels := map[string]string{
+			"namespace_name": environment.FromContext(ctx).Namespace(),
+			"trigger_name":   triggerName,
+		}
+		metrics.CounterIncreased("filter", metrics.BrokerFilter, "mt_broker_filter_event_count", labels, 1)(ctx, t)
+		metrics.HistogramObserved("filter", metrics.BrokerFilter, "mt_broker_filter_event_dispatch_latencies", labels, 1)(ctx, t)
+	})
+
+	return f
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/test"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/system"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	eventassert "knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/resources/service"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/delivery"
+	"knative.dev/eventing/test/rekt/resources/trigger"
+)
+
+// BrokerNoEventLossUnderChaos sends events through a Broker while its filter
+// pods are killed and the subscriber fails with 503, and asserts that the
+// Trigger's DeliverySpec lets every accepted event reach the subscriber.
+//
+// The pods it kills are shared by all the Brokers of the cluster, so it must
+// not run in parallel with other tests.
+func BrokerNoEventLossUnderChaos() *feature.Feature {
+	f := feature.NewFeatureNamed("Broker delivers every accepted event under chaos")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	source := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	killer := feature.MakeRandomK8sName("killer")
+
+	const (
+		events       = 20
+		sinkFailures = 3
+	)
+	spec := &eventingduckv1.DeliverySpec{
+		Retry:         ptr.To[int32](10),
+		BackoffPolicy: ptr.To(eventingduckv1.BackoffPolicyExponential),
+		BackoffDelay:  ptr.To("PT0.5S"),
+	}
+
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("install faulty sink", eventshub.Install(sink,
+		eventshub.StartReceiver,
+		FaultySink(sinkFailures, http.StatusServiceUnavailable),
+	))
+	f.Setup("install trigger", trigger.Install(triggerName, brokerName,
+		trigger.WithSubscriber(service.AsKReference(sink), ""),
+		delivery.WithRetry(*spec.Retry, spec.BackoffPolicy, spec.BackoffDelay),
+	))
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+
+	f.Requirement("start killing broker filter pods", StartPodKiller(killer, system.Namespace(), BrokerFilterSelector, 5*time.Second))
+	f.Requirement("install source", eventshub.Install(source,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(test.FullEvent()),
+		eventshub.EnableIncrementalId,
+		eventshub.SendMultipleEvents(events, time.Second),
+	))
+	f.Requirement("all events sent", eventassert.OnStore(source).Match(eventassert.MatchKind(eventshub.EventResponse)).AtLeast(events))
+	f.Requirement("stop killing broker filter pods", Stop(killer))
+
+	f.Stable("broker").Must("deliver every accepted event", NoEventLoss(source, sink, spec, sinkFailures))
+
+	return f
+}
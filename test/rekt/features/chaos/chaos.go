@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/feature"
+)
+
+const (
+	// BrokerIngressSelector selects the pods of the multi tenant channel based
+	// broker ingress.
+	BrokerIngressSelector = "eventing.knative.dev/brokerRole=ingress"
+	// BrokerFilterSelector selects the pods of the multi tenant channel based
+	// broker filter.
+	BrokerFilterSelector = "eventing.knative.dev/brokerRole=filter"
+	// IMCDispatcherSelector selects the pods of the in-memory channel
+	// dispatcher.
+	IMCDispatcherSelector = "messaging.knative.dev/role=dispatcher"
+)
+
+// running holds the stop functions of the running faults, keyed by the
+// environment namespace and the fault name.
+var running sync.Map
+
+func faultKey(ctx context.Context, name string) string {
+	return environment.FromContext(ctx).Namespace() + "/" + name
+}
+
+// start registers the stop function of a running fault.
+func start(ctx context.Context, t feature.T, name string, stop func(context.Context) error) {
+	if _, loaded := running.LoadOrStore(faultKey(ctx, name), stop); loaded {
+		if err := stop(ctx); err != nil {
+			t.Log("failed to stop duplicate fault", name, err)
+		}
+		t.Fatalf("fault %q is already running", name)
+	}
+}
+
+// Stop stops the fault with the given name, started by one of the Start
+// functions of this package.
+func Stop(name string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		stop, ok := running.LoadAndDelete(faultKey(ctx, name))
+		if !ok {
+			t.Fatalf("fault %q is not running", name)
+		}
+		if err := stop.(func(context.Context) error)(ctx); err != nil {
+			t.Fatal(fmt.Errorf("failed to stop fault %q: %w", name, err))
+		}
+	}
+}
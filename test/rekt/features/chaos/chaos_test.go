@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+	"knative.dev/reconciler-test/pkg/eventshub"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+func TestToleratedConsecutiveFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		delivery *eventingduckv1.DeliverySpec
+		want     uint
+	}{{
+		name: "no delivery",
+		want: 0,
+	}, {
+		name:     "no retry",
+		delivery: &eventingduckv1.DeliverySpec{},
+		want:     0,
+	}, {
+		name:     "retry",
+		delivery: &eventingduckv1.DeliverySpec{Retry: ptr.To[int32](3)},
+		want:     3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToleratedConsecutiveFailures(tt.delivery); got != tt.want {
+				t.Errorf("ToleratedConsecutiveFailures() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingEventIDs(t *testing.T) {
+	sent := []eventshub.EventInfo{
+		{Kind: eventshub.EventSent, SentId: "1"},
+		{Kind: eventshub.EventResponse, SentId: "1", StatusCode: 202},
+		{Kind: eventshub.EventSent, SentId: "2"},
+		{Kind: eventshub.EventResponse, SentId: "2", StatusCode: 503},
+		{Kind: eventshub.EventSent, SentId: "3"},
+		{Kind: eventshub.EventResponse, SentId: "3", StatusCode: 200},
+	}
+	accepted := AcceptedEventIDs(sent)
+	if diff := cmp.Diff([]string{"1", "3"}, accepted); diff != "" {
+		t.Error("unexpected accepted event IDs (-want, +got):", diff)
+	}
+
+	received := []eventshub.EventInfo{
+		receivedEvent(eventshub.EventRejected, "3"),
+		receivedEvent(eventshub.EventReceived, "1"),
+		receivedEvent(eventshub.EventReceived, "2"),
+	}
+	if diff := cmp.Diff([]string{"3"}, MissingEventIDs(accepted, received)); diff != "" {
+		t.Error("unexpected missing event IDs (-want, +got):", diff)
+	}
+
+	received = append(received, receivedEvent(eventshub.EventReceived, "3"))
+	if diff := cmp.Diff([]string{}, MissingEventIDs(accepted, received)); diff != "" {
+		t.Error("unexpected missing event IDs (-want, +got):", diff)
+	}
+}
+
+func receivedEvent(kind eventshub.EventKind, id string) eventshub.EventInfo {
+	e := cloudevents.NewEvent()
+	e.SetID(id)
+	return eventshub.EventInfo{Kind: kind, Event: &e}
+}
+
+func TestNetworkFaultNetemArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault NetworkFault
+		want  []string
+	}{{
+		name:  "latency",
+		fault: NetworkFault{Latency: 100 * time.Millisecond},
+		want:  []string{"delay", "100ms"},
+	}, {
+		name:  "latency with jitter and loss",
+		fault: NetworkFault{Latency: time.Second, Jitter: 50 * time.Millisecond, LossPercent: 2.5},
+		want:  []string{"delay", "1000ms", "50ms", "loss", "2.5%"},
+	}, {
+		name:  "jitter without latency",
+		fault: NetworkFault{Jitter: 50 * time.Millisecond},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.fault.netemArgs()); diff != "" {
+				t.Error("unexpected netem args (-want, +got):", diff)
+			}
+		})
+	}
+}
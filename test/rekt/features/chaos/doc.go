@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos contains helpers to inject faults into the data plane while
+// a feature asserts on event delivery, and to verify that no event is lost
+// given the DeliverySpec in use.
+//
+// Faults that run for a while, like PodKiller, are started by one step and
+// stopped by another, so a feature usually looks like:
+//
+//	f.Setup("start killing ingress pods", chaos.StartPodKiller("killer", system.Namespace(), chaos.BrokerIngressSelector, 10*time.Second))
+//	f.Requirement("send events", ...)
+//	f.Assert("no event loss", chaos.NoEventLoss(source, sink, delivery, 1))
+//	f.Teardown("stop killing ingress pods", chaos.Stop("killer"))
+package chaos
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/feature"
+)
+
+const (
+	// netemImageEnv is the environment variable overriding the image used to
+	// configure the network of the targeted pods. It must provide tc.
+	netemImageEnv     = "CHAOS_NETEM_IMAGE"
+	defaultNetemImage = "docker.io/nicolaka/netshoot:latest"
+)
+
+// NetworkFault describes the degradation applied to the egress traffic of a
+// pod.
+type NetworkFault struct {
+	// Latency is the delay added to every packet.
+	Latency time.Duration
+	// Jitter is the random variation of Latency.
+	Jitter time.Duration
+	// LossPercent is the percentage of dropped packets, between 0 and 100.
+	LossPercent float64
+}
+
+// netemArgs returns the tc netem arguments of the fault.
+func (f NetworkFault) netemArgs() []string {
+	var args []string
+	if f.Latency > 0 {
+		args = append(args, "delay", formatMillis(f.Latency))
+		if f.Jitter > 0 {
+			args = append(args, formatMillis(f.Jitter))
+		}
+	}
+	if f.LossPercent > 0 {
+		args = append(args, "loss", strconv.FormatFloat(f.LossPercent, 'f', -1, 64)+"%")
+	}
+	return args
+}
+
+func formatMillis(d time.Duration) string {
+	return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+}
+
+// StartNetworkFault degrades the network of all the pods matching the label
+// selector in the given namespace until the fault is stopped with Stop.
+//
+// The fault is applied with tc netem from an ephemeral container, which needs
+// the NET_ADMIN capability, so it cannot run in clusters enforcing the
+// restricted pod security standard. Pods created after the fault started, for
+// example because of a PodKiller, are not degraded.
+func StartNetworkFault(name, namespace, selector string, fault NetworkFault) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		args := fault.netemArgs()
+		if len(args) == 0 {
+			t.Fatal("network fault has neither latency nor loss")
+		}
+
+		pods, err := listPods(ctx, namespace, selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pods) == 0 {
+			t.Fatalf("no pods matching %q in namespace %s", selector, namespace)
+		}
+
+		var degraded []string
+		for _, p := range pods {
+			command := append([]string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem"}, args...)
+			if err := runNetworkCommand(ctx, namespace, p.Name, name+"-start", command); err != nil {
+				t.Fatal(err)
+			}
+			degraded = append(degraded, p.Name)
+		}
+
+		start(ctx, t, name, func(ctx context.Context) error {
+			var errs []string
+			for _, pod := range degraded {
+				err := runNetworkCommand(ctx, namespace, pod, name+"-stop", []string{"tc", "qdisc", "del", "dev", "eth0", "root"})
+				if err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+			if len(errs) > 0 {
+				return fmt.Errorf("failed to restore the network: %s", strings.Join(errs, "; "))
+			}
+			return nil
+		})
+	}
+}
+
+// runNetworkCommand runs the command in an ephemeral container sharing the
+// network namespace of the pod and waits for it to succeed. Pods that are
+// gone in the meantime are ignored.
+func runNetworkCommand(ctx context.Context, namespace, podName, containerName string, command []string) error {
+	image := os.Getenv(netemImageEnv)
+	if image == "" {
+		image = defaultNetemImage
+	}
+
+	pods := kubeclient.Get(ctx).CoreV1().Pods(namespace)
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+	}
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:    containerName,
+			Image:   image,
+			Command: command,
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{
+					Add: []corev1.Capability{"NET_ADMIN"},
+				},
+			},
+		},
+	})
+	_, err = pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add ephemeral container %s to pod %s/%s: %w", containerName, namespace, podName, err)
+	}
+
+	interval, timeout := environment.PollTimingsFromContext(ctx)
+	var last *corev1.ContainerStateTerminated
+	err = wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, nil
+		}
+		for _, s := range pod.Status.EphemeralContainerStatuses {
+			if s.Name == containerName && s.State.Terminated != nil {
+				last = s.State.Terminated
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("ephemeral container %s in pod %s/%s did not complete: %w", containerName, namespace, podName, err)
+	}
+	if last != nil && last.ExitCode != 0 {
+		return fmt.Errorf("ephemeral container %s in pod %s/%s failed with exit code %d: %s",
+			containerName, namespace, podName, last.ExitCode, last.Message)
+	}
+	return nil
+}
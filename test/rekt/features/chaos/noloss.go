@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/feature"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+)
+
+// FaultySink returns the options making an eventshub receiver reply with the
+// given status code to its first failures requests, to simulate a subscriber
+// failing with 5xx errors.
+func FaultySink(failures uint, statusCode int) eventshub.EventsHubOption {
+	return func(ctx context.Context, env map[string]string) error {
+		if statusCode < http.StatusInternalServerError {
+			return fmt.Errorf("expected a 5xx status code, got %d", statusCode)
+		}
+		if err := eventshub.DropFirstN(failures)(ctx, env); err != nil {
+			return err
+		}
+		return eventshub.DropEventsResponseCode(statusCode)(ctx, env)
+	}
+}
+
+// ToleratedConsecutiveFailures returns how many consecutive failed attempts to
+// deliver an event the DeliverySpec tolerates before giving up on it.
+func ToleratedConsecutiveFailures(delivery *eventingduckv1.DeliverySpec) uint {
+	if delivery == nil || delivery.Retry == nil || *delivery.Retry < 0 {
+		return 0
+	}
+	return uint(*delivery.Retry)
+}
+
+// NoEventLoss asserts that every event the sender got a 2xx response for is
+// eventually received by the sink. It fails right away if the DeliverySpec
+// does not retry enough to survive consecutiveFailures failed attempts, as
+// event loss would then be expected.
+func NoEventLoss(sender, sink string, delivery *eventingduckv1.DeliverySpec, consecutiveFailures uint) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		if tolerated := ToleratedConsecutiveFailures(delivery); tolerated < consecutiveFailures {
+			t.Fatalf("the DeliverySpec tolerates %d consecutive failures, less than the %d injected", tolerated, consecutiveFailures)
+		}
+
+		accepted := AcceptedEventIDs(eventshub.StoreFromContext(ctx, sender).Collected())
+		if len(accepted) == 0 {
+			t.Fatalf("no event sent by %s was accepted", sender)
+		}
+
+		var missing []string
+		interval, timeout := environment.PollTimingsFromContext(ctx)
+		err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			missing = MissingEventIDs(accepted, eventshub.StoreFromContext(ctx, sink).Collected())
+			return len(missing) == 0, nil
+		})
+		if err != nil {
+			t.Fatalf("%d of the %d accepted events were lost: %v", len(missing), len(accepted), missing)
+		}
+	}
+}
+
+// AcceptedEventIDs returns the sorted IDs of the events a sender got a 2xx
+// response for.
+func AcceptedEventIDs(infos []eventshub.EventInfo) []string {
+	ids := make(map[string]struct{})
+	for _, info := range infos {
+		if info.Kind == eventshub.EventResponse && info.StatusCode >= 200 && info.StatusCode < 300 {
+			ids[info.SentId] = struct{}{}
+		}
+	}
+	return sortedKeys(ids)
+}
+
+// MissingEventIDs returns the sorted IDs among ids of the events that were
+// not received.
+func MissingEventIDs(ids []string, received []eventshub.EventInfo) []string {
+	missing := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		missing[id] = struct{}{}
+	}
+	for _, info := range received {
+		if info.Kind == eventshub.EventReceived && info.Event != nil {
+			delete(missing, info.Event.ID())
+		}
+	}
+	return sortedKeys(missing)
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
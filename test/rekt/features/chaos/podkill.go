@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/logging"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/feature"
+)
+
+// KillPods deletes all the pods matching the label selector in the given
+// namespace.
+func KillPods(namespace, selector string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		pods, err := listPods(ctx, namespace, selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pods) == 0 {
+			t.Fatalf("no pods matching %q in namespace %s", selector, namespace)
+		}
+		for _, p := range pods {
+			if err := deletePod(ctx, &p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// StartPodKiller deletes a random pod matching the label selector in the given
+// namespace every interval, until the fault is stopped with Stop. Stopping the
+// fault waits for the matching pods to be ready again.
+func StartPodKiller(name, namespace, selector string, interval time.Duration) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		killCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+			logger := logging.FromContext(ctx)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-killCtx.Done():
+					return
+				case <-ticker.C:
+				}
+				pods, err := listPods(killCtx, namespace, selector)
+				if err != nil {
+					logger.Warnw("Failed to list pods to kill", "selector", selector, "error", err)
+					continue
+				}
+				if len(pods) == 0 {
+					continue
+				}
+				p := pods[rand.Intn(len(pods))]
+				if err := deletePod(killCtx, &p); err != nil {
+					logger.Warnw("Failed to kill pod", "pod", p.Name, "error", err)
+					continue
+				}
+				logger.Infow("Killed pod", "pod", p.Name)
+			}
+		}()
+
+		start(ctx, t, name, func(ctx context.Context) error {
+			cancel()
+			<-done
+			return waitForPodsReady(ctx, namespace, selector)
+		})
+	}
+}
+
+func listPods(ctx context.Context, namespace, selector string) ([]corev1.Pod, error) {
+	pods, err := kubeclient.Get(ctx).CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods matching %q in namespace %s: %w", selector, namespace, err)
+	}
+	return pods.Items, nil
+}
+
+func deletePod(ctx context.Context, p *corev1.Pod) error {
+	err := kubeclient.Get(ctx).CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{
+		GracePeriodSeconds: new(int64),
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pod %s/%s: %w", p.Namespace, p.Name, err)
+	}
+	return nil
+}
+
+// waitForPodsReady waits until there is at least one pod matching the selector
+// and all the matching pods which are not being deleted are ready.
+func waitForPodsReady(ctx context.Context, namespace, selector string) error {
+	interval, timeout := environment.PollTimingsFromContext(ctx)
+	return wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		pods, err := listPods(ctx, namespace, selector)
+		if err != nil {
+			return false, nil
+		}
+		ready := 0
+		for _, p := range pods {
+			if p.DeletionTimestamp != nil {
+				continue
+			}
+			if !isPodReady(&p) {
+				return false, nil
+			}
+			ready++
+		}
+		return ready > 0, nil
+	})
+}
+
+func isPodReady(p *corev1.Pod) bool {
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containersource
+
+import (
+	"github.com/cloudevents/sdk-go/v2/test"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/manifest"
+
+	"knative.dev/eventing/test/rekt/features/source"
+	"knative.dev/eventing/test/rekt/resources/containersource"
+)
+
+// Conformance runs the source duck conformance features against
+// ContainerSource.
+func Conformance() *feature.FeatureSet {
+	return source.Conformance(source.UnderTest{
+		GVR: containersource.Gvr(),
+		Install: func(name string, sink *duckv1.Destination, overrides *duckv1.CloudEventOverrides) feature.StepFn {
+			opts := []manifest.CfgFn{containersource.WithSink(sink)}
+			if overrides != nil {
+				extensions := make(map[string]interface{}, len(overrides.Extensions))
+				for k, v := range overrides.Extensions {
+					extensions[k] = v
+				}
+				opts = append(opts, containersource.WithExtensions(extensions))
+			}
+			return containersource.Install(name, opts...)
+		},
+		EventMatchers:     []test.EventMatcher{test.HasType("dev.knative.eventing.samples.heartbeat")},
+		HasReceiveAdapter: true,
+	})
+}
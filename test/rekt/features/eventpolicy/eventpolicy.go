@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventpolicy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudevents/sdk-go/v2/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	eventassert "knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/k8s"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1alpha1 "knative.dev/eventing/pkg/apis/eventing/v1alpha1"
+	"knative.dev/eventing/test/rekt/features/featureflags"
+	"knative.dev/eventing/test/rekt/resources/eventpolicy"
+)
+
+// AddressableAuthZConformance returns the features an addressable enforcing
+// EventPolicies has to pass. The addressable of the given kind and name must
+// already exist in the environment namespace.
+func AddressableAuthZConformance(gvr schema.GroupVersionResource, kind, name string) *feature.FeatureSet {
+	fs := feature.FeatureSet{
+		Name: fmt.Sprintf("%s handles authorization with EventPolicies correctly", kind),
+		Features: []*feature.Feature{
+			addressableAllowsAuthorizedRequest(gvr, kind, name),
+			addressableRejectsUnauthorizedRequest(gvr, kind, name),
+			addressableAllowsSubjectPrefix(gvr, kind, name),
+		},
+	}
+
+	return &fs
+}
+
+func addressableAllowsAuthorizedRequest(gvr schema.GroupVersionResource, kind, name string) *feature.Feature {
+	f := feature.NewFeatureNamed(fmt.Sprintf("%s accepts event from a subject allowed by an EventPolicy", kind))
+
+	addPrerequisites(f)
+
+	source := feature.MakeRandomK8sName("source")
+	policy := feature.MakeRandomK8sName("eventpolicy")
+	event := test.FullEvent()
+
+	f.Requirement(fmt.Sprintf("%s is ready", kind), k8s.IsReady(gvr, name))
+	f.Requirement(fmt.Sprintf("%s is addressable", kind), k8s.IsAddressable(gvr, name))
+	f.Requirement("install EventPolicy allowing the source", installPolicy(policy, gvr, kind, name, func(ns string) string {
+		return serviceAccountSubject(ns, source)
+	}))
+	f.Requirement(fmt.Sprintf("EventPolicy is applied to %s", kind), policyIsApplied(gvr, name, policy))
+
+	f.Requirement("install source", eventshub.Install(
+		source,
+		eventshub.StartSenderToResourceTLS(gvr, name, nil),
+		eventshub.InputEvent(event),
+	))
+
+	f.Alpha(kind).
+		Must("event sent", eventassert.OnStore(source).MatchSentEvent(test.HasId(event.ID())).Exact(1)).
+		Must("get 202 on response", eventassert.OnStore(source).Match(eventassert.MatchStatusCode(202)).Exact(1))
+
+	return f
+}
+
+func addressableRejectsUnauthorizedRequest(gvr schema.GroupVersionResource, kind, name string) *feature.Feature {
+	f := feature.NewFeatureNamed(fmt.Sprintf("%s rejects event from a subject not allowed by any EventPolicy", kind))
+
+	addPrerequisites(f)
+
+	source := feature.MakeRandomK8sName("source")
+	policy := feature.MakeRandomK8sName("eventpolicy")
+	event := test.FullEvent()
+
+	f.Requirement(fmt.Sprintf("%s is ready", kind), k8s.IsReady(gvr, name))
+	f.Requirement(fmt.Sprintf("%s is addressable", kind), k8s.IsAddressable(gvr, name))
+	f.Requirement("install EventPolicy allowing another subject", installPolicy(policy, gvr, kind, name, func(ns string) string {
+		return serviceAccountSubject(ns, feature.MakeRandomK8sName("other"))
+	}))
+	f.Requirement(fmt.Sprintf("EventPolicy is applied to %s", kind), policyIsApplied(gvr, name, policy))
+
+	f.Requirement("install source", eventshub.Install(
+		source,
+		eventshub.StartSenderToResourceTLS(gvr, name, nil),
+		eventshub.InputEvent(event),
+	))
+
+	f.Alpha(kind).
+		Must("event sent", eventassert.OnStore(source).MatchSentEvent(test.HasId(event.ID())).Exact(1)).
+		Must("get 403 on response", eventassert.OnStore(source).Match(eventassert.MatchStatusCode(403)).Exact(1))
+
+	return f
+}
+
+func addressableAllowsSubjectPrefix(gvr schema.GroupVersionResource, kind, name string) *feature.Feature {
+	f := feature.NewFeatureNamed(fmt.Sprintf("%s accepts event from a subject matching an EventPolicy subject prefix", kind))
+
+	addPrerequisites(f)
+
+	source := feature.MakeRandomK8sName("source")
+	policy := feature.MakeRandomK8sName("eventpolicy")
+	event := test.FullEvent()
+
+	f.Requirement(fmt.Sprintf("%s is ready", kind), k8s.IsReady(gvr, name))
+	f.Requirement(fmt.Sprintf("%s is addressable", kind), k8s.IsAddressable(gvr, name))
+	f.Requirement("install EventPolicy allowing the namespace", installPolicy(policy, gvr, kind, name, func(ns string) string {
+		return serviceAccountSubject(ns, "*")
+	}))
+	f.Requirement(fmt.Sprintf("EventPolicy is applied to %s", kind), policyIsApplied(gvr, name, policy))
+
+	f.Requirement("install source", eventshub.Install(
+		source,
+		eventshub.StartSenderToResourceTLS(gvr, name, nil),
+		eventshub.InputEvent(event),
+	))
+
+	f.Alpha(kind).
+		Must("event sent", eventassert.OnStore(source).MatchSentEvent(test.HasId(event.ID())).Exact(1)).
+		Must("get 202 on response", eventassert.OnStore(source).Match(eventassert.MatchStatusCode(202)).Exact(1))
+
+	return f
+}
+
+func addPrerequisites(f *feature.Feature) {
+	f.Prerequisite("OIDC authentication is enabled", featureflags.AuthenticationOIDCEnabled())
+	f.Prerequisite("transport encryption is strict", featureflags.TransportEncryptionStrict())
+	f.Prerequisite("should not run when Istio is enabled", featureflags.IstioDisabled())
+}
+
+// serviceAccountSubject returns the OIDC subject of the given service account,
+// which is also the name of the eventshub using it.
+func serviceAccountSubject(namespace, serviceAccount string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+}
+
+// installPolicy installs an EventPolicy applying to the given addressable and
+// allowing the subject returned by sub for the environment namespace.
+func installPolicy(policy string, gvr schema.GroupVersionResource, kind, name string, sub func(namespace string) string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		ns := environment.FromContext(ctx).Namespace()
+		eventpolicy.Install(policy,
+			eventpolicy.WithTo(eventingv1alpha1.EventPolicySpecTo{
+				Ref: &eventingv1alpha1.EventPolicyToReference{
+					APIVersion: gvr.GroupVersion().String(),
+					Kind:       kind,
+					Name:       name,
+				},
+			}),
+			eventpolicy.WithFrom(eventingv1alpha1.EventPolicySpecFrom{
+				Sub: ptr.To(sub(ns)),
+			}),
+		)(ctx, t)
+	}
+}
+
+// policyIsApplied waits until the addressable lists the EventPolicy in its
+// status.policies.
+func policyIsApplied(gvr schema.GroupVersionResource, name, policy string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		ns := environment.FromContext(ctx).Namespace()
+		interval, timeout := environment.PollTimingsFromContext(ctx)
+
+		var last eventingduckv1.AppliedEventPoliciesStatus
+		err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			obj, err := dynamicclient.Get(ctx).Resource(gvr).Namespace(ns).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			status, _, err := unstructured.NestedMap(obj.Object, "status")
+			if err != nil || status == nil {
+				return false, err
+			}
+			last = eventingduckv1.AppliedEventPoliciesStatus{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &last); err != nil {
+				return false, err
+			}
+			for _, p := range last.Policies {
+				if p.Name == policy {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			t.Fatalf("EventPolicy %s was not applied to %s %s: %v, applied policies: %+v", policy, gvr.Resource, name, err, last.Policies)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics has helpers to assert that the metrics exported by the
+// Knative components change as expected while a feature runs.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/knative"
+	"knative.dev/reconciler-test/pkg/state"
+)
+
+// Component identifies the pods of a Knative component whose metrics are
+// scraped.
+type Component struct {
+	// Name of the component, used in messages.
+	Name string
+	// LabelSelector selects the pods of the component in the Knative
+	// namespace.
+	LabelSelector string
+	// Port is the port the pods serve their Prometheus metrics on.
+	Port int
+}
+
+var (
+	// BrokerIngress is the ingress of the MT channel based Broker, its
+	// metrics are prefixed with mt_broker_ingress_.
+	BrokerIngress = Component{
+		Name:          "mt-broker-ingress",
+		LabelSelector: "eventing.knative.dev/brokerRole=ingress",
+		Port:          9092,
+	}
+	// BrokerFilter is the filter of the MT channel based Broker, its
+	// metrics are prefixed with mt_broker_filter_.
+	BrokerFilter = Component{
+		Name:          "mt-broker-filter",
+		LabelSelector: "eventing.knative.dev/brokerRole=filter",
+		Port:          9092,
+	}
+)
+
+// Sample is a single value of a scraped metric. Histograms and summaries are
+// flattened to their _count and _sum samples.
+type Sample struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Value  float64           `json:"value"`
+}
+
+// Samples are the metrics scraped from the pods of a component.
+type Samples []Sample
+
+// Value returns the sum of the samples with the given name having all the
+// given labels, across all the pods of the component.
+func (s Samples) Value(name string, labels map[string]string) float64 {
+	var v float64
+	for _, sample := range s {
+		if sample.Name == name && hasLabels(sample.Labels, labels) {
+			v += sample.Value
+		}
+	}
+	return v
+}
+
+func hasLabels(got, want map[string]string) bool {
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses metrics in the Prometheus text exposition format.
+func Parse(r io.Reader) (Samples, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples Samples
+	for name, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			add := func(name string, value float64) {
+				samples = append(samples, Sample{Name: name, Labels: labels, Value: value})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case dto.MetricType_HISTOGRAM:
+				add(name+"_count", float64(m.GetHistogram().GetSampleCount()))
+				add(name+"_sum", m.GetHistogram().GetSampleSum())
+			case dto.MetricType_SUMMARY:
+				add(name+"_count", float64(m.GetSummary().GetSampleCount()))
+				add(name+"_sum", m.GetSummary().GetSampleSum())
+			default:
+				add(name, m.GetUntyped().GetValue())
+			}
+		}
+	}
+	return samples, nil
+}
+
+// Scrape returns the metrics of all the running pods of the component,
+// through the API server pod proxy.
+func Scrape(ctx context.Context, c Component) (Samples, error) {
+	namespace := knative.KnativeNamespaceFromContext(ctx)
+	pods := kubeclient.Get(ctx).CoreV1().Pods(namespace)
+
+	list, err := pods.List(ctx, metav1.ListOptions{LabelSelector: c.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %s pods: %w", c.Name, err)
+	}
+
+	var samples Samples
+	scraped := 0
+	for _, p := range list.Items {
+		if p.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		b, err := pods.ProxyGet("http", p.Name, strconv.Itoa(c.Port), "metrics", nil).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scrape the metrics of %s pod %s: %w", c.Name, p.Name, err)
+		}
+		s, err := Parse(bytes.NewReader(b))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the metrics of %s pod %s: %w", c.Name, p.Name, err)
+		}
+		samples = append(samples, s...)
+		scraped++
+	}
+	if scraped == 0 {
+		return nil, fmt.Errorf("no running %s pod matching %q in namespace %s", c.Name, c.LabelSelector, namespace)
+	}
+	return samples, nil
+}
+
+func stateKey(key string) string {
+	return "metrics-" + key
+}
+
+// Snapshot scrapes the metrics of the component and stores them in the
+// feature state under key, as the baseline of the assertions using the same
+// key. Counters are reset when a pod restarts, so the assertions are only
+// meaningful if the pods of the component are not restarted in between.
+func Snapshot(key string, c Component) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		samples, err := Scrape(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.SetOrFail(ctx, t, stateKey(key), samples)
+	}
+}
+
+// DeltaMatcher checks the change of a metric value since its snapshot.
+type DeltaMatcher func(delta float64) error
+
+// AtLeast matches a change of at least n.
+func AtLeast(n float64) DeltaMatcher {
+	return func(delta float64) error {
+		if delta < n {
+			return fmt.Errorf("changed by %v, want at least %v", delta, n)
+		}
+		return nil
+	}
+}
+
+// Exactly matches a change of exactly n.
+func Exactly(n float64) DeltaMatcher {
+	return func(delta float64) error {
+		if delta != n {
+			return fmt.Errorf("changed by %v, want %v", delta, n)
+		}
+		return nil
+	}
+}
+
+// Changed asserts that the metric with the given name and labels changed as
+// expected by matcher since the snapshot stored under key. Metrics are
+// exported asynchronously, so the component is scraped until matcher is
+// satisfied or the poll timeout expires.
+func Changed(key string, c Component, name string, labels map[string]string, matcher DeltaMatcher) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		var before Samples
+		state.GetOrFail(ctx, t, stateKey(key), &before)
+		baseline := before.Value(name, labels)
+
+		var last error
+		interval, timeout := environment.PollTimingsFromContext(ctx)
+		err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			samples, err := Scrape(ctx, c)
+			if err != nil {
+				last = err
+				return false, nil
+			}
+			last = matcher(samples.Value(name, labels) - baseline)
+			return last == nil, nil
+		})
+		if err != nil {
+			t.Fatalf("metric %s%v of %s: %v", name, labels, c.Name, last)
+		}
+	}
+}
+
+// CounterIncreased asserts that the counter with the given name and labels
+// increased by at least n since the snapshot stored under key.
+func CounterIncreased(key string, c Component, name string, labels map[string]string, n float64) feature.StepFn {
+	return Changed(key, c, name, labels, AtLeast(n))
+}
+
+// HistogramObserved asserts that at least n values were recorded by the
+// histogram with the given name and labels since the snapshot stored under
+// key.
+func HistogramObserved(key string, c Component, name string, labels map[string]string, n float64) feature.StepFn {
+	return Changed(key, c, name+"_count", labels, AtLeast(n))
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+const exposition = `# HELP mt_broker_filter_event_count Number of events received by a Trigger
+# TYPE mt_broker_filter_event_count counter
+mt_broker_filter_event_count{namespace_name="ns",trigger_name="t1",response_code="202"} 3
+mt_broker_filter_event_count{namespace_name="ns",trigger_name="t1",response_code="500"} 1
+mt_broker_filter_event_count{namespace_name="ns",trigger_name="t2",response_code="202"} 5
+# HELP mt_broker_filter_event_dispatch_latencies The time spent dispatching an event to a Trigger subscriber
+# TYPE mt_broker_filter_event_dispatch_latencies histogram
+mt_broker_filter_event_dispatch_latencies_bucket{namespace_name="ns",trigger_name="t1",le="10"} 2
+mt_broker_filter_event_dispatch_latencies_bucket{namespace_name="ns",trigger_name="t1",le="+Inf"} 4
+mt_broker_filter_event_dispatch_latencies_sum{namespace_name="ns",trigger_name="t1"} 42.5
+mt_broker_filter_event_dispatch_latencies_count{namespace_name="ns",trigger_name="t1"} 4
+`
+
+func TestSamplesValue(t *testing.T) {
+	samples, err := Parse(strings.NewReader(exposition))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   float64
+	}{{
+		name:   "counter of a trigger",
+		metric: "mt_broker_filter_event_count",
+		labels: map[string]string{"trigger_name": "t1"},
+		want:   4,
+	}, {
+		name:   "counter of a trigger and response code",
+		metric: "mt_broker_filter_event_count",
+		labels: map[string]string{"trigger_name": "t1", "response_code": "500"},
+		want:   1,
+	}, {
+		name:   "counter without labels",
+		metric: "mt_broker_filter_event_count",
+		want:   9,
+	}, {
+		name:   "histogram count",
+		metric: "mt_broker_filter_event_dispatch_latencies_count",
+		labels: map[string]string{"trigger_name": "t1"},
+		want:   4,
+	}, {
+		name:   "histogram sum",
+		metric: "mt_broker_filter_event_dispatch_latencies_sum",
+		labels: map[string]string{"trigger_name": "t1"},
+		want:   42.5,
+	}, {
+		name:   "unknown label value",
+		metric: "mt_broker_filter_event_count",
+		labels: map[string]string{"trigger_name": "t3"},
+		want:   0,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := samples.Value(tc.metric, tc.labels); got != tc.want {
+				t.Errorf("Value() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeltaMatchers(t *testing.T) {
+	if err := AtLeast(2)(3); err != nil {
+		t.Error("AtLeast(2)(3) =", err)
+	}
+	if err := AtLeast(2)(1); err == nil {
+		t.Error("AtLeast(2)(1) = nil, want an error")
+	}
+	if err := Exactly(2)(2); err != nil {
+		t.Error("Exactly(2)(2) =", err)
+	}
+	if err := Exactly(2)(3); err == nil {
+		t.Error("Exactly(2)(3) = nil, want an error")
+	}
+}
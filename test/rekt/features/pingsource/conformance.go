@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pingsource
+
+import (
+	"context"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/manifest"
+	"knative.dev/reconciler-test/pkg/resources/service"
+
+	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
+	"knative.dev/eventing/test/rekt/resources/pingsource"
+)
+
+// Conformance returns the features checking the CloudEvents attributes of the
+// events sent by PingSource, so that alternative implementations can verify
+// they are compatible.
+func Conformance() *feature.FeatureSet {
+	return &feature.FeatureSet{
+		Name: "PingSource CloudEvents attributes conformance",
+		Features: []*feature.Feature{
+			AttributesConformance(),
+			DataConformance(),
+			DataBase64Conformance(),
+			OverridesConformance(),
+		},
+	}
+}
+
+// AttributesConformance checks the type, source and spec version of the
+// events, and that they have no data when none is configured.
+func AttributesConformance() *feature.Feature {
+	f := feature.NewFeatureNamed("PingSource event attributes")
+
+	src, sink := installSourceAndSink(f)
+
+	f.Stable("pingsource").
+		Must("send events with the ping type, the PingSource source and no data",
+			expectEvents(src, sink, cetest.HasNoData()))
+
+	return f
+}
+
+// DataConformance checks that spec.data is sent as is, with
+// spec.contentType as data content type.
+func DataConformance() *feature.Feature {
+	const data = `{"message":"Hello world!"}`
+
+	f := feature.NewFeatureNamed("PingSource data")
+
+	src, sink := installSourceAndSink(f, pingsource.WithData("application/json", data))
+
+	f.Stable("pingsource").
+		Must("send spec.data with spec.contentType", expectEvents(src, sink,
+			cetest.HasDataContentType("application/json"),
+			cetest.HasData([]byte(data)),
+		))
+
+	return f
+}
+
+// DataBase64Conformance checks that spec.dataBase64 is decoded before it is
+// sent, with spec.contentType as data content type.
+func DataBase64Conformance() *feature.Feature {
+	f := feature.NewFeatureNamed("PingSource dataBase64")
+
+	src, sink := installSourceAndSink(f, pingsource.WithDataBase64("text/plain", "aGVsbG8sIHdvcmxkIQ=="))
+
+	f.Stable("pingsource").
+		Must("send the decoded spec.dataBase64 with spec.contentType", expectEvents(src, sink,
+			cetest.HasDataContentType("text/plain"),
+			cetest.HasData([]byte("hello, world!")),
+		))
+
+	return f
+}
+
+// OverridesConformance checks that the extensions of spec.ceOverrides are
+// added to the events, without changing their other attributes.
+func OverridesConformance() *feature.Feature {
+	f := feature.NewFeatureNamed("PingSource CloudEventOverrides")
+
+	src, sink := installSourceAndSink(f, pingsource.WithExtensions(map[string]interface{}{
+		"conformance": "overridden",
+	}))
+
+	f.Stable("pingsource").
+		Must("add the extensions to the events", expectEvents(src, sink,
+			cetest.HasExtension("conformance", "overridden"),
+		))
+
+	return f
+}
+
+func installSourceAndSink(f *feature.Feature, opts ...manifest.CfgFn) (string, string) {
+	src := feature.MakeRandomK8sName("pingsource")
+	sink := feature.MakeRandomK8sName("sink")
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install pingsource", pingsource.Install(src, append(opts, pingsource.WithSink(service.AsDestinationRef(sink)))...))
+	f.Setup("pingsource goes ready", pingsource.IsReady(src))
+
+	return src, sink
+}
+
+// expectEvents asserts that the sink received events with the attributes
+// every PingSource event has, matching the given matchers.
+func expectEvents(src, sink string, matchers ...cetest.EventMatcher) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		ns := environment.FromContext(ctx).Namespace()
+		matchers := append([]cetest.EventMatcher{
+			cetest.IsValid(),
+			cetest.HasSpecVersion("1.0"),
+			cetest.HasType(sourcesv1.PingSourceEventType),
+			cetest.HasSource(sourcesv1.PingSourceSource(ns, src)),
+		}, matchers...)
+
+		assert.OnStore(sink).
+			MatchReceivedEvent(matchers...).
+			AtLeast(1)(ctx, t)
+	}
+}
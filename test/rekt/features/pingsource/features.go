@@ -30,6 +30,7 @@ import (
 	"knative.dev/reconciler-test/pkg/manifest"
 	"knative.dev/reconciler-test/pkg/resources/service"
 
+	eventingv1beta3 "knative.dev/eventing/pkg/apis/eventing/v1beta3"
 	sourcesv1 "knative.dev/eventing/pkg/apis/sources/v1"
 	"knative.dev/eventing/pkg/eventingtls/eventingtlstesting"
 	"knative.dev/eventing/test/rekt/resources/addressable"
@@ -228,7 +229,15 @@ func SendsEventsWithEventTypes() *feature.Feature {
 		Must("PingSource test eventtypes match", eventtype.WaitForEventType(
 			eventtype.AssertReady(expectedCeTypes),
 			eventtype.AssertPresent(expectedCeTypes),
-			eventtype.AssertReferencePresent(broker.AsKReference(brokerName))))
+			eventtype.AssertReferencePresent(broker.AsKReference(brokerName)))).
+		Must("PingSource test v1beta3 eventtypes match", eventtype.WaitForEventTypeVersion(
+			eventingv1beta3.SchemeGroupVersion,
+			eventtype.AssertAttributes(sourcesv1.PingSourceEventType, eventingv1beta3.EventAttributeDefinition{
+				Name:     "type",
+				Required: true,
+				Value:    sourcesv1.PingSourceEventType,
+			}),
+			eventtype.AssertReferenceMatches(sourcesv1.PingSourceEventType, broker.AsKReference(brokerName))))
 
 	return f
 }
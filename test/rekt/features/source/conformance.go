@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection/clients/dynamicclient"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/k8s"
+	"knative.dev/reconciler-test/pkg/resources/service"
+
+	"knative.dev/eventing/test/rekt/features/featureflags"
+)
+
+// InstallFn installs the source under test with the given name, sink and
+// CloudEventOverrides. overrides is nil when the feature does not use them.
+type InstallFn func(name string, sink *duckv1.Destination, overrides *duckv1.CloudEventOverrides) feature.StepFn
+
+// UnderTest describes a source implementation to run the conformance
+// features against. Third-party source authors provide the GVR of their CRD
+// and a way to install an instance of it.
+type UnderTest struct {
+	// GVR is the resource of the source.
+	GVR schema.GroupVersionResource
+	// Install installs an instance of the source.
+	Install InstallFn
+	// EventMatchers optionally restrict the events the source is expected to
+	// deliver, e.g. by type. By default, any event is accepted.
+	EventMatchers []cetest.EventMatcher
+	// HasReceiveAdapter enables the K_SINK and K_CA_CERTS contract checks on
+	// the Deployments controlled by the source in its own namespace. Leave it
+	// unset for sources whose adapter is shared or lives elsewhere.
+	HasReceiveAdapter bool
+}
+
+// Conformance returns the source duck conformance features for the given
+// source implementation.
+func Conformance(s UnderTest) *feature.FeatureSet {
+	fs := &feature.FeatureSet{
+		Name: fmt.Sprintf("%s source conformance", s.GVR.Resource),
+		Features: []*feature.Feature{
+			SinkRefResolution(s),
+			SinkURIResolution(s),
+			CloudEventOverridesApplied(s),
+			SinkOIDCAudience(s),
+		},
+	}
+	if s.HasReceiveAdapter {
+		fs.Features = append(fs.Features, SinkEnvContract(s))
+	}
+	return fs
+}
+
+// SinkRefResolution checks that a sink reference is resolved into the
+// status of the source and that events are delivered to it.
+func SinkRefResolution(s UnderTest) *feature.Feature {
+	src := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	f := feature.NewFeatureNamed("Source resolves sink reference")
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install source", s.Install(src, service.AsDestinationRef(sink), nil))
+	f.Setup("source is ready", k8s.IsReady(s.GVR, src))
+
+	f.Stable("source").
+		Must("set status.sinkUri to the sink address", expectSinkURIOf(s.GVR, src, sink)).
+		Must("deliver events to the sink", delivers(s, sink))
+
+	return f
+}
+
+// SinkURIResolution checks that a sink URI is used as is.
+func SinkURIResolution(s UnderTest) *feature.Feature {
+	src := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	f := feature.NewFeatureNamed("Source uses sink URI")
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install source", func(ctx context.Context, t feature.T) {
+		addr, err := service.Address(ctx, sink)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Install(src, &duckv1.Destination{URI: addr.URL}, nil)(ctx, t)
+	})
+	f.Setup("source is ready", k8s.IsReady(s.GVR, src))
+
+	f.Stable("source").
+		Must("set status.sinkUri to the sink URI", expectSinkURIOf(s.GVR, src, sink)).
+		Must("deliver events to the sink", delivers(s, sink))
+
+	return f
+}
+
+// CloudEventOverridesApplied checks that the extensions of
+// spec.ceOverrides are added to every event sent by the source.
+func CloudEventOverridesApplied(s UnderTest) *feature.Feature {
+	src := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	f := feature.NewFeatureNamed("Source applies CloudEventOverrides")
+
+	overrides := &duckv1.CloudEventOverrides{
+		Extensions: map[string]string{"conformance": "overridden"},
+	}
+	matchers := append([]cetest.EventMatcher{cetest.HasExtension("conformance", "overridden")}, s.EventMatchers...)
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiver))
+	f.Setup("install source", s.Install(src, service.AsDestinationRef(sink), overrides))
+	f.Setup("source is ready", k8s.IsReady(s.GVR, src))
+
+	f.Stable("source").
+		Must("add the extensions to delivered events", assert.OnStore(sink).
+			MatchReceivedEvent(matchers...).
+			AtLeast(1))
+
+	return f
+}
+
+// SinkEnvContract checks that the receive adapter of the source gets the
+// sink through K_SINK and the CA certificates of a TLS sink through
+// K_CA_CERTS.
+func SinkEnvContract(s UnderTest) *feature.Feature {
+	src := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	f := feature.NewFeatureNamed("Source receive adapter honors K_SINK and K_CA_CERTS")
+
+	f.Prerequisite("should not run when Istio is enabled", featureflags.IstioDisabled())
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiverTLS))
+	f.Setup("install source", func(ctx context.Context, t feature.T) {
+		d := service.AsDestinationRef(sink)
+		d.CACerts = eventshub.GetCaCerts(ctx)
+		s.Install(src, d, nil)(ctx, t)
+	})
+	f.Setup("source is ready", k8s.IsReady(s.GVR, src))
+
+	f.Stable("source").
+		Must("set status.sinkUri to an HTTPS endpoint", ExpectHTTPSSink(s.GVR, src)).
+		Must("set status.sinkCACerts", ExpectCACerts(s.GVR, src)).
+		Must("inject K_SINK and K_CA_CERTS into the receive adapter", expectSinkEnv(s.GVR, src)).
+		Must("deliver events to the sink", delivers(s, sink))
+
+	return f
+}
+
+// SinkOIDCAudience checks that the source propagates the audience of its
+// sink and authenticates with an OIDC token of its own identity.
+func SinkOIDCAudience(s UnderTest) *feature.Feature {
+	src := feature.MakeRandomK8sName("source")
+	sink := feature.MakeRandomK8sName("sink")
+	audience := "conformance-audience"
+	f := feature.NewFeatureNamed("Source uses the OIDC audience of its sink")
+
+	f.Prerequisite("OIDC authentication is enabled", featureflags.AuthenticationOIDCEnabled())
+	f.Prerequisite("transport encryption is strict", featureflags.TransportEncryptionStrict())
+	f.Prerequisite("should not run when Istio is enabled", featureflags.IstioDisabled())
+
+	f.Setup("install sink", eventshub.Install(sink,
+		eventshub.OIDCReceiverAudience(audience),
+		eventshub.StartReceiverTLS))
+	f.Setup("install source", func(ctx context.Context, t feature.T) {
+		d := service.AsDestinationRef(sink)
+		d.CACerts = eventshub.GetCaCerts(ctx)
+		d.Audience = &audience
+		s.Install(src, d, nil)(ctx, t)
+	})
+	f.Setup("source is ready", k8s.IsReady(s.GVR, src))
+
+	f.Stable("source").
+		Must("set status.sinkAudience", waitFor(s.GVR, src, func(so *duckv1.Source) (bool, error) {
+			if so.Status.SinkAudience == nil || *so.Status.SinkAudience != audience {
+				return false, fmt.Errorf("expected status.sinkAudience %q, got %v", audience, so.Status.SinkAudience)
+			}
+			return true, nil
+		})).
+		Must("deliver events to the sink", delivers(s, sink)).
+		Must("use its own identity for OIDC", assert.OnStore(sink).MatchWithContext(
+			assert.MatchKind(eventshub.EventReceived).WithContext(),
+			assert.MatchOIDCUserFromResource(s.GVR, src)).AtLeast(1))
+
+	return f
+}
+
+func delivers(s UnderTest, sink string) feature.StepFn {
+	return assert.OnStore(sink).MatchReceivedEvent(s.EventMatchers...).AtLeast(1)
+}
+
+func expectSinkURIOf(gvr schema.GroupVersionResource, name, sink string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		addr, err := service.Address(ctx, sink)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitFor(gvr, name, func(s *duckv1.Source) (bool, error) {
+			if s.Status.SinkURI == nil || s.Status.SinkURI.String() != addr.URL.String() {
+				return false, fmt.Errorf("expected status.sinkUri %s, got %v", addr.URL, s.Status.SinkURI)
+			}
+			return true, nil
+		})(ctx, t)
+	}
+}
+
+func expectSinkEnv(gvr schema.GroupVersionResource, name string) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		namespace := environment.FromContext(ctx).Namespace()
+		interval, timeout := environment.PollTimingsFromContext(ctx)
+
+		var lastErr error
+		err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			lastErr = checkSinkEnv(ctx, gvr, namespace, name)
+			return lastErr == nil, nil
+		})
+		if err != nil {
+			t.Errorf("receive adapter of %s %s/%s does not honor the sink contract: %v", gvr.Resource, namespace, name, lastErr)
+		}
+	}
+}
+
+func checkSinkEnv(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) error {
+	obj, err := dynamicclient.Get(ctx).Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	s := &duckv1.Source{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, s); err != nil {
+		return err
+	}
+	if s.Status.SinkURI == nil {
+		return fmt.Errorf("status.sinkUri is not set")
+	}
+
+	deployments, err := kubeclient.Get(ctx).AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, d := range deployments.Items {
+		if !metav1.IsControlledBy(&d, obj) {
+			continue
+		}
+		found = true
+		for _, c := range d.Spec.Template.Spec.Containers {
+			if got := envValue(c.Env, "K_SINK"); got != s.Status.SinkURI.String() {
+				return fmt.Errorf("container %s of deployment %s has K_SINK %q, want %q", c.Name, d.Name, got, s.Status.SinkURI)
+			}
+			if s.Status.SinkCACerts == nil {
+				continue
+			}
+			if got := envValue(c.Env, "K_CA_CERTS"); got != *s.Status.SinkCACerts {
+				return fmt.Errorf("container %s of deployment %s has K_CA_CERTS %q, want status.sinkCACerts", c.Name, d.Name, got)
+			}
+		}
+	}
+	if !found {
+		return fmt.Errorf("no deployment controlled by the source")
+	}
+	return nil
+}
+
+func envValue(env []corev1.EnvVar, name string) string {
+	for _, e := range env {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	return ""
+}
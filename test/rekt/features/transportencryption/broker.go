@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transportencryption
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	cetest "github.com/cloudevents/sdk-go/v2/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/eventshub"
+	"knative.dev/reconciler-test/pkg/eventshub/assert"
+	"knative.dev/reconciler-test/pkg/feature"
+	"knative.dev/reconciler-test/pkg/resources/service"
+
+	apifeature "knative.dev/eventing/pkg/apis/feature"
+	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	"knative.dev/eventing/test/rekt/features/featureflags"
+	"knative.dev/eventing/test/rekt/resources/broker"
+	"knative.dev/eventing/test/rekt/resources/trigger"
+)
+
+const (
+	httpEventType  = "dev.knative.test.transportencryption.http"
+	httpsEventType = "dev.knative.test.transportencryption.https"
+)
+
+// BrokerTransportEncryptionTransitions flips transport-encryption from
+// disabled to permissive, strict and back to permissive while events are sent
+// to a Broker, and asserts that the Broker addresses follow the mode and that
+// no event sent with a scheme accepted throughout is lost:
+//   - events sent over HTTP while going from disabled to permissive,
+//   - events sent over HTTPS while going from permissive to strict and back.
+//
+// It changes the transport-encryption mode of the cluster, so it must not run
+// in parallel with other tests.
+func BrokerTransportEncryptionTransitions() *feature.Feature {
+	f := feature.NewFeatureNamed("Broker delivers events across transport-encryption mode transitions")
+
+	brokerName := feature.MakeRandomK8sName("broker")
+	triggerName := feature.MakeRandomK8sName("trigger")
+	sink := feature.MakeRandomK8sName("sink")
+	httpSource := feature.MakeRandomK8sName("http-source")
+	httpsSource := feature.MakeRandomK8sName("https-source")
+
+	const (
+		httpEvents  = 30
+		httpsEvents = 60
+	)
+
+	f.Prerequisite("transport encryption is permissive or strict", featureflags.TransportEncryptionPermissiveOrStrict())
+	f.Prerequisite("should not run when Istio is enabled", featureflags.IstioDisabled())
+
+	f.Setup("save transport-encryption mode", SaveMode())
+	f.Setup("disable transport-encryption", SetMode(apifeature.Disabled))
+
+	f.Setup("install sink", eventshub.Install(sink, eventshub.StartReceiverTLS))
+	f.Setup("install broker", broker.Install(brokerName, broker.WithEnvConfig()...))
+	f.Setup("broker is ready", broker.IsReady(brokerName))
+	f.Setup("install trigger", func(ctx context.Context, t feature.T) {
+		d := service.AsDestinationRef(sink)
+		d.CACerts = eventshub.GetCaCerts(ctx)
+		trigger.Install(triggerName, brokerName, trigger.WithSubscriberFromDestination(d))(ctx, t)
+	})
+	f.Setup("trigger is ready", trigger.IsReady(triggerName))
+	f.Setup("broker has an HTTP address", broker.WaitForCondition(brokerName, broker.HasAddressSchemes("http")))
+
+	httpEvent := cetest.FullEvent()
+	httpEvent.SetType(httpEventType)
+	f.Requirement("install HTTP source", eventshub.Install(httpSource,
+		eventshub.StartSenderToResource(broker.GVR(), brokerName),
+		eventshub.InputEvent(httpEvent),
+		eventshub.EnableIncrementalId,
+		eventshub.SendMultipleEvents(httpEvents, time.Second),
+	))
+
+	f.Requirement("set transport-encryption to permissive", SetMode(apifeature.Permissive))
+	f.Requirement("broker has HTTP and HTTPS addresses", broker.WaitForCondition(brokerName, broker.HasAddressSchemes("http", "https")))
+
+	httpsEvent := cetest.FullEvent()
+	httpsEvent.SetType(httpsEventType)
+	f.Requirement("install HTTPS source", installHTTPSSender(httpsSource, brokerName,
+		eventshub.InputEvent(httpsEvent),
+		eventshub.EnableIncrementalId,
+		eventshub.SendMultipleEvents(httpsEvents, time.Second),
+	))
+
+	f.Requirement("all HTTP events sent", assert.OnStore(httpSource).Match(assert.MatchKind(eventshub.EventResponse)).AtLeast(httpEvents))
+
+	f.Requirement("set transport-encryption to strict", SetMode(apifeature.Strict))
+	f.Requirement("broker has an HTTPS address", broker.WaitForCondition(brokerName, broker.HasAddressSchemes("https")))
+
+	f.Requirement("set transport-encryption back to permissive", SetMode(apifeature.Permissive))
+	f.Requirement("broker has HTTP and HTTPS addresses again", broker.WaitForCondition(brokerName, broker.HasAddressSchemes("http", "https")))
+
+	f.Requirement("all HTTPS events sent", assert.OnStore(httpsSource).Match(assert.MatchKind(eventshub.EventResponse)).AtLeast(httpsEvents))
+
+	f.Stable("broker").
+		Must("accept every event sent over HTTP", assert.OnStore(httpSource).
+			Match(assert.MatchKind(eventshub.EventResponse), assert.MatchStatusCode(http.StatusAccepted)).
+			Exact(httpEvents)).
+		Must("deliver every event sent over HTTP", assert.OnStore(sink).
+			MatchReceivedEvent(cetest.HasType(httpEventType)).
+			AtLeast(httpEvents)).
+		Must("accept every event sent over HTTPS", assert.OnStore(httpsSource).
+			Match(assert.MatchKind(eventshub.EventResponse), assert.MatchStatusCode(http.StatusAccepted)).
+			Exact(httpsEvents)).
+		Must("deliver every event sent over HTTPS", assert.OnStore(sink).
+			MatchReceivedEvent(cetest.HasType(httpsEventType)).
+			AtLeast(httpsEvents))
+
+	f.Teardown("restore transport-encryption mode", RestoreMode())
+
+	return f
+}
+
+// installHTTPSSender installs an eventshub sending events to the HTTPS
+// address of the Broker, which isn't its main address in permissive mode.
+func installHTTPSSender(name, brokerName string, opts ...eventshub.EventsHubOption) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		br, err := eventingclient.Get(ctx).
+			EventingV1().
+			Brokers(environment.FromContext(ctx).Namespace()).
+			Get(ctx, brokerName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get broker %s: %v", brokerName, err)
+		}
+		for _, addr := range br.Status.Addresses {
+			if addr.URL != nil && addr.URL.Scheme == "https" {
+				opts = append(opts, eventshub.StartSenderURLTLS(addr.URL.String(), addr.CACerts))
+				eventshub.Install(name, opts...)(ctx, t)
+				return
+			}
+		}
+		t.Fatalf("broker %s has no HTTPS address: %+v", brokerName, br.Status.Addresses)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package transportencryption contains features that change the
+// transport-encryption mode of the cluster while events are sent, to catch
+// regressions in the rollout of TLS to the data plane.
+//
+// The mode is saved before it is changed and restored afterward:
+//
+//	f.Setup("save transport-encryption mode", transportencryption.SaveMode())
+//	f.Setup("set transport-encryption to strict", transportencryption.SetMode(feature.Strict))
+//	...
+//	f.Teardown("restore transport-encryption mode", transportencryption.RestoreMode())
+package transportencryption
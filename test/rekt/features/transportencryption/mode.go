@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transportencryption
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/system"
+	"knative.dev/reconciler-test/pkg/environment"
+	"knative.dev/reconciler-test/pkg/feature"
+
+	apifeature "knative.dev/eventing/pkg/apis/feature"
+)
+
+const configFeaturesName = "config-features"
+
+// savedModes holds the transport-encryption modes of the cluster saved by
+// SaveMode, keyed by the environment namespace. A nil mode means it wasn't
+// set.
+var savedModes sync.Map
+
+// SaveMode remembers the current transport-encryption mode of the cluster,
+// so that RestoreMode can set it back.
+func SaveMode() feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		cm, err := kubeclient.Get(ctx).CoreV1().ConfigMaps(system.Namespace()).Get(ctx, configFeaturesName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get %s/%s: %v", system.Namespace(), configFeaturesName, err)
+		}
+		var mode *string
+		if m, ok := cm.Data[apifeature.TransportEncryption]; ok {
+			mode = &m
+		}
+		savedModes.Store(environment.FromContext(ctx).Namespace(), mode)
+	}
+}
+
+// RestoreMode sets back the transport-encryption mode saved by SaveMode.
+func RestoreMode() feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		mode, ok := savedModes.LoadAndDelete(environment.FromContext(ctx).Namespace())
+		if !ok {
+			t.Fatal("no transport-encryption mode was saved")
+		}
+		if err := setMode(ctx, mode.(*string)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// SetMode sets the transport-encryption mode of the cluster.
+func SetMode(mode apifeature.Flag) feature.StepFn {
+	return func(ctx context.Context, t feature.T) {
+		value := strings.ToLower(string(mode))
+		if err := setMode(ctx, &value); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// setMode sets the transport-encryption mode in config-features, or removes
+// it when mode is nil.
+func setMode(ctx context.Context, mode *string) error {
+	cms := kubeclient.Get(ctx).CoreV1().ConfigMaps(system.Namespace())
+	cm, err := cms.Get(ctx, configFeaturesName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get %s/%s: %w", system.Namespace(), configFeaturesName, err)
+	}
+	if mode == nil {
+		delete(cm.Data, apifeature.TransportEncryption)
+	} else {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[apifeature.TransportEncryption] = *mode
+	}
+	if _, err := cms.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update %s/%s: %w", system.Namespace(), configFeaturesName, err)
+	}
+	return nil
+}
@@ -134,3 +134,18 @@ func TestPingSourceSendsEventsOIDC(t *testing.T) {
 
 	env.Test(ctx, t, pingsource.PingSourceSendEventOIDC())
 }
+
+func TestPingSourceConformance(t *testing.T) {
+	t.Parallel()
+
+	ctx, env := global.Environment(
+		knative.WithKnativeNamespace(system.Namespace()),
+		knative.WithLoggingConfig,
+		knative.WithTracingConfig,
+		k8s.WithEventListener,
+		environment.Managed(t),
+	)
+	t.Cleanup(env.Finish)
+
+	env.TestSet(ctx, t, pingsource.Conformance())
+}
@@ -120,6 +120,9 @@ var WithRetry = delivery.WithRetry
 // WithTimeout adds the timeout related config to the config.
 var WithTimeout = delivery.WithTimeout
 
+// WithRetryAfterMax adds the retryAfterMax related config to a Broker spec.
+var WithRetryAfterMax = delivery.WithRetryAfterMax
+
 // Install will create a Broker resource, augmented with the config fn options.
 func Install(name string, opts ...manifest.CfgFn) feature.StepFn {
 	cfg := map[string]interface{}{
@@ -256,6 +259,28 @@ func HasDeliveryBackoffPolicy() Condition {
 	}
 }
 
+// HasAddressSchemes is satisfied when the addresses of the Broker have the
+// given schemes, in order, the first one being its main address.
+func HasAddressSchemes(schemes ...string) Condition {
+	return Condition{
+		Name: fmt.Sprintf("has address schemes %v", schemes),
+		Condition: func(br eventingv1.Broker) (bool, error) {
+			if len(schemes) == 0 || br.Status.Address == nil || br.Status.Address.URL == nil || len(br.Status.Addresses) != len(schemes) {
+				return false, nil
+			}
+			if br.Status.Address.URL.Scheme != schemes[0] {
+				return false, nil
+			}
+			for i, addr := range br.Status.Addresses {
+				if addr.URL == nil || addr.URL.Scheme != schemes[i] {
+					return false, nil
+				}
+			}
+			return true, nil
+		},
+	}
+}
+
 func AsDestinationRef(name string) *duckv1.Destination {
 	return &duckv1.Destination{
 		Ref: AsKReference(name),
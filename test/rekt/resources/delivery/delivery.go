@@ -117,6 +117,18 @@ func WithRetry(count int32, backoffPolicy *eventingv1.BackoffPolicyType, backoff
 	}
 }
 
+// WithRetryAfterMax adds the retryAfterMax related config to the config.
+func WithRetryAfterMax(retryAfterMax string) manifest.CfgFn {
+	return func(cfg map[string]interface{}) {
+		if _, set := cfg["delivery"]; !set {
+			cfg["delivery"] = map[string]interface{}{}
+		}
+		delivery := cfg["delivery"].(map[string]interface{})
+
+		delivery["retryAfterMax"] = retryAfterMax
+	}
+}
+
 // WithTimeout adds the timeout related config to the config.
 func WithTimeout(timeout string) manifest.CfgFn {
 	return func(cfg map[string]interface{}) {
@@ -18,29 +18,112 @@ package eventtype
 
 import (
 	"context"
+	"embed"
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/reconciler-test/pkg/environment"
 	"knative.dev/reconciler-test/pkg/feature"
 	"knative.dev/reconciler-test/pkg/k8s"
+	"knative.dev/reconciler-test/pkg/manifest"
 
 	eventingv1beta2 "knative.dev/eventing/pkg/apis/eventing/v1beta2"
-	eventingclient "knative.dev/eventing/pkg/client/injection/client"
+	eventingv1beta3 "knative.dev/eventing/pkg/apis/eventing/v1beta3"
 )
 
+//go:embed *.yaml
+var yaml embed.FS
+
+// GVR returns the v1beta3 EventType resource.
+func GVR() schema.GroupVersionResource {
+	return eventingv1beta3.SchemeGroupVersion.WithResource("eventtypes")
+}
+
+// Install will create a v1beta3 EventType resource, augmented with the config fn options.
+func Install(name string, opts ...manifest.CfgFn) feature.StepFn {
+	cfg := map[string]interface{}{
+		"name": name,
+	}
+	for _, fn := range opts {
+		fn(cfg)
+	}
+	return func(ctx context.Context, t feature.T) {
+		if _, err := manifest.InstallYamlFS(ctx, yaml, cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// IsReady tests to see if an EventType becomes ready within the time given.
+func IsReady(name string, timing ...time.Duration) feature.StepFn {
+	return k8s.IsReady(GVR(), name, timing...)
+}
+
+// WithReference adds the reference to the EventType spec.
+func WithReference(ref *duckv1.KReference) manifest.CfgFn {
+	return func(cfg map[string]interface{}) {
+		if ref == nil {
+			return
+		}
+		reference := map[string]interface{}{
+			"apiVersion": ref.APIVersion,
+			"kind":       ref.Kind,
+			"name":       ref.Name,
+		}
+		if ref.Namespace != "" {
+			reference["namespace"] = ref.Namespace
+		}
+		cfg["reference"] = reference
+	}
+}
+
+// WithDescription adds the description to the EventType spec.
+func WithDescription(description string) manifest.CfgFn {
+	return func(cfg map[string]interface{}) {
+		cfg["description"] = description
+	}
+}
+
+// WithAttributes appends the attribute definitions to the EventType spec.
+func WithAttributes(attributes ...eventingv1beta3.EventAttributeDefinition) manifest.CfgFn {
+	return func(cfg map[string]interface{}) {
+		if _, set := cfg["attributes"]; !set {
+			cfg["attributes"] = []map[string]interface{}{}
+		}
+		res := cfg["attributes"].([]map[string]interface{})
+		for _, a := range attributes {
+			attr := map[string]interface{}{
+				"name":     a.Name,
+				"required": a.Required,
+			}
+			if a.Value != "" {
+				attr["value"] = a.Value
+			}
+			res = append(res, attr)
+		}
+		cfg["attributes"] = res
+	}
+}
+
+// EventType is an assertion on the EventTypes of the test namespace. The
+// EventTypes are always presented in their v1beta3 shape, whatever the
+// version they were listed with.
 type EventType struct {
 	Name       string
-	EventTypes func(etl eventingv1beta2.EventTypeList) (bool, error)
+	EventTypes func(etl eventingv1beta3.EventTypeList) (bool, error)
 }
 
 func (et EventType) And(eventType EventType) EventType {
 	return EventType{
 		Name: fmt.Sprintf("%s and %s", et.Name, eventType.Name),
-		EventTypes: func(etl eventingv1beta2.EventTypeList) (bool, error) {
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
 			v, err := et.EventTypes(etl)
 			if err != nil || !v {
 				return v, err
@@ -50,21 +133,26 @@ func (et EventType) And(eventType EventType) EventType {
 	}
 }
 
+// WaitForEventType waits for the v1beta2 EventTypes of the test namespace
+// to satisfy all the assertions.
 func WaitForEventType(eventtypes ...EventType) feature.StepFn {
+	return WaitForEventTypeVersion(eventingv1beta2.SchemeGroupVersion, eventtypes...)
+}
+
+// WaitForEventTypeVersion waits for the EventTypes of the test namespace,
+// listed with the given group/version, to satisfy all the assertions.
+func WaitForEventTypeVersion(gv schema.GroupVersion, eventtypes ...EventType) feature.StepFn {
 	return func(ctx context.Context, t feature.T) {
 		env := environment.FromContext(ctx)
 		interval, timeout := k8s.PollTimings(ctx, nil)
 		var lastErr error
-		var lastEtl *eventingv1beta2.EventTypeList
+		var lastEtl *eventingv1beta3.EventTypeList
 		eventType := eventtypes[0] // It's fine to panic when is empty
 		for _, et := range eventtypes[1:] {
 			eventType = eventType.And(et)
 		}
 		err := wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (done bool, err error) {
-			etl, err := eventingclient.Get(ctx).
-				EventingV1beta2().
-				EventTypes(env.Namespace()).
-				List(ctx, metav1.ListOptions{})
+			etl, err := listEventTypes(ctx, gv, env.Namespace())
 			if err != nil {
 				lastErr = err
 				return false, nil
@@ -79,14 +167,58 @@ func WaitForEventType(eventtypes ...EventType) feature.StepFn {
 	}
 }
 
+// listEventTypes lists the EventTypes of the namespace with the given
+// group/version and converts them to v1beta3.
+func listEventTypes(ctx context.Context, gv schema.GroupVersion, namespace string) (*eventingv1beta3.EventTypeList, error) {
+	ul, err := dynamicclient.Get(ctx).
+		Resource(gv.WithResource("eventtypes")).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	etl := &eventingv1beta3.EventTypeList{}
+	for _, u := range ul.Items {
+		et := eventingv1beta3.EventType{}
+		switch gv.Version {
+		case eventingv1beta2.SchemeGroupVersion.Version:
+			v1beta2 := &eventingv1beta2.EventType{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, v1beta2); err != nil {
+				return nil, err
+			}
+			if err := v1beta2.ConvertTo(ctx, &et); err != nil {
+				return nil, err
+			}
+		case eventingv1beta3.SchemeGroupVersion.Version:
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &et); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported EventType version %s", gv)
+		}
+		etl.Items = append(etl.Items, et)
+	}
+	return etl, nil
+}
+
+// typeOf returns the CloudEvent type the EventType describes.
+func typeOf(et eventingv1beta3.EventType) string {
+	for _, a := range et.Spec.Attributes {
+		if a.Name == "type" {
+			return a.Value
+		}
+	}
+	return ""
+}
+
 func AssertPresent(expectedCeTypes sets.Set[string]) EventType {
 	return EventType{
 		Name: "test expected EventTypes",
-		EventTypes: func(etl eventingv1beta2.EventTypeList) (bool, error) {
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
 			// Clone the expectedCeTypes
 			clonedExpectedCeTypes := expectedCeTypes.Clone()
 			for _, et := range etl.Items {
-				clonedExpectedCeTypes.Delete(et.Spec.Type) // remove from the cloned set
+				clonedExpectedCeTypes.Delete(typeOf(et)) // remove from the cloned set
 			}
 			return clonedExpectedCeTypes.Len() == 0, nil
 		},
@@ -96,9 +228,9 @@ func AssertPresent(expectedCeTypes sets.Set[string]) EventType {
 func AssertReady(expectedCeTypes sets.Set[string]) EventType {
 	return EventType{
 		Name: "test EventTypes ready",
-		EventTypes: func(etl eventingv1beta2.EventTypeList) (bool, error) {
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
 			for _, et := range etl.Items {
-				if expectedCeTypes.Has(et.Spec.Type) && !et.Status.IsReady() {
+				if expectedCeTypes.Has(typeOf(et)) && !et.Status.IsReady() {
 					return false, nil
 				}
 			}
@@ -110,14 +242,14 @@ func AssertReady(expectedCeTypes sets.Set[string]) EventType {
 func AssertExactPresent(expectedCeTypes sets.Set[string]) EventType {
 	return EventType{
 		Name: "test eventtypes match or not",
-		EventTypes: func(etl eventingv1beta2.EventTypeList) (bool, error) {
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
 			// Clone the expectedCeTypes
 			clonedExpectedCeTypes := expectedCeTypes.Clone()
 			for _, et := range etl.Items {
-				if !clonedExpectedCeTypes.Has(et.Spec.Type) {
+				if !clonedExpectedCeTypes.Has(typeOf(et)) {
 					return false, nil
 				}
-				clonedExpectedCeTypes.Delete(et.Spec.Type) // remove from the cloned set
+				clonedExpectedCeTypes.Delete(typeOf(et)) // remove from the cloned set
 			}
 			return clonedExpectedCeTypes.Len() == 0, nil
 		},
@@ -127,10 +259,26 @@ func AssertExactPresent(expectedCeTypes sets.Set[string]) EventType {
 func AssertReferencePresent(expectedReference *duckv1.KReference) EventType {
 	return EventType{
 		Name: "test eventtypes have reference",
-		EventTypes: func(etl eventingv1beta2.EventTypeList) (bool, error) {
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
 			for _, et := range etl.Items {
 				ref := et.Spec.Reference
-				if ref.APIVersion == expectedReference.APIVersion && ref.Name == expectedReference.Name && ref.Kind == expectedReference.Kind {
+				if ref != nil && ref.APIVersion == expectedReference.APIVersion && ref.Name == expectedReference.Name && ref.Kind == expectedReference.Kind {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+// AssertAttributes checks that the EventType of the given CloudEvent type
+// defines all the given attributes, with the same value and requiredness.
+func AssertAttributes(ceType string, expected ...eventingv1beta3.EventAttributeDefinition) EventType {
+	return EventType{
+		Name: fmt.Sprintf("test eventtype %s has attributes", ceType),
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
+			for _, et := range etl.Items {
+				if typeOf(et) == ceType && hasAttributes(et, expected) {
 					return true, nil
 				}
 			}
@@ -138,3 +286,46 @@ func AssertReferencePresent(expectedReference *duckv1.KReference) EventType {
 		},
 	}
 }
+
+func hasAttributes(et eventingv1beta3.EventType, expected []eventingv1beta3.EventAttributeDefinition) bool {
+	for _, want := range expected {
+		found := false
+		for _, got := range et.Spec.Attributes {
+			if got == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AssertReferenceMatches checks that the EventType of the given CloudEvent
+// type references the expected resource. The namespace is only compared
+// when set on the expected reference.
+func AssertReferenceMatches(ceType string, expectedReference *duckv1.KReference) EventType {
+	return EventType{
+		Name: fmt.Sprintf("test eventtype %s has reference", ceType),
+		EventTypes: func(etl eventingv1beta3.EventTypeList) (bool, error) {
+			for _, et := range etl.Items {
+				if typeOf(et) == ceType && referenceMatches(et.Spec.Reference, expectedReference) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+func referenceMatches(ref, expected *duckv1.KReference) bool {
+	if ref == nil || expected == nil {
+		return ref == expected
+	}
+	return ref.APIVersion == expected.APIVersion &&
+		ref.Kind == expected.Kind &&
+		ref.Name == expected.Name &&
+		(expected.Namespace == "" || ref.Namespace == expected.Namespace)
+}
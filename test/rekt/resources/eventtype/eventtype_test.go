@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventtype_test
+
+import (
+	"embed"
+	"os"
+
+	testlog "knative.dev/reconciler-test/pkg/logging"
+	"knative.dev/reconciler-test/pkg/manifest"
+
+	eventingv1beta3 "knative.dev/eventing/pkg/apis/eventing/v1beta3"
+	"knative.dev/eventing/test/rekt/resources/eventtype"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+//go:embed *.yaml
+var yaml embed.FS
+
+// The following examples validate the processing of the With* helper methods
+// applied to config and go template parser.
+func Example_min() {
+	ctx := testlog.NewContext()
+	images := map[string]string{}
+	cfg := map[string]interface{}{
+		"name":      "foo",
+		"namespace": "bar",
+	}
+
+	eventtype.WithAttributes(eventingv1beta3.EventAttributeDefinition{
+		Name:     "type",
+		Required: true,
+		Value:    "dev.knative.test",
+	})(cfg)
+
+	files, err := manifest.ExecuteYAML(ctx, yaml, images, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	manifest.OutputYAML(os.Stdout, files)
+	// Output:
+	// apiVersion: eventing.knative.dev/v1beta3
+	// kind: EventType
+	// metadata:
+	//   name: foo
+	//   namespace: bar
+	// spec:
+	//   attributes:
+	//     - name: type
+	//       required: true
+	//       value: "dev.knative.test"
+}
+
+func Example_full() {
+	ctx := testlog.NewContext()
+	images := map[string]string{}
+	cfg := map[string]interface{}{
+		"name":      "foo",
+		"namespace": "bar",
+	}
+
+	eventtype.WithReference(&duckv1.KReference{
+		APIVersion: "eventing.knative.dev/v1",
+		Kind:       "Broker",
+		Name:       "baz",
+	})(cfg)
+	eventtype.WithDescription("A test event")(cfg)
+	eventtype.WithAttributes(
+		eventingv1beta3.EventAttributeDefinition{Name: "type", Required: true, Value: "dev.knative.test"},
+		eventingv1beta3.EventAttributeDefinition{Name: "source", Required: true, Value: "/apis/v1/namespaces/{namespace}/pingsources/{name}"},
+		eventingv1beta3.EventAttributeDefinition{Name: "subject"},
+	)(cfg)
+
+	files, err := manifest.ExecuteYAML(ctx, yaml, images, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	manifest.OutputYAML(os.Stdout, files)
+	// Output:
+	// apiVersion: eventing.knative.dev/v1beta3
+	// kind: EventType
+	// metadata:
+	//   name: foo
+	//   namespace: bar
+	// spec:
+	//   reference:
+	//     apiVersion: eventing.knative.dev/v1
+	//     kind: Broker
+	//     name: baz
+	//   description: "A test event"
+	//   attributes:
+	//     - name: type
+	//       required: true
+	//       value: "dev.knative.test"
+	//     - name: source
+	//       required: true
+	//       value: "/apis/v1/namespaces/{namespace}/pingsources/{name}"
+	//     - name: subject
+	//       required: false
+}
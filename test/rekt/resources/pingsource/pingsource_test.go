@@ -191,3 +191,41 @@ func Example_schedule_with_secs() {
 	//       apiVersion: sinkversion
 	//     uri: uri/parts
 }
+
+func Example_ceOverrides() {
+	ctx := testlog.NewContext()
+	images := map[string]string{}
+	cfg := map[string]interface{}{
+		"name":      "foo",
+		"namespace": "bar",
+		"ceOverrides": map[string]interface{}{
+			"extensions": map[string]string{
+				"ext1": "val1",
+				"ext2": "val2",
+			},
+		},
+		"sink": map[string]interface{}{
+			"uri": "uri/parts",
+		},
+	}
+
+	files, err := manifest.ExecuteYAML(ctx, yaml, images, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	manifest.OutputYAML(os.Stdout, files)
+	// Output:
+	// apiVersion: sources.knative.dev/v1
+	// kind: PingSource
+	// metadata:
+	//   name: foo
+	//   namespace: bar
+	// spec:
+	//   ceOverrides:
+	//     extensions:
+	//       ext1: val1
+	//       ext2: val2
+	//   sink:
+	//     uri: uri/parts
+}
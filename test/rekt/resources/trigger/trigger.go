@@ -169,6 +169,9 @@ var WithRetry = delivery.WithRetry
 // WithTimeout adds the timeout related config to the config.
 var WithTimeout = delivery.WithTimeout
 
+// WithRetryAfterMax adds the retryAfterMax related config to a Trigger spec.
+var WithRetryAfterMax = delivery.WithRetryAfterMax
+
 // Install will create a Trigger resource, augmented with the config fn options.
 func Install(name, brokerName string, opts ...manifest.CfgFn) feature.StepFn {
 	cfg := map[string]interface{}{
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+const (
+	// constantProfile starts all the senders at once.
+	constantProfile = "constant"
+	// rampProfile starts the senders one after the other, evenly spread
+	// over the ramp duration.
+	rampProfile = "ramp"
+
+	binaryEncoding     = "binary"
+	structuredEncoding = "structured"
+	// mixedEncoding alternates between the binary and structured encodings.
+	mixedEncoding = "mixed"
+)
+
+// startDelay returns how long the sender with the given index waits before
+// sending its first event.
+func startDelay(profile string, rampDuration time.Duration, sender, concurrency int) time.Duration {
+	if profile != rampProfile || concurrency <= 1 {
+		return 0
+	}
+	return rampDuration * time.Duration(sender) / time.Duration(concurrency)
+}
+
+// encodingFor returns the encoding of the event with the given sequence
+// number.
+func encodingFor(encoding string, sequence int) string {
+	if encoding != mixedEncoding {
+		return encoding
+	}
+	if sequence%2 == 1 {
+		return binaryEncoding
+	}
+	return structuredEncoding
+}
+
+// withEncoding sets the given encoding on the context used to send an event.
+func withEncoding(ctx context.Context, encoding string) context.Context {
+	if encoding == structuredEncoding {
+		return cloudevents.WithEncodingStructured(ctx)
+	}
+	return cloudevents.WithEncodingBinary(ctx)
+}
+
+// isInvalid tells whether the event with the given sequence number is sent
+// as an invalid event, the invalid events are evenly spread so that their
+// share among the events sent so far is the given rate.
+func isInvalid(rate float64, sequence int) bool {
+	if rate <= 0 {
+		return false
+	}
+	return int(float64(sequence)*rate) > int(float64(sequence-1)*rate)
+}
+
+// results is the machine-readable summary of the events sent.
+type results struct {
+	mu sync.Mutex
+
+	// Sent is the number of events sent, including the invalid ones.
+	Sent int `json:"sent"`
+	// Delivered is the number of events acknowledged by the sink.
+	Delivered int `json:"delivered"`
+	// Failed is the number of events that were not delivered or that the
+	// sink rejected.
+	Failed int `json:"failed"`
+	// Invalid is the number of invalid events sent.
+	Invalid int `json:"invalid"`
+	// Encodings counts the valid events sent with each encoding.
+	Encodings map[string]int `json:"encodings"`
+	// StatusCodes counts the HTTP status codes of the responses.
+	StatusCodes map[string]int `json:"statusCodes"`
+	// Duration is how long it took to send all the events.
+	Duration string `json:"duration"`
+}
+
+func newResults() *results {
+	return &results{
+		Encodings:   make(map[string]int),
+		StatusCodes: make(map[string]int),
+	}
+}
+
+// record adds an event sent to the results, statusCode is 0 when no
+// response was received.
+func (r *results) record(encoding string, invalid bool, delivered bool, statusCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Sent++
+	if invalid {
+		r.Invalid++
+	} else {
+		r.Encodings[encoding]++
+	}
+	if delivered {
+		r.Delivered++
+	} else {
+		r.Failed++
+	}
+	if statusCode != 0 {
+		r.StatusCodes[fmt.Sprint(statusCode)]++
+	}
+}
+
+// write writes the results as JSON to the given file.
+func (r *results) write(path string, duration time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Duration = duration.String()
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
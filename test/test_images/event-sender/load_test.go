@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStartDelay(t *testing.T) {
+	tests := map[string]struct {
+		profile     string
+		sender      int
+		concurrency int
+		want        time.Duration
+	}{
+		"constant": {
+			profile:     constantProfile,
+			sender:      3,
+			concurrency: 4,
+		},
+		"ramp first sender": {
+			profile:     rampProfile,
+			concurrency: 4,
+		},
+		"ramp last sender": {
+			profile:     rampProfile,
+			sender:      3,
+			concurrency: 4,
+			want:        30 * time.Second,
+		},
+		"ramp single sender": {
+			profile:     rampProfile,
+			concurrency: 1,
+		},
+	}
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			if got := startDelay(tc.profile, 40*time.Second, tc.sender, tc.concurrency); got != tc.want {
+				t.Errorf("startDelay() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEncodingFor(t *testing.T) {
+	var got []string
+	for seq := 1; seq <= 4; seq++ {
+		got = append(got, encodingFor(mixedEncoding, seq))
+	}
+	want := []string{binaryEncoding, structuredEncoding, binaryEncoding, structuredEncoding}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Unexpected mixed encodings (-want, +got) =", diff)
+	}
+	if got := encodingFor(structuredEncoding, 1); got != structuredEncoding {
+		t.Errorf("encodingFor(structured) = %s, want %s", got, structuredEncoding)
+	}
+}
+
+func TestIsInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		rate float64
+		want int
+	}{
+		{rate: 0, want: 0},
+		{rate: 0.1, want: 10},
+		{rate: 0.25, want: 25},
+		{rate: 1, want: 100},
+	} {
+		invalid := 0
+		for seq := 1; seq <= 100; seq++ {
+			if isInvalid(tc.rate, seq) {
+				invalid++
+			}
+		}
+		if invalid != tc.want {
+			t.Errorf("Got %d invalid events out of 100 with rate %v, want %d", invalid, tc.rate, tc.want)
+		}
+	}
+}
+
+func TestResults(t *testing.T) {
+	res := newResults()
+	res.record(binaryEncoding, false, true, 202)
+	res.record(structuredEncoding, false, false, 500)
+	res.record(binaryEncoding, true, false, 400)
+	res.record(binaryEncoding, false, false, 0)
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	if err := res.write(path, time.Second); err != nil {
+		t.Fatal("write() =", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"sent":        4.0,
+		"delivered":   1.0,
+		"failed":      3.0,
+		"invalid":     1.0,
+		"encodings":   map[string]interface{}{"binary": 2.0, "structured": 1.0},
+		"statusCodes": map[string]interface{}{"202": 1.0, "500": 1.0, "400": 1.0},
+		"duration":    "1s",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error("Unexpected results (-want, +got) =", diff)
+	}
+}
@@ -23,10 +23,13 @@ import (
 	"fmt"
 	"log"
 	nethttp "net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	obsclient "github.com/cloudevents/sdk-go/observability/opencensus/v2/client"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
 	"github.com/cloudevents/sdk-go/v2/client"
 	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"go.opencensus.io/plugin/ochttp"
@@ -50,13 +53,18 @@ var (
 	addSequence       bool
 	incrementalId     bool
 	additionalHeaders string
+	concurrency       int
+	loadProfile       string
+	rampDurationStr   string
+	invalidEventRate  float64
+	resultFile        string
 )
 
 func init() {
 	flag.StringVar(&sink, "sink", "", "The sink url for the message destination.")
 	flag.StringVar(&responseSink, "response-sink", "", "The response sink url to send the response.")
 	flag.StringVar(&inputEvent, "event", "", "Event JSON encoded")
-	flag.StringVar(&eventEncoding, "event-encoding", "binary", "The encoding of the cloud event: [binary, structured].")
+	flag.StringVar(&eventEncoding, "event-encoding", "binary", "The encoding of the cloud event: [binary, structured, mixed].")
 	flag.StringVar(&periodStr, "period", "5", "The number of seconds between messages.")
 	flag.StringVar(&delayStr, "delay", "5", "The number of seconds to wait before sending messages.")
 	flag.IntVar(&maxMsg, "max-messages", 1, "The number of messages to attempt to send. 0 for unlimited.")
@@ -64,6 +72,11 @@ func init() {
 	flag.BoolVar(&addSequence, "add-sequence-extension", false, "Should add extension 'sequence' identifying the sequence number.")
 	flag.BoolVar(&incrementalId, "incremental-id", false, "Override the event id with an incremental id.")
 	flag.StringVar(&additionalHeaders, "additional-headers", "", "Additional non-CloudEvents headers to send")
+	flag.IntVar(&concurrency, "concurrency", 1, "The number of senders sending messages concurrently.")
+	flag.StringVar(&loadProfile, "load-profile", constantProfile, "How the senders are started: [constant, ramp].")
+	flag.StringVar(&rampDurationStr, "ramp-duration", "0", "The number of seconds over which the senders are started with the ramp load profile.")
+	flag.Float64Var(&invalidEventRate, "invalid-event-rate", 0, "The share of messages, between 0 and 1, sent as invalid events.")
+	flag.StringVar(&resultFile, "result-file", "", "The file the JSON results are written to, e.g. /dev/termination-log.")
 }
 
 func main() {
@@ -77,34 +90,46 @@ func main() {
 		log.Printf("awake, continuing")
 	}
 
-	ctx := context.Background()
 	switch eventEncoding {
-	case "binary":
-		ctx = cloudevents.WithEncodingBinary(ctx)
-	case "structured":
-		ctx = cloudevents.WithEncodingStructured(ctx)
+	case binaryEncoding, structuredEncoding, mixedEncoding:
 	default:
 		log.Fatalf("unsupported encoding option: %q\n", eventEncoding)
 	}
+	switch loadProfile {
+	case constantProfile, rampProfile:
+	default:
+		log.Fatalf("unsupported load profile: %q\n", loadProfile)
+	}
+	if concurrency < 1 {
+		log.Fatalf("invalid concurrency: %d\n", concurrency)
+	}
+	if invalidEventRate < 0 || invalidEventRate > 1 {
+		log.Fatalf("invalid event rate %v isn't between 0 and 1\n", invalidEventRate)
+	}
+	rampDuration := test_images.ParseDurationStr(rampDurationStr, 0)
 
 	httpOpts := []cehttp.Option{
 		cloudevents.WithTarget(sink),
 	}
 
+	// The invalid events can't be sent by the CloudEvents client, they are
+	// sent by a plain HTTP client with the same transport and headers.
+	var roundTripper nethttp.RoundTripper = nethttp.DefaultTransport
 	if addTracing {
-		httpOpts = append(
-			httpOpts,
-			cloudevents.WithRoundTripper(&ochttp.Transport{
-				Base:        nethttp.DefaultTransport,
-				Propagation: tracecontextb3.TraceContextEgress,
-			}),
-		)
+		roundTripper = &ochttp.Transport{
+			Base:        nethttp.DefaultTransport,
+			Propagation: tracecontextb3.TraceContextEgress,
+		}
+		httpOpts = append(httpOpts, cloudevents.WithRoundTripper(roundTripper))
 	}
+	headers := make(nethttp.Header)
 	if additionalHeaders != "" {
-		for k, v := range test_images.ParseHeaders(additionalHeaders) {
+		headers = test_images.ParseHeaders(additionalHeaders)
+		for k, v := range headers {
 			httpOpts = append(httpOpts, cloudevents.WithHeader(k, v[0]))
 		}
 	}
+	httpClient := &nethttp.Client{Transport: roundTripper}
 
 	t, err := cloudevents.NewHTTP(httpOpts...)
 	if err != nil {
@@ -134,56 +159,127 @@ func main() {
 		log.Fatalf("Unable to unmarshal the event from json: %v", err)
 	}
 
-	sequence := 0
-
-	ticker := time.NewTicker(period)
-	for {
-		event := baseEvent.Clone()
-
-		sequence++
-		if addSequence {
-			event.SetExtension("sequence", sequence)
-		}
-		if incrementalId {
-			event.SetID(fmt.Sprintf("%d", sequence))
-		}
-
-		log.Printf("I'm going to send\n%s\n", event)
+	res := newResults()
+	start := time.Now()
 
-		responseEvent, responseResult := c.Request(ctx, event)
-		if cloudevents.IsUndelivered(responseResult) {
-			log.Printf("send returned an error: %v\n", responseResult)
-		} else {
-			if responseEvent != nil {
-				log.Printf("Got response from %q\nresult: %q\nresponse event: %q\n", sink, responseResult, *responseEvent)
-			} else {
-				log.Printf("Got response from %q\nresult: %q\n", sink, responseResult)
+	var sequence int64
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if d := startDelay(loadProfile, rampDuration, i, concurrency); d > 0 {
+				time.Sleep(d)
 			}
+			ticker := time.NewTicker(period)
+			defer ticker.Stop()
+			for {
+				seq := int(atomic.AddInt64(&sequence, 1))
+				// Only send a limited number of messages.
+				if maxMsg != 0 && seq > maxMsg {
+					return
+				}
+
+				event := baseEvent.Clone()
+				if addSequence {
+					event.SetExtension("sequence", seq)
+				}
+				if incrementalId {
+					event.SetID(fmt.Sprintf("%d", seq))
+				}
 
-			if responseSink != "" {
-				var httpResult *cehttp.Result
-				cloudevents.ResultAs(responseResult, &httpResult)
-				responseEvent := sender.NewSenderEvent(
-					event.ID(),
-					"https://knative.dev/eventing/test/event-sender",
-					responseEvent,
-					httpResult,
-				)
-
-				result2 := c.Send(cloudevents.ContextWithTarget(context.Background(), responseSink), responseEvent)
-				if cloudevents.IsUndelivered(result2) {
-					log.Printf("send to response sink returned an error: %v\n", result2)
+				if isInvalid(invalidEventRate, seq) {
+					sendInvalid(httpClient, headers, event, res)
 				} else {
-					log.Printf("Got response from %s\n%s\n", responseSink, result2)
+					send(c, event, encodingFor(eventEncoding, seq), res)
 				}
+
+				// Wait for next tick
+				<-ticker.C
 			}
+		}(i)
+	}
+	wg.Wait()
+
+	if resultFile != "" {
+		if err := res.write(resultFile, time.Since(start)); err != nil {
+			log.Fatalf("Unable to write the results: %v", err)
 		}
+	}
+}
 
-		// Wait for next tick
-		<-ticker.C
-		// Only send a limited number of messages.
-		if maxMsg != 0 && maxMsg == sequence {
-			return
+// send sends the event with the CloudEvents client and forwards the response
+// to the response sink, if any.
+func send(c cloudevents.Client, event cloudevents.Event, encoding string, res *results) {
+	log.Printf("I'm going to send\n%s\n", event)
+
+	responseEvent, responseResult := c.Request(withEncoding(context.Background(), encoding), event)
+
+	var httpResult *cehttp.Result
+	cloudevents.ResultAs(responseResult, &httpResult)
+	statusCode := 0
+	if httpResult != nil {
+		statusCode = httpResult.StatusCode
+	}
+	res.record(encoding, false, cloudevents.IsACK(responseResult), statusCode)
+
+	if cloudevents.IsUndelivered(responseResult) {
+		log.Printf("send returned an error: %v\n", responseResult)
+		return
+	}
+	if responseEvent != nil {
+		log.Printf("Got response from %q\nresult: %q\nresponse event: %q\n", sink, responseResult, *responseEvent)
+	} else {
+		log.Printf("Got response from %q\nresult: %q\n", sink, responseResult)
+	}
+
+	if responseSink != "" {
+		responseEvent := sender.NewSenderEvent(
+			event.ID(),
+			"https://knative.dev/eventing/test/event-sender",
+			responseEvent,
+			httpResult,
+		)
+
+		result2 := c.Send(cloudevents.ContextWithTarget(context.Background(), responseSink), responseEvent)
+		if cloudevents.IsUndelivered(result2) {
+			log.Printf("send to response sink returned an error: %v\n", result2)
+		} else {
+			log.Printf("Got response from %s\n%s\n", responseSink, result2)
 		}
 	}
 }
+
+// sendInvalid sends the event in binary mode without its id and spec
+// version, which makes it an invalid CloudEvent.
+func sendInvalid(httpClient *nethttp.Client, headers nethttp.Header, event cloudevents.Event, res *results) {
+	log.Printf("I'm going to send as an invalid event\n%s\n", event)
+
+	ctx := cloudevents.WithEncodingBinary(context.Background())
+	req, err := nethttp.NewRequestWithContext(ctx, nethttp.MethodPost, sink, nil)
+	if err != nil {
+		log.Printf("failed to create the request: %v\n", err)
+		res.record(binaryEncoding, true, false, 0)
+		return
+	}
+	if err := cehttp.WriteRequest(ctx, binding.ToMessage(&event), req); err != nil {
+		log.Printf("failed to write the request: %v\n", err)
+		res.record(binaryEncoding, true, false, 0)
+		return
+	}
+	req.Header.Del("ce-id")
+	req.Header.Del("ce-specversion")
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Printf("send returned an error: %v\n", err)
+		res.record(binaryEncoding, true, false, 0)
+		return
+	}
+	defer resp.Body.Close()
+	log.Printf("Got response from %q\nstatus: %d\n", sink, resp.StatusCode)
+	res.record(binaryEncoding, true, resp.StatusCode >= 200 && resp.StatusCode < 300, resp.StatusCode)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kelseyhightower/envconfig"
+
+	"knative.dev/eventing/test/performance"
+)
+
+type envConfig struct {
+	// Scenario is the JSON encoded performance.Scenario to run.
+	Scenario string `envconfig:"SCENARIO" required:"true"`
+	// Target is the URL the events are sent to.
+	Target string `envconfig:"TARGET" required:"true"`
+	// ResultsPath is where the JSON encoded performance.Results are written.
+	ResultsPath string `envconfig:"RESULTS_PATH" default:"/dev/termination-log"`
+}
+
+func main() {
+	var env envConfig
+	if err := envconfig.Process("", &env); err != nil {
+		log.Fatal("Failed to process env var: ", err)
+	}
+
+	s, err := performance.ParseScenario([]byte(env.Scenario))
+	if err != nil {
+		log.Fatal("Invalid scenario: ", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:        1000,
+			MaxIdleConnsPerHost: 1000,
+		},
+	}
+
+	log.Printf("Running scenario %q against %s for %s", s.Name, env.Target, s.Duration())
+	results, err := performance.Run(ctx, s, performance.HTTPSender(client, env.Target))
+	if err != nil {
+		log.Fatal("Failed to run scenario: ", err)
+	}
+
+	b, err := json.Marshal(results)
+	if err != nil {
+		log.Fatal("Failed to marshal results: ", err)
+	}
+	log.Printf("Results: %s", b)
+	if err := os.WriteFile(env.ResultsPath, b, 0o644); err != nil {
+		log.Fatal("Failed to write results: ", err)
+	}
+}
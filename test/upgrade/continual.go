@@ -18,6 +18,7 @@ package upgrade
 
 import (
 	"knative.dev/eventing/test/upgrade/prober"
+	"knative.dev/eventing/test/upgrade/prober/sut"
 	pkgupgrade "knative.dev/pkg/test/upgrade"
 )
 
@@ -26,3 +27,38 @@ func ContinualTest() pkgupgrade.BackgroundOperation {
 	return prober.NewContinualVerification("EventingContinualTest",
 		prober.ContinualVerificationOptions{})
 }
+
+// ContinualTests returns continual validations that send ordered events
+// through both a Broker/Trigger and a Channel/Subscription topology. It is
+// meant to be reused by downstream distributions in their upgrade suites.
+// The given configurators are applied to both validations, e.g. to bound the
+// tolerated loss and duplication.
+func ContinualTests(configurators ...prober.Configurator) []pkgupgrade.BackgroundOperation {
+	return []pkgupgrade.BackgroundOperation{
+		BrokerContinualTest(configurators...),
+		ChannelContinualTest(configurators...),
+	}
+}
+
+// BrokerContinualTest will perform a continual validation of events sent
+// through a Broker and Triggers.
+func BrokerContinualTest(configurators ...prober.Configurator) pkgupgrade.BackgroundOperation {
+	return continualTest("BrokerContinualTest", sut.NewBrokerAndTriggers(), configurators)
+}
+
+// ChannelContinualTest will perform a continual validation of events sent
+// through a Channel and a Subscription.
+func ChannelContinualTest(configurators ...prober.Configurator) pkgupgrade.BackgroundOperation {
+	return continualTest("ChannelContinualTest", sut.NewChannelAndSubscription(), configurators)
+}
+
+func continualTest(name string, s sut.SystemUnderTest, configurators []prober.Configurator) pkgupgrade.BackgroundOperation {
+	return prober.NewContinualVerification(name, prober.ContinualVerificationOptions{
+		Configurators: append([]prober.Configurator{
+			func(config *prober.Config) error {
+				config.SystemUnderTest = s
+				return nil
+			},
+		}, configurators...),
+	})
+}
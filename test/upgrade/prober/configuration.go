@@ -74,10 +74,22 @@ type Config struct {
 	Serving          ServingConfig
 	FailOnErrors     bool
 	OnDuplicate      DuplicateAction
+	Tolerance        Tolerance
 	Ctx              context.Context
 	TraceExportLimit int
 }
 
+// Tolerance bounds the number of lost and duplicated events that are
+// accepted without failing the verification. Events above the bound are
+// reported as errors. The zero value tolerates nothing.
+type Tolerance struct {
+	// Missing is the number of missing events that are tolerated.
+	Missing int
+	// Duplicated is the number of duplicated events that are tolerated when
+	// OnDuplicate is Error.
+	Duplicated int
+}
+
 // Wathola represents options related strictly to wathola testing tool.
 type Wathola struct {
 	ConfigToml
@@ -29,11 +29,12 @@ const (
 	defaultConfigFilename = "config.toml"
 	servingEnvName        = "EVENTING_UPGRADE_TESTS_SERVING_USE"
 	configFilenameEnvName = "EVENTING_UPGRADE_TESTS_CONFIGFILENAME"
+	missingEnvName        = "EVENTING_UPGRADE_TESTS_TOLERANCE_MISSING"
 )
 
 func TestNewConfig(t *testing.T) {
 	unsetList := []string{
-		servingEnvName, configFilenameEnvName,
+		servingEnvName, configFilenameEnvName, missingEnvName,
 	}
 
 	for _, s := range createTestSuite() {
@@ -57,15 +58,17 @@ func TestNewConfig(t *testing.T) {
 			assert.Equal(t, s.servingUse, config.Serving.Use)
 			assert.True(t, config.Serving.ScaleToZero)
 			assert.Equal(t, s.configFilename, config.ConfigFilename)
+			assert.Equal(t, s.toleratedMissing, config.Tolerance.Missing)
 		})
 	}
 }
 
 type testCase struct {
-	servingUse     bool
-	configFilename string
-	env            map[string]string
-	err            error
+	servingUse       bool
+	configFilename   string
+	toleratedMissing int
+	env              map[string]string
+	err              error
 }
 
 func createTestSuite() []testCase {
@@ -90,6 +93,12 @@ func createTestSuite() []testCase {
 			}
 			c.configFilename = "replaced.toml"
 		}),
+		createTestCase(func(c *testCase) {
+			c.env = map[string]string{
+				missingEnvName: "5",
+			}
+			c.toleratedMissing = 5
+		}),
 	}
 }
 
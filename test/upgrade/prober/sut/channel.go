@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sut
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	messagingv1 "knative.dev/eventing/pkg/apis/messaging/v1"
+	testlib "knative.dev/eventing/test/lib"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// ChannelAndSubscription will deploy a channel and a subscription to route
+// all events to receiver.
+type ChannelAndSubscription struct {
+	Name string
+	// TypeMeta is the type of the channel to deploy.
+	TypeMeta metav1.TypeMeta
+	// Delivery is the delivery spec of the subscription.
+	Delivery *eventingduckv1.DeliverySpec
+}
+
+// NewChannelAndSubscription will create default configuration for
+// ChannelAndSubscription based SUT.
+func NewChannelAndSubscription() SystemUnderTest {
+	return &ChannelAndSubscription{
+		Name:     "sut",
+		TypeMeta: testlib.DefaultChannel,
+		Delivery: &eventingduckv1.DeliverySpec{
+			Retry:         &retryCount,
+			BackoffPolicy: &backoffPolicy,
+			BackoffDelay:  &backoffDelay,
+		},
+	}
+}
+
+func (c *ChannelAndSubscription) Deploy(ctx Context, dest duckv1.Destination) interface{} {
+	ctx.Client.CreateChannelOrFail(c.Name, &c.TypeMeta)
+	ctx.Client.WaitForResourceReadyOrFail(c.Name, &c.TypeMeta)
+	url := c.fetchURL(ctx)
+	c.deploySubscription(ctx, dest)
+	return url
+}
+
+func (c *ChannelAndSubscription) fetchURL(ctx Context) *apis.URL {
+	ctx.Log.Debugf("Fetching %s \"%s\" URL for ns %s",
+		c.TypeMeta.Kind, c.Name, ctx.Client.Namespace)
+	uri, err := ctx.Client.GetAddressableURI(c.Name, &c.TypeMeta)
+	if err != nil {
+		ctx.T.Fatal(err)
+	}
+	url, err := apis.ParseURL(uri)
+	if err != nil {
+		ctx.T.Fatal(err)
+	}
+	ctx.Log.Debugf("%s \"%s\" URL for ns %s is %v",
+		c.TypeMeta.Kind, c.Name, ctx.Client.Namespace, url)
+	return url
+}
+
+func (c *ChannelAndSubscription) deploySubscription(ctx Context, dest duckv1.Destination) {
+	name := fmt.Sprintf("%s-subscription", c.Name)
+	ctx.Log.Debugf("Creating subscription \"%s\" for %s \"%s\" to route to %#v",
+		name, c.TypeMeta.Kind, c.Name, dest)
+	ctx.Client.CreateSubscriptionOrFail(
+		name, c.Name, &c.TypeMeta,
+		func(s *messagingv1.Subscription) {
+			s.Spec.Subscriber = &dest
+			s.Spec.Delivery = c.Delivery
+		},
+	)
+}
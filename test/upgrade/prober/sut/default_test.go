@@ -30,3 +30,11 @@ func TestNewDefault(t *testing.T) {
 		return ok
 	})
 }
+
+func TestNewChannelAndSubscription(t *testing.T) {
+	s := sut.NewChannelAndSubscription()
+	c, ok := s.(*sut.ChannelAndSubscription)
+	assert.True(t, ok)
+	assert.Equal(t, "InMemoryChannel", c.TypeMeta.Kind)
+	assert.NotNil(t, c.Delivery)
+}
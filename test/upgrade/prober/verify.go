@@ -82,7 +82,13 @@ func (p *prober) Verify() (eventErrs []error, eventsSent int) {
 		elapsed, report.EventsSent, report.State)
 	p.log.Infof("Availability: %.3f%%, Requests sent: %d.",
 		availRate, report.TotalRequests)
-	for i, t := range report.Thrown.Missing {
+	missing := report.Thrown.Missing
+	if len(missing) > 0 && len(missing) <= p.config.Tolerance.Missing {
+		p.log.Infof("WARNING: %d missing events are within the tolerance of %d",
+			len(missing), p.config.Tolerance.Missing)
+		missing = nil
+	}
+	for i, t := range missing {
 		eventErrs = append(eventErrs, errors.New(t))
 		p.exportStepEventTrace(i, t)
 	}
@@ -93,8 +99,9 @@ func (p *prober) Verify() (eventErrs []error, eventsSent int) {
 		eventErrs = append(eventErrs, errors.New(t))
 		p.exportStepEventTrace(i, t)
 	}
+	withinTolerance := len(report.Thrown.Duplicated) <= p.config.Tolerance.Duplicated
 	for i, t := range report.Thrown.Duplicated {
-		if p.config.OnDuplicate == Warn {
+		if p.config.OnDuplicate == Warn || (p.config.OnDuplicate == Error && withinTolerance) {
 			// Print at info level to prevent excessive stacktraces.
 			p.log.Info("WARNING: Duplicate:", t)
 		} else if p.config.OnDuplicate == Error {
@@ -52,9 +52,7 @@ func TestEventingUpgrades(t *testing.T) {
 			PostDowngrade: []pkgupgrade.Operation{
 				PostDowngradeTest(),
 			},
-			Continual: []pkgupgrade.BackgroundOperation{
-				ContinualTest(),
-			},
+			Continual: ContinualTests(),
 		},
 		Installations: pkgupgrade.Installations{
 			Base: []pkgupgrade.Operation{